@@ -165,8 +165,20 @@ const (
 	AgentConnUpperThreshold = "AGENT_CONN_UPPER_THRESHOLD"
 	AgentConnLookbackWindow = "AGENT_CONN_LOOKBACK_WINDOW"
 
+	AgentRequestRateLimit = "AGENT_REQUEST_RATE_LIMIT"
+	AgentRequestRateBurst = "AGENT_REQUEST_RATE_BURST"
+	AgentRequestMaxWait   = "AGENT_REQUEST_MAX_WAIT"
+
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
+	// UnitAgentsInProcess records in a machine agent's config whether
+	// the agent runs the agents of the units deployed to its machine
+	// within its own process ("true"), or installs a service for each
+	// unit agent ("false"). The deployer sets it from the model's
+	// unit-agents-in-process config when it first runs on a machine,
+	// so that the unit agents of a machine are never of both kinds.
+	UnitAgentsInProcess = "UNIT_AGENTS_IN_PROCESS"
+
 	// LoggingOverride will set the logging for this agent to the value
 	// specified. Model configuration will be ignored and this value takes
 	// precidence for the agent.
@@ -118,3 +118,78 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// ScheduleActions adds schedules which enqueue the given actions on
+// their units at regular intervals.
+func (c *Client) ScheduleActions(arg params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduled actions on this controller")
+	}
+	err := c.facade.FacadeCall("ScheduleActions", arg, &results)
+	return results, err
+}
+
+// ListActionSchedules returns all the action schedules in the model.
+func (c *Client) ListActionSchedules() (params.ActionSchedules, error) {
+	results := params.ActionSchedules{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduled actions on this controller")
+	}
+	err := c.facade.FacadeCall("ListActionSchedules", nil, &results)
+	return results, err
+}
+
+// RemoveActionSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduled actions on this controller")
+	}
+	err := c.facade.FacadeCall("RemoveActionSchedules", arg, &results)
+	return results, err
+}
+
+// ListActions returns the actions in the model matching the given
+// query, most recently enqueued first.
+func (c *Client) ListActions(arg params.ActionQuery) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action queries on this controller")
+	}
+	err := c.facade.FacadeCall("ListActions", arg, &results)
+	return results, err
+}
+
+// ActionArtifacts returns the artifacts stored for each of the given
+// actions.
+func (c *Client) ActionArtifacts(arg params.Entities) (params.ActionArtifactsResults, error) {
+	results := params.ActionArtifactsResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action artifacts on this controller")
+	}
+	err := c.facade.FacadeCall("ActionArtifacts", arg, &results)
+	return results, err
+}
+
+// ActionArtifactContent returns the content of each of the given
+// action artifacts.
+func (c *Client) ActionArtifactContent(arg params.ActionArtifactRefs) (params.ActionArtifactContentResults, error) {
+	results := params.ActionArtifactContentResults{}
+	if c.BestAPIVersion() < 4 {
+		return results, errors.NotSupportedf("action artifacts on this controller")
+	}
+	err := c.facade.FacadeCall("ActionArtifactContent", arg, &results)
+	return results, err
+}
+
+// Introspect enqueues actions that query the introspection sockets of
+// agents running on the given machines.
+func (c *Client) Introspect(arg params.IntrospectArgs) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	if c.BestAPIVersion() < 5 {
+		return results, errors.NotSupportedf("remote introspection on this controller")
+	}
+	err := c.facade.FacadeCall("Introspect", arg, &results)
+	return results, err
+}
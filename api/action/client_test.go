@@ -6,6 +6,7 @@ package action_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -127,3 +128,50 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestListActionSchedules(c *gc.C) {
+	schedules, err := s.client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestListActions(c *gc.C) {
+	results, err := s.client.ListActions(params.ActionQuery{Application: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestActionArtifactsNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.ActionArtifacts(params.Entities{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = s.client.ActionArtifactContent(params.ActionArtifactRefs{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *actionSuite) TestIntrospect(c *gc.C) {
+	results, err := s.client.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Machine: "42", Path: "depengine"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *actionSuite) TestIntrospectNotSupported(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.Introspect(params.IntrospectArgs{})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
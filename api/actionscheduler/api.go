@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// API makes calls to the ActionScheduler facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "ActionScheduler"),
+	}
+}
+
+// DispatchDue enqueues the actions for all schedules that are due,
+// and returns the time at which the next schedule is due, or the
+// zero time if there are no schedules.
+func (api *API) DispatchDue() (time.Time, error) {
+	var result params.DispatchActionSchedulesResult
+	if err := api.caller.FacadeCall("DispatchDue", nil, &result); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return result.NextRun, nil
+}
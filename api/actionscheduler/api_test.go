@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestDispatchDue(c *gc.C) {
+	next := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ActionScheduler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "DispatchDue")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.DispatchActionSchedulesResult{})
+		*(result.(*params.DispatchActionSchedulesResult)) = params.DispatchActionSchedulesResult{
+			NextRun: next,
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	result, err := api.DispatchDue()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, next)
+}
+
+func (s *APISuite) TestDispatchDueError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		return errors.New("blammo")
+	})
+	api := actionscheduler.NewAPI(caller)
+
+	_, err := api.DispatchDue()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
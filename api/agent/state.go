@@ -5,6 +5,7 @@ package agent
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -79,6 +80,34 @@ func (st *State) IsMaster() (bool, error) {
 	return results.Master, err
 }
 
+// RefreshToken asks the controller to issue a new token for the
+// connected agent, returning the token and the time at which it
+// expires. The agent must use the token as its password from then on.
+func (st *State) RefreshToken() (string, time.Time, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return "", time.Time{}, errors.NotSupportedf("agent tokens")
+	}
+	var result params.AgentTokenResult
+	if err := st.facade.FacadeCall("RefreshToken", nil, &result); err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	return result.Token, result.Expires, nil
+}
+
+// TokenValidity returns the times at which the connected agent's
+// current token was issued and expires. Both times are zero if the
+// agent has not been issued a token.
+func (st *State) TokenValidity() (time.Time, time.Time, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return time.Time{}, time.Time{}, errors.NotSupportedf("agent tokens")
+	}
+	var result params.AgentTokenResult
+	if err := st.facade.FacadeCall("TokenValidity", nil, &result); err != nil {
+		return time.Time{}, time.Time{}, errors.Trace(err)
+	}
+	return result.Issued, result.Expires, nil
+}
+
 // WatchCredential returns a watcher which reports when the specified
 // credential has changed.
 func (c *State) WatchCredential(tag names.CloudCredentialTag) (watcher.NotifyWatcher, error) {
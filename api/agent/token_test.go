@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agent"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type TokenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TokenSuite{})
+
+func (s *TokenSuite) TestRefreshToken(c *gc.C) {
+	expires := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(facade string, version int, id, request string, arg, result interface{}) error {
+			c.Check(facade, gc.Equals, "Agent")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "RefreshToken")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.AgentTokenResult)) = params.AgentTokenResult{
+				Token:   "new-token",
+				Expires: expires,
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	st, err := agent.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	token, tokenExpires, err := st.RefreshToken()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Equals, "new-token")
+	c.Assert(tokenExpires, gc.Equals, expires)
+}
+
+func (s *TokenSuite) TestRefreshTokenNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(facade string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	st, err := agent.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = st.RefreshToken()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *TokenSuite) TestTokenValidity(c *gc.C) {
+	issued := time.Date(2017, 8, 31, 12, 0, 0, 0, time.UTC)
+	expires := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(facade string, version int, id, request string, arg, result interface{}) error {
+			c.Check(facade, gc.Equals, "Agent")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "TokenValidity")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.AgentTokenResult)) = params.AgentTokenResult{
+				Issued:  issued,
+				Expires: expires,
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	st, err := agent.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	tokenIssued, tokenExpires, err := st.TokenValidity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokenIssued, gc.Equals, issued)
+	c.Assert(tokenExpires, gc.Equals, expires)
+}
@@ -41,6 +41,69 @@ func (c *Client) Set(annotations map[string]map[string]string) ([]params.ErrorRe
 	return results.Results, nil
 }
 
+// GetStructured returns the annotations that have been set on the given
+// entities, with structured values returned as JSON values.
+func (c *Client) GetStructured(tags []string) ([]params.StructuredAnnotationsGetResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("structured annotations")
+	}
+	var results params.StructuredAnnotationsGetResults
+	if err := c.facade.FacadeCall("GetStructured", entitiesFromTags(tags), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// SetStructured sets annotations on entities, where the values may be
+// any JSON value. A nil value removes the annotation.
+func (c *Client) SetStructured(annotations map[string]map[string]interface{}) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("structured annotations")
+	}
+	var args params.StructuredAnnotationsSet
+	for tag, values := range annotations {
+		args.Annotations = append(args.Annotations, params.EntityStructuredAnnotations{
+			EntityTag:   tag,
+			Annotations: values,
+		})
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetStructured", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// Find returns the tags of the entities of the given kind whose
+// annotations match all of the given filters. If kind is empty,
+// entities of any kind are returned.
+func (c *Client) Find(kind string, filters ...params.AnnotationFilter) ([]string, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("finding entities by annotation")
+	}
+	args := params.AnnotationQueries{
+		Queries: []params.AnnotationQuery{{Kind: kind, Filters: filters}},
+	}
+	var results params.AnnotationQueryResults
+	if err := c.facade.FacadeCall("Find", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]string, len(results.Results[0].Entities))
+	for i, entity := range results.Results[0].Entities {
+		tags[i] = entity.Tag
+	}
+	return tags, nil
+}
+
 func entitiesFromTags(tags []string) params.Entities {
 	entities := []params.Entity{}
 	for _, tag := range tags {
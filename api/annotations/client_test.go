@@ -91,3 +91,53 @@ func (s *annotationsMockSuite) TestGetEntitiesAnnotations(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *annotationsMockSuite) TestFind(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(
+			objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Annotations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "Find")
+			c.Check(a, jc.DeepEquals, params.AnnotationQueries{
+				Queries: []params.AnnotationQuery{{
+					Kind:    "machine",
+					Filters: []params.AnnotationFilter{{Key: "cost.centre", Value: "ops"}},
+				}},
+			})
+			*(response.(*params.AnnotationQueryResults)) = params.AnnotationQueryResults{
+				Results: []params.AnnotationQueryResult{{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-2"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	annotationsClient := annotations.NewClient(apiCaller)
+	tags, err := annotationsClient.Find("machine", params.AnnotationFilter{Key: "cost.centre", Value: "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(tags, jc.DeepEquals, []string{"machine-0", "machine-2"})
+}
+
+func (s *annotationsMockSuite) TestFindNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.Find("machine")
+	c.Assert(err, gc.ErrorMatches, "finding entities by annotation not supported")
+	_, err = annotationsClient.SetStructured(nil)
+	c.Assert(err, gc.ErrorMatches, "structured annotations not supported")
+}
@@ -274,6 +274,10 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 func loginWithContext(ctx context.Context, st *state, info *Info) error {
 	result := make(chan error, 1)
 	go func() {
+		if info.Token != "" {
+			result <- st.loginWithToken(info.Token)
+			return
+		}
 		result <- st.Login(info.Tag, info.Password, info.Nonce, info.Macaroons)
 	}()
 	select {
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apitokens provides access to the APITokens API facade, which
+// manages the scoped tokens with which automation may log in on behalf
+// of a user.
+package apitokens
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the APITokens API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the APITokens API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "APITokens")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Add adds an API token for the logged in user, valid for the given
+// lifetime and granted the given capabilities. If the model tag is
+// not empty, the token may only log in to that model. Add returns the
+// token, which cannot be retrieved later, along with a description of
+// it.
+func (c *Client) Add(model names.ModelTag, capabilities []string, lifetime time.Duration) (params.APIToken, string, error) {
+	if c.BestAPIVersion() < 1 {
+		return params.APIToken{}, "", errors.NotSupportedf("API tokens")
+	}
+	arg := params.AddAPITokenArg{
+		Capabilities: capabilities,
+		Lifetime:     lifetime,
+	}
+	if model.Id() != "" {
+		arg.ModelTag = model.String()
+	}
+	args := params.AddAPITokensArgs{Args: []params.AddAPITokenArg{arg}}
+	var results params.AddAPITokenResults
+	if err := c.facade.FacadeCall("AddTokens", args, &results); err != nil {
+		return params.APIToken{}, "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.APIToken{}, "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.APIToken{}, "", errors.Trace(result.Error)
+	}
+	return result.Info, result.Token, nil
+}
+
+// List returns the API tokens of the logged in user, or of all users
+// if the user is a controller superuser.
+func (c *Client) List() ([]params.APIToken, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("API tokens")
+	}
+	var result params.APITokensResult
+	if err := c.facade.FacadeCall("ListTokens", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Tokens, nil
+}
+
+// Remove removes the API token with the given ID, so that it may no
+// longer be used to log in.
+func (c *Client) Remove(id string) error {
+	if c.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("API tokens")
+	}
+	args := params.RemoveAPITokensArgs{IDs: []string{id}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveTokens", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
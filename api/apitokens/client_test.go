@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/apitokens"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type apiTokensSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&apiTokensSuite{})
+
+func newAPICaller(c *gc.C, request string, check func(arg, result interface{})) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, req string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "APITokens")
+			c.Check(version, gc.Equals, 1)
+			c.Check(req, gc.Equals, request)
+			check(arg, result)
+			return nil
+		},
+		BestVersion: 1,
+	}
+}
+
+func (s *apiTokensSuite) TestAdd(c *gc.C) {
+	info := params.APIToken{
+		ID:           "0123456789abcdef",
+		UserTag:      "user-bob",
+		ModelTag:     coretesting.ModelTag.String(),
+		Capabilities: []string{"read"},
+	}
+	apiCaller := newAPICaller(c, "AddTokens", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.AddAPITokensArgs{
+			Args: []params.AddAPITokenArg{{
+				ModelTag:     coretesting.ModelTag.String(),
+				Capabilities: []string{"read"},
+				Lifetime:     time.Hour,
+			}},
+		})
+		*(result.(*params.AddAPITokenResults)) = params.AddAPITokenResults{
+			Results: []params.AddAPITokenResult{{
+				Info:  info,
+				Token: "0123456789abcdef.s3cret",
+			}},
+		}
+	})
+	result, token, err := apitokens.NewClient(apiCaller).Add(coretesting.ModelTag, []string{"read"}, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, info)
+	c.Assert(token, gc.Equals, "0123456789abcdef.s3cret")
+}
+
+func (s *apiTokensSuite) TestAddControllerWide(c *gc.C) {
+	apiCaller := newAPICaller(c, "AddTokens", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.AddAPITokensArgs{
+			Args: []params.AddAPITokenArg{{
+				Capabilities: []string{"write"},
+				Lifetime:     time.Hour,
+			}},
+		})
+		*(result.(*params.AddAPITokenResults)) = params.AddAPITokenResults{
+			Results: []params.AddAPITokenResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+	})
+	_, _, err := apitokens.NewClient(apiCaller).Add(names.ModelTag{}, []string{"write"}, time.Hour)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *apiTokensSuite) TestList(c *gc.C) {
+	expected := []params.APIToken{{
+		ID:           "0123456789abcdef",
+		UserTag:      "user-bob",
+		Capabilities: []string{"read"},
+	}}
+	apiCaller := newAPICaller(c, "ListTokens", func(arg, result interface{}) {
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.APITokensResult)) = params.APITokensResult{Tokens: expected}
+	})
+	result, err := apitokens.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *apiTokensSuite) TestRemove(c *gc.C) {
+	apiCaller := newAPICaller(c, "RemoveTokens", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.RemoveAPITokensArgs{IDs: []string{"0123456789abcdef"}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+	})
+	err := apitokens.NewClient(apiCaller).Remove("0123456789abcdef")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *apiTokensSuite) TestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 0,
+	}
+	_, err := apitokens.NewClient(apiCaller).List()
+	c.Assert(err, gc.ErrorMatches, "API tokens not supported")
+}
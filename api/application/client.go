@@ -258,6 +258,10 @@ type SetCharmConfig struct {
 	// ForceUnits forces the upgrade on units in an error state.
 	ForceUnits bool
 
+	// Pinned records that the charm was chosen at a specific revision,
+	// so that the application is not offered upgrades.
+	Pinned bool
+
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string
@@ -297,6 +301,7 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ConfigSettingsYAML: cfg.ConfigSettingsYAML,
 		ForceSeries:        cfg.ForceSeries,
 		ForceUnits:         cfg.ForceUnits,
+		Pinned:             cfg.Pinned,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
 	}
@@ -643,6 +648,180 @@ func (c *Client) Unset(application string, options []string) error {
 	return c.facade.FacadeCall("Unset", p, nil)
 }
 
+// ValidateConfig checks the proposed config for an application against
+// its charm's config schema, without applying it. The config may be
+// given as key=value strings, or as YAML in the format accepted by
+// Update; the YAML takes precedence if both are given. It returns the
+// typed values which would be set, with nil for those which would be
+// reset to their defaults.
+func (c *Client) ValidateConfig(application string, options map[string]string, configYAML string) (map[string]interface{}, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("validating config on this controller")
+	}
+	args := params.ApplicationValidateConfigArgs{
+		Args: []params.ApplicationValidateConfig{{
+			ApplicationName: application,
+			Config:          options,
+			ConfigYAML:      configYAML,
+		}},
+	}
+	var results params.ApplicationGetConfigResults
+	if err := c.facade.FacadeCall("ValidateConfig", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Config, nil
+}
+
+// ConfigHistory returns the recorded changes to the config of an
+// application, oldest first. Only the most recent changes are kept.
+func (c *Client) ConfigHistory(application string) ([]params.ConfigChange, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("config history on this controller")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationConfigHistoryResults
+	if err := c.facade.FacadeCall("ConfigHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Changes, nil
+}
+
+// MoveUnit moves a principal unit, along with its subordinates, to
+// another machine. The move is completed once the unit has stopped on
+// its current machine, unless force is true, in which case it is
+// completed immediately.
+func (c *Client) MoveUnit(unit, machine string, force bool) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("moving units on this controller")
+	}
+	if !names.IsValidUnit(unit) {
+		return errors.NotValidf("unit name %q", unit)
+	}
+	if !names.IsValidMachine(machine) {
+		return errors.NotValidf("machine id %q", machine)
+	}
+	args := params.MoveUnitsParams{
+		Units: []params.MoveUnitParams{{
+			UnitTag:    names.NewUnitTag(unit).String(),
+			MachineTag: names.NewMachineTag(machine).String(),
+			Force:      force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("MoveUnits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// HookRetryPolicy returns the hook retry policy of the application.
+func (c *Client) HookRetryPolicy(application string) (params.HookRetryPolicy, error) {
+	if c.BestAPIVersion() < 9 {
+		return params.HookRetryPolicy{}, errors.NotSupportedf("hook retry policies on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return params.HookRetryPolicy{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.HookRetryPolicyResults
+	if err := c.facade.FacadeCall("HookRetryPolicies", args, &results); err != nil {
+		return params.HookRetryPolicy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.HookRetryPolicy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.HookRetryPolicy{}, result.Error
+	}
+	return *result.Policy, nil
+}
+
+// SetHookRetryPolicy sets the hook retry policy of the application,
+// overriding the model's automatic hook retry behaviour for its units.
+func (c *Client) SetHookRetryPolicy(application string, policy params.HookRetryPolicy) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("hook retry policies on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.ApplicationHookRetryPolicies{
+		Policies: []params.ApplicationHookRetryPolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetHookRetryPolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ProxyOverrides returns the proxy overrides of the application.
+func (c *Client) ProxyOverrides(application string) (params.ProxyConfig, error) {
+	if c.BestAPIVersion() < 10 {
+		return params.ProxyConfig{}, errors.NotSupportedf("application proxy overrides on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return params.ProxyConfig{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ProxyOverridesResults
+	if err := c.facade.FacadeCall("ProxyOverrides", args, &results); err != nil {
+		return params.ProxyConfig{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ProxyConfig{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ProxyConfig{}, result.Error
+	}
+	return *result.Overrides, nil
+}
+
+// SetProxyOverrides sets the proxy overrides of the application,
+// overriding the model's proxy settings in its units' hooks.
+func (c *Client) SetProxyOverrides(application string, overrides params.ProxyConfig) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("application proxy overrides on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.ApplicationsProxyOverrides{
+		Applications: []params.ApplicationProxyOverrides{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Overrides:      overrides,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetProxyOverrides", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // CharmRelations returns the application's charms relation names.
 func (c *Client) CharmRelations(application string) ([]string, error) {
 	var results params.ApplicationCharmRelationsResults
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -944,3 +946,256 @@ func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 		fooConstraints, barConstraints,
 	})
 }
+
+func (s *applicationSuite) TestValidateConfig(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ValidateConfig")
+				c.Assert(a, jc.DeepEquals, params.ApplicationValidateConfigArgs{
+					Args: []params.ApplicationValidateConfig{{
+						ApplicationName: "foo",
+						Config:          map[string]string{"skill-level": "42"},
+					}},
+				})
+				result, ok := response.(*params.ApplicationGetConfigResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ConfigResult{{
+					Config: map[string]interface{}{"skill-level": 42},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+
+	config, err := client.ValidateConfig("foo", map[string]string{"skill-level": "42"}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]interface{}{"skill-level": 42})
+}
+
+func (s *applicationSuite) TestValidateConfigNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.ValidateConfig("foo", map[string]string{"skill-level": "42"}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestConfigHistory(c *gc.C) {
+	changed := time.Date(2017, 11, 2, 10, 0, 0, 0, time.UTC)
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ConfigHistory")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result, ok := response.(*params.ApplicationConfigHistoryResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ApplicationConfigHistoryResult{{
+					Changes: []params.ConfigChange{{
+						Version: 1,
+						Author:  "bob",
+						Changed: changed,
+						Items: []params.ConfigItemChange{
+							{Kind: "added", Key: "skill-level", NewValue: 42},
+						},
+					}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+
+	history, err := client.ConfigHistory("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.ConfigChange{{
+		Version: 1,
+		Author:  "bob",
+		Changed: changed,
+		Items: []params.ConfigItemChange{
+			{Kind: "added", Key: "skill-level", NewValue: 42},
+		},
+	}})
+}
+
+func (s *applicationSuite) TestConfigHistoryNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 6,
+	})
+	_, err := client.ConfigHistory("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestMoveUnit(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "MoveUnits")
+				c.Assert(a, jc.DeepEquals, params.MoveUnitsParams{
+					Units: []params.MoveUnitParams{{
+						UnitTag:    "unit-foo-0",
+						MachineTag: "machine-1",
+						Force:      true,
+					}},
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.MoveUnit("foo/0", "1", true)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestMoveUnitNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	})
+	err := client.MoveUnit("foo/0", "1", false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestHookRetryPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "HookRetryPolicies")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result, ok := response.(*params.HookRetryPolicyResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.HookRetryPolicyResult{{
+					Policy: &params.HookRetryPolicy{MaxAttempts: 2},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	policy, err := client.HookRetryPolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, params.HookRetryPolicy{MaxAttempts: 2})
+}
+
+func (s *applicationSuite) TestSetHookRetryPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetHookRetryPolicies")
+				c.Assert(a, jc.DeepEquals, params.ApplicationHookRetryPolicies{
+					Policies: []params.ApplicationHookRetryPolicy{{
+						ApplicationTag: "application-foo",
+						Policy:         params.HookRetryPolicy{Disabled: true},
+					}},
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	err := client.SetHookRetryPolicy("foo", params.HookRetryPolicy{Disabled: true})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetHookRetryPolicyNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.SetHookRetryPolicy("foo", params.HookRetryPolicy{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestProxyOverrides(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ProxyOverrides")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result, ok := response.(*params.ProxyOverridesResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ProxyOverridesResult{{
+					Overrides: &params.ProxyConfig{HTTP: "http://proxy"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	overrides, err := client.ProxyOverrides("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides, jc.DeepEquals, params.ProxyConfig{HTTP: "http://proxy"})
+}
+
+func (s *applicationSuite) TestSetProxyOverrides(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "SetProxyOverrides")
+				c.Assert(a, jc.DeepEquals, params.ApplicationsProxyOverrides{
+					Applications: []params.ApplicationProxyOverrides{{
+						ApplicationTag: "application-foo",
+						Overrides:      params.ProxyConfig{NoProxy: "example.com"},
+					}},
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	err := client.SetProxyOverrides("foo", params.ProxyConfig{NoProxy: "example.com"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestProxyOverridesNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	_, err := client.ProxyOverrides("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package capabilities provides access to the Capabilities API
+// facade, which reports the features supported by a controller.
+package capabilities
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Capabilities API end point.
+type Client struct {
+	base.ClientFacade
+	st     base.APICallCloser
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the capabilities api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Capabilities")
+	return &Client{ClientFacade: frontend, st: st, facade: backend}
+}
+
+// Capabilities returns the features supported by the controller, and
+// the facade versions it has deprecated. Controllers that predate the
+// Capabilities facade do not report deprecations, and their supported
+// features are inferred from the facades that they serve.
+func (c *Client) Capabilities() (params.CapabilitiesResult, error) {
+	if c.BestAPIVersion() < 1 {
+		return c.inferCapabilities(), nil
+	}
+	var result params.CapabilitiesResult
+	if err := c.facade.FacadeCall("Capabilities", nil, &result); err != nil {
+		return params.CapabilitiesResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// inferCapabilities returns the features provided by facades
+// that the controller reported at login.
+func (c *Client) inferCapabilities() params.CapabilitiesResult {
+	features := []string{}
+	for feature, facadeName := range params.FeatureFacades {
+		if c.st.BestFacadeVersion(facadeName) > 0 {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	return params.CapabilitiesResult{Features: features}
+}
+
+// CheckFeature returns an error satisfying errors.IsNotSupported if
+// the controller does not support the given feature.
+func (c *Client) CheckFeature(feature string) error {
+	result, err := c.Capabilities()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, supported := range result.Features {
+		if supported == feature {
+			return nil
+		}
+	}
+	return errors.NotSupportedf("%s on this controller", feature)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capabilities_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/capabilities"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type CapabilitiesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CapabilitiesSuite{})
+
+// facadesCaller is an APICallerFunc that reports the given
+// facade versions.
+type facadesCaller struct {
+	basetesting.APICallerFunc
+	facades map[string]int
+}
+
+func (c facadesCaller) BestFacadeVersion(facade string) int {
+	return c.facades[facade]
+}
+
+func (s *CapabilitiesSuite) TestCapabilities(c *gc.C) {
+	apiCaller := facadesCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Capabilities")
+			c.Check(version, gc.Equals, 1)
+			c.Check(request, gc.Equals, "Capabilities")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.CapabilitiesResult)) = params.CapabilitiesResult{
+				Features: []string{params.FeatureSpaces},
+				DeprecatedFacades: []params.DeprecatedFacade{{
+					Name: "Spaces", Version: 2, Message: "use Spaces(3)",
+				}},
+			}
+			return nil
+		},
+		facades: map[string]int{"Capabilities": 1},
+	}
+	result, err := capabilities.NewClient(apiCaller).Capabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Features, jc.DeepEquals, []string{params.FeatureSpaces})
+	c.Assert(result.DeprecatedFacades, gc.HasLen, 1)
+}
+
+func (s *CapabilitiesSuite) TestCapabilitiesOlderController(c *gc.C) {
+	apiCaller := facadesCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call %s.%s", objType, request)
+			return nil
+		},
+		facades: map[string]int{"Spaces": 3, "Client": 1},
+	}
+	client := capabilities.NewClient(apiCaller)
+	result, err := client.Capabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CapabilitiesResult{
+		Features: []string{params.FeatureSpaces},
+	})
+
+	c.Assert(client.CheckFeature(params.FeatureSpaces), jc.ErrorIsNil)
+	err = client.CheckFeature(params.FeatureCrossModelRelations)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "cross-model-relations on this controller not supported")
+}
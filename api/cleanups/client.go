@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cleanups provides access to the Cleanups API facade, which
+// reports the cleanups pending in a model.
+package cleanups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Cleanups API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Cleanups API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Cleanups")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns the cleanups in the model that have not yet completed,
+// in the order they will be run.
+func (c *Client) List() ([]params.CleanupInfo, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("listing cleanups")
+	}
+	var result params.CleanupsResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Cleanups, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cleanups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/cleanups"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cleanupsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&cleanupsSuite{})
+
+func (s *cleanupsSuite) TestList(c *gc.C) {
+	expected := []params.CleanupInfo{{
+		Id:        "5a0b",
+		Kind:      "dyingMachine",
+		Prefix:    "0",
+		Created:   time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC),
+		Attempts:  1,
+		LastError: "boom",
+	}}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Cleanups")
+			c.Check(version, gc.Equals, 1)
+			c.Check(request, gc.Equals, "List")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.CleanupsResult)) = params.CleanupsResult{Cleanups: expected}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	result, err := cleanups.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *cleanupsSuite) TestListNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+	}
+	_, err := cleanups.NewClient(apiCaller).List()
+	c.Assert(err, gc.ErrorMatches, "listing cleanups not supported")
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
@@ -48,6 +49,53 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusArgs holds the arguments to StatusWithArgs.
+type StatusArgs struct {
+	// Patterns filters the status as for Status.
+	Patterns []string
+
+	// Fields, if non-empty, restricts the sections of the
+	// status returned. See the params.StatusField* constants.
+	Fields []string
+
+	// Applications, if non-empty, restricts the applications
+	// returned to those named.
+	Applications []string
+}
+
+// StatusWithArgs returns the status of the juju model, restricted
+// to the selected sections and applications. Controllers that do
+// not support field selection ignore the restrictions and return
+// the full status.
+func (c *Client) StatusWithArgs(args StatusArgs) (*params.FullStatus, error) {
+	var result params.FullStatus
+	p := params.StatusParams{
+		Patterns:     args.Patterns,
+		Fields:       args.Fields,
+		Applications: args.Applications,
+	}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StatusAt returns the status of the model as it was at the given
+// time, and the time at which that status was recorded. The status
+// is taken from the latest snapshot of the model's status taken no
+// later than the given time.
+func (c *Client) StatusAt(t time.Time) (*params.FullStatus, time.Time, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, time.Time{}, errors.NotSupportedf("status at a past time")
+	}
+	var result params.StatusAtResult
+	p := params.StatusAtParams{At: t}
+	if err := c.facade.FacadeCall("StatusAt", p, &result); err != nil {
+		return nil, time.Time{}, errors.Trace(err)
+	}
+	return &result.Status, result.Taken, nil
+}
+
 // CACert returns the CA certificate associated with
 // the connection.
 func (c *Client) CACert() (string, error) {
@@ -273,6 +321,38 @@ func (c *Client) SetModelAgentVersion(version version.Number, ignoreAgentVersion
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// SetModelAgentVersionStaged sets the model agent-version setting to
+// the given value, staging the upgrade of the model's machines: after
+// the controllers, the canary machines are upgraded, then the rest in
+// batches of the given size.
+func (c *Client) SetModelAgentVersionStaged(version version.Number, ignoreAgentVersions bool, canaries []string, batchSize int) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("staged upgrades")
+	}
+	args := params.SetModelAgentVersion{
+		Version:             version,
+		IgnoreAgentVersions: ignoreAgentVersions,
+		Canaries:            canaries,
+		BatchSize:           batchSize,
+	}
+	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
+}
+
+// UpgradePrecheck reports the problems that would prevent, or that
+// should be resolved before, upgrading the model to the given version.
+func (c *Client) UpgradePrecheck(version version.Number, ignoreAgentVersions bool) (params.UpgradePrecheckResult, error) {
+	var result params.UpgradePrecheckResult
+	if c.facade.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("upgrade precheck")
+	}
+	args := params.UpgradePrecheckArgs{
+		Version:             version,
+		IgnoreAgentVersions: ignoreAgentVersions,
+	}
+	err := c.facade.FacadeCall("UpgradePrecheck", args, &result)
+	return result, err
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
@@ -554,6 +554,38 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestUpgradePrecheck(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "UpgradePrecheck")
+			c.Assert(args, jc.DeepEquals, params.UpgradePrecheckArgs{
+				Version:             version.MustParse("2.3.1"),
+				IgnoreAgentVersions: true,
+			})
+			result := response.(*params.UpgradePrecheckResult)
+			result.Version = version.MustParse("2.3.1")
+			result.Issues = []params.UpgradePrecheckIssue{{
+				Check:    "series",
+				Severity: params.UpgradePrecheckWarning,
+				Entity:   "machine-0",
+				Message:  "boom",
+			}}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := client.UpgradePrecheck(version.MustParse("2.3.1"), true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, jc.DeepEquals, []params.UpgradePrecheckIssue{{
+		Check:    "series",
+		Severity: params.UpgradePrecheckWarning,
+		Entity:   "machine-0",
+		Message:  "boom",
+	}})
+}
+
 // badReader raises err when Read is called.
 type badReader struct {
 	err error
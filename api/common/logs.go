@@ -34,6 +34,16 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeLabel lists labels to include in the response. If any are
+	// set, only messages with at least one of the labels are included.
+	IncludeLabel []string
+	// ExcludeLabel lists labels to exclude from the response. Messages
+	// with any of the labels are excluded.
+	ExcludeLabel []string
+	// IncludeTrace lists API request trace IDs. If any are set, only the
+	// log messages with those correlation IDs, and the API server's log
+	// messages for those requests, are included.
+	IncludeTrace []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,6 +63,10 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, limits the records returned to those with a
+	// log time before EndTime. The server stops sending once it has
+	// returned the existing matching records, as if NoTail were set.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -62,6 +76,15 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
 	}
+	if len(args.IncludeLabel) > 0 {
+		attrs["includeLabel"] = args.IncludeLabel
+	}
+	if len(args.ExcludeLabel) > 0 {
+		attrs["excludeLabel"] = args.ExcludeLabel
+	}
+	if len(args.IncludeTrace) > 0 {
+		attrs["includeTrace"] = args.IncludeTrace
+	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
 	}
@@ -80,17 +103,22 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
-	Entity    string
-	Timestamp time.Time
-	Severity  string
-	Module    string
-	Location  string
-	Message   string
+	Entity        string    `json:"entity"`
+	Timestamp     time.Time `json:"timestamp"`
+	Severity      string    `json:"severity"`
+	Module        string    `json:"module"`
+	Location      string    `json:"location"`
+	Message       string    `json:"message"`
+	Labels        []string  `json:"labels,omitempty"`
+	CorrelationID string    `json:"correlation-id,omitempty"`
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +156,9 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+
+				Labels:        msg.Labels,
+				CorrelationID: msg.CorrelationID,
 			}
 		}
 	}()
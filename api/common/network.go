@@ -68,8 +68,11 @@ func DefaultNetworkConfigSource() NetworkConfigSource {
 //   and will have InterfaceType set LoopbackInterface.
 // * On Linux only, the InterfaceType field will be reliably detected for a few
 //   types: BondInterface, BridgeInterface, VLAN_8021QInterface.
-// * Also on Linux, for interfaces that are discovered to be ports on a bridge,
-//   the ParentInterfaceName will be populated with the name of the bridge.
+// * Also on Linux, for interfaces that are discovered to be ports on a bridge
+//   or slaves of a bond, the ParentInterfaceName will be populated with the
+//   name of the bridge or bond. VLAN interfaces which are not bridge ports get
+//   the name of the device they are stacked on as ParentInterfaceName. VLANTag
+//   is set when it can be parsed from the VLAN interface name.
 // * ConfigType fields will be set to ConfigManual when no address is detected,
 //   or ConfigStatic when it is.
 // * TODO: IPv6 link-local addresses will be ignored and treated as empty ATM.
@@ -91,8 +94,13 @@ func GetObservedNetworkConfig(source NetworkConfigSource) ([]params.NetworkConfi
 		nicType := network.ParseInterfaceType(sysClassNetPath, nic.Name)
 		nicConfig := interfaceToNetworkConfig(nic, nicType)
 
-		if nicType == network.BridgeInterface {
-			updateParentForBridgePorts(nic.Name, sysClassNetPath, nameToConfigs)
+		switch nicType {
+		case network.BridgeInterface:
+			ports := network.GetBridgePorts(sysClassNetPath, nic.Name)
+			updateParentForPorts(nic.Name, ports, nameToConfigs)
+		case network.BondInterface:
+			slaves := network.GetBondSlaves(sysClassNetPath, nic.Name)
+			updateParentForPorts(nic.Name, slaves, nameToConfigs)
 		}
 
 		seenSoFar := false
@@ -103,6 +111,14 @@ func GetObservedNetworkConfig(source NetworkConfigSource) ([]params.NetworkConfi
 			// not yet seen.
 			seenSoFar = existing[0].InterfaceName != ""
 		}
+		if nicType == network.VLAN_8021QInterface {
+			// A VLAN that is a bridge port keeps the bridge as its parent.
+			lowerName, vlanTag := network.GetVLANParentAndTag(sysClassNetPath, nic.Name)
+			if nicConfig.ParentInterfaceName == "" {
+				nicConfig.ParentInterfaceName = lowerName
+			}
+			nicConfig.VLANTag = vlanTag
+		}
 
 		if !seenSoFar {
 			nameToConfigs[nic.Name] = []params.NetworkConfig(nil)
@@ -171,14 +187,18 @@ func interfaceToNetworkConfig(nic net.Interface, nicType network.InterfaceType)
 	}
 }
 
-func updateParentForBridgePorts(bridgeName, sysClassNetPath string, nameToConfigs map[string][]params.NetworkConfig) {
-	ports := network.GetBridgePorts(sysClassNetPath, bridgeName)
+// updateParentForPorts sets parentName as the ParentInterfaceName of each of
+// the given ports (bridge ports or bond slaves), whether or not they have been
+// seen yet.
+func updateParentForPorts(parentName string, ports []string, nameToConfigs map[string][]params.NetworkConfig) {
 	for _, portName := range ports {
 		portConfigs, ok := nameToConfigs[portName]
 		if ok {
-			portConfigs[0].ParentInterfaceName = bridgeName
+			for i := range portConfigs {
+				portConfigs[i].ParentInterfaceName = parentName
+			}
 		} else {
-			portConfigs = []params.NetworkConfig{{ParentInterfaceName: bridgeName}}
+			portConfigs = []params.NetworkConfig{{ParentInterfaceName: parentName}}
 		}
 		nameToConfigs[portName] = portConfigs
 	}
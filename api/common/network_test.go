@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -967,26 +968,31 @@ func (s *NetworkSuite) TestGetObservedNetworkConfigVLANInfrerred(c *gc.C) {
 			fakeAddr("10.100.19.123/24"),
 		},
 	}
-	s.stubConfigSource.makeSysClassNetInterfacePath(c, "eth0.100", "vlan")
+	vlanPath := s.stubConfigSource.makeSysClassNetInterfacePath(c, "eth0.100", "vlan")
+	s.stubConfigSource.makeSysClassNetLowerDevice(c, vlanPath, "eth0")
 
 	observedConfig, err := common.GetObservedNetworkConfig(s.stubConfigSource)
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(observedConfig, jc.DeepEquals, []params.NetworkConfig{{
-		DeviceIndex:   13,
-		MACAddress:    "aa:bb:cc:dd:ee:f0",
-		MTU:           1500,
-		InterfaceName: "eth0.100",
-		InterfaceType: "802.1q",
-		ConfigType:    "manual", // the IPv6 address treated as empty.
+		DeviceIndex:         13,
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+		MTU:                 1500,
+		InterfaceName:       "eth0.100",
+		InterfaceType:       "802.1q",
+		ParentInterfaceName: "eth0",
+		VLANTag:             100,
+		ConfigType:          "manual", // the IPv6 address treated as empty.
 	}, {
-		DeviceIndex:   13,
-		CIDR:          "10.100.19.0/24",
-		Address:       "10.100.19.123",
-		MACAddress:    "aa:bb:cc:dd:ee:f0",
-		MTU:           1500,
-		InterfaceName: "eth0.100",
-		InterfaceType: "802.1q",
-		ConfigType:    "static",
+		DeviceIndex:         13,
+		CIDR:                "10.100.19.0/24",
+		Address:             "10.100.19.123",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+		MTU:                 1500,
+		InterfaceName:       "eth0.100",
+		InterfaceType:       "802.1q",
+		ParentInterfaceName: "eth0",
+		VLANTag:             100,
+		ConfigType:          "static",
 	}})
 
 	s.stubConfigSource.CheckCallNames(c, "Interfaces", "SysClassNetPath", "InterfaceAddresses")
@@ -1095,6 +1101,50 @@ func (s *NetworkSuite) TestGetObservedNetworkConfigBridgePortsHaveParentSet(c *g
 	s.stubConfigSource.CheckCall(c, 5, "InterfaceAddresses", "eth1")
 }
 
+func (s *NetworkSuite) TestGetObservedNetworkConfigBondSlavesHaveParentSet(c *gc.C) {
+	bond := net.Interface{
+		Index:        20,
+		MTU:          1500,
+		Name:         "bond0",
+		HardwareAddr: mustParseMAC("aa:bb:cc:dd:ee:f0"),
+		Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+	}
+	s.stubConfigSource.interfaces = []net.Interface{
+		exampleObservedInterfaces[1], // eth0
+		bond,
+		exampleObservedInterfaces[4], // eth1
+	}
+	bondPath := s.stubConfigSource.makeSysClassNetInterfacePath(c, "bond0", "bond")
+	s.stubConfigSource.makeSysClassNetBondSlaves(c, bondPath, "eth0", "eth1")
+
+	observedConfig, err := common.GetObservedNetworkConfig(s.stubConfigSource)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(observedConfig, jc.DeepEquals, []params.NetworkConfig{{
+		DeviceIndex:         2,
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+		MTU:                 1500,
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		ParentInterfaceName: "bond0",
+		ConfigType:          "manual",
+	}, {
+		DeviceIndex:   20,
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		MTU:           1500,
+		InterfaceName: "bond0",
+		InterfaceType: "bond",
+		ConfigType:    "manual",
+	}, {
+		DeviceIndex:         3,
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+		MTU:                 1500,
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		ParentInterfaceName: "bond0",
+		ConfigType:          "manual",
+	}})
+}
+
 func (s *NetworkSuite) TestGetObservedNetworkConfigAddressNotInCIDRFormat(c *gc.C) {
 	s.stubConfigSource.interfaces = exampleObservedInterfaces[1:2] // only eth0
 	s.stubConfigSource.makeSysClassNetInterfacePath(c, "eth0", "")
@@ -1208,6 +1258,28 @@ func (s *stubNetworkConfigSource) makeSysClassNetBridgePorts(c *gc.C, interfaceP
 	}
 }
 
+// makeSysClassNetBondSlaves creates a "bonding/slaves" file in the given
+// interfacePath, listing the given slaves. Needed to simulate the FS structure
+// network.GetBondSlaves() can handle.
+func (s *stubNetworkConfigSource) makeSysClassNetBondSlaves(c *gc.C, interfacePath string, slaves ...string) {
+	bondingPath := filepath.Join(interfacePath, "bonding")
+	err := os.Mkdir(bondingPath, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	contents := strings.Join(slaves, " ") + "\n"
+	err = ioutil.WriteFile(filepath.Join(bondingPath, "slaves"), []byte(contents), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// makeSysClassNetLowerDevice creates a "lower_<lowerName>" file in the given
+// interfacePath. Needed to simulate the FS structure
+// network.GetVLANParentAndTag() can handle.
+func (s *stubNetworkConfigSource) makeSysClassNetLowerDevice(c *gc.C, interfacePath, lowerName string) {
+	lowerPath := filepath.Join(interfacePath, "lower_"+lowerName)
+	err := ioutil.WriteFile(lowerPath, []byte("#empty"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 // SysClassNetPath implements NetworkConfigSource.
 func (s *stubNetworkConfigSource) SysClassNetPath() string {
 	s.AddCall("SysClassNetPath")
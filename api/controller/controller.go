@@ -325,3 +325,15 @@ func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	}
 	return string(out), nil
 }
+
+// ConfigSet changes the values of the given controller config
+// attributes. Only some attributes may be changed after the controller
+// has been bootstrapped.
+func (c *Client) ConfigSet(values map[string]interface{}) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("changing controller config on this controller")
+	}
+	return errors.Trace(c.facade.FacadeCall("ConfigSet", params.ControllerConfigSet{
+		Config: values,
+	}, nil))
+}
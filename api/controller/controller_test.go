@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -350,3 +351,150 @@ func (s *Suite) TestModelStatusError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model error")
 	c.Assert(out, gc.IsNil)
 }
+
+func (s *Suite) TestEngineReport(c *gc.C) {
+	var stub jujutesting.Stub
+	updated := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.EngineReportResults) = params.EngineReportResults{
+				Results: []params.EngineReportResult{{
+					Report:  "manifolds: {}\n",
+					Updated: updated,
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+
+	report, err := client.EngineReport(coretesting.ModelTag, names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, controller.EngineReport{
+		Report:  "manifolds: {}\n",
+		Updated: updated,
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.EngineReports", []interface{}{params.EngineReportQueries{
+			Queries: []params.EngineReportQuery{{
+				ModelTag: coretesting.ModelTag.String(),
+				Tag:      "machine-1",
+			}},
+		}}},
+	})
+}
+
+func (s *Suite) TestEngineReportError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*result.(*params.EngineReportResults) = params.EngineReportResults{
+				Results: []params.EngineReportResult{{
+					Error: &params.Error{Message: "no report", Code: params.CodeNotFound},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.EngineReport(coretesting.ModelTag, names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, "no report")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *Suite) TestEngineReportNotSupported(c *gc.C) {
+	client := controller.NewClient(apitesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.EngineReport(coretesting.ModelTag, names.NewMachineTag("1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestControllerMetrics(c *gc.C) {
+	var stub jujutesting.Stub
+	since := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.ControllerMetricsResult) = params.ControllerMetricsResult{
+				Samples: []params.ControllerMetricsSample{{
+					Machine: "0",
+					Time:    since.Add(time.Minute),
+					Metrics: map[string]float64{"txn-queue-depth": 2},
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+
+	samples, err := client.ControllerMetrics(since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(samples, jc.DeepEquals, []controller.ControllerMetricsSample{{
+		Machine: "0",
+		Time:    since.Add(time.Minute),
+		Metrics: map[string]float64{"txn-queue-depth": 2},
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ControllerMetrics", []interface{}{params.ControllerMetricsArgs{Since: since}}},
+	})
+}
+
+func (s *Suite) TestControllerMetricsNotSupported(c *gc.C) {
+	client := controller.NewClient(apitesting.BestVersionCaller{BestVersion: 5})
+	_, err := client.ControllerMetrics(time.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestRefreshInstanceTypes(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+
+	err := client.RefreshInstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.RefreshInstanceTypes", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestRefreshInstanceTypesNotSupported(c *gc.C) {
+	client := controller.NewClient(apitesting.BestVersionCaller{BestVersion: 6})
+	err := client.RefreshInstanceTypes()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestConfigSet(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+
+	err := client.ConfigSet(map[string]interface{}{
+		"auditing-enabled": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ConfigSet", []interface{}{params.ControllerConfigSet{
+			Config: map[string]interface{}{"auditing-enabled": true},
+		}}},
+	})
+}
+
+func (s *Suite) TestConfigSetNotSupported(c *gc.C) {
+	client := controller.NewClient(apitesting.BestVersionCaller{BestVersion: 7})
+	err := client.ConfigSet(map[string]interface{}{"auditing-enabled": true})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
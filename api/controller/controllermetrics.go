@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ControllerMetricsSample holds the internal metrics sampled by a
+// controller machine at one point in time.
+type ControllerMetricsSample struct {
+	// Machine is the id of the controller machine that took the
+	// sample.
+	Machine string
+
+	// Time is the time at which the sample was taken.
+	Time time.Time
+
+	// Metrics holds the sampled values, keyed by metric name.
+	Metrics map[string]float64
+}
+
+// ControllerMetrics returns the internal metrics sampled by the
+// controller machines since the given time, oldest first.
+func (c *Client) ControllerMetrics(since time.Time) ([]ControllerMetricsSample, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("controller metrics on this controller")
+	}
+	args := params.ControllerMetricsArgs{Since: since}
+	var result params.ControllerMetricsResult
+	if err := c.facade.FacadeCall("ControllerMetrics", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	samples := make([]ControllerMetricsSample, len(result.Samples))
+	for i, sample := range result.Samples {
+		samples[i] = ControllerMetricsSample{
+			Machine: sample.Machine,
+			Time:    sample.Time,
+			Metrics: sample.Metrics,
+		}
+	}
+	return samples, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const drainPath = "/drain"
+
+// DrainAPIServer asks the API server of the given controller machine to
+// stop accepting new connections and to close its existing ones, so
+// that its clients move to the other API servers. If drain is false,
+// the API server resumes accepting connections.
+func (c *Client) DrainAPIServer(machineId string, drain bool) error {
+	req, err := http.NewRequest("POST", drainPath, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create POST request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeJSON)
+	content, err := json.Marshal(params.APIServerDrainRequest{
+		MachineId: machineId,
+		Drain:     drain,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal request body")
+	}
+
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	if err = httpClient.Do(req, bytes.NewReader(content), nil); err != nil {
+		return errors.Annotate(err, "cannot request API server drain")
+	}
+	return nil
+}
+
+// APIServerDrainStatus returns the drain status of the controller's
+// API servers, as most recently reported by each.
+func (c *Client) APIServerDrainStatus() ([]params.APIServerDrainStatus, error) {
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	var resp params.APIServerDrainResponse
+	if err = httpClient.Get(drainPath, &resp); err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve API server drain status")
+	}
+	return resp.Servers, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestDrainAPIServer(c *gc.C) {
+	withHTTPClient(c, "/drain", "POST", func(w http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+		var drainReq params.APIServerDrainRequest
+		err := json.NewDecoder(req.Body).Decode(&drainReq)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(drainReq, jc.DeepEquals, params.APIServerDrainRequest{
+			MachineId: "1",
+			Drain:     true,
+		})
+		sendJSONResponse(c, w, &params.ErrorResult{})
+	}, func(client *controller.Client) {
+		err := client.DrainAPIServer("1", true)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *Suite) TestDrainAPIServerError(c *gc.C) {
+	withHTTPClient(c, "/drain", "POST", func(w http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+		w.WriteHeader(http.StatusBadRequest)
+	}, func(client *controller.Client) {
+		err := client.DrainAPIServer("1", true)
+		c.Assert(err, gc.ErrorMatches, "cannot request API server drain: .*")
+	})
+}
+
+func (s *Suite) TestAPIServerDrainStatus(c *gc.C) {
+	response := params.APIServerDrainResponse{
+		Servers: []params.APIServerDrainStatus{
+			{MachineId: "0", Connections: 10},
+			{MachineId: "1", Draining: true, Idle: true},
+		},
+	}
+	withHTTPClient(c, "/drain", "GET", func(w http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+		sendJSONResponse(c, w, response)
+	}, func(client *controller.Client) {
+		servers, err := client.APIServerDrainStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(servers, jc.DeepEquals, response.Servers)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// EngineReport holds the most recent dependency engine report sent
+// by an agent to the controller.
+type EngineReport struct {
+	// Report holds the report, formatted as YAML.
+	Report string
+
+	// Updated is the time at which the controller received the report.
+	Updated time.Time
+}
+
+// EngineReport returns the most recent dependency engine report of
+// the given machine or unit agent in the given model.
+func (c *Client) EngineReport(model names.ModelTag, agent names.Tag) (EngineReport, error) {
+	if c.BestAPIVersion() < 5 {
+		return EngineReport{}, errors.NotSupportedf("engine reports on this controller")
+	}
+	args := params.EngineReportQueries{
+		Queries: []params.EngineReportQuery{{
+			ModelTag: model.String(),
+			Tag:      agent.String(),
+		}},
+	}
+	var results params.EngineReportResults
+	if err := c.facade.FacadeCall("EngineReports", args, &results); err != nil {
+		return EngineReport{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return EngineReport{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return EngineReport{}, errors.Trace(result.Error)
+	}
+	return EngineReport{
+		Report:  result.Report,
+		Updated: result.Updated,
+	}, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+)
+
+// RefreshInstanceTypes asks the controller to discard the instance
+// types it has cached for the clouds, so that they are listed from the
+// clouds when next used.
+func (c *Client) RefreshInstanceTypes() error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("refreshing instance types on this controller")
+	}
+	return errors.Trace(c.facade.FacadeCall("RefreshInstanceTypes", nil, nil))
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/api/common/cloudspec"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// API makes calls to the CredentialValidator facade.
+type API struct {
+	caller base.FacadeCaller
+	*common.ModelWatcher
+	*cloudspec.CloudSpecAPI
+}
+
+// NewAPI returns a new API using the supplied caller, which must be
+// connected to a model.
+func NewAPI(caller base.APICaller) (*API, error) {
+	modelTag, isModel := caller.ModelTag()
+	if !isModel {
+		return nil, errors.New("expected model specific API connection")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, "CredentialValidator")
+	return &API{
+		caller:       facadeCaller,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		CloudSpecAPI: cloudspec.NewCloudSpecAPI(facadeCaller, modelTag),
+	}, nil
+}
+
+// WatchModelCredential returns a watcher that reports changes to the
+// model's cloud credential. If the model has no credential, an error
+// satisfying errors.IsNotFound is returned.
+func (api *API) WatchModelCredential() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.caller.FacadeCall("WatchModelCredential", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return nil, errors.NotFoundf("model credential")
+		}
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.caller.RawAPICaller(), result), nil
+}
+
+// SetCredentialValidity records whether the cloud accepted the model's
+// credential, suspending the model if it did not.
+func (api *API) SetCredentialValidity(valid bool, reason string) error {
+	args := params.CredentialValidity{
+		Valid:  valid,
+		Reason: reason,
+	}
+	return errors.Trace(api.caller.FacadeCall("SetCredentialValidity", args, nil))
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestWatchModelCredentialNotFound(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "WatchModelCredential")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "model credential not found"},
+		}
+		return nil
+	})
+	api, err := credentialvalidator.NewAPI(caller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.WatchModelCredential()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APISuite) TestWatchModelCredentialError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "blammo"},
+		}
+		return nil
+	})
+	api, err := credentialvalidator.NewAPI(caller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.WatchModelCredential()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *APISuite) TestSetCredentialValidity(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "SetCredentialValidity")
+		c.Check(arg, jc.DeepEquals, params.CredentialValidity{
+			Reason: "token expired",
+		})
+		c.Check(result, gc.IsNil)
+		return errors.New("blammo")
+	})
+	api, err := credentialvalidator.NewAPI(caller)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.SetCredentialValidity(false, "token expired")
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
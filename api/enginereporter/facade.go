@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginereporter implements the client-side API facade used
+// by the enginereporter worker.
+package enginereporter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the EngineReporter API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side EngineReporter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "EngineReporter"),
+	}
+}
+
+// SetEngineReport sends the dependency engine report of the agent
+// with the given tag to the controller.
+func (f *Facade) SetEngineReport(agent names.Tag, report string) error {
+	args := params.EngineReports{Reports: []params.EngineReport{{
+		Tag:    agent.String(),
+		Report: report,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("SetEngineReports", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -11,29 +11,35 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"APITokens":                    1,
+	"Action":                       5,
 	"ActionPruner":                 1,
-	"Agent":                        2,
+	"ActionScheduler":              1,
+	"Agent":                        3,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
-	"Annotations":                  2,
-	"Application":                  5,
+	"Annotations":                  3,
+	"Application":                  10,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
 	"Bundle":                       1,
+	"Capabilities":                 1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Cleanups":                     1,
+	"Client":                       4,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"Controller":                   8,
+	"CredentialValidator":          1,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"EngineReporter":               1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
@@ -42,10 +48,12 @@ var facadeVersions = map[string]int{
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
+	"HostsUpdater":                 1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstancePoller":               3,
+	"Integrity":                    1,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -53,8 +61,9 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               4,
-	"MachineUndertaker":            1,
+	"MachineHealth":                1,
+	"MachineManager":               5,
+	"MachineUndertaker":            2,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
@@ -65,17 +74,21 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
+	"ModelArchives":                1,
 	"ModelConfig":                  1,
-	"ModelManager":                 4,
+	"ModelManager":                 5,
+	"ModelQuotas":                  1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  5,
+	"Provisioner":                  6,
+	"ProviderCapabilities":         1,
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
+	"RebootCoordinator":            1,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
 	"RemoteRelations":              1,
@@ -86,17 +99,22 @@ var facadeVersions = map[string]int{
 	"Singular":                     2,
 	"Spaces":                       3,
 	"SSHClient":                    2,
-	"StatusHistory":                2,
-	"Storage":                      4,
+	"StatusHistory":                3,
+	"StatusSnapshotter":            1,
+	"Storage":                      5,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
+	"SubnetDiscovery":              1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
+	"Uniter":                       11,
 	"Upgrader":                     1,
-	"UserManager":                  2,
+	"UpgradeStager":                1,
+	"UserManager":                  3,
 	"VolumeAttachmentsWatcher":     2,
+	"Webhooks":                     1,
+	"WireGuardMesh":                1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
@@ -23,8 +23,9 @@ func NewClient(st base.APICallCloser) *Client {
 	return &Client{ClientFacade: frontend, st: st, facade: backend}
 }
 
-// SetFirewallRule creates or updates a firewall rule.
-func (c *Client) SetFirewallRule(service string, whiteListCidrs []string) error {
+// SetFirewallRule creates or updates a firewall rule. The description
+// is recorded against the rule, along with the user who set it.
+func (c *Client) SetFirewallRule(service string, whiteListCidrs []string, description string) error {
 	serviceValue := params.KnownServiceValue(service)
 	if err := serviceValue.Validate(); err != nil {
 		return errors.Trace(err)
@@ -35,6 +36,7 @@ func (c *Client) SetFirewallRule(service string, whiteListCidrs []string) error
 			{
 				KnownService:   serviceValue,
 				WhitelistCIDRS: whiteListCidrs,
+				Description:    description,
 			}},
 	}
 	var results params.ErrorResults
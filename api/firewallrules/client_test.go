@@ -39,6 +39,7 @@ func (s *FirewallRulesSuite) TestSetFirewallRule(c *gc.C) {
 			rule := args.Args[0]
 			c.Assert(rule.KnownService, gc.Equals, params.SSHRule)
 			c.Assert(rule.WhitelistCIDRS, jc.DeepEquals, []string{"192.168.1.0/32"})
+			c.Assert(rule.Description, gc.Equals, "office network")
 
 			if results, ok := result.(*params.ErrorResults); ok {
 				results.Results = []params.ErrorResult{{
@@ -48,7 +49,7 @@ func (s *FirewallRulesSuite) TestSetFirewallRule(c *gc.C) {
 		})
 
 	client := firewallrules.NewClient(apiCaller)
-	err := client.SetFirewallRule("ssh", []string{"192.168.1.0/32"})
+	err := client.SetFirewallRule("ssh", []string{"192.168.1.0/32"}, "office network")
 	c.Assert(err, gc.ErrorMatches, "fail")
 }
 
@@ -66,7 +67,7 @@ func (s *FirewallRulesSuite) TestSetFirewallRuleFacadeCallError(c *gc.C) {
 			return errors.New(msg)
 		})
 	client := firewallrules.NewClient(apiCaller)
-	err := client.SetFirewallRule("ssh", nil)
+	err := client.SetFirewallRule("ssh", nil, "")
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
 
@@ -82,7 +83,7 @@ func (s *FirewallRulesSuite) TestSetFirewallRuleInvalid(c *gc.C) {
 		})
 
 	client := firewallrules.NewClient(apiCaller)
-	err := client.SetFirewallRule("foo", []string{"192.168.1.0/32"})
+	err := client.SetFirewallRule("foo", []string{"192.168.1.0/32"}, "")
 	c.Assert(err, gc.ErrorMatches, `known service "foo" not valid`)
 }
 
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostsupdater implements the client-side API facade used
+// by the hostsupdater worker.
+package hostsupdater
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the HostsUpdater API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HostsUpdater facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "HostsUpdater"),
+	}
+}
+
+// WatchHostEntries returns a NotifyWatcher that triggers when the
+// host entries of the machine may have changed.
+func (f *Facade) WatchHostEntries() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := f.caller.FacadeCall("WatchHostEntries", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// HostEntries returns the host entries that should resolve on the
+// machine with the given tag.
+func (f *Facade) HostEntries(machine names.MachineTag) ([]params.HostEntry, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.HostEntriesResults
+	err := f.caller.FacadeCall("HostEntries", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Entries, nil
+}
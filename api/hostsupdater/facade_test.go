@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostsupdater_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hostsupdater"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestHostEntries(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HostsUpdater")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.HostEntriesResults) = params.HostEntriesResults{
+			Results: []params.HostEntriesResult{{
+				Entries: []params.HostEntry{{
+					Address: "10.0.0.2",
+					Names:   []string{"juju-abcdef-1-lxd-0"},
+				}},
+			}},
+		}
+		return nil
+	})
+	facade := hostsupdater.NewFacade(apiCaller)
+
+	entries, err := facade.HostEntries(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.HostEntry{{
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-1-lxd-0"},
+	}})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"HostEntries", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestHostEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.HostEntriesResults) = params.HostEntriesResults{
+			Results: []params.HostEntriesResult{{
+				Error: &params.Error{Message: "permission denied"},
+			}},
+		}
+		return nil
+	})
+	facade := hostsupdater.NewFacade(apiCaller)
+
+	_, err := facade.HostEntries(names.NewMachineTag("1"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestWatchHostEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "WatchHostEntries")
+		*response.(*params.NotifyWatchResult) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "permission denied"},
+		}
+		return nil
+	})
+	facade := hostsupdater.NewFacade(apiCaller)
+
+	_, err := facade.WatchHostEntries()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
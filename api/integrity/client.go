@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package integrity provides access to the Integrity API facade,
+// which finds and repairs dangling references in a controller's
+// database.
+package integrity
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Integrity API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Integrity API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Integrity")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Check returns the dangling references found in the given models, or
+// in all of the controller's models if none are given. If repair is
+// true, the problems that can be repaired automatically are.
+func (c *Client) Check(models []names.ModelTag, repair bool) ([]params.IntegrityCheckResult, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("checking controller integrity")
+	}
+	args := params.IntegrityCheckArgs{Repair: repair}
+	for _, tag := range models {
+		args.Models = append(args.Models, params.Entity{Tag: tag.String()})
+	}
+	var results params.IntegrityCheckResults
+	if err := c.facade.FacadeCall("Check", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(models) > 0 && len(results.Results) != len(models) {
+		return nil, errors.Errorf("expected %d results, got %d", len(models), len(results.Results))
+	}
+	return results.Results, nil
+}
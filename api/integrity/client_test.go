@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package integrity_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/integrity"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type integritySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&integritySuite{})
+
+func (s *integritySuite) TestCheck(c *gc.C) {
+	modelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	expected := []params.IntegrityCheckResult{{
+		ModelTag: modelTag.String(),
+		Problems: []params.IntegrityProblem{{
+			Kind:       "leaked-settings",
+			Collection: "settings",
+			Id:         "r#1#peer#foo/0",
+			Repairable: true,
+		}},
+		Repaired: 1,
+	}}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Integrity")
+			c.Check(version, gc.Equals, 1)
+			c.Check(request, gc.Equals, "Check")
+			c.Check(arg, jc.DeepEquals, params.IntegrityCheckArgs{
+				Models: []params.Entity{{Tag: modelTag.String()}},
+				Repair: true,
+			})
+			*(result.(*params.IntegrityCheckResults)) = params.IntegrityCheckResults{Results: expected}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	results, err := integrity.NewClient(apiCaller).Check([]names.ModelTag{modelTag}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *integritySuite) TestCheckNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+	}
+	_, err := integrity.NewClient(apiCaller).Check(nil, false)
+	c.Assert(err, gc.ErrorMatches, "checking controller integrity not supported")
+}
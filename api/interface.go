@@ -82,6 +82,11 @@ type Info struct {
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
+
+	// Token holds an API token with which to log in, in place of
+	// a password or macaroons. The token identifies the user, so
+	// Tag need not be set.
+	Token string `yaml:",omitempty"`
 }
 
 // Ports returns the unique ports for the api addresses.
@@ -116,6 +121,9 @@ func (info *Info) Validate() error {
 		if len(info.Macaroons) > 0 {
 			return errors.NotValidf("specifying Macaroons and SkipLogin")
 		}
+		if info.Token != "" {
+			return errors.NotValidf("specifying Token and SkipLogin")
+		}
 	}
 	return nil
 }
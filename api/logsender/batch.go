@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsender
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/version"
+)
+
+// compressThreshold is the size of the JSON encoding of a batch of
+// log records above which the batch is sent compressed.
+const compressThreshold = 1024
+
+// BatchLogWriter is the interface that allows sending batches of log
+// messages to the server for storage. Unlike LogWriter, the server
+// acknowledges each batch once it has been stored.
+type BatchLogWriter interface {
+	// WriteLogs writes the given log records, and waits for the
+	// server to store them.
+	WriteLogs([]*params.LogRecord) error
+
+	io.Closer
+}
+
+// BatchLogWriter returns a new batch log writer interface value
+// which must be closed when finished with. If the server does not
+// support batched log records, an error satisfying
+// errors.IsNotSupported is returned, and LogWriter should be used
+// instead.
+func (api *API) BatchLogWriter() (BatchLogWriter, error) {
+	attrs := make(url.Values)
+	attrs.Set("jujuclientversion", version.Current.String())
+	// Version 2 adds batching, compression and acknowledgements.
+	attrs.Set("version", "2")
+	conn, err := api.connector.ConnectStream("/logsink", attrs)
+	if err != nil {
+		// Older servers reject versions they don't know.
+		if strings.Contains(err.Error(), `unknown version "2"`) {
+			return nil, errors.NotSupportedf("batched log records")
+		}
+		return nil, errors.Annotatef(err, "cannot connect to /logsink")
+	}
+	w := &batchWriter{
+		conn: conn,
+		acks: make(chan params.LogRecordBatchAck),
+	}
+	go w.readLoop()
+	return w, nil
+}
+
+type batchWriter struct {
+	conn base.Stream
+	seq  uint64
+	acks chan params.LogRecordBatchAck
+}
+
+// readLoop reads the server's acknowledgements, which also processes
+// websocket control messages. Close() is safe to call concurrently.
+func (w *batchWriter) readLoop() {
+	defer close(w.acks)
+	for {
+		var ack params.LogRecordBatchAck
+		if err := w.conn.ReadJSON(&ack); err != nil {
+			w.conn.Close()
+			return
+		}
+		w.acks <- ack
+	}
+}
+
+// WriteLogs is part of the BatchLogWriter interface.
+func (w *batchWriter) WriteLogs(ms []*params.LogRecord) error {
+	records := make([]params.LogRecord, len(ms))
+	for i, m := range ms {
+		records[i] = *m
+	}
+	w.seq++
+	batch, err := newLogRecordBatch(w.seq, records)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.conn.WriteJSON(batch); err != nil {
+		return errors.Annotatef(err, "cannot send log messages")
+	}
+	ack, ok := <-w.acks
+	if !ok {
+		return errors.New("connection closed before log messages were acknowledged")
+	}
+	if ack.Seq != batch.Seq {
+		return errors.Errorf("log messages %d acknowledged, expected %d", ack.Seq, batch.Seq)
+	}
+	if ack.Error != nil {
+		return errors.Annotate(ack.Error, "cannot store log messages")
+	}
+	return nil
+}
+
+// Close is part of the BatchLogWriter interface.
+func (w *batchWriter) Close() error {
+	return w.conn.Close()
+}
+
+// newLogRecordBatch returns a batch holding the given records,
+// compressing them if that is likely to be worthwhile.
+func newLogRecordBatch(seq uint64, records []params.LogRecord) (*params.LogRecordBatch, error) {
+	batch := &params.LogRecordBatch{Seq: seq}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot encode log messages")
+	}
+	if len(data) <= compressThreshold {
+		batch.Records = records
+		return batch, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, errors.Annotate(err, "cannot compress log messages")
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot compress log messages")
+	}
+	batch.Compressed = buf.Bytes()
+	return batch, nil
+}
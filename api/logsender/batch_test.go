@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsender_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	jujuerrors "github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/logsender"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+)
+
+type BatchLogWriterSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&BatchLogWriterSuite{})
+
+func (s *BatchLogWriterSuite) TestWriteLogs(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	w, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, jc.ErrorIsNil)
+
+	records := []*params.LogRecord{{Message: "one"}, {Message: "two"}}
+	err = w.WriteLogs(records)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.WriteLogs(records[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(conn.written, jc.DeepEquals, []*params.LogRecordBatch{{
+		Seq:     1,
+		Records: []params.LogRecord{{Message: "one"}, {Message: "two"}},
+	}, {
+		Seq:     2,
+		Records: []params.LogRecord{{Message: "one"}},
+	}})
+	c.Assert(w.Close(), jc.ErrorIsNil)
+}
+
+func (s *BatchLogWriterSuite) TestWriteLogsCompressed(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	w, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, jc.ErrorIsNil)
+	defer w.Close()
+
+	var records []*params.LogRecord
+	for i := 0; i < 50; i++ {
+		records = append(records, &params.LogRecord{
+			Module:  "juju.worker.uniter",
+			Message: strings.Repeat("chatty ", 10),
+		})
+	}
+	err = w.WriteLogs(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(conn.written, gc.HasLen, 1)
+	batch := conn.written[0]
+	c.Assert(batch.Records, gc.HasLen, 0)
+	c.Assert(len(batch.Compressed) < 1024, jc.IsTrue)
+
+	r, err := gzip.NewReader(bytes.NewReader(batch.Compressed))
+	c.Assert(err, jc.ErrorIsNil)
+	var decoded []*params.LogRecord
+	err = json.NewDecoder(r).Decode(&decoded)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decoded, jc.DeepEquals, records)
+}
+
+func (s *BatchLogWriterSuite) TestWriteLogsError(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	conn.ackError = &params.Error{Message: "cannae write"}
+	w, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, jc.ErrorIsNil)
+	defer w.Close()
+
+	err = w.WriteLogs([]*params.LogRecord{{Message: "one"}})
+	c.Assert(err, gc.ErrorMatches, "cannot store log messages: cannae write")
+}
+
+func (s *BatchLogWriterSuite) TestWriteLogsConnectionClosed(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	w, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	err = w.WriteLogs([]*params.LogRecord{{Message: "one"}})
+	c.Assert(err, gc.ErrorMatches, "cannot send log messages: connection closed")
+}
+
+func (s *BatchLogWriterSuite) TestNotSupported(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	conn.connectError = &params.Error{Message: `unknown version "2"`}
+	_, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *BatchLogWriterSuite) TestConnectError(c *gc.C) {
+	conn := newMockBatchConnector(c)
+	conn.connectError = errors.New("foo")
+	_, err := logsender.NewAPI(conn).BatchLogWriter()
+	c.Assert(err, gc.ErrorMatches, "cannot connect to /logsink: foo")
+}
+
+// mockBatchConnector provides a stream that acknowledges each batch
+// of log records written to it.
+type mockBatchConnector struct {
+	c *gc.C
+
+	connectError error
+	ackError     *params.Error
+	written      []*params.LogRecordBatch
+	acks         chan params.LogRecordBatchAck
+	closed       chan struct{}
+	closeOnce    sync.Once
+}
+
+func newMockBatchConnector(c *gc.C) *mockBatchConnector {
+	return &mockBatchConnector{
+		c:      c,
+		acks:   make(chan params.LogRecordBatchAck, 1),
+		closed: make(chan struct{}),
+	}
+}
+
+func (m *mockBatchConnector) ConnectStream(path string, values url.Values) (base.Stream, error) {
+	m.c.Assert(path, gc.Equals, "/logsink")
+	m.c.Assert(values, jc.DeepEquals, url.Values{
+		"jujuclientversion": []string{version.Current.String()},
+		"version":           []string{"2"},
+	})
+	if m.connectError != nil {
+		return nil, m.connectError
+	}
+	return m, nil
+}
+
+func (m *mockBatchConnector) WriteJSON(v interface{}) error {
+	select {
+	case <-m.closed:
+		return errors.New("connection closed")
+	default:
+	}
+	batch := v.(*params.LogRecordBatch)
+	m.written = append(m.written, batch)
+	m.acks <- params.LogRecordBatchAck{Seq: batch.Seq, Error: m.ackError}
+	return nil
+}
+
+func (m *mockBatchConnector) ReadJSON(v interface{}) error {
+	select {
+	case ack := <-m.acks:
+		*(v.(*params.LogRecordBatchAck)) = ack
+		return nil
+	case <-m.closed:
+		return io.EOF
+	}
+}
+
+func (m *mockBatchConnector) NextReader() (messageType int, r io.Reader, err error) {
+	m.c.Errorf("NextReader called unexpectedly")
+	return 0, nil, nil
+}
+
+func (m *mockBatchConnector) Close() error {
+	m.closeOnce.Do(func() { close(m.closed) })
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinehealth implements the client-side API facade used
+// by the machinehealth worker.
+package machinehealth
+
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the MachineHealth API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side MachineHealth facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "MachineHealth"),
+	}
+}
+
+// ControllerTime returns the current time according to the controller.
+func (f *Facade) ControllerTime() (time.Time, error) {
+	var result params.ControllerTimeResult
+	if err := f.caller.FacadeCall("ControllerTime", nil, &result); err != nil {
+		return time.Time{}, err
+	}
+	return result.Time, nil
+}
+
+// SetHealthProblems records the health problems of the machine with
+// the given tag. An empty list marks the machine healthy.
+func (f *Facade) SetHealthProblems(machine names.MachineTag, problems []string) error {
+	args := params.SetMachineHealthProblems{Machines: []params.MachineHealthProblems{{
+		Tag:      machine.String(),
+		Problems: problems,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("SetHealthProblems", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinehealth"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestControllerTime(c *gc.C) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MachineHealth")
+		c.Check(request, gc.Equals, "ControllerTime")
+		c.Check(args, gc.IsNil)
+		*response.(*params.ControllerTimeResult) = params.ControllerTimeResult{Time: now}
+		return nil
+	})
+	facade := machinehealth.NewFacade(apiCaller)
+
+	result, err := facade.ControllerTime()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, now)
+}
+
+func (s *facadeSuite) TestSetHealthProblems(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MachineHealth")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				(*params.Error)(nil),
+			}},
+		}
+		return nil
+	})
+	facade := machinehealth.NewFacade(apiCaller)
+
+	err := facade.SetHealthProblems(names.NewMachineTag("0"), []string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetHealthProblems", []interface{}{params.SetMachineHealthProblems{
+			Machines: []params.MachineHealthProblems{{
+				Tag:      "machine-0",
+				Problems: []string{"root disk 95% full"},
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := machinehealth.NewFacade(apiCaller)
+
+	_, err := facade.ControllerTime()
+	c.Assert(err, gc.ErrorMatches, "blam")
+	err = facade.SetHealthProblems(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				&params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := machinehealth.NewFacade(apiCaller)
+
+	err := facade.SetHealthProblems(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
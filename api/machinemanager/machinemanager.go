@@ -135,3 +135,24 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// RevokeAgentTokens prevents the agent of the specified machine, and
+// the agents of the units it hosts, from authenticating with the
+// controller.
+func (client *Client) RevokeAgentTokens(machineName string) error {
+	if client.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("revoking agent tokens")
+	}
+	if !names.IsValidMachine(machineName) {
+		return errors.NotValidf("machine ID %q", machineName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineName).String()}},
+	}
+	results := new(params.ErrorResults)
+	err := client.facade.FacadeCall("RevokeAgentTokens", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -210,3 +210,37 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestRevokeAgentTokens(c *gc.C) {
+	var called bool
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(version, gc.Equals, 5)
+			c.Check(request, gc.Equals, "RevokeAgentTokens")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		BestVersion: 5,
+	})
+	err := client.RevokeAgentTokens("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *MachinemanagerSuite) TestRevokeAgentTokensNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 4,
+	})
+	err := client.RevokeAgentTokens("0")
+	c.Assert(err, gc.ErrorMatches, "revoking agent tokens not supported")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
@@ -89,6 +90,30 @@ func (api *API) GetProviderInterfaceInfo(machine names.MachineTag) ([]network.Pr
 	return infos, nil
 }
 
+// KnownProviderResources returns the provider resources which the
+// model's state accounts for: the instance ids of its machines and
+// the provider ids of its volumes.
+func (api *API) KnownProviderResources() ([]instance.Id, []string, error) {
+	var results params.KnownProviderResourcesResults
+	args := wrapEntities(api.modelTag)
+	err := api.facade.FacadeCall("KnownProviderResources", &args, &results)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, nil, errors.Errorf("expected one result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, errors.Trace(result.Error)
+	}
+	instanceIds := make([]instance.Id, len(result.Instances))
+	for i, id := range result.Instances {
+		instanceIds[i] = instance.Id(id)
+	}
+	return instanceIds, result.Volumes, nil
+}
+
 // CompleteRemoval finishes the removal of the machine in the database
 // after any provider resources are cleaned up.
 func (api *API) CompleteRemoval(machine names.MachineTag) error {
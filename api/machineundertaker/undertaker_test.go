@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/api/machineundertaker"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -219,6 +220,41 @@ func (*undertakerSuite) TestGetInfo_BadMachine(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (*undertakerSuite) TestKnownProviderResources(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachineUndertaker")
+		c.Check(request, gc.Equals, "KnownProviderResources")
+		c.Check(arg, gc.DeepEquals, wrapEntities(coretesting.ModelTag.String()))
+		c.Assert(result, gc.FitsTypeOf, &params.KnownProviderResourcesResults{})
+		*result.(*params.KnownProviderResourcesResults) = params.KnownProviderResourcesResults{
+			Results: []params.KnownProviderResourcesResult{{
+				Instances: []string{"i-0", "i-1"},
+				Volumes:   []string{"vol-0"},
+			}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	instanceIds, volumeIds, err := api.KnownProviderResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceIds, gc.DeepEquals, []instance.Id{"i-0", "i-1"})
+	c.Assert(volumeIds, gc.DeepEquals, []string{"vol-0"})
+}
+
+func (*undertakerSuite) TestKnownProviderResourcesError(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.KnownProviderResourcesResults) = params.KnownProviderResourcesResults{
+			Results: []params.KnownProviderResourcesResult{{
+				Error: common.ServerError(errors.New("permission denied")),
+			}},
+		}
+		return nil
+	}
+	api := makeAPI(c, caller)
+	_, _, err := api.KnownProviderResources()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (*undertakerSuite) TestCompleteRemoval(c *gc.C) {
 	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
 		c.Check(facade, gc.Equals, "MachineUndertaker")
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelarchives provides access to the ModelArchives API
+// facade, which creates and lists the signed model archives held by a
+// controller, and to the HTTP endpoint from which they are downloaded.
+package modelarchives
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const archivesPath = "/model-archives/"
+
+// Client allows access to the ModelArchives API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelArchives API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelArchives")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Create archives the model with the given UUID, signing the archive
+// with the given key, and returns the archive stored in the controller.
+func (c *Client) Create(modelUUID string, signingKey []byte) (params.ModelArchive, error) {
+	if c.BestAPIVersion() < 1 {
+		return params.ModelArchive{}, errors.NotSupportedf("model archives")
+	}
+	args := params.CreateModelArchivesArgs{
+		Archives: []params.CreateModelArchiveArg{{
+			ModelTag:   names.NewModelTag(modelUUID).String(),
+			SigningKey: signingKey,
+		}},
+	}
+	var results params.ModelArchiveResults
+	if err := c.facade.FacadeCall("Create", args, &results); err != nil {
+		return params.ModelArchive{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ModelArchive{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ModelArchive{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// List returns the archives of the model with the given UUID held by
+// the controller, or of all models if the UUID is empty.
+func (c *Client) List(modelUUID string) ([]params.ModelArchive, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("model archives")
+	}
+	var filter params.ModelArchivesFilter
+	if modelUUID != "" {
+		filter.ModelTag = names.NewModelTag(modelUUID).String()
+	}
+	var result params.ModelArchivesResult
+	if err := c.facade.FacadeCall("List", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Archives, nil
+}
+
+// Download returns the content of the archive with the given ID. The
+// caller must close the returned reader.
+func (c *Client) Download(id string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", archivesPath+id, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create GET request")
+	}
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	var resp *http.Response
+	if err := httpClient.Do(req, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot download model archive %q", id)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchives_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelarchives"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelArchivesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelArchivesSuite{})
+
+var archive = params.ModelArchive{
+	ID:        "archive-id",
+	ModelTag:  coretesting.ModelTag.String(),
+	ModelName: "prod",
+	Size:      1234,
+	SHA256:    "abcd",
+	Created:   time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func newAPICaller(c *gc.C, request string, check func(arg, result interface{})) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, req string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelArchives")
+			c.Check(version, gc.Equals, 1)
+			c.Check(req, gc.Equals, request)
+			check(arg, result)
+			return nil
+		},
+		BestVersion: 1,
+	}
+}
+
+func (s *modelArchivesSuite) TestCreate(c *gc.C) {
+	apiCaller := newAPICaller(c, "Create", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.CreateModelArchivesArgs{
+			Archives: []params.CreateModelArchiveArg{{
+				ModelTag:   coretesting.ModelTag.String(),
+				SigningKey: []byte("key"),
+			}},
+		})
+		*(result.(*params.ModelArchiveResults)) = params.ModelArchiveResults{
+			Results: []params.ModelArchiveResult{{Result: &archive}},
+		}
+	})
+	result, err := modelarchives.NewClient(apiCaller).Create(coretesting.ModelTag.Id(), []byte("key"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, archive)
+}
+
+func (s *modelArchivesSuite) TestCreateError(c *gc.C) {
+	apiCaller := newAPICaller(c, "Create", func(arg, result interface{}) {
+		*(result.(*params.ModelArchiveResults)) = params.ModelArchiveResults{
+			Results: []params.ModelArchiveResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+	})
+	_, err := modelarchives.NewClient(apiCaller).Create(coretesting.ModelTag.Id(), []byte("key"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelArchivesSuite) TestList(c *gc.C) {
+	apiCaller := newAPICaller(c, "List", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.ModelArchivesFilter{
+			ModelTag: coretesting.ModelTag.String(),
+		})
+		*(result.(*params.ModelArchivesResult)) = params.ModelArchivesResult{
+			Archives: []params.ModelArchive{archive},
+		}
+	})
+	result, err := modelarchives.NewClient(apiCaller).List(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.ModelArchive{archive})
+}
+
+func (s *modelArchivesSuite) TestListAll(c *gc.C) {
+	apiCaller := newAPICaller(c, "List", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.ModelArchivesFilter{})
+	})
+	_, err := modelarchives.NewClient(apiCaller).List("")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelArchivesSuite) TestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, req string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	}
+	client := modelarchives.NewClient(apiCaller)
+	_, err := client.List("")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.Create(coretesting.ModelTag.Id(), []byte("key"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelArchivesSuite) TestDownload(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "GET")
+		c.Check(req.URL.Path, gc.Equals, "/model-archives/archive-id")
+		w.Header().Set("Content-Type", params.ContentTypeRaw)
+		w.Write([]byte("archived model"))
+	}))
+	defer server.Close()
+
+	client := modelarchives.NewClient(httpAPICaller{
+		BestVersionCaller: basetesting.BestVersionCaller{BestVersion: 1},
+		url:               server.URL,
+	})
+	r, err := client.Download("archive-id")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archived model")
+}
+
+// httpAPICaller is an APICaller whose HTTP client sends requests to
+// the given URL.
+type httpAPICaller struct {
+	basetesting.BestVersionCaller
+	url string
+}
+
+func (ac httpAPICaller) HTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{BaseURL: ac.url}, nil
+}
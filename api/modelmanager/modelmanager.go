@@ -39,16 +39,22 @@ func NewClient(st base.APICallCloser) *Client {
 }
 
 // CreateModel creates a new model using the model config,
-// cloud region and credential specified in the args.
+// cloud region and credential specified in the args. If database
+// is not empty, the model's logs and non-transactional collections
+// are placed in the named mongo database.
 func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+	database string,
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
 		return result, errors.Errorf("invalid owner name %q", owner)
 	}
+	if database != "" && c.BestAPIVersion() < 5 {
+		return result, errors.NotSupportedf("selecting a model database")
+	}
 	var cloudTag string
 	if cloud != "" {
 		if !names.IsValidCloud(cloud) {
@@ -67,6 +73,7 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Database:           database,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
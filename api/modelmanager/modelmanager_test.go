@@ -29,13 +29,13 @@ var _ = gc.Suite(&modelmanagerSuite{})
 
 func (s *modelmanagerSuite) TestCreateModelBadUser(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
-	_, err := client.CreateModel("mymodel", "not a user", "", "", names.CloudCredentialTag{}, nil)
+	_, err := client.CreateModel("mymodel", "not a user", "", "", names.CloudCredentialTag{}, nil, "")
 	c.Assert(err, gc.ErrorMatches, `invalid owner name "not a user"`)
 }
 
 func (s *modelmanagerSuite) TestCreateModelBadCloud(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
-	_, err := client.CreateModel("mymodel", "bob", "123!", "", names.CloudCredentialTag{}, nil)
+	_, err := client.CreateModel("mymodel", "bob", "123!", "", names.CloudCredentialTag{}, nil, "")
 	c.Assert(err, gc.ErrorMatches, `invalid cloud name "123!"`)
 }
 
@@ -74,6 +74,7 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 		"catbus",
 		names.CloudCredentialTag{},
 		map[string]interface{}{"abc": 123},
+		"",
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -95,6 +96,31 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	})
 }
 
+func (s *modelmanagerSuite) TestCreateModelDatabase(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(arg.(params.ModelCreateArgs).Database, gc.Equals, "juju-heavy")
+			*(result.(*params.ModelInfo)) = params.ModelInfo{
+				Name:     "new-model",
+				CloudTag: "cloud-nimbus",
+				OwnerTag: "user-bob",
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CreateModel("new-model", "bob", "", "", names.CloudCredentialTag{}, nil, "juju-heavy")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelmanagerSuite) TestCreateModelDatabaseNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.CreateModel("new-model", "bob", "", "", names.CloudCredentialTag{}, nil, "juju-heavy")
+	c.Assert(err, gc.ErrorMatches, "selecting a model database not supported")
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
 	_, err := client.ListModels("not a user")
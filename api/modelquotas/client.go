@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelquotas provides access to the ModelQuotas API facade,
+// through which controller administrators limit the resources models
+// may use.
+package modelquotas
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ModelQuotas API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelQuotas API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelQuotas")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelQuotas returns the quotas of the given model, along with the
+// resources it currently uses.
+func (c *Client) ModelQuotas(model names.ModelTag) (params.ModelQuotas, params.ModelQuotaUsage, error) {
+	if c.BestAPIVersion() < 1 {
+		return params.ModelQuotas{}, params.ModelQuotaUsage{}, errors.NotSupportedf("model quotas")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: model.String()}}}
+	var results params.ModelQuotasResults
+	if err := c.facade.FacadeCall("ModelQuotas", args, &results); err != nil {
+		return params.ModelQuotas{}, params.ModelQuotaUsage{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelQuotas{}, params.ModelQuotaUsage{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelQuotas{}, params.ModelQuotaUsage{}, errors.Trace(result.Error)
+	}
+	return result.Quotas, result.Usage, nil
+}
+
+// SetModelQuotas replaces the quotas of the given model. A zero limit
+// means that the resource is not limited.
+func (c *Client) SetModelQuotas(model names.ModelTag, quotas params.ModelQuotas) error {
+	if c.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("model quotas")
+	}
+	args := params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotasArg{{
+			ModelTag: model.String(),
+			Quotas:   quotas,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetModelQuotas", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelquotas_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelquotas"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelQuotasSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelQuotasSuite{})
+
+func newAPICaller(c *gc.C, request string, check func(arg, result interface{})) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, req string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelQuotas")
+			c.Check(version, gc.Equals, 1)
+			c.Check(req, gc.Equals, request)
+			check(arg, result)
+			return nil
+		},
+		BestVersion: 1,
+	}
+}
+
+func (s *modelQuotasSuite) TestModelQuotas(c *gc.C) {
+	apiCaller := newAPICaller(c, "ModelQuotas", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+		})
+		*(result.(*params.ModelQuotasResults)) = params.ModelQuotasResults{
+			Results: []params.ModelQuotasResult{{
+				Quotas: params.ModelQuotas{MaxUnits: 10},
+				Usage:  params.ModelQuotaUsage{Machines: 2, Units: 4},
+			}},
+		}
+	})
+	quotas, usage, err := modelquotas.NewClient(apiCaller).ModelQuotas(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, params.ModelQuotas{MaxUnits: 10})
+	c.Assert(usage, jc.DeepEquals, params.ModelQuotaUsage{Machines: 2, Units: 4})
+}
+
+func (s *modelQuotasSuite) TestModelQuotasError(c *gc.C) {
+	apiCaller := newAPICaller(c, "ModelQuotas", func(arg, result interface{}) {
+		*(result.(*params.ModelQuotasResults)) = params.ModelQuotasResults{
+			Results: []params.ModelQuotasResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+	})
+	_, _, err := modelquotas.NewClient(apiCaller).ModelQuotas(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelQuotasSuite) TestSetModelQuotas(c *gc.C) {
+	quotas := params.ModelQuotas{MaxMachines: 5, MaxStorageGB: 200}
+	apiCaller := newAPICaller(c, "SetModelQuotas", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.SetModelQuotasArgs{
+			Args: []params.SetModelQuotasArg{{
+				ModelTag: coretesting.ModelTag.String(),
+				Quotas:   quotas,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+	})
+	err := modelquotas.NewClient(apiCaller).SetModelQuotas(coretesting.ModelTag, quotas)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelQuotasSuite) TestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 0,
+	}
+	_, _, err := modelquotas.NewClient(apiCaller).ModelQuotas(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "model quotas not supported")
+}
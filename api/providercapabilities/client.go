@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package providercapabilities provides access to the
+// ProviderCapabilities API facade, which reports the optional
+// features supported by a model's cloud provider.
+package providercapabilities
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ProviderCapabilities API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the provider
+// capabilities api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ProviderCapabilities")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ProviderCapabilities returns the provider type of the model, and
+// the optional features that the provider supports.
+func (c *Client) ProviderCapabilities() (params.ProviderCapabilitiesResult, error) {
+	var result params.ProviderCapabilitiesResult
+	if err := c.facade.FacadeCall("ProviderCapabilities", nil, &result); err != nil {
+		return params.ProviderCapabilitiesResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// CheckCapabilities returns an error satisfying errors.IsNotSupported
+// if the model's provider does not support all of the given
+// capabilities. Controllers that predate the ProviderCapabilities
+// facade cannot report capabilities, so nothing is checked.
+func (c *Client) CheckCapabilities(capabilities ...string) error {
+	if len(capabilities) == 0 || c.BestAPIVersion() < 1 {
+		return nil
+	}
+	result, err := c.ProviderCapabilities()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	supported := make(map[string]bool)
+	for _, capability := range result.Capabilities {
+		supported[capability] = true
+	}
+	for _, capability := range capabilities {
+		if !supported[capability] {
+			return errors.NewNotSupported(nil, fmt.Sprintf(
+				"provider %q does not support %s", result.ProviderType, capability,
+			))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package providercapabilities_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/providercapabilities"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ProviderCapabilitiesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ProviderCapabilitiesSuite{})
+
+func (s *ProviderCapabilitiesSuite) apiCaller(c *gc.C, bestVersion int, calls *int) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			*calls++
+			c.Check(objType, gc.Equals, "ProviderCapabilities")
+			c.Check(request, gc.Equals, "ProviderCapabilities")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ProviderCapabilitiesResult)) = params.ProviderCapabilitiesResult{
+				ProviderType: "lxd",
+				Capabilities: []string{"availability-zones"},
+			}
+			return nil
+		},
+		BestVersion: bestVersion,
+	}
+}
+
+func (s *ProviderCapabilitiesSuite) TestProviderCapabilities(c *gc.C) {
+	var calls int
+	client := providercapabilities.NewClient(s.apiCaller(c, 1, &calls))
+	result, err := client.ProviderCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProviderCapabilitiesResult{
+		ProviderType: "lxd",
+		Capabilities: []string{"availability-zones"},
+	})
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *ProviderCapabilitiesSuite) TestCheckCapabilities(c *gc.C) {
+	var calls int
+	client := providercapabilities.NewClient(s.apiCaller(c, 1, &calls))
+	err := client.CheckCapabilities("availability-zones")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ProviderCapabilitiesSuite) TestCheckCapabilitiesNotSupported(c *gc.C) {
+	var calls int
+	client := providercapabilities.NewClient(s.apiCaller(c, 1, &calls))
+	err := client.CheckCapabilities("availability-zones", "spaces")
+	c.Assert(err, gc.ErrorMatches, `provider "lxd" does not support spaces`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ProviderCapabilitiesSuite) TestCheckCapabilitiesNone(c *gc.C) {
+	var calls int
+	client := providercapabilities.NewClient(s.apiCaller(c, 1, &calls))
+	err := client.CheckCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 0)
+}
+
+func (s *ProviderCapabilitiesSuite) TestCheckCapabilitiesOlderController(c *gc.C) {
+	var calls int
+	client := providercapabilities.NewClient(s.apiCaller(c, 0, &calls))
+	err := client.CheckCapabilities("spaces")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 0)
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/watcher"
+)
+
+// WatchCharmProfiles returns a NotifyWatcher that notifies when the
+// charm LXD profiles that machines need may have changed.
+func (st *State) WatchCharmProfiles() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("charm profiles")
+	}
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchCharmProfiles", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
+
+// CharmProfiles returns the names of the charm LXD profiles applied to
+// the machine's instance, and the profiles, keyed by name, that it
+// should have.
+func (m *Machine) CharmProfiles() ([]string, map[string]*lxdprofile.Profile, error) {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return nil, nil, errors.NotSupportedf("charm profiles")
+	}
+	var results params.CharmProfilesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("CharmProfiles", args, &results)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	return result.Current, CharmLXDProfiles(result.Profiles), nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to the machine's instance.
+func (m *Machine) SetCharmProfiles(names []string) error {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("charm profiles")
+	}
+	var results params.ErrorResults
+	args := params.SetCharmProfiles{
+		Machines: []params.MachineCharmProfiles{{
+			Tag:      m.tag.String(),
+			Profiles: names,
+		}},
+	}
+	err := m.st.facade.FacadeCall("SetCharmProfiles", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CharmLXDProfiles converts charm LXD profiles from their wire format.
+func CharmLXDProfiles(in map[string]params.CharmLXDProfile) map[string]*lxdprofile.Profile {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]*lxdprofile.Profile)
+	for name, profile := range in {
+		out[name] = &lxdprofile.Profile{
+			Config:      profile.Config,
+			Description: profile.Description,
+			Devices:     profile.Devices,
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	apibasetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+func (s *provisionerSuite) addUnitWithProfile(c *gc.C, profile *lxdprofile.Profile) {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("dummy"),
+		ID:          charm.MustParseURL("local:quantal/dummy-1"),
+		StoragePath: "dummy-1",
+		SHA256:      "dummy-1-sha256",
+		LXDProfile:  profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "lxd-profile", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestCharmProfiles(c *gc.C) {
+	profile := &lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+	}
+	s.addUnitWithProfile(c, profile)
+	apiMachine := s.assertGetOneMachine(c, s.machine.MachineTag())
+
+	current, profiles, err := apiMachine.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current, gc.HasLen, 0)
+	c.Assert(profiles, jc.DeepEquals, map[string]*lxdprofile.Profile{
+		"juju-controller-lxd-profile-1": profile,
+	})
+
+	err = apiMachine.SetCharmProfiles([]string{"juju-controller-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	current, _, err = apiMachine.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current, jc.DeepEquals, []string{"juju-controller-lxd-profile-1"})
+}
+
+func (s *provisionerSuite) TestWatchCharmProfiles(c *gc.C) {
+	w, err := s.provisioner.WatchCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	s.addUnitWithProfile(c, nil)
+	wc.AssertOneChange()
+}
+
+func (s *provisionerSuite) TestWatchCharmProfilesNotSupported(c *gc.C) {
+	apiCaller := apibasetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 5,
+	}
+	st := provisioner.NewState(apiCaller)
+	_, err := st.WatchCharmProfiles()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -47,6 +47,13 @@ type DistributionGroupResult struct {
 	Err        *params.Error
 }
 
+// ProvisioningInfoResult holds the information needed to provision
+// a machine, or any Error related to finding it.
+type ProvisioningInfoResult struct {
+	Info *params.ProvisioningInfo
+	Err  *params.Error
+}
+
 const provisionerFacade = "Provisioner"
 
 // NewState creates a new client-side Machiner facade.
@@ -341,3 +348,25 @@ func (st *State) DistributionGroupByMachineId(tags ...names.MachineTag) ([]Distr
 	}
 	return results, nil
 }
+
+// ProvisioningInfo returns the information needed to provision each
+// of the given machines, in a single call.
+func (st *State) ProvisioningInfo(tags ...names.MachineTag) ([]ProvisioningInfoResult, error) {
+	var infoResults params.ProvisioningInfoResults
+	entities := make([]params.Entity, len(tags))
+	for i, t := range tags {
+		entities[i] = params.Entity{Tag: t.String()}
+	}
+	err := st.facade.FacadeCall("ProvisioningInfo", params.Entities{Entities: entities}, &infoResults)
+	if err != nil {
+		return nil, err
+	}
+	if len(infoResults.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(infoResults.Results))
+	}
+	results := make([]ProvisioningInfoResult, len(tags))
+	for i, infoResult := range infoResults.Results {
+		results[i] = ProvisioningInfoResult{Info: infoResult.Result, Err: infoResult.Error}
+	}
+	return results, nil
+}
@@ -550,6 +550,24 @@ func (s *provisionerSuite) TestProvisioningInfoMachineNotFound(c *gc.C) {
 	// auth tests in apiserver
 }
 
+func (s *provisionerSuite) TestBulkProvisioningInfo(c *gc.C) {
+	template := state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "valid",
+	}
+	machine, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.provisioner.ProvisioningInfo(machine.MachineTag(), names.NewMachineTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Err, gc.IsNil)
+	c.Assert(results[0].Info.Series, gc.Equals, template.Series)
+	c.Assert(results[0].Info.Placement, gc.Equals, template.Placement)
+	c.Assert(results[1].Info, gc.IsNil)
+	c.Assert(results[1].Err, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func (s *provisionerSuite) TestWatchContainers(c *gc.C) {
 	apiMachine := s.assertGetOneMachine(c, s.machine.MachineTag())
 
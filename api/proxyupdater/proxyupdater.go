@@ -87,6 +87,9 @@ func (api *API) ProxyConfig() (proxySettings, APTProxySettings proxy.Settings, e
 		return proxySettings, APTProxySettings, errors.NotFoundf("ProxyConfig for %q", api.tag)
 	}
 	result := results.Results[0]
+	if result.Error != nil {
+		return proxySettings, APTProxySettings, result.Error
+	}
 	proxySettings = proxySettingsParamToProxySettings(result.ProxySettings)
 	APTProxySettings = proxySettingsParamToProxySettings(result.APTProxySettings)
 	return proxySettings, APTProxySettings, nil
@@ -117,3 +117,19 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 		NoProxy: "NoProxy-apt",
 	})
 }
+
+func (s *ProxyUpdaterSuite) TestProxyConfigError(c *gc.C) {
+	called, api := newAPI(c, apitesting.APICall{
+		Facade: "ProxyUpdater",
+		Method: "ProxyConfig",
+		Results: params.ProxyConfigResults{
+			Results: []params.ProxyConfigResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		},
+	})
+
+	_, _, err := api.ProxyConfig()
+	c.Assert(*called, gc.Equals, 1)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RebootApplication holds the pending reboot requests of the units of
+// an application.
+type RebootApplication struct {
+	// Application identifies the application.
+	Application names.ApplicationTag
+
+	// UnitsReady reports whether all of the application's units have
+	// an active workload and an idle agent.
+	UnitsReady bool
+
+	// Requests holds the application's reboot requests, oldest first.
+	Requests []RebootRequest
+}
+
+// RebootRequest describes a unit's request for its machine to be
+// rebooted.
+type RebootRequest struct {
+	// Unit identifies the unit that requested the reboot.
+	Unit names.UnitTag
+
+	// Granted reports whether the reboot has been granted.
+	Granted bool
+
+	// Rebooting reports whether the unit's machine has yet to
+	// reboot.
+	Rebooting bool
+
+	// Ready reports whether the unit has an active workload and an
+	// idle agent.
+	Ready bool
+}
+
+// API makes calls to the RebootCoordinator facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "RebootCoordinator"),
+	}
+}
+
+// RebootApplications returns the pending reboot requests of the
+// model's units, grouped by application.
+func (api *API) RebootApplications() ([]RebootApplication, error) {
+	var result params.RebootApplicationsResult
+	if err := api.caller.FacadeCall("RebootApplications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make([]RebootApplication, len(result.Applications))
+	for i, app := range result.Applications {
+		appTag, err := names.ParseApplicationTag(app.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		requests := make([]RebootRequest, len(app.Requests))
+		for j, req := range app.Requests {
+			unitTag, err := names.ParseUnitTag(req.UnitTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			requests[j] = RebootRequest{
+				Unit:      unitTag,
+				Granted:   req.Granted,
+				Rebooting: req.Rebooting,
+				Ready:     req.Ready,
+			}
+		}
+		apps[i] = RebootApplication{
+			Application: appTag,
+			UnitsReady:  app.UnitsReady,
+			Requests:    requests,
+		}
+	}
+	return apps, nil
+}
+
+// GrantReboot grants the reboot requested by the given unit.
+func (api *API) GrantReboot(unit names.UnitTag) error {
+	return api.unitCall("GrantReboots", unit)
+}
+
+// CompleteReboot records that the granted reboot of the given unit has
+// completed.
+func (api *API) CompleteReboot(unit names.UnitTag) error {
+	return api.unitCall("CompleteReboots", unit)
+}
+
+func (api *API) unitCall(method string, unit names.UnitTag) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: unit.String()}},
+	}
+	var result params.ErrorResults
+	if err := api.caller.FacadeCall(method, args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
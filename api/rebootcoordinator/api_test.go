@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/rebootcoordinator"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestRebootApplications(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RebootCoordinator")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RebootApplications")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.RebootApplicationsResult{})
+		*(result.(*params.RebootApplicationsResult)) = params.RebootApplicationsResult{
+			Applications: []params.RebootApplication{{
+				ApplicationTag: "application-mysql",
+				UnitsReady:     true,
+				Requests: []params.RebootRequest{{
+					UnitTag:   "unit-mysql-0",
+					Granted:   true,
+					Rebooting: true,
+				}},
+			}},
+		}
+		return nil
+	})
+	api := rebootcoordinator.NewAPI(caller)
+
+	apps, err := api.RebootApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(apps, jc.DeepEquals, []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{{
+			Unit:      names.NewUnitTag("mysql/0"),
+			Granted:   true,
+			Rebooting: true,
+		}},
+	}})
+}
+
+func (s *APISuite) TestRebootApplicationsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		return errors.New("blammo")
+	})
+	api := rebootcoordinator.NewAPI(caller)
+
+	_, err := api.RebootApplications()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *APISuite) TestGrantReboot(c *gc.C) {
+	s.testUnitCall(c, "GrantReboots", (*rebootcoordinator.API).GrantReboot)
+}
+
+func (s *APISuite) TestCompleteReboot(c *gc.C) {
+	s.testUnitCall(c, "CompleteReboots", (*rebootcoordinator.API).CompleteReboot)
+}
+
+func (s *APISuite) testUnitCall(c *gc.C, method string, call func(*rebootcoordinator.API, names.UnitTag) error) {
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "RebootCoordinator")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "nope"},
+			}},
+		}
+		return nil
+	})
+	api := rebootcoordinator.NewAPI(caller)
+
+	err := call(api, names.NewUnitTag("mysql/0"))
+	c.Check(err, gc.ErrorMatches, "nope")
+}
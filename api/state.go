@@ -36,7 +36,6 @@ import (
 // This method is usually called automatically by Open. The machine nonce
 // should be empty unless logging in as a machine agent.
 func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaroon.Slice) error {
-	var result params.LoginResult
 	request := &params.LoginRequest{
 		AuthTag:     tagToString(tag),
 		Credentials: password,
@@ -57,6 +56,20 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 			httpbakery.MacaroonsForURL(st.bakeryClient.Client.Jar, st.cookieURL)...,
 		)
 	}
+	return st.login(tag, request)
+}
+
+// loginWithToken authenticates with the given API token. Subsequent
+// requests on the state will act as the user on whose behalf the
+// token was added, limited to the token's capabilities.
+func (st *state) loginWithToken(token string) error {
+	return st.login(nil, &params.LoginRequest{Token: token})
+}
+
+// login makes the given login request. The tag identifies the entity
+// logging in if the server does not report one.
+func (st *state) login(tag names.Tag, request *params.LoginRequest) error {
+	var result params.LoginResult
 	err := st.APICall("Admin", 3, "", "Login", request, &result)
 	if err != nil {
 		var resp params.RedirectInfoResult
@@ -27,9 +27,22 @@ func NewFacade(caller base.APICaller) *Facade {
 
 // Prune calls "StatusHistory.Prune"
 func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
+	_, err := s.PruneWithResult(maxHistoryTime, maxHistoryMB)
+	return err
+}
+
+// PruneWithResult calls "StatusHistory.Prune", and returns the number of
+// entries removed and the space reclaimed. Controllers that do not report
+// these return a zero result.
+func (s *Facade) PruneWithResult(maxHistoryTime time.Duration, maxHistoryMB int) (params.StatusHistoryPruneResult, error) {
 	p := params.StatusHistoryPruneArgs{
 		MaxHistoryTime: maxHistoryTime,
 		MaxHistoryMB:   maxHistoryMB,
 	}
-	return s.facade.FacadeCall("Prune", p, nil)
+	var result params.StatusHistoryPruneResult
+	if s.facade.BestAPIVersion() < 3 {
+		return result, s.facade.FacadeCall("Prune", p, nil)
+	}
+	err := s.facade.FacadeCall("Prune", p, &result)
+	return result, err
 }
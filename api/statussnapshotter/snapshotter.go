@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshotter
+
+import (
+	"time"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "StatusSnapshotter"
+
+// Facade allows calls to "StatusSnapshotter" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewFacade returns a "StatusSnapshotter" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Facade{facade: facadeCaller, ModelWatcher: common.NewModelWatcher(facadeCaller)}
+}
+
+// Snapshot calls "StatusSnapshotter.Snapshot", which records a snapshot
+// of the model's status and removes the snapshots older than maxAge, or
+// beyond the maxSizeMB budget.
+func (s *Facade) Snapshot(maxAge time.Duration, maxSizeMB int) error {
+	p := params.StatusSnapshotArgs{
+		MaxAge:    maxAge,
+		MaxSizeMB: maxSizeMB,
+	}
+	return s.facade.FacadeCall("Snapshot", p, nil)
+}
+
+// Prune implements pruner.Facade by taking a snapshot, so that
+// snapshots are taken and pruned on the pruner's schedule.
+func (s *Facade) Prune(maxAge time.Duration, maxSizeMB int) error {
+	return s.Snapshot(maxAge, maxSizeMB)
+}
@@ -90,6 +90,41 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool replaces the configuration of the named pool. If provider
+// is empty, the pool's existing provider is kept.
+func (c *Client) UpdatePool(pname, provider string, attrs map[string]interface{}) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("updating storage pools on this juju controller")
+	}
+	args := params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:     pname,
+			Provider: provider,
+			Attrs:    attrs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdatePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemovePool removes the named pool.
+func (c *Client) RemovePool(pname string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("removing storage pools on this juju controller")
+	}
+	args := params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: pname}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemovePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -287,3 +322,25 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// Resize grows the storage instance with the specified ID to
+// the given size, in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("resizing storage on this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	var results params.ErrorResults
+	args := params.BulkResizeStorageParams{
+		[]params.ResizeStorageParams{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
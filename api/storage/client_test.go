@@ -847,3 +847,124 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+					StorageTag: "storage-data-0",
+					Size:       2048,
+				}}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("data/0", 2048)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestResizeError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "qux"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("data/0", 2048)
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestResizeV4(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 4}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("data/0", 2048)
+	c.Check(err, gc.ErrorMatches, "resizing storage on this juju controller not supported")
+}
+
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "UpdatePool")
+				c.Check(a, jc.DeepEquals, params.StoragePoolArgs{[]params.StoragePool{{
+					Name:     "fast",
+					Provider: "ebs",
+					Attrs:    map[string]interface{}{"volume-type": "ssd"},
+				}}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.UpdatePool("fast", "ebs", map[string]interface{}{"volume-type": "ssd"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestUpdatePoolV4(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	err := client.UpdatePool("fast", "", nil)
+	c.Check(err, gc.ErrorMatches, "updating storage pools on this juju controller not supported")
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemovePool")
+				c.Check(a, jc.DeepEquals, params.StoragePoolDeleteArgs{[]params.StoragePoolDeleteArg{{
+					Name: "fast",
+				}}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: `storage pool "fast" in use`},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RemovePool("fast")
+	c.Assert(err, gc.ErrorMatches, `storage pool "fast" in use`)
+}
+
+func (s *storageMockSuite) TestRemovePoolV4(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	err := client.RemovePool("fast")
+	c.Check(err, gc.ErrorMatches, "removing storage pools on this juju controller not supported")
+}
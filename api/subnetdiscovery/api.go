@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+)
+
+// API makes calls to the SubnetDiscovery facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "SubnetDiscovery"),
+	}
+}
+
+// ReconcileSubnets asks the controller to bring the model's subnets
+// into line with those known to the model's provider. An error
+// satisfying params.IsCodeNotSupported is returned if the provider
+// does not support networking.
+func (api *API) ReconcileSubnets() error {
+	return errors.Trace(api.caller.FacadeCall("ReconcileSubnets", nil, nil))
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/subnetdiscovery"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestReconcileSubnets(c *gc.C) {
+	var called bool
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "SubnetDiscovery")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ReconcileSubnets")
+		c.Check(arg, gc.IsNil)
+		c.Check(result, gc.IsNil)
+		called = true
+		return nil
+	})
+	api := subnetdiscovery.NewAPI(caller)
+
+	err := api.ReconcileSubnets()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestReconcileSubnetsError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		return &params.Error{Code: params.CodeNotSupported, Message: "not supported"}
+	})
+	api := subnetdiscovery.NewAPI(caller)
+
+	err := api.ReconcileSubnets()
+	c.Check(err, gc.ErrorMatches, "not supported")
+	c.Check(err, jc.Satisfies, params.IsCodeNotSupported)
+}
@@ -122,3 +122,20 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestAddActionArtifact(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.AddActionArtifact(action.ActionTag(), "dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	artifacts, err := model.ActionArtifacts(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts, gc.HasLen, 1)
+	c.Assert(artifacts[0].Name, gc.Equals, "dump.sql")
+}
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/proxy"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
@@ -22,6 +23,7 @@ type Unit struct {
 	life         params.Life
 	resolvedMode params.ResolvedMode
 	series       string
+	movingTo     string
 }
 
 // Tag returns the unit's tag.
@@ -54,6 +56,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// MovingTo returns the id of the machine to which the unit is being
+// moved, or "" if it is not being moved.
+func (u *Unit) MovingTo() string {
+	return u.movingTo
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -77,6 +85,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.series = result.Series
+	u.movingTo = result.MovingTo
 	return nil
 }
 
@@ -194,6 +203,24 @@ func (u *Unit) EnsureDead() error {
 	return result.OneError()
 }
 
+// CompleteMove completes the move of the unit to the machine to which
+// it is being moved. It should be called only once the unit has
+// stopped.
+func (u *Unit) CompleteMove() error {
+	if u.st.facade.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("moving units")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CompleteMove", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // Watch returns a watcher for observing changes to the unit.
 func (u *Unit) Watch() (watcher.NotifyWatcher, error) {
 	return common.Watch(u.st.facade, "Watch", u.tag)
@@ -644,6 +671,53 @@ func (u *Unit) RequestReboot() error {
 	return result.OneError()
 }
 
+// RequestCoordinatedReboot asks the controller to reboot the unit's
+// machine once no other unit of the application is rebooting.
+func (u *Unit) RequestCoordinatedReboot() error {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("coordinated reboots")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("RequestCoordinatedReboot", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// ProxySettings returns the proxy settings to be used by the unit's
+// hooks: the model's proxy settings with the application's overrides
+// applied.
+func (u *Unit) ProxySettings() (proxy.Settings, error) {
+	if u.st.facade.BestAPIVersion() < 11 {
+		return proxy.Settings{}, errors.NotSupportedf("application proxy settings")
+	}
+	var results params.ProxyConfigResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ProxySettings", args, &results)
+	if err != nil {
+		return proxy.Settings{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return proxy.Settings{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return proxy.Settings{}, result.Error
+	}
+	return proxy.Settings{
+		Http:    result.ProxySettings.HTTP,
+		Https:   result.ProxySettings.HTTPS,
+		Ftp:     result.ProxySettings.FTP,
+		NoProxy: result.ProxySettings.NoProxy,
+	}, nil
+}
+
 // RelationStatus holds information about a relation's scope and status.
 type RelationStatus struct {
 	// Tag is the relation tag.
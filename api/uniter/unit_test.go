@@ -51,6 +51,33 @@ func (s *unitSuite) TestRequestReboot(c *gc.C) {
 	c.Assert(rFlag, jc.IsTrue)
 }
 
+func (s *unitSuite) TestRequestCoordinatedReboot(c *gc.C) {
+	err := s.apiUnit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Unit, gc.Equals, s.wordpressUnit.Name())
+
+	// The machine is not rebooted until the request is granted.
+	rFlag, err := s.wordpressMachine.GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rFlag, jc.IsFalse)
+}
+
+func (s *unitSuite) TestProxySettings(c *gc.C) {
+	err := s.wordpressApplication.SetProxyOverrides(state.ProxyOverrides{
+		HTTP:    "http://app-proxy",
+		NoProxy: "example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.apiUnit.ProxySettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Http, gc.Equals, "http://app-proxy")
+	c.Assert(settings.NoProxy, jc.Contains, "example.com")
+}
+
 func (s *unitSuite) TestUnitAndUnitTag(c *gc.C) {
 	apiUnitFoo, err := s.uniter.Unit(names.NewUnitTag("foo/42"))
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -216,6 +243,26 @@ func (s *unitSuite) TestRefreshLife(c *gc.C) {
 	c.Assert(s.apiUnit.Life(), gc.Equals, params.Dead)
 }
 
+func (s *unitSuite) TestMoveUnit(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.MoveToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.apiUnit.MovingTo(), gc.Equals, "")
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.MovingTo(), gc.Equals, machine.Id())
+
+	err = s.apiUnit.CompleteMove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+}
+
 func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	err := s.wordpressUnit.SetResolved(state.ResolvedRetryHooks)
 	c.Assert(err, jc.ErrorIsNil)
@@ -289,6 +289,27 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// AddActionArtifact stores a file returned by the running action
+// with the given tag.
+func (st *State) AddActionArtifact(tag names.ActionTag, name string, content []byte) error {
+	if st.facade.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("action artifacts")
+	}
+	var result params.ErrorResults
+	args := params.ActionArtifactArgs{
+		Artifacts: []params.ActionArtifactArg{{
+			ActionTag: tag.String(),
+			Name:      name,
+			Content:   content,
+		}},
+	}
+	err := st.facade.FacadeCall("AddActionArtifacts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
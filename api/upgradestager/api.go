@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Status describes the progress of a model's staged agent upgrade.
+type Status struct {
+	// InProgress reports whether a staged upgrade is in progress. If
+	// not, the remaining fields are empty.
+	InProgress bool
+
+	// Version is the version being upgraded to.
+	Version version.Number
+
+	// Canaries holds the ids of the machines to be upgraded first.
+	Canaries []string
+
+	// BatchSize is the number of machines to upgrade at a time after
+	// the canaries; zero means all of them.
+	BatchSize int
+
+	// Machines holds the progress of the model's machines, excluding
+	// controllers, ordered by id.
+	Machines []Machine
+}
+
+// Machine describes the progress of a machine in a staged upgrade.
+type Machine struct {
+	// Id identifies the machine.
+	Id string
+
+	// Admitted reports whether the machine has been allowed to
+	// upgrade.
+	Admitted bool
+
+	// Upgraded reports whether the machine's agents are running the
+	// new version.
+	Upgraded bool
+
+	// Healthy reports whether the machine's agent has started and
+	// none of its units' agents are in error.
+	Healthy bool
+}
+
+// API makes calls to the UpgradeStager facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "UpgradeStager"),
+	}
+}
+
+// Status returns the progress of the model's staged upgrade.
+func (api *API) Status() (Status, error) {
+	var result params.StagedUpgradeStatusResult
+	if err := api.caller.FacadeCall("StagedUpgradeStatus", nil, &result); err != nil {
+		return Status{}, errors.Trace(err)
+	}
+	if !result.InProgress {
+		return Status{}, nil
+	}
+	machines := make([]Machine, len(result.Machines))
+	for i, machine := range result.Machines {
+		tag, err := names.ParseMachineTag(machine.MachineTag)
+		if err != nil {
+			return Status{}, errors.Trace(err)
+		}
+		machines[i] = Machine{
+			Id:       tag.Id(),
+			Admitted: machine.Admitted,
+			Upgraded: machine.Upgraded,
+			Healthy:  machine.Healthy,
+		}
+	}
+	return Status{
+		InProgress: true,
+		Version:    result.Version,
+		Canaries:   result.Canaries,
+		BatchSize:  result.BatchSize,
+		Machines:   machines,
+	}, nil
+}
+
+// AdmitMachines allows the identified machines to upgrade.
+func (api *API) AdmitMachines(ids []string) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(ids)),
+	}
+	for i, id := range ids {
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var result params.ErrorResult
+	if err := api.caller.FacadeCall("AdmitMachines", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// Complete ends the model's staged upgrade.
+func (api *API) Complete() error {
+	var result params.ErrorResult
+	if err := api.caller.FacadeCall("CompleteStagedUpgrade", nil, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradestager"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestStatus(c *gc.C) {
+	vers := version.MustParse("2.3.1")
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "UpgradeStager")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "StagedUpgradeStatus")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StagedUpgradeStatusResult{})
+		*(result.(*params.StagedUpgradeStatusResult)) = params.StagedUpgradeStatusResult{
+			InProgress: true,
+			Version:    vers,
+			Canaries:   []string{"1"},
+			BatchSize:  2,
+			Machines: []params.StagedUpgradeMachine{{
+				MachineTag: "machine-1",
+				Admitted:   true,
+				Healthy:    true,
+			}},
+		}
+		return nil
+	})
+	api := upgradestager.NewAPI(caller)
+
+	status, err := api.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, upgradestager.Status{
+		InProgress: true,
+		Version:    vers,
+		Canaries:   []string{"1"},
+		BatchSize:  2,
+		Machines: []upgradestager.Machine{{
+			Id:       "1",
+			Admitted: true,
+			Healthy:  true,
+		}},
+	})
+}
+
+func (s *APISuite) TestStatusNotInProgress(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		return nil
+	})
+	api := upgradestager.NewAPI(caller)
+
+	status, err := api.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, upgradestager.Status{})
+}
+
+func (s *APISuite) TestStatusError(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		return errors.New("blammo")
+	})
+	api := upgradestager.NewAPI(caller)
+
+	_, err := api.Status()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *APISuite) TestAdmitMachines(c *gc.C) {
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "UpgradeStager")
+		c.Check(request, gc.Equals, "AdmitMachines")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-2"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{Message: "bad"},
+		}
+		return nil
+	})
+	api := upgradestager.NewAPI(caller)
+
+	err := api.AdmitMachines([]string{"1", "2"})
+	c.Check(err, gc.ErrorMatches, "bad")
+}
+
+func (s *APISuite) TestComplete(c *gc.C) {
+	called := false
+	caller := apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "UpgradeStager")
+		c.Check(request, gc.Equals, "CompleteStagedUpgrade")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		called = true
+		return nil
+	})
+	api := upgradestager.NewAPI(caller)
+
+	err := api.Complete()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
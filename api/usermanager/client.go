@@ -95,6 +95,16 @@ func (c *Client) EnableUser(username string) error {
 	return c.userCall(username, "EnableUser")
 }
 
+// UnlockUser lifts the lockout of a user who has been locked out after
+// too many failed logins. If the user is not locked out, the action is
+// considered a success.
+func (c *Client) UnlockUser(username string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("unlocking users on this controller")
+	}
+	return c.userCall(username, "UnlockUser")
+}
+
 // RemoveUser deletes a user. That is it permanently removes the user, while
 // retaining the record of the user to maintain provenance.
 func (c *Client) RemoveUser(username string) error {
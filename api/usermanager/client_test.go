@@ -126,6 +126,19 @@ func (s *usermanagerSuite) TestEnableUser(c *gc.C) {
 	c.Assert(user.IsDisabled(), jc.IsFalse)
 }
 
+func (s *usermanagerSuite) TestUnlockUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := user.RecordFailedLogin(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.UnlockUser(user.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+}
+
 func (s *usermanagerSuite) TestEnableUserBadName(c *gc.C) {
 	err := s.usermanager.EnableUser("not!good")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
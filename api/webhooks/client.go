@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package webhooks provides access to the Webhooks API facade, which
+// manages the webhooks to which a controller sends events.
+package webhooks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Webhooks API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Webhooks API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Webhooks")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns the controller's webhooks.
+func (c *Client) List() ([]params.Webhook, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("webhooks")
+	}
+	var result params.WebhooksResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Webhooks, nil
+}
+
+// Add adds a webhook to the controller. Events that are signed with
+// the given secret are sent to the URL; if no events are specified,
+// all events are sent.
+func (c *Client) Add(name, url, secret string, events []string) error {
+	if c.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("webhooks")
+	}
+	args := params.AddWebhooksArgs{
+		Webhooks: []params.AddWebhookArg{{
+			Name:   name,
+			URL:    url,
+			Secret: secret,
+			Events: events,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Add", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Remove removes the named webhook from the controller.
+func (c *Client) Remove(name string) error {
+	if c.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("webhooks")
+	}
+	args := params.RemoveWebhooksArgs{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
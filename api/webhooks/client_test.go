@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/webhooks"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type webhooksSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&webhooksSuite{})
+
+func newAPICaller(c *gc.C, request string, check func(arg, result interface{})) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, req string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Webhooks")
+			c.Check(version, gc.Equals, 1)
+			c.Check(req, gc.Equals, request)
+			check(arg, result)
+			return nil
+		},
+		BestVersion: 1,
+	}
+}
+
+func (s *webhooksSuite) TestList(c *gc.C) {
+	expected := []params.Webhook{{
+		Name:   "ops",
+		URL:    "https://ops.example.com/",
+		Events: []string{"unit-removed"},
+	}}
+	apiCaller := newAPICaller(c, "List", func(arg, result interface{}) {
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.WebhooksResult)) = params.WebhooksResult{Webhooks: expected}
+	})
+	result, err := webhooks.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *webhooksSuite) TestAdd(c *gc.C) {
+	apiCaller := newAPICaller(c, "Add", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.AddWebhooksArgs{
+			Webhooks: []params.AddWebhookArg{{
+				Name:   "ops",
+				URL:    "https://ops.example.com/",
+				Secret: "sekrit",
+				Events: []string{"unit-removed"},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+	})
+	err := webhooks.NewClient(apiCaller).Add("ops", "https://ops.example.com/", "sekrit", []string{"unit-removed"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *webhooksSuite) TestRemove(c *gc.C) {
+	apiCaller := newAPICaller(c, "Remove", func(arg, result interface{}) {
+		c.Check(arg, jc.DeepEquals, params.RemoveWebhooksArgs{Names: []string{"ops"}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+	})
+	err := webhooks.NewClient(apiCaller).Remove("ops")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *webhooksSuite) TestNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 0,
+	}
+	_, err := webhooks.NewClient(apiCaller).List()
+	c.Assert(err, gc.ErrorMatches, "webhooks not supported")
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package wireguardmesh implements the client-side API facade used
+// by the wireguardmesh worker.
+package wireguardmesh
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the WireGuardMesh API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side WireGuardMesh facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "WireGuardMesh"),
+	}
+}
+
+// MeshConfig returns the WireGuard configuration of the machine with
+// the given tag, generating the machine's keys if necessary.
+func (f *Facade) MeshConfig(machine names.MachineTag) (params.WireGuardMeshConfig, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.WireGuardMeshConfigResults
+	if err := f.caller.FacadeCall("MeshConfig", args, &results); err != nil {
+		return params.WireGuardMeshConfig{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.WireGuardMeshConfig{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.WireGuardMeshConfig{}, err
+	}
+	return *results.Results[0].Result, nil
+}
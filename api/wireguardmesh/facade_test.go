@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireguardmesh_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/wireguardmesh"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestMeshConfig(c *gc.C) {
+	expected := params.WireGuardMeshConfig{
+		Enabled:    true,
+		PrivateKey: "private",
+		ListenPort: 51820,
+		Peers: []params.WireGuardPeer{{
+			PublicKey:  "public",
+			Endpoint:   "203.0.113.10:51820",
+			AllowedIPs: []string{"10.0.0.4/32"},
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "WireGuardMesh")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "MeshConfig")
+		c.Check(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}},
+		})
+		config := expected
+		*response.(*params.WireGuardMeshConfigResults) = params.WireGuardMeshConfigResults{
+			Results: []params.WireGuardMeshConfigResult{{Result: &config}},
+		}
+		return nil
+	})
+	facade := wireguardmesh.NewFacade(apiCaller)
+
+	config, err := facade.MeshConfig(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, expected)
+}
+
+func (s *facadeSuite) TestMeshConfigError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.WireGuardMeshConfigResults) = params.WireGuardMeshConfigResults{
+			Results: []params.WireGuardMeshConfigResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	facade := wireguardmesh.NewFacade(apiCaller)
+
+	_, err := facade.MeshConfig(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/presence"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/apitoken"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -75,6 +76,10 @@ func (a *admin) RedirectInfo() (params.RedirectInfoResult, error) {
 var MaintenanceNoLoginError = errors.New("login failed - maintenance in progress")
 var errAlreadyLoggedIn = errors.New("already logged in")
 
+// errDraining is returned by login when the API server is draining its
+// connections, so that clients connect to one of the other API servers.
+var errDraining = errors.New("login failed - API server is draining")
+
 // login is the internal version of the Login API call.
 func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginResult, error) {
 	var fail params.LoginResult
@@ -99,6 +104,15 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		return fail, errors.Trace(err)
 	}
 
+	// Controller machine agents may log in while the API server
+	// drains, since the controllers forward hub messages over
+	// their API connections.
+	if authResult.controllerMachineLogin {
+		a.srv.exemptFromDrain(a.root.rpcConn)
+	} else if a.srv.isDraining() {
+		return fail, errDraining
+	}
+
 	// Fetch the API server addresses from state.
 	hostPorts, err := a.root.state.APIHostPorts()
 	if err != nil {
@@ -135,6 +149,24 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		modelTag = a.root.model.Tag().String()
 	}
 
+	if a.root.entity != nil && !authResult.controllerMachineLogin {
+		var limited facade.Resource
+		apiRoot, limited = a.srv.requestLimiter.restrict(apiRoot, a.root.entity.Tag())
+		a.root.resources.Register(limited)
+	}
+
+	// Calls made by users are also subject to the controller's
+	// external authorization policy, if there is one.
+	if a.srv.policyAuthorizer != nil && a.root.entity != nil {
+		if userTag, ok := a.root.entity.Tag().(names.UserTag); ok {
+			var modelTag names.Tag
+			if !authResult.controllerOnlyLogin {
+				modelTag = a.root.model.ModelTag()
+			}
+			apiRoot = a.srv.policyAuthorizer.restrict(apiRoot, userTag, modelTag)
+		}
+	}
+
 	a.root.rpcConn.ServeRoot(apiRoot, serverError)
 	return params.LoginResult{
 		Servers:       params.FromNetworkHostsPorts(hostPorts),
@@ -154,6 +186,14 @@ type authResult struct {
 	controllerOnlyLogin    bool
 	controllerMachineLogin bool
 	userInfo               *params.AuthUserInfo
+
+	// tokenCapabilities holds the capabilities of the API token
+	// used to log in, or nil if no token was used.
+	tokenCapabilities []string
+
+	// agentTokenRecovery is true if an agent logged in with an
+	// expired agent token, and may only obtain a new one.
+	agentTokenRecovery bool
 }
 
 func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
@@ -162,8 +202,18 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 		userLogin:           true,
 	}
 
-	// Maybe rate limit non-user auth attempts.
-	if req.AuthTag != "" {
+	// Logins with an API token identify the user by the token.
+	var token *state.APIToken
+	if req.Token != "" {
+		var err error
+		token, err = a.checkToken(req.Token, result.controllerOnlyLogin)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.tag = token.User
+		result.tokenCapabilities = token.Capabilities
+	} else if req.AuthTag != "" {
+		// Maybe rate limit non-user auth attempts.
 		tag, err := names.ParseTag(req.AuthTag)
 		if err == nil {
 			result.tag = tag
@@ -177,8 +227,11 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 			// Users are not rate limited, all other entities are.
 			if !a.srv.limiter.Acquire() {
 				logger.Debugf("rate limiting for agent %s", req.AuthTag)
+				if n := atomic.AddInt64(&a.srv.throttledLogins, 1); n%throttleWarningInterval == 1 {
+					logger.Warningf("agent login rate limit reached (%d logins throttled in total)", n)
+				}
 				select {
-				case <-time.After(a.srv.loginRetryPause):
+				case <-time.After(a.srv.loginRetryPauseWithJitter()):
 				}
 				return nil, common.ErrTryAgain
 			}
@@ -192,7 +245,7 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 	switch result.tag.(type) {
 	case nil:
 	case names.UserTag:
-		if result.tag.Id() == api.AnonymousUsername && len(req.Macaroons) == 0 {
+		if result.tag.Id() == api.AnonymousUsername && len(req.Macaroons) == 0 && token == nil {
 			result.anonymousLogin = true
 			result.userLogin = false
 		}
@@ -207,9 +260,22 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 		err            error
 		startPinger    = true
 	)
-	if !result.anonymousLogin {
-		entity, lastConnection, err = a.checkCreds(req, result.tag, result.userLogin)
+	if token != nil {
+		entity, lastConnection, err = a.checkTokenCreds(*token)
 		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else if !result.anonymousLogin {
+		entity, lastConnection, err = a.checkCreds(req, result.tag, result.userLogin)
+		if expiredErr, ok := errors.Cause(err).(*authentication.AgentTokenExpiredError); ok {
+			// The agent has been offline for longer than its
+			// token's lifetime; it may log in only to obtain
+			// a new token.
+			logger.Infof("%s logged in with an expired agent token", names.ReadableString(result.tag))
+			entity, err = expiredErr.Entity, nil
+			result.agentTokenRecovery = true
+			startPinger = false
+		} else if err != nil {
 			// If above login fails, we may still be a login to a controller
 			// machine in the controller model.
 			entity, err = a.handleAuthError(req, result.tag, err)
@@ -407,6 +473,47 @@ func (a *admin) checkCreds(req params.LoginRequest, authTag names.Tag, userLogin
 	return doCheckCreds(a.root.state, req, authTag, userLogin, a.authenticator())
 }
 
+// checkToken returns the API token presented in a login request, if
+// it is valid and may be used for this connection.
+func (a *admin) checkToken(presented string, controllerOnlyLogin bool) (*state.APIToken, error) {
+	id, secret, err := apitoken.Parse(presented)
+	if err != nil {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	token, err := a.root.state.AuthenticateAPIToken(id, secret)
+	if errors.IsUnauthorized(err) {
+		logger.Debugf("rejecting API token login: %v", err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if token.ModelUUID != "" && (controllerOnlyLogin || token.ModelUUID != a.root.modelUUID) {
+		return nil, errors.Annotatef(common.ErrPerm, "API token %q is limited to model %q", token.ID, token.ModelUUID)
+	}
+	return &token, nil
+}
+
+// checkTokenCreds returns the entity of the user on whose behalf an
+// API token logs in, and updates the user's last login time.
+func (a *admin) checkTokenCreds(token state.APIToken) (state.Entity, *time.Time, error) {
+	entity, err := modelUserEntityFinder{a.root.state}.FindEntity(token.User)
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	user := entity.(*modelUserEntity)
+	if user.user == nil || user.user.IsDisabled() {
+		return nil, nil, errors.Trace(common.ErrBadCreds)
+	}
+	lastLogin, err := user.LastLogin()
+	if err != nil && !state.IsNeverLoggedInError(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	user.UpdateLastLogin()
+	return user, &lastLogin, nil
+}
+
 func (a *admin) checkControllerMachineCreds(req params.LoginRequest, authTag names.MachineTag) (state.Entity, error) {
 	return checkControllerMachineCreds(a.srv.statePool.SystemState(), req, authTag, a.authenticator())
 }
@@ -610,6 +717,46 @@ func (u *modelUserEntity) PasswordValid(pass string) bool {
 	return u.user.PasswordValid(pass)
 }
 
+// PasswordChanged returns when the local user's password was last set.
+func (u *modelUserEntity) PasswordChanged() time.Time {
+	if u.user == nil {
+		return time.Time{}
+	}
+	return u.user.PasswordChanged()
+}
+
+// FailedLogins returns the local user's consecutive failed logins.
+func (u *modelUserEntity) FailedLogins() int {
+	if u.user == nil {
+		return 0
+	}
+	return u.user.FailedLogins()
+}
+
+// LockedAt returns when the local user was locked out, if ever.
+func (u *modelUserEntity) LockedAt() time.Time {
+	if u.user == nil {
+		return time.Time{}
+	}
+	return u.user.LockedAt()
+}
+
+// RecordFailedLogin records a failed login for the local user.
+func (u *modelUserEntity) RecordFailedLogin(threshold int) error {
+	if u.user == nil {
+		return errors.New("cannot record failed login for external user")
+	}
+	return u.user.RecordFailedLogin(threshold)
+}
+
+// Unlock clears the local user's failed logins.
+func (u *modelUserEntity) Unlock() error {
+	if u.user == nil {
+		return errors.New("cannot unlock external user")
+	}
+	return u.user.Unlock()
+}
+
 // Tag implements state.Entity.Tag.
 func (u *modelUserEntity) Tag() names.Tag {
 	return u.tag
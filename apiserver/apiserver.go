@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"io"
 	"log"
+	"math/rand"
 	"net"
 	"net/http"
 	"path"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"gopkg.in/juju/names.v2"
@@ -37,9 +39,11 @@ import (
 	"github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/logsink"
+	"github.com/juju/juju/apiserver/modelcache"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/websocket"
+	pubsubapiserver "github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
@@ -62,6 +66,8 @@ const (
 	defaultConnLookbackWindow     = 1 * time.Second
 	defaultConnLowerThreshold     = 1000   // connections per second
 	defaultConnUpperThreshold     = 100000 // connections per second
+	defaultRequestRateLimit       = 0      // requests per second per entity; 0 disables
+	defaultRequestMaxWait         = 5 * time.Second
 	defaultLogSinkRateLimitBurst  = 1000
 	defaultLogSinkRateLimitRefill = time.Millisecond
 )
@@ -86,18 +92,44 @@ type Server struct {
 	lastConnectionID       uint64
 	centralHub             *pubsub.StructuredHub
 	newObserver            observer.ObserverFactory
+	newAuditObserver       observer.ObserverFactory
 	connCount              int64
 	totalConn              int64
 	loginAttempts          int64
+	throttledLogins        int64
+	requestLimiter         *requestLimiter
+	rateLimitConfig        RateLimitConfig
+	policyAuthorizer       *policyAuthorizer
 	certChanged            <-chan params.StateServingInfo
 	tlsConfig              *tls.Config
 	allowModelAccess       bool
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
+	deltaHubs              *deltaStreamHubs
+	modelCache             *modelcache.Cache
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
 
+	// draining is non-zero while the server is draining its API
+	// connections; it must be accessed atomically.
+	draining int32
+
+	// auditingEnabled is non-zero while auditing is enabled in the
+	// controller config; it must be accessed atomically.
+	auditingEnabled int32
+
+	// drainChanged is signalled when draining starts or stops.
+	drainChanged chan struct{}
+
+	// connsMu guards conns.
+	connsMu sync.Mutex
+
+	// conns holds the open API connections, so that they can be
+	// closed when the server drains. The value records whether the
+	// connection is exempt from draining.
+	conns map[*rpc.Conn]bool
+
 	// mu guards the fields below it.
 	mu sync.Mutex
 
@@ -114,11 +146,27 @@ type Server struct {
 	// certDNSNames holds the DNS names associated with cert.
 	certDNSNames []string
 
+	// drainStatus holds the most recently published drain status
+	// of each API server, keyed by machine ID.
+	drainStatus map[string]pubsubapiserver.DrainStatus
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	registerIntrospectionHandlers func(func(string, http.Handler))
+
+	// metricsGatherer and metricsAllowedNetworks configure the
+	// /metrics endpoint; it is only served if metricsGatherer
+	// is non-nil.
+	metricsGatherer        prometheus.Gatherer
+	metricsAllowedNetworks []*net.IPNet
+
+	// healthEndpointEnabled, healthAllowedNetworks and workersHealthy
+	// configure the /health endpoint.
+	healthEndpointEnabled bool
+	healthAllowedNetworks []*net.IPNet
+	workersHealthy        func() error
 }
 
 // ServerConfig holds parameters required to set up an API server.
@@ -165,6 +213,17 @@ type ServerConfig struct {
 	// notified of key events during API requests.
 	NewObserver observer.ObserverFactory
 
+	// NewAuditObserver, if non-nil, is a function which will return
+	// an observer that records audit entries. It is used for each
+	// connection made while auditing is enabled in the controller
+	// config.
+	NewAuditObserver observer.ObserverFactory
+
+	// AuditingEnabled holds whether auditing is enabled when the
+	// server starts. The server observes later changes to the
+	// controller config.
+	AuditingEnabled bool
+
 	// RegisterIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
@@ -180,8 +239,36 @@ type ServerConfig struct {
 	// DefaultLogSinkConfig() will be used.
 	LogSinkConfig *LogSinkConfig
 
+	// AuthorizationPolicy holds parameters to control the delegation
+	// of authorization decisions for user API calls to an external
+	// policy endpoint.
+	AuthorizationPolicy AuthorizationPolicyConfig
+
 	// PrometheusRegisterer registers Prometheus collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// MetricsGatherer, if non-nil, is used to serve the controller's
+	// Prometheus metrics at /metrics.
+	MetricsGatherer prometheus.Gatherer
+
+	// MetricsAllowedNetworks restricts the source addresses from
+	// which /metrics may be accessed. If empty, any address is
+	// allowed.
+	MetricsAllowedNetworks []*net.IPNet
+
+	// HealthEndpointEnabled holds whether the API server serves
+	// unauthenticated liveness and readiness checks at /health.
+	HealthEndpointEnabled bool
+
+	// HealthAllowedNetworks restricts the source addresses from
+	// which /health may be accessed. If empty, any address is
+	// allowed.
+	HealthAllowedNetworks []*net.IPNet
+
+	// WorkersHealthy, if non-nil, is used by the /health endpoint
+	// to check that the workers the controller requires are
+	// running. It returns an error describing any that are not.
+	WorkersHealthy func() error
 }
 
 // Validate validates the API server configuration.
@@ -209,6 +296,9 @@ func (c ServerConfig) Validate() error {
 			return errors.Annotate(err, "validating logsink configuration")
 		}
 	}
+	if err := c.AuthorizationPolicy.Validate(); err != nil {
+		return errors.Annotate(err, "validating authorization policy configuration")
+	}
 	return nil
 }
 
@@ -231,6 +321,21 @@ type RateLimitConfig struct {
 	ConnLookbackWindow time.Duration
 	ConnLowerThreshold int
 	ConnUpperThreshold int
+
+	// RequestRateLimit is the number of API requests per second
+	// allowed for each authenticated user or agent, other than
+	// controller agents. If zero, requests are not rate limited.
+	RequestRateLimit int
+
+	// RequestRateBurst is the number of requests an entity may make
+	// in a burst before being limited to RequestRateLimit. If zero,
+	// RequestRateLimit is used.
+	RequestRateBurst int
+
+	// RequestMaxWait is the longest a request will be delayed
+	// waiting for its entity's rate limit; requests that would
+	// wait longer are rejected, telling the client to try again.
+	RequestMaxWait time.Duration
 }
 
 // DefaultRateLimitConfig returns a RateLimtConfig struct with
@@ -246,6 +351,8 @@ func DefaultRateLimitConfig() RateLimitConfig {
 		ConnLookbackWindow: defaultConnLookbackWindow,
 		ConnLowerThreshold: defaultConnLowerThreshold,
 		ConnUpperThreshold: defaultConnUpperThreshold,
+		RequestRateLimit:   defaultRequestRateLimit,
+		RequestMaxWait:     defaultRequestMaxWait,
 	}
 }
 
@@ -274,6 +381,15 @@ func (c RateLimitConfig) Validate() error {
 	if c.ConnLookbackWindow < 0 || c.ConnLookbackWindow > 5*time.Second {
 		return errors.NotValidf("conn-lookback-window %d < 0 or > 5s", c.ConnMaxPause)
 	}
+	if c.RequestRateLimit < 0 || c.RequestRateLimit > 10000 {
+		return errors.NotValidf("request-rate-limit %d < 0 or > 10000", c.RequestRateLimit)
+	}
+	if c.RequestRateBurst < 0 || c.RequestRateBurst > 100000 {
+		return errors.NotValidf("request-rate-burst %d < 0 or > 100000", c.RequestRateBurst)
+	}
+	if c.RequestMaxWait < 0 || c.RequestMaxWait > 30*time.Second {
+		return errors.NotValidf("request-max-wait %d < 0 or > 30s", c.RequestMaxWait)
+	}
 	return nil
 }
 
@@ -364,20 +480,33 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		pingClock:                     cfg.pingClock(),
 		lis:                           lis,
 		newObserver:                   cfg.NewObserver,
+		newAuditObserver:              cfg.NewAuditObserver,
 		statePool:                     stPool,
 		tag:                           cfg.Tag,
 		dataDir:                       cfg.DataDir,
 		logDir:                        cfg.LogDir,
 		limiter:                       limiter,
 		loginRetryPause:               cfg.RateLimitConfig.LoginRetryPause,
+		requestLimiter:                newRequestLimiter(cfg.RateLimitConfig, cfg.Clock),
+		rateLimitConfig:               cfg.RateLimitConfig,
+		policyAuthorizer:              newPolicyAuthorizer(cfg.AuthorizationPolicy, cfg.Clock),
 		upgradeComplete:               cfg.UpgradeComplete,
 		restoreStatus:                 cfg.RestoreStatus,
 		facades:                       AllFacades(),
+		deltaHubs:                     newDeltaStreamHubs(stPool),
 		centralHub:                    cfg.Hub,
+		drainChanged:                  make(chan struct{}, 1),
+		conns:                         make(map[*rpc.Conn]bool),
+		drainStatus:                   make(map[string]pubsubapiserver.DrainStatus),
 		certChanged:                   cfg.CertChanged,
 		allowModelAccess:              cfg.AllowModelAccess,
 		publicDNSName_:                cfg.AutocertDNSName,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
+		metricsGatherer:               cfg.MetricsGatherer,
+		metricsAllowedNetworks:        cfg.MetricsAllowedNetworks,
+		healthEndpointEnabled:         cfg.HealthEndpointEnabled,
+		healthAllowedNetworks:         cfg.HealthAllowedNetworks,
+		workersHealthy:                cfg.WorkersHealthy,
 		logsinkRateLimitConfig: logsink.RateLimitConfig{
 			Refill: cfg.LogSinkConfig.RateLimitRefill,
 			Burst:  cfg.LogSinkConfig.RateLimitBurst,
@@ -390,6 +519,10 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		},
 	}
 
+	if cfg.AuditingEnabled {
+		srv.auditingEnabled = 1
+	}
+
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = newThrottlingListener(
 		tls.NewListener(lis, srv.tlsConfig), cfg.RateLimitConfig, clock.WallClock)
@@ -424,6 +557,11 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		}
 	}
 
+	srv.modelCache, err = newModelCache(stPool, cfg.Clock, cfg.PrometheusRegisterer)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating model cache")
+	}
+
 	go srv.run()
 	return srv, nil
 }
@@ -448,6 +586,14 @@ func (a *metricAdaptor) ConnectionPauseTime() time.Duration {
 	return a.srv.lis.(*throttlingListener).pauseTime()
 }
 
+func (a *metricAdaptor) ThrottledLogins() int64 {
+	return atomic.LoadInt64(&a.srv.throttledLogins)
+}
+
+func (a *metricAdaptor) ThrottledRequests() int64 {
+	return a.srv.requestLimiter.Throttled()
+}
+
 func (srv *Server) newTLSConfig(cfg ServerConfig) *tls.Config {
 	tlsConfig := utils.SecureTLSConfig()
 	if cfg.AutocertDNSName == "" {
@@ -502,6 +648,15 @@ func (srv *Server) LoginAttempts() int64 {
 	return atomic.LoadInt64(&srv.loginAttempts)
 }
 
+// loginRetryPauseWithJitter returns the time to wait before telling a
+// rate limited agent to try logging in again. The pause is randomised
+// so that agents throttled together, for example after a mass restart,
+// don't all retry at the same moment.
+func (srv *Server) loginRetryPauseWithJitter() time.Duration {
+	pause := srv.loginRetryPause
+	return pause + time.Duration(rand.Int63n(int64(pause)/2+1))
+}
+
 // Dead returns a channel that signals when the server has exited.
 func (srv *Server) Dead() <-chan struct{} {
 	return srv.tomb.Dead()
@@ -552,6 +707,12 @@ func (srv *Server) run() {
 		srv.statePool.SystemState().KillWorkers()
 
 		srv.wg.Wait() // wait for any outstanding requests to complete.
+		if srv.modelCache != nil {
+			srv.modelCache.Kill()
+			if err := srv.modelCache.Wait(); err != nil {
+				logger.Warningf("stopping model cache: %v", err)
+			}
+		}
 		srv.tomb.Done()
 		srv.dbloggers.dispose()
 		srv.logSinkWriter.Close()
@@ -581,6 +742,18 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.drainer())
+	}()
+
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processControllerConfigChanges())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -638,6 +811,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(httpCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
 	pubsubHandler := srv.trackRequests(newPubSubHandler(httpCtxt, srv.centralHub))
+	deltaStreamHandler := srv.trackRequests(newDeltaStreamHandler(httpCtxt, srv.deltaHubs))
 
 	// This handler is model specific even though it only ever makes sense
 	// for a controller because the API caller that is handed to the worker
@@ -646,6 +820,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	// The delta stream is versioned so that the frame format can
+	// evolve without breaking third party consumers.
+	add("/model/:modeluuid/deltas/1", deltaStreamHandler)
 
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -672,6 +849,22 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	}
 	add("/model/:modeluuid/rest/1.0/:entity/:name/:attribute", modelRestServer)
 
+	// The read-only REST gateway, for integrations that cannot
+	// speak the websocket API.
+	addGateway := func(pattern string, modelScoped bool, get func(*Server, *state.State, names.UserTag) (interface{}, error)) {
+		add(pattern, srv.trackRequests(&restGatewayHandler{
+			ctxt:        httpCtxt,
+			modelScoped: modelScoped,
+			get:         get,
+		}))
+	}
+	add("/gateway/"+restGatewayVersion+"/openapi.json", restGatewaySpecHandler{})
+	addGateway("/gateway/"+restGatewayVersion+"/models", false, gatewayModels)
+	addGateway("/model/:modeluuid/gateway/"+restGatewayVersion+"/status", true, gatewayStatus)
+	addGateway("/model/:modeluuid/gateway/"+restGatewayVersion+"/applications", true, gatewayApplications)
+	addGateway("/model/:modeluuid/gateway/"+restGatewayVersion+"/units", true, gatewayUnits)
+	addGateway("/model/:modeluuid/gateway/"+restGatewayVersion+"/machines", true, gatewayMachines)
+
 	modelCharmsHandler := &charmsHandler{
 		ctxt:          httpCtxt,
 		dataDir:       srv.dataDir,
@@ -764,6 +957,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/drain", srv.trackRequests(&drainHandler{
+		ctxt: httpCtxt,
+	}))
+	add("/model-archives/:id", &modelArchiveHandler{
+		ctxt: httpCtxt,
+	})
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -804,6 +1003,27 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		srv.registerIntrospectionHandlers(handle)
 	}
 
+	// Register the Prometheus metrics endpoint, if enabled.
+	if srv.metricsGatherer != nil {
+		add("/metrics", metricsHandler{
+			ctxt:        httpCtxt,
+			handler:     promhttp.HandlerFor(srv.metricsGatherer, promhttp.HandlerOpts{}),
+			allowedNets: srv.metricsAllowedNetworks,
+		})
+	}
+
+	// Register the health check endpoints, if enabled.
+	if srv.healthEndpointEnabled {
+		health := healthHandler{
+			allowedNets: srv.healthAllowedNetworks,
+			checks:      srv.healthChecks(),
+		}
+		add("/health", health)
+		add("/health/ready", health)
+		health.liveOnly = true
+		add("/health/live", health)
+	}
+
 	// Add HTTP handlers for local-user macaroon authentication.
 	localLoginHandlers := &localLoginHandlers{srv.loginAuthCtxt, srv.statePool.SystemState()}
 	dischargeMux := http.NewServeMux()
@@ -907,7 +1127,7 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 
 	connectionID := atomic.AddUint64(&srv.lastConnectionID, 1)
 
-	apiObserver := srv.newObserver()
+	apiObserver := srv.newConnObserver()
 	apiObserver.Join(req, connectionID)
 	defer apiObserver.Leave()
 
@@ -923,6 +1143,8 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserver observer.Observer, host string) error {
 	codec := jsoncodec.NewWebsocket(wsConn.Conn)
 	conn := rpc.NewConn(codec, apiObserver)
+	srv.addConn(conn)
+	defer srv.removeConn(conn)
 
 	// Note that we don't overwrite modelUUID here because
 	// newAPIHandler treats an empty modelUUID as signifying
@@ -1088,6 +1310,7 @@ func (srv *Server) processModelRemovals() error {
 					// from from the state pool once people are finished
 					// with it.
 					logger.Debugf("removing model %v from the state pool", modelUUID)
+					srv.modelCache.Invalidate(modelUUID)
 					if _, err := srv.statePool.Remove(modelUUID); err != nil {
 						return errors.Trace(err)
 					}
@@ -19,6 +19,8 @@ type ServerMetricsSource interface {
 	ConnectionCount() int64
 	ConcurrentLoginAttempts() int64
 	ConnectionPauseTime() time.Duration
+	ThrottledLogins() int64
+	ThrottledRequests() int64
 }
 
 // Collector is a prometheus.Collector that collects metrics based
@@ -30,6 +32,8 @@ type Collector struct {
 	connectionCountGauge     prometheus.Gauge
 	connectionPauseTimeGauge prometheus.Gauge
 	concurrentLoginsGauge    prometheus.Gauge
+	throttledLoginsCounter   prometheus.Counter
+	throttledRequestsCounter prometheus.Counter
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -56,6 +60,16 @@ func NewMetricsCollector(src ServerMetricsSource) *Collector {
 			Name:      "active_login_attempts",
 			Help:      "Current number of active agent login attempts",
 		}),
+		throttledLoginsCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "throttled_logins_total",
+			Help:      "Total number of agent logins rejected by rate limiting",
+		}),
+		throttledRequestsCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Name:      "throttled_requests_total",
+			Help:      "Total number of API requests rejected by rate limiting",
+		}),
 	}
 }
 
@@ -65,6 +79,8 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.connectionCountGauge.Describe(ch)
 	c.connectionPauseTimeGauge.Describe(ch)
 	c.concurrentLoginsGauge.Describe(ch)
+	c.throttledLoginsCounter.Describe(ch)
+	c.throttledRequestsCounter.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -81,4 +97,14 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.connectionCountGauge.Collect(ch)
 	c.connectionPauseTimeGauge.Collect(ch)
 	c.concurrentLoginsGauge.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(
+		c.throttledLoginsCounter.Desc(),
+		prometheus.CounterValue,
+		float64(c.src.ThrottledLogins()),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.throttledRequestsCounter.Desc(),
+		prometheus.CounterValue,
+		float64(c.src.ThrottledRequests()),
+	)
 }
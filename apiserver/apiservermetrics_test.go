@@ -37,11 +37,13 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 4)
+	c.Assert(descs, gc.HasLen, 6)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_apiserver_connections_total".*`)
 	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_apiserver_connection_count".*`)
 	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_apiserver_connection_pause_seconds".*`)
 	c.Assert(descs[3].String(), gc.Matches, `.*fqName: "juju_apiserver_active_login_attempts".*`)
+	c.Assert(descs[4].String(), gc.Matches, `.*fqName: "juju_apiserver_throttled_logins_total".*`)
+	c.Assert(descs[5].String(), gc.Matches, `.*fqName: "juju_apiserver_throttled_requests_total".*`)
 }
 
 func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
@@ -55,9 +57,9 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	for metric := range ch {
 		metrics = append(metrics, metric)
 	}
-	c.Assert(metrics, gc.HasLen, 4)
+	c.Assert(metrics, gc.HasLen, 6)
 
-	var dtoMetrics [4]dto.Metric
+	var dtoMetrics [6]dto.Metric
 	for i, metric := range metrics {
 		err := metric.Write(&dtoMetrics[i])
 		c.Assert(err, jc.ErrorIsNil)
@@ -66,11 +68,13 @@ func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
 	float64ptr := func(v float64) *float64 {
 		return &v
 	}
-	c.Assert(dtoMetrics, jc.DeepEquals, [4]dto.Metric{
+	c.Assert(dtoMetrics, jc.DeepEquals, [6]dto.Metric{
 		{Counter: &dto.Counter{Value: float64ptr(200)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(2)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(0.02)}},
 		{Gauge: &dto.Gauge{Value: float64ptr(3)}},
+		{Counter: &dto.Counter{Value: float64ptr(4)}},
+		{Counter: &dto.Counter{Value: float64ptr(5)}},
 	})
 }
 
@@ -91,3 +95,11 @@ func (a *stubCollector) ConcurrentLoginAttempts() int64 {
 func (a *stubCollector) ConnectionPauseTime() time.Duration {
 	return 20 * time.Millisecond
 }
+
+func (a *stubCollector) ThrottledLogins() int64 {
+	return 4
+}
+
+func (a *stubCollector) ThrottledRequests() int64 {
+	return 5
+}
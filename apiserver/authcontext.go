@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/passwordpolicy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/bakerystorage"
 )
@@ -187,12 +188,23 @@ func (a authenticator) localUserAuth() *authentication.UserAuthenticator {
 		Path:   localUserIdentityLocationPath,
 	}
 	return &authentication.UserAuthenticator{
-		Service: a.ctxt.localUserBakeryService,
-		Clock:   a.ctxt.clock,
+		Service:                   a.ctxt.localUserBakeryService,
+		Clock:                     a.ctxt.clock,
 		LocalUserIdentityLocation: localUserIdentityLocation.String(),
+		PasswordPolicy:            a.ctxt.passwordPolicy,
 	}
 }
 
+// passwordPolicy returns the password policy configured for the
+// controller.
+func (ctxt *authContext) passwordPolicy() (passwordpolicy.Policy, error) {
+	controllerConfig, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return passwordpolicy.Policy{}, errors.Trace(err)
+	}
+	return controllerConfig.PasswordPolicy(), nil
+}
+
 // externalMacaroonAuth returns an authenticator that can authenticate macaroon-based
 // logins for external users. If it fails once, it will always fail.
 func (ctxt *authContext) externalMacaroonAuth() (authentication.EntityAuthenticator, error) {
@@ -22,6 +22,13 @@ type taggedAuthenticator interface {
 	state.Authenticator
 }
 
+// agentCredentialsChecker is implemented by entity finders that
+// support rotating agent tokens. Agents found by other entity finders
+// are authenticated with their password alone.
+type agentCredentialsChecker interface {
+	AgentCredentialsValid(entity state.AgentTokenHolder, credentials string) (bool, error)
+}
+
 // Authenticate authenticates the provided entity.
 // It takes an entityfinder and the tag used to find the entity that requires authentication.
 func (*AgentAuthenticator) Authenticate(entityFinder EntityFinder, tag names.Tag, req params.LoginRequest) (state.Entity, error) {
@@ -36,7 +43,12 @@ func (*AgentAuthenticator) Authenticate(entityFinder EntityFinder, tag names.Tag
 	if !ok {
 		return nil, errors.Trace(common.ErrBadRequest)
 	}
-	if !authenticator.PasswordValid(req.Credentials) {
+	valid, err := credentialsValid(entityFinder, authenticator, req.Credentials)
+	expired := errors.Cause(err) == state.ErrAgentTokenExpired
+	if err != nil && !expired {
+		return nil, errors.Trace(err)
+	}
+	if !valid && !expired {
 		return nil, errors.Trace(common.ErrBadCreds)
 	}
 
@@ -55,5 +67,33 @@ func (*AgentAuthenticator) Authenticate(entityFinder EntityFinder, tag names.Tag
 		}
 	}
 
+	if expired {
+		return nil, &AgentTokenExpiredError{Entity: entity}
+	}
 	return entity, nil
 }
+
+// AgentTokenExpiredError is returned by AgentAuthenticator.Authenticate
+// when an agent presents a token that has expired but has not been
+// revoked. The agent may only log in to obtain a new token.
+type AgentTokenExpiredError struct {
+	// Entity is the entity of the agent that presented the token.
+	Entity state.Entity
+}
+
+// Error is part of the error interface.
+func (e *AgentTokenExpiredError) Error() string {
+	return state.ErrAgentTokenExpired.Error()
+}
+
+func credentialsValid(entityFinder EntityFinder, authenticator taggedAuthenticator, credentials string) (bool, error) {
+	checker, ok := entityFinder.(agentCredentialsChecker)
+	if !ok {
+		return authenticator.PasswordValid(credentials), nil
+	}
+	switch authenticator.Tag().(type) {
+	case names.MachineTag, names.UnitTag:
+		return checker.AgentCredentialsValid(authenticator, credentials)
+	}
+	return authenticator.PasswordValid(credentials), nil
+}
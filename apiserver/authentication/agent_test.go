@@ -4,6 +4,10 @@
 package authentication_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -146,3 +150,55 @@ func (s *agentAuthenticatorSuite) TestInvalidLogins(c *gc.C) {
 		c.Assert(entity, gc.IsNil)
 	}
 }
+
+func (s *agentAuthenticatorSuite) TestAgentTokenLogin(c *gc.C) {
+	_, _, err := s.State.IssueAgentToken(s.unit, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	token, _, err := s.State.IssueAgentToken(s.unit, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var authenticator authentication.AgentAuthenticator
+	entity, err := authenticator.Authenticate(s.State, s.unit.Tag(), params.LoginRequest{
+		Credentials: token,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.DeepEquals, s.unit.Tag())
+
+	entity, err = authenticator.Authenticate(s.State, s.unit.Tag(), params.LoginRequest{
+		Credentials: s.unitPassword,
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(entity, gc.IsNil)
+}
+
+func (s *agentAuthenticatorSuite) TestRevokedAgentTokenLogin(c *gc.C) {
+	err := s.State.RevokeAgentTokens(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var authenticator authentication.AgentAuthenticator
+	entity, err := authenticator.Authenticate(s.State, s.machine.Tag(), params.LoginRequest{
+		Credentials: s.machinePassword,
+		Nonce:       s.machineNonce,
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(entity, gc.IsNil)
+}
+
+func (s *agentAuthenticatorSuite) TestExpiredAgentTokenLogin(c *gc.C) {
+	clock := jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	token, _, err := s.State.IssueAgentToken(s.unit, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	clock.Advance(2 * time.Hour)
+
+	var authenticator authentication.AgentAuthenticator
+	entity, err := authenticator.Authenticate(s.State, s.unit.Tag(), params.LoginRequest{
+		Credentials: token,
+	})
+	c.Assert(err, gc.ErrorMatches, "agent token expired")
+	c.Assert(entity, gc.IsNil)
+	expiredErr, ok := errors.Cause(err).(*authentication.AgentTokenExpiredError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(expiredErr.Entity.Tag(), gc.Equals, s.unit.Tag())
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/passwordpolicy"
 	"github.com/juju/juju/state"
 )
 
@@ -38,6 +39,21 @@ type UserAuthenticator struct {
 	// to for local users. This always points at the same controller
 	// agent that is servicing the authorisation request.
 	LocalUserIdentityLocation string
+
+	// PasswordPolicy, if non-nil, returns the policy that is enforced
+	// when local users log in with a password.
+	PasswordPolicy func() (passwordpolicy.Policy, error)
+}
+
+// passwordPolicyUser is implemented by entities representing local
+// users, to which the controller's password policy applies.
+type passwordPolicyUser interface {
+	taggedAuthenticator
+	PasswordChanged() time.Time
+	FailedLogins() int
+	LockedAt() time.Time
+	RecordFailedLogin(threshold int) error
+	Unlock() error
 }
 
 const (
@@ -74,9 +90,67 @@ func (u *UserAuthenticator) Authenticate(
 	if req.Credentials == "" && userTag.IsLocal() {
 		return u.authenticateMacaroons(entityFinder, userTag, req)
 	}
+	if userTag.IsLocal() && u.PasswordPolicy != nil {
+		return u.authenticatePassword(entityFinder, userTag, req)
+	}
 	return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
 }
 
+// authenticatePassword authenticates a local user with a password,
+// enforcing the controller's password policy: users are locked out
+// after too many consecutive failed logins, and expired passwords are
+// rejected.
+func (u *UserAuthenticator) authenticatePassword(
+	entityFinder EntityFinder, tag names.UserTag, req params.LoginRequest,
+) (state.Entity, error) {
+	policy, err := u.PasswordPolicy()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	user, ok := entity.(passwordPolicyUser)
+	if !ok {
+		return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
+	}
+
+	now := u.Clock.Now()
+	if lockedAt := user.LockedAt(); !lockedAt.IsZero() {
+		if policy.Locked(lockedAt, now) {
+			logger.Debugf("rejecting login for locked out user %s", tag.Id())
+			return nil, errors.Trace(common.ErrUserLocked)
+		}
+		// The lockout has expired, so start counting
+		// failed logins afresh.
+		if err := user.Unlock(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	if !user.PasswordValid(req.Credentials) {
+		if policy.LockoutThreshold > 0 {
+			if err := user.RecordFailedLogin(policy.LockoutThreshold); err != nil {
+				logger.Warningf("cannot record failed login for %s: %v", tag.Id(), err)
+			}
+		}
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if user.FailedLogins() > 0 {
+		if err := user.Unlock(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if policy.Expired(user.PasswordChanged(), now) {
+		return nil, errors.Trace(common.ErrPasswordExpired)
+	}
+	return entity, nil
+}
+
 // CreateLocalLoginMacaroon creates a macaroon that may be provided to a
 // user as proof that they have logged in with a valid username and password.
 // This macaroon may then be used to obtain a discharge macaroon so that
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/passwordpolicy"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
@@ -120,6 +121,80 @@ func (s *userAuthenticatorSuite) TestUserLoginWrongPassword(c *gc.C) {
 
 }
 
+func (s *userAuthenticatorSuite) TestUserLoginLockout(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	clock := testing.NewClock(time.Now())
+	authenticator := &authentication.UserAuthenticator{
+		Clock: clock,
+		PasswordPolicy: func() (passwordpolicy.Policy, error) {
+			return passwordpolicy.Policy{
+				LockoutThreshold: 2,
+				LockoutDuration:  15 * time.Minute,
+			}, nil
+		},
+	}
+	login := func(password string) error {
+		_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Credentials: password,
+		})
+		return err
+	}
+
+	c.Assert(login("wrongpassword"), gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(login("wrongpassword"), gc.ErrorMatches, "invalid entity name or password")
+	err := login("password")
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrUserLocked)
+
+	clock.Advance(16 * time.Minute)
+	c.Assert(login("password"), jc.ErrorIsNil)
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginResetsFailedLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	authenticator := &authentication.UserAuthenticator{
+		Clock: testing.NewClock(time.Now()),
+		PasswordPolicy: func() (passwordpolicy.Policy, error) {
+			return passwordpolicy.Policy{LockoutThreshold: 2}, nil
+		},
+	}
+	for _, password := range []string{"wrongpassword", "password", "wrongpassword", "password"} {
+		authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Credentials: password,
+		})
+	}
+	err := user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginPasswordExpired(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	authenticator := &authentication.UserAuthenticator{
+		Clock: testing.NewClock(time.Now().Add(2 * time.Hour)),
+		PasswordPolicy: func() (passwordpolicy.Policy, error) {
+			return passwordpolicy.Policy{MaxAge: time.Hour}, nil
+		},
+	}
+	_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPasswordExpired)
+}
+
 func (s *userAuthenticatorSuite) TestInvalidRelationLogin(c *gc.C) {
 
 	// add relation
@@ -0,0 +1,281 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+const (
+	// policyRequestTimeout is the maximum time to wait for the
+	// authorization webhook to respond.
+	policyRequestTimeout = 10 * time.Second
+
+	// maxPolicyCacheSize is the maximum number of decisions cached
+	// by the policy authorizer.
+	maxPolicyCacheSize = 10000
+)
+
+// AuthorizationPolicyConfig holds parameters to control the
+// delegation of API authorization decisions to an external policy
+// endpoint.
+type AuthorizationPolicyConfig struct {
+	// WebhookURL is the URL to which authorization requests are
+	// POSTed. If empty, no external policy is consulted.
+	WebhookURL string
+
+	// FailOpen holds whether API calls are allowed when the webhook
+	// cannot be reached or does not return a decision.
+	FailOpen bool
+
+	// CacheTTL is the length of time for which the webhook's
+	// decisions are cached. If zero, decisions are not cached.
+	CacheTTL time.Duration
+}
+
+// Validate validates the authorization policy configuration.
+func (c AuthorizationPolicyConfig) Validate() error {
+	if c.CacheTTL < 0 {
+		return errors.NotValidf("negative CacheTTL")
+	}
+	return nil
+}
+
+// policyAuthorizer asks an external policy endpoint whether API calls
+// made by users should be allowed. The endpoint is sent a JSON-encoded
+// params.AuthorizationPolicyRequest, and must respond with a status of
+// 200 and a JSON-encoded params.AuthorizationPolicyResponse.
+type policyAuthorizer struct {
+	clock    clock.Clock
+	client   *http.Client
+	url      string
+	failOpen bool
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]policyDecision
+}
+
+// policyDecision is a cached decision of the policy endpoint.
+type policyDecision struct {
+	params.AuthorizationPolicyResponse
+	expires time.Time
+}
+
+// newPolicyAuthorizer returns a policyAuthorizer configured from the
+// given configuration, or nil if no external policy is configured.
+func newPolicyAuthorizer(cfg AuthorizationPolicyConfig, clk clock.Clock) *policyAuthorizer {
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+	return &policyAuthorizer{
+		clock:    clk,
+		client:   &http.Client{Timeout: policyRequestTimeout},
+		url:      cfg.WebhookURL,
+		failOpen: cfg.FailOpen,
+		cacheTTL: cfg.CacheTTL,
+		cache:    make(map[string]policyDecision),
+	}
+}
+
+// restrict wraps the provided root so that each method call made by
+// the given user is authorized by the policy endpoint.
+func (a *policyAuthorizer) restrict(root rpc.Root, user names.Tag, model names.Tag) rpc.Root {
+	policyRoot := &policyRestrictedRoot{
+		Root:       root,
+		authorizer: a,
+		user:       user.String(),
+	}
+	if model != nil {
+		policyRoot.model = model.String()
+	}
+	return policyRoot
+}
+
+// authorize returns nil if the policy endpoint allows the request.
+// If the endpoint denies the request, or cannot make a decision and
+// the authorizer does not fail open, an unauthorized error is
+// returned.
+func (a *policyAuthorizer) authorize(req params.AuthorizationPolicyRequest) error {
+	key := policyCacheKey(req)
+	decision, ok := a.cached(key)
+	if !ok {
+		response, err := a.ask(req)
+		if err != nil {
+			logger.Warningf("cannot check authorization policy for %s.%s: %v", req.Facade, req.Method, err)
+			if a.failOpen {
+				return nil
+			}
+			return errors.Unauthorizedf("permission denied: authorization policy unavailable")
+		}
+		decision = a.store(key, response)
+	}
+	if decision.Allowed {
+		return nil
+	}
+	if decision.Reason != "" {
+		return errors.Unauthorizedf("permission denied by authorization policy: %s", decision.Reason)
+	}
+	return errors.Unauthorizedf("permission denied by authorization policy")
+}
+
+// ask sends the request to the policy endpoint and returns its
+// response.
+func (a *policyAuthorizer) ask(req params.AuthorizationPolicyRequest) (params.AuthorizationPolicyResponse, error) {
+	var response params.AuthorizationPolicyResponse
+	body, err := json.Marshal(req)
+	if err != nil {
+		return response, errors.Trace(err)
+	}
+	resp, err := a.client.Post(a.url, params.ContentTypeJSON, bytes.NewReader(body))
+	if err != nil {
+		return response, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return response, errors.Errorf("unexpected response status %q", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return response, errors.Annotate(err, "cannot decode response")
+	}
+	return response, nil
+}
+
+// cached returns the unexpired cached decision with the given key.
+func (a *policyAuthorizer) cached(key string) (policyDecision, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	decision, ok := a.cache[key]
+	if !ok || !a.clock.Now().Before(decision.expires) {
+		return policyDecision{}, false
+	}
+	return decision, true
+}
+
+// store caches the given response, and returns it as a decision.
+func (a *policyAuthorizer) store(key string, response params.AuthorizationPolicyResponse) policyDecision {
+	now := a.clock.Now()
+	decision := policyDecision{
+		AuthorizationPolicyResponse: response,
+		expires:                     now.Add(a.cacheTTL),
+	}
+	if a.cacheTTL == 0 {
+		return decision
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.cache) >= maxPolicyCacheSize {
+		for k, d := range a.cache {
+			if !now.Before(d.expires) {
+				delete(a.cache, k)
+			}
+		}
+		if len(a.cache) >= maxPolicyCacheSize {
+			a.cache = make(map[string]policyDecision)
+		}
+	}
+	a.cache[key] = decision
+	return decision
+}
+
+// policyCacheKey returns the key under which the decision for the
+// given request is cached.
+func policyCacheKey(req params.AuthorizationPolicyRequest) string {
+	return strings.Join([]string{
+		req.User,
+		req.Model,
+		req.Facade,
+		strconv.Itoa(req.Version),
+		req.Method,
+		strings.Join(req.Entities, ","),
+	}, "\x00")
+}
+
+// policyRestrictedRoot wraps an rpc.Root, authorizing all method
+// calls made through it with the policy endpoint.
+type policyRestrictedRoot struct {
+	rpc.Root
+	authorizer *policyAuthorizer
+	user       string
+	model      string
+}
+
+// FindMethod implements rpc.Root.
+func (r *policyRestrictedRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.Root.FindMethod(facadeName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	return policyRestrictedCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       facadeName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// policyRestrictedCaller is an rpcreflect.MethodCaller that asks the
+// policy endpoint to authorize each call.
+type policyRestrictedCaller struct {
+	rpcreflect.MethodCaller
+	root    *policyRestrictedRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c policyRestrictedCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	err := c.root.authorizer.authorize(params.AuthorizationPolicyRequest{
+		User:     c.root.user,
+		Model:    c.root.model,
+		Facade:   c.facade,
+		Version:  c.version,
+		Method:   c.method,
+		Entities: entityTags(arg),
+	})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return c.MethodCaller.Call(ctx, objId, arg)
+}
+
+var entitySliceType = reflect.TypeOf([]params.Entity(nil))
+
+// entityTags returns the tags of the entities that the call argument
+// refers to in its Entities field, as params.Entities does.
+func entityTags(arg reflect.Value) []string {
+	for arg.IsValid() && (arg.Kind() == reflect.Ptr || arg.Kind() == reflect.Interface) {
+		arg = arg.Elem()
+	}
+	if !arg.IsValid() || arg.Kind() != reflect.Struct {
+		return nil
+	}
+	field := arg.FieldByName("Entities")
+	if !field.IsValid() || field.Type() != entitySliceType {
+		return nil
+	}
+	var tags []string
+	for _, entity := range field.Interface().([]params.Entity) {
+		tags = append(tags, entity.Tag)
+	}
+	return tags
+}
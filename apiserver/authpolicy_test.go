@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type policyAuthorizerSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	root     *countingRoot
+	server   *httptest.Server
+	requests []params.AuthorizationPolicyRequest
+	status   int
+	response params.AuthorizationPolicyResponse
+}
+
+var _ = gc.Suite(&policyAuthorizerSuite{})
+
+func (s *policyAuthorizerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.root = &countingRoot{}
+	s.requests = nil
+	s.status = http.StatusOK
+	s.response = params.AuthorizationPolicyResponse{Allowed: true}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var policyReq params.AuthorizationPolicyRequest
+		err := json.NewDecoder(req.Body).Decode(&policyReq)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, policyReq)
+		w.WriteHeader(s.status)
+		json.NewEncoder(w).Encode(s.response)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *policyAuthorizerSuite) newAuthorizer(failOpen bool, ttl time.Duration) *policyAuthorizer {
+	return newPolicyAuthorizer(AuthorizationPolicyConfig{
+		WebhookURL: s.server.URL,
+		FailOpen:   failOpen,
+		CacheTTL:   ttl,
+	}, s.clock)
+}
+
+func (s *policyAuthorizerSuite) call(c *gc.C, a *policyAuthorizer, arg interface{}) error {
+	root := a.restrict(s.root, names.NewUserTag("bob"), coretesting.ModelTag)
+	caller, err := root.FindMethod("Application", 4, "Destroy")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.ValueOf(arg))
+	return err
+}
+
+func (s *policyAuthorizerSuite) TestDisabledByDefault(c *gc.C) {
+	c.Assert(newPolicyAuthorizer(AuthorizationPolicyConfig{}, s.clock), gc.IsNil)
+}
+
+func (s *policyAuthorizerSuite) TestAllowed(c *gc.C) {
+	err := s.call(c, s.newAuthorizer(false, 0), params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.root.calls, gc.Equals, 1)
+	c.Assert(s.requests, jc.DeepEquals, []params.AuthorizationPolicyRequest{{
+		User:     "user-bob",
+		Model:    coretesting.ModelTag.String(),
+		Facade:   "Application",
+		Version:  4,
+		Method:   "Destroy",
+		Entities: []string{"application-mysql", "application-wordpress"},
+	}})
+}
+
+func (s *policyAuthorizerSuite) TestDenied(c *gc.C) {
+	s.response = params.AuthorizationPolicyResponse{Reason: "change freeze"}
+	err := s.call(c, s.newAuthorizer(false, 0), params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied by authorization policy: change freeze")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(s.root.calls, gc.Equals, 0)
+}
+
+func (s *policyAuthorizerSuite) TestFailClosed(c *gc.C) {
+	s.status = http.StatusInternalServerError
+	authorizer := s.newAuthorizer(false, time.Minute)
+	err := s.call(c, authorizer, params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied: authorization policy unavailable")
+	c.Assert(s.root.calls, gc.Equals, 0)
+
+	// Failures are not cached.
+	s.status = http.StatusOK
+	err = s.call(c, authorizer, params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+}
+
+func (s *policyAuthorizerSuite) TestFailOpen(c *gc.C) {
+	s.server.Close()
+	err := s.call(c, s.newAuthorizer(true, 0), params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.root.calls, gc.Equals, 1)
+}
+
+func (s *policyAuthorizerSuite) TestDecisionsCached(c *gc.C) {
+	authorizer := s.newAuthorizer(false, time.Minute)
+	arg := params.Entities{Entities: []params.Entity{{Tag: "application-mysql"}}}
+	c.Assert(s.call(c, authorizer, arg), jc.ErrorIsNil)
+	c.Assert(s.call(c, authorizer, arg), jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+
+	// A call on other entities is a different request.
+	other := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	c.Assert(s.call(c, authorizer, other), jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+
+	s.clock.Advance(time.Minute)
+	c.Assert(s.call(c, authorizer, arg), jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Assert(s.root.calls, gc.Equals, 4)
+}
+
+func (s *policyAuthorizerSuite) TestEntityTags(c *gc.C) {
+	type withEntities struct {
+		Entities []params.Entity
+		Other    string
+	}
+	for i, test := range []struct {
+		arg    interface{}
+		expect []string
+	}{{
+		arg:    params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}},
+		expect: []string{"machine-0"},
+	}, {
+		arg:    &params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}},
+		expect: []string{"unit-mysql-0"},
+	}, {
+		arg:    withEntities{Entities: []params.Entity{{Tag: "user-bob"}}},
+		expect: []string{"user-bob"},
+	}, {
+		arg: params.Entity{Tag: "machine-0"},
+	}, {
+		arg: "machine-0",
+	}, {
+		arg: (*params.Entities)(nil),
+	}} {
+		c.Logf("test %d: %#v", i, test.arg)
+		c.Check(entityTags(reflect.ValueOf(test.arg)), jc.DeepEquals, test.expect)
+	}
+	c.Check(entityTags(reflect.Value{}), gc.IsNil)
+}
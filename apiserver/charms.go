@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -241,6 +242,13 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	if err := charm.ValidateName(name); err != nil {
 		return nil, errors.NewBadRequest(err, "")
 	}
+	lxdProfile, err := lxdprofile.ReadFromArchive(charmFileName)
+	if err != nil {
+		return nil, errors.BadRequestf("invalid charm archive: %v", err)
+	}
+	if err := lxdProfile.Validate(); err != nil {
+		return nil, errors.NewBadRequest(err, "")
+	}
 
 	// We got it, now let's reserve a charm URL for it in state.
 	curl := &charm.URL{
@@ -238,6 +238,36 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) archiveWithLXDProfile(c *gc.C, profile string) string {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
+	err := ioutil.WriteFile(filepath.Join(dir.Path, "lxd-profile.yaml"), []byte(profile), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	tempFile, err := ioutil.TempFile(c.MkDir(), "charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer tempFile.Close()
+	err = dir.ArchiveTo(tempFile)
+	c.Assert(err, jc.ErrorIsNil)
+	return tempFile.Name()
+}
+
+func (s *charmsSuite) TestUploadStoresLXDProfile(c *gc.C) {
+	path := s.archiveWithLXDProfile(c, "config:\n  security.nesting: \"true\"\n")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", path)
+	expectedURL := charm.MustParseURL("local:quantal/dummy-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+	sch, err := s.State.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err := sch.LXDProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Config, jc.DeepEquals, map[string]string{"security.nesting": "true"})
+}
+
+func (s *charmsSuite) TestUploadRejectsDisallowedLXDProfile(c *gc.C) {
+	path := s.archiveWithLXDProfile(c, "config:\n  boot.autostart: \"true\"\n")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `.*lxd profile with config "boot.autostart" not valid$`)
+}
+
 func (s *charmsSuite) TestUploadWithMultiSeriesCharm(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	resp := s.uploadRequest(c, s.charmsURL(c, "").String(), "application/zip", ch.Path)
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
@@ -21,6 +23,23 @@ type AddressAndCertGetter interface {
 	WatchAPIHostPorts() state.NotifyWatcher
 }
 
+// ipv6Preferrer is implemented by getters, such as *state.State, that
+// know whether their model prefers IPv6 addresses over IPv4 ones.
+type ipv6Preferrer interface {
+	PreferIPv6() (bool, error)
+}
+
+// preferIPv6 reports whether the getter's model prefers IPv6
+// addresses. Getters that don't know are taken to prefer IPv4.
+func preferIPv6(getter interface{}) (bool, error) {
+	preferrer, ok := getter.(ipv6Preferrer)
+	if !ok {
+		return false, nil
+	}
+	prefer, err := preferrer.PreferIPv6()
+	return prefer, errors.Trace(err)
+}
+
 // APIAddresser implements the APIAddresses method
 type APIAddresser struct {
 	resources facade.Resources
@@ -36,12 +55,23 @@ func NewAPIAddresser(getter AddressAndCertGetter, resources facade.Resources) *A
 	}
 }
 
-// APIHostPorts returns the API server addresses.
+// APIHostPorts returns the API server addresses. If the model prefers
+// IPv6, each server's IPv6 addresses are listed first.
 func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
 	servers, err := api.getter.APIHostPorts()
 	if err != nil {
 		return params.APIHostPortsResult{}, err
 	}
+	prefer, err := preferIPv6(api.getter)
+	if err != nil {
+		return params.APIHostPortsResult{}, err
+	}
+	if prefer {
+		for i, hostPorts := range servers {
+			servers[i] = append([]network.HostPort{}, hostPorts...)
+			network.SortHostPortsPreferringIPv6(servers[i])
+		}
+	}
 	return params.APIHostPortsResult{
 		Servers: params.FromNetworkHostsPorts(servers),
 	}, nil
@@ -74,9 +104,17 @@ func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	prefer, err := preferIPv6(getter)
+	if err != nil {
+		return nil, err
+	}
+	prioritize := network.PrioritizeInternalHostPorts
+	if prefer {
+		prioritize = network.PrioritizeInternalHostPortsPreferringIPv6
+	}
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
-		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
+		ordered := prioritize(hostPorts, false)
 		for _, addr := range ordered {
 			if addr != "" {
 				addrs = append(addrs, addr)
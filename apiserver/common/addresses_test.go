@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -88,6 +89,30 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesPreferIPv6(c *gc.C) {
+	ctlr, err := network.ParseHostPorts("52.7.1.1:17070", "10.0.2.1:17070", "[2001:db8::1]:17070", "[fc00::1]:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.hostPorts = [][]network.HostPort{ctlr}
+	addresser := common.NewAPIAddresser(ipv6Addresses{s.fake}, common.NewResources())
+
+	result, err := addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Result, gc.DeepEquals, []string{
+		"[fc00::1]:17070",
+		"10.0.2.1:17070",
+		"[2001:db8::1]:17070",
+		"52.7.1.1:17070",
+	})
+
+	hostPorts, err := addresser.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params.NetworkHostsPorts(hostPorts.Servers), jc.DeepEquals, [][]network.HostPort{{
+		ctlr[2], ctlr[0], ctlr[3], ctlr[1],
+	}})
+	// The getter's host ports are left alone.
+	c.Check(s.fake.hostPorts[0][0].Value, gc.Equals, "52.7.1.1")
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -123,3 +148,12 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+// ipv6Addresses is a fakeAddresses whose model prefers IPv6.
+type ipv6Addresses struct {
+	*fakeAddresses
+}
+
+func (ipv6Addresses) PreferIPv6() (bool, error) {
+	return true, nil
+}
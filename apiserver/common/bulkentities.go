@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// bulkEntityFinder is implemented by backends, such as *state.State,
+// that can read many entities at once.
+type bulkEntityFinder interface {
+	FindEntities([]names.Tag) (map[names.Tag]state.Entity, error)
+}
+
+// prefetchedEntities is a state.EntityFinder that returns entities
+// read in bulk, and looks up any others individually.
+type prefetchedEntities struct {
+	state.EntityFinder
+	entities map[names.Tag]state.Entity
+}
+
+// FindEntity is part of the state.EntityFinder interface.
+func (p *prefetchedEntities) FindEntity(tag names.Tag) (state.Entity, error) {
+	if entity, ok := p.entities[tag]; ok {
+		return entity, nil
+	}
+	return p.EntityFinder.FindEntity(tag)
+}
+
+// prefetchEntities returns an EntityFinder that serves the given
+// entities from a single bulk read, if st supports it. Agents call the
+// common facade methods for all their entities at once, so this saves
+// a query for each of them.
+func prefetchEntities(st state.EntityFinder, tags []names.Tag) (state.EntityFinder, error) {
+	bulk, ok := st.(bulkEntityFinder)
+	if !ok || len(tags) == 0 {
+		return st, nil
+	}
+	entities, err := bulk.FindEntities(tags)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &prefetchedEntities{
+		EntityFinder: st,
+		entities:     entities,
+	}, nil
+}
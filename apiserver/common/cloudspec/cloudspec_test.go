@@ -51,13 +51,13 @@ func (s *CloudSpecSuite) SetUpTest(c *gc.C) {
 		map[string]string{"k": "v"},
 	)
 	s.result = environs.CloudSpec{
-		"type",
-		"name",
-		"region",
-		"endpoint",
-		"identity-endpoint",
-		"storage-endpoint",
-		&credential,
+		Type:             "type",
+		Name:             "name",
+		Region:           "region",
+		Endpoint:         "endpoint",
+		IdentityEndpoint: "identity-endpoint",
+		StorageEndpoint:  "storage-endpoint",
+		Credential:       &credential,
 	}
 }
 
@@ -28,8 +28,8 @@ func NewDeadEnsurer(st state.EntityFinder, getCanModify GetAuthFunc) *DeadEnsure
 	}
 }
 
-func (d *DeadEnsurer) ensureEntityDead(tag names.Tag) error {
-	entity0, err := d.st.FindEntity(tag)
+func (d *DeadEnsurer) ensureEntityDead(finder state.EntityFinder, tag names.Tag) error {
+	entity0, err := finder.FindEntity(tag)
 	if err != nil {
 		return err
 	}
@@ -42,7 +42,8 @@ func (d *DeadEnsurer) ensureEntityDead(tag names.Tag) error {
 
 // EnsureDead calls EnsureDead on each given entity from state. It
 // will fail if the entity is not present. If it's Alive, nothing will
-// happen (see state/EnsureDead() for units or machines).
+// happen (see state/EnsureDead() for units or machines). Machines and
+// units are read from state in bulk where possible.
 func (d *DeadEnsurer) EnsureDead(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -54,15 +55,29 @@ func (d *DeadEnsurer) EnsureDead(args params.Entities) (params.ErrorResults, err
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	tags := make([]names.Tag, len(args.Entities))
+	modifiable := make([]names.Tag, 0, len(args.Entities))
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
 			return params.ErrorResults{}, errors.Trace(err)
 		}
-
-		err = ErrPerm
 		if canModify(tag) {
-			err = d.ensureEntityDead(tag)
+			tags[i] = tag
+			modifiable = append(modifiable, tag)
+		}
+	}
+	// The entities are read in bulk, but each is killed in its own
+	// transaction, so that one failing its assertions does not stop
+	// the others from dying.
+	finder, err := prefetchEntities(d.st, modifiable)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, tag := range tags {
+		err := ErrPerm
+		if tag != nil {
+			err = d.ensureEntityDead(finder, tag)
 		}
 		result.Results[i].Error = ServerError(err)
 	}
@@ -73,6 +73,55 @@ func (*deadEnsurerSuite) TestEnsureDead(c *gc.C) {
 	})
 }
 
+type fakeBulkEntityState struct {
+	fakeState
+	found    map[names.Tag]state.Entity
+	requests [][]names.Tag
+}
+
+func (st *fakeBulkEntityState) FindEntities(tags []names.Tag) (map[names.Tag]state.Entity, error) {
+	st.requests = append(st.requests, tags)
+	return st.found, nil
+}
+
+func (*deadEnsurerSuite) TestEnsureDeadBulk(c *gc.C) {
+	st := &fakeBulkEntityState{
+		fakeState: fakeState{
+			entities: map[names.Tag]entityWithError{
+				u("x/1"): &fakeDeadEnsurer{err: fmt.Errorf("x1 fails")},
+			},
+		},
+		found: map[names.Tag]state.Entity{
+			u("x/0"): &fakeDeadEnsurer{err: fmt.Errorf("x0 fails")},
+			u("x/2"): &fakeDeadEnsurer{life: state.Dying},
+		},
+	}
+	getCanModify := func() (common.AuthFunc, error) {
+		x3 := u("x/3")
+		return func(tag names.Tag) bool {
+			return tag != x3
+		}, nil
+	}
+
+	d := common.NewDeadEnsurer(st, getCanModify)
+	entities := params.Entities{[]params.Entity{
+		{"unit-x-0"}, {"unit-x-1"}, {"unit-x-2"}, {"unit-x-3"},
+	}}
+	result, err := d.EnsureDead(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{&params.Error{Message: "x0 fails"}},
+			{&params.Error{Message: "x1 fails"}},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(st.requests, jc.DeepEquals, [][]names.Tag{{
+		u("x/0"), u("x/1"), u("x/2"),
+	}})
+}
+
 func (*deadEnsurerSuite) TestEnsureDeadError(c *gc.C) {
 	getCanModify := func() (common.AuthFunc, error) {
 		return nil, fmt.Errorf("pow")
@@ -91,6 +91,8 @@ var (
 	ErrBadCreds           = errors.New("invalid entity name or password")
 	ErrNoCreds            = errors.New("no credentials provided")
 	ErrLoginExpired       = errors.New("login expired")
+	ErrUserLocked         = errors.New("user locked out after too many failed logins")
+	ErrPasswordExpired    = errors.New("password expired")
 	ErrPerm               = errors.New("permission denied")
 	ErrNotLoggedIn        = errors.New("not logged in")
 	ErrUnknownWatcher     = errors.New("unknown watcher id")
@@ -125,6 +127,8 @@ var singletonErrorCodes = map[error]string{
 	ErrBadCreds:                  params.CodeUnauthorized,
 	ErrNoCreds:                   params.CodeNoCreds,
 	ErrLoginExpired:              params.CodeLoginExpired,
+	ErrUserLocked:                params.CodeUnauthorized,
+	ErrPasswordExpired:           params.CodeUnauthorized,
 	ErrPerm:                      params.CodeUnauthorized,
 	ErrNotLoggedIn:               params.CodeUnauthorized,
 	ErrUnknownWatcher:            params.CodeNotFound,
@@ -243,6 +247,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeNotImplemented
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
 	default:
 		if err, ok := err.(*DischargeRequiredError); ok {
 			code = params.CodeDischargeRequired
@@ -4,14 +4,21 @@
 package imagecommon
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.common.imagecommon")
+
 // ImageMetadataInterface is an interface for manipulating images metadata.
 type ImageMetadataInterface interface {
 
@@ -69,3 +76,42 @@ func ParseMetadataListFromParams(p params.CloudImageMetadataList, cfg *config.Co
 	}
 	return results
 }
+
+// FilterMissingImages returns the given metadata without the custom
+// image metadata that refers to images that the environ reports do not
+// exist, so that machines are not provisioned with them. If the environ
+// cannot check for images, or the check fails, the metadata is returned
+// unchanged.
+func FilterMissingImages(env environs.Environ, metadata []params.CloudImageMetadata) []params.CloudImageMetadata {
+	checker, ok := env.(environs.ImageChecker)
+	if !ok {
+		return metadata
+	}
+	var imageIds []string
+	for _, m := range metadata {
+		if m.Source == "custom" {
+			imageIds = append(imageIds, m.ImageId)
+		}
+	}
+	if len(imageIds) == 0 {
+		return metadata
+	}
+	missing, err := checker.MissingImages(imageIds)
+	if err != nil {
+		logger.Warningf("cannot check custom images exist: %v", err)
+		return metadata
+	}
+	if len(missing) == 0 {
+		return metadata
+	}
+	logger.Warningf("ignoring custom image metadata for missing images %s", strings.Join(missing, ", "))
+	missingIds := set.NewStrings(missing...)
+	var result []params.CloudImageMetadata
+	for _, m := range metadata {
+		if m.Source == "custom" && missingIds.Contains(m.ImageId) {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
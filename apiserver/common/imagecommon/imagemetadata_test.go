@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/imagecommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	coretesting "github.com/juju/juju/testing"
@@ -102,6 +103,45 @@ func (s *imageMetadataSuite) TestSave(c *gc.C) {
 	})
 }
 
+func (s *imageMetadataSuite) TestFilterMissingImages(c *gc.C) {
+	metadata := []params.CloudImageMetadata{
+		{ImageId: "published", Source: "default cloud images"},
+		{ImageId: "custom", Source: "custom"},
+		{ImageId: "deleted", Source: "custom"},
+	}
+	env := &imageCheckingEnviron{Stub: &testing.Stub{}, missing: []string{"deleted"}}
+	filtered := imagecommon.FilterMissingImages(env, metadata)
+	c.Assert(filtered, jc.DeepEquals, metadata[:2])
+	env.CheckCalls(c, []testing.StubCall{
+		{"MissingImages", []interface{}{[]string{"custom", "deleted"}}},
+	})
+}
+
+func (s *imageMetadataSuite) TestFilterMissingImagesCheckFails(c *gc.C) {
+	metadata := []params.CloudImageMetadata{{ImageId: "custom", Source: "custom"}}
+	env := &imageCheckingEnviron{Stub: &testing.Stub{}}
+	env.SetErrors(errors.New("boom"))
+	filtered := imagecommon.FilterMissingImages(env, metadata)
+	c.Assert(filtered, jc.DeepEquals, metadata)
+}
+
+func (s *imageMetadataSuite) TestFilterMissingImagesNotSupported(c *gc.C) {
+	metadata := []params.CloudImageMetadata{{ImageId: "custom", Source: "custom"}}
+	filtered := imagecommon.FilterMissingImages(struct{ environs.Environ }{}, metadata)
+	c.Assert(filtered, jc.DeepEquals, metadata)
+}
+
+type imageCheckingEnviron struct {
+	environs.Environ
+	*testing.Stub
+	missing []string
+}
+
+func (e *imageCheckingEnviron) MissingImages(imageIds []string) ([]string, error) {
+	e.MethodCall(e, "MissingImages", imageIds)
+	return e.missing, e.NextErr()
+}
+
 type mockState struct {
 	*testing.Stub
 	modelCfg *config.Config
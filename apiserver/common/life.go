@@ -26,6 +26,12 @@ func NewLifeGetter(st state.EntityFinder, getCanRead GetAuthFunc) *LifeGetter {
 	}
 }
 
+// bulkLifeGetter is implemented by backends, such as *state.State,
+// that can read the life of many entities at once.
+type bulkLifeGetter interface {
+	EntitiesLife([]names.Tag) (map[names.Tag]state.Life, error)
+}
+
 func (lg *LifeGetter) oneLife(tag names.Tag) (params.Life, error) {
 	entity0, err := lg.st.FindEntity(tag)
 	if err != nil {
@@ -50,16 +56,34 @@ func (lg *LifeGetter) Life(args params.Entities) (params.LifeResults, error) {
 	if err != nil {
 		return params.LifeResults{}, errors.Trace(err)
 	}
+	tags := make([]names.Tag, len(args.Entities))
+	readable := make([]names.Tag, 0, len(args.Entities))
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
+		if err == nil && canRead(tag) {
+			tags[i] = tag
+			readable = append(readable, tag)
+		}
+	}
+	// Entities missing from the bulk result are looked up individually,
+	// so that they get the same errors as before.
+	var lives map[names.Tag]state.Life
+	if bulk, ok := lg.st.(bulkLifeGetter); ok && len(readable) > 0 {
+		lives, err = bulk.EntitiesLife(readable)
 		if err != nil {
+			return params.LifeResults{}, errors.Trace(err)
+		}
+	}
+	for i, tag := range tags {
+		if tag == nil {
 			result.Results[i].Error = ServerError(ErrPerm)
 			continue
 		}
-		err = ErrPerm
-		if canRead(tag) {
-			result.Results[i].Life, err = lg.oneLife(tag)
+		if life, ok := lives[tag]; ok {
+			result.Results[i].Life = params.Life(life.String())
+			continue
 		}
+		result.Results[i].Life, err = lg.oneLife(tag)
 		result.Results[i].Error = ServerError(err)
 	}
 	return result, nil
@@ -64,6 +64,56 @@ func (*lifeSuite) TestLife(c *gc.C) {
 	})
 }
 
+type fakeBulkLifeState struct {
+	fakeState
+	lives    map[names.Tag]state.Life
+	requests [][]names.Tag
+}
+
+func (st *fakeBulkLifeState) EntitiesLife(tags []names.Tag) (map[names.Tag]state.Life, error) {
+	st.requests = append(st.requests, tags)
+	return st.lives, nil
+}
+
+func (*lifeSuite) TestLifeBulk(c *gc.C) {
+	st := &fakeBulkLifeState{
+		fakeState: fakeState{
+			entities: map[names.Tag]entityWithError{
+				u("x/2"): &fakeLifer{life: state.Dying},
+			},
+		},
+		lives: map[names.Tag]state.Life{
+			u("x/0"): state.Alive,
+			u("x/1"): state.Dead,
+		},
+	}
+	getCanRead := func() (common.AuthFunc, error) {
+		x3 := u("x/3")
+		return func(tag names.Tag) bool {
+			return tag != x3
+		}, nil
+	}
+	lg := common.NewLifeGetter(st, getCanRead)
+	entities := params.Entities{[]params.Entity{
+		{"unit-x-0"}, {"unit-x-1"}, {"unit-x-2"}, {"unit-x-3"}, {"unit-x-4"},
+	}}
+	results, err := lg.Life(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.LifeResults{
+		Results: []params.LifeResult{
+			{Life: params.Alive},
+			{Life: params.Dead},
+			{Life: params.Dying},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `entity "unit-x-4" not found`, Code: params.CodeNotFound}},
+		},
+	})
+	// Only the readable entities are requested, all at once.
+	c.Assert(st.requests, jc.DeepEquals, [][]names.Tag{{
+		u("x/0"), u("x/1"), u("x/2"), u("x/4"),
+	}})
+}
+
 func (*lifeSuite) TestLifeError(c *gc.C) {
 	getCanRead := func() (common.AuthFunc, error) {
 		return nil, fmt.Errorf("pow")
@@ -224,6 +224,11 @@ func NetworkConfigsToStateArgs(networkConfig []params.NetworkConfig) (
 				IsUp:        !netConfig.Disabled,
 				ParentName:  netConfig.ParentInterfaceName,
 			}
+			// Providers report the VLAN tag of the subnet an interface is
+			// on, but only VLAN devices carry their own tag.
+			if args.Type == state.VLAN_8021QDevice {
+				args.VLANTag = netConfig.VLANTag
+			}
 			logger.Tracef("state device args for device: %+v", args)
 			devicesArgs = append(devicesArgs, args)
 		}
@@ -447,9 +452,12 @@ func networkToParamsNetworkInfo(info network.NetworkInfo) params.NetworkInfo {
 		}
 	}
 	return params.NetworkInfo{
-		MACAddress:    info.MACAddress,
-		InterfaceName: info.InterfaceName,
-		Addresses:     addresses,
+		MACAddress:          info.MACAddress,
+		InterfaceName:       info.InterfaceName,
+		InterfaceType:       string(info.InterfaceType),
+		ParentInterfaceName: info.ParentInterfaceName,
+		VLANTag:             info.VLANTag,
+		Addresses:           addresses,
 	}
 }
 
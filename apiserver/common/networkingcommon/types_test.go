@@ -736,6 +736,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth0.100",
+	VLANTag:     100,
 }, {
 	Name:        "eth0.250",
 	MTU:         1500,
@@ -745,6 +746,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth0.250",
+	VLANTag:     250,
 }, {
 	Name:        "eth0.50",
 	MTU:         1500,
@@ -754,6 +756,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth0.50",
+	VLANTag:     50,
 }, {
 	Name:        "br-eth1",
 	MTU:         1500,
@@ -803,6 +806,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth1.11",
+	VLANTag:     11,
 }, {
 	Name:        "eth1.12",
 	MTU:         1500,
@@ -812,6 +816,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth1.12",
+	VLANTag:     12,
 }, {
 	Name:        "eth1.13",
 	MTU:         1500,
@@ -821,6 +826,7 @@ var expectedLinkLayerDeviceArgsWithFinalNetworkConfig = []state.LinkLayerDeviceA
 	IsAutoStart: true,
 	IsUp:        true,
 	ParentName:  "br-eth1.13",
+	VLANTag:     13,
 }}
 
 var expectedLinkLayerDeviceAdressesWithFinalNetworkConfig = []state.LinkLayerDeviceAddress{{
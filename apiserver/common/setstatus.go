@@ -134,8 +134,8 @@ func NewStatusSetter(st state.EntityFinder, getCanModify GetAuthFunc) *StatusSet
 	}
 }
 
-func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
-	entity, err := s.st.FindEntity(tag)
+func (s *StatusSetter) setEntityStatus(finder state.EntityFinder, tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
+	entity, err := finder.FindEntity(tag)
 	if err != nil {
 		return err
 	}
@@ -167,18 +167,32 @@ func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, er
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
-	// TODO(perrito666) 2016-05-02 lp:1558657
-	now := time.Now()
+	tags := make([]names.Tag, len(args.Entities))
+	modifiable := make([]names.Tag, 0, len(args.Entities))
 	for i, arg := range args.Entities {
 		tag, err := names.ParseTag(arg.Tag)
 		if err != nil {
 			result.Results[i].Error = ServerError(err)
 			continue
 		}
-		err = ErrPerm
-		if canModify(tag) {
-			err = s.setEntityStatus(tag, status.Status(arg.Status), arg.Info, arg.Data, &now)
+		if !canModify(tag) {
+			result.Results[i].Error = ServerError(ErrPerm)
+			continue
+		}
+		tags[i] = tag
+		modifiable = append(modifiable, tag)
+	}
+	finder, err := prefetchEntities(s.st, modifiable)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	now := time.Now()
+	for i, arg := range args.Entities {
+		if tags[i] == nil {
+			continue
 		}
+		err := s.setEntityStatus(finder, tags[i], status.Status(arg.Status), arg.Info, arg.Data, &now)
 		result.Results[i].Error = ServerError(err)
 	}
 	return result, nil
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/watcher"
+)
+
+// processControllerConfigChanges watches the controller config, and
+// applies changes to the attributes that the API server observes
+// while it is running: auditing and API request rate limits.
+func (srv *Server) processControllerConfigChanges() error {
+	st := srv.statePool.SystemState()
+	w := st.WatchControllerConfig()
+	defer w.Stop()
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+			cfg, err := st.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot read controller config")
+			}
+			srv.applyControllerConfig(cfg)
+		}
+	}
+}
+
+// applyControllerConfig applies the given controller config to the
+// running API server.
+func (srv *Server) applyControllerConfig(cfg controller.Config) {
+	var auditingEnabled int32
+	if cfg.AuditingEnabled() {
+		auditingEnabled = 1
+	}
+	if atomic.SwapInt32(&srv.auditingEnabled, auditingEnabled) != auditingEnabled {
+		logger.Infof("auditing enabled: %v", cfg.AuditingEnabled())
+	}
+
+	// The request rate limits in the controller config take
+	// precedence over those in the agent config.
+	rate, burst := srv.rateLimitConfig.RequestRateLimit, srv.rateLimitConfig.RequestRateBurst
+	if v, ok := cfg.APIRequestRateLimit(); ok {
+		rate, burst = v, cfg.APIRequestRateBurst()
+	}
+	if srv.requestLimiter.setLimits(rate, burst) {
+		logger.Infof("API request rate limit set to %d per second (burst %d)", rate, burst)
+	}
+}
+
+// newConnObserver returns the observer for a new API connection,
+// which includes the audit observer if auditing is enabled.
+func (srv *Server) newConnObserver() observer.Observer {
+	if srv.newAuditObserver == nil || atomic.LoadInt32(&srv.auditingEnabled) == 0 {
+		return srv.newObserver()
+	}
+	return observer.NewMultiplexer(srv.newObserver(), srv.newAuditObserver())
+}
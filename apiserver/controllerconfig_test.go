@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/fakeobserver"
+	"github.com/juju/juju/controller"
+)
+
+type controllerConfigSuite struct {
+	testing.IsolationSuite
+	srv   *Server
+	audit *fakeobserver.Instance
+	conn  *fakeobserver.Instance
+}
+
+var _ = gc.Suite(&controllerConfigSuite{})
+
+func (s *controllerConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.audit = &fakeobserver.Instance{}
+	s.conn = &fakeobserver.Instance{}
+	cfg := DefaultRateLimitConfig()
+	cfg.RequestRateLimit = 10
+	s.srv = &Server{
+		newObserver:      func() observer.Observer { return s.conn },
+		newAuditObserver: func() observer.Observer { return s.audit },
+		requestLimiter:   newRequestLimiter(cfg, testing.NewClock(time.Now())),
+		rateLimitConfig:  cfg,
+	}
+}
+
+func (s *controllerConfigSuite) TestAuditing(c *gc.C) {
+	c.Assert(s.srv.newConnObserver(), gc.Equals, s.conn)
+
+	s.srv.applyControllerConfig(controller.Config{controller.AuditingEnabled: true})
+	s.srv.newConnObserver().Leave()
+	s.conn.CheckCallNames(c, "Leave")
+	s.audit.CheckCallNames(c, "Leave")
+
+	s.srv.applyControllerConfig(controller.Config{controller.AuditingEnabled: false})
+	c.Assert(s.srv.newConnObserver(), gc.Equals, s.conn)
+}
+
+func (s *controllerConfigSuite) TestRequestRateLimits(c *gc.C) {
+	limiter := s.srv.requestLimiter
+	tag := names.NewUserTag("bob")
+
+	s.srv.applyControllerConfig(controller.Config{
+		controller.APIRequestRateLimit: 1,
+		controller.APIRequestRateBurst: 2,
+	})
+	c.Assert(limiter.rate, gc.Equals, float64(1))
+	c.Assert(limiter.burst, gc.Equals, int64(2))
+	c.Assert(limiter.bucket(tag), gc.NotNil)
+
+	s.srv.applyControllerConfig(controller.Config{
+		controller.APIRequestRateLimit: 0,
+	})
+	c.Assert(limiter.bucket(tag), gc.IsNil)
+
+	// Without a limit in the controller config, the agent's
+	// limit applies.
+	s.srv.applyControllerConfig(controller.Config{})
+	c.Assert(limiter.rate, gc.Equals, float64(10))
+	c.Assert(limiter.burst, gc.Equals, int64(10))
+	c.Assert(limiter.setLimits(10, 0), jc.IsFalse)
+}
@@ -60,14 +60,22 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeLabel -> []string - only show log messages with any of these labels
+//   excludeLabel -> []string - do not show log messages with any of these labels
+//   includeTrace -> []string - only show log messages with these correlation
+//      IDs, and the API request logs with these trace IDs
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
 //      - has no meaning if 'replay' is true
+//      - may be at most 10000
 //   level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time; only show log messages logged at or after it
+//   endTime -> string - RFC3339 time; only show log messages logged before it
+//      - implies noTail
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -149,6 +157,7 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
 	startTime     time.Time
+	endTime       time.Time
 	maxLines      uint
 	fromTheStart  bool
 	noTail        bool
@@ -158,6 +167,9 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	includeLabel  []string
+	excludeLabel  []string
+	includeTrace  []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -192,6 +204,9 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		if err != nil {
 			return params, errors.Errorf("backlog value %q is not a valid unsigned number", value)
 		}
+		if num > state.MaxInitialLogLines {
+			return params, errors.Errorf("backlog value %d exceeds the maximum of %d", num, state.MaxInitialLogLines)
+		}
 		params.backlog = uint(num)
 	}
 
@@ -213,10 +228,24 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before the start time", value)
+		}
+		params.endTime = endTime
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeLabel = queryMap["includeLabel"]
+	params.excludeLabel = queryMap["excludeLabel"]
+	params.includeTrace = queryMap["includeTrace"]
 
 	return params, nil
 }
@@ -25,6 +25,7 @@ func handleDebugLogDBRequest(
 	params := makeLogTailerParams(reqParams)
 	tailer, err := newLogTailer(st, params)
 	if err != nil {
+		socket.sendError(err)
 		return errors.Trace(err)
 	}
 	defer tailer.Stop()
@@ -59,11 +60,15 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		InitialLines:  int(reqParams.backlog),
 		IncludeEntity: reqParams.includeEntity,
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		IncludeLabel:  reqParams.includeLabel,
+		ExcludeLabel:  reqParams.excludeLabel,
+		IncludeTrace:  reqParams.includeTrace,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -79,6 +84,9 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+
+		Labels:        r.Labels,
+		CorrelationID: r.CorrelationID,
 	}
 }
 
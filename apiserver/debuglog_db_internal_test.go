@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -31,16 +32,21 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
 	reqParams := debugLogParams{
 		fromTheStart:  false,
 		noTail:        true,
 		backlog:       11,
 		startTime:     t1,
+		endTime:       t2,
 		filterLevel:   loggo.INFO,
 		includeEntity: []string{"foo"},
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		includeLabel:  []string{"charm"},
+		excludeLabel:  []string{"api"},
+		includeTrace:  []string{"abcd"},
 	}
 
 	called := false
@@ -50,6 +56,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		// Start time will be used once the client is extended to send
 		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -57,6 +64,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeLabel, jc.DeepEquals, []string{"charm"})
+		c.Assert(params.ExcludeLabel, jc.DeepEquals, []string{"api"})
+		c.Assert(params.IncludeTrace, jc.DeepEquals, []string{"abcd"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -91,6 +101,40 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestTailerError(c *gc.C) {
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		return nil, errors.New("boom")
+	})
+
+	stop := make(chan struct{})
+	err := handleDebugLogDBRequest(nil, debugLogParams{}, s.sock, stop)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.assertOutput(c, []string{"err: boom"})
+}
+
+func (s *debugLogDBIntSuite) TestFormatLogRecordStructuredFields(c *gc.C) {
+	msg := formatLogRecord(&state.LogRecord{
+		Time:          time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:        names.NewUnitTag("foo/2"),
+		Module:        "unit.foo/2.juju-log",
+		Location:      "code.go:42",
+		Level:         loggo.INFO,
+		Message:       "stuff happened",
+		Labels:        []string{"charm"},
+		CorrelationID: "abcd",
+	})
+	c.Assert(msg, jc.DeepEquals, &params.LogMessage{
+		Entity:        "unit-foo-2",
+		Timestamp:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Severity:      "INFO",
+		Module:        "unit.foo/2.juju-log",
+		Location:      "code.go:42",
+		Message:       "stuff happened",
+		Labels:        []string{"charm"},
+		CorrelationID: "abcd",
+	})
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
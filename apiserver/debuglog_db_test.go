@@ -31,6 +31,21 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogDBSuite) TestBacklogTooLarge(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"backlog": {"10001"}})
+	websockettest.AssertJSONError(c, reader, `backlog value 10001 exceeds the maximum of 10000`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{
+		"startTime": {"2017-06-01T12:00:00Z"},
+		"endTime":   {"2017-06-01T11:00:00Z"},
+	})
+	websockettest.AssertJSONError(c, reader, `end time "2017-06-01T11:00:00Z" is before the start time`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
@@ -0,0 +1,430 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/juju/errors"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// deltaStreamMaxFrames is the number of frames each model's delta
+// stream hub retains so that reconnecting clients can resume.
+var deltaStreamMaxFrames = 1000
+
+// deltaSource is the source of deltas for a deltaStreamHub. It is
+// satisfied by *state.Multiwatcher.
+type deltaSource interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// deltaStreamFrame is a frame held by a deltaStreamHub.
+type deltaStreamFrame struct {
+	generation int64
+	deltas     []multiwatcher.Delta
+}
+
+// deltaStreamHub watches a single model, and records the deltas
+// so that any number of subscribers may stream them, and resume
+// streaming after reconnecting.
+//
+// A hub's frames are held only in memory, in the API server process
+// that started it. The hub is discarded when its last subscriber goes
+// away or its source fails, and hubs are not shared between the API
+// servers of an HA controller, so bookmarks are only resumable while
+// the client keeps reconnecting to the same hub. Any other bookmark
+// is answered with a snapshot frame.
+type deltaStreamHub struct {
+	// epoch identifies this hub; generations are only meaningful
+	// within a single epoch. It is derived from the time the hub
+	// was started, so that a hub started later, in this or another
+	// API server process, does not accept bookmarks from this one.
+	epoch  string
+	source deltaSource
+	done   func()
+	// failed, if not nil, is called once the source has failed.
+	failed func()
+
+	mu          sync.Mutex
+	subscribers int
+	generation  int64
+	frames      []deltaStreamFrame
+	entities    map[multiwatcher.EntityId]multiwatcher.EntityInfo
+	changed     chan struct{}
+	err         error
+}
+
+func newDeltaStreamHub(epoch string, source deltaSource, done, failed func()) *deltaStreamHub {
+	hub := &deltaStreamHub{
+		epoch:    epoch,
+		source:   source,
+		done:     done,
+		failed:   failed,
+		entities: make(map[multiwatcher.EntityId]multiwatcher.EntityInfo),
+		changed:  make(chan struct{}),
+	}
+	go hub.loop()
+	return hub
+}
+
+func (h *deltaStreamHub) loop() {
+	for {
+		deltas, err := h.source.Next()
+		h.mu.Lock()
+		if err != nil {
+			h.err = err
+			close(h.changed)
+			h.mu.Unlock()
+			if h.failed != nil {
+				h.failed()
+			}
+			return
+		}
+		h.record(deltas)
+		close(h.changed)
+		h.changed = make(chan struct{})
+		h.mu.Unlock()
+	}
+}
+
+// record adds the deltas as a new frame, and applies them to the
+// snapshot of the model. It must be called with h.mu held.
+func (h *deltaStreamHub) record(deltas []multiwatcher.Delta) {
+	h.generation++
+	h.frames = append(h.frames, deltaStreamFrame{
+		generation: h.generation,
+		deltas:     deltas,
+	})
+	if len(h.frames) > deltaStreamMaxFrames {
+		h.frames = h.frames[len(h.frames)-deltaStreamMaxFrames:]
+	}
+	for _, delta := range deltas {
+		id := delta.Entity.EntityId()
+		if delta.Removed {
+			delete(h.entities, id)
+		} else {
+			h.entities[id] = delta.Entity
+		}
+	}
+}
+
+// bookmark returns the bookmark for the given generation.
+func (h *deltaStreamHub) bookmark(generation int64) string {
+	return fmt.Sprintf("%s:%d", h.epoch, generation)
+}
+
+// parseBookmark returns the generation encoded in the bookmark, and
+// whether the bookmark belongs to this hub.
+func (h *deltaStreamHub) parseBookmark(bookmark string) (int64, bool) {
+	i := strings.LastIndex(bookmark, ":")
+	if i < 0 || bookmark[:i] != h.epoch {
+		return 0, false
+	}
+	generation, err := strconv.ParseInt(bookmark[i+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+// framesSince returns the frames to send to a client that has
+// received everything up to and including the given bookmark.
+// If the frames following the bookmark are no longer held, a
+// snapshot frame is returned instead. It also returns the
+// generation of the last frame returned, and a channel that is
+// closed when there are further frames.
+func (h *deltaStreamHub) framesSince(bookmark string) ([]params.DeltaStreamFrame, int64, <-chan struct{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.err != nil {
+		return nil, 0, nil, errors.Trace(h.err)
+	}
+	if h.generation == 0 {
+		// Nothing has been received from the model yet.
+		return nil, -1, h.changed, nil
+	}
+	generation, ok := h.parseBookmark(bookmark)
+	if ok && generation <= h.generation && (len(h.frames) > 0 && generation >= h.frames[0].generation-1) {
+		return h.framesAfter(generation), h.generation, h.changed, nil
+	}
+	return []params.DeltaStreamFrame{h.snapshot()}, h.generation, h.changed, nil
+}
+
+// framesAfter returns all held frames with a generation greater than
+// the one given. It must be called with h.mu held.
+func (h *deltaStreamHub) framesAfter(generation int64) []params.DeltaStreamFrame {
+	var result []params.DeltaStreamFrame
+	for _, frame := range h.frames {
+		if frame.generation <= generation {
+			continue
+		}
+		result = append(result, params.DeltaStreamFrame{
+			Bookmark: h.bookmark(frame.generation),
+			Deltas:   frame.deltas,
+		})
+	}
+	return result
+}
+
+// snapshot returns a frame holding the current state of every
+// entity in the model. It must be called with h.mu held.
+func (h *deltaStreamHub) snapshot() params.DeltaStreamFrame {
+	ids := make([]multiwatcher.EntityId, 0, len(h.entities))
+	for id := range h.entities {
+		ids = append(ids, id)
+	}
+	sort.Sort(entityIdsByKindAndId(ids))
+	deltas := make([]multiwatcher.Delta, len(ids))
+	for i, id := range ids {
+		deltas[i] = multiwatcher.Delta{Entity: h.entities[id]}
+	}
+	return params.DeltaStreamFrame{
+		Bookmark: h.bookmark(h.generation),
+		Reset:    true,
+		Deltas:   deltas,
+	}
+}
+
+type entityIdsByKindAndId []multiwatcher.EntityId
+
+func (ids entityIdsByKindAndId) Len() int      { return len(ids) }
+func (ids entityIdsByKindAndId) Swap(i, j int) { ids[i], ids[j] = ids[j], ids[i] }
+func (ids entityIdsByKindAndId) Less(i, j int) bool {
+	if ids[i].Kind != ids[j].Kind {
+		return ids[i].Kind < ids[j].Kind
+	}
+	return ids[i].Id < ids[j].Id
+}
+
+// deltaStreamHubs holds a deltaStreamHub for each model that has
+// delta stream subscribers.
+type deltaStreamHubs struct {
+	// newSource returns a deltaSource for the model with the given
+	// UUID, and a function to call when the source is no longer needed.
+	newSource func(modelUUID string) (deltaSource, func(), error)
+
+	mu   sync.Mutex
+	hubs map[string]*deltaStreamHub
+}
+
+func newDeltaStreamHubs(pool *state.StatePool) *deltaStreamHubs {
+	return &deltaStreamHubs{
+		newSource: func(modelUUID string) (deltaSource, func(), error) {
+			st, releaser, err := pool.Get(modelUUID)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			return st.Watch(state.WatchParams{}), func() { releaser() }, nil
+		},
+		hubs: make(map[string]*deltaStreamHub),
+	}
+}
+
+// subscribe returns the hub for the given model, starting it if
+// necessary. The caller must call unsubscribe when done.
+func (hs *deltaStreamHubs) subscribe(modelUUID string) (*deltaStreamHub, error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hub, ok := hs.hubs[modelUUID]
+	if !ok {
+		source, done, err := hs.newSource(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		epoch := strconv.FormatInt(time.Now().UnixNano(), 36)
+		// A hub whose source has failed is removed, so that
+		// subsequent subscribers start a new one. The failed hub
+		// is still stopped when its remaining subscribers
+		// unsubscribe.
+		var newHub *deltaStreamHub
+		newHub = newDeltaStreamHub(epoch, source, done, func() {
+			hs.mu.Lock()
+			defer hs.mu.Unlock()
+			if hs.hubs[modelUUID] == newHub {
+				delete(hs.hubs, modelUUID)
+			}
+		})
+		hub = newHub
+		hs.hubs[modelUUID] = hub
+	}
+	hub.mu.Lock()
+	hub.subscribers++
+	hub.mu.Unlock()
+	return hub, nil
+}
+
+// unsubscribe releases a hub obtained from subscribe. The hub is
+// stopped when it has no remaining subscribers.
+func (hs *deltaStreamHubs) unsubscribe(modelUUID string, hub *deltaStreamHub) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hub.mu.Lock()
+	hub.subscribers--
+	remaining := hub.subscribers
+	hub.mu.Unlock()
+	if remaining > 0 {
+		return
+	}
+	if hs.hubs[modelUUID] == hub {
+		delete(hs.hubs, modelUUID)
+	}
+	if err := hub.source.Stop(); err != nil {
+		logger.Warningf("stopping delta stream watcher for model %s: %v", modelUUID, err)
+	}
+	hub.done()
+}
+
+// deltaStreamHandler serves the versioned model delta stream
+// endpoint, which streams AllWatcher deltas as JSON frames to
+// users with read access to the model.
+type deltaStreamHandler struct {
+	ctxt httpContext
+	hubs *deltaStreamHubs
+}
+
+func newDeltaStreamHandler(ctxt httpContext, hubs *deltaStreamHubs) http.Handler {
+	return &deltaStreamHandler{
+		ctxt: ctxt,
+		hubs: hubs,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the delta
+// stream API.
+//
+// Args for the HTTP request are as follows:
+//   bookmark -> string - the bookmark of the last frame received, if any
+//
+// Bookmarks are not persisted: they cannot be resumed after the API
+// server restarts, or on another API server, in which case the stream
+// starts with a snapshot frame.
+func (h *deltaStreamHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		modelUUID, err := h.authenticate(req)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		var cfg params.DeltaStreamConfig
+		query := req.URL.Query()
+		query.Del(":modeluuid")
+		if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+			h.sendError(conn, req, errors.Annotate(err, "decoding schema"))
+			return
+		}
+		hub, err := h.hubs.subscribe(modelUUID)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		defer h.hubs.unsubscribe(modelUUID, hub)
+
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the connection is always a json
+		// formatted simple error.
+		h.sendError(conn, req, nil)
+
+		// The client never sends us anything, but we must keep reading
+		// so that we notice when it goes away; otherwise the handler
+		// and its hub subscription would live until the next delta.
+		// The read is unblocked by conn.Close when the handler returns.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+		if err := serveDeltaStream(conn, hub, cfg.Bookmark, h.ctxt.stop(), closed); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("delta stream handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("delta stream handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// authenticate checks that the request is from a user with read
+// access to the model, and returns the model's UUID.
+func (h *deltaStreamHandler) authenticate(req *http.Request) (string, error) {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer releaser()
+	userTag, ok := entity.Tag().(names.UserTag)
+	if !ok {
+		return "", errors.Trace(common.ErrPerm)
+	}
+	access, err := st.UserPermission(userTag, names.NewModelTag(st.ModelUUID()))
+	if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	if !access.EqualOrGreaterModelAccessThan(permission.ReadAccess) {
+		return "", errors.Trace(common.ErrPerm)
+	}
+	return st.ModelUUID(), nil
+}
+
+// serveDeltaStream writes frames from the hub to the connection until
+// either the stop or closed channel is closed, or an error occurs.
+func serveDeltaStream(conn messageWriter, hub *deltaStreamHub, bookmark string, stop, closed <-chan struct{}) error {
+	for {
+		frames, generation, changed, err := hub.framesSince(bookmark)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, frame := range frames {
+			if err := conn.WriteJSON(frame); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if generation >= 0 {
+			bookmark = hub.bookmark(generation)
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-closed:
+			logger.Tracef("delta stream client disconnected")
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *deltaStreamHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
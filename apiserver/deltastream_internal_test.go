@@ -0,0 +1,282 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type deltaStreamSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&deltaStreamSuite{})
+
+type fakeDeltaSource struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func newFakeDeltaSource() *fakeDeltaSource {
+	return &fakeDeltaSource{
+		deltas:  make(chan []multiwatcher.Delta),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (s *fakeDeltaSource) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-s.deltas:
+		return deltas, nil
+	case <-s.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (s *fakeDeltaSource) Stop() error {
+	select {
+	case <-s.stopped:
+	default:
+		close(s.stopped)
+	}
+	return nil
+}
+
+func (s *fakeDeltaSource) send(c *gc.C, deltas ...multiwatcher.Delta) {
+	select {
+	case s.deltas <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending deltas")
+	}
+}
+
+func machineDelta(id string, removed bool) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Removed: removed,
+		Entity:  &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: id},
+	}
+}
+
+// waitGeneration waits for the hub to have received the given
+// number of frames.
+func waitGeneration(c *gc.C, hub *deltaStreamHub, generation int64) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		hub.mu.Lock()
+		current := hub.generation
+		hub.mu.Unlock()
+		if current >= generation {
+			return
+		}
+		select {
+		case <-time.After(coretesting.ShortWait):
+		case <-timeout:
+			c.Fatalf("timed out waiting for generation %d", generation)
+		}
+	}
+}
+
+func (s *deltaStreamSuite) newHub(c *gc.C) (*deltaStreamHub, *fakeDeltaSource) {
+	source := newFakeDeltaSource()
+	hub := newDeltaStreamHub("epoch", source, func() {}, nil)
+	s.AddCleanup(func(*gc.C) {
+		select {
+		case <-source.stopped:
+		default:
+			source.Stop()
+		}
+	})
+	return hub, source
+}
+
+func (s *deltaStreamSuite) TestSnapshotWithoutBookmark(c *gc.C) {
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false), machineDelta("1", false))
+	source.send(c, machineDelta("0", true))
+	waitGeneration(c, hub, 2)
+
+	frames, generation, _, err := hub.framesSince("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(generation, gc.Equals, int64(2))
+	c.Assert(frames, jc.DeepEquals, []params.DeltaStreamFrame{{
+		Bookmark: "epoch:2",
+		Reset:    true,
+		Deltas:   []multiwatcher.Delta{machineDelta("1", false)},
+	}})
+}
+
+func (s *deltaStreamSuite) TestResumeFromBookmark(c *gc.C) {
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false))
+	source.send(c, machineDelta("1", false))
+	source.send(c, machineDelta("0", true))
+	waitGeneration(c, hub, 3)
+
+	frames, generation, _, err := hub.framesSince("epoch:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(generation, gc.Equals, int64(3))
+	c.Assert(frames, jc.DeepEquals, []params.DeltaStreamFrame{{
+		Bookmark: "epoch:2",
+		Deltas:   []multiwatcher.Delta{machineDelta("1", false)},
+	}, {
+		Bookmark: "epoch:3",
+		Deltas:   []multiwatcher.Delta{machineDelta("0", true)},
+	}})
+}
+
+func (s *deltaStreamSuite) TestUnknownEpochGivesSnapshot(c *gc.C) {
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false))
+	waitGeneration(c, hub, 1)
+
+	frames, _, _, err := hub.framesSince("other:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(frames, gc.HasLen, 1)
+	c.Assert(frames[0].Reset, jc.IsTrue)
+}
+
+func (s *deltaStreamSuite) TestExpiredBookmarkGivesSnapshot(c *gc.C) {
+	s.PatchValue(&deltaStreamMaxFrames, 2)
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false))
+	source.send(c, machineDelta("1", false))
+	source.send(c, machineDelta("2", false))
+	source.send(c, machineDelta("3", false))
+	waitGeneration(c, hub, 4)
+
+	frames, _, _, err := hub.framesSince("epoch:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(frames, gc.HasLen, 1)
+	c.Assert(frames[0].Reset, jc.IsTrue)
+	c.Assert(frames[0].Deltas, gc.HasLen, 4)
+}
+
+func (s *deltaStreamSuite) TestChangedNotification(c *gc.C) {
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false))
+	waitGeneration(c, hub, 1)
+
+	_, _, changed, err := hub.framesSince("epoch:1")
+	c.Assert(err, jc.ErrorIsNil)
+	source.send(c, machineDelta("1", false))
+	select {
+	case <-changed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for change")
+	}
+}
+
+func (s *deltaStreamSuite) TestHubsStopWhenUnsubscribed(c *gc.C) {
+	source := newFakeDeltaSource()
+	released := false
+	hubs := &deltaStreamHubs{
+		newSource: func(modelUUID string) (deltaSource, func(), error) {
+			c.Assert(modelUUID, gc.Equals, "uuid")
+			return source, func() { released = true }, nil
+		},
+		hubs: make(map[string]*deltaStreamHub),
+	}
+	hub1, err := hubs.subscribe("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	hub2, err := hubs.subscribe("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hub1, gc.Equals, hub2)
+
+	hubs.unsubscribe("uuid", hub1)
+	c.Assert(released, jc.IsFalse)
+	hubs.unsubscribe("uuid", hub2)
+	c.Assert(released, jc.IsTrue)
+	c.Assert(hubs.hubs, gc.HasLen, 0)
+}
+
+func (s *deltaStreamSuite) TestHubsEvictFailedHub(c *gc.C) {
+	sources := []*fakeDeltaSource{newFakeDeltaSource(), newFakeDeltaSource()}
+	hubs := &deltaStreamHubs{
+		newSource: func(modelUUID string) (deltaSource, func(), error) {
+			source := sources[0]
+			sources = sources[1:]
+			return source, func() {}, nil
+		},
+		hubs: make(map[string]*deltaStreamHub),
+	}
+	failing := sources[0]
+	hub1, err := hubs.subscribe("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	defer hubs.unsubscribe("uuid", hub1)
+	_, _, changed, err := hub1.framesSince("")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Stopping the source out from under the hub makes it fail.
+	failing.Stop()
+	select {
+	case <-changed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for hub to fail")
+	}
+	_, _, _, err = hub1.framesSince("")
+	c.Assert(err, gc.ErrorMatches, "watcher was stopped")
+
+	// The failed hub is evicted, so a new subscriber gets a new hub.
+	timeout := time.After(coretesting.LongWait)
+	for {
+		hubs.mu.Lock()
+		_, ok := hubs.hubs["uuid"]
+		hubs.mu.Unlock()
+		if !ok {
+			break
+		}
+		select {
+		case <-time.After(coretesting.ShortWait):
+		case <-timeout:
+			c.Fatalf("timed out waiting for hub to be evicted")
+		}
+	}
+	hub2, err := hubs.subscribe("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	defer hubs.unsubscribe("uuid", hub2)
+	c.Assert(hub2, gc.Not(gc.Equals), hub1)
+}
+
+type recordingWriter struct {
+	frames chan interface{}
+}
+
+func (w *recordingWriter) WriteJSON(v interface{}) error {
+	w.frames <- v
+	return nil
+}
+
+func (s *deltaStreamSuite) TestServeStopsWhenClientCloses(c *gc.C) {
+	hub, source := s.newHub(c)
+	source.send(c, machineDelta("0", false))
+	waitGeneration(c, hub, 1)
+
+	writer := &recordingWriter{frames: make(chan interface{}, 10)}
+	closed := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- serveDeltaStream(writer, hub, "", nil, closed)
+	}()
+	select {
+	case <-writer.frames:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for snapshot frame")
+	}
+
+	close(closed)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for serveDeltaStream to return")
+	}
+}
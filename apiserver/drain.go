@@ -0,0 +1,305 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	pubsubapiserver "github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/rpc"
+)
+
+const (
+	// drainInterval is the time between the batches of connections
+	// closed by a draining API server.
+	drainInterval = time.Second
+
+	// drainBatchSize is the number of connections closed in each
+	// batch, so that clients reconnecting to the other API servers
+	// don't arrive all at once.
+	drainBatchSize = 20
+)
+
+// isDraining reports whether the server is draining its connections.
+func (srv *Server) isDraining() bool {
+	return atomic.LoadInt32(&srv.draining) != 0
+}
+
+// setDraining starts or stops draining the server's connections.
+func (srv *Server) setDraining(drain bool) {
+	var value int32
+	if drain {
+		value = 1
+	}
+	if atomic.SwapInt32(&srv.draining, value) == value {
+		return
+	}
+	if drain {
+		logger.Infof("draining API connections")
+	} else {
+		logger.Infof("no longer draining API connections")
+	}
+	select {
+	case srv.drainChanged <- struct{}{}:
+	default:
+	}
+}
+
+// addConn records an open API connection, so that it can be closed
+// when the server drains.
+func (srv *Server) addConn(conn *rpc.Conn) {
+	srv.connsMu.Lock()
+	defer srv.connsMu.Unlock()
+	srv.conns[conn] = false
+}
+
+// removeConn forgets a closed API connection.
+func (srv *Server) removeConn(conn *rpc.Conn) {
+	srv.connsMu.Lock()
+	defer srv.connsMu.Unlock()
+	delete(srv.conns, conn)
+}
+
+// exemptFromDrain records that the connection must be kept open while
+// the server drains. Controller machine agents are exempt, because the
+// controllers forward hub messages to each other over their API
+// connections.
+func (srv *Server) exemptFromDrain(conn *rpc.Conn) {
+	srv.connsMu.Lock()
+	defer srv.connsMu.Unlock()
+	if _, ok := srv.conns[conn]; ok {
+		srv.conns[conn] = true
+	}
+}
+
+// drainableConns returns the number of connections that will be
+// closed while the server drains.
+func (srv *Server) drainableConns() int64 {
+	srv.connsMu.Lock()
+	defer srv.connsMu.Unlock()
+	var count int64
+	for _, exempt := range srv.conns {
+		if !exempt {
+			count++
+		}
+	}
+	return count
+}
+
+// closeConns closes up to n of the server's API connections that are
+// not exempt from draining. Clients are expected to reconnect to one
+// of the other API servers.
+func (srv *Server) closeConns(n int) {
+	srv.connsMu.Lock()
+	conns := make([]*rpc.Conn, 0, n)
+	for conn, exempt := range srv.conns {
+		if len(conns) == n {
+			break
+		}
+		if !exempt {
+			conns = append(conns, conn)
+		}
+	}
+	srv.connsMu.Unlock()
+
+	for _, conn := range conns {
+		if err := conn.Close(); err != nil {
+			logger.Debugf("closing drained connection: %v", err)
+		}
+	}
+}
+
+// machineID returns the id of the server's controller machine, or ""
+// if the server is not running in a machine agent.
+func (srv *Server) machineID() string {
+	if tag, ok := srv.tag.(names.MachineTag); ok {
+		return tag.Id()
+	}
+	return ""
+}
+
+// drainer responds to drain requests published on the central hub,
+// and while the server is draining, closes its API connections in
+// batches and publishes its progress.
+func (srv *Server) drainer() error {
+	unsubRequests, err := srv.centralHub.Subscribe(pubsubapiserver.DrainTopic, srv.onDrainRequest)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to drain requests")
+	}
+	defer unsubRequests()
+	unsubStatus, err := srv.centralHub.Subscribe(pubsubapiserver.DrainStatusTopic, srv.onDrainStatus)
+	if err != nil {
+		return errors.Annotate(err, "subscribing to drain status")
+	}
+	defer unsubStatus()
+
+	var (
+		next     <-chan time.Time
+		reported = int64(-1)
+	)
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case <-srv.drainChanged:
+			reported = -1
+		case <-next:
+		}
+		draining := srv.isDraining()
+		next = nil
+		if draining {
+			srv.closeConns(drainBatchSize)
+			next = srv.clock.After(drainInterval)
+		}
+		connections := srv.drainableConns()
+		if connections == reported {
+			continue
+		}
+		reported = connections
+		_, err := srv.centralHub.Publish(pubsubapiserver.DrainStatusTopic, pubsubapiserver.DrainStatus{
+			ID:          srv.machineID(),
+			Draining:    draining,
+			Connections: connections,
+		})
+		if err != nil {
+			logger.Warningf("cannot publish drain status: %v", err)
+		}
+	}
+}
+
+func (srv *Server) onDrainRequest(topic string, req pubsubapiserver.DrainRequest, err error) {
+	if err != nil {
+		logger.Errorf("drain request callback error: %v", err)
+		return
+	}
+	if id := srv.machineID(); id == "" || id != req.ID {
+		return
+	}
+	srv.setDraining(req.Drain)
+}
+
+func (srv *Server) onDrainStatus(topic string, status pubsubapiserver.DrainStatus, err error) {
+	if err != nil {
+		logger.Errorf("drain status callback error: %v", err)
+		return
+	}
+	if status.ID == "" {
+		return
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.drainStatus[status.ID] = status
+}
+
+// drainStatuses returns the most recently reported drain status of
+// each API server, sorted by machine id.
+func (srv *Server) drainStatuses() []params.APIServerDrainStatus {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	result := make([]params.APIServerDrainStatus, 0, len(srv.drainStatus))
+	for _, status := range srv.drainStatus {
+		result = append(result, params.APIServerDrainStatus{
+			MachineId:   status.ID,
+			Draining:    status.Draining,
+			Connections: status.Connections,
+			Idle:        status.Draining && status.Connections == 0,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MachineId < result[j].MachineId
+	})
+	return result
+}
+
+// drainHandler is used to drain API servers ahead of upgrades and
+// restarts, and to report their progress. A POST request asks the
+// API server of a controller machine to start or stop draining; a GET
+// request returns the drain status of the API servers. Both require
+// superuser access to the controller.
+type drainHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *drainHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "GET":
+		err = h.handleGet(w, req)
+	case "POST":
+		err = h.handlePost(w, req)
+	default:
+		err = emitUnsupportedMethodErr(req.Method)
+	}
+	if err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *drainHandler) handleGet(w http.ResponseWriter, req *http.Request) error {
+	if err := h.checkAuth(req); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, params.APIServerDrainResponse{
+		Servers: h.ctxt.srv.drainStatuses(),
+	}))
+}
+
+func (h *drainHandler) handlePost(w http.ResponseWriter, req *http.Request) error {
+	if ctype := req.Header.Get("Content-Type"); ctype != params.ContentTypeJSON {
+		return errors.BadRequestf("invalid content type %q: expected %q", ctype, params.ContentTypeJSON)
+	}
+	if err := h.checkAuth(req); err != nil {
+		return errors.Trace(err)
+	}
+	var drainReq params.APIServerDrainRequest
+	if err := json.NewDecoder(req.Body).Decode(&drainReq); err != nil {
+		return errors.NewBadRequest(err, "invalid request body")
+	}
+	if !names.IsValidMachine(drainReq.MachineId) {
+		return errors.BadRequestf("invalid machine id %q", drainReq.MachineId)
+	}
+	_, err := h.ctxt.srv.centralHub.Publish(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{
+		ID:    drainReq.MachineId,
+		Drain: drainReq.Drain,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot publish drain request")
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, &params.ErrorResult{}))
+}
+
+func (h *drainHandler) checkAuth(req *http.Request) error {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		entity.Tag(),
+		permission.SuperuserAccess,
+		st.ControllerTag(),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.Trace(common.ErrPerm)
+	}
+	return nil
+}
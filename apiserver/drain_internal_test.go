@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	pubsubapiserver "github.com/juju/juju/pubsub/apiserver"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type drainInternalSuite struct {
+	coretesting.BaseSuite
+	srv *Server
+}
+
+var _ = gc.Suite(&drainInternalSuite{})
+
+func (s *drainInternalSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.srv = &Server{
+		tag:          names.NewMachineTag("1"),
+		drainChanged: make(chan struct{}, 1),
+		drainStatus:  make(map[string]pubsubapiserver.DrainStatus),
+	}
+}
+
+func (s *drainInternalSuite) TestDrainRequestForOtherMachine(c *gc.C) {
+	s.srv.onDrainRequest(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{ID: "2", Drain: true}, nil)
+	c.Assert(s.srv.isDraining(), jc.IsFalse)
+	s.assertNotChanged(c)
+}
+
+func (s *drainInternalSuite) TestDrainRequest(c *gc.C) {
+	s.srv.onDrainRequest(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{ID: "1", Drain: true}, nil)
+	c.Assert(s.srv.isDraining(), jc.IsTrue)
+	s.assertChanged(c)
+
+	// Repeated requests have no effect.
+	s.srv.onDrainRequest(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{ID: "1", Drain: true}, nil)
+	s.assertNotChanged(c)
+
+	s.srv.onDrainRequest(pubsubapiserver.DrainTopic, pubsubapiserver.DrainRequest{ID: "1", Drain: false}, nil)
+	c.Assert(s.srv.isDraining(), jc.IsFalse)
+	s.assertChanged(c)
+}
+
+func (s *drainInternalSuite) TestDrainStatuses(c *gc.C) {
+	for _, status := range []pubsubapiserver.DrainStatus{
+		{ID: "2", Draining: true, Connections: 0},
+		{ID: "0", Draining: false, Connections: 12},
+		{ID: "1", Draining: true, Connections: 3},
+		{ID: "0", Draining: false, Connections: 10},
+	} {
+		s.srv.onDrainStatus(pubsubapiserver.DrainStatusTopic, status, nil)
+	}
+	c.Assert(s.srv.drainStatuses(), jc.DeepEquals, []params.APIServerDrainStatus{
+		{MachineId: "0", Connections: 10},
+		{MachineId: "1", Draining: true, Connections: 3},
+		{MachineId: "2", Draining: true, Idle: true},
+	})
+}
+
+func (s *drainInternalSuite) assertChanged(c *gc.C) {
+	select {
+	case <-s.srv.drainChanged:
+	default:
+		c.Fatalf("drain change not signalled")
+	}
+}
+
+func (s *drainInternalSuite) assertNotChanged(c *gc.C) {
+	select {
+	case <-s.srv.drainChanged:
+		c.Fatalf("unexpected drain change")
+	default:
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing/factory"
+)
+
+type drainSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&drainSuite{})
+
+func (s *drainSuite) drainURL(c *gc.C) string {
+	uri := s.baseURL(c)
+	uri.Path = "/drain"
+	return uri.String()
+}
+
+func (s *drainSuite) assertError(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *drainSuite) TestRequiresAuthentication(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.drainURL(c)})
+	s.assertError(c, resp, http.StatusUnauthorized, ".*no credentials provided$")
+}
+
+func (s *drainSuite) TestRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.drainURL(c),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *drainSuite) TestGetStatus(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.drainURL(c)})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.APIServerDrainResponse
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Servers, gc.HasLen, 0)
+}
+
+func (s *drainSuite) TestPostRequest(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.drainURL(c),
+		contentType: params.ContentTypeJSON,
+		jsonBody:    params.APIServerDrainRequest{MachineId: "42", Drain: true},
+	})
+	assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+}
+
+func (s *drainSuite) TestPostInvalidMachine(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.drainURL(c),
+		contentType: params.ContentTypeJSON,
+		jsonBody:    params.APIServerDrainRequest{MachineId: "machine-0"},
+	})
+	s.assertError(c, resp, http.StatusBadRequest, `invalid machine id "machine-0"`)
+}
+
+func (s *drainSuite) TestPostInvalidContentType(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.drainURL(c),
+		contentType: "text/plain",
+	})
+	s.assertError(c, resp, http.StatusBadRequest, `invalid content type "text/plain": expected "application/json"`)
+}
+
+func (s *drainSuite) TestUnsupportedMethod(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.drainURL(c)})
+	s.assertError(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
@@ -146,6 +146,20 @@ func TestingAnonymousRoot() rpc.Root {
 	return restrictRoot(r, anonymousFacadesOnly)
 }
 
+// TestingTokenRoot returns a restricted srvRoot as if logged in with
+// an API token with the given capabilities.
+func TestingTokenRoot(capabilities ...string) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, tokenCapabilitiesOnly(capabilities))
+}
+
+// TestingAgentTokenRecoveryRoot returns a restricted srvRoot as if
+// logged in by an agent with an expired token.
+func TestingAgentTokenRecoveryRoot() rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, agentTokenRecoveryMethodsOnly)
+}
+
 // TestingControllerOnlyRoot returns a restricted srvRoot as if
 // logged in to the root of the API path.
 func TestingControllerOnlyRoot() rpc.Root {
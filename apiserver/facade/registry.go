@@ -18,6 +18,10 @@ import (
 type record struct {
 	factory    Factory
 	facadeType reflect.Type
+
+	// deprecated holds a message explaining why the facade
+	// version is deprecated, or is empty if it is not.
+	deprecated string
 }
 
 // versions is our internal structure for tracking specific versions of a
@@ -189,6 +193,53 @@ func (f *Registry) Discard(name string, version int) {
 	}
 }
 
+// Deprecate marks a registered facade version as deprecated. The
+// message should tell clients what to use instead. Deprecated facades
+// continue to be served, but are reported to clients so that they can
+// warn their users before the facade is removed.
+func (f *Registry) Deprecate(name string, version int, message string) error {
+	record, err := f.lookup(name, version)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if message == "" {
+		return errors.NotValidf("empty deprecation message")
+	}
+	record.deprecated = message
+	f.facades[name][version] = record
+	return nil
+}
+
+// Deprecation describes a deprecated facade version.
+type Deprecation struct {
+	// Name is the name of the facade.
+	Name string
+	// Version holds the deprecated version of the facade.
+	Version int
+	// Message explains the deprecation.
+	Message string
+}
+
+// ListDeprecated returns the deprecated facade versions in f,
+// ordered by name and version.
+func (f *Registry) ListDeprecated() []Deprecation {
+	var deprecations []Deprecation
+	for _, description := range f.List() {
+		for _, version := range description.Versions {
+			record := f.facades[description.Name][version]
+			if record.deprecated == "" {
+				continue
+			}
+			deprecations = append(deprecations, Deprecation{
+				Name:    description.Name,
+				Version: version,
+				Message: record.deprecated,
+			})
+		}
+	}
+	return deprecations
+}
+
 // niceFactory defines the preferred facade registration function signature.
 type niceFactory func(Context) (interface{}, error)
 
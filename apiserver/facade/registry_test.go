@@ -135,6 +135,38 @@ func (*RegistrySuite) TestRegisterAlreadyPresent(c *gc.C) {
 	c.Check(*asIntPtr, gc.Equals, 100)
 }
 
+func (*RegistrySuite) TestDeprecate(c *gc.C) {
+	registry := &facade.Registry{}
+	assertRegister(c, registry, "b", 1)
+	assertRegister(c, registry, "a", 1)
+	assertRegister(c, registry, "a", 2)
+	assertRegister(c, registry, "a", 3)
+	c.Assert(registry.ListDeprecated(), gc.HasLen, 0)
+
+	err := registry.Deprecate("a", 2, "use a(3)")
+	c.Assert(err, jc.ErrorIsNil)
+	err = registry.Deprecate("a", 1, "use a(3)")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(registry.ListDeprecated(), jc.DeepEquals, []facade.Deprecation{
+		{Name: "a", Version: 1, Message: "use a(3)"},
+		{Name: "a", Version: 2, Message: "use a(3)"},
+	})
+
+	// Deprecated facades are still available.
+	factory, err := registry.GetFactory("a", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(factory, gc.NotNil)
+}
+
+func (*RegistrySuite) TestDeprecateUnknown(c *gc.C) {
+	registry := &facade.Registry{}
+	assertRegister(c, registry, "a", 1)
+	err := registry.Deprecate("a", 2, "gone")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = registry.Deprecate("a", 1, "")
+	c.Assert(err, gc.ErrorMatches, "empty deprecation message not valid")
+}
+
 func (*RegistrySuite) TestGetFactory(c *gc.C) {
 	registry := &facade.Registry{}
 	assertRegister(c, registry, "name", 0)
@@ -63,6 +63,22 @@ func NewAgentAPIV2(st *state.State, resources facade.Resources, auth facade.Auth
 	}, nil
 }
 
+// AgentAPIV3 implements the version 3 of the API provided to an agent,
+// which adds RefreshToken.
+type AgentAPIV3 struct {
+	*AgentAPIV2
+}
+
+// NewAgentAPIV3 returns an object implementing version 3 of the Agent API
+// with the given authorizer representing the currently logged in client.
+func NewAgentAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV3, error) {
+	api, err := NewAgentAPIV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &AgentAPIV3{api}, nil
+}
+
 func (api *AgentAPIV2) GetEntities(args params.Entities) params.AgentGetEntitiesResults {
 	results := params.AgentGetEntitiesResults{
 		Entities: make([]params.AgentGetEntitiesResult, len(args.Entities)),
@@ -188,3 +204,66 @@ func (api *AgentAPIV2) WatchCredentials(args params.Entities) (params.NotifyWatc
 	}
 	return results, nil
 }
+
+// RefreshToken issues a new token for the authenticated agent, which
+// replaces its password. The token is valid for the controller's
+// configured agent token lifetime. Controller machine agents are not
+// issued tokens, since their password is also used to connect to mongo.
+func (api *AgentAPIV3) RefreshToken() (params.AgentTokenResult, error) {
+	entity, err := api.tokenHolder()
+	if err != nil {
+		return params.AgentTokenResult{}, errors.Trace(err)
+	}
+	controllerConfig, err := api.st.ControllerConfig()
+	if err != nil {
+		return params.AgentTokenResult{}, errors.Trace(err)
+	}
+	lifetime := controllerConfig.AgentTokenLifetime()
+	token, expires, err := api.st.IssueAgentToken(entity, lifetime)
+	if err != nil {
+		return params.AgentTokenResult{}, errors.Trace(err)
+	}
+	return params.AgentTokenResult{
+		Token:   token,
+		Issued:  expires.Add(-lifetime),
+		Expires: expires,
+	}, nil
+}
+
+// TokenValidity returns the times at which the authenticated agent's
+// current token was issued and expires, so that the agent can decide
+// when to refresh it. The times are zero if the agent has not yet been
+// issued a token.
+func (api *AgentAPIV3) TokenValidity() (params.AgentTokenResult, error) {
+	entity, err := api.tokenHolder()
+	if err != nil {
+		return params.AgentTokenResult{}, errors.Trace(err)
+	}
+	issued, expires, err := api.st.AgentTokenValidity(entity.Tag())
+	if errors.IsNotFound(err) {
+		return params.AgentTokenResult{}, nil
+	} else if err != nil {
+		return params.AgentTokenResult{}, errors.Trace(err)
+	}
+	return params.AgentTokenResult{
+		Issued:  issued,
+		Expires: expires,
+	}, nil
+}
+
+// tokenHolder returns the authenticated agent's entity, if it may be
+// issued tokens.
+func (api *AgentAPIV3) tokenHolder() (state.AgentTokenHolder, error) {
+	entity0, err := api.st.FindEntity(api.auth.GetAuthTag())
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	entity, ok := entity0.(state.AgentTokenHolder)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	if machine, ok := entity.(*state.Machine); ok && machine.IsManager() {
+		return nil, errors.NotSupportedf("agent tokens for controller machines")
+	}
+	return entity, nil
+}
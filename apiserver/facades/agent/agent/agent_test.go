@@ -5,7 +5,9 @@ package agent_test
 
 import (
 	stdtesting "testing"
+	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -276,3 +279,66 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+func (s *agentSuite) TestRefreshToken(c *gc.C) {
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.RefreshToken()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Token, gc.Not(gc.Equals), "")
+	c.Assert(result.Expires.After(time.Now()), jc.IsTrue)
+	c.Assert(result.Expires.Sub(result.Issued), gc.Equals, 24*time.Hour)
+
+	err = s.machine1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine1.PasswordValid(result.Token), jc.IsTrue)
+	valid, err := s.State.AgentCredentialsValid(s.machine1, result.Token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid, jc.IsTrue)
+}
+
+func (s *agentSuite) TestRefreshTokenController(c *gc.C) {
+	auth := s.authorizer
+	auth.Tag = s.machine0.Tag()
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.RefreshToken()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *agentSuite) TestRefreshTokenRevoked(c *gc.C) {
+	err := s.State.RevokeAgentTokens(s.machine1.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.RefreshToken()
+	c.Assert(err, gc.ErrorMatches, "cannot issue agent token for machine 1: agent tokens for machine 1 have been revoked")
+}
+
+func (s *agentSuite) TestRefreshTokenConfiguredLifetime(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AgentTokenLifetime: "168h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.RefreshToken()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Expires.Sub(result.Issued), gc.Equals, 168*time.Hour)
+}
+
+func (s *agentSuite) TestTokenValidity(c *gc.C) {
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.TokenValidity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentTokenResult{})
+
+	refreshed, err := api.RefreshToken()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.TokenValidity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Token, gc.Equals, "")
+	c.Assert(result.Issued.Equal(refreshed.Issued), jc.IsTrue)
+	c.Assert(result.Expires.Equal(refreshed.Expires), jc.IsTrue)
+}
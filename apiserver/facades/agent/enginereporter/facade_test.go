@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereporter_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/enginereporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *enginereporter.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = new(mockBackend)
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/1"),
+	}
+	facade, err := enginereporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := enginereporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestSetEngineReports(c *gc.C) {
+	args := params.EngineReports{
+		Reports: []params.EngineReport{{
+			Tag:    names.NewUnitTag("mysql/0").String(),
+			Report: "not mine",
+		}, {
+			Tag:    names.NewUnitTag("mysql/1").String(),
+			Report: "mine",
+		}, {
+			Tag:    "invalid",
+			Report: "whatever",
+		}},
+	}
+	result, err := s.facade.SetEngineReports(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SetEngineReport",
+		[]interface{}{names.NewUnitTag("mysql/1"), "mine"},
+	}})
+}
+
+type mockBackend struct {
+	stub jujutesting.Stub
+}
+
+func (backend *mockBackend) SetEngineReport(tag names.Tag, report string) error {
+	backend.stub.AddCall("SetEngineReport", tag, report)
+	return nil
+}
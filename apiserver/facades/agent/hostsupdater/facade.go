@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostsupdater implements the API facade used by the
+// hostsupdater worker.
+package hostsupdater
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the State API used by the hostsupdater facade.
+type Backend interface {
+	HostEntries(machineId string) ([]state.HostEntry, error)
+	WatchHostEntries() state.NotifyWatcher
+}
+
+// Facade implements the API required by the hostsupdater worker.
+type Facade struct {
+	backend      Backend
+	resources    facade.Resources
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the hostsupdater worker.
+func New(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// WatchHostEntries returns a NotifyWatcher that triggers when the host
+// entries of any machine may have changed.
+func (facade *Facade) WatchHostEntries() (params.NotifyWatchResult, error) {
+	w := facade.backend.WatchHostEntries()
+	// Consume the initial event; the watch call itself conveys it.
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(w)
+}
+
+// HostEntries returns the host entries that should resolve on each of
+// the given machines. Agents may only ask about their own machine.
+func (facade *Facade) HostEntries(args params.Entities) (params.HostEntriesResults, error) {
+	results := params.HostEntriesResults{
+		Results: make([]params.HostEntriesResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		entries, err := facade.backend.HostEntries(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, entry := range entries {
+			results.Results[i].Entries = append(results.Results[i].Entries, params.HostEntry{
+				Address: entry.Address,
+				Names:   entry.Names,
+			})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostsupdater_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/hostsupdater"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *hostsupdater.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		watcher: apiservertesting.NewFakeNotifyWatcher(),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := hostsupdater.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := hostsupdater.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestWatchHostEntries(c *gc.C) {
+	result, err := s.facade.WatchHostEntries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *facadeSuite) TestHostEntries(c *gc.C) {
+	s.backend.entries = []state.HostEntry{{
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-1-lxd-0", "wordpress-0"},
+	}}
+	result, err := s.facade.HostEntries(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HostEntriesResults{
+		Results: []params.HostEntriesResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Entries: []params.HostEntry{{
+				Address: "10.0.0.2",
+				Names:   []string{"juju-abcdef-1-lxd-0", "wordpress-0"},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"HostEntries", []interface{}{"1"},
+	}})
+}
+
+func (s *facadeSuite) TestHostEntriesError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	result, err := s.facade.HostEntries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	entries []state.HostEntry
+	watcher *apiservertesting.FakeNotifyWatcher
+}
+
+func (backend *mockBackend) HostEntries(machineId string) ([]state.HostEntry, error) {
+	backend.stub.AddCall("HostEntries", machineId)
+	if err := backend.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return backend.entries, nil
+}
+
+func (backend *mockBackend) WatchHostEntries() state.NotifyWatcher {
+	return backend.watcher
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logsampling"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
@@ -82,6 +83,8 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 }
 
 // LoggingConfig reports the logging configuration for the agents specified.
+// Log sampling rules are enforced by the controller, so they are left out
+// of the configuration passed to agents.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
@@ -97,7 +100,7 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
 			if configErr == nil {
-				results[i].Result = config.LoggingConfig()
+				results[i].Result = logsampling.LevelsOnly(config.LoggingConfig())
 				err = nil
 			} else {
 				err = configErr
@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinehealth implements the API facade used by the
+// machinehealth worker.
+package machinehealth
+
+import (
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Backend defines the State API used by the machinehealth facade.
+type Backend interface {
+	SetMachineHealthProblems(tag names.MachineTag, problems []string) error
+}
+
+// Facade implements the API required by the machinehealth worker.
+type Facade struct {
+	backend      Backend
+	clock        clock.Clock
+	getCanModify common.GetAuthFunc
+}
+
+// New returns a new API facade for the machinehealth worker.
+func New(backend Backend, clock clock.Clock, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+		clock:   clock,
+		getCanModify: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// ControllerTime returns the controller's current time, so that
+// machine agents can detect skew in their own clocks.
+func (facade *Facade) ControllerTime() (params.ControllerTimeResult, error) {
+	return params.ControllerTimeResult{Time: facade.clock.Now().UTC()}, nil
+}
+
+// SetHealthProblems records the health problems found by one or more
+// machine agents. Agents may only set their own machine's problems.
+func (facade *Facade) SetHealthProblems(args params.SetMachineHealthProblems) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+
+	canModify, err := facade.getCanModify()
+	if err != nil {
+		return results, err
+	}
+
+	for i, arg := range args.Machines {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canModify(tag) {
+			err = facade.backend.SetMachineHealthProblems(tag, arg.Problems)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/machinehealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	clock      *jujutesting.Clock
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *machinehealth.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = new(mockBackend)
+	s.clock = jujutesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := machinehealth.New(s.backend, s.clock, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := machinehealth.New(s.backend, s.clock, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestControllerTime(c *gc.C) {
+	result, err := s.facade.ControllerTime()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ControllerTimeResult{
+		Time: time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *facadeSuite) TestSetHealthProblems(c *gc.C) {
+	args := params.SetMachineHealthProblems{
+		Machines: []params.MachineHealthProblems{{
+			Tag:      names.NewMachineTag("0").String(),
+			Problems: []string{"not mine"},
+		}, {
+			Tag:      names.NewMachineTag("1").String(),
+			Problems: []string{"root disk 95% full"},
+		}, {
+			Tag:      names.NewUnitTag("mysql/0").String(),
+			Problems: []string{"not a machine"},
+		}},
+	}
+	result, err := s.facade.SetHealthProblems(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{nil},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SetMachineHealthProblems",
+		[]interface{}{names.NewMachineTag("1"), []string{"root disk 95% full"}},
+	}})
+}
+
+type mockBackend struct {
+	stub jujutesting.Stub
+}
+
+func (backend *mockBackend) SetMachineHealthProblems(tag names.MachineTag, problems []string) error {
+	backend.stub.AddCall("SetMachineHealthProblems", tag, problems)
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied *state.State as a Backend.
+func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(backendShim{st}, clock.WallClock, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// SetMachineHealthProblems is part of the Backend interface.
+func (shim backendShim) SetMachineHealthProblems(tag names.MachineTag, problems []string) error {
+	m, err := shim.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.SetHealthProblems(problems)
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// ProvisionerAPIV6 adds the charm LXD profile methods.
+type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV5
+}
+
+// NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
+	provisionerAPI, err := NewProvisionerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV6{provisionerAPI}, nil
+}
+
+// machineCharmLXDProfiles returns the charm LXD profiles, keyed by name,
+// that the machine should have.
+func machineCharmLXDProfiles(m *state.Machine) (map[string]params.CharmLXDProfile, error) {
+	profiles, err := m.CharmLXDProfiles()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.CharmLXDProfile)
+	for name, profile := range profiles {
+		result[name] = params.CharmLXDProfile{
+			Config:      profile.Config,
+			Description: profile.Description,
+			Devices:     profile.Devices,
+		}
+	}
+	return result, nil
+}
+
+// CharmProfiles returns, for each given machine, the names of the charm
+// LXD profiles applied to its instance and the profiles it should have.
+func (p *ProvisionerAPIV6) CharmProfiles(args params.Entities) (params.CharmProfilesResults, error) {
+	result := params.CharmProfilesResults{
+		Results: make([]params.CharmProfilesResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		profiles, err := machineCharmLXDProfiles(machine)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Current = machine.CharmProfiles()
+		result.Results[i].Profiles = profiles
+	}
+	return result, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to each given machine's instance.
+func (p *ProvisionerAPIV6) SetCharmProfiles(args params.SetCharmProfiles) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Machines {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetCharmProfiles(arg.Profiles)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchCharmProfiles returns a NotifyWatcher that notifies when the
+// charm LXD profiles that machines need may have changed.
+func (p *ProvisionerAPIV6) WatchCharmProfiles() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := p.st.WatchCharmProfiles()
+	// Consume any initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = p.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/facades/agent/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testcharms"
+)
+
+type charmProfilesSuite struct {
+	provisionerSuite
+	api *provisioner.ProvisionerAPIV6
+}
+
+var _ = gc.Suite(&charmProfilesSuite{})
+
+func (s *charmProfilesSuite) SetUpTest(c *gc.C) {
+	s.setUpTest(c, false)
+	api, err := provisioner.NewProvisionerAPIV6(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *charmProfilesSuite) addUnitWithProfile(c *gc.C, m *state.Machine) {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("dummy"),
+		ID:          charm.MustParseURL("local:quantal/dummy-1"),
+		StoragePath: "dummy-1",
+		SHA256:      "dummy-1-sha256",
+		LXDProfile: &lxdprofile.Profile{
+			Config: map[string]string{"security.nesting": "true"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "lxd-profile", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmProfilesSuite) TestCharmProfiles(c *gc.C) {
+	s.addUnitWithProfile(c, s.machines[0])
+	err := s.machines[1].SetCharmProfiles([]string{"juju-controller-old-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.CharmProfiles(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "unit-foo-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmProfilesResults{
+		Results: []params.CharmProfilesResult{{
+			Profiles: map[string]params.CharmLXDProfile{
+				"juju-controller-lxd-profile-1": {
+					Config: map[string]string{"security.nesting": "true"},
+				},
+			},
+		}, {
+			Current: []string{"juju-controller-old-1"},
+		}, {
+			Error: apiservertesting.NotFoundError("machine 42"),
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *charmProfilesSuite) TestSetCharmProfiles(c *gc.C) {
+	result, err := s.api.SetCharmProfiles(params.SetCharmProfiles{
+		Machines: []params.MachineCharmProfiles{
+			{Tag: s.machines[0].Tag().String(), Profiles: []string{"juju-controller-lxd-profile-1"}},
+			{Tag: "machine-42"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].CharmProfiles(), jc.DeepEquals, []string{"juju-controller-lxd-profile-1"})
+}
+
+func (s *charmProfilesSuite) TestProvisioningInfoIncludesProfiles(c *gc.C) {
+	s.addUnitWithProfile(c, s.machines[0])
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.CharmLXDProfiles, jc.DeepEquals, map[string]params.CharmLXDProfile{
+		"juju-controller-lxd-profile-1": {
+			Config: map[string]string{"security.nesting": "true"},
+		},
+	})
+}
+
+func (s *charmProfilesSuite) TestWatchCharmProfiles(c *gc.C) {
+	result, err := s.api.WatchCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.addUnitWithProfile(c, s.machines[0])
+	wc.AssertOneChange()
+}
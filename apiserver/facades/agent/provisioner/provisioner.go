@@ -110,8 +110,12 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 	if err != nil {
 		return nil, err
 	}
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, err
+	}
 	urlGetter := common.NewToolsURLGetter(model.UUID(), st)
-	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(env)
+	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(env, controllerConfig)
 	return &ProvisionerAPI{
 		Remover:                 common.NewRemover(st, false, getAuthFunc),
 		StatusSetter:            common.NewStatusSetter(st, getAuthFunc),
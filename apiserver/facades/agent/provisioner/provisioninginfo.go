@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/imagecommon"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
@@ -96,6 +97,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	charmProfiles, err := machineCharmLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm lxd profiles")
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -108,6 +114,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
 		ControllerConfig:  controllerCfg,
+		CharmLXDProfiles:  charmProfiles,
 	}, nil
 }
 
@@ -443,6 +450,9 @@ func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageC
 		logger.Infof("could not get image metadata from controller: %v", err)
 	}
 	logger.Debugf("got from controller %d metadata", len(stateMetadata))
+	// Custom image metadata may refer to images that have been
+	// deleted from the cloud since it was added.
+	stateMetadata = imagecommon.FilterMissingImages(env, stateMetadata)
 	// No need to look in data sources if found in state.
 	if len(stateMetadata) != 0 {
 		return stateMetadata, nil
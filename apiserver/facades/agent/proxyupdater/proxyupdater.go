@@ -4,6 +4,7 @@
 package proxyupdater
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/utils/proxy"
 	"gopkg.in/juju/names.v2"
 
@@ -23,6 +24,17 @@ type Backend interface {
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
 	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// AgentMachine returns the machine hosting the agent with the
+	// given tag: the machine itself for a machine agent, or the
+	// machine the unit is assigned to for a unit agent.
+	AgentMachine(names.Tag) (Machine, error)
+}
+
+// Machine defines the state methods of a machine this facade needs.
+type Machine interface {
+	Addresses() []network.Address
+	Watch() state.NotifyWatcher
 }
 
 type ProxyUpdaterAPI struct {
@@ -43,12 +55,20 @@ func NewAPIWithBacking(st Backend, resources facade.Resources, authorizer facade
 	}, nil
 }
 
-func (api *ProxyUpdaterAPI) oneWatch() params.NotifyWatchResult {
+func (api *ProxyUpdaterAPI) oneWatch(tag names.Tag) params.NotifyWatchResult {
 	var result params.NotifyWatchResult
 
+	// The machine is watched because its addresses are included in
+	// the computed no-proxy settings.
+	machine, err := api.backend.AgentMachine(tag)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
 	watch := common.NewMultiNotifyWatcher(
 		api.backend.WatchForModelConfigChanges(),
-		api.backend.WatchAPIHostPorts())
+		api.backend.WatchAPIHostPorts(),
+		machine.Watch())
 
 	if _, ok := <-watch.Changes(); ok {
 		result = params.NotifyWatchResult{
@@ -65,13 +85,14 @@ func (api *ProxyUpdaterAPI) WatchForProxyConfigAndAPIHostPortChanges(args params
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
 	}
-	errors, _ := api.authEntities(args)
+	authErrors, _ := api.authEntities(args)
 
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = api.oneWatch()
+	for i, entity := range args.Entities {
+		if authErrors.Results[i].Error == nil {
+			tag, _ := names.ParseTag(entity.Tag)
+			results.Results[i] = api.oneWatch(tag)
 		} else {
-			results.Results[i].Error = errors.Results[i].Error
+			results.Results[i].Error = authErrors.Results[i].Error
 		}
 	}
 
@@ -110,7 +131,7 @@ func (api *ProxyUpdaterAPI) authEntities(args params.Entities) (params.ErrorResu
 	return result, ok
 }
 
-func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
+func (api *ProxyUpdaterAPI) proxyConfig(tag names.Tag) params.ProxyConfigResult {
 	var result params.ProxyConfigResult
 	env, err := api.backend.ModelConfig()
 	if err != nil {
@@ -124,8 +145,16 @@ func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
 		return result
 	}
 
+	machine, err := api.backend.AgentMachine(tag)
+	if err != nil {
+		result.Error = common.ServerError(errors.Annotate(err, "cannot get agent machine"))
+		return result
+	}
+
+	// Neither the controllers nor the agent's own machine may be
+	// reached through a proxy, whatever the model's no-proxy says.
 	proxySettings := env.ProxySettings()
-	proxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
+	proxySettings.AutoNoProxy = network.NoProxyString(apiHostPorts, machine.Addresses())
 	result.ProxySettings = proxyUtilsSettingsToProxySettingsParam(proxySettings)
 	result.APTProxySettings = proxyUtilsSettingsToProxySettingsParam(env.AptProxySettings())
 	return result
@@ -133,21 +162,18 @@ func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
 
 // ProxyConfig returns the proxy settings for the current environment
 func (api *ProxyUpdaterAPI) ProxyConfig(args params.Entities) params.ProxyConfigResults {
-	var result params.ProxyConfigResult
-	errors, ok := api.authEntities(args)
-
-	if ok {
-		result = api.proxyConfig()
-	}
+	authErrors, _ := api.authEntities(args)
 
 	results := params.ProxyConfigResults{
 		Results: make([]params.ProxyConfigResult, len(args.Entities)),
 	}
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = result
+	for i, entity := range args.Entities {
+		if authErrors.Results[i].Error != nil {
+			results.Results[i].Error = authErrors.Results[i].Error
+			continue
 		}
-		results.Results[i].Error = errors.Results[i].Error
+		tag, _ := names.ParseTag(entity.Tag)
+		results.Results[i] = api.proxyConfig(tag)
 	}
 
 	return results
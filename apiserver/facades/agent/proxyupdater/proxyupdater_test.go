@@ -6,6 +6,7 @@ package proxyupdater_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -70,9 +71,11 @@ func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndAPIHostPortChanges(c *gc.C
 	c.Assert(result.Results[0].Error, gc.IsNil)
 
 	s.state.Stub.CheckCallNames(c,
+		"AgentMachine",
 		"WatchForModelConfigChanges",
 		"WatchAPIHostPorts",
 	)
+	s.state.Stub.CheckCall(c, 0, "AgentMachine", s.tag)
 
 	// Verify the watcher resource was registered.
 	c.Assert(s.resources.Count(), gc.Equals, 1)
@@ -103,9 +106,10 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 	s.state.Stub.CheckCallNames(c,
 		"ModelConfig",
 		"APIHostPorts",
+		"AgentMachine",
 	)
 
-	noProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+	noProxy := "0.1.2.3,0.1.2.4,0.1.2.5,0.1.2.6"
 
 	r := params.ProxyConfigResult{
 		ProxySettings: params.ProxyConfig{
@@ -129,9 +133,10 @@ func (s *ProxyUpdaterSuite) TestProxyConfigExtendsExisting(c *gc.C) {
 	s.state.Stub.CheckCallNames(c,
 		"ModelConfig",
 		"APIHostPorts",
+		"AgentMachine",
 	)
 
-	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5,9.9.9.9"
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5,0.1.2.6,9.9.9.9"
 	expectedAptNoProxy := "9.9.9.9"
 
 	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
@@ -155,9 +160,10 @@ func (s *ProxyUpdaterSuite) TestProxyConfigNoDuplicates(c *gc.C) {
 	s.state.Stub.CheckCallNames(c,
 		"ModelConfig",
 		"APIHostPorts",
+		"AgentMachine",
 	)
 
-	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5,0.1.2.6"
 	expectedAptNoProxy := "0.1.2.3"
 
 	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
@@ -168,6 +174,25 @@ func (s *ProxyUpdaterSuite) TestProxyConfigNoDuplicates(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestProxyConfigAgentMachineError(c *gc.C) {
+	s.state.SetErrors(nil, nil, errors.NotFoundf("machine 1"))
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results[0].Error, gc.ErrorMatches, "cannot get agent machine: machine 1 not found")
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigUnitAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	facade, err := proxyupdater.NewAPIWithBacking(s.state, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := facade.ProxyConfig(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+	})
+	c.Assert(cfg.Results[0].Error, gc.IsNil)
+	c.Assert(cfg.Results[0].ProxySettings.NoProxy, gc.Equals, "0.1.2.3,0.1.2.4,0.1.2.5,0.1.2.6")
+	s.state.Stub.CheckCall(c, 2, "AgentMachine", names.NewUnitTag("mysql/0"))
+}
+
 type stubBackend struct {
 	*testing.Stub
 
@@ -176,6 +201,7 @@ type stubBackend struct {
 	configAttrs coretesting.Attrs
 	hpWatcher   workertest.NotAWatcher
 	confWatcher workertest.NotAWatcher
+	machine     *stubMachine
 }
 
 func (sb *stubBackend) SetUp(c *gc.C) {
@@ -189,11 +215,16 @@ func (sb *stubBackend) SetUp(c *gc.C) {
 	}
 	sb.hpWatcher = workertest.NewFakeWatcher(1, 1)
 	sb.confWatcher = workertest.NewFakeWatcher(1, 1)
+	sb.machine = &stubMachine{
+		addresses: network.NewAddresses("0.1.2.6", "127.0.0.1"),
+		watcher:   workertest.NewFakeWatcher(1, 1),
+	}
 }
 
 func (sb *stubBackend) Kill() {
 	sb.hpWatcher.Kill()
 	sb.confWatcher.Kill()
+	sb.machine.watcher.Kill()
 }
 
 func (sb *stubBackend) SetModelConfig(ca coretesting.Attrs) {
@@ -230,3 +261,24 @@ func (sb *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
 	sb.MethodCall(sb, "WatchForModelConfigChanges")
 	return sb.confWatcher
 }
+
+func (sb *stubBackend) AgentMachine(tag names.Tag) (proxyupdater.Machine, error) {
+	sb.MethodCall(sb, "AgentMachine", tag)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.machine, nil
+}
+
+type stubMachine struct {
+	addresses []network.Address
+	watcher   workertest.NotAWatcher
+}
+
+func (m *stubMachine) Addresses() []network.Address {
+	return m.addresses
+}
+
+func (m *stubMachine) Watch() state.NotifyWatcher {
+	return m.watcher
+}
@@ -4,6 +4,9 @@
 package proxyupdater
 
 import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -32,3 +35,27 @@ func (s *stateShim) WatchAPIHostPorts() state.NotifyWatcher {
 func (s *stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
 	return s.m.WatchForModelConfigChanges()
 }
+
+func (s *stateShim) AgentMachine(tag names.Tag) (Machine, error) {
+	var machineId string
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		machineId = tag.Id()
+	case names.UnitTag:
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err = unit.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotValidf("agent tag %q", tag)
+	}
+	machine, err := s.st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
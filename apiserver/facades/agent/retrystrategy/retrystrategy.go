@@ -89,25 +89,69 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the environment
-			// The rest are hardcoded
-			results.Results[i].Result = &params.RetryStrategy{
+			// ShouldRetry is taken from the model config, and may
+			// be overridden by the unit's application, along with
+			// the retry limits. The rest are hardcoded.
+			strategy := &params.RetryStrategy{
 				ShouldRetry:     config.AutomaticallyRetryHooks(),
 				MinRetryTime:    MinRetryTime,
 				MaxRetryTime:    MaxRetryTime,
 				JitterRetryTime: JitterRetryTime,
 				RetryTimeFactor: RetryTimeFactor,
 			}
-			err = nil
+			err = h.applyHookRetryPolicy(tag, strategy)
+			if err == nil {
+				results.Results[i].Result = strategy
+			}
 		}
 		results.Results[i].Error = common.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the environment. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// applyHookRetryPolicy applies the hook retry policy of the
+// application of the unit with the given tag, if it is a unit, to
+// the strategy.
+func (h *RetryStrategyAPI) applyHookRetryPolicy(tag names.Tag, strategy *params.RetryStrategy) error {
+	app, err := h.application(tag)
+	if err != nil || app == nil {
+		return errors.Trace(err)
+	}
+	policy := app.HookRetryPolicy()
+	if policy.Disabled {
+		strategy.ShouldRetry = false
+	}
+	if policy.MaxRetryTime > 0 {
+		strategy.MaxRetryTime = policy.MaxRetryTime
+		if strategy.MinRetryTime > strategy.MaxRetryTime {
+			strategy.MinRetryTime = strategy.MaxRetryTime
+		}
+	}
+	strategy.MaxHookRetries = policy.MaxAttempts
+	return nil
+}
+
+// application returns the application of the unit with the given tag,
+// or nil if the tag is not a unit tag.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return nil, nil
+	}
+	unit, err := h.st.Unit(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// WatchRetryStrategy watches for changes to the model config and, for
+// units, to their application, either of which may change the retry
+// strategy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -124,7 +168,12 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
+			var watch state.NotifyWatcher
+			watch, err = h.watchRetryStrategy(tag)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -139,3 +188,17 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 	}
 	return results, nil
 }
+
+func (h *RetryStrategyAPI) watchRetryStrategy(tag names.Tag) (state.NotifyWatcher, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app == nil {
+		return h.model.WatchForModelConfigChanges(), nil
+	}
+	return common.NewMultiNotifyWatcher(
+		h.model.WatchForModelConfigChanges(),
+		app.Watch(),
+	), nil
+}
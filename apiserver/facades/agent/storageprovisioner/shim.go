@@ -27,7 +27,11 @@ func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.
 	if err != nil {
 		return nil, errors.Annotate(err, "getting environ")
 	}
-	registry := stateenvirons.NewStorageProviderRegistry(env)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env, controllerConfig)
 	pm := poolmanager.New(state.NewStateSettings(st), registry)
 
 	backend, err := NewStateBackend(st)
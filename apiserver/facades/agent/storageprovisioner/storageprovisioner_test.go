@@ -52,7 +52,9 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 
 	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
 	c.Assert(err, jc.ErrorIsNil)
-	registry := stateenvirons.NewStorageProviderRegistry(env)
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	registry := stateenvirons.NewStorageProviderRegistry(env, controllerConfig)
 	pm := poolmanager.New(state.NewStateSettings(s.State), registry)
 
 	s.authorizer = &apiservertesting.FakeAuthorizer{
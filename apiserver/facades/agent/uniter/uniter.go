@@ -53,9 +53,29 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV10 doesn't have the ProxySettings method.
+type UniterAPIV10 struct {
+	UniterAPI
+}
+
+// UniterAPIV9 doesn't have the RequestCoordinatedReboot method.
+type UniterAPIV9 struct {
+	UniterAPIV10
+}
+
+// UniterAPIV8 doesn't have the AddActionArtifacts method.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
+// UniterAPIV7 doesn't have the CompleteMove method.
+type UniterAPIV7 struct {
+	UniterAPIV8
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -170,14 +190,58 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV10 creates an instance of the V10 uniter API.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPIV10: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPIV8: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -1004,6 +1068,32 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// AddActionArtifacts stores files returned by running actions.
+func (u *UniterAPI) AddActionArtifacts(args params.ActionArtifactArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Artifacts)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	for i, arg := range args.Artifacts {
+		action, err := actionFn(arg.ActionTag)
+		if err == nil {
+			err = m.AddActionArtifact(action.ActionTag(), arg.Name, arg.Content)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1145,6 +1235,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 				result.Results[i].Series = unit.Series()
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].MovingTo = unit.MovingTo()
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1152,6 +1243,149 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 	return result, nil
 }
 
+// CompleteMove completes the moves of the given units to the machines
+// to which they are moving. It is called by each unit's agent once
+// the unit has stopped on its old machine.
+func (u *UniterAPI) CompleteMove(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			if unit, err = u.getUnit(tag); err == nil {
+				err = unit.CompleteMove()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RequestCoordinatedReboot records that each given unit needs its
+// machine rebooted. The reboots are granted by the reboot coordinator,
+// one unit of an application at a time.
+func (u *UniterAPI) RequestCoordinatedReboot(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			if unit, err = u.getUnit(tag); err == nil {
+				err = unit.RequestCoordinatedReboot()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ProxySettings returns the proxy settings to be used by the hooks of
+// each given unit: the model's proxy settings, with the unit's
+// application overrides applied. The no-proxy settings always include
+// the controllers and the unit's machine.
+func (u *UniterAPI) ProxySettings(args params.Entities) (params.ProxyConfigResults, error) {
+	result := params.ProxyConfigResults{
+		Results: make([]params.ProxyConfigResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ProxyConfigResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		result.Results[i], err = u.unitProxySettings(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) unitProxySettings(tag names.UnitTag) (params.ProxyConfigResult, error) {
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+	apiHostPorts, err := u.st.APIHostPorts()
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+	machine, err := u.st.Machine(machineId)
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return params.ProxyConfigResult{}, errors.Trace(err)
+	}
+
+	settings := cfg.ProxySettings()
+	settings.AutoNoProxy = network.NoProxyString(apiHostPorts, machine.Addresses())
+	settings = app.ProxyOverrides().Apply(settings)
+	aptSettings := cfg.AptProxySettings()
+	return params.ProxyConfigResult{
+		ProxySettings: params.ProxyConfig{
+			HTTP:    settings.Http,
+			HTTPS:   settings.Https,
+			FTP:     settings.Ftp,
+			NoProxy: settings.FullNoProxy(),
+		},
+		APTProxySettings: params.ProxyConfig{
+			HTTP:    aptSettings.Http,
+			HTTPS:   aptSettings.Https,
+			FTP:     aptSettings.Ftp,
+			NoProxy: aptSettings.FullNoProxy(),
+		},
+	}, nil
+}
+
 // CurrentModel returns the name and UUID for the current juju model.
 func (u *UniterAPI) CurrentModel() (params.ModelResult, error) {
 	result := params.ModelResult{}
@@ -2189,3 +2423,15 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// CompleteMove isn't on the V7 API.
+func (u *UniterAPIV7) CompleteMove(_, _ struct{}) {}
+
+// AddActionArtifacts isn't on the V8 API.
+func (u *UniterAPIV8) AddActionArtifacts(_, _ struct{}) {}
+
+// RequestCoordinatedReboot isn't on the V9 API.
+func (u *UniterAPIV9) RequestCoordinatedReboot(_, _ struct{}) {}
+
+// ProxySettings isn't on the V10 API.
+func (u *UniterAPIV10) ProxySettings(_, _ struct{}) {}
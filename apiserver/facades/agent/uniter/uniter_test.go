@@ -5,11 +5,13 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -1665,6 +1667,36 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestAddActionArtifacts(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.uniter.AddActionArtifacts(params.ActionArtifactArgs{
+		Artifacts: []params.ActionArtifactArg{
+			{ActionTag: good.ActionTag().String(), Name: "dump.sql", Content: []byte("create table")},
+			{ActionTag: bad.ActionTag().String(), Name: "dump.sql", Content: []byte("create table")},
+			{ActionTag: "unit-wordpress-0", Name: "dump.sql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(res.Results[2].Error, gc.NotNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	artifacts, err := model.ActionArtifacts(good.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts, gc.HasLen, 1)
+	c.Assert(artifacts[0].Name, gc.Equals, "dump.sql")
+	c.Assert(artifacts[0].Size, gc.Equals, int64(len("create table")))
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -2985,6 +3017,115 @@ func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{Results: []params.UnitRefreshResult{}})
 }
 
+func (s *uniterSuite) TestRefreshMovingTo(c *gc.C) {
+	err := s.wordpressUnit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: params.Alive, Resolved: params.ResolvedNone, Series: "quantal", MovingTo: s.machine1.Id()},
+		},
+	})
+}
+
+func (s *uniterSuite) TestCompleteMove(c *gc.C) {
+	err := s.wordpressUnit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{
+			{s.wordpressUnit.Tag().String()},
+			{s.mysqlUnit.Tag().String()},
+			{"some-word"},
+		},
+	}
+	results, err := s.uniter.CompleteMove(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.MovingTo(), gc.Equals, "")
+	machineId, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, s.machine1.Id())
+}
+
+func (s *uniterSuite) TestRequestCoordinatedReboot(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{
+			{s.wordpressUnit.Tag().String()},
+			{s.mysqlUnit.Tag().String()},
+			{"some-word"},
+		},
+	}
+	results, err := s.uniter.RequestCoordinatedReboot(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Unit, gc.Equals, s.wordpressUnit.Name())
+	c.Assert(requests[0].Granted, jc.IsFalse)
+}
+
+func (s *uniterSuite) TestProxySettings(c *gc.C) {
+	err := s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.7", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"http-proxy":  "http://model-proxy",
+		"https-proxy": "https://model-proxy",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetProxyOverrides(state.ProxyOverrides{
+		HTTPS:   "https://app-proxy",
+		NoProxy: "example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{s.wordpressUnit.Tag().String()},
+			{s.mysqlUnit.Tag().String()},
+			{"some-word"},
+		},
+	}
+	results, err := s.uniter.ProxySettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[1].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(results.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.ProxySettings.HTTP, gc.Equals, "http://model-proxy")
+	c.Assert(result.ProxySettings.HTTPS, gc.Equals, "https://app-proxy")
+	noProxy := set.NewStrings(strings.Split(result.ProxySettings.NoProxy, ",")...)
+	c.Assert(noProxy.SortedValues(), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.7", "example.com"})
+}
+
 type unitMetricBatchesSuite struct {
 	uniterSuite
 	*commontesting.ModelWatcherTest
@@ -3508,6 +3649,7 @@ func (s *uniterNetworkInfoSuite) makeMachineDevicesAndAddressesArgs(addrSuffix i
 			Name:       "eth0.100",
 			Type:       state.VLAN_8021QDevice,
 			ParentName: "eth0",
+			VLANTag:    100,
 			MACAddress: fmt.Sprintf("00:11:22:33:%0.2d:50", addrSuffix),
 		}, {
 			Name:       "eth1",
@@ -3517,6 +3659,7 @@ func (s *uniterNetworkInfoSuite) makeMachineDevicesAndAddressesArgs(addrSuffix i
 			Name:       "eth1.100",
 			Type:       state.VLAN_8021QDevice,
 			ParentName: "eth1",
+			VLANTag:    100,
 			MACAddress: fmt.Sprintf("00:11:22:33:%0.2d:51", addrSuffix),
 		}, {
 			Name:       "eth2",
@@ -3660,15 +3803,21 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 	expectedConfigWithRelationName := params.NetworkInfoResult{
 		Info: []params.NetworkInfo{
 			{
-				MACAddress:    "00:11:22:33:10:50",
-				InterfaceName: "eth0.100",
+				MACAddress:          "00:11:22:33:10:50",
+				InterfaceName:       "eth0.100",
+				InterfaceType:       "802.1q",
+				ParentInterfaceName: "eth0",
+				VLANTag:             100,
 				Addresses: []params.InterfaceAddress{
 					{Address: "10.0.0.10", CIDR: "10.0.0.0/24"},
 				},
 			},
 			{
-				MACAddress:    "00:11:22:33:10:51",
-				InterfaceName: "eth1.100",
+				MACAddress:          "00:11:22:33:10:51",
+				InterfaceName:       "eth1.100",
+				InterfaceType:       "802.1q",
+				ParentInterfaceName: "eth1",
+				VLANTag:             100,
 				Addresses: []params.InterfaceAddress{
 					{Address: "10.0.0.11", CIDR: "10.0.0.0/24"},
 				},
@@ -3684,6 +3833,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 			{
 				MACAddress:    "00:11:22:33:10:50",
 				InterfaceName: "eth0",
+				InterfaceType: "ethernet",
 				Addresses: []params.InterfaceAddress{
 					{Address: "8.8.8.10", CIDR: "8.8.0.0/16"},
 				},
@@ -3691,6 +3841,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 			{
 				MACAddress:    "00:11:22:33:10:51",
 				InterfaceName: "eth1",
+				InterfaceType: "ethernet",
 				Addresses: []params.InterfaceAddress{
 					{Address: "8.8.4.10", CIDR: "8.8.0.0/16"},
 					{Address: "8.8.4.11", CIDR: "8.8.0.0/16"},
@@ -3708,6 +3859,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForExplicitlyBoundEndpointAndDef
 			{
 				MACAddress:    "00:11:22:33:10:52",
 				InterfaceName: "eth2",
+				InterfaceType: "ethernet",
 				Addresses: []params.InterfaceAddress{
 					{Address: "100.64.0.10", CIDR: "100.64.0.0/16"},
 				},
@@ -3777,6 +3929,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoForImplicitlyBoundEndpoint(c *gc
 			{
 				MACAddress:    "00:11:22:33:20:54",
 				InterfaceName: "eth4",
+				InterfaceType: "ethernet",
 				Addresses: []params.InterfaceAddress{
 					{Address: "192.168.1.20", CIDR: "192.168.1.0/24"},
 				},
@@ -3832,6 +3985,7 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoUsesRelationAddressNonDefaultBin
 			{
 				MACAddress:    "00:11:22:33:20:54",
 				InterfaceName: "eth4",
+				InterfaceType: "ethernet",
 				Addresses: []params.InterfaceAddress{
 					{Address: "192.168.1.20", CIDR: "192.168.1.0/24"},
 				},
@@ -3902,8 +4056,11 @@ func (s *uniterNetworkInfoSuite) TestNetworkInfoUsesRelationAddressDefaultBindin
 	expectedInfo := params.NetworkInfoResult{
 		Info: []params.NetworkInfo{
 			{
-				MACAddress:    "00:11:22:33:20:50",
-				InterfaceName: "eth0.100",
+				MACAddress:          "00:11:22:33:20:50",
+				InterfaceName:       "eth0.100",
+				InterfaceType:       "802.1q",
+				ParentInterfaceName: "eth0",
+				VLANTag:             100,
 				Addresses: []params.InterfaceAddress{
 					{Address: "10.0.0.20", CIDR: "10.0.0.0/24"},
 				},
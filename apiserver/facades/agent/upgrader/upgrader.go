@@ -95,7 +95,9 @@ func NewUpgraderAPI(
 }
 
 // WatchAPIVersion starts a watcher to track if there is a new version
-// of the API that we want to upgrade to
+// of the API that we want to upgrade to. Changes to the model's staged
+// upgrade are also reported, since they change which machines are
+// allowed to upgrade.
 func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -107,7 +109,10 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.m.WatchForModelConfigChanges()
+			watch := common.NewMultiNotifyWatcher(
+				u.m.WatchForModelConfigChanges(),
+				u.st.WatchStagedUpgrade(),
+			)
 			// Consume the initial event. Technically, API
 			// calls to Watch 'transmit' the initial event
 			// in the Watch response. But NotifyWatchers
@@ -153,6 +158,41 @@ func (u *UpgraderAPI) entityIsManager(tag names.Tag) bool {
 	}
 }
 
+// heldVersion returns the version that the machine with the given tag
+// must keep running while a staged upgrade to agentVersion is in
+// progress, and reports whether the machine is held at all. Managers,
+// admitted machines, and machines already running the new version (such
+// as those provisioned during the upgrade) are not held.
+func (u *UpgraderAPI) heldVersion(tag names.Tag, agentVersion version.Number) (version.Number, bool, error) {
+	machineTag, ok := tag.(names.MachineTag)
+	if !ok {
+		return version.Number{}, false, nil
+	}
+	upgrade, err := u.st.StagedUpgrade()
+	if errors.IsNotFound(err) {
+		return version.Number{}, false, nil
+	} else if err != nil {
+		return version.Number{}, false, errors.Trace(err)
+	}
+	if upgrade.Version != agentVersion || upgrade.IsAdmitted(machineTag.Id()) {
+		return version.Number{}, false, nil
+	}
+	machine, err := u.st.Machine(machineTag.Id())
+	if err != nil {
+		return version.Number{}, false, errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return version.Number{}, false, nil
+	}
+	current, err := machine.AgentTools()
+	if err != nil && !errors.IsNotFound(err) {
+		return version.Number{}, false, errors.Trace(err)
+	} else if err == nil && current.Version.Number == agentVersion {
+		return version.Number{}, false, nil
+	}
+	return upgrade.PreviousVersion, true, nil
+}
+
 // DesiredVersion reports the Agent Version that we want that agent to be running
 func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResults, error) {
 	results := make([]params.VersionResult, len(args.Entities))
@@ -183,8 +223,23 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 			// first - once they have restarted and are running the
 			// new version other agents will start to see the new
 			// agent version.
+			//
+			// While a staged upgrade is in progress, machines that
+			// have not yet been admitted keep the previous version.
 			if !isNewerVersion || u.entityIsManager(tag) {
-				results[i].Version = &agentVersion
+				var held version.Number
+				var isHeld bool
+				held, isHeld, err = u.heldVersion(tag, agentVersion)
+				if err != nil {
+					results[i].Error = common.ServerError(err)
+					continue
+				}
+				if isHeld {
+					logger.Debugf("desired version is %s, but %s is held at %s by a staged upgrade", agentVersion, tag, held)
+					results[i].Version = &held
+				} else {
+					results[i].Version = &agentVersion
+				}
 			} else {
 				logger.Debugf("desired version is %s, but current version is %s and agent is not a manager node", agentVersion, jujuversion.Current)
 				results[i].Version = &jujuversion.Current
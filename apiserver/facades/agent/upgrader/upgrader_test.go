@@ -341,3 +341,63 @@ func (s *upgraderSuite) TestDesiredVersionRestrictedForNonAPIAgents(c *gc.C) {
 	c.Assert(agentVersion, gc.NotNil)
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
+
+func (s *upgraderSuite) stageUpgradeToCurrent(c *gc.C) version.Number {
+	// Start from an older version, so the staged upgrade is to the
+	// version that this API server is running.
+	older := jujuversion.Current
+	older.Patch--
+	err := s.State.SetModelAgentVersion(older, true)
+	c.Assert(err, jc.ErrorIsNil)
+	current := version.Binary{
+		Number: older,
+		Arch:   arch.HostArch(),
+		Series: series.MustHostSeries(),
+	}
+	s.apiMachine.SetAgentVersion(current)
+	s.rawMachine.SetAgentVersion(current)
+	err = s.State.SetModelAgentVersionStaged(jujuversion.Current, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	return older
+}
+
+func (s *upgraderSuite) assertDesiredVersion(c *gc.C, expected version.Number) {
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	agentVersion := results.Results[0].Version
+	c.Assert(agentVersion, gc.NotNil)
+	c.Check(*agentVersion, gc.DeepEquals, expected)
+}
+
+func (s *upgraderSuite) TestDesiredVersionHeldByStagedUpgrade(c *gc.C) {
+	older := s.stageUpgradeToCurrent(c)
+	s.assertDesiredVersion(c, older)
+
+	err := s.State.AdmitStagedUpgradeMachines([]string{s.rawMachine.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDesiredVersion(c, jujuversion.Current)
+}
+
+func (s *upgraderSuite) TestDesiredVersionStagedUpgradeCompleted(c *gc.C) {
+	s.stageUpgradeToCurrent(c)
+	err := s.State.CompleteStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDesiredVersion(c, jujuversion.Current)
+}
+
+func (s *upgraderSuite) TestDesiredVersionStagedUpgradeUnrestrictedForAPIAgents(c *gc.C) {
+	s.stageUpgradeToCurrent(c)
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.apiMachine.Tag(),
+	}
+	upgraderAPI, err := upgrader.NewUpgraderAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{Entities: []params.Entity{{Tag: s.apiMachine.Tag().String()}}}
+	results, err := upgraderAPI.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(*results.Results[0].Version, gc.DeepEquals, jujuversion.Current)
+}
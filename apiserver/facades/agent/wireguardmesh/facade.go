@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package wireguardmesh implements the API facade used by the
+// wireguardmesh worker.
+package wireguardmesh
+
+import (
+	"net"
+	"strconv"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// DefaultListenPort is the UDP port on which machines accept WireGuard
+// traffic.
+const DefaultListenPort = 51820
+
+// Peer describes a machine with which another machine is meshed.
+type Peer struct {
+	// PublicKey is the machine's WireGuard public key.
+	PublicKey string
+
+	// PublicAddress is the machine's public address, if it has one.
+	PublicAddress string
+
+	// Addresses holds the machine's cloud-local addresses, which are
+	// routed through the mesh.
+	Addresses []network.Address
+}
+
+// Backend defines the State API used by the wireguardmesh facade.
+type Backend interface {
+	// MeshEnabled reports whether the model's machines join a mesh.
+	MeshEnabled() (bool, error)
+
+	// EnsureMachineKey returns the machine's WireGuard key pair,
+	// generating one if the machine has none.
+	EnsureMachineKey(tag names.MachineTag) (state.WireGuardKey, error)
+
+	// MeshPeers returns the machines with which the model's machines
+	// are meshed.
+	MeshPeers() ([]Peer, error)
+}
+
+// Facade implements the API required by the wireguardmesh worker.
+type Facade struct {
+	backend      Backend
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the wireguardmesh worker.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend: backend,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// MeshConfig returns the WireGuard configuration of one or more
+// machines. Agents may only get their own machine's configuration,
+// which includes its private key.
+func (facade *Facade) MeshConfig(args params.Entities) (params.WireGuardMeshConfigResults, error) {
+	results := params.WireGuardMeshConfigResults{
+		Results: make([]params.WireGuardMeshConfigResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, err
+	}
+	enabled, err := facade.backend.MeshEnabled()
+	if err != nil {
+		return results, common.ServerError(err)
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		config, err := facade.meshConfig(tag, enabled)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = config
+	}
+	return results, nil
+}
+
+func (facade *Facade) meshConfig(tag names.MachineTag, enabled bool) (*params.WireGuardMeshConfig, error) {
+	if !enabled {
+		return &params.WireGuardMeshConfig{}, nil
+	}
+	key, err := facade.backend.EnsureMachineKey(tag)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := facade.backend.MeshPeers()
+	if err != nil {
+		return nil, err
+	}
+	config := &params.WireGuardMeshConfig{
+		Enabled:    true,
+		PrivateKey: key.PrivateKey,
+		ListenPort: DefaultListenPort,
+	}
+	for _, peer := range peers {
+		config.Peers = append(config.Peers, wireGuardPeer(peer))
+	}
+	return config, nil
+}
+
+// wireGuardPeer returns the WireGuard configuration for a peer.
+func wireGuardPeer(peer Peer) params.WireGuardPeer {
+	result := params.WireGuardPeer{
+		PublicKey:  peer.PublicKey,
+		AllowedIPs: []string{},
+	}
+	if peer.PublicAddress != "" {
+		result.Endpoint = net.JoinHostPort(peer.PublicAddress, strconv.Itoa(DefaultListenPort))
+	}
+	for _, addr := range peer.Addresses {
+		switch addr.Type {
+		case network.IPv4Address:
+			result.AllowedIPs = append(result.AllowedIPs, addr.Value+"/32")
+		case network.IPv6Address:
+			result.AllowedIPs = append(result.AllowedIPs, addr.Value+"/128")
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireguardmesh_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/wireguardmesh"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *wireguardmesh.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		enabled: true,
+		key: state.WireGuardKey{
+			PrivateKey: "private-1",
+			PublicKey:  "public-1",
+		},
+		peers: []wireguardmesh.Peer{{
+			PublicKey:     "public-peer-0",
+			PublicAddress: "203.0.113.10",
+			Addresses: []network.Address{
+				network.NewScopedAddress("10.0.0.4", network.ScopeCloudLocal),
+				network.NewScopedAddress("fd00::4", network.ScopeCloudLocal),
+			},
+		}, {
+			PublicKey: "public-peer-1",
+			Addresses: []network.Address{
+				network.NewScopedAddress("10.0.0.5", network.ScopeCloudLocal),
+			},
+		}},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := wireguardmesh.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := wireguardmesh.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestMeshConfig(c *gc.C) {
+	result, err := s.facade.MeshConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WireGuardMeshConfigResults{
+		Results: []params.WireGuardMeshConfigResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.WireGuardMeshConfig{
+				Enabled:    true,
+				PrivateKey: "private-1",
+				ListenPort: 51820,
+				Peers: []params.WireGuardPeer{{
+					PublicKey:  "public-peer-0",
+					Endpoint:   "203.0.113.10:51820",
+					AllowedIPs: []string{"10.0.0.4/32", "fd00::4/128"},
+				}, {
+					PublicKey:  "public-peer-1",
+					AllowedIPs: []string{"10.0.0.5/32"},
+				}},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "MeshEnabled", "EnsureMachineKey", "MeshPeers")
+	s.backend.stub.CheckCall(c, 1, "EnsureMachineKey", names.NewMachineTag("1"))
+}
+
+func (s *facadeSuite) TestMeshConfigDisabled(c *gc.C) {
+	s.backend.enabled = false
+	result, err := s.facade.MeshConfig(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WireGuardMeshConfigResults{
+		Results: []params.WireGuardMeshConfigResult{
+			{Result: &params.WireGuardMeshConfig{}},
+		},
+	})
+	// No key is generated for machines outside a mesh.
+	s.backend.stub.CheckCallNames(c, "MeshEnabled")
+}
+
+func (s *facadeSuite) TestMeshConfigKeyError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("machine is not alive"))
+	result, err := s.facade.MeshConfig(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "machine is not alive")
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	enabled bool
+	key     state.WireGuardKey
+	peers   []wireguardmesh.Peer
+}
+
+func (backend *mockBackend) MeshEnabled() (bool, error) {
+	backend.stub.AddCall("MeshEnabled")
+	return backend.enabled, backend.stub.NextErr()
+}
+
+func (backend *mockBackend) EnsureMachineKey(tag names.MachineTag) (state.WireGuardKey, error) {
+	backend.stub.AddCall("EnsureMachineKey", tag)
+	return backend.key, backend.stub.NextErr()
+}
+
+func (backend *mockBackend) MeshPeers() ([]wireguardmesh.Peer, error) {
+	backend.stub.AddCall("MeshPeers")
+	return backend.peers, backend.stub.NextErr()
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireguardmesh
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// NewFacade wraps New to express the supplied state as a Backend.
+func NewFacade(ctx facade.Context) (*Facade, error) {
+	backend := backendShim{
+		st:   ctx.State(),
+		pool: ctx.StatePool(),
+	}
+	facade, err := New(backend, ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+type backendShim struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+// MeshEnabled is part of the Backend interface.
+func (shim backendShim) MeshEnabled() (bool, error) {
+	return meshEnabled(shim.st)
+}
+
+// meshEnabled reports whether the wireguard-mesh model config
+// setting is enabled for the supplied state's model.
+func meshEnabled(st *state.State) (bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.WireGuardMesh(), nil
+}
+
+// EnsureMachineKey is part of the Backend interface.
+func (shim backendShim) EnsureMachineKey(tag names.MachineTag) (state.WireGuardKey, error) {
+	m, err := shim.st.Machine(tag.Id())
+	if err != nil {
+		return state.WireGuardKey{}, errors.Trace(err)
+	}
+	return m.EnsureWireGuardKey()
+}
+
+// MeshPeers is part of the Backend interface. A machine is meshed with
+// the machines of every model on this controller to which its model is
+// related by a cross-model relation. Both ends of such a relation hold
+// a remote application whose source is the other model, so meshes are
+// symmetric.
+func (shim backendShim) MeshPeers() ([]Peer, error) {
+	remoteApps, err := shim.st.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUIDs := set.NewStrings()
+	for _, app := range remoteApps {
+		if uuid := app.SourceModel().Id(); uuid != shim.st.ModelUUID() {
+			modelUUIDs.Add(uuid)
+		}
+	}
+	var peers []Peer
+	for _, uuid := range modelUUIDs.SortedValues() {
+		modelPeers, err := shim.modelPeers(uuid)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting mesh peers in model %v", uuid)
+		}
+		peers = append(peers, modelPeers...)
+	}
+	return peers, nil
+}
+
+// modelPeers returns the machines of the specified model which have
+// joined its mesh.
+func (shim backendShim) modelPeers(modelUUID string) ([]Peer, error) {
+	exists, err := shim.st.ModelExists(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !exists {
+		// The model is hosted by another controller.
+		return nil, nil
+	}
+	st, release, err := shim.pool.Get(modelUUID)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	enabled, err := meshEnabled(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var peers []Peer
+	for _, m := range machines {
+		if m.Life() != state.Alive {
+			continue
+		}
+		key, err := m.WireGuardKey()
+		if errors.IsNotFound(err) {
+			// The machine's agent has not joined the mesh yet.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		peer := Peer{PublicKey: key.PublicKey}
+		if addr, err := m.PublicAddress(); err == nil {
+			peer.PublicAddress = addr.Value
+		}
+		for _, addr := range m.Addresses() {
+			if addr.Scope == network.ScopeCloudLocal {
+				peer.Addresses = append(peer.Addresses, addr)
+			}
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV3 implements the Action facade for version 3, which does
+// not support action queries or artifacts.
+type ActionAPIV3 struct {
+	*ActionAPIV4
+}
+
+// NewActionAPIV3 returns an initialized ActionAPIV3.
+func NewActionAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV3, error) {
+	api, err := NewActionAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV3{api}, nil
+}
+
+// Mask the new methods from the V3 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// ListActions isn't on the V3 API.
+func (*ActionAPIV3) ListActions(_, _ struct{}) {}
+
+// ActionArtifacts isn't on the V3 API.
+func (*ActionAPIV3) ActionArtifacts(_, _ struct{}) {}
+
+// ActionArtifactContent isn't on the V3 API.
+func (*ActionAPIV3) ActionArtifactContent(_, _ struct{}) {}
+
+var validActionStatuses = map[string]state.ActionStatus{
+	params.ActionPending:   state.ActionPending,
+	params.ActionRunning:   state.ActionRunning,
+	params.ActionCompleted: state.ActionCompleted,
+	params.ActionFailed:    state.ActionFailed,
+	params.ActionCancelled: state.ActionCancelled,
+}
+
+// ListActions returns the actions in the model matching the given
+// query, most recently enqueued first.
+func (a *ActionAPI) ListActions(arg params.ActionQuery) (params.ActionResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+	query := state.ActionQuery{
+		Application:    arg.Application,
+		EnqueuedAfter:  arg.EnqueuedAfter,
+		EnqueuedBefore: arg.EnqueuedBefore,
+		Limit:          arg.Limit,
+	}
+	for _, s := range arg.Statuses {
+		status, ok := validActionStatuses[s]
+		if !ok {
+			return params.ActionResults{}, errors.NotValidf("action status %q", s)
+		}
+		query.Statuses = append(query.Statuses, status)
+	}
+	actions, err := a.model.FindActions(query)
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+	results := params.ActionResults{
+		Results: make([]params.ActionResult, len(actions)),
+	}
+	for i, action := range actions {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = common.MakeActionResult(receiverTag, action)
+	}
+	return results, nil
+}
+
+// ActionArtifacts returns the artifacts stored for each of the given
+// actions.
+func (a *ActionAPI) ActionArtifacts(arg params.Entities) (params.ActionArtifactsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionArtifactsResults{}, errors.Trace(err)
+	}
+	results := params.ActionArtifactsResults{
+		Results: make([]params.ActionArtifactsResult, len(arg.Entities)),
+	}
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		artifacts, err := a.model.ActionArtifacts(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Artifacts = make([]params.ActionArtifact, len(artifacts))
+		for j, artifact := range artifacts {
+			results.Results[i].Artifacts[j] = makeActionArtifact(artifact)
+		}
+	}
+	return results, nil
+}
+
+// ActionArtifactContent returns the content of each of the given
+// action artifacts.
+func (a *ActionAPI) ActionArtifactContent(arg params.ActionArtifactRefs) (params.ActionArtifactContentResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionArtifactContentResults{}, errors.Trace(err)
+	}
+	results := params.ActionArtifactContentResults{
+		Results: make([]params.ActionArtifactContentResult, len(arg.Artifacts)),
+	}
+	for i, ref := range arg.Artifacts {
+		tag, err := names.ParseActionTag(ref.ActionTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		artifact, content, err := a.actionArtifactContent(tag, ref.Name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionArtifact(artifact)
+		results.Results[i].Artifact = &result
+		results.Results[i].Content = content
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) actionArtifactContent(tag names.ActionTag, name string) (state.ActionArtifact, []byte, error) {
+	artifact, r, err := a.model.OpenActionArtifact(tag, name)
+	if err != nil {
+		return state.ActionArtifact{}, nil, errors.Trace(err)
+	}
+	defer r.Close()
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return state.ActionArtifact{}, nil, errors.Annotatef(err, "cannot read artifact %q", name)
+	}
+	return artifact, content, nil
+}
+
+func makeActionArtifact(artifact state.ActionArtifact) params.ActionArtifact {
+	return params.ActionArtifact{
+		Name:    artifact.Name,
+		Size:    artifact.Size,
+		SHA256:  artifact.SHA256,
+		Created: artifact.Created,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *actionSuite) TestListActions(c *gc.C) {
+	wordpress, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.ListActions(params.ActionQuery{
+		Application: "wordpress",
+		Statuses:    []string{params.ActionPending},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Action.Tag, gc.Equals, wordpress.ActionTag().String())
+	c.Assert(results.Results[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+
+	results, err = s.action.ListActions(params.ActionQuery{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+}
+
+func (s *actionSuite) TestListActionsInvalidStatus(c *gc.C) {
+	_, err := s.action.ListActions(params.ActionQuery{Statuses: []string{"sleeping"}})
+	c.Assert(err, gc.ErrorMatches, `action status "sleeping" not valid`)
+}
+
+func (s *actionSuite) TestActionArtifacts(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.AddActionArtifact(a.ActionTag(), "dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	artifacts, err := s.action.ActionArtifacts(params.Entities{
+		Entities: []params.Entity{{Tag: a.ActionTag().String()}, {Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts.Results, gc.HasLen, 2)
+	c.Assert(artifacts.Results[0].Error, gc.IsNil)
+	c.Assert(artifacts.Results[0].Artifacts, gc.HasLen, 1)
+	artifact := artifacts.Results[0].Artifacts[0]
+	c.Assert(artifact.Name, gc.Equals, "dump.sql")
+	c.Assert(artifact.Size, gc.Equals, int64(len("create table")))
+	c.Assert(artifacts.Results[1].Error, gc.ErrorMatches, "id not found")
+
+	content, err := s.action.ActionArtifactContent(params.ActionArtifactRefs{
+		Artifacts: []params.ActionArtifactRef{
+			{ActionTag: a.ActionTag().String(), Name: "dump.sql"},
+			{ActionTag: a.ActionTag().String(), Name: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(content.Results, gc.HasLen, 2)
+	c.Assert(content.Results[0].Error, gc.IsNil)
+	c.Assert(*content.Results[0].Artifact, jc.DeepEquals, artifact)
+	c.Assert(string(content.Results[0].Content), gc.Equals, "create table")
+	c.Assert(content.Results[1].Error, gc.ErrorMatches, `artifact "missing" of action .* not found`)
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV4 implements the Action facade for version 4, which does
+// not support remote agent introspection.
+type ActionAPIV4 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV4 returns an initialized ActionAPIV4.
+func NewActionAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV4, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV4{api}, nil
+}
+
+// Introspect isn't on the V4 API.
+func (*ActionAPIV4) Introspect(_, _ struct{}) {}
+
+// Introspect enqueues a juju-introspect action for each of the given
+// arguments. The action queries the introspection socket of an agent
+// running on the machine and records the response as its output, so
+// that agents can be debugged without direct access to the machine.
+func (a *ActionAPI) Introspect(args params.IntrospectArgs) (params.ActionResults, error) {
+	results := params.ActionResults{
+		Results: make([]params.ActionResult, len(args.Args)),
+	}
+	if err := a.checkCanAdmin(); err != nil {
+		return results, err
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	var (
+		toEnqueue params.Actions
+		indices   []int
+	)
+	for i, arg := range args.Args {
+		action, err := a.introspectAction(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		toEnqueue.Actions = append(toEnqueue.Actions, action)
+		indices = append(indices, i)
+	}
+	if len(toEnqueue.Actions) == 0 {
+		return results, nil
+	}
+
+	enqueued, err := queueActions(a, toEnqueue)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(enqueued.Results) != len(indices) {
+		return results, errors.Errorf("expected %d results, got %d", len(indices), len(enqueued.Results))
+	}
+	for i, result := range enqueued.Results {
+		results.Results[indices[i]] = result
+	}
+	return results, nil
+}
+
+// introspectAction validates the given introspection request and
+// returns the juju-introspect action that satisfies it.
+func (a *ActionAPI) introspectAction(arg params.IntrospectArg) (params.Action, error) {
+	if !names.IsValidMachine(arg.Machine) {
+		return params.Action{}, errors.NotValidf("machine id %q", arg.Machine)
+	}
+	if arg.Path == "" {
+		return params.Action{}, errors.NotValidf("empty introspection path")
+	}
+	machine, err := a.state.Machine(arg.Machine)
+	if err != nil {
+		return params.Action{}, errors.Trace(err)
+	}
+
+	agent := machine.Tag()
+	if arg.Agent != "" {
+		agent, err = names.ParseTag(arg.Agent)
+		if err != nil {
+			return params.Action{}, errors.Trace(err)
+		}
+		switch agent.Kind() {
+		case names.MachineTagKind:
+			if agent != machine.Tag() {
+				return params.Action{}, errors.Errorf("agent %q is not running on machine %s", arg.Agent, arg.Machine)
+			}
+		case names.UnitTagKind:
+			unit, err := a.state.Unit(agent.Id())
+			if err != nil {
+				return params.Action{}, errors.Trace(err)
+			}
+			machineId, err := unit.AssignedMachineId()
+			if err != nil {
+				return params.Action{}, errors.Trace(err)
+			}
+			if machineId != arg.Machine {
+				return params.Action{}, errors.Errorf("agent %q is not running on machine %s", arg.Agent, arg.Machine)
+			}
+		default:
+			return params.Action{}, errors.NotValidf("agent %q", arg.Agent)
+		}
+	}
+
+	return params.Action{
+		Receiver: machine.Tag().String(),
+		Name:     actions.JujuIntrospectActionName,
+		Parameters: map[string]interface{}{
+			"agent": agent.String(),
+			"path":  arg.Path,
+		},
+	}, nil
+}
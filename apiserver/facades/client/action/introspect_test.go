@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type introspectSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	client *action.ActionAPI
+}
+
+var _ = gc.Suite(&introspectSuite{})
+
+func (s *introspectSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+
+	var err error
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	s.client, err = action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *introspectSuite) TestIntrospect(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	charm := s.AddTestingCharm(c, "dummy")
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := magic.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{
+			{Machine: "0", Path: "depengine"},
+			{Machine: "1", Agent: "unit-magic-0", Path: "debug/pprof/goroutine?debug=1"},
+			{Machine: "0", Agent: "unit-magic-0", Path: "depengine"},
+			{Machine: "0", Agent: "application-magic", Path: "depengine"},
+			{Machine: "0"},
+			{Machine: "42", Path: "metrics"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 6)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Action.Receiver, gc.Equals, "machine-0")
+	c.Assert(result.Results[0].Action.Name, gc.Equals, "juju-introspect")
+	c.Assert(result.Results[0].Action.Parameters, jc.DeepEquals, map[string]interface{}{
+		"agent": "machine-0",
+		"path":  "depengine",
+	})
+
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Action.Receiver, gc.Equals, "machine-1")
+	c.Assert(result.Results[1].Action.Parameters, jc.DeepEquals, map[string]interface{}{
+		"agent": "unit-magic-0",
+		"path":  "debug/pprof/goroutine?debug=1",
+	})
+
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `agent "unit-magic-0" is not running on machine 0`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `agent "application-magic" not valid`)
+	c.Assert(result.Results[4].Error, gc.ErrorMatches, `empty introspection path not valid`)
+	c.Assert(result.Results[5].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	actions, err := s.Model.AllActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+}
+
+func (s *introspectSuite) TestBlockIntrospect(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockIntrospect")
+	_, err := s.client.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Machine: "0", Path: "depengine"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+func (s *introspectSuite) TestIntrospectRequiresAdmin(c *gc.C) {
+	alpha := names.NewUserTag("alpha@bravo")
+	auth := apiservertesting.FakeAuthorizer{
+		Tag:         alpha,
+		HasWriteTag: alpha,
+	}
+	client, err := action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Introspect(params.IntrospectArgs{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
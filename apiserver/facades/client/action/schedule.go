@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV2 implements the Action facade for versions 1 and 2,
+// which do not support scheduled actions.
+type ActionAPIV2 struct {
+	*ActionAPIV3
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// Mask the new methods from the V2 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+
+// ScheduleActions isn't on the V2 API.
+func (*ActionAPIV2) ScheduleActions(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the V2 API.
+func (*ActionAPIV2) ListActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the V2 API.
+func (*ActionAPIV2) RemoveActionSchedules(_, _ struct{}) {}
+
+// ScheduleActions adds schedules which enqueue the given actions on
+// their units at regular intervals.
+func (a *ActionAPI) ScheduleActions(args params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		unitTag, err := names.ParseUnitTag(arg.Receiver)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		schedule, err := a.model.AddActionSchedule(state.ActionScheduleArgs{
+			Receiver:   unitTag,
+			Name:       arg.Name,
+			Parameters: arg.Parameters,
+			Interval:   arg.Interval,
+		})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionSchedule(schedule)
+		results.Results[i].Schedule = &result
+	}
+	return results, nil
+}
+
+// ListActionSchedules returns all the action schedules in the model.
+func (a *ActionAPI) ListActionSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := a.model.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// ids. Actions already enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.model.RemoveActionSchedule(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	return params.ActionSchedule{
+		Id:           schedule.Id(),
+		Receiver:     schedule.Receiver().String(),
+		Name:         schedule.Name(),
+		Parameters:   schedule.Parameters(),
+		Interval:     schedule.Interval(),
+		NextRun:      schedule.NextRun(),
+		LastActionId: schedule.LastActionId(),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestBlockScheduleActions(c *gc.C) {
+	s.BlockAllChanges(c, "ScheduleActions")
+	_, err := s.action.ScheduleActions(params.AddActionSchedules{})
+	s.AssertBlocked(c, err, "ScheduleActions")
+}
+
+func (s *actionSuite) TestScheduleActions(c *gc.C) {
+	res, err := s.action.ScheduleActions(params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Interval: time.Hour,
+		}, {
+			Receiver: s.wordpress.Tag().String(),
+			Name:     "fakeaction",
+			Interval: time.Hour,
+		}, {
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Interval: time.Second,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Schedule.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(res.Results[0].Schedule.Interval, gc.Equals, time.Hour)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "id not found")
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `interval 1s \(minimum 1m0s\) not valid`)
+
+	list, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, jc.DeepEquals, []params.ActionSchedule{*res.Results[0].Schedule})
+
+	removed, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{
+		Ids: []string{res.Results[0].Schedule.Id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+
+	list, err = s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 0)
+}
@@ -22,6 +22,9 @@ var getState = func(st *state.State, m *state.Model) annotationAccess {
 type Annotations interface {
 	Get(args params.Entities) params.AnnotationsGetResults
 	Set(args params.AnnotationsSet) params.ErrorResults
+	GetStructured(args params.Entities) params.StructuredAnnotationsGetResults
+	SetStructured(args params.StructuredAnnotationsSet) params.ErrorResults
+	Find(args params.AnnotationQueries) params.AnnotationQueryResults
 }
 
 // API implements the service interface and is the concrete
@@ -31,6 +34,25 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
+// APIv2 provides the Annotations API facade for version 2, which
+// does not support structured annotations.
+type APIv2 struct {
+	*API
+}
+
+// NewAPIv2 returns a new Annotations API facade for version 2.
+func NewAPIv2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewAPI returns a new charm annotator API facade.
 func NewAPI(
 	st *state.State,
@@ -118,6 +140,86 @@ func (api *API) Set(args params.AnnotationsSet) params.ErrorResults {
 	return params.ErrorResults{Results: setErrors}
 }
 
+// GetStructured returns the annotations for the given entities, with
+// structured values returned as JSON values rather than strings.
+func (api *API) GetStructured(args params.Entities) params.StructuredAnnotationsGetResults {
+	results := make([]params.StructuredAnnotationsGetResult, len(args.Entities))
+	if err := api.checkCanRead(); err != nil {
+		for i := range results {
+			results[i].Error = common.ServerError(err)
+		}
+		return params.StructuredAnnotationsGetResults{Results: results}
+	}
+	for i, entity := range args.Entities {
+		results[i].EntityTag = entity.Tag
+		values, err := api.getEntityStructuredAnnotations(entity.Tag)
+		if err != nil {
+			results[i].Error = annotateError(err, entity.Tag, "getting")
+			continue
+		}
+		results[i].Annotations = values
+	}
+	return params.StructuredAnnotationsGetResults{Results: results}
+}
+
+// SetStructured stores structured annotations for the given entities.
+// Unlike Set, there is a result for each entity.
+func (api *API) SetStructured(args params.StructuredAnnotationsSet) params.ErrorResults {
+	results := make([]params.ErrorResult, len(args.Annotations))
+	if err := api.checkCanWrite(); err != nil {
+		for i := range results {
+			results[i].Error = common.ServerError(err)
+		}
+		return params.ErrorResults{Results: results}
+	}
+	for i, arg := range args.Annotations {
+		err := api.setEntityStructuredAnnotations(arg.EntityTag, arg.Annotations)
+		if err != nil {
+			results[i].Error = annotateError(err, arg.EntityTag, "setting")
+		}
+	}
+	return params.ErrorResults{Results: results}
+}
+
+// Find returns the tags of the entities whose annotations match each
+// of the given queries.
+func (api *API) Find(args params.AnnotationQueries) params.AnnotationQueryResults {
+	results := make([]params.AnnotationQueryResult, len(args.Queries))
+	if err := api.checkCanRead(); err != nil {
+		for i := range results {
+			results[i].Error = common.ServerError(err)
+		}
+		return params.AnnotationQueryResults{Results: results}
+	}
+	for i, query := range args.Queries {
+		filters := make([]state.AnnotationFilter, len(query.Filters))
+		for j, filter := range query.Filters {
+			filters[j] = state.AnnotationFilter{
+				Key:   filter.Key,
+				Value: filter.Value,
+			}
+		}
+		tags, err := api.access.AnnotatedEntities(query.Kind, filters...)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, tag := range tags {
+			results[i].Entities = append(results[i].Entities, params.Entity{Tag: tag.String()})
+		}
+	}
+	return params.AnnotationQueryResults{Results: results}
+}
+
+// GetStructured is not available in version 2.
+func (*APIv2) GetStructured(_, _ struct{}) {}
+
+// SetStructured is not available in version 2.
+func (*APIv2) SetStructured(_, _ struct{}) {}
+
+// Find is not available in version 2.
+func (*APIv2) Find(_, _ struct{}) {}
+
 func annotateError(err error, tag, op string) *params.Error {
 	return common.ServerError(
 		errors.Trace(
@@ -167,3 +269,31 @@ func (api *API) setEntityAnnotations(entityTag string, annotations map[string]st
 	}
 	return api.access.SetAnnotations(entity, annotations)
 }
+
+func (api *API) getEntityStructuredAnnotations(entityTag string) (map[string]interface{}, error) {
+	tag, err := names.ParseTag(entityTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entity, err := api.findEntity(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values, err := api.access.StructuredAnnotations(entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return values, nil
+}
+
+func (api *API) setEntityStructuredAnnotations(entityTag string, values map[string]interface{}) error {
+	tag, err := names.ParseTag(entityTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entity, err := api.findEntity(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.access.SetStructuredAnnotations(entity, values)
+}
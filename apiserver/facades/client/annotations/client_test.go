@@ -231,6 +231,61 @@ func (s *annotationSuite) TestMultipleEntitiesAnnotations(c *gc.C) {
 	c.Assert(rGet, jc.IsTrue)
 }
 
+func (s *annotationSuite) TestStructuredAnnotations(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	other := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	entity := machine.Tag().String()
+	setResult := s.annotationsAPI.SetStructured(params.StructuredAnnotationsSet{
+		Annotations: []params.EntityStructuredAnnotations{{
+			EntityTag: entity,
+			Annotations: map[string]interface{}{
+				"cost": map[string]interface{}{"centre": "ops"},
+			},
+		}, {
+			EntityTag:   other.Tag().String(),
+			Annotations: map[string]interface{}{"cost": "none"},
+		}, {
+			EntityTag:   "relation-foo.bar#baz.qux",
+			Annotations: map[string]interface{}{"cost": "none"},
+		}},
+	})
+	c.Assert(setResult.Results, gc.HasLen, 3)
+	c.Assert(setResult.Results[0].Error, gc.IsNil)
+	c.Assert(setResult.Results[1].Error, gc.IsNil)
+	c.Assert(setResult.Results[2].Error, gc.ErrorMatches, ".*permission denied.*")
+
+	got := s.annotationsAPI.GetStructured(params.Entities{[]params.Entity{{entity}}})
+	c.Assert(got.Results, jc.DeepEquals, []params.StructuredAnnotationsGetResult{{
+		EntityTag: entity,
+		Annotations: map[string]interface{}{
+			"cost": map[string]interface{}{"centre": "ops"},
+		},
+	}})
+
+	found := s.annotationsAPI.Find(params.AnnotationQueries{
+		Queries: []params.AnnotationQuery{{
+			Kind:    "machine",
+			Filters: []params.AnnotationFilter{{Key: "cost.centre", Value: "ops"}},
+		}, {
+			Filters: []params.AnnotationFilter{{Key: "cost"}},
+		}, {
+			Filters: []params.AnnotationFilter{{Key: "$cost"}},
+		}},
+	})
+	c.Assert(found.Results, gc.HasLen, 3)
+	c.Assert(found.Results[0], jc.DeepEquals, params.AnnotationQueryResult{
+		Entities: []params.Entity{{entity}},
+	})
+	c.Assert(found.Results[1], jc.DeepEquals, params.AnnotationQueryResult{
+		Entities: []params.Entity{{entity}, {other.Tag().String()}},
+	})
+	c.Assert(found.Results[2].Error, gc.ErrorMatches, `annotation filter key "\$cost" not valid`)
+}
+
 func (s *annotationSuite) testSetGetEntitiesAnnotations(c *gc.C, tag names.Tag) {
 	entity := tag.String()
 	entities := []string{entity}
@@ -14,6 +14,9 @@ type annotationAccess interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Annotations(entity state.GlobalEntity) (map[string]string, error)
 	SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error
+	StructuredAnnotations(entity state.GlobalEntity) (map[string]interface{}, error)
+	SetStructuredAnnotations(entity state.GlobalEntity, values map[string]interface{}) error
+	AnnotatedEntities(kind string, filters ...state.AnnotationFilter) ([]names.Tag, error)
 }
 
 // TODO - CAAS(externalreality): After all relevant methods are moved from
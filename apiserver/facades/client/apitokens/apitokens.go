@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apitokens defines an API endpoint that lets local users
+// manage the scoped API tokens with which automation may log in on
+// their behalf.
+package apitokens
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/apitoken"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the controller state used by the facade.
+type Backend interface {
+	AddAPIToken(state.AddAPITokenArgs) (state.APIToken, string, error)
+	APIToken(id string) (state.APIToken, error)
+	APITokens(user *names.UserTag) ([]state.APIToken, error)
+	RemoveAPIToken(id string) error
+}
+
+// API implements the APITokens facade.
+type API struct {
+	backend     Backend
+	user        names.UserTag
+	isSuperuser bool
+}
+
+// NewFacade returns a new APITokens facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(st, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new APITokens facade using the given backend. Users
+// may manage their own tokens; controller superusers may also list
+// and remove the tokens of other users.
+func NewAPI(
+	backend Backend,
+	controllerTag names.ControllerTag,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	user, ok := authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	isSuperuser, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		backend:     backend,
+		user:        user,
+		isSuperuser: isSuperuser,
+	}, nil
+}
+
+// AddTokens adds API tokens for the calling user, returning each
+// token along with a description of it. The tokens cannot be
+// retrieved later.
+func (api *API) AddTokens(args params.AddAPITokensArgs) (params.AddAPITokenResults, error) {
+	results := params.AddAPITokenResults{
+		Results: make([]params.AddAPITokenResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		info, token, err := api.addToken(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Info = info
+		results.Results[i].Token = token
+	}
+	return results, nil
+}
+
+func (api *API) addToken(arg params.AddAPITokenArg) (params.APIToken, string, error) {
+	var modelUUID string
+	if arg.ModelTag != "" {
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return params.APIToken{}, "", errors.Trace(err)
+		}
+		modelUUID = modelTag.Id()
+	}
+	token, secret, err := api.backend.AddAPIToken(state.AddAPITokenArgs{
+		User:         api.user,
+		ModelUUID:    modelUUID,
+		Capabilities: arg.Capabilities,
+		Lifetime:     arg.Lifetime,
+	})
+	if err != nil {
+		return params.APIToken{}, "", errors.Trace(err)
+	}
+	return tokenToParams(token), apitoken.Format(token.ID, secret), nil
+}
+
+// ListTokens returns the API tokens of the calling user, or of all
+// users if the caller is a controller superuser.
+func (api *API) ListTokens() (params.APITokensResult, error) {
+	var user *names.UserTag
+	if !api.isSuperuser {
+		user = &api.user
+	}
+	tokens, err := api.backend.APITokens(user)
+	if err != nil {
+		return params.APITokensResult{}, errors.Trace(err)
+	}
+	result := params.APITokensResult{
+		Tokens: make([]params.APIToken, len(tokens)),
+	}
+	for i, token := range tokens {
+		result.Tokens[i] = tokenToParams(token)
+	}
+	return result, nil
+}
+
+// RemoveTokens removes the API tokens with the given IDs, so that
+// they may no longer be used to log in. Only controller superusers
+// may remove the tokens of other users.
+func (api *API) RemoveTokens(args params.RemoveAPITokensArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.IDs)),
+	}
+	for i, id := range args.IDs {
+		err := api.removeToken(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) removeToken(id string) error {
+	if !api.isSuperuser {
+		token, err := api.backend.APIToken(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if token.User != api.user {
+			return common.ErrPerm
+		}
+	}
+	return errors.Trace(api.backend.RemoveAPIToken(id))
+}
+
+func tokenToParams(token state.APIToken) params.APIToken {
+	result := params.APIToken{
+		ID:           token.ID,
+		UserTag:      token.User.String(),
+		Capabilities: token.Capabilities,
+		Created:      token.Created,
+		Expires:      token.Expires,
+	}
+	if token.ModelUUID != "" {
+		result.ModelTag = names.NewModelTag(token.ModelUUID).String()
+	}
+	return result
+}
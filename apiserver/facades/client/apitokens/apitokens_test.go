@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitokens_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type apiTokensSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	created    time.Time
+}
+
+var _ = gc.Suite(&apiTokensSuite{})
+
+func (s *apiTokensSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.created = time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		tokens: []state.APIToken{{
+			ID:           "0123456789abcdef",
+			User:         names.NewUserTag("bob"),
+			ModelUUID:    coretesting.ModelTag.Id(),
+			Capabilities: []string{"read"},
+			Created:      s.created,
+			Expires:      s.created.Add(time.Hour),
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("bob"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *apiTokensSuite) newAPI(c *gc.C) *apitokens.API {
+	api, err := apitokens.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *apiTokensSuite) TestNewAPIRequiresUser(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := apitokens.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *apiTokensSuite) TestAddTokens(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf("empty capabilities"))
+	results, err := s.newAPI(c).AddTokens(params.AddAPITokensArgs{
+		Args: []params.AddAPITokenArg{{
+			ModelTag:     coretesting.ModelTag.String(),
+			Capabilities: []string{"read", "run-actions"},
+			Lifetime:     time.Hour,
+		}, {
+			Lifetime: time.Hour,
+		}, {
+			ModelTag:     "invalid",
+			Capabilities: []string{"read"},
+			Lifetime:     time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0], jc.DeepEquals, params.AddAPITokenResult{
+		Info: params.APIToken{
+			ID:           "fedcba9876543210",
+			UserTag:      "user-bob",
+			ModelTag:     coretesting.ModelTag.String(),
+			Capabilities: []string{"read", "run-actions"},
+			Created:      s.created,
+			Expires:      s.created.Add(time.Hour),
+		},
+		Token: "fedcba9876543210.s3cret",
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "empty capabilities not valid")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"invalid" is not a valid tag`)
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		"AddAPIToken", []interface{}{state.AddAPITokenArgs{
+			User:         names.NewUserTag("bob"),
+			ModelUUID:    coretesting.ModelTag.Id(),
+			Capabilities: []string{"read", "run-actions"},
+			Lifetime:     time.Hour,
+		}},
+	}, {
+		"AddAPIToken", []interface{}{state.AddAPITokenArgs{
+			User:     names.NewUserTag("bob"),
+			Lifetime: time.Hour,
+		}},
+	}})
+}
+
+func (s *apiTokensSuite) TestListTokens(c *gc.C) {
+	result, err := s.newAPI(c).ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.APITokensResult{
+		Tokens: []params.APIToken{{
+			ID:           "0123456789abcdef",
+			UserTag:      "user-bob",
+			ModelTag:     coretesting.ModelTag.String(),
+			Capabilities: []string{"read"},
+			Created:      s.created,
+			Expires:      s.created.Add(time.Hour),
+		}},
+	})
+	bob := names.NewUserTag("bob")
+	s.backend.CheckCall(c, 0, "APITokens", &bob)
+}
+
+func (s *apiTokensSuite) TestListTokensSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	_, err := s.newAPI(c).ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "APITokens", (*names.UserTag)(nil))
+}
+
+func (s *apiTokensSuite) TestRemoveTokens(c *gc.C) {
+	s.backend.tokens = append(s.backend.tokens, state.APIToken{
+		ID:   "1111111111111111",
+		User: names.NewUserTag("mary"),
+	})
+	results, err := s.newAPI(c).RemoveTokens(params.RemoveAPITokensArgs{
+		IDs: []string{"0123456789abcdef", "1111111111111111", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"APIToken", []interface{}{"0123456789abcdef"}},
+		{"RemoveAPIToken", []interface{}{"0123456789abcdef"}},
+		{"APIToken", []interface{}{"1111111111111111"}},
+		{"APIToken", []interface{}{"missing"}},
+	})
+}
+
+func (s *apiTokensSuite) TestRemoveTokensSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.newAPI(c).RemoveTokens(params.RemoveAPITokensArgs{
+		IDs: []string{"0123456789abcdef"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "RemoveAPIToken")
+}
+
+type mockBackend struct {
+	testing.Stub
+	tokens []state.APIToken
+}
+
+func (b *mockBackend) AddAPIToken(args state.AddAPITokenArgs) (state.APIToken, string, error) {
+	b.MethodCall(b, "AddAPIToken", args)
+	if err := b.NextErr(); err != nil {
+		return state.APIToken{}, "", err
+	}
+	created := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	return state.APIToken{
+		ID:           "fedcba9876543210",
+		User:         args.User,
+		ModelUUID:    args.ModelUUID,
+		Capabilities: args.Capabilities,
+		Created:      created,
+		Expires:      created.Add(args.Lifetime),
+	}, "s3cret", nil
+}
+
+func (b *mockBackend) APIToken(id string) (state.APIToken, error) {
+	b.MethodCall(b, "APIToken", id)
+	if err := b.NextErr(); err != nil {
+		return state.APIToken{}, err
+	}
+	for _, token := range b.tokens {
+		if token.ID == id {
+			return token, nil
+		}
+	}
+	return state.APIToken{}, errors.NotFoundf("API token %q", id)
+}
+
+func (b *mockBackend) APITokens(user *names.UserTag) ([]state.APIToken, error) {
+	b.MethodCall(b, "APITokens", user)
+	return b.tokens, b.NextErr()
+}
+
+func (b *mockBackend) RemoveAPIToken(id string) error {
+	b.MethodCall(b, "RemoveAPIToken", id)
+	return b.NextErr()
+}
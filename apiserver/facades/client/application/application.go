@@ -7,6 +7,7 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"net"
 
@@ -22,9 +23,11 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/modelcache"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -41,10 +44,35 @@ type APIv4 struct {
 	*API
 }
 
+// APIv5 provides the Application API facade for version 5.
+type APIv5 struct {
+	*API
+}
+
+// APIv6 provides the Application API facade for version 6.
+type APIv6 struct {
+	*API
+}
+
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*API
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*API
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*API
+}
+
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 //
-// API provides the Application API facade for version 5.
+// API provides the Application API facade for version 10.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
@@ -58,6 +86,10 @@ type API struct {
 
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
 	getEnviron            stateenvirons.NewEnvironFunc
+
+	// modelCache, if non-nil, is used to serve reads of the
+	// connected model without querying the database.
+	modelCache *modelcache.Cache
 }
 
 // NewFacadeV4 provides the signature required for facade registration
@@ -70,6 +102,56 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration
+// for version 6.
+func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
+// NewFacadeV7 provides the signature required for facade registration
+// for version 7.
+func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -78,13 +160,18 @@ func NewFacade(ctx facade.Context) (*API, error) {
 	}
 	blockChecker := common.NewBlockChecker(ctx.State())
 	stateCharm := CharmToStateCharm
-	return NewAPI(
+	api, err := NewAPI(
 		backend,
 		ctx.Auth(),
 		blockChecker,
 		stateCharm,
 		DeployApplication,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.modelCache = modelcache.FromResources(ctx.Resources())
+	return api, nil
 }
 
 // NewAPI returns a new application API facade.
@@ -126,6 +213,12 @@ func (api *API) checkCanWrite() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
 }
 
+// author returns the name of the user making the API calls, for
+// recording in the config history of the applications they change.
+func (api *API) author() string {
+	return api.authorizer.GetAuthTag().Id()
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -251,6 +344,11 @@ func deployApplication(
 		attachStorage[i] = tag
 	}
 
+	request := deployQuotaRequest(ch.Meta(), args.NumUnits, args.Placement, args.Storage)
+	if err := backend.CheckModelQuotas(request); err != nil {
+		return errors.Annotatef(err, "cannot deploy %q", args.ApplicationName)
+	}
+
 	_, err = deployApplicationFunc(backend, DeployApplicationParams{
 		ApplicationName:  args.ApplicationName,
 		Series:           args.Series,
@@ -270,7 +368,7 @@ func deployApplication(
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
+func ApplicationSetSettingsStrings(application Application, author string, settings map[string]string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -280,7 +378,7 @@ func ApplicationSetSettingsStrings(application Application, settings map[string]
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateConfigSettings(changes)
+	return application.UpdateConfigSettingsBy(author, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -346,8 +444,9 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			"",  // charm settings (YAML)
 			args.ForceSeries,
 			args.ForceCharmURL,
-			nil, // resource IDs
-			nil, // storage constraints
+			false, // pinned
+			nil,   // resource IDs
+			nil,   // storage constraints
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -360,11 +459,11 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
+		if err = applicationSetSettingsYAML(args.ApplicationName, app, api.author(), args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
+		if err = ApplicationSetSettingsStrings(app, api.author(), args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -446,6 +545,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ConfigSettingsYAML,
 		args.ForceSeries,
 		args.ForceUnits,
+		args.Pinned,
 		args.ResourceIDs,
 		args.StorageConstraints,
 	)
@@ -502,7 +602,8 @@ func (api *API) applicationSetCharm(
 	configSettingsStrings map[string]string,
 	configSettingsYAML string,
 	forceSeries,
-	forceUnits bool,
+	forceUnits,
+	pinned bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
 ) error {
@@ -543,6 +644,7 @@ func (api *API) applicationSetCharm(
 		ConfigSettings:     settings,
 		ForceSeries:        forceSeries,
 		ForceUnits:         forceUnits,
+		Pinned:             pinned,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
@@ -579,31 +681,42 @@ func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, e
 
 // applicationSetSettingsYAML updates the settings for the given application,
 // taking the configuration from a YAML string.
-func applicationSetSettingsYAML(appName string, application Application, settings string) error {
+func applicationSetSettingsYAML(appName string, application Application, author, settings string) error {
+	changes, err := parseSettingsYAML(appName, application, settings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(application.UpdateConfigSettingsBy(author, changes), "updating settings")
+}
+
+// parseSettingsYAML parses the settings for the given application from
+// a YAML string, which may be in the format generated by get, or keyed
+// by the application name.
+func parseSettingsYAML(appName string, application Application, settings string) (charm.Settings, error) {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
-		return errors.Annotate(err, "parsing settings data")
+		return nil, errors.Annotate(err, "parsing settings data")
 	}
 	// The file is already in the right format.
 	if _, ok := all[appName]; !ok {
 		changes, err := settingsFromGetYaml(all)
 		if err != nil {
-			return errors.Annotate(err, "processing YAML generated by get")
+			return nil, errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings with application YAML")
+		return changes, nil
 	}
 
 	ch, _, err := application.Charm()
 	if err != nil {
-		return errors.Annotate(err, "obtaining charm for this application")
+		return nil, errors.Annotate(err, "obtaining charm for this application")
 	}
 
 	changes, err := ch.Config().ParseSettingsYAML(b, appName)
 	if err != nil {
-		return errors.Annotate(err, "creating config from YAML")
+		return nil, errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings")
+	return changes, nil
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -644,7 +757,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return app.UpdateConfigSettings(changes)
+	return app.UpdateConfigSettingsBy(api.author(), changes)
 
 }
 
@@ -664,7 +777,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return app.UpdateConfigSettings(settings)
+	return app.UpdateConfigSettingsBy(api.author(), settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -722,14 +835,18 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 }
 
 // AddUnits adds a given number of units to an application.
-func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
+func (api *API) AddUnits(ctx context.Context, args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
-	units, err := addApplicationUnits(api.backend, args)
+	backend := api.backend
+	if traceID := trace.ID(ctx); traceID != "" {
+		backend = backend.WithTraceID(traceID)
+	}
+	units, err := addApplicationUnits(backend, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
@@ -761,6 +878,13 @@ func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]Un
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	request, err := addUnitsQuotaRequest(application, args.NumUnits, args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := backend.CheckModelQuotas(request); err != nil {
+		return nil, errors.Annotatef(err, "cannot add %d units to application %q", args.NumUnits, args.ApplicationName)
+	}
 	return addUnits(
 		application,
 		args.ApplicationName,
@@ -1058,6 +1182,11 @@ func (api *API) getConstraints(entity string) (constraints.Value, error) {
 	}
 	switch kind := tag.Kind(); kind {
 	case names.ApplicationTagKind:
+		if model, ok := api.modelCache.Model(api.authorizer.ConnectedModel()); ok {
+			if app, ok := model.Application(tag.Id()); ok {
+				return app.Constraints, nil
+			}
+		}
 		app, err := api.backend.Application(tag.Id())
 		if err != nil {
 			return constraints.Value{}, err
@@ -1080,7 +1209,12 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err != nil {
 		return err
 	}
-	return app.SetConstraints(args.Constraints)
+	if err := app.SetConstraints(args.Constraints); err != nil {
+		return err
+	}
+	// Subsequent reads must see the new constraints.
+	api.modelCache.Invalidate(api.authorizer.ConnectedModel())
+	return nil
 }
 
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
@@ -1406,6 +1540,99 @@ func (u *APIv4) UpdateApplicationSeries(_, _ struct{}) {}
 // GetConfig isn't on the V4 API.
 func (u *APIv4) GetConfig(_, _ struct{}) {}
 
+// ValidateConfig isn't on the V4 API.
+func (u *APIv4) ValidateConfig(_, _ struct{}) {}
+
+// ValidateConfig isn't on the V5 API.
+func (u *APIv5) ValidateConfig(_, _ struct{}) {}
+
+// ConfigHistory isn't on the V4 API.
+func (u *APIv4) ConfigHistory(_, _ struct{}) {}
+
+// ConfigHistory isn't on the V5 API.
+func (u *APIv5) ConfigHistory(_, _ struct{}) {}
+
+// ConfigHistory isn't on the V6 API.
+func (u *APIv6) ConfigHistory(_, _ struct{}) {}
+
+// MoveUnits isn't on the V4 API.
+func (u *APIv4) MoveUnits(_, _ struct{}) {}
+
+// MoveUnits isn't on the V5 API.
+func (u *APIv5) MoveUnits(_, _ struct{}) {}
+
+// MoveUnits isn't on the V6 API.
+func (u *APIv6) MoveUnits(_, _ struct{}) {}
+
+// MoveUnits isn't on the V7 API.
+func (u *APIv7) MoveUnits(_, _ struct{}) {}
+
+// HookRetryPolicies isn't on the V4 API.
+func (u *APIv4) HookRetryPolicies(_, _ struct{}) {}
+
+// HookRetryPolicies isn't on the V5 API.
+func (u *APIv5) HookRetryPolicies(_, _ struct{}) {}
+
+// HookRetryPolicies isn't on the V6 API.
+func (u *APIv6) HookRetryPolicies(_, _ struct{}) {}
+
+// HookRetryPolicies isn't on the V7 API.
+func (u *APIv7) HookRetryPolicies(_, _ struct{}) {}
+
+// HookRetryPolicies isn't on the V8 API.
+func (u *APIv8) HookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies isn't on the V4 API.
+func (u *APIv4) SetHookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies isn't on the V5 API.
+func (u *APIv5) SetHookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies isn't on the V6 API.
+func (u *APIv6) SetHookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies isn't on the V7 API.
+func (u *APIv7) SetHookRetryPolicies(_, _ struct{}) {}
+
+// SetHookRetryPolicies isn't on the V8 API.
+func (u *APIv8) SetHookRetryPolicies(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V4 API.
+func (u *APIv4) ProxyOverrides(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V5 API.
+func (u *APIv5) ProxyOverrides(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V6 API.
+func (u *APIv6) ProxyOverrides(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V7 API.
+func (u *APIv7) ProxyOverrides(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V8 API.
+func (u *APIv8) ProxyOverrides(_, _ struct{}) {}
+
+// ProxyOverrides isn't on the V9 API.
+func (u *APIv9) ProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V4 API.
+func (u *APIv4) SetProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V5 API.
+func (u *APIv5) SetProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V6 API.
+func (u *APIv6) SetProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V7 API.
+func (u *APIv7) SetProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V8 API.
+func (u *APIv8) SetProxyOverrides(_, _ struct{}) {}
+
+// SetProxyOverrides isn't on the V9 API.
+func (u *APIv9) SetProxyOverrides(_, _ struct{}) {}
+
 // GetConstraints returns the v4 implementation of GetConstraints.
 func (api *APIv4) GetConstraints(args params.GetApplicationConstraints) (params.GetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"regexp"
@@ -1819,7 +1820,7 @@ func (s *applicationSuite) TestClientAddApplicationUnits(c *gc.C) {
 		if t.to != "" {
 			args.Placement = []*instance.Placement{instance.MustParsePlacement(t.to)}
 		}
-		result, err := s.applicationAPI.AddUnits(args)
+		result, err := s.applicationAPI.AddUnits(context.Background(), args)
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 			continue
@@ -1840,7 +1841,7 @@ func (s *applicationSuite) TestAddApplicationUnitsToNewContainer(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, err = s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err = s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        1,
 		Placement:       []*instance.Placement{instance.MustParsePlacement("lxd:" + machine.Id())},
@@ -1891,7 +1892,7 @@ func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
 		if applicationName == "" {
 			applicationName = "dummy"
 		}
-		result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		result, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 			ApplicationName: applicationName,
 			NumUnits:        len(t.expected),
 			Placement:       t.placement,
@@ -1913,7 +1914,7 @@ func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
 }
 
 func (s *applicationSuite) assertAddApplicationUnits(c *gc.C) {
-	result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	result, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 	})
@@ -1947,7 +1948,7 @@ func (s *applicationSuite) TestApplicationCharmRelations(c *gc.C) {
 }
 
 func (s *applicationSuite) assertAddApplicationUnitsBlocked(c *gc.C, msg string) {
-	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 	})
@@ -1974,7 +1975,7 @@ func (s *applicationSuite) TestBlockChangeAddApplicationUnits(c *gc.C) {
 
 func (s *applicationSuite) TestAddUnitToMachineNotFound(c *gc.C) {
 	s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
-	_, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+	_, err := s.applicationAPI.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "dummy",
 		NumUnits:        3,
 		Placement:       []*instance.Placement{instance.MustParsePlacement("42")},
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -17,10 +19,13 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -237,6 +242,22 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmPinned(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql-42",
+		Pinned:          true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm:  &state.Charm{},
+		Pinned: true,
+	})
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -476,7 +497,7 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 }
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
-	results, err := s.api.AddUnits(params.AddApplicationUnits{
+	results, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "postgresql",
 		NumUnits:        1,
 		AttachStorage:   []string{"storage-pgdata-0"},
@@ -487,13 +508,24 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	})
 
 	app := s.backend.applications["postgresql"].(*mockApplication)
-	app.CheckCall(c, 0, "AddUnit", state.AddUnitParams{
+	app.CheckCall(c, 1, "AddUnits", 1, state.AddUnitParams{
 		AttachStorage: []names.StorageTag{names.NewStorageTag("pgdata/0")},
 	})
 }
 
+func (s *ApplicationSuite) TestAddUnitsTraced(c *gc.C) {
+	ctx := trace.WithID(context.Background(), "abcd")
+	_, err := s.api.AddUnits(ctx, params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelTag", "WithTraceID", "Application", "CheckModelQuotas")
+	s.backend.CheckCall(c, 1, "WithTraceID", "abcd")
+}
+
 func (s *ApplicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "foo",
 		NumUnits:        2,
 		AttachStorage:   []string{"storage-foo-0"},
@@ -502,7 +534,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
 }
 
 func (s *ApplicationSuite) TestAddUnitsAttachStorageInvalidStorageTag(c *gc.C) {
-	_, err := s.api.AddUnits(params.AddApplicationUnits{
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
 		ApplicationName: "foo",
 		NumUnits:        1,
 		AttachStorage:   []string{"volume-0"},
@@ -510,6 +542,75 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorageInvalidStorageTag(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
 }
 
+func (s *ApplicationSuite) TestAddUnitsChecksQuotas(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.storage = map[string]state.StorageConstraints{
+		"pgdata": {Pool: "ebs", Size: 2048, Count: 1},
+		"logs":   {Pool: "ebs", Size: 1024, Count: 2},
+	}
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 2, "CheckModelQuotas", state.QuotaUsage{
+		Machines:   2,
+		Units:      3,
+		StorageMiB: 3 * 4096,
+	})
+}
+
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("model quota exceeded"))
+	_, err := s.api.AddUnits(context.Background(), params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add 1 units to application "postgresql": model quota exceeded`)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "StorageConstraints")
+}
+
+func (s *ApplicationSuite) TestDeployChecksQuotas(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{
+		Storage: map[string]charm.Storage{
+			"data": {Name: "data", Type: charm.StorageBlock, CountMin: 1, CountMax: 1},
+			"logs": {Name: "logs", Type: charm.StorageFilesystem, CountMin: 1, CountMax: 1},
+		},
+	}
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+			Storage: map[string]storage.Constraints{
+				"data": {Size: 10240},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCall(c, 2, "CheckModelQuotas", state.QuotaUsage{
+		Machines:   2,
+		Units:      2,
+		StorageMiB: 2 * (10240 + 1024),
+	})
+}
+
+func (s *ApplicationSuite) TestDeployQuotaExceeded(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("model quota exceeded"))
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `cannot deploy "foo": model quota exceeded`)
+}
+
 func (s *ApplicationSuite) TestSetRelationSuspended(c *gc.C) {
 	s.backend.offerConnections["wordpress:db mysql:db"] = &mockOfferConnection{}
 	results, err := s.api.SetRelationsSuspended(params.RelationSuspendedArgs{
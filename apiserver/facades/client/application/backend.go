@@ -31,6 +31,7 @@ type Backend interface {
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
 	Charm(*charm.URL) (Charm, error)
+	CheckModelQuotas(state.QuotaUsage) error
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
@@ -42,6 +43,10 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+
+	// WithTraceID returns a Backend whose transactions are marked
+	// with the given trace ID.
+	WithTraceID(id string) Backend
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -58,25 +63,33 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
+	AddUnits(int, state.AddUnitParams) ([]Unit, error)
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
 	ClearExposed() error
+	ConfigHistory() ([]state.SettingsChange, error)
 	ConfigSettings() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
 	Endpoints() ([]state.Endpoint, error)
+	HookRetryPolicy() state.HookRetryPolicy
 	IsPrincipal() bool
+	ProxyOverrides() state.ProxyOverrides
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetHookRetryPolicy(state.HookRetryPolicy) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetProxyOverrides(state.ProxyOverrides) error
+	StorageConstraints() (map[string]state.StorageConstraints, error)
 	UpdateApplicationSeries(string, bool) error
 	UpdateConfigSettings(charm.Settings) error
+	UpdateConfigSettingsBy(string, charm.Settings) error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -121,6 +134,8 @@ type Unit interface {
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
+	MoveToMachine(string) error
+	CompleteMove() error
 }
 
 // Model defines a subset of the functionality provided by the
@@ -154,6 +169,13 @@ func (s stateShim) SaveController(controllerInfo crossmodel.ControllerInfo, mode
 	return api.Save(controllerInfo, modelUUID)
 }
 
+func (s stateShim) WithTraceID(id string) Backend {
+	return &stateShim{
+		State:     s.State.WithTraceID(id),
+		IAASModel: s.IAASModel,
+	}
+}
+
 // NewStateBackend converts a state.State into a Backend.
 func NewStateBackend(st *state.State) (Backend, error) {
 	im, err := st.IAASModel()
@@ -295,6 +317,18 @@ func (a stateApplicationShim) AddUnit(args state.AddUnitParams) (Unit, error) {
 	return stateUnitShim{u, a.st}, nil
 }
 
+func (a stateApplicationShim) AddUnits(n int, args state.AddUnitParams) ([]Unit, error) {
+	units, err := a.Application.AddUnits(n, args)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = stateUnitShim{u, a.st}
+	}
+	return result, nil
+}
+
 func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	ch, force, err := a.Application.Charm()
 	if err != nil {
@@ -340,6 +374,14 @@ func (u stateUnitShim) AssignWithPlacement(placement *instance.Placement) error
 	return u.st.AssignUnitWithPlacement(u.Unit, placement)
 }
 
+func (u stateUnitShim) MoveToMachine(machineId string) error {
+	m, err := u.st.Machine(machineId)
+	if err != nil {
+		return err
+	}
+	return u.Unit.MoveToMachine(m)
+}
+
 type Subnet interface {
 	CIDR() string
 	VLANTag() int
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -210,6 +211,10 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	lxdProfile, err := charmLXDProfile(archive.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -225,6 +230,7 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+		LXDProfile:  lxdProfile,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -250,6 +256,23 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 	return nil
 }
 
+// charmLXDProfile returns the LXD profile shipped in the charm archive,
+// if any, returning an error if it uses settings that charms may not.
+func charmLXDProfile(ch charm.Charm) (*lxdprofile.Profile, error) {
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return nil, nil
+	}
+	profile, err := lxdprofile.ReadFromArchive(archive.Path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm lxd profile")
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Annotate(err, "invalid charm")
+	}
+	return profile, nil
+}
+
 // charmArchiveStoragePath returns a string that is suitable as a
 // storage path, using a random UUID to avoid colliding with concurrent
 // uploads.
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ConfigHistory returns the recorded changes to the config of each
+// of the given applications, oldest first. Only the most recent
+// changes to each application's config are kept.
+func (api *API) ConfigHistory(args params.Entities) (params.ApplicationConfigHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationConfigHistoryResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationConfigHistoryResults{
+		Results: make([]params.ApplicationConfigHistoryResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		changes, err := api.configHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
+func (api *API) configHistory(tagString string) ([]params.ConfigChange, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := app.ConfigHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.ConfigChange, len(history))
+	for i, change := range history {
+		result[i] = params.ConfigChange{
+			Version: change.Version,
+			Author:  change.Author,
+			Changed: change.Changed,
+			Items:   make([]params.ConfigItemChange, len(change.Changes)),
+		}
+		for j, item := range change.Changes {
+			result[i].Items[j] = params.ConfigItemChange{
+				Kind:     configItemChangeKind(item.Type),
+				Key:      item.Key,
+				OldValue: item.OldValue,
+				NewValue: item.NewValue,
+			}
+		}
+	}
+	return result, nil
+}
+
+func configItemChangeKind(changeType int) string {
+	switch changeType {
+	case state.ItemAdded:
+		return "added"
+	case state.ItemModified:
+		return "modified"
+	case state.ItemDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *ApplicationSuite) TestConfigHistory(c *gc.C) {
+	changed := coretesting.NonZeroTime()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.history = []state.SettingsChange{{
+		Version: 1,
+		Author:  "bob",
+		Changed: changed,
+		Changes: []state.ItemChange{
+			{Type: state.ItemAdded, Key: "stringOption", NewValue: "foo"},
+		},
+	}, {
+		Version: 2,
+		Changed: changed,
+		Changes: []state.ItemChange{
+			{Type: state.ItemModified, Key: "intOption", OldValue: int64(1), NewValue: int64(2)},
+			{Type: state.ItemDeleted, Key: "stringOption", OldValue: "foo"},
+		},
+	}}
+
+	results, err := s.api.ConfigHistory(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-missing"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0], jc.DeepEquals, params.ApplicationConfigHistoryResult{
+		Changes: []params.ConfigChange{{
+			Version: 1,
+			Author:  "bob",
+			Changed: changed,
+			Items: []params.ConfigItemChange{
+				{Kind: "added", Key: "stringOption", NewValue: "foo"},
+			},
+		}, {
+			Version: 2,
+			Changed: changed,
+			Items: []params.ConfigItemChange{
+				{Kind: "modified", Key: "intOption", OldValue: int64(1), NewValue: int64(2)},
+				{Kind: "deleted", Key: "stringOption", OldValue: "foo"},
+			},
+		}},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestConfigHistoryPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.ConfigHistory(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestSetRecordsAuthor(c *gc.C) {
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"stringOption": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCall(c, 0, "UpdateConfigSettingsBy", "admin", charm.Settings{"stringOption": "value"})
+}
@@ -46,6 +46,7 @@ type ApplicationDeployer interface {
 
 type UnitAdder interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
+	AddUnits(int, state.AddUnitParams) ([]Unit, error)
 }
 
 // DeployApplication takes a charm and various parameters and deploys it.
@@ -163,29 +164,28 @@ func addUnits(
 	placement []*instance.Placement,
 	attachStorage []names.StorageTag,
 ) ([]Unit, error) {
-	units := make([]Unit, n)
 	// Hard code for now till we implement a different approach.
 	policy := state.AssignCleanEmpty
+	// The units are created together, in as few transactions as
+	// possible, and then assigned one by one.
 	// TODO what do we do if we fail half-way through this process?
-	for i := 0; i < n; i++ {
-		unit, err := unitAdder.AddUnit(state.AddUnitParams{
-			AttachStorage: attachStorage,
-		})
-		if err != nil {
-			return nil, errors.Annotatef(err, "cannot add unit %d/%d to application %q", i+1, n, appName)
-		}
+	units, err := unitAdder.AddUnits(n, state.AddUnitParams{
+		AttachStorage: attachStorage,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot add %d units to application %q", n, appName)
+	}
+	for i, unit := range units {
 		// Are there still placement directives to use?
 		if i > len(placement)-1 {
 			if err := unit.AssignWithPolicy(policy); err != nil {
 				return nil, errors.Trace(err)
 			}
-			units[i] = unit
 			continue
 		}
 		if err := unit.AssignWithPlacement(placement[i]); err != nil {
 			return nil, errors.Annotatef(err, "adding new machine to host unit %q", unit.UnitTag().Id())
 		}
-		units[i] = unit
 	}
 	return units, nil
 }
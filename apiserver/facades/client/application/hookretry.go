@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// HookRetryPolicies returns the hook retry policies of the given
+// applications.
+func (api *API) HookRetryPolicies(args params.Entities) (params.HookRetryPolicyResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookRetryPolicyResults{}, errors.Trace(err)
+	}
+	results := params.HookRetryPolicyResults{
+		Results: make([]params.HookRetryPolicyResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := api.applicationFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		policy := app.HookRetryPolicy()
+		results.Results[i].Policy = &params.HookRetryPolicy{
+			Disabled:     policy.Disabled,
+			MaxAttempts:  policy.MaxAttempts,
+			MaxRetryTime: policy.MaxRetryTime,
+		}
+	}
+	return results, nil
+}
+
+// SetHookRetryPolicies sets the hook retry policies of the given
+// applications, overriding the model's automatic hook retry behaviour
+// for their units.
+func (api *API) SetHookRetryPolicies(args params.ApplicationHookRetryPolicies) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Policies)),
+	}
+	for i, arg := range args.Policies {
+		app, err := api.applicationFromTag(arg.ApplicationTag)
+		if err == nil {
+			err = app.SetHookRetryPolicy(state.HookRetryPolicy{
+				Disabled:     arg.Policy.Disabled,
+				MaxAttempts:  arg.Policy.MaxAttempts,
+				MaxRetryTime: arg.Policy.MaxRetryTime,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) applicationFromTag(tagString string) (Application, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Application(tag.Id())
+}
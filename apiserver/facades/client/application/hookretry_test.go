@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *ApplicationSuite) TestHookRetryPolicies(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.hookRetry = state.HookRetryPolicy{MaxAttempts: 3, MaxRetryTime: time.Minute}
+	results, err := s.api.HookRetryPolicies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Policy, jc.DeepEquals, &params.HookRetryPolicy{
+		MaxAttempts:  3,
+		MaxRetryTime: time.Minute,
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetHookRetryPolicies(c *gc.C) {
+	results, err := s.api.SetHookRetryPolicies(params.ApplicationHookRetryPolicies{
+		Policies: []params.ApplicationHookRetryPolicy{{
+			ApplicationTag: "application-postgresql",
+			Policy:         params.HookRetryPolicy{Disabled: true},
+		}, {
+			ApplicationTag: "application-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []jtesting.StubCall{
+		{"SetHookRetryPolicy", []interface{}{state.HookRetryPolicy{Disabled: true}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetHookRetryPoliciesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetHookRetryPolicies(params.ApplicationHookRetryPolicies{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+}
+
+func (s *ApplicationSuite) TestSetHookRetryPoliciesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetHookRetryPolicies(params.ApplicationHookRetryPolicies{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"sync"
@@ -86,9 +87,13 @@ type mockApplication struct {
 	charm       *mockCharm
 	curl        *charm.URL
 	endpoints   []state.Endpoint
+	history     []state.SettingsChange
+	hookRetry   state.HookRetryPolicy
+	proxy       state.ProxyOverrides
 	name        string
 	subordinate bool
 	series      string
+	storage     map[string]state.StorageConstraints
 	units       []mockUnit
 }
 
@@ -124,6 +129,16 @@ func (a *mockApplication) AllUnits() ([]application.Unit, error) {
 	return units, nil
 }
 
+func (a *mockApplication) ConfigHistory() ([]state.SettingsChange, error) {
+	a.MethodCall(a, "ConfigHistory")
+	return a.history, a.NextErr()
+}
+
+func (a *mockApplication) UpdateConfigSettingsBy(author string, changes charm.Settings) error {
+	a.MethodCall(a, "UpdateConfigSettingsBy", author, changes)
+	return a.NextErr()
+}
+
 func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	a.MethodCall(a, "SetCharm", cfg)
 	return a.NextErr()
@@ -143,6 +158,24 @@ func (a *mockApplication) AddUnit(args state.AddUnitParams) (application.Unit, e
 	return &mockUnit{tag: unitTag}, nil
 }
 
+func (a *mockApplication) AddUnits(n int, args state.AddUnitParams) ([]application.Unit, error) {
+	a.MethodCall(a, "AddUnits", n, args)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	units := make([]application.Unit, n)
+	for i := range units {
+		unitTag := names.NewUnitTag(fmt.Sprintf("%s/%d", a.name, 99+i))
+		units[i] = &mockUnit{tag: unitTag}
+	}
+	return units, nil
+}
+
+func (a *mockApplication) StorageConstraints() (map[string]state.StorageConstraints, error) {
+	a.MethodCall(a, "StorageConstraints")
+	return a.storage, a.NextErr()
+}
+
 func (a *mockApplication) IsPrincipal() bool {
 	a.MethodCall(a, "IsPrincipal")
 	a.PopNoErr()
@@ -160,6 +193,28 @@ func (a *mockApplication) Series() string {
 	return a.series
 }
 
+func (a *mockApplication) HookRetryPolicy() state.HookRetryPolicy {
+	a.MethodCall(a, "HookRetryPolicy")
+	a.PopNoErr()
+	return a.hookRetry
+}
+
+func (a *mockApplication) SetHookRetryPolicy(policy state.HookRetryPolicy) error {
+	a.MethodCall(a, "SetHookRetryPolicy", policy)
+	return a.NextErr()
+}
+
+func (a *mockApplication) ProxyOverrides() state.ProxyOverrides {
+	a.MethodCall(a, "ProxyOverrides")
+	a.PopNoErr()
+	return a.proxy
+}
+
+func (a *mockApplication) SetProxyOverrides(overrides state.ProxyOverrides) error {
+	a.MethodCall(a, "SetProxyOverrides", overrides)
+	return a.NextErr()
+}
+
 type mockRemoteApplication struct {
 	jtesting.Stub
 	name           string
@@ -302,9 +357,9 @@ func (m *mockBackend) Unit(name string) (application.Unit, error) {
 		}
 	}
 	if unitApp != nil {
-		for _, u := range unitApp.units {
-			if u.tag.Id() == name {
-				return &u, nil
+		for i := range unitApp.units {
+			if u := &unitApp.units[i]; u.tag.Id() == name {
+				return u, nil
 			}
 		}
 	}
@@ -448,6 +503,11 @@ func (m *mockBackend) RemoteApplication(name string) (application.RemoteApplicat
 	return app, nil
 }
 
+func (m *mockBackend) WithTraceID(id string) application.Backend {
+	m.MethodCall(m, "WithTraceID", id)
+	return m
+}
+
 func (m *mockBackend) Application(name string) (application.Application, error) {
 	m.MethodCall(m, "Application", name)
 	if err := m.NextErr(); err != nil {
@@ -499,6 +559,11 @@ func (m *mockBackend) ModelUUID() string {
 	return m.modelUUID
 }
 
+func (m *mockBackend) CheckModelQuotas(request state.QuotaUsage) error {
+	m.MethodCall(m, "CheckModelQuotas", request)
+	return m.NextErr()
+}
+
 func (m *mockBackend) ModelTag() names.ModelTag {
 	m.MethodCall(m, "ModelTag")
 	m.PopNoErr()
@@ -594,6 +659,16 @@ func (u *mockUnit) AssignWithPlacement(placement *instance.Placement) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) MoveToMachine(machineId string) error {
+	u.MethodCall(u, "MoveToMachine", machineId)
+	return u.NextErr()
+}
+
+func (u *mockUnit) CompleteMove() error {
+	u.MethodCall(u, "CompleteMove")
+	return u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
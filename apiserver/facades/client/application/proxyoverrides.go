@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ProxyOverrides returns the proxy overrides of the given
+// applications.
+func (api *API) ProxyOverrides(args params.Entities) (params.ProxyOverridesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ProxyOverridesResults{}, errors.Trace(err)
+	}
+	results := params.ProxyOverridesResults{
+		Results: make([]params.ProxyOverridesResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := api.applicationFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		overrides := app.ProxyOverrides()
+		results.Results[i].Overrides = &params.ProxyConfig{
+			HTTP:    overrides.HTTP,
+			HTTPS:   overrides.HTTPS,
+			FTP:     overrides.FTP,
+			NoProxy: overrides.NoProxy,
+		}
+	}
+	return results, nil
+}
+
+// SetProxyOverrides sets the proxy overrides of the given
+// applications, overriding the model's proxy settings in the
+// environment of their units' hooks.
+func (api *API) SetProxyOverrides(args params.ApplicationsProxyOverrides) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		app, err := api.applicationFromTag(arg.ApplicationTag)
+		if err == nil {
+			err = app.SetProxyOverrides(state.ProxyOverrides{
+				HTTP:    arg.Overrides.HTTP,
+				HTTPS:   arg.Overrides.HTTPS,
+				FTP:     arg.Overrides.FTP,
+				NoProxy: arg.Overrides.NoProxy,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
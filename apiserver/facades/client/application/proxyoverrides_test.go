@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *ApplicationSuite) TestProxyOverrides(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.proxy = state.ProxyOverrides{HTTP: "http://proxy", NoProxy: "example.com"}
+	results, err := s.api.ProxyOverrides(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Overrides, jc.DeepEquals, &params.ProxyConfig{
+		HTTP:    "http://proxy",
+		NoProxy: "example.com",
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetProxyOverrides(c *gc.C) {
+	results, err := s.api.SetProxyOverrides(params.ApplicationsProxyOverrides{
+		Applications: []params.ApplicationProxyOverrides{{
+			ApplicationTag: "application-postgresql",
+			Overrides:      params.ProxyConfig{HTTPS: "https://proxy"},
+		}, {
+			ApplicationTag: "application-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "mysql" not found`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCalls(c, []jtesting.StubCall{
+		{"SetProxyOverrides", []interface{}{state.ProxyOverrides{HTTPS: "https://proxy"}}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetProxyOverridesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetProxyOverrides(params.ApplicationsProxyOverrides{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+}
+
+func (s *ApplicationSuite) TestSetProxyOverridesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetProxyOverrides(params.ApplicationsProxyOverrides{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// deployQuotaRequest returns the resources, subject to model quotas,
+// that deploying units of the charm will add to the model.
+func deployQuotaRequest(
+	meta *charm.Meta,
+	numUnits int,
+	placement []*instance.Placement,
+	cons map[string]storage.Constraints,
+) state.QuotaUsage {
+	if meta.Subordinate || numUnits <= 0 {
+		return state.QuotaUsage{}
+	}
+	// Storage not specified in the constraints gets the defaults
+	// that state applies when the application is added.
+	var unitStorage uint64
+	for name, charmStorage := range meta.Storage {
+		size := uint64(1024)
+		if charmStorage.MinimumSize > 0 {
+			size = charmStorage.MinimumSize
+		}
+		count := uint64(charmStorage.CountMin)
+		if c, ok := cons[name]; ok {
+			if c.Size > 0 {
+				size = c.Size
+			}
+			if c.Count > 0 {
+				count = c.Count
+			}
+		}
+		unitStorage += size * count
+	}
+	return state.QuotaUsage{
+		Machines:   newMachineCount(numUnits, placement),
+		Units:      numUnits,
+		StorageMiB: unitStorage * uint64(numUnits),
+	}
+}
+
+// addUnitsQuotaRequest returns the resources, subject to model quotas,
+// that adding units to the application will add to the model.
+func addUnitsQuotaRequest(
+	application Application,
+	numUnits int,
+	placement []*instance.Placement,
+) (state.QuotaUsage, error) {
+	cons, err := application.StorageConstraints()
+	if err != nil {
+		return state.QuotaUsage{}, errors.Trace(err)
+	}
+	var unitStorage uint64
+	for _, c := range cons {
+		unitStorage += c.Size * c.Count
+	}
+	return state.QuotaUsage{
+		Machines:   newMachineCount(numUnits, placement),
+		Units:      numUnits,
+		StorageMiB: unitStorage * uint64(numUnits),
+	}, nil
+}
+
+// newMachineCount returns how many of the units, placed with the given
+// directives, may require a new machine. Units placed on an existing
+// machine do not; all others, including those placed in new
+// containers, are assumed to.
+func newMachineCount(numUnits int, placement []*instance.Placement) int {
+	count := 0
+	for i := 0; i < numUnits; i++ {
+		if i < len(placement) && placement[i] != nil && placement[i].Scope == instance.MachineScope {
+			continue
+		}
+		count++
+	}
+	return count
+}
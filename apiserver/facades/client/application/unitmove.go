@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MoveUnits moves each of the given principal units, along with their
+// subordinates, to another machine. The unit's agent stops the unit on
+// its current machine before the move is completed, unless Force is
+// set, in which case the move is completed immediately.
+func (api *API) MoveUnits(args params.MoveUnitsParams) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	for i, arg := range args.Units {
+		if err := api.moveUnit(arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (api *API) moveUnit(arg params.MoveUnitParams) error {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineTag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !unit.IsPrincipal() {
+		return errors.Errorf("unit %q is a subordinate", unitTag.Id())
+	}
+	if err := unit.MoveToMachine(machineTag.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Force {
+		return errors.Trace(unit.CompleteMove())
+	}
+	return nil
+}
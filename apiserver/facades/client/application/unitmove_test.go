@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *ApplicationSuite) TestMoveUnits(c *gc.C) {
+	results, err := s.api.MoveUnits(params.MoveUnitsParams{
+		Units: []params.MoveUnitParams{
+			{UnitTag: "unit-postgresql-0", MachineTag: "machine-1"},
+			{UnitTag: "unit-postgresql-1", MachineTag: "machine-2", Force: true},
+			{UnitTag: "unit-postgresql-2", MachineTag: "machine-1"},
+			{UnitTag: "unit-postgresql-0", MachineTag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `unit "postgresql/2" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"application-postgresql" is not a valid machine tag`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.units[0].CheckCalls(c, []jtesting.StubCall{
+		{"IsPrincipal", nil},
+		{"MoveToMachine", []interface{}{"1"}},
+	})
+	app.units[1].CheckCalls(c, []jtesting.StubCall{
+		{"IsPrincipal", nil},
+		{"MoveToMachine", []interface{}{"2"}},
+		{"CompleteMove", nil},
+	})
+}
+
+func (s *ApplicationSuite) TestMoveUnitsError(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.units[0].SetErrors(nil, errors.New("cannot move unit: series does not match"))
+	results, err := s.api.MoveUnits(params.MoveUnitsParams{
+		Units: []params.MoveUnitParams{
+			{UnitTag: "unit-postgresql-0", MachineTag: "machine-1", Force: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "cannot move unit: series does not match")
+	app.units[0].CheckCallNames(c, "IsPrincipal", "MoveToMachine")
+}
+
+func (s *ApplicationSuite) TestMoveUnitsBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.MoveUnits(params.MoveUnitsParams{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+}
+
+func (s *ApplicationSuite) TestMoveUnitsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.MoveUnits(params.MoveUnitsParams{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
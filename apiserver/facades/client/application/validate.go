@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ValidateConfig checks the proposed config for each application
+// against its charm's config schema, without applying it. Each
+// result holds the typed values which would be set, with nil for
+// those which would be reset to their defaults.
+func (api *API) ValidateConfig(args params.ApplicationValidateConfigArgs) (params.ApplicationGetConfigResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationGetConfigResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationGetConfigResults{
+		Results: make([]params.ConfigResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		changes, err := api.validateConfig(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Config = changes
+	}
+	return results, nil
+}
+
+func (api *API) validateConfig(arg params.ApplicationValidateConfig) (charm.Settings, error) {
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if arg.ConfigYAML != "" {
+		changes, err := parseSettingsYAML(arg.ApplicationName, app, arg.ConfigYAML)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Settings in the format generated by get are not checked
+		// against the charm when parsed, so check them here.
+		changes, err = ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return changes, nil
+	}
+	changes, err := parseSettingsCompatible(ch.Config(), arg.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return changes, nil
+}
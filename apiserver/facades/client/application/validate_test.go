@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *ApplicationSuite) TestValidateConfig(c *gc.C) {
+	results, err := s.api.ValidateConfig(params.ApplicationValidateConfigArgs{
+		Args: []params.ApplicationValidateConfig{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "value", "intOption": ""},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"intOption": "forty-two"},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"unknownOption": "value"},
+		}, {
+			ApplicationName: "postgresql",
+			ConfigYAML: `
+postgresql:
+  intOption: 42
+`,
+		}, {
+			ApplicationName: "postgresql",
+			ConfigYAML: `
+settings:
+  unknownOption:
+    value: foo
+`,
+		}, {
+			ApplicationName: "missing",
+			Config:          map[string]string{"stringOption": "value"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 6)
+	c.Check(results.Results[0], jc.DeepEquals, params.ConfigResult{
+		Config: map[string]interface{}{"stringOption": "value", "intOption": nil},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `option "intOption" expected int, got "forty-two"`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `unknown option "unknownOption"`)
+	c.Check(results.Results[3], jc.DeepEquals, params.ConfigResult{
+		Config: map[string]interface{}{"intOption": int64(42)},
+	})
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `unknown option "unknownOption"`)
+	c.Check(results.Results[5].Error, gc.ErrorMatches, `application "missing" not found`)
+
+	// Nothing was changed.
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestValidateConfigPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.ValidateConfig(params.ApplicationValidateConfigArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package capabilities defines an API endpoint that lets clients
+// discover the features supported by a controller.
+package capabilities
+
+import (
+	"sort"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Registry describes the facades served by the controller.
+// It is satisfied by *facade.Registry.
+type Registry interface {
+	List() []facade.Description
+	ListDeprecated() []facade.Deprecation
+}
+
+// API implements the Capabilities facade.
+type API struct {
+	registry Registry
+}
+
+// NewAPI returns a new Capabilities facade reporting on the
+// facades in the given registry.
+func NewAPI(registry Registry) *API {
+	return &API{registry: registry}
+}
+
+// Capabilities returns the features supported by the controller,
+// and the facade versions that it has deprecated.
+func (api *API) Capabilities() params.CapabilitiesResult {
+	facades := make(map[string]bool)
+	for _, description := range api.registry.List() {
+		facades[description.Name] = true
+	}
+	features := []string{}
+	for feature, facadeName := range params.FeatureFacades {
+		if facades[facadeName] {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+
+	var deprecated []params.DeprecatedFacade
+	for _, d := range api.registry.ListDeprecated() {
+		deprecated = append(deprecated, params.DeprecatedFacade{
+			Name:    d.Name,
+			Version: d.Version,
+			Message: d.Message,
+		})
+	}
+	return params.CapabilitiesResult{
+		Features:          features,
+		DeprecatedFacades: deprecated,
+	}
+}
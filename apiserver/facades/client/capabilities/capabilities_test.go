@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capabilities_test
+
+import (
+	"reflect"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/capabilities"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type capabilitiesSuite struct {
+	testing.IsolationSuite
+	registry *facade.Registry
+}
+
+var _ = gc.Suite(&capabilitiesSuite{})
+
+func (s *capabilitiesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.registry = &facade.Registry{}
+}
+
+func (s *capabilitiesSuite) register(c *gc.C, name string, version int) {
+	factory := func(facade.Context) (facade.Facade, error) {
+		return nil, nil
+	}
+	err := s.registry.Register(name, version, factory, reflect.TypeOf((*int)(nil)))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *capabilitiesSuite) TestNoFeatures(c *gc.C) {
+	s.register(c, "Client", 1)
+	result := capabilities.NewAPI(s.registry).Capabilities()
+	c.Assert(result, jc.DeepEquals, params.CapabilitiesResult{
+		Features: []string{},
+	})
+}
+
+func (s *capabilitiesSuite) TestFeatures(c *gc.C) {
+	s.register(c, "Spaces", 3)
+	s.register(c, "ApplicationOffers", 1)
+	result := capabilities.NewAPI(s.registry).Capabilities()
+	c.Assert(result.Features, jc.DeepEquals, []string{
+		params.FeatureCrossModelRelations,
+		params.FeatureSpaces,
+	})
+}
+
+func (s *capabilitiesSuite) TestDeprecatedFacades(c *gc.C) {
+	s.register(c, "Spaces", 2)
+	s.register(c, "Spaces", 3)
+	err := s.registry.Deprecate("Spaces", 2, "use Spaces(3)")
+	c.Assert(err, jc.ErrorIsNil)
+	result := capabilities.NewAPI(s.registry).Capabilities()
+	c.Assert(result.DeprecatedFacades, jc.DeepEquals, []params.DeprecatedFacade{{
+		Name:    "Spaces",
+		Version: 2,
+		Message: "use Spaces(3)",
+	}})
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cleanups defines an API endpoint that lets model
+// administrators see the cleanups pending in a model, to diagnose
+// teardown that does not complete.
+package cleanups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the model state used by the facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Cleanups() ([]state.CleanupInfo, error)
+}
+
+// stateShim adapts *state.State to Backend.
+type stateShim struct {
+	*state.State
+}
+
+// ModelTag is part of the Backend interface.
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+// API implements the Cleanups facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade returns a new Cleanups facade for the model of the
+// context's State.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new Cleanups facade using the given backend. Only
+// administrators of the backend's model may use it.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// List returns the cleanups in the model that have not yet completed,
+// in the order they will be run.
+func (api *API) List() (params.CleanupsResult, error) {
+	cleanups, err := api.backend.Cleanups()
+	if err != nil {
+		return params.CleanupsResult{}, errors.Trace(err)
+	}
+	result := params.CleanupsResult{
+		Cleanups: make([]params.CleanupInfo, len(cleanups)),
+	}
+	for i, cleanup := range cleanups {
+		info := params.CleanupInfo{
+			Id:        cleanup.Id,
+			Kind:      cleanup.Kind,
+			Prefix:    cleanup.Prefix,
+			Created:   cleanup.Created,
+			Attempts:  cleanup.Attempts,
+			LastError: cleanup.LastError,
+		}
+		if !cleanup.LastAttempt.IsZero() {
+			lastAttempt := cleanup.LastAttempt
+			info.LastAttempt = &lastAttempt
+		}
+		result.Cleanups[i] = info
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cleanups_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/cleanups"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type cleanupsSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&cleanupsSuite{})
+
+func (s *cleanupsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *cleanupsSuite) TestNewAPIRequiresModelAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("writebob")
+	_, err := cleanups.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *cleanupsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := cleanups.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *cleanupsSuite) TestList(c *gc.C) {
+	created := time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC)
+	lastAttempt := created.Add(time.Minute)
+	s.backend.cleanups = []state.CleanupInfo{{
+		Id:          "5a0b",
+		Kind:        "dyingMachine",
+		Prefix:      "0",
+		Created:     created,
+		Attempts:    2,
+		LastAttempt: lastAttempt,
+		LastError:   "boom",
+	}, {
+		Id:      "5a0c",
+		Kind:    "charm",
+		Prefix:  "cs:mysql-1",
+		Created: created,
+	}}
+	api, err := cleanups.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CleanupsResult{
+		Cleanups: []params.CleanupInfo{{
+			Id:          "5a0b",
+			Kind:        "dyingMachine",
+			Prefix:      "0",
+			Created:     created,
+			Attempts:    2,
+			LastAttempt: &lastAttempt,
+			LastError:   "boom",
+		}, {
+			Id:      "5a0c",
+			Kind:    "charm",
+			Prefix:  "cs:mysql-1",
+			Created: created,
+		}},
+	})
+}
+
+func (s *cleanupsSuite) TestListError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := cleanups.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.List()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	cleanups []state.CleanupInfo
+	err      error
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Cleanups() ([]state.CleanupInfo, error) {
+	return b.cleanups, b.err
+}
@@ -28,6 +28,7 @@ type Backend interface {
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddOneMachine(state.MachineTemplate) (*state.Machine, error)
 	AddRelation(...state.Endpoint) (*state.Relation, error)
+	AgentsNotAtVersion(version.Number, version.Number) ([]string, error)
 	AllApplications() ([]*state.Application, error)
 	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
 	AllRemoteApplications() ([]*state.RemoteApplication, error)
@@ -38,6 +39,7 @@ type Backend interface {
 	AllRelations() ([]*state.Relation, error)
 	AllSubnets() ([]*state.Subnet, error)
 	Annotations(state.GlobalEntity) (map[string]string, error)
+	CheckModelQuotas(state.QuotaUsage) error
 	APIHostPorts() ([][]network.HostPort, error)
 	Application(string) (*state.Application, error)
 	ApplicationLeaders() (map[string]string, error)
@@ -48,7 +50,6 @@ type Backend interface {
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	IsController() bool
 	LatestMigration() (state.ModelMigration, error)
-	LatestPlaceholderCharm(*charm.URL) (*state.Charm, error)
 	Machine(string) (*state.Machine, error)
 	Model() (*state.Model, error)
 	ModelConfig() (*config.Config, error)
@@ -61,7 +62,9 @@ type Backend interface {
 	RemoveUserAccess(names.UserTag, names.Tag) error
 	SetAnnotations(state.GlobalEntity, map[string]string) error
 	SetModelAgentVersion(version.Number, bool) error
+	SetModelAgentVersionStaged(version.Number, bool, []string, int) error
 	SetModelConstraints(constraints.Value) error
+	StagedUpgrade() (state.StagedUpgrade, error)
 	Unit(string) (Unit, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	Watch(params state.WatchParams) *state.Multiwatcher
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
+	"github.com/juju/juju/apiserver/modelcache"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -40,6 +41,9 @@ type API struct {
 	// statusSetter provides common methods for updating an entity's provisioning status.
 	statusSetter *common.StatusSetter
 	toolsFinder  *common.ToolsFinder
+	// modelCache, if non-nil, is used to serve reads of the
+	// connected model without querying the database.
+	modelCache *modelcache.Cache
 }
 
 // TODO(wallyworld) - remove this method
@@ -141,7 +145,7 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 		return nil, errors.Trace(err)
 	}
 	addresser := common.NewAPIAddresser(st, resources)
-	return NewClient(
+	client, err := NewClient(
 		&stateShim{st, model},
 		&poolShim{ctx.StatePool()},
 		modelConfigAPI,
@@ -153,6 +157,79 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 		blockChecker,
 		addresser,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client.api.modelCache = modelcache.FromResources(resources)
+	return client, nil
+}
+
+// ClientV3 serves the Client facade for version 3, which does not
+// support UpgradePrecheck.
+type ClientV3 struct {
+	*Client
+}
+
+// ClientV2 serves the Client facade for version 2, which does not
+// support staged upgrades.
+type ClientV2 struct {
+	*ClientV3
+}
+
+// ClientV1 serves the Client facade for version 1, which does not
+// support StatusAt.
+type ClientV1 struct {
+	*ClientV2
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade
+// registration.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV2 provides the required signature for version 2 facade
+// registration.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
+// NewFacadeV1 provides the required signature for version 1 facade
+// registration.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
+// SetModelAgentVersion sets the model agent version. Staged upgrades
+// aren't supported on the V2 API, so the upgrade is never staged.
+func (c *ClientV2) SetModelAgentVersion(args params.SetModelAgentVersion) error {
+	args.Canaries = nil
+	args.BatchSize = 0
+	return c.Client.SetModelAgentVersion(args)
+}
+
+// StatusAt isn't on the V1 API.
+func (*ClientV1) StatusAt(_, _ struct{}) {}
+
+// cachedModel returns the connected model from the model cache, if
+// it has been loaded there.
+func (c *Client) cachedModel() (*modelcache.Model, bool) {
+	if c.api.modelCache == nil {
+		return nil, false
+	}
+	return c.api.modelCache.Model(c.api.auth.ConnectedModel())
 }
 
 // NewClient creates a new instance of the Client Facade.
@@ -247,6 +324,11 @@ func (c *Client) PublicAddress(p params.PublicAddress) (results params.PublicAdd
 		return params.PublicAddressResults{PublicAddress: addr.Value}, nil
 
 	case names.IsValidUnit(p.Target):
+		if model, ok := c.cachedModel(); ok {
+			if unit, ok := model.Unit(p.Target); ok && unit.PublicAddress != "" {
+				return params.PublicAddressResults{PublicAddress: unit.PublicAddress}, nil
+			}
+		}
 		unit, err := c.api.stateAccessor.Unit(p.Target)
 		if err != nil {
 			return results, err
@@ -279,6 +361,11 @@ func (c *Client) PrivateAddress(p params.PrivateAddress) (results params.Private
 		return params.PrivateAddressResults{PrivateAddress: addr.Value}, nil
 
 	case names.IsValidUnit(p.Target):
+		if model, ok := c.cachedModel(); ok {
+			if unit, ok := model.Unit(p.Target); ok && unit.PrivateAddress != "" {
+				return params.PrivateAddressResults{PrivateAddress: unit.PrivateAddress}, nil
+			}
+		}
 		unit, err := c.api.stateAccessor.Unit(p.Target)
 		if err != nil {
 			return results, err
@@ -299,6 +386,11 @@ func (c *Client) GetModelConstraints() (params.GetConstraintsResults, error) {
 		return params.GetConstraintsResults{}, err
 	}
 
+	if model, ok := c.cachedModel(); ok {
+		if info, ok := model.Info(); ok {
+			return params.GetConstraintsResults{info.Constraints}, nil
+		}
+	}
 	cons, err := c.api.stateAccessor.ModelConstraints()
 	if err != nil {
 		return params.GetConstraintsResults{}, err
@@ -315,7 +407,12 @@ func (c *Client) SetModelConstraints(args params.SetConstraints) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.api.stateAccessor.SetModelConstraints(args.Constraints)
+	if err := c.api.stateAccessor.SetModelConstraints(args.Constraints); err != nil {
+		return err
+	}
+	// Subsequent reads must see the new constraints.
+	c.api.modelCache.Invalidate(c.api.auth.ConnectedModel())
+	return nil
 }
 
 // AddMachines adds new machines with the supplied parameters.
@@ -403,6 +500,15 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		placementDirective = p.Placement.Directive
 	}
 
+	request := state.QuotaUsage{Machines: 1}
+	if p.ContainerType != "" && p.ParentId == "" {
+		// The container's new host machine counts too.
+		request.Machines++
+	}
+	if err := c.api.stateAccessor.CheckModelQuotas(request); err != nil {
+		return nil, err
+	}
+
 	jobs, err := common.StateJobs(p.Jobs)
 	if err != nil {
 		return nil, err
@@ -575,7 +681,10 @@ func (c *Client) AgentVersion() (params.AgentVersionResult, error) {
 	return params.AgentVersionResult{Version: jujuversion.Current}, nil
 }
 
-// SetModelAgentVersion sets the model agent version.
+// SetModelAgentVersion sets the model agent version. If canaries or a
+// batch size are given, the upgrade is staged: after the controllers,
+// only the canary machines upgrade, followed by the remaining machines
+// in batches once the upgraded machines are healthy.
 func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	if err := c.checkCanWrite(); err != nil {
 		return err
@@ -614,6 +723,11 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 		}
 	}
 
+	if len(args.Canaries) > 0 || args.BatchSize != 0 {
+		return c.api.stateAccessor.SetModelAgentVersionStaged(
+			args.Version, args.IgnoreAgentVersions, args.Canaries, args.BatchSize,
+		)
+	}
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version, args.IgnoreAgentVersions)
 }
 
@@ -234,6 +234,21 @@ func (s *serverSuite) TestSetModelAgentVersion(c *gc.C) {
 	s.assertModelVersion(c, s.State, "9.8.7")
 }
 
+func (s *serverSuite) TestSetModelAgentVersionStaged(c *gc.C) {
+	args := params.SetModelAgentVersion{
+		Version:   version.MustParse("9.8.7"),
+		BatchSize: 2,
+	}
+	err := s.client.SetModelAgentVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertModelVersion(c, s.State, "9.8.7")
+
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Version, gc.Equals, version.MustParse("9.8.7"))
+	c.Check(upgrade.BatchSize, gc.Equals, 2)
+}
+
 func (s *serverSuite) TestSetModelAgentVersionForced(c *gc.C) {
 	// Get the agent-version set in the model.
 	cfg, err := s.Model.ModelConfig()
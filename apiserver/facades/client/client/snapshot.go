@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModelStatusSnapshot returns a compact encoding of the full status
+// of the given model, as seen by a model administrator, for storing
+// as a status snapshot.
+func ModelStatusSnapshot(st *state.State) ([]byte, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &Client{api: &API{stateAccessor: &stateShim{st, model}}}
+	status, err := c.fullStatus(params.StatusParams{}, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		return nil, errors.Annotate(err, "encoding status")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Annotate(err, "compressing status")
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeStatusSnapshot returns the status held in data, as encoded
+// by ModelStatusSnapshot.
+func decodeStatusSnapshot(data []byte) (params.FullStatus, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return params.FullStatus{}, errors.Annotate(err, "decompressing status")
+	}
+	defer r.Close()
+	var status params.FullStatus
+	if err := json.NewDecoder(r).Decode(&status); err != nil {
+		return params.FullStatus{}, errors.Annotate(err, "decoding status")
+	}
+	return status, nil
+}
+
+// StatusAt returns the status of the model as it was at the given
+// time, from the most recent status snapshot taken no later than
+// that time. Snapshots are only kept for as long as the model's
+// status history.
+func (c *Client) StatusAt(args params.StatusAtParams) (params.StatusAtResult, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.StatusAtResult{}, err
+	}
+	model, err := c.api.stateAccessor.Model()
+	if err != nil {
+		return params.StatusAtResult{}, errors.Trace(err)
+	}
+	snapshot, err := model.StatusSnapshotAt(args.At)
+	if err != nil {
+		return params.StatusAtResult{}, errors.Trace(err)
+	}
+	status, err := decodeStatusSnapshot(snapshot.Data)
+	if err != nil {
+		return params.StatusAtResult{}, errors.Annotatef(err, "status snapshot taken at %s", snapshot.Taken)
+	}
+	// Only admins can see offer details.
+	if err := c.checkIsAdmin(); err != nil {
+		status.Offers = nil
+	}
+	return params.StatusAtResult{
+		Taken:  snapshot.Taken,
+		Status: status,
+	}, nil
+}
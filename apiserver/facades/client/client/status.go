@@ -167,41 +167,61 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
 	}
+	// Only admins can see offer details.
+	includeOffers := c.checkIsAdmin() == nil
+	return c.fullStatus(args, includeOffers)
+}
 
+// fullStatus computes the status of the model. Offers are included
+// only if includeOffers is true.
+func (c *Client) fullStatus(args params.StatusParams, includeOffers bool) (params.FullStatus, error) {
 	var noStatus params.FullStatus
+	fields, err := newStatusFields(args.Fields)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
 	var context statusContext
-	var err error
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch model")
 	}
 	if context.status, err = context.model.LoadModelStatus(); err != nil {
 		return noStatus, errors.Annotate(err, "could not load model status values")
 	}
-	if context.applications, context.units, context.latestCharms, err =
+	if context.applications, context.units, context.charmUpgrades, err =
 		fetchAllApplicationsAndUnits(c.api.stateAccessor, context.model); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch applications and units")
 	}
-	if context.consumerRemoteApplications, err =
-		fetchConsumerRemoteApplications(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch remote applications")
+	if fields.Contains(params.StatusFieldRemoteApplications) {
+		if context.consumerRemoteApplications, err =
+			fetchConsumerRemoteApplications(c.api.stateAccessor); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch remote applications")
+		}
 	}
-	// Only admins can see offer details.
-	if err := c.checkIsAdmin(); err == nil {
+	if includeOffers && fields.Contains(params.StatusFieldOffers) {
 		if context.offers, err =
 			fetchOffers(c.api.stateAccessor, context.applications); err != nil {
 			return noStatus, errors.Annotate(err, "could not fetch application offers")
 		}
 	}
-	if context.machines, err = fetchMachines(c.api.stateAccessor, nil); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch machines")
+	if fields.Contains(params.StatusFieldMachines) {
+		if context.machines, err = fetchMachines(c.api.stateAccessor, nil); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch machines")
+		}
+		// These may be empty when machines have not finished deployment.
+		if context.ipAddresses, context.spaces, context.linkLayerDevices, err =
+			fetchNetworkInterfaces(c.api.stateAccessor); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
+		}
 	}
-	// These may be empty when machines have not finished deployment.
-	if context.ipAddresses, context.spaces, context.linkLayerDevices, err =
-		fetchNetworkInterfaces(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
+	// Application status includes the relations of each application,
+	// so relations are needed for either section.
+	if fields.Contains(params.StatusFieldRelations) || fields.Contains(params.StatusFieldApplications) {
+		if context.relations, context.relationsById, err = fetchRelations(c.api.stateAccessor); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch relations")
+		}
 	}
-	if context.relations, context.relationsById, err = fetchRelations(c.api.stateAccessor); err != nil {
-		return noStatus, errors.Annotate(err, "could not fetch relations")
+	if len(args.Applications) > 0 {
+		context.filterApplications(set.NewStrings(args.Applications...))
 	}
 	if len(context.applications) > 0 {
 		if context.leaders, err = c.api.stateAccessor.ApplicationLeaders(); err != nil {
@@ -313,14 +333,91 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
-	return params.FullStatus{
-		Model:              modelStatus,
-		Machines:           context.processMachines(),
-		Applications:       context.processApplications(),
-		RemoteApplications: context.processRemoteApplications(),
-		Offers:             context.processOffers(),
-		Relations:          context.processRelations(),
-	}, nil
+	result := params.FullStatus{Model: modelStatus}
+	if fields.Contains(params.StatusFieldMachines) {
+		result.Machines = context.processMachines()
+	}
+	if fields.Contains(params.StatusFieldApplications) {
+		result.Applications = context.processApplications()
+	}
+	if fields.Contains(params.StatusFieldRemoteApplications) {
+		result.RemoteApplications = context.processRemoteApplications()
+	}
+	if fields.Contains(params.StatusFieldOffers) {
+		result.Offers = context.processOffers()
+	}
+	if fields.Contains(params.StatusFieldRelations) {
+		result.Relations = context.processRelations()
+	}
+	return result, nil
+}
+
+// allStatusFields holds all of the status sections that may be
+// selected with StatusParams.Fields.
+var allStatusFields = []string{
+	params.StatusFieldMachines,
+	params.StatusFieldApplications,
+	params.StatusFieldRemoteApplications,
+	params.StatusFieldOffers,
+	params.StatusFieldRelations,
+}
+
+// newStatusFields returns the set of status sections to compute.
+// If no fields are specified, all sections are computed.
+func newStatusFields(fields []string) (set.Strings, error) {
+	all := set.NewStrings(allStatusFields...)
+	if len(fields) == 0 {
+		return all, nil
+	}
+	result := set.NewStrings()
+	for _, field := range fields {
+		if !all.Contains(field) {
+			return nil, errors.NotValidf("status field %q", field)
+		}
+		result.Add(field)
+	}
+	return result, nil
+}
+
+// filterApplications removes all applications not in the given set,
+// along with their units and relations, from the status context.
+// Subordinate applications related to the kept principals are kept
+// too, and relations are kept if any of their endpoints belongs to a
+// kept application.
+func (context *statusContext) filterApplications(names set.Strings) {
+	keep := set.NewStrings(names.Values()...)
+	for _, appName := range names.Values() {
+		for _, r := range context.relations[appName] {
+			for _, ep := range r.Endpoints() {
+				if ep.Scope != charm.ScopeContainer || ep.ApplicationName == appName {
+					continue
+				}
+				if app, ok := context.applications[ep.ApplicationName]; ok && !app.IsPrincipal() {
+					keep.Add(ep.ApplicationName)
+				}
+			}
+		}
+	}
+	for appName := range context.applications {
+		if keep.Contains(appName) {
+			continue
+		}
+		delete(context.applications, appName)
+		delete(context.units, appName)
+		delete(context.relations, appName)
+	}
+	for id, r := range context.relationsById {
+		kept := false
+		for _, ep := range r.Endpoints() {
+			if keep.Contains(ep.ApplicationName) {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			delete(context.relationsById, id)
+		}
+	}
 }
 
 // newToolsVersionAvailable will return a string representing a tools
@@ -350,19 +447,24 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 		}
 	}
 
-	status, err := m.Status()
+	info.ModelStatus, err = c.modelDetailedStatus(m)
 	if err != nil {
 		return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain model status info")
 	}
 
-	info.SLA = m.SLALevel()
+	upgrade, err := c.api.stateAccessor.StagedUpgrade()
+	if err == nil {
+		info.UpgradeStatus = stagedUpgradeStatus(upgrade)
+	} else if !errors.IsNotFound(err) {
+		return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain staged upgrade")
+	}
 
-	info.ModelStatus = params.DetailedStatus{
-		Status: status.Status.String(),
-		Info:   status.Message,
-		Since:  status.Since,
-		Data:   status.Data,
+	info.FanSubnets, err = fanSubnets(c.api.stateAccessor)
+	if err != nil {
+		return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain fan subnets")
 	}
+
+	info.SLA = m.SLALevel()
 	ms := m.MeterStatus()
 	if isColorStatus(ms.Code) {
 		info.MeterStatus = params.MeterStatus{Color: strings.ToLower(ms.Code.String()), Message: ms.Info}
@@ -371,6 +473,68 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 	return info, nil
 }
 
+// fanSubnets returns the fan overlay segments known to the model,
+// ordered by CIDR.
+func fanSubnets(st Backend) ([]params.FanSubnet, error) {
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []params.FanSubnet
+	for _, subnet := range subnets {
+		if subnet.FanOverlay() == "" {
+			continue
+		}
+		out = append(out, params.FanSubnet{
+			CIDR:     subnet.CIDR(),
+			Underlay: subnet.FanLocalUnderlay(),
+			Overlay:  subnet.FanOverlay(),
+			Space:    subnet.SpaceName(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CIDR < out[j].CIDR
+	})
+	return out, nil
+}
+
+// stagedUpgradeStatus describes the progress of a staged upgrade.
+func stagedUpgradeStatus(upgrade state.StagedUpgrade) string {
+	msg := fmt.Sprintf("staged upgrade from %s to %s", upgrade.PreviousVersion, upgrade.Version)
+	if len(upgrade.Canaries) > 0 {
+		msg += fmt.Sprintf(", canaries %s", strings.Join(upgrade.Canaries, ","))
+	}
+	if upgrade.BatchSize > 0 {
+		msg += fmt.Sprintf(", batch size %d", upgrade.BatchSize)
+	}
+	return fmt.Sprintf("%s: %d machine(s) admitted", msg, len(upgrade.Admitted))
+}
+
+// modelDetailedStatus returns the status of the model, from the model
+// cache if it is loaded there.
+func (c *Client) modelDetailedStatus(m *state.Model) (params.DetailedStatus, error) {
+	if model, ok := c.cachedModel(); ok {
+		if info, ok := model.Info(); ok && info.Status.Current != "" {
+			return params.DetailedStatus{
+				Status: info.Status.Current.String(),
+				Info:   info.Status.Message,
+				Since:  info.Status.Since,
+				Data:   info.Status.Data,
+			}, nil
+		}
+	}
+	status, err := m.Status()
+	if err != nil {
+		return params.DetailedStatus{}, errors.Trace(err)
+	}
+	return params.DetailedStatus{
+		Status: status.Status.String(),
+		Info:   status.Message,
+		Since:  status.Since,
+		Data:   status.Data,
+	}, nil
+}
+
 type statusContext struct {
 	model  *state.Model
 	status *state.ModelStatus
@@ -399,7 +563,7 @@ type statusContext struct {
 	relations     map[string][]*state.Relation
 	relationsById map[int]*state.Relation
 	units         map[string]map[string]*state.Unit
-	latestCharms  map[charm.URL]*state.Charm
+	charmUpgrades map[string]state.CharmUpgrade
 	leaders       map[string]string
 }
 
@@ -515,15 +679,16 @@ func fetchNetworkInterfaces(st Backend) (map[string][]*state.Address, map[string
 }
 
 // fetchAllApplicationsAndUnits returns a map from application name to application,
-// a map from application name to unit name to unit, and a map from base charm URL to latest URL.
+// a map from application name to unit name to unit, and a map from application
+// name to the charm upgrade available to it.
 func fetchAllApplicationsAndUnits(
 	st Backend,
 	model *state.Model,
-) (map[string]*state.Application, map[string]map[string]*state.Unit, map[charm.URL]*state.Charm, error) {
+) (map[string]*state.Application, map[string]map[string]*state.Unit, map[string]state.CharmUpgrade, error) {
 
 	appMap := make(map[string]*state.Application)
 	unitMap := make(map[string]map[string]*state.Unit)
-	latestCharms := make(map[charm.URL]*state.Charm)
+	charmUpgrades := make(map[string]state.CharmUpgrade)
 	applications, err := st.AllApplications()
 	if err != nil {
 		return nil, nil, nil, err
@@ -549,26 +714,24 @@ func fetchAllApplicationsAndUnits(
 		appUnits := allUnitsByApp[app.Name()]
 		if len(appUnits) > 0 {
 			unitMap[app.Name()] = appUnits
-			// Record the base URL for the application's charm so that
-			// the latest store revision can be looked up.
+			// Record the upgrade found in the application's
+			// charm store channel, if any.
 			charmURL, _ := app.CharmURL()
-			if charmURL.Schema == "cs" {
-				latestCharms[*charmURL.WithRevision(-1)] = nil
+			if charmURL.Schema != "cs" || app.CharmPinned() {
+				continue
 			}
+			upgrade, err := app.CharmUpgrade()
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			charmUpgrades[app.Name()] = upgrade
 		}
 	}
-	for baseURL := range latestCharms {
-		ch, err := st.LatestPlaceholderCharm(&baseURL)
-		if errors.IsNotFound(err) {
-			continue
-		}
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		latestCharms[baseURL] = ch
-	}
 
-	return appMap, unitMap, latestCharms, nil
+	return appMap, unitMap, charmUpgrades, nil
 }
 
 // fetchConsumerRemoteApplications returns a map from application name to remote application.
@@ -774,6 +937,9 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 				DNSNameservers: ns,
 				Space:          strings.Join(sp.Values(), " "),
 				IsUp:           llDev.IsUp(),
+				Type:           string(llDev.Type()),
+				ParentName:     llDev.ParentDeviceName(),
+				VLANTag:        llDev.VLANTag(),
 			}
 		}
 		logger.Debugf("NetworkInterfaces: %+v", status.NetworkInterfaces)
@@ -802,6 +968,7 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 	} else {
 		status.Hardware = hc.String()
 	}
+	status.LXDProfiles = machine.CharmProfiles()
 	status.Containers = make(map[string]params.MachineStatus)
 	return
 }
@@ -896,10 +1063,11 @@ func (context *statusContext) processApplication(application *state.Application)
 		Life:    processLife(application),
 	}
 
-	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
-		if latestCharm.Revision() > applicationCharm.URL().Revision {
-			processedStatus.CanUpgradeTo = latestCharm.String()
+	if upgrade, ok := context.charmUpgrades[application.Name()]; ok {
+		if upgrade.CharmURL.Revision > applicationCharm.URL().Revision {
+			processedStatus.CanUpgradeTo = upgrade.CharmURL.String()
 		}
+		processedStatus.CanUpgradeResources = upgrade.Resources
 	}
 
 	processedStatus.Relations, processedStatus.SubordinateTo, err = context.processApplicationRelations(application)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	"github.com/juju/juju/apiserver/params"
@@ -73,6 +74,70 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusFields(c *gc.C) {
+	s.addMachine(c)
+	s.Factory.MakeUnit(c, nil)
+	client := s.APIState.Client()
+	status, err := client.StatusWithArgs(api.StatusArgs{
+		Fields: []string{params.StatusFieldApplications},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Model.Name, gc.Equals, "controller")
+	c.Check(status.Applications, gc.HasLen, 1)
+	c.Check(status.Machines, gc.HasLen, 0)
+	c.Check(status.Relations, gc.HasLen, 0)
+}
+
+func (s *statusSuite) TestFullStatusInvalidField(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.StatusWithArgs(api.StatusArgs{
+		Fields: []string{"storage"},
+	})
+	c.Assert(err, gc.ErrorMatches, `status field "storage" not valid`)
+}
+
+func (s *statusSuite) TestFullStatusApplicationFilter(c *gc.C) {
+	u1 := s.Factory.MakeUnit(c, nil)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "other"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	client := s.APIState.Client()
+	status, err := client.StatusWithArgs(api.StatusArgs{
+		Applications: []string{u1.ApplicationName()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 1)
+	_, ok := status.Applications[u1.ApplicationName()]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *statusSuite) TestStatusAt(c *gc.C) {
+	machine := s.addMachine(c)
+	data, err := client.ModelStatusSnapshot(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.AddStatusSnapshot(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Machines added after the snapshot was taken are not reported.
+	s.addMachine(c)
+	status, taken, err := s.APIState.Client().StatusAt(farFuture)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(taken.IsZero(), jc.IsFalse)
+	c.Check(status.Model.Name, gc.Equals, "controller")
+	c.Check(status.Machines, gc.HasLen, 1)
+	_, ok := status.Machines[machine.Id()]
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *statusSuite) TestStatusAtNoSnapshot(c *gc.C) {
+	_, _, err := s.APIState.Client().StatusAt(farFuture)
+	c.Assert(err, gc.ErrorMatches, "status snapshot at .* not found")
+}
+
+// farFuture is later than any status snapshot taken by the tests.
+var farFuture = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -433,6 +498,38 @@ func (s *statusUnitTestSuite) TestRelationFiltered(c *gc.C) {
 	assertApplicationRelations(c, a3.Name(), 1, status.Relations)
 }
 
+func (s *statusUnitTestSuite) TestApplicationFilterKeepsSubordinatesAndRelations(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	logging := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "logging"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeRelation(c, &factory.RelationParams{Endpoints: eps})
+	eps, err = s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeRelation(c, &factory.RelationParams{Endpoints: eps})
+
+	client := s.APIState.Client()
+	status, err := client.StatusWithArgs(api.StatusArgs{
+		Applications: []string{wordpress.Name()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 2)
+	_, ok := status.Applications[logging.Name()]
+	c.Assert(ok, jc.IsTrue)
+	_, ok = status.Applications[mysql.Name()]
+	c.Assert(ok, jc.IsFalse)
+	// The relation to the filtered out mysql application is still
+	// reported, as wordpress is one of its endpoints.
+	assertApplicationRelations(c, wordpress.Name(), 2, status.Relations)
+}
+
 func assertApplicationRelations(c *gc.C, appName string, expectedNumber int, relations []params.RelationStatus) {
 	c.Assert(relations, gc.HasLen, expectedNumber)
 	for _, relation := range relations {
@@ -509,3 +606,28 @@ func (s *statusUpgradeUnitSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(ok, gc.Equals, true)
 	c.Assert(serviceStatus.CanUpgradeTo, gc.Equals, "cs:quantal/mysql-23")
 }
+
+func (s *statusUpgradeUnitSuite) TestUpdateRevisionsPinned(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	// Pin mysql to its current, out of date, revision.
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetCharm(state.SetCharmConfig{Charm: ch, Pinned: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// No upgrade is suggested for the pinned application.
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	serviceStatus, ok := status.Applications["mysql"]
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(serviceStatus.CanUpgradeTo, gc.Equals, "")
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// seriesEOL holds the dates on which the Ubuntu series that juju
+// supports reach the end of their standard support.
+var seriesEOL = map[string]time.Time{
+	"precise": time.Date(2017, time.April, 28, 0, 0, 0, 0, time.UTC),
+	"trusty":  time.Date(2019, time.April, 30, 0, 0, 0, 0, time.UTC),
+	"xenial":  time.Date(2021, time.April, 30, 0, 0, 0, 0, time.UTC),
+	"yakkety": time.Date(2017, time.July, 20, 0, 0, 0, 0, time.UTC),
+	"zesty":   time.Date(2018, time.January, 13, 0, 0, 0, 0, time.UTC),
+	"artful":  time.Date(2018, time.July, 19, 0, 0, 0, 0, time.UTC),
+	"bionic":  time.Date(2023, time.May, 31, 0, 0, 0, 0, time.UTC),
+}
+
+// seriesEOLWarning is how long before a series reaches its end of
+// life that machines running it are reported.
+const seriesEOLWarning = 180 * 24 * time.Hour
+
+// deprecatedConfigAttributes maps model config attributes that are
+// deprecated to the reason they should no longer be used.
+var deprecatedConfigAttributes = map[string]string{
+	"ignore-machine-addresses": "machine addresses should be managed with spaces instead",
+	"default-image-id":         "it is ignored; image metadata determines the image used",
+	"default-instance-type":    "it is ignored; constraints determine the instance type used",
+}
+
+// UpgradePrecheck reports the problems that would prevent, or that
+// should be resolved before, upgrading the model to the given version.
+// It changes nothing.
+func (c *Client) UpgradePrecheck(args params.UpgradePrecheckArgs) (params.UpgradePrecheckResult, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.UpgradePrecheckResult{}, err
+	}
+	issues, err := c.upgradePrecheck(args.Version, args.IgnoreAgentVersions, time.Now())
+	if err != nil {
+		return params.UpgradePrecheckResult{}, errors.Trace(err)
+	}
+	return params.UpgradePrecheckResult{
+		Version: args.Version,
+		Issues:  issues,
+	}, nil
+}
+
+// UpgradePrecheck isn't on the V3 API.
+func (*ClientV3) UpgradePrecheck(_, _ struct{}) {}
+
+func (c *Client) upgradePrecheck(target version.Number, ignoreAgentVersions bool, now time.Time) ([]params.UpgradePrecheckIssue, error) {
+	var issues []params.UpgradePrecheckIssue
+	for _, check := range []func(version.Number, bool, time.Time) ([]params.UpgradePrecheckIssue, error){
+		c.precheckAgentVersions,
+		c.precheckMigrations,
+		c.precheckModelConfig,
+		c.precheckCharms,
+		c.precheckSeries,
+	} {
+		found, err := check(target, ignoreAgentVersions, now)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// precheckAgentVersions reports agents that have not finished
+// upgrading to the model's current version, and versions that a
+// hosted model cannot be upgraded to.
+func (c *Client) precheckAgentVersions(target version.Number, ignoreAgentVersions bool, _ time.Time) ([]params.UpgradePrecheckIssue, error) {
+	cfg, err := c.api.stateAccessor.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current, ok := cfg.AgentVersion()
+	if !ok {
+		return nil, errors.New("no agent version set in the model")
+	}
+	var issues []params.UpgradePrecheckIssue
+	if target.Compare(jujuversion.Current) > 0 && !c.api.stateAccessor.IsController() {
+		issues = append(issues, params.UpgradePrecheckIssue{
+			Check:    "agent-version",
+			Severity: params.UpgradePrecheckError,
+			Message: fmt.Sprintf(
+				"a hosted model cannot have a higher version than the controller (%s)",
+				jujuversion.Current,
+			),
+		})
+	}
+	agents, err := c.api.stateAccessor.AgentsNotAtVersion(current, target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	severity := params.UpgradePrecheckError
+	if ignoreAgentVersions {
+		severity = params.UpgradePrecheckWarning
+	}
+	for _, agent := range agents {
+		issues = append(issues, params.UpgradePrecheckIssue{
+			Check:    "agent-version",
+			Severity: severity,
+			Entity:   agent,
+			Message:  fmt.Sprintf("agent has not upgraded to the current model version %s", current),
+		})
+	}
+	return issues, nil
+}
+
+// precheckMigrations reports the model, or for the controller model
+// any model, being migrated.
+func (c *Client) precheckMigrations(version.Number, bool, time.Time) ([]params.UpgradePrecheckIssue, error) {
+	var modelUUIDs []string
+	if c.api.stateAccessor.IsController() {
+		var err error
+		modelUUIDs, err = c.api.stateAccessor.AllModelUUIDs()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		modelUUIDs = []string{c.api.stateAccessor.ModelUUID()}
+	}
+	var issues []params.UpgradePrecheckIssue
+	for _, modelUUID := range modelUUIDs {
+		model, release, err := c.api.pool.GetModel(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if mode := model.MigrationMode(); mode != state.MigrationModeNone {
+			issues = append(issues, params.UpgradePrecheckIssue{
+				Check:    "migration",
+				Severity: params.UpgradePrecheckError,
+				Entity:   model.ModelTag().String(),
+				Message:  fmt.Sprintf("model \"%s/%s\" is %s", model.Owner().Name(), model.Name(), mode),
+			})
+		}
+		release()
+	}
+	return issues, nil
+}
+
+// precheckModelConfig reports deprecated model config attributes that
+// are set.
+func (c *Client) precheckModelConfig(version.Number, bool, time.Time) ([]params.UpgradePrecheckIssue, error) {
+	cfg, err := c.api.stateAccessor.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs := cfg.AllAttrs()
+	var issues []params.UpgradePrecheckIssue
+	for _, name := range sortedKeys(deprecatedConfigAttributes) {
+		switch attrs[name] {
+		case nil, false, "":
+			continue
+		}
+		issues = append(issues, params.UpgradePrecheckIssue{
+			Check:    "model-config",
+			Severity: params.UpgradePrecheckWarning,
+			Message: fmt.Sprintf(
+				"model config %q is deprecated: %s",
+				name, deprecatedConfigAttributes[name],
+			),
+		})
+	}
+	return issues, nil
+}
+
+// precheckCharms reports applications whose charms require a newer
+// version of juju than the target version.
+func (c *Client) precheckCharms(target version.Number, _ bool, _ time.Time) ([]params.UpgradePrecheckIssue, error) {
+	applications, err := c.api.stateAccessor.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var issues []params.UpgradePrecheckIssue
+	for _, app := range applications {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		minver := ch.Meta().MinJujuVersion
+		if minver == version.Zero || minver.Compare(target) <= 0 {
+			continue
+		}
+		issues = append(issues, params.UpgradePrecheckIssue{
+			Check:    "charm",
+			Severity: params.UpgradePrecheckError,
+			Entity:   app.Tag().String(),
+			Message: fmt.Sprintf(
+				"charm %s requires juju %s or later",
+				ch.URL(), minver,
+			),
+		})
+	}
+	return issues, nil
+}
+
+// precheckSeries reports machines running series that have reached,
+// or will soon reach, the end of their support.
+func (c *Client) precheckSeries(_ version.Number, _ bool, now time.Time) ([]params.UpgradePrecheckIssue, error) {
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var issues []params.UpgradePrecheckIssue
+	for _, m := range machines {
+		eol, ok := seriesEOL[m.Series()]
+		if !ok || now.Add(seriesEOLWarning).Before(eol) {
+			continue
+		}
+		verb := "reaches"
+		if !now.Before(eol) {
+			verb = "reached"
+		}
+		issues = append(issues, params.UpgradePrecheckIssue{
+			Check:    "series",
+			Severity: params.UpgradePrecheckWarning,
+			Entity:   names.NewMachineTag(m.Id()).String(),
+			Message: fmt.Sprintf(
+				"series %q %s end of life on %s",
+				m.Series(), verb, eol.Format("2006-01-02"),
+			),
+		})
+	}
+	return issues, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
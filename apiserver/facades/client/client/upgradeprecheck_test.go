@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
+)
+
+func (s *serverSuite) upgradePrecheck(c *gc.C, vers string, ignoreAgentVersions bool) []params.UpgradePrecheckIssue {
+	result, err := s.client.UpgradePrecheck(params.UpgradePrecheckArgs{
+		Version:             version.MustParse(vers),
+		IgnoreAgentVersions: ignoreAgentVersions,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Version, gc.Equals, version.MustParse(vers))
+	return result.Issues
+}
+
+func (s *serverSuite) TestUpgradePrecheckNoIssues(c *gc.C) {
+	c.Assert(s.upgradePrecheck(c, "9.8.7", false), gc.HasLen, 0)
+}
+
+func (s *serverSuite) TestUpgradePrecheckAgentVersions(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetAgentVersion(version.MustParseBinary("1.0.2-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	current, _ := cfg.AgentVersion()
+
+	expected := params.UpgradePrecheckIssue{
+		Check:    "agent-version",
+		Severity: params.UpgradePrecheckError,
+		Entity:   machine.Tag().String(),
+		Message:  "agent has not upgraded to the current model version " + current.String(),
+	}
+	c.Assert(s.upgradePrecheck(c, "9.8.7", false), jc.DeepEquals, []params.UpgradePrecheckIssue{expected})
+
+	expected.Severity = params.UpgradePrecheckWarning
+	c.Assert(s.upgradePrecheck(c, "9.8.7", true), jc.DeepEquals, []params.UpgradePrecheckIssue{expected})
+}
+
+func (s *serverSuite) TestUpgradePrecheckMigrations(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "some-user"})
+	s.makeMigratingModel(c, "to-migrate", state.MigrationModeExporting)
+
+	issues := s.upgradePrecheck(c, "9.8.7", false)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Assert(issues[0].Check, gc.Equals, "migration")
+	c.Assert(issues[0].Severity, gc.Equals, params.UpgradePrecheckError)
+	c.Assert(issues[0].Message, gc.Equals, `model "some-user/to-migrate" is exporting`)
+}
+
+func (s *serverSuite) TestUpgradePrecheckDeprecatedConfig(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"ignore-machine-addresses": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.upgradePrecheck(c, "9.8.7", false), jc.DeepEquals, []params.UpgradePrecheckIssue{{
+		Check:    "model-config",
+		Severity: params.UpgradePrecheckWarning,
+		Message:  `model config "ignore-machine-addresses" is deprecated: machine addresses should be managed with spaces instead`,
+	}})
+}
+
+func (s *serverSuite) TestUpgradePrecheckCharmMinVersion(c *gc.C) {
+	// The charm can only be added while juju is new enough for it.
+	s.PatchValue(&jujuversion.Current, version.MustParse("999.999.999"))
+	ch := s.AddTestingCharm(c, "minjujuversion")
+	app := s.AddTestingApplication(c, "minjujuversion", ch)
+
+	issues := s.upgradePrecheck(c, "2.3.0", false)
+	c.Assert(issues, jc.DeepEquals, []params.UpgradePrecheckIssue{{
+		Check:    "charm",
+		Severity: params.UpgradePrecheckError,
+		Entity:   app.Tag().String(),
+		Message:  "charm " + ch.URL().String() + " requires juju 999.999.999 or later",
+	}})
+	c.Assert(s.upgradePrecheck(c, "999.999.999", false), gc.HasLen, 0)
+}
+
+func (s *serverSuite) TestUpgradePrecheckSeries(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "trusty"})
+	s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+
+	// Machines that have not reported an agent version are reported
+	// too, so only series issues are checked.
+	issues := s.upgradePrecheck(c, "9.8.7", true)
+	var seriesIssues []params.UpgradePrecheckIssue
+	for _, issue := range issues {
+		if issue.Check == "series" {
+			seriesIssues = append(seriesIssues, issue)
+		}
+	}
+	c.Assert(seriesIssues, jc.DeepEquals, []params.UpgradePrecheckIssue{{
+		Check:    "series",
+		Severity: params.UpgradePrecheckWarning,
+		Entity:   machine.Tag().String(),
+		Message:  `series "trusty" reached end of life on 2019-04-30`,
+	}})
+}
@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ConfigSet changes the values of the given controller config
+// attributes. Only the attributes in
+// controller.AllowedUpdateConfigAttributes may be changed; the
+// controller applies the changes without its agents being restarted.
+// Only controller superusers may call it.
+func (s *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.state.UpdateControllerConfig(args.Config, nil))
+}
+
+// ConfigSet isn't on the V7 API.
+func (s *ControllerAPIv7) ConfigSet(_, _ struct{}) {}
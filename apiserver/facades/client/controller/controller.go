@@ -42,13 +42,37 @@ type ControllerAPI struct {
 	resources  facade.Resources
 }
 
+// ControllerAPIv7 provides the v7 Controller API. It lacks
+// ConfigSet.
+type ControllerAPIv7 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv6 provides the v6 Controller API. It lacks
+// RefreshInstanceTypes.
+type ControllerAPIv6 struct {
+	*ControllerAPIv7
+}
+
+// ControllerAPIv5 provides the v5 Controller API. It lacks
+// ControllerMetrics.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
+// ControllerAPIv4 provides the v4 Controller API. It lacks
+// EngineReports.
+type ControllerAPIv4 struct {
+	*ControllerAPIv5
+}
+
 // ControllerAPIv3 provides the v3 Controller API.
 type ControllerAPIv3 struct {
-	*ControllerAPI
+	*ControllerAPIv4
 }
 
-// NewControllerAPIv4 creates a new ControllerAPIv4.
-func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -62,6 +86,42 @@ func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
+// NewControllerAPIv4 creates a new ControllerAPIv4.
+func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
+	v5, err := NewControllerAPIv5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv4{v5}, nil
+}
+
 // NewControllerAPIv3 creates a new ControllerAPIv3.
 func NewControllerAPIv3(ctx facade.Context) (*ControllerAPIv3, error) {
 	v4, err := NewControllerAPIv4(ctx)
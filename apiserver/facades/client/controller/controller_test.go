@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -66,7 +68,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv4(
+	controller, err := controller.NewControllerAPIv8(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.statePool,
@@ -907,3 +909,142 @@ func (s *controllerSuite) TestModelStatus(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
 }
+
+func (s *controllerSuite) TestEngineReports(c *gc.C) {
+	modelTag := s.IAASModel.ModelTag().String()
+	err := s.State.SetEngineReport(names.NewMachineTag("0"), "engine report")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.controller.EngineReports(params.EngineReportQueries{
+		Queries: []params.EngineReportQuery{
+			{ModelTag: modelTag, Tag: "machine-0"},
+			{ModelTag: modelTag, Tag: "unit-mysql-0"},
+			{ModelTag: modelTag, Tag: "user-bob"},
+			{ModelTag: "bad-tag", Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Report, gc.Equals, "engine report")
+	c.Check(results.Results[0].Updated.IsZero(), jc.IsFalse)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `engine report for unit mysql/0 not found`)
+	c.Check(results.Results[1].Error.Code, gc.Equals, params.CodeNotFound)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `agent tag "user-bob" not valid`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+}
+
+func (s *controllerSuite) TestEngineReportsRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv7(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.EngineReports(params.EngineReportQueries{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestControllerMetrics(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	for i, machine := range []string{"0", "1"} {
+		err := s.State.AddControllerMetrics(state.ControllerMetricsSample{
+			Machine: machine,
+			Time:    now.Add(time.Duration(i) * time.Minute),
+			Metrics: map[string]float64{"txn-queue-depth": float64(i)},
+		}, time.Hour)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := s.controller.ControllerMetrics(params.ControllerMetricsArgs{
+		Since: now.Add(30 * time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Samples, gc.HasLen, 1)
+	c.Check(result.Samples[0].Machine, gc.Equals, "1")
+	c.Check(result.Samples[0].Time.Equal(now.Add(time.Minute)), jc.IsTrue)
+	c.Check(result.Samples[0].Metrics, jc.DeepEquals, map[string]float64{"txn-queue-depth": 1})
+}
+
+func (s *controllerSuite) TestControllerMetricsRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv7(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.ControllerMetrics(params.ControllerMetricsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestRefreshInstanceTypes(c *gc.C) {
+	cache := instances.NewTypesCache(clock.WallClock, time.Hour)
+	controller.PatchInstanceTypesCache(s, cache)
+	fetch := func() ([]instances.InstanceType, error) {
+		return []instances.InstanceType{{Name: "n1-standard-1"}}, nil
+	}
+	_, err := cache.Get("gce/project/us-east1", fetch)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.controller.RefreshInstanceTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache.Refresh(), gc.Equals, 0)
+}
+
+func (s *controllerSuite) TestRefreshInstanceTypesRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv7(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	err = endpoint.RefreshInstanceTypes()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled":       true,
+		"api-request-rate-limit": 20,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	limit, ok := cfg.APIRequestRateLimit()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(limit, gc.Equals, 20)
+}
+
+func (s *controllerSuite) TestConfigSetNotAllowed(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-port": 1234,
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv8(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	err = endpoint.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled": true,
+	}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
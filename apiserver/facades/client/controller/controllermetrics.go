@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ControllerMetrics returns the internal metrics sampled by the
+// controller machines since the given time, oldest first. Only
+// controller superusers may call it.
+func (s *ControllerAPI) ControllerMetrics(args params.ControllerMetricsArgs) (params.ControllerMetricsResult, error) {
+	if err := s.checkHasAdmin(); err != nil {
+		return params.ControllerMetricsResult{}, errors.Trace(err)
+	}
+	samples, err := s.state.ControllerMetrics(args.Since)
+	if err != nil {
+		return params.ControllerMetricsResult{}, errors.Trace(err)
+	}
+	result := params.ControllerMetricsResult{
+		Samples: make([]params.ControllerMetricsSample, len(samples)),
+	}
+	for i, sample := range samples {
+		result.Samples[i] = params.ControllerMetricsSample{
+			Machine: sample.Machine,
+			Time:    sample.Time,
+			Metrics: sample.Metrics,
+		}
+	}
+	return result, nil
+}
+
+// ControllerMetrics isn't on the V5 API.
+func (s *ControllerAPIv5) ControllerMetrics(_, _ struct{}) {}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// EngineReports returns the most recent dependency engine reports
+// sent by the given machine and unit agents. Only controller
+// superusers may call it.
+func (s *ControllerAPI) EngineReports(args params.EngineReportQueries) (params.EngineReportResults, error) {
+	results := params.EngineReportResults{
+		Results: make([]params.EngineReportResult, len(args.Queries)),
+	}
+	if err := s.checkHasAdmin(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, query := range args.Queries {
+		report, err := s.engineReport(query)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Report = report.Report
+		results.Results[i].Updated = report.Updated
+	}
+	return results, nil
+}
+
+func (s *ControllerAPI) engineReport(query params.EngineReportQuery) (state.EngineReport, error) {
+	modelTag, err := names.ParseModelTag(query.ModelTag)
+	if err != nil {
+		return state.EngineReport{}, errors.Trace(err)
+	}
+	tag, err := names.ParseTag(query.Tag)
+	if err != nil {
+		return state.EngineReport{}, errors.Trace(err)
+	}
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return state.EngineReport{}, errors.NotValidf("agent tag %q", query.Tag)
+	}
+	st, release, err := s.statePool.Get(modelTag.Id())
+	if err != nil {
+		return state.EngineReport{}, errors.Trace(err)
+	}
+	defer release()
+	report, err := st.EngineReport(tag)
+	return report, errors.Trace(err)
+}
+
+// EngineReports isn't on the V4 API.
+func (s *ControllerAPIv4) EngineReports(_, _ struct{}) {}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 )
 
@@ -17,3 +18,7 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func PatchInstanceTypesCache(p patcher, cache *instances.TypesCache) {
+	p.PatchValue(&instanceTypesCache, cache)
+}
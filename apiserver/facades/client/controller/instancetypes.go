@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/instances"
+)
+
+// instanceTypesCache is the cache emptied by RefreshInstanceTypes.
+var instanceTypesCache = instances.DefaultTypesCache
+
+// RefreshInstanceTypes discards the instance types that the providers
+// running in this controller have cached, so that they are listed from
+// the clouds when next used. This allows newly introduced instance
+// types to be used without waiting for the cache to expire. Only
+// controller superusers may call it.
+func (s *ControllerAPI) RefreshInstanceTypes() error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	n := instanceTypesCache.Refresh()
+	logger.Infof("discarded %d cached instance type lists", n)
+	return nil
+}
+
+// RefreshInstanceTypes isn't on the V6 API.
+func (s *ControllerAPIv6) RefreshInstanceTypes(_, _ struct{}) {}
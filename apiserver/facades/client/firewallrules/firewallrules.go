@@ -83,12 +83,17 @@ func (api *API) SetFirewallRules(args params.FirewallRuleArgs) (params.ErrorResu
 		return errResults, errors.Trace(err)
 	}
 
+	// Record who set the rules so they can be traced when auditing
+	// the resulting firewall configuration.
+	origin := api.authorizer.GetAuthTag().String()
 	results := make([]params.ErrorResult, len(args.Args))
 	for i, arg := range args.Args {
 		logger.Debugf("saving firewall rule %+v", arg)
 		err := api.backend.SaveFirewallRule(state.FirewallRule{
 			WellKnownService: state.WellKnownServiceType(arg.KnownService),
 			WhitelistCIDRs:   arg.WhitelistCIDRS,
+			Description:      arg.Description,
+			Origin:           origin,
 		})
 		results[i].Error = common.ServerError(err)
 	}
@@ -111,6 +116,8 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 		listResults.Rules[i] = params.FirewallRule{
 			KnownService:   params.KnownServiceValue(r.WellKnownService),
 			WhitelistCIDRS: r.WhitelistCIDRs,
+			Description:    r.Description,
+			Origin:         r.Origin,
 		}
 	}
 	return listResults, nil
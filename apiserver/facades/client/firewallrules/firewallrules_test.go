@@ -70,6 +70,8 @@ func (s *FirewallRulesSuite) TestSetFirewallRules(c *gc.C) {
 		Args: []params.FirewallRule{{
 			KnownService:   "juju-controller",
 			WhitelistCIDRS: []string{"1.2.3.4/8"},
+			Description:    "office network",
+			Origin:         "user-mallory",
 		}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -77,6 +79,8 @@ func (s *FirewallRulesSuite) TestSetFirewallRules(c *gc.C) {
 	c.Assert(s.backend.rules["juju-controller"], jc.DeepEquals, state.FirewallRule{
 		WellKnownService: state.JujuControllerRule,
 		WhitelistCIDRs:   []string{"1.2.3.4/8"},
+		Description:      "office network",
+		Origin:           "user-admin",
 	})
 }
 
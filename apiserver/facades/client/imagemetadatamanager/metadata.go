@@ -5,15 +5,19 @@ package imagemetadatamanager
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/imagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -97,15 +101,82 @@ func (api *API) List(filter params.ImageMetadataFilter) (params.ListCloudImageMe
 }
 
 // Save stores given cloud image metadata.
-// It supports bulk calls.
+// It supports bulk calls. Metadata with an unknown series or
+// architecture, or which refers to images that the model's
+// cloud region does not have, is rejected.
 func (api *API) Save(metadata params.MetadataSaveParams) (params.ErrorResults, error) {
-	all, err := imagecommon.Save(api.metadata, metadata)
+	if len(metadata.Metadata) == 0 {
+		return params.ErrorResults{}, nil
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return params.ErrorResults{}, errors.Annotate(err, "getting environ")
+	}
+	all := make([]params.ErrorResult, len(metadata.Metadata))
+	var valid params.MetadataSaveParams
+	var validIndices []int
+	for i, list := range metadata.Metadata {
+		if err := validateMetadata(env, list.Metadata); err != nil {
+			all[i].Error = common.ServerError(err)
+			continue
+		}
+		valid.Metadata = append(valid.Metadata, list)
+		validIndices = append(validIndices, i)
+	}
+	saved, err := imagecommon.Save(api.metadata, valid)
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	for i, result := range saved {
+		all[validIndices[i]] = result
+	}
 	return params.ErrorResults{Results: all}, nil
 }
 
+// validateMetadata returns an error if any of the given metadata has
+// no image ID, or an unknown series or architecture. If the environ
+// can check for images, an error is also returned if any image in the
+// model's region does not exist.
+func validateMetadata(env environs.Environ, metadata []params.CloudImageMetadata) error {
+	region := ""
+	if hasRegion, ok := env.(simplestreams.HasRegion); ok {
+		spec, err := hasRegion.Region()
+		if err != nil {
+			return errors.Annotate(err, "getting cloud region")
+		}
+		region = spec.Region
+	}
+	var imageIds []string
+	for _, m := range metadata {
+		if m.ImageId == "" {
+			return errors.NotValidf("image metadata without image id")
+		}
+		if m.Series != "" {
+			if _, err := series.SeriesVersion(m.Series); err != nil {
+				return errors.NotValidf("image %q series %q", m.ImageId, m.Series)
+			}
+		}
+		if m.Arch != "" && !arch.IsSupportedArch(m.Arch) {
+			return errors.NotValidf("image %q architecture %q", m.ImageId, m.Arch)
+		}
+		if m.Region == "" || m.Region == region {
+			imageIds = append(imageIds, m.ImageId)
+		}
+	}
+	checker, ok := env.(environs.ImageChecker)
+	if !ok || len(imageIds) == 0 {
+		return nil
+	}
+	missing, err := checker.MissingImages(imageIds)
+	if err != nil {
+		return errors.Annotate(err, "checking images exist")
+	}
+	if len(missing) > 0 {
+		return errors.NotFoundf("images %s in region %q", strings.Join(missing, ", "), region)
+	}
+	return nil
+}
+
 // Delete deletes cloud image metadata for given image ids.
 // It supports bulk calls.
 func (api *API) Delete(images params.MetadataImageIds) (params.ErrorResults, error) {
@@ -8,7 +8,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
@@ -104,7 +106,8 @@ func (s *metadataSuite) TestSaveEmpty(c *gc.C) {
 
 func (s *metadataSuite) TestSave(c *gc.C) {
 	m := params.CloudImageMetadata{
-		Source: "custom",
+		ImageId: "image-id",
+		Source:  "custom",
 	}
 	msg := "save error"
 
@@ -138,6 +141,60 @@ func (s *metadataSuite) TestSave(c *gc.C) {
 	s.assertCalls(c, controllerTag, modelConfig, saveMetadata, saveMetadata)
 }
 
+func (s *metadataSuite) TestSaveInvalid(c *gc.C) {
+	valid := params.CloudImageMetadata{
+		ImageId: "image-id",
+		Series:  "xenial",
+		Arch:    "amd64",
+		Source:  "custom",
+	}
+	noImageId := valid
+	noImageId.ImageId = ""
+	badSeries := valid
+	badSeries.Series = "nosuchseries"
+	badArch := valid
+	badArch.Arch = "z80"
+
+	errs, err := s.api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{
+			{Metadata: []params.CloudImageMetadata{valid, noImageId}},
+			{Metadata: []params.CloudImageMetadata{badSeries}},
+			{Metadata: []params.CloudImageMetadata{badArch}},
+			{Metadata: []params.CloudImageMetadata{valid}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 4)
+	c.Check(errs.Results[0].Error, gc.ErrorMatches, "image metadata without image id not valid")
+	c.Check(errs.Results[1].Error, gc.ErrorMatches, `image "image-id" series "nosuchseries" not valid`)
+	c.Check(errs.Results[2].Error, gc.ErrorMatches, `image "image-id" architecture "z80" not valid`)
+	c.Check(errs.Results[3].Error, gc.IsNil)
+	s.assertCalls(c, controllerTag, modelConfig, saveMetadata)
+}
+
+func (s *metadataSuite) TestSaveMissingImages(c *gc.C) {
+	env := &imageCheckingEnviron{missing: []string{"missing-image"}}
+	api, err := imagemetadatamanager.CreateAPI(s.state, func() (environs.Environ, error) {
+		return env, nil
+	}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := api.Save(params.MetadataSaveParams{
+		Metadata: []params.CloudImageMetadataList{{
+			Metadata: []params.CloudImageMetadata{
+				{ImageId: "image-id", Source: "custom"},
+				{ImageId: "missing-image", Region: "dummy_region", Source: "custom"},
+				{ImageId: "other-region-image", Region: "elsewhere", Source: "custom"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.ErrorMatches, `images missing-image in region "dummy_region" not found`)
+	c.Assert(env.checked, jc.DeepEquals, []string{"image-id", "missing-image"})
+	s.assertCalls(c, controllerTag, controllerTag)
+}
+
 func (s *metadataSuite) TestDeleteEmpty(c *gc.C) {
 	errs, err := s.api.Delete(params.MetadataImageIds{})
 	c.Assert(err, jc.ErrorIsNil)
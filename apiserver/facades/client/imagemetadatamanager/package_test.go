@@ -160,3 +160,17 @@ func mockConfig() coretesting.Attrs {
 		"type": "mock",
 	})
 }
+
+// imageCheckingEnviron is an environment that reports the
+// given images as missing.
+type imageCheckingEnviron struct {
+	mockEnviron
+	missing []string
+	checked []string
+}
+
+// MissingImages is specified in the environs.ImageChecker interface.
+func (e *imageCheckingEnviron) MissingImages(imageIds []string) ([]string, error) {
+	e.checked = append(e.checked, imageIds...)
+	return e.missing, nil
+}
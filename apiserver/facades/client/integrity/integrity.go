@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package integrity defines an API endpoint that lets controller
+// administrators find and repair dangling references in the database
+// of a damaged controller.
+package integrity
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the controller state used by the facade.
+type Backend interface {
+	AllModelUUIDs() ([]string, error)
+}
+
+// ModelChecker checks and repairs the integrity of a model. It is
+// satisfied by *state.State.
+type ModelChecker interface {
+	CheckIntegrity() ([]state.IntegrityProblem, error)
+	RepairIntegrity([]state.IntegrityProblem) (int, error)
+}
+
+// GetModelChecker returns the ModelChecker for the model with the given
+// UUID, and a function to release it.
+type GetModelChecker func(modelUUID string) (ModelChecker, func(), error)
+
+// API implements the Integrity facade.
+type API struct {
+	backend         Backend
+	getModelChecker GetModelChecker
+}
+
+// NewFacade returns a new Integrity facade. Only controller
+// superusers may use it.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	pool := ctx.StatePool()
+	return NewAPI(st, func(modelUUID string) (ModelChecker, func(), error) {
+		st, release, err := pool.Get(modelUUID)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return st, func() { release() }, nil
+	}, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new Integrity facade using the given backend.
+func NewAPI(
+	backend Backend,
+	getModelChecker GetModelChecker,
+	controllerTag names.ControllerTag,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:         backend,
+		getModelChecker: getModelChecker,
+	}, nil
+}
+
+// Check finds the dangling references in the given models, or in all
+// models if none are given, and repairs them if requested. The
+// problems found are returned whether or not they were repaired.
+func (api *API) Check(args params.IntegrityCheckArgs) (params.IntegrityCheckResults, error) {
+	var modelTags []string
+	if len(args.Models) == 0 {
+		uuids, err := api.backend.AllModelUUIDs()
+		if err != nil {
+			return params.IntegrityCheckResults{}, errors.Trace(err)
+		}
+		for _, uuid := range uuids {
+			modelTags = append(modelTags, names.NewModelTag(uuid).String())
+		}
+	} else {
+		for _, entity := range args.Models {
+			modelTags = append(modelTags, entity.Tag)
+		}
+	}
+
+	results := make([]params.IntegrityCheckResult, len(modelTags))
+	for i, modelTag := range modelTags {
+		results[i].ModelTag = modelTag
+		problems, repaired, err := api.checkModel(modelTag, args.Repair)
+		results[i].Repaired = repaired
+		for _, problem := range problems {
+			results[i].Problems = append(results[i].Problems, params.IntegrityProblem{
+				Kind:       string(problem.Kind),
+				Collection: problem.Collection,
+				Id:         problem.Id,
+				Message:    problem.Message,
+				Repairable: problem.Repairable(),
+			})
+		}
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.IntegrityCheckResults{Results: results}, nil
+}
+
+func (api *API) checkModel(modelTag string, repair bool) ([]state.IntegrityProblem, int, error) {
+	tag, err := names.ParseModelTag(modelTag)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	checker, release, err := api.getModelChecker(tag.Id())
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	defer release()
+	problems, err := checker.CheckIntegrity()
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	if !repair {
+		return problems, 0, nil
+	}
+	repaired, err := checker.RepairIntegrity(problems)
+	return problems, repaired, errors.Trace(err)
+}
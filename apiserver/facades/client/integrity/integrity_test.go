@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package integrity_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/integrity"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	modelUUID0 = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	modelUUID1 = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+)
+
+type integritySuite struct {
+	testing.IsolationSuite
+	stub       testing.Stub
+	authorizer apiservertesting.FakeAuthorizer
+	checkers   map[string]*mockChecker
+}
+
+var _ = gc.Suite(&integritySuite{})
+
+func (s *integritySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	problem := state.IntegrityProblem{
+		Kind:       state.UnitMissingMachine,
+		Collection: "units",
+		Id:         "mysql/0",
+		Message:    "unit is lost",
+	}
+	s.checkers = map[string]*mockChecker{
+		modelUUID0: {stub: &s.stub, problems: []state.IntegrityProblem{problem}},
+		modelUUID1: {stub: &s.stub},
+	}
+}
+
+func (s *integritySuite) newAPI(c *gc.C) *integrity.API {
+	api, err := integrity.NewAPI(
+		&mockBackend{stub: &s.stub, uuids: []string{modelUUID0, modelUUID1}},
+		func(modelUUID string) (integrity.ModelChecker, func(), error) {
+			s.stub.AddCall("GetModelChecker", modelUUID)
+			checker, ok := s.checkers[modelUUID]
+			if !ok {
+				return nil, nil, errors.NotFoundf("model %q", modelUUID)
+			}
+			return checker, func() { s.stub.AddCall("Release", modelUUID) }, nil
+		},
+		coretesting.ControllerTag,
+		s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *integritySuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.AdminTag = names.NewUserTag("other")
+	_, err := integrity.NewAPI(nil, nil, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *integritySuite) TestCheckAllModels(c *gc.C) {
+	results, err := s.newAPI(c).Check(params.IntegrityCheckArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.IntegrityCheckResults{
+		Results: []params.IntegrityCheckResult{{
+			ModelTag: names.NewModelTag(modelUUID0).String(),
+			Problems: []params.IntegrityProblem{{
+				Kind:       "unit-missing-machine",
+				Collection: "units",
+				Id:         "mysql/0",
+				Message:    "unit is lost",
+			}},
+		}, {
+			ModelTag: names.NewModelTag(modelUUID1).String(),
+		}},
+	})
+	s.stub.CheckCallNames(c,
+		"AllModelUUIDs",
+		"GetModelChecker", "CheckIntegrity", "Release",
+		"GetModelChecker", "CheckIntegrity", "Release",
+	)
+}
+
+func (s *integritySuite) TestCheckAndRepair(c *gc.C) {
+	s.checkers[modelUUID0].repaired = 1
+	results, err := s.newAPI(c).Check(params.IntegrityCheckArgs{
+		Models: []params.Entity{{Tag: names.NewModelTag(modelUUID0).String()}},
+		Repair: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Problems, gc.HasLen, 1)
+	c.Assert(results.Results[0].Repaired, gc.Equals, 1)
+	s.stub.CheckCallNames(c, "GetModelChecker", "CheckIntegrity", "RepairIntegrity", "Release")
+}
+
+func (s *integritySuite) TestCheckErrors(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	results, err := s.newAPI(c).Check(params.IntegrityCheckArgs{
+		Models: []params.Entity{
+			{Tag: names.NewModelTag(modelUUID0).String()},
+			{Tag: "machine-0"},
+			{Tag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f0ff").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `model "deadbeef-0bad-400d-8000-4b1d0d06f0ff" not found`)
+}
+
+type mockBackend struct {
+	stub  *testing.Stub
+	uuids []string
+}
+
+func (b *mockBackend) AllModelUUIDs() ([]string, error) {
+	b.stub.AddCall("AllModelUUIDs")
+	return b.uuids, b.stub.NextErr()
+}
+
+type mockChecker struct {
+	stub     *testing.Stub
+	problems []state.IntegrityProblem
+	repaired int
+}
+
+func (m *mockChecker) CheckIntegrity() ([]state.IntegrityProblem, error) {
+	m.stub.AddCall("CheckIntegrity")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.problems, nil
+}
+
+func (m *mockChecker) RepairIntegrity(problems []state.IntegrityProblem) (int, error) {
+	m.stub.AddCall("RepairIntegrity", problems)
+	return m.repaired, m.stub.NextErr()
+}
@@ -57,6 +57,21 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+// MachineManagerAPIV5 provides access to version 5 of the MachineManager
+// API facade, which adds RevokeAgentTokens.
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPI, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPI}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -169,6 +184,18 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		}
 	}
 
+	request := state.QuotaUsage{Machines: 1}
+	if p.ContainerType != "" && p.ParentId == "" {
+		// The container's new host machine counts too.
+		request.Machines++
+	}
+	for _, cons := range p.Disks {
+		request.StorageMiB += cons.Size * cons.Count
+	}
+	if err := mm.st.CheckModelQuotas(request); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	jobs, err := common.StateJobs(p.Jobs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -330,3 +357,36 @@ func (mm *MachineManagerAPIV4) updateOneMachineSeries(arg params.UpdateSeriesArg
 	}
 	return machine.UpdateMachineSeries(arg.Series, arg.Force)
 }
+
+// RevokeAgentTokens prevents the agents of the given machines, and of
+// the units they host, from authenticating with the controller. The machines must be removed
+// and recreated to restore their agents' access.
+func (mm *MachineManagerAPIV5) RevokeAgentTokens(args params.Entities) (params.ErrorResults, error) {
+	isAdmin, err := mm.authorizer.HasPermission(permission.AdminAccess, mm.st.ModelTag())
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !isAdmin {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machineTag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if _, err := mm.st.Machine(machineTag.Id()); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = mm.st.RevokeAgentTokens(machineTag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -97,6 +98,35 @@ func (s *MachineManagerSuite) TestAddMachines(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestAddMachinesChecksQuotas(c *gc.C) {
+	_, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+			Disks:  []storage.Constraints{{Size: 1024, Count: 2}},
+		}, {
+			Series:        "trusty",
+			ContainerType: instance.LXD,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.quotaRequests, jc.DeepEquals, []state.QuotaUsage{
+		{Machines: 1, StorageMiB: 2048},
+		{Machines: 2},
+	})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = errors.New("model quota exceeded")
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines[0].Error, gc.ErrorMatches, "model quota exceeded")
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	tag := names.NewUnitTag("mysql/0")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
@@ -313,10 +343,44 @@ func (s *MachineManagerSuite) TestUpdateMachineSeriesPermissionDenied(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestRevokeAgentTokens(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	results, err := apiV5.RevokeAgentTokens(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: "machine 1 not found"}},
+			{Error: &params.Error{Message: `"unit-foo-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.revoked, jc.DeepEquals, []names.Tag{names.NewMachineTag("0")})
+}
+
+func (s *MachineManagerSuite) TestRevokeAgentTokensPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV4{s.api}}
+	_, err := apiV5.RevokeAgentTokens(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.revoked, gc.HasLen, 0)
+}
+
 type mockState struct {
 	machinemanager.Backend
+	revoked          []names.Tag
 	calls            int
 	machineTemplates []state.MachineTemplate
+	quotaRequests    []state.QuotaUsage
+	quotaErr         error
 	machines         map[string]*mockMachine
 	err              error
 	blockMsg         string
@@ -330,6 +394,18 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error) {
+	st.calls++
+	st.machineTemplates = append(st.machineTemplates, template)
+	m := state.Machine{}
+	return &m, st.err
+}
+
+func (st *mockState) CheckModelQuotas(request state.QuotaUsage) error {
+	st.quotaRequests = append(st.quotaRequests, request)
+	return st.quotaErr
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if st.block == t {
 		return &mockBlock{t: t, m: st.blockMsg}, true, nil
@@ -338,6 +414,11 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	}
 }
 
+func (st *mockState) RevokeAgentTokens(tag names.Tag) error {
+	st.revoked = append(st.revoked, tag)
+	return nil
+}
+
 func (st *mockState) ModelTag() names.ModelTag {
 	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
 }
@@ -25,6 +25,8 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	CheckModelQuotas(state.QuotaUsage) error
+	RevokeAgentTokens(names.Tag) error
 }
 
 type Pool interface {
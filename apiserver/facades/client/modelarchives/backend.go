@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchives
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+)
+
+// stateBackend implements Backend using the controller's state.
+type stateBackend struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+// ArchiveModel is part of the Backend interface.
+func (b *stateBackend) ArchiveModel(modelUUID string, signingKey []byte) (state.ModelArchive, error) {
+	st, release, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return state.ModelArchive{}, errors.Trace(err)
+	}
+	defer release()
+	model, err := st.Model()
+	if err != nil {
+		return state.ModelArchive{}, errors.Trace(err)
+	}
+	config, err := migration.NewStateArchiveConfig(st, signingKey)
+	if err != nil {
+		return state.ModelArchive{}, errors.Annotatef(err, "cannot export model %q", model.Name())
+	}
+
+	// The archive's size must be known before it is stored, so it
+	// is written to a temporary file first.
+	f, err := ioutil.TempFile("", "model-archive")
+	if err != nil {
+		return state.ModelArchive{}, errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := migration.WriteArchive(f, config); err != nil {
+		return state.ModelArchive{}, errors.Annotatef(err, "cannot archive model %q", model.Name())
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return state.ModelArchive{}, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return state.ModelArchive{}, errors.Trace(err)
+	}
+	archive, err := b.st.AddModelArchive(modelUUID, model.Name(), f, size)
+	return archive, errors.Trace(err)
+}
+
+// ModelArchives is part of the Backend interface.
+func (b *stateBackend) ModelArchives(modelUUID string) ([]state.ModelArchive, error) {
+	return b.st.ModelArchives(modelUUID)
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelarchives defines an API endpoint that lets controller
+// administrators create and list signed model archives, which hold a
+// model and its binaries for cold backup. Archives are downloaded
+// over HTTP from /model-archives/:id.
+package modelarchives
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the controller state used by the facade.
+type Backend interface {
+	// ArchiveModel writes a signed archive of the model with the
+	// given UUID, and stores it in the controller.
+	ArchiveModel(modelUUID string, signingKey []byte) (state.ModelArchive, error)
+
+	// ModelArchives returns the archives of the model with the
+	// given UUID, or of all models if the UUID is empty.
+	ModelArchives(modelUUID string) ([]state.ModelArchive, error)
+}
+
+// API implements the ModelArchives facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade returns a new ModelArchives facade. Only controller
+// superusers may use it.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	backend := &stateBackend{st: st, pool: ctx.StatePool()}
+	return NewAPI(backend, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new ModelArchives facade using the given backend.
+func NewAPI(
+	backend Backend,
+	controllerTag names.ControllerTag,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Create archives the given models, signing each archive with the
+// key given for it, and stores the archives in the controller.
+func (api *API) Create(args params.CreateModelArchivesArgs) (params.ModelArchiveResults, error) {
+	results := params.ModelArchiveResults{
+		Results: make([]params.ModelArchiveResult, len(args.Archives)),
+	}
+	for i, arg := range args.Archives {
+		archive, err := api.create(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &archive
+	}
+	return results, nil
+}
+
+func (api *API) create(arg params.CreateModelArchiveArg) (params.ModelArchive, error) {
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return params.ModelArchive{}, errors.Trace(err)
+	}
+	if len(arg.SigningKey) == 0 {
+		return params.ModelArchive{}, errors.NotValidf("empty signing key")
+	}
+	archive, err := api.backend.ArchiveModel(modelTag.Id(), arg.SigningKey)
+	if err != nil {
+		return params.ModelArchive{}, errors.Trace(err)
+	}
+	return toParams(archive), nil
+}
+
+// List returns the archives held by the controller of the model
+// selected by the filter, or of all models, oldest first.
+func (api *API) List(filter params.ModelArchivesFilter) (params.ModelArchivesResult, error) {
+	var modelUUID string
+	if filter.ModelTag != "" {
+		modelTag, err := names.ParseModelTag(filter.ModelTag)
+		if err != nil {
+			return params.ModelArchivesResult{}, errors.Trace(err)
+		}
+		modelUUID = modelTag.Id()
+	}
+	archives, err := api.backend.ModelArchives(modelUUID)
+	if err != nil {
+		return params.ModelArchivesResult{}, errors.Trace(err)
+	}
+	result := params.ModelArchivesResult{
+		Archives: make([]params.ModelArchive, len(archives)),
+	}
+	for i, archive := range archives {
+		result.Archives[i] = toParams(archive)
+	}
+	return result, nil
+}
+
+func toParams(archive state.ModelArchive) params.ModelArchive {
+	return params.ModelArchive{
+		ID:        archive.ID,
+		ModelTag:  names.NewModelTag(archive.ModelUUID).String(),
+		ModelName: archive.ModelName,
+		Size:      archive.Size,
+		SHA256:    archive.SHA256,
+		Created:   archive.Created,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchives_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelarchives"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelArchivesSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&modelArchivesSuite{})
+
+var created = time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *modelArchivesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		archives: []state.ModelArchive{{
+			ID:        "archive-id",
+			ModelUUID: coretesting.ModelTag.Id(),
+			ModelName: "prod",
+			Size:      1234,
+			SHA256:    "abcd",
+			Created:   created,
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *modelArchivesSuite) newAPI(c *gc.C) *modelarchives.API {
+	api, err := modelarchives.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelArchivesSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.AdminTag = names.NewUserTag("other")
+	_, err := modelarchives.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *modelArchivesSuite) TestList(c *gc.C) {
+	result, err := s.newAPI(c).List(params.ModelArchivesFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelArchivesResult{
+		Archives: []params.ModelArchive{{
+			ID:        "archive-id",
+			ModelTag:  coretesting.ModelTag.String(),
+			ModelName: "prod",
+			Size:      1234,
+			SHA256:    "abcd",
+			Created:   created,
+		}},
+	})
+	s.backend.CheckCall(c, 0, "ModelArchives", "")
+}
+
+func (s *modelArchivesSuite) TestListModel(c *gc.C) {
+	_, err := s.newAPI(c).List(params.ModelArchivesFilter{
+		ModelTag: coretesting.ModelTag.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "ModelArchives", coretesting.ModelTag.Id())
+}
+
+func (s *modelArchivesSuite) TestListInvalidModelTag(c *gc.C) {
+	_, err := s.newAPI(c).List(params.ModelArchivesFilter{ModelTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelArchivesSuite) TestCreate(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf("model"))
+	otherTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	results, err := s.newAPI(c).Create(params.CreateModelArchivesArgs{
+		Archives: []params.CreateModelArchiveArg{{
+			ModelTag:   coretesting.ModelTag.String(),
+			SigningKey: []byte("key"),
+		}, {
+			ModelTag:   otherTag.String(),
+			SigningKey: []byte("key"),
+		}, {
+			ModelTag: coretesting.ModelTag.String(),
+		}, {
+			ModelTag:   "machine-0",
+			SigningKey: []byte("key"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], jc.DeepEquals, params.ModelArchiveResult{
+		Result: &params.ModelArchive{
+			ID:        "archive-id",
+			ModelTag:  coretesting.ModelTag.String(),
+			ModelName: "prod",
+			Size:      1234,
+			SHA256:    "abcd",
+			Created:   created,
+		},
+	})
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "empty signing key not valid")
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ArchiveModel", []interface{}{coretesting.ModelTag.Id(), []byte("key")}},
+		{"ArchiveModel", []interface{}{otherTag.Id(), []byte("key")}},
+	})
+}
+
+type mockBackend struct {
+	testing.Stub
+	archives []state.ModelArchive
+}
+
+func (b *mockBackend) ArchiveModel(modelUUID string, signingKey []byte) (state.ModelArchive, error) {
+	b.MethodCall(b, "ArchiveModel", modelUUID, signingKey)
+	return b.archives[0], b.NextErr()
+}
+
+func (b *mockBackend) ModelArchives(modelUUID string) ([]state.ModelArchive, error) {
+	b.MethodCall(b, "ModelArchives", modelUUID)
+	return b.archives, b.NextErr()
+}
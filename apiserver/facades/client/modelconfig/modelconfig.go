@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logsampling"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -136,7 +137,11 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		if !ok {
 			return nil
 		}
-		logCfg, err := loggo.ParseConfigString(spec.(string))
+		levels, _, err := logsampling.ParseLoggingConfig(spec.(string))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logCfg, err := loggo.ParseConfigString(levels)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -632,7 +632,7 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -811,8 +811,47 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"add-model\" permission does not permit creation of models for different owners: permission denied")
 }
 
+func (s *modelManagerSuite) TestCreateModelDatabase(c *gc.C) {
+	args := createArgs(names.NewUserTag("admin"))
+	args.Database = "juju-heavy"
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	c.Assert(newModelArgs.Database, gc.Equals, "juju-heavy")
+}
+
+func (s *modelManagerSuite) TestCreateModelDatabaseV4(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV4{s.api}
+	args := createArgs(names.NewUserTag("admin"))
+	args.Database = "juju-heavy"
+	_, err := api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	c.Assert(newModelArgs.Database, gc.Equals, "")
+}
+
+func (s *modelManagerSuite) TestAddModelCantSelectDatabase(c *gc.C) {
+	addModelUser := names.NewUserTag("add-model")
+	s.st.users = []permission.UserAccess{{
+		UserID:      addModelUser.Id(),
+		UserTag:     addModelUser,
+		Object:      s.st.ControllerTag(),
+		Access:      permission.AddModelAccess,
+		CreatedBy:   names.NewUserTag("admin"),
+		DisplayName: addModelUser.Name(),
+		UserName:    addModelUser.Name(),
+	}}
+	s.setAPIUser(c, addModelUser)
+	args := createArgs(addModelUser)
+	args.Database = "juju-heavy"
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, "only controller administrators may select a model database: permission denied")
+}
+
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -1584,7 +1623,7 @@ func (s *modelManagerStateSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 
 func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -1613,7 +1652,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelquotas defines an API endpoint through which
+// controller administrators limit the resources models may use.
+package modelquotas
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the quotas of the controller's models.
+type Backend interface {
+	ModelQuotas(modelUUID string) (state.ModelQuotas, state.QuotaUsage, error)
+	SetModelQuotas(modelUUID string, quotas state.ModelQuotas) error
+}
+
+// API implements the ModelQuotas facade.
+type API struct {
+	backend       Backend
+	authorizer    facade.Authorizer
+	controllerTag names.ControllerTag
+}
+
+// NewFacade returns a new ModelQuotas facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(poolBackend{ctx.StatePool()}, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new ModelQuotas facade using the given backend.
+func NewAPI(
+	backend Backend,
+	controllerTag names.ControllerTag,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:       backend,
+		authorizer:    authorizer,
+		controllerTag: controllerTag,
+	}, nil
+}
+
+func (api *API) isSuperuser() (bool, error) {
+	return api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+}
+
+// ModelQuotas returns the quotas and current usage of the given
+// models. Any user with read access to a model may see its quotas.
+func (api *API) ModelQuotas(args params.Entities) (params.ModelQuotasResults, error) {
+	isSuperuser, err := api.isSuperuser()
+	if err != nil {
+		return params.ModelQuotasResults{}, errors.Trace(err)
+	}
+	results := params.ModelQuotasResults{
+		Results: make([]params.ModelQuotasResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := api.modelQuotas(arg.Tag, isSuperuser)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) modelQuotas(tag string, isSuperuser bool) (params.ModelQuotasResult, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return params.ModelQuotasResult{}, errors.Trace(err)
+	}
+	if !isSuperuser {
+		canRead, err := api.authorizer.HasPermission(permission.ReadAccess, modelTag)
+		if err != nil {
+			return params.ModelQuotasResult{}, errors.Trace(err)
+		}
+		if !canRead {
+			return params.ModelQuotasResult{}, common.ErrPerm
+		}
+	}
+	quotas, usage, err := api.backend.ModelQuotas(modelTag.Id())
+	if err != nil {
+		return params.ModelQuotasResult{}, errors.Trace(err)
+	}
+	return params.ModelQuotasResult{
+		Quotas: params.ModelQuotas{
+			MaxMachines:  quotas.MaxMachines,
+			MaxUnits:     quotas.MaxUnits,
+			MaxStorageGB: quotas.MaxStorageGB,
+		},
+		Usage: params.ModelQuotaUsage{
+			Machines:   usage.Machines,
+			Units:      usage.Units,
+			StorageMiB: usage.StorageMiB,
+		},
+	}, nil
+}
+
+// SetModelQuotas replaces the quotas of the given models. Only
+// controller superusers may set quotas.
+func (api *API) SetModelQuotas(args params.SetModelQuotasArgs) (params.ErrorResults, error) {
+	isSuperuser, err := api.isSuperuser()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !isSuperuser {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setModelQuotas(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setModelQuotas(arg params.SetModelQuotasArg) error {
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.backend.SetModelQuotas(modelTag.Id(), state.ModelQuotas{
+		MaxMachines:  arg.Quotas.MaxMachines,
+		MaxUnits:     arg.Quotas.MaxUnits,
+		MaxStorageGB: arg.Quotas.MaxStorageGB,
+	}))
+}
+
+// poolBackend implements Backend using a state pool.
+type poolBackend struct {
+	pool *state.StatePool
+}
+
+// ModelQuotas is part of the Backend interface.
+func (b poolBackend) ModelQuotas(modelUUID string) (state.ModelQuotas, state.QuotaUsage, error) {
+	st, release, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return state.ModelQuotas{}, state.QuotaUsage{}, errors.Trace(err)
+	}
+	defer release()
+	model, err := st.Model()
+	if err != nil {
+		return state.ModelQuotas{}, state.QuotaUsage{}, errors.Trace(err)
+	}
+	usage, err := st.QuotaUsage()
+	if err != nil {
+		return state.ModelQuotas{}, state.QuotaUsage{}, errors.Trace(err)
+	}
+	return model.Quotas(), usage, nil
+}
+
+// SetModelQuotas is part of the Backend interface.
+func (b poolBackend) SetModelQuotas(modelUUID string, quotas state.ModelQuotas) error {
+	st, release, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetQuotas(quotas))
+}
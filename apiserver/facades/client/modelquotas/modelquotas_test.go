@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelquotas_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelquotas"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelQuotasSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&modelQuotasSuite{})
+
+func (s *modelQuotasSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		quotas: state.ModelQuotas{MaxMachines: 10, MaxStorageGB: 100},
+		usage:  state.QuotaUsage{Machines: 3, Units: 5, StorageMiB: 4096},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *modelQuotasSuite) newAPI(c *gc.C) *modelquotas.API {
+	api, err := modelquotas.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelQuotasSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelquotas.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *modelQuotasSuite) TestModelQuotas(c *gc.C) {
+	results, err := s.newAPI(c).ModelQuotas(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}, {Tag: "invalid"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelQuotasResults{
+		Results: []params.ModelQuotasResult{{
+			Quotas: params.ModelQuotas{MaxMachines: 10, MaxStorageGB: 100},
+			Usage:  params.ModelQuotaUsage{Machines: 3, Units: 5, StorageMiB: 4096},
+		}, {
+			Error: &params.Error{Message: `"invalid" is not a valid tag`},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelQuotas", []interface{}{coretesting.ModelTag.Id()}},
+	})
+}
+
+func (s *modelQuotasSuite) TestModelQuotasModelReader(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.HasWriteTag = names.NewUserTag("bob")
+	results, err := s.newAPI(c).ModelQuotas(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *modelQuotasSuite) TestModelQuotasNoAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	results, err := s.newAPI(c).ModelQuotas(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelQuotasSuite) TestSetModelQuotas(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf("negative max-units -1"))
+	results, err := s.newAPI(c).SetModelQuotas(params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotasArg{{
+			ModelTag: coretesting.ModelTag.String(),
+			Quotas:   params.ModelQuotas{MaxMachines: 5, MaxUnits: 20, MaxStorageGB: 50},
+		}, {
+			ModelTag: coretesting.ModelTag.String(),
+			Quotas:   params.ModelQuotas{MaxUnits: -1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "negative max-units -1 not valid")
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetModelQuotas", []interface{}{
+			coretesting.ModelTag.Id(),
+			state.ModelQuotas{MaxMachines: 5, MaxUnits: 20, MaxStorageGB: 50},
+		}},
+		{"SetModelQuotas", []interface{}{
+			coretesting.ModelTag.Id(),
+			state.ModelQuotas{MaxUnits: -1},
+		}},
+	})
+}
+
+func (s *modelQuotasSuite) TestSetModelQuotasRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.HasWriteTag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).SetModelQuotas(params.SetModelQuotasArgs{
+		Args: []params.SetModelQuotasArg{{
+			ModelTag: coretesting.ModelTag.String(),
+			Quotas:   params.ModelQuotas{MaxMachines: 100},
+		}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	quotas state.ModelQuotas
+	usage  state.QuotaUsage
+}
+
+func (b *mockBackend) ModelQuotas(modelUUID string) (state.ModelQuotas, state.QuotaUsage, error) {
+	b.MethodCall(b, "ModelQuotas", modelUUID)
+	return b.quotas, b.usage, b.NextErr()
+}
+
+func (b *mockBackend) SetModelQuotas(modelUUID string, quotas state.ModelQuotas) error {
+	b.MethodCall(b, "SetModelQuotas", modelUUID, quotas)
+	return b.NextErr()
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package providercapabilities defines an API endpoint that reports
+// the optional features supported by a model's cloud provider.
+package providercapabilities
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// API implements the ProviderCapabilities facade.
+type API struct {
+	modelTag   names.ModelTag
+	authorizer facade.Authorizer
+	newEnviron func() (environs.Environ, error)
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	configGetter := stateenvirons.EnvironConfigGetter{st, model}
+	newEnviron := func() (environs.Environ, error) {
+		return environs.GetEnviron(configGetter, environs.New)
+	}
+	return NewAPI(model.ModelTag(), ctx.Auth(), newEnviron)
+}
+
+// NewAPI returns a new ProviderCapabilities facade for the model
+// with the given tag, whose environ is returned by newEnviron.
+func NewAPI(
+	modelTag names.ModelTag,
+	authorizer facade.Authorizer,
+	newEnviron func() (environs.Environ, error),
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		modelTag:   modelTag,
+		authorizer: authorizer,
+		newEnviron: newEnviron,
+	}, nil
+}
+
+// ProviderCapabilities returns the provider type of the model, and
+// the optional features that the provider supports.
+func (api *API) ProviderCapabilities() (params.ProviderCapabilitiesResult, error) {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return params.ProviderCapabilitiesResult{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.ProviderCapabilitiesResult{}, common.ErrPerm
+	}
+	env, err := api.newEnviron()
+	if err != nil {
+		return params.ProviderCapabilitiesResult{}, errors.Annotate(err, "getting environ")
+	}
+	capabilities, err := environs.Capabilities(env)
+	if err != nil {
+		return params.ProviderCapabilitiesResult{}, errors.Trace(err)
+	}
+	result := params.ProviderCapabilitiesResult{
+		ProviderType: env.Config().Type(),
+		Capabilities: make([]string, len(capabilities)),
+	}
+	for i, capability := range capabilities {
+		result.Capabilities[i] = string(capability)
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package providercapabilities_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/providercapabilities"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerCapabilitiesSuite struct {
+	testing.IsolationSuite
+	modelTag names.ModelTag
+	env      *mockEnviron
+}
+
+var _ = gc.Suite(&providerCapabilitiesSuite{})
+
+func (s *providerCapabilitiesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.modelTag = coretesting.ModelTag
+	s.env = &mockEnviron{
+		config:   coretesting.ModelConfig(c),
+		reported: []environs.Capability{environs.CapabilityInstanceFirewall},
+	}
+}
+
+func (s *providerCapabilitiesSuite) newAPI(c *gc.C, user string) (*providercapabilities.API, error) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag(user)}
+	return providercapabilities.NewAPI(s.modelTag, authorizer, func() (environs.Environ, error) {
+		return s.env, nil
+	})
+}
+
+func (s *providerCapabilitiesSuite) TestProviderCapabilities(c *gc.C) {
+	api, err := s.newAPI(c, "read")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProviderCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProviderCapabilitiesResult{
+		ProviderType: s.env.config.Type(),
+		Capabilities: []string{"instance-firewall", "spot-instances"},
+	})
+}
+
+func (s *providerCapabilitiesSuite) TestProviderCapabilitiesNone(c *gc.C) {
+	s.env.reported = nil
+	s.env.unsupported = []string{constraints.Spot}
+	api, err := s.newAPI(c, "read")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ProviderCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Capabilities, jc.DeepEquals, []string{})
+}
+
+func (s *providerCapabilitiesSuite) TestProviderCapabilitiesError(c *gc.C) {
+	s.env.err = errors.New("boom")
+	api, err := s.newAPI(c, "read")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ProviderCapabilities()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *providerCapabilitiesSuite) TestProviderCapabilitiesNoReadAccess(c *gc.C) {
+	api, err := s.newAPI(c, "nobody")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ProviderCapabilities()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *providerCapabilitiesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := providercapabilities.NewAPI(s.modelTag, authorizer, nil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+type mockEnviron struct {
+	environs.Environ
+	config      *config.Config
+	unsupported []string
+	reported    []environs.Capability
+	err         error
+}
+
+func (e *mockEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *mockEnviron) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(e.unsupported)
+	return validator, nil
+}
+
+func (e *mockEnviron) Capabilities() ([]environs.Capability, error) {
+	return e.reported, e.err
+}
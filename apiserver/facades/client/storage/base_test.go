@@ -25,7 +25,7 @@ type baseStorageSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 
-	api   *storage.APIv4
+	api   *storage.APIv5
 	apiv3 *storage.APIv3
 	state *mockState
 
@@ -61,7 +61,7 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.poolManager = s.constructPoolManager()
 
 	var err error
-	s.api, err = storage.NewAPIv4(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	s.api, err = storage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
@@ -96,6 +96,8 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	addExistingVolumeCall                   = "addExistingVolume"
+	setVolumeInfoCall                       = "setVolumeInfo"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -280,6 +282,14 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		addExistingVolume: func(v state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.stub.AddCall(addExistingVolumeCall, v, storageName)
+			return s.storageTag, s.stub.NextErr()
+		},
+		setVolumeInfo: func(tag names.VolumeTag, info state.VolumeInfo) error {
+			s.stub.AddCall(setVolumeInfoCall, tag, info)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -324,6 +334,18 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			delete(s.pools, name)
 			return nil
 		},
+		replacePool: func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error) {
+			existing, ok := s.pools[name]
+			if !ok {
+				return nil, errors.NotFoundf("mock pool manager: replace pool %v", name)
+			}
+			if providerType == "" {
+				providerType = existing.Provider()
+			}
+			pool, err := jujustorage.NewConfig(name, providerType, attrs)
+			s.pools[name] = pool
+			return pool, err
+		},
 		listPools: func() ([]*jujustorage.Config, error) {
 			result := make([]*jujustorage.Config, len(s.pools))
 			i := 0
@@ -15,10 +15,11 @@ import (
 )
 
 type mockPoolManager struct {
-	getPool    func(name string) (*jujustorage.Config, error)
-	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
-	deletePool func(name string) error
-	listPools  func() ([]*jujustorage.Config, error)
+	getPool     func(name string) (*jujustorage.Config, error)
+	createPool  func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
+	deletePool  func(name string) error
+	listPools   func() ([]*jujustorage.Config, error)
+	replacePool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
 }
 
 func (m *mockPoolManager) Get(name string) (*jujustorage.Config, error) {
@@ -37,6 +38,10 @@ func (m *mockPoolManager) List() ([]*jujustorage.Config, error) {
 	return m.listPools()
 }
 
+func (m *mockPoolManager) Replace(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error) {
+	return m.replacePool(name, providerType, attrs)
+}
+
 type mockState struct {
 	storageInstance                     func(names.StorageTag) (state.StorageInstance, error)
 	allStorageInstances                 func() ([]state.StorageInstance, error)
@@ -61,6 +66,7 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error)
+	checkModelQuotas                    func(state.QuotaUsage) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool) error
@@ -68,6 +74,8 @@ type mockState struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	addExistingVolume                   func(state.VolumeInfo, string) (names.StorageTag, error)
+	setVolumeInfo                       func(names.VolumeTag, state.VolumeInfo) error
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -166,6 +174,13 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) CheckModelQuotas(request state.QuotaUsage) error {
+	if st.checkModelQuotas == nil {
+		return nil
+	}
+	return st.checkModelQuotas(request)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -201,6 +216,14 @@ func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.Volu
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockState) AddExistingVolume(v state.VolumeInfo, s string) (names.StorageTag, error) {
+	return st.addExistingVolume(v, s)
+}
+
+func (st *mockState) SetVolumeInfo(tag names.VolumeTag, info state.VolumeInfo) error {
+	return st.setVolumeInfo(tag, info)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -317,12 +340,17 @@ type mockStorageInstance struct {
 	owner      names.Tag
 	storageTag names.Tag
 	life       state.Life
+	pool       string
 }
 
 func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
+func (m *mockStorageInstance) Pool() string {
+	return m.pool
+}
+
 func (m *mockStorageInstance) Owner() (names.Tag, bool) {
 	return m.owner, m.owner != nil
 }
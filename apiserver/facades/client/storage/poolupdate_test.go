@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	pool, err := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["pname"] = pool
+}
+
+func (s *poolUpdateSuite) TestUpdatePool(c *gc.C) {
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{[]params.StoragePool{{
+		Name:  "pname",
+		Attrs: map[string]interface{}{"baz": "qux"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+
+	expected, err := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pools["pname"], jc.DeepEquals, expected)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolNotFound(c *gc.C) {
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{[]params.StoragePool{{
+		Name: "missing",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolError(c *gc.C) {
+	s.poolManager.replacePool = func(string, jujustorage.ProviderType, map[string]interface{}) (*jujustorage.Config, error) {
+		return nil, errors.New("as expected")
+	}
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{[]params.StoragePool{{
+		Name: "pname",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: "as expected"}},
+	})
+}
+
+func (s *poolUpdateSuite) TestRemovePool(c *gc.C) {
+	results, err := s.api.RemovePool(params.StoragePoolDeleteArgs{[]params.StoragePoolDeleteArg{{
+		Name: "pname",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	c.Assert(s.pools, gc.HasLen, 0)
+}
+
+func (s *poolUpdateSuite) TestRemovePoolNotFound(c *gc.C) {
+	results, err := s.api.RemovePool(params.StoragePoolDeleteArgs{[]params.StoragePoolDeleteArg{{
+		Name: "missing",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *poolUpdateSuite) TestRemovePoolInUse(c *gc.C) {
+	s.storageInstance.pool = "pname"
+	results, err := s.api.RemovePool(params.StoragePoolDeleteArgs{[]params.StoragePoolDeleteArg{{
+		Name: "pname",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: `storage pool "pname" in use`}},
+	})
+	c.Assert(s.pools, gc.HasLen, 1)
+}
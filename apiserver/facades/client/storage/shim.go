@@ -19,6 +19,19 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -29,7 +42,11 @@ func NewFacadeV4(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting environ")
 	}
-	registry := stateenvirons.NewStorageProviderRegistry(env)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env, controllerConfig)
 	pm := poolmanager.New(state.NewStateSettings(st), registry)
 
 	backend, err := getState(st)
@@ -49,7 +66,11 @@ func NewFacadeV3(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting environ")
 	}
-	registry := stateenvirons.NewStorageProviderRegistry(env)
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env, controllerConfig)
 	pm := poolmanager.New(state.NewStateSettings(st), registry)
 
 	backend, err := getState(st)
@@ -135,6 +156,9 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error)
 
+	// CheckModelQuotas is required to enforce model storage quotas.
+	CheckModelQuotas(state.QuotaUsage) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 
@@ -158,6 +182,12 @@ type storageAccess interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// AddExistingVolume imports an existing volume into the model.
+	AddExistingVolume(v state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// SetVolumeInfo sets the provisioned information for a volume.
+	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 }
 
 var getState = func(st *state.State) (storageAccess, error) {
@@ -35,6 +35,26 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -391,6 +411,52 @@ func (a *APIv3) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// UpdatePool replaces the configuration of existing pools with the
+// specified parameters. If a pool's provider is not specified, its
+// existing provider is kept.
+func (a *APIv5) UpdatePool(args params.StoragePoolArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Pools))
+	for i, p := range args.Pools {
+		_, err := a.poolManager.Replace(p.Name, storage.ProviderType(p.Provider), p.Attrs)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RemovePool removes the named pools. A pool cannot be removed
+// while there is storage in the model that was created from it.
+func (a *APIv5) RemovePool(args params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	storageInstances, err := a.storage.AllStorageInstances()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	inUse := set.NewStrings()
+	for _, storageInstance := range storageInstances {
+		inUse.Add(storageInstance.Pool())
+	}
+	results := make([]params.ErrorResult, len(args.Pools))
+	for i, p := range args.Pools {
+		results[i].Error = common.ServerError(a.removePool(p.Name, inUse))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *APIv5) removePool(name string, inUse set.Strings) error {
+	if _, err := a.poolManager.Get(name); err != nil {
+		return errors.Trace(err)
+	}
+	if inUse.Contains(name) {
+		return errors.Errorf("storage pool %q in use", name)
+	}
+	return errors.Trace(a.poolManager.Delete(name))
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -698,6 +764,21 @@ func createFilesystemDetails(
 	return details, nil
 }
 
+// addStorageQuotaRequest returns the storage, subject to model quotas,
+// requested by adding storage with the given constraints to a unit.
+// Unspecified sizes and counts are taken to be the defaults applied by
+// state when the unit's own constraints don't specify them.
+func addStorageQuotaRequest(cons state.StorageConstraints) state.QuotaUsage {
+	size, count := cons.Size, cons.Count
+	if size == 0 {
+		size = 1024
+	}
+	if count == 0 {
+		count = 1
+	}
+	return state.QuotaUsage{StorageMiB: size * count}
+}
+
 // AddToUnit validates and creates additional storage instances for units.
 // A "CHANGE" block can block this operation.
 func (a *APIv3) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, error) {
@@ -748,9 +829,13 @@ func (a *APIv3) addToUnit(args params.StoragesAddParams) (params.AddStorageResul
 			continue
 		}
 
-		tags, err := a.storage.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		cons := paramsToState(one.Constraints)
+		if err := a.storage.CheckModelQuotas(addStorageQuotaRequest(cons)); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+
+		tags, err := a.storage.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i].Error = common.ServerError(err)
 		}
@@ -946,32 +1031,110 @@ func (a *APIv4) Import(args params.BulkImportStorageParams) (params.ImportStorag
 }
 
 func (a *APIv4) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
-	if arg.Kind != params.StorageKindFilesystem {
-		// TODO(axw) implement support for volumes.
+	if arg.Kind != params.StorageKindFilesystem && arg.Kind != params.StorageKindBlock {
 		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
 	}
 	if !storage.IsValidPoolName(arg.Pool) {
 		return nil, errors.NotValidf("pool name %q", arg.Pool)
 	}
+	provider, cfg, err := a.poolStorageProvider(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if arg.Kind == params.StorageKindBlock {
+		return a.importVolume(arg, provider, cfg)
+	}
+	return a.importFilesystem(arg, provider, cfg)
+}
 
-	cfg, err := a.poolManager.Get(arg.Pool)
+// poolStorageProvider returns the storage provider and configuration
+// for the named pool. If there is no pool with the given name, the
+// name is taken to be a storage provider type.
+func (a *APIv3) poolStorageProvider(pool string) (storage.Provider, *storage.Config, error) {
+	cfg, err := a.poolManager.Get(pool)
 	if errors.IsNotFound(err) {
 		cfg, err = storage.NewConfig(
-			arg.Pool,
-			storage.ProviderType(arg.Pool),
+			pool,
+			storage.ProviderType(pool),
 			map[string]interface{}{},
 		)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return provider, cfg, nil
+}
+
+// resourceTags returns the tags to apply to imported storage.
+func (a *APIv4) resourceTags() map[string]string {
+	return map[string]string{
+		tags.JujuModel:      a.storage.ModelTag().Id(),
+		tags.JujuController: a.storage.ControllerTag().Id(),
+	}
+}
+
+func (a *APIv4) importVolume(
+	arg params.ImportStorageParams,
+	provider storage.Provider,
+	cfg *storage.Config,
+) (*params.ImportStorageDetails, error) {
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	volumeInfo, err := importVolumeInfo(arg, provider, cfg, a.resourceTags())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return a.importFilesystem(arg, provider, cfg)
+	storageTag, err := a.storage.AddExistingVolume(*volumeInfo, arg.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// importVolumeInfo imports the volume with the given provider ID
+// using the provider's volume source, and returns the state
+// representation of the volume.
+func importVolumeInfo(
+	arg params.ImportStorageParams,
+	provider storage.Provider,
+	cfg *storage.Config,
+	resourceTags map[string]string,
+) (*state.VolumeInfo, error) {
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeImporter, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	info, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "importing volume")
+	}
+	return &state.VolumeInfo{
+		HardwareId: info.HardwareId,
+		WWN:        info.WWN,
+		Size:       info.Size,
+		Pool:       arg.Pool,
+		VolumeId:   info.VolumeId,
+		Persistent: info.Persistent,
+	}, nil
 }
 
 func (a *APIv4) importFilesystem(
@@ -979,10 +1142,7 @@ func (a *APIv4) importFilesystem(
 	provider storage.Provider,
 	cfg *storage.Config,
 ) (*params.ImportStorageDetails, error) {
-	resourceTags := map[string]string{
-		tags.JujuModel:      a.storage.ModelTag().Id(),
-		tags.JujuController: a.storage.ControllerTag().Id(),
-	}
+	resourceTags := a.resourceTags()
 	var volumeInfo *state.VolumeInfo
 	filesystemInfo := state.FilesystemInfo{Pool: arg.Pool}
 
@@ -1007,29 +1167,11 @@ func (a *APIv4) importFilesystem(
 		filesystemInfo.FilesystemId = arg.ProviderId
 		filesystemInfo.Size = info.Size
 	} else {
-		volumeSource, err := provider.VolumeSource(cfg)
+		info, err := importVolumeInfo(arg, provider, cfg, resourceTags)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		volumeImporter, ok := volumeSource.(storage.VolumeImporter)
-		if !ok {
-			return nil, errors.NotSupportedf(
-				"importing volume with storage provider %q",
-				cfg.Provider(),
-			)
-		}
-		info, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags)
-		if err != nil {
-			return nil, errors.Annotate(err, "importing volume")
-		}
-		volumeInfo = &state.VolumeInfo{
-			HardwareId: info.HardwareId,
-			WWN:        info.WWN,
-			Size:       info.Size,
-			Pool:       arg.Pool,
-			VolumeId:   info.VolumeId,
-			Persistent: info.Persistent,
-		}
+		volumeInfo = info
 		filesystemInfo.Size = info.Size
 	}
 
@@ -1042,6 +1184,110 @@ func (a *APIv4) importFilesystem(
 	}, nil
 }
 
+// Resize grows storage instances to the specified sizes.
+// A "CHANGE" block can block this operation.
+func (a *APIv5) Resize(args params.BulkResizeStorageParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := a.resizeStorage(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *APIv5) resizeStorage(arg params.ResizeStorageParams) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storageInstanceBackingVolume(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if volume.Life() != state.Alive {
+		return errors.Errorf("%s is not alive", names.ReadableString(volume.VolumeTag()))
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Size <= info.Size {
+		return errors.Errorf(
+			"new size %dMiB must be larger than current size %dMiB",
+			arg.Size, info.Size,
+		)
+	}
+
+	request := state.QuotaUsage{StorageMiB: arg.Size - info.Size}
+	if err := a.storage.CheckModelQuotas(request); err != nil {
+		return errors.Trace(err)
+	}
+
+	provider, cfg, err := a.poolStorageProvider(info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		// Machine-scoped volumes can only be managed from
+		// within the machine.
+		return errors.NotSupportedf("resizing machine-scoped volume")
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeResizer, ok := volumeSource.(storage.VolumeResizer)
+	if !ok {
+		return errors.NotSupportedf(
+			"resizing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	newInfo, err := volumeResizer.ResizeVolume(info.VolumeId, arg.Size)
+	if err != nil {
+		return errors.Annotate(err, "resizing volume")
+	}
+	info.Size = newInfo.Size
+	return errors.Trace(a.storage.SetVolumeInfo(volume.VolumeTag(), info))
+}
+
+// storageInstanceBackingVolume returns the volume that backs the
+// storage instance with the specified tag. For filesystem storage,
+// this is the volume backing the filesystem.
+func (a *APIv5) storageInstanceBackingVolume(tag names.StorageTag) (state.Volume, error) {
+	storageInstance, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if storageInstance.Kind() == state.StorageKindBlock {
+		return a.storage.StorageInstanceVolume(tag)
+	}
+	filesystem, err := a.storage.StorageInstanceFilesystem(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := filesystem.Volume()
+	if errors.Cause(err) == state.ErrNoBackingVolume {
+		return nil, errors.NotSupportedf(
+			"resizing %s, which has no backing volume",
+			names.ReadableString(filesystem.FilesystemTag()),
+		)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.storage.Volume(volumeTag)
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
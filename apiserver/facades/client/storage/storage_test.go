@@ -690,12 +690,121 @@ func (s *storageSuite) TestImportFilesystemVolumeBackedNotSupported(c *gc.C) {
 	s.stub.CheckCallNames(c, getBlockForTypeCall)
 }
 
-func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
+func (s *storageSuite) TestImportVolume(c *gc.C) {
+	s.state.modelTag = coretesting.ModelTag
+	volumeSource := volumeImporter{&dummy.VolumeSource{}}
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{
+			StorageTag: "storage-data-0",
+		},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ImportVolume", []interface{}{
+			"foo", map[string]string{
+				"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+			},
+		}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{addExistingVolumeCall, []interface{}{
+			state.VolumeInfo{
+				VolumeId:   "foo",
+				Pool:       "radiance",
+				Size:       123,
+				HardwareId: "hw",
+			},
+			"pgdata",
+		}},
+	})
+}
+
+func (s *storageSuite) TestImportVolumeError(c *gc.C) {
+	volumeSource := volumeImporter{&dummy.VolumeSource{}}
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
+	volumeSource.SetErrors(errors.New("nope"))
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{Message: `importing volume: nope`}},
+	})
+	volumeSource.CheckCallNames(c, "ImportVolume")
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *storageSuite) TestImportVolumeBlockNotSupported(c *gc.C) {
+	filesystemSource := &dummy.FilesystemSource{}
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindFilesystem
+		},
+		FilesystemSourceFunc: func(*storage.Config) (storage.FilesystemSource, error) {
+			return filesystemSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
 	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
 		Kind:        params.StorageKindBlock,
 		Pool:        "radiance",
 		ProviderId:  "foo",
 		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{
+			Message: `importing volume with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		}},
+	})
+	filesystemSource.CheckNoCalls(c)
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindUnknown,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
 	}, {
 		Kind:        params.StorageKindFilesystem,
 		Pool:        "123",
@@ -704,11 +813,140 @@ func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
 	}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
-		{Error: &params.Error{Message: `storage kind "block" not supported`, Code: "not supported"}},
+		{Error: &params.Error{Message: `storage kind "unknown" not supported`, Code: "not supported"}},
 		{Error: &params.Error{Message: `pool name "123" not valid`}},
 	})
 }
 
+func (s *storageSuite) TestResizeVolume(c *gc.C) {
+	volumeSource := volumeResizer{&dummy.VolumeSource{}}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ResizeVolume", []interface{}{"vol-0", uint64(2048)}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceVolumeCall, nil},
+		{setVolumeInfoCall, []interface{}{
+			s.volumeTag,
+			state.VolumeInfo{
+				VolumeId: "vol-0",
+				Pool:     "radiance",
+				Size:     2048,
+			},
+		}},
+	})
+}
+
+func (s *storageSuite) TestResizeFilesystemBackingVolume(c *gc.C) {
+	volumeSource := volumeResizer{&dummy.VolumeSource{}}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	volumeSource.CheckCallNames(c, "ResizeVolume")
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceFilesystemCall,
+		volumeCall,
+		setVolumeInfoCall,
+	)
+}
+
+func (s *storageSuite) TestResizeErrors(c *gc.C) {
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: "volume-0",
+		Size:       2048,
+	}, {
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{
+			Message: `resizing filesystem 104, which has no backing volume not supported`,
+			Code:    "not supported",
+		}},
+	})
+
+	s.storageInstance.kind = state.StorageKindBlock
+	results, err = s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       1024,
+	}, {
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: `new size 1024MiB must be larger than current size 1024MiB`}},
+		{Error: &params.Error{
+			Message: `resizing volume with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		}},
+	})
+}
+
+func (s *storageSuite) TestResizeBlocked(c *gc.C) {
+	s.addBlock(c, state.ChangeBlock, "TestResizeBlocked")
+	_, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
+
 type filesystemImporter struct {
 	*dummy.FilesystemSource
 }
@@ -735,3 +973,16 @@ func (v volumeImporter) ImportVolume(providerId string, tags map[string]string)
 		HardwareId: "hw",
 	}, v.NextErr()
 }
+
+type volumeResizer struct {
+	*dummy.VolumeSource
+}
+
+// ResizeVolume is part of the storage.VolumeResizer interface.
+func (v volumeResizer) ResizeVolume(volumeId string, size uint64) (storage.VolumeInfo, error) {
+	v.MethodCall(v, "ResizeVolume", volumeId, size)
+	return storage.VolumeInfo{
+		VolumeId: volumeId,
+		Size:     size,
+	}, v.NextErr()
+}
@@ -177,3 +177,25 @@ func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	c.Assert(failures.Results[0].Error.Error(), gc.Matches, "sanity not found")
 	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
 }
+
+func (s *storageAddSuite) TestStorageAddUnitQuotaExceeded(c *gc.C) {
+	var requests []state.QuotaUsage
+	s.state.checkModelQuotas = func(request state.QuotaUsage) error {
+		requests = append(requests, request)
+		return errors.New("model quota exceeded")
+	}
+
+	size, count := uint64(2048), uint64(3)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Size: &size, Count: &count},
+	}
+	failures, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{args}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures.Results, gc.HasLen, 1)
+	c.Assert(failures.Results[0].Error, gc.ErrorMatches, "model quota exceeded")
+	c.Assert(requests, jc.DeepEquals, []state.QuotaUsage{{StorageMiB: 6144}})
+
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
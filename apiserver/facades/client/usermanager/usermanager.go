@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/passwordpolicy"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -58,6 +59,16 @@ func NewUserManagerAPI(
 	}, nil
 }
 
+// passwordPolicy returns the password policy configured for the
+// controller.
+func (api *UserManagerAPI) passwordPolicy() (passwordpolicy.Policy, error) {
+	controllerConfig, err := api.state.ControllerConfig()
+	if err != nil {
+		return passwordpolicy.Policy{}, errors.Trace(err)
+	}
+	return controllerConfig.PasswordPolicy(), nil
+}
+
 func (api *UserManagerAPI) hasControllerAdminAccess() (bool, error) {
 	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
 	if errors.IsNotFound(err) {
@@ -90,10 +101,19 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 		return result, common.ErrPerm
 	}
 
+	policy, err := api.passwordPolicy()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
 	for i, arg := range args.Users {
 		var user *state.User
 		var err error
 		if arg.Password != "" {
+			if err := policy.CheckPassword(arg.Password); err != nil {
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
 			user, err = api.state.AddUser(arg.Username, arg.DisplayName, arg.Password, api.apiUser.Id())
 		} else {
 			user, err = api.state.AddUserWithSecretKey(arg.Username, arg.DisplayName, api.apiUser.Id())
@@ -215,6 +235,25 @@ func (api *UserManagerAPI) DisableUser(users params.Entities) (params.ErrorResul
 	return api.enableUserImpl(users, "disable", (*state.User).Disable)
 }
 
+// UnlockUser lifts the lockout of one or more users who have been
+// locked out after too many failed logins, and resets their count of
+// failed logins. If a user is not locked out, the action is considered
+// a success.
+func (api *UserManagerAPI) UnlockUser(users params.Entities) (params.ErrorResults, error) {
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return api.enableUserImpl(users, "unlock", (*state.User).Unlock)
+}
+
 func (api *UserManagerAPI) enableUserImpl(args params.Entities, action string, method func(*state.User) error) (params.ErrorResults, error) {
 	var result params.ErrorResults
 
@@ -255,6 +294,11 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 	if err != nil {
 		return results, errors.Trace(err)
 	}
+	policy, err := api.passwordPolicy()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	now := time.Now()
 
 	var accessForUser = func(userTag names.UserTag, result *params.UserInfoResult) {
 		// Lookup the access the specified user has to the controller.
@@ -287,6 +331,15 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 				Disabled:       user.IsDisabled(),
 			},
 		}
+		if lockedAt := user.LockedAt(); policy.Locked(lockedAt, now) {
+			result.Result.Locked = true
+			if until := policy.LockedUntil(lockedAt); !until.IsZero() {
+				result.Result.LockedUntil = &until
+			}
+		}
+		if expiry := policy.Expiry(user.PasswordChanged()); !expiry.IsZero() {
+			result.Result.PasswordExpiry = &expiry
+		}
 		accessForUser(user.UserTag(), &result)
 		return result
 	}
@@ -378,6 +431,13 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	if arg.Password == "" {
 		return errors.New("cannot use an empty password")
 	}
+	policy, err := api.passwordPolicy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := policy.CheckPassword(arg.Password); err != nil {
+		return errors.Trace(err)
+	}
 	if err := user.SetPassword(arg.Password); err != nil {
 		return errors.Annotate(err, "failed to set password")
 	}
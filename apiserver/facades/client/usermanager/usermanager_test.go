@@ -990,3 +990,114 @@ func (s *userManagerSuite) TestResetPasswordEmpty(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 0)
 }
+
+func (s *userManagerSuite) TestUnlockUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	for i := 0; i < 3; i++ {
+		err := alex.RecordFailedLogin(3)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(alex.LockedAt().IsZero(), jc.IsFalse)
+
+	result, err := s.usermanager.UnlockUser(params.Entities{
+		Entities: []params.Entity{{alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	})
+	err = alex.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alex.FailedLogins(), gc.Equals, 0)
+	c.Assert(alex.LockedAt().IsZero(), jc.IsTrue)
+}
+
+func (s *userManagerSuite) TestUnlockUserAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	api, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.UnlockUser(params.Entities{
+		Entities: []params.Entity{{alex.Tag().String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type passwordPolicySuite struct {
+	jujutesting.JujuConnSuite
+
+	usermanager *usermanager.UserManagerAPI
+}
+
+var _ = gc.Suite(&passwordPolicySuite{})
+
+func (s *passwordPolicySuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		"password-min-length":            8,
+		"password-min-character-classes": 3,
+		"password-max-age":               "720h",
+		"login-lockout-threshold":        3,
+		"login-lockout-duration":         "0s",
+	}
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	s.usermanager, err = usermanager.NewUserManagerAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *passwordPolicySuite) TestAddUserChecksPassword(c *gc.C) {
+	result, err := s.usermanager.AddUser(params.AddUsers{
+		Users: []params.AddUser{{
+			Username: "foobar",
+			Password: "password",
+		}, {
+			Username: "barfoo",
+			Password: "Passw0rd",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "password must contain at least 3 of: .*")
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	_, err = s.State.User(names.NewLocalUserTag("foobar"))
+	c.Assert(err, jc.Satisfies, errors.IsUserNotFound)
+}
+
+func (s *passwordPolicySuite) TestSetPasswordChecksPassword(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", Password: "Passw0rd"})
+	result, err := s.usermanager.SetPassword(params.EntityPasswords{
+		Changes: []params.EntityPassword{{
+			Tag:      alex.Tag().String(),
+			Password: "Sh0rt",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "password must be at least 8 characters long")
+
+	err = alex.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alex.PasswordValid("Passw0rd"), jc.IsTrue)
+}
+
+func (s *passwordPolicySuite) TestUserInfoLockState(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", Password: "Passw0rd"})
+	for i := 0; i < 3; i++ {
+		err := alex.RecordFailedLogin(3)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	results, err := s.usermanager.UserInfo(params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	info := results.Results[0].Result
+	c.Assert(info, gc.NotNil)
+	c.Assert(info.Locked, jc.IsTrue)
+	c.Assert(info.LockedUntil, gc.IsNil)
+	c.Assert(info.PasswordExpiry, gc.NotNil)
+	c.Assert(info.PasswordExpiry.Equal(alex.PasswordChanged().Add(720*time.Hour)), jc.IsTrue)
+}
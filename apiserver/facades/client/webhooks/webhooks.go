@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package webhooks defines an API endpoint that lets controller
+// administrators manage the webhooks to which the controller sends
+// events about the machines, units and applications in its models.
+package webhooks
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the controller state used by the facade.
+type Backend interface {
+	AddWebhook(state.Webhook) error
+	RemoveWebhook(name string) error
+	Webhooks() ([]state.Webhook, error)
+}
+
+// API implements the Webhooks facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade returns a new Webhooks facade. Only controller superusers
+// may use it.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(st, st.ControllerTag(), ctx.Auth())
+}
+
+// NewAPI returns a new Webhooks facade using the given backend.
+func NewAPI(
+	backend Backend,
+	controllerTag names.ControllerTag,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// List returns the controller's webhooks. Their secrets are not
+// included.
+func (api *API) List() (params.WebhooksResult, error) {
+	webhooks, err := api.backend.Webhooks()
+	if err != nil {
+		return params.WebhooksResult{}, errors.Trace(err)
+	}
+	result := params.WebhooksResult{
+		Webhooks: make([]params.Webhook, len(webhooks)),
+	}
+	for i, webhook := range webhooks {
+		result.Webhooks[i] = params.Webhook{
+			Name:   webhook.Name,
+			URL:    webhook.URL,
+			Events: webhook.Events,
+		}
+	}
+	return result, nil
+}
+
+// Add adds the given webhooks to the controller.
+func (api *API) Add(args params.AddWebhooksArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Webhooks)),
+	}
+	for i, arg := range args.Webhooks {
+		err := api.backend.AddWebhook(state.Webhook{
+			Name:   arg.Name,
+			URL:    arg.URL,
+			Secret: arg.Secret,
+			Events: arg.Events,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Remove removes the named webhooks from the controller.
+func (api *API) Remove(args params.RemoveWebhooksArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := api.backend.RemoveWebhook(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/webhooks"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type webhooksSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&webhooksSuite{})
+
+func (s *webhooksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		webhooks: []state.Webhook{{
+			Name:   "ops",
+			URL:    "https://ops.example.com/",
+			Secret: "sekrit",
+			Events: []string{state.WebhookUnitRemoved},
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *webhooksSuite) newAPI(c *gc.C) *webhooks.API {
+	api, err := webhooks.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *webhooksSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.AdminTag = names.NewUserTag("other")
+	_, err := webhooks.NewAPI(s.backend, coretesting.ControllerTag, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *webhooksSuite) TestList(c *gc.C) {
+	result, err := s.newAPI(c).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WebhooksResult{
+		Webhooks: []params.Webhook{{
+			Name:   "ops",
+			URL:    "https://ops.example.com/",
+			Events: []string{state.WebhookUnitRemoved},
+		}},
+	})
+	s.backend.CheckCallNames(c, "Webhooks")
+}
+
+func (s *webhooksSuite) TestAdd(c *gc.C) {
+	s.backend.SetErrors(nil, errors.AlreadyExistsf(`webhook "ops"`))
+	results, err := s.newAPI(c).Add(params.AddWebhooksArgs{
+		Webhooks: []params.AddWebhookArg{{
+			Name:   "audit",
+			URL:    "https://audit.example.com/",
+			Secret: "hush",
+		}, {
+			Name:   "ops",
+			URL:    "https://ops.example.com/",
+			Secret: "sekrit",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `webhook "ops" already exists`)
+	s.backend.CheckCall(c, 0, "AddWebhook", state.Webhook{
+		Name:   "audit",
+		URL:    "https://audit.example.com/",
+		Secret: "hush",
+	})
+}
+
+func (s *webhooksSuite) TestRemove(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf(`webhook "missing"`))
+	results, err := s.newAPI(c).Remove(params.RemoveWebhooksArgs{
+		Names: []string{"ops", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RemoveWebhook", []interface{}{"ops"}},
+		{"RemoveWebhook", []interface{}{"missing"}},
+	})
+}
+
+type mockBackend struct {
+	testing.Stub
+	webhooks []state.Webhook
+}
+
+func (b *mockBackend) AddWebhook(webhook state.Webhook) error {
+	b.MethodCall(b, "AddWebhook", webhook)
+	return b.NextErr()
+}
+
+func (b *mockBackend) RemoveWebhook(name string) error {
+	b.MethodCall(b, "RemoveWebhook", name)
+	return b.NextErr()
+}
+
+func (b *mockBackend) Webhooks() ([]state.Webhook, error) {
+	b.MethodCall(b, "Webhooks")
+	return b.webhooks, b.NextErr()
+}
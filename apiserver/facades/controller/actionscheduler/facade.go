@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// DispatchDueActionSchedules enqueues the actions for all due
+	// schedules, and returns the time at which the next is due.
+	DispatchDueActionSchedules() (time.Time, error)
+}
+
+// Facade allows model-manager clients to dispatch scheduled actions.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// DispatchDue enqueues the actions for all schedules that are due,
+// and returns the time at which the next schedule is due.
+func (facade *Facade) DispatchDue() (params.DispatchActionSchedulesResult, error) {
+	next, err := facade.backend.DispatchDueActionSchedules()
+	if err != nil {
+		return params.DispatchActionSchedulesResult{}, errors.Trace(err)
+	}
+	return params.DispatchActionSchedulesResult{NextRun: next}, nil
+}
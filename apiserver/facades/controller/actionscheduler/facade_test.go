@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestDispatchDue(c *gc.C) {
+	next := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	backend := &mockBackend{next: next}
+	facade, err := actionscheduler.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.DispatchDue()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.NextRun, gc.Equals, next)
+	c.Check(backend.calls, gc.Equals, 1)
+}
+
+func (s *FacadeSuite) TestDispatchDueError(c *gc.C) {
+	backend := &mockBackend{err: errors.New("blammo")}
+	facade, err := actionscheduler.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.DispatchDue()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+// mockBackend implements actionscheduler.Backend.
+type mockBackend struct {
+	next  time.Time
+	err   error
+	calls int
+}
+
+func (mock *mockBackend) DispatchDueActionSchedules() (time.Time, error) {
+	mock.calls++
+	return mock.next, mock.err
+}
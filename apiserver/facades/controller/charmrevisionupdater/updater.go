@@ -4,6 +4,8 @@
 package charmrevisionupdater
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -60,13 +62,36 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 		return err
 	}
 
+	applications, err := api.state.AllApplications()
+	if err != nil {
+		return err
+	}
+
+	// Applications pinned to a charm revision are not offered
+	// upgrades; forget any found before they were pinned.
+	var unpinned []*state.Application
+	for _, application := range applications {
+		if !application.CharmPinned() {
+			unpinned = append(unpinned, application)
+			continue
+		}
+		if err := application.SetCharmUpgrade(nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Look up the information for all the deployed charms. This is the
 	// "expensive" part.
-	latest, err := retrieveLatestCharmInfo(api.state)
+	latest, err := retrieveLatestCharmInfo(api.state, unpinned)
 	if err != nil {
 		return err
 	}
 
+	resources, err := api.state.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// Process the resulting info for each charm.
 	for _, info := range latest {
 		// First, add a charm placeholder to the model for each.
@@ -81,11 +106,52 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 				return err
 			}
 		}
+
+		// Finally, record the upgrade available to the application
+		// from its channel, if any.
+		upgrade, err := charmUpgrade(resources, info)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := info.application.SetCharmUpgrade(upgrade); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return nil
 }
 
+// charmUpgrade returns the upgrade available to the application
+// described by info, or nil if it is running the latest charm
+// revision and resources.
+func charmUpgrade(resources state.Resources, info latestCharmInfo) (*state.CharmUpgrade, error) {
+	var updatedResources []string
+	appResources, err := resources.ListResources(info.application.Name())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	updates, err := appResources.Updates()
+	if err != nil {
+		// The application's resources may not yet be aligned with
+		// those in the charm store; report the charm alone.
+		logger.Warningf("checking resource updates for %q: %v", info.application.Name(), err)
+	}
+	for _, res := range updates {
+		updatedResources = append(updatedResources, res.Name)
+	}
+	sort.Strings(updatedResources)
+
+	latestURL := info.LatestURL()
+	if latestURL.Revision <= info.OriginalURL.Revision && len(updatedResources) == 0 {
+		return nil, nil
+	}
+	return &state.CharmUpgrade{
+		CharmURL:  latestURL,
+		Channel:   info.application.Channel(),
+		Resources: updatedResources,
+	}, nil
+}
+
 // NewCharmStoreClient instantiates a new charm store repository.  Exported so
 // we can change it during testing.
 var NewCharmStoreClient = func(st *state.State) (charmstore.Client, error) {
@@ -98,18 +164,14 @@ type latestCharmInfo struct {
 }
 
 // retrieveLatestCharmInfo looks up the charm store to return the charm URLs for the
-// latest revision of the deployed charms.
-func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
+// latest revision of the given applications' charms, in each application's
+// channel.
+func retrieveLatestCharmInfo(st *state.State, applications []*state.Application) ([]latestCharmInfo, error) {
 	model, err := st.Model()
 	if err != nil {
 		return nil, err
 	}
 
-	applications, err := st.AllApplications()
-	if err != nil {
-		return nil, err
-	}
-
 	client, err := NewCharmStoreClient(st)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -131,6 +131,63 @@ func (s *charmVersionSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *charmVersionSuite) TestUpdateRevisionsRecordsCharmUpgrades(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := mysql.CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.CharmURL.String(), gc.Equals, "cs:quantal/mysql-23")
+	c.Assert(upgrade.Channel, gc.Equals, mysql.Channel())
+	c.Assert(upgrade.Resources, gc.HasLen, 0)
+
+	// Latest wordpress is already deployed, so no upgrade.
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = wordpress.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Upgrading mysql to the latest revision clears its upgrade.
+	ch := s.AddCharmWithRevision(c, "mysql", 23)
+	err = mysql.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	_, err = mysql.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmVersionSuite) TestUpdateRevisionsSkipsPinnedApplications(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// Pin mysql to its current revision.
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := mysql.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetCharm(state.SetCharmConfig{Charm: ch, Pinned: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	_, err = mysql.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *charmVersionSuite) TestWordpressCharmNoReadAccessIsntVisible(c *gc.C) {
 	s.AddMachine(c, "0", state.JobManageModel)
 	s.SetupScenario(c)
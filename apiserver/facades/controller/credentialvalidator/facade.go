@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// ModelCredential returns the tag of the model's cloud
+	// credential, and whether the model has one.
+	ModelCredential() (names.CloudCredentialTag, bool, error)
+
+	// WatchCredential returns a watcher that reports changes to the
+	// identified credential.
+	WatchCredential(names.CloudCredentialTag) state.NotifyWatcher
+
+	// ModelStatus returns the status of the model.
+	ModelStatus() (status.StatusInfo, error)
+
+	// SetModelStatus sets the status of the model.
+	SetModelStatus(status.StatusInfo) error
+}
+
+// Facade allows model-manager clients to check the model's cloud
+// credential, and to suspend the model while the credential is not
+// valid.
+type Facade struct {
+	*common.ModelWatcher
+	cloudspec.CloudSpecAPI
+
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(
+	backend Backend,
+	modelWatcher *common.ModelWatcher,
+	cloudSpecAPI cloudspec.CloudSpecAPI,
+	resources facade.Resources,
+	auth facade.Authorizer,
+) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		ModelWatcher: modelWatcher,
+		CloudSpecAPI: cloudSpecAPI,
+		backend:      backend,
+		resources:    resources,
+	}, nil
+}
+
+// WatchModelCredential returns a watcher that reports changes to the
+// model's cloud credential. If the model has no credential, the
+// result's error satisfies params.IsCodeNotFound.
+func (facade *Facade) WatchModelCredential() (params.NotifyWatchResult, error) {
+	tag, ok, err := facade.backend.ModelCredential()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	if !ok {
+		return params.NotifyWatchResult{
+			Error: common.ServerError(errors.NotFoundf("model credential")),
+		}, nil
+	}
+	watch := facade.backend.WatchCredential(tag)
+	// Consume the initial event; the client-side watcher sends
+	// its own.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// SetCredentialValidity suspends the model when its cloud credential
+// is not valid, and makes it available again once the credential is
+// valid. A model that is busy or being destroyed keeps its status.
+func (facade *Facade) SetCredentialValidity(args params.CredentialValidity) error {
+	current, err := facade.backend.ModelStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var next status.StatusInfo
+	switch {
+	case current.Status != status.Available && current.Status != status.Suspended:
+		return nil
+	case args.Valid:
+		next.Status = status.Available
+	default:
+		next.Status = status.Suspended
+		next.Message = "suspended: cloud credential is not valid"
+		if args.Reason != "" {
+			next.Message += ": " + args.Reason
+		}
+	}
+	if next.Status == current.Status && next.Message == current.Message {
+		return nil
+	}
+	return errors.Trace(facade.backend.SetModelStatus(next))
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/credentialvalidator"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+	resources *common.Resources
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+}
+
+func (s *FacadeSuite) newFacade(c *gc.C, backend credentialvalidator.Backend) *credentialvalidator.Facade {
+	facade, err := credentialvalidator.NewFacade(backend, nil, nil, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := credentialvalidator.NewFacade(&mockBackend{}, nil, nil, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := credentialvalidator.NewFacade(&mockBackend{}, nil, nil, s.resources, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchModelCredential(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/fred/default")
+	backend := &mockBackend{credential: tag}
+	facade := s.newFacade(c, backend)
+
+	result, err := facade.WatchModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.NotifyWatcherId, gc.Equals, "1")
+	c.Check(backend.watched, jc.DeepEquals, []names.CloudCredentialTag{tag})
+	c.Check(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *FacadeSuite) TestWatchModelCredentialNoCredential(c *gc.C) {
+	facade := s.newFacade(c, &mockBackend{})
+
+	result, err := facade.WatchModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, params.CodeNotFound)
+	c.Check(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestSetCredentialValidity(c *gc.C) {
+	for i, test := range []struct {
+		about    string
+		current  status.StatusInfo
+		args     params.CredentialValidity
+		expected *status.StatusInfo
+	}{{
+		about:   "invalid credential suspends available model",
+		current: status.StatusInfo{Status: status.Available},
+		args:    params.CredentialValidity{Reason: "token expired"},
+		expected: &status.StatusInfo{
+			Status:  status.Suspended,
+			Message: "suspended: cloud credential is not valid: token expired",
+		},
+	}, {
+		about:    "valid credential resumes suspended model",
+		current:  status.StatusInfo{Status: status.Suspended, Message: "suspended: cloud credential is not valid"},
+		args:     params.CredentialValidity{Valid: true},
+		expected: &status.StatusInfo{Status: status.Available},
+	}, {
+		about:   "valid credential leaves available model",
+		current: status.StatusInfo{Status: status.Available},
+		args:    params.CredentialValidity{Valid: true},
+	}, {
+		about:   "invalid credential leaves suspended model",
+		current: status.StatusInfo{Status: status.Suspended, Message: "suspended: cloud credential is not valid"},
+		args:    params.CredentialValidity{},
+	}, {
+		about:   "invalid credential leaves busy model",
+		current: status.StatusInfo{Status: status.Busy},
+		args:    params.CredentialValidity{},
+	}, {
+		about:   "valid credential leaves destroying model",
+		current: status.StatusInfo{Status: status.Destroying},
+		args:    params.CredentialValidity{Valid: true},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		backend := &mockBackend{status: test.current}
+		facade := s.newFacade(c, backend)
+
+		err := facade.SetCredentialValidity(test.args)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(backend.setStatus, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *FacadeSuite) TestSetCredentialValidityError(c *gc.C) {
+	facade := s.newFacade(c, &mockBackend{err: errors.New("blammo")})
+
+	err := facade.SetCredentialValidity(params.CredentialValidity{})
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+// mockBackend implements credentialvalidator.Backend.
+type mockBackend struct {
+	credential names.CloudCredentialTag
+	status     status.StatusInfo
+	err        error
+
+	watched   []names.CloudCredentialTag
+	setStatus *status.StatusInfo
+}
+
+func (mock *mockBackend) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	return mock.credential, mock.credential != names.CloudCredentialTag{}, mock.err
+}
+
+func (mock *mockBackend) WatchCredential(tag names.CloudCredentialTag) state.NotifyWatcher {
+	mock.watched = append(mock.watched, tag)
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (mock *mockBackend) ModelStatus() (status.StatusInfo, error) {
+	return mock.status, mock.err
+}
+
+func (mock *mockBackend) SetModelStatus(info status.StatusInfo) error {
+	mock.setStatus = &info
+	return mock.err
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudSpecAPI := cloudspec.NewCloudSpec(
+		cloudspec.MakeCloudSpecGetterForModel(st),
+		common.AuthFuncForTag(model.ModelTag()),
+	)
+	return NewFacade(
+		backendShim{st},
+		common.NewModelWatcher(model, resources, auth),
+		cloudSpecAPI,
+		resources,
+		auth,
+	)
+}
+
+// backendShim implements Backend with a *state.State.
+type backendShim struct {
+	st *state.State
+}
+
+// ModelCredential is part of the Backend interface.
+func (shim backendShim) ModelCredential() (names.CloudCredentialTag, bool, error) {
+	model, err := shim.st.Model()
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	tag, ok := model.CloudCredential()
+	return tag, ok, nil
+}
+
+// WatchCredential is part of the Backend interface.
+func (shim backendShim) WatchCredential(tag names.CloudCredentialTag) state.NotifyWatcher {
+	return shim.st.WatchCredential(tag)
+}
+
+// ModelStatus is part of the Backend interface.
+func (shim backendShim) ModelStatus() (status.StatusInfo, error) {
+	model, err := shim.st.Model()
+	if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	return model.Status()
+}
+
+// SetModelStatus is part of the Backend interface.
+func (shim backendShim) SetModelStatus(info status.StatusInfo) error {
+	model, err := shim.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return model.SetStatus(info)
+}
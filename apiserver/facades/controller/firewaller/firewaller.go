@@ -487,6 +487,8 @@ func (f *FirewallerAPIV4) FirewallRules(args params.KnownServiceArgs) (params.Li
 		result.Rules = append(result.Rules, params.FirewallRule{
 			KnownService:   params.KnownServiceValue(knownService),
 			WhitelistCIDRS: rule.WhitelistCIDRs,
+			Description:    rule.Description,
+			Origin:         rule.Origin,
 		})
 	}
 	return result, nil
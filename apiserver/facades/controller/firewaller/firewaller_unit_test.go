@@ -126,6 +126,8 @@ func (s *RemoteFirewallerSuite) TestFirewallRules(c *gc.C) {
 	s.st.firewallRules[state.JujuApplicationOfferRule] = &state.FirewallRule{
 		WellKnownService: state.JujuApplicationOfferRule,
 		WhitelistCIDRs:   []string{"192.168.0.0/16"},
+		Description:      "office network",
+		Origin:           "user-admin",
 	}
 	result, err := s.api.FirewallRules(params.KnownServiceArgs{
 		KnownServices: []params.KnownServiceValue{params.JujuApplicationOfferRule, params.SSHRule}})
@@ -133,4 +135,6 @@ func (s *RemoteFirewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result.Rules, gc.HasLen, 1)
 	c.Assert(result.Rules[0].KnownService, gc.Equals, params.KnownServiceValue("juju-application-offer"))
 	c.Assert(result.Rules[0].WhitelistCIDRS, jc.SameContents, []string{"192.168.0.0/16"})
+	c.Assert(result.Rules[0].Description, gc.Equals, "office network")
+	c.Assert(result.Rules[0].Origin, gc.Equals, "user-admin")
 }
@@ -4,6 +4,9 @@
 package machineundertaker
 
 import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -26,6 +29,14 @@ type Backend interface {
 	// Machine gets a specific machine, so we can collect details of
 	// its network interfaces.
 	Machine(id string) (Machine, error)
+
+	// AllInstanceIds returns the instance ids of the model's
+	// provisioned machines.
+	AllInstanceIds() ([]instance.Id, error)
+
+	// AllVolumeProviderIds returns the provider ids of the model's
+	// provisioned volumes.
+	AllVolumeProviderIds() ([]string, error)
 }
 
 // Machine defines the methods we need from state.Machine.
@@ -43,3 +54,45 @@ type backendShim struct {
 func (b *backendShim) Machine(id string) (Machine, error) {
 	return b.State.Machine(id)
 }
+
+// AllInstanceIds implements Backend.
+func (b *backendShim) AllInstanceIds() ([]instance.Id, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, m := range machines {
+		id, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// AllVolumeProviderIds implements Backend.
+func (b *backendShim) AllVolumeProviderIds() ([]string, error) {
+	im, err := b.State.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := im.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, info.VolumeId)
+	}
+	return ids, nil
+}
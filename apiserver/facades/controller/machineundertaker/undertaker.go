@@ -43,11 +43,30 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 	return api, nil
 }
 
+// APIV1 implements version 1 of the machine undertaker facade, which
+// does not report the provider resources known to the model.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
 	return NewAPI(&backendShim{st}, res, auth)
 }
 
+// NewFacadeV1 provides the signature required for registering
+// version 1 of the facade.
+func NewFacadeV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIV1, error) {
+	api, err := NewFacade(st, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// KnownProviderResources isn't on the V1 API.
+func (*APIV1) KnownProviderResources(_, _ struct{}) {}
+
 // AllMachineRemovals returns tags for all of the machines that have
 // been marked for removal in the requested model.
 func (m *API) AllMachineRemovals(models params.Entities) params.EntitiesResults {
@@ -155,6 +174,41 @@ func (m *API) watchRemovalsForTag(tag string) (string, error) {
 	}
 }
 
+// KnownProviderResources returns the provider resources which the
+// requested models' state accounts for: the instances of their
+// machines and their volumes.
+func (m *API) KnownProviderResources(models params.Entities) params.KnownProviderResourcesResults {
+	results := make([]params.KnownProviderResourcesResult, len(models.Entities))
+	for i, entity := range models.Entities {
+		result, err := m.knownResourcesForTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i] = result
+	}
+	return params.KnownProviderResourcesResults{Results: results}
+}
+
+func (m *API) knownResourcesForTag(tag string) (params.KnownProviderResourcesResult, error) {
+	var result params.KnownProviderResourcesResult
+	if err := m.checkModelAuthorization(tag); err != nil {
+		return result, errors.Trace(err)
+	}
+	instanceIds, err := m.backend.AllInstanceIds()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, id := range instanceIds {
+		result.Instances = append(result.Instances, string(id))
+	}
+	result.Volumes, err = m.backend.AllVolumeProviderIds()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 func (m *API) checkModelAuthorization(tag string) error {
 	modelTag, err := names.ParseModelTag(tag)
 	if err != nil {
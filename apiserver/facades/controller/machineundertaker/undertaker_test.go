@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -218,6 +219,30 @@ func (*undertakerSuite) TestWatchMachineRemovalsError(c *gc.C) {
 	backend.CheckCallNames(c, "WatchMachineRemovals")
 }
 
+func (*undertakerSuite) TestKnownProviderResources(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.instanceIds = []instance.Id{"i-0", "i-1"}
+	backend.volumeIds = []string{"vol-0"}
+
+	results := api.KnownProviderResources(makeEntities(tag1, tag2))
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], gc.DeepEquals, params.KnownProviderResourcesResult{
+		Instances: []string{"i-0", "i-1"},
+		Volumes:   []string{"vol-0"},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	backend.CheckCallNames(c, "AllInstanceIds", "AllVolumeProviderIds")
+}
+
+func (*undertakerSuite) TestKnownProviderResourcesError(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.SetErrors(errors.New("kaboom"))
+
+	results := api.KnownProviderResources(makeEntities(tag1))
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "kaboom")
+}
+
 func makeAPI(c *gc.C, modelUUID string) (*mockBackend, *common.Resources, *machineundertaker.API) {
 	backend := &mockBackend{Stub: &testing.Stub{}}
 	res := common.NewResources()
@@ -258,9 +283,21 @@ type mockBackend struct {
 
 	removals       []string
 	machines       map[string]*mockMachine
+	instanceIds    []instance.Id
+	volumeIds      []string
 	watcherBlowsUp bool
 }
 
+func (b *mockBackend) AllInstanceIds() ([]instance.Id, error) {
+	b.AddCall("AllInstanceIds")
+	return b.instanceIds, b.NextErr()
+}
+
+func (b *mockBackend) AllVolumeProviderIds() ([]string, error) {
+	b.AddCall("AllVolumeProviderIds")
+	return b.volumeIds, b.NextErr()
+}
+
 func (b *mockBackend) AllMachineRemovals() ([]string, error) {
 	b.AddCall("AllMachineRemovals")
 	return b.removals, b.NextErr()
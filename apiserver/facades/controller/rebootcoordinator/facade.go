@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// RebootRequests returns the model's pending reboot requests,
+	// oldest first.
+	RebootRequests() ([]state.RebootRequest, error)
+
+	// GrantReboot grants the reboot requested by the named unit.
+	GrantReboot(unit string) error
+
+	// RemoveRebootRequest removes the named unit's reboot request.
+	RemoveRebootRequest(unit string) error
+
+	// UnitReady reports whether the named unit has an active
+	// workload and an idle agent.
+	UnitReady(unit string) (bool, error)
+
+	// ApplicationUnitsReady reports whether every unit of the named
+	// application is ready, as defined by UnitReady.
+	ApplicationUnitsReady(application string) (bool, error)
+
+	// MachineRebooting reports whether the reboot flag of the
+	// identified machine is set.
+	MachineRebooting(machine string) (bool, error)
+}
+
+// Facade allows model-manager clients to coordinate the reboots
+// requested by units.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// RebootApplications returns the pending reboot requests of the
+// model's units, grouped by application, along with the readiness of
+// the units involved.
+func (facade *Facade) RebootApplications() (params.RebootApplicationsResult, error) {
+	requests, err := facade.backend.RebootRequests()
+	if err != nil {
+		return params.RebootApplicationsResult{}, errors.Trace(err)
+	}
+	var result params.RebootApplicationsResult
+	index := make(map[string]int)
+	for _, req := range requests {
+		i, ok := index[req.Application]
+		if !ok {
+			ready, err := facade.backend.ApplicationUnitsReady(req.Application)
+			if err != nil {
+				return params.RebootApplicationsResult{}, errors.Trace(err)
+			}
+			i = len(result.Applications)
+			index[req.Application] = i
+			result.Applications = append(result.Applications, params.RebootApplication{
+				ApplicationTag: names.NewApplicationTag(req.Application).String(),
+				UnitsReady:     ready,
+			})
+		}
+		ready, err := facade.backend.UnitReady(req.Unit)
+		if err != nil {
+			return params.RebootApplicationsResult{}, errors.Trace(err)
+		}
+		rebooting, err := facade.backend.MachineRebooting(req.Machine)
+		if err != nil {
+			return params.RebootApplicationsResult{}, errors.Trace(err)
+		}
+		app := &result.Applications[i]
+		app.Requests = append(app.Requests, params.RebootRequest{
+			UnitTag:   names.NewUnitTag(req.Unit).String(),
+			Granted:   req.Granted,
+			Rebooting: rebooting,
+			Ready:     ready,
+		})
+	}
+	return result, nil
+}
+
+// GrantReboots grants the reboots requested by the given units.
+func (facade *Facade) GrantReboots(args params.Entities) (params.ErrorResults, error) {
+	return facade.forEachUnit(args, facade.backend.GrantReboot), nil
+}
+
+// CompleteReboots removes the reboot requests of the given units,
+// whose granted reboots have completed.
+func (facade *Facade) CompleteReboots(args params.Entities) (params.ErrorResults, error) {
+	return facade.forEachUnit(args, facade.backend.RemoveRebootRequest), nil
+}
+
+func (facade *Facade) forEachUnit(args params.Entities, f func(string) error) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err == nil {
+			err = f(tag.Id())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/rebootcoordinator"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := rebootcoordinator.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := rebootcoordinator.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestRebootApplications(c *gc.C) {
+	requested := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	backend := &mockBackend{
+		requests: []state.RebootRequest{{
+			Unit: "mysql/1", Application: "mysql", Machine: "1",
+			Requested: requested, Granted: true,
+		}, {
+			Unit: "wordpress/0", Application: "wordpress", Machine: "2",
+			Requested: requested,
+		}, {
+			Unit: "mysql/0", Application: "mysql", Machine: "0",
+			Requested: requested.Add(time.Minute),
+		}},
+		readyUnits:        map[string]bool{"mysql/0": true, "wordpress/0": true},
+		readyApps:         map[string]bool{"wordpress": true},
+		rebootingMachines: map[string]bool{"1": true},
+	}
+	facade, err := rebootcoordinator.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.RebootApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.RebootApplicationsResult{
+		Applications: []params.RebootApplication{{
+			ApplicationTag: "application-mysql",
+			Requests: []params.RebootRequest{{
+				UnitTag:   "unit-mysql-1",
+				Granted:   true,
+				Rebooting: true,
+			}, {
+				UnitTag: "unit-mysql-0",
+				Ready:   true,
+			}},
+		}, {
+			ApplicationTag: "application-wordpress",
+			UnitsReady:     true,
+			Requests: []params.RebootRequest{{
+				UnitTag: "unit-wordpress-0",
+				Ready:   true,
+			}},
+		}},
+	})
+}
+
+func (s *FacadeSuite) TestRebootApplicationsError(c *gc.C) {
+	backend := &mockBackend{err: errors.New("blammo")}
+	facade, err := rebootcoordinator.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.RebootApplications()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *FacadeSuite) TestGrantReboots(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := rebootcoordinator.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.GrantReboots(params.Entities{
+		Entities: []params.Entity{{"unit-mysql-0"}, {"machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+	c.Check(backend.granted, jc.DeepEquals, []string{"mysql/0"})
+}
+
+func (s *FacadeSuite) TestCompleteReboots(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := rebootcoordinator.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.CompleteReboots(params.Entities{
+		Entities: []params.Entity{{"unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(backend.removed, jc.DeepEquals, []string{"mysql/0"})
+}
+
+// mockBackend implements rebootcoordinator.Backend.
+type mockBackend struct {
+	requests          []state.RebootRequest
+	readyUnits        map[string]bool
+	readyApps         map[string]bool
+	rebootingMachines map[string]bool
+	err               error
+
+	granted []string
+	removed []string
+}
+
+func (mock *mockBackend) RebootRequests() ([]state.RebootRequest, error) {
+	return mock.requests, mock.err
+}
+
+func (mock *mockBackend) GrantReboot(unit string) error {
+	mock.granted = append(mock.granted, unit)
+	return mock.err
+}
+
+func (mock *mockBackend) RemoveRebootRequest(unit string) error {
+	mock.removed = append(mock.removed, unit)
+	return mock.err
+}
+
+func (mock *mockBackend) UnitReady(unit string) (bool, error) {
+	return mock.readyUnits[unit], mock.err
+}
+
+func (mock *mockBackend) ApplicationUnitsReady(application string) (bool, error) {
+	return mock.readyApps[application], mock.err
+}
+
+func (mock *mockBackend) MachineRebooting(machine string) (bool, error) {
+	return mock.rebootingMachines[machine], mock.err
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, auth)
+}
+
+// backendShim implements Backend with a *state.State.
+type backendShim struct {
+	st *state.State
+}
+
+// RebootRequests is part of the Backend interface.
+func (shim backendShim) RebootRequests() ([]state.RebootRequest, error) {
+	return shim.st.RebootRequests()
+}
+
+// GrantReboot is part of the Backend interface. As well as setting the
+// machine's reboot flag, it marks the unit's agent as rebooting, so
+// that the unit is not considered ready until its agent runs again.
+func (shim backendShim) GrantReboot(unitName string) error {
+	if err := shim.st.GrantRebootRequest(unitName); err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := shim.st.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Rebooting,
+		Message: "coordinated reboot granted",
+	})
+}
+
+// RemoveRebootRequest is part of the Backend interface.
+func (shim backendShim) RemoveRebootRequest(unitName string) error {
+	return shim.st.RemoveRebootRequest(unitName)
+}
+
+// UnitReady is part of the Backend interface.
+func (shim backendShim) UnitReady(unitName string) (bool, error) {
+	unit, err := shim.st.Unit(unitName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return unitReady(unit)
+}
+
+// ApplicationUnitsReady is part of the Backend interface.
+func (shim backendShim) ApplicationUnitsReady(appName string) (bool, error) {
+	app, err := shim.st.Application(appName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, unit := range units {
+		if ready, err := unitReady(unit); err != nil || !ready {
+			return false, errors.Trace(err)
+		}
+	}
+	return true, nil
+}
+
+// MachineRebooting is part of the Backend interface.
+func (shim backendShim) MachineRebooting(machineId string) (bool, error) {
+	machine, err := shim.st.Machine(machineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return machine.GetRebootFlag()
+}
+
+func unitReady(unit *state.Unit) (bool, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if agentStatus.Status != status.Idle {
+		return false, nil
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return workloadStatus.Status == status.Active, nil
+}
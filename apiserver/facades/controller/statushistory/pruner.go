@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -17,6 +19,12 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
+// APIv2 implements the StatusHistory v2 API, whose Prune method
+// does not report what was removed.
+type APIv2 struct {
+	*API
+}
+
 // NewAPI returns an API Instance.
 func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
 	m, err := st.Model()
@@ -31,12 +39,38 @@ func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API,
 	}, nil
 }
 
+// NewAPIv2 returns an APIv2 Instance.
+func NewAPIv2(st *state.State, r facade.Resources, auth facade.Authorizer) (*APIv2, error) {
+	api, err := NewAPI(st, r, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv2{api}, nil
+}
+
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
-func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
+// the model's history is smaller than p.MaxHistoryMB. It
+// reports the entries removed and the space reclaimed.
+func (api *API) Prune(p params.StatusHistoryPruneArgs) (params.StatusHistoryPruneResult, error) {
 	if !api.authorizer.AuthController() {
-		return common.ErrPerm
+		return params.StatusHistoryPruneResult{}, common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	result, err := state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err != nil {
+		return params.StatusHistoryPruneResult{}, errors.Trace(err)
+	}
+	return params.StatusHistoryPruneResult{
+		DeletedByAge:   result.DeletedByAge,
+		DeletedBySize:  result.DeletedBySize,
+		ReclaimedBytes: result.ReclaimedBytes,
+	}, nil
+}
+
+// Prune endpoint removes status history entries until
+// only the ones newer than now - p.MaxHistoryTime remain and
+// the model's history is smaller than p.MaxHistoryMB.
+func (api *APIv2) Prune(p params.StatusHistoryPruneArgs) error {
+	_, err := api.API.Prune(p)
+	return err
 }
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshotter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/client"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API is the concrete implementation of the StatusSnapshotter endpoint.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, r, auth),
+		st:           st,
+		authorizer:   auth,
+	}, nil
+}
+
+// Snapshot records a snapshot of the model's status, and removes
+// the snapshots older than now - p.MaxAge, and the oldest snapshots
+// beyond the p.MaxSizeMB budget.
+func (api *API) Snapshot(p params.StatusSnapshotArgs) error {
+	data, err := client.ModelStatusSnapshot(api.st)
+	if err != nil {
+		return errors.Annotate(err, "cannot compute model status")
+	}
+	model, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := model.AddStatusSnapshot(data); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = state.PruneStatusSnapshots(api.st, p.MaxAge, p.MaxSizeMB)
+	return errors.Trace(err)
+}
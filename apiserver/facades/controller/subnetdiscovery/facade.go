@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// ReconcileSubnets brings the model's subnets into line with
+	// those known to the model's provider.
+	ReconcileSubnets() error
+}
+
+// Facade allows model-manager clients to keep the model's subnets in
+// step with the provider.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// ReconcileSubnets adds the subnets that are new to the provider to
+// the model, and marks those that the provider no longer reports as
+// dead. An error satisfying params.IsCodeNotSupported is returned if
+// the model's provider does not support networking.
+func (facade *Facade) ReconcileSubnets() error {
+	return errors.Trace(facade.backend.ReconcileSubnets())
+}
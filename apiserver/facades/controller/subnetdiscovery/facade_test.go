@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/subnetdiscovery"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := subnetdiscovery.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := subnetdiscovery.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestReconcileSubnets(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := subnetdiscovery.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = facade.ReconcileSubnets()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(backend.calls, gc.Equals, 1)
+}
+
+func (s *FacadeSuite) TestReconcileSubnetsNotSupported(c *gc.C) {
+	backend := &mockBackend{
+		err: errors.NotSupportedf("spaces discovery in a non-networking environ"),
+	}
+	facade, err := subnetdiscovery.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = facade.ReconcileSubnets()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(common.ServerError(err), jc.Satisfies, params.IsCodeNotSupported)
+}
+
+// mockBackend implements subnetdiscovery.Backend.
+type mockBackend struct {
+	calls int
+	err   error
+}
+
+func (mock *mockBackend) ReconcileSubnets() error {
+	mock.calls++
+	return mock.err
+}
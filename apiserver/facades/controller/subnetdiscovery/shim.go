@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, auth)
+}
+
+// backendShim implements Backend with a *state.State.
+type backendShim struct {
+	st *state.State
+}
+
+// ReconcileSubnets is part of the Backend interface.
+func (shim backendShim) ReconcileSubnets() error {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(shim.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(shim.st.ReconcileSubnets(env))
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// StagedUpgrade returns the model's staged upgrade, or an error
+	// satisfying errors.IsNotFound if none is in progress.
+	StagedUpgrade() (state.StagedUpgrade, error)
+
+	// MachineIds returns the ids of the model's machines that are
+	// not controllers, ordered by id.
+	MachineIds() ([]string, error)
+
+	// MachineUpgraded reports whether the identified machine and its
+	// units are running agents of the given version.
+	MachineUpgraded(machine string, vers version.Number) (bool, error)
+
+	// MachineHealthy reports whether the identified machine's agent
+	// has started and none of its units' agents are in error.
+	MachineHealthy(machine string) (bool, error)
+
+	// AdmitStagedUpgradeMachines allows the identified machines to
+	// upgrade.
+	AdmitStagedUpgradeMachines(machines []string) error
+
+	// CompleteStagedUpgrade ends the model's staged upgrade.
+	CompleteStagedUpgrade() error
+}
+
+// Facade allows model-manager clients to roll a staged agent upgrade
+// out to the model's machines.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// StagedUpgradeStatus returns the progress of the model's staged
+// upgrade, if any.
+func (facade *Facade) StagedUpgradeStatus() (params.StagedUpgradeStatusResult, error) {
+	upgrade, err := facade.backend.StagedUpgrade()
+	if errors.IsNotFound(err) {
+		return params.StagedUpgradeStatusResult{}, nil
+	} else if err != nil {
+		return params.StagedUpgradeStatusResult{}, errors.Trace(err)
+	}
+	ids, err := facade.backend.MachineIds()
+	if err != nil {
+		return params.StagedUpgradeStatusResult{}, errors.Trace(err)
+	}
+	result := params.StagedUpgradeStatusResult{
+		InProgress: true,
+		Version:    upgrade.Version,
+		Canaries:   upgrade.Canaries,
+		BatchSize:  upgrade.BatchSize,
+	}
+	for _, id := range ids {
+		upgraded, err := facade.backend.MachineUpgraded(id, upgrade.Version)
+		if err != nil {
+			return params.StagedUpgradeStatusResult{}, errors.Trace(err)
+		}
+		healthy, err := facade.backend.MachineHealthy(id)
+		if err != nil {
+			return params.StagedUpgradeStatusResult{}, errors.Trace(err)
+		}
+		result.Machines = append(result.Machines, params.StagedUpgradeMachine{
+			MachineTag: names.NewMachineTag(id).String(),
+			Admitted:   upgrade.IsAdmitted(id),
+			Upgraded:   upgraded,
+			Healthy:    healthy,
+		})
+	}
+	return result, nil
+}
+
+// AdmitMachines allows the given machines to upgrade to the version of
+// the model's staged upgrade.
+func (facade *Facade) AdmitMachines(args params.Entities) (params.ErrorResult, error) {
+	ids := make([]string, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			return params.ErrorResult{Error: common.ServerError(err)}, nil
+		}
+		ids[i] = tag.Id()
+	}
+	err := facade.backend.AdmitStagedUpgradeMachines(ids)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// CompleteStagedUpgrade ends the model's staged upgrade, once all its
+// machines have been admitted.
+func (facade *Facade) CompleteStagedUpgrade() (params.ErrorResult, error) {
+	err := facade.backend.CompleteStagedUpgrade()
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
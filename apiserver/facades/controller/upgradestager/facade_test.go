@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/upgradestager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := upgradestager.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := upgradestager.NewFacade(&mockBackend{}, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestStagedUpgradeStatusNotInProgress(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.StagedUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.StagedUpgradeStatusResult{})
+}
+
+func (s *FacadeSuite) TestStagedUpgradeStatus(c *gc.C) {
+	backend := &mockBackend{
+		upgrade: &state.StagedUpgrade{
+			Version:   version.MustParse("2.3.1"),
+			Canaries:  []string{"1"},
+			BatchSize: 2,
+			Admitted:  []string{"1"},
+		},
+		machines: []string{"1", "2"},
+		upgraded: map[string]bool{"1": true},
+		healthy:  map[string]bool{"1": true, "2": true},
+	}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.StagedUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.StagedUpgradeStatusResult{
+		InProgress: true,
+		Version:    version.MustParse("2.3.1"),
+		Canaries:   []string{"1"},
+		BatchSize:  2,
+		Machines: []params.StagedUpgradeMachine{{
+			MachineTag: "machine-1",
+			Admitted:   true,
+			Upgraded:   true,
+			Healthy:    true,
+		}, {
+			MachineTag: "machine-2",
+			Healthy:    true,
+		}},
+	})
+}
+
+func (s *FacadeSuite) TestStagedUpgradeStatusError(c *gc.C) {
+	backend := &mockBackend{err: errors.New("blammo")}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.StagedUpgradeStatus()
+	c.Check(err, gc.ErrorMatches, "blammo")
+}
+
+func (s *FacadeSuite) TestAdmitMachines(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.AdmitMachines(params.Entities{
+		Entities: []params.Entity{{"machine-1"}, {"machine-2"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(backend.admitted, jc.DeepEquals, []string{"1", "2"})
+}
+
+func (s *FacadeSuite) TestAdmitMachinesInvalidTag(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.AdmitMachines(params.Entities{
+		Entities: []params.Entity{{"unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid machine tag`)
+	c.Check(backend.admitted, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestCompleteStagedUpgrade(c *gc.C) {
+	backend := &mockBackend{}
+	facade, err := upgradestager.NewFacade(backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.CompleteStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.IsNil)
+	c.Check(backend.completed, jc.IsTrue)
+}
+
+// mockBackend implements upgradestager.Backend.
+type mockBackend struct {
+	upgrade  *state.StagedUpgrade
+	machines []string
+	upgraded map[string]bool
+	healthy  map[string]bool
+	err      error
+
+	admitted  []string
+	completed bool
+}
+
+func (mock *mockBackend) StagedUpgrade() (state.StagedUpgrade, error) {
+	if mock.err != nil {
+		return state.StagedUpgrade{}, mock.err
+	}
+	if mock.upgrade == nil {
+		return state.StagedUpgrade{}, errors.NotFoundf("staged upgrade")
+	}
+	return *mock.upgrade, nil
+}
+
+func (mock *mockBackend) MachineIds() ([]string, error) {
+	return mock.machines, mock.err
+}
+
+func (mock *mockBackend) MachineUpgraded(machine string, _ version.Number) (bool, error) {
+	return mock.upgraded[machine], mock.err
+}
+
+func (mock *mockBackend) MachineHealthy(machine string) (bool, error) {
+	return mock.healthy[machine], mock.err
+}
+
+func (mock *mockBackend) AdmitStagedUpgradeMachines(machines []string) error {
+	mock.admitted = append(mock.admitted, machines...)
+	return mock.err
+}
+
+func (mock *mockBackend) CompleteStagedUpgrade() error {
+	mock.completed = true
+	return mock.err
+}
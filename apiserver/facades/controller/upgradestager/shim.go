@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, auth)
+}
+
+// backendShim implements Backend with a *state.State.
+type backendShim struct {
+	*state.State
+}
+
+// MachineIds is part of the Backend interface.
+func (shim backendShim) MachineIds() ([]string, error) {
+	machines, err := shim.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, machine := range machines {
+		if machine.IsManager() || machine.Life() == state.Dead {
+			continue
+		}
+		ids = append(ids, machine.Id())
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// MachineUpgraded is part of the Backend interface.
+func (shim backendShim) MachineUpgraded(machineId string, vers version.Number) (bool, error) {
+	machine, err := shim.Machine(machineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if upgraded, err := agentUpgraded(machine, vers); err != nil || !upgraded {
+		return false, errors.Trace(err)
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, unit := range units {
+		if upgraded, err := agentUpgraded(unit, vers); err != nil || !upgraded {
+			return false, errors.Trace(err)
+		}
+	}
+	return true, nil
+}
+
+// MachineHealthy is part of the Backend interface.
+func (shim backendShim) MachineHealthy(machineId string) (bool, error) {
+	machine, err := shim.Machine(machineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	machineStatus, err := machine.Status()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if machineStatus.Status != status.Started {
+		return false, nil
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, unit := range units {
+		agentStatus, err := unit.AgentStatus()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if agentStatus.Status == status.Error {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func agentUpgraded(tooler state.AgentTooler, vers version.Number) (bool, error) {
+	tools, err := tooler.AgentTools()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return tools.Version.Number == vers, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// healthCheck is a named check made by the /health endpoint. The
+// check returns an error if the API server is not ready to serve
+// clients.
+type healthCheck struct {
+	name  string
+	check func() error
+}
+
+// healthHandler serves liveness and readiness checks for load
+// balancers and orchestrators. Requests are not authenticated, but
+// must come from one of the allowed networks, if any are specified.
+//
+// GET /health/live succeeds whenever the API server is running.
+// GET /health and /health/ready report the result of each check,
+// and succeed only if all of them pass.
+type healthHandler struct {
+	allowedNets []*net.IPNet
+	checks      []healthCheck
+	liveOnly    bool
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h healthHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := h.serveHTTP(w, req); err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Debugf("%v", err)
+		}
+	}
+}
+
+func (h healthHandler) serveHTTP(w http.ResponseWriter, req *http.Request) error {
+	if req.Method != "GET" && req.Method != "HEAD" {
+		return emitUnsupportedMethodErr(req.Method)
+	}
+	if err := checkRequestSource(h.allowedNets, req.RemoteAddr); err != nil {
+		return err
+	}
+	response := params.HealthResponse{Live: true, Ready: true}
+	if h.liveOnly {
+		return sendStatusAndJSON(w, http.StatusOK, response)
+	}
+	for _, check := range h.checks {
+		result := params.HealthCheck{Name: check.name, Healthy: true}
+		if err := check.check(); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+			response.Ready = false
+		}
+		response.Checks = append(response.Checks, result)
+	}
+	status := http.StatusOK
+	if !response.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	return sendStatusAndJSON(w, status, response)
+}
+
+// healthChecks returns the checks made by the server's /health
+// endpoint.
+func (srv *Server) healthChecks() []healthCheck {
+	checks := []healthCheck{
+		{"mongo", srv.checkMongo},
+		{"mongo-primary", srv.checkMongoPrimary},
+		{"upgrade", srv.checkUpgradeComplete},
+		{"drain", srv.checkNotDraining},
+	}
+	if srv.workersHealthy != nil {
+		checks = append(checks, healthCheck{"workers", srv.workersHealthy})
+	}
+	return checks
+}
+
+func (srv *Server) checkMongo() error {
+	return errors.Annotate(srv.statePool.SystemState().Ping(), "cannot reach mongo")
+}
+
+func (srv *Server) checkMongoPrimary() error {
+	session := srv.statePool.SystemState().MongoSession().Copy()
+	defer session.Close()
+	status, err := replicaset.CurrentStatus(session)
+	if err != nil {
+		return errors.Annotate(err, "cannot get replica set status")
+	}
+	for _, member := range status.Members {
+		if member.State == replicaset.PrimaryState {
+			return nil
+		}
+	}
+	return errors.New("replica set has no primary")
+}
+
+func (srv *Server) checkUpgradeComplete() error {
+	if srv.upgradeComplete != nil && !srv.upgradeComplete() {
+		return errors.New("upgrade in progress")
+	}
+	return nil
+}
+
+func (srv *Server) checkNotDraining() error {
+	if srv.isDraining() {
+		return errors.New("API server is draining")
+	}
+	return nil
+}
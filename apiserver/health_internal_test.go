@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthHandlerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&healthHandlerSuite{})
+
+func (s *healthHandlerSuite) serve(c *gc.C, h healthHandler, method, remoteAddr string) (int, params.HealthResponse) {
+	req, err := http.NewRequest(method, "/health", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var response params.HealthResponse
+	if rec.Code != http.StatusForbidden && rec.Code != http.StatusMethodNotAllowed {
+		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return rec.Code, response
+}
+
+func healthy() error   { return nil }
+func unhealthy() error { return errors.New("boom") }
+
+func (s *healthHandlerSuite) TestReady(c *gc.C) {
+	h := healthHandler{checks: []healthCheck{{"a", healthy}, {"b", healthy}}}
+	code, response := s.serve(c, h, "GET", "10.0.0.1:1234")
+	c.Assert(code, gc.Equals, http.StatusOK)
+	c.Assert(response, jc.DeepEquals, params.HealthResponse{
+		Live:  true,
+		Ready: true,
+		Checks: []params.HealthCheck{
+			{Name: "a", Healthy: true},
+			{Name: "b", Healthy: true},
+		},
+	})
+}
+
+func (s *healthHandlerSuite) TestNotReady(c *gc.C) {
+	h := healthHandler{checks: []healthCheck{{"a", healthy}, {"b", unhealthy}}}
+	code, response := s.serve(c, h, "GET", "10.0.0.1:1234")
+	c.Assert(code, gc.Equals, http.StatusServiceUnavailable)
+	c.Assert(response, jc.DeepEquals, params.HealthResponse{
+		Live:  true,
+		Ready: false,
+		Checks: []params.HealthCheck{
+			{Name: "a", Healthy: true},
+			{Name: "b", Healthy: false, Error: "boom"},
+		},
+	})
+}
+
+func (s *healthHandlerSuite) TestLiveOnly(c *gc.C) {
+	h := healthHandler{
+		checks: []healthCheck{{"a", func() error {
+			c.Fatalf("check made for liveness request")
+			return nil
+		}}},
+		liveOnly: true,
+	}
+	code, response := s.serve(c, h, "GET", "10.0.0.1:1234")
+	c.Assert(code, gc.Equals, http.StatusOK)
+	c.Assert(response, jc.DeepEquals, params.HealthResponse{Live: true, Ready: true})
+}
+
+func (s *healthHandlerSuite) TestSourceDenied(c *gc.C) {
+	h := healthHandler{allowedNets: mustParseCIDRs(c, "10.0.0.0/8")}
+	code, _ := s.serve(c, h, "GET", "192.168.0.1:1234")
+	c.Assert(code, gc.Equals, http.StatusForbidden)
+}
+
+func (s *healthHandlerSuite) TestUnsupportedMethod(c *gc.C) {
+	code, _ := s.serve(c, healthHandler{}, "POST", "10.0.0.1:1234")
+	c.Assert(code, gc.Equals, http.StatusMethodNotAllowed)
+}
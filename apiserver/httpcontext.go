@@ -12,7 +12,9 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery/checkers"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -238,6 +240,9 @@ func (ctxt *httpContext) loginRequest(r *http.Request) (params.LoginRequest, err
 		}, nil
 	}
 	parts := strings.Fields(authHeader)
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		return bearerLoginRequest(r, parts[1])
+	}
 	if len(parts) != 2 || parts[0] != "Basic" {
 		// Invalid header format or no header provided.
 		return params.LoginRequest{}, errors.NotValidf("request format")
@@ -265,6 +270,33 @@ func (ctxt *httpContext) loginRequest(r *http.Request) (params.LoginRequest, err
 	}, nil
 }
 
+// bearerLoginRequest forms a LoginRequest from a bearer token. The
+// token is a base64-encoded JSON macaroon slice, in the same form as
+// the macaroon cookies stored by Juju clients. If the macaroons
+// declare a local user name, the login is made as that user;
+// otherwise it is treated as an external user login. In either case
+// the macaroons are verified by the authenticator.
+func bearerLoginRequest(r *http.Request, token string) (params.LoginRequest, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return params.LoginRequest{}, errors.NotValidf("bearer token")
+	}
+	var ms macaroon.Slice
+	if err := json.Unmarshal(data, &ms); err != nil || len(ms) == 0 {
+		return params.LoginRequest{}, errors.NotValidf("bearer token")
+	}
+	req := params.LoginRequest{
+		Macaroons: append([]macaroon.Slice{ms}, httpbakery.RequestMacaroons(r)...),
+	}
+	if username := checkers.InferDeclared(ms)["username"]; username != "" {
+		if !names.IsValidUser(username) {
+			return params.LoginRequest{}, errors.NotValidf("bearer token")
+		}
+		req.AuthTag = names.NewUserTag(username).String()
+	}
+	return req, nil
+}
+
 // stop returns a channel which will be closed when a handler should
 // exit.
 func (ctxt *httpContext) stop() <-chan struct{} {
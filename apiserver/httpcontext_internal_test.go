@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon-bakery.v1/bakery/checkers"
+	"gopkg.in/macaroon.v1"
+)
+
+type bearerTokenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&bearerTokenSuite{})
+
+func (s *bearerTokenSuite) token(c *gc.C, caveats ...string) string {
+	m, err := macaroon.New([]byte("root-key"), "id", "juju")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, caveat := range caveats {
+		err := m.AddFirstPartyCaveat(caveat)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	data, err := json.Marshal(macaroon.Slice{m})
+	c.Assert(err, jc.ErrorIsNil)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func (s *bearerTokenSuite) request(c *gc.C, token string) *http.Request {
+	req, err := http.NewRequest("GET", "https://localhost/gateway/v1/models", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func (s *bearerTokenSuite) TestLocalUser(c *gc.C) {
+	req := s.request(c, s.token(c, checkers.DeclaredCaveat("username", "bob").Condition))
+	var ctxt httpContext
+	loginReq, err := ctxt.loginRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loginReq.AuthTag, gc.Equals, "user-bob")
+	c.Assert(loginReq.Credentials, gc.Equals, "")
+	c.Assert(loginReq.Macaroons, gc.HasLen, 1)
+}
+
+func (s *bearerTokenSuite) TestExternalUser(c *gc.C) {
+	req := s.request(c, s.token(c))
+	var ctxt httpContext
+	loginReq, err := ctxt.loginRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loginReq.AuthTag, gc.Equals, "")
+	c.Assert(loginReq.Macaroons, gc.HasLen, 1)
+}
+
+func (s *bearerTokenSuite) TestInvalidToken(c *gc.C) {
+	for _, token := range []string{
+		"!!!",
+		base64.StdEncoding.EncodeToString([]byte("not json")),
+		base64.StdEncoding.EncodeToString([]byte("[]")),
+		s.token(c, checkers.DeclaredCaveat("username", "not/valid").Condition),
+	} {
+		req := s.request(c, token)
+		var ctxt httpContext
+		_, err := ctxt.loginRequest(req)
+		c.Check(err, gc.ErrorMatches, "bearer token not valid")
+	}
+}
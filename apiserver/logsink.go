@@ -4,6 +4,7 @@
 package apiserver
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logsampling"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/logdb"
 )
@@ -25,6 +27,14 @@ import (
 const (
 	defaultDBLoggerBufferSize    = 1000
 	defaultDBLoggerFlushInterval = 2 * time.Second
+
+	// samplingRefreshInterval is how often an agent's logsink
+	// session rereads the model's log sampling rules.
+	samplingRefreshInterval = time.Minute
+
+	// samplingLoggerName is the module of the messages recording
+	// that log messages were dropped by sampling.
+	samplingLoggerName = "juju.apiserver.logsink"
 )
 
 type agentLoggingStrategy struct {
@@ -36,6 +46,14 @@ type agentLoggingStrategy struct {
 	version    version.Number
 	entity     names.Tag
 	filePrefix string
+
+	// samplingRules returns the model's current log sampling rules.
+	// The sampler enforces them on the agent's messages, and is
+	// replaced when the rules change.
+	samplingRules     func() ([]logsampling.Rule, error)
+	rules             []logsampling.Rule
+	sampler           *logsampling.Sampler
+	samplingRefreshed time.Time
 }
 
 type recordLogger interface {
@@ -145,6 +163,18 @@ func (s *agentLoggingStrategy) init(ctxt httpContext, req *http.Request) error {
 	s.entity = entity.Tag()
 	s.filePrefix = st.ModelUUID() + ":"
 	s.dblogger = s.dbloggers.get(st)
+	s.samplingRules = func() ([]logsampling.Rule, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cfg, err := model.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return cfg.LogSamplingRules(), nil
+	}
+	s.sampler = logsampling.NewSampler(nil)
 	s.releaser = func() {
 		if removed := releaseState(); removed {
 			s.dbloggers.remove(st)
@@ -165,22 +195,39 @@ func (s *agentLoggingStrategy) Close() error {
 
 // WriteLog is part of the logsink.LogWriteCloser interface.
 func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
-	level, _ := loggo.ParseLevel(m.Level)
-	dbErr := errors.Annotate(s.dblogger.Log([]state.LogRecord{{
-		Time:     m.Time,
-		Entity:   s.entity,
-		Version:  s.version,
-		Module:   m.Module,
-		Location: m.Location,
-		Level:    level,
-		Message:  m.Message,
-	}}), "logging to DB failed")
-
-	m.Entity = s.entity.String()
-	fileErr := errors.Annotate(
-		logToFile(s.fileLogger, s.filePrefix, m),
-		"logging to logsink.log failed",
-	)
+	return s.WriteLogs([]params.LogRecord{m})
+}
+
+// WriteLogs is part of the logsink.LogBatchWriter interface. The
+// records are passed to the DB logger together, so that they are
+// inserted in bulk.
+func (s *agentLoggingStrategy) WriteLogs(records []params.LogRecord) error {
+	records = s.sample(records)
+	if len(records) == 0 {
+		return nil
+	}
+	dbRecords := make([]state.LogRecord, len(records))
+	var fileErr error
+	for i, m := range records {
+		level, _ := loggo.ParseLevel(m.Level)
+		dbRecords[i] = state.LogRecord{
+			Time:     m.Time,
+			Entity:   s.entity,
+			Version:  s.version,
+			Module:   m.Module,
+			Location: m.Location,
+			Level:    level,
+			Message:  m.Message,
+
+			Labels:        m.Labels,
+			CorrelationID: m.CorrelationID,
+		}
+		m.Entity = s.entity.String()
+		if err := logToFile(s.fileLogger, s.filePrefix, m); err != nil && fileErr == nil {
+			fileErr = errors.Annotate(err, "logging to logsink.log failed")
+		}
+	}
+	dbErr := errors.Annotate(s.dblogger.Log(dbRecords), "logging to DB failed")
 	err := dbErr
 	if err == nil {
 		err = fileErr
@@ -190,6 +237,64 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 	return err
 }
 
+// sample returns the records that are allowed by the model's log
+// sampling rules. Where identical records were dropped, a warning
+// recording how many is included.
+func (s *agentLoggingStrategy) sample(records []params.LogRecord) []params.LogRecord {
+	s.refreshSampling()
+	if len(s.rules) == 0 {
+		return records
+	}
+	allowed := make([]params.LogRecord, 0, len(records))
+	for _, m := range records {
+		ok, suppressed := s.sampler.Allow(m.Module, m.Message, m.Time)
+		if suppressed > 0 {
+			allowed = append(allowed, params.LogRecord{
+				Time:    m.Time,
+				Module:  samplingLoggerName,
+				Level:   loggo.WARNING.String(),
+				Message: fmt.Sprintf("%d identical %s log messages dropped by log sampling: %s", suppressed, m.Module, m.Message),
+			})
+		}
+		if ok {
+			allowed = append(allowed, m)
+		}
+	}
+	return allowed
+}
+
+// refreshSampling rereads the model's log sampling rules if they
+// haven't been read recently, and replaces the sampler if they have
+// changed.
+func (s *agentLoggingStrategy) refreshSampling() {
+	now := s.dbloggers.clock.Now()
+	if !s.samplingRefreshed.IsZero() && now.Sub(s.samplingRefreshed) < samplingRefreshInterval {
+		return
+	}
+	s.samplingRefreshed = now
+	rules, err := s.samplingRules()
+	if err != nil {
+		logger.Warningf("cannot read log sampling rules: %v", err)
+		return
+	}
+	if !sameSamplingRules(rules, s.rules) {
+		s.rules = rules
+		s.sampler = logsampling.NewSampler(rules)
+	}
+}
+
+func sameSamplingRules(a, b []logsampling.Rule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // logToFile writes a single log message to the logsink log file.
 func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
 	_, err := writer.Write([]byte(strings.Join([]string{
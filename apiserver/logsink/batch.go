@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+)
+
+// LogBatchWriter is an optional interface implemented by
+// LogWriteClosers that can persist many log records at once. Batches
+// of log records received from version 2 clients are written with
+// WriteLogs where it is available, and record by record otherwise.
+type LogBatchWriter interface {
+	// WriteLogs writes out the given log records.
+	WriteLogs([]params.LogRecord) error
+}
+
+// logBatch holds a batch of log records received from a client, or
+// the error encountered decoding them.
+type logBatch struct {
+	seq     uint64
+	records []params.LogRecord
+	err     error
+}
+
+// decodeLogRecordBatch returns the log records held in the batch,
+// decompressing them if necessary.
+func decodeLogRecordBatch(batch params.LogRecordBatch) ([]params.LogRecord, error) {
+	if len(batch.Compressed) == 0 {
+		return batch.Records, nil
+	}
+	if len(batch.Records) > 0 {
+		return nil, errors.New("batch has both compressed and uncompressed records")
+	}
+	r, err := gzip.NewReader(bytes.NewReader(batch.Compressed))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decompress log records")
+	}
+	defer r.Close()
+	var records []params.LogRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, errors.Annotate(err, "cannot decode log records")
+	}
+	return records, nil
+}
+
+// writeLogs writes the log records with a single call to the writer,
+// if it supports that.
+func writeLogs(writer LogWriteCloser, records []params.LogRecord) error {
+	if batchWriter, ok := writer.(LogBatchWriter); ok {
+		return batchWriter.WriteLogs(records)
+	}
+	for _, record := range records {
+		if err := writer.WriteLog(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendAck acknowledges a batch of log records, reporting the error
+// encountered storing them, if any.
+func sendAck(socket *websocket.Conn, seq uint64, err error) error {
+	ack := params.LogRecordBatchAck{Seq: seq}
+	if err != nil {
+		ack.Error = &params.Error{Message: err.Error()}
+	}
+	return socket.WriteJSON(&ack)
+}
+
+// receiveBatches reads batches of log records sent by version 2
+// clients. The batches are rate-limited by their number of records.
+func (h *logSinkHandler) receiveBatches(socket *websocket.Conn) <-chan logBatch {
+	batchCh := make(chan logBatch)
+
+	var tokenBucket *ratelimit.Bucket
+	if h.ratelimit != nil {
+		tokenBucket = ratelimit.NewBucketWithClock(
+			h.ratelimit.Refill,
+			h.ratelimit.Burst,
+			ratelimitClock{h.ratelimit.Clock},
+		)
+	}
+
+	go func() {
+		// See receiveLogs for why the channel is closed.
+		defer close(batchCh)
+		for {
+			var batch params.LogRecordBatch
+			if err := socket.ReadJSON(&batch); err != nil {
+				if gorillaws.IsUnexpectedCloseError(err, gorillaws.CloseNormalClosure, gorillaws.CloseGoingAway) {
+					logger.Debugf("logsink receive error: %v", err)
+				} else {
+					logger.Debugf("disconnected, %p", socket)
+				}
+				socket.WriteMessage(gorillaws.CloseMessage, []byte{})
+				return
+			}
+			records, err := decodeLogRecordBatch(batch)
+			if tokenBucket != nil && len(records) > 0 {
+				if d := tokenBucket.Take(int64(len(records))); d > 0 {
+					select {
+					case <-h.ratelimit.Clock.After(d):
+					case <-h.abort:
+						return
+					}
+				}
+			}
+			select {
+			case <-h.abort:
+				return
+			case batchCh <- logBatch{seq: batch.Seq, records: records, err: err}:
+			}
+		}
+	}()
+
+	return batchCh
+}
@@ -137,7 +137,17 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			socket.SetReadDeadline(time.Now().Add(vZeroDelay))
 		}
 
-		logCh := h.receiveLogs(socket, endpointVersion)
+		// Version 2 clients send batches of log records, and expect
+		// each batch to be acknowledged once it has been written.
+		var (
+			logCh   <-chan params.LogRecord
+			batchCh <-chan logBatch
+		)
+		if endpointVersion >= 2 {
+			batchCh = h.receiveBatches(socket)
+		} else {
+			logCh = h.receiveLogs(socket, endpointVersion)
+		}
 		for {
 			select {
 			case <-h.abort:
@@ -160,6 +170,21 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 					h.sendError(socket, req, err)
 					return
 				}
+			case b, ok := <-batchCh:
+				if !ok {
+					return
+				}
+				err := b.err
+				if err == nil {
+					err = writeLogs(writer, b.records)
+				}
+				if ackErr := sendAck(socket, b.seq, err); ackErr != nil {
+					logger.Debugf("failed to acknowledge log records: %v", ackErr)
+					return
+				}
+				if err != nil {
+					return
+				}
 			}
 		}
 	}
@@ -173,6 +198,8 @@ func (h *logSinkHandler) getVersion(req *http.Request) (int, error) {
 		return 0, nil
 	case "1":
 		return 1, nil
+	case "2":
+		return 2, nil
 	default:
 		return 0, errors.Errorf("unknown version %q", verStr)
 	}
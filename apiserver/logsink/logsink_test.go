@@ -4,6 +4,9 @@
 package logsink_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -71,9 +74,16 @@ func (s *logsinkSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *logsinkSuite) dialWebsocket(c *gc.C) *websocket.Conn {
+	return s.dialWebsocketVersion(c, "")
+}
+
+func (s *logsinkSuite) dialWebsocketVersion(c *gc.C, version string) *websocket.Conn {
 	u, err := url.Parse(s.srv.URL)
 	c.Assert(err, jc.ErrorIsNil)
 	u.Scheme = "ws"
+	if version != "" {
+		u.RawQuery = url.Values{"version": {version}}.Encode()
+	}
 	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AddCleanup(func(*gc.C) { conn.Close() })
@@ -233,6 +243,114 @@ func (s *logsinkSuite) TestRateLimit(c *gc.C) {
 	expectNoRecord()
 }
 
+func (s *logsinkSuite) assertWritten(c *gc.C, expect params.LogRecord) {
+	select {
+	case written, ok := <-s.written:
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(written, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for log record to be written")
+	}
+}
+
+func (s *logsinkSuite) assertAck(c *gc.C, conn *websocket.Conn, expect params.LogRecordBatchAck) {
+	var ack params.LogRecordBatchAck
+	err := conn.ReadJSON(&ack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ack, jc.DeepEquals, expect)
+}
+
+func (s *logsinkSuite) TestUnknownVersion(c *gc.C) {
+	conn := s.dialWebsocketVersion(c, "3")
+	websockettest.AssertJSONError(c, conn, `unknown version "3"`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *logsinkSuite) TestBatch(c *gc.C) {
+	conn := s.dialWebsocketVersion(c, "2")
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	records := []params.LogRecord{{
+		Time:    time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:  "some.where",
+		Level:   loggo.INFO.String(),
+		Message: "all is well",
+	}, {
+		Time:    time.Date(2015, time.June, 1, 23, 2, 2, 0, time.UTC),
+		Module:  "some.where",
+		Level:   loggo.WARNING.String(),
+		Message: "all is not well",
+	}}
+	err := conn.WriteJSON(&params.LogRecordBatch{Seq: 1, Records: records})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertWritten(c, records[0])
+	s.assertWritten(c, records[1])
+	s.assertAck(c, conn, params.LogRecordBatchAck{Seq: 1})
+	s.stub.CheckCallNames(c, "Open", "WriteLog", "WriteLog")
+}
+
+func (s *logsinkSuite) TestBatchCompressed(c *gc.C) {
+	conn := s.dialWebsocketVersion(c, "2")
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{
+		Time:    time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:  "some.where",
+		Level:   loggo.INFO.String(),
+		Message: "all is well",
+	}
+	data, err := json.Marshal([]params.LogRecord{record})
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zw.Close(), jc.ErrorIsNil)
+
+	err = conn.WriteJSON(&params.LogRecordBatch{Seq: 7, Compressed: buf.Bytes()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertWritten(c, record)
+	s.assertAck(c, conn, params.LogRecordBatchAck{Seq: 7})
+}
+
+func (s *logsinkSuite) TestBatchInvalidCompressed(c *gc.C) {
+	conn := s.dialWebsocketVersion(c, "2")
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	err := conn.WriteJSON(&params.LogRecordBatch{Seq: 1, Compressed: []byte("junk!")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ack params.LogRecordBatchAck
+	err = conn.ReadJSON(&ack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ack.Seq, gc.Equals, uint64(1))
+	c.Assert(ack.Error, gc.ErrorMatches, "cannot decompress log records: .*")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *logsinkSuite) TestBatchWriteFails(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannae write"))
+	conn := s.dialWebsocketVersion(c, "2")
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{Message: "all is well"}
+	err := conn.WriteJSON(&params.LogRecordBatch{
+		Seq:     1,
+		Records: []params.LogRecord{record, record},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertWritten(c, record)
+	s.assertAck(c, conn, params.LogRecordBatchAck{
+		Seq:   1,
+		Error: &params.Error{Message: "cannae write"},
+	})
+	websockettest.AssertWebsocketClosed(c, conn)
+	s.stub.CheckCallNames(c, "Open", "WriteLog", "Close")
+}
+
 type mockLogWriteCloser struct {
 	*testing.Stub
 	written chan<- params.LogRecord
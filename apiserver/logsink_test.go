@@ -125,6 +125,9 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 		Location: "bar.go:99",
 		Level:    loggo.ERROR.String(),
 		Message:  "oh noes",
+
+		Labels:        []string{"charm"},
+		CorrelationID: "abcd",
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -160,6 +163,8 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:99")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["c"], jc.DeepEquals, []interface{}{"charm"})
+	c.Assert(docs[1]["i"], gc.Equals, "abcd")
 
 	// Close connection.
 	err = conn.Close()
@@ -195,6 +200,56 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	}
 }
 
+func (s *logsinkSuite) TestLogSampling(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"logging-config": "<root>=INFO;unit=sample(2/1m)",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn := s.dialWebsocket(c)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	t0 := time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC)
+	send := func(t time.Time) {
+		err := conn.WriteJSON(&params.LogRecord{
+			Time:     t,
+			Module:   "unit.foo/0.install",
+			Location: "foo.go:42",
+			Level:    loggo.INFO.String(),
+			Message:  "looping",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for i := 0; i < 5; i++ {
+		send(t0)
+	}
+	t1 := t0.Add(time.Minute)
+	send(t1)
+
+	// Two messages are kept, the next three are dropped and
+	// reported once the next interval starts.
+	logsColl := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	var docs []bson.M
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := logsColl.Find(nil).Sort("t", "_id").All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(docs) >= 4 {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for log writes")
+		}
+	}
+	c.Assert(docs, gc.HasLen, 4)
+	c.Assert(docs[0]["x"], gc.Equals, "looping")
+	c.Assert(docs[1]["x"], gc.Equals, "looping")
+	c.Assert(docs[2]["m"], gc.Equals, "juju.apiserver.logsink")
+	c.Assert(docs[2]["v"], gc.Equals, int(loggo.WARNING))
+	c.Assert(docs[2]["x"], gc.Equals, "3 identical unit.foo/0.install log messages dropped by log sampling: looping")
+	c.Assert(docs[3]["t"], gc.Equals, t1.UnixNano())
+}
+
 func (s *logsinkSuite) TestReceiveErrorBreaksConn(c *gc.C) {
 	conn := s.dialWebsocket(c)
 	defer conn.Close()
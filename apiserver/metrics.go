@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// metricsHandler is an http.Handler that serves the controller's
+// Prometheus metrics. Requests must come from one of the allowed
+// networks, if any are specified, and are then authenticated in the
+// same way as the introspection endpoints.
+type metricsHandler struct {
+	ctxt        httpContext
+	handler     http.Handler
+	allowedNets []*net.IPNet
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := checkRequestSource(h.allowedNets, r.RemoteAddr); err != nil {
+		if err := sendError(w, err); err != nil {
+			logger.Debugf("%v", err)
+		}
+		return
+	}
+	introspectionHandler{h.ctxt, h.handler}.ServeHTTP(w, r)
+}
+
+// checkRequestSource returns an error if the remote address
+// is not within one of the allowed networks. If no networks
+// are specified, all addresses are allowed.
+func checkRequestSource(allowedNets []*net.IPNet, remoteAddr string) error {
+	if len(allowedNets) == 0 {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, ipNet := range allowedNets {
+			if ipNet.Contains(ip) {
+				return nil
+			}
+		}
+	}
+	logger.Debugf("rejecting request from %q", remoteAddr)
+	return &params.Error{
+		Code:    params.CodeForbidden,
+		Message: "access denied",
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type metricsSourceSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&metricsSourceSuite{})
+
+func mustParseCIDRs(c *gc.C, cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		c.Assert(err, jc.ErrorIsNil)
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+func (s *metricsSourceSuite) TestNoRestriction(c *gc.C) {
+	err := checkRequestSource(nil, "203.0.113.1:1234")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsSourceSuite) TestAllowed(c *gc.C) {
+	nets := mustParseCIDRs(c, "10.0.0.0/8", "2001:db8::/32")
+	c.Assert(checkRequestSource(nets, "10.1.2.3:1234"), jc.ErrorIsNil)
+	c.Assert(checkRequestSource(nets, "[2001:db8::1]:1234"), jc.ErrorIsNil)
+}
+
+func (s *metricsSourceSuite) TestDenied(c *gc.C) {
+	nets := mustParseCIDRs(c, "10.0.0.0/8")
+	for _, addr := range []string{"192.168.0.1:1234", "garbage"} {
+		err := checkRequestSource(nets, addr)
+		c.Assert(err, gc.ErrorMatches, "access denied")
+		c.Assert(err.(*params.Error).Code, gc.Equals, params.CodeForbidden)
+	}
+}
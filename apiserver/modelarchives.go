@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// modelArchiveHandler serves the signed model archives held by the
+// controller, which are created and listed with the ModelArchives
+// facade. A GET request to /model-archives/:id returns the content of
+// the archive. It requires superuser access to the controller.
+type modelArchiveHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *modelArchiveHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "GET":
+		err = h.handleGet(w, req)
+	default:
+		err = emitUnsupportedMethodErr(req.Method)
+	}
+	if err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *modelArchiveHandler) handleGet(w http.ResponseWriter, req *http.Request) error {
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		entity.Tag(),
+		permission.SuperuserAccess,
+		st.ControllerTag(),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.Trace(common.ErrPerm)
+	}
+
+	id := req.URL.Query().Get(":id")
+	archive, r, err := st.OpenModelArchive(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	w.Header().Set("Content-Type", params.ContentTypeRaw)
+	w.Header().Set("Content-Length", fmt.Sprint(archive.Size))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.ID+".tar.gz"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, r); err != nil {
+		// The status has been sent, so the error can only be logged.
+		logger.Errorf("cannot stream model archive %q: %v", id, err)
+	}
+	return nil
+}
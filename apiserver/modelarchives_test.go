@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing/factory"
+)
+
+type modelArchivesSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&modelArchivesSuite{})
+
+func (s *modelArchivesSuite) archiveURL(c *gc.C, id string) string {
+	uri := s.baseURL(c)
+	uri.Path = "/model-archives/" + id
+	return uri.String()
+}
+
+func (s *modelArchivesSuite) assertError(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *modelArchivesSuite) TestRequiresAuthentication(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.archiveURL(c, "id")})
+	s.assertError(c, resp, http.StatusUnauthorized, ".*no credentials provided$")
+}
+
+func (s *modelArchivesSuite) TestRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.archiveURL(c, "id"),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *modelArchivesSuite) TestGet(c *gc.C) {
+	content := "archived model"
+	archive, err := s.State.AddModelArchive(s.State.ModelUUID(), "controller", strings.NewReader(content), int64(len(content)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.archiveURL(c, archive.ID)})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeRaw)
+	c.Check(string(body), gc.Equals, content)
+}
+
+func (s *modelArchivesSuite) TestGetNotFound(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.archiveURL(c, "missing")})
+	s.assertError(c, resp, http.StatusNotFound, `model archive "missing" not found`)
+}
+
+func (s *modelArchivesSuite) TestUnsupportedMethod(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.archiveURL(c, "id")})
+	s.assertError(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
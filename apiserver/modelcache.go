@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/featureflag"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/apiserver/modelcache"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+)
+
+// modelCacheIdleTimeout is the length of time after which a model
+// that has not been read through the model cache stops being cached.
+const modelCacheIdleTimeout = 10 * time.Minute
+
+// newModelCache returns a model cache that watches models obtained
+// from the given state pool, or nil if the model cache feature is not
+// enabled. If registerer is non-nil, the cache's metrics are
+// registered with it.
+func newModelCache(pool *state.StatePool, clk clock.Clock, registerer prometheus.Registerer) (*modelcache.Cache, error) {
+	if !featureflag.Enabled(feature.ModelCache) {
+		return nil, nil
+	}
+	cache, err := modelcache.New(modelcache.Config{
+		NewSource: func(modelUUID string) (modelcache.Source, func(), error) {
+			st, releaser, err := pool.Get(modelUUID)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			return st.Watch(state.WatchParams{}), func() { releaser() }, nil
+		},
+		Clock:       clk,
+		IdleTimeout: modelCacheIdleTimeout,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if registerer != nil {
+		registerer.Unregister(cache)
+		if err := registerer.Register(cache); err != nil {
+			cache.Kill()
+			return nil, errors.Annotate(err, "registering model cache metrics collector")
+		}
+	}
+	return cache, nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcache provides an in-memory cache of the entities in
+// the controller's models, kept up to date from AllWatcher deltas, so
+// that read-heavy API facades can be served without querying mongo.
+//
+// The cache is eventually consistent: a model's entities reflect the
+// deltas received so far. Facades that need to read their own writes
+// should invalidate the model after writing.
+package modelcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelcache")
+
+// ResourceName is the name under which the API server registers the
+// Cache as a named resource for each API connection.
+const ResourceName = "modelCache"
+
+// Source is the source of deltas for a cached model. It is satisfied
+// by *state.Multiwatcher.
+type Source interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// Config holds the configuration for a Cache.
+type Config struct {
+	// NewSource returns a Source for the model with the given UUID,
+	// and a function to call when the source is no longer needed.
+	NewSource func(modelUUID string) (Source, func(), error)
+
+	// Clock is used to determine when models are idle.
+	Clock clock.Clock
+
+	// IdleTimeout is the length of time after which a model that has
+	// not been read is removed from the cache, and its source stopped.
+	IdleTimeout time.Duration
+}
+
+// Validate returns an error if the configuration is not valid.
+func (config Config) Validate() error {
+	if config.NewSource == nil {
+		return errors.NotValidf("nil NewSource")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.IdleTimeout <= 0 {
+		return errors.NotValidf("non-positive IdleTimeout")
+	}
+	return nil
+}
+
+// Cache holds the cached models. Models are loaded on demand, when
+// they are first read, and are removed when they have been idle for
+// the configured timeout.
+type Cache struct {
+	tomb   tomb.Tomb
+	config Config
+
+	mu     sync.Mutex
+	models map[string]*Model
+
+	// hits, misses and invalidations count the reads served from the
+	// cache, the reads that were not, and the models invalidated. They
+	// must be accessed atomically.
+	hits          int64
+	misses        int64
+	invalidations int64
+}
+
+// New returns a new Cache with the given configuration. The cache
+// runs until it is killed.
+func New(config Config) (*Cache, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &Cache{
+		config: config,
+		models: make(map[string]*Model),
+	}
+	go func() {
+		defer c.tomb.Done()
+		c.tomb.Kill(c.loop())
+	}()
+	return c, nil
+}
+
+// FromResources returns the Cache registered in the given facade
+// resources, or nil if there is none. The methods of a nil Cache
+// report that no model is cached.
+func FromResources(resources facade.Resources) *Cache {
+	if resources == nil {
+		return nil
+	}
+	cache, _ := resources.Get(ResourceName).(*Cache)
+	return cache
+}
+
+// Stop is part of the facade.Resource interface. It does nothing,
+// because the Cache outlives the API connections that share it.
+func (c *Cache) Stop() error {
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (c *Cache) Kill() {
+	c.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (c *Cache) Wait() error {
+	return c.tomb.Wait()
+}
+
+func (c *Cache) loop() error {
+	defer c.removeAll()
+	for {
+		select {
+		case <-c.tomb.Dying():
+			return tomb.ErrDying
+		case <-c.config.Clock.After(c.config.IdleTimeout):
+			c.removeIdle()
+		}
+	}
+}
+
+// Model returns the cached model with the given UUID, if it has been
+// loaded. If it has not, Model starts loading it and returns false,
+// and the caller should read from the database instead.
+func (c *Cache) Model(modelUUID string) (*Model, bool) {
+	if c == nil {
+		return nil, false
+	}
+	model := c.model(modelUUID)
+	if model == nil || !model.loaded() {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddInt64(&c.hits, 1)
+	return model, true
+}
+
+// model returns the model with the given UUID, starting to load it
+// if necessary. It returns nil if the model cannot be loaded.
+func (c *Cache) model(modelUUID string) *Model {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.tomb.Dying():
+		return nil
+	default:
+	}
+	model, ok := c.models[modelUUID]
+	if !ok {
+		source, done, err := c.config.NewSource(modelUUID)
+		if err != nil {
+			logger.Warningf("cannot watch model %s: %v", modelUUID, err)
+			return nil
+		}
+		model = newModel(modelUUID, source)
+		c.models[modelUUID] = model
+		go model.run(func(err error) {
+			logger.Debugf("model %s no longer cached: %v", modelUUID, err)
+			c.forget(modelUUID, model)
+			done()
+		})
+	}
+	model.touch(c.config.Clock.Now())
+	return model
+}
+
+// Invalidate removes the model with the given UUID from the cache,
+// so that it is reloaded when next read. Facades should invalidate a
+// model after changing it if subsequent reads must observe the change.
+func (c *Cache) Invalidate(modelUUID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	model, ok := c.models[modelUUID]
+	delete(c.models, modelUUID)
+	c.mu.Unlock()
+	if ok {
+		atomic.AddInt64(&c.invalidations, 1)
+		model.stop()
+	}
+}
+
+// forget removes the given model from the cache, if it is still
+// cached.
+func (c *Cache) forget(modelUUID string, model *Model) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.models[modelUUID] == model {
+		delete(c.models, modelUUID)
+	}
+}
+
+// removeIdle removes the models that have not been read within the
+// idle timeout.
+func (c *Cache) removeIdle() {
+	cutoff := c.config.Clock.Now().Add(-c.config.IdleTimeout)
+	var idle []*Model
+	c.mu.Lock()
+	for modelUUID, model := range c.models {
+		if model.lastUsed().Before(cutoff) {
+			delete(c.models, modelUUID)
+			idle = append(idle, model)
+		}
+	}
+	c.mu.Unlock()
+	for _, model := range idle {
+		model.stop()
+	}
+}
+
+// removeAll removes all models from the cache.
+func (c *Cache) removeAll() {
+	c.mu.Lock()
+	models := c.models
+	c.models = make(map[string]*Model)
+	c.mu.Unlock()
+	for _, model := range models {
+		model.stop()
+	}
+}
+
+// modelCount returns the number of models in the cache.
+func (c *Cache) modelCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.models)
+}
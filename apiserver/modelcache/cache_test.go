@@ -0,0 +1,255 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcache_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/modelcache"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type cacheSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	mu       sync.Mutex
+	sources  []*fakeSource
+	released chan string
+}
+
+var _ = gc.Suite(&cacheSuite{})
+
+func (s *cacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.sources = nil
+	s.released = make(chan string, 10)
+}
+
+func (s *cacheSuite) newCache(c *gc.C) *modelcache.Cache {
+	cache, err := modelcache.New(modelcache.Config{
+		NewSource: func(uuid string) (modelcache.Source, func(), error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			source := newFakeSource()
+			s.sources = append(s.sources, source)
+			return source, func() { s.released <- uuid }, nil
+		},
+		Clock:       s.clock,
+		IdleTimeout: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		cache.Kill()
+		c.Check(cache.Wait(), jc.ErrorIsNil)
+	})
+	return cache
+}
+
+func (s *cacheSuite) source(c *gc.C, i int) *fakeSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.Assert(len(s.sources), jc.GreaterThan, i)
+	return s.sources[i]
+}
+
+func (s *cacheSuite) sourceCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sources)
+}
+
+// waitLoaded reads the model until it has been loaded.
+func (s *cacheSuite) waitLoaded(c *gc.C, cache *modelcache.Cache) *modelcache.Model {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if model, ok := cache.Model(modelUUID); ok {
+			return model
+		}
+	}
+	c.Fatalf("timed out waiting for model to load")
+	return nil
+}
+
+func (s *cacheSuite) waitReleased(c *gc.C) {
+	select {
+	case uuid := <-s.released:
+		c.Assert(uuid, gc.Equals, modelUUID)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for source to be released")
+	}
+}
+
+func (s *cacheSuite) TestValidateConfig(c *gc.C) {
+	_, err := modelcache.New(modelcache.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil NewSource not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *cacheSuite) TestNilCache(c *gc.C) {
+	var cache *modelcache.Cache
+	_, ok := cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	cache.Invalidate(modelUUID)
+}
+
+func (s *cacheSuite) TestFromResources(c *gc.C) {
+	cache := s.newCache(c)
+	resources := common.NewResources()
+	c.Assert(modelcache.FromResources(resources), gc.IsNil)
+	err := resources.RegisterNamed(modelcache.ResourceName, cache)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelcache.FromResources(resources), gc.Equals, cache)
+}
+
+func (s *cacheSuite) TestModelNotLoaded(c *gc.C) {
+	cache := s.newCache(c)
+	_, ok := cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(s.sourceCount(), gc.Equals, 1)
+
+	s.source(c, 0).send(c)
+	model := s.waitLoaded(c, cache)
+	c.Assert(model.UUID(), gc.Equals, modelUUID)
+	c.Assert(s.sourceCount(), gc.Equals, 1)
+}
+
+func (s *cacheSuite) TestEntities(c *gc.C) {
+	cache := s.newCache(c)
+	cache.Model(modelUUID)
+	s.source(c, 0).send(c,
+		multiwatcher.Delta{Entity: &multiwatcher.ModelInfo{ModelUUID: modelUUID, Name: "foo"}},
+		multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{ModelUUID: modelUUID, Name: "mysql"}},
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0", PublicAddress: "10.0.0.1"}},
+		multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "0"}},
+	)
+	model := s.waitLoaded(c, cache)
+
+	info, ok := model.Info()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info.Name, gc.Equals, "foo")
+	app, ok := model.Application("mysql")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(app.Name, gc.Equals, "mysql")
+	unit, ok := model.Unit("mysql/0")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(unit.PublicAddress, gc.Equals, "10.0.0.1")
+	_, ok = model.Machine("0")
+	c.Assert(ok, jc.IsTrue)
+	_, ok = model.Unit("mysql/1")
+	c.Assert(ok, jc.IsFalse)
+
+	s.source(c, 0).send(c,
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0", PublicAddress: "10.0.0.2"}},
+		multiwatcher.Delta{Removed: true, Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "0"}},
+	)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if _, ok := model.Machine("0"); !ok {
+			break
+		}
+	}
+	_, ok = model.Machine("0")
+	c.Assert(ok, jc.IsFalse)
+	unit, _ = model.Unit("mysql/0")
+	c.Assert(unit.PublicAddress, gc.Equals, "10.0.0.2")
+}
+
+func (s *cacheSuite) TestInvalidate(c *gc.C) {
+	cache := s.newCache(c)
+	cache.Model(modelUUID)
+	s.source(c, 0).send(c)
+	s.waitLoaded(c, cache)
+
+	cache.Invalidate(modelUUID)
+	s.waitReleased(c)
+	_, ok := cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(s.sourceCount(), gc.Equals, 2)
+}
+
+func (s *cacheSuite) TestSourceError(c *gc.C) {
+	cache := s.newCache(c)
+	cache.Model(modelUUID)
+	s.source(c, 0).Stop()
+	s.waitReleased(c)
+	_, ok := cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(s.sourceCount(), gc.Equals, 2)
+}
+
+func (s *cacheSuite) TestIdleModelRemoved(c *gc.C) {
+	cache := s.newCache(c)
+	cache.Model(modelUUID)
+	s.source(c, 0).send(c)
+	s.waitLoaded(c, cache)
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.released:
+		c.Fatalf("model removed before idle timeout")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitReleased(c)
+}
+
+func (s *cacheSuite) TestKillStopsSources(c *gc.C) {
+	cache := s.newCache(c)
+	cache.Model(modelUUID)
+	cache.Kill()
+	c.Assert(cache.Wait(), jc.ErrorIsNil)
+	s.waitReleased(c)
+	_, ok := cache.Model(modelUUID)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(s.sourceCount(), gc.Equals, 1)
+}
+
+type fakeSource struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		deltas:  make(chan []multiwatcher.Delta),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (s *fakeSource) send(c *gc.C, deltas ...multiwatcher.Delta) {
+	select {
+	case s.deltas <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending deltas")
+	}
+}
+
+func (s *fakeSource) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-s.deltas:
+		return deltas, nil
+	case <-s.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (s *fakeSource) Stop() error {
+	s.once.Do(func() { close(s.stopped) })
+	return nil
+}
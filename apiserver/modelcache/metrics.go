@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcache
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "juju_modelcache"
+
+var (
+	hitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "hits_total"),
+		"Total number of reads served from the model cache",
+		nil, nil,
+	)
+	missesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "misses_total"),
+		"Total number of reads of models not loaded in the model cache",
+		nil, nil,
+	)
+	invalidationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "invalidations_total"),
+		"Total number of models invalidated in the model cache",
+		nil, nil,
+	)
+	modelsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "models"),
+		"Current number of models in the model cache",
+		nil, nil,
+	)
+)
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Cache) Describe(ch chan<- *prometheus.Desc) {
+	ch <- hitsDesc
+	ch <- missesDesc
+	ch <- invalidationsDesc
+	ch <- modelsDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Cache) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		hitsDesc,
+		prometheus.CounterValue,
+		float64(atomic.LoadInt64(&c.hits)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		missesDesc,
+		prometheus.CounterValue,
+		float64(atomic.LoadInt64(&c.misses)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		invalidationsDesc,
+		prometheus.CounterValue,
+		float64(atomic.LoadInt64(&c.invalidations)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		modelsDesc,
+		prometheus.GaugeValue,
+		float64(c.modelCount()),
+	)
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// Model holds the cached entities of a single model. The values
+// returned by its methods must not be modified.
+type Model struct {
+	uuid   string
+	source Source
+
+	mu           sync.RWMutex
+	used         time.Time
+	received     bool
+	info         *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+// newModel returns a new Model, whose entities are loaded from the
+// given source.
+func newModel(uuid string, source Source) *Model {
+	return &Model{
+		uuid:         uuid,
+		source:       source,
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// run applies the deltas from the model's source until the source
+// fails or is stopped, when it calls the done function.
+func (m *Model) run(done func(error)) {
+	for {
+		deltas, err := m.source.Next()
+		if err != nil {
+			done(err)
+			return
+		}
+		m.apply(deltas)
+	}
+}
+
+// apply updates the model's entities with the given deltas.
+func (m *Model) apply(deltas []multiwatcher.Delta) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.received = true
+	for _, delta := range deltas {
+		switch entity := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if delta.Removed {
+				m.info = nil
+			} else {
+				m.info = entity
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(m.applications, entity.Name)
+			} else {
+				m.applications[entity.Name] = entity
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.units, entity.Name)
+			} else {
+				m.units[entity.Name] = entity
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(m.machines, entity.Id)
+			} else {
+				m.machines[entity.Id] = entity
+			}
+		}
+	}
+}
+
+// stop stops the model's source.
+func (m *Model) stop() {
+	if err := m.source.Stop(); err != nil {
+		logger.Warningf("stopping watcher for model %s: %v", m.uuid, err)
+	}
+}
+
+// loaded reports whether the model's entities have been loaded.
+func (m *Model) loaded() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.received
+}
+
+// touch records that the model was read at the given time.
+func (m *Model) touch(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.used = now
+}
+
+// lastUsed returns the time at which the model was last read.
+func (m *Model) lastUsed() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.used
+}
+
+// UUID returns the model's UUID.
+func (m *Model) UUID() string {
+	return m.uuid
+}
+
+// Info returns the model's details, including its config,
+// constraints and status.
+func (m *Model) Info() (multiwatcher.ModelInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.info == nil {
+		return multiwatcher.ModelInfo{}, false
+	}
+	return *m.info, true
+}
+
+// Application returns the details of the named application.
+func (m *Model) Application(name string) (multiwatcher.ApplicationInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	app, ok := m.applications[name]
+	if !ok {
+		return multiwatcher.ApplicationInfo{}, false
+	}
+	return *app, true
+}
+
+// Unit returns the details of the named unit.
+func (m *Model) Unit(name string) (multiwatcher.UnitInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	unit, ok := m.units[name]
+	if !ok {
+		return multiwatcher.UnitInfo{}, false
+	}
+	return *unit, true
+}
+
+// Machine returns the details of the machine with the given id.
+func (m *Model) Machine(id string) (multiwatcher.MachineInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	machine, ok := m.machines[id]
+	if !ok {
+		return multiwatcher.MachineInfo{}, false
+	}
+	return *machine, true
+}
@@ -143,3 +143,137 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionSchedules holds the arguments for adding action schedules.
+type AddActionSchedules struct {
+	Schedules []AddActionSchedule `json:"schedules"`
+}
+
+// AddActionSchedule describes an action to be enqueued on a unit at
+// regular intervals.
+type AddActionSchedule struct {
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Interval   time.Duration          `json:"interval"`
+}
+
+// ActionSchedule describes a scheduled action.
+type ActionSchedule struct {
+	Id           string                 `json:"id"`
+	Receiver     string                 `json:"receiver"`
+	Name         string                 `json:"name"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Interval     time.Duration          `json:"interval"`
+	NextRun      time.Time              `json:"next-run"`
+	LastActionId string                 `json:"last-action-id,omitempty"`
+}
+
+// ActionScheduleResult holds a scheduled action or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of a bulk schedule call.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionSchedules holds a list of scheduled actions.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// DispatchActionSchedulesResult holds the time at which the next
+// scheduled action is due, which is zero if there are none.
+type DispatchActionSchedulesResult struct {
+	NextRun time.Time `json:"next-run"`
+}
+
+// ActionArtifactArgs holds the arguments for storing action
+// artifacts.
+type ActionArtifactArgs struct {
+	Artifacts []ActionArtifactArg `json:"artifacts"`
+}
+
+// ActionArtifactArg holds a file returned by a running action.
+type ActionArtifactArg struct {
+	ActionTag string `json:"action-tag"`
+	Name      string `json:"name"`
+	Content   []byte `json:"content"`
+}
+
+// ActionArtifact describes a file returned by an action.
+type ActionArtifact struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"`
+	Created time.Time `json:"created"`
+}
+
+// ActionArtifactsResult holds the artifacts of an action, or an
+// error.
+type ActionArtifactsResult struct {
+	Artifacts []ActionArtifact `json:"artifacts,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// ActionArtifactsResults holds the results of a bulk artifacts call.
+type ActionArtifactsResults struct {
+	Results []ActionArtifactsResult `json:"results"`
+}
+
+// ActionArtifactRef identifies an artifact of an action.
+type ActionArtifactRef struct {
+	ActionTag string `json:"action-tag"`
+	Name      string `json:"name"`
+}
+
+// ActionArtifactRefs holds references to action artifacts.
+type ActionArtifactRefs struct {
+	Artifacts []ActionArtifactRef `json:"artifacts"`
+}
+
+// ActionArtifactContentResult holds the content of an action
+// artifact, or an error.
+type ActionArtifactContentResult struct {
+	Artifact *ActionArtifact `json:"artifact,omitempty"`
+	Content  []byte          `json:"content,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionArtifactContentResults holds the results of a bulk artifact
+// content call.
+type ActionArtifactContentResults struct {
+	Results []ActionArtifactContentResult `json:"results"`
+}
+
+// ActionQuery holds the criteria used to find actions. Actions must
+// match all of the criteria that are set.
+type ActionQuery struct {
+	Application    string    `json:"application,omitempty"`
+	Statuses       []string  `json:"statuses,omitempty"`
+	EnqueuedAfter  time.Time `json:"enqueued-after,omitempty"`
+	EnqueuedBefore time.Time `json:"enqueued-before,omitempty"`
+	Limit          int       `json:"limit,omitempty"`
+}
+
+// IntrospectArg identifies an agent introspection endpoint to be
+// queried on a machine. Agent is the tag of the agent whose socket
+// is queried; if empty, the machine agent is used.
+type IntrospectArg struct {
+	Machine string `json:"machine"`
+	Agent   string `json:"agent,omitempty"`
+	Path    string `json:"path"`
+}
+
+// IntrospectArgs holds the arguments to a bulk Introspect call.
+type IntrospectArgs struct {
+	Args []IntrospectArg `json:"args"`
+}
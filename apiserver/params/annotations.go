@@ -25,3 +25,66 @@ type EntityAnnotations struct {
 	EntityTag   string            `json:"entity"`
 	Annotations map[string]string `json:"annotations"`
 }
+
+// StructuredAnnotationsGetResult holds the structured annotations of an
+// entity, or the error retrieving them.
+type StructuredAnnotationsGetResult struct {
+	EntityTag   string                 `json:"entity"`
+	Annotations map[string]interface{} `json:"annotations"`
+	Error       *Error                 `json:"error,omitempty"`
+}
+
+// StructuredAnnotationsGetResults holds the structured annotations of
+// entities.
+type StructuredAnnotationsGetResults struct {
+	Results []StructuredAnnotationsGetResult `json:"results"`
+}
+
+// StructuredAnnotationsSet holds the parameters for making the
+// SetStructured call on the Annotations facade.
+type StructuredAnnotationsSet struct {
+	Annotations []EntityStructuredAnnotations `json:"annotations"`
+}
+
+// EntityStructuredAnnotations holds structured annotations for an
+// entity. The values may be any JSON value; a null value removes the
+// annotation.
+type EntityStructuredAnnotations struct {
+	EntityTag   string                 `json:"entity"`
+	Annotations map[string]interface{} `json:"annotations"`
+}
+
+// AnnotationFilter matches entities by one of their annotations. The
+// fields of structured annotations may be matched with a dotted key,
+// such as "owner.team". If Value is null, any entity with the
+// annotation matches.
+type AnnotationFilter struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// AnnotationQuery holds the filters for finding annotated entities.
+// Entities match if their annotations match all of the filters. If
+// Kind is not empty, only entities of that tag kind match.
+type AnnotationQuery struct {
+	Kind    string             `json:"kind,omitempty"`
+	Filters []AnnotationFilter `json:"filters"`
+}
+
+// AnnotationQueries holds the parameters for making the Find call on
+// the Annotations facade.
+type AnnotationQueries struct {
+	Queries []AnnotationQuery `json:"queries"`
+}
+
+// AnnotationQueryResult holds the tags of the entities matching an
+// annotation query, or the error running it.
+type AnnotationQueryResult struct {
+	Entities []Entity `json:"entities,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// AnnotationQueryResults holds the results of annotation queries.
+type AnnotationQueryResults struct {
+	Results []AnnotationQueryResult `json:"results"`
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// APIToken describes a scoped API token. The token's secret is only
+// returned when the token is added.
+type APIToken struct {
+	ID           string    `json:"id"`
+	UserTag      string    `json:"user-tag"`
+	ModelTag     string    `json:"model-tag,omitempty"`
+	Capabilities []string  `json:"capabilities"`
+	Created      time.Time `json:"created"`
+	Expires      time.Time `json:"expires"`
+}
+
+// APITokensResult holds a list of API tokens.
+type APITokensResult struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+// AddAPITokensArgs holds the arguments for the APITokens facade's
+// AddTokens call.
+type AddAPITokensArgs struct {
+	Args []AddAPITokenArg `json:"args"`
+}
+
+// AddAPITokenArg describes an API token to add for the calling user.
+// If ModelTag is empty, the token is not limited to a single model.
+type AddAPITokenArg struct {
+	ModelTag     string        `json:"model-tag,omitempty"`
+	Capabilities []string      `json:"capabilities"`
+	Lifetime     time.Duration `json:"lifetime"`
+}
+
+// AddAPITokenResults holds the results of the AddTokens call.
+type AddAPITokenResults struct {
+	Results []AddAPITokenResult `json:"results"`
+}
+
+// AddAPITokenResult holds a description of an added API token and
+// the token itself, as presented when logging in, or an error.
+type AddAPITokenResult struct {
+	Info  APIToken `json:"info"`
+	Token string   `json:"token,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// RemoveAPITokensArgs holds the IDs of the API tokens to remove.
+type RemoveAPITokensArgs struct {
+	IDs []string `json:"ids"`
+}
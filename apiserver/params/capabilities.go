@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// The following features may be reported by the Capabilities facade.
+const (
+	FeatureSpaces              = "spaces"
+	FeatureCrossModelRelations = "cross-model-relations"
+	FeatureSecrets             = "secrets"
+	FeatureBranches            = "branches"
+)
+
+// FeatureFacades maps each known feature to the facade that provides
+// it. Clients talking to a controller without the Capabilities facade
+// use it to infer the supported features from the controller's facades.
+var FeatureFacades = map[string]string{
+	FeatureSpaces:              "Spaces",
+	FeatureCrossModelRelations: "ApplicationOffers",
+	FeatureSecrets:             "Secrets",
+	FeatureBranches:            "ModelGeneration",
+}
+
+// CapabilitiesResult holds the features supported by a controller,
+// and the facade versions it has deprecated.
+type CapabilitiesResult struct {
+	// Features holds the names of the supported features.
+	Features []string `json:"features"`
+
+	// DeprecatedFacades holds the facade versions that the
+	// controller still serves, but which will be removed.
+	DeprecatedFacades []DeprecatedFacade `json:"deprecated-facades,omitempty"`
+}
+
+// DeprecatedFacade describes a deprecated facade version.
+type DeprecatedFacade struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Message string `json:"message"`
+}
+
+// ProviderCapabilitiesResult holds the capabilities of the
+// provider of a model.
+type ProviderCapabilitiesResult struct {
+	// ProviderType holds the type of the model's provider.
+	ProviderType string `json:"provider-type"`
+
+	// Capabilities holds the names of the provider's capabilities.
+	Capabilities []string `json:"capabilities"`
+}
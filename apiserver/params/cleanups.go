@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CleanupInfo describes a cleanup in a model that has not yet
+// completed.
+type CleanupInfo struct {
+	Id      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Prefix  string    `json:"prefix"`
+	Created time.Time `json:"created"`
+
+	// Attempts is the number of times the cleanup has failed, and
+	// LastAttempt and LastError record the most recent failure.
+	Attempts    int        `json:"attempts"`
+	LastAttempt *time.Time `json:"last-attempt,omitempty"`
+	LastError   string     `json:"last-error,omitempty"`
+}
+
+// CleanupsResult holds the cleanups in a model that have not yet
+// completed, in the order they will be run.
+type CleanupsResult struct {
+	Cleanups []CleanupInfo `json:"cleanups"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ControllerMetricsArgs holds the arguments for a ControllerMetrics
+// call.
+type ControllerMetricsArgs struct {
+	// Since restricts the result to samples taken at or after the
+	// given time.
+	Since time.Time `json:"since"`
+}
+
+// ControllerMetricsResult holds the results of a ControllerMetrics
+// call.
+type ControllerMetricsResult struct {
+	Samples []ControllerMetricsSample `json:"samples"`
+}
+
+// ControllerMetricsSample holds the internal metrics sampled by a
+// controller machine at one point in time.
+type ControllerMetricsSample struct {
+	Machine string             `json:"machine"`
+	Time    time.Time          `json:"time"`
+	Metrics map[string]float64 `json:"metrics"`
+}
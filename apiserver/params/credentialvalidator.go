@@ -0,0 +1,14 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// CredentialValidity holds the result of checking a model's cloud
+// credential against the cloud.
+type CredentialValidity struct {
+	// Valid reports whether the cloud accepted the credential.
+	Valid bool `json:"valid"`
+
+	// Reason describes why the credential is not valid.
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// DeltaStreamConfig holds all the information necessary to
+// connect to the model delta stream endpoint.
+type DeltaStreamConfig struct {
+	// Bookmark, if set, is the bookmark of the last frame received
+	// by the client. If the server still holds the frames following
+	// it, only those are sent; otherwise the stream starts with a
+	// full snapshot of the model.
+	Bookmark string `schema:"bookmark"`
+}
+
+// DeltaStreamFrame is a single message sent on the model delta
+// stream.
+type DeltaStreamFrame struct {
+	// Bookmark identifies this frame. Clients should record it and
+	// pass it back when reconnecting to resume the stream.
+	Bookmark string `json:"bookmark"`
+
+	// Reset is true if the frame holds a full snapshot of the model,
+	// in which case clients should discard any state they hold
+	// before applying the deltas.
+	Reset bool `json:"reset,omitempty"`
+
+	// Deltas holds the changes to the model.
+	Deltas []multiwatcher.Delta `json:"deltas"`
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// EngineReports holds the dependency engine reports of one or more
+// agents.
+type EngineReports struct {
+	Reports []EngineReport `json:"reports"`
+}
+
+// EngineReport holds the dependency engine report of a single agent.
+type EngineReport struct {
+	// Tag identifies the agent whose engine is reported.
+	Tag string `json:"tag"`
+
+	// Report holds the report, formatted as YAML in the same way as
+	// the agent's introspection socket formats it.
+	Report string `json:"report"`
+}
+
+// EngineReportQueries holds the agents whose dependency engine
+// reports are requested.
+type EngineReportQueries struct {
+	Queries []EngineReportQuery `json:"queries"`
+}
+
+// EngineReportQuery identifies an agent in a model.
+type EngineReportQuery struct {
+	ModelTag string `json:"model-tag"`
+	Tag      string `json:"tag"`
+}
+
+// EngineReportResults holds the results of an EngineReports call.
+type EngineReportResults struct {
+	Results []EngineReportResult `json:"results"`
+}
+
+// EngineReportResult holds the most recent dependency engine report
+// of an agent, or an error.
+type EngineReportResult struct {
+	Report  string    `json:"report,omitempty"`
+	Updated time.Time `json:"updated,omitempty"`
+	Error   *Error    `json:"error,omitempty"`
+}
@@ -25,6 +25,13 @@ type FirewallRule struct {
 
 	// WhitelistCIDRS is the ist of subnets allowed access.
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
+
+	// Description optionally explains the purpose of the rule.
+	Description string `json:"description,omitempty"`
+
+	// Origin is the tag of the user who last set the rule. It is
+	// recorded by the controller and ignored when setting rules.
+	Origin string `json:"origin,omitempty"`
 }
 
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
@@ -0,0 +1,22 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// HostEntry maps an address to the host names that should resolve to it.
+type HostEntry struct {
+	Address string   `json:"address"`
+	Names   []string `json:"names"`
+}
+
+// HostEntriesResult holds the host entries for a single machine, or an
+// error.
+type HostEntriesResult struct {
+	Entries []HostEntry `json:"entries,omitempty"`
+	Error   *Error      `json:"error,omitempty"`
+}
+
+// HostEntriesResults holds the results of a HostEntries call.
+type HostEntriesResults struct {
+	Results []HostEntriesResult `json:"results"`
+}
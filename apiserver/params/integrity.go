@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// IntegrityCheckArgs holds the arguments for the Integrity facade's
+// Check call.
+type IntegrityCheckArgs struct {
+	// Models holds the tags of the models to check. If it is empty,
+	// all models in the controller are checked.
+	Models []Entity `json:"models,omitempty"`
+
+	// Repair indicates whether the problems that can be repaired
+	// automatically should be.
+	Repair bool `json:"repair"`
+}
+
+// IntegrityProblem describes a document in a model that refers to an
+// entity that does not exist.
+type IntegrityProblem struct {
+	Kind       string `json:"kind"`
+	Collection string `json:"collection"`
+	Id         string `json:"id"`
+	Message    string `json:"message"`
+	Repairable bool   `json:"repairable"`
+}
+
+// IntegrityCheckResult holds the problems found in a model, and the
+// number of them repaired.
+type IntegrityCheckResult struct {
+	ModelTag string             `json:"model-tag"`
+	Problems []IntegrityProblem `json:"problems,omitempty"`
+	Repaired int                `json:"repaired"`
+	Error    *Error             `json:"error,omitempty"`
+}
+
+// IntegrityCheckResults holds the results of checking the integrity of
+// models.
+type IntegrityCheckResults struct {
+	Results []IntegrityCheckResult `json:"results"`
+}
@@ -128,6 +128,13 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Database is the name of the mongo database in which to place
+	// the model's logs and non-transactional collections, to isolate
+	// a heavyweight model from the controller's database. If this is
+	// empty, the controller's databases are used. Only controller
+	// superusers may select a database.
+	Database string `json:"database,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -250,6 +257,12 @@ type ControllerConfigResult struct {
 	Config ControllerConfig `json:"config"`
 }
 
+// ControllerConfigSet holds the controller config attributes to
+// change.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+}
+
 // ControllerAPIInfoResult holds controller api address details.
 type ControllerAPIInfoResult struct {
 	Addresses []string `json:"addresses"`
@@ -674,17 +687,18 @@ type AgentVersionResult struct {
 
 // ProvisioningInfo holds machine provisioning info.
 type ProvisioningInfo struct {
-	Constraints       constraints.Value         `json:"constraints"`
-	Series            string                    `json:"series"`
-	Placement         string                    `json:"placement"`
-	Jobs              []multiwatcher.MachineJob `json:"jobs"`
-	Volumes           []VolumeParams            `json:"volumes,omitempty"`
-	VolumeAttachments []VolumeAttachmentParams  `json:"volume-attachments,omitempty"`
-	Tags              map[string]string         `json:"tags,omitempty"`
-	SubnetsToZones    map[string][]string       `json:"subnets-to-zones,omitempty"`
-	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
-	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
-	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
+	Constraints       constraints.Value          `json:"constraints"`
+	Series            string                     `json:"series"`
+	Placement         string                     `json:"placement"`
+	Jobs              []multiwatcher.MachineJob  `json:"jobs"`
+	Volumes           []VolumeParams             `json:"volumes,omitempty"`
+	VolumeAttachments []VolumeAttachmentParams   `json:"volume-attachments,omitempty"`
+	Tags              map[string]string          `json:"tags,omitempty"`
+	SubnetsToZones    map[string][]string        `json:"subnets-to-zones,omitempty"`
+	ImageMetadata     []CloudImageMetadata       `json:"image-metadata,omitempty"`
+	EndpointBindings  map[string]string          `json:"endpoint-bindings,omitempty"`
+	ControllerConfig  map[string]interface{}     `json:"controller-config,omitempty"`
+	CharmLXDProfiles  map[string]CharmLXDProfile `json:"charm-lxd-profiles,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -782,6 +796,84 @@ type GUIVersionRequest struct {
 	Version version.Number `json:"version"`
 }
 
+// AuthorizationPolicyRequest is sent to an external authorization
+// policy endpoint to ask whether an API call should be allowed.
+type AuthorizationPolicyRequest struct {
+	// User holds the tag of the authenticated user making the call.
+	User string `json:"user"`
+
+	// Model holds the tag of the model the user is connected to,
+	// or is empty if the user is connected to the controller.
+	Model string `json:"model,omitempty"`
+
+	// Facade, Version and Method identify the API call.
+	Facade  string `json:"facade"`
+	Version int    `json:"version"`
+	Method  string `json:"method"`
+
+	// Entities holds the tags of the entities the call acts on,
+	// when they can be determined from the call's arguments.
+	Entities []string `json:"entities,omitempty"`
+}
+
+// AuthorizationPolicyResponse holds the decision of an external
+// authorization policy endpoint.
+type AuthorizationPolicyResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// HealthCheck holds the result of one of the checks made by the
+// /health endpoint.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HealthResponse is returned by the /health endpoint. Live is true
+// if the API server is running, and Ready is true if all of the
+// checks passed, so that the API server can take client connections.
+type HealthResponse struct {
+	Live   bool          `json:"live"`
+	Ready  bool          `json:"ready"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// APIServerDrainRequest holds the body for /drain POST requests.
+type APIServerDrainRequest struct {
+	// MachineId holds the id of the controller machine whose API
+	// server should start or stop draining.
+	MachineId string `json:"machine-id"`
+
+	// Drain is true if the API server should stop accepting new
+	// connections and close its existing ones, and false if it
+	// should resume accepting connections.
+	Drain bool `json:"drain"`
+}
+
+// APIServerDrainStatus holds the drain status of a single API server.
+type APIServerDrainStatus struct {
+	// MachineId holds the id of the API server's controller machine.
+	MachineId string `json:"machine-id"`
+
+	// Draining reports whether the API server is draining.
+	Draining bool `json:"draining"`
+
+	// Connections holds the number of API connections the server
+	// had when it last reported.
+	Connections int64 `json:"connections"`
+
+	// Idle reports whether the API server is draining and has no
+	// remaining API connections, so it can safely be stopped.
+	Idle bool `json:"idle"`
+}
+
+// APIServerDrainResponse holds the response for /drain GET requests.
+type APIServerDrainResponse struct {
+	Servers []APIServerDrainStatus `json:"servers"`
+}
+
 // LogMessage is a structured logging entry.
 type LogMessage struct {
 	Entity    string    `json:"tag"`
@@ -790,6 +882,9 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+
+	Labels        []string `json:"lab,omitempty"`
+	CorrelationID string   `json:"cid,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -811,6 +906,7 @@ type UnitRefreshResult struct {
 	Life     Life
 	Resolved ResolvedMode
 	Series   string
+	MovingTo string
 	Error    *Error
 }
 
@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// CharmLXDProfile holds an LXD profile shipped with a charm.
+type CharmLXDProfile struct {
+	Config      map[string]string            `json:"config,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// CharmProfilesResult holds the names of the charm LXD profiles applied
+// to a machine, and the profiles, keyed by name, that it should have.
+type CharmProfilesResult struct {
+	Current  []string                   `json:"current,omitempty"`
+	Profiles map[string]CharmLXDProfile `json:"profiles,omitempty"`
+	Error    *Error                     `json:"error,omitempty"`
+}
+
+// CharmProfilesResults holds the results of a CharmProfiles call.
+type CharmProfilesResults struct {
+	Results []CharmProfilesResult `json:"results"`
+}
+
+// MachineCharmProfiles holds the names of the charm LXD profiles applied
+// to a machine.
+type MachineCharmProfiles struct {
+	Tag      string   `json:"tag"`
+	Profiles []string `json:"profiles"`
+}
+
+// SetCharmProfiles holds the arguments for recording the charm LXD
+// profiles applied to machines.
+type SetCharmProfiles struct {
+	Machines []MachineCharmProfiles `json:"machines"`
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// SetMachineHealthProblems holds the health problems reported by one
+// or more machine agents.
+type SetMachineHealthProblems struct {
+	Machines []MachineHealthProblems `json:"machines"`
+}
+
+// MachineHealthProblems holds the health problems reported by a
+// single machine agent. An empty list of problems means the machine
+// is healthy.
+type MachineHealthProblems struct {
+	Tag      string   `json:"tag"`
+	Problems []string `json:"problems"`
+}
+
+// ControllerTimeResult holds the current time according to the
+// controller.
+type ControllerTimeResult struct {
+	Time time.Time `json:"time"`
+}
@@ -112,6 +112,47 @@ type UnsetModelDefaults struct {
 type SetModelAgentVersion struct {
 	Version             version.Number `json:"version"`
 	IgnoreAgentVersions bool           `json:"force,omitempty"`
+
+	// Canaries and BatchSize, if either is set, request a staged
+	// upgrade: after the controllers, the canary machines are
+	// upgraded first, then the remaining machines in batches of
+	// BatchSize (zero meaning all at once).
+	Canaries  []string `json:"canaries,omitempty"`
+	BatchSize int      `json:"batch-size,omitempty"`
+}
+
+// UpgradePrecheckArgs holds the arguments to the UpgradePrecheck API
+// call.
+type UpgradePrecheckArgs struct {
+	Version             version.Number `json:"version"`
+	IgnoreAgentVersions bool           `json:"force,omitempty"`
+}
+
+// Severities of upgrade precheck issues. Issues with
+// UpgradePrecheckError severity prevent the upgrade.
+const (
+	UpgradePrecheckError   = "error"
+	UpgradePrecheckWarning = "warning"
+)
+
+// UpgradePrecheckIssue describes a problem found when checking whether
+// a model can be upgraded.
+type UpgradePrecheckIssue struct {
+	// Check is the name of the check that found the issue, such as
+	// "agent-version" or "charm".
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+
+	// Entity is the tag of the entity the issue concerns, if any.
+	Entity  string `json:"entity,omitempty"`
+	Message string `json:"message"`
+}
+
+// UpgradePrecheckResult holds the issues found when checking whether
+// a model can be upgraded to a version.
+type UpgradePrecheckResult struct {
+	Version version.Number         `json:"version"`
+	Issues  []UpgradePrecheckIssue `json:"issues,omitempty"`
 }
 
 // ModelMigrationStatus holds information about the progress of a (possibly
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// ModelArchive describes a signed model archive held by the
+// controller.
+type ModelArchive struct {
+	ID        string    `json:"id"`
+	ModelTag  string    `json:"model-tag"`
+	ModelName string    `json:"model-name"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Created   time.Time `json:"created"`
+}
+
+// ModelArchivesFilter selects the model archives returned by the
+// ModelArchives facade's List call. If ModelTag is empty, the archives
+// of all models are returned.
+type ModelArchivesFilter struct {
+	ModelTag string `json:"model-tag,omitempty"`
+}
+
+// ModelArchivesResult holds model archives held by the controller.
+type ModelArchivesResult struct {
+	Archives []ModelArchive `json:"archives"`
+}
+
+// CreateModelArchivesArgs holds the arguments for the ModelArchives
+// facade's Create call.
+type CreateModelArchivesArgs struct {
+	Archives []CreateModelArchiveArg `json:"archives"`
+}
+
+// CreateModelArchiveArg identifies a model to archive, and the key
+// with which to sign the archive. The same key must be given to import
+// the archive.
+type CreateModelArchiveArg struct {
+	ModelTag   string `json:"model-tag"`
+	SigningKey []byte `json:"signing-key"`
+}
+
+// ModelArchiveResult holds a model archive or an error.
+type ModelArchiveResult struct {
+	Result *ModelArchive `json:"result,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// ModelArchiveResults holds the results of the ModelArchives facade's
+// Create call.
+type ModelArchiveResults struct {
+	Results []ModelArchiveResult `json:"results"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ModelQuotas holds the limits on the resources a model may use.
+// A zero limit means that the resource is not limited.
+type ModelQuotas struct {
+	MaxMachines  int    `json:"max-machines,omitempty"`
+	MaxUnits     int    `json:"max-units,omitempty"`
+	MaxStorageGB uint64 `json:"max-storage-gb,omitempty"`
+}
+
+// ModelQuotaUsage holds the resources, subject to quotas, that a
+// model currently uses.
+type ModelQuotaUsage struct {
+	Machines   int    `json:"machines"`
+	Units      int    `json:"units"`
+	StorageMiB uint64 `json:"storage-mib"`
+}
+
+// ModelQuotasResult holds the quotas and usage of a model.
+type ModelQuotasResult struct {
+	Quotas ModelQuotas     `json:"quotas"`
+	Usage  ModelQuotaUsage `json:"usage"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// ModelQuotasResults holds the results of a ModelQuotas call.
+type ModelQuotasResults struct {
+	Results []ModelQuotasResult `json:"results"`
+}
+
+// SetModelQuotasArgs holds the arguments for a SetModelQuotas call.
+type SetModelQuotasArgs struct {
+	Args []SetModelQuotasArg `json:"args"`
+}
+
+// SetModelQuotasArg holds the quotas to set on a model.
+type SetModelQuotasArg struct {
+	ModelTag string      `json:"model-tag"`
+	Quotas   ModelQuotas `json:"quotas"`
+}
@@ -181,6 +181,21 @@ type ProviderInterfaceInfoResult struct {
 	Error      *Error                  `json:"error,omitempty"`
 }
 
+// KnownProviderResourcesResults holds the results of a
+// KnownProviderResources call.
+type KnownProviderResourcesResults struct {
+	Results []KnownProviderResourcesResult `json:"results"`
+}
+
+// KnownProviderResourcesResult holds the provider resources which
+// one model's state accounts for, or any error that occurred getting
+// them.
+type KnownProviderResourcesResult struct {
+	Instances []string `json:"instances"`
+	Volumes   []string `json:"volumes"`
+	Error     *Error   `json:"error,omitempty"`
+}
+
 // ProviderInterfaceInfo stores the details needed to identify an
 // interface to a provider. It's the params equivalent of
 // network.ProviderInterfaceInfo, defined here separately to ensure
@@ -682,6 +697,17 @@ type NetworkInfo struct {
 	// "eth1", even for a VLAN eth1.42 virtual interface).
 	InterfaceName string `json:"interface-name"`
 
+	// InterfaceType is the type of the interface (e.g. "ethernet", "bond",
+	// "802.1q" or "bridge"), if known.
+	InterfaceType string `json:"interface-type,omitempty" yaml:"interfacetype,omitempty"`
+
+	// ParentInterfaceName is the name of the parent interface, such as the
+	// bond of a bond slave or the device a VLAN is stacked on, if any.
+	ParentInterfaceName string `json:"parent-interface-name,omitempty" yaml:"parentinterfacename,omitempty"`
+
+	// VLANTag is the 802.1q tag of a VLAN interface, and 0 otherwise.
+	VLANTag int `json:"vlan-tag,omitempty" yaml:"vlantag,omitempty"`
+
 	// Addresses contains a list of addresses configured on the interface.
 	Addresses []InterfaceAddress `json:"addresses"`
 }
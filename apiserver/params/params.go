@@ -278,6 +278,51 @@ type ApplicationUpdate struct {
 	Constraints     *constraints.Value `json:"constraints,omitempty"`
 }
 
+// ApplicationValidateConfigArgs holds the parameters for validating
+// the config of several applications.
+type ApplicationValidateConfigArgs struct {
+	Args []ApplicationValidateConfig `json:"args"`
+}
+
+// ApplicationValidateConfig holds the proposed config for an
+// application. ConfigYAML takes precedence over Config if both are
+// present, as it does for ApplicationUpdate.
+type ApplicationValidateConfig struct {
+	ApplicationName string            `json:"application"`
+	Config          map[string]string `json:"config,omitempty"`
+	ConfigYAML      string            `json:"config-yaml,omitempty"`
+}
+
+// ApplicationConfigHistoryResults holds the config history of several
+// applications.
+type ApplicationConfigHistoryResults struct {
+	Results []ApplicationConfigHistoryResult `json:"results"`
+}
+
+// ApplicationConfigHistoryResult holds the recorded changes to the
+// config of an application, oldest first, or an error.
+type ApplicationConfigHistoryResult struct {
+	Changes []ConfigChange `json:"changes,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ConfigChange describes a change made to config settings.
+type ConfigChange struct {
+	Version int64              `json:"version"`
+	Author  string             `json:"author,omitempty"`
+	Changed time.Time          `json:"changed"`
+	Items   []ConfigItemChange `json:"items"`
+}
+
+// ConfigItemChange describes the change of a single config setting.
+// Kind is one of "added", "modified" or "deleted".
+type ConfigItemChange struct {
+	Kind     string      `json:"kind"`
+	Key      string      `json:"key"`
+	OldValue interface{} `json:"old-value,omitempty"`
+	NewValue interface{} `json:"new-value,omitempty"`
+}
+
 // UpdateSeriesArg holds the parameters for updating the series for the
 // specified application or machine. For Application, only known by facade
 // version 5 and greater. For MachineManger, only known by facade version
@@ -325,6 +370,11 @@ type ApplicationSetCharm struct {
 	// series of the unit.
 	ForceSeries bool `json:"force-series"`
 
+	// Pinned records that the charm was chosen at a specific revision,
+	// so that the application is not offered upgrades by the charm
+	// revision updater.
+	Pinned bool `json:"pinned,omitempty"`
+
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resource-ids,omitempty"`
@@ -475,6 +525,93 @@ type DestroyUnitParams struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// MoveUnitsParams holds bulk parameters for the Application.MoveUnits call.
+type MoveUnitsParams struct {
+	Units []MoveUnitParams `json:"units"`
+}
+
+// MoveUnitParams holds parameters for the Application.MoveUnits call.
+type MoveUnitParams struct {
+	// UnitTag holds the tag of the unit to move.
+	UnitTag string `json:"unit-tag"`
+
+	// MachineTag holds the tag of the machine to which
+	// the unit should be moved.
+	MachineTag string `json:"machine-tag"`
+
+	// Force controls whether the move is completed without
+	// waiting for the unit to stop on its current machine.
+	Force bool `json:"force,omitempty"`
+}
+
+// HookRetryPolicy holds an application's overrides of the model's
+// automatic hook retry behaviour.
+type HookRetryPolicy struct {
+	// Disabled stops failed hooks being retried automatically.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// MaxAttempts, if non-zero, limits the number of automatic
+	// retries of a failed hook.
+	MaxAttempts int `json:"max-attempts,omitempty"`
+
+	// MaxRetryTime, if non-zero, is the longest delay between
+	// automatic retries.
+	MaxRetryTime time.Duration `json:"max-retry-time,omitempty"`
+}
+
+// ApplicationHookRetryPolicy holds the hook retry policy of an
+// application.
+type ApplicationHookRetryPolicy struct {
+	ApplicationTag string          `json:"application-tag"`
+	Policy         HookRetryPolicy `json:"policy"`
+}
+
+// ApplicationHookRetryPolicies holds bulk parameters for the
+// Application.SetHookRetryPolicies call.
+type ApplicationHookRetryPolicies struct {
+	Policies []ApplicationHookRetryPolicy `json:"policies"`
+}
+
+// HookRetryPolicyResult holds the hook retry policy of an
+// application, or an error.
+type HookRetryPolicyResult struct {
+	Policy *HookRetryPolicy `json:"policy,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// HookRetryPolicyResults holds the results of the
+// Application.HookRetryPolicies call.
+type HookRetryPolicyResults struct {
+	Results []HookRetryPolicyResult `json:"results"`
+}
+
+// ApplicationProxyOverrides holds an application's overrides of the
+// model's proxy settings, as seen by its units' hooks. Empty fields
+// do not override the model's settings.
+type ApplicationProxyOverrides struct {
+	ApplicationTag string      `json:"application-tag"`
+	Overrides      ProxyConfig `json:"overrides"`
+}
+
+// ApplicationsProxyOverrides holds bulk parameters for the
+// Application.SetProxyOverrides call.
+type ApplicationsProxyOverrides struct {
+	Applications []ApplicationProxyOverrides `json:"applications"`
+}
+
+// ProxyOverridesResult holds the proxy overrides of an application,
+// or an error.
+type ProxyOverridesResult struct {
+	Overrides *ProxyConfig `json:"overrides,omitempty"`
+	Error     *Error       `json:"error,omitempty"`
+}
+
+// ProxyOverridesResults holds the results of the
+// Application.ProxyOverrides call.
+type ProxyOverridesResults struct {
+	Results []ProxyOverridesResult `json:"results"`
+}
+
 // ApplicationDestroy holds the parameters for making the deprecated
 // Application.Destroy call.
 type ApplicationDestroy struct {
@@ -523,12 +660,14 @@ type Creds struct {
 // any one is valid, the authentication succeeds). If there are no
 // valid macaroons and macaroon authentication is configured,
 // the LoginResult will contain a macaroon that when
-// discharged, may allow access.
+// discharged, may allow access. If Token is set, it holds an API
+// token identifying the user, and the other credentials are ignored.
 type LoginRequest struct {
 	AuthTag     string           `json:"auth-tag"`
 	Credentials string           `json:"credentials"`
 	Nonce       string           `json:"nonce"`
 	Macaroons   []macaroon.Slice `json:"macaroons"`
+	Token       string           `json:"token,omitempty"`
 	UserData    string           `json:"user-data"`
 }
 
@@ -646,6 +785,21 @@ type IsMasterResult struct {
 	Master bool `json:"master"`
 }
 
+// AgentTokenResult holds the result of a RefreshToken or
+// TokenValidity API call.
+type AgentTokenResult struct {
+	// Token is the newly issued agent token, which the agent
+	// must use as its password from now on. It is not set in
+	// the result of TokenValidity.
+	Token string `json:"token,omitempty"`
+
+	// Issued is the time at which the token was issued.
+	Issued time.Time `json:"issued"`
+
+	// Expires is the time at which the token expires.
+	Expires time.Time `json:"expires"`
+}
+
 // ContainerManagerConfigParams contains the parameters for the
 // ContainerManagerConfig provisioner API call.
 type ContainerManagerConfigParams struct {
@@ -933,6 +1087,28 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+
+	// Labels and CorrelationID are optional structured fields.
+	Labels        []string `json:"c,omitempty"`
+	CorrelationID string   `json:"i,omitempty"`
+}
+
+// LogRecordBatch is used to transmit batches of log messages to
+// version 2 of the logsink API endpoint. The records are sent either
+// in Records, or as their gzip-compressed JSON encoding in Compressed.
+// Each batch is acknowledged with a LogRecordBatchAck carrying its
+// sequence number.
+type LogRecordBatch struct {
+	Seq        uint64      `json:"s"`
+	Records    []LogRecord `json:"r,omitempty"`
+	Compressed []byte      `json:"z,omitempty"`
+}
+
+// LogRecordBatchAck is sent by version 2 of the logsink API endpoint
+// once a batch of log messages has been stored, or has failed to be.
+type LogRecordBatchAck struct {
+	Seq   uint64 `json:"s"`
+	Error *Error `json:"e,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// RebootApplicationsResult holds the pending coordinated reboot
+// requests of the model's units, grouped by application.
+type RebootApplicationsResult struct {
+	Applications []RebootApplication `json:"applications"`
+}
+
+// RebootApplication holds the pending coordinated reboot requests of
+// an application's units, oldest first.
+type RebootApplication struct {
+	ApplicationTag string `json:"application-tag"`
+
+	// UnitsReady reports whether every unit of the application has
+	// an active workload and an idle agent.
+	UnitsReady bool `json:"units-ready"`
+
+	Requests []RebootRequest `json:"requests"`
+}
+
+// RebootRequest describes a unit's coordinated reboot request.
+type RebootRequest struct {
+	UnitTag string `json:"unit-tag"`
+
+	// Granted reports whether the reboot has been granted.
+	Granted bool `json:"granted"`
+
+	// Rebooting reports whether the reboot flag of the unit's machine
+	// is still set; that is, whether the machine has yet to reboot.
+	Rebooting bool `json:"rebooting"`
+
+	// Ready reports whether the unit has an active workload and an
+	// idle agent.
+	Ready bool `json:"ready"`
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// GatewayModel describes a model, as returned by the read-only
+// REST gateway.
+type GatewayModel struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Owner       string `json:"owner"`
+	Cloud       string `json:"cloud"`
+	CloudRegion string `json:"cloud-region,omitempty"`
+	Life        Life   `json:"life"`
+}
+
+// GatewayStatus describes the status of an entity, as returned by
+// the read-only REST gateway.
+type GatewayStatus struct {
+	Status string     `json:"status"`
+	Info   string     `json:"info,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
+// GatewayApplication describes an application, as returned by the
+// read-only REST gateway.
+type GatewayApplication struct {
+	Name    string        `json:"name"`
+	Charm   string        `json:"charm"`
+	Life    Life          `json:"life"`
+	Exposed bool          `json:"exposed"`
+	Status  GatewayStatus `json:"status"`
+	Units   []string      `json:"units"`
+}
+
+// GatewayUnit describes a unit, as returned by the read-only REST
+// gateway.
+type GatewayUnit struct {
+	Name           string        `json:"name"`
+	Application    string        `json:"application"`
+	Machine        string        `json:"machine,omitempty"`
+	Life           Life          `json:"life"`
+	WorkloadStatus GatewayStatus `json:"workload-status"`
+	AgentStatus    GatewayStatus `json:"agent-status"`
+}
+
+// GatewayMachine describes a machine, as returned by the read-only
+// REST gateway.
+type GatewayMachine struct {
+	Id         string        `json:"id"`
+	Life       Life          `json:"life"`
+	Series     string        `json:"series"`
+	InstanceId string        `json:"instance-id,omitempty"`
+	Status     GatewayStatus `json:"status"`
+}
+
+// GatewayModels holds the models returned by the REST gateway.
+type GatewayModels struct {
+	Models []GatewayModel `json:"models"`
+}
+
+// GatewayApplications holds the applications returned by the REST
+// gateway.
+type GatewayApplications struct {
+	Applications []GatewayApplication `json:"applications"`
+}
+
+// GatewayUnits holds the units returned by the REST gateway.
+type GatewayUnits struct {
+	Units []GatewayUnit `json:"units"`
+}
+
+// GatewayMachines holds the machines returned by the REST gateway.
+type GatewayMachines struct {
+	Machines []GatewayMachine `json:"machines"`
+}
+
+// GatewayModelStatus holds the status of a model and all of its
+// applications, units and machines, as returned by the REST gateway.
+type GatewayModelStatus struct {
+	Model        GatewayModel         `json:"model"`
+	Applications []GatewayApplication `json:"applications"`
+	Units        []GatewayUnit        `json:"units"`
+	Machines     []GatewayMachine     `json:"machines"`
+}
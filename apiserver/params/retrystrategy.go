@@ -15,6 +15,10 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxHookRetries, if non-zero, limits the number of times a
+	// failed hook is retried.
+	MaxHookRetries int `json:"max-hook-retries,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/version"
+)
+
+// StagedUpgradeStatusResult describes the progress of a model's
+// staged agent upgrade.
+type StagedUpgradeStatusResult struct {
+	// InProgress reports whether a staged upgrade is in progress. If
+	// not, the remaining fields are empty.
+	InProgress bool `json:"in-progress"`
+
+	Version   version.Number `json:"version"`
+	Canaries  []string       `json:"canaries,omitempty"`
+	BatchSize int            `json:"batch-size"`
+
+	// Machines holds the progress of each of the model's machines,
+	// excluding controllers, ordered by id.
+	Machines []StagedUpgradeMachine `json:"machines,omitempty"`
+}
+
+// StagedUpgradeMachine describes the progress of a single machine in
+// a staged agent upgrade.
+type StagedUpgradeMachine struct {
+	MachineTag string `json:"machine-tag"`
+
+	// Admitted reports whether the machine has been allowed to
+	// upgrade.
+	Admitted bool `json:"admitted"`
+
+	// Upgraded reports whether the machine's agents are running the
+	// new version.
+	Upgraded bool `json:"upgraded"`
+
+	// Healthy reports whether the machine's agent has started and
+	// none of its units' agents are in error.
+	Healthy bool `json:"healthy"`
+}
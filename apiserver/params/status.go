@@ -16,8 +16,37 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Fields, if non-empty, restricts the sections of the status
+	// that are computed and returned to those named. Valid values
+	// are the StatusField* constants. The model section is always
+	// returned.
+	Fields []string `json:"fields,omitempty"`
+
+	// Applications, if non-empty, restricts the applications (and
+	// their units and relations) that are returned to those named.
+	Applications []string `json:"applications,omitempty"`
 }
 
+const (
+	// StatusFieldMachines selects the machines section of the status.
+	StatusFieldMachines = "machines"
+
+	// StatusFieldApplications selects the applications section of
+	// the status, including units.
+	StatusFieldApplications = "applications"
+
+	// StatusFieldRemoteApplications selects the remote applications
+	// section of the status.
+	StatusFieldRemoteApplications = "remote-applications"
+
+	// StatusFieldOffers selects the offers section of the status.
+	StatusFieldOffers = "offers"
+
+	// StatusFieldRelations selects the relations section of the status.
+	StatusFieldRelations = "relations"
+)
+
 // TODO(ericsnow) Add FullStatusResult.
 
 // FullStatus holds information about the status of a juju model.
@@ -40,6 +69,22 @@ type ModelStatusInfo struct {
 	ModelStatus      DetailedStatus `json:"model-status"`
 	MeterStatus      MeterStatus    `json:"meter-status"`
 	SLA              string         `json:"sla"`
+
+	// UpgradeStatus describes the progress of a staged agent
+	// upgrade, if one is in progress.
+	UpgradeStatus string `json:"upgrade-status,omitempty"`
+
+	// FanSubnets holds the fan overlay segments known to the model.
+	FanSubnets []FanSubnet `json:"fan-subnets,omitempty"`
+}
+
+// FanSubnet describes the segment of a fan overlay that maps onto one
+// underlay subnet.
+type FanSubnet struct {
+	CIDR     string `json:"cidr"`
+	Underlay string `json:"underlay"`
+	Overlay  string `json:"overlay"`
+	Space    string `json:"space,omitempty"`
 }
 
 // NetworkInterfaceStatus holds a /etc/network/interfaces-type data and the
@@ -57,6 +102,17 @@ type NetworkInterface struct {
 
 	// Is this interface up?
 	IsUp bool `json:"is-up"`
+
+	// Type is the type of the underlying link-layer device, e.g. "bond"
+	// or "802.1q".
+	Type string `json:"type,omitempty"`
+
+	// ParentName is the name of the parent device, such as the bond of a
+	// bond slave, or the device a VLAN is stacked on.
+	ParentName string `json:"parent-name,omitempty"`
+
+	// VLANTag is the 802.1q tag of a VLAN device.
+	VLANTag int `json:"vlan-tag,omitempty"`
 }
 
 // MachineStatus holds status info about a machine.
@@ -98,6 +154,10 @@ type MachineStatus struct {
 	// hardware specification datum.
 	Hardware string `json:"hardware"`
 
+	// LXDProfiles holds the names of the charm LXD profiles applied to
+	// the machine's instance.
+	LXDProfiles []string `json:"lxd-profiles,omitempty"`
+
 	Jobs      []multiwatcher.MachineJob `json:"jobs"`
 	HasVote   bool                      `json:"has-vote"`
 	WantsVote bool                      `json:"wants-vote"`
@@ -105,18 +165,19 @@ type MachineStatus struct {
 
 // ApplicationStatus holds status info about an application.
 type ApplicationStatus struct {
-	Err             error                  `json:"err,omitempty"`
-	Charm           string                 `json:"charm"`
-	Series          string                 `json:"series"`
-	Exposed         bool                   `json:"exposed"`
-	Life            string                 `json:"life"`
-	Relations       map[string][]string    `json:"relations"`
-	CanUpgradeTo    string                 `json:"can-upgrade-to"`
-	SubordinateTo   []string               `json:"subordinate-to"`
-	Units           map[string]UnitStatus  `json:"units"`
-	MeterStatuses   map[string]MeterStatus `json:"meter-statuses"`
-	Status          DetailedStatus         `json:"status"`
-	WorkloadVersion string                 `json:"workload-version"`
+	Err                 error                  `json:"err,omitempty"`
+	Charm               string                 `json:"charm"`
+	Series              string                 `json:"series"`
+	Exposed             bool                   `json:"exposed"`
+	Life                string                 `json:"life"`
+	Relations           map[string][]string    `json:"relations"`
+	CanUpgradeTo        string                 `json:"can-upgrade-to"`
+	CanUpgradeResources []string               `json:"can-upgrade-resources,omitempty"`
+	SubordinateTo       []string               `json:"subordinate-to"`
+	Units               map[string]UnitStatus  `json:"units"`
+	MeterStatuses       map[string]MeterStatus `json:"meter-statuses"`
+	Status              DetailedStatus         `json:"status"`
+	WorkloadVersion     string                 `json:"workload-version"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -245,6 +306,48 @@ type StatusHistoryPruneArgs struct {
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
 
+// StatusHistoryPruneResult holds the result of pruning a model's
+// status history.
+type StatusHistoryPruneResult struct {
+	// DeletedByAge is the number of entries removed because they
+	// were older than the maximum age.
+	DeletedByAge int `json:"deleted-by-age"`
+
+	// DeletedBySize is the number of entries removed to keep the
+	// model's history within its size budget.
+	DeletedBySize int `json:"deleted-by-size"`
+
+	// ReclaimedBytes is an estimate of the space freed.
+	ReclaimedBytes int64 `json:"reclaimed-bytes"`
+}
+
+// StatusSnapshotArgs holds the arguments for taking a snapshot of
+// a model's status.
+type StatusSnapshotArgs struct {
+	// MaxAge is the age beyond which older snapshots are removed.
+	MaxAge time.Duration `json:"max-age"`
+
+	// MaxSizeMB is the size budget for the model's snapshots.
+	MaxSizeMB int `json:"max-size-mb"`
+}
+
+// StatusAtParams holds the parameters for the StatusAt call.
+type StatusAtParams struct {
+	// At is the time at which the model's status is wanted.
+	At time.Time `json:"at"`
+}
+
+// StatusAtResult holds the status of a model as it was at a past
+// point in time.
+type StatusAtResult struct {
+	// Taken is the time at which the status was recorded. It is
+	// the latest time not after the requested time at which a
+	// snapshot of the status was taken.
+	Taken time.Time `json:"taken"`
+
+	Status FullStatus `json:"status"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
@@ -549,6 +549,22 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePoolArgs contains a set of StoragePool.
+type StoragePoolArgs struct {
+	Pools []StoragePool `json:"pools"`
+}
+
+// StoragePoolDeleteArg holds data for a pool instance to be deleted.
+type StoragePoolDeleteArg struct {
+	// Name is the pool's name.
+	Name string `json:"name"`
+}
+
+// StoragePoolDeleteArgs contains a set of StoragePoolDeleteArg.
+type StoragePoolDeleteArgs struct {
+	Pools []StoragePoolDeleteArg `json:"pools"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -847,6 +863,22 @@ type ImportStorageDetails struct {
 	StorageTag string `json:"storage-tag"`
 }
 
+// BulkResizeStorageParams contains the parameters for resizing a
+// collection of storage instances.
+type BulkResizeStorageParams struct {
+	Storage []ResizeStorageParams `json:"storage"`
+}
+
+// ResizeStorageParams contains the parameters for resizing a
+// storage instance.
+type ResizeStorageParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
 // AddStorageResults contains the results of adding storage to units.
 type AddStorageResults struct {
 	Results []AddStorageResult `json:"results"`
@@ -16,6 +16,16 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// Locked is true if the user has been locked out after too many
+	// failed logins. LockedUntil holds the time the lockout ends; if
+	// nil, the user remains locked out until unlocked.
+	Locked      bool       `json:"locked,omitempty"`
+	LockedUntil *time.Time `json:"locked-until,omitempty"`
+
+	// PasswordExpiry holds the time at which the user's password
+	// expires, if it does.
+	PasswordExpiry *time.Time `json:"password-expiry,omitempty"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
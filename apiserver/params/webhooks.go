@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// Webhook describes an HTTPS endpoint to which the controller POSTs
+// events. The webhook's secret is never returned by the API.
+type Webhook struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+}
+
+// WebhooksResult holds the webhooks of a controller.
+type WebhooksResult struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// AddWebhooksArgs holds the arguments for the Webhooks facade's Add
+// call.
+type AddWebhooksArgs struct {
+	Webhooks []AddWebhookArg `json:"webhooks"`
+}
+
+// AddWebhookArg describes a webhook to add to the controller. If
+// Events is empty, all events are sent to the webhook.
+type AddWebhookArg struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events,omitempty"`
+}
+
+// RemoveWebhooksArgs holds the names of the webhooks to remove.
+type RemoveWebhooksArgs struct {
+	Names []string `json:"names"`
+}
+
+// WebhookEvent is the JSON body POSTed to a webhook. The body is
+// signed with the webhook's secret using HMAC-SHA256, and the
+// hex-encoded signature is sent in the X-Juju-Signature header
+// as "sha256=<signature>".
+type WebhookEvent struct {
+	// Id uniquely identifies the event. It is the same for
+	// each attempt to deliver the event.
+	Id string `json:"id"`
+
+	// Type is the kind of event, eg "unit-removed".
+	Type string `json:"type"`
+
+	// Time is the time at which the controller observed the event.
+	Time time.Time `json:"time"`
+
+	ModelUUID string `json:"model-uuid"`
+	ModelName string `json:"model-name,omitempty"`
+
+	Machine     string `json:"machine,omitempty"`
+	InstanceId  string `json:"instance-id,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Application string `json:"application,omitempty"`
+
+	// Status and PreviousStatus are set for status changes.
+	Status         string `json:"status,omitempty"`
+	PreviousStatus string `json:"previous-status,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// WireGuardPeer describes a peer in a machine's WireGuard mesh.
+type WireGuardPeer struct {
+	// PublicKey is the peer's base64 encoded public key.
+	PublicKey string `json:"public-key"`
+
+	// Endpoint is the host:port at which the peer can be reached,
+	// if it has a public address.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// AllowedIPs holds the CIDRs of the peer's addresses, which are
+	// routed through the mesh.
+	AllowedIPs []string `json:"allowed-ips"`
+}
+
+// WireGuardMeshConfig holds the WireGuard configuration of a machine.
+type WireGuardMeshConfig struct {
+	// Enabled reports whether the machine should be part of a mesh.
+	// The remaining fields are only set when it is.
+	Enabled bool `json:"enabled"`
+
+	// PrivateKey is the machine's base64 encoded private key.
+	PrivateKey string `json:"private-key,omitempty"`
+
+	// ListenPort is the UDP port on which the machine accepts
+	// WireGuard traffic.
+	ListenPort int `json:"listen-port,omitempty"`
+
+	// Peers holds the machines with which the machine is meshed.
+	Peers []WireGuardPeer `json:"peers,omitempty"`
+}
+
+// WireGuardMeshConfigResult holds a WireGuard configuration or an error.
+type WireGuardMeshConfigResult struct {
+	Result *WireGuardMeshConfig `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// WireGuardMeshConfigResults holds the results of a bulk call to
+// WireGuardMesh.MeshConfig.
+type WireGuardMeshConfigResults struct {
+	Results []WireGuardMeshConfigResult `json:"results"`
+}
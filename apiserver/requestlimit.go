@@ -0,0 +1,223 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// throttleWarningInterval is the number of throttled requests
+// or logins between successive log warnings.
+const throttleWarningInterval = 100
+
+// requestLimiter limits the rate of API requests made by each
+// authenticated entity. A token bucket is kept for each entity,
+// and shared by all of its connections, so that opening more
+// connections does not increase an entity's request allowance.
+// The bucket is discarded when the entity's last connection
+// closes.
+type requestLimiter struct {
+	clock   clock.Clock
+	maxWait time.Duration
+
+	// throttled counts the requests that were rejected because
+	// the entity exceeded its rate. It must be accessed atomically.
+	throttled int64
+
+	// mu guards the fields below it.
+	mu      sync.Mutex
+	rate    float64
+	burst   int64
+	buckets map[string]*ratelimit.Bucket
+
+	// conns holds the number of open connections restricted for
+	// each entity.
+	conns map[string]int
+}
+
+// newRequestLimiter returns a requestLimiter configured from the
+// given rate limit configuration. If the configured rate is zero,
+// requests are not limited until setLimits is called.
+func newRequestLimiter(cfg RateLimitConfig, clk clock.Clock) *requestLimiter {
+	l := &requestLimiter{
+		clock:   clk,
+		maxWait: cfg.RequestMaxWait,
+		conns:   make(map[string]int),
+	}
+	l.setLimits(cfg.RequestRateLimit, cfg.RequestRateBurst)
+	return l
+}
+
+// setLimits changes the number of requests per second, and the
+// burst size, allowed for each entity. If rate is zero, requests
+// are not rate limited; if burst is zero, rate is used. If the
+// limits change, every entity starts again with a full allowance.
+// setLimits reports whether the limits changed.
+func (l *requestLimiter) setLimits(rate, burst int) bool {
+	if burst <= 0 {
+		burst = rate
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buckets != nil && l.rate == float64(rate) && l.burst == int64(burst) {
+		return false
+	}
+	l.rate = float64(rate)
+	l.burst = int64(burst)
+	l.buckets = make(map[string]*ratelimit.Bucket)
+	return true
+}
+
+// bucket returns the token bucket for the given entity, creating
+// it if necessary, or nil if requests are not rate limited. A new
+// bucket is only kept if the entity has open connections, so that
+// a call racing with the close of its connection cannot leave a
+// bucket behind.
+func (l *requestLimiter) bucket(tag names.Tag) *ratelimit.Bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return nil
+	}
+	key := tag.String()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = ratelimit.NewBucketWithRateAndClock(l.rate, l.burst, ratelimitClock{l.clock})
+		if l.conns[key] > 0 {
+			l.buckets[key] = b
+		}
+	}
+	return b
+}
+
+// release records that one of the entity's connections has
+// closed, discarding its bucket if it has no others.
+func (l *requestLimiter) release(tag names.Tag) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := tag.String()
+	if l.conns[key]--; l.conns[key] <= 0 {
+		delete(l.conns, key)
+		delete(l.buckets, key)
+	}
+}
+
+// Throttled returns the total number of requests rejected
+// because of rate limiting.
+func (l *requestLimiter) Throttled() int64 {
+	if l == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&l.throttled)
+}
+
+// restrict wraps the provided root so that method calls made by
+// the given entity are rate limited. The returned resource must be
+// stopped when the connection closes.
+func (l *requestLimiter) restrict(root rpc.Root, tag names.Tag) (rpc.Root, facade.Resource) {
+	l.mu.Lock()
+	l.conns[tag.String()]++
+	l.mu.Unlock()
+	restricted := &rateLimitedRoot{
+		Root:    root,
+		tag:     tag,
+		limiter: l,
+	}
+	return restricted, &limitedConn{limiter: l, tag: tag}
+}
+
+// wait blocks until the entity's bucket has a token available. If
+// a token will not be available within the limiter's maximum wait
+// time, wait returns common.ErrTryAgain immediately.
+func (l *requestLimiter) wait(tag names.Tag) error {
+	bucket := l.bucket(tag)
+	if bucket == nil {
+		return nil
+	}
+	d, ok := bucket.TakeMaxDuration(1, l.maxWait)
+	if !ok {
+		if n := atomic.AddInt64(&l.throttled, 1); n%throttleWarningInterval == 1 {
+			logger.Warningf(
+				"API request rate limit exceeded by %s (%d requests rejected in total)",
+				names.ReadableString(tag), n,
+			)
+		}
+		return common.ErrTryAgain
+	}
+	if d > 0 {
+		<-l.clock.After(d)
+	}
+	return nil
+}
+
+// limitedConn is a facade.Resource that releases an entity's
+// connection from the limiter when stopped.
+type limitedConn struct {
+	limiter *requestLimiter
+	tag     names.Tag
+	once    sync.Once
+}
+
+// Stop is part of the facade.Resource interface.
+func (c *limitedConn) Stop() error {
+	c.once.Do(func() {
+		c.limiter.release(c.tag)
+	})
+	return nil
+}
+
+// rateLimitedRoot wraps an rpc.Root, rate limiting all
+// method calls made through it.
+type rateLimitedRoot struct {
+	rpc.Root
+	tag     names.Tag
+	limiter *requestLimiter
+}
+
+// FindMethod implements rpc.Root.
+func (r *rateLimitedRoot) FindMethod(facadeName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.Root.FindMethod(facadeName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimitedCaller{caller, r}, nil
+}
+
+// rateLimitedCaller is an rpcreflect.MethodCaller that waits
+// for the calling entity's rate limit before each call.
+type rateLimitedCaller struct {
+	rpcreflect.MethodCaller
+	root *rateLimitedRoot
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c rateLimitedCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	if err := c.root.limiter.wait(c.root.tag); err != nil {
+		return reflect.Value{}, err
+	}
+	return c.MethodCaller.Call(ctx, objId, arg)
+}
+
+// ratelimitClock adapts clock.Clock to ratelimit.Clock.
+type ratelimitClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c ratelimitClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
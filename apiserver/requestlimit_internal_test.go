@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type requestLimiterSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+	root  *countingRoot
+}
+
+var _ = gc.Suite(&requestLimiterSuite{})
+
+func (s *requestLimiterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.root = &countingRoot{}
+}
+
+func (s *requestLimiterSuite) newLimiter(rate, burst int, maxWait time.Duration) *requestLimiter {
+	cfg := DefaultRateLimitConfig()
+	cfg.RequestRateLimit = rate
+	cfg.RequestRateBurst = burst
+	cfg.RequestMaxWait = maxWait
+	return newRequestLimiter(cfg, s.clock)
+}
+
+func (s *requestLimiterSuite) call(c *gc.C, limiter *requestLimiter, tag names.Tag) error {
+	restricted, _ := limiter.restrict(s.root, tag)
+	caller, err := restricted.FindMethod("Client", 1, "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.Background(), "", reflect.Value{})
+	return err
+}
+
+func (s *requestLimiterSuite) TestDisabledByDefault(c *gc.C) {
+	limiter := newRequestLimiter(DefaultRateLimitConfig(), s.clock)
+	tag := names.NewUserTag("bob")
+	for i := 0; i < 100; i++ {
+		c.Assert(s.call(c, limiter, tag), jc.ErrorIsNil)
+	}
+	c.Assert(s.root.calls, gc.Equals, 100)
+	c.Assert(limiter.Throttled(), gc.Equals, int64(0))
+}
+
+func (s *requestLimiterSuite) TestSetLimits(c *gc.C) {
+	limiter := newRequestLimiter(DefaultRateLimitConfig(), s.clock)
+	tag := names.NewUserTag("bob")
+	restricted, _ := limiter.restrict(s.root, tag)
+	call := func() error {
+		caller, err := restricted.FindMethod("Client", 1, "FullStatus")
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = caller.Call(context.Background(), "", reflect.Value{})
+		return err
+	}
+	c.Assert(call(), jc.ErrorIsNil)
+
+	// Limits apply to existing connections.
+	limiter.setLimits(1, 1)
+	c.Assert(call(), jc.ErrorIsNil)
+	c.Assert(call(), gc.Equals, common.ErrTryAgain)
+
+	// Changing the limits resets each entity's allowance.
+	limiter.setLimits(1, 2)
+	c.Assert(call(), jc.ErrorIsNil)
+	c.Assert(call(), jc.ErrorIsNil)
+	c.Assert(call(), gc.Equals, common.ErrTryAgain)
+
+	limiter.setLimits(0, 0)
+	c.Assert(call(), jc.ErrorIsNil)
+	c.Assert(s.root.calls, gc.Equals, 5)
+	c.Assert(limiter.Throttled(), gc.Equals, int64(2))
+}
+
+func (s *requestLimiterSuite) TestRejectsOverBurst(c *gc.C) {
+	limiter := s.newLimiter(1, 2, 0)
+	tag := names.NewUserTag("bob")
+	c.Assert(s.call(c, limiter, tag), jc.ErrorIsNil)
+	c.Assert(s.call(c, limiter, tag), jc.ErrorIsNil)
+	c.Assert(s.call(c, limiter, tag), gc.Equals, common.ErrTryAgain)
+	c.Assert(s.root.calls, gc.Equals, 2)
+	c.Assert(limiter.Throttled(), gc.Equals, int64(1))
+}
+
+func (s *requestLimiterSuite) TestLimitsPerEntity(c *gc.C) {
+	limiter := s.newLimiter(1, 1, 0)
+	c.Assert(s.call(c, limiter, names.NewUserTag("bob")), jc.ErrorIsNil)
+	c.Assert(s.call(c, limiter, names.NewUserTag("bob")), gc.Equals, common.ErrTryAgain)
+	c.Assert(s.call(c, limiter, names.NewMachineTag("0")), jc.ErrorIsNil)
+	c.Assert(s.root.calls, gc.Equals, 2)
+}
+
+func (s *requestLimiterSuite) TestWaitsForToken(c *gc.C) {
+	limiter := s.newLimiter(1, 1, 5*time.Second)
+	tag := names.NewUserTag("bob")
+	c.Assert(s.call(c, limiter, tag), jc.ErrorIsNil)
+
+	done := make(chan error)
+	go func() {
+		done <- s.call(c, limiter, tag)
+	}()
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rate limited call")
+	}
+	c.Assert(s.root.calls, gc.Equals, 2)
+	c.Assert(limiter.Throttled(), gc.Equals, int64(0))
+}
+
+func (s *requestLimiterSuite) TestReleaseDiscardsIdleBuckets(c *gc.C) {
+	limiter := s.newLimiter(1, 1, 0)
+	tag := names.NewUserTag("bob")
+	call := func(root rpc.Root) error {
+		caller, err := root.FindMethod("Client", 1, "FullStatus")
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = caller.Call(context.Background(), "", reflect.Value{})
+		return err
+	}
+	root1, conn1 := limiter.restrict(s.root, tag)
+	root2, conn2 := limiter.restrict(s.root, tag)
+	c.Assert(call(root1), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 1)
+
+	// The bucket is kept while any connection remains.
+	c.Assert(conn1.Stop(), jc.ErrorIsNil)
+	c.Assert(conn1.Stop(), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 1)
+	c.Assert(call(root2), gc.Equals, common.ErrTryAgain)
+
+	c.Assert(conn2.Stop(), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 0)
+	c.Assert(limiter.conns, gc.HasLen, 0)
+
+	// A call racing with the close does not leave a bucket behind.
+	c.Assert(call(root2), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 0)
+}
+
+type countingRoot struct {
+	calls int
+}
+
+func (r *countingRoot) FindMethod(string, int, string) (rpcreflect.MethodCaller, error) {
+	return countingCaller{r}, nil
+}
+
+func (r *countingRoot) Kill() {}
+
+type countingCaller struct {
+	root *countingRoot
+}
+
+func (countingCaller) ParamsType() reflect.Type { return nil }
+
+func (countingCaller) ResultType() reflect.Type { return nil }
+
+func (c countingCaller) Call(context.Context, string, reflect.Value) (reflect.Value, error) {
+	c.root.calls++
+	return reflect.Value{}, nil
+}
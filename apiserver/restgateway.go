@@ -0,0 +1,310 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// restGatewayVersion is the version of the REST gateway. It forms
+// part of every gateway path, so that the resources can evolve
+// without breaking existing integrations.
+const restGatewayVersion = "v1"
+
+// restGatewayHandler serves a single read-only resource of the REST
+// gateway. The gateway exists for integrations that cannot speak the
+// websocket RPC protocol; all requests must be authenticated as a
+// user, either with basic auth, macaroon cookies or a bearer token.
+type restGatewayHandler struct {
+	ctxt httpContext
+
+	// modelScoped is true if the resource belongs to the model
+	// named in the request path, in which case the user must
+	// have read access to that model.
+	modelScoped bool
+
+	// get returns the resource for the authenticated user.
+	get func(srv *Server, st *state.State, user names.UserTag) (interface{}, error)
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *restGatewayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.serve(w, r); err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", errors.Annotate(err, "cannot return error to user"))
+		}
+	}
+}
+
+func (h *restGatewayHandler) serve(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != "GET" {
+		return errors.Trace(emitUnsupportedMethodErr(r.Method))
+	}
+	st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+	user, ok := entity.Tag().(names.UserTag)
+	if !ok {
+		return errors.Trace(common.ErrPerm)
+	}
+	if h.modelScoped {
+		if err := checkModelReadAccess(st, user); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	result, err := h.get(h.ctxt.srv, st, user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, result))
+}
+
+// checkModelReadAccess returns common.ErrPerm unless the user is a
+// controller superuser, or has read access to the state's model.
+func checkModelReadAccess(st *state.State, user names.UserTag) error {
+	ok, err := common.HasPermission(st.UserPermission, user, permission.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = common.HasPermission(st.UserPermission, user, permission.ReadAccess, names.NewModelTag(st.ModelUUID()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return errors.Trace(common.ErrPerm)
+	}
+	return nil
+}
+
+// restGatewaySpecHandler serves the OpenAPI description of the REST
+// gateway. It does not require authentication.
+type restGatewaySpecHandler struct{}
+
+// ServeHTTP is part of the http.Handler interface.
+func (restGatewaySpecHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		if err := sendError(w, emitUnsupportedMethodErr(r.Method)); err != nil {
+			logger.Errorf("%v", errors.Annotate(err, "cannot return error to user"))
+		}
+		return
+	}
+	w.Header().Set("Content-Type", params.ContentTypeJSON)
+	w.Header().Set("Content-Length", fmt.Sprint(len(restGatewaySpec)))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(restGatewaySpec))
+}
+
+// gatewayModels returns the models that the user can access.
+func gatewayModels(srv *Server, st *state.State, user names.UserTag) (interface{}, error) {
+	modelUUIDs, err := st.ModelUUIDsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := params.GatewayModels{
+		Models: make([]params.GatewayModel, 0, len(modelUUIDs)),
+	}
+	for _, modelUUID := range modelUUIDs {
+		model, err := gatewayModelForUUID(srv, modelUUID)
+		if errors.IsNotFound(err) {
+			// The model was removed after we listed it.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Models = append(result.Models, model)
+	}
+	return result, nil
+}
+
+func gatewayModelForUUID(srv *Server, modelUUID string) (params.GatewayModel, error) {
+	st, releaser, err := srv.statePool.Get(modelUUID)
+	if err != nil {
+		return params.GatewayModel{}, errors.Trace(err)
+	}
+	defer releaser()
+	return gatewayModel(st)
+}
+
+func gatewayModel(st *state.State) (params.GatewayModel, error) {
+	model, err := st.Model()
+	if err != nil {
+		return params.GatewayModel{}, errors.Trace(err)
+	}
+	return params.GatewayModel{
+		UUID:        model.UUID(),
+		Name:        model.Name(),
+		Type:        string(model.Type()),
+		Owner:       model.Owner().Id(),
+		Cloud:       model.Cloud(),
+		CloudRegion: model.CloudRegion(),
+		Life:        params.Life(model.Life().String()),
+	}, nil
+}
+
+// gatewayApplications returns the applications in the model.
+func gatewayApplications(_ *Server, st *state.State, _ names.UserTag) (interface{}, error) {
+	applications, _, err := gatewayApplicationsAndUnits(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params.GatewayApplications{Applications: applications}, nil
+}
+
+// gatewayUnits returns the units in the model.
+func gatewayUnits(_ *Server, st *state.State, _ names.UserTag) (interface{}, error) {
+	_, units, err := gatewayApplicationsAndUnits(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params.GatewayUnits{Units: units}, nil
+}
+
+// gatewayMachines returns the machines in the model.
+func gatewayMachines(_ *Server, st *state.State, _ names.UserTag) (interface{}, error) {
+	machines, err := gatewayMachineList(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params.GatewayMachines{Machines: machines}, nil
+}
+
+// gatewayStatus returns the model, together with all of its
+// applications, units and machines.
+func gatewayStatus(_ *Server, st *state.State, _ names.UserTag) (interface{}, error) {
+	model, err := gatewayModel(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applications, units, err := gatewayApplicationsAndUnits(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines, err := gatewayMachineList(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params.GatewayModelStatus{
+		Model:        model,
+		Applications: applications,
+		Units:        units,
+		Machines:     machines,
+	}, nil
+}
+
+func gatewayApplicationsAndUnits(st *state.State) ([]params.GatewayApplication, []params.GatewayUnit, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].Name() < applications[j].Name()
+	})
+	appResults := make([]params.GatewayApplication, 0, len(applications))
+	unitResults := []params.GatewayUnit{}
+	for _, app := range applications {
+		appStatus, err := app.Status()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		curl, _ := app.CharmURL()
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		sort.Slice(units, func(i, j int) bool {
+			return units[i].Name() < units[j].Name()
+		})
+		unitNames := make([]string, len(units))
+		for i, unit := range units {
+			unitNames[i] = unit.Name()
+			unitResult, err := gatewayUnit(unit)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			unitResults = append(unitResults, unitResult)
+		}
+		appResults = append(appResults, params.GatewayApplication{
+			Name:    app.Name(),
+			Charm:   curl.String(),
+			Life:    params.Life(app.Life().String()),
+			Exposed: app.IsExposed(),
+			Status:  gatewayStatusInfo(appStatus),
+			Units:   unitNames,
+		})
+	}
+	return appResults, unitResults, nil
+}
+
+func gatewayUnit(unit *state.Unit) (params.GatewayUnit, error) {
+	machineId, err := unit.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return params.GatewayUnit{}, errors.Trace(err)
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return params.GatewayUnit{}, errors.Trace(err)
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return params.GatewayUnit{}, errors.Trace(err)
+	}
+	return params.GatewayUnit{
+		Name:           unit.Name(),
+		Application:    unit.ApplicationName(),
+		Machine:        machineId,
+		Life:           params.Life(unit.Life().String()),
+		WorkloadStatus: gatewayStatusInfo(workloadStatus),
+		AgentStatus:    gatewayStatusInfo(agentStatus),
+	}, nil
+}
+
+func gatewayMachineList(st *state.State) ([]params.GatewayMachine, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]params.GatewayMachine, 0, len(machines))
+	for _, machine := range machines {
+		instId, err := machine.InstanceId()
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		machineStatus, err := machine.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, params.GatewayMachine{
+			Id:         machine.Id(),
+			Life:       params.Life(machine.Life().String()),
+			Series:     machine.Series(),
+			InstanceId: string(instId),
+			Status:     gatewayStatusInfo(machineStatus),
+		})
+	}
+	return results, nil
+}
+
+func gatewayStatusInfo(info status.StatusInfo) params.GatewayStatus {
+	return params.GatewayStatus{
+		Status: string(info.Status),
+		Info:   info.Message,
+		Since:  info.Since,
+	}
+}
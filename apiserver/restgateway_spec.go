@@ -0,0 +1,223 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+// restGatewaySpec is the OpenAPI description of the REST gateway,
+// served at /gateway/v1/openapi.json. It must be kept in step with
+// the gateway endpoints and the params.Gateway* types.
+const restGatewaySpec = `{
+  "openapi": "3.0.0",
+  "info": {
+    "title": "Juju REST gateway",
+    "description": "Read-only access to models, applications, units and machines, for integrations that cannot use the Juju websocket API.",
+    "version": "1.0.0"
+  },
+  "security": [
+    {"basicAuth": []},
+    {"bearerAuth": []}
+  ],
+  "paths": {
+    "/gateway/v1/models": {
+      "get": {
+        "summary": "List the models the user can access.",
+        "responses": {
+          "200": {
+            "description": "The models.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Models"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/model/{modeluuid}/gateway/v1/status": {
+      "get": {
+        "summary": "Get the status of a model and everything in it.",
+        "parameters": [{"$ref": "#/components/parameters/ModelUUID"}],
+        "responses": {
+          "200": {
+            "description": "The model status.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ModelStatus"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/model/{modeluuid}/gateway/v1/applications": {
+      "get": {
+        "summary": "List the applications in a model.",
+        "parameters": [{"$ref": "#/components/parameters/ModelUUID"}],
+        "responses": {
+          "200": {
+            "description": "The applications.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Applications"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/model/{modeluuid}/gateway/v1/units": {
+      "get": {
+        "summary": "List the units in a model.",
+        "parameters": [{"$ref": "#/components/parameters/ModelUUID"}],
+        "responses": {
+          "200": {
+            "description": "The units.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Units"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/model/{modeluuid}/gateway/v1/machines": {
+      "get": {
+        "summary": "List the machines in a model.",
+        "parameters": [{"$ref": "#/components/parameters/ModelUUID"}],
+        "responses": {
+          "200": {
+            "description": "The machines.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Machines"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "basicAuth": {
+        "type": "http",
+        "scheme": "basic",
+        "description": "The user name must be a user tag, for example user-admin."
+      },
+      "bearerAuth": {
+        "type": "http",
+        "scheme": "bearer",
+        "description": "A base64-encoded JSON macaroon slice, as stored in a Juju client's macaroon cookie."
+      }
+    },
+    "parameters": {
+      "ModelUUID": {
+        "name": "modeluuid",
+        "in": "path",
+        "required": true,
+        "schema": {"type": "string"}
+      }
+    },
+    "responses": {
+      "Error": {
+        "description": "The request failed.",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResult"}}}
+      }
+    },
+    "schemas": {
+      "ErrorResult": {
+        "type": "object",
+        "properties": {
+          "error": {
+            "type": "object",
+            "properties": {
+              "message": {"type": "string"},
+              "code": {"type": "string"}
+            }
+          }
+        }
+      },
+      "Life": {
+        "type": "string",
+        "enum": ["alive", "dying", "dead"]
+      },
+      "Status": {
+        "type": "object",
+        "required": ["status"],
+        "properties": {
+          "status": {"type": "string"},
+          "info": {"type": "string"},
+          "since": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Model": {
+        "type": "object",
+        "required": ["uuid", "name", "type", "owner", "cloud", "life"],
+        "properties": {
+          "uuid": {"type": "string"},
+          "name": {"type": "string"},
+          "type": {"type": "string"},
+          "owner": {"type": "string"},
+          "cloud": {"type": "string"},
+          "cloud-region": {"type": "string"},
+          "life": {"$ref": "#/components/schemas/Life"}
+        }
+      },
+      "Application": {
+        "type": "object",
+        "required": ["name", "charm", "life", "exposed", "status", "units"],
+        "properties": {
+          "name": {"type": "string"},
+          "charm": {"type": "string"},
+          "life": {"$ref": "#/components/schemas/Life"},
+          "exposed": {"type": "boolean"},
+          "status": {"$ref": "#/components/schemas/Status"},
+          "units": {"type": "array", "items": {"type": "string"}}
+        }
+      },
+      "Unit": {
+        "type": "object",
+        "required": ["name", "application", "life", "workload-status", "agent-status"],
+        "properties": {
+          "name": {"type": "string"},
+          "application": {"type": "string"},
+          "machine": {"type": "string"},
+          "life": {"$ref": "#/components/schemas/Life"},
+          "workload-status": {"$ref": "#/components/schemas/Status"},
+          "agent-status": {"$ref": "#/components/schemas/Status"}
+        }
+      },
+      "Machine": {
+        "type": "object",
+        "required": ["id", "life", "series", "status"],
+        "properties": {
+          "id": {"type": "string"},
+          "life": {"$ref": "#/components/schemas/Life"},
+          "series": {"type": "string"},
+          "instance-id": {"type": "string"},
+          "status": {"$ref": "#/components/schemas/Status"}
+        }
+      },
+      "Models": {
+        "type": "object",
+        "properties": {
+          "models": {"type": "array", "items": {"$ref": "#/components/schemas/Model"}}
+        }
+      },
+      "Applications": {
+        "type": "object",
+        "properties": {
+          "applications": {"type": "array", "items": {"$ref": "#/components/schemas/Application"}}
+        }
+      },
+      "Units": {
+        "type": "object",
+        "properties": {
+          "units": {"type": "array", "items": {"$ref": "#/components/schemas/Unit"}}
+        }
+      },
+      "Machines": {
+        "type": "object",
+        "properties": {
+          "machines": {"type": "array", "items": {"$ref": "#/components/schemas/Machine"}}
+        }
+      },
+      "ModelStatus": {
+        "type": "object",
+        "properties": {
+          "model": {"$ref": "#/components/schemas/Model"},
+          "applications": {"type": "array", "items": {"$ref": "#/components/schemas/Application"}},
+          "units": {"type": "array", "items": {"$ref": "#/components/schemas/Unit"}},
+          "machines": {"type": "array", "items": {"$ref": "#/components/schemas/Machine"}}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing/factory"
+)
+
+type restGatewaySuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&restGatewaySuite{})
+
+func (s *restGatewaySuite) gatewayURL(c *gc.C, resource string) string {
+	uri := s.baseURL(c)
+	uri.Path = "/model/" + s.modelUUID + "/gateway/v1/" + resource
+	return uri.String()
+}
+
+func (s *restGatewaySuite) getJSON(c *gc.C, uri string, result interface{}) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: uri})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	err := json.Unmarshal(body, result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+}
+
+func (s *restGatewaySuite) assertError(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *restGatewaySuite) TestOpenAPISpec(c *gc.C) {
+	uri := s.baseURL(c)
+	uri.Path = "/gateway/v1/openapi.json"
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: uri.String()})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+
+	var spec struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	err := json.Unmarshal(body, &spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.OpenAPI, gc.Equals, "3.0.0")
+	for _, path := range []string{
+		"/gateway/v1/models",
+		"/model/{modeluuid}/gateway/v1/status",
+		"/model/{modeluuid}/gateway/v1/applications",
+		"/model/{modeluuid}/gateway/v1/units",
+		"/model/{modeluuid}/gateway/v1/machines",
+	} {
+		c.Check(spec.Paths[path], gc.NotNil, gc.Commentf("path %s", path))
+	}
+}
+
+func (s *restGatewaySuite) TestRequiresAuthentication(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.gatewayURL(c, "status")})
+	s.assertError(c, resp, http.StatusUnauthorized, ".*no credentials provided$")
+}
+
+func (s *restGatewaySuite) TestInvalidBearerToken(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method:       "GET",
+		url:          s.gatewayURL(c, "status"),
+		extraHeaders: map[string]string{"Authorization": "Bearer not-a-token"},
+	})
+	s.assertError(c, resp, http.StatusUnauthorized, ".*bearer token not valid$")
+}
+
+func (s *restGatewaySuite) TestRequiresModelAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password:    "hunter2",
+		NoModelUser: true,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.gatewayURL(c, "status"),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *restGatewaySuite) TestReadOnly(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.gatewayURL(c, "status")})
+	s.assertError(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *restGatewaySuite) TestModels(c *gc.C) {
+	uri := s.baseURL(c)
+	uri.Path = "/gateway/v1/models"
+	var result params.GatewayModels
+	s.getJSON(c, uri.String(), &result)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].UUID, gc.Equals, s.modelUUID)
+	c.Assert(result.Models[0].Life, gc.Equals, params.Alive)
+}
+
+func (s *restGatewaySuite) TestStatus(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result params.GatewayModelStatus
+	s.getJSON(c, s.gatewayURL(c, "status"), &result)
+	c.Assert(result.Model.UUID, gc.Equals, s.modelUUID)
+	c.Assert(result.Applications, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Name, gc.Equals, unit.ApplicationName())
+	c.Assert(result.Applications[0].Units, jc.DeepEquals, []string{unit.Name()})
+	c.Assert(result.Units, gc.HasLen, 1)
+	c.Assert(result.Units[0].Name, gc.Equals, unit.Name())
+	c.Assert(result.Units[0].Machine, gc.Equals, machineId)
+	c.Assert(result.Machines, gc.HasLen, 1)
+	c.Assert(result.Machines[0].Id, gc.Equals, machineId)
+}
+
+func (s *restGatewaySuite) TestResources(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+
+	var applications params.GatewayApplications
+	s.getJSON(c, s.gatewayURL(c, "applications"), &applications)
+	c.Assert(applications.Applications, gc.HasLen, 1)
+	c.Assert(applications.Applications[0].Name, gc.Equals, unit.ApplicationName())
+
+	var units params.GatewayUnits
+	s.getJSON(c, s.gatewayURL(c, "units"), &units)
+	c.Assert(units.Units, gc.HasLen, 1)
+	c.Assert(units.Units[0].Application, gc.Equals, unit.ApplicationName())
+
+	var machines params.GatewayMachines
+	s.getJSON(c, s.gatewayURL(c, "machines"), &machines)
+	c.Assert(machines.Machines, gc.HasLen, 1)
+}
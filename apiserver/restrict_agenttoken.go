@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// errAgentTokenExpired is returned for calls made by an agent that
+// logged in with an expired token, other than those needed to obtain
+// a new one.
+var errAgentTokenExpired = errors.New("agent token expired; a new token must be requested")
+
+// agentTokenRecoveryMethods holds the facade methods that an agent
+// which logged in with an expired token may call.
+var agentTokenRecoveryMethods = map[string]set.Strings{
+	"Agent":  set.NewStrings("RefreshToken", "TokenValidity"),
+	"Pinger": set.NewStrings("Ping"),
+}
+
+// agentTokenRecoveryMethodsOnly allows only the calls an agent needs
+// to replace an expired token. Once the agent has recorded the new
+// token, it reconnects and is given full access.
+func agentTokenRecoveryMethodsOnly(facadeName, methodName string) error {
+	if methods, ok := agentTokenRecoveryMethods[facadeName]; ok && methods.Contains(methodName) {
+		return nil
+	}
+	return errAgentTokenExpired
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/testing"
+)
+
+type restrictAgentTokenSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictAgentTokenSuite{})
+
+func (s *restrictAgentTokenSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingAgentTokenRecoveryRoot()
+	checkAllowed := func(facade string, version int, method string) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Agent", 3, "RefreshToken")
+	checkAllowed("Agent", 3, "TokenValidity")
+	checkAllowed("Pinger", 1, "Ping")
+}
+
+func (s *restrictAgentTokenSuite) TestDisallowedMethods(c *gc.C) {
+	root := apiserver.TestingAgentTokenRecoveryRoot()
+	for _, call := range []struct {
+		facade  string
+		version int
+		method  string
+	}{
+		{"Agent", 3, "GetEntities"},
+		{"Uniter", 7, "Life"},
+		{"Machiner", 1, "SetStatus"},
+	} {
+		caller, err := root.FindMethod(call.facade, call.version, call.method)
+		c.Check(err, gc.ErrorMatches, "agent token expired; a new token must be requested")
+		c.Check(caller, gc.IsNil)
+	}
+}
@@ -14,14 +14,19 @@ import (
 // using a controller-only login. Any facade added here needs to work
 // independently of individual models.
 var controllerFacadeNames = set.NewStrings(
+	"APITokens",
 	"AllModelWatcher",
 	"ApplicationOffers",
 	"Cloud",
 	"Controller",
 	"CrossController",
+	"Integrity",
 	"MigrationTarget",
+	"ModelArchives",
 	"ModelManager",
+	"ModelQuotas",
 	"UserManager",
+	"Webhooks",
 )
 
 // commonFacadeNames holds root names that can be accessed using both
@@ -29,6 +34,7 @@ var controllerFacadeNames = set.NewStrings(
 var commonFacadeNames = set.NewStrings(
 	"Pinger",
 	"Bundle",
+	"Capabilities",
 
 	// TODO(mjs) - bug 1632172 - Exposed for model logins for
 	// backwards compatibility. Remove once we're sure no non-Juju
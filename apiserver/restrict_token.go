@@ -0,0 +1,255 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/apitoken"
+)
+
+// tokenAlwaysAllowedCalls holds the facade methods that connections
+// logged in with an API token may always call, whatever the token's
+// capabilities, keyed by facade name.
+var tokenAlwaysAllowedCalls = map[string]set.Strings{
+	"Pinger": set.NewStrings("Ping", "Stop"),
+}
+
+// tokenReadCalls holds the facade methods allowed by the read
+// capability, keyed by facade name. These methods only read state.
+var tokenReadCalls = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"ActionArtifactContent",
+		"ActionArtifacts",
+		"Actions",
+		"ApplicationsCharmsActions",
+		"FindActionTagsByPrefix",
+		"FindActionsByNames",
+		"ListActionSchedules",
+		"ListActions",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+	),
+	"AllWatcher": set.NewStrings("Next", "Stop"),
+	"Annotations": set.NewStrings(
+		"Find",
+		"Get",
+		"GetStructured",
+	),
+	"Application": set.NewStrings(
+		"CharmRelations",
+		"ConfigHistory",
+		"Get",
+		"GetCharmURL",
+		"GetConfig",
+		"GetConstraints",
+		"HookRetryPolicies",
+		"ProxyOverrides",
+	),
+	"ApplicationOffers": set.NewStrings(
+		"ApplicationOffers",
+		"FindApplicationOffers",
+		"ListApplicationOffers",
+	),
+	"Block":  set.NewStrings("List"),
+	"Bundle": set.NewStrings("GetChanges"),
+	"Charms": set.NewStrings(
+		"CharmInfo",
+		"IsMetered",
+		"List",
+	),
+	"Client": set.NewStrings(
+		"APIHostPorts",
+		"AgentVersion",
+		"FindTools",
+		"FullStatus",
+		"GetBundleChanges",
+		"GetModelConstraints",
+		"ModelInfo",
+		"ModelUserInfo",
+		"PrivateAddress",
+		"PublicAddress",
+		"ResolveCharms",
+		"StatusAt",
+		"StatusHistory",
+		"WatchAll",
+	),
+	"Cloud": set.NewStrings(
+		"Cloud",
+		"Clouds",
+		"DefaultCloud",
+		"InstanceTypes",
+	),
+	"KeyManager":     set.NewStrings("ListKeys"),
+	"MachineManager": set.NewStrings("InstanceTypes"),
+	"ModelConfig": set.NewStrings(
+		"ModelGet",
+		"SLALevel",
+	),
+	"ModelManager": set.NewStrings(
+		"ListModels",
+		"ModelInfo",
+	),
+	"Resources": set.NewStrings("ListResources"),
+	"SSHClient": set.NewStrings(
+		"AllAddresses",
+		"PrivateAddress",
+		"Proxy",
+		"PublicAddress",
+		"PublicKeys",
+	),
+	"Spaces": set.NewStrings("ListSpaces"),
+	"Storage": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StorageDetails",
+	),
+	"Subnets": set.NewStrings(
+		"AllSpaces",
+		"AllZones",
+		"ListSubnets",
+	),
+	"UserManager": set.NewStrings("UserInfo"),
+}
+
+// tokenRunActionsCalls holds the facade methods allowed by the
+// run-actions capability, keyed by facade name. Running commands and
+// introspecting agents require admin access, and are not allowed.
+var tokenRunActionsCalls = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"ActionArtifactContent",
+		"ActionArtifacts",
+		"Actions",
+		"ApplicationsCharmsActions",
+		"Cancel",
+		"Enqueue",
+		"FindActionTagsByPrefix",
+		"FindActionsByNames",
+		"ListActionSchedules",
+		"ListActions",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+		"RemoveActionSchedules",
+		"ScheduleActions",
+	),
+}
+
+// tokenWriteCalls holds the facade methods that change a model which
+// are allowed by the write capability, keyed by facade name. Tokens
+// never manage API tokens, users, SSH keys or access, provision
+// machines by hand, upgrade models, or change controller config or
+// blocks, so none of those methods are included.
+var tokenWriteCalls = map[string]set.Strings{
+	"Annotations": set.NewStrings(
+		"Set",
+		"SetStructured",
+	),
+	"Application": set.NewStrings(
+		"AddRelation",
+		"AddUnits",
+		"Consume",
+		"Deploy",
+		"Destroy",
+		"DestroyApplication",
+		"DestroyConsumedApplications",
+		"DestroyRelation",
+		"DestroyUnit",
+		"DestroyUnits",
+		"Expose",
+		"MoveUnits",
+		"Set",
+		"SetCharm",
+		"SetConstraints",
+		"SetHookRetryPolicies",
+		"SetMetricCredentials",
+		"SetProxyOverrides",
+		"SetRelationsSuspended",
+		"Unexpose",
+		"Unset",
+		"Update",
+		"UpdateApplicationSeries",
+		"ValidateConfig",
+	),
+	"ApplicationOffers": set.NewStrings(
+		"DestroyOffers",
+		"Offer",
+	),
+	"Client": set.NewStrings(
+		"AddCharm",
+		"AddCharmWithAuthorization",
+		"AddMachines",
+		"DestroyMachines",
+		"Resolved",
+		"RetryProvisioning",
+		"SetModelConstraints",
+	),
+	"MachineManager": set.NewStrings(
+		"AddMachines",
+		"DestroyMachine",
+		"DestroyMachineWithParams",
+		"ForceDestroyMachine",
+		"UpdateMachineSeries",
+	),
+	"ModelConfig": set.NewStrings(
+		"ModelSet",
+		"ModelUnset",
+	),
+	"Resources": set.NewStrings("AddPendingResources"),
+	"Spaces": set.NewStrings(
+		"CreateSpaces",
+		"ReloadSpaces",
+	),
+	"Storage": set.NewStrings(
+		"AddToUnit",
+		"Attach",
+		"CreatePool",
+		"Destroy",
+		"Detach",
+		"Import",
+		"Remove",
+		"RemovePool",
+		"Resize",
+		"UpdatePool",
+	),
+	"Subnets": set.NewStrings("AddSubnets"),
+}
+
+// tokenCapabilityCalls holds the tables of facade methods allowed by
+// each API token capability. The write capability also allows the
+// calls allowed by the read and run-actions capabilities.
+var tokenCapabilityCalls = map[string][]map[string]set.Strings{
+	apitoken.Read:       {tokenReadCalls},
+	apitoken.RunActions: {tokenRunActionsCalls},
+	apitoken.Write:      {tokenReadCalls, tokenRunActionsCalls, tokenWriteCalls},
+}
+
+// tokenCapabilitiesOnly returns a check function for restrictRoot
+// that allows only the calls permitted by the given API token
+// capabilities. Any facade method not listed for one of the
+// capabilities, including those of facades added later, is denied.
+func tokenCapabilitiesOnly(capabilities []string) func(string, string) error {
+	tables := []map[string]set.Strings{tokenAlwaysAllowedCalls}
+	for _, capability := range capabilities {
+		tables = append(tables, tokenCapabilityCalls[capability]...)
+	}
+	return func(facadeName, methodName string) error {
+		for _, calls := range tables {
+			if methods, ok := calls[facadeName]; ok && methods.Contains(methodName) {
+				return nil
+			}
+		}
+		return errors.Annotatef(common.ErrPerm, "%s.%s not allowed by API token capabilities %s",
+			facadeName, methodName, strings.Join(capabilities, ","))
+	}
+}
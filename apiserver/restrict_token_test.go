@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/testing"
+)
+
+type restrictTokenSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictTokenSuite{})
+
+func (s *restrictTokenSuite) TestRead(c *gc.C) {
+	root := apiserver.TestingTokenRoot("read")
+	s.assertAllowed(c, root, "Client", 1, "FullStatus")
+	s.assertAllowed(c, root, "Client", 1, "ModelInfo")
+	s.assertAllowed(c, root, "Application", 10, "GetConfig")
+	s.assertAllowed(c, root, "Action", 5, "Actions")
+	s.assertAllowed(c, root, "AllWatcher", 1, "Next")
+	s.assertAllowed(c, root, "Pinger", 1, "Ping")
+	s.assertDenied(c, root, "Application", 10, "Set", `Application.Set not allowed by API token capabilities read: permission denied`)
+	s.assertDenied(c, root, "Action", 5, "Enqueue", `Action.Enqueue not allowed by API token capabilities read: permission denied`)
+}
+
+func (s *restrictTokenSuite) TestReadDeniesUnlistedReads(c *gc.C) {
+	// Methods are only allowed if they are listed, whatever
+	// their names suggest.
+	root := apiserver.TestingTokenRoot("read")
+	s.assertDenied(c, root, "Controller", 8, "GetControllerAccess", `Controller.GetControllerAccess not allowed by API token capabilities read: permission denied`)
+	s.assertDenied(c, root, "Cloud", 1, "Credential", `Cloud.Credential not allowed by API token capabilities read: permission denied`)
+}
+
+func (s *restrictTokenSuite) TestRunActions(c *gc.C) {
+	root := apiserver.TestingTokenRoot("read", "run-actions")
+	s.assertAllowed(c, root, "Action", 5, "Enqueue")
+	s.assertAllowed(c, root, "Action", 5, "Cancel")
+	s.assertAllowed(c, root, "Client", 1, "FullStatus")
+	s.assertDenied(c, root, "Application", 10, "Set", `Application.Set not allowed by API token capabilities read,run-actions: permission denied`)
+}
+
+func (s *restrictTokenSuite) TestRunActionsCannotRunOrIntrospect(c *gc.C) {
+	root := apiserver.TestingTokenRoot("run-actions")
+	for _, method := range []string{"Introspect", "Run", "RunOnAllMachines"} {
+		s.assertDenied(c, root, "Action", 5, method, `Action.`+method+` not allowed by API token capabilities run-actions: permission denied`)
+	}
+}
+
+func (s *restrictTokenSuite) TestWrite(c *gc.C) {
+	root := apiserver.TestingTokenRoot("write")
+	s.assertAllowed(c, root, "Application", 10, "Set")
+	s.assertAllowed(c, root, "Action", 5, "Enqueue")
+	s.assertAllowed(c, root, "Client", 1, "FullStatus")
+	s.assertAllowed(c, root, "UserManager", 3, "UserInfo")
+	s.assertDenied(c, root, "Action", 5, "Introspect", `Action.Introspect not allowed by API token capabilities write: permission denied`)
+}
+
+func (s *restrictTokenSuite) TestWriteCannotManageAccess(c *gc.C) {
+	root := apiserver.TestingTokenRoot("write")
+	for _, call := range []struct {
+		facade  string
+		version int
+		method  string
+	}{
+		{"APITokens", 1, "AddTokens"},
+		{"UserManager", 3, "AddUser"},
+		{"UserManager", 3, "EnableUser"},
+		{"UserManager", 3, "DisableUser"},
+		{"UserManager", 3, "RemoveUser"},
+		{"UserManager", 3, "UnlockUser"},
+		{"UserManager", 3, "SetPassword"},
+		{"KeyManager", 1, "AddKeys"},
+		{"ModelManager", 4, "ModifyModelAccess"},
+		{"Controller", 8, "ModifyControllerAccess"},
+		{"Controller", 8, "ConfigSet"},
+		{"ApplicationOffers", 1, "ModifyOfferAccess"},
+	} {
+		c.Logf("%s.%s", call.facade, call.method)
+		s.assertDenied(c, root, call.facade, call.version, call.method,
+			call.facade+"."+call.method+` not allowed by API token capabilities write: permission denied`)
+	}
+}
+
+func (s *restrictTokenSuite) assertAllowed(c *gc.C, root rpc.Root, facadeName string, version int, method string) {
+	caller, err := root.FindMethod(facadeName, version, method)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
+
+func (s *restrictTokenSuite) assertDenied(c *gc.C, root rpc.Root, facadeName string, version int, method, expect string) {
+	caller, err := root.FindMethod(facadeName, version, method)
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	c.Check(caller, gc.IsNil)
+}
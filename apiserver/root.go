@@ -4,6 +4,7 @@
 package apiserver
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/modelcache"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
@@ -102,6 +104,11 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if srv.modelCache != nil {
+		if err := r.resources.RegisterNamed(modelcache.ResourceName, srv.modelCache); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return r, nil
 }
 
@@ -143,12 +150,12 @@ func (s *srvCaller) ResultType() reflect.Type {
 
 // Call takes the object Id and an instance of ParamsType to create an object and place
 // a call on its method. It then returns an instance of ResultType.
-func (s *srvCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	objVal, err := s.creator(objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return s.objMethod.Call(objVal, arg)
+	return s.objMethod.Call(ctx, objVal, arg)
 }
 
 // apiRoot implements basic method dispatching to the facade registry.
@@ -200,6 +207,12 @@ func restrictAPIRoot(
 	} else {
 		apiRoot = restrictRoot(apiRoot, modelFacadesOnly)
 	}
+	if auth.tokenCapabilities != nil {
+		apiRoot = restrictRoot(apiRoot, tokenCapabilitiesOnly(auth.tokenCapabilities))
+	}
+	if auth.agentTokenRecovery {
+		apiRoot = restrictRoot(apiRoot, agentTokenRecoveryMethodsOnly)
+	}
 	return apiRoot, nil
 }
 
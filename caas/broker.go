@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package caas defines the interface between Juju and container
+// orchestration clouds, such as Kubernetes, on which CAAS models run.
+package caas
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/status"
+)
+
+// ContainerEnvironProvider opens brokers for the models hosted on a
+// type of container orchestration cloud.
+type ContainerEnvironProvider interface {
+	// Open opens a broker for the model described by the given
+	// parameters.
+	Open(args OpenParams) (Broker, error)
+}
+
+// OpenParams contains the parameters for ContainerEnvironProvider.Open.
+type OpenParams struct {
+	// Cloud is the specification of the cloud hosting the model.
+	Cloud environs.CloudSpec
+
+	// Namespace is the name of the namespace in which the model's
+	// resources are created.
+	Namespace string
+}
+
+// Broker creates and removes the cloud resources that run the
+// applications of a CAAS model.
+type Broker interface {
+	// EnsureNamespace ensures that the model's namespace exists.
+	EnsureNamespace() error
+
+	// Destroy removes the model's namespace, and all of the
+	// resources in it.
+	Destroy() error
+
+	// EnsureOperator creates or updates the operator for the named
+	// application. The operator runs the uniter for the application's
+	// units.
+	EnsureOperator(appName string, config *OperatorConfig) error
+
+	// DeleteOperator removes the operator for the named application.
+	DeleteOperator(appName string) error
+
+	// EnsureUnit creates or updates the workload for the named unit
+	// of the named application.
+	EnsureUnit(appName, unitName string, spec *ContainerSpec) error
+
+	// DeleteUnit removes the workload of the named unit.
+	DeleteUnit(unitName string) error
+
+	// EnsureService creates or updates the service through which
+	// the named application's units are reached.
+	EnsureService(appName string, params *ServiceParams) error
+
+	// DeleteService removes the service of the named application.
+	DeleteService(appName string) error
+
+	// Units returns the units of the named application.
+	Units(appName string) ([]Unit, error)
+}
+
+// OperatorConfig is the configuration of an application operator.
+type OperatorConfig struct {
+	// OperatorImagePath is the path of the image containing the
+	// Juju agent that the operator runs.
+	OperatorImagePath string
+
+	// AgentConf is the contents of the operator's agent
+	// configuration file.
+	AgentConf []byte
+}
+
+// ContainerSpec describes the container that runs a unit's workload.
+type ContainerSpec struct {
+	// ImageName is the name of the container image.
+	ImageName string
+
+	// Ports are the ports that the container listens on.
+	Ports []ContainerPort
+
+	// Config contains the environment variables for the container.
+	Config map[string]string
+
+	// Filesystems are the filesystems mounted in the container.
+	Filesystems []FilesystemParams
+}
+
+// ContainerPort is a port that a container listens on.
+type ContainerPort struct {
+	// Name is the name of the port, which must be unique within
+	// the container.
+	Name string
+
+	// ContainerPort is the port number.
+	ContainerPort int
+
+	// Protocol is the protocol of the port, either TCP or UDP.
+	Protocol string
+}
+
+// FilesystemParams describes a filesystem to be provisioned for, and
+// mounted in, a unit's container.
+type FilesystemParams struct {
+	// StorageName is the name of the storage that the filesystem
+	// is provisioned for.
+	StorageName string
+
+	// Size is the size of the filesystem, in MiB.
+	Size uint64
+
+	// StorageClass is the name of the cloud's storage class to
+	// provision the filesystem with. If empty, the cloud's default
+	// is used.
+	StorageClass string
+
+	// Path is the path at which the filesystem is mounted.
+	Path string
+}
+
+// ServiceParams describes the service for an application.
+type ServiceParams struct {
+	// Ports are the ports of the application's units that are
+	// exposed by the service.
+	Ports []ContainerPort
+
+	// Exposed reports whether the service is reachable from outside
+	// of the cloud.
+	Exposed bool
+}
+
+// Unit is a unit of an application running on a CAAS cloud.
+type Unit struct {
+	// Id is the cloud's identifier for the unit's workload.
+	Id string
+
+	// UnitName is the name of the unit.
+	UnitName string
+
+	// Address is the address of the unit's workload, if it has one.
+	Address string
+
+	// Status is the status of the unit's workload.
+	Status status.StatusInfo
+}
+
+var (
+	providersMu sync.Mutex
+	providers   = make(map[string]ContainerEnvironProvider)
+)
+
+// RegisterContainerProvider registers a provider for the given cloud
+// type. It panics if a provider is already registered for the type.
+func RegisterContainerProvider(cloudType string, p ContainerEnvironProvider) (unregister func()) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, ok := providers[cloudType]; ok {
+		panic(fmt.Errorf("juju: duplicate CAAS provider type %q", cloudType))
+	}
+	providers[cloudType] = p
+	return func() {
+		providersMu.Lock()
+		defer providersMu.Unlock()
+		delete(providers, cloudType)
+	}
+}
+
+// RegisteredProviders returns the cloud types for which CAAS providers
+// are registered.
+func RegisteredProviders() []string {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	var result []string
+	for cloudType := range providers {
+		result = append(result, cloudType)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// New returns a broker for the model described by the parameters,
+// using the provider registered for the cloud's type.
+func New(args OpenParams) (Broker, error) {
+	providersMu.Lock()
+	p, ok := providers[args.Cloud.Type]
+	providersMu.Unlock()
+	if !ok {
+		return nil, errors.NotFoundf("CAAS provider %q", args.Cloud.Type)
+	}
+	broker, err := p.Open(args)
+	return broker, errors.Trace(err)
+}
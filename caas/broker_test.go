@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs"
+)
+
+type brokerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&brokerSuite{})
+
+type fakeProvider struct {
+	testing.Stub
+	broker caas.Broker
+}
+
+func (p *fakeProvider) Open(args caas.OpenParams) (caas.Broker, error) {
+	p.MethodCall(p, "Open", args)
+	return p.broker, p.NextErr()
+}
+
+func (s *brokerSuite) TestNew(c *gc.C) {
+	p := &fakeProvider{}
+	unregister := caas.RegisterContainerProvider("fake", p)
+	defer unregister()
+	c.Assert(caas.RegisteredProviders(), jc.DeepEquals, []string{"fake"})
+
+	args := caas.OpenParams{
+		Cloud:     environs.CloudSpec{Type: "fake"},
+		Namespace: "default",
+	}
+	_, err := caas.New(args)
+	c.Assert(err, jc.ErrorIsNil)
+	p.CheckCall(c, 0, "Open", args)
+}
+
+func (s *brokerSuite) TestNewUnknownType(c *gc.C) {
+	_, err := caas.New(caas.OpenParams{Cloud: environs.CloudSpec{Type: "unknown"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `CAAS provider "unknown" not found`)
+}
+
+func (s *brokerSuite) TestRegisterDuplicate(c *gc.C) {
+	unregister := caas.RegisterContainerProvider("fake", &fakeProvider{})
+	defer unregister()
+	c.Assert(func() {
+		caas.RegisterContainerProvider("fake", &fakeProvider{})
+	}, gc.PanicMatches, `juju: duplicate CAAS provider type "fake"`)
+}
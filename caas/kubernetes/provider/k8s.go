@@ -0,0 +1,417 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"k8s.io/client-go/kubernetes"
+	k8serrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/status"
+)
+
+const (
+	labelApplication = "juju-application"
+	labelUnit        = "juju-unit"
+	labelOperator    = "juju-operator"
+
+	// storageClassAnnotation is the annotation used to select the
+	// storage class of a persistent volume claim.
+	storageClassAnnotation = "volume.beta.kubernetes.io/storage-class"
+
+	// operatorContainerName is the name of the container in an
+	// operator pod that runs the Juju agent.
+	operatorContainerName = "juju-operator"
+
+	// agentConfFile is the name of the agent configuration file in
+	// the operator's config map.
+	agentConfFile = "agent.conf"
+)
+
+var _ caas.Broker = (*kubernetesClient)(nil)
+
+type kubernetesClient struct {
+	kubernetes.Interface
+
+	// namespace is the namespace in which the model's resources
+	// are created.
+	namespace string
+}
+
+// EnsureNamespace is part of the caas.Broker interface.
+func (k *kubernetesClient) EnsureNamespace() error {
+	ns := &v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: k.namespace}}
+	_, err := k.CoreV1().Namespaces().Create(ns)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "creating namespace %q", k.namespace)
+}
+
+// Destroy is part of the caas.Broker interface. Deleting the namespace
+// deletes all of the resources in it.
+func (k *kubernetesClient) Destroy() error {
+	err := k.CoreV1().Namespaces().Delete(k.namespace, &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "deleting namespace %q", k.namespace)
+}
+
+// EnsureOperator is part of the caas.Broker interface. The operator is
+// a pod running the Juju agent, which in turn runs the uniter for the
+// application's units. The agent's configuration is held in a config
+// map, which is mounted in the pod.
+func (k *kubernetesClient) EnsureOperator(appName string, config *caas.OperatorConfig) error {
+	configMapName := operatorConfigMapName(appName)
+	configMap := &v1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   configMapName,
+			Labels: map[string]string{labelOperator: appName},
+		},
+		Data: map[string]string{
+			agentConfFile: string(config.AgentConf),
+		},
+	}
+	if err := k.ensureConfigMap(configMap); err != nil {
+		return errors.Annotate(err, "creating operator config map")
+	}
+	pod := operatorPod(appName, config.OperatorImagePath, configMapName)
+	if err := k.ensurePod(pod); err != nil {
+		return errors.Annotate(err, "creating operator pod")
+	}
+	return nil
+}
+
+// DeleteOperator is part of the caas.Broker interface.
+func (k *kubernetesClient) DeleteOperator(appName string) error {
+	if err := k.deletePod(operatorPodName(appName)); err != nil {
+		return errors.Trace(err)
+	}
+	err := k.CoreV1().ConfigMaps(k.namespace).Delete(operatorConfigMapName(appName), &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// EnsureUnit is part of the caas.Broker interface. The unit's workload
+// runs in a pod, with a persistent volume claim for each of the unit's
+// filesystems.
+func (k *kubernetesClient) EnsureUnit(appName, unitName string, spec *caas.ContainerSpec) error {
+	podName := unitPodName(unitName)
+	pod := &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: podName,
+			Labels: map[string]string{
+				labelApplication: appName,
+				labelUnit:        podName,
+			},
+			Annotations: map[string]string{
+				labelUnit: unitName,
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:            appName,
+				Image:           spec.ImageName,
+				ImagePullPolicy: v1.PullIfNotPresent,
+				Ports:           containerPorts(spec.Ports),
+				Env:             containerEnv(spec.Config),
+			}},
+		},
+	}
+	for i, fs := range spec.Filesystems {
+		claim, err := k.ensurePersistentVolumeClaim(unitName, fs)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume claim for storage %q", fs.StorageName)
+		}
+		volumeName := fmt.Sprintf("juju-%s-%d", fs.StorageName, i)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claim.Name,
+				},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: fs.Path,
+		})
+	}
+	return errors.Annotatef(k.ensurePod(pod), "creating pod for unit %q", unitName)
+}
+
+// DeleteUnit is part of the caas.Broker interface. The unit's
+// persistent volume claims are left in place, so that the storage
+// may be reattached.
+func (k *kubernetesClient) DeleteUnit(unitName string) error {
+	return errors.Trace(k.deletePod(unitPodName(unitName)))
+}
+
+// EnsureService is part of the caas.Broker interface. Exposed services
+// are given an external load balancer.
+func (k *kubernetesClient) EnsureService(appName string, params *caas.ServiceParams) error {
+	serviceType := v1.ServiceTypeClusterIP
+	if params.Exposed {
+		serviceType = v1.ServiceTypeLoadBalancer
+	}
+	var ports []v1.ServicePort
+	for _, p := range params.Ports {
+		ports = append(ports, v1.ServicePort{
+			Name:       p.Name,
+			Protocol:   protocol(p.Protocol),
+			Port:       int32(p.ContainerPort),
+			TargetPort: intstr.FromInt(p.ContainerPort),
+		})
+	}
+	service := &v1.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   serviceName(appName),
+			Labels: map[string]string{labelApplication: appName},
+		},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{labelApplication: appName},
+			Type:     serviceType,
+			Ports:    ports,
+		},
+	}
+
+	services := k.CoreV1().Services(k.namespace)
+	existing, err := services.Get(service.Name)
+	if k8serrors.IsNotFound(err) {
+		_, err = services.Create(service)
+		return errors.Annotatef(err, "creating service for %q", appName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// The cluster IP assigned to the service must be preserved.
+	existing.Spec.Type = service.Spec.Type
+	existing.Spec.Ports = service.Spec.Ports
+	existing.Spec.Selector = service.Spec.Selector
+	_, err = services.Update(existing)
+	return errors.Annotatef(err, "updating service for %q", appName)
+}
+
+// DeleteService is part of the caas.Broker interface.
+func (k *kubernetesClient) DeleteService(appName string) error {
+	err := k.CoreV1().Services(k.namespace).Delete(serviceName(appName), &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// Units is part of the caas.Broker interface.
+func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
+	pods, err := k.CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: labelApplication + "=" + appName,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units []caas.Unit
+	for _, pod := range pods.Items {
+		units = append(units, caas.Unit{
+			Id:       pod.Name,
+			UnitName: pod.Annotations[labelUnit],
+			Address:  pod.Status.PodIP,
+			Status:   podStatus(pod.Status),
+		})
+	}
+	return units, nil
+}
+
+// ensurePod creates the pod if it does not exist. The only part of an
+// existing pod's spec that may be changed is its containers' images,
+// so only those are updated.
+func (k *kubernetesClient) ensurePod(pod *v1.Pod) error {
+	pods := k.CoreV1().Pods(k.namespace)
+	existing, err := pods.Get(pod.Name)
+	if k8serrors.IsNotFound(err) {
+		_, err = pods.Create(pod)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	images := make(map[string]string)
+	for _, container := range pod.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+	for i, container := range existing.Spec.Containers {
+		if image, ok := images[container.Name]; ok {
+			existing.Spec.Containers[i].Image = image
+		}
+	}
+	_, err = pods.Update(existing)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePod(name string) error {
+	err := k.CoreV1().Pods(k.namespace).Delete(name, &v1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureConfigMap(configMap *v1.ConfigMap) error {
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
+	_, err := configMaps.Update(configMap)
+	if k8serrors.IsNotFound(err) {
+		_, err = configMaps.Create(configMap)
+	}
+	return errors.Trace(err)
+}
+
+// ensurePersistentVolumeClaim returns the persistent volume claim for
+// the unit's filesystem, creating it if it does not exist.
+func (k *kubernetesClient) ensurePersistentVolumeClaim(unitName string, fs caas.FilesystemParams) (*v1.PersistentVolumeClaim, error) {
+	claims := k.CoreV1().PersistentVolumeClaims(k.namespace)
+	name := fmt.Sprintf("juju-%s-%s", fs.StorageName, unitPodName(unitName)[len("juju-"):])
+	existing, err := claims.Get(name)
+	if err == nil {
+		return existing, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	size, err := resource.ParseQuantity(fmt.Sprintf("%dMi", fs.Size))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	claim := &v1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{labelUnit: unitPodName(unitName)},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: size},
+			},
+		},
+	}
+	if fs.StorageClass != "" {
+		claim.Annotations = map[string]string{storageClassAnnotation: fs.StorageClass}
+	}
+	claim, err = claims.Create(claim)
+	return claim, errors.Trace(err)
+}
+
+func operatorPod(appName, imagePath, configMapName string) *v1.Pod {
+	configVolume := "juju-operator-config"
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   operatorPodName(appName),
+			Labels: map[string]string{labelOperator: appName},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:            operatorContainerName,
+				Image:           imagePath,
+				ImagePullPolicy: v1.PullIfNotPresent,
+				Command:         []string{"/opt/jujud"},
+				Args:            []string{"caasoperator", "--application-name", appName, "--debug"},
+				VolumeMounts: []v1.VolumeMount{{
+					Name:      configVolume,
+					MountPath: "/var/lib/juju/agents/application-" + appName + "/" + agentConfFile,
+					SubPath:   agentConfFile,
+				}},
+			}},
+			Volumes: []v1.Volume{{
+				Name: configVolume,
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: configMapName},
+						Items: []v1.KeyToPath{{
+							Key:  agentConfFile,
+							Path: agentConfFile,
+						}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func containerPorts(ports []caas.ContainerPort) []v1.ContainerPort {
+	var result []v1.ContainerPort
+	for _, p := range ports {
+		result = append(result, v1.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: int32(p.ContainerPort),
+			Protocol:      protocol(p.Protocol),
+		})
+	}
+	return result
+}
+
+func containerEnv(config map[string]string) []v1.EnvVar {
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var env []v1.EnvVar
+	for _, name := range names {
+		env = append(env, v1.EnvVar{Name: name, Value: config[name]})
+	}
+	return env
+}
+
+func protocol(p string) v1.Protocol {
+	if strings.ToUpper(p) == string(v1.ProtocolUDP) {
+		return v1.ProtocolUDP
+	}
+	return v1.ProtocolTCP
+}
+
+// podStatus returns the Juju status of a unit's pod.
+func podStatus(podStatus v1.PodStatus) status.StatusInfo {
+	var jujuStatus status.Status
+	switch podStatus.Phase {
+	case v1.PodRunning:
+		jujuStatus = status.Running
+	case v1.PodPending:
+		jujuStatus = status.Allocating
+	case v1.PodFailed:
+		jujuStatus = status.Error
+	case v1.PodSucceeded:
+		jujuStatus = status.Terminated
+	default:
+		jujuStatus = status.Unknown
+	}
+	return status.StatusInfo{
+		Status:  jujuStatus,
+		Message: podStatus.Message,
+	}
+}
+
+func operatorPodName(appName string) string {
+	return "juju-operator-" + appName
+}
+
+func operatorConfigMapName(appName string) string {
+	return "juju-operator-" + appName + "-config"
+}
+
+func serviceName(appName string) string {
+	return "juju-" + appName
+}
+
+// unitPodName returns the name of the pod for the named unit. Pod
+// names may not contain "/".
+func unitPodName(unitName string) string {
+	return "juju-" + strings.Replace(unitName, "/", "-", -1)
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/status"
+)
+
+type K8sSuite struct {
+	testing.IsolationSuite
+
+	clientset *fake.Clientset
+	broker    caas.Broker
+}
+
+var _ = gc.Suite(&K8sSuite{})
+
+func (s *K8sSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clientset = fake.NewSimpleClientset()
+	s.PatchValue(&newK8sClient, func(*rest.Config) (kubernetes.Interface, error) {
+		return s.clientset, nil
+	})
+	broker, err := caas.New(caas.OpenParams{
+		Cloud:     fakeCloudSpec(),
+		Namespace: "test",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.broker = broker
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"Username": "fred",
+		"Password": "secret",
+	})
+	return environs.CloudSpec{
+		Type:           CAASProviderType,
+		Name:           "k8s",
+		Endpoint:       "https://10.0.0.1:6443",
+		Credential:     &cred,
+		CACertificates: []string{"ca-cert"},
+	}
+}
+
+func (s *K8sSuite) TestNewK8sConfig(c *gc.C) {
+	config, err := newK8sConfig(fakeCloudSpec())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.Host, gc.Equals, "https://10.0.0.1:6443")
+	c.Assert(config.Username, gc.Equals, "fred")
+	c.Assert(config.Password, gc.Equals, "secret")
+	c.Assert(string(config.TLSClientConfig.CAData), gc.Equals, "ca-cert")
+}
+
+func (s *K8sSuite) TestNewK8sConfigToken(c *gc.C) {
+	spec := fakeCloudSpec()
+	cred := cloud.NewCredential(cloud.OAuth2WithCertAuthType, map[string]string{
+		"Token":                 "token",
+		"ClientCertificateData": "cert",
+		"ClientKeyData":         "key",
+	})
+	spec.Credential = &cred
+	config, err := newK8sConfig(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.BearerToken, gc.Equals, "token")
+	c.Assert(string(config.TLSClientConfig.CertData), gc.Equals, "cert")
+	c.Assert(string(config.TLSClientConfig.KeyData), gc.Equals, "key")
+}
+
+func (s *K8sSuite) TestNewK8sConfigUnsupportedAuthType(c *gc.C) {
+	spec := fakeCloudSpec()
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, nil)
+	spec.Credential = &cred
+	_, err := newK8sConfig(spec)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *K8sSuite) TestEnsureNamespace(c *gc.C) {
+	err := s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.EnsureNamespace()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ns, err := s.clientset.CoreV1().Namespaces().Get("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ns.Name, gc.Equals, "test")
+
+	err = s.broker.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.clientset.CoreV1().Namespaces().Get("test")
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *K8sSuite) TestEnsureOperator(c *gc.C) {
+	err := s.broker.EnsureOperator("gitlab", &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator",
+		AgentConf:         []byte("agent-conf-data"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	configMap, err := s.clientset.CoreV1().ConfigMaps("test").Get("juju-operator-gitlab-config")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(configMap.Data, jc.DeepEquals, map[string]string{"agent.conf": "agent-conf-data"})
+
+	pod, err := s.clientset.CoreV1().Pods("test").Get("juju-operator-gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Labels, jc.DeepEquals, map[string]string{"juju-operator": "gitlab"})
+	c.Assert(pod.Spec.Containers, gc.HasLen, 1)
+	container := pod.Spec.Containers[0]
+	c.Assert(container.Image, gc.Equals, "jujusolutions/caas-jujud-operator")
+	c.Assert(container.Args, jc.DeepEquals, []string{"caasoperator", "--application-name", "gitlab", "--debug"})
+	c.Assert(container.VolumeMounts[0].MountPath, gc.Equals, "/var/lib/juju/agents/application-gitlab/agent.conf")
+	c.Assert(pod.Spec.Volumes[0].ConfigMap.Name, gc.Equals, "juju-operator-gitlab-config")
+
+	// Ensuring the operator again updates it in place.
+	err = s.broker.EnsureOperator("gitlab", &caas.OperatorConfig{
+		OperatorImagePath: "jujusolutions/caas-jujud-operator:2.3",
+		AgentConf:         []byte("new-agent-conf-data"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	pod, err = s.clientset.CoreV1().Pods("test").Get("juju-operator-gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Spec.Containers[0].Image, gc.Equals, "jujusolutions/caas-jujud-operator:2.3")
+
+	err = s.broker.DeleteOperator("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.clientset.CoreV1().Pods("test").Get("juju-operator-gitlab")
+	c.Assert(err, gc.NotNil)
+	_, err = s.clientset.CoreV1().ConfigMaps("test").Get("juju-operator-gitlab-config")
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *K8sSuite) TestEnsureUnit(c *gc.C) {
+	err := s.broker.EnsureUnit("gitlab", "gitlab/0", &caas.ContainerSpec{
+		ImageName: "gitlab/latest",
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80, Protocol: "TCP"},
+		},
+		Config: map[string]string{
+			"restart": "always",
+			"attr":    "foo=bar",
+		},
+		Filesystems: []caas.FilesystemParams{{
+			StorageName:  "database",
+			Size:         100,
+			StorageClass: "fast",
+			Path:         "/var/lib/gitlab",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pod, err := s.clientset.CoreV1().Pods("test").Get("juju-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Labels, jc.DeepEquals, map[string]string{
+		"juju-application": "gitlab",
+		"juju-unit":        "juju-gitlab-0",
+	})
+	container := pod.Spec.Containers[0]
+	c.Assert(container.Image, gc.Equals, "gitlab/latest")
+	c.Assert(container.Ports, jc.DeepEquals, []v1.ContainerPort{
+		{Name: "http", ContainerPort: 80, Protocol: v1.ProtocolTCP},
+	})
+	c.Assert(container.Env, jc.DeepEquals, []v1.EnvVar{
+		{Name: "attr", Value: "foo=bar"},
+		{Name: "restart", Value: "always"},
+	})
+	c.Assert(container.VolumeMounts, jc.DeepEquals, []v1.VolumeMount{
+		{Name: "juju-database-0", MountPath: "/var/lib/gitlab"},
+	})
+	c.Assert(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, gc.Equals, "juju-database-gitlab-0")
+
+	claim, err := s.clientset.CoreV1().PersistentVolumeClaims("test").Get("juju-database-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claim.Annotations, jc.DeepEquals, map[string]string{
+		"volume.beta.kubernetes.io/storage-class": "fast",
+	})
+	c.Assert(claim.Spec.AccessModes, jc.DeepEquals, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce})
+	size := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	c.Assert(size.Cmp(resource.MustParse("100Mi")), gc.Equals, 0)
+
+	err = s.broker.DeleteUnit("gitlab/0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.clientset.CoreV1().Pods("test").Get("juju-gitlab-0")
+	c.Assert(err, gc.NotNil)
+
+	// The volume claim is kept, so that the storage can be reattached.
+	_, err = s.clientset.CoreV1().PersistentVolumeClaims("test").Get("juju-database-gitlab-0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sSuite) TestEnsureService(c *gc.C) {
+	err := s.broker.EnsureService("gitlab", &caas.ServiceParams{
+		Ports: []caas.ContainerPort{
+			{Name: "http", ContainerPort: 80},
+			{Name: "dns", ContainerPort: 53, Protocol: "udp"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	service, err := s.clientset.CoreV1().Services("test").Get("juju-gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Spec.Type, gc.Equals, v1.ServiceTypeClusterIP)
+	c.Assert(service.Spec.Selector, jc.DeepEquals, map[string]string{"juju-application": "gitlab"})
+	c.Assert(service.Spec.Ports, gc.HasLen, 2)
+	c.Assert(service.Spec.Ports[0].Port, gc.Equals, int32(80))
+	c.Assert(service.Spec.Ports[0].Protocol, gc.Equals, v1.ProtocolTCP)
+	c.Assert(service.Spec.Ports[1].Protocol, gc.Equals, v1.ProtocolUDP)
+
+	err = s.broker.EnsureService("gitlab", &caas.ServiceParams{
+		Ports:   []caas.ContainerPort{{Name: "http", ContainerPort: 80}},
+		Exposed: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	service, err = s.clientset.CoreV1().Services("test").Get("juju-gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Spec.Type, gc.Equals, v1.ServiceTypeLoadBalancer)
+	c.Assert(service.Spec.Ports, gc.HasLen, 1)
+
+	err = s.broker.DeleteService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.DeleteService("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sSuite) TestUnits(c *gc.C) {
+	for _, pod := range []*v1.Pod{{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "juju-gitlab-0",
+			Labels:      map[string]string{"juju-application": "gitlab"},
+			Annotations: map[string]string{"juju-unit": "gitlab/0"},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.1.1.1"},
+	}, {
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-mysql-0",
+			Labels: map[string]string{"juju-application": "mysql"},
+		},
+	}} {
+		_, err := s.clientset.CoreV1().Pods("test").Create(pod)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	units, err := s.broker.Units("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{{
+		Id:       "juju-gitlab-0",
+		UnitName: "gitlab/0",
+		Address:  "10.1.1.1",
+		Status:   status.StatusInfo{Status: status.Running},
+	}})
+}
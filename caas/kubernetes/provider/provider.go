@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package provider implements a CAAS broker for Kubernetes clusters.
+package provider
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+var logger = loggo.GetLogger("juju.kubernetes.provider")
+
+// CAASProviderType is the cloud type of Kubernetes clusters.
+const CAASProviderType = "kubernetes"
+
+// The credential attributes, as read from the Kubernetes client
+// configuration by "juju add-k8s".
+const (
+	credAttrUsername              = "Username"
+	credAttrPassword              = "Password"
+	credAttrToken                 = "Token"
+	credAttrClientCertificateData = "ClientCertificateData"
+	credAttrClientKeyData         = "ClientKeyData"
+)
+
+type kubernetesEnvironProvider struct{}
+
+var providerInstance = kubernetesEnvironProvider{}
+
+func init() {
+	caas.RegisterContainerProvider(CAASProviderType, providerInstance)
+}
+
+// Open is part of the caas.ContainerEnvironProvider interface.
+func (kubernetesEnvironProvider) Open(args caas.OpenParams) (caas.Broker, error) {
+	if err := args.Cloud.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	if args.Namespace == "" {
+		return nil, errors.NotValidf("empty namespace")
+	}
+	config, err := newK8sConfig(args.Cloud)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := newK8sClient(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &kubernetesClient{
+		Interface: client,
+		namespace: args.Namespace,
+	}, nil
+}
+
+// newK8sClient returns a Kubernetes client for the given configuration.
+// It is a variable so that it may be replaced in tests.
+var newK8sClient = func(config *rest.Config) (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(config)
+}
+
+// newK8sConfig returns the Kubernetes client configuration for the
+// given cloud spec.
+func newK8sConfig(spec environs.CloudSpec) (*rest.Config, error) {
+	if spec.Credential == nil {
+		return nil, errors.NotValidf("missing credential")
+	}
+	attrs := spec.Credential.Attributes()
+	config := &rest.Config{
+		Host: spec.Endpoint,
+		TLSClientConfig: rest.TLSClientConfig{
+			CertData: []byte(attrs[credAttrClientCertificateData]),
+			KeyData:  []byte(attrs[credAttrClientKeyData]),
+		},
+	}
+	if len(spec.CACertificates) > 0 {
+		config.TLSClientConfig.CAData = []byte(spec.CACertificates[0])
+	}
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.UserPassAuthType, cloud.UserPassWithCertAuthType:
+		config.Username = attrs[credAttrUsername]
+		config.Password = attrs[credAttrPassword]
+	case cloud.OAuth2AuthType, cloud.OAuth2WithCertAuthType:
+		config.BearerToken = attrs[credAttrToken]
+	case cloud.CertificateAuthType:
+	default:
+		return nil, errors.NotSupportedf("%q auth-type", authType)
+	}
+	return config, nil
+}
@@ -335,6 +335,7 @@ var defaultCloudDescription = map[string]string{
 	"rackspace":   "Rackspace Cloud",
 	"joyent":      "Joyent Cloud",
 	"cloudsigma":  "CloudSigma Cloud",
+	"equinix":     "Equinix Metal",
 	"lxd":         "LXD Container Hypervisor",
 	"maas":        "Metal As A Service",
 	"openstack":   "Openstack Cloud",
@@ -88,7 +88,7 @@ clouds:
   azure:
     type: azure
     description: Microsoft Azure
-    auth-types: [ interactive, service-principal-secret ]
+    auth-types: [ interactive, service-principal-secret, managed-identity ]
     regions:
       centralus:
         endpoint: https://management.azure.com
@@ -197,7 +197,7 @@ clouds:
   azure-china:
     type: azure
     description: Microsoft Azure China
-    auth-types: [ interactive, service-principal-secret ]
+    auth-types: [ interactive, service-principal-secret, managed-identity ]
     regions:
       chinaeast:
         endpoint: https://management.chinacloudapi.cn
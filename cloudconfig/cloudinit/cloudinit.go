@@ -44,6 +44,7 @@ type cloudConfig struct {
 	// shh_keys				map[SSHKeyType]string
 	// ssh_authorized_keys	[]string
 	// disable_root			bool
+	// ntp					map[string]interface{}
 	//
 	// used only for Ubuntu but implemented as runcmds on CentOS:
 	// apt_proxy			string
@@ -88,6 +89,12 @@ func (cfg *cloudConfig) GetSeries() string {
 	return cfg.series
 }
 
+// getAttrs returns the cloud-init attributes set on the config, so that
+// they may be rendered in another format.
+func (cfg *cloudConfig) getAttrs() map[string]interface{} {
+	return cfg.attrs
+}
+
 // SetAttr is defined on the CloudConfig interface.
 func (cfg *cloudConfig) SetAttr(name string, value interface{}) {
 	cfg.attrs[name] = value
@@ -272,6 +279,22 @@ func (cfg *cloudConfig) Locale() string {
 	return locale
 }
 
+// SetNTPServers is defined on the NTPConfig interface.
+func (cfg *cloudConfig) SetNTPServers(servers []string) {
+	if len(servers) == 0 {
+		cfg.UnsetAttr("ntp")
+		return
+	}
+	cfg.SetAttr("ntp", map[string]interface{}{"servers": servers})
+}
+
+// NTPServers is defined on the NTPConfig interface.
+func (cfg *cloudConfig) NTPServers() []string {
+	ntp, _ := cfg.attrs["ntp"].(map[string]interface{})
+	servers, _ := ntp["servers"].([]string)
+	return servers
+}
+
 // AddMount adds takes arguments for installing a mount point in /etc/fstab
 // The options are of the order and format specific to fstab entries:
 // <device> <mountpoint> <filesystem> <options> <backup setting> <fsck priority>
@@ -8,12 +8,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging"
+	"github.com/juju/utils/packaging/commands"
 	"github.com/juju/utils/packaging/config"
 	"github.com/juju/utils/proxy"
+	"github.com/juju/utils/shell"
 	"gopkg.in/yaml.v2"
 )
 
+func init() {
+	RegisterOS(os.CentOS, newCentOSCloudConfig)
+}
+
 //PackageHelper is the interface for configuring specific parameter of the package manager
 type packageHelper interface {
 	// addPackageProxyCmd is a helper method which returns the corresponding runcmd
@@ -54,6 +61,21 @@ type centOSCloudConfig struct {
 	helper packageHelper
 }
 
+// newCentOSCloudConfig is the Factory for CentOS series.
+func newCentOSCloudConfig(series string) (CloudConfig, error) {
+	renderer, _ := shell.NewRenderer("bash")
+	return &centOSCloudConfig{
+		cloudConfig: &cloudConfig{
+			series:    series,
+			paccmder:  commands.NewYumPackageCommander(),
+			pacconfer: config.NewYumPackagingConfigurer(series),
+			renderer:  renderer,
+			attrs:     make(map[string]interface{}),
+		},
+		helper: centOSHelper{},
+	}, nil
+}
+
 // SetPackageProxy is defined on the PackageProxyConfig interface.
 func (cfg *centOSCloudConfig) SetPackageProxy(url string) {
 	cfg.SetAttr("package_proxy", url)
@@ -5,10 +5,35 @@
 package cloudinit
 
 import (
+	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging/commands"
+	"github.com/juju/utils/packaging/config"
 	"github.com/juju/utils/proxy"
+	"github.com/juju/utils/shell"
 )
 
+func init() {
+	RegisterOS(os.OpenSUSE, newOpenSUSECloudConfig)
+}
+
+// newOpenSUSECloudConfig is the Factory for OpenSUSE series, which
+// share the CentOS cloudconfig type with a different packageHelper.
+func newOpenSUSECloudConfig(series string) (CloudConfig, error) {
+	renderer, _ := shell.NewRenderer("bash")
+	return &centOSCloudConfig{
+		cloudConfig: &cloudConfig{
+			series:    series,
+			paccmder:  commands.NewZypperPackageCommander(),
+			pacconfer: config.NewZypperPackagingConfigurer(series),
+			renderer:  renderer,
+			attrs:     make(map[string]interface{}),
+		},
+		helper: openSUSEHelper{
+			paccmder: commands.NewZypperPackageCommander(),
+		},
+	}, nil
+}
+
 //Implementation of PackageHelper for OpenSUSE
 type openSUSEHelper struct {
 	paccmder commands.PackageCommander
@@ -5,10 +5,14 @@
 package cloudinit_test
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging"
 	sshtesting "github.com/juju/utils/ssh/testing"
 	gc "gopkg.in/check.v1"
@@ -360,6 +364,21 @@ var ctests = []struct {
 			0644,
 		)
 	},
+}, {
+	"NTPServers",
+	map[string]interface{}{"ntp": map[string]interface{}{
+		"servers": []string{"ntp1.example.com", "10.0.0.1"},
+	}},
+	func(cfg cloudinit.CloudConfig) {
+		cfg.SetNTPServers([]string{"ntp1.example.com", "10.0.0.1"})
+	},
+}, {
+	"NTPServers unsets servers",
+	map[string]interface{}{},
+	func(cfg cloudinit.CloudConfig) {
+		cfg.SetNTPServers([]string{"ntp1.example.com"})
+		cfg.SetNTPServers(nil)
+	},
 }, {
 	"ManageEtcHosts",
 	map[string]interface{}{"manage_etc_hosts": true},
@@ -464,6 +483,133 @@ func (S) TestSetOutput(c *gc.C) {
 	}
 }
 
+func (S) TestNTPServers(c *gc.C) {
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.NTPServers(), gc.HasLen, 0)
+	cfg.SetNTPServers([]string{"ntp1.example.com"})
+	c.Assert(cfg.NTPServers(), jc.DeepEquals, []string{"ntp1.example.com"})
+}
+
+func (S) TestRegisterOS(c *gc.C) {
+	original := cloudinit.OSFactory(os.Ubuntu)
+	defer cloudinit.RegisterOS(os.Ubuntu, original)
+	cloudinit.RegisterOS(os.Ubuntu, func(series string) (cloudinit.CloudConfig, error) {
+		cfg, err := original(series)
+		if err != nil {
+			return nil, err
+		}
+		return cloudinit.NewIgnitionConfig(cfg)
+	})
+
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.GetSeries(), gc.Equals, "xenial")
+	data, err := cfg.RenderYAML()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `{"ignition":{"version":"2.1.0"},"passwd":{},"storage":{},"systemd":{}}`)
+}
+
+func (S) TestIgnitionRender(c *gc.C) {
+	base, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := cloudinit.NewIgnitionConfig(base)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.SetSSHAuthorizedKeys(sshtesting.ValidKeyOne.Key + " Juju:user@host")
+	cfg.AddUser(&cloudinit.User{
+		Name:              "ubuntu",
+		Groups:            []string{"adm"},
+		Shell:             "/bin/bash",
+		SSHAuthorizedKeys: sshtesting.ValidKeyTwo.Key + " Juju:another@host",
+		Sudo:              []string{"ALL=(ALL) NOPASSWD:ALL"},
+	})
+	cfg.SetNTPServers([]string{"ntp1.example.com", "10.0.0.1"})
+	cfg.AddBootCmd("echo boot")
+	cfg.AddRunCmd("echo run")
+	cfg.AddPackage("tmux")
+
+	data, err := cfg.RenderYAML()
+	c.Assert(err, jc.ErrorIsNil)
+	var rendered map[string]interface{}
+	err = json.Unmarshal(data, &rendered)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dataURL := func(contents string) string {
+		return "data:;base64," + base64.StdEncoding.EncodeToString([]byte(contents))
+	}
+	unit := func(kind, deps string) string {
+		script := "/var/lib/juju/ignition/" + kind + "cmd.sh"
+		return "[Unit]\nDescription=Juju " + kind + " commands\n" +
+			"ConditionPathExists=!" + script + ".done\n" + deps + "\n\n" +
+			"[Service]\nType=oneshot\nRemainAfterExit=yes\nExecStart=" + script + "\n" +
+			"ExecStartPost=/usr/bin/touch " + script + ".done\n\n" +
+			"[Install]\nWantedBy=multi-user.target\n"
+	}
+	c.Assert(rendered, jc.DeepEquals, map[string]interface{}{
+		"ignition": map[string]interface{}{"version": "2.1.0"},
+		"passwd": map[string]interface{}{"users": []interface{}{
+			map[string]interface{}{
+				"name":              "core",
+				"sshAuthorizedKeys": []interface{}{sshtesting.ValidKeyOne.Key + " Juju:user@host"},
+			},
+			map[string]interface{}{
+				"name":              "ubuntu",
+				"sshAuthorizedKeys": []interface{}{sshtesting.ValidKeyTwo.Key + " Juju:another@host"},
+				"groups":            []interface{}{"adm"},
+				"shell":             "/bin/bash",
+			},
+		}},
+		"storage": map[string]interface{}{"files": []interface{}{
+			map[string]interface{}{
+				"filesystem": "root",
+				"path":       "/etc/sudoers.d/90-juju-ubuntu",
+				"mode":       float64(0440),
+				"contents":   map[string]interface{}{"source": dataURL("ubuntu ALL=(ALL) NOPASSWD:ALL\n")},
+			},
+			map[string]interface{}{
+				"filesystem": "root",
+				"path":       "/etc/systemd/timesyncd.conf",
+				"mode":       float64(0644),
+				"contents":   map[string]interface{}{"source": dataURL("[Time]\nNTP=ntp1.example.com 10.0.0.1\n")},
+			},
+			map[string]interface{}{
+				"filesystem": "root",
+				"path":       "/var/lib/juju/ignition/bootcmd.sh",
+				"mode":       float64(0755),
+				"contents":   map[string]interface{}{"source": dataURL("#!/bin/bash\nset -e\necho boot\n")},
+			},
+			map[string]interface{}{
+				"filesystem": "root",
+				"path":       "/var/lib/juju/ignition/runcmd.sh",
+				"mode":       float64(0755),
+				"contents":   map[string]interface{}{"source": dataURL("#!/bin/bash\nset -e\necho run\n")},
+			},
+		}},
+		"systemd": map[string]interface{}{"units": []interface{}{
+			map[string]interface{}{
+				"name":     "juju-boot.service",
+				"enabled":  true,
+				"contents": unit("boot", "Before=network-pre.target\nWants=network-pre.target"),
+			},
+			map[string]interface{}{
+				"name":     "juju-run.service",
+				"enabled":  true,
+				"contents": unit("run", "After=network-online.target juju-boot.service\nWants=network-online.target"),
+			},
+		}},
+	})
+}
+
+func (S) TestNewUnknownOS(c *gc.C) {
+	original := cloudinit.OSFactory(os.CentOS)
+	defer cloudinit.RegisterOS(os.CentOS, original)
+	delete(cloudinit.OSFactories, os.CentOS)
+
+	_, err := cloudinit.New("centos7")
+	c.Assert(err, gc.ErrorMatches, `cloudconfig for series "centos7" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (S) TestWindowsRender(c *gc.C) {
 	compareOutput := "#ps1_sysnative\r\n\r\npowershell"
 	cfg, err := cloudinit.New("win8")
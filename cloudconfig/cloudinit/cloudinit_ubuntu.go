@@ -10,12 +10,19 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging"
+	"github.com/juju/utils/packaging/commands"
 	"github.com/juju/utils/packaging/config"
 	"github.com/juju/utils/proxy"
+	"github.com/juju/utils/shell"
 	"gopkg.in/yaml.v2"
 )
 
+func init() {
+	RegisterOS(os.Ubuntu, newUbuntuCloudConfig)
+}
+
 // ubuntuCloudConfig is the cloudconfig type specific to Ubuntu machines
 // It simply contains a cloudConfig with the added package management-related
 // methods for the Ubuntu version of cloudinit.
@@ -24,6 +31,20 @@ type ubuntuCloudConfig struct {
 	*cloudConfig
 }
 
+// newUbuntuCloudConfig is the Factory for Ubuntu series.
+func newUbuntuCloudConfig(series string) (CloudConfig, error) {
+	renderer, _ := shell.NewRenderer("bash")
+	return &ubuntuCloudConfig{
+		&cloudConfig{
+			series:    series,
+			paccmder:  commands.NewAptPackageCommander(),
+			pacconfer: config.NewAptPackagingConfigurer(series),
+			renderer:  renderer,
+			attrs:     make(map[string]interface{}),
+		},
+	}, nil
+}
+
 // SetPackageProxy is defined on the PackageProxyConfig interface.
 func (cfg *ubuntuCloudConfig) SetPackageProxy(url string) {
 	cfg.SetAttr("apt_proxy", url)
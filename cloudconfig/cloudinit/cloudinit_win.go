@@ -5,10 +5,16 @@
 package cloudinit
 
 import (
+	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging"
 	"github.com/juju/utils/proxy"
+	"github.com/juju/utils/shell"
 )
 
+func init() {
+	RegisterOS(os.Windows, newWindowsCloudConfig)
+}
+
 // windowsCloudConfig is the cloudconfig type specific to Windows machines.
 // It mostly deals entirely with passing the equivalent of runcmds to
 // cloudbase-init, leaving most of the other functionalities uninmplemented.
@@ -17,6 +23,18 @@ type windowsCloudConfig struct {
 	*cloudConfig
 }
 
+// newWindowsCloudConfig is the Factory for Windows series.
+func newWindowsCloudConfig(series string) (CloudConfig, error) {
+	renderer, _ := shell.NewRenderer("powershell")
+	return &windowsCloudConfig{
+		&cloudConfig{
+			series:   series,
+			renderer: renderer,
+			attrs:    make(map[string]interface{}),
+		},
+	}, nil
+}
+
 // SetPackageProxy is defined on the PackageProxyConfig interface.
 func (cfg *windowsCloudConfig) SetPackageProxy(url string) {
 }
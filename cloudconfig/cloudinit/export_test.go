@@ -0,0 +1,12 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import "github.com/juju/utils/os"
+
+var OSFactories = osFactories
+
+func OSFactory(osType os.OSType) Factory {
+	return osFactories[osType]
+}
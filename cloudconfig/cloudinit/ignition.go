@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// ignitionVersion is the version of the Ignition config
+	// specification that ignitionCloudConfig renders.
+	ignitionVersion = "2.1.0"
+
+	// ignitionScriptDir is the directory holding the scripts that run
+	// the boot and run commands of an Ignition config.
+	ignitionScriptDir = "/var/lib/juju/ignition"
+
+	// ignitionDefaultUser is the user that receives the SSH keys set
+	// with SetSSHAuthorizedKeys, as cloud-init's default user does.
+	ignitionDefaultUser = "core"
+)
+
+// ignitionCloudConfig renders the configuration of the CloudConfig it
+// wraps as an Ignition config, for operating systems such as Flatcar
+// Container Linux which are provisioned by Ignition instead of
+// cloud-init.
+type ignitionCloudConfig struct {
+	CloudConfig
+	attrs func() map[string]interface{}
+}
+
+// NewIgnitionConfig returns a CloudConfig which behaves as cfg, except
+// that RenderYAML renders an Ignition config in place of cloud-config.
+// The config is JSON, which is also valid YAML. cfg must have been
+// created by New or by a Factory defined in this package; a Factory for
+// an Ignition-based operating system may wrap one of those.
+//
+// Users, SSH authorized keys, boot and run commands, written files and
+// NTP servers are rendered. Boot and run commands are written to scripts
+// run by systemd units on first boot. Package settings are not rendered,
+// because such operating systems have no package manager.
+func NewIgnitionConfig(cfg CloudConfig) (CloudConfig, error) {
+	getter, ok := cfg.(interface {
+		getAttrs() map[string]interface{}
+	})
+	if !ok {
+		return nil, errors.NotSupportedf("Ignition rendering of %T", cfg)
+	}
+	return &ignitionCloudConfig{CloudConfig: cfg, attrs: getter.getAttrs}, nil
+}
+
+type ignitionConfig struct {
+	Ignition ignitionMeta    `json:"ignition"`
+	Passwd   ignitionPasswd  `json:"passwd"`
+	Storage  ignitionStorage `json:"storage"`
+	Systemd  ignitionSystemd `json:"systemd"`
+}
+
+type ignitionMeta struct {
+	Version string `json:"version"`
+}
+
+type ignitionPasswd struct {
+	Users []ignitionUser `json:"users,omitempty"`
+}
+
+type ignitionUser struct {
+	Name              string   `json:"name"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	Shell             string   `json:"shell,omitempty"`
+}
+
+type ignitionStorage struct {
+	Files []ignitionFile `json:"files,omitempty"`
+}
+
+type ignitionFile struct {
+	Filesystem string               `json:"filesystem"`
+	Path       string               `json:"path"`
+	Mode       int                  `json:"mode"`
+	Contents   ignitionFileContents `json:"contents"`
+}
+
+type ignitionFileContents struct {
+	Source string `json:"source"`
+}
+
+type ignitionSystemd struct {
+	Units []ignitionUnit `json:"units,omitempty"`
+}
+
+type ignitionUnit struct {
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Contents string `json:"contents"`
+}
+
+// ignitionCommandsUnit is the template of the systemd unit which runs
+// a script of boot or run commands once, on first boot.
+const ignitionCommandsUnit = `[Unit]
+Description=Juju %s commands
+ConditionPathExists=!%s.done
+%s
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=%s
+ExecStartPost=/usr/bin/touch %s.done
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// RenderYAML is defined on the RenderConfig interface.
+func (cfg *ignitionCloudConfig) RenderYAML() ([]byte, error) {
+	attrs := cfg.attrs()
+	config := ignitionConfig{
+		Ignition: ignitionMeta{Version: ignitionVersion},
+	}
+
+	if keys, _ := attrs["ssh_authorized_keys"].([]string); len(keys) > 0 {
+		config.Passwd.Users = append(config.Passwd.Users, ignitionUser{
+			Name:              ignitionDefaultUser,
+			SSHAuthorizedKeys: keys,
+		})
+	}
+	users, _ := attrs["users"].([]map[string]interface{})
+	for _, user := range users {
+		name, _ := user["name"].(string)
+		groups, _ := user["groups"].([]string)
+		shell, _ := user["shell"].(string)
+		keys, _ := user["ssh-authorized-keys"].([]string)
+		config.Passwd.Users = append(config.Passwd.Users, ignitionUser{
+			Name:              name,
+			SSHAuthorizedKeys: keys,
+			Groups:            groups,
+			Shell:             shell,
+		})
+		if sudo, _ := user["sudo"].([]string); len(sudo) > 0 {
+			var lines []string
+			for _, directive := range sudo {
+				lines = append(lines, name+" "+directive)
+			}
+			config.Storage.Files = append(config.Storage.Files, ignitionDataFile(
+				"/etc/sudoers.d/90-juju-"+name, strings.Join(lines, "\n")+"\n", 0440,
+			))
+		}
+	}
+
+	if servers := cfg.NTPServers(); len(servers) > 0 {
+		config.Storage.Files = append(config.Storage.Files, ignitionDataFile(
+			"/etc/systemd/timesyncd.conf",
+			"[Time]\nNTP="+strings.Join(servers, " ")+"\n",
+			0644,
+		))
+	}
+
+	// Boot commands run before the network is up, as cloud-init's
+	// bootcmd does. Written files are written by these commands.
+	for _, cmds := range []struct {
+		kind  string
+		cmds  []string
+		after string
+	}{
+		{"boot", cfg.BootCmds(), "Before=network-pre.target\nWants=network-pre.target"},
+		{"run", cfg.RunCmds(), "After=network-online.target juju-boot.service\nWants=network-online.target"},
+	} {
+		if len(cmds.cmds) == 0 {
+			continue
+		}
+		script := ignitionScriptDir + "/" + cmds.kind + "cmd.sh"
+		config.Storage.Files = append(config.Storage.Files, ignitionDataFile(
+			script,
+			"#!/bin/bash\nset -e\n"+strings.Join(cmds.cmds, "\n")+"\n",
+			0755,
+		))
+		config.Systemd.Units = append(config.Systemd.Units, ignitionUnit{
+			Name:     "juju-" + cmds.kind + ".service",
+			Enabled:  true,
+			Contents: fmt.Sprintf(ignitionCommandsUnit, cmds.kind, script, cmds.after, script, script),
+		})
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// ignitionDataFile returns an Ignition file on the root filesystem with
+// the given path, contents and mode, the contents inlined as a data URL.
+func ignitionDataFile(path, contents string, mode int) ignitionFile {
+	return ignitionFile{
+		Filesystem: "root",
+		Path:       path,
+		Mode:       mode,
+		Contents: ignitionFileContents{
+			Source: "data:;base64," + base64.StdEncoding.EncodeToString([]byte(contents)),
+		},
+	}
+}
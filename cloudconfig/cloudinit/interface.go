@@ -11,7 +11,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/os"
 	"github.com/juju/utils/packaging"
-	"github.com/juju/utils/packaging/commands"
 	"github.com/juju/utils/packaging/config"
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
@@ -55,6 +54,7 @@ type CloudConfig interface {
 	RenderConfig
 	AdvancedPackagingConfig
 	HostnameConfig
+	NTPConfig
 }
 
 // SystemUpdateConfig is the interface for managing all system update options.
@@ -393,62 +393,44 @@ type HostnameConfig interface {
 	ManageEtcHosts(manage bool)
 }
 
+// Factory returns a new CloudConfig with no options set for the given
+// series.
+type Factory func(series string) (CloudConfig, error)
+
+// osFactories holds the Factory registered for each operating system.
+var osFactories = make(map[os.OSType]Factory)
+
+// RegisterOS registers the Factory used by New to create CloudConfigs
+// for series of the given operating system, replacing any Factory
+// already registered for it. Factories defined outside this package
+// may wrap a CloudConfig created by another Factory, for example to
+// render user-data in a different format.
+func RegisterOS(osType os.OSType, factory Factory) {
+	osFactories[osType] = factory
+}
+
+// NTPConfig is the interface for managing time synchronisation.
+type NTPConfig interface {
+	// SetNTPServers sets the NTP servers with which the machine
+	// synchronises its clock, in place of those of the image.
+	// Setting no servers leaves the image's configuration alone.
+	SetNTPServers([]string)
+
+	// NTPServers returns the servers set by SetNTPServers.
+	NTPServers() []string
+}
+
 // New returns a new Config with no options set.
 func New(ser string) (CloudConfig, error) {
 	seriesos, err := series.GetOSFromSeries(ser)
 	if err != nil {
 		return nil, err
 	}
-	switch seriesos {
-	case os.Windows:
-		renderer, _ := shell.NewRenderer("powershell")
-		return &windowsCloudConfig{
-			&cloudConfig{
-				series:   ser,
-				renderer: renderer,
-				attrs:    make(map[string]interface{}),
-			},
-		}, nil
-	case os.Ubuntu:
-		renderer, _ := shell.NewRenderer("bash")
-		return &ubuntuCloudConfig{
-			&cloudConfig{
-				series:    ser,
-				paccmder:  commands.NewAptPackageCommander(),
-				pacconfer: config.NewAptPackagingConfigurer(ser),
-				renderer:  renderer,
-				attrs:     make(map[string]interface{}),
-			},
-		}, nil
-	case os.CentOS:
-		renderer, _ := shell.NewRenderer("bash")
-		return &centOSCloudConfig{
-			cloudConfig: &cloudConfig{
-				series:    ser,
-				paccmder:  commands.NewYumPackageCommander(),
-				pacconfer: config.NewYumPackagingConfigurer(ser),
-				renderer:  renderer,
-				attrs:     make(map[string]interface{}),
-			},
-			helper: centOSHelper{},
-		}, nil
-	case os.OpenSUSE:
-		renderer, _ := shell.NewRenderer("bash")
-		return &centOSCloudConfig{
-			cloudConfig: &cloudConfig{
-				series:    ser,
-				paccmder:  commands.NewZypperPackageCommander(),
-				pacconfer: config.NewZypperPackagingConfigurer(ser),
-				renderer:  renderer,
-				attrs:     make(map[string]interface{}),
-			},
-			helper: openSUSEHelper{
-				paccmder: commands.NewZypperPackageCommander(),
-			},
-		}, nil
-	default:
+	factory, ok := osFactories[seriesos]
+	if !ok {
 		return nil, errors.NotFoundf("cloudconfig for series %q", ser)
 	}
+	return factory(ser)
 }
 
 // SSHKeys contains SSH host keys to configure on a machine.
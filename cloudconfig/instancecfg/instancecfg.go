@@ -105,6 +105,11 @@ type InstanceConfig struct {
 	// cloud config for the instance. If this is not set, hostname uses the default.
 	MachineContainerHostname string
 
+	// CharmLXDProfiles holds the names of the charm LXD profiles to
+	// apply to the instance, if it is an LXD container. The profiles
+	// must already exist.
+	CharmLXDProfiles []string
+
 	// AuthorizedKeys specifies the keys that are allowed to
 	// connect to the instance (see cloudinit.SSHAddAuthorizedKeys)
 	// If no keys are supplied, there can be no ssh access to the node.
@@ -138,6 +143,14 @@ type InstanceConfig struct {
 	// override the default APT sources.
 	AptMirror string
 
+	// YumMirror defines a yum mirror location, which, if specified, will
+	// be used in place of AptMirror on CentOS machines.
+	YumMirror string
+
+	// NTPServers holds the NTP servers with which the machine synchronises
+	// its clock. If empty, the servers configured in the image are used.
+	NTPServers []string
+
 	// The type of Simple Stream to download and deploy on this instance.
 	ImageStream string
 
@@ -822,6 +835,8 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 	); err != nil {
 		return errors.Trace(err)
 	}
+	icfg.YumMirror = cfg.YumMirror()
+	icfg.NTPServers = cfg.NTPServers()
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
@@ -1210,6 +1210,34 @@ func (s *cloudinitSuite) TestAptMirrorNotSet(c *gc.C) {
 	s.testAptMirror(c, environConfig, "")
 }
 
+func (s *cloudinitSuite) TestYumMirrorNotUsedOnUbuntu(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"apt-mirror": "http://my.archive.ubuntu.com/ubuntu",
+		"yum-mirror": "http://mirror.example.com/centos",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.testAptMirror(c, environConfig, "http://my.archive.ubuntu.com/ubuntu")
+}
+
+func (s *cloudinitSuite) TestNTPServers(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"ntp-servers": "ntp1.example.com,ntp2.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	c.Assert(instanceCfg.NTPServers, jc.DeepEquals, []string{"ntp1.example.com", "ntp2.example.com"})
+
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.ConfigureBasic()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudcfg.NTPServers(), jc.DeepEquals, instanceCfg.NTPServers)
+}
+
 func (s *cloudinitSuite) testAptMirror(c *gc.C, cfg *config.Config, expect string) {
 	instanceCfg := s.createInstanceConfig(c, cfg)
 	cloudcfg, err := cloudinit.New("quantal")
@@ -154,6 +154,7 @@ func (w *unixConfigure) ConfigureBasic() error {
 		}
 		w.conf.SetSSHKeys(keys)
 	}
+	w.conf.SetNTPServers(w.icfg.NTPServers)
 
 	w.conf.SetOutput(cloudinit.OutAll, "| tee -a "+w.icfg.CloudInitOutputLog, "")
 	// Create a file in a well-defined location containing the machine's
@@ -233,9 +234,13 @@ func (w *unixConfigure) ConfigureJuju() error {
 		}
 	}
 
+	packageMirror := w.icfg.AptMirror
+	if w.os == os.CentOS && w.icfg.YumMirror != "" {
+		packageMirror = w.icfg.YumMirror
+	}
 	w.conf.AddPackageCommands(
 		w.icfg.AptProxySettings,
-		w.icfg.AptMirror,
+		packageMirror,
 		w.icfg.EnableOSRefreshUpdate,
 		w.icfg.EnableOSUpgrade,
 	)
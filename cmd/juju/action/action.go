@@ -41,6 +41,10 @@ type APIClient interface {
 	// Cancel attempts to cancel a queued up Action from running.
 	Cancel(params.Entities) (params.ActionResults, error)
 
+	// ScheduleActions adds schedules which queue up the given Actions
+	// at regular intervals.
+	ScheduleActions(params.AddActionSchedules) (params.ActionScheduleResults, error)
+
 	// ApplicationCharmActions is a single query which uses ApplicationsCharmsActions to
 	// get the charm.Actions for a single Service by tag.
 	ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error)
@@ -56,6 +60,22 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// ListActions returns the Actions matching the given query, most
+	// recently enqueued first.
+	ListActions(params.ActionQuery) (params.ActionResults, error)
+
+	// ActionArtifacts returns the artifacts stored for each of the
+	// given Actions.
+	ActionArtifacts(params.Entities) (params.ActionArtifactsResults, error)
+
+	// ActionArtifactContent returns the content of each of the given
+	// Action artifacts.
+	ActionArtifactContent(params.ActionArtifactRefs) (params.ActionArtifactContentResults, error)
+
+	// Introspect queues up Actions that query the introspection
+	// sockets of agents running on machines.
+	Introspect(params.IntrospectArgs) (params.ActionResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
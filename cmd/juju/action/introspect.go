@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"encoding/base64"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewIntrospectCommand() cmd.Command {
+	return modelcmd.Wrap(&introspectCommand{})
+}
+
+// introspectCommand queries an agent's introspection socket through
+// the controller.
+type introspectCommand struct {
+	ActionCommandBase
+	machineId string
+	path      string
+	agent     string
+	timeout   time.Duration
+}
+
+const introspectDoc = `
+Query the introspection endpoint of an agent running on the given
+machine, without needing SSH access to it. The controller queues a
+juju-introspect action on the machine, whose machine agent queries the
+introspection socket of the requested agent and returns the response.
+
+By default the machine agent itself is queried; use --agent to query
+the agent of a unit deployed to the machine instead.
+
+Useful endpoints include:
+    depengine                      the dependency engine report
+    metrics                        the agent's Prometheus metrics
+    debug/pprof/goroutine?debug=1  the agent's goroutines
+    debug/pprof/heap               a heap profile
+    debug/pprof/profile            a 30 second CPU profile
+
+Only model administrators may introspect agents.
+
+Examples:
+    juju agent-introspect 0 depengine
+    juju agent-introspect 3 debug/pprof/goroutine?debug=1 --agent mysql/0
+    juju agent-introspect 0 debug/pprof/heap > heap.pprof
+`
+
+func (c *introspectCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "agent-introspect",
+		Args:    "<machine> <endpoint>",
+		Purpose: "Query an agent's introspection endpoint via the controller.",
+		Doc:     introspectDoc,
+	}
+}
+
+func (c *introspectCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.agent, "agent", "", "Unit whose agent is queried (defaults to the machine agent)")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait for the response")
+}
+
+func (c *introspectCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine specified")
+	case 1:
+		return errors.New("no introspection endpoint specified")
+	}
+	c.machineId, c.path = args[0], args[1]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine %q", c.machineId)
+	}
+	if c.agent != "" {
+		if !names.IsValidUnit(c.agent) {
+			return errors.NotValidf("unit %q", c.agent)
+		}
+		c.agent = names.NewUnitTag(c.agent).String()
+	}
+	if c.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *introspectCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{
+			Machine: c.machineId,
+			Agent:   c.agent,
+			Path:    c.path,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	actionTag, err := names.ParseActionTag(results.Results[0].Action.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	result, err := GetActionResult(api, actionTag.Id(), time.NewTimer(c.timeout))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch result.Status {
+	case params.ActionCompleted:
+	case params.ActionPending, params.ActionRunning:
+		return errors.Errorf(
+			"timed out waiting for the response; use \"juju show-action-output %s\" to check later",
+			actionTag.Id(),
+		)
+	default:
+		return errors.Errorf("introspection %s: %s", result.Status, result.Message)
+	}
+
+	code, _ := result.Output["Code"].(string)
+	if code != "200" {
+		if err := writeOutput(ctx.Stderr, result.Output, "Stderr"); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("response returned %s", code)
+	}
+	return writeOutput(ctx.Stdout, result.Output, "Stdout")
+}
+
+// writeOutput writes the output value stored in the action results
+// under the given key, decoding it if necessary.
+func writeOutput(w io.Writer, output map[string]interface{}, key string) error {
+	value, _ := output[key].(string)
+	encoding, _ := output[key+"Encoding"].(string)
+	switch encoding {
+	case "":
+		_, err := io.WriteString(w, value)
+		return err
+	case "base64":
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return errors.Annotatef(err, "decoding %s", strings.ToLower(key))
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return errors.NotSupportedf("%s encoding %q", strings.ToLower(key), encoding)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type IntrospectSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&IntrospectSuite{})
+
+func (s *IntrospectSuite) runIntrospect(c *gc.C, results []params.ActionResult, args ...string) (*fakeAPIClient, string, string, error) {
+	client := makeFakeClient(
+		0*time.Second, // No API delay
+		5*time.Second, // 5 second test timeout
+		tagsForIdPrefix(validActionId, validActionTagString),
+		results,
+		params.ActionsByNames{},
+		"", // No API error
+	)
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	args = append([]string{"-m", "admin"}, args...)
+	ctx, err := cmdtesting.RunCommand(c, action.NewIntrospectCommandForTest(s.store), args...)
+	if ctx == nil {
+		return client, "", "", err
+	}
+	return client, cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), err
+}
+
+func (s *IntrospectSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine specified",
+	}, {
+		args: []string{"0"},
+		err:  "no introspection endpoint specified",
+	}, {
+		args: []string{"foo", "depengine"},
+		err:  `machine "foo" not valid`,
+	}, {
+		args: []string{"0", "depengine", "--agent", "mysql"},
+		err:  `unit "mysql" not valid`,
+	}, {
+		args: []string{"0", "depengine", "--timeout", "0s"},
+		err:  "timeout must be positive",
+	}, {
+		args: []string{"0", "depengine", "metrics"},
+		err:  `unrecognized args: \["metrics"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, _, _, err := s.runIntrospect(c, nil, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *IntrospectSuite) TestIntrospect(c *gc.C) {
+	client, stdout, _, err := s.runIntrospect(c, []params.ActionResult{{
+		Action: &params.Action{Tag: validActionTagString},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"Code":   "200",
+			"Stdout": "engine report\n",
+		},
+	}}, "3", "depengine", "--agent", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout, gc.Equals, "engine report\n")
+	c.Check(client.introspectArgs, jc.DeepEquals, params.IntrospectArgs{
+		Args: []params.IntrospectArg{{
+			Machine: "3",
+			Agent:   "unit-mysql-0",
+			Path:    "depengine",
+		}},
+	})
+}
+
+func (s *IntrospectSuite) TestIntrospectBinaryOutput(c *gc.C) {
+	_, stdout, _, err := s.runIntrospect(c, []params.ActionResult{{
+		Action: &params.Action{Tag: validActionTagString},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"Code":           "200",
+			"Stdout":         "AP8=",
+			"StdoutEncoding": "base64",
+		},
+	}}, "0", "debug/pprof/heap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout, gc.Equals, "\x00\xff")
+}
+
+func (s *IntrospectSuite) TestIntrospectErrorResponse(c *gc.C) {
+	_, _, stderr, err := s.runIntrospect(c, []params.ActionResult{{
+		Action: &params.Action{Tag: validActionTagString},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"Code":   "404",
+			"Stderr": "404 page not found\n",
+		},
+	}}, "0", "missing")
+	c.Assert(err, gc.ErrorMatches, "response returned 404")
+	c.Check(stderr, gc.Equals, "404 page not found\n")
+}
+
+func (s *IntrospectSuite) TestIntrospectFailed(c *gc.C) {
+	_, _, _, err := s.runIntrospect(c, []params.ActionResult{{
+		Action:  &params.Action{Tag: validActionTagString},
+		Status:  params.ActionFailed,
+		Message: "querying machine-0 introspection socket: connection refused",
+	}}, "0", "depengine")
+	c.Assert(err, gc.ErrorMatches, "introspection failed: querying machine-0 introspection socket: connection refused")
+}
+
+func (s *IntrospectSuite) TestIntrospectError(c *gc.C) {
+	_, _, _, err := s.runIntrospect(c, []params.ActionResult{{
+		Error: &params.Error{Message: `machine 42 not found`, Code: params.CodeNotFound},
+	}}, "42", "depengine")
+	c.Assert(err, gc.ErrorMatches, "machine 42 not found")
+}
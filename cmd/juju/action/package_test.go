@@ -124,10 +124,16 @@ type fakeAPIClient struct {
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
 	enqueuedActions    params.Actions
+	scheduledActions   params.AddActionSchedules
+	scheduleResults    []params.ActionScheduleResult
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	actionQuery        params.ActionQuery
+	artifacts          []params.ActionArtifact
+	artifactContent    map[string][]byte
+	introspectArgs     params.IntrospectArgs
 	apiErr             error
 }
 
@@ -148,6 +154,11 @@ func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, erro
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) ScheduleActions(args params.AddActionSchedules) (params.ActionScheduleResults, error) {
+	c.scheduledActions = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) ListAll(args params.Entities) (params.ActionsByReceivers, error) {
 	return params.ActionsByReceivers{
 		Actions: c.actionsByReceivers,
@@ -210,3 +221,38 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) ListActions(query params.ActionQuery) (params.ActionResults, error) {
+	c.actionQuery = query
+	return params.ActionResults{Results: c.actionResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionArtifacts(args params.Entities) (params.ActionArtifactsResults, error) {
+	results := params.ActionArtifactsResults{
+		Results: make([]params.ActionArtifactsResult, len(args.Entities)),
+	}
+	for i := range args.Entities {
+		results.Results[i].Artifacts = c.artifacts
+	}
+	return results, nil
+}
+
+func (c *fakeAPIClient) ActionArtifactContent(args params.ActionArtifactRefs) (params.ActionArtifactContentResults, error) {
+	results := params.ActionArtifactContentResults{
+		Results: make([]params.ActionArtifactContentResult, len(args.Artifacts)),
+	}
+	for i, ref := range args.Artifacts {
+		content, ok := c.artifactContent[ref.Name]
+		if !ok {
+			results.Results[i].Error = &params.Error{Message: "not found", Code: params.CodeNotFound}
+			continue
+		}
+		results.Results[i].Content = content
+	}
+	return results, nil
+}
+
+func (c *fakeAPIClient) Introspect(args params.IntrospectArgs) (params.ActionResults, error) {
+	c.introspectArgs = args
+	return params.ActionResults{Results: c.actionResults}, c.apiErr
+}
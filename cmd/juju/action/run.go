@@ -35,6 +35,7 @@ type runCommand struct {
 	paramsYAML   cmd.FileVar
 	parseStrings bool
 	wait         waitFlag
+	schedule     time.Duration
 	out          cmd.Output
 	args         [][]string
 }
@@ -116,6 +117,11 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --schedule 24h
+...
+A backup action will be queued on mysql/3 every 24 hours, starting
+24 hours from now, until the schedule is removed.
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -128,6 +134,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.DurationVar(&c.schedule, "schedule", 0, "Queue the action repeatedly, at the given interval")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -158,6 +165,12 @@ func (c *runCommand) Init(args []string) error {
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
+	if c.schedule < 0 {
+		return errors.Errorf("--schedule interval %v not valid", c.schedule)
+	}
+	if c.schedule > 0 && (c.wait.forever || c.wait.d > 0) {
+		return errors.New("--schedule and --wait cannot be used together")
+	}
 	c.unitTags = make([]names.UnitTag, len(unitNames))
 	for idx, unitName := range unitNames {
 		c.unitTags[idx] = names.NewUnitTag(unitName)
@@ -243,6 +256,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.schedule > 0 {
+		return c.scheduleActions(ctx, api, actionParams)
+	}
+
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
 		actions[i].Receiver = unitTag.String()
@@ -330,3 +347,39 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, output)
 }
+
+// scheduleActions adds a schedule for the action on each unit, instead
+// of enqueuing it once.
+func (c *runCommand) scheduleActions(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	schedules := make([]params.AddActionSchedule, len(c.unitTags))
+	for i, unitTag := range c.unitTags {
+		schedules[i] = params.AddActionSchedule{
+			Receiver:   unitTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Interval:   c.schedule,
+		}
+	}
+	results, err := api.ScheduleActions(params.AddActionSchedules{Schedules: schedules})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.unitTags) {
+		return errors.New("illegal number of results returned")
+	}
+
+	output := make(map[string]interface{}, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.Schedule == nil {
+			return errors.Errorf("action failed to schedule on %q", c.unitTags[i].Id())
+		}
+		output[c.unitTags[i].Id()] = map[string]string{
+			"schedule-id": result.Schedule.Id,
+			"next-run":    result.Schedule.NextRun.Format(time.RFC3339),
+		}
+	}
+	return c.out.Write(ctx, output)
+}
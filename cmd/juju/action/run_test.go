@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -413,3 +414,47 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestInitSchedule(c *gc.C) {
+	wrappedCommand, command := action.NewRunCommandForTest(s.store)
+	err := cmdtesting.InitCommand(wrappedCommand, []string{"-m", "admin", validUnitId, "some-action", "--schedule", "1h"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.Schedule(), gc.Equals, time.Hour)
+
+	wrappedCommand, _ = action.NewRunCommandForTest(s.store)
+	err = cmdtesting.InitCommand(wrappedCommand, []string{"-m", "admin", validUnitId, "some-action", "--schedule", "1h", "--wait"})
+	c.Check(err, gc.ErrorMatches, "--schedule and --wait cannot be used together")
+}
+
+func (s *RunSuite) TestRunSchedule(c *gc.C) {
+	nextRun := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{Id: "schedule-id", NextRun: nextRun},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", validUnitId, "some-action", "out=name", "--schedule", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.enqueuedActions.Actions, gc.HasLen, 0)
+	c.Check(fakeClient.scheduledActions, jc.DeepEquals, params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Name:       "some-action",
+			Parameters: map[string]interface{}{"out": "name"},
+			Interval:   time.Hour,
+		}},
+	})
+	var output map[string]map[string]string
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, jc.DeepEquals, map[string]map[string]string{
+		validUnitId: {
+			"schedule-id": "schedule-id",
+			"next-run":    "2017-11-01T12:00:00Z",
+		},
+	})
+}
@@ -4,6 +4,11 @@
 package action
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -23,10 +28,11 @@ func NewShowOutputCommand() cmd.Command {
 // showOutputCommand fetches the results of an action by ID.
 type showOutputCommand struct {
 	ActionCommandBase
-	out         cmd.Output
-	requestedId string
-	fullSchema  bool
-	wait        string
+	out           cmd.Output
+	requestedId   string
+	fullSchema    bool
+	wait          string
+	saveArtifacts string
 }
 
 const showOutputDoc = `
@@ -38,6 +44,9 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+Any files stored by the action with action-artifact are listed with the
+results. Use --save-artifacts with a directory to save them there.
 `
 
 // Set up the output.
@@ -45,6 +54,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.StringVar(&c.saveArtifacts, "save-artifacts", "", "Save the action's artifacts to the given directory")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -109,7 +119,93 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	return c.out.Write(ctx, FormatActionResult(result))
+	formatted := FormatActionResult(result)
+	if result.Action != nil {
+		artifacts, err := c.artifacts(api, result.Action.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(artifacts) > 0 {
+			formatted["artifacts"] = formatArtifacts(artifacts)
+		}
+		if c.saveArtifacts != "" {
+			if err := saveArtifacts(ctx, api, result.Action.Tag, artifacts, ctx.AbsPath(c.saveArtifacts)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// artifacts returns the artifacts of the action with the given tag.
+// Controllers that don't support artifacts are treated as having none,
+// unless the artifacts are to be saved.
+func (c *showOutputCommand) artifacts(api APIClient, tag string) ([]params.ActionArtifact, error) {
+	results, err := api.ActionArtifacts(params.Entities{
+		Entities: []params.Entity{{Tag: tag}},
+	})
+	if errors.IsNotSupported(err) && c.saveArtifacts == "" {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Artifacts, nil
+}
+
+func formatArtifacts(artifacts []params.ActionArtifact) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, artifact := range artifacts {
+		out[artifact.Name] = map[string]interface{}{
+			"size":   artifact.Size,
+			"sha256": artifact.SHA256,
+		}
+	}
+	return out
+}
+
+// saveArtifacts fetches the given artifacts of the action with the
+// given tag and writes them to dir, checking their hashes.
+func saveArtifacts(ctx *cmd.Context, api APIClient, tag string, artifacts []params.ActionArtifact, dir string) error {
+	if len(artifacts) == 0 {
+		ctx.Infof("No artifacts to save.")
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	refs := make([]params.ActionArtifactRef, len(artifacts))
+	for i, artifact := range artifacts {
+		refs[i] = params.ActionArtifactRef{ActionTag: tag, Name: artifact.Name}
+	}
+	results, err := api.ActionArtifactContent(params.ActionArtifactRefs{Artifacts: refs})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(refs) {
+		return errors.Errorf("expected %d results, got %d", len(refs), len(results.Results))
+	}
+	for i, result := range results.Results {
+		name := refs[i].Name
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "cannot get artifact %q", name)
+		}
+		hash := sha256.Sum256(result.Content)
+		if hex.EncodeToString(hash[:]) != artifacts[i].SHA256 {
+			return errors.Errorf("artifact %q is corrupt: SHA-256 mismatch", name)
+		}
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, result.Content, 0644); err != nil {
+			return errors.Annotatef(err, "cannot save artifact %q", name)
+		}
+		ctx.Infof("Saved %s", path)
+	}
+	return nil
 }
 
 // GetActionResult tries to repeatedly fetch an action until it is
@@ -5,7 +5,11 @@ package action_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -290,6 +294,61 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunWithArtifacts(c *gc.C) {
+	content := []byte("create table")
+	hash := sha256.Sum256(content)
+	client := makeFakeClient(0, 5*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: params.ActionCompleted,
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		params.ActionsByNames{}, "")
+	client.artifacts = []params.ActionArtifact{{
+		Name:   "dump.sql",
+		Size:   int64(len(content)),
+		SHA256: hex.EncodeToString(hash[:]),
+	}}
+	client.artifactContent = map[string][]byte{"dump.sql": content}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	dir := filepath.Join(c.MkDir(), "artifacts")
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--save-artifacts", dir)
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+artifacts:
+  dump.sql:
+    sha256: `+hex.EncodeToString(hash[:])+`
+    size: 12
+status: completed
+`[1:])
+	path := filepath.Join(dir, "dump.sql")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Saved "+path+"\n")
+	saved, err := ioutil.ReadFile(path)
+	c.Assert(err, gc.IsNil)
+	c.Check(string(saved), gc.Equals, "create table")
+}
+
+func (s *ShowOutputSuite) TestRunSaveArtifactsCorrupt(c *gc.C) {
+	client := makeFakeClient(0, 5*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: params.ActionCompleted,
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		params.ActionsByNames{}, "")
+	client.artifacts = []params.ActionArtifact{{Name: "dump.sql", SHA256: "bad"}}
+	client.artifactContent = map[string][]byte{"dump.sql": []byte("create table")}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--save-artifacts", c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `artifact "dump.sql" is corrupt: SHA-256 mismatch`)
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
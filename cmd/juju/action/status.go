@@ -4,6 +4,7 @@
 package action
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
@@ -26,11 +27,23 @@ type statusCommand struct {
 	out         cmd.Output
 	requestedId string
 	name        string
+	application string
+	statuses    string
+	since       time.Duration
 }
 
 const statusDoc = `
 Show the status of Actions matching given ID, partial ID prefix, or all Actions if no ID is supplied.
 If --name <name> is provided the search will be done by name rather than by ID.
+
+Actions may instead be searched for by the application whose units they
+ran on, by status, and by the time they were queued, using --application,
+--status and --since. Matching Actions are shown most recent first.
+
+Examples:
+
+    juju show-action-status --application mysql --status failed,cancelled
+    juju show-action-status --since 24h
 `
 
 // Set up the output.
@@ -38,6 +51,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.name, "name", "", "Action name")
+	f.StringVar(&c.application, "application", "", "Show Actions of the application's units")
+	f.StringVar(&c.statuses, "status", "", "Comma-separated statuses of Actions to show")
+	f.DurationVar(&c.since, "since", 0, "Show Actions queued within the given duration, e.g. 24h")
 }
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -50,6 +66,18 @@ func (c *statusCommand) Info() *cmd.Info {
 }
 
 func (c *statusCommand) Init(args []string) error {
+	if c.since < 0 {
+		return errors.NotValidf("negative --since duration")
+	}
+	if c.query() {
+		if c.name != "" {
+			return errors.New("--name cannot be used with --application, --status or --since")
+		}
+		if len(args) > 0 {
+			return errors.New("an action ID cannot be used with --application, --status or --since")
+		}
+		return nil
+	}
 	switch len(args) {
 	case 0:
 		c.requestedId = ""
@@ -69,6 +97,10 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.query() {
+		return c.runQuery(ctx, api)
+	}
+
 	if c.name != "" {
 		actions, err := GetActionsByName(api, c.name)
 		if err != nil {
@@ -107,6 +139,32 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, resultsToMap(actions.Results))
 }
 
+// query reports whether actions are to be found with a query rather
+// than by ID or name.
+func (c *statusCommand) query() bool {
+	return c.application != "" || c.statuses != "" || c.since != 0
+}
+
+func (c *statusCommand) runQuery(ctx *cmd.Context, api APIClient) error {
+	query := params.ActionQuery{Application: c.application}
+	for _, status := range strings.Split(c.statuses, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+	if c.since != 0 {
+		query.EnqueuedAfter = time.Now().Add(-c.since)
+	}
+	results, err := api.ListActions(query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) == 0 {
+		return errors.Errorf("no actions found")
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
+
 // resultsToMap is a helper function that takes in a []params.ActionResult
 // and returns a map[string]interface{} ready to be served to the
 // formatter for printing.
@@ -92,6 +92,63 @@ func (s *StatusSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *StatusSuite) TestQuery(c *gc.C) {
+	results := []params.ActionResult{{
+		Status: params.ActionFailed,
+		Action: &params.Action{Tag: validActionTagString, Name: "backup", Receiver: "unit-mysql-0"},
+	}}
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, results, params.ActionsByNames{}, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+	before := time.Now()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "-m", "admin",
+		"--application", "mysql", "--status", "failed, cancelled", "--since", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	query := fakeClient.actionQuery
+	c.Check(query.Application, gc.Equals, "mysql")
+	c.Check(query.Statuses, jc.DeepEquals, []string{"failed", "cancelled"})
+	c.Check(query.EnqueuedAfter.Before(before.Add(-time.Hour)), jc.IsFalse)
+	c.Check(query.EnqueuedAfter.After(time.Now().Add(-time.Hour)), jc.IsFalse)
+
+	out := &bytes.Buffer{}
+	err = cmd.FormatYaml(out, action.ActionResultsToMap(results))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out.String())
+}
+
+func (s *StatusSuite) TestQueryNoResults(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, params.ActionsByNames{}, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, s.subcommand, "-m", "admin", "--status", "pending")
+	c.Assert(err, gc.ErrorMatches, "no actions found")
+}
+
+func (s *StatusSuite) TestQueryInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--application", "mysql", "deadbeef"},
+		err:  "an action ID cannot be used with --application, --status or --since",
+	}, {
+		args: []string{"--status", "failed", "--name", "backup"},
+		err:  "--name cannot be used with --application, --status or --since",
+	}, {
+		args: []string{"--since", "-1h"},
+		err:  "negative --since duration not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command, _ := action.NewStatusCommandForTest(s.store)
+		err := cmdtesting.InitCommand(command, append([]string{"-m", "admin"}, test.args...))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *StatusSuite) runTestCase(c *gc.C, tc statusTestCase) {
 	for _, modelFlag := range s.modelFlags {
 		fakeClient := makeFakeClient(
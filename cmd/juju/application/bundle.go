@@ -106,6 +106,9 @@ func deployBundle(
 		}
 		return nil, errors.Trace(verifyError)
 	}
+	if err := checkProviderCapabilities(apiRoot, bundleCapabilities(data)); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// TODO: move bundle parsing and checking into the handler.
 	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -625,6 +626,23 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidSpace(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot deploy bundle: cannot deploy application "wp": cannot add application "wp": unknown space "public" not valid`)
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleSpacesNotSupported(c *gc.C) {
+	supported := dummy.SetSupportsSpaces(false)
+	s.AddCleanup(func(*gc.C) { dummy.SetSupportsSpaces(supported) })
+
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	err := s.DeployBundleYAML(c, `
+        applications:
+            wp:
+                charm: xenial/wordpress-42
+                num_units: 1
+                bindings:
+                  url: public
+    `)
+	c.Assert(err, gc.ErrorMatches, `cannot deploy bundle: provider "dummy" does not support spaces`)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleWatcherTimeout(c *gc.C) {
 	coretesting.SkipFlaky(c, "lp:1625213")
 	// Inject an "AllWatcher" that never delivers a result.
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/providercapabilities"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+// zonePlacementPrefix is the prefix of placement directives
+// that place machines in an availability zone.
+const zonePlacementPrefix = "zone="
+
+// requiredCapabilities adds to required the provider capabilities
+// needed to honour the given constraints, placement and endpoint
+// bindings.
+func requiredCapabilities(
+	required set.Strings,
+	cons constraints.Value,
+	placement []*instance.Placement,
+	bindings map[string]string,
+) {
+	if len(bindings) > 0 || len(cons.IncludeSpaces()) > 0 {
+		required.Add(string(environs.CapabilitySpaces))
+	}
+	if cons.HasSpot() {
+		required.Add(string(environs.CapabilitySpotInstances))
+	}
+	for _, p := range placement {
+		if p != nil && strings.HasPrefix(p.Directive, zonePlacementPrefix) {
+			required.Add(string(environs.CapabilityAvailabilityZones))
+		}
+	}
+}
+
+// bundleCapabilities returns the provider capabilities needed to
+// deploy the given bundle, which must already have been verified.
+func bundleCapabilities(data *charm.BundleData) set.Strings {
+	required := set.NewStrings()
+	for _, application := range data.Applications {
+		cons, err := constraints.Parse(application.Constraints)
+		if err != nil {
+			continue
+		}
+		requiredCapabilities(required, cons, nil, application.EndpointBindings)
+	}
+	for _, machine := range data.Machines {
+		if machine == nil {
+			continue
+		}
+		cons, err := constraints.Parse(machine.Constraints)
+		if err != nil {
+			continue
+		}
+		requiredCapabilities(required, cons, nil, nil)
+	}
+	return required
+}
+
+// checkProviderCapabilities returns an error if the model's provider
+// does not support all of the required capabilities, so that deploy
+// fails before anything has been added to the model.
+func checkProviderCapabilities(apiRoot base.APICallCloser, required set.Strings) error {
+	if required.IsEmpty() {
+		return nil
+	}
+	client := providercapabilities.NewClient(apiRoot)
+	return errors.Trace(client.CheckCapabilities(required.SortedValues()...))
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -41,6 +42,16 @@ Examples:
     juju config apache2 --file path/to/config.yaml
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
+    juju config mysql --dry-run dataset-size=80%
+    juju config mysql --history
+    juju config mysql --history dataset-size
+
+When --dry-run is specified, the new values are checked against the
+charm's config schema and displayed, but are not applied.
+
+When --history is specified, the recent changes to the application's
+configuration are displayed, oldest first, with the user who made each
+change. If a key is given, only the changes to that key are displayed.
 
 See also:
     deploy
@@ -72,6 +83,8 @@ type configCommand struct {
 	action          func(configCommandAPI, *cmd.Context) error // get, set, or reset action set in  Init
 	applicationName string
 	configFile      cmd.FileVar
+	dryRun          bool
+	history         bool
 	keys            []string
 	reset           []string // Holds the keys to be reset until parsed.
 	resetKeys       []string // Holds the keys to be reset once parsed.
@@ -86,6 +99,8 @@ type configCommandAPI interface {
 	Get(application string) (*params.ApplicationGetResults, error)
 	Set(application string, options map[string]string) error
 	Unset(application string, options []string) error
+	ValidateConfig(application string, options map[string]string, configYAML string) (map[string]interface{}, error)
+	ConfigHistory(application string) ([]params.ConfigChange, error)
 }
 
 // Info is part of the cmd.Command interface.
@@ -104,6 +119,8 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Validate the new values without applying them")
+	f.BoolVar(&c.history, "history", false, "Show the recent changes to the configuration")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -137,14 +154,28 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	var err error
 	switch len(args) {
 	case 0:
-		return c.handleZeroArgs()
+		err = c.handleZeroArgs()
 	case 1:
-		return c.handleOneArg(args)
+		err = c.handleOneArg(args)
 	default:
-		return c.handleArgs(args)
+		err = c.handleArgs(args)
+	}
+	if err != nil {
+		return err
+	}
+	if c.dryRun && (c.action == nil || len(c.resetKeys) > 0 || len(c.keys) > 0) {
+		return errors.New("--dry-run can only be used when setting values")
+	}
+	if c.history {
+		if c.dryRun || len(c.resetKeys) > 0 || c.useFile || len(c.values) > 0 {
+			return errors.New("--history can only be used when getting values")
+		}
+		c.action = c.showHistory
 	}
+	return nil
 }
 
 // handleZeroArgs handles the case where there are no positional args.
@@ -278,6 +309,9 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.dryRun {
+		return c.dryRunConfig(client, ctx, settings, "")
+	}
 
 	result, err := client.Get(c.applicationName)
 	if err != nil {
@@ -316,6 +350,9 @@ func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Cont
 			return err
 		}
 	}
+	if c.dryRun {
+		return c.dryRunConfig(client, ctx, nil, string(b))
+	}
 	return block.ProcessBlockedError(
 		client.Update(
 			params.ApplicationUpdate{
@@ -323,6 +360,16 @@ func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Cont
 				SettingsYAML:    string(b)}), block.BlockChange)
 }
 
+// dryRunConfig checks the new attribute values against the charm's config
+// schema, and displays the values which would be set.
+func (c *configCommand) dryRunConfig(client configCommandAPI, ctx *cmd.Context, settings map[string]string, settingsYAML string) error {
+	changes, err := client.ValidateConfig(c.applicationName, settings, settingsYAML)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, changes)
+}
+
 // getConfig is the run action to return one or all configuration values.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
 	results, err := client.Get(c.applicationName)
@@ -352,6 +399,60 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, resultsMap)
 }
 
+// showHistory is the run action to display the recent changes to the
+// configuration, or to a single value.
+func (c *configCommand) showHistory(client configCommandAPI, ctx *cmd.Context) error {
+	history, err := client.ConfigHistory(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var out []configChange
+	for _, change := range history {
+		entry := configChange{
+			Version: change.Version,
+			Author:  change.Author,
+			Changed: change.Changed.UTC(),
+		}
+		for _, item := range change.Items {
+			if len(c.keys) == 1 && item.Key != c.keys[0] {
+				continue
+			}
+			entry.Changes = append(entry.Changes, configItemChange{
+				Kind:     item.Kind,
+				Key:      item.Key,
+				OldValue: item.OldValue,
+				NewValue: item.NewValue,
+			})
+		}
+		if len(entry.Changes) > 0 {
+			out = append(out, entry)
+		}
+	}
+	if len(out) == 0 {
+		ctx.Infof("No configuration changes recorded.")
+		return nil
+	}
+	return c.out.Write(ctx, out)
+}
+
+// configChange describes a change to an application's configuration
+// for output.
+type configChange struct {
+	Version int64              `yaml:"version" json:"version"`
+	Author  string             `yaml:"author,omitempty" json:"author,omitempty"`
+	Changed time.Time          `yaml:"changed" json:"changed"`
+	Changes []configItemChange `yaml:"changes" json:"changes"`
+}
+
+// configItemChange describes the change of a single configuration
+// value for output.
+type configItemChange struct {
+	Kind     string      `yaml:"kind" json:"kind"`
+	Key      string      `yaml:"key" json:"key"`
+	OldValue interface{} `yaml:"old,omitempty" json:"old,omitempty"`
+	NewValue interface{} `yaml:"new,omitempty" json:"new,omitempty"`
+}
+
 // validateValues reads the values provided as args and validates that they are
 // valid UTF-8.
 func (c *configCommand) validateValues(ctx *cmd.Context) (map[string]string, error) {
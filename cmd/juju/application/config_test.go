@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -18,6 +19,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -379,3 +381,126 @@ func setupConfigFile(c *gc.C, dir string) string {
 	c.Assert(err, jc.ErrorIsNil)
 	return path
 }
+
+func (s *configCommandSuite) TestSetConfigDryRun(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(application.NewConfigCommandForTest(s.fake), ctx, []string{
+		"dummy-application",
+		"--dry-run",
+		"username=hello",
+	})
+	c.Check(code, gc.Equals, 0)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "username: hello\n")
+	c.Check(s.fake.validated, jc.DeepEquals, map[string]string{"username": "hello"})
+	c.Check(s.fake.values["username"], gc.Equals, "admin001")
+}
+
+func (s *configCommandSuite) TestSetConfigDryRunFile(c *gc.C) {
+	ctx := cmdtesting.ContextForDir(c, s.dir)
+	code := cmd.Main(application.NewConfigCommandForTest(s.fake), ctx, []string{
+		"dummy-application",
+		"--dry-run",
+		"--file",
+		"testconfig.yaml"})
+
+	c.Check(code, gc.Equals, 0)
+	c.Check(s.fake.validatedYAML, gc.Equals, yamlConfigValue)
+	c.Check(s.fake.config, gc.Equals, "")
+}
+
+func (s *configCommandSuite) TestSetConfigDryRunInvalid(c *gc.C) {
+	s.assertSetFail(c, s.dir, []string{
+		"--dry-run",
+		"yummy=didgeridoo",
+	}, `unknown option "yummy"`)
+}
+
+func (s *configCommandSuite) TestDryRunInit(c *gc.C) {
+	for _, args := range [][]string{
+		{"app", "--dry-run"},
+		{"app", "--dry-run", "key"},
+		{"app", "--dry-run", "--reset", "key"},
+	} {
+		err := cmdtesting.InitCommand(application.NewConfigCommandForTest(s.fake), args)
+		c.Check(err, gc.ErrorMatches, "--dry-run can only be used when setting values")
+	}
+}
+
+func (s *configCommandSuite) setHistory() {
+	changed := time.Date(2017, 11, 2, 10, 0, 0, 0, time.UTC)
+	s.fake.history = []params.ConfigChange{{
+		Version: 1,
+		Author:  "bob",
+		Changed: changed,
+		Items: []params.ConfigItemChange{
+			{Kind: "modified", Key: "username", OldValue: "admin001", NewValue: "hello"},
+		},
+	}, {
+		Version: 2,
+		Author:  "alice",
+		Changed: changed.Add(time.Hour),
+		Items: []params.ConfigItemChange{
+			{Kind: "added", Key: "title", NewValue: "sir"},
+			{Kind: "deleted", Key: "username", OldValue: "hello"},
+		},
+	}}
+}
+
+func (s *configCommandSuite) TestHistory(c *gc.C) {
+	s.setHistory()
+	ctx, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- version: 1
+  author: bob
+  changed: 2017-11-02T10:00:00Z
+  changes:
+  - kind: modified
+    key: username
+    old: admin001
+    new: hello
+- version: 2
+  author: alice
+  changed: 2017-11-02T11:00:00Z
+  changes:
+  - kind: added
+    key: title
+    new: sir
+  - kind: deleted
+    key: username
+    old: hello
+`[1:])
+}
+
+func (s *configCommandSuite) TestHistoryKey(c *gc.C) {
+	s.setHistory()
+	ctx, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--history", "title")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- version: 2
+  author: alice
+  changed: 2017-11-02T11:00:00Z
+  changes:
+  - kind: added
+    key: title
+    new: sir
+`[1:])
+}
+
+func (s *configCommandSuite) TestHistoryNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No configuration changes recorded.\n")
+}
+
+func (s *configCommandSuite) TestHistoryInit(c *gc.C) {
+	for _, args := range [][]string{
+		{"app", "--history", "key=value"},
+		{"app", "--history", "--reset", "key"},
+		{"app", "--history", "--dry-run", "key=value"},
+	} {
+		err := cmdtesting.InitCommand(application.NewConfigCommandForTest(s.fake), args)
+		c.Check(err, gc.ErrorMatches, "--history can only be used when getting values")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charmrepo.v2"
 	"gopkg.in/juju/charmrepo.v2/csclient"
@@ -688,6 +689,12 @@ func (c *DeployCommand) deployCharm(
 			return errors.New("cannot use --num-units or --to with subordinate application")
 		}
 	}
+	required := set.NewStrings()
+	requiredCapabilities(required, c.Constraints, c.Placement, c.Bindings)
+	if err := checkProviderCapabilities(apiRoot, required); err != nil {
+		return errors.Trace(err)
+	}
+
 	serviceName := c.ApplicationName
 	if serviceName == "" {
 		serviceName = charmInfo.Meta.Name
@@ -47,6 +47,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
@@ -1171,6 +1172,16 @@ func (s *DeployCharmStoreSuite) TestDeployCharmWithSomeEndpointBindingsSpecified
 	})
 }
 
+func (s *DeployCharmStoreSuite) TestDeployCharmWithEndpointBindingsSpacesNotSupported(c *gc.C) {
+	supported := dummy.SetSupportsSpaces(false)
+	s.AddCleanup(func(*gc.C) { dummy.SetSupportsSpaces(supported) })
+
+	testcharms.UploadCharm(c, s.client, "cs:quantal/wordpress-extra-bindings-1", "wordpress-extra-bindings")
+	err := runDeploy(c, "cs:quantal/wordpress-extra-bindings-1", "--bind", "db")
+	c.Assert(err, gc.ErrorMatches, `provider "dummy" does not support spaces`)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
 func (s *DeployCharmStoreSuite) TestDeployCharmsEndpointNotImplemented(c *gc.C) {
 	stub := &jujutesting.Stub{}
 	handler := &testMetricsRegistrationHandler{Stub: stub}
@@ -82,6 +82,30 @@ func NewUpdateSeriesCommandForTest(
 	return modelcmd.Wrap(cmd)
 }
 
+// NewHookRetryCommandForTest returns a HookRetryCommand with the api provided as specified.
+func NewHookRetryCommandForTest(api HookRetryAPI) modelcmd.ModelCommand {
+	cmd := &hookRetryCommand{newAPIFunc: func() (HookRetryAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewProxyOverridesCommandForTest returns a ProxyOverridesCommand with the api provided as specified.
+func NewProxyOverridesCommandForTest(api ProxyOverridesAPI) modelcmd.ModelCommand {
+	cmd := &proxyOverridesCommand{newAPIFunc: func() (ProxyOverridesAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewMoveUnitCommandForTest returns a MoveUnitCommand with the api provided as specified.
+func NewMoveUnitCommandForTest(api MoveUnitAPI) modelcmd.ModelCommand {
+	cmd := &moveUnitCommand{newAPIFunc: func() (MoveUnitAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewSuspendRelationCommandForTest returns a SuspendRelationCommand with the api provided as specified.
 func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI) modelcmd.ModelCommand {
 	cmd := &suspendRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
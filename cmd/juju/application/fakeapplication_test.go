@@ -19,6 +19,10 @@ type fakeApplicationAPI struct {
 	values    map[string]interface{}
 	config    string
 	err       error
+
+	validated     map[string]string
+	validatedYAML string
+	history       []params.ConfigChange
 }
 
 func (f *fakeApplicationAPI) Update(args params.ApplicationUpdate) error {
@@ -100,3 +104,35 @@ func (f *fakeApplicationAPI) Unset(application string, options []string) error {
 
 	return nil
 }
+
+func (f *fakeApplicationAPI) ValidateConfig(application string, options map[string]string, configYAML string) (map[string]interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+
+	f.validated = options
+	f.validatedYAML = configYAML
+	changes := make(map[string]interface{})
+	for k, v := range options {
+		if _, ok := f.values[k]; !ok {
+			return nil, errors.Errorf("unknown option %q", k)
+		}
+		changes[k] = v
+	}
+	return changes, nil
+}
+
+func (f *fakeApplicationAPI) ConfigHistory(application string) ([]params.ConfigChange, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	return f.history, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var hookRetryHelpSummary = `
+Shows or sets how an application's failed hooks are retried.`[1:]
+
+var hookRetryHelpDetails = `
+By default, failed hooks are retried automatically with an increasing
+delay, as long as the model's automatically-retry-hooks setting is
+true. An application may override this: --disable stops its failed
+hooks being retried automatically, --max-attempts limits the number of
+automatic retries of a failed hook, and --max-retry-time limits the
+delay between them. A hook which is not retried is left for the
+operator to resolve with "juju resolved".
+
+Setting any of these replaces the application's whole policy; --reset
+removes the overrides. With no options, the current policy is shown.
+
+Examples:
+    juju hook-retry mysql
+    juju hook-retry mysql --disable
+    juju hook-retry mysql --max-attempts 3 --max-retry-time 1m
+    juju hook-retry mysql --reset
+
+See also:
+    model-config
+    resolved`
+
+// NewHookRetryCommand returns a command to show or set the hook retry
+// policy of an application.
+func NewHookRetryCommand() cmd.Command {
+	cmd := &hookRetryCommand{}
+	cmd.newAPIFunc = func() (HookRetryAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// HookRetryAPI defines the API methods that the hook-retry command
+// uses.
+type HookRetryAPI interface {
+	Close() error
+	BestAPIVersion() int
+	HookRetryPolicy(application string) (params.HookRetryPolicy, error)
+	SetHookRetryPolicy(application string, policy params.HookRetryPolicy) error
+}
+
+type hookRetryCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	flags      *gnuflag.FlagSet
+	newAPIFunc func() (HookRetryAPI, error)
+
+	applicationName string
+	set             bool
+	reset           bool
+	policy          params.HookRetryPolicy
+}
+
+// hookRetryPolicy is the output format of the hook-retry command.
+type hookRetryPolicy struct {
+	Disabled     bool   `yaml:"disabled" json:"disabled"`
+	MaxAttempts  int    `yaml:"max-attempts,omitempty" json:"max-attempts,omitempty"`
+	MaxRetryTime string `yaml:"max-retry-time,omitempty" json:"max-retry-time,omitempty"`
+}
+
+func (c *hookRetryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "hook-retry",
+		Args:    "<application>",
+		Purpose: hookRetryHelpSummary,
+		Doc:     hookRetryHelpDetails,
+	}
+}
+
+func (c *hookRetryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.policy.Disabled, "disable", false, "Do not retry failed hooks automatically")
+	f.IntVar(&c.policy.MaxAttempts, "max-attempts", 0, "The maximum number of automatic retries of a failed hook")
+	f.DurationVar(&c.policy.MaxRetryTime, "max-retry-time", 0, "The maximum delay between automatic retries")
+	f.BoolVar(&c.reset, "reset", false, "Remove the application's overrides")
+	c.flags = f
+}
+
+func (c *hookRetryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.NotValidf("application name %q", c.applicationName)
+	}
+	c.flags.Visit(func(f *gnuflag.Flag) {
+		switch f.Name {
+		case "disable", "max-attempts", "max-retry-time":
+			c.set = true
+		}
+	})
+	if c.set && c.reset {
+		return errors.New("cannot specify --reset with other options")
+	}
+	if c.policy.MaxAttempts < 0 {
+		return errors.New("--max-attempts cannot be negative")
+	}
+	if c.policy.MaxRetryTime < 0 {
+		return errors.New("--max-retry-time cannot be negative")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *hookRetryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 9 {
+		return errors.New("hook retry policies are not supported by this version of Juju")
+	}
+	if !c.set && !c.reset {
+		policy, err := client.HookRetryPolicy(c.applicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		out := hookRetryPolicy{
+			Disabled:    policy.Disabled,
+			MaxAttempts: policy.MaxAttempts,
+		}
+		if policy.MaxRetryTime > 0 {
+			out.MaxRetryTime = policy.MaxRetryTime.String()
+		}
+		return c.out.Write(ctx, out)
+	}
+	if c.reset {
+		c.policy = params.HookRetryPolicy{}
+	}
+	err = client.SetHookRetryPolicy(c.applicationName, c.policy)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetrySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookRetryAPI
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockHookRetryAPI{Stub: &testing.Stub{}, version: 9}
+}
+
+func (s *HookRetrySuite) runHookRetry(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewHookRetryCommandForTest(s.mockAPI), args...)
+}
+
+func (s *HookRetrySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "--disable", "--reset"},
+		err:  "cannot specify --reset with other options",
+	}, {
+		args: []string{"mysql", "--max-attempts", "-1"},
+		err:  "--max-attempts cannot be negative",
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runHookRetry(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookRetrySuite) TestShow(c *gc.C) {
+	s.mockAPI.policy = params.HookRetryPolicy{MaxAttempts: 3, MaxRetryTime: time.Minute}
+	ctx, err := s.runHookRetry(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "HookRetryPolicy", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+disabled: false
+max-attempts: 3
+max-retry-time: 1m0s
+`[1:])
+}
+
+func (s *HookRetrySuite) TestSet(c *gc.C) {
+	_, err := s.runHookRetry(c, "mysql", "--max-attempts", "2", "--max-retry-time", "30s")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetHookRetryPolicy", []interface{}{"mysql", params.HookRetryPolicy{
+			MaxAttempts:  2,
+			MaxRetryTime: 30 * time.Second,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookRetrySuite) TestReset(c *gc.C) {
+	_, err := s.runHookRetry(c, "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetHookRetryPolicy", "mysql", params.HookRetryPolicy{})
+}
+
+func (s *HookRetrySuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 8
+	_, err := s.runHookRetry(c, "mysql", "--disable")
+	c.Assert(err, gc.ErrorMatches, "hook retry policies are not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *HookRetrySuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := s.runHookRetry(c, "mysql", "--disable")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockHookRetryAPI struct {
+	*testing.Stub
+	version int
+	policy  params.HookRetryPolicy
+}
+
+func (m *mockHookRetryAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockHookRetryAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockHookRetryAPI) HookRetryPolicy(application string) (params.HookRetryPolicy, error) {
+	m.MethodCall(m, "HookRetryPolicy", application)
+	return m.policy, m.NextErr()
+}
+
+func (m *mockHookRetryAPI) SetHookRetryPolicy(application string, policy params.HookRetryPolicy) error {
+	m.MethodCall(m, "SetHookRetryPolicy", application, policy)
+	return m.NextErr()
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var moveUnitHelpSummary = `
+Moves a unit to another machine.`[1:]
+
+var moveUnitHelpDetails = `
+The unit, along with its subordinates, is moved to the given machine
+without being removed and added again. The unit keeps its name, its
+relations and its configuration.
+
+The unit's agent first runs the stop hook on the unit's current machine,
+after which the unit is removed from that machine and deployed to the
+new one, where it is installed and started again. Ports opened by the
+unit and its subordinates on the old machine are closed.
+
+If the unit's current machine is unreachable, --force completes the move
+immediately, without waiting for the unit to stop. The stop hook will
+not be run.
+
+Units with attached storage cannot currently be moved.
+
+Examples:
+    juju move-unit mysql/0 3
+    juju move-unit mysql/0 3 --force
+
+See also:
+    add-machine
+    add-unit
+    remove-unit`
+
+// NewMoveUnitCommand returns a command to move a unit to another machine.
+func NewMoveUnitCommand() cmd.Command {
+	cmd := &moveUnitCommand{}
+	cmd.newAPIFunc = func() (MoveUnitAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// MoveUnitAPI defines the API methods that the move-unit command uses.
+type MoveUnitAPI interface {
+	Close() error
+	BestAPIVersion() int
+	MoveUnit(unit, machine string, force bool) error
+}
+
+type moveUnitCommand struct {
+	modelcmd.ModelCommandBase
+	unitName   string
+	machineId  string
+	force      bool
+	newAPIFunc func() (MoveUnitAPI, error)
+}
+
+func (c *moveUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "move-unit",
+		Args:    "<unit> <machine>",
+		Purpose: moveUnitHelpSummary,
+		Doc:     moveUnitHelpDetails,
+	}
+}
+
+func (c *moveUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Complete the move without waiting for the unit to stop")
+}
+
+func (c *moveUnitCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+		return errors.New("no machine specified")
+	}
+	c.unitName, c.machineId = args[0], args[1]
+	if !names.IsValidUnit(c.unitName) {
+		return errors.NotValidf("unit name %q", c.unitName)
+	}
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine %q", c.machineId)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *moveUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 8 {
+		return errors.New("moving a unit is not supported by this version of Juju")
+	}
+	err = client.MoveUnit(c.unitName, c.machineId, c.force)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	if c.force {
+		ctx.Infof("Moved unit %s to machine %s.", c.unitName, c.machineId)
+	} else {
+		ctx.Infof("Moving unit %s to machine %s.", c.unitName, c.machineId)
+	}
+	return nil
+}
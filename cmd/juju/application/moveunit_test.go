@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MoveUnitSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockMoveUnitAPI
+}
+
+var _ = gc.Suite(&MoveUnitSuite{})
+
+func (s *MoveUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockMoveUnitAPI{Stub: &testing.Stub{}, version: 8}
+}
+
+func (s *MoveUnitSuite) runMoveUnit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewMoveUnitCommandForTest(s.mockAPI), args...)
+}
+
+func (s *MoveUnitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no unit specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  "no machine specified",
+	}, {
+		args: []string{"mysql", "1"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "lxd"},
+		err:  `machine "lxd" not valid`,
+	}, {
+		args: []string{"mysql/0", "1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runMoveUnit(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MoveUnitSuite) TestMoveUnit(c *gc.C) {
+	ctx, err := s.runMoveUnit(c, "mysql/0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"MoveUnit", []interface{}{"mysql/0", "1", false}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Moving unit mysql/0 to machine 1.\n")
+}
+
+func (s *MoveUnitSuite) TestMoveUnitForce(c *gc.C) {
+	ctx, err := s.runMoveUnit(c, "mysql/0", "1/lxd/0", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "MoveUnit", "mysql/0", "1/lxd/0", true)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Moved unit mysql/0 to machine 1/lxd/0.\n")
+}
+
+func (s *MoveUnitSuite) TestMoveUnitOldServer(c *gc.C) {
+	s.mockAPI.version = 7
+	_, err := s.runMoveUnit(c, "mysql/0", "1")
+	c.Assert(err, gc.ErrorMatches, "moving a unit is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *MoveUnitSuite) TestMoveUnitFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`cannot move unit "mysql/0" to machine 1: series does not match`))
+	_, err := s.runMoveUnit(c, "mysql/0", "1")
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "mysql/0" to machine 1: series does not match`)
+}
+
+func (s *MoveUnitSuite) TestMoveUnitBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestMoveUnitBlocked"))
+	_, err := s.runMoveUnit(c, "mysql/0", "1")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestMoveUnitBlocked.*")
+}
+
+type mockMoveUnitAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (m *mockMoveUnitAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockMoveUnitAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockMoveUnitAPI) MoveUnit(unit, machine string, force bool) error {
+	m.MethodCall(m, "MoveUnit", unit, machine, force)
+	return m.NextErr()
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var proxyOverridesHelpSummary = `
+Shows or sets the proxy settings seen by an application's hooks.`[1:]
+
+var proxyOverridesHelpDetails = `
+By default, the hooks of an application's units see the proxy settings
+of the model, as set by the http-proxy, https-proxy, ftp-proxy and
+no-proxy model config. An application may override any of these for
+its own hooks; settings which are not overridden are taken from the
+model. The proxy settings of the machines hosting the units are not
+affected.
+
+Whatever the settings, the addresses of the controllers and of the
+unit's machine are always added to the hooks' no-proxy settings.
+
+Setting any of these replaces all of the application's overrides;
+--reset removes them. With no options, the current overrides are
+shown.
+
+Examples:
+    juju proxy-overrides mysql
+    juju proxy-overrides mysql --http-proxy http://squid.internal:3128
+    juju proxy-overrides mysql --no-proxy 10.0.0.0/8,example.com
+    juju proxy-overrides mysql --reset
+
+See also:
+    model-config`
+
+// NewProxyOverridesCommand returns a command to show or set the proxy
+// overrides of an application.
+func NewProxyOverridesCommand() cmd.Command {
+	cmd := &proxyOverridesCommand{}
+	cmd.newAPIFunc = func() (ProxyOverridesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// ProxyOverridesAPI defines the API methods that the proxy-overrides
+// command uses.
+type ProxyOverridesAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ProxyOverrides(application string) (params.ProxyConfig, error)
+	SetProxyOverrides(application string, overrides params.ProxyConfig) error
+}
+
+type proxyOverridesCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	flags      *gnuflag.FlagSet
+	newAPIFunc func() (ProxyOverridesAPI, error)
+
+	applicationName string
+	set             bool
+	reset           bool
+	overrides       params.ProxyConfig
+}
+
+// proxyOverrides is the output format of the proxy-overrides command.
+type proxyOverrides struct {
+	HTTP    string `yaml:"http-proxy,omitempty" json:"http-proxy,omitempty"`
+	HTTPS   string `yaml:"https-proxy,omitempty" json:"https-proxy,omitempty"`
+	FTP     string `yaml:"ftp-proxy,omitempty" json:"ftp-proxy,omitempty"`
+	NoProxy string `yaml:"no-proxy,omitempty" json:"no-proxy,omitempty"`
+}
+
+func (c *proxyOverridesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "proxy-overrides",
+		Args:    "<application>",
+		Purpose: proxyOverridesHelpSummary,
+		Doc:     proxyOverridesHelpDetails,
+	}
+}
+
+func (c *proxyOverridesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.overrides.HTTP, "http-proxy", "", "The HTTP proxy for the application's hooks")
+	f.StringVar(&c.overrides.HTTPS, "https-proxy", "", "The HTTPS proxy for the application's hooks")
+	f.StringVar(&c.overrides.FTP, "ftp-proxy", "", "The FTP proxy for the application's hooks")
+	f.StringVar(&c.overrides.NoProxy, "no-proxy", "", "Comma-separated hosts not to be proxied for the application's hooks")
+	f.BoolVar(&c.reset, "reset", false, "Remove the application's overrides")
+	c.flags = f
+}
+
+func (c *proxyOverridesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.NotValidf("application name %q", c.applicationName)
+	}
+	c.flags.Visit(func(f *gnuflag.Flag) {
+		switch f.Name {
+		case "http-proxy", "https-proxy", "ftp-proxy", "no-proxy":
+			c.set = true
+		}
+	})
+	if c.set && c.reset {
+		return errors.New("cannot specify --reset with other options")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *proxyOverridesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 10 {
+		return errors.New("application proxy overrides are not supported by this version of Juju")
+	}
+	if !c.set && !c.reset {
+		overrides, err := client.ProxyOverrides(c.applicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, proxyOverrides{
+			HTTP:    overrides.HTTP,
+			HTTPS:   overrides.HTTPS,
+			FTP:     overrides.FTP,
+			NoProxy: overrides.NoProxy,
+		})
+	}
+	if c.reset {
+		c.overrides = params.ProxyConfig{}
+	}
+	err = client.SetProxyOverrides(c.applicationName, c.overrides)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ProxyOverridesSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockProxyOverridesAPI
+}
+
+var _ = gc.Suite(&ProxyOverridesSuite{})
+
+func (s *ProxyOverridesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockProxyOverridesAPI{Stub: &testing.Stub{}, version: 10}
+}
+
+func (s *ProxyOverridesSuite) runProxyOverrides(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, NewProxyOverridesCommandForTest(s.mockAPI), args...)
+}
+
+func (s *ProxyOverridesSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "--http-proxy", "http://proxy", "--reset"},
+		err:  "cannot specify --reset with other options",
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runProxyOverrides(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ProxyOverridesSuite) TestShow(c *gc.C) {
+	s.mockAPI.overrides = params.ProxyConfig{HTTP: "http://proxy", NoProxy: "example.com"}
+	ctx, err := s.runProxyOverrides(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "ProxyOverrides", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+http-proxy: http://proxy
+no-proxy: example.com
+`[1:])
+}
+
+func (s *ProxyOverridesSuite) TestSet(c *gc.C) {
+	_, err := s.runProxyOverrides(c, "mysql", "--https-proxy", "https://proxy", "--no-proxy", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetProxyOverrides", []interface{}{"mysql", params.ProxyConfig{
+			HTTPS:   "https://proxy",
+			NoProxy: "10.0.0.0/8",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ProxyOverridesSuite) TestReset(c *gc.C) {
+	_, err := s.runProxyOverrides(c, "mysql", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetProxyOverrides", "mysql", params.ProxyConfig{})
+}
+
+func (s *ProxyOverridesSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 9
+	_, err := s.runProxyOverrides(c, "mysql", "--http-proxy", "http://proxy")
+	c.Assert(err, gc.ErrorMatches, "application proxy overrides are not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ProxyOverridesSuite) TestSetBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := s.runProxyOverrides(c, "mysql", "--http-proxy", "http://proxy")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+type mockProxyOverridesAPI struct {
+	*testing.Stub
+	version   int
+	overrides params.ProxyConfig
+}
+
+func (m *mockProxyOverridesAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockProxyOverridesAPI) BestAPIVersion() int {
+	return m.version
+}
+
+func (m *mockProxyOverridesAPI) ProxyOverrides(application string) (params.ProxyConfig, error) {
+	m.MethodCall(m, "ProxyOverrides", application)
+	return m.overrides, m.NextErr()
+}
+
+func (m *mockProxyOverridesAPI) SetProxyOverrides(application string, overrides params.ProxyConfig) error {
+	m.MethodCall(m, "SetProxyOverrides", application, overrides)
+	return m.NextErr()
+}
@@ -126,7 +126,9 @@ type upgradeCharmCommand struct {
 const upgradeCharmDoc = `
 When no flags are set, the application's charm will be upgraded to the latest
 revision available in the repository from which it was originally deployed. An
-explicit revision can be chosen with the --revision flag.
+explicit revision can be chosen with the --revision flag. An application upgraded
+to an explicit revision is pinned to it: "juju status" stops reporting upgrades
+for the application until it is next upgraded without --revision.
 
 A path will need to be supplied to allow an updated copy of the charm
 to be located.
@@ -340,6 +342,7 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ConfigSettingsYAML: string(configYAML),
 		ForceSeries:        c.ForceSeries,
 		ForceUnits:         c.ForceUnits,
+		Pinned:             c.Revision != -1,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
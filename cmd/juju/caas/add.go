@@ -72,7 +72,11 @@ type AddCAASCommand struct {
 
 // NewAddCAASCommand returns a command to add caas information.
 func NewAddCAASCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
-	cmd := &AddCAASCommand{
+	return modelcmd.Wrap(newAddCAASCommand(cloudMetadataStore))
+}
+
+func newAddCAASCommand(cloudMetadataStore CloudMetadataStore) *AddCAASCommand {
+	return &AddCAASCommand{
 		cloudMetadataStore:  cloudMetadataStore,
 		fileCredentialStore: jujuclient.NewFileCredentialStore(),
 		newCloudAPI: func(caller base.APICallCloser) CloudAPI {
@@ -82,9 +86,14 @@ func NewAddCAASCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
 			return caascfg.NewClientConfigReader(caasType)
 		},
 	}
-	return modelcmd.Wrap(cmd)
 }
+
 func NewAddCAASCommandForTest(cloudMetadataStore CloudMetadataStore, fileCredentialStore jujuclient.CredentialStore, clientStore jujuclient.ClientStore, apiRoot api.Connection, newCloudAPIFunc func(base.APICallCloser) CloudAPI, newClientConfigReaderFunc func(string) (caascfg.ClientConfigFunc, error)) cmd.Command {
+	cmd := newAddCAASCommandForTest(cloudMetadataStore, fileCredentialStore, clientStore, apiRoot, newCloudAPIFunc, newClientConfigReaderFunc)
+	return modelcmd.Wrap(cmd)
+}
+
+func newAddCAASCommandForTest(cloudMetadataStore CloudMetadataStore, fileCredentialStore jujuclient.CredentialStore, clientStore jujuclient.ClientStore, apiRoot api.Connection, newCloudAPIFunc func(base.APICallCloser) CloudAPI, newClientConfigReaderFunc func(string) (caascfg.ClientConfigFunc, error)) *AddCAASCommand {
 	cmd := &AddCAASCommand{
 		cloudMetadataStore:    cloudMetadataStore,
 		fileCredentialStore:   fileCredentialStore,
@@ -93,7 +102,7 @@ func NewAddCAASCommandForTest(cloudMetadataStore CloudMetadataStore, fileCredent
 		newClientConfigReader: newClientConfigReaderFunc,
 	}
 	cmd.SetClientStore(clientStore)
-	return modelcmd.Wrap(cmd)
+	return cmd
 }
 
 // Info returns help information about the command.
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	caascfg "github.com/juju/juju/caas/clientconfig"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// k8sCloudType is the cloud type of Kubernetes clusters.
+const k8sCloudType = "kubernetes"
+
+var usageAddK8sSummary = `
+Adds a Kubernetes cluster and credential to Juju.`[1:]
+
+var usageAddK8sDetails = `
+The cluster and credential of the current context of the Kubernetes
+client configuration are added. The configuration is read from
+$KUBECONFIG, or ~/.kube/config if that is not set.
+
+Once added, CAAS models may be created on the cluster.
+
+Examples:
+    juju add-k8s myk8s
+
+See also:
+    add-caas`
+
+// AddK8sCommand is the command that adds a Kubernetes cluster to Juju.
+// It is equivalent to "juju add-caas kubernetes <name>".
+type AddK8sCommand struct {
+	*AddCAASCommand
+}
+
+// NewAddK8sCommand returns a command to add a Kubernetes cluster.
+func NewAddK8sCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
+	return modelcmd.Wrap(&AddK8sCommand{newAddCAASCommand(cloudMetadataStore)})
+}
+
+func NewAddK8sCommandForTest(cloudMetadataStore CloudMetadataStore, fileCredentialStore jujuclient.CredentialStore, clientStore jujuclient.ClientStore, apiRoot api.Connection, newCloudAPIFunc func(base.APICallCloser) CloudAPI, newClientConfigReaderFunc func(string) (caascfg.ClientConfigFunc, error)) cmd.Command {
+	cmd := newAddCAASCommandForTest(cloudMetadataStore, fileCredentialStore, clientStore, apiRoot, newCloudAPIFunc, newClientConfigReaderFunc)
+	return modelcmd.Wrap(&AddK8sCommand{cmd})
+}
+
+// Info returns help information about the command.
+func (c *AddK8sCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-k8s",
+		Args:    "<k8s name>",
+		Purpose: usageAddK8sSummary,
+		Doc:     usageAddK8sDetails,
+	}
+}
+
+// Init populates the command with the args from the command line.
+func (c *AddK8sCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("missing k8s name.")
+	}
+	c.caasType = k8sCloudType
+	c.caasName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	caascfg "github.com/juju/juju/caas/clientconfig"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/caas"
+)
+
+// addK8sSuite reuses the fixtures of addCAASSuite, without embedding
+// it, so that the add-caas tests are not run again.
+type addK8sSuite struct {
+	base      addCAASSuite
+	caasTypes []string
+}
+
+var _ = gc.Suite(&addK8sSuite{})
+
+func (s *addK8sSuite) SetUpSuite(c *gc.C) {
+	s.base.SetUpSuite(c)
+}
+
+func (s *addK8sSuite) TearDownSuite(c *gc.C) {
+	s.base.TearDownSuite(c)
+}
+
+func (s *addK8sSuite) SetUpTest(c *gc.C) {
+	s.base.SetUpTest(c)
+}
+
+func (s *addK8sSuite) TearDownTest(c *gc.C) {
+	s.base.TearDownTest(c)
+}
+
+func (s *addK8sSuite) makeK8sCommand(c *gc.C) cmd.Command {
+	s.caasTypes = nil
+	return caas.NewAddK8sCommandForTest(s.base.store,
+		&fakeCredentialStore{},
+		NewMockClientStore(),
+		&fakeAPIConnection{},
+		func(caller base.APICallCloser) caas.CloudAPI {
+			return s.base.fakeCloudAPI
+		},
+		func(caasType string) (caascfg.ClientConfigFunc, error) {
+			s.caasTypes = append(s.caasTypes, caasType)
+			return fakeK8SClientConfig, nil
+		},
+	)
+}
+
+func (s *addK8sSuite) TestMissingName(c *gc.C) {
+	_, err := s.base.runCommand(c, s.makeK8sCommand(c))
+	c.Assert(err, gc.ErrorMatches, `missing k8s name.`)
+}
+
+func (s *addK8sSuite) TestExtraArg(c *gc.C) {
+	_, err := s.base.runCommand(c, s.makeK8sCommand(c), "myk8s", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *addK8sSuite) TestAdd(c *gc.C) {
+	_, err := s.base.runCommand(c, s.makeK8sCommand(c), "myk8s")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.caasTypes, jc.DeepEquals, []string{"kubernetes"})
+
+	written := s.base.store.Calls()[2].Args[0].(map[string]cloud.Cloud)
+	c.Assert(written["myk8s"].Type, gc.Equals, "kubernetes")
+	c.Assert(written["myk8s"].Endpoint, gc.Equals, "fakeendpoint")
+}
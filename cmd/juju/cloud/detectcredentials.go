@@ -49,7 +49,10 @@ including the locations searched.
 EC2
   Credentials and regions:
     1. On Linux, $HOME/.aws/credentials and $HOME/.aws/config
-    2. Environment variables AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY
+    2. Environment variables AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+       AWS_DEFAULT_REGION
+    3. On an EC2 instance, the default region is the instance's region,
+       from the instance metadata service
 
 GCE
   Credentials:
@@ -59,6 +62,8 @@ GCE
        Default region is specified by the CLOUDSDK_COMPUTE_REGION environment
        variable.
     3. On Windows, %APPDATA%\gcloud\application_default_credentials.json
+    4. On a GCE instance, the default region is the instance's region,
+       from the metadata server
 
 OpenStack
   Credentials:
@@ -66,6 +71,37 @@ OpenStack
     2. Environment variables OS_USERNAME, OS_PASSWORD, OS_TENANT_NAME,
        OS_DOMAIN_NAME
 
+Azure
+  Credentials:
+    1. Accounts known to the Azure CLI (az)
+
+MAAS
+  Credentials:
+    1. On Linux, $HOME/.maasrc
+
+CloudSigma
+  Credentials and regions:
+    1. Environment variables CLOUDSIGMA_USERNAME, CLOUDSIGMA_PASSWORD,
+       CLOUDSIGMA_REGION
+
+Joyent
+  Credentials:
+    1. Environment variables SDC_ACCOUNT, SDC_KEY_ID and SDC_KEY_FILE
+       (defaults to $HOME/.ssh/id_rsa)
+
+vSphere
+  Credentials:
+    1. Environment variables GOVC_USERNAME, GOVC_PASSWORD
+
+LXD
+  Credentials:
+    1. On Linux, $HOME/.config/lxc/client.crt and client.key
+
+If a detected credential has the same name as an existing credential for
+the chosen cloud, but different contents, you will be asked whether to
+replace the existing credential, save the detected one under a new name,
+or skip it.
+
 Example:
     juju autoload-credentials
    
@@ -308,10 +344,21 @@ func (c *detectCredentialsCommand) interactiveCredentialsUpdate(ctxt *cmd.Contex
 				AuthCredentials: make(map[string]jujucloud.Credential),
 			}
 		}
+		credentialName := cred.credentialName
+		if current, ok := existing.AuthCredentials[credentialName]; ok && !sameCredential(current, cred.credential) {
+			credentialName, err = c.resolveConflict(ctxt.Stderr, ctxt.Stdin, cloudName, credentialName, existing)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if credentialName == "" {
+				fmt.Fprintf(ctxt.Stderr, "Skipped %s\n", cred.credential.Label)
+				continue
+			}
+		}
 		if cred.region != "" {
 			existing.DefaultRegion = cred.region
 		}
-		existing.AuthCredentials[cred.credentialName] = cred.credential
+		existing.AuthCredentials[credentialName] = cred.credential
 		if err := c.store.UpdateCredential(cloudName, *existing); err != nil {
 			fmt.Fprintf(ctxt.Stderr, "error saving credential: %v\n", err)
 		} else {
@@ -369,6 +416,73 @@ func (c *detectCredentialsCommand) promptCloudName(out io.Writer, in io.Reader,
 	return cloudName, nil
 }
 
+// sameCredential reports whether the two credentials have the same
+// auth type and attributes. Labels are not compared, as they are
+// only used for display.
+func sameCredential(a, b jujucloud.Credential) bool {
+	if a.AuthType() != b.AuthType() {
+		return false
+	}
+	aAttrs, bAttrs := a.Attributes(), b.Attributes()
+	if len(aAttrs) != len(bAttrs) {
+		return false
+	}
+	for k, v := range aAttrs {
+		if bv, ok := bAttrs[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveConflict asks the user what to do with a detected credential
+// whose name clashes with a different existing credential. It returns
+// the name under which to save the credential, or "" if the credential
+// should be skipped.
+func (c *detectCredentialsCommand) resolveConflict(
+	out io.Writer, in io.Reader,
+	cloudName, credentialName string,
+	existing *jujucloud.CloudCredential,
+) (string, error) {
+	fmt.Fprintf(out, "Credential %q already exists for cloud %s with different contents.\n", credentialName, cloudName)
+	for {
+		fmt.Fprint(out, "Replace it (r), save under a new name (n), or skip (s) [r]: ")
+		input, err := readLine(in)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		switch strings.ToLower(strings.TrimSpace(input)) {
+		case "", "r":
+			return credentialName, nil
+		case "s":
+			return "", nil
+		case "n":
+			return c.promptNewCredentialName(out, in, existing)
+		default:
+			fmt.Fprintln(out, "Invalid choice, enter r, n or s")
+		}
+	}
+}
+
+func (c *detectCredentialsCommand) promptNewCredentialName(out io.Writer, in io.Reader, existing *jujucloud.CloudCredential) (string, error) {
+	for {
+		fmt.Fprint(out, "Enter a new credential name: ")
+		input, err := readLine(in)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		name := strings.TrimSpace(input)
+		if name == "" {
+			continue
+		}
+		if _, ok := existing.AuthCredentials[name]; ok {
+			fmt.Fprintf(out, "Credential %q already exists, choose another name\n", name)
+			continue
+		}
+		return name, nil
+	}
+}
+
 func readLine(stdin io.Reader) (string, error) {
 	// Read one byte at a time to avoid reading beyond the delimiter.
 	line, err := bufio.NewReader(byteAtATimeReader{stdin}).ReadString('\n')
@@ -228,3 +228,65 @@ func (s *detectCredentialsSuite) TestDetectCredentialInvalidChoice(c *gc.C) {
 	c.Assert(output, gc.Matches, ".*Invalid choice, enter a number between 1 and 2.*")
 	c.Assert(s.store.Credentials, gc.HasLen, 0)
 }
+
+func (s *detectCredentialsSuite) setupConflict() map[string]jujucloud.Cloud {
+	existing := jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+		"access-key": "old-key",
+	})
+	s.store.Credentials = map[string]jujucloud.CloudCredential{
+		"test-cloud": {
+			AuthCredentials: map[string]jujucloud.Credential{
+				"test": existing,
+			},
+		},
+	}
+	detected := jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+		"access-key": "new-key",
+	})
+	detected.Label = "credential"
+	s.aCredential = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"test": detected,
+		},
+	}
+	return map[string]jujucloud.Cloud{
+		"test-cloud": {
+			Type: "mock-provider",
+		},
+	}
+}
+
+func (s *detectCredentialsSuite) TestDetectCredentialConflictReplace(c *gc.C) {
+	clouds := s.setupConflict()
+	stdin := strings.NewReader("1\ntest-cloud\nr\nQ\n")
+	ctx, err := s.run(c, stdin, clouds)
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.Replace(cmdtesting.Stderr(ctx), "\n", "", -1)
+	c.Assert(output, gc.Matches, `.*Credential "test" already exists for cloud test-cloud with different contents.*`)
+	c.Assert(s.store.Credentials["test-cloud"].AuthCredentials, jc.DeepEquals, s.aCredential.AuthCredentials)
+}
+
+func (s *detectCredentialsSuite) TestDetectCredentialConflictNewName(c *gc.C) {
+	clouds := s.setupConflict()
+	existing := s.store.Credentials["test-cloud"].AuthCredentials["test"]
+	stdin := strings.NewReader("1\ntest-cloud\nn\ntest\nrenamed\nQ\n")
+	ctx, err := s.run(c, stdin, clouds)
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.Replace(cmdtesting.Stderr(ctx), "\n", "", -1)
+	c.Assert(output, gc.Matches, `.*Credential "test" already exists, choose another name.*`)
+	c.Assert(s.store.Credentials["test-cloud"].AuthCredentials, jc.DeepEquals, map[string]jujucloud.Credential{
+		"test":    existing,
+		"renamed": s.aCredential.AuthCredentials["test"],
+	})
+}
+
+func (s *detectCredentialsSuite) TestDetectCredentialConflictSkip(c *gc.C) {
+	clouds := s.setupConflict()
+	existing := s.store.Credentials["test-cloud"]
+	stdin := strings.NewReader("1\ntest-cloud\ns\nQ\n")
+	ctx, err := s.run(c, stdin, clouds)
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.Replace(cmdtesting.Stderr(ctx), "\n", "", -1)
+	c.Assert(output, gc.Matches, ".*Skipped credential.*")
+	c.Assert(s.store.Credentials["test-cloud"], jc.DeepEquals, existing)
+}
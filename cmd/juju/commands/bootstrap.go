@@ -50,6 +50,8 @@ var usageBootstrapDetails = `
 Used without arguments, bootstrap will step you through the process of
 initializing a Juju cloud environment. Initialization consists of creating
 a 'controller' model and provisioning a machine to act as controller.
+With '--interactive', you will additionally be asked to choose a credential,
+model constraints and common controller configuration options.
 
 We recommend you call your controller ‘username-region’ e.g. ‘fred-us-east-1’
 See --clouds for a list of clouds and credentials.
@@ -106,6 +108,7 @@ The agent version can be specified a simple numeric version, e.g. 2.2.4.
 
 Examples:
     juju bootstrap
+    juju bootstrap --interactive
     juju bootstrap --clouds
     juju bootstrap --regions aws
     juju bootstrap aws
@@ -165,6 +168,13 @@ type bootstrapCommand struct {
 	noGUI               bool
 	noSwitch            bool
 	interactive         bool
+	// wizard is set when --interactive is specified, and causes
+	// the interactive bootstrap to also query for credential,
+	// constraints and controller config.
+	wizard bool
+	// constraintsScanner is set by the interactive wizard when the
+	// model constraints are still to be queried for.
+	constraintsScanner *bufio.Scanner
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -199,12 +209,19 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created controller")
+	f.BoolVar(&c.wizard, "interactive", false, "Walk through cloud, region, credential, constraints and controller configuration interactively")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.showClouds && c.showRegionsForCloud != "" {
 		return errors.New("--clouds and --regions can't be used together")
 	}
+	if c.wizard && (c.showClouds || c.showRegionsForCloud != "") {
+		return errors.New("--interactive can't be used with --clouds or --regions")
+	}
+	if c.wizard && len(args) > 0 {
+		return errors.New("--interactive can't be used with a cloud or controller name")
+	}
 	if c.showClouds {
 		return cmd.CheckEmpty(args)
 	}
@@ -554,6 +571,20 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.constraintsScanner != nil {
+		consStr, err := queryConstraints(constraintsValidator, c.constraintsScanner, ctx.Stdout)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if consStr != "" {
+			cons, err := constraints.Parse(consStr)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			c.ConstraintsStr = consStr
+			c.Constraints = cons
+		}
+	}
 	bootstrapConstraints, err := constraintsValidator.Merge(
 		c.Constraints, c.BootstrapConstraints,
 	)
@@ -1089,9 +1120,81 @@ func (c *bootstrapCommand) runInteractive(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if !c.wizard {
+		return nil
+	}
+	return c.runWizard(ctx, cloud, scanner)
+}
+
+// runWizard queries the user for the remaining bootstrap choices when
+// --interactive is specified: the credential, model constraints and
+// common controller config toggles.
+func (c *bootstrapCommand) runWizard(ctx *cmd.Context, cloud *jujucloud.Cloud, scanner *bufio.Scanner) error {
+	if c.CredentialName == "" {
+		credentials, err := c.ClientStore().CredentialForCloud(c.Cloud)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err == nil && len(credentials.AuthCredentials) > 0 {
+			var names []string
+			for name, credential := range credentials.AuthCredentials {
+				if !cloudSupportsAuthType(cloud, credential.AuthType()) {
+					continue
+				}
+				names = append(names, name)
+			}
+			switch len(names) {
+			case 0:
+				return errors.Errorf("no credentials for cloud %s support its auth-types", c.Cloud)
+			case 1:
+				c.CredentialName = names[0]
+			default:
+				defCredential := credentials.DefaultCredential
+				if defCredential == "" {
+					sort.Strings(names)
+					defCredential = names[0]
+				}
+				c.CredentialName, err = queryCredential(c.Cloud, names, defCredential, scanner, ctx.Stdout)
+				if err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+
+	if c.ConstraintsStr == "" {
+		// The constraints can only be validated once the environ
+		// has been prepared, so they are queried for later.
+		c.constraintsScanner = scanner
+	}
+
+	toggles, err := queryControllerToggles(scanner, ctx.Stdout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, value := range toggles {
+		if err := c.config.Set(fmt.Sprintf("%s=%v", key, value)); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
+// cloudSupportsAuthType reports whether the cloud supports the given
+// auth-type. Clouds which do not declare any auth-types are assumed to
+// support all of them.
+func cloudSupportsAuthType(cloud *jujucloud.Cloud, authType jujucloud.AuthType) bool {
+	if len(cloud.AuthTypes) == 0 {
+		return true
+	}
+	for _, t := range cloud.AuthTypes {
+		if t == authType {
+			return true
+		}
+	}
+	return false
+}
+
 // checkProviderType ensures the provider type is okay.
 func checkProviderType(envType string) error {
 	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
@@ -16,6 +16,8 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/interact"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 )
 
 // assembleClouds
@@ -120,6 +122,117 @@ func queryName(defName string, scanner *bufio.Scanner, w io.Writer) (string, err
 	return name, nil
 }
 
+// queryCredential asks the user to choose one of the named credentials. The
+// default credential is used if the user does not enter a name.
+func queryCredential(cloud string, credentials []string, defCredential string, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	fmt.Fprintf(w, "Credentials for %s:\n", cloud)
+	sort.Strings(credentials)
+	options := append(credentials, "")
+	if _, err := fmt.Fprintln(w, strings.Join(options, "\n")); err != nil {
+		return "", errors.Trace(err)
+	}
+	verify := interact.MatchOptions(options, "Invalid credential.")
+	query := fmt.Sprintf("Select a credential [%s]: ", defCredential)
+	credential, err := interact.QueryVerify(query, scanner, w, w, verify)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if credential == "" {
+		return defCredential, nil
+	}
+	credentialName, ok := interact.FindMatch(credential, options)
+	if !ok {
+		// should be impossible
+		return "", errors.Errorf("invalid credential name chosen: %s", credential)
+	}
+	return credentialName, nil
+}
+
+// queryConstraints asks the user for the model constraints, verifying
+// that they can be parsed and are accepted by the provider's constraints
+// validator.
+func queryConstraints(validator constraints.Validator, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	verify := func(s string) (ok bool, msg string, err error) {
+		cons, err := constraints.Parse(s)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		unsupported, err := validator.Validate(cons)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		if len(unsupported) > 0 {
+			return false, fmt.Sprintf("unsupported constraints: %s", strings.Join(unsupported, ",")), nil
+		}
+		return true, "", nil
+	}
+	query := "Enter constraints for the model (e.g. mem=4G cores=2) []: "
+	cons, err := interact.QueryVerify(query, scanner, w, w, verify)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSpace(cons), nil
+}
+
+// controllerToggle describes a boolean controller config attribute that
+// the interactive bootstrap offers to change.
+type controllerToggle struct {
+	key      string
+	question string
+	defVal   bool
+}
+
+var controllerToggles = []controllerToggle{{
+	key:      controller.AuditingEnabled,
+	question: "Enable auditing on the controller",
+	defVal:   controller.DefaultAuditingEnabled,
+}, {
+	key:      controller.AllowModelAccessKey,
+	question: "Allow model users to connect without controller access",
+	defVal:   false,
+}, {
+	key:      controller.SetNUMAControlPolicyKey,
+	question: "Use NUMA control policy for mongo",
+	defVal:   controller.DefaultNUMAControlPolicy,
+}}
+
+// queryControllerToggles asks the user a yes/no question for each of the
+// common controller config toggles, and returns the attributes whose
+// answer differs from the default.
+func queryControllerToggles(scanner *bufio.Scanner, w io.Writer) (map[string]bool, error) {
+	result := make(map[string]bool)
+	for _, toggle := range controllerToggles {
+		value, err := queryYN(toggle.question, toggle.defVal, scanner, w)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if value != toggle.defVal {
+			result[toggle.key] = value
+		}
+	}
+	return result, nil
+}
+
+func queryYN(question string, defVal bool, scanner *bufio.Scanner, w io.Writer) (bool, error) {
+	defaultStr := "(y/N)"
+	if defVal {
+		defaultStr = "(Y/n)"
+	}
+	options := []string{"", "y", "yes", "n", "no"}
+	verify := interact.MatchOptions(options, "Invalid entry, please choose y or n.")
+	answer, err := interact.QueryVerify(question+"? "+defaultStr+": ", scanner, w, w, verify)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return defVal, nil
+}
+
 func sortClouds(maps ...map[string]jujucloud.Cloud) []string {
 	var clouds []string
 	for _, m := range maps {
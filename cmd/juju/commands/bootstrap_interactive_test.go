@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/version"
 )
 
@@ -67,6 +68,26 @@ func (BSInteractSuite) TestInitVariousFlags(c *gc.C) {
 	c.Assert(cmd.interactive, jc.IsTrue)
 }
 
+func (BSInteractSuite) TestInitInteractiveFlag(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := cmdtesting.InitCommand(cmd, []string{"--interactive"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmd.interactive, jc.IsTrue)
+	c.Assert(cmd.wizard, jc.IsTrue)
+}
+
+func (BSInteractSuite) TestInitInteractiveFlagWithArgs(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := cmdtesting.InitCommand(cmd, []string{"--interactive", "foo"})
+	c.Assert(err, gc.ErrorMatches, "--interactive can't be used with a cloud or controller name")
+}
+
+func (BSInteractSuite) TestInitInteractiveFlagWithClouds(c *gc.C) {
+	cmd := &bootstrapCommand{}
+	err := cmdtesting.InitCommand(cmd, []string{"--interactive", "--clouds"})
+	c.Assert(err, gc.ErrorMatches, "--interactive can't be used with --clouds or --regions")
+}
+
 func (BSInteractSuite) TestQueryCloud(c *gc.C) {
 	input := "search\n"
 
@@ -177,3 +198,67 @@ func (BSInteractSuite) TestQueryNameDefault(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(name, gc.Equals, "default-cloud")
 }
+
+func (BSInteractSuite) TestQueryCredential(c *gc.C) {
+	input := "work\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	buf := bytes.Buffer{}
+	credential, err := queryCredential("goggles", []string{"work", "home"}, "home", scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, gc.Equals, "work")
+
+	expected := `
+Credentials for goggles:
+home
+work
+
+Select a credential [home]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQueryCredentialDefault(c *gc.C) {
+	input := "\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	credential, err := queryCredential("goggles", []string{"work", "home"}, "home", scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, gc.Equals, "home")
+}
+
+func (BSInteractSuite) TestQueryConstraints(c *gc.C) {
+	input := "mem=lots\nmem=4G cores=2\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	buf := bytes.Buffer{}
+	cons, err := queryConstraints(constraints.NewValidator(), scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, gc.Equals, "mem=4G cores=2")
+	c.Assert(buf.String(), gc.Matches, `(?s).*bad "mem" constraint.*`)
+}
+
+func (BSInteractSuite) TestQueryConstraintsValidated(c *gc.C) {
+	input := "tags=foo\ninstance-type=big mem=4G\nmem=4G\n"
+
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported([]string{constraints.Tags})
+	validator.RegisterConflicts([]string{constraints.InstanceType}, []string{constraints.Mem})
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	buf := bytes.Buffer{}
+	cons, err := queryConstraints(validator, scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, gc.Equals, "mem=4G")
+	c.Assert(buf.String(), gc.Matches, `(?s).*unsupported constraints: tags.*ambiguous constraints: "instance-type" overlaps with "mem".*`)
+}
+
+func (BSInteractSuite) TestQueryControllerToggles(c *gc.C) {
+	input := "y\n\nn\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	toggles, err := queryControllerToggles(scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(toggles, jc.DeepEquals, map[string]bool{
+		"auditing-enabled": true,
+	})
+}
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -49,13 +50,37 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-trace' option shows only the log messages marked with the
+given trace IDs. A trace ID is reported when an API request fails; the API
+server marks its request logs and the model transactions made by traced
+requests (module juju.state.trace) with it, and agents mark their reports
+of failed requests (module juju.rpc). As the API server logs to the
+controller model, use it with "-m controller". These messages are only
+logged at DEBUG level, so the logging-config must include DEBUG for those
+modules for them to be recorded.
+
+The '--include-label' and '--exclude-label' options filter by the labels
+attached to log messages. Messages from charm hooks and the uniter are
+labelled "charm", and the API server's messages are labelled "api".
+
+The '--from' and '--until' options restrict the messages shown to those
+logged within a time range, given in RFC3339 format. When '--until' is
+given, the command stops once the matching messages have been shown.
+
+With '--format json', each log message is written as a JSON object on a
+line of its own, including its labels and correlation ID, for processing
+by other tools.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-label options are logically ORed together.
+* All --exclude-label options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-label and --exclude-label selections are logically ANDed to
+  form the complete filter.
 
 Examples:
 
@@ -94,6 +119,16 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+To see the controller's log messages for a failed API request with trace ID
+0123456789abcdef:
+
+    juju debug-log -m controller --replay --no-tail --include-trace 0123456789abcdef
+
+To write the charm messages logged during an hour as JSON:
+
+    juju debug-log --include-label charm --format json \
+        --from 2017-06-01T12:00:00Z --until 2017-06-01T13:00:00Z
+
 See also: 
     status
     ssh`
@@ -131,6 +166,10 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+
+	from   string
+	until  string
+	output string
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -141,6 +180,12 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages with these labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages with these labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeTrace), "include-trace", "Only show log messages marked with these trace IDs")
+	f.StringVar(&c.from, "from", "", "Only show log messages logged at or after this time (RFC3339)")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time (RFC3339), then stop")
+	f.StringVar(&c.output, "format", "text", "Output format, one of [text, json]")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -172,6 +217,28 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.from != "" {
+		from, err := time.Parse(time.RFC3339Nano, c.from)
+		if err != nil {
+			return errors.Errorf("--from value %q is not a valid time in RFC3339 format", c.from)
+		}
+		c.params.StartTime = from
+	}
+	if c.until != "" {
+		until, err := time.Parse(time.RFC3339Nano, c.until)
+		if err != nil {
+			return errors.Errorf("--until value %q is not a valid time in RFC3339 format", c.until)
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.Errorf("--until time is before --from time")
+		}
+		c.params.EndTime = until
+	}
+	switch c.output {
+	case "text", "json":
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q", c.output, "text", "json")
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -258,6 +325,15 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.output == "json" {
+		encoder := json.NewEncoder(ctx.Stdout)
+		for msg := range messages {
+			if err := encoder.Encode(msg); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
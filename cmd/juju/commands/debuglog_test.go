@@ -88,6 +88,35 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-trace", "0123456789abcdef"},
+			expected: common.DebugLogParams{
+				IncludeTrace: []string{"0123456789abcdef"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--include-label", "charm", "--exclude-label", "api"},
+			expected: common.DebugLogParams{
+				IncludeLabel: []string{"charm"},
+				ExcludeLabel: []string{"api"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--from", "2017-06-01T12:00:00Z", "--until", "2017-06-01T13:00:00Z"},
+			expected: common.DebugLogParams{
+				StartTime: time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2017, 6, 1, 13, 0, 0, 0, time.UTC),
+				Backlog:   10,
+			},
+		}, {
+			args:     []string{"--from", "yesterday"},
+			errMatch: `--from value "yesterday" is not a valid time in RFC3339 format`,
+		}, {
+			args:     []string{"--from", "2017-06-01T12:00:00Z", "--until", "2017-06-01T11:00:00Z"},
+			errMatch: `--until time is before --from time`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -182,6 +211,38 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestJSONOutput(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "unit.mysql/0.juju-log",
+				Location:  "juju-log.go:60",
+				Message:   "hello",
+				Labels:    []string{"charm"},
+			}, {
+				Entity:        "machine-0",
+				Timestamp:     time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:      "DEBUG",
+				Module:        "juju.apiserver",
+				Location:      "request.go:12",
+				Message:       "request",
+				Labels:        []string{"api"},
+				CorrelationID: "abcd",
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommand(), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"entity":"unit-mysql-0","timestamp":"2016-10-09T08:15:23.345Z","severity":"INFO",`+
+		`"module":"unit.mysql/0.juju-log","location":"juju-log.go:60","message":"hello","labels":["charm"]}`+"\n"+
+		`{"entity":"machine-0","timestamp":"2016-10-09T08:15:24Z","severity":"DEBUG",`+
+		`"module":"juju.apiserver","location":"request.go:12","message":"request","labels":["api"],"correlation-id":"abcd"}`+"\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
@@ -68,6 +68,7 @@ Aliases: help-tool, hook-tools
 }
 
 var expectedCommands = []string{
+	"action-artifact",
 	"action-fail",
 	"action-get",
 	"action-set",
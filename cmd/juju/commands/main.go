@@ -342,11 +342,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewShowUserCommand())
 	r.Register(user.NewListCommand())
 	r.Register(user.NewEnableCommand())
+	r.Register(user.NewUnlockCommand())
 	r.Register(user.NewDisableCommand())
 	r.Register(user.NewLoginCommand())
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddTokenCommand())
+	r.Register(user.NewRemoveTokenCommand())
+	r.Register(user.NewListTokensCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -366,6 +370,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewShowCleanupsCommand())
+	r.Register(model.NewModelQuotasCommand())
+	r.Register(model.NewSetModelQuotasCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -379,12 +386,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewIntrospectCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
+	r.Register(application.NewMoveUnitCommand())
+	r.Register(application.NewHookRetryCommand())
+	r.Register(application.NewProxyOverridesCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
@@ -401,12 +412,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewAddCommand())
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
+	r.Register(storage.NewPoolUpdateCommand())
+	r.Register(storage.NewPoolRemoveCommand())
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewImportVolumeCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -437,6 +452,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewDrainCommand())
+	r.Register(controller.NewVerifyDBCommand())
+	r.Register(controller.NewAddWebhookCommand())
+	r.Register(controller.NewRemoveWebhookCommand())
+	r.Register(controller.NewListWebhooksCommand())
+	r.Register(controller.NewCreateModelArchiveCommand())
+	r.Register(controller.NewListModelArchivesCommand())
+	r.Register(controller.NewDownloadModelArchiveCommand())
+	r.Register(controller.NewControllerMetricsCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -461,6 +485,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// CAAS commands
 	if featureflag.Enabled(feature.CAAS) {
 		r.Register(caas.NewAddCAASCommand(&cloudToCommandAdapter{}))
+		r.Register(caas.NewAddK8sCommand(&cloudToCommandAdapter{}))
 	}
 
 	// Juju GUI commands.
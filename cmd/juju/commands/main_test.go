@@ -396,8 +396,11 @@ var commandNames = []string{
 	"add-ssh-key",
 	"add-storage",
 	"add-subnet",
+	"add-token",
 	"add-unit",
 	"add-user",
+	"add-webhook",
+	"agent-introspect",
 	"agree",
 	"agreements",
 	"attach",
@@ -417,8 +420,10 @@ var commandNames = []string{
 	"config",
 	"consume",
 	"controller-config",
+	"controller-metrics",
 	"controllers",
 	"create-backup",
+	"create-model-archive",
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
@@ -432,6 +437,8 @@ var commandNames = []string{
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"download-model-archive",
+	"drain-controller",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
@@ -445,10 +452,12 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"hook-retry",
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
 	"import-ssh-key",
+	"import-volume",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -461,6 +470,7 @@ var commandNames = []string{
 	"list-disabled-commands",
 	"list-firewall-rules",
 	"list-machines",
+	"list-model-archives",
 	"list-models",
 	"list-offers",
 	"list-payloads",
@@ -472,21 +482,27 @@ var commandNames = []string{
 	"list-storage",
 	"list-storage-pools",
 	"list-subnets",
+	"list-tokens",
 	"list-users",
 	"list-wallets",
+	"list-webhooks",
 	"login",
 	"logout",
 	"machines",
 	"metrics",
 	"migrate",
+	"model-archives",
 	"model-config",
 	"model-default",
 	"model-defaults",
+	"model-quotas",
 	"models",
+	"move-unit",
 	"offer",
 	"offers",
 	"payloads",
 	"plans",
+	"proxy-overrides",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
@@ -503,8 +519,12 @@ var commandNames = []string{
 	"remove-saas",
 	"remove-ssh-key",
 	"remove-storage",
+	"remove-storage-pool",
+	"remove-token",
 	"remove-unit",
 	"remove-user",
+	"remove-webhook",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
@@ -521,11 +541,13 @@ var commandNames = []string{
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-model-quotas",
 	"set-plan",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
 	"show-backup",
+	"show-cleanups",
 	"show-cloud",
 	"show-controller",
 	"show-machine",
@@ -548,18 +570,24 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"tokens",
 	"unexpose",
+	"unlock-user",
 	"unregister",
 	"update-clouds",
 	"update-credential",
 	"update-series",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-model",
 	"upload-backup",
 	"users",
+	"verify-db",
 	"version",
 	"wallets",
+	"webhooks",
 	"whoami",
 }
 
@@ -17,12 +17,14 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/environs/tools"
@@ -54,10 +56,26 @@ controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
 Backups are recommended prior to upgrading.
+The upgrade of the model's other machines may be staged with '--canary'
+and '--batch-size'. Once the controllers have upgraded, only the canary
+machines are upgraded; the remaining machines are then upgraded in
+batches of the given size, each batch only once all the machines already
+upgraded are healthy. The progress of a staged upgrade is shown by
+` + "`juju status`" + `.
+With '--dry-run', the controller checks the model for problems that
+would prevent or complicate the upgrade, and reports them without
+changing anything: agents that have not finished a previous upgrade,
+models being migrated, deprecated model config, charms that require a
+newer version of Juju, and machines running series that are at or near
+the end of their support. Errors block the upgrade, and the command
+fails if any are found; warnings do not. The report may be formatted
+with '--format'.
 
 Examples:
     juju upgrade-juju --dry-run
+    juju upgrade-model --dry-run --agent-version 2.3.1 --format yaml
     juju upgrade-juju --agent-version 2.0.1
+    juju upgrade-model --canary 3,7 --batch-size 5
     
 See also: 
     sync-agent-binaries`
@@ -78,11 +96,20 @@ type upgradeJujuCommand struct {
 	DryRun        bool
 	ResetPrevious bool
 	AssumeYes     bool
+	out           cmd.Output
 
 	// IgnoreAgentVersions is used to allow an admin to request an agent version without waiting for all agents to be at the right
 	// version.
 	IgnoreAgentVersions bool
 
+	// Canaries holds the ids of the machines to upgrade first in a
+	// staged upgrade.
+	Canaries []string
+
+	// BatchSize is the number of machines to upgrade at a time after
+	// the canaries in a staged upgrade.
+	BatchSize int
+
 	// minMajorUpgradeVersion maps known major numbers to
 	// the minimum version that can be upgraded to that
 	// major version.  For example, users must be running
@@ -93,6 +120,7 @@ type upgradeJujuCommand struct {
 func (c *upgradeJujuCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "upgrade-juju",
+		Aliases: []string{"upgrade-model"},
 		Purpose: usageUpgradeJujuSummary,
 		Doc:     usageUpgradeJujuDetails,
 	}
@@ -108,6 +136,13 @@ func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
 	f.BoolVar(&c.IgnoreAgentVersions, "ignore-agent-versions", false,
 		"Don't check if all agents have already reached the current version")
+	f.Var(cmd.NewStringsValue(nil, &c.Canaries), "canary", "Comma-separated ids of machines to upgrade first, in a staged upgrade")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Number of machines to upgrade at a time after the canaries, in a staged upgrade")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatUpgradePrecheckTabular,
+		"yaml":    cmd.FormatYaml,
+	})
 }
 
 func (c *upgradeJujuCommand) Init(args []string) error {
@@ -127,6 +162,14 @@ func (c *upgradeJujuCommand) Init(args []string) error {
 		}
 		c.Version = vers
 	}
+	if c.BatchSize < 0 {
+		return errors.New("--batch-size must not be negative")
+	}
+	for _, id := range c.Canaries {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("canary machine %q", id)
+		}
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -178,6 +221,8 @@ type upgradeJujuAPI interface {
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
 	AbortCurrentUpgrade() error
 	SetModelAgentVersion(version version.Number, ignoreAgentVersion bool) error
+	SetModelAgentVersionStaged(version version.Number, ignoreAgentVersion bool, canaries []string, batchSize int) error
+	UpgradePrecheck(version version.Number, ignoreAgentVersions bool) (params.UpgradePrecheckResult, error)
 	Close() error
 }
 
@@ -363,6 +408,9 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", context.chosen, jujuversion.Current)
 	}
 	if c.DryRun {
+		if err := c.upgradePrecheck(ctx, client, context.chosen); err != nil {
+			return err
+		}
 		if c.BuildAgent {
 			fmt.Fprint(ctx.Stderr, "upgrade to this version by running\n    juju upgrade-juju --build-agent\n")
 		} else {
@@ -381,7 +429,7 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
-		if err := client.SetModelAgentVersion(context.chosen, c.IgnoreAgentVersions); err != nil {
+		if err := c.setModelAgentVersion(client, context.chosen); err != nil {
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
 					"Please wait for the upgrade to complete or if there was a problem with\n"+
@@ -397,6 +445,87 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// setModelAgentVersion starts the upgrade to the given version, staging
+// it if canaries or a batch size were requested.
+func (c *upgradeJujuCommand) setModelAgentVersion(client upgradeJujuAPI, vers version.Number) error {
+	if len(c.Canaries) == 0 && c.BatchSize == 0 {
+		return client.SetModelAgentVersion(vers, c.IgnoreAgentVersions)
+	}
+	return client.SetModelAgentVersionStaged(vers, c.IgnoreAgentVersions, c.Canaries, c.BatchSize)
+}
+
+// upgradePrecheck writes the report of the controller's checks for
+// problems upgrading the model to the given version, and returns an
+// error if any of them would prevent the upgrade.
+func (c *upgradeJujuCommand) upgradePrecheck(ctx *cmd.Context, client upgradeJujuAPI, vers version.Number) error {
+	result, err := client.UpgradePrecheck(vers, c.IgnoreAgentVersions)
+	if errors.IsNotSupported(err) {
+		ctx.Infof("upgrade precheck not supported by the controller")
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	report := upgradePrecheckReport{
+		Version: vers.String(),
+		Issues:  make([]upgradePrecheckIssue, len(result.Issues)),
+	}
+	blocking := 0
+	for i, issue := range result.Issues {
+		report.Issues[i] = upgradePrecheckIssue{
+			Check:    issue.Check,
+			Severity: issue.Severity,
+			Entity:   issue.Entity,
+			Message:  issue.Message,
+		}
+		if issue.Severity == params.UpgradePrecheckError {
+			blocking++
+		}
+	}
+	if err := c.out.Write(ctx, report); err != nil {
+		return errors.Trace(err)
+	}
+	if blocking > 0 {
+		return errors.Errorf("upgrade to %s blocked by %d precheck error(s)", vers, blocking)
+	}
+	return nil
+}
+
+// upgradePrecheckReport holds the result of an upgrade precheck for
+// output.
+type upgradePrecheckReport struct {
+	Version string                 `yaml:"version" json:"version"`
+	Issues  []upgradePrecheckIssue `yaml:"issues" json:"issues"`
+}
+
+// upgradePrecheckIssue describes a problem found by an upgrade
+// precheck for output.
+type upgradePrecheckIssue struct {
+	Check    string `yaml:"check" json:"check"`
+	Severity string `yaml:"severity" json:"severity"`
+	Entity   string `yaml:"entity,omitempty" json:"entity,omitempty"`
+	Message  string `yaml:"message" json:"message"`
+}
+
+func formatUpgradePrecheckTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(upgradePrecheckReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(writer, "no problems found upgrading to %s\n", report.Version)
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Check", "Severity", "Entity", "Message")
+	for _, issue := range report.Issues {
+		w.Println(issue.Check, issue.Severity, issue.Entity, issue.Message)
+	}
+	w.Flush()
+	return nil
+}
+
 func tryImplicitUpload(agentVersion version.Number) (bool, error) {
 	newerAgent := jujuversion.Current.Compare(agentVersion) > 0
 	if newerAgent || agentVersion.Build > 0 || jujuversion.Current.Build > 0 {
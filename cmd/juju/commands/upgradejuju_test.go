@@ -7,6 +7,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
@@ -93,6 +94,16 @@ var upgradeJujuTests = []struct {
 	currentVersion: "4.2.0-quantal-amd64",
 	args:           []string{"--agent-version", "4"},
 	expectInitErr:  `invalid version "4"`,
+}, {
+	about:          "invalid canary machine",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--canary", "0,foo"},
+	expectInitErr:  `canary machine "foo" not valid`,
+}, {
+	about:          "negative batch size",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--batch-size", "-1"},
+	expectInitErr:  "--batch-size must not be negative",
 }, {
 	about:          "major version upgrade to incompatible version",
 	currentVersion: "2.0.0-quantal-amd64",
@@ -844,6 +855,69 @@ func (s *UpgradeJujuSuite) TestUpgradeInProgress(c *gc.C) {
 	)
 }
 
+func (s *UpgradeJujuSuite) TestStagedUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := cmdtesting.InitCommand(modelcmd.Wrap(cmd), []string{"--canary", "3,7", "--batch-size", "5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelcmd.Wrap(cmd).Run(cmdtesting.Context(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.setCanariesCalledWith, jc.DeepEquals, []string{"3", "7"})
+	c.Assert(fakeAPI.setBatchSizeCalledWith, gc.Equals, 5)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeDryRunPrecheck(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(&upgradeJujuCommand{}), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.precheckCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"no problems found upgrading to "+fakeAPI.nextVersion.Number.String()+"\n")
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeDryRunPrecheckIssues(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.precheckIssues = []params.UpgradePrecheckIssue{{
+		Check:    "agent-version",
+		Severity: params.UpgradePrecheckError,
+		Entity:   "machine-1",
+		Message:  "agent has not upgraded",
+	}, {
+		Check:    "model-config",
+		Severity: params.UpgradePrecheckWarning,
+		Message:  "deprecated",
+	}}
+	fakeAPI.patch(s)
+
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(&upgradeJujuCommand{}), "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `upgrade to .* blocked by 1 precheck error\(s\)`)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Check          Severity  Entity     Message\n"+
+		"agent-version  error     machine-1  agent has not upgraded\n"+
+		"model-config   warning              deprecated\n")
+
+	ctx, err = cmdtesting.RunCommand(c, modelcmd.Wrap(&upgradeJujuCommand{}), "--dry-run", "--format", "yaml")
+	c.Assert(err, gc.NotNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+version: %s
+issues:
+- check: agent-version
+  severity: error
+  entity: machine-1
+  message: agent has not upgraded
+- check: model-config
+  severity: warning
+  message: deprecated
+`[1:], fakeAPI.nextVersion.Number))
+}
+
 func (s *UpgradeJujuSuite) TestBlockUpgradeInProgress(c *gc.C) {
 	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
 	fakeAPI.setVersionErr = common.OperationBlockedError("the operation has been blocked")
@@ -944,8 +1018,12 @@ type fakeUpgradeJujuAPI struct {
 	abortCurrentUpgradeCalled bool
 	setVersionCalledWith      version.Number
 	setIgnoreCalledWith       bool
+	setCanariesCalledWith     []string
+	setBatchSizeCalledWith    int
 	tools                     []string
 	findToolsCalled           bool
+	precheckCalledWith        version.Number
+	precheckIssues            []params.UpgradePrecheckIssue
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -953,8 +1031,12 @@ func (a *fakeUpgradeJujuAPI) reset() {
 	a.abortCurrentUpgradeCalled = false
 	a.setVersionCalledWith = version.Number{}
 	a.setIgnoreCalledWith = false
+	a.setCanariesCalledWith = nil
+	a.setBatchSizeCalledWith = 0
 	a.tools = []string{}
 	a.findToolsCalled = false
+	a.precheckCalledWith = version.Number{}
+	a.precheckIssues = nil
 }
 
 func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
@@ -1017,6 +1099,19 @@ func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number, ignoreAgentV
 	return a.setVersionErr
 }
 
+func (a *fakeUpgradeJujuAPI) SetModelAgentVersionStaged(v version.Number, ignoreAgentVersions bool, canaries []string, batchSize int) error {
+	a.setVersionCalledWith = v
+	a.setIgnoreCalledWith = ignoreAgentVersions
+	a.setCanariesCalledWith = canaries
+	a.setBatchSizeCalledWith = batchSize
+	return a.setVersionErr
+}
+
+func (a *fakeUpgradeJujuAPI) UpgradePrecheck(v version.Number, ignoreAgentVersions bool) (params.UpgradePrecheckResult, error) {
+	a.precheckCalledWith = v
+	return params.UpgradePrecheckResult{Version: v, Issues: a.precheckIssues}, nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
@@ -54,6 +54,7 @@ type addModelCommand struct {
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Database       string
 	noSwitch       bool
 }
 
@@ -93,6 +94,12 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+Controller administrators may place the logs and non-transactional
+collections of a heavyweight model in a mongo database of its own with
+--database, so that the model does not crowd out the controller's own
+database. The database name must start with "juju-", and is fixed when
+the model is created.
+
 Examples:
 
     juju add-model mymodel
@@ -100,6 +107,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --database juju-mymodel
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -116,6 +124,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.Database, "database", "", "The mongo database in which to place the model's logs and history, if not the controller's")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
 }
 
@@ -145,6 +154,7 @@ type AddModelAPI interface {
 		name, owner, cloudName, cloudRegion string,
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
+		database string,
 	) (base.ModelInfo, error)
 }
 
@@ -229,7 +239,7 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(api)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs, c.Database)
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -327,6 +327,13 @@ func (s *AddModelSuite) TestCloudRegionPassedThrough(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
 }
 
+func (s *AddModelSuite) TestDatabasePassedThrough(c *gc.C) {
+	_, err := s.run(c, "test", "--database", "juju-test")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.database, gc.Equals, "juju-test")
+}
+
 func (s *AddModelSuite) TestDefaultCloudPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test")
 	c.Assert(err, jc.ErrorIsNil)
@@ -593,6 +600,7 @@ type fakeAddClient struct {
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	database        string
 	err             error
 	model           base.ModelInfo
 }
@@ -603,10 +611,11 @@ func (*fakeAddClient) Close() error {
 	return nil
 }
 
-func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}, database string) (base.ModelInfo, error) {
 	if f.err != nil {
 		return base.ModelInfo{}, f.err
 	}
+	f.database = database
 	f.owner = owner
 	f.cloudCredential = cloudCredential
 	f.cloudName = cloudName
@@ -0,0 +1,213 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewControllerMetricsCommand returns a command that shows the
+// controller's internal metrics.
+func NewControllerMetricsCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerMetricsCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// controllerMetricsCommand shows the internal metrics recently
+// sampled by the controller machines.
+type controllerMetricsCommand struct {
+	modelcmd.ControllerCommandBase
+	api   controllerMetricsAPI
+	clock clock.Clock
+	out   cmd.Output
+	since time.Duration
+}
+
+const controllerMetricsHelpDoc = `
+Shows the internal metrics recently sampled by each controller machine.
+Controllers regularly record the size of their database, the time taken
+to ping it, the number of transactions waiting to be applied, the time
+taken to deliver messages on the controller's internal hub and the time
+taken to read leadership leases. Samples are kept for 24 hours.
+
+The tabular format summarises each metric over the requested period;
+the yaml and json formats show every sample. Only controller
+administrators may see the metrics.
+
+Examples:
+
+    juju controller-metrics
+    juju controller-metrics --since 6h --format yaml
+
+See also:
+    controllers
+    show-controller
+`
+
+// Info is part of the cmd.Command interface.
+func (c *controllerMetricsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-metrics",
+		Purpose: "Shows the controller's internal metrics.",
+		Doc:     strings.TrimSpace(controllerMetricsHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *controllerMetricsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.DurationVar(&c.since, "since", time.Hour, "Show samples taken within this period")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerMetricsTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *controllerMetricsCommand) Init(args []string) error {
+	if c.since <= 0 {
+		return errors.New("--since must be positive")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type controllerMetricsAPI interface {
+	Close() error
+	ControllerMetrics(since time.Time) ([]apicontroller.ControllerMetricsSample, error)
+}
+
+func (c *controllerMetricsCommand) getAPI() (controllerMetricsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *controllerMetricsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	samples, err := client.ControllerMetrics(c.clock.Now().Add(-c.since))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(samples) == 0 {
+		ctx.Infof("no controller metrics recorded in the last %v", c.since)
+		return nil
+	}
+	out := make([]controllerMetricsSample, len(samples))
+	for i, sample := range samples {
+		out[i] = controllerMetricsSample{
+			Machine: sample.Machine,
+			Time:    sample.Time,
+			Metrics: sample.Metrics,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+// controllerMetricsSample holds a sample of controller metrics for
+// output.
+type controllerMetricsSample struct {
+	Machine string             `yaml:"machine" json:"machine"`
+	Time    time.Time          `yaml:"time" json:"time"`
+	Metrics map[string]float64 `yaml:"metrics" json:"metrics"`
+}
+
+// metricSummary summarises the samples of one metric from one machine.
+type metricSummary struct {
+	latest, min, max, total float64
+	count                   int
+}
+
+func formatControllerMetricsTabular(writer io.Writer, value interface{}) error {
+	samples, ok := value.([]controllerMetricsSample)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", samples, value)
+	}
+	summaries := make(map[string]map[string]*metricSummary)
+	for _, sample := range samples {
+		machine := summaries[sample.Machine]
+		if machine == nil {
+			machine = make(map[string]*metricSummary)
+			summaries[sample.Machine] = machine
+		}
+		for name, value := range sample.Metrics {
+			summary := machine[name]
+			if summary == nil {
+				summary = &metricSummary{min: math.Inf(1), max: math.Inf(-1)}
+				machine[name] = summary
+			}
+			// Samples arrive oldest first, so the last one seen
+			// is the latest.
+			summary.latest = value
+			summary.min = math.Min(summary.min, value)
+			summary.max = math.Max(summary.max, value)
+			summary.total += value
+			summary.count++
+		}
+	}
+	var machines []string
+	for machine := range summaries {
+		machines = append(machines, machine)
+	}
+	sort.Strings(machines)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Metric", "Latest", "Min", "Max", "Avg", "Samples")
+	for _, machine := range machines {
+		var names []string
+		for name := range summaries[machine] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			summary := summaries[machine][name]
+			w.Println(
+				machine, name,
+				formatMetricValue(summary.latest),
+				formatMetricValue(summary.min),
+				formatMetricValue(summary.max),
+				formatMetricValue(summary.total/float64(summary.count)),
+				summary.count,
+			)
+		}
+	}
+	w.Flush()
+	return nil
+}
+
+// formatMetricValue formats whole values, such as sizes and counts,
+// without a fractional part, and others, such as latencies, to four
+// decimal places.
+func formatMetricValue(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.4f", value)
+}
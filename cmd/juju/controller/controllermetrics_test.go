@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type ControllerMetricsSuite struct {
+	baseControllerSuite
+	api   *fakeControllerMetricsAPI
+	clock *jtesting.Clock
+}
+
+var _ = gc.Suite(&ControllerMetricsSuite{})
+
+func (s *ControllerMetricsSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.clock = jtesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.api = &fakeControllerMetricsAPI{
+		samples: []apicontroller.ControllerMetricsSample{{
+			Machine: "0",
+			Time:    time.Date(2017, 6, 1, 11, 58, 0, 0, time.UTC),
+			Metrics: map[string]float64{"txn-queue-depth": 4, "pubsub-lag-seconds": 0.0002},
+		}, {
+			Machine: "1",
+			Time:    time.Date(2017, 6, 1, 11, 58, 30, 0, time.UTC),
+			Metrics: map[string]float64{"txn-queue-depth": 1},
+		}, {
+			Machine: "0",
+			Time:    time.Date(2017, 6, 1, 11, 59, 0, 0, time.UTC),
+			Metrics: map[string]float64{"txn-queue-depth": 2, "pubsub-lag-seconds": 0.0004},
+		}},
+	}
+}
+
+func (s *ControllerMetricsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewControllerMetricsCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *ControllerMetricsSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"ControllerMetrics", []interface{}{time.Date(2017, 6, 1, 11, 0, 0, 0, time.UTC)}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Metric              Latest  Min     Max     Avg     Samples
+0        pubsub-lag-seconds  0.0004  0.0002  0.0004  0.0003  2
+0        txn-queue-depth     2       2       4       3       2
+1        txn-queue-depth     1       1       1       1       1
+`[1:])
+}
+
+func (s *ControllerMetricsSuite) TestYAML(c *gc.C) {
+	s.api.samples = s.api.samples[1:2]
+	ctx, err := s.run(c, "--since", "10m", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ControllerMetrics", time.Date(2017, 6, 1, 11, 50, 0, 0, time.UTC))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- machine: "1"
+  time: 2017-06-01T11:58:30Z
+  metrics:
+    txn-queue-depth: 1
+`[1:])
+}
+
+func (s *ControllerMetricsSuite) TestNoSamples(c *gc.C) {
+	s.api.samples = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no controller metrics recorded in the last 1h0m0s\n")
+}
+
+func (s *ControllerMetricsSuite) TestInvalidSince(c *gc.C) {
+	_, err := s.run(c, "--since", "0s")
+	c.Assert(err, gc.ErrorMatches, "--since must be positive")
+}
+
+func (s *ControllerMetricsSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeControllerMetricsAPI struct {
+	jtesting.Stub
+	samples []apicontroller.ControllerMetricsSample
+}
+
+func (f *fakeControllerMetricsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeControllerMetricsAPI) ControllerMetrics(since time.Time) ([]apicontroller.ControllerMetricsSample, error) {
+	f.MethodCall(f, "ControllerMetrics", since)
+	return f.samples, f.NextErr()
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewDrainCommand returns a command that drains the API server of a
+// controller machine.
+func NewDrainCommand() cmd.Command {
+	return modelcmd.WrapController(&drainCommand{})
+}
+
+// drainCommand asks the API server of a controller machine to stop
+// accepting connections and to move its clients to the other API
+// servers, or reports the drain status of the API servers.
+type drainCommand struct {
+	modelcmd.ControllerCommandBase
+	api       drainAPI
+	out       cmd.Output
+	machineId string
+	cancel    bool
+	status    bool
+}
+
+const drainControllerHelpDoc = `
+Draining the API server of a controller machine stops it from accepting
+new connections, and closes its existing connections in small batches so
+that agents and clients reconnect to the other API servers. Once the API
+server is idle, the controller machine can be upgraded or restarted
+without the agents connected to it all reconnecting at once.
+
+Connections between the controller machines are not closed.
+
+Use --status to show the drain status of each API server, and --cancel
+to make a draining API server accept connections again.
+
+Examples:
+
+    juju drain-controller 1
+    juju drain-controller --status
+    juju drain-controller --cancel 1
+
+See also:
+    enable-ha
+    show-controller
+`
+
+// Info is part of the cmd.Command interface.
+func (c *drainCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "drain-controller",
+		Args:    "[<machine id>]",
+		Purpose: "Moves connections away from the API server of a controller machine.",
+		Doc:     strings.TrimSpace(drainControllerHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *drainCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.cancel, "cancel", false, "Stop draining the API server")
+	f.BoolVar(&c.status, "status", false, "Show the drain status of the API servers")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatDrainStatusTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *drainCommand) Init(args []string) error {
+	if c.status {
+		if c.cancel {
+			return errors.New("cannot specify both --status and --cancel")
+		}
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no machine id specified")
+	}
+	c.machineId, args = args[0], args[1:]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine id %q", c.machineId)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type drainAPI interface {
+	Close() error
+	DrainAPIServer(machineId string, drain bool) error
+	APIServerDrainStatus() ([]params.APIServerDrainStatus, error)
+}
+
+func (c *drainCommand) getAPI() (drainAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *drainCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.status {
+		servers, err := client.APIServerDrainStatus()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, convertDrainStatus(servers))
+	}
+	if err := client.DrainAPIServer(c.machineId, !c.cancel); err != nil {
+		return errors.Trace(err)
+	}
+	if c.cancel {
+		ctx.Infof("API server on machine %s is no longer draining", c.machineId)
+	} else {
+		ctx.Infof("draining API server on machine %s; use --status to follow progress", c.machineId)
+	}
+	return nil
+}
+
+// drainStatus holds the drain status of an API server for output.
+type drainStatus struct {
+	Draining    bool  `yaml:"draining" json:"draining"`
+	Connections int64 `yaml:"connections" json:"connections"`
+	Idle        bool  `yaml:"idle" json:"idle"`
+}
+
+func convertDrainStatus(servers []params.APIServerDrainStatus) map[string]drainStatus {
+	result := make(map[string]drainStatus, len(servers))
+	for _, server := range servers {
+		result[server.MachineId] = drainStatus{
+			Draining:    server.Draining,
+			Connections: server.Connections,
+			Idle:        server.Idle,
+		}
+	}
+	return result
+}
+
+func formatDrainStatusTabular(writer io.Writer, value interface{}) error {
+	servers, ok := value.(map[string]drainStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", servers, value)
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(servers))
+	for id := range servers {
+		ids = append(ids, id)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Status", "Connections")
+	for _, id := range utils.SortStringsNaturally(ids) {
+		server := servers[id]
+		status := "serving"
+		switch {
+		case server.Idle:
+			status = "idle"
+		case server.Draining:
+			status = "draining"
+		}
+		w.Println(id, status, server.Connections)
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type DrainSuite struct {
+	baseControllerSuite
+	api *fakeDrainAPI
+}
+
+var _ = gc.Suite(&DrainSuite{})
+
+func (s *DrainSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeDrainAPI{
+		servers: []params.APIServerDrainStatus{
+			{MachineId: "0", Connections: 12},
+			{MachineId: "10", Draining: true, Idle: true},
+			{MachineId: "2", Draining: true, Connections: 3},
+		},
+	}
+}
+
+func (s *DrainSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewDrainCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *DrainSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine id specified",
+	}, {
+		args: []string{"foo"},
+		err:  `machine id "foo" not valid`,
+	}, {
+		args: []string{"1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}, {
+		args: []string{"--status", "1"},
+		err:  `unrecognized args: \["1"\]`,
+	}, {
+		args: []string{"--status", "--cancel"},
+		err:  "cannot specify both --status and --cancel",
+	}, {
+		args: []string{"1"},
+	}, {
+		args: []string{"--cancel", "1"},
+	}, {
+		args: []string{"--status"},
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		err := cmdtesting.InitCommand(controller.NewDrainCommandForTest(s.api, s.store), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *DrainSuite) TestDrain(c *gc.C) {
+	ctx, err := s.run(c, "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"DrainAPIServer", []interface{}{"1", true}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "draining API server on machine 1; use --status to follow progress\n")
+}
+
+func (s *DrainSuite) TestCancel(c *gc.C) {
+	ctx, err := s.run(c, "--cancel", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"DrainAPIServer", []interface{}{"1", false}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "API server on machine 1 is no longer draining\n")
+}
+
+func (s *DrainSuite) TestDrainError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DrainSuite) TestStatusTabular(c *gc.C) {
+	ctx, err := s.run(c, "--status")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "APIServerDrainStatus", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Status    Connections
+0        serving   12
+2        draining  3
+10       idle      0
+`[1:])
+}
+
+func (s *DrainSuite) TestStatusYAML(c *gc.C) {
+	ctx, err := s.run(c, "--status", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"0":
+  draining: false
+  connections: 12
+  idle: false
+"2":
+  draining: true
+  connections: 3
+  idle: false
+"10":
+  draining: true
+  connections: 0
+  idle: true
+`[1:])
+}
+
+type fakeDrainAPI struct {
+	jtesting.Stub
+	servers []params.APIServerDrainStatus
+}
+
+func (f *fakeDrainAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeDrainAPI) DrainAPIServer(machineId string, drain bool) error {
+	f.MethodCall(f, "DrainAPIServer", machineId, drain)
+	return f.NextErr()
+}
+
+func (f *fakeDrainAPI) APIServerDrainStatus() ([]params.APIServerDrainStatus, error) {
+	f.MethodCall(f, "APIServerDrainStatus")
+	return f.servers, f.NextErr()
+}
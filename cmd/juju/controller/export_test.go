@@ -161,6 +161,78 @@ func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c)
 }
 
+// NewDrainCommandForTest returns a drainCommand with the api provided
+// as specified.
+func NewDrainCommandForTest(api drainAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &drainCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewVerifyDBCommandForTest returns a verifyDBCommand with the api
+// provided as specified.
+func NewVerifyDBCommandForTest(api verifyDBAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &verifyDBCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddWebhookCommandForTest returns an addWebhookCommand with the
+// api provided as specified.
+func NewAddWebhookCommandForTest(api webhooksAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addWebhookCommand{webhooksCommandBase: webhooksCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveWebhookCommandForTest returns a removeWebhookCommand with
+// the api provided as specified.
+func NewRemoveWebhookCommandForTest(api webhooksAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeWebhookCommand{webhooksCommandBase: webhooksCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListWebhooksCommandForTest returns a listWebhooksCommand with the
+// api provided as specified.
+func NewListWebhooksCommandForTest(api webhooksAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listWebhooksCommand{webhooksCommandBase: webhooksCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewCreateModelArchiveCommandForTest returns a
+// createModelArchiveCommand with the api provided as specified.
+func NewCreateModelArchiveCommandForTest(api modelArchivesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &createModelArchiveCommand{modelArchivesCommandBase: modelArchivesCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListModelArchivesCommandForTest returns a
+// listModelArchivesCommand with the api provided as specified.
+func NewListModelArchivesCommandForTest(api modelArchivesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listModelArchivesCommand{modelArchivesCommandBase: modelArchivesCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewDownloadModelArchiveCommandForTest returns a
+// downloadModelArchiveCommand with the api provided as specified.
+func NewDownloadModelArchiveCommandForTest(api modelArchivesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &downloadModelArchiveCommand{modelArchivesCommandBase: modelArchivesCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewControllerMetricsCommandForTest returns a controllerMetricsCommand
+// with the api and clock provided as specified.
+func NewControllerMetricsCommandForTest(api controllerMetricsAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerMetricsCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
 	"github.com/juju/utils/set"
 
 	apicontroller "github.com/juju/juju/api/controller"
@@ -24,12 +25,14 @@ func NewGetConfigCommand() cmd.Command {
 }
 
 // getConfigCommand is able to output either the entire environment or
-// the requested value in a format of the user's choosing.
+// the requested value in a format of the user's choosing, or to change
+// the values of the attributes that may be changed at runtime.
 type getConfigCommand struct {
 	modelcmd.ControllerCommandBase
-	api controllerAPI
-	key string
-	out cmd.Output
+	api    controllerAPI
+	key    string
+	values map[string]interface{}
+	out    cmd.Output
 }
 
 const getControllerHelpDoc = `
@@ -40,11 +43,33 @@ The controller configuration is set during bootstrap, available keys
 and values can be found here:
   https://jujucharms.com/docs/stable/controllers-config
 
+Controller administrators may change the following keys by giving
+key=value pairs. The controller applies the new values without its
+agents being restarted.
+
+    auditing-enabled
+    agent-token-lifetime
+    api-request-rate-limit
+    api-request-rate-burst
+    login-lockout-duration
+    login-lockout-threshold
+    max-logs-age
+    max-logs-size
+    mongo-memory-profile
+    password-max-age
+    password-min-character-classes
+    password-min-length
+
+Log forwarding targets are set with the syslog-* keys of the
+controller model's configuration; see model-config.
+
 Examples:
 
     juju controller-config
     juju controller-config api-port
     juju controller-config -c mycontroller
+    juju controller-config auditing-enabled=true
+    juju controller-config api-request-rate-limit=20 api-request-rate-burst=100
 
 See also:
     controllers
@@ -55,7 +80,7 @@ See also:
 func (c *getConfigCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "controller-config",
-		Args:    "[<attribute key>]",
+		Args:    "[<attribute key> | <attribute key>=<value> ...]",
 		Purpose: "Displays configuration settings for a controller.",
 		Doc:     strings.TrimSpace(getControllerHelpDoc),
 	}
@@ -71,6 +96,20 @@ func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *getConfigCommand) Init(args []string) (err error) {
+	if len(args) > 0 && strings.Contains(args[0], "=") {
+		values, err := keyvalues.Parse(args, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.values = make(map[string]interface{})
+		for key, value := range values {
+			if !controller.AllowedUpdateConfigAttributes.Contains(key) {
+				return errors.Errorf("cannot change %q after bootstrap", key)
+			}
+			c.values[key] = value
+		}
+		return nil
+	}
 	c.key, err = cmd.ZeroOrOneArgs(args)
 	return
 }
@@ -78,6 +117,7 @@ func (c *getConfigCommand) Init(args []string) (err error) {
 type controllerAPI interface {
 	Close() error
 	ControllerConfig() (controller.Config, error)
+	ConfigSet(map[string]interface{}) error
 }
 
 func (c *getConfigCommand) getAPI() (controllerAPI, error) {
@@ -102,6 +142,10 @@ func (c *getConfigCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.values != nil {
+		return errors.Trace(client.ConfigSet(c.values))
+	}
+
 	attrs, err := client.ControllerConfig()
 	if err != nil {
 		return err
@@ -89,8 +89,33 @@ func (s *GetConfigSuite) TestError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error")
 }
 
+func (s *GetConfigSuite) TestInitSet(c *gc.C) {
+	err := cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"auditing-enabled=true", "max-logs-age"})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "max-logs-age"`)
+	err = cmdtesting.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"api-port=1234"})
+	c.Check(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+}
+
+func (s *GetConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "auditing-enabled=true", "api-request-rate-limit=20")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"auditing-enabled":       "true",
+		"api-request-rate-limit": "20",
+	})
+}
+
+func (s *GetConfigSuite) TestSetValuesError(c *gc.C) {
+	command := controller.NewGetConfigCommandForTest(&fakeControllerAPI{err: errors.New("error")}, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "auditing-enabled=true")
+	c.Assert(err, gc.ErrorMatches, "error")
+}
+
 type fakeControllerAPI struct {
-	err error
+	err    error
+	values map[string]interface{}
 }
 
 func (f *fakeControllerAPI) Close() error {
@@ -107,3 +132,8 @@ func (f *fakeControllerAPI) ControllerConfig() (jujucontroller.Config, error) {
 		"ca-cert":         "multi\nline",
 	}, nil
 }
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}) error {
+	f.values = values
+	return f.err
+}
@@ -0,0 +1,385 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelarchives"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// modelArchivesAPI defines the methods of the ModelArchives facade
+// used by the model archive commands.
+type modelArchivesAPI interface {
+	Close() error
+	Create(modelUUID string, signingKey []byte) (params.ModelArchive, error)
+	List(modelUUID string) ([]params.ModelArchive, error)
+	Download(id string) (io.ReadCloser, error)
+}
+
+// modelArchivesCommandBase holds the API used by the model archive
+// commands.
+type modelArchivesCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api modelArchivesAPI
+}
+
+func (c *modelArchivesCommandBase) getAPI() (modelArchivesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelarchives.NewClient(root), nil
+}
+
+// modelUUID returns the UUID of the named model. An unqualified name
+// is taken to be that of a model owned by the current user.
+func (c *modelArchivesCommandBase) modelUUID(modelName string) (string, error) {
+	if !jujuclient.IsQualifiedModelName(modelName) {
+		account, err := c.CurrentAccountDetails()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		modelName = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), modelName)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return uuids[0], nil
+}
+
+// NewCreateModelArchiveCommand returns a command that archives a
+// model in the controller.
+func NewCreateModelArchiveCommand() cmd.Command {
+	return modelcmd.WrapController(&createModelArchiveCommand{})
+}
+
+type createModelArchiveCommand struct {
+	modelArchivesCommandBase
+	modelName      string
+	signingKeyFile string
+}
+
+const createModelArchiveHelpDoc = `
+Archives a model for cold backup. The archive holds the model's
+description along with the charms, agent binaries and resources it
+uses, and is kept by the controller even after the model is destroyed.
+
+The archive is signed, so that it can be checked before it is imported
+into a controller. Unless a file containing the signing key is given
+with --signing-key-file, a key is generated and printed; it cannot be
+retrieved later, and is needed to import the archive.
+
+Only controller administrators may archive models.
+
+Examples:
+
+    juju create-model-archive prod
+    juju create-model-archive prod --signing-key-file ~/archive-key
+
+See also:
+    download-model-archive
+    model-archives
+`
+
+// Info is part of the cmd.Command interface.
+func (c *createModelArchiveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-model-archive",
+		Args:    "<model name>",
+		Purpose: "Archives a model in the controller.",
+		Doc:     strings.TrimSpace(createModelArchiveHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *createModelArchiveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.modelArchivesCommandBase.SetFlags(f)
+	f.StringVar(&c.signingKeyFile, "signing-key-file", "", "Path to a file containing the key used to sign the archive")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *createModelArchiveCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model name specified")
+	}
+	c.modelName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *createModelArchiveCommand) Run(ctx *cmd.Context) error {
+	signingKey, generated, err := c.signingKey(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID, err := c.modelUUID(c.modelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	archive, err := client.Create(modelUUID, []byte(signingKey))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Archived model %q as %s.", c.modelName, archive.ID)
+	if generated {
+		ctx.Infof("The archive is signed with the key:")
+		fmt.Fprintln(ctx.Stdout, signingKey)
+	}
+	return nil
+}
+
+// signingKey returns the key read from the signing key file, or a new
+// random key if no file was given.
+func (c *createModelArchiveCommand) signingKey(ctx *cmd.Context) (string, bool, error) {
+	if c.signingKeyFile == "" {
+		key, err := utils.RandomPassword()
+		if err != nil {
+			return "", false, errors.Annotate(err, "cannot generate signing key")
+		}
+		return key, true, nil
+	}
+	path, err := utils.NormalizePath(c.signingKeyFile)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(path))
+	if err != nil {
+		return "", false, errors.Annotate(err, "cannot read signing key")
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", false, errors.Errorf("signing key file %q is empty", c.signingKeyFile)
+	}
+	return key, false, nil
+}
+
+// NewListModelArchivesCommand returns a command that lists the model
+// archives held by the controller.
+func NewListModelArchivesCommand() cmd.Command {
+	return modelcmd.WrapController(&listModelArchivesCommand{})
+}
+
+type listModelArchivesCommand struct {
+	modelArchivesCommandBase
+	modelName string
+	out       cmd.Output
+}
+
+const listModelArchivesHelpDoc = `
+Lists the model archives held by the controller, oldest first. If a
+model name is given, only the archives of that model are listed.
+
+Examples:
+
+    juju model-archives
+    juju model-archives prod --format yaml
+
+See also:
+    create-model-archive
+    download-model-archive
+`
+
+// Info is part of the cmd.Command interface.
+func (c *listModelArchivesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-archives",
+		Args:    "[<model name>]",
+		Purpose: "Lists the model archives held by the controller.",
+		Doc:     strings.TrimSpace(listModelArchivesHelpDoc),
+		Aliases: []string{"list-model-archives"},
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listModelArchivesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.modelArchivesCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatModelArchivesTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listModelArchivesCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.modelName, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// modelArchive describes a model archive for output.
+type modelArchive struct {
+	ID      string    `yaml:"id" json:"id"`
+	Model   string    `yaml:"model" json:"model"`
+	Created time.Time `yaml:"created" json:"created"`
+	Size    int64     `yaml:"size" json:"size"`
+	SHA256  string    `yaml:"sha256" json:"sha256"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listModelArchivesCommand) Run(ctx *cmd.Context) error {
+	var modelUUID string
+	if c.modelName != "" {
+		var err error
+		if modelUUID, err = c.modelUUID(c.modelName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.List(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model archives to display.")
+		return nil
+	}
+	out := make([]modelArchive, len(results))
+	for i, result := range results {
+		out[i] = modelArchive{
+			ID:      result.ID,
+			Model:   result.ModelName,
+			Created: result.Created,
+			Size:    result.Size,
+			SHA256:  result.SHA256,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatModelArchivesTabular(writer io.Writer, value interface{}) error {
+	archives, ok := value.([]modelArchive)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", archives, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "Model", "Created", "Size")
+	for _, archive := range archives {
+		w.Println(
+			archive.ID,
+			archive.Model,
+			archive.Created.UTC().Format(time.RFC3339),
+			humanize.IBytes(uint64(archive.Size)),
+		)
+	}
+	w.Flush()
+	return nil
+}
+
+// NewDownloadModelArchiveCommand returns a command that downloads a
+// model archive from the controller.
+func NewDownloadModelArchiveCommand() cmd.Command {
+	return modelcmd.WrapController(&downloadModelArchiveCommand{})
+}
+
+type downloadModelArchiveCommand struct {
+	modelArchivesCommandBase
+	id       string
+	filename string
+}
+
+const downloadModelArchiveHelpDoc = `
+Downloads a model archive held by the controller. Unless a filename is
+given with --filename, the archive is saved as juju-model-<id>.tar.gz
+in the current directory. The filename is printed.
+
+Examples:
+
+    juju download-model-archive 5e3b6e0a-53a7-4b4e-8f2b-0c9d1d2a6e1f
+    juju download-model-archive 5e3b6e0a-53a7-4b4e-8f2b-0c9d1d2a6e1f --filename prod.tar.gz
+
+See also:
+    create-model-archive
+    model-archives
+`
+
+// Info is part of the cmd.Command interface.
+func (c *downloadModelArchiveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "download-model-archive",
+		Args:    "<id>",
+		Purpose: "Downloads a model archive from the controller.",
+		Doc:     strings.TrimSpace(downloadModelArchiveHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *downloadModelArchiveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.modelArchivesCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "File to which the archive is saved")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *downloadModelArchiveCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive ID specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *downloadModelArchiveCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	r, err := client.Download(c.id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+
+	filename := c.filename
+	if filename == "" {
+		filename = "juju-model-" + c.id + ".tar.gz"
+	}
+	f, err := os.Create(ctx.AbsPath(filename))
+	if err != nil {
+		return errors.Annotate(err, "cannot create archive file")
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Annotate(err, "cannot write archive file")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Annotate(err, "cannot write archive file")
+	}
+	fmt.Fprintln(ctx.Stdout, filename)
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type ModelArchivesSuite struct {
+	baseControllerSuite
+	api *fakeModelArchivesAPI
+}
+
+var _ = gc.Suite(&ModelArchivesSuite{})
+
+func (s *ModelArchivesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.modelsYaml = `
+controllers:
+  mallards:
+    models:
+      admin/prod:
+        uuid: prod-uuid
+    current-model: admin/prod
+`
+	s.createTestClientStore(c)
+	s.api = &fakeModelArchivesAPI{
+		archives: []params.ModelArchive{{
+			ID:        "archive-1",
+			ModelTag:  "model-prod-uuid",
+			ModelName: "prod",
+			Size:      3 * 1024 * 1024,
+			SHA256:    "abcd",
+			Created:   time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+		}},
+		content: "archived model",
+	}
+}
+
+func (s *ModelArchivesSuite) TestCreateInit(c *gc.C) {
+	command := controller.NewCreateModelArchiveCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Check(err, gc.ErrorMatches, "no model name specified")
+
+	command = controller.NewCreateModelArchiveCommandForTest(s.api, s.store)
+	err = cmdtesting.InitCommand(command, []string{"prod", "extra"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ModelArchivesSuite) TestCreateGeneratesKey(c *gc.C) {
+	command := controller.NewCreateModelArchiveCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "prod")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Create", "Close")
+	args := s.api.Calls()[0].Args
+	c.Check(args[0], gc.Equals, "prod-uuid")
+
+	key := string(args[1].([]byte))
+	c.Check(key, gc.Not(gc.Equals), "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, key+"\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Archived model "prod" as archive-1.
+The archive is signed with the key:
+`[1:])
+}
+
+func (s *ModelArchivesSuite) TestCreateSigningKeyFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(path, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := controller.NewCreateModelArchiveCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "admin/prod", "--signing-key-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Create", []interface{}{"prod-uuid", []byte("sekrit")}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *ModelArchivesSuite) TestList(c *gc.C) {
+	command := controller.NewListModelArchivesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "List", "")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ID         Model  Created               Size
+archive-1  prod   2017-11-01T12:00:00Z  3.0 MiB
+`[1:])
+}
+
+func (s *ModelArchivesSuite) TestListModelYAML(c *gc.C) {
+	command := controller.NewListModelArchivesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "prod", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "List", "prod-uuid")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: archive-1
+  model: prod
+  created: 2017-11-01T12:00:00Z
+  size: 3145728
+  sha256: abcd
+`[1:])
+}
+
+func (s *ModelArchivesSuite) TestListNone(c *gc.C) {
+	s.api.archives = nil
+	command := controller.NewListModelArchivesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No model archives to display.\n")
+}
+
+func (s *ModelArchivesSuite) TestDownload(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "prod.tar.gz")
+	command := controller.NewDownloadModelArchiveCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "archive-1", "--filename", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Download", []interface{}{"archive-1"}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, path+"\n")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archived model")
+}
+
+func (s *ModelArchivesSuite) TestDownloadDefaultFilename(c *gc.C) {
+	command := controller.NewDownloadModelArchiveCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "archive-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "juju-model-archive-1.tar.gz\n")
+	_, err = ioutil.ReadFile(ctx.AbsPath("juju-model-archive-1.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelArchivesSuite) TestDownloadInit(c *gc.C) {
+	command := controller.NewDownloadModelArchiveCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no archive ID specified")
+}
+
+type fakeModelArchivesAPI struct {
+	jtesting.Stub
+	archives []params.ModelArchive
+	content  string
+}
+
+func (f *fakeModelArchivesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeModelArchivesAPI) Create(modelUUID string, signingKey []byte) (params.ModelArchive, error) {
+	f.MethodCall(f, "Create", modelUUID, signingKey)
+	return f.archives[0], f.NextErr()
+}
+
+func (f *fakeModelArchivesAPI) List(modelUUID string) ([]params.ModelArchive, error) {
+	f.MethodCall(f, "List", modelUUID)
+	return f.archives, f.NextErr()
+}
+
+func (f *fakeModelArchivesAPI) Download(id string) (io.ReadCloser, error) {
+	f.MethodCall(f, "Download", id)
+	return ioutil.NopCloser(bytes.NewBufferString(f.content)), f.NextErr()
+}
@@ -0,0 +1,240 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/integrity"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewVerifyDBCommand returns a command that checks the controller's
+// database for dangling references.
+func NewVerifyDBCommand() cmd.Command {
+	return modelcmd.WrapController(&verifyDBCommand{})
+}
+
+// verifyDBCommand asks the controller to find, and optionally repair,
+// documents in its database that refer to entities that do not exist.
+type verifyDBCommand struct {
+	modelcmd.ControllerCommandBase
+	api        verifyDBAPI
+	out        cmd.Output
+	modelNames []string
+	repair     bool
+}
+
+const verifyDBHelpDoc = `
+Checks the controller's database for documents that refer to entities
+that no longer exist, such as units assigned to missing machines,
+relation scopes of removed relations or units, and settings left
+behind by removed relations and applications. These can accumulate in
+long-lived controllers after failed or interrupted operations.
+
+All models in the controller are checked, unless model names are
+given. Only controller administrators may verify the database.
+
+With --repair, the problems that can be repaired safely are repaired,
+by removing the dangling documents. Problems that cannot be repaired
+automatically are reported for manual recovery.
+
+Examples:
+
+    juju verify-db
+    juju verify-db default
+    juju verify-db --repair
+
+See also:
+    backups
+    models
+`
+
+// Info is part of the cmd.Command interface.
+func (c *verifyDBCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-db",
+		Args:    "[<model name> ...]",
+		Purpose: "Finds and repairs dangling references in the controller's database.",
+		Doc:     strings.TrimSpace(verifyDBHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *verifyDBCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.repair, "repair", false, "Repair the problems found where possible")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatVerifyDBTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *verifyDBCommand) Init(args []string) error {
+	c.modelNames = args
+	return nil
+}
+
+type verifyDBAPI interface {
+	Close() error
+	Check(models []names.ModelTag, repair bool) ([]params.IntegrityCheckResult, error)
+}
+
+func (c *verifyDBCommand) getAPI() (verifyDBAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return integrity.NewClient(root), nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *verifyDBCommand) Run(ctx *cmd.Context) error {
+	uuids, err := c.ModelUUIDs(c.modelNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var models []names.ModelTag
+	for _, uuid := range uuids {
+		models = append(models, names.NewModelTag(uuid))
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.Check(models, c.repair)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	modelNames := c.modelNamesByUUID()
+	out := make(map[string]modelIntegrity)
+	var found, repaired int
+	var failed bool
+	for _, result := range results {
+		name := result.ModelTag
+		if tag, err := names.ParseModelTag(result.ModelTag); err == nil {
+			name = tag.Id()
+			if modelName, ok := modelNames[tag.Id()]; ok {
+				name = modelName
+			}
+		}
+		model := modelIntegrity{Repaired: result.Repaired}
+		for _, problem := range result.Problems {
+			model.Problems = append(model.Problems, integrityProblem{
+				Kind:       problem.Kind,
+				Collection: problem.Collection,
+				Id:         problem.Id,
+				Message:    problem.Message,
+				Repairable: problem.Repairable,
+			})
+		}
+		if result.Error != nil {
+			model.Error = result.Error.Error()
+			ctx.Infof("ERROR verifying model %s: %s", name, result.Error)
+			failed = true
+		}
+		found += len(model.Problems)
+		repaired += model.Repaired
+		out[name] = model
+	}
+	if err := c.out.Write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case found == 0:
+		ctx.Infof("no problems found")
+	case c.repair:
+		ctx.Infof("found %d problems, repaired %d", found, repaired)
+	default:
+		ctx.Infof("found %d problems; use --repair to repair them where possible", found)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// modelNamesByUUID returns the names of the controller's models known
+// to the client, keyed by model UUID.
+func (c *verifyDBCommand) modelNamesByUUID() map[string]string {
+	result := make(map[string]string)
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return result
+	}
+	models, err := c.ClientStore().AllModels(controllerName)
+	if err != nil {
+		logger.Debugf("cannot read model names: %v", err)
+		return result
+	}
+	for name, details := range models {
+		result[details.ModelUUID] = name
+	}
+	return result
+}
+
+// modelIntegrity holds the problems found in a model for output.
+type modelIntegrity struct {
+	Problems []integrityProblem `yaml:"problems,omitempty" json:"problems,omitempty"`
+	Repaired int                `yaml:"repaired,omitempty" json:"repaired,omitempty"`
+	Error    string             `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// integrityProblem describes a dangling reference for output.
+type integrityProblem struct {
+	Kind       string `yaml:"kind" json:"kind"`
+	Collection string `yaml:"collection" json:"collection"`
+	Id         string `yaml:"id" json:"id"`
+	Message    string `yaml:"message" json:"message"`
+	Repairable bool   `yaml:"repairable" json:"repairable"`
+}
+
+func formatVerifyDBTabular(writer io.Writer, value interface{}) error {
+	models, ok := value.(map[string]modelIntegrity)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", models, value)
+	}
+	var modelNames []string
+	for name, model := range models {
+		if len(model.Problems) > 0 {
+			modelNames = append(modelNames, name)
+		}
+	}
+	if len(modelNames) == 0 {
+		return nil
+	}
+	sort.Strings(modelNames)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Problem", "Repairable", "Message")
+	for _, name := range modelNames {
+		for _, problem := range models[name].Problems {
+			repairable := "no"
+			if problem.Repairable {
+				repairable = "yes"
+			}
+			w.Println(name, problem.Kind, repairable, problem.Message)
+		}
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+const (
+	verifyModelUUID0 = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	verifyModelUUID1 = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+)
+
+type VerifyDBSuite struct {
+	baseControllerSuite
+	api *fakeVerifyDBAPI
+}
+
+var _ = gc.Suite(&VerifyDBSuite{})
+
+func (s *VerifyDBSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.modelsYaml = `
+controllers:
+  mallards:
+    models:
+      admin/model0:
+        uuid: ` + verifyModelUUID0 + `
+      admin/my-model:
+        uuid: ` + verifyModelUUID1 + `
+    current-model: admin/my-model
+`
+	s.createTestClientStore(c)
+	s.api = &fakeVerifyDBAPI{
+		results: []params.IntegrityCheckResult{{
+			ModelTag: names.NewModelTag(verifyModelUUID0).String(),
+			Problems: []params.IntegrityProblem{{
+				Kind:       "unit-missing-machine",
+				Collection: "units",
+				Id:         "mysql/0",
+				Message:    `unit "mysql/0" is assigned to machine "3", which does not exist`,
+			}, {
+				Kind:       "leaked-settings",
+				Collection: "settings",
+				Id:         "r#7#peer#foo/0",
+				Message:    `settings "r#7#peer#foo/0" belong to relation 7, which does not exist`,
+				Repairable: true,
+			}},
+		}, {
+			ModelTag: names.NewModelTag(verifyModelUUID1).String(),
+		}},
+	}
+}
+
+func (s *VerifyDBSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewVerifyDBCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *VerifyDBSuite) TestVerifyAll(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Check", []interface{}{[]names.ModelTag(nil), false}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model         Problem               Repairable  Message
+admin/model0  unit-missing-machine  no          unit "mysql/0" is assigned to machine "3", which does not exist
+admin/model0  leaked-settings       yes         settings "r#7#peer#foo/0" belong to relation 7, which does not exist
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"found 2 problems; use --repair to repair them where possible\n")
+}
+
+func (s *VerifyDBSuite) TestRepairModel(c *gc.C) {
+	s.api.results = s.api.results[:1]
+	s.api.results[0].Repaired = 1
+	ctx, err := s.run(c, "--repair", "--format", "yaml", "model0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Check", []interface{}{[]names.ModelTag{names.NewModelTag(verifyModelUUID0)}, true}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+admin/model0:
+  problems:
+  - kind: unit-missing-machine
+    collection: units
+    id: mysql/0
+    message: unit "mysql/0" is assigned to machine "3", which does not exist
+    repairable: false
+  - kind: leaked-settings
+    collection: settings
+    id: r#7#peer#foo/0
+    message: settings "r#7#peer#foo/0" belong to relation 7, which does not exist
+    repairable: true
+  repaired: 1
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "found 2 problems, repaired 1\n")
+}
+
+func (s *VerifyDBSuite) TestNoProblems(c *gc.C) {
+	s.api.results = s.api.results[1:]
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no problems found\n")
+}
+
+func (s *VerifyDBSuite) TestModelError(c *gc.C) {
+	s.api.results[1].Error = &params.Error{Message: "boom"}
+	ctx, err := s.run(c)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "ERROR verifying model admin/my-model: boom\n")
+}
+
+func (s *VerifyDBSuite) TestCheckError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeVerifyDBAPI struct {
+	jtesting.Stub
+	results []params.IntegrityCheckResult
+}
+
+func (f *fakeVerifyDBAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeVerifyDBAPI) Check(models []names.ModelTag, repair bool) ([]params.IntegrityCheckResult, error) {
+	f.MethodCall(f, "Check", models, repair)
+	return f.results, f.NextErr()
+}
@@ -0,0 +1,341 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/api/webhooks"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// webhooksAPI defines the methods of the Webhooks facade used by the
+// webhook commands.
+type webhooksAPI interface {
+	Close() error
+	List() ([]params.Webhook, error)
+	Add(name, url, secret string, events []string) error
+	Remove(name string) error
+}
+
+// webhooksCommandBase holds the API used by the webhook commands.
+type webhooksCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api webhooksAPI
+}
+
+func (c *webhooksCommandBase) getAPI() (webhooksAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return webhooks.NewClient(root), nil
+}
+
+// NewAddWebhookCommand returns a command that adds a webhook to the
+// controller.
+func NewAddWebhookCommand() cmd.Command {
+	return modelcmd.WrapController(&addWebhookCommand{})
+}
+
+type addWebhookCommand struct {
+	webhooksCommandBase
+	name       string
+	url        string
+	events     string
+	secretFile string
+}
+
+const addWebhookHelpDoc = `
+Adds a webhook to the controller. The controller POSTs a JSON document
+to the webhook's HTTPS URL when any of the following happen in any of
+its models:
+
+    machine-provisioned         a machine is provisioned with an instance
+    unit-removed                a unit is removed
+    application-status-changed  the status of an application changes
+
+All events are sent unless some are chosen with --events. A request
+that fails is retried several times, with increasing delays.
+
+Each request is signed with the webhook's secret, so the receiver can
+check that it came from the controller. The X-Juju-Signature header
+holds "sha256=" followed by the hex-encoded HMAC-SHA256 of the request
+body, keyed with the secret. Unless a file containing the secret is
+given with --secret-file, a secret is generated and printed; it cannot
+be retrieved later.
+
+Only controller administrators may add webhooks.
+
+Examples:
+
+    juju add-webhook ops https://ops.example.com/juju
+    juju add-webhook billing https://billing.example.com/hook --events unit-removed,machine-provisioned
+    juju add-webhook audit https://audit.example.com/ --secret-file ~/audit-secret
+
+See also:
+    remove-webhook
+    webhooks
+`
+
+// Info is part of the cmd.Command interface.
+func (c *addWebhookCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-webhook",
+		Args:    "<name> <url>",
+		Purpose: "Adds a webhook to which the controller sends events.",
+		Doc:     strings.TrimSpace(addWebhookHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addWebhookCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.webhooksCommandBase.SetFlags(f)
+	f.StringVar(&c.events, "events", "", "Comma-separated events to send to the webhook (default all)")
+	f.StringVar(&c.secretFile, "secret-file", "", "Path to a file containing the secret used to sign events")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addWebhookCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no webhook name specified")
+	case 1:
+		return errors.New("no webhook URL specified")
+	}
+	c.name, c.url = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addWebhookCommand) Run(ctx *cmd.Context) error {
+	var events []string
+	for _, event := range strings.Split(c.events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+
+	secret, generated, err := c.secret(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Add(c.name, c.url, secret, events); err != nil {
+		return errors.Trace(err)
+	}
+	if generated {
+		ctx.Infof("Added webhook %q. Events are signed with the secret:", c.name)
+		fmt.Fprintln(ctx.Stdout, secret)
+	} else {
+		ctx.Infof("Added webhook %q.", c.name)
+	}
+	return nil
+}
+
+// secret returns the secret read from the secret file, or a new
+// random secret if no file was given.
+func (c *addWebhookCommand) secret(ctx *cmd.Context) (string, bool, error) {
+	if c.secretFile == "" {
+		secret, err := utils.RandomPassword()
+		if err != nil {
+			return "", false, errors.Annotate(err, "cannot generate secret")
+		}
+		return secret, true, nil
+	}
+	path, err := utils.NormalizePath(c.secretFile)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(path))
+	if err != nil {
+		return "", false, errors.Annotate(err, "cannot read secret")
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", false, errors.Errorf("secret file %q is empty", c.secretFile)
+	}
+	return secret, false, nil
+}
+
+// NewRemoveWebhookCommand returns a command that removes a webhook
+// from the controller.
+func NewRemoveWebhookCommand() cmd.Command {
+	return modelcmd.WrapController(&removeWebhookCommand{})
+}
+
+type removeWebhookCommand struct {
+	webhooksCommandBase
+	name string
+}
+
+const removeWebhookHelpDoc = `
+Removes a webhook from the controller. Events that are waiting to be
+sent to the webhook are discarded.
+
+Examples:
+
+    juju remove-webhook ops
+
+See also:
+    add-webhook
+    webhooks
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeWebhookCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-webhook",
+		Args:    "<name>",
+		Purpose: "Removes a webhook from the controller.",
+		Doc:     strings.TrimSpace(removeWebhookHelpDoc),
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeWebhookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no webhook name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeWebhookCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Remove(c.name); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewListWebhooksCommand returns a command that lists the
+// controller's webhooks.
+func NewListWebhooksCommand() cmd.Command {
+	return modelcmd.WrapController(&listWebhooksCommand{})
+}
+
+type listWebhooksCommand struct {
+	webhooksCommandBase
+	out cmd.Output
+}
+
+const listWebhooksHelpDoc = `
+Lists the webhooks to which the controller sends events, and the
+events sent to each. Webhook secrets are not shown.
+
+Examples:
+
+    juju webhooks
+    juju webhooks --format yaml
+
+See also:
+    add-webhook
+    remove-webhook
+`
+
+// Info is part of the cmd.Command interface.
+func (c *listWebhooksCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "webhooks",
+		Purpose: "Lists the controller's webhooks.",
+		Doc:     strings.TrimSpace(listWebhooksHelpDoc),
+		Aliases: []string{"list-webhooks"},
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listWebhooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.webhooksCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatWebhooksTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listWebhooksCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// webhook describes a webhook for output.
+type webhook struct {
+	URL    string   `yaml:"url" json:"url"`
+	Events []string `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listWebhooksCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No webhooks to display.")
+		return nil
+	}
+	out := make(map[string]webhook)
+	for _, result := range results {
+		out[result.Name] = webhook{
+			URL:    result.URL,
+			Events: result.Events,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatWebhooksTabular(writer io.Writer, value interface{}) error {
+	webhooks, ok := value.(map[string]webhook)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", webhooks, value)
+	}
+	var names []string
+	for name := range webhooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Name", "URL", "Events")
+	for _, name := range names {
+		events := "all"
+		if e := webhooks[name].Events; len(e) > 0 {
+			events = strings.Join(e, ",")
+		}
+		w.Println(name, webhooks[name].URL, events)
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type WebhooksSuite struct {
+	baseControllerSuite
+	api *fakeWebhooksAPI
+}
+
+var _ = gc.Suite(&WebhooksSuite{})
+
+func (s *WebhooksSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeWebhooksAPI{
+		webhooks: []params.Webhook{{
+			Name:   "ops",
+			URL:    "https://ops.example.com/",
+			Events: []string{"unit-removed", "machine-provisioned"},
+		}, {
+			Name: "audit",
+			URL:  "https://audit.example.com/",
+		}},
+	}
+}
+
+func (s *WebhooksSuite) TestAddWebhookInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no webhook name specified",
+	}, {
+		args: []string{"ops"},
+		err:  "no webhook URL specified",
+	}, {
+		args: []string{"ops", "https://ops.example.com/", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		command := controller.NewAddWebhookCommandForTest(s.api, s.store)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WebhooksSuite) TestAddWebhookGeneratesSecret(c *gc.C) {
+	command := controller.NewAddWebhookCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "ops", "https://ops.example.com/", "--events", "unit-removed, machine-provisioned")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.Calls(), gc.HasLen, 2)
+	s.api.CheckCallNames(c, "Add", "Close")
+	args := s.api.Calls()[0].Args
+	c.Check(args[0], gc.Equals, "ops")
+	c.Check(args[1], gc.Equals, "https://ops.example.com/")
+	c.Check(args[3], jc.DeepEquals, []string{"unit-removed", "machine-provisioned"})
+
+	secret := args[2].(string)
+	c.Check(secret, gc.Not(gc.Equals), "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, secret+"\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Added webhook \"ops\". Events are signed with the secret:\n")
+}
+
+func (s *WebhooksSuite) TestAddWebhookSecretFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "secret")
+	err := ioutil.WriteFile(path, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := controller.NewAddWebhookCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "ops", "https://ops.example.com/", "--secret-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Add", []interface{}{"ops", "https://ops.example.com/", "sekrit", []string(nil)}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Added webhook \"ops\".\n")
+}
+
+func (s *WebhooksSuite) TestAddWebhookError(c *gc.C) {
+	s.api.SetErrors(errors.New(`webhook URL "http://ops.example.com/" (expected an https URL) not valid`))
+	command := controller.NewAddWebhookCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "ops", "http://ops.example.com/")
+	c.Assert(err, gc.ErrorMatches, `webhook URL .* not valid`)
+}
+
+func (s *WebhooksSuite) TestRemoveWebhook(c *gc.C) {
+	command := controller.NewRemoveWebhookCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Remove", []interface{}{"ops"}},
+		{"Close", nil},
+	})
+}
+
+func (s *WebhooksSuite) TestRemoveWebhookInit(c *gc.C) {
+	command := controller.NewRemoveWebhookCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no webhook name specified")
+}
+
+func (s *WebhooksSuite) TestListWebhooks(c *gc.C) {
+	command := controller.NewListWebhooksCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name   URL                         Events
+audit  https://audit.example.com/  all
+ops    https://ops.example.com/    unit-removed,machine-provisioned
+`[1:])
+}
+
+func (s *WebhooksSuite) TestListWebhooksYAML(c *gc.C) {
+	command := controller.NewListWebhooksCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+audit:
+  url: https://audit.example.com/
+ops:
+  url: https://ops.example.com/
+  events:
+  - unit-removed
+  - machine-provisioned
+`[1:])
+}
+
+func (s *WebhooksSuite) TestListWebhooksNone(c *gc.C) {
+	s.api.webhooks = nil
+	command := controller.NewListWebhooksCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No webhooks to display.\n")
+}
+
+type fakeWebhooksAPI struct {
+	jtesting.Stub
+	webhooks []params.Webhook
+}
+
+func (f *fakeWebhooksAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeWebhooksAPI) List() ([]params.Webhook, error) {
+	f.MethodCall(f, "List")
+	return f.webhooks, f.NextErr()
+}
+
+func (f *fakeWebhooksAPI) Add(name, url, secret string, events []string) error {
+	f.MethodCall(f, "Add", name, url, secret, events)
+	return f.NextErr()
+}
+
+func (f *fakeWebhooksAPI) Remove(name string) error {
+	f.MethodCall(f, "Remove", name)
+	return f.NextErr()
+}
@@ -4,6 +4,8 @@
 package crossmodel
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -18,9 +20,17 @@ Find which offered application endpoints are available to the current user.
 
 This command is aimed for a user who wants to discover what endpoints are available to them.
 
+Offers on another controller are found by specifying its name, either on its
+own or followed by a model. The access shown is that of the user logged in to
+the controller making the offers. To search a model with the same name as a
+known controller, qualify the model with its owner.
+
 Examples:
    $ juju find-offers
+   $ juju find-offers mycontroller
    $ juju find-offers mycontroller:
+   $ juju find-offers mycontroller --interface mysql
+   $ juju find-offers mycontroller:fred/prod
    $ juju find-offers fred/prod
    $ juju find-offers --interface mysql
    $ juju find-offers --url fred/prod.db2
@@ -76,6 +86,7 @@ func (c *findCommand) Init(args []string) (err error) {
 func (c *findCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "find-offers",
+		Args:    "[<controller>|<offer url>]",
 		Purpose: "Find offered application endpoints.",
 		Doc:     findCommandDoc,
 	}
@@ -99,7 +110,7 @@ func (c *findCommand) Run(ctx *cmd.Context) (err error) {
 	if err := c.validateOrSetURL(); err != nil {
 		return errors.Trace(err)
 	}
-	accountDetails, err := c.CurrentAccountDetails()
+	accountDetails, err := c.ClientStore().AccountDetails(c.source)
 	if err != nil {
 		return err
 	}
@@ -146,6 +157,9 @@ func (c *findCommand) validateOrSetURL() error {
 		c.source = controllerName
 		return nil
 	}
+	if c.isControllerName(c.url) {
+		c.url += ":"
+	}
 	urlParts, err := crossmodel.ParseOfferURLParts(c.url)
 	if err != nil {
 		return errors.Trace(err)
@@ -157,7 +171,7 @@ func (c *findCommand) validateOrSetURL() error {
 	}
 	user := urlParts.User
 	if user == "" {
-		accountDetails, err := c.CurrentAccountDetails()
+		accountDetails, err := c.ClientStore().AccountDetails(c.source)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -169,6 +183,16 @@ func (c *findCommand) validateOrSetURL() error {
 	return nil
 }
 
+// isControllerName reports whether the URL term is just the name of a
+// controller known to the client, rather than a model or offer.
+func (c *findCommand) isControllerName(term string) bool {
+	if strings.ContainsAny(term, ":/.") {
+		return false
+	}
+	_, err := c.ClientStore().ControllerByName(term)
+	return err == nil
+}
+
 // FindAPI defines the API methods that cross model find command uses.
 type FindAPI interface {
 	Close() error
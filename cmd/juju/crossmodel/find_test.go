@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/crossmodel"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/jujuclient"
 )
 
 type findSuite struct {
@@ -173,6 +174,71 @@ different  fred/model.hosted-db2  consume  http:db2, http:log
 	)
 }
 
+func (s *findSuite) addOtherController() {
+	s.store.Controllers["other"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["other"] = jujuclient.AccountDetails{
+		User: "mary",
+	}
+}
+
+func (s *findSuite) TestFindControllerName(c *gc.C) {
+	s.addOtherController()
+	s.mockAPI.c = c
+	s.mockAPI.expectedFilter = &jujucrossmodel.ApplicationOfferFilter{
+		OwnerName: "mary",
+		Endpoints: []jujucrossmodel.EndpointFilterTerm{{
+			Interface: "mysql",
+		}},
+	}
+	s.mockAPI.results = []*jujucrossmodel.ApplicationOfferDetails{{
+		OfferURL:  "other:fred/prod.mysql",
+		OfferName: "mysql",
+		Endpoints: []charm.Relation{
+			{Name: "db", Interface: "mysql", Role: charm.RoleProvider},
+		},
+		Users: []jujucrossmodel.OfferUserDetails{{
+			UserName: "bob", Access: "consume",
+		}, {
+			UserName: "mary", Access: "read",
+		}},
+	}, {
+		OfferURL:  "other:fred/dev.mysql",
+		OfferName: "mysql",
+		Endpoints: []charm.Relation{
+			{Name: "db", Interface: "mysql", Role: charm.RoleProvider},
+		},
+	}}
+	s.assertFind(
+		c,
+		[]string{"other", "--interface", "mysql"},
+		`
+Store  URL              Access  Interfaces
+other  fred/dev.mysql   -       mysql:db
+other  fred/prod.mysql  read    mysql:db
+
+`[1:],
+	)
+}
+
+func (s *findSuite) TestFindModelNotController(c *gc.C) {
+	s.addOtherController()
+	s.mockAPI.c = c
+	s.mockAPI.expectedModelName = "other"
+	s.mockAPI.expectedFilter = &jujucrossmodel.ApplicationOfferFilter{
+		OwnerName: "fred",
+		ModelName: "other",
+	}
+	s.assertFind(
+		c,
+		[]string{"fred/other"},
+		`
+Store   URL                    Access   Interfaces
+master  fred/other.hosted-db2  consume  http:db2, http:log
+
+`[1:],
+	)
+}
+
 func (s *findSuite) assertFind(c *gc.C, args []string, expected string) {
 	context, err := s.runFind(c, args...)
 	c.Assert(err, jc.ErrorIsNil)
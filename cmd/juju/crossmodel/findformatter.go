@@ -31,7 +31,13 @@ func formatFoundEndpointsTabular(writer io.Writer, all map[string]ApplicationOff
 	w := output.Wrapper{tw}
 	w.Println("Store", "URL", "Access", "Interfaces")
 
-	for urlStr, one := range all {
+	urls := make([]string, 0, len(all))
+	for urlStr := range all {
+		urls = append(urls, urlStr)
+	}
+	sort.Strings(urls)
+	for _, urlStr := range urls {
+		one := all[urlStr]
 		url, err := crossmodel.ParseOfferURL(urlStr)
 		if err != nil {
 			return err
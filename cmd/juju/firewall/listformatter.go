@@ -16,6 +16,8 @@ import (
 type firewallRule struct {
 	KnownService   string   `yaml:"known-service" json:"known-service"`
 	WhitelistCIDRS []string `yaml:"whitelist-subnets,omitempty" json:"whitelist-subnets,omitempty"`
+	Description    string   `yaml:"description,omitempty" json:"description,omitempty"`
+	SetBy          string   `yaml:"set-by,omitempty" json:"set-by,omitempty"`
 }
 
 type firewallRules []firewallRule
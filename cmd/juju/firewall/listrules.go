@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewallrules"
 	"github.com/juju/juju/apiserver/params"
@@ -95,7 +96,18 @@ func (c *listFirewallRulesCommand) Run(ctx *cmd.Context) error {
 		rules[i] = firewallRule{
 			KnownService:   string(r.KnownService),
 			WhitelistCIDRS: r.WhitelistCIDRS,
+			Description:    r.Description,
+			SetBy:          ruleSetBy(r.Origin),
 		}
 	}
 	return c.out.Write(ctx, rules)
 }
+
+// ruleSetBy returns the name of the user who set a rule, given
+// the rule's origin tag.
+func ruleSetBy(origin string) string {
+	if tag, err := names.ParseUserTag(origin); err == nil {
+		return tag.Id()
+	}
+	return origin
+}
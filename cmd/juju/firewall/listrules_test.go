@@ -76,6 +76,27 @@ func (s *ListSuite) TestListYAML(c *gc.C) {
 	)
 }
 
+func (s *ListSuite) TestListYAMLProvenance(c *gc.C) {
+	s.mockAPI.rules = []params.FirewallRule{{
+		KnownService:   "ssh",
+		WhitelistCIDRS: []string{"10.0.0.0/8"},
+		Description:    "office VPN",
+		Origin:         "user-admin",
+	}}
+	s.assertValidList(
+		c,
+		[]string{"--format", "yaml"},
+		`
+- known-service: ssh
+  whitelist-subnets:
+  - 10.0.0.0/8
+  description: office VPN
+  set-by: admin
+`[1:],
+		"",
+	)
+}
+
 func (s *ListSuite) runList(c *gc.C, args []string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, firewall.NewListRulesCommandForTest(s.mockAPI), args...)
 }
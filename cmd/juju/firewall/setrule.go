@@ -24,7 +24,10 @@ Sets a firewall rule.`[1:]
 var setRuleHelpDetails = `
 Firewall rules control ingress to a well known services
 within a Juju model. A rule consists of the service name
-and a whitelist of allowed ingress subnets.
+and a whitelist of allowed ingress subnets. An optional
+description is recorded against the rule, along with the
+user who set it, and is used to describe the resulting
+cloud firewall rules where the cloud supports it.
 The currently supported services are:
 %v
 
@@ -32,6 +35,7 @@ Examples:
     juju set-firewall-rule ssh --whitelist 192.168.1.0/16
     juju set-firewall-rule juju-controller --whitelist 192.168.1.0/16
     juju set-firewall-rule juju-application-offer --whitelist 192.168.1.0/16
+    juju set-firewall-rule ssh --whitelist 10.0.0.0/8 --description "office VPN"
 
 See also: 
     list-firewall-rules`
@@ -54,6 +58,7 @@ type setFirewallRuleCommand struct {
 	modelcmd.ModelCommandBase
 	service        string
 	whitelistValue string
+	description    string
 
 	whiteList  []string
 	newAPIFunc func() (SetFirewallRuleAPI, error)
@@ -68,7 +73,7 @@ func (c *setFirewallRuleCommand) Info() *cmd.Info {
 	}
 	return &cmd.Info{
 		Name:    "set-firewall-rule",
-		Args:    "<service-name>, --whitelist <cidr>[,<cidr>...]",
+		Args:    "<service-name>, --whitelist <cidr>[,<cidr>...] [--description <text>]",
 		Purpose: setRuleHelpSummary,
 		Doc:     fmt.Sprintf(setRuleHelpDetails, strings.Join(supportedRules, "\n")),
 	}
@@ -77,6 +82,7 @@ func (c *setFirewallRuleCommand) Info() *cmd.Info {
 // SetFlags implements cmd.Command.
 func (c *setFirewallRuleCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.whitelistValue, "whitelist", "", "list of subnets to whitelist")
+	f.StringVar(&c.description, "description", "", "description of the rule's purpose")
 }
 
 // Init implements cmd.Command.
@@ -115,7 +121,7 @@ func (c *setFirewallRuleCommand) parseCIDRs(cidrs *[]string, value string) error
 // SetFirewallRuleAPI defines the API methods that the set firewall rules command uses.
 type SetFirewallRuleAPI interface {
 	Close() error
-	SetFirewallRule(service string, whiteListCidrs []string) error
+	SetFirewallRule(service string, whiteListCidrs []string, description string) error
 }
 
 func (c *setFirewallRuleCommand) Run(_ *cmd.Context) error {
@@ -124,6 +130,6 @@ func (c *setFirewallRuleCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	err = client.SetFirewallRule(c.service, c.whiteList)
+	err = client.SetFirewallRule(c.service, c.whiteList, c.description)
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
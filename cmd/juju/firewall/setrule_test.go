@@ -51,6 +51,16 @@ func (s *SetRuleSuite) TestSetRule(c *gc.C) {
 	})
 }
 
+func (s *SetRuleSuite) TestSetRuleWithDescription(c *gc.C) {
+	_, err := s.runSetRule(c, "--whitelist", "10.0.0.0/8", "--description", "office VPN", "ssh")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.rule, jc.DeepEquals, params.FirewallRule{
+		KnownService:   params.SSHRule,
+		WhitelistCIDRS: []string{"10.0.0.0/8"},
+		Description:    "office VPN",
+	})
+}
+
 func (s *SetRuleSuite) TestSetError(c *gc.C) {
 	s.mockAPI.err = errors.New("fail")
 	_, err := s.runSetRule(c, "ssh", "--whitelist", "10.0.0.0/8")
@@ -70,13 +80,14 @@ func (s *mockSetRuleAPI) Close() error {
 	return nil
 }
 
-func (s *mockSetRuleAPI) SetFirewallRule(service string, whiteListCidrs []string) error {
+func (s *mockSetRuleAPI) SetFirewallRule(service string, whiteListCidrs []string, description string) error {
 	if s.err != nil {
 		return s.err
 	}
 	s.rule = params.FirewallRule{
 		KnownService:   params.KnownServiceValue(service),
 		WhitelistCIDRS: whiteListCidrs,
+		Description:    description,
 	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/enrollprovisioner"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
 	"github.com/juju/juju/environs/manual/winrmprovisioner"
 	"github.com/juju/juju/instance"
@@ -57,6 +58,14 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Where the machine cannot be reached over SSH, for example in a locked-down
+network, it may be enrolled instead with "enroll:host". Juju records the
+machine without connecting to it, and writes an enrollment script to
+standard output. Run the script once, as root, on the machine; the machine
+agent then connects to the API server and registers itself. The script
+contains the agent's credentials, so it should be kept private. The series
+of the machine is taken from --series, or the model's default series.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -71,6 +80,8 @@ Examples:
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
+   juju add-machine enroll:10.10.0.3 > enroll.sh
+                                         (writes a script that enrolls the machine)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -119,7 +130,7 @@ type addCommand struct {
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
-		Args:    "[<container>:machine | <container> | ssh:[user@]host | winrm:[user@]host | placement]",
+		Args:    "[<container>:machine | <container> | ssh:[user@]host | winrm:[user@]host | enroll:host | placement]",
 		Purpose: "Start a new, empty machine and optionally a container, or add a container to a machine.",
 		Doc:     addMachineDoc,
 	}
@@ -341,9 +352,11 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 var (
 	sshProvisioner    = sshprovisioner.ProvisionMachine
 	winrmProvisioner  = winrmprovisioner.ProvisionMachine
+	enrollProvisioner = enrollprovisioner.ProvisionMachine
 	errNonManualScope = errors.New("non-manual scope")
 	sshScope          = "ssh"
 	winrmScope        = "winrm"
+	enrollScope       = "enroll"
 )
 
 func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
@@ -354,6 +367,8 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		provisionMachine = sshProvisioner
 	case winrmScope:
 		provisionMachine = c.provisionWinRM
+	case enrollScope:
+		provisionMachine = enrollProvisioner
 	default:
 		return errNonManualScope
 	}
@@ -363,10 +378,16 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		return errors.Annotatef(err, "cannot reading authorized-keys")
 	}
 
+	series := c.Series
+	if series == "" {
+		series, _ = config.DefaultSeries()
+	}
+
 	user, host := splitUserHost(c.Placement.Directive)
 	args := manual.ProvisionMachineArgs{
 		Host:           host,
 		User:           user,
+		Series:         series,
 		Client:         client,
 		Stdin:          ctx.Stdin,
 		Stdout:         ctx.Stdout,
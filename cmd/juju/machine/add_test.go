@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -75,6 +76,10 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"winrm:user@10.10.0.3"},
 			count:     1,
 			placement: "winrm:user@10.10.0.3",
+		}, {
+			args:      []string{"enroll:10.10.0.3"},
+			count:     1,
+			placement: "enroll:10.10.0.3",
 		}, {
 			args:      []string{"zone=us-east-1a"},
 			count:     1,
@@ -156,6 +161,31 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) TestEnrollPlacement(c *gc.C) {
+	var provisionArgs manual.ProvisionMachineArgs
+	s.PatchValue(machine.EnrollProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisionArgs = args
+		return "42", nil
+	})
+	context, err := s.run(c, "--series", "xenial", "enroll:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
+	c.Assert(provisionArgs.Host, gc.Equals, "10.1.2.3")
+	c.Assert(provisionArgs.Series, gc.Equals, "xenial")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestEnrollPlacementDefaultSeries(c *gc.C) {
+	var provisionArgs manual.ProvisionMachineArgs
+	s.PatchValue(machine.EnrollProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		provisionArgs = args
+		return "42", nil
+	})
+	_, err := s.run(c, "enroll:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provisionArgs.Series, gc.Equals, series.LatestLts())
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
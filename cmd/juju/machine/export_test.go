@@ -12,7 +12,8 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner    = &sshProvisioner
+	EnrollProvisioner = &enrollProvisioner
 )
 
 type AddCommand struct {
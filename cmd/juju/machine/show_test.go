@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -131,3 +132,86 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"constraints\":\"mem=3584M\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}}}}}}}\n")
 }
+
+func (s *MachineShowCommandSuite) TestShowMachineNetworkTopology(c *gc.C) {
+	command := machine.NewShowCommandForTest(&topologyStatusAPI{})
+	context, err := cmdtesting.RunCommand(c, command, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"model: dummyenv\n"+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    juju-status:\n"+
+		"      current: started\n"+
+		"    dns-name: 10.0.42.1\n"+
+		"    instance-id: juju-badd06-0\n"+
+		"    series: xenial\n"+
+		"    network-interfaces:\n"+
+		"      bond0:\n"+
+		"        ip-addresses: []\n"+
+		"        mac-address: aa:bb:cc:dd:ee:f0\n"+
+		"        is-up: true\n"+
+		"        type: bond\n"+
+		"      bond0.42:\n"+
+		"        ip-addresses:\n"+
+		"        - 10.0.42.1\n"+
+		"        mac-address: aa:bb:cc:dd:ee:f0\n"+
+		"        is-up: true\n"+
+		"        type: 802.1q\n"+
+		"        parent-name: bond0\n"+
+		"        vlan-tag: 42\n"+
+		"      eth0:\n"+
+		"        ip-addresses: []\n"+
+		"        mac-address: aa:bb:cc:dd:ee:f0\n"+
+		"        is-up: true\n"+
+		"        type: ethernet\n"+
+		"        parent-name: bond0\n")
+}
+
+// topologyStatusAPI returns a machine with a VLAN on top of a bond.
+type topologyStatusAPI struct {
+	fakeStatusAPI
+}
+
+func (*topologyStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:    "dummyenv",
+			Version: "1.2.3",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				AgentStatus: params.DetailedStatus{
+					Status: "started",
+				},
+				DNSName:    "10.0.42.1",
+				InstanceId: "juju-badd06-0",
+				Series:     "xenial",
+				NetworkInterfaces: map[string]params.NetworkInterface{
+					"eth0": {
+						IPAddresses: []string{},
+						MACAddress:  "aa:bb:cc:dd:ee:f0",
+						IsUp:        true,
+						Type:        "ethernet",
+						ParentName:  "bond0",
+					},
+					"bond0": {
+						IPAddresses: []string{},
+						MACAddress:  "aa:bb:cc:dd:ee:f0",
+						IsUp:        true,
+						Type:        "bond",
+					},
+					"bond0.42": {
+						IPAddresses: []string{"10.0.42.1"},
+						MACAddress:  "aa:bb:cc:dd:ee:f0",
+						IsUp:        true,
+						Type:        "802.1q",
+						ParentName:  "bond0",
+						VLANTag:     42,
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -69,6 +69,9 @@ action.
 If there is persistent storage in any of the models managed by the
 controller, then you must choose to either destroy or release the
 storage, using --destroy-storage or --release-storage respectively.
+When storage is released, the cloud IDs of the released volumes and
+filesystems are printed once the model has been destroyed, so that
+they can be found in, or imported from, the cloud later.
 
 Examples:
 
@@ -257,6 +260,17 @@ upgrade the controller to version 2.3 or greater.
 		}
 	}
 
+	// Record the storage that will be left behind in the cloud,
+	// so we can tell the user about it once the model is gone.
+	var releasedVolumes []params.VolumeDetails
+	var releasedFilesystems []params.FilesystemDetails
+	if c.releaseStorage {
+		releasedVolumes, releasedFilesystems, err = c.listReleasedStorage()
+		if err != nil {
+			return errors.Annotate(err, "listing storage")
+		}
+	}
+
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
 	var destroyStorage *bool
@@ -284,6 +298,7 @@ upgrade the controller to version 2.3 or greater.
 	if err != nil && !errors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
+	printReleasedStorage(ctx, releasedVolumes, releasedFilesystems)
 
 	// Check if the model has an sla auth.
 	if slaIsSet {
@@ -296,6 +311,84 @@ upgrade the controller to version 2.3 or greater.
 	return nil
 }
 
+// listReleasedStorage returns the details of the provisioned, persistent
+// volumes and filesystems in the model; these are the ones that will be
+// left in the cloud when the model's storage is released.
+func (c *destroyCommand) listReleasedStorage() ([]params.VolumeDetails, []params.FilesystemDetails, error) {
+	storageAPI, err := c.getStorageAPI()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer storageAPI.Close()
+
+	volumeResults, err := storageAPI.ListVolumes(nil)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var volumes []params.VolumeDetails
+	for _, result := range volumeResults {
+		if result.Error != nil {
+			return nil, nil, errors.Trace(result.Error)
+		}
+		for _, volume := range result.Result {
+			if volume.Info.VolumeId == "" || !volume.Info.Persistent {
+				// Not provisioned, or destroyed along with
+				// its machine, so nothing is left in the cloud.
+				continue
+			}
+			volumes = append(volumes, volume)
+		}
+	}
+
+	filesystemResults, err := storageAPI.ListFilesystems(nil)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var filesystems []params.FilesystemDetails
+	for _, result := range filesystemResults {
+		if result.Error != nil {
+			return nil, nil, errors.Trace(result.Error)
+		}
+		for _, filesystem := range result.Result {
+			if filesystem.Info.FilesystemId == "" || filesystem.VolumeTag != "" {
+				// Not provisioned, or backed by a volume
+				// which is reported above.
+				continue
+			}
+			if filesystem.Storage == nil || !filesystem.Storage.Persistent {
+				continue
+			}
+			filesystems = append(filesystems, filesystem)
+		}
+	}
+	return volumes, filesystems, nil
+}
+
+// printReleasedStorage prints the cloud IDs of volumes and filesystems
+// that were released from the model rather than destroyed.
+func printReleasedStorage(ctx *cmd.Context, volumes []params.VolumeDetails, filesystems []params.FilesystemDetails) {
+	if len(volumes) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Released volumes (left in the cloud):")
+		for _, volume := range volumes {
+			id := volume.VolumeTag
+			if tag, err := names.ParseVolumeTag(volume.VolumeTag); err == nil {
+				id = tag.Id()
+			}
+			fmt.Fprintf(ctx.Stdout, "  %s: %s\n", id, volume.Info.VolumeId)
+		}
+	}
+	if len(filesystems) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Released filesystems (left in the cloud):")
+		for _, filesystem := range filesystems {
+			id := filesystem.FilesystemTag
+			if tag, err := names.ParseFilesystemTag(filesystem.FilesystemTag); err == nil {
+				id = tag.Id()
+			}
+			fmt.Fprintf(ctx.Stdout, "  %s: %s\n", id, filesystem.Info.FilesystemId)
+		}
+	}
+}
+
 func (c *destroyCommand) removeModelBudget(uuid string) error {
 	bakeryClient, err := c.BakeryClient()
 	if err != nil {
@@ -470,4 +563,6 @@ type BudgetAPIClient interface {
 type StorageAPI interface {
 	Close() error
 	ListStorageDetails() ([]params.StorageDetails, error)
+	ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error)
+	ListFilesystems(machines []string) ([]params.FilesystemDetailsListResult, error)
 }
@@ -252,10 +252,61 @@ func (s *DestroySuite) TestDestroyReleaseStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	destroyStorage := false
 	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"ListVolumes", []interface{}{[]string(nil)}},
+		{"ListFilesystems", []interface{}{[]string(nil)}},
 		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage}},
 	})
 }
 
+func (s *DestroySuite) TestDestroyReleaseStoragePrintsVolumes(c *gc.C) {
+	s.storageAPI.volumes = []params.VolumeDetails{{
+		VolumeTag: "volume-0",
+		Info:      params.VolumeInfo{VolumeId: "vol-abc", Persistent: true},
+	}, {
+		VolumeTag: "volume-1",
+		// Not provisioned, so not reported.
+	}, {
+		VolumeTag: "volume-2-3",
+		Info:      params.VolumeInfo{VolumeId: "vol-def", Persistent: true},
+	}, {
+		VolumeTag: "volume-4",
+		// Not persistent, so destroyed with its machine.
+		Info: params.VolumeInfo{VolumeId: "vol-ghi"},
+	}}
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--release-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Released volumes (left in the cloud):
+  0: vol-abc
+  2/3: vol-def
+`[1:])
+}
+
+func (s *DestroySuite) TestDestroyReleaseStoragePrintsFilesystems(c *gc.C) {
+	s.storageAPI.filesystems = []params.FilesystemDetails{{
+		FilesystemTag: "filesystem-0",
+		Info:          params.FilesystemInfo{FilesystemId: "fs-abc"},
+		Storage:       &params.StorageDetails{Persistent: true},
+	}, {
+		FilesystemTag: "filesystem-1",
+		// Backed by a volume, which is reported instead.
+		VolumeTag: "volume-1",
+		Info:      params.FilesystemInfo{FilesystemId: "fs-def"},
+		Storage:   &params.StorageDetails{Persistent: true},
+	}, {
+		FilesystemTag: "filesystem-2",
+		// Not persistent, so destroyed with its machine.
+		Info:    params.FilesystemInfo{FilesystemId: "fs-ghi"},
+		Storage: &params.StorageDetails{},
+	}}
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--release-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Released filesystems (left in the cloud):
+  0: fs-abc
+`[1:])
+}
+
 func (s *DestroySuite) TestDestroyDestroyReleaseStorageFlagsMutuallyExclusive(c *gc.C) {
 	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage", "--release-storage")
 	c.Assert(err, gc.ErrorMatches, "--destroy-storage and --release-storage cannot both be specified")
@@ -386,7 +437,9 @@ func (c *mockBudgetAPIClient) DeleteBudget(model string) (string, error) {
 
 type mockStorageAPI struct {
 	*jutesting.Stub
-	storage []params.StorageDetails
+	storage     []params.StorageDetails
+	volumes     []params.VolumeDetails
+	filesystems []params.FilesystemDetails
 }
 
 func (*mockStorageAPI) Close() error { return nil }
@@ -395,3 +448,13 @@ func (m *mockStorageAPI) ListStorageDetails() ([]params.StorageDetails, error) {
 	m.MethodCall(m, "ListStorageDetails")
 	return m.storage, m.NextErr()
 }
+
+func (m *mockStorageAPI) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
+	m.MethodCall(m, "ListVolumes", machines)
+	return []params.VolumeDetailsListResult{{Result: m.volumes}}, m.NextErr()
+}
+
+func (m *mockStorageAPI) ListFilesystems(machines []string) ([]params.FilesystemDetailsListResult, error) {
+	m.MethodCall(m, "ListFilesystems", machines)
+	return []params.FilesystemDetailsListResult{{Result: m.filesystems}}, m.NextErr()
+}
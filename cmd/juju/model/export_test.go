@@ -57,6 +57,29 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewModelQuotasCommandForTest returns a ModelQuotasCommand with the api provided as specified.
+func NewModelQuotasCommandForTest(api ModelQuotasAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &modelQuotasCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetModelQuotasCommandForTest returns a SetModelQuotasCommand with the api provided as specified.
+func NewSetModelQuotasCommandForTest(api ModelQuotasAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setModelQuotasCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowCleanupsCommandForTest returns a ShowCleanupsCommand with the api provided as specified.
+func NewShowCleanupsCommandForTest(api ShowCleanupsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showCleanupsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -0,0 +1,294 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelquotas"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const (
+	maxMachinesKey  = "max-machines"
+	maxUnitsKey     = "max-units"
+	maxStorageGBKey = "max-storage-gb"
+)
+
+// ModelQuotasAPI defines the methods of the ModelQuotas facade used by
+// the quota commands.
+type ModelQuotasAPI interface {
+	Close() error
+	ModelQuotas(names.ModelTag) (params.ModelQuotas, params.ModelQuotaUsage, error)
+	SetModelQuotas(names.ModelTag, params.ModelQuotas) error
+}
+
+// modelQuotasCommandBase holds the API used by the quota commands.
+type modelQuotasCommandBase struct {
+	modelcmd.ModelCommandBase
+	api ModelQuotasAPI
+}
+
+func (c *modelQuotasCommandBase) getAPI() (ModelQuotasAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelquotas.NewClient(root), nil
+}
+
+func (c *modelQuotasCommandBase) modelTag() (names.ModelTag, error) {
+	_, details, err := c.ModelDetails()
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "getting model details")
+	}
+	return names.NewModelTag(details.ModelUUID), nil
+}
+
+// NewModelQuotasCommand returns a command that shows the quotas of a
+// model.
+func NewModelQuotasCommand() cmd.Command {
+	return modelcmd.Wrap(&modelQuotasCommand{})
+}
+
+type modelQuotasCommand struct {
+	modelQuotasCommandBase
+	out cmd.Output
+}
+
+const modelQuotasHelpDoc = `
+Shows the limits set by controller administrators on the resources a
+model may use, along with the resources the model currently uses.
+
+Machines include containers. Only principal units count towards the
+unit limit. Storage is the total size of the model's volumes and
+filesystems.
+
+Examples:
+
+    juju model-quotas
+    juju model-quotas -m mymodel --format yaml
+
+See also:
+    set-model-quotas
+`
+
+// Info is part of the cmd.Command interface.
+func (c *modelQuotasCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-quotas",
+		Purpose: "Shows the resource quotas of a model.",
+		Doc:     strings.TrimSpace(modelQuotasHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *modelQuotasCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatModelQuotasTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *modelQuotasCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// modelQuotaInfo describes a model's quota on a resource, and its use
+// of the resource, for output. A zero limit means that the resource is
+// not limited.
+type modelQuotaInfo struct {
+	Limit uint64 `yaml:"limit,omitempty" json:"limit,omitempty"`
+	Used  uint64 `yaml:"used" json:"used"`
+}
+
+// modelQuotasInfo describes the quotas of a model for output.
+type modelQuotasInfo struct {
+	Machines  modelQuotaInfo `yaml:"machines" json:"machines"`
+	Units     modelQuotaInfo `yaml:"units" json:"units"`
+	StorageGB modelQuotaInfo `yaml:"storage-gb" json:"storage-gb"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *modelQuotasCommand) Run(ctx *cmd.Context) error {
+	modelTag, err := c.modelTag()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	quotas, usage, err := client.ModelQuotas(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, modelQuotasInfo{
+		Machines: modelQuotaInfo{
+			Limit: uint64(quotas.MaxMachines),
+			Used:  uint64(usage.Machines),
+		},
+		Units: modelQuotaInfo{
+			Limit: uint64(quotas.MaxUnits),
+			Used:  uint64(usage.Units),
+		},
+		StorageGB: modelQuotaInfo{
+			Limit: quotas.MaxStorageGB,
+			// Round up, so that any storage shows as used.
+			Used: (usage.StorageMiB + 1023) / 1024,
+		},
+	})
+}
+
+func formatModelQuotasTabular(writer io.Writer, value interface{}) error {
+	quotas, ok := value.(modelQuotasInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", quotas, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Resource", "Limit", "Used")
+	for _, row := range []struct {
+		resource string
+		quota    modelQuotaInfo
+	}{
+		{"machines", quotas.Machines},
+		{"units", quotas.Units},
+		{"storage-gb", quotas.StorageGB},
+	} {
+		limit := "unlimited"
+		if row.quota.Limit > 0 {
+			limit = fmt.Sprint(row.quota.Limit)
+		}
+		w.Println(row.resource, limit, row.quota.Used)
+	}
+	w.Flush()
+	return nil
+}
+
+// NewSetModelQuotasCommand returns a command that sets the quotas of a
+// model.
+func NewSetModelQuotasCommand() cmd.Command {
+	return modelcmd.Wrap(&setModelQuotasCommand{})
+}
+
+type setModelQuotasCommand struct {
+	modelQuotasCommandBase
+	values map[string]uint64
+}
+
+const setModelQuotasHelpDoc = `
+Limits the resources a model may use. Only controller administrators
+may set quotas.
+
+The following quotas may be set, each to a whole number:
+
+    max-machines    machines, including containers
+    max-units       principal units
+    max-storage-gb  total size of volumes and filesystems, in GiB
+
+A quota of 0 removes the limit. Quotas that are not given keep their
+current values.
+
+Once a quota is reached, requests that would exceed it, such as
+deploying applications, adding units or machines, or adding or
+resizing storage, fail with a "quota exceeded" error. Lowering a quota
+below the model's current usage removes nothing; it only prevents the
+model from growing further.
+
+Examples:
+
+    juju set-model-quotas max-machines=10 max-units=50
+    juju set-model-quotas -m tenant1 max-storage-gb=500
+    juju set-model-quotas max-units=0
+
+See also:
+    model-quotas
+`
+
+// Info is part of the cmd.Command interface.
+func (c *setModelQuotasCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-model-quotas",
+		Args:    "<quota>=<value> ...",
+		Purpose: "Sets the resource quotas of a model.",
+		Doc:     strings.TrimSpace(setModelQuotasHelpDoc),
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *setModelQuotasCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no quotas specified")
+	}
+	c.values = make(map[string]uint64)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("invalid quota %q (expected <quota>=<value>)", arg)
+		}
+		key := parts[0]
+		switch key {
+		case maxMachinesKey, maxUnitsKey, maxStorageGBKey:
+		default:
+			return errors.Errorf(
+				"unknown quota %q (expected one of %s, %s, %s)",
+				key, maxMachinesKey, maxUnitsKey, maxStorageGBKey,
+			)
+		}
+		if _, ok := c.values[key]; ok {
+			return errors.Errorf("quota %q specified more than once", key)
+		}
+		value, err := strconv.ParseUint(parts[1], 10, 31)
+		if err != nil {
+			return errors.Errorf("invalid value %q for quota %q", parts[1], key)
+		}
+		c.values[key] = value
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *setModelQuotasCommand) Run(ctx *cmd.Context) error {
+	modelTag, err := c.modelTag()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	quotas, _, err := client.ModelQuotas(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if value, ok := c.values[maxMachinesKey]; ok {
+		quotas.MaxMachines = int(value)
+	}
+	if value, ok := c.values[maxUnitsKey]; ok {
+		quotas.MaxUnits = int(value)
+	}
+	if value, ok := c.values[maxStorageGBKey]; ok {
+		quotas.MaxStorageGB = value
+	}
+	return errors.Trace(client.SetModelQuotas(modelTag, quotas))
+}
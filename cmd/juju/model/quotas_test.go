@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ModelQuotasCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeModelQuotasClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ModelQuotasCommandSuite{})
+
+type fakeModelQuotasClient struct {
+	gitjujutesting.Stub
+	quotas params.ModelQuotas
+	usage  params.ModelQuotaUsage
+}
+
+func (f *fakeModelQuotasClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelQuotasClient) ModelQuotas(model names.ModelTag) (params.ModelQuotas, params.ModelQuotaUsage, error) {
+	f.MethodCall(f, "ModelQuotas", model)
+	return f.quotas, f.usage, f.NextErr()
+}
+
+func (f *fakeModelQuotasClient) SetModelQuotas(model names.ModelTag, quotas params.ModelQuotas) error {
+	f.MethodCall(f, "SetModelQuotas", model, quotas)
+	return f.NextErr()
+}
+
+func (s *ModelQuotasCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeModelQuotasClient{
+		quotas: params.ModelQuotas{MaxMachines: 10, MaxStorageGB: 100},
+		usage:  params.ModelQuotaUsage{Machines: 3, Units: 5, StorageMiB: 2049},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ModelQuotasCommandSuite) TestModelQuotasTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewModelQuotasCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelQuotas", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Resource    Limit      Used\n"+
+		"machines    10         3\n"+
+		"units       unlimited  5\n"+
+		"storage-gb  100        3\n")
+}
+
+func (s *ModelQuotasCommandSuite) TestModelQuotasYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewModelQuotasCommandForTest(&s.fake, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+machines:
+  limit: 10
+  used: 3
+units:
+  used: 5
+storage-gb:
+  limit: 100
+  used: 3
+`[1:])
+}
+
+func (s *ModelQuotasCommandSuite) TestModelQuotasError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, model.NewModelQuotasCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.fake.CheckCallNames(c, "ModelQuotas", "Close")
+}
+
+func (s *ModelQuotasCommandSuite) TestModelQuotasExtraArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewModelQuotasCommandForTest(&s.fake, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ModelQuotasCommandSuite) TestSetModelQuotas(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSetModelQuotasCommandForTest(&s.fake, s.store),
+		"max-units=50", "max-machines=0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelQuotas", []interface{}{testing.ModelTag}},
+		{"SetModelQuotas", []interface{}{testing.ModelTag, params.ModelQuotas{
+			MaxUnits:     50,
+			MaxStorageGB: 100,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ModelQuotasCommandSuite) TestSetModelQuotasError(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("permission denied"))
+	_, err := cmdtesting.RunCommand(c, model.NewSetModelQuotasCommandForTest(&s.fake, s.store), "max-units=1")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.fake.CheckCallNames(c, "ModelQuotas", "SetModelQuotas", "Close")
+}
+
+func (s *ModelQuotasCommandSuite) TestSetModelQuotasInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no quotas specified",
+	}, {
+		args: []string{"max-units"},
+		err:  `invalid quota "max-units" \(expected <quota>=<value>\)`,
+	}, {
+		args: []string{"max-cpus=4"},
+		err:  `unknown quota "max-cpus" \(expected one of max-machines, max-units, max-storage-gb\)`,
+	}, {
+		args: []string{"max-units=-1"},
+		err:  `invalid value "-1" for quota "max-units"`,
+	}, {
+		args: []string{"max-units=1", "max-units=2"},
+		err:  `quota "max-units" specified more than once`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := cmdtesting.RunCommand(c, model.NewSetModelQuotasCommandForTest(&s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
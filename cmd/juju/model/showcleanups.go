@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/cleanups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewShowCleanupsCommand returns a command that shows the cleanups
+// pending in a model.
+func NewShowCleanupsCommand() cmd.Command {
+	return modelcmd.Wrap(&showCleanupsCommand{})
+}
+
+// showCleanupsCommand shows the cleanups pending in a model.
+type showCleanupsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ShowCleanupsAPI
+}
+
+const showCleanupsHelpDoc = `
+Shows the cleanups that are pending in a model, in the order they will
+be run. Cleanups do the work left after entities are destroyed, such
+as removing the units of a destroyed application, or the machines and
+applications of a destroyed model.
+
+A model, machine or application whose removal does not complete is
+often waiting on a cleanup that keeps failing. The number of failed
+attempts and the most recent error are shown for each cleanup.
+
+Only model administrators may show the cleanups.
+
+Examples:
+
+    juju show-cleanups
+    juju show-cleanups -m mymodel --format yaml
+
+See also:
+    destroy-model
+    remove-application
+    remove-machine
+`
+
+// Info implements Command.
+func (c *showCleanupsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-cleanups",
+		Purpose: "Shows the cleanups pending in a model.",
+		Doc:     showCleanupsHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *showCleanupsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatCleanupsTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init implements Command.
+func (c *showCleanupsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ShowCleanupsAPI specifies the used function calls of the Cleanups
+// facade.
+type ShowCleanupsAPI interface {
+	Close() error
+	List() ([]params.CleanupInfo, error)
+}
+
+func (c *showCleanupsCommand) getAPI() (ShowCleanupsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cleanups.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *showCleanupsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		ctx.Infof("No cleanups pending.")
+		return nil
+	}
+	out := make([]cleanupInfo, len(results))
+	for i, result := range results {
+		out[i] = cleanupInfo{
+			Id:          result.Id,
+			Kind:        result.Kind,
+			Prefix:      result.Prefix,
+			Created:     result.Created,
+			Attempts:    result.Attempts,
+			LastAttempt: result.LastAttempt,
+			LastError:   result.LastError,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+// cleanupInfo describes a pending cleanup for output.
+type cleanupInfo struct {
+	Id          string     `yaml:"id" json:"id"`
+	Kind        string     `yaml:"kind" json:"kind"`
+	Prefix      string     `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	Created     time.Time  `yaml:"created" json:"created"`
+	Attempts    int        `yaml:"attempts" json:"attempts"`
+	LastAttempt *time.Time `yaml:"last-attempt,omitempty" json:"last-attempt,omitempty"`
+	LastError   string     `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+func formatCleanupsTabular(writer io.Writer, value interface{}) error {
+	cleanups, ok := value.([]cleanupInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", cleanups, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Id", "Kind", "Prefix", "Created", "Attempts", "Last error")
+	for _, cleanup := range cleanups {
+		w.Println(
+			cleanup.Id,
+			cleanup.Kind,
+			cleanup.Prefix,
+			common.FormatTime(&cleanup.Created, true),
+			fmt.Sprint(cleanup.Attempts),
+			cleanup.LastError,
+		)
+	}
+	w.Flush()
+	return nil
+}
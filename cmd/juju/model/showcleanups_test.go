@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ShowCleanupsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeCleanupsClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ShowCleanupsCommandSuite{})
+
+type fakeCleanupsClient struct {
+	gitjujutesting.Stub
+	cleanups []params.CleanupInfo
+}
+
+func (f *fakeCleanupsClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeCleanupsClient) List() ([]params.CleanupInfo, error) {
+	f.MethodCall(f, "List")
+	return f.cleanups, f.NextErr()
+}
+
+func (s *ShowCleanupsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeCleanupsClient{}
+	lastAttempt := time.Date(2017, 11, 1, 10, 5, 0, 0, time.UTC)
+	s.fake.cleanups = []params.CleanupInfo{{
+		Id:          "5a0b",
+		Kind:        "dyingMachine",
+		Prefix:      "0",
+		Created:     time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC),
+		Attempts:    3,
+		LastAttempt: &lastAttempt,
+		LastError:   "machine 0 has unit \"mysql/0\" assigned",
+	}, {
+		Id:      "5a0c",
+		Kind:    "charm",
+		Prefix:  "cs:mysql-1",
+		Created: time.Date(2017, 11, 1, 10, 1, 0, 0, time.UTC),
+	}}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ShowCleanupsCommandSuite) TestShowTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowCleanupsCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "List", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Id    Kind          Prefix      Created               Attempts  Last error\n"+
+		"5a0b  dyingMachine  0           2017-11-01 10:00:00Z  3         machine 0 has unit \"mysql/0\" assigned\n"+
+		"5a0c  charm         cs:mysql-1  2017-11-01 10:01:00Z  0         \n")
+}
+
+func (s *ShowCleanupsCommandSuite) TestShowYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowCleanupsCommandForTest(&s.fake, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- id: 5a0b\n"+
+		"  kind: dyingMachine\n"+
+		"  prefix: \"0\"\n"+
+		"  created: 2017-11-01T10:00:00Z\n"+
+		"  attempts: 3\n"+
+		"  last-attempt: 2017-11-01T10:05:00Z\n"+
+		"  last-error: machine 0 has unit \"mysql/0\" assigned\n"+
+		"- id: 5a0c\n"+
+		"  kind: charm\n"+
+		"  prefix: cs:mysql-1\n"+
+		"  created: 2017-11-01T10:01:00Z\n"+
+		"  attempts: 0\n")
+}
+
+func (s *ShowCleanupsCommandSuite) TestShowNone(c *gc.C) {
+	s.fake.cleanups = nil
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowCleanupsCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No cleanups pending.\n")
+}
+
+func (s *ShowCleanupsCommandSuite) TestShowError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, model.NewShowCleanupsCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.fake.CheckCallNames(c, "List", "Close")
+}
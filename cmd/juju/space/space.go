@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/capabilities"
 	"github.com/juju/juju/api/spaces"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -155,6 +156,12 @@ func (c *SpaceCommandBase) NewAPI() (SpaceAPI, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Fail with a clear error, rather than an unknown facade
+	// error, if the controller does not support spaces.
+	if err := capabilities.NewClient(root).CheckFeature(params.FeatureSpaces); err != nil {
+		root.Close()
+		return nil, errors.Trace(err)
+	}
 
 	// This is tested with a feature test.
 	shim := &mvpAPIShim{
@@ -41,6 +41,15 @@ type modelStatus struct {
 	Status           statusInfoContents `json:"model-status,omitempty" yaml:"model-status,omitempty"`
 	MeterStatus      *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 	SLA              string             `json:"sla,omitempty" yaml:"sla,omitempty"`
+	UpgradeStatus    string             `json:"upgrade-status,omitempty" yaml:"upgrade-status,omitempty"`
+	FanSubnets       []fanSubnet        `json:"fan-subnets,omitempty" yaml:"fan-subnets,omitempty"`
+}
+
+type fanSubnet struct {
+	CIDR     string `json:"cidr" yaml:"cidr"`
+	Underlay string `json:"underlay" yaml:"underlay"`
+	Overlay  string `json:"overlay" yaml:"overlay"`
+	Space    string `json:"space,omitempty" yaml:"space,omitempty"`
 }
 
 type networkInterface struct {
@@ -50,6 +59,9 @@ type networkInterface struct {
 	DNSNameservers []string `json:"dns-nameservers,omitempty" yaml:"dns-nameservers,omitempty"`
 	Space          string   `json:"space,omitempty" yaml:"space,omitempty"`
 	IsUp           bool     `json:"is-up" yaml:"is-up"`
+	Type           string   `json:"type,omitempty" yaml:"type,omitempty"`
+	ParentName     string   `json:"parent-name,omitempty" yaml:"parent-name,omitempty"`
+	VLANTag        int      `json:"vlan-tag,omitempty" yaml:"vlan-tag,omitempty"`
 }
 
 type machineStatus struct {
@@ -65,6 +77,7 @@ type machineStatus struct {
 	Containers        map[string]machineStatus    `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Constraints       string                      `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Hardware          string                      `json:"hardware,omitempty" yaml:"hardware,omitempty"`
+	LXDProfiles       []string                    `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
 	HAStatus          string                      `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
 }
 
@@ -87,21 +100,22 @@ func (s machineStatus) MarshalYAML() (interface{}, error) {
 }
 
 type applicationStatus struct {
-	Err           error                 `json:"-" yaml:",omitempty"`
-	Charm         string                `json:"charm" yaml:"charm"`
-	Series        string                `json:"series"`
-	OS            string                `json:"os"`
-	CharmOrigin   string                `json:"charm-origin" yaml:"charm-origin"`
-	CharmName     string                `json:"charm-name" yaml:"charm-name"`
-	CharmRev      int                   `json:"charm-rev" yaml:"charm-rev"`
-	CanUpgradeTo  string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
-	Exposed       bool                  `json:"exposed" yaml:"exposed"`
-	Life          string                `json:"life,omitempty" yaml:"life,omitempty"`
-	StatusInfo    statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
-	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
-	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
-	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
-	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Err                 error                 `json:"-" yaml:",omitempty"`
+	Charm               string                `json:"charm" yaml:"charm"`
+	Series              string                `json:"series"`
+	OS                  string                `json:"os"`
+	CharmOrigin         string                `json:"charm-origin" yaml:"charm-origin"`
+	CharmName           string                `json:"charm-name" yaml:"charm-name"`
+	CharmRev            int                   `json:"charm-rev" yaml:"charm-rev"`
+	CanUpgradeTo        string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
+	CanUpgradeResources []string              `json:"can-upgrade-resources,omitempty" yaml:"can-upgrade-resources,omitempty"`
+	Exposed             bool                  `json:"exposed" yaml:"exposed"`
+	Life                string                `json:"life,omitempty" yaml:"life,omitempty"`
+	StatusInfo          statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
+	Relations           map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
+	SubordinateTo       []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
+	Units               map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+	Version             string                `json:"version,omitempty" yaml:"version,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
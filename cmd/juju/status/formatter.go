@@ -61,6 +61,7 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			AvailableVersion: sf.status.Model.AvailableVersion,
 			Status:           sf.getStatusInfoContents(sf.status.Model.ModelStatus),
 			SLA:              sf.status.Model.SLA,
+			UpgradeStatus:    sf.status.Model.UpgradeStatus,
 		},
 		Machines:           make(map[string]machineStatus),
 		Applications:       make(map[string]applicationStatus),
@@ -68,6 +69,14 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 		Offers:             make(map[string]offerStatus),
 		Relations:          make([]relationStatus, len(sf.relations)),
 	}
+	for _, subnet := range sf.status.Model.FanSubnets {
+		out.Model.FanSubnets = append(out.Model.FanSubnets, fanSubnet{
+			CIDR:     subnet.CIDR,
+			Underlay: subnet.Underlay,
+			Overlay:  subnet.Overlay,
+			Space:    subnet.Space,
+		})
+	}
 	if sf.status.Model.MeterStatus.Color != "" {
 		out.Model.MeterStatus = &meterStatus{
 			Color:   sf.status.Model.MeterStatus.Color,
@@ -132,6 +141,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Containers:        make(map[string]machineStatus),
 		Constraints:       machine.Constraints,
 		Hardware:          machine.Hardware,
+		LXDProfiles:       machine.LXDProfiles,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -142,6 +152,9 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 			DNSNameservers: d.DNSNameservers,
 			Space:          d.Space,
 			IsUp:           d.IsUp,
+			Type:           d.Type,
+			ParentName:     d.ParentName,
+			VLANTag:        d.VLANTag,
 		}
 	}
 	for k, m := range machine.Containers {
@@ -182,21 +195,22 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	}
 
 	out := applicationStatus{
-		Err:           application.Err,
-		Charm:         application.Charm,
-		Series:        application.Series,
-		OS:            strings.ToLower(appOS.String()),
-		CharmOrigin:   charmOrigin,
-		CharmName:     charmName,
-		CharmRev:      charmRev,
-		Exposed:       application.Exposed,
-		Life:          application.Life,
-		Relations:     application.Relations,
-		CanUpgradeTo:  application.CanUpgradeTo,
-		SubordinateTo: application.SubordinateTo,
-		Units:         make(map[string]unitStatus),
-		StatusInfo:    sf.getApplicationStatusInfo(application),
-		Version:       application.WorkloadVersion,
+		Err:                 application.Err,
+		Charm:               application.Charm,
+		Series:              application.Series,
+		OS:                  strings.ToLower(appOS.String()),
+		CharmOrigin:         charmOrigin,
+		CharmName:           charmName,
+		CharmRev:            charmRev,
+		Exposed:             application.Exposed,
+		Life:                application.Life,
+		Relations:           application.Relations,
+		CanUpgradeTo:        application.CanUpgradeTo,
+		CanUpgradeResources: application.CanUpgradeResources,
+		SubordinateTo:       application.SubordinateTo,
+		Units:               make(map[string]unitStatus),
+		StatusInfo:          sf.getApplicationStatusInfo(application),
+		Version:             application.WorkloadVersion,
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
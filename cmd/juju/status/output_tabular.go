@@ -277,6 +277,8 @@ func getModelMessage(model modelStatus) string {
 	switch {
 	case model.Status.Message != "":
 		return model.Status.Message
+	case model.UpgradeStatus != "":
+		return model.UpgradeStatus
 	case model.AvailableVersion != "":
 		return "upgrade available: " + model.AvailableVersion
 	default:
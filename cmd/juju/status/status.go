@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
 )
@@ -23,6 +25,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	StatusAt(t time.Time) (*params.FullStatus, time.Time, error)
 	Close() error
 }
 
@@ -37,6 +40,8 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	atArg    string
+	at       time.Time
 	api      statusAPI
 
 	color bool
@@ -55,6 +60,12 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+The --at option shows the status of the model as it was at a past time,
+given either as an RFC3339 time or as a duration before now. The status is
+taken from periodic snapshots of the model's status, which are kept for as
+long as the model's status history; the time of the snapshot used is
+reported. Filter patterns cannot be used with --at.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -77,6 +88,8 @@ Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --at 2h
+    juju show-status --at 2017-10-16T09:30:00Z
 
 See also:
     machines
@@ -99,6 +112,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.StringVar(&c.atArg, "at", "", "Show the status as it was at the given time, or the given duration ago")
 
 	defaultFormat := "tabular"
 
@@ -126,9 +140,32 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.atArg != "" {
+		if len(c.patterns) > 0 {
+			return errors.New("filter patterns cannot be used with --at")
+		}
+		at, err := parseStatusTime(c.atArg, time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.at = at
+	}
 	return nil
 }
 
+// parseStatusTime parses the value of the --at option, which may be
+// an RFC3339 time or a duration before now.
+func parseStatusTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("invalid --at value %q: expected an RFC3339 time or a duration", value)
+	}
+	return now.Add(-d), nil
+}
+
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	return c.NewAPIClient()
 }
@@ -140,6 +177,15 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if !c.at.IsZero() {
+		status, taken, err := apiclient.StatusAt(c.at)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintf(ctx.Stderr, "Status as of %s\n", common.FormatTime(&taken, c.isoTime))
+		return c.write(ctx, status)
+	}
+
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
@@ -151,7 +197,11 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	} else if status == nil {
 		return errors.Errorf("unable to obtain the current status")
 	}
+	return c.write(ctx, status)
+}
 
+// write formats and writes out the given status.
+func (c *statusCommand) write(ctx *cmd.Context, status *params.FullStatus) error {
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return errors.Trace(err)
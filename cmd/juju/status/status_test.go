@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -190,6 +191,7 @@ var (
 				"ip-addresses": []string{"10.0.0.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware":                 "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -213,6 +215,7 @@ var (
 				"ip-addresses": []string{"10.0.1.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -235,6 +238,7 @@ var (
 				"ip-addresses": []string{"10.0.2.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -258,6 +262,7 @@ var (
 				"ip-addresses": []string{"10.0.3.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -280,6 +285,7 @@ var (
 				"ip-addresses": []string{"10.0.4.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -314,6 +320,7 @@ var (
 								"ip-addresses": []string{"10.0.3.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 					},
@@ -331,6 +338,7 @@ var (
 						"ip-addresses": []string{"10.0.2.1"},
 						"mac-address":  "aa:bb:cc:dd:ee:ff",
 						"is-up":        true,
+						"type":         "ethernet",
 					},
 				},
 			},
@@ -360,6 +368,7 @@ var (
 				"ip-addresses": []string{"10.0.1.1"},
 				"mac-address":  "aa:bb:cc:dd:ee:ff",
 				"is-up":        true,
+				"type":         "ethernet",
 			},
 		},
 		"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -472,11 +481,13 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.0.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 							"eth1": M{
 								"ip-addresses": []string{"10.0.0.2"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware":                 "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -511,11 +522,13 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.0.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 							"eth1": M{
 								"ip-addresses": []string{"10.0.0.2"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware":                 "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -551,11 +564,13 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.0.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 							"eth1": M{
 								"ip-addresses": []string{"10.0.0.2"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware":                 "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -598,11 +613,13 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.0.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 							"eth1": M{
 								"ip-addresses": []string{"10.0.0.2"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"constraints":              "cores=2 mem=8192M root-disk=8192M",
@@ -877,6 +894,7 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.3.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -900,6 +918,7 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.4.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -2148,6 +2167,7 @@ var statusTests = []testCase{
 										"ip-addresses": []string{"10.0.2.1"},
 										"mac-address":  "aa:bb:cc:dd:ee:ff",
 										"is-up":        true,
+										"type":         "ethernet",
 									},
 								},
 							},
@@ -2166,6 +2186,7 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"10.0.1.1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"hardware": "arch=amd64 cores=1 mem=1024M root-disk=8192M",
@@ -2210,7 +2231,7 @@ var statusTests = []testCase{
 		addCharm{"mysql"},
 		addService{name: "mysql", charm: "mysql"},
 		setServiceExposed{"mysql", true},
-		addCharmPlaceholder{"mysql", 23},
+		setCharmUpgrade{"mysql", "cs:quantal/mysql-23", nil},
 		addAliveUnit{"mysql", "1"},
 
 		expect{
@@ -2322,7 +2343,7 @@ var statusTests = []testCase{
 		setUnitCharmURL{"mysql/0", "cs:quantal/mysql-1"},
 		addCharmWithRevision{addCharm{"mysql"}, "cs", 2},
 		setServiceCharm{"mysql", "cs:quantal/mysql-2"},
-		addCharmPlaceholder{"mysql", 23},
+		setCharmUpgrade{"mysql", "cs:quantal/mysql-23", []string{"data"}},
 
 		expect{
 			"services and units with correct charm status",
@@ -2334,10 +2355,11 @@ var statusTests = []testCase{
 				},
 				"applications": M{
 					"mysql": mysqlCharm(M{
-						"charm":          "cs:quantal/mysql-2",
-						"charm-rev":      2,
-						"can-upgrade-to": "cs:quantal/mysql-23",
-						"exposed":        true,
+						"charm":                 "cs:quantal/mysql-2",
+						"charm-rev":             2,
+						"can-upgrade-to":        "cs:quantal/mysql-23",
+						"can-upgrade-resources": L{"data"},
+						"exposed":               true,
 						"application-status": M{
 							"current": "active",
 							"since":   "01 Apr 15 01:23+10:00",
@@ -2379,7 +2401,7 @@ var statusTests = []testCase{
 		setUnitCharmURL{"mysql/0", "cs:quantal/mysql-1"},
 		addCharmWithRevision{addCharm{"mysql"}, "local", 1},
 		setServiceCharm{"mysql", "local:quantal/mysql-1"},
-		addCharmPlaceholder{"mysql", 23},
+		setCharmUpgrade{"mysql", "cs:quantal/mysql-23", nil},
 
 		expect{
 			"services and units with correct charm status",
@@ -2772,6 +2794,7 @@ var statusTests = []testCase{
 								"ip-addresses": []string{"2001:db8::1"},
 								"mac-address":  "aa:bb:cc:dd:ee:ff",
 								"is-up":        true,
+								"type":         "ethernet",
 							},
 						},
 						"constraints":              "cores=2 mem=8192M root-disk=8192M",
@@ -3341,16 +3364,19 @@ func (ssc setServiceCharm) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-type addCharmPlaceholder struct {
-	name string
-	rev  int
+type setCharmUpgrade struct {
+	serviceName string
+	charmURL    string
+	resources   []string
 }
 
-func (ac addCharmPlaceholder) step(c *gc.C, ctx *context) {
-	ch := testcharms.Repo.CharmDir(ac.name)
-	name := ch.Meta().Name
-	curl := charm.MustParseURL(fmt.Sprintf("cs:quantal/%s-%d", name, ac.rev))
-	err := ctx.st.AddStoreCharmPlaceholder(curl)
+func (scu setCharmUpgrade) step(c *gc.C, ctx *context) {
+	s, err := ctx.st.Application(scu.serviceName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL:  charm.MustParseURL(scu.charmURL),
+		Resources: scu.resources,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -3851,6 +3877,9 @@ type fakeAPIClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
 	closeCalled  bool
+	atUsed       time.Time
+	takenReturn  time.Time
+	statusAtErr  error
 }
 
 func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
@@ -3858,6 +3887,14 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) StatusAt(t time.Time) (*params.FullStatus, time.Time, error) {
+	a.atUsed = t
+	if a.statusAtErr != nil {
+		return nil, time.Time{}, a.statusAtErr
+	}
+	return a.statusReturn, a.takenReturn, nil
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4197,6 +4234,60 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	c.Check(string(stderr), gc.Equals, "ERROR unable to obtain the current status\n")
 }
 
+func (s *StatusSuite) TestStatusAt(c *gc.C) {
+	client := fakeAPIClient{
+		statusReturn: &params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "past"},
+		},
+		takenReturn: time.Date(2017, 10, 16, 9, 15, 0, 0, time.UTC),
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--at", "2017-10-16T09:30:00Z", "--utc", "--format", "yaml")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(client.atUsed.Equal(time.Date(2017, 10, 16, 9, 30, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(client.patternsUsed, gc.IsNil)
+	c.Check(string(stderr), gc.Equals, "Status as of 2017-10-16T09:15:00Z\n")
+	c.Check(string(stdout), jc.Contains, "name: past")
+}
+
+func (s *StatusSuite) TestStatusAtDuration(c *gc.C) {
+	client := fakeAPIClient{statusAtErr: errors.NotFoundf("status snapshot")}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	before := time.Now()
+	code, _, stderr := runStatus(c, "--at", "2h")
+	c.Assert(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "ERROR status snapshot not found\n")
+	c.Check(client.atUsed.After(before.Add(-2*time.Hour-time.Second)), jc.IsTrue)
+	c.Check(client.atUsed.Before(before.Add(-2*time.Hour+time.Minute)), jc.IsTrue)
+}
+
+func (s *StatusSuite) TestStatusAtInvalid(c *gc.C) {
+	for i, t := range []struct {
+		args   []string
+		errMsg string
+	}{{
+		args:   []string{"--at", "yesterday"},
+		errMsg: `invalid --at value "yesterday": expected an RFC3339 time or a duration`,
+	}, {
+		args:   []string{"--at", "-2h"},
+		errMsg: `invalid --at value "-2h": expected an RFC3339 time or a duration`,
+	}, {
+		args:   []string{"--at", "2h", "mysql"},
+		errMsg: "filter patterns cannot be used with --at",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		code, _, stderr := runStatus(c, t.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(string(stderr), gc.Equals, "ERROR "+t.errMsg+"\n")
+	}
+}
+
 func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -4239,6 +4330,26 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 		"Machine  State  DNS  Inst id  Series  AZ  Message\n")
 }
 
+func (s *StatusSuite) TestFormatTabularUpgradeStatus(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Name:             "m",
+			Controller:       "c",
+			Cloud:            "dummy",
+			Version:          "2.3.1",
+			AvailableVersion: "2.3.2",
+			UpgradeStatus:    "staged upgrade from 2.3.0 to 2.3.1: 1 machine(s) admitted",
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Matches, "(?s)"+
+		"Model  Controller  Cloud/Region  Version  Notes\n"+
+		"m      c           dummy         2.3.1    staged upgrade from 2.3.0 to 2.3.1: 1 machine\\(s\\) admitted\n"+
+		".*")
+}
+
 //
 // Filtering Feature
 //
@@ -4410,6 +4521,7 @@ func (s *StatusSuite) TestFilterToContainer(c *gc.C) {
 		"        - 10.0.0.1\n" +
 		"        mac-address: aa:bb:cc:dd:ee:ff\n" +
 		"        is-up: true\n" +
+		"        type: ethernet\n" +
 		"    containers:\n" +
 		"      0/lxd/0:\n" +
 		"        juju-status:\n" +
@@ -4740,6 +4852,39 @@ func (s *StatusSuite) TestIsoTimeFormat(c *gc.C) {
 	}(statusTimeTest)
 }
 
+func (s *StatusSuite) TestFormatFanSubnets(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+			FanSubnets: []params.FanSubnet{{
+				CIDR:     "252.0.0.0/16",
+				Underlay: "10.0.0.0/24",
+				Overlay:  "252.0.0.0/8",
+				Space:    "internal",
+			}},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Model.FanSubnets, jc.DeepEquals, []fanSubnet{{
+		CIDR:     "252.0.0.0/16",
+		Underlay: "10.0.0.0/24",
+		Overlay:  "252.0.0.0/8",
+		Space:    "internal",
+	}})
+
+	out, err := goyaml.Marshal(formatted.Model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, `
+fan-subnets:
+- cidr: 252.0.0.0/16
+  underlay: 10.0.0.0/24
+  overlay: 252.0.0.0/8
+  space: internal
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	status := &params.FullStatus{
 		Model: params.ModelStatusInfo{
@@ -35,6 +35,16 @@ const (
 Attach existing storage to a unit. Specify a unit
 and one or more storage IDs to attach to it.
 
+Storage that has been detached from a unit may be
+attached to another unit, including a unit of a
+different application, so long as the unit's charm
+declares storage with the same name and kind, and a
+minimum size no larger than the storage. If the unit
+is assigned to a machine, the storage's volume or
+filesystem is attached to the machine once it has been
+detached from its previous machine. Once attached, the
+storage-attached hook runs on the unit.
+
 Examples:
     juju attach-storage postgresql/1 pgdata/0
 `
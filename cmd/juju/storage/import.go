@@ -29,7 +29,36 @@ func NewImportFilesystemCommand(
 	newStorageImporter NewStorageImporterFunc,
 	store jujuclient.ClientStore,
 ) cmd.Command {
-	cmd := &importFilesystemCommand{}
+	return newImportStorageCommand(
+		storage.StorageKindFilesystem,
+		newStorageImporter, store,
+	)
+}
+
+// NewImportVolumeCommand returns a command used to import a volume.
+//
+// newStorageImporter is the function to use to acquire a StorageImporter.
+// A non-nil function must be provided.
+//
+// store is an optional ClientStore to use for interacting with the client
+// model/controller storage. If nil, the default file-based store will be
+// used.
+func NewImportVolumeCommand(
+	newStorageImporter NewStorageImporterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	return newImportStorageCommand(
+		storage.StorageKindBlock,
+		newStorageImporter, store,
+	)
+}
+
+func newImportStorageCommand(
+	kind storage.StorageKind,
+	newStorageImporter NewStorageImporterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &importStorageCommand{kind: kind}
 	cmd.newAPIFunc = newStorageImporter
 	if store != nil {
 		cmd.SetClientStore(store)
@@ -38,7 +67,7 @@ func NewImportFilesystemCommand(
 }
 
 // NewStorageImporterFunc is the type of a function passed to
-// NewImportFilesystemCommand, in order to acquire a StorageImporter.
+// NewImportFilesystemCommand and NewImportVolumeCommand, in order to acquire a StorageImporter.
 type NewStorageImporterFunc func(*StorageCommandBase) (StorageImporter, error)
 
 // NewStorageImporter returns a new StorageImporter,
@@ -73,25 +102,57 @@ Examples:
     # the volume and filesystem contained within.
     juju import-filesystem ebs vol-123456 pgdata
 `
-	importFilesystemCommandAgs = `
+	importVolumeCommandDoc = `
+Import an existing volume (block device) into the model. This will lead to
+the model taking ownership of the storage, so you must take care not to
+import storage that is in use by another Juju model.
+
+To import a volume, you must specify three things:
+
+ - the storage provider which manages the storage, and with
+   which the storage will be associated
+ - the storage provider ID for the volume
+ - the storage name to assign to the volume,
+   corresponding to the storage name used by a charm
+
+Once a volume is imported, Juju will create an associated storage
+instance using the given storage name.
+
+Examples:
+    # Import an existing EBS volume, and assign it the
+    # "pgdata" storage name. Juju will associate a storage
+    # instance ID like "pgdata/0" with the volume.
+    juju import-volume ebs vol-123456 pgdata
+`
+	importStorageCommandArgs = `
 <storage-provider> <provider-id> <storage-name>
 `
 )
 
-// importFilesystemCommand imports filesystems into the model.
-type importFilesystemCommand struct {
+// importStorageCommand imports filesystems or volumes into the model.
+type importStorageCommand struct {
 	StorageCommandBase
 	newAPIFunc NewStorageImporterFunc
+	kind       storage.StorageKind
 
 	storagePool       string
 	storageProviderId string
 	storageName       string
 }
 
+// commandName returns the name of the command, which depends on
+// the kind of storage being imported.
+func (c *importStorageCommand) commandName() string {
+	if c.kind == storage.StorageKindBlock {
+		return "import-volume"
+	}
+	return "import-filesystem"
+}
+
 // Init implements Command.Init.
-func (c *importFilesystemCommand) Init(args []string) error {
+func (c *importStorageCommand) Init(args []string) error {
 	if len(args) < 3 {
-		return errors.New("import-filesystem requires a storage provider, provider ID, and storage name")
+		return errors.Errorf("%s requires a storage provider, provider ID, and storage name", c.commandName())
 	}
 	c.storagePool = args[0]
 	c.storageProviderId = args[1]
@@ -112,17 +173,25 @@ func (c *importFilesystemCommand) Init(args []string) error {
 }
 
 // Info implements Command.Info.
-func (c *importFilesystemCommand) Info() *cmd.Info {
+func (c *importStorageCommand) Info() *cmd.Info {
+	if c.kind == storage.StorageKindBlock {
+		return &cmd.Info{
+			Name:    "import-volume",
+			Purpose: "Imports a volume into the model.",
+			Doc:     importVolumeCommandDoc,
+			Args:    importStorageCommandArgs,
+		}
+	}
 	return &cmd.Info{
 		Name:    "import-filesystem",
 		Purpose: "Imports a filesystem into the model.",
 		Doc:     importFilesystemCommandDoc,
-		Args:    importFilesystemCommandAgs,
+		Args:    importStorageCommandArgs,
 	}
 }
 
 // Run implements Command.Run.
-func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
+func (c *importStorageCommand) Run(ctx *cmd.Context) (err error) {
 	api, err := c.newAPIFunc(&c.StorageCommandBase)
 	if err != nil {
 		return err
@@ -134,7 +203,7 @@ func (c *importFilesystemCommand) Run(ctx *cmd.Context) (err error) {
 		c.storageProviderId, c.storagePool, c.storageName,
 	)
 	storageTag, err := api.ImportStorage(
-		storage.StorageKindFilesystem,
+		c.kind,
 		c.storagePool, c.storageProviderId, c.storageName,
 	)
 	if err != nil {
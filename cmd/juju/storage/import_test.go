@@ -90,6 +90,51 @@ func (s *ImportFilesystemSuite) run(c *gc.C, args ...string) (*cmd.Context, erro
 	), args...)
 }
 
+type ImportVolumeSuite struct {
+	SubStorageSuite
+	importer mockStorageImporter
+}
+
+var _ = gc.Suite(&ImportVolumeSuite{})
+
+func (s *ImportVolumeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.importer = mockStorageImporter{}
+}
+
+func (s *ImportVolumeSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, "import-volume requires a storage provider, provider ID, and storage name")
+}
+
+func (s *ImportVolumeSuite) TestImportSuccess(c *gc.C) {
+	ctx, err := s.run(c, "foo", "bar", "baz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+importing "bar" from storage pool "foo" as storage "baz"
+imported storage baz/0
+`[1:])
+
+	s.importer.CheckCalls(c, []testing.StubCall{
+		{"ImportStorage", []interface{}{
+			jujustorage.StorageKindBlock,
+			"foo", "bar", "baz",
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *ImportVolumeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewImportVolumeCommand(
+		func(*storage.StorageCommandBase) (storage.StorageImporter, error) {
+			return &s.importer, nil
+		},
+		s.store,
+	), args...)
+}
+
 type mockStorageImporter struct {
 	testing.Stub
 }
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type PoolRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolRemoveAPI
+}
+
+var _ = gc.Suite(&PoolRemoveSuite{})
+
+func (s *PoolRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockPoolRemoveAPI{}
+}
+
+func (s *PoolRemoveSuite) runPoolRemove(c *gc.C, args []string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewPoolRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveArgs(c *gc.C) {
+	_, err := s.runPoolRemove(c, nil)
+	c.Check(err, gc.ErrorMatches, "pool removal requires a single pool name")
+	_, err = s.runPoolRemove(c, []string{"sunshine", "lollypop"})
+	c.Check(err, gc.ErrorMatches, "pool removal requires a single pool name")
+}
+
+func (s *PoolRemoveSuite) TestPoolRemove(c *gc.C) {
+	_, err := s.runPoolRemove(c, []string{"sunshine"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "RemovePool", "Close")
+	s.mockAPI.CheckCall(c, 0, "RemovePool", "sunshine")
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`storage pool "sunshine" in use`))
+	_, err := s.runPoolRemove(c, []string{"sunshine"})
+	c.Assert(err, gc.ErrorMatches, `storage pool "sunshine" in use`)
+}
+
+type mockPoolRemoveAPI struct {
+	testing.Stub
+}
+
+func (m *mockPoolRemoveAPI) RemovePool(pname string) error {
+	m.MethodCall(m, "RemovePool", pname)
+	return m.NextErr()
+}
+
+func (m *mockPoolRemoveAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type PoolUpdateSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolUpdateAPI
+}
+
+var _ = gc.Suite(&PoolUpdateSuite{})
+
+func (s *PoolUpdateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockPoolUpdateAPI{}
+}
+
+func (s *PoolUpdateSuite) runPoolUpdate(c *gc.C, args []string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewPoolUpdateCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNoArgs(c *gc.C) {
+	_, err := s.runPoolUpdate(c, nil)
+	c.Check(err, gc.ErrorMatches, "pool update requires a pool name")
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateAttrMissingValue(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "something="})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "something="`)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdate(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "something=too", "another=one"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "UpdatePool", "Close")
+	s.mockAPI.CheckCall(c, 0, "UpdatePool", "sunshine", "", map[string]interface{}{
+		"something": "too",
+		"another":   "one",
+	})
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNoAttrs(c *gc.C) {
+	_, err := s.runPoolUpdate(c, []string{"sunshine"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "UpdatePool", "sunshine", "", map[string]interface{}{})
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runPoolUpdate(c, []string{"sunshine", "something=too"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCallNames(c, "UpdatePool", "Close")
+}
+
+type mockPoolUpdateAPI struct {
+	testing.Stub
+}
+
+func (m *mockPoolUpdateAPI) UpdatePool(pname, ptype string, pconfig map[string]interface{}) error {
+	m.MethodCall(m, "UpdatePool", pname, ptype, pconfig)
+	return m.NextErr()
+}
+
+func (m *mockPoolUpdateAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -40,6 +40,11 @@ Displays a list of all subnets known to Juju. Results can be filtered
 using the optional --space and/or --zone arguments to only display
 subnets associated with a given network space and/or availability zone.
 
+On clouds that support networking, Juju periodically discovers the
+cloud's subnets, so new subnets appear without being added by hand.
+Subnets that the cloud no longer reports are shown with the status
+"terminating".
+
 Like with other Juju commands, the output and its format can be changed
 using the --format and --output (or -o) optional arguments. Supported
 output formats include "yaml" (default) and "json". To redirect the
@@ -118,6 +123,7 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 			subResult := formattedSubnet{
 				ProviderId:        sub.ProviderId,
 				ProviderNetworkId: sub.ProviderNetworkId,
+				VLANTag:           sub.VLANTag,
 				Zones:             sub.Zones,
 			}
 
@@ -169,6 +175,7 @@ type formattedSubnet struct {
 	Type              string   `json:"type" yaml:"type"`
 	ProviderId        string   `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	ProviderNetworkId string   `json:"provider-network-id,omitempty" yaml:"provider-network-id,omitempty"`
+	VLANTag           int      `json:"vlan-tag,omitempty" yaml:"vlan-tag,omitempty"`
 	Status            string   `json:"status,omitempty" yaml:"status,omitempty"`
 	Space             string   `json:"space" yaml:"space"`
 	Zones             []string `json:"zones" yaml:"zones"`
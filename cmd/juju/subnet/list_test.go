@@ -103,6 +103,7 @@ func (s *ListSuite) TestOutputFormats(c *gc.C) {
 subnets:
   10.10.0.0/16:
     type: ipv4
+    vlan-tag: 42
     status: terminating
     space: vlan-42
     zones:
@@ -127,6 +128,7 @@ subnets:
 	expectedJSON := `{"subnets":{` +
 		`"10.10.0.0/16":{` +
 		`"type":"ipv4",` +
+		`"vlan-tag":42,` +
 		`"status":"terminating",` +
 		`"space":"vlan-42",` +
 		`"zones":["zone1"]},` +
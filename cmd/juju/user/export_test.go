@@ -46,6 +46,10 @@ type DisenableUserBase struct {
 	*disenableUserBase
 }
 
+type UnlockCommand struct {
+	*unlockCommand
+}
+
 func NewAddCommandForTest(api AddUserAPI, store jujuclient.ClientStore, modelAPI modelcmd.ModelAPI) (cmd.Command, *AddCommand) {
 	c := &addCommand{api: api}
 	c.SetClientStore(store)
@@ -106,6 +110,38 @@ func NewEnableCommandForTest(api disenableUserAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c), &DisenableUserBase{&c.disenableUserBase}
 }
 
+// NewUnlockCommandForTest returns an UnlockCommand with the api provided
+// as specified.
+func NewUnlockCommandForTest(api unlockUserAPI, store jujuclient.ClientStore) (cmd.Command, *UnlockCommand) {
+	c := &unlockCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &UnlockCommand{c}
+}
+
+// NewAddTokenCommandForTest returns an add-token command with the api
+// provided as specified.
+func NewAddTokenCommandForTest(api apiTokensAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addTokenCommand{tokensCommandBase: tokensCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveTokenCommandForTest returns a remove-token command with the
+// api provided as specified.
+func NewRemoveTokenCommandForTest(api apiTokensAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeTokenCommand{tokensCommandBase: tokensCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListTokensCommandForTest returns a tokens command with the api
+// provided as specified.
+func NewListTokensCommandForTest(api apiTokensAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listTokensCommand{tokensCommandBase: tokensCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewListCommand returns a ListCommand with the api provided as specified.
 func NewListCommandForTest(api UserInfoAPI, modelAPI modelUsersAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
 	c := &listCommand{
@@ -4,6 +4,8 @@
 package user
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -24,6 +26,10 @@ var helpDetails = `
 By default, the YAML format is used and the user name is the current
 user.
 
+If the controller enforces a login lockout and the user has been locked
+out after too many failed logins, the lock state is shown along with the
+time the lock expires. A controller superuser can clear the lock early
+with 'juju unlock-user'.
 
 Examples:
     juju show-user
@@ -34,6 +40,7 @@ Examples:
 See also: 
     add-user
     register
+    unlock-user
     users`[1:]
 
 // UserInfoAPI defines the API methods that the info command uses.
@@ -77,6 +84,9 @@ type UserInfo struct {
 	DateCreated    string `yaml:"date-created,omitempty" json:"date-created,omitempty"`
 	LastConnection string `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Locked         bool   `yaml:"locked,omitempty" json:"locked,omitempty"`
+	LockedUntil    string `yaml:"locked-until,omitempty" json:"locked-until,omitempty"`
+	PasswordExpiry string `yaml:"password-expiry,omitempty" json:"password-expiry,omitempty"`
 }
 
 // Info implements Command.Info.
@@ -134,7 +144,13 @@ func (c *infoCommand) Run(ctx *cmd.Context) (err error) {
 	if len(output) != 1 {
 		return errors.Errorf("expected 1 result, got %d", len(output))
 	}
-	return c.out.Write(ctx, output[0])
+	if err := c.out.Write(ctx, output[0]); err != nil {
+		return err
+	}
+	if output[0].Locked {
+		ctx.Infof("User %q is locked out; run 'juju unlock-user %s' to unlock", username, username)
+	}
+	return nil
 }
 
 func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []UserInfo {
@@ -146,6 +162,7 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			DisplayName: info.DisplayName,
 			Access:      info.Access,
 			Disabled:    info.Disabled,
+			Locked:      info.Locked,
 		}
 		// TODO(wallyworld) record login information about external users.
 		if names.NewUserTag(info.Username).IsLocal() {
@@ -155,9 +172,24 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			} else {
 				outInfo.DateCreated = common.UserFriendlyDuration(info.DateCreated, now)
 			}
+			if info.LockedUntil != nil {
+				outInfo.LockedUntil = c.formatTime(*info.LockedUntil)
+			}
+			if info.PasswordExpiry != nil {
+				outInfo.PasswordExpiry = c.formatTime(*info.PasswordExpiry)
+			}
 		}
 		output = append(output, outInfo)
 	}
 
 	return output
 }
+
+// formatTime formats a time that may lie in the future, such as the
+// expiry of a lockout or password.
+func (c *infoCommandBase) formatTime(t time.Time) string {
+	if c.exactTime {
+		return t.String()
+	}
+	return t.Format("2006-01-02 15:04")
+}
@@ -32,6 +32,7 @@ var (
 	// Mock out timestamps
 	dateCreated    = time.Unix(352138205, 0).UTC()
 	lastConnection = time.Unix(1388534400, 0).UTC()
+	lockedUntil    = time.Unix(1388535300, 0).UTC()
 )
 
 func (s *UserInfoCommandSuite) NewShowUserCommand() cmd.Command {
@@ -62,6 +63,12 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 		info.Username = "fred@external"
 		info.DisplayName = "Fred External"
 		info.Access = "add-model"
+	case "lockie":
+		info.Username = "lockie"
+		info.Access = "login"
+		info.Locked = true
+		info.LockedUntil = &lockedUntil
+		info.PasswordExpiry = &lockedUntil
 	default:
 		return nil, common.ErrPerm
 	}
@@ -108,6 +115,21 @@ access: add-model
 `)
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoLockedUser(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, s.NewShowUserCommand(), "lockie", "--exact-time")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `user-name: lockie
+access: login
+date-created: 1981-02-27 16:10:05 +0000 UTC
+last-connection: 2014-01-01 00:00:00 +0000 UTC
+locked: true
+locked-until: 2014-01-01 00:15:00 +0000 UTC
+password-expiry: 2014-01-01 00:15:00 +0000 UTC
+`)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals,
+		"User \"lockie\" is locked out; run 'juju unlock-user lockie' to unlock\n")
+}
+
 func (s *UserInfoCommandSuite) TestUserInfoUserDoesNotExist(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.NewShowUserCommand(), "barfoo")
 	c.Assert(err, gc.ErrorMatches, "permission denied")
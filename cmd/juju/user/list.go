@@ -228,6 +228,9 @@ func (c *listCommand) formatControllerUsers(writer io.Writer, value interface{})
 		if user.Disabled {
 			conn += " (disabled)"
 		}
+		if user.Locked {
+			conn += " (locked)"
+		}
 		var highlight *ansiterm.Context
 		userName := user.Username
 		if c.isLoggedInUser(user.Username) {
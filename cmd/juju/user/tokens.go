@@ -0,0 +1,398 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/apitokens"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/apitoken"
+	"github.com/juju/juju/jujuclient"
+)
+
+// apiTokensAPI defines the methods of the APITokens facade used by the
+// token commands.
+type apiTokensAPI interface {
+	Close() error
+	Add(model names.ModelTag, capabilities []string, lifetime time.Duration) (params.APIToken, string, error)
+	List() ([]params.APIToken, error)
+	Remove(id string) error
+}
+
+// tokensCommandBase holds the API used by the token commands.
+type tokensCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api apiTokensAPI
+}
+
+func (c *tokensCommandBase) getAPI() (apiTokensAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apitokens.NewClient(root), nil
+}
+
+// modelNames returns the names of the controller's models known to
+// the client, keyed by UUID.
+func (c *tokensCommandBase) modelNames() map[string]string {
+	result := make(map[string]string)
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return result
+	}
+	models, err := c.ClientStore().AllModels(controllerName)
+	if err != nil {
+		return result
+	}
+	for name, details := range models {
+		result[details.ModelUUID] = name
+	}
+	return result
+}
+
+// NewAddTokenCommand returns a command that adds an API token.
+func NewAddTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&addTokenCommand{})
+}
+
+type addTokenCommand struct {
+	tokensCommandBase
+	scope        string
+	capabilities string
+	expires      string
+
+	modelName string
+	lifetime  time.Duration
+}
+
+const addTokenHelpDoc = `
+Adds a revocable API token with which automation, such as a CI system,
+may log in to the controller on your behalf without sharing your
+password. The token is printed once; it cannot be retrieved later.
+
+To log in with the token, set the JUJU_TOKEN environment variable to
+it. The client then uses the token in place of the credentials stored
+for the controller.
+
+The token can do no more than you can, and is further limited to the
+capabilities given with --capability, which are a comma-separated list
+of:
+
+    read         read status, configuration and other state
+    run-actions  queue, cancel and read actions
+    write        read, run actions, and change models
+
+By default the token may only read. A token may never be used to
+manage API tokens, users, passwords or SSH keys, to grant or revoke
+access, to run commands on or introspect machines, or to change
+controller configuration.
+
+By default the token may log in to any model you have access to, and
+to the controller. Use --scope model=<name> to limit it to one model.
+
+Tokens expire after 30 days unless another lifetime is given with
+--expires, as a number of days (such as 7d) or a duration (such as
+12h).
+
+Examples:
+
+    juju add-token --scope model=ci --capability read,run-actions --expires 30d
+    juju add-token --capability write --expires 12h
+
+See also:
+    remove-token
+    tokens
+`
+
+// Info is part of the cmd.Command interface.
+func (c *addTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-token",
+		Purpose: "Adds an API token for logging in without a password.",
+		Doc:     strings.TrimSpace(addTokenHelpDoc),
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.tokensCommandBase.SetFlags(f)
+	f.StringVar(&c.scope, "scope", "", "Limit the token to a model, as model=<name>")
+	f.StringVar(&c.capabilities, "capability", apitoken.Read, "Comma-separated capabilities granted to the token")
+	f.StringVar(&c.expires, "expires", "30d", "How long the token remains valid")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addTokenCommand) Init(args []string) error {
+	if c.scope != "" {
+		parts := strings.SplitN(c.scope, "=", 2)
+		if len(parts) != 2 || parts[0] != "model" || parts[1] == "" {
+			return errors.Errorf("invalid scope %q (expected model=<name>)", c.scope)
+		}
+		c.modelName = parts[1]
+	}
+	if err := apitoken.ValidateCapabilities(c.capabilityList()); err != nil {
+		return errors.Trace(err)
+	}
+	lifetime, err := parseTokenLifetime(c.expires)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.lifetime = lifetime
+	return cmd.CheckEmpty(args)
+}
+
+func (c *addTokenCommand) capabilityList() []string {
+	var capabilities []string
+	for _, capability := range strings.Split(c.capabilities, ",") {
+		if capability = strings.TrimSpace(capability); capability != "" {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	return capabilities
+}
+
+// parseTokenLifetime parses a token lifetime, which is either a
+// number of days such as "30d", or a duration such as "12h".
+func parseTokenLifetime(s string) (time.Duration, error) {
+	var lifetime time.Duration
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, errors.Errorf("invalid expiry %q", s)
+		}
+		lifetime = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		if lifetime, err = time.ParseDuration(s); err != nil {
+			return 0, errors.Errorf("invalid expiry %q", s)
+		}
+	}
+	if lifetime <= 0 {
+		return 0, errors.Errorf("invalid expiry %q (must be positive)", s)
+	}
+	return lifetime, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addTokenCommand) Run(ctx *cmd.Context) error {
+	var modelTag names.ModelTag
+	if c.modelName != "" {
+		modelName := c.modelName
+		if !jujuclient.IsQualifiedModelName(modelName) {
+			account, err := c.CurrentAccountDetails()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			modelName = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), modelName)
+		}
+		uuids, err := c.ModelUUIDs([]string{modelName})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		modelTag = names.NewModelTag(uuids[0])
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	info, token, err := client.Add(modelTag, c.capabilityList(), c.lifetime)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added API token %s, expiring %s. Log in with it by setting JUJU_TOKEN to:",
+		info.ID, info.Expires.Format(time.RFC3339))
+	fmt.Fprintln(ctx.Stdout, token)
+	return nil
+}
+
+// NewRemoveTokenCommand returns a command that removes an API token.
+func NewRemoveTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&removeTokenCommand{})
+}
+
+type removeTokenCommand struct {
+	tokensCommandBase
+	id string
+}
+
+const removeTokenHelpDoc = `
+Removes an API token, so that it may no longer be used to log in.
+Connections already logged in with the token are not closed.
+
+Controller administrators may remove the tokens of any user.
+
+Examples:
+
+    juju remove-token 3f2a9c1b7e6d5a40
+
+See also:
+    add-token
+    tokens
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-token",
+		Args:    "<token id>",
+		Purpose: "Removes an API token.",
+		Doc:     strings.TrimSpace(removeTokenHelpDoc),
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token ID specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeTokenCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Remove(c.id); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewListTokensCommand returns a command that lists API tokens.
+func NewListTokensCommand() cmd.Command {
+	return modelcmd.WrapController(&listTokensCommand{})
+}
+
+type listTokensCommand struct {
+	tokensCommandBase
+	out cmd.Output
+}
+
+const listTokensHelpDoc = `
+Lists your API tokens, or the tokens of all users if you are a
+controller administrator. The tokens themselves are not shown.
+
+Examples:
+
+    juju tokens
+    juju tokens --format yaml
+
+See also:
+    add-token
+    remove-token
+`
+
+// Info is part of the cmd.Command interface.
+func (c *listTokensCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "tokens",
+		Purpose: "Lists API tokens.",
+		Doc:     strings.TrimSpace(listTokensHelpDoc),
+		Aliases: []string{"list-tokens"},
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.tokensCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatTokensTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listTokensCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// tokenInfo describes an API token for output.
+type tokenInfo struct {
+	ID           string   `yaml:"id" json:"id"`
+	User         string   `yaml:"user" json:"user"`
+	Model        string   `yaml:"model,omitempty" json:"model,omitempty"`
+	Capabilities []string `yaml:"capabilities" json:"capabilities"`
+	Created      string   `yaml:"created" json:"created"`
+	Expires      string   `yaml:"expires" json:"expires"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listTokensCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	results, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No API tokens to display.")
+		return nil
+	}
+	modelNames := c.modelNames()
+	out := make([]tokenInfo, len(results))
+	for i, result := range results {
+		info := tokenInfo{
+			ID:           result.ID,
+			Capabilities: result.Capabilities,
+			Created:      result.Created.Format(time.RFC3339),
+			Expires:      result.Expires.Format(time.RFC3339),
+		}
+		if userTag, err := names.ParseUserTag(result.UserTag); err == nil {
+			info.User = userTag.Id()
+		}
+		if modelTag, err := names.ParseModelTag(result.ModelTag); err == nil {
+			info.Model = modelTag.Id()
+			if name, ok := modelNames[modelTag.Id()]; ok {
+				info.Model = name
+			}
+		}
+		out[i] = info
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatTokensTabular(writer io.Writer, value interface{}) error {
+	tokens, ok := value.([]tokenInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "User", "Model", "Capabilities", "Expires")
+	for _, token := range tokens {
+		model := token.Model
+		if model == "" {
+			model = "all"
+		}
+		w.Println(token.ID, token.User, model, strings.Join(token.Capabilities, ","), token.Expires)
+	}
+	w.Flush()
+	return nil
+}
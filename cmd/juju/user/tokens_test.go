@@ -0,0 +1,194 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type TokensSuite struct {
+	BaseSuite
+	api     *fakeAPITokensAPI
+	expires time.Time
+}
+
+var _ = gc.Suite(&TokensSuite{})
+
+func (s *TokensSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	err := s.store.UpdateModel("testing", "current-user/ci", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.expires = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAPITokensAPI{
+		tokens: []params.APIToken{{
+			ID:           "0123456789abcdef",
+			UserTag:      "user-current-user",
+			ModelTag:     testing.ModelTag.String(),
+			Capabilities: []string{"read", "run-actions"},
+			Created:      s.expires.Add(-30 * 24 * time.Hour),
+			Expires:      s.expires,
+		}, {
+			ID:           "fedcba9876543210",
+			UserTag:      "user-bob",
+			Capabilities: []string{"write"},
+			Created:      s.expires.Add(-time.Hour),
+			Expires:      s.expires,
+		}},
+		expires: s.expires,
+	}
+}
+
+func (s *TokensSuite) TestAddTokenInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--scope", "controller"},
+		err:  `invalid scope "controller" \(expected model=<name>\)`,
+	}, {
+		args: []string{"--scope", "model="},
+		err:  `invalid scope "model=" \(expected model=<name>\)`,
+	}, {
+		args: []string{"--capability", "read,admin"},
+		err:  `capability "admin" \(expected one of read, run-actions, write\) not valid`,
+	}, {
+		args: []string{"--expires", "soon"},
+		err:  `invalid expiry "soon"`,
+	}, {
+		args: []string{"--expires", "0d"},
+		err:  `invalid expiry "0d" \(must be positive\)`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		command := user.NewAddTokenCommandForTest(s.api, s.store)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TokensSuite) TestAddToken(c *gc.C) {
+	command := user.NewAddTokenCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command,
+		"--scope", "model=ci", "--capability", "read, run-actions", "--expires", "7d")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Add", []interface{}{testing.ModelTag, []string{"read", "run-actions"}, 7 * 24 * time.Hour}},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "0123456789abcdef.s3cret\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		"Added API token 0123456789abcdef, expiring 2017-10-01T12:00:00Z. Log in with it by setting JUJU_TOKEN to:\n")
+}
+
+func (s *TokensSuite) TestAddTokenDefaults(c *gc.C) {
+	command := user.NewAddTokenCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Add", names.ModelTag{}, []string{"read"}, 30*24*time.Hour)
+}
+
+func (s *TokensSuite) TestRemoveToken(c *gc.C) {
+	command := user.NewRemoveTokenCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "0123456789abcdef")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jtesting.StubCall{
+		{"Remove", []interface{}{"0123456789abcdef"}},
+		{"Close", nil},
+	})
+}
+
+func (s *TokensSuite) TestRemoveTokenNoID(c *gc.C) {
+	command := user.NewRemoveTokenCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no token ID specified")
+}
+
+func (s *TokensSuite) TestListTokens(c *gc.C) {
+	command := user.NewListTokensCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"ID                User          Model            Capabilities      Expires\n"+
+		"0123456789abcdef  current-user  current-user/ci  read,run-actions  2017-10-01T12:00:00Z\n"+
+		"fedcba9876543210  bob           all              write             2017-10-01T12:00:00Z\n"+
+		"\n")
+}
+
+func (s *TokensSuite) TestListTokensYAML(c *gc.C) {
+	command := user.NewListTokensCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- id: 0123456789abcdef
+  user: current-user
+  model: current-user/ci
+  capabilities:
+  - read
+  - run-actions
+  created: 2017-09-01T12:00:00Z
+  expires: 2017-10-01T12:00:00Z
+- id: fedcba9876543210
+  user: bob
+  capabilities:
+  - write
+  created: 2017-10-01T11:00:00Z
+  expires: 2017-10-01T12:00:00Z
+`[1:])
+}
+
+func (s *TokensSuite) TestListTokensNone(c *gc.C) {
+	s.api.tokens = nil
+	command := user.NewListTokensCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No API tokens to display.\n")
+}
+
+type fakeAPITokensAPI struct {
+	jtesting.Stub
+	tokens  []params.APIToken
+	expires time.Time
+}
+
+func (f *fakeAPITokensAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAPITokensAPI) Add(model names.ModelTag, capabilities []string, lifetime time.Duration) (params.APIToken, string, error) {
+	f.MethodCall(f, "Add", model, capabilities, lifetime)
+	if err := f.NextErr(); err != nil {
+		return params.APIToken{}, "", err
+	}
+	return params.APIToken{
+		ID:      "0123456789abcdef",
+		Expires: f.expires,
+	}, "0123456789abcdef.s3cret", nil
+}
+
+func (f *fakeAPITokensAPI) List() ([]params.APIToken, error) {
+	f.MethodCall(f, "List")
+	return f.tokens, f.NextErr()
+}
+
+func (f *fakeAPITokensAPI) Remove(id string) error {
+	f.MethodCall(f, "Remove", id)
+	return f.NextErr()
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageUnlockUserSummary = `
+Clears a login lockout on a Juju user.`[1:]
+
+var usageUnlockUserDetails = `
+When the controller's login-lockout-threshold is set, a local user is
+locked out after that many consecutive failed logins. The lock expires
+on its own after login-lockout-duration; this command clears it early
+and resets the user's failed login count.
+
+Examples:
+    juju unlock-user bob
+
+See also: 
+    show-user
+    enable-user
+    login`[1:]
+
+// unlockUserAPI defines the API methods that the unlock command uses.
+type unlockUserAPI interface {
+	UnlockUser(username string) error
+	Close() error
+}
+
+func NewUnlockCommand() cmd.Command {
+	return modelcmd.WrapController(&unlockCommand{})
+}
+
+// unlockCommand clears the login lockout on a user.
+type unlockCommand struct {
+	modelcmd.ControllerCommandBase
+	api  unlockUserAPI
+	User string
+}
+
+// Info implements Command.Info.
+func (c *unlockCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "unlock-user",
+		Args:    "<user name>",
+		Purpose: usageUnlockUserSummary,
+		Doc:     usageUnlockUserDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *unlockCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no username supplied")
+	}
+	c.User = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *unlockCommand) Run(ctx *cmd.Context) error {
+	if c.api == nil {
+		api, err := c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.api = api
+		defer c.api.Close()
+	}
+
+	if err := c.api.UnlockUser(c.User); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("User %q unlocked", c.User)
+	return nil
+}
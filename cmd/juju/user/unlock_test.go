@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/user"
+)
+
+type UnlockUserSuite struct {
+	BaseSuite
+	mock *mockUnlockUserAPI
+}
+
+var _ = gc.Suite(&UnlockUserSuite{})
+
+func (s *UnlockUserSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockUnlockUserAPI{}
+}
+
+func (s *UnlockUserSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+		user     string
+	}{
+		{
+			errMatch: "no username supplied",
+		}, {
+			args:     []string{"username", "password"},
+			errMatch: `unrecognized args: \["password"\]`,
+		}, {
+			args: []string{"username"},
+			user: "username",
+		},
+	} {
+		c.Logf("test %d, args %v", i, test.args)
+		wrappedCommand, command := user.NewUnlockCommandForTest(nil, s.store)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errMatch == "" {
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(command.User, gc.Equals, test.user)
+		} else {
+			c.Assert(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *UnlockUserSuite) TestUnlock(c *gc.C) {
+	unlockCommand, _ := user.NewUnlockCommandForTest(s.mock, s.store)
+	ctx, err := cmdtesting.RunCommand(c, unlockCommand, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.unlock, gc.Equals, "bob")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "User \"bob\" unlocked\n")
+}
+
+func (s *UnlockUserSuite) TestUnlockError(c *gc.C) {
+	s.mock.err = errors.New("boom")
+	unlockCommand, _ := user.NewUnlockCommandForTest(s.mock, s.store)
+	_, err := cmdtesting.RunCommand(c, unlockCommand, "bob")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockUnlockUserAPI struct {
+	unlock string
+	err    error
+}
+
+func (m *mockUnlockUserAPI) Close() error {
+	return nil
+}
+
+func (m *mockUnlockUserAPI) UnlockUser(username string) error {
+	m.unlock = username
+	return m.err
+}
@@ -39,8 +39,10 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -52,9 +54,14 @@ var (
 		"application-scaler",
 		"state-cleaner",
 		"status-history-pruner",
+		"status-snapshotter",
 		"storage-provisioner",
 		"unit-assigner",
+		"reboot-coordinator",
+		"subnet-discovery",
+		"upgrade-stager",
 		"remote-relations",
+		"resource-sweeper",
 		"log-forwarder",
 	}
 	migratingModelWorkers = []string{
@@ -88,6 +95,7 @@ var (
 		"upgrade-check-flag",
 	}
 	notMigratingUnitWorkers = []string{
+		"agent-token-refresher",
 		"api-address-updater",
 		"charm-dir",
 		"hook-retry-strategy",
@@ -118,13 +126,17 @@ var (
 		"upgrader",
 	}
 	notMigratingMachineWorkers = []string{
+		"agent-token-refresher",
 		"api-address-updater",
 		"disk-manager",
+		"engine-reporter",
 		"fan-configurer",
 		// "host-key-reporter", not stable, exits when done
+		"hosts-updater",
 		"log-sender",
 		"logging-config-updater",
 		"machine-action-runner",
+		"machine-health-monitor",
 		"machiner",
 		"proxy-config-updater",
 		"reboot-executor",
@@ -132,6 +144,7 @@ var (
 		"storage-provisioner",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
+		"wireguard-mesh",
 	}
 )
 
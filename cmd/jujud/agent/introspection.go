@@ -31,6 +31,7 @@ type introspectionConfig struct {
 	Engine             *dependency.Engine
 	StatePoolReporter  introspection.IntrospectionReporter
 	PubSubReporter     introspection.IntrospectionReporter
+	LeaseReporter      introspection.LeaseReporter
 	PrometheusGatherer prometheus.Gatherer
 	NewSocketName      func(names.Tag) string
 	WorkerFunc         func(config introspection.Config) (worker.Worker, error)
@@ -54,6 +55,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
+		Leases:             cfg.LeaseReporter,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 	})
 	if err != nil {
@@ -93,3 +95,12 @@ func (h *statePoolHolder) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+func (h *statePoolHolder) LeaseReport() map[string]interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pool == nil {
+		return map[string]interface{}{}
+	}
+	return h.pool.LeaseReport()
+}
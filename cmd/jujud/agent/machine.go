@@ -56,7 +56,6 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
-	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
@@ -81,10 +80,12 @@ import (
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dependency/dependencymetrics"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
 	"github.com/juju/juju/worker/introspection"
+	"github.com/juju/juju/worker/lease/leasemetrics"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
 	"github.com/juju/juju/worker/migrationmaster"
@@ -519,6 +520,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			WorstError:  cmdutil.MoreImportantError,
 			ErrorDelay:  3 * time.Second,
 			BounceDelay: 10 * time.Millisecond,
+			Clock:       clock.WallClock,
 		}
 		engine, err := dependency.NewEngine(config)
 		if err != nil {
@@ -549,12 +551,13 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			StartAPIWorkers:      a.startAPIWorkers,
 			PreUpgradeSteps:      a.preUpgradeSteps,
 			LogSource:            a.bufferedLogger.Logs(),
-			NewDeployContext:     newDeployContext,
+			NewDeployContext:     a.newDeployContext,
 			Clock:                clock.WallClock,
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
 			CentralHub:           a.centralHub,
 			PubSubReporter:       pubsubReporter,
+			EngineReporter:       engine,
 			UpdateLoggerConfig:   updateAgentConfLogging,
 			NewAgentStatusSetter: func(apiConn api.Connection) (upgradesteps.StatusSetter, error) {
 				return a.machine(apiConn)
@@ -569,11 +572,18 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			}
 			return nil, err
 		}
+		// Replace any collector registered for a previous engine.
+		engineCollector := dependencymetrics.EngineMetrics{Reporter: engine}
+		a.prometheusRegistry.Unregister(engineCollector)
+		if err := a.prometheusRegistry.Register(engineCollector); err != nil {
+			logger.Errorf("failed to register dependency engine collector: %v", err)
+		}
 		if err := startIntrospection(introspectionConfig{
 			Agent:              a,
 			Engine:             engine,
 			StatePoolReporter:  a.statePool,
 			PubSubReporter:     pubsubReporter,
+			LeaseReporter:      a.statePool,
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
 			WorkerFunc:         introspection.NewWorker,
@@ -1171,22 +1181,29 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		Filter:      model.IgnoreErrRemoved,
 		ErrorDelay:  3 * time.Second,
 		BounceDelay: 10 * time.Millisecond,
+		Clock:       clock.WallClock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	manifolds := modelManifolds(model.ManifoldsConfig{
-		Agent:                       modelAgent,
-		AgentConfigChanged:          a.configChangedVal,
-		Clock:                       clock.WallClock,
-		RunFlagDuration:             time.Minute,
-		CharmRevisionUpdateInterval: 24 * time.Hour,
-		InstPollerAggregationDelay:  3 * time.Second,
-		StatusHistoryPrunerInterval: 5 * time.Minute,
-		ActionPrunerInterval:        24 * time.Hour,
-		NewEnvironFunc:              newEnvirons,
-		NewMigrationMaster:          migrationmaster.NewWorker,
+		Agent:                         modelAgent,
+		AgentConfigChanged:            a.configChangedVal,
+		Clock:                         clock.WallClock,
+		RunFlagDuration:               time.Minute,
+		CharmRevisionUpdateInterval:   24 * time.Hour,
+		InstPollerAggregationDelay:    3 * time.Second,
+		StatusHistoryPrunerInterval:   5 * time.Minute,
+		ActionPrunerInterval:          24 * time.Hour,
+		StatusSnapshotInterval:        15 * time.Minute,
+		ActionSchedulerPollInterval:   time.Minute,
+		RebootCoordinatorPollInterval: 30 * time.Second,
+		SubnetDiscoveryPollInterval:   10 * time.Minute,
+		UpgradeStagerPollInterval:     30 * time.Second,
+		CredentialCheckInterval:       15 * time.Minute,
+		NewEnvironFunc:                newEnvirons,
+		NewMigrationMaster:            migrationmaster.NewWorker,
 	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
@@ -1227,6 +1244,35 @@ func (a *MachineAgent) apiserverWorkerStarter(
 	}
 }
 
+// controllerWorkers are the names of the machine manifolds that must
+// be running for a controller's API server to be considered healthy.
+var controllerWorkers = []string{
+	"state",
+	"central-hub",
+	"pubsub-forwarder",
+	"global-clock-updater",
+}
+
+// workersHealthy returns a function, used by the API server's /health
+// endpoint, which checks that all of the controllerWorkers have been
+// started by the dependency engine.
+func workersHealthy(reporter dependency.Reporter) func() error {
+	return func() error {
+		manifolds, _ := reporter.Report()[dependency.KeyManifolds].(map[string]interface{})
+		var notRunning []string
+		for _, name := range controllerWorkers {
+			report, _ := manifolds[name].(map[string]interface{})
+			if report[dependency.KeyState] != "started" {
+				notRunning = append(notRunning, name)
+			}
+		}
+		if len(notRunning) > 0 {
+			return errors.Errorf("workers not running: %s", strings.Join(notRunning, ", "))
+		}
+		return nil
+	}
+}
+
 func (a *MachineAgent) newAPIserverWorker(
 	st *state.State,
 	statePool *state.StatePool,
@@ -1270,18 +1316,16 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
-	newObserver, err := newObserverFn(
-		controllerConfig,
-		clock.WallClock,
+	newObserver, err := newObserverFn(clock.WallClock, a.prometheusRegistry)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create RPC observer factory")
+	}
+	newAuditObserver := newAuditObserverFn(
 		jujuversion.Current,
 		agentConfig.Model().Id(),
 		newAuditEntrySink(st, logDir),
 		auditErrorHandler,
-		a.prometheusRegistry,
 	)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot create RPC observer factory")
-	}
 
 	registerIntrospectionHandlers := func(f func(string, http.Handler)) {
 		introspection.RegisterHTTPHandlers(
@@ -1300,6 +1344,11 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "getting log sink config")
 	}
 
+	var metricsGatherer prometheus.Gatherer
+	if controllerConfig.MetricsEndpointEnabled() {
+		metricsGatherer = a.prometheusRegistry
+	}
+
 	server, err := apiserver.NewServer(statePool, listener, apiserver.ServerConfig{
 		Clock:                         clock.WallClock,
 		Cert:                          cert,
@@ -1315,10 +1364,22 @@ func (a *MachineAgent) newAPIserverWorker(
 		AutocertDNSName:               controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
 		NewObserver:                   newObserver,
+		NewAuditObserver:              newAuditObserver,
+		AuditingEnabled:               controllerConfig.AuditingEnabled(),
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
 		RateLimitConfig:               rateLimitConfig,
 		LogSinkConfig:                 &logSinkConfig,
 		PrometheusRegisterer:          a.prometheusRegistry,
+		MetricsGatherer:               metricsGatherer,
+		MetricsAllowedNetworks:        controllerConfig.MetricsEndpointAllowedCIDRs(),
+		HealthEndpointEnabled:         controllerConfig.HealthEndpointEnabled(),
+		HealthAllowedNetworks:         controllerConfig.HealthEndpointAllowedCIDRs(),
+		WorkersHealthy:                workersHealthy(dependencyReporter),
+		AuthorizationPolicy: apiserver.AuthorizationPolicyConfig{
+			WebhookURL: controllerConfig.AuthorizationWebhookURL(),
+			FailOpen:   controllerConfig.AuthorizationWebhookFailOpen(),
+			CacheTTL:   controllerConfig.AuthorizationWebhookCacheTTL(),
+		},
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1448,6 +1509,33 @@ func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
 		}
 		result.ConnUpperThreshold = val
 	}
+	if v := cfg.Value(agent.AgentRequestRateLimit); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.AgentRequestRateLimit,
+			)
+		}
+		result.RequestRateLimit = val
+	}
+	if v := cfg.Value(agent.AgentRequestRateBurst); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.AgentRequestRateBurst,
+			)
+		}
+		result.RequestRateBurst = val
+	}
+	if v := cfg.Value(agent.AgentRequestMaxWait); v != "" {
+		val, err := time.ParseDuration(v)
+		if err != nil {
+			return apiserver.RateLimitConfig{}, errors.Annotatef(
+				err, "parsing %s", agent.AgentRequestMaxWait,
+			)
+		}
+		result.RequestMaxWait = val
+	}
 	return result, nil
 }
 
@@ -1475,13 +1563,28 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 	}
 }
 
-func newObserverFn(
-	controllerConfig controller.Config,
-	clock clock.Clock,
+// newAuditObserverFn returns a factory for the observers which record
+// audit entries. The API server uses it for each connection made while
+// auditing is enabled in the controller config.
+//
+// TODO(katco): Auditing needs feature tests (lp:1604551)
+func newAuditObserverFn(
 	jujuServerVersion version.Number,
 	modelUUID string,
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
+) observer.ObserverFactory {
+	return func() observer.Observer {
+		ctx := &observer.AuditContext{
+			JujuServerVersion: jujuServerVersion,
+			ModelUUID:         modelUUID,
+		}
+		return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
+	}
+}
+
+func newObserverFn(
+	clock clock.Clock,
 	prometheusRegisterer prometheus.Registerer,
 ) (observer.ObserverFactory, error) {
 
@@ -1497,18 +1600,6 @@ func newObserverFn(
 		return observer.NewRequestObserver(ctx)
 	})
 
-	// Auditing observer
-	// TODO(katco): Auditing needs feature tests (lp:1604551)
-	if controllerConfig.AuditingEnabled() {
-		observerFactories = append(observerFactories, func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
-		})
-	}
-
 	// Metrics observer.
 	metricObserver, err := metricobserver.NewObserverFactory(metricobserver.Config{
 		Clock:                clock,
@@ -1776,6 +1867,28 @@ var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) dep
 	return deployer.NewSimpleContext(agentConfig, st)
 }
 
+// newDeployContext returns the deployer.Context used by the machine
+// agent's deployer. If the agent's config records that unit agents run
+// in-process, as set from the model's unit-agents-in-process config,
+// they are run as dependency engines within the machine agent's
+// process; otherwise each unit agent is installed as a service.
+func (a *MachineAgent) newDeployContext(st *apideployer.State, agentConfig agent.Config) deployer.Context {
+	if agentConfig.Value(agent.UnitAgentsInProcess) == "true" {
+		return deployer.NewNestedContext(agentConfig, st, a.startNestedUnitAgent)
+	}
+	return newDeployContext(st, agentConfig)
+}
+
+// startNestedUnitAgent starts the workers of the named unit's agent
+// within the machine agent's process.
+func (a *MachineAgent) startNestedUnitAgent(unitName string) (worker.Worker, error) {
+	unitAgent, err := newNestedUnitAgent(a.CurrentConfig().DataDir(), unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unitAgent.APIWorkers()
+}
+
 func newStateMetricsWorker(statePool *state.StatePool, registry *prometheus.Registry) worker.Worker {
 	return jworker.NewSimpleWorker(func(stop <-chan struct{}) error {
 		collector := statemetrics.New(statemetrics.NewStatePool(statePool))
@@ -1783,6 +1896,11 @@ func newStateMetricsWorker(statePool *state.StatePool, registry *prometheus.Regi
 			return errors.Annotate(err, "registering statemetrics collector")
 		}
 		defer registry.Unregister(collector)
+		leaseCollector := leasemetrics.LeaseMetrics{statePool}
+		if err := registry.Register(leaseCollector); err != nil {
+			return errors.Annotate(err, "registering leasemetrics collector")
+		}
+		defer registry.Unregister(leaseCollector)
 		<-stop
 		return nil
 	})
@@ -29,28 +29,34 @@ import (
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
+	"github.com/juju/juju/worker/agenttokenrefresher"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/authenticationworker"
 	"github.com/juju/juju/worker/centralhub"
+	"github.com/juju/juju/worker/controllermetrics"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/enginereporter"
 	"github.com/juju/juju/worker/externalcontrollerupdater"
 	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
 	"github.com/juju/juju/worker/hostkeyreporter"
+	"github.com/juju/juju/worker/hostsupdater"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
+	"github.com/juju/juju/worker/machinehealth"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
+	"github.com/juju/juju/worker/mongoprofile"
 	"github.com/juju/juju/worker/proxyupdater"
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/reboot"
@@ -64,6 +70,8 @@ import (
 	"github.com/juju/juju/worker/txnpruner"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/webhooks"
+	"github.com/juju/juju/worker/wireguardmesh"
 )
 
 const (
@@ -74,6 +82,12 @@ const (
 	// globalClockUpdaterBackoffDelay is the amount of time to
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
+
+	// webhookRetryDelay is the delay before a failed webhook
+	// request is first retried; it doubles for each further
+	// attempt, up to webhookMaxAttempts.
+	webhookRetryDelay  = 5 * time.Second
+	webhookMaxAttempts = 8
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -165,6 +179,10 @@ type ManifoldsConfig struct {
 	// worker.
 	PubSubReporter psworker.Reporter
 
+	// EngineReporter reports on the agent's dependency engine, so that
+	// its report can be sent to the controller.
+	EngineReporter dependency.Reporter
+
 	// UpdateLoggerConfig is a function that will save the specified
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
@@ -513,6 +531,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The agent token refresher is a leaf worker that obtains fresh
+		// agent tokens before they expire, and records them as the
+		// agent's password. It uninstalls itself on controller machines,
+		// whose passwords are also used to connect to mongo.
+		agentTokenRefresherName: ifNotMigrating(agenttokenrefresher.Manifold(agenttokenrefresher.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewFacade:     agenttokenrefresher.NewFacade,
+			NewWorker:     agenttokenrefresher.New,
+		})),
+
 		fanConfigurerName: ifNotMigrating(fanconfigurer.Manifold(fanconfigurer.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
@@ -599,12 +629,77 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
 
+		// The engine reporter regularly sends the agent's dependency
+		// engine report to the controller, so that it can be
+		// retrieved without access to the machine.
+		engineReporterName: ifNotMigrating(enginereporter.Manifold(enginereporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Reporter:      config.EngineReporter,
+			Clock:         config.Clock,
+			Interval:      enginereporter.DefaultInterval,
+			NewFacade:     enginereporter.NewFacade,
+			NewWorker:     enginereporter.NewWorker,
+		})),
+
+		// The machine health monitor regularly checks the machine's
+		// root disk and clock, reporting problems to the controller
+		// so that they show in the machine's status.
+		machineHealthName: ifNotMigrating(machinehealth.Manifold(machinehealth.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Interval:      machinehealth.DefaultInterval,
+			NewFacade:     machinehealth.NewFacade,
+			NewWorker:     machinehealth.NewWorker,
+		})),
+
+		// The hosts updater keeps a block of entries in the machine's
+		// hosts file, so that its containers and units can be resolved
+		// by name.
+		hostsUpdaterName: ifNotMigrating(hostsupdater.Manifold(hostsupdater.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     hostsupdater.NewFacade,
+			NewWorker:     hostsupdater.NewWorker,
+		})),
+
+		// The WireGuard mesh worker configures a tunnel interface
+		// connecting the machine to the machines of cross-model
+		// related models, when the mesh is enabled in model config.
+		wireGuardMeshName: ifNotMigrating(wireguardmesh.Manifold(wireguardmesh.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Interval:      wireguardmesh.DefaultInterval,
+			NewFacade:     wireguardmesh.NewFacade,
+			NewWorker:     wireguardmesh.NewWorker,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
 				NewExternalControllerWatcherClient: newExternalControllerWatcherClient,
 			},
 		))),
+		controllerMetricsName: ifNotMigrating(ifController(controllermetrics.Manifold(
+			controllermetrics.ManifoldConfig{
+				AgentName:      agentName,
+				ClockName:      clockName,
+				StateName:      stateName,
+				CentralHubName: centralHubName,
+				Interval:       controllermetrics.DefaultInterval,
+				Window:         controllermetrics.DefaultWindow,
+				NewWorker:      controllermetrics.NewWorker,
+			},
+		))),
+		mongoProfileName: ifNotMigrating(ifController(mongoprofile.Manifold(
+			mongoprofile.ManifoldConfig{
+				AgentName: agentName,
+				StateName: stateName,
+				NewWorker: mongoprofile.NewWorker,
+			},
+		))),
 		logPrunerName: ifNotMigrating(ifPrimaryController(dblogpruner.Manifold(
 			dblogpruner.ManifoldConfig{
 				ClockName:     clockName,
@@ -621,6 +716,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker:     txnpruner.New,
 			},
 		))),
+		webhooksName: ifNotMigrating(ifPrimaryController(webhooks.Manifold(
+			webhooks.ManifoldConfig{
+				ClockName:   clockName,
+				StateName:   stateName,
+				RetryDelay:  webhookRetryDelay,
+				MaxAttempts: webhookMaxAttempts,
+				NewWorker:   webhooks.NewWorker,
+			},
+		))),
 	}
 }
 
@@ -690,6 +794,7 @@ const (
 	diskManagerName               = "disk-manager"
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
+	agentTokenRefresherName       = "agent-token-refresher"
 	machinerName                  = "machiner"
 	logSenderName                 = "log-sender"
 	deployerName                  = "unit-agent-deployer"
@@ -700,11 +805,18 @@ const (
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
+	engineReporterName            = "engine-reporter"
+	machineHealthName             = "machine-health-monitor"
 	fanConfigurerName             = "fan-configurer"
+	hostsUpdaterName              = "hosts-updater"
+	wireGuardMeshName             = "wireguard-mesh"
 	externalControllerUpdaterName = "external-controller-updater"
+	controllerMetricsName         = "controller-metrics-collector"
 	globalClockUpdaterName        = "global-clock-updater"
 	isPrimaryControllerFlagName   = "is-primary-controller-flag"
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	webhooksName                  = "webhooks"
+	mongoProfileName              = "mongo-memory-profile"
 )
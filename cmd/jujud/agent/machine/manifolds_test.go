@@ -44,27 +44,33 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 	sort.Strings(keys)
 	expectedKeys := []string{
 		"agent",
+		"agent-token-refresher",
 		"api-address-updater",
 		"api-caller",
 		"api-config-watcher",
 		"central-hub",
 		"clock",
+		"controller-metrics-collector",
 		"disk-manager",
+		"engine-reporter",
 		"external-controller-updater",
 		"fan-configurer",
 		"global-clock-updater",
 		"host-key-reporter",
+		"hosts-updater",
 		"is-controller-flag",
 		"is-primary-controller-flag",
 		"log-pruner",
 		"log-sender",
 		"logging-config-updater",
 		"machine-action-runner",
+		"machine-health-monitor",
 		"machiner",
 		"mgo-txn-resumer",
 		"migration-fortress",
 		"migration-minion",
 		"migration-inactive-flag",
+		"mongo-memory-profile",
 		"proxy-config-updater",
 		"pubsub-forwarder",
 		"reboot-executor",
@@ -86,6 +92,8 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"upgrade-steps-gate",
 		"upgrade-steps-runner",
 		"upgrader",
+		"webhooks",
+		"wireguard-mesh",
 	}
 	c.Assert(keys, jc.SameContents, expectedKeys)
 }
@@ -149,7 +157,10 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		case "is-primary-controller-flag":
 			checkContains(c, manifold.Inputs, "is-controller-flag")
 			checkNotContains(c, manifold.Inputs, "is-primary-controller-flag")
-		case "external-controller-updater", "log-pruner", "transaction-pruner":
+		case "controller-metrics-collector", "mongo-memory-profile":
+			checkContains(c, manifold.Inputs, "is-controller-flag")
+			checkNotContains(c, manifold.Inputs, "is-primary-controller-flag")
+		case "external-controller-updater", "log-pruner", "transaction-pruner", "webhooks":
 			checkNotContains(c, manifold.Inputs, "is-controller-flag")
 			checkContains(c, manifold.Inputs, "is-primary-controller-flag")
 		default:
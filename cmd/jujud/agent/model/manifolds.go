@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
 	"github.com/juju/juju/worker/cleaner"
+	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
@@ -41,12 +43,16 @@ import (
 	"github.com/juju/juju/worker/modelupgrader"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
+	"github.com/juju/juju/worker/rebootcoordinator"
 	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
+	"github.com/juju/juju/worker/statussnapshotter"
 	"github.com/juju/juju/worker/storageprovisioner"
+	"github.com/juju/juju/worker/subnetdiscovery"
 	"github.com/juju/juju/worker/undertaker"
 	"github.com/juju/juju/worker/unitassigner"
+	"github.com/juju/juju/worker/upgradestager"
 )
 
 // ManifoldsConfig holds the dependencies and configuration options for a
@@ -92,6 +98,33 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// StatusSnapshotInterval controls the rate at which snapshots of
+	// the model's status are taken.
+	StatusSnapshotInterval time.Duration
+
+	// ActionSchedulerPollInterval is the longest time the action
+	// scheduler worker waits before checking for due actions.
+	ActionSchedulerPollInterval time.Duration
+
+	// RebootCoordinatorPollInterval is the time the reboot
+	// coordinator worker waits between checks of the reboots
+	// requested by units.
+	RebootCoordinatorPollInterval time.Duration
+
+	// SubnetDiscoveryPollInterval is the time the subnet discovery
+	// worker waits between reconciliations of the model's subnets
+	// with the provider.
+	SubnetDiscoveryPollInterval time.Duration
+
+	// UpgradeStagerPollInterval is the time the upgrade stager
+	// worker waits between checks of a staged upgrade's progress.
+	UpgradeStagerPollInterval time.Duration
+
+	// CredentialCheckInterval is the longest time the credential
+	// validator worker waits between checks of the model's cloud
+	// credential.
+	CredentialCheckInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -316,16 +349,67 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		statusSnapshotterName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			NewWorker:     statussnapshotter.New,
+			NewFacade:     statussnapshotter.NewFacade,
+			PruneInterval: config.StatusSnapshotInterval,
+		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PollInterval:  config.ActionSchedulerPollInterval,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.New,
+		})),
+		rebootCoordinatorName: ifNotMigrating(rebootcoordinator.Manifold(rebootcoordinator.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PollInterval:  config.RebootCoordinatorPollInterval,
+			NewFacade:     rebootcoordinator.NewFacade,
+			NewWorker:     rebootcoordinator.New,
+		})),
+		subnetDiscoveryName: ifNotMigrating(subnetdiscovery.Manifold(subnetdiscovery.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PollInterval:  config.SubnetDiscoveryPollInterval,
+			NewFacade:     subnetdiscovery.NewFacade,
+			NewWorker:     subnetdiscovery.New,
+		})),
+		upgradeStagerName: ifNotMigrating(upgradestager.Manifold(upgradestager.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PollInterval:  config.UpgradeStagerPollInterval,
+			NewFacade:     upgradestager.NewFacade,
+			NewWorker:     upgradestager.New,
+		})),
+		credentialValidatorName: ifNotMigrating(credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{
+			APICallerName:  apiCallerName,
+			ClockName:      clockName,
+			CheckInterval:  config.CredentialCheckInterval,
+			NewEnvironFunc: config.NewEnvironFunc,
+			NewFacade:      credentialvalidator.NewFacade,
+			NewWorker:      credentialvalidator.New,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
 			NewWorker:     machineundertaker.NewWorker,
 		})),
+		resourceSweeperName: ifNotMigrating(machineundertaker.SweeperManifold(machineundertaker.SweeperManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			Interval:      machineundertaker.DefaultSweepInterval,
+			NewSweeper:    machineundertaker.NewSweeper,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 		})),
 	}
@@ -439,7 +523,14 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	statusSnapshotterName    = "status-snapshotter"
+	actionSchedulerName      = "action-scheduler"
+	rebootCoordinatorName    = "reboot-coordinator"
+	subnetDiscoveryName      = "subnet-discovery"
+	upgradeStagerName        = "upgrade-stager"
+	credentialValidatorName  = "credential-validator"
 	machineUndertakerName    = "machine-undertaker"
+	resourceSweeperName      = "resource-sweeper"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 )
@@ -32,6 +32,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -39,6 +40,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -54,12 +56,17 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"reboot-coordinator",
 		"remote-relations",
+		"resource-sweeper",
 		"state-cleaner",
 		"status-history-pruner",
+		"status-snapshotter",
 		"storage-provisioner",
+		"subnet-discovery",
 		"undertaker",
 		"unit-assigner",
+		"upgrade-stager",
 	})
 }
 
@@ -148,6 +155,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -155,6 +163,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-validator",
 		"environ-tracker",
 		"firewaller",
 		"instance-poller",
@@ -170,11 +179,16 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"reboot-coordinator",
 		"remote-relations",
+		"resource-sweeper",
 		"state-cleaner",
 		"status-history-pruner",
+		"status-snapshotter",
 		"storage-provisioner",
+		"subnet-discovery",
 		"undertaker",
 		"unit-assigner",
+		"upgrade-stager",
 	})
 }
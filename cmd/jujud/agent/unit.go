@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/voyeur"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
@@ -75,6 +76,28 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	}, nil
 }
 
+// newNestedUnitAgent returns a UnitAgent for the named unit, whose
+// workers run within a machine agent's process. The unit agent's log
+// records are sent to the controller by the machine agent.
+func newNestedUnitAgent(dataDir, unitName string) (*UnitAgent, error) {
+	prometheusRegistry, err := newPrometheusRegistry()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &UnitAgent{
+		AgentConf:                   NewAgentConf(dataDir),
+		UnitName:                    unitName,
+		configChangedVal:            voyeur.NewValue(true),
+		initialUpgradeCheckComplete: gate.NewLock(),
+		prometheusRegistry:          prometheusRegistry,
+		preUpgradeSteps:             upgrades.PreUpgradeSteps,
+	}
+	if err := a.ReadConfig(a.Tag().String()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
+}
+
 // Info returns usage information for the command.
 func (a *UnitAgent) Info() *cmd.Info {
 	return &cmd.Info{
@@ -166,9 +189,24 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	agentConfig := a.AgentConf.CurrentConfig()
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
 
+	config := dependency.EngineConfig{
+		IsFatal:     cmdutil.IsFatal,
+		WorstError:  cmdutil.MoreImportantError,
+		ErrorDelay:  3 * time.Second,
+		BounceDelay: 10 * time.Millisecond,
+		Clock:       clock.WallClock,
+	}
+	engine, err := dependency.NewEngine(config)
+	if err != nil {
+		return nil, err
+	}
+	var logSource logsender.LogRecordCh
+	if a.bufferedLogger != nil {
+		logSource = a.bufferedLogger.Logs()
+	}
 	manifolds := unitManifolds(unit.ManifoldsConfig{
 		Agent:                agent.APIHostPortsSetter{a},
-		LogSource:            a.bufferedLogger.Logs(),
+		LogSource:            logSource,
 		LeadershipGuarantee:  30 * time.Second,
 		AgentConfigChanged:   a.configChangedVal,
 		ValidateMigration:    a.validateMigration,
@@ -178,18 +216,9 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		PreUpgradeSteps:      a.preUpgradeSteps,
 		UpgradeStepsLock:     a.upgradeComplete,
 		UpgradeCheckLock:     a.initialUpgradeCheckComplete,
+		EngineReporter:       engine,
 	})
 
-	config := dependency.EngineConfig{
-		IsFatal:     cmdutil.IsFatal,
-		WorstError:  cmdutil.MoreImportantError,
-		ErrorDelay:  3 * time.Second,
-		BounceDelay: 10 * time.Millisecond,
-	}
-	engine, err := dependency.NewEngine(config)
-	if err != nil {
-		return nil, err
-	}
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
@@ -22,10 +22,12 @@ import (
 	"github.com/juju/juju/utils/proxy"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
+	"github.com/juju/juju/worker/agenttokenrefresher"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/enginereporter"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/leadership"
@@ -92,6 +94,10 @@ type ManifoldsConfig struct {
 	// worker to ensure that conditions are OK for an upgrade to
 	// proceed.
 	PreUpgradeSteps func(*state.State, coreagent.Config, bool, bool) error
+
+	// EngineReporter reports on the agent's dependency engine, so that
+	// its report can be sent to the controller.
+	EngineReporter dependency.Reporter
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -250,6 +256,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The agent token refresher is a leaf worker that obtains fresh
+		// agent tokens before they expire, and records them as the
+		// agent's password.
+		agentTokenRefresherName: ifNotMigrating(agenttokenrefresher.Manifold(agenttokenrefresher.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         clock.WallClock,
+			NewFacade:     agenttokenrefresher.NewFacade,
+			NewWorker:     agenttokenrefresher.New,
+		})),
+
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
 		// proxy settings.
 		// TODO(fwereade): timing of this is suspicious. There was superstitious
@@ -336,6 +353,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   apiCallerName,
 			MetricSpoolName: metricSpoolName,
 		})),
+
+		// The engine reporter regularly sends the agent's dependency
+		// engine report to the controller.
+		engineReporterName: ifNotMigrating(enginereporter.Manifold(enginereporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Reporter:      config.EngineReporter,
+			Clock:         clock.WallClock,
+			Interval:      enginereporter.DefaultInterval,
+			NewFacade:     enginereporter.NewFacade,
+			NewWorker:     enginereporter.NewWorker,
+		})),
 	}
 }
 
@@ -373,6 +402,8 @@ const (
 	loggingConfigUpdaterName = "logging-config-updater"
 	proxyConfigUpdaterName   = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
+	agentTokenRefresherName  = "agent-token-refresher"
+	engineReporterName       = "engine-reporter"
 
 	charmDirName          = "charm-dir"
 	leadershipTrackerName = "leadership-tracker"
@@ -45,6 +45,8 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"logging-config-updater",
 		"proxy-config-updater",
 		"api-address-updater",
+		"agent-token-refresher",
+		"engine-reporter",
 		"charm-dir",
 		"leadership-tracker",
 		"hook-retry-strategy",
@@ -272,7 +272,7 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 				BootstrapMachineJobs:      jobs,
 				SharedSecret:              sharedSecret,
 				Provider:                  environs.Provider,
-				StorageProviderRegistry:   stateenvirons.NewStorageProviderRegistry(env),
+				StorageProviderRegistry:   stateenvirons.NewStorageProviderRegistry(env, args.ControllerConfig),
 			},
 			dialOpts,
 			stateenvirons.GetNewPolicyFunc(
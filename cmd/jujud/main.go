@@ -213,8 +213,8 @@ func Main(args []string) int {
 	}
 
 	code := 1
-	commandName := filepath.Base(args[0])
-	switch commandName {
+	name := commandName(args[0])
+	switch name {
 	case names.Jujud:
 		code, err = jujuDMain(args, ctx)
 	case names.Jujuc:
@@ -231,7 +231,7 @@ func Main(args []string) int {
 	case names.JujuIntrospect:
 		code = cmd.Main(&introspect.IntrospectCommand{}, ctx, args[1:])
 	default:
-		code, err = jujuCMain(commandName, ctx, args)
+		code, err = jujuCMain(name, ctx, args)
 	}
 	if err != nil {
 		cmd.WriteError(ctx.Stderr, err)
@@ -8,6 +8,7 @@ package main
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/juju/utils/featureflag"
 
@@ -18,6 +19,12 @@ func init() {
 	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
 }
 
+// commandName returns the name of the command with which jujud
+// was invoked, given its first argument.
+func commandName(arg0 string) string {
+	return filepath.Base(arg0)
+}
+
 func main() {
 	MainWrapper(os.Args)
 }
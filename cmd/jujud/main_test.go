@@ -98,6 +98,18 @@ func (s *MainSuite) TestProvidersAreRegistered(c *gc.C) {
 	}
 }
 
+func (s *MainSuite) TestCommandName(c *gc.C) {
+	if runtime.GOOS != "windows" {
+		c.Assert(commandName("/var/lib/juju/tools/unit-foo-0/relation-get"), gc.Equals, "relation-get")
+		return
+	}
+	// Hook tools invoked by PowerShell hooks may be named in
+	// any case, with or without the .exe extension.
+	c.Assert(commandName(`C:\Juju\lib\juju\tools\unit-foo-0\Relation-Get`), gc.Equals, "relation-get"+jujuc.CmdSuffix)
+	c.Assert(commandName("relation-get.exe"), gc.Equals, "relation-get"+jujuc.CmdSuffix)
+	c.Assert(commandName("JUJUD.EXE"), gc.Equals, names.Jujud)
+}
+
 type RemoteCommand struct {
 	cmd.CommandBase
 	msg string
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/utils/featureflag"
 	"golang.org/x/sys/windows/svc"
@@ -31,6 +32,19 @@ func init() {
 	}
 }
 
+// commandName returns the name of the command with which jujud
+// was invoked, given its first argument. Windows file names are
+// case insensitive, and hook tools may be invoked without their
+// .exe extension, so the name is normalised to match the names
+// under which jujud's commands and the hook tools are registered.
+func commandName(arg0 string) string {
+	name := strings.ToLower(filepath.Base(arg0))
+	if filepath.Ext(name) == "" {
+		name += ".exe"
+	}
+	return name
+}
+
 func main() {
 	isInteractive, err := svc.IsAnInteractiveSession()
 	if err != nil {
@@ -38,8 +52,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	commandName := filepath.Base(os.Args[0])
-	if isInteractive || commandName != names.Jujud {
+	if isInteractive || commandName(os.Args[0]) != names.Jujud {
 		os.Exit(Main(os.Args))
 	} else {
 		s := service.SystemService{
@@ -26,7 +26,9 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc"
 )
 
 var errNoNameSpecified = errors.New("no name specified")
@@ -143,6 +145,12 @@ func (c *CommandBase) NewAPIRoot(
 			accountDetails = &jujuclient.AccountDetails{}
 		}
 	}
+	// An API token in the environment takes the place of
+	// the stored account, so that automation need not
+	// share a user's credentials.
+	if token := os.Getenv(osenv.JujuTokenEnvKey); token != "" {
+		accountDetails = &jujuclient.AccountDetails{Token: token}
+	}
 	param, err := c.NewAPIConnectionParams(
 		store, controllerName, modelName, accountDetails,
 	)
@@ -530,18 +538,27 @@ func (g bootstrapConfigGetter) getBootstrapConfigParams(controllerName string) (
 	}
 	return bootstrapConfig, &environs.PrepareConfigParams{
 		environs.CloudSpec{
-			bootstrapConfig.CloudType,
-			bootstrapConfig.Cloud,
-			bootstrapConfig.CloudRegion,
-			bootstrapConfig.CloudEndpoint,
-			bootstrapConfig.CloudIdentityEndpoint,
-			bootstrapConfig.CloudStorageEndpoint,
-			credential,
+			Type:             bootstrapConfig.CloudType,
+			Name:             bootstrapConfig.Cloud,
+			Region:           bootstrapConfig.CloudRegion,
+			Endpoint:         bootstrapConfig.CloudEndpoint,
+			IdentityEndpoint: bootstrapConfig.CloudIdentityEndpoint,
+			StorageEndpoint:  bootstrapConfig.CloudStorageEndpoint,
+			Credential:       credential,
 		},
 		cfg,
 	}, nil
 }
 
+// reportTraceId writes the trace ID of the failed API request that
+// caused err, if any, so that the request can be found in the
+// controller's logs with "juju debug-log --include-trace".
+func reportTraceId(ctx *cmd.Context, err error) {
+	if traceId := rpc.TraceId(err); traceId != "" {
+		ctx.Infof("API request trace ID: %s", traceId)
+	}
+}
+
 // TODO(axw) this is now in three places: change-password,
 // register, and here. Refactor and move to a common location.
 func readPassword(stdin io.Reader) (string, error) {
@@ -334,7 +334,9 @@ func (w *controllerCommandWrapper) Run(ctx *cmd.Context) error {
 	}
 	store = QualifyingClientStore{store}
 	w.SetClientStore(store)
-	return w.ControllerCommand.Run(ctx)
+	err := w.ControllerCommand.Run(ctx)
+	reportTraceId(ctx, err)
+	return err
 }
 
 func translateControllerError(store jujuclient.ClientStore, err error) error {
@@ -371,7 +371,9 @@ func (w *modelCommandWrapper) Run(ctx *cmd.Context) error {
 	}
 	store = QualifyingClientStore{store}
 	w.SetClientStore(store)
-	return w.ModelCommand.Run(ctx)
+	err := w.ModelCommand.Run(ctx)
+	reportTraceId(ctx, err)
+	return err
 }
 
 func (w *modelCommandWrapper) SetFlags(f *gnuflag.FlagSet) {
@@ -44,7 +44,7 @@ options:
    root storage type [provider specific], e.g. ebs
 --storage-size
    root storage size [provider specific]
---stream (= "released")
+--stream (= model's image-stream)
    image stream
 
 `
@@ -94,7 +94,7 @@ func (c *addImageMetadataCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.VirtType, "virt-type", "", "image metadata virtualisation type")
 	f.StringVar(&c.RootStorageType, "storage-type", "", "image metadata root storage type")
 	f.Uint64Var(&c.RootStorageSize, "storage-size", 0, "image metadata root storage size")
-	f.StringVar(&c.Stream, "stream", "released", "image metadata stream")
+	f.StringVar(&c.Stream, "stream", "", "image metadata stream (defaults to the model's image-stream)")
 }
 
 // Run implements Command.Run.
@@ -114,6 +114,15 @@ func (s *addImageSuite) TestAddImageMetadataNoArch(c *gc.C) {
 	s.assertValidAddImageMetadata(c, m)
 }
 
+func (s *addImageSuite) TestAddImageMetadataDefaultStream(c *gc.C) {
+	_, err := runAddImageMetadata(c, "--series", "trusty", "im-33333")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller uses the model's image-stream.
+	c.Assert(s.data, gc.HasLen, 1)
+	c.Assert(s.data[0].Stream, gc.Equals, "")
+}
+
 func (s *addImageSuite) assertValidAddImageMetadata(c *gc.C, m params.CloudImageMetadata) {
 	args := getAddImageMetadataCmdFlags(c, m)
 
@@ -32,6 +32,10 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Spot         = "spot"
+
+	InstanceProfile = "instance-profile"
+	RootDiskSource  = "root-disk-source"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +89,24 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Spot, if not nil or empty, indicates whether a machine should be
+	// started as a spot (preemptible) instance. It holds either "true"
+	// or "false", or the maximum hourly price to pay for the instance.
+	// Only valid for clouds which support spot instances.
+	Spot *string `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// InstanceProfile, if not nil or empty, names the cloud identity
+	// (such as an AWS IAM instance profile) to attach to a machine, so
+	// that workloads on it can use the role's credentials. Only valid
+	// for clouds which support instance profiles.
+	InstanceProfile *string `json:"instance-profile,omitempty" yaml:"instance-profile,omitempty"`
+
+	// RootDiskSource, if not nil or empty, names where a machine's root
+	// disk should come from: "local" for the disk provided with the
+	// instance type, or the name of a kind or type of cloud volume.
+	// Only valid for clouds which support booting from volumes.
+	RootDiskSource *string `json:"root-disk-source,omitempty" yaml:"root-disk-source,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +202,32 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasInstanceProfile returns true if the constraints.Value specifies an
+// instance profile.
+func (v *Value) HasInstanceProfile() bool {
+	return v.InstanceProfile != nil && *v.InstanceProfile != ""
+}
+
+// HasRootDiskSource returns true if the constraints.Value specifies a
+// root disk source.
+func (v *Value) HasRootDiskSource() bool {
+	return v.RootDiskSource != nil && *v.RootDiskSource != ""
+}
+
+// HasSpot returns true if the constraints.Value asks for a spot instance.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot != "" && *v.Spot != "false"
+}
+
+// SpotPrice returns the maximum price to pay for a spot instance, or the
+// empty string if no price cap was specified.
+func (v *Value) SpotPrice() string {
+	if !v.HasSpot() || *v.Spot == "true" {
+		return ""
+	}
+	return *v.Spot
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +271,15 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+*v.Spot)
+	}
+	if v.InstanceProfile != nil {
+		strs = append(strs, "instance-profile="+*v.InstanceProfile)
+	}
+	if v.RootDiskSource != nil {
+		strs = append(strs, "root-disk-source="+*v.RootDiskSource)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,6 +321,15 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Spot != nil {
+		values = append(values, fmt.Sprintf("Spot: %q", *v.Spot))
+	}
+	if v.InstanceProfile != nil {
+		values = append(values, fmt.Sprintf("InstanceProfile: %q", *v.InstanceProfile))
+	}
+	if v.RootDiskSource != nil {
+		values = append(values, fmt.Sprintf("RootDiskSource: %q", *v.RootDiskSource))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +486,12 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Spot:
+		err = v.setSpot(str)
+	case InstanceProfile:
+		err = v.setInstanceProfile(str)
+	case RootDiskSource:
+		err = v.setRootDiskSource(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +555,15 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Spot:
+			err = v.validateSpot(vstr)
+			if err == nil {
+				v.Spot = &vstr
+			}
+		case InstanceProfile:
+			v.InstanceProfile = &vstr
+		case RootDiskSource:
+			v.RootDiskSource = &vstr
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +688,44 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setInstanceProfile(str string) error {
+	if v.InstanceProfile != nil {
+		return errors.Errorf("already set")
+	}
+	v.InstanceProfile = &str
+	return nil
+}
+
+func (v *Value) setRootDiskSource(str string) error {
+	if v.RootDiskSource != nil {
+		return errors.Errorf("already set")
+	}
+	v.RootDiskSource = &str
+	return nil
+}
+
+func (v *Value) setSpot(str string) error {
+	if v.Spot != nil {
+		return errors.Errorf("already set")
+	}
+	if err := v.validateSpot(str); err != nil {
+		return err
+	}
+	v.Spot = &str
+	return nil
+}
+
+func (v *Value) validateSpot(str string) error {
+	switch str {
+	case "", "true", "false":
+		return nil
+	}
+	if price, err := strconv.ParseFloat(str, 64); err != nil || price <= 0 {
+		return errors.Errorf("must be true, false or a positive price")
+	}
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
@@ -313,6 +313,59 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "spot" in detail.
+	{
+		summary: "set spot empty",
+		args:    []string{"spot="},
+	}, {
+		summary: "set spot true",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "set spot false",
+		args:    []string{"spot=false"},
+	}, {
+		summary: "set spot price",
+		args:    []string{"spot=0.05"},
+	}, {
+		summary: "set spot zero price",
+		args:    []string{"spot=0"},
+		err:     `bad "spot" constraint: must be true, false or a positive price`,
+	}, {
+		summary: "set spot garbage",
+		args:    []string{"spot=cheap"},
+		err:     `bad "spot" constraint: must be true, false or a positive price`,
+	}, {
+		summary: "double set spot together",
+		args:    []string{"spot=true spot=true"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// "instance-profile" in detail.
+	{
+		summary: "set instance-profile empty",
+		args:    []string{"instance-profile="},
+	}, {
+		summary: "set instance-profile",
+		args:    []string{"instance-profile=web-role"},
+	}, {
+		summary: "double set instance-profile separately",
+		args:    []string{"instance-profile=web-role", "instance-profile=db-role"},
+		err:     `bad "instance-profile" constraint: already set`,
+	},
+
+	// "root-disk-source" in detail.
+	{
+		summary: "set root-disk-source empty",
+		args:    []string{"root-disk-source="},
+	}, {
+		summary: "set root-disk-source",
+		args:    []string{"root-disk-source=volume"},
+	}, {
+		summary: "double set root-disk-source separately",
+		args:    []string{"root-disk-source=volume", "root-disk-source=local"},
+		err:     `bad "root-disk-source" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -597,6 +650,39 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasInstanceProfile(c *gc.C) {
+	cons := constraints.MustParse("instance-profile=")
+	c.Check(cons.HasInstanceProfile(), jc.IsFalse)
+	cons = constraints.MustParse("instance-profile=web-role")
+	c.Check(cons.HasInstanceProfile(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
+	cons := constraints.MustParse("root-disk-source=")
+	c.Check(cons.HasRootDiskSource(), jc.IsFalse)
+	cons = constraints.MustParse("root-disk-source=volume")
+	c.Check(cons.HasRootDiskSource(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestSpot(c *gc.C) {
+	for i, t := range []struct {
+		constraints string
+		hasSpot     bool
+		price       string
+	}{
+		{"arch=amd64", false, ""},
+		{"spot=", false, ""},
+		{"spot=false", false, ""},
+		{"spot=true", true, ""},
+		{"spot=0.05", true, "0.05"},
+	} {
+		c.Logf("test %d: %s", i, t.constraints)
+		cons := constraints.MustParse(t.constraints)
+		c.Check(cons.HasSpot(), gc.Equals, t.hasSpot)
+		c.Check(cons.SpotPrice(), gc.Equals, t.price)
+	}
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
@@ -124,7 +124,12 @@ func (manager *containerManager) CreateContainer(
 
 	hc = &instance.HardwareCharacteristics{AvailabilityZone: &manager.availabilityZone}
 
-	imageName, err := manager.client.EnsureImageExists(
+	instType := lxdclient.ConstraintsInstanceType(cons)
+	ensureImageExists := manager.client.EnsureImageExists
+	if instType == lxdclient.InstanceTypeVirtualMachine {
+		ensureImageExists = manager.client.EnsureVirtualMachineImageExists
+	}
+	imageName, err := ensureImageExists(
 		series,
 		hostArch,
 		lxdclient.DefaultImageSources,
@@ -175,9 +180,19 @@ func (manager *containerManager) CreateContainer(
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.CharmLXDProfiles) > 0 {
+		if len(profiles) == 0 {
+			// Naming any profile stops LXD applying the default
+			// profile implicitly, so name it too.
+			profiles = append(profiles, lxdDefaultProfileName)
+		}
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.CharmLXDProfiles)
+		profiles = append(profiles, instanceConfig.CharmLXDProfiles...)
+	}
 
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
+		Type:     instType,
 		Image:    imageName,
 		Metadata: metadata,
 		Devices:  nics,
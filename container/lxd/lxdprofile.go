@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+// containerManager implements environs.LXDProfiler.
+var _ environs.LXDProfiler = (*containerManager)(nil)
+
+// MaybeWriteLXDProfile is part of the environs.LXDProfiler interface.
+func (manager *containerManager) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	if err := manager.ensureClient(); err != nil {
+		return errors.Trace(err)
+	}
+	exists, err := manager.client.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return nil
+	}
+	logger.Debugf("creating charm profile %q", name)
+	return errors.Trace(manager.client.CreateProfileWithDevices(name, profile.Config, profile.Devices))
+}
+
+// ReplaceLXDProfiles is part of the environs.LXDProfiler interface.
+func (manager *containerManager) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	if err := manager.ensureClient(); err != nil {
+		return errors.Trace(err)
+	}
+	name := string(id)
+	current, err := manager.client.InstanceProfiles(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	profiles := lxdprofile.ReplaceNames(current, remove, add)
+	logger.Infof("instance %q profiles changing from %v to %v", name, current, profiles)
+	if err := manager.client.SetInstanceProfiles(name, profiles); err != nil {
+		return errors.Trace(err)
+	}
+	for _, profile := range remove {
+		// LXD refuses to delete a profile that is still in use by
+		// another container, which is exactly what we want.
+		if err := manager.client.ProfileDelete(profile); err != nil {
+			logger.Debugf("not deleting profile %q: %v", profile, err)
+		}
+	}
+	return nil
+}
+
+func (manager *containerManager) ensureClient() error {
+	if manager.client != nil {
+		return nil
+	}
+	client, err := ConnectLocal()
+	if err != nil {
+		return errors.Annotate(err, "failed to connect to local LXD")
+	}
+	manager.client = client
+	return nil
+}
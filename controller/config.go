@@ -5,16 +5,21 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/passwordpolicy"
 )
 
 const (
@@ -82,6 +87,86 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// MetricsEndpointEnabled sets whether the API server will serve
+	// Prometheus metrics for the controller at /metrics.
+	MetricsEndpointEnabled = "metrics-endpoint-enabled"
+
+	// MetricsEndpointAllowedCIDRs is a comma-separated list of CIDRs
+	// from which the /metrics endpoint may be accessed. If empty,
+	// requests are accepted from any address.
+	MetricsEndpointAllowedCIDRs = "metrics-endpoint-allowed-cidrs"
+
+	// HealthEndpointEnabled sets whether the API server will serve
+	// unauthenticated liveness and readiness checks at /health, for
+	// use by load balancers.
+	HealthEndpointEnabled = "health-endpoint-enabled"
+
+	// HealthEndpointAllowedCIDRs is a comma-separated list of CIDRs
+	// from which the /health endpoint may be accessed. If empty,
+	// requests are accepted from any address.
+	HealthEndpointAllowedCIDRs = "health-endpoint-allowed-cidrs"
+
+	// AuthorizationWebhookURL is the URL of an external policy
+	// endpoint which is asked to authorize each API call made by
+	// users. If empty, no external policy is consulted.
+	AuthorizationWebhookURL = "authorization-webhook-url"
+
+	// AuthorizationWebhookFailOpen sets whether API calls are allowed
+	// when the authorization webhook cannot be reached or returns an
+	// error. By default such calls are denied.
+	AuthorizationWebhookFailOpen = "authorization-webhook-fail-open"
+
+	// AuthorizationWebhookCacheTTL is the length of time for which
+	// the decisions of the authorization webhook are cached, eg "1m".
+	AuthorizationWebhookCacheTTL = "authorization-webhook-cache-ttl"
+
+	// StoragePlugins is a comma-separated list of external storage
+	// provider plugins, each of the form "<provider-type>=<path>",
+	// where path is the absolute path of the plugin executable on
+	// the controller machines.
+	StoragePlugins = "storage-plugins"
+
+	// PasswordMinLength is the minimum number of characters in the
+	// password of a local user.
+	PasswordMinLength = "password-min-length"
+
+	// PasswordMinCharacterClasses is the minimum number of character
+	// classes (lower case letters, upper case letters, digits and
+	// symbols) in the password of a local user.
+	PasswordMinCharacterClasses = "password-min-character-classes"
+
+	// PasswordMaxAge is how long the password of a local user remains
+	// valid after it is set, eg "2160h". If empty, passwords never
+	// expire.
+	PasswordMaxAge = "password-max-age"
+
+	// LoginLockoutThreshold is the number of consecutive failed
+	// password logins after which a local user is locked out. If
+	// zero, users are never locked out.
+	LoginLockoutThreshold = "login-lockout-threshold"
+
+	// LoginLockoutDuration is how long a local user remains locked
+	// out after too many failed logins, eg "15m". If zero, the user
+	// remains locked out until unlocked by an administrator.
+	LoginLockoutDuration = "login-lockout-duration"
+
+	// APIRequestRateLimit is the number of API requests per second
+	// allowed for each authenticated user or agent, other than
+	// controller agents. If zero, requests are not rate limited. If
+	// unset, the limit in each controller agent's configuration is
+	// used.
+	APIRequestRateLimit = "api-request-rate-limit"
+
+	// APIRequestRateBurst is the number of API requests a user or
+	// agent may make in a burst before being limited to the
+	// APIRequestRateLimit. If zero, the rate limit is used.
+	APIRequestRateBurst = "api-request-rate-burst"
+
+	// AgentTokenLifetime is how long the tokens issued to machine and
+	// unit agents remain valid, eg "24h". Agents obtain a new token
+	// as their current one nears expiry.
+	AgentTokenLifetime = "agent-token-lifetime"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -110,6 +195,30 @@ const (
 
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
+
+	// DefaultMetricsEndpointEnabled contains the default value for the
+	// MetricsEndpointEnabled config value.
+	DefaultMetricsEndpointEnabled = false
+
+	// DefaultHealthEndpointEnabled contains the default value for the
+	// HealthEndpointEnabled config value.
+	DefaultHealthEndpointEnabled = true
+
+	// DefaultAuthorizationWebhookFailOpen contains the default value
+	// for the AuthorizationWebhookFailOpen config value.
+	DefaultAuthorizationWebhookFailOpen = false
+
+	// DefaultAuthorizationWebhookCacheTTL contains the default value
+	// for the AuthorizationWebhookCacheTTL config value.
+	DefaultAuthorizationWebhookCacheTTL = "1m"
+
+	// DefaultLoginLockoutDuration contains the default value for the
+	// LoginLockoutDuration config value.
+	DefaultLoginLockoutDuration = "15m"
+
+	// DefaultAgentTokenLifetime contains the default value for the
+	// AgentTokenLifetime config value.
+	DefaultAgentTokenLifetime = "24h"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -129,8 +238,44 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	MetricsEndpointEnabled,
+	MetricsEndpointAllowedCIDRs,
+	HealthEndpointEnabled,
+	HealthEndpointAllowedCIDRs,
+	AuthorizationWebhookURL,
+	AuthorizationWebhookFailOpen,
+	AuthorizationWebhookCacheTTL,
+	StoragePlugins,
+	PasswordMinLength,
+	PasswordMinCharacterClasses,
+	PasswordMaxAge,
+	LoginLockoutThreshold,
+	LoginLockoutDuration,
+	APIRequestRateLimit,
+	APIRequestRateBurst,
+	AgentTokenLifetime,
 }
 
+// AllowedUpdateConfigAttributes contains the controller config
+// attributes that may be changed after the controller has been
+// bootstrapped. The controller observes changes to these attributes
+// while it is running; changing them does not require the controller
+// agents to be restarted.
+var AllowedUpdateConfigAttributes = set.NewStrings(
+	AuditingEnabled,
+	MongoMemoryProfile,
+	MaxLogsAge,
+	MaxLogsSize,
+	PasswordMinLength,
+	PasswordMinCharacterClasses,
+	PasswordMaxAge,
+	LoginLockoutThreshold,
+	LoginLockoutDuration,
+	APIRequestRateLimit,
+	APIRequestRateBurst,
+	AgentTokenLifetime,
+)
+
 // ControllerOnlyAttribute returns true if the specified attribute name
 // is only relevant for a controller.
 func ControllerOnlyAttribute(attr string) bool {
@@ -182,6 +327,17 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// asInt returns the named attribute as an integer, returning 0
+// if it isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -313,6 +469,171 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// MetricsEndpointEnabled returns whether the API server serves
+// Prometheus metrics at /metrics.
+func (c Config) MetricsEndpointEnabled() bool {
+	if v, ok := c[MetricsEndpointEnabled]; ok {
+		return v.(bool)
+	}
+	return DefaultMetricsEndpointEnabled
+}
+
+// MetricsEndpointAllowedCIDRs returns the networks from which the
+// /metrics endpoint may be accessed. An empty result means that
+// access is not restricted by address.
+func (c Config) MetricsEndpointAllowedCIDRs() []*net.IPNet {
+	// Value has already been validated.
+	nets, _ := parseCIDRs(c.asString(MetricsEndpointAllowedCIDRs))
+	return nets
+}
+
+// HealthEndpointEnabled returns whether the API server serves
+// health checks at /health.
+func (c Config) HealthEndpointEnabled() bool {
+	if v, ok := c[HealthEndpointEnabled]; ok {
+		return v.(bool)
+	}
+	return DefaultHealthEndpointEnabled
+}
+
+// HealthEndpointAllowedCIDRs returns the networks from which the
+// /health endpoint may be accessed. An empty result means that
+// access is not restricted by address.
+func (c Config) HealthEndpointAllowedCIDRs() []*net.IPNet {
+	// Value has already been validated.
+	nets, _ := parseCIDRs(c.asString(HealthEndpointAllowedCIDRs))
+	return nets
+}
+
+// AuthorizationWebhookURL returns the URL of the external policy
+// endpoint used to authorize API calls, or "" if there is none.
+func (c Config) AuthorizationWebhookURL() string {
+	return c.asString(AuthorizationWebhookURL)
+}
+
+// AuthorizationWebhookFailOpen returns whether API calls are allowed
+// when the authorization webhook cannot make a decision.
+func (c Config) AuthorizationWebhookFailOpen() bool {
+	if v, ok := c[AuthorizationWebhookFailOpen]; ok {
+		return v.(bool)
+	}
+	return DefaultAuthorizationWebhookFailOpen
+}
+
+// AuthorizationWebhookCacheTTL returns the length of time for which
+// the authorization webhook's decisions are cached.
+func (c Config) AuthorizationWebhookCacheTTL() time.Duration {
+	value := c.asString(AuthorizationWebhookCacheTTL)
+	if value == "" {
+		value = DefaultAuthorizationWebhookCacheTTL
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(value)
+	return val
+}
+
+// StoragePlugins returns the paths of the configured external storage
+// provider plugin executables, keyed by storage provider type.
+func (c Config) StoragePlugins() map[string]string {
+	// Value has already been validated.
+	plugins, _ := parseStoragePlugins(c.asString(StoragePlugins))
+	return plugins
+}
+
+// PasswordPolicy returns the policy applied to the passwords of local
+// users.
+func (c Config) PasswordPolicy() passwordpolicy.Policy {
+	// Values have already been validated.
+	maxAge, _ := parseOptionalDuration(c.asString(PasswordMaxAge))
+	lockoutDuration := c.asString(LoginLockoutDuration)
+	if lockoutDuration == "" {
+		lockoutDuration = DefaultLoginLockoutDuration
+	}
+	lockout, _ := time.ParseDuration(lockoutDuration)
+	return passwordpolicy.Policy{
+		MinLength:           c.asInt(PasswordMinLength),
+		MinCharacterClasses: c.asInt(PasswordMinCharacterClasses),
+		MaxAge:              maxAge,
+		LockoutThreshold:    c.asInt(LoginLockoutThreshold),
+		LockoutDuration:     lockout,
+	}
+}
+
+// APIRequestRateLimit returns the number of API requests per second
+// allowed for each entity, and whether the limit is set.
+func (c Config) APIRequestRateLimit() (int, bool) {
+	if _, ok := c[APIRequestRateLimit]; !ok {
+		return 0, false
+	}
+	return c.asInt(APIRequestRateLimit), true
+}
+
+// APIRequestRateBurst returns the number of API requests each entity
+// may make in a burst.
+func (c Config) APIRequestRateBurst() int {
+	return c.asInt(APIRequestRateBurst)
+}
+
+// AgentTokenLifetime returns how long the tokens issued to machine
+// and unit agents remain valid.
+func (c Config) AgentTokenLifetime() time.Duration {
+	value := c.asString(AgentTokenLifetime)
+	if value == "" {
+		value = DefaultAgentTokenLifetime
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(value)
+	return val
+}
+
+// parseOptionalDuration parses the given duration, treating an empty
+// value as zero.
+func parseOptionalDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func parseStoragePlugins(value string) (map[string]string, error) {
+	plugins := make(map[string]string)
+	for _, plugin := range strings.Split(value, ",") {
+		plugin = strings.TrimSpace(plugin)
+		if plugin == "" {
+			continue
+		}
+		parts := strings.SplitN(plugin, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("expected \"<provider-type>=<path>\", got %q", plugin)
+		}
+		providerType, executable := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if !path.IsAbs(executable) {
+			return nil, errors.Errorf("plugin path %q is not absolute", executable)
+		}
+		if _, ok := plugins[providerType]; ok {
+			return nil, errors.Errorf("duplicate plugin for provider type %q", providerType)
+		}
+		plugins[providerType] = executable
+	}
+	return plugins, nil
+}
+
+func parseCIDRs(value string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -372,6 +693,78 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[MetricsEndpointAllowedCIDRs].(string); ok {
+		if _, err := parseCIDRs(v); err != nil {
+			return errors.Annotate(err, "invalid metrics endpoint allowed CIDRs in configuration")
+		}
+	}
+
+	if v, ok := c[HealthEndpointAllowedCIDRs].(string); ok {
+		if _, err := parseCIDRs(v); err != nil {
+			return errors.Annotate(err, "invalid health endpoint allowed CIDRs in configuration")
+		}
+	}
+
+	if v, ok := c[AuthorizationWebhookURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid authorization webhook URL in configuration")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid authorization webhook URL in configuration: expected http or https URL, got %q", v)
+		}
+	}
+
+	if v, ok := c[AuthorizationWebhookCacheTTL].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid authorization webhook cache TTL in configuration")
+		}
+		if d < 0 {
+			return errors.Errorf("invalid authorization webhook cache TTL in configuration: negative duration %q", v)
+		}
+	}
+
+	if v, ok := c[StoragePlugins].(string); ok {
+		if _, err := parseStoragePlugins(v); err != nil {
+			return errors.Annotate(err, "invalid storage plugins in configuration")
+		}
+	}
+
+	if v, ok := c[PasswordMaxAge].(string); ok {
+		if _, err := parseOptionalDuration(v); err != nil {
+			return errors.Annotate(err, "invalid password max age in configuration")
+		}
+	}
+
+	if v, ok := c[LoginLockoutDuration].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid login lockout duration in configuration")
+		}
+	}
+
+	if v, ok := c.APIRequestRateLimit(); ok && v < 0 {
+		return errors.Errorf("invalid API request rate limit in configuration: negative value %d", v)
+	}
+
+	if v := c.APIRequestRateBurst(); v < 0 {
+		return errors.Errorf("invalid API request rate burst in configuration: negative value %d", v)
+	}
+
+	if v, ok := c[AgentTokenLifetime].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid agent token lifetime in configuration")
+		}
+		if d <= 0 {
+			return errors.Errorf("invalid agent token lifetime in configuration: non-positive duration %q", v)
+		}
+	}
+
+	if err := c.PasswordPolicy().Validate(); err != nil {
+		return errors.Annotate(err, "invalid password policy in configuration")
+	}
+
 	return nil
 }
 
@@ -382,31 +775,63 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
+	AuditingEnabled:              schema.Bool(),
+	APIPort:                      schema.ForceInt(),
+	StatePort:                    schema.ForceInt(),
+	IdentityURL:                  schema.String(),
+	IdentityPublicKey:            schema.String(),
+	SetNUMAControlPolicyKey:      schema.Bool(),
+	AutocertURLKey:               schema.String(),
+	AutocertDNSNameKey:           schema.String(),
+	AllowModelAccessKey:          schema.Bool(),
+	MongoMemoryProfile:           schema.String(),
+	MaxLogsAge:                   schema.String(),
+	MaxLogsSize:                  schema.String(),
+	MaxTxnLogSize:                schema.String(),
+	MetricsEndpointEnabled:       schema.Bool(),
+	MetricsEndpointAllowedCIDRs:  schema.String(),
+	HealthEndpointEnabled:        schema.Bool(),
+	HealthEndpointAllowedCIDRs:   schema.String(),
+	AuthorizationWebhookURL:      schema.String(),
+	AuthorizationWebhookFailOpen: schema.Bool(),
+	AuthorizationWebhookCacheTTL: schema.String(),
+	StoragePlugins:               schema.String(),
+	PasswordMinLength:            schema.ForceInt(),
+	PasswordMinCharacterClasses:  schema.ForceInt(),
+	PasswordMaxAge:               schema.String(),
+	LoginLockoutThreshold:        schema.ForceInt(),
+	LoginLockoutDuration:         schema.String(),
+	APIRequestRateLimit:          schema.ForceInt(),
+	APIRequestRateBurst:          schema.ForceInt(),
+	AgentTokenLifetime:           schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	APIPort:                      DefaultAPIPort,
+	AuditingEnabled:              DefaultAuditingEnabled,
+	StatePort:                    DefaultStatePort,
+	IdentityURL:                  schema.Omit,
+	IdentityPublicKey:            schema.Omit,
+	SetNUMAControlPolicyKey:      DefaultNUMAControlPolicy,
+	AutocertURLKey:               schema.Omit,
+	AutocertDNSNameKey:           schema.Omit,
+	AllowModelAccessKey:          schema.Omit,
+	MongoMemoryProfile:           schema.Omit,
+	MaxLogsAge:                   fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:                  fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:                fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MetricsEndpointEnabled:       DefaultMetricsEndpointEnabled,
+	MetricsEndpointAllowedCIDRs:  schema.Omit,
+	HealthEndpointEnabled:        DefaultHealthEndpointEnabled,
+	HealthEndpointAllowedCIDRs:   schema.Omit,
+	AuthorizationWebhookURL:      schema.Omit,
+	AuthorizationWebhookFailOpen: DefaultAuthorizationWebhookFailOpen,
+	AuthorizationWebhookCacheTTL: DefaultAuthorizationWebhookCacheTTL,
+	StoragePlugins:               schema.Omit,
+	PasswordMinLength:            schema.Omit,
+	PasswordMinCharacterClasses:  schema.Omit,
+	PasswordMaxAge:               schema.Omit,
+	LoginLockoutThreshold:        schema.Omit,
+	LoginLockoutDuration:         schema.Omit,
+	APIRequestRateLimit:          schema.Omit,
+	APIRequestRateBurst:          schema.Omit,
+	AgentTokenLifetime:           schema.Omit,
 })
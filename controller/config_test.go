@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/passwordpolicy"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +122,104 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid metrics endpoint CIDR",
+	config: controller.Config{
+		controller.MetricsEndpointAllowedCIDRs: "10.0.0.0/8, foo",
+		controller.CACertKey:                   testing.CACert,
+	},
+	expectError: `invalid metrics endpoint allowed CIDRs in configuration: invalid CIDR address: foo`,
+}, {
+	about: "invalid health endpoint CIDR",
+	config: controller.Config{
+		controller.HealthEndpointAllowedCIDRs: "bar, 10.0.0.0/8",
+		controller.CACertKey:                  testing.CACert,
+	},
+	expectError: `invalid health endpoint allowed CIDRs in configuration: invalid CIDR address: bar`,
+}, {
+	about: "invalid authorization webhook URL",
+	config: controller.Config{
+		controller.AuthorizationWebhookURL: "ftp://policy.example.com",
+		controller.CACertKey:               testing.CACert,
+	},
+	expectError: `invalid authorization webhook URL in configuration: expected http or https URL, got "ftp://policy.example.com"`,
+}, {
+	about: "invalid authorization webhook cache TTL",
+	config: controller.Config{
+		controller.AuthorizationWebhookCacheTTL: "soon",
+		controller.CACertKey:                    testing.CACert,
+	},
+	expectError: `invalid authorization webhook cache TTL in configuration: time: invalid duration soon`,
+}, {
+	about: "negative authorization webhook cache TTL",
+	config: controller.Config{
+		controller.AuthorizationWebhookCacheTTL: "-1m",
+		controller.CACertKey:                    testing.CACert,
+	},
+	expectError: `invalid authorization webhook cache TTL in configuration: negative duration "-1m"`,
+}, {
+	about: "invalid password max age",
+	config: controller.Config{
+		controller.PasswordMaxAge: "forever",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid password max age in configuration: time: invalid duration forever`,
+}, {
+	about: "invalid login lockout duration",
+	config: controller.Config{
+		controller.LoginLockoutDuration: "a while",
+		controller.CACertKey:            testing.CACert,
+	},
+	expectError: `invalid login lockout duration in configuration: time: invalid duration a while`,
+}, {
+	about: "invalid password character classes",
+	config: controller.Config{
+		controller.PasswordMinCharacterClasses: 5,
+		controller.CACertKey:                   testing.CACert,
+	},
+	expectError: `invalid password policy in configuration: minimum password character classes 5 \(expected 0-4\) not valid`,
+}, {
+	about: "invalid storage plugin",
+	config: controller.Config{
+		controller.StoragePlugins: "san",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid storage plugins in configuration: expected "<provider-type>=<path>", got "san"`,
+}, {
+	about: "relative storage plugin path",
+	config: controller.Config{
+		controller.StoragePlugins: "san=bin/san",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid storage plugins in configuration: plugin path "bin/san" is not absolute`,
+}, {
+	about: "negative API request rate limit",
+	config: controller.Config{
+		controller.APIRequestRateLimit: -1,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `invalid API request rate limit in configuration: negative value -1`,
+}, {
+	about: "negative API request rate burst",
+	config: controller.Config{
+		controller.APIRequestRateBurst: -5,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `invalid API request rate burst in configuration: negative value -5`,
+}, {
+	about: "invalid agent token lifetime",
+	config: controller.Config{
+		controller.AgentTokenLifetime: "a day",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid agent token lifetime in configuration: time: invalid duration a day`,
+}, {
+	about: "zero agent token lifetime",
+	config: controller.Config{
+		controller.AgentTokenLifetime: "0s",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid agent token lifetime in configuration: non-positive duration "0s"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +272,162 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestMetricsEndpointConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsEndpointEnabled(), jc.IsFalse)
+	c.Assert(cfg.MetricsEndpointAllowedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestMetricsEndpointConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"metrics-endpoint-enabled":       true,
+			"metrics-endpoint-allowed-cidrs": "10.0.0.0/8, 192.168.1.0/24",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MetricsEndpointEnabled(), jc.IsTrue)
+	nets := cfg.MetricsEndpointAllowedCIDRs()
+	c.Assert(nets, gc.HasLen, 2)
+	c.Assert(nets[0].String(), gc.Equals, "10.0.0.0/8")
+	c.Assert(nets[1].String(), gc.Equals, "192.168.1.0/24")
+}
+
+func (s *ConfigSuite) TestHealthEndpointConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.HealthEndpointEnabled(), jc.IsTrue)
+	c.Assert(cfg.HealthEndpointAllowedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestHealthEndpointConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"health-endpoint-enabled":       false,
+			"health-endpoint-allowed-cidrs": "10.0.0.0/8",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.HealthEndpointEnabled(), jc.IsFalse)
+	nets := cfg.HealthEndpointAllowedCIDRs()
+	c.Assert(nets, gc.HasLen, 1)
+	c.Assert(nets[0].String(), gc.Equals, "10.0.0.0/8")
+}
+
+func (s *ConfigSuite) TestAuthorizationWebhookConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuthorizationWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuthorizationWebhookFailOpen(), jc.IsFalse)
+	c.Assert(cfg.AuthorizationWebhookCacheTTL(), gc.Equals, time.Minute)
+}
+
+func (s *ConfigSuite) TestAuthorizationWebhookConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"authorization-webhook-url":       "https://policy.example.com/authorize",
+			"authorization-webhook-fail-open": true,
+			"authorization-webhook-cache-ttl": "30s",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuthorizationWebhookURL(), gc.Equals, "https://policy.example.com/authorize")
+	c.Assert(cfg.AuthorizationWebhookFailOpen(), jc.IsTrue)
+	c.Assert(cfg.AuthorizationWebhookCacheTTL(), gc.Equals, 30*time.Second)
+}
+
+func (s *ConfigSuite) TestStoragePlugins(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StoragePlugins(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"storage-plugins": "san=/usr/lib/san/plugin, nas=/opt/nas",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StoragePlugins(), jc.DeepEquals, map[string]string{
+		"san": "/usr/lib/san/plugin",
+		"nas": "/opt/nas",
+	})
+}
+
+func (s *ConfigSuite) TestPasswordPolicyDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.PasswordPolicy(), jc.DeepEquals, passwordpolicy.Policy{
+		LockoutDuration: 15 * time.Minute,
+	})
+}
+
+func (s *ConfigSuite) TestPasswordPolicyValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"password-min-length":            12,
+			"password-min-character-classes": 3,
+			"password-max-age":               "2160h",
+			"login-lockout-threshold":        5,
+			"login-lockout-duration":         "0s",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.PasswordPolicy(), jc.DeepEquals, passwordpolicy.Policy{
+		MinLength:           12,
+		MinCharacterClasses: 3,
+		MaxAge:              2160 * time.Hour,
+		LockoutThreshold:    5,
+	})
+}
+
+func (s *ConfigSuite) TestAPIRequestRateDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.APIRequestRateLimit()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(cfg.APIRequestRateBurst(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestAgentTokenLifetime(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentTokenLifetime(), gc.Equals, 24*time.Hour)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"agent-token-lifetime": "168h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentTokenLifetime(), gc.Equals, 168*time.Hour)
+}
+
+func (s *ConfigSuite) TestAPIRequestRateValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-request-rate-limit": "20",
+			"api-request-rate-burst": 50,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	limit, ok := cfg.APIRequestRateLimit()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(limit, gc.Equals, 20)
+	c.Assert(cfg.APIRequestRateBurst(), gc.Equals, 50)
+}
@@ -11,6 +11,10 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuIntrospectActionName defines the action name used to query an
+// agent's introspection socket remotely.
+const JujuIntrospectActionName = "juju-introspect"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: charm.ActionSpec{
@@ -32,4 +36,23 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuIntrospectActionName: charm.ActionSpec{
+		Description: "predefined juju-introspect action",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuIntrospectActionName,
+			"description": "predefined juju-introspect action params",
+			"required":    []interface{}{"agent", "path"},
+			"properties": map[string]interface{}{
+				"agent": map[string]interface{}{
+					"type":        "string",
+					"description": "tag of the agent whose introspection socket is queried",
+				},
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "introspection endpoint to query",
+				},
+			},
+		},
+	},
 }
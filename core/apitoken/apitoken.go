@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apitoken defines the capabilities that may be granted to
+// the scoped API tokens with which automation logs in to a controller
+// in place of a user's password, and the textual form of those tokens.
+package apitoken
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// Read allows API calls that only read state, and watchers.
+	Read = "read"
+
+	// RunActions allows API calls to queue, cancel and read actions.
+	// It does not allow running commands or introspecting agents.
+	RunActions = "run-actions"
+
+	// Write allows the API calls allowed by Read and RunActions, and
+	// calls that change models. It does not allow the management of
+	// API tokens, users, SSH keys or access, or changes to controller
+	// config.
+	Write = "write"
+)
+
+// Capabilities holds all capabilities that a token may be granted.
+var Capabilities = []string{Read, RunActions, Write}
+
+// ValidateCapabilities returns an error if the given capabilities are
+// empty or contain an unknown capability.
+func ValidateCapabilities(capabilities []string) error {
+	if len(capabilities) == 0 {
+		return errors.NotValidf("empty capabilities")
+	}
+	for _, capability := range capabilities {
+		if !isCapability(capability) {
+			return errors.NotValidf("capability %q (expected one of %s)",
+				capability, strings.Join(Capabilities, ", "))
+		}
+	}
+	return nil
+}
+
+func isCapability(capability string) bool {
+	for _, c := range Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// separator separates the ID of a token from its secret.
+const separator = "."
+
+// Format returns the textual form of the token with the given ID and
+// secret, as presented by a client when logging in.
+func Format(id, secret string) string {
+	return id + separator + secret
+}
+
+// Parse splits the textual form of a token into its ID and secret.
+func Parse(token string) (id, secret string, err error) {
+	i := strings.Index(token, separator)
+	if i <= 0 || i == len(token)-1 {
+		return "", "", errors.NotValidf("API token")
+	}
+	return token[:i], token[i+1:], nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apitoken_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/apitoken"
+)
+
+type APITokenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APITokenSuite{})
+
+func (s *APITokenSuite) TestValidateCapabilities(c *gc.C) {
+	err := apitoken.ValidateCapabilities([]string{"read", "run-actions"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = apitoken.ValidateCapabilities([]string{"write"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = apitoken.ValidateCapabilities(nil)
+	c.Assert(err, gc.ErrorMatches, "empty capabilities not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = apitoken.ValidateCapabilities([]string{"read", "admin"})
+	c.Assert(err, gc.ErrorMatches, `capability "admin" \(expected one of read, run-actions, write\) not valid`)
+}
+
+func (s *APITokenSuite) TestFormatParse(c *gc.C) {
+	token := apitoken.Format("abc123", "s3cr+t/")
+	c.Assert(token, gc.Equals, "abc123.s3cr+t/")
+	id, secret, err := apitoken.Parse(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "abc123")
+	c.Assert(secret, gc.Equals, "s3cr+t/")
+}
+
+func (s *APITokenSuite) TestParseInvalid(c *gc.C) {
+	for _, token := range []string{"", "abc123", ".secret", "abc123."} {
+		_, _, err := apitoken.Parse(token)
+		c.Check(err, gc.ErrorMatches, "API token not valid", gc.Commentf("token %q", token))
+	}
+}
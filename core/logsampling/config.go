@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logsampling supports the sampling rules that may be included
+// in a model's logging-config, alongside the usual logging levels.
+//
+// A sampling rule has the form
+//
+//	<module>=sample(<count>/<interval>)
+//
+// and limits each agent to at most count identical log messages from
+// the module (and its submodules) in each interval, e.g.
+//
+//	<root>=INFO;unit=DEBUG;unit=sample(10/1m)
+//
+// Rules are enforced by the controller as log messages are received,
+// so that a looping charm cannot flood the controller's log storage.
+package logsampling
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// rootModule is the name by which logging-config refers to the root
+// logging module.
+const rootModule = "<root>"
+
+// samplePrefix introduces a sampling rule in place of a logging
+// level. A colon cannot be used, as loggo treats it as a separator.
+const samplePrefix = "sample("
+
+// Rule limits the number of identical log messages accepted from a
+// logging module and its submodules.
+type Rule struct {
+	// Module is the name of the logging module, or "" for the root
+	// module.
+	Module string
+
+	// Limit is the number of identical messages accepted in each
+	// Interval; any more are dropped.
+	Limit int
+
+	// Interval is the period over which messages are counted.
+	Interval time.Duration
+}
+
+// String returns the rule as it is written in logging-config.
+func (r Rule) String() string {
+	module := r.Module
+	if module == "" {
+		module = rootModule
+	}
+	return fmt.Sprintf("%s=%s%d/%s)", module, samplePrefix, r.Limit, r.Interval)
+}
+
+// Validate returns an error if the rule cannot be enforced.
+func (r Rule) Validate() error {
+	if r.Limit <= 0 {
+		return errors.NotValidf("sampling limit %d for module %q", r.Limit, r.Module)
+	}
+	if r.Interval <= 0 {
+		return errors.NotValidf("sampling interval %v for module %q", r.Interval, r.Module)
+	}
+	return nil
+}
+
+// ParseLoggingConfig splits a logging-config string into the logging
+// levels, in the form accepted by loggo, and the sampling rules it
+// contains. The rules are sorted by module name. An error is returned
+// if either the levels or the rules are not valid.
+func ParseLoggingConfig(config string) (string, []Rule, error) {
+	var levels []string
+	rules := make(map[string]Rule)
+	for _, entry := range strings.FieldsFunc(config, isEntrySeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(strings.TrimSpace(parts[1]), samplePrefix) {
+			levels = append(levels, entry)
+			continue
+		}
+		rule, err := parseRule(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if _, ok := rules[rule.Module]; ok {
+			return "", nil, errors.Errorf("multiple sampling rules for module %q", parts[0])
+		}
+		rules[rule.Module] = rule
+	}
+	levelConfig := strings.Join(levels, ";")
+	if _, err := loggo.ParseConfigString(levelConfig); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return levelConfig, sortedRules(rules), nil
+}
+
+// LevelsOnly returns the logging levels from a logging-config string,
+// without any sampling rules, so that it can be passed to loggo. If
+// the config is not valid it is returned unchanged, for loggo to
+// report the error.
+func LevelsOnly(config string) string {
+	levels, _, err := ParseLoggingConfig(config)
+	if err != nil {
+		return config
+	}
+	return levels
+}
+
+func parseRule(module, value string) (Rule, error) {
+	if module == "" {
+		return Rule{}, errors.Errorf("sampling rule %q has no module", value)
+	}
+	if module == rootModule {
+		module = ""
+	}
+	spec := strings.TrimPrefix(value, samplePrefix)
+	parts := strings.SplitN(strings.TrimSuffix(spec, ")"), "/", 2)
+	if !strings.HasSuffix(spec, ")") || len(parts) != 2 {
+		return Rule{}, errors.Errorf("sampling rule %q is not of the form %s<count>/<interval>)", value, samplePrefix)
+	}
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Rule{}, errors.Errorf("sampling count %q is not a number", parts[0])
+	}
+	interval, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return Rule{}, errors.Errorf("sampling interval %q is not a valid duration", parts[1])
+	}
+	rule := Rule{
+		Module:   module,
+		Limit:    limit,
+		Interval: interval,
+	}
+	if err := rule.Validate(); err != nil {
+		return Rule{}, errors.Trace(err)
+	}
+	return rule, nil
+}
+
+func sortedRules(rules map[string]Rule) []Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+	modules := make([]string, 0, len(rules))
+	for module := range rules {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	result := make([]Rule, len(modules))
+	for i, module := range modules {
+		result[i] = rules[module]
+	}
+	return result
+}
+
+// isEntrySeparator matches the separators loggo accepts between
+// logging-config entries.
+func isEntrySeparator(r rune) bool {
+	return r == ';' || r == ':'
+}
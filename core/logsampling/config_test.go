@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsampling_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logsampling"
+)
+
+type ConfigSuite struct{}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (*ConfigSuite) TestParseLevelsOnly(c *gc.C) {
+	levels, rules, err := logsampling.ParseLoggingConfig("<root>=INFO;unit=DEBUG")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(levels, gc.Equals, "<root>=INFO;unit=DEBUG")
+	c.Check(rules, gc.HasLen, 0)
+}
+
+func (*ConfigSuite) TestParseRules(c *gc.C) {
+	levels, rules, err := logsampling.ParseLoggingConfig(
+		"<root>=INFO; unit=sample(10/1m) ;unit=DEBUG;<root>=sample(100/10s)")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(levels, gc.Equals, "<root>=INFO;unit=DEBUG")
+	c.Check(rules, jc.DeepEquals, []logsampling.Rule{
+		{Module: "", Limit: 100, Interval: 10 * time.Second},
+		{Module: "unit", Limit: 10, Interval: time.Minute},
+	})
+	c.Check(rules[1].String(), gc.Equals, "unit=sample(10/1m0s)")
+	c.Check(rules[0].String(), gc.Equals, "<root>=sample(100/10s)")
+}
+
+func (*ConfigSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		config string
+		err    string
+	}{{
+		config: "unit=sample(10)",
+		err:    `sampling rule "sample\(10\)" is not of the form sample\(<count>/<interval>\)`,
+	}, {
+		config: "unit=sample(10/1m",
+		err:    `sampling rule "sample\(10/1m" is not of the form sample\(<count>/<interval>\)`,
+	}, {
+		config: "unit=sample(ten/1m)",
+		err:    `sampling count "ten" is not a number`,
+	}, {
+		config: "unit=sample(10/often)",
+		err:    `sampling interval "often" is not a valid duration`,
+	}, {
+		config: "unit=sample(0/1m)",
+		err:    `sampling limit 0 for module "unit" not valid`,
+	}, {
+		config: "unit=sample(1/1m);unit=sample(2/1m)",
+		err:    `multiple sampling rules for module "unit"`,
+	}, {
+		config: "unit=sample(1/1m);unit=LOUD",
+		err:    `unknown severity level "LOUD"`,
+	}} {
+		c.Logf("test %d: %q", i, test.config)
+		_, _, err := logsampling.ParseLoggingConfig(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*ConfigSuite) TestLevelsOnly(c *gc.C) {
+	c.Check(logsampling.LevelsOnly("<root>=WARNING;unit=sample(5/1m)"), gc.Equals, "<root>=WARNING")
+	c.Check(logsampling.LevelsOnly("unit=sample(5)"), gc.Equals, "unit=sample(5)")
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsampling
+
+import (
+	"strings"
+	"time"
+)
+
+// maxTracked limits the number of distinct messages a Sampler tracks.
+// When it is reached, messages whose interval has passed are
+// forgotten, and new messages are let through untracked until there
+// is room.
+const maxTracked = 1000
+
+// Sampler applies sampling rules to the log messages from a single
+// source. It is not safe for concurrent use.
+type Sampler struct {
+	rules   []Rule
+	tracked map[sampleKey]*sample
+}
+
+type sampleKey struct {
+	module  string
+	message string
+}
+
+type sample struct {
+	rule       Rule
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// NewSampler returns a Sampler that applies the given rules.
+func NewSampler(rules []Rule) *Sampler {
+	return &Sampler{
+		rules:   rules,
+		tracked: make(map[sampleKey]*sample),
+	}
+}
+
+// Allow reports whether a message from the named module, logged at
+// the given time, should be kept. It also returns the number of
+// identical messages dropped in the interval before this one, so that
+// the caller can record that they were dropped.
+func (s *Sampler) Allow(module, message string, now time.Time) (bool, int) {
+	rule, ok := s.ruleFor(module)
+	if !ok {
+		return true, 0
+	}
+	key := sampleKey{module, message}
+	current, ok := s.tracked[key]
+	if !ok {
+		if len(s.tracked) >= maxTracked {
+			s.forgetExpired(now)
+			if len(s.tracked) >= maxTracked {
+				// Too many distinct messages to track; let
+				// this one through rather than grow without
+				// bound.
+				return true, 0
+			}
+		}
+		s.tracked[key] = &sample{rule: rule, start: now, count: 1}
+		return true, 0
+	}
+	if now.Sub(current.start) >= current.rule.Interval {
+		suppressed := current.suppressed
+		*current = sample{rule: rule, start: now, count: 1}
+		return true, suppressed
+	}
+	if current.count < current.rule.Limit {
+		current.count++
+		return true, 0
+	}
+	current.suppressed++
+	return false, 0
+}
+
+// ruleFor returns the rule for the most specific module that matches
+// the named module.
+func (s *Sampler) ruleFor(module string) (Rule, bool) {
+	var (
+		best  Rule
+		found bool
+	)
+	for _, rule := range s.rules {
+		if !moduleMatches(rule.Module, module) {
+			continue
+		}
+		if !found || len(rule.Module) > len(best.Module) {
+			best, found = rule, true
+		}
+	}
+	return best, found
+}
+
+// forgetExpired stops tracking messages whose interval has passed.
+// Any count of dropped messages for them is lost.
+func (s *Sampler) forgetExpired(now time.Time) {
+	for key, sample := range s.tracked {
+		if now.Sub(sample.start) >= sample.rule.Interval {
+			delete(s.tracked, key)
+		}
+	}
+}
+
+// moduleMatches reports whether the named module is, or is a
+// submodule of, the rule's module.
+func moduleMatches(ruleModule, module string) bool {
+	if ruleModule == "" || ruleModule == module {
+		return true
+	}
+	return strings.HasPrefix(module, ruleModule+".")
+}
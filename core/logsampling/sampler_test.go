@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsampling_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logsampling"
+)
+
+type SamplerSuite struct{}
+
+var _ = gc.Suite(&SamplerSuite{})
+
+var t0 = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (*SamplerSuite) TestNoRules(c *gc.C) {
+	sampler := logsampling.NewSampler(nil)
+	for i := 0; i < 10; i++ {
+		allowed, suppressed := sampler.Allow("unit.foo/0.install", "looping", t0)
+		c.Check(allowed, jc.IsTrue)
+		c.Check(suppressed, gc.Equals, 0)
+	}
+}
+
+func (*SamplerSuite) TestLimitsIdenticalMessages(c *gc.C) {
+	sampler := logsampling.NewSampler([]logsampling.Rule{
+		{Module: "unit", Limit: 2, Interval: time.Minute},
+	})
+	allow := func(module, message string, t time.Time) bool {
+		allowed, _ := sampler.Allow(module, message, t)
+		return allowed
+	}
+	c.Check(allow("unit.foo/0.install", "looping", t0), jc.IsTrue)
+	c.Check(allow("unit.foo/0.install", "looping", t0), jc.IsTrue)
+	c.Check(allow("unit.foo/0.install", "looping", t0), jc.IsFalse)
+	c.Check(allow("unit.foo/0.install", "looping", t0.Add(30*time.Second)), jc.IsFalse)
+
+	// Other messages and modules are counted separately.
+	c.Check(allow("unit.foo/0.install", "different", t0), jc.IsTrue)
+	c.Check(allow("unit.foo/0.start", "looping", t0), jc.IsTrue)
+
+	// Modules without a rule are not limited.
+	for i := 0; i < 3; i++ {
+		c.Check(allow("juju.worker.uniter", "looping", t0), jc.IsTrue)
+	}
+
+	// Once the interval has passed, the message is allowed again,
+	// and the dropped messages are reported.
+	allowed, suppressed := sampler.Allow("unit.foo/0.install", "looping", t0.Add(time.Minute))
+	c.Check(allowed, jc.IsTrue)
+	c.Check(suppressed, gc.Equals, 2)
+}
+
+func (*SamplerSuite) TestMostSpecificRule(c *gc.C) {
+	sampler := logsampling.NewSampler([]logsampling.Rule{
+		{Module: "", Limit: 1, Interval: time.Minute},
+		{Module: "unit", Limit: 3, Interval: time.Minute},
+	})
+	count := func(module string) int {
+		var n int
+		for i := 0; i < 5; i++ {
+			if allowed, _ := sampler.Allow(module, "message", t0); allowed {
+				n++
+			}
+		}
+		return n
+	}
+	c.Check(count("unit.foo/0.install"), gc.Equals, 3)
+	c.Check(count("juju.worker"), gc.Equals, 1)
+	c.Check(count("units"), gc.Equals, 1)
+}
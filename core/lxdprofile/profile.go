@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile holds the LXD profiles that charms may ship in an
+// lxd-profile.yaml file, and the rules they must follow.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, at the root of a charm, that holds
+// the charm's LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// disallowedConfigPrefixes holds the prefixes of config keys that a charm
+// may not set, because they would interfere with the way juju manages the
+// machine or container.
+var disallowedConfigPrefixes = []string{
+	"boot.",
+	"limits.",
+	"migration.",
+}
+
+// disallowedDeviceTypes holds the device types that a charm may not add.
+var disallowedDeviceTypes = []string{
+	"unix-disk",
+}
+
+// Profile is an LXD profile supplied by a charm.
+type Profile struct {
+	Config      map[string]string            `yaml:"config,omitempty"`
+	Description string                       `yaml:"description,omitempty"`
+	Devices     map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Parse reads a profile from the contents of an lxd-profile.yaml file.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := goyaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotate(err, "parsing lxd profile")
+	}
+	return &profile, nil
+}
+
+// ReadFromArchive reads the profile from the charm archive at path. It
+// returns nil if the charm has no profile.
+func ReadFromArchive(path string) (*Profile, error) {
+	zipr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if f.Name != Filename {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return Parse(data)
+	}
+	return nil, nil
+}
+
+// Empty reports whether the profile changes nothing.
+func (p *Profile) Empty() bool {
+	return p == nil || len(p.Config) == 0 && len(p.Devices) == 0
+}
+
+// Validate returns an error if the profile uses any config or devices
+// that charms are not allowed to.
+func (p *Profile) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var problems []string
+	for key := range p.Config {
+		for _, prefix := range disallowedConfigPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				problems = append(problems, fmt.Sprintf("config %q", key))
+			}
+		}
+	}
+	for name, device := range p.Devices {
+		for _, deviceType := range disallowedDeviceTypes {
+			if device["type"] == deviceType {
+				problems = append(problems, fmt.Sprintf("device %q of type %q", name, deviceType))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.NotValidf("lxd profile with %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// Name returns the name of the LXD profile for the given revision of an
+// application's charm in a model.
+func Name(modelName, appName string, revision int) string {
+	return fmt.Sprintf("juju-%s-%s-%d", modelName, appName, revision)
+}
+
+// ReplaceNames returns the current profile names, in order, without those
+// removed, followed by any added names not already present. LXD applies
+// profiles in order, so existing profiles keep their precedence.
+func ReplaceNames(current, remove, add []string) []string {
+	removed := set.NewStrings(remove...)
+	seen := set.NewStrings()
+	var names []string
+	candidates := append(append([]string(nil), current...), add...)
+	for _, name := range candidates {
+		if removed.Contains(name) || seen.Contains(name) {
+			continue
+		}
+		seen.Add(name)
+		names = append(names, name)
+	}
+	return names
+}
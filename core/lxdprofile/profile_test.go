@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+type ProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+const profileYAML = `
+description: sriov networking
+config:
+  security.nesting: "true"
+  linux.kernel_modules: openvswitch,nbd
+devices:
+  tun:
+    path: /dev/net/tun
+    type: unix-char
+`
+
+func (*ProfileSuite) TestParse(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte(profileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Description: "sriov networking",
+		Config: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch,nbd",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {"path": "/dev/net/tun", "type": "unix-char"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+	c.Assert(profile.Validate(), jc.ErrorIsNil)
+}
+
+func (*ProfileSuite) TestParseInvalid(c *gc.C) {
+	_, err := lxdprofile.Parse([]byte("config: [nope"))
+	c.Assert(err, gc.ErrorMatches, "parsing lxd profile: .*")
+}
+
+func (*ProfileSuite) TestEmpty(c *gc.C) {
+	var profile *lxdprofile.Profile
+	c.Assert(profile.Empty(), jc.IsTrue)
+	c.Assert((&lxdprofile.Profile{Description: "nothing"}).Empty(), jc.IsTrue)
+}
+
+func (*ProfileSuite) TestValidateDisallowed(c *gc.C) {
+	profile := &lxdprofile.Profile{
+		Config: map[string]string{
+			"boot.autostart":   "true",
+			"limits.memory":    "1GB",
+			"security.nesting": "true",
+		},
+		Devices: map[string]map[string]string{
+			"root": {"type": "unix-disk", "path": "/"},
+		},
+	}
+	err := profile.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `lxd profile with config "boot.autostart", config "limits.memory", device "root" of type "unix-disk" not valid`)
+}
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "lxd-profile", 3), gc.Equals, "juju-default-lxd-profile-3")
+}
+
+func (*ProfileSuite) TestReplaceNames(c *gc.C) {
+	names := lxdprofile.ReplaceNames(
+		[]string{"default", "juju-model", "juju-model-app-1", "juju-model-other-2"},
+		[]string{"juju-model-app-1"},
+		[]string{"juju-model-app-2", "juju-model-other-2"},
+	)
+	c.Assert(names, jc.DeepEquals, []string{"default", "juju-model", "juju-model-other-2", "juju-model-app-2"})
+}
+
+func (*ProfileSuite) TestReplaceNamesRemoveAll(c *gc.C) {
+	names := lxdprofile.ReplaceNames([]string{"default", "juju-model-app-1"}, []string{"juju-model-app-1"}, nil)
+	c.Assert(names, jc.DeepEquals, []string{"default"})
+}
+
+func (s *ProfileSuite) TestReadFromArchive(c *gc.C) {
+	path := s.writeArchive(c, map[string]string{
+		"metadata.yaml":     "name: foo\n",
+		lxdprofile.Filename: profileYAML,
+	})
+	profile, err := lxdprofile.ReadFromArchive(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Description, gc.Equals, "sriov networking")
+}
+
+func (s *ProfileSuite) TestReadFromArchiveNoProfile(c *gc.C) {
+	path := s.writeArchive(c, map[string]string{
+		"metadata.yaml": "name: foo\n",
+	})
+	profile, err := lxdprofile.ReadFromArchive(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (*ProfileSuite) writeArchive(c *gc.C, files map[string]string) string {
+	path := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	zipw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zipw.Create(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = w.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(zipw.Close(), jc.ErrorIsNil)
+	return path
+}
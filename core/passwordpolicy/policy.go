@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package passwordpolicy defines the rules a controller applies to
+// the passwords of local users: how complex they must be, how long
+// they remain valid, and how many failed logins lock a user out.
+package passwordpolicy
+
+import (
+	"time"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// MaxCharacterClasses is the number of distinct classes of character
+// (lower case letters, upper case letters, digits and everything else)
+// that a password may contain.
+const MaxCharacterClasses = 4
+
+// Policy holds a controller's password policy. The zero Policy
+// imposes no restrictions.
+type Policy struct {
+	// MinLength is the minimum number of characters in a password.
+	MinLength int
+
+	// MinCharacterClasses is the minimum number of character
+	// classes that a password must contain.
+	MinCharacterClasses int
+
+	// MaxAge is how long a password remains valid after it is set.
+	// Zero means that passwords never expire.
+	MaxAge time.Duration
+
+	// LockoutThreshold is the number of consecutive failed logins
+	// after which a user is locked out. Zero disables lockout.
+	LockoutThreshold int
+
+	// LockoutDuration is how long a user remains locked out. Zero
+	// means that the user remains locked out until unlocked by an
+	// administrator.
+	LockoutDuration time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p Policy) Validate() error {
+	if p.MinLength < 0 {
+		return errors.NotValidf("negative minimum password length %d", p.MinLength)
+	}
+	if p.MinCharacterClasses < 0 || p.MinCharacterClasses > MaxCharacterClasses {
+		return errors.NotValidf(
+			"minimum password character classes %d (expected 0-%d)",
+			p.MinCharacterClasses, MaxCharacterClasses,
+		)
+	}
+	if p.MaxAge < 0 {
+		return errors.NotValidf("negative maximum password age %v", p.MaxAge)
+	}
+	if p.LockoutThreshold < 0 {
+		return errors.NotValidf("negative login lockout threshold %d", p.LockoutThreshold)
+	}
+	if p.LockoutDuration < 0 {
+		return errors.NotValidf("negative login lockout duration %v", p.LockoutDuration)
+	}
+	return nil
+}
+
+// CheckPassword returns an error describing why the given password
+// does not satisfy the policy, or nil if it does.
+func (p Policy) CheckPassword(password string) error {
+	if n := len([]rune(password)); n < p.MinLength {
+		return errors.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if n := characterClasses(password); n < p.MinCharacterClasses {
+		return errors.Errorf(
+			"password must contain at least %d of: lower case letters, upper case letters, digits and symbols",
+			p.MinCharacterClasses,
+		)
+	}
+	return nil
+}
+
+// characterClasses returns the number of character classes found in
+// the given password.
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// Expiry returns the time at which a password set at the given time
+// expires, or the zero time if it never does.
+func (p Policy) Expiry(changed time.Time) time.Time {
+	if p.MaxAge == 0 || changed.IsZero() {
+		return time.Time{}
+	}
+	return changed.Add(p.MaxAge)
+}
+
+// Expired reports whether a password set at the given time has
+// expired at the given time.
+func (p Policy) Expired(changed, now time.Time) bool {
+	expiry := p.Expiry(changed)
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// LockedUntil returns the time at which a user locked out at the
+// given time is unlocked, or the zero time if the user remains locked
+// out until unlocked by an administrator.
+func (p Policy) LockedUntil(lockedAt time.Time) time.Time {
+	if p.LockoutDuration == 0 {
+		return time.Time{}
+	}
+	return lockedAt.Add(p.LockoutDuration)
+}
+
+// Locked reports whether a user locked out at the given time is still
+// locked out at the given time. A zero lockedAt means that the user
+// has not been locked out.
+func (p Policy) Locked(lockedAt, now time.Time) bool {
+	if lockedAt.IsZero() || p.LockoutThreshold == 0 {
+		return false
+	}
+	until := p.LockedUntil(lockedAt)
+	return until.IsZero() || now.Before(until)
+}
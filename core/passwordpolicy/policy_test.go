@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package passwordpolicy_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/passwordpolicy"
+)
+
+type PolicySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PolicySuite{})
+
+func (s *PolicySuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		policy passwordpolicy.Policy
+		err    string
+	}{{
+		policy: passwordpolicy.Policy{},
+	}, {
+		policy: passwordpolicy.Policy{
+			MinLength:           12,
+			MinCharacterClasses: 4,
+			MaxAge:              90 * 24 * time.Hour,
+			LockoutThreshold:    5,
+			LockoutDuration:     15 * time.Minute,
+		},
+	}, {
+		policy: passwordpolicy.Policy{MinLength: -1},
+		err:    "negative minimum password length -1 not valid",
+	}, {
+		policy: passwordpolicy.Policy{MinCharacterClasses: 5},
+		err:    `minimum password character classes 5 \(expected 0-4\) not valid`,
+	}, {
+		policy: passwordpolicy.Policy{MaxAge: -time.Hour},
+		err:    "negative maximum password age -1h0m0s not valid",
+	}, {
+		policy: passwordpolicy.Policy{LockoutThreshold: -1},
+		err:    "negative login lockout threshold -1 not valid",
+	}, {
+		policy: passwordpolicy.Policy{LockoutDuration: -time.Minute},
+		err:    "negative login lockout duration -1m0s not valid",
+	}} {
+		c.Logf("test %d: %+v", i, test.policy)
+		err := test.policy.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *PolicySuite) TestCheckPassword(c *gc.C) {
+	policy := passwordpolicy.Policy{MinLength: 8, MinCharacterClasses: 3}
+	for i, test := range []struct {
+		password string
+		err      string
+	}{{
+		password: "Secret-123",
+	}, {
+		password: "Sécret99",
+	}, {
+		password: "Sec-1",
+		err:      "password must be at least 8 characters long",
+	}, {
+		password: "secretsecret",
+		err:      "password must contain at least 3 of: .*",
+	}, {
+		password: "SECRET-SECRET",
+		err:      "password must contain at least 3 of: .*",
+	}} {
+		c.Logf("test %d: %q", i, test.password)
+		err := policy.CheckPassword(test.password)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *PolicySuite) TestNoRestrictions(c *gc.C) {
+	var policy passwordpolicy.Policy
+	now := time.Now()
+	c.Assert(policy.CheckPassword("x"), jc.ErrorIsNil)
+	c.Assert(policy.Expired(now.Add(-10000*time.Hour), now), jc.IsFalse)
+	c.Assert(policy.Expiry(now), gc.Equals, time.Time{})
+	c.Assert(policy.Locked(now, now), jc.IsFalse)
+}
+
+func (s *PolicySuite) TestExpired(c *gc.C) {
+	policy := passwordpolicy.Policy{MaxAge: 24 * time.Hour}
+	changed := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(policy.Expiry(changed), gc.Equals, changed.Add(24*time.Hour))
+	c.Assert(policy.Expired(changed, changed.Add(time.Hour)), jc.IsFalse)
+	c.Assert(policy.Expired(changed, changed.Add(24*time.Hour)), jc.IsTrue)
+	c.Assert(policy.Expired(time.Time{}, changed), jc.IsFalse)
+}
+
+func (s *PolicySuite) TestLocked(c *gc.C) {
+	lockedAt := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := passwordpolicy.Policy{
+		LockoutThreshold: 3,
+		LockoutDuration:  15 * time.Minute,
+	}
+	c.Assert(policy.LockedUntil(lockedAt), gc.Equals, lockedAt.Add(15*time.Minute))
+	c.Assert(policy.Locked(time.Time{}, lockedAt), jc.IsFalse)
+	c.Assert(policy.Locked(lockedAt, lockedAt.Add(time.Minute)), jc.IsTrue)
+	c.Assert(policy.Locked(lockedAt, lockedAt.Add(15*time.Minute)), jc.IsFalse)
+
+	policy.LockoutDuration = 0
+	c.Assert(policy.LockedUntil(lockedAt), gc.Equals, time.Time{})
+	c.Assert(policy.Locked(lockedAt, lockedAt.Add(1000*time.Hour)), jc.IsTrue)
+
+	policy.LockoutThreshold = 0
+	c.Assert(policy.Locked(lockedAt, lockedAt), jc.IsFalse)
+}
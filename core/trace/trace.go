@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package trace carries the correlation IDs that the API server
+// assigns to requests through the code that serves them, and marks
+// the log messages written on their behalf so that they can be found
+// with debug-log --include-trace.
+package trace
+
+import (
+	"context"
+	"strings"
+)
+
+// contextKey is the key under which a context holds its trace ID.
+type contextKey struct{}
+
+// WithID returns a copy of ctx holding the given trace ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ID returns the trace ID held by ctx, or "" if it holds none.
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// marker precedes the trace ID in a marked log message. It is the
+// form taken by the ID in the API server's logs of serialised RPC
+// headers, so those are marked without further effort.
+const marker = `"trace-id":"`
+
+// Marker returns the text with which a log message is marked as
+// written on behalf of the request with the given trace ID.
+func Marker(id string) string {
+	return marker + id + `"`
+}
+
+// IDFromMessage returns the trace ID with which a log message is
+// marked, or "" if it isn't marked.
+func IDFromMessage(message string) string {
+	i := strings.Index(message, marker)
+	if i < 0 {
+		return ""
+	}
+	id := message[i+len(marker):]
+	end := strings.Index(id, `"`)
+	if end < 0 {
+		return ""
+	}
+	return id[:end]
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package trace_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/trace"
+)
+
+type TraceSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TraceSuite{})
+
+func (s *TraceSuite) TestContext(c *gc.C) {
+	ctx := context.Background()
+	c.Assert(trace.ID(ctx), gc.Equals, "")
+	ctx = trace.WithID(ctx, "abcd")
+	c.Assert(trace.ID(ctx), gc.Equals, "abcd")
+}
+
+func (s *TraceSuite) TestIDFromMessage(c *gc.C) {
+	for i, test := range []struct {
+		message string
+		expect  string
+	}{{
+		message: "no trace here",
+	}, {
+		message: `-> [1] user-admin {"request-id":1,"trace-id":"abcd"}`,
+		expect:  "abcd",
+	}, {
+		message: fmt.Sprintf("adding 2 units to wordpress (%s)", trace.Marker("0123")),
+		expect:  "0123",
+	}, {
+		message: `truncated "trace-id":"ab`,
+	}} {
+		c.Logf("test %d: %s", i, test.message)
+		c.Check(trace.IDFromMessage(test.message), gc.Equals, test.expect)
+	}
+}
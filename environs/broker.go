@@ -6,6 +6,7 @@ package environs
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -87,6 +88,11 @@ type StartInstanceParams struct {
 	// changes in status. Its signature is consistent with other
 	// status-related functions to allow them to be used as callbacks.
 	StatusCallback StatusCallbackFunc
+
+	// CharmLXDProfiles holds the LXD profiles, keyed by name, of the
+	// charms whose units will be deployed to the instance. Brokers that
+	// do not implement LXDProfiler ignore them.
+	CharmLXDProfiles map[string]*lxdprofile.Profile
 }
 
 // StartInstanceResult holds the result of an
@@ -149,3 +155,16 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// LXDProfiler is implemented by instance brokers whose instances are LXD
+// containers, so that charm LXD profiles can be applied to them.
+type LXDProfiler interface {
+	// MaybeWriteLXDProfile creates the named LXD profile, unless one
+	// with that name already exists.
+	MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error
+
+	// ReplaceLXDProfiles removes the named profiles from the instance and
+	// applies the added ones, which must already exist. Removed profiles
+	// that are no longer used by any instance are deleted.
+	ReplaceLXDProfiles(id instance.Id, remove, add []string) error
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// Capability names an optional feature of a provider, which models
+// may depend on.
+type Capability string
+
+const (
+	// CapabilitySpaces indicates that the provider supports
+	// network spaces.
+	CapabilitySpaces Capability = "spaces"
+
+	// CapabilityAvailabilityZones indicates that the provider
+	// supports availability zones, and zone placement.
+	CapabilityAvailabilityZones Capability = "availability-zones"
+
+	// CapabilitySpotInstances indicates that the provider can
+	// start spot instances.
+	CapabilitySpotInstances Capability = "spot-instances"
+
+	// CapabilityInstanceFirewall indicates that the provider
+	// can open and close ports for individual instances.
+	CapabilityInstanceFirewall Capability = "instance-firewall"
+
+	// CapabilityStorageResize indicates that the provider can
+	// resize volumes after they have been created.
+	CapabilityStorageResize Capability = "storage-resize"
+
+	// CapabilityUserDataMutation indicates that the provider can
+	// change the user data of an instance after it has started.
+	CapabilityUserDataMutation Capability = "user-data-mutation"
+)
+
+// AllCapabilities holds all of the known capabilities.
+var AllCapabilities = []Capability{
+	CapabilitySpaces,
+	CapabilityAvailabilityZones,
+	CapabilitySpotInstances,
+	CapabilityInstanceFirewall,
+	CapabilityStorageResize,
+	CapabilityUserDataMutation,
+}
+
+// CapabilitiesReporter may be implemented by an Environ to report
+// capabilities that cannot be inferred from the interfaces that it
+// implements.
+type CapabilitiesReporter interface {
+	// Capabilities returns the capabilities of the environ.
+	Capabilities() ([]Capability, error)
+}
+
+// zonedEnviron matches the part of the availability zone interface
+// implemented by environs that support zones.
+type zonedEnviron interface {
+	InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error)
+}
+
+// Capabilities returns the capabilities of the given environ, sorted
+// by name. Spaces, availability zones and spot instances are inferred
+// from the environ's interfaces and constraints validator; any others
+// must be reported by the environ implementing CapabilitiesReporter.
+func Capabilities(env Environ) ([]Capability, error) {
+	found := make(map[Capability]bool)
+	if SupportsSpaces(env) {
+		found[CapabilitySpaces] = true
+	}
+	if _, ok := env.(zonedEnviron); ok {
+		found[CapabilityAvailabilityZones] = true
+	}
+	validator, err := env.ConstraintsValidator()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting constraints validator")
+	}
+	unsupported, err := validator.Validate(constraints.MustParse("spot=true"))
+	if err != nil {
+		return nil, errors.Annotate(err, "validating spot constraint")
+	}
+	if len(unsupported) == 0 {
+		found[CapabilitySpotInstances] = true
+	}
+	if reporter, ok := env.(CapabilitiesReporter); ok {
+		reported, err := reporter.Capabilities()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, capability := range reported {
+			found[capability] = true
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for capability := range found {
+		names = append(names, string(capability))
+	}
+	sort.Strings(names)
+	result := make([]Capability, len(names))
+	for i, name := range names {
+		result[i] = Capability(name)
+	}
+	return result, nil
+}
+
+// HasCapability reports whether the given capabilities include
+// the capability.
+func HasCapability(capabilities []Capability, capability Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
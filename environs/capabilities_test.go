@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+type CapabilitiesSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&CapabilitiesSuite{})
+
+func (s *CapabilitiesSuite) TestNoCapabilities(c *gc.C) {
+	env := &capabilitiesEnv{unsupported: []string{constraints.Spot}}
+	capabilities, err := environs.Capabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, gc.HasLen, 0)
+}
+
+func (s *CapabilitiesSuite) TestInferredCapabilities(c *gc.C) {
+	env := &zonedCapabilitiesEnv{}
+	capabilities, err := environs.Capabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilitySpotInstances,
+	})
+}
+
+func (s *CapabilitiesSuite) TestReportedCapabilities(c *gc.C) {
+	env := &reportingCapabilitiesEnv{
+		reported: []environs.Capability{
+			environs.CapabilityStorageResize,
+			environs.CapabilityInstanceFirewall,
+		},
+	}
+	env.unsupported = []string{constraints.Spot}
+	capabilities, err := environs.Capabilities(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, []environs.Capability{
+		environs.CapabilityInstanceFirewall,
+		environs.CapabilityStorageResize,
+	})
+	c.Assert(environs.HasCapability(capabilities, environs.CapabilityStorageResize), jc.IsTrue)
+	c.Assert(environs.HasCapability(capabilities, environs.CapabilitySpaces), jc.IsFalse)
+}
+
+func (s *CapabilitiesSuite) TestReportedCapabilitiesError(c *gc.C) {
+	env := &reportingCapabilitiesEnv{err: errors.New("boom")}
+	_, err := environs.Capabilities(env)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type capabilitiesEnv struct {
+	environs.Environ
+	unsupported []string
+}
+
+func (e *capabilitiesEnv) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(e.unsupported)
+	return validator, nil
+}
+
+type zonedCapabilitiesEnv struct {
+	capabilitiesEnv
+}
+
+func (e *zonedCapabilitiesEnv) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	return nil, errors.NotImplementedf("InstanceAvailabilityZoneNames")
+}
+
+type reportingCapabilitiesEnv struct {
+	capabilitiesEnv
+	reported []environs.Capability
+	err      error
+}
+
+func (e *reportingCapabilitiesEnv) Capabilities() ([]environs.Capability, error) {
+	return e.reported, e.err
+}
@@ -36,6 +36,11 @@ type CloudSpec struct {
 	// with the cloud, or nil if the cloud does not require any
 	// credentials.
 	Credential *jujucloud.Credential
+
+	// CACertificates contains an optional list of Certificate
+	// Authority certificates to be used to validate certificates
+	// of cloud infrastructure components.
+	CACertificates []string
 }
 
 // Validate validates that the CloudSpec is well-formed. It does
@@ -61,6 +66,7 @@ func MakeCloudSpec(cloud jujucloud.Cloud, cloudRegionName string, credential *ju
 		IdentityEndpoint: cloud.IdentityEndpoint,
 		StorageEndpoint:  cloud.StorageEndpoint,
 		Credential:       credential,
+		CACertificates:   cloud.CACertificates,
 	}
 	if cloudRegionName != "" {
 		cloudRegion, err := jujucloud.RegionByName(cloud.Regions, cloudRegionName)
@@ -22,6 +22,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/logsampling"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -39,6 +40,11 @@ const (
 	// port opened.
 	FwGlobal = "global"
 
+	// FwApplication requests the use of a firewall group per application.
+	// When ports are opened for one unit, all machines hosting units of
+	// the same application will have the same port opened.
+	FwApplication = "application"
+
 	// FwNone requests that no firewalling should be performed inside
 	// the environment. No firewaller worker will be started. It's
 	// useful for clouds without support for either global or per
@@ -46,6 +52,20 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// ResourceSweepOff disables the reconciliation of provider resources
+	// with the model.
+	ResourceSweepOff = "off"
+
+	// ResourceSweepReport reports the provider resources which are
+	// unknown to the model, without removing them.
+	ResourceSweepReport = "report"
+
+	// ResourceSweepRemove removes the provider resources which are
+	// unknown to the model.
+	ResourceSweepRemove = "remove"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -120,6 +140,10 @@ const (
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
 
+	// RequiredResourceTagsKey is an optional space-separated list of
+	// tag names which must be defined by resource-tags.
+	RequiredResourceTagsKey = "required-resource-tags"
+
 	// LogForwardEnabled determines whether the log forward functionality is enabled.
 	LogForwardEnabled = "logforward-enabled"
 
@@ -138,6 +162,21 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdType sets the kind of log forwarding target: "syslog" (the
+	// default) or "loki".
+	LogFwdType = "logforward-type"
+
+	// LogFwdURL sets the push URL of a loki log forwarding target.
+	LogFwdURL = "logforward-url"
+
+	// LogFwdInclude sets a comma-separated list of patterns selecting
+	// the log records to forward, by entity or module.
+	LogFwdInclude = "logforward-include"
+
+	// LogFwdExclude sets a comma-separated list of patterns selecting
+	// log records not to forward, by entity or module.
+	LogFwdExclude = "logforward-exclude"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -154,8 +193,8 @@ const (
 	// to keep when pruning, eg "72h"
 	MaxStatusHistoryAge = "max-status-history-age"
 
-	// MaxStatusHistorySize is the maximum size the status history
-	// collection can grow to before it is pruned, eg "5M"
+	// MaxStatusHistorySize is the maximum size the model's status
+	// history can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
 	// MaxActionResultsAge is the maximum age of actions to keep when pruning, eg
@@ -173,9 +212,57 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
 
+	// FirewallEgressCIDRs are the destination addresses to which machines
+	// in this model may send traffic. If empty, outgoing traffic is not
+	// restricted.
+	FirewallEgressCIDRs = "firewall-egress-cidrs"
+
+	// ResourceSweepKey determines what happens to provider resources
+	// tagged for the model which the model does not know about.
+	ResourceSweepKey = "resource-sweep"
+
+	// BlockUnhealthyMachinesKey, when true, prevents units from being
+	// assigned to machines whose agents report health problems, such
+	// as a full disk.
+	BlockUnhealthyMachinesKey = "block-unhealthy-machines"
+
+	// ModelWideHostsKey, when true, makes machine agents maintain host
+	// entries for every container and unit in the model, rather than
+	// only for those on their own machine.
+	ModelWideHostsKey = "model-wide-hosts"
+
+	// PreferIPv6Key, when true, makes Juju prefer IPv6 addresses over
+	// IPv4 ones when selecting machine addresses and ordering the API
+	// server addresses given to agents, and open the ports of exposed
+	// applications to IPv6 as well as IPv4 traffic.
+	PreferIPv6Key = "prefer-ipv6"
+
+	// WireGuardMeshKey, when true, makes the machines of the model join
+	// a WireGuard mesh with the machines of cross-model related models
+	// hosted by the same controller, so that relations between them do
+	// not need publicly exposed ports.
+	WireGuardMeshKey = "wireguard-mesh"
+
+	// UnitAgentsInProcessKey, when true, makes the agents of machines
+	// provisioned in the model run the agents of the units deployed to
+	// them within their own process, rather than as a service per unit.
+	UnitAgentsInProcessKey = "unit-agents-in-process"
+
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// NumProvisionWorkersKey is the number of machines the provisioner
+	// starts concurrently.
+	NumProvisionWorkersKey = "num-provision-workers"
+
+	// NTPServersKey is a comma-separated list of NTP servers with which
+	// new machines synchronise their clocks.
+	NTPServersKey = "ntp-servers"
+
+	// YumMirrorKey is the yum mirror used by new CentOS machines,
+	// in place of apt-mirror.
+	YumMirrorKey = "yum-mirror"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -339,6 +426,13 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultNumProvisionWorkers is the default value for NumProvisionWorkers.
+	DefaultNumProvisionWorkers = 16
+
+	// MaxNumProvisionWorkers is the largest allowed value for
+	// NumProvisionWorkers.
+	MaxNumProvisionWorkers = 100
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -372,6 +466,7 @@ var defaultConfigValues = map[string]interface{}{
 	"default-series":           series.LatestLts(),
 	ProvisionerHarvestModeKey:  HarvestDestroyed.String(),
 	ResourceTagsKey:            "",
+	RequiredResourceTagsKey:    "",
 	"logging-config":           "",
 	AutomaticallyRetryHooks:    true,
 	"enable-os-refresh-update": true,
@@ -381,7 +476,15 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:   true,
 	UpdateStatusHookInterval:   DefaultUpdateStatusHookInterval,
 	EgressSubnets:              "",
+	FirewallEgressCIDRs:        "",
+	ResourceSweepKey:           ResourceSweepReport,
+	BlockUnhealthyMachinesKey:  false,
+	ModelWideHostsKey:          false,
+	PreferIPv6Key:              false,
+	WireGuardMeshKey:           false,
+	UnitAgentsInProcessKey:     false,
 	FanConfig:                  "",
+	NumProvisionWorkersKey:     DefaultNumProvisionWorkers,
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -402,6 +505,8 @@ var defaultConfigValues = map[string]interface{}{
 	AptFTPProxyKey:   "",
 	AptNoProxyKey:    "",
 	"apt-mirror":     "",
+	YumMirrorKey:     "",
+	NTPServersKey:    "",
 
 	// Status history settings
 	MaxStatusHistoryAge:  DefaultStatusHistoryAge,
@@ -428,7 +533,11 @@ func (c *Config) ensureUnitLogging() error {
 			loggingConfig = loggo.LoggerInfo()
 		}
 	}
-	levels, err := loggo.ParseConfigString(loggingConfig)
+	levelConfig, _, err := logsampling.ParseLoggingConfig(loggingConfig)
+	if err != nil {
+		return err
+	}
+	levels, err := loggo.ParseConfigString(levelConfig)
 	if err != nil {
 		return err
 	}
@@ -506,7 +615,7 @@ func Validate(cfg, old *Config) error {
 
 	// If the logging config is set, make sure it is valid.
 	if v, ok := cfg.defined["logging-config"].(string); ok {
-		if _, err := loggo.ParseConfigString(v); err != nil {
+		if _, _, err := logsampling.ParseLoggingConfig(v); err != nil {
 			return err
 		}
 	}
@@ -522,9 +631,18 @@ func Validate(cfg, old *Config) error {
 	}
 
 	// Ensure the resource tags have the expected k=v format.
-	if _, err := cfg.resourceTags(); err != nil {
+	resourceTags, err := cfg.resourceTags()
+	if err != nil {
 		return errors.Annotate(err, "validating resource tags")
 	}
+	for _, k := range cfg.RequiredResourceTags() {
+		if strings.HasPrefix(k, tags.JujuTagPrefix) {
+			return errors.Errorf("required tag %q uses reserved prefix %q", k, tags.JujuTagPrefix)
+		}
+		if resourceTags[k] == "" {
+			return errors.Errorf("resource-tags must define required tag %q", k)
+		}
+	}
 
 	if v, ok := cfg.defined[MaxStatusHistoryAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
@@ -550,6 +668,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[NumProvisionWorkersKey].(int); ok {
+		if v < 1 || v > MaxNumProvisionWorkers {
+			return errors.Errorf("%s %d must be between 1 and %d", NumProvisionWorkersKey, v, MaxNumProvisionWorkers)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -575,6 +699,14 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[FirewallEgressCIDRs].(string); ok && v != "" {
+		for _, cidr := range strings.Split(v, ",") {
+			if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+				return errors.Annotatef(err, "invalid firewall egress CIDR: %v", cidr)
+			}
+		}
+	}
+
 	if v, ok := cfg.defined[FanConfig].(string); ok && v != "" {
 		_, err := network.ParseFanConfig(v)
 		if err != nil {
@@ -707,6 +839,16 @@ func (c *Config) NetBondReconfigureDelay() int {
 	return value
 }
 
+// NumProvisionWorkers returns the number of machines the provisioner
+// starts concurrently.
+func (c *Config) NumProvisionWorkers() int {
+	value, ok := c.defined[NumProvisionWorkersKey].(int)
+	if !ok {
+		return DefaultNumProvisionWorkers
+	}
+	return value
+}
+
 // ContainerNetworkingMethod returns the method with which
 // containers network should be set up.
 func (c *Config) ContainerNetworkingMethod() string {
@@ -798,6 +940,25 @@ func (c *Config) AptMirror() string {
 	return c.asString("apt-mirror")
 }
 
+// YumMirror returns the yum mirror used by CentOS machines in the
+// model, or "" if apt-mirror is used.
+func (c *Config) YumMirror() string {
+	return c.asString(YumMirrorKey)
+}
+
+// NTPServers returns the NTP servers with which new machines in the
+// model synchronise their clocks. If none are set, machines use the
+// servers configured in their images.
+func (c *Config) NTPServers() []string {
+	var servers []string
+	for _, server := range strings.Split(c.asString(NTPServersKey), ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
 // LogFwdSyslog returns the syslog forwarding config.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
@@ -828,15 +989,47 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 		lfCfg.ClientKey = s.(string)
 	}
 
+	if s, ok := c.defined[LogFwdType]; ok && s != "" {
+		partial = true
+		lfCfg.Type = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdInclude]; ok && s != "" {
+		partial = true
+		lfCfg.Include = splitPatterns(s.(string))
+	}
+
+	if s, ok := c.defined[LogFwdExclude]; ok && s != "" {
+		partial = true
+		lfCfg.Exclude = splitPatterns(s.(string))
+	}
+
 	if !partial {
 		return nil, false
 	}
 	return &lfCfg, true
 }
 
+// splitPatterns splits a comma-separated list of patterns, ignoring
+// surrounding whitespace and empty entries.
+func splitPatterns(s string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
-// (FwInstance, FwGlobal, or FwNone).
+// (FwInstance, FwGlobal, FwApplication, or FwNone).
 func (c *Config) FirewallMode() string {
 	return c.mustString("firewall-mode")
 }
@@ -906,10 +1099,20 @@ func (c *Config) SSLHostnameVerification() bool {
 }
 
 // LoggingConfig returns the configuration string for the loggers.
+// It may include log sampling rules, which must be removed with
+// logsampling.LevelsOnly before the string is passed to loggo.
 func (c *Config) LoggingConfig() string {
 	return c.asString("logging-config")
 }
 
+// LogSamplingRules returns the log sampling rules included in the
+// logging config.
+func (c *Config) LogSamplingRules() []logsampling.Rule {
+	// The logging config is validated when the Config is created.
+	_, rules, _ := logsampling.ParseLoggingConfig(c.LoggingConfig())
+	return rules
+}
+
 // AutomaticallyRetryHooks returns whether we should automatically retry hooks.
 // By default this should be true.
 func (c *Config) AutomaticallyRetryHooks() bool {
@@ -1008,12 +1211,22 @@ func (c *Config) StorageDefaultFilesystemSource() (string, bool) {
 // that Juju creates and manages, if the provider supports them. These
 // tags have no special meaning to Juju, but may be used for existing
 // chargeback accounting schemes or other identification purposes.
+//
+// The model name template variable is expanded in the tag values; the
+// remaining variables are expanded by tags.ResourceTags.
 func (c *Config) ResourceTags() (map[string]string, bool) {
-	tags, err := c.resourceTags()
+	v, err := c.resourceTags()
 	if err != nil {
 		panic(err) // should be prevented by Validate
 	}
-	return tags, tags != nil
+	if v == nil {
+		return nil, false
+	}
+	expanded := make(map[string]string, len(v))
+	for k, value := range v {
+		expanded[k] = strings.Replace(value, tags.TemplateModelName, c.Name(), -1)
+	}
+	return expanded, true
 }
 
 func (c *Config) resourceTags() (map[string]string, error) {
@@ -1025,10 +1238,20 @@ func (c *Config) resourceTags() (map[string]string, error) {
 		if strings.HasPrefix(k, tags.JujuTagPrefix) {
 			return nil, errors.Errorf("tag %q uses reserved prefix %q", k, tags.JujuTagPrefix)
 		}
+		if err := tags.ValidateTemplate(v[k]); err != nil {
+			return nil, errors.Annotatef(err, "tag %q", k)
+		}
 	}
 	return v, nil
 }
 
+// RequiredResourceTags returns the names of the tags which resource-tags
+// must define, so that every provider resource created for the model
+// carries them.
+func (c *Config) RequiredResourceTags() []string {
+	return strings.Fields(c.asString(RequiredResourceTagsKey))
+}
+
 // MaxStatusHistoryAge is the maximum age of status history entries
 // before being pruned.
 func (c *Config) MaxStatusHistoryAge() time.Duration {
@@ -1037,8 +1260,8 @@ func (c *Config) MaxStatusHistoryAge() time.Duration {
 	return val
 }
 
-// MaxStatusHistorySizeMB is the maximum size in MiB which the status history
-// collection can grow to before being pruned.
+// MaxStatusHistorySizeMB is the maximum size in MiB which the model's status
+// history can grow to before being pruned.
 func (c *Config) MaxStatusHistorySizeMB() uint {
 	// Value has already been validated.
 	val, _ := utils.ParseSize(c.mustString(MaxStatusHistorySize))
@@ -1076,11 +1299,67 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
-	raw := c.asString(EgressSubnets)
+	return c.asCIDRs(EgressSubnets)
+}
+
+// FirewallEgressCIDRs are the destination addresses to which machines in
+// this model may send traffic. If empty, outgoing traffic is not restricted.
+func (c *Config) FirewallEgressCIDRs() []string {
+	return c.asCIDRs(FirewallEgressCIDRs)
+}
+
+// ResourceSweep returns what happens to provider resources tagged for
+// the model which the model does not know about: one of
+// ResourceSweepOff, ResourceSweepReport or ResourceSweepRemove.
+func (c *Config) ResourceSweep() string {
+	if mode, ok := c.defined[ResourceSweepKey].(string); ok && mode != "" {
+		return mode
+	}
+	return ResourceSweepReport
+}
+
+// BlockUnhealthyMachines returns whether units may not be assigned to
+// machines whose agents report health problems.
+func (c *Config) BlockUnhealthyMachines() bool {
+	val, _ := c.defined[BlockUnhealthyMachinesKey].(bool)
+	return val
+}
+
+// ModelWideHosts returns whether machine agents maintain host entries
+// for every container and unit in the model.
+func (c *Config) ModelWideHosts() bool {
+	val, _ := c.defined[ModelWideHostsKey].(bool)
+	return val
+}
+
+// PreferIPv6 returns whether IPv6 addresses are preferred over IPv4
+// ones in the model.
+func (c *Config) PreferIPv6() bool {
+	val, _ := c.defined[PreferIPv6Key].(bool)
+	return val
+}
+
+// WireGuardMesh returns whether the model's machines join a WireGuard
+// mesh with the machines of cross-model related models.
+func (c *Config) WireGuardMesh() bool {
+	val, _ := c.defined[WireGuardMeshKey].(bool)
+	return val
+}
+
+// UnitAgentsInProcess returns whether the agents of machines in the
+// model run the agents of their units within their own process.
+func (c *Config) UnitAgentsInProcess() bool {
+	val, _ := c.defined[UnitAgentsInProcessKey].(bool)
+	return val
+}
+
+// asCIDRs returns the comma separated list of CIDRs held in the given
+// attribute, which has already been validated.
+func (c *Config) asCIDRs(name string) []string {
+	raw := c.asString(name)
 	if raw == "" {
 		return []string{}
 	}
-	// Value has already been validated.
 	rawAddr := strings.Split(raw, ",")
 	result := make([]string, len(rawAddr))
 	for i, addr := range rawAddr {
@@ -1165,6 +1444,10 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdType:             schema.Omit,
+	LogFwdURL:              schema.Omit,
+	LogFwdInclude:          schema.Omit,
+	LogFwdExclude:          schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1183,8 +1466,11 @@ var alwaysOptional = schema.Defaults{
 	AptFTPProxyKey:               schema.Omit,
 	AptNoProxyKey:                schema.Omit,
 	"apt-mirror":                 schema.Omit,
+	YumMirrorKey:                 schema.Omit,
+	NTPServersKey:                schema.Omit,
 	AgentStreamKey:               schema.Omit,
 	ResourceTagsKey:              schema.Omit,
+	RequiredResourceTagsKey:      schema.Omit,
 	"cloudimg-base-url":          schema.Omit,
 	"enable-os-refresh-update":   schema.Omit,
 	"enable-os-upgrade":          schema.Omit,
@@ -1208,7 +1494,15 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
+	FirewallEgressCIDRs:          schema.Omit,
+	ResourceSweepKey:             schema.Omit,
+	BlockUnhealthyMachinesKey:    schema.Omit,
+	ModelWideHostsKey:            schema.Omit,
+	PreferIPv6Key:                schema.Omit,
+	WireGuardMeshKey:             schema.Omit,
+	UnitAgentsInProcessKey:       schema.Omit,
 	FanConfig:                    schema.Omit,
+	NumProvisionWorkersKey:       schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1402,6 +1696,16 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	YumMirrorKey: {
+		Description: "The yum mirror for CentOS machines in the model; if unset, apt-mirror is used",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	NTPServersKey: {
+		Description: "Comma-separated NTP servers with which new machines synchronise their clocks, in place of those configured in their images",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	AuthorizedKeysKey: {
 		Description: "Any authorized SSH public keys for the model, as found in a ~/.ssh/authorized_keys file",
 		Type:        environschema.Tstring,
@@ -1451,11 +1755,15 @@ var configSchema = environschema.Fields{
 for a network port is enabled to one instance if any instance requires
 that port).
 
+'application' uses a firewall per application, shared by all instances
+hosting units of that application. It keeps the number of firewall
+rules down in large models, on clouds which support it.
+
 'none' requests that no firewalling should be performed
 inside the model. It's useful for clouds without support for either
 global or per instance security groups.`,
 		Type:      environschema.Tstring,
-		Values:    []interface{}{FwInstance, FwGlobal, FwNone},
+		Values:    []interface{}{FwInstance, FwGlobal, FwApplication, FwNone},
 		Immutable: true,
 		Group:     environschema.EnvironGroup,
 	},
@@ -1485,7 +1793,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	"logging-config": {
-		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
+		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details). Entries of the form <module>=sample(<count>/<interval>) limit each agent to count identical messages from the module in each interval`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1515,10 +1823,15 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	ResourceTagsKey: {
-		Description: "resource tags",
+		Description: `Tags set on the provider resources, such as instances, volumes, security groups and networks, that Juju creates for the model. Tag values may use the template variables {model-name}, {model-uuid} and {controller-uuid}`,
 		Type:        environschema.Tattrs,
 		Group:       environschema.EnvironGroup,
 	},
+	RequiredResourceTagsKey: {
+		Description: "Space-separated names of tags which resource-tags must define, such as those required by cost-allocation policies",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	LogForwardEnabled: {
 		Description: `Whether syslog forwarding is enabled.`,
 		Type:        environschema.Tbool,
@@ -1544,6 +1857,27 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdType: {
+		Description: `The kind of log forwarding target, "syslog" (the default) or "loki".`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"syslog", "loki"},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdURL: {
+		Description: `The push URL of a loki log forwarding target. The syslog CA certificate, client certificate and key are used for TLS, if set.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdInclude: {
+		Description: `A comma-separated list of glob patterns matching the entities (e.g. unit-mysql-*) or modules (e.g. juju.worker.*) whose logs are forwarded. If empty, all logs are forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExclude: {
+		Description: `A comma-separated list of glob patterns matching the entities or modules whose logs are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1605,7 +1939,7 @@ data of the store. (default false)`,
 		Group:       environschema.EnvironGroup,
 	},
 	MaxStatusHistorySize: {
-		Description: "The maximum size for the status history collection, in human-readable memory format",
+		Description: "The maximum size of the model's status history, in human-readable memory format",
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1619,6 +1953,11 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	NumProvisionWorkersKey: {
+		Description: "The number of machines the provisioner starts concurrently (default 16, range 1-100)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
@@ -1629,6 +1968,48 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	FirewallEgressCIDRs: {
+		Description: "Destination address(es) to which machines in this model may send traffic; if empty, outgoing traffic is not restricted",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ResourceSweepKey: {
+		Description: `What to do with provider resources, such as instances, volumes and security groups, which are tagged for the model but unknown to it:
+
+'off' leaves them alone.
+
+'report' logs them, without removing them.
+
+'remove' removes them once they have been found in two consecutive sweeps.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{ResourceSweepOff, ResourceSweepReport, ResourceSweepRemove},
+		Group:  environschema.EnvironGroup,
+	},
+	BlockUnhealthyMachinesKey: {
+		Description: "Determines whether units may not be assigned to machines whose agents report health problems, such as a full disk or a skewed clock",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	ModelWideHostsKey: {
+		Description: "Determines whether machines can resolve the host names of every container and unit in the model, rather than only those on the machine itself",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	PreferIPv6Key: {
+		Description: "Whether IPv6 addresses are preferred over IPv4 ones for machines and API servers, and exposed applications are opened to IPv6 traffic",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	WireGuardMeshKey: {
+		Description: "Whether machines join a WireGuard mesh with the machines of cross-model related models on the same controller",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	UnitAgentsInProcessKey: {
+		Description: "Whether machine agents run the agents of their units within their own process, rather than as a service per unit; this is fixed for each machine when its first unit is deployed",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	FanConfig: {
 		Description: "Configuration for fan networking for this model",
 		Type:        environschema.Tstring,
@@ -21,8 +21,10 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/logsampling"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -369,6 +371,13 @@ var configTests = []configTest{
 			"logging-config": "foo=bar",
 		}),
 		err: `unknown severity level "bar"`,
+	}, {
+		about:       "Invalid log sampling rule",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logging-config": "<root>=INFO;unit=sample(often)",
+		}),
+		err: `sampling rule "sample\(often\)" is not of the form sample\(<count>/<interval>\)`,
 	}, {
 		about:       "Sample configuration",
 		useDefaults: config.UseDefaults,
@@ -526,6 +535,26 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			config.NetBondReconfigureDelayKey: 1234,
 		}),
+	}, {
+		about:       "num-provision-workers value",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.NumProvisionWorkersKey: 4,
+		}),
+	}, {
+		about:       "num-provision-workers too small",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.NumProvisionWorkersKey: 0,
+		}),
+		err: `num-provision-workers 0 must be between 1 and 100`,
+	}, {
+		about:       "num-provision-workers too large",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.NumProvisionWorkersKey: 101,
+		}),
+		err: `num-provision-workers 101 must be between 1 and 100`,
 	}, {
 		about:       "transmit-vendor-metrics asserted with default value",
 		useDefaults: config.UseDefaults,
@@ -730,6 +759,12 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	if val, ok := test.attrs[config.NetBondReconfigureDelayKey].(int); ok {
 		c.Assert(cfg.NetBondReconfigureDelay(), gc.Equals, val)
 	}
+
+	if val, ok := test.attrs[config.NumProvisionWorkersKey].(int); ok {
+		c.Assert(cfg.NumProvisionWorkers(), gc.Equals, val)
+	} else {
+		c.Assert(cfg.NumProvisionWorkers(), gc.Equals, config.DefaultNumProvisionWorkers)
+	}
 }
 
 func (s *ConfigSuite) TestConfigAttrs(c *gc.C) {
@@ -965,6 +1000,16 @@ func (s *ConfigSuite) TestLoggingConfigWithUnit(c *gc.C) {
 	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=WARNING;unit=INFO")
 }
 
+func (s *ConfigSuite) TestLoggingConfigWithSampling(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"logging-config": "<root>=WARNING;unit=sample(10/1m)"})
+	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=WARNING;unit=sample(10/1m);unit=DEBUG")
+	c.Assert(config.LogSamplingRules(), jc.DeepEquals, []logsampling.Rule{
+		{Module: "unit", Limit: 10, Interval: time.Minute},
+	})
+}
+
 func (s *ConfigSuite) TestLoggingConfigFromEnvironment(c *gc.C) {
 	s.addJujuFiles(c)
 	s.PatchEnvironment(osenv.JujuLoggingConfigEnvKey, "<root>=INFO")
@@ -1131,6 +1176,138 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestFirewallEgressCIDRs(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.FirewallEgressCIDRs(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"firewall-egress-cidrs": "10.0.0.0/8, 0.0.0.0/0",
+	})
+	c.Assert(cfg.FirewallEgressCIDRs(), gc.DeepEquals, []string{"10.0.0.0/8", "0.0.0.0/0"})
+}
+
+func (s *ConfigSuite) TestFirewallEgressCIDRsInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"firewall-egress-cidrs": "10.0.0.0/8,bad",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid firewall egress CIDR: bad: invalid CIDR address: bad`)
+}
+
+func (s *ConfigSuite) TestNTPServers(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.NTPServers(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"ntp-servers": "ntp1.example.com, 10.0.0.1,",
+	})
+	c.Assert(cfg.NTPServers(), jc.DeepEquals, []string{"ntp1.example.com", "10.0.0.1"})
+}
+
+func (s *ConfigSuite) TestYumMirror(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.YumMirror(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"yum-mirror": "http://mirror.example.com/centos",
+	})
+	c.Assert(cfg.YumMirror(), gc.Equals, "http://mirror.example.com/centos")
+}
+
+func (s *ConfigSuite) TestResourceSweep(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ResourceSweep(), gc.Equals, config.ResourceSweepReport)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"resource-sweep": config.ResourceSweepRemove,
+	})
+	c.Assert(cfg.ResourceSweep(), gc.Equals, config.ResourceSweepRemove)
+}
+
+func (s *ConfigSuite) TestResourceSweepInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"resource-sweep": "sometimes",
+	}))
+	c.Assert(err, gc.ErrorMatches, `resource-sweep: expected one of \[off report remove\], got "sometimes"`)
+}
+
+func (s *ConfigSuite) TestBlockUnhealthyMachines(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.BlockUnhealthyMachines(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"block-unhealthy-machines": true,
+	})
+	c.Assert(cfg.BlockUnhealthyMachines(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestLogFwdLoki(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"logforward-type":    "loki",
+		"logforward-url":     "https://loki.example.com/loki/api/v1/push",
+		"logforward-include": "unit-mysql-*, machine-0",
+		"logforward-exclude": "juju.worker.uniter.*,",
+	})
+	lfCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg, jc.DeepEquals, &syslog.RawConfig{
+		Enabled: true,
+		Type:    "loki",
+		URL:     "https://loki.example.com/loki/api/v1/push",
+		Include: []string{"unit-mysql-*", "machine-0"},
+		Exclude: []string{"juju.worker.uniter.*"},
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdLokiInvalidURL(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"logforward-enabled": true,
+		"logforward-type":    "loki",
+		"logforward-url":     "loki.example.com",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid syslog forwarding config: URL "loki.example.com" not valid`)
+}
+
+func (s *ConfigSuite) TestModelWideHosts(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ModelWideHosts(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"model-wide-hosts": true,
+	})
+	c.Assert(cfg.ModelWideHosts(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestPreferIPv6(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferIPv6(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"prefer-ipv6": true,
+	})
+	c.Assert(cfg.PreferIPv6(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestWireGuardMesh(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.WireGuardMesh(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"wireguard-mesh": true,
+	})
+	c.Assert(cfg.WireGuardMesh(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestUnitAgentsInProcess(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.UnitAgentsInProcess(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"unit-agents-in-process": true,
+	})
+	c.Assert(cfg.UnitAgentsInProcess(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
@@ -1174,6 +1351,62 @@ func (s *ConfigSuite) TestSchemaWithExtraOverlap(c *gc.C) {
 	c.Assert(schema, gc.IsNil)
 }
 
+func (s *ConfigSuite) TestResourceTagsTemplate(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"resource-tags": "owner={model-name} cost-centre=eng-{controller-uuid}",
+	})
+	tags, ok := cfg.ResourceTags()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(tags, gc.DeepEquals, map[string]string{
+		"owner":       cfg.Name(),
+		"cost-centre": "eng-{controller-uuid}",
+	})
+
+	// The template is stored, rather than the expanded tags.
+	tagsStr := config.CoerceForStorage(cfg.AllAttrs())["resource-tags"].(string)
+	c.Assert(tagsStr, jc.Contains, "owner={model-name}")
+}
+
+func (s *ConfigSuite) TestResourceTagsTemplateInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"resource-tags": "owner={owner}",
+	}))
+	c.Assert(err, gc.ErrorMatches, `validating resource tags: tag "owner": template variable "\{owner\}" not valid`)
+}
+
+func (s *ConfigSuite) TestRequiredResourceTags(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.RequiredResourceTags(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"resource-tags":          "owner=bob cost-centre=eng",
+		"required-resource-tags": "owner cost-centre",
+	})
+	c.Assert(cfg.RequiredResourceTags(), jc.DeepEquals, []string{"owner", "cost-centre"})
+}
+
+func (s *ConfigSuite) TestRequiredResourceTagsMissing(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"resource-tags":          "owner=bob",
+		"required-resource-tags": "owner cost-centre",
+	}))
+	c.Assert(err, gc.ErrorMatches, `resource-tags must define required tag "cost-centre"`)
+
+	_, err = config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"required-resource-tags": "juju-owner",
+	}))
+	c.Assert(err, gc.ErrorMatches, `required tag "juju-owner" uses reserved prefix "juju-"`)
+}
+
+func (s *ConfigSuite) TestRequiredResourceTagsUpdate(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"resource-tags":          "owner=bob",
+		"required-resource-tags": "owner",
+	})
+	_, err := cfg.Apply(map[string]interface{}{"resource-tags": "team=eng"})
+	c.Assert(err, gc.ErrorMatches, `resource-tags must define required tag "owner"`)
+}
+
 func (s *ConfigSuite) TestCoerceForStorage(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"resource-tags": "a=b c=d"})
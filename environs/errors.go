@@ -41,3 +41,49 @@ func IsAvailabilityZoneIndependent(err error) bool {
 	}
 	return false
 }
+
+// ErrorClass identifies the kind of failure reported by a provider
+// when starting an instance, so that the provisioner can decide how
+// to retry it.
+type ErrorClass string
+
+const (
+	// ErrorClassUnknown is the class of errors that have not been
+	// classified by the provider.
+	ErrorClassUnknown ErrorClass = ""
+
+	// ErrorClassQuota is the class of errors caused by exceeding a
+	// limit on the resources the account may use.
+	ErrorClassQuota ErrorClass = "quota"
+
+	// ErrorClassRateLimit is the class of errors caused by making
+	// too many requests to the provider's API.
+	ErrorClassRateLimit ErrorClass = "rate-limit"
+
+	// ErrorClassCapacity is the class of errors caused by the cloud
+	// not having enough capacity to satisfy the request.
+	ErrorClassCapacity ErrorClass = "capacity"
+
+	// ErrorClassCredential is the class of errors caused by the
+	// cloud credential being invalid or lacking permissions.
+	ErrorClassCredential ErrorClass = "credential"
+)
+
+// ClassifiedError provides an interface for compute providers to
+// indicate the class of a failure.
+type ClassifiedError interface {
+	error
+
+	// ErrorClass returns the class of the error.
+	ErrorClass() ErrorClass
+}
+
+// ErrorClassOf returns the class of the given error, or its cause.
+// If the error does not implement ClassifiedError, ErrorClassUnknown
+// is returned.
+func ErrorClassOf(err error) ErrorClass {
+	if err, ok := errors.Cause(err).(ClassifiedError); ok {
+		return err.ErrorClass()
+	}
+	return ErrorClassUnknown
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// DefaultTypesCacheTTL is the time for which the instance types
+// offered by a cloud are cached by DefaultTypesCache.
+const DefaultTypesCacheTTL = 24 * time.Hour
+
+// DefaultTypesCache is the instance type cache shared by the providers
+// running in a controller process.
+var DefaultTypesCache = NewTypesCache(clock.WallClock, DefaultTypesCacheTTL)
+
+// TypesCache caches the instance types offered by clouds, so that
+// providers can query them from the cloud's API, rather than using
+// hard-coded lists, without querying the cloud for every instance
+// started. Entries are keyed by a string chosen by the provider, which
+// should identify the cloud region and any account that the instance
+// types depend on.
+type TypesCache struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]typesCacheEntry
+}
+
+type typesCacheEntry struct {
+	instanceTypes []InstanceType
+	expires       time.Time
+}
+
+// NewTypesCache returns a new TypesCache whose entries expire
+// after the given duration.
+func NewTypesCache(clock clock.Clock, ttl time.Duration) *TypesCache {
+	return &TypesCache{
+		clock:   clock,
+		ttl:     ttl,
+		entries: make(map[string]typesCacheEntry),
+	}
+}
+
+// Get returns the instance types cached with the given key. If there
+// are none, or they have expired, fetch is called to get them from the
+// cloud. If fetch fails and expired instance types are cached, they
+// are returned rather than the error.
+//
+// The cache is not locked while fetch is called, so concurrent
+// callers may fetch the same instance types.
+func (c *TypesCache) Get(key string, fetch func() ([]InstanceType, error)) ([]InstanceType, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.clock.Now().Before(entry.expires) {
+		return entry.instanceTypes, nil
+	}
+
+	instanceTypes, err := fetch()
+	if err != nil {
+		if ok {
+			logger.Warningf("cannot refresh instance types for %s, using cached types: %v", key, err)
+			return entry.instanceTypes, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = typesCacheEntry{
+		instanceTypes: instanceTypes,
+		expires:       c.clock.Now().Add(c.ttl),
+	}
+	return instanceTypes, nil
+}
+
+// Refresh discards all cached instance types, so that they are fetched
+// from the clouds when next used. It returns the number of entries
+// discarded.
+func (c *TypesCache) Refresh() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.entries)
+	c.entries = make(map[string]typesCacheEntry)
+	return n
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instances_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/testing"
+)
+
+type typesCacheSuite struct {
+	testing.BaseSuite
+	clock *jujutesting.Clock
+	cache *instances.TypesCache
+	calls int
+	types []instances.InstanceType
+	err   error
+}
+
+var _ = gc.Suite(&typesCacheSuite{})
+
+func (s *typesCacheSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+	s.cache = instances.NewTypesCache(s.clock, time.Hour)
+	s.calls = 0
+	s.types = []instances.InstanceType{{Name: "n1-standard-1"}}
+	s.err = nil
+}
+
+func (s *typesCacheSuite) fetch() ([]instances.InstanceType, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.types, nil
+}
+
+func (s *typesCacheSuite) TestGetCaches(c *gc.C) {
+	for i := 0; i < 2; i++ {
+		types, err := s.cache.Get("gce/project/us-east1", s.fetch)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(types, jc.DeepEquals, s.types)
+	}
+	c.Assert(s.calls, gc.Equals, 1)
+
+	_, err := s.cache.Get("gce/project/europe-west1", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *typesCacheSuite) TestGetExpires(c *gc.C) {
+	_, err := s.cache.Get("key", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Hour)
+	s.types = []instances.InstanceType{{Name: "n2-standard-2"}}
+
+	types, err := s.cache.Get("key", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, s.types)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *typesCacheSuite) TestGetError(c *gc.C) {
+	s.err = errors.New("boom")
+	_, err := s.cache.Get("key", s.fetch)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	// Errors are not cached.
+	s.err = nil
+	_, err = s.cache.Get("key", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *typesCacheSuite) TestGetErrorUsesExpiredTypes(c *gc.C) {
+	_, err := s.cache.Get("key", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(2 * time.Hour)
+	s.err = errors.New("boom")
+
+	types, err := s.cache.Get("key", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, s.types)
+	c.Assert(s.calls, gc.Equals, 2)
+}
+
+func (s *typesCacheSuite) TestRefresh(c *gc.C) {
+	_, err := s.cache.Get("a", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.cache.Get("b", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.cache.Refresh(), gc.Equals, 2)
+	_, err = s.cache.Get("a", s.fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, gc.Equals, 3)
+}
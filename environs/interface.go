@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
 )
 
 // A EnvironProvider represents a computing and storage provider.
@@ -378,6 +379,13 @@ type PrecheckInstanceParams struct {
 	// expect the attachment's Machine field to be set, as PrecheckInstance
 	// may be called before a machine ID is allocated.
 	VolumeAttachments []storage.VolumeAttachmentParams
+
+	// SubnetsToZones is an optional map of provider-specific subnet
+	// id to a list of availability zone names the subnet is available
+	// in. As with StartInstanceParams, it is only populated when valid
+	// positive spaces constraints are present, and contains the subnets
+	// of the first positive space.
+	SubnetsToZones map[network.Id][]string
 }
 
 // CreateParams contains the parameters for Environ.Create.
@@ -408,6 +416,102 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// ApplicationFirewaller exposes methods for managing network ports per
+// application. It is used when the environment was set up with the
+// FwApplication firewall mode.
+type ApplicationFirewaller interface {
+	// OpenApplicationPorts opens the given port ranges for all
+	// instances hosting units of the named application.
+	OpenApplicationPorts(applicationName string, rules []network.IngressRule) error
+
+	// CloseApplicationPorts closes the given port ranges for all
+	// instances hosting units of the named application.
+	CloseApplicationPorts(applicationName string, rules []network.IngressRule) error
+
+	// ApplicationIngressRules returns the ingress rules applied to
+	// instances hosting units of the named application.
+	ApplicationIngressRules(applicationName string) ([]network.IngressRule, error)
+
+	// AddApplicationInstance ensures that the ports opened for the
+	// named application apply to the instance with the given ID. It
+	// is used for units placed on a machine after it was started.
+	AddApplicationInstance(applicationName string, id instance.Id) error
+}
+
+// EgressFirewaller exposes methods for restricting the destinations to
+// which machines in the model may send traffic. It may be used in any
+// firewall mode other than FwNone.
+type EgressFirewaller interface {
+	// OpenEgress allows outgoing traffic matching the given rules
+	// from all machines in the model. Once any egress rule has been
+	// opened, outgoing traffic that matches no rule is denied.
+	OpenEgress(rules []network.EgressRule) error
+
+	// CloseEgress stops allowing outgoing traffic matching the given
+	// rules. Once all egress rules have been closed, outgoing traffic
+	// is no longer restricted.
+	CloseEgress(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole model.
+	// An empty result means outgoing traffic is not restricted.
+	// It is expected that there be only one egress rule result for a
+	// given port range - the rule's DestinationCIDRs will contain all
+	// applicable destination address rules for that port range.
+	EgressRules() ([]network.EgressRule, error)
+}
+
+// InstanceChangeWatcher is implemented by environs which can be told
+// by the cloud when the state of their instances changes, so changes
+// to instance status and addresses can be noticed without frequent
+// polling.
+type InstanceChangeWatcher interface {
+	// WatchInstanceChanges returns a watcher which notifies of the ids
+	// of the model's instances whose status or addresses may have
+	// changed since the watcher was started. No initial event is sent.
+	WatchInstanceChanges() (watcher.StringsWatcher, error)
+}
+
+// Kinds of provider resources reported by ResourceSweeper.
+const (
+	ResourceKindInstance      = "instance"
+	ResourceKindVolume        = "volume"
+	ResourceKindSecurityGroup = "security-group"
+)
+
+// ModelResource identifies a provider resource created for a model.
+type ModelResource struct {
+	// Kind is the kind of the resource, such as ResourceKindVolume.
+	Kind string
+
+	// Id is the provider's id for the resource.
+	Id string
+}
+
+// KnownResources holds the provider resources which the model's
+// state accounts for.
+type KnownResources struct {
+	// Instances holds the ids of the model's machines' instances.
+	Instances []instance.Id
+
+	// Volumes holds the provider ids of the model's volumes.
+	Volumes []string
+}
+
+// ResourceSweeper is implemented by environs which can find the
+// resources, other than instances, which are tagged for the model but
+// not accounted for by the model's state, such as those left behind by
+// crashed provisioning attempts.
+type ResourceSweeper interface {
+	// UnknownResources returns the resources tagged for the model
+	// which are neither known to the model nor in use by its known
+	// instances.
+	UnknownResources(known KnownResources) ([]ModelResource, error)
+
+	// RemoveResources removes the given resources, which were
+	// returned by UnknownResources.
+	RemoveResources(resources []ModelResource) error
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -423,6 +527,16 @@ type InstanceTypesFetcher interface {
 	InstanceTypes(constraints.Value) (instances.InstanceTypesWithCostMetadata, error)
 }
 
+// ImageChecker is an interface that can be implemented by Environs
+// that can report whether images exist in the model's cloud region.
+// It is used to validate custom image metadata, which may refer to
+// images that were never uploaded or have since been deleted.
+type ImageChecker interface {
+	// MissingImages returns the IDs of the given images that do
+	// not exist in the model's cloud region.
+	MissingImages(imageIds []string) ([]string, error)
+}
+
 // Upgrader is an interface that can be used for upgrading Environs. If an
 // Environ implements this interface, its UpgradeOperations method will be
 // invoked to identify operations that should be run on upgrade.
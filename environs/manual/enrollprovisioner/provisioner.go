@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enrollprovisioner provisions machines that cannot be reached
+// from the client or controller. Instead of connecting to the machine,
+// it records the machine in state and produces an enrollment script,
+// which is run on the machine to install the machine agent. The agent
+// then connects to the controller and registers itself, using the
+// nonce and credentials contained in the script.
+package enrollprovisioner
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+var logger = loggo.GetLogger("juju.environs.manual.enrollprovisioner")
+
+// ProvisionMachine records a machine in state without connecting to
+// it, and writes an enrollment script for the machine to args.Stdout.
+// The machine ID is returned.
+//
+// The series of the machine cannot be detected, so it must be given
+// in args.Series. The script contains the machine agent's credentials,
+// and may only be used to enroll the one machine.
+func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err error) {
+	defer func() {
+		if machineId != "" && err != nil {
+			logger.Errorf("enrollment failed, removing machine %v: %v", machineId, err)
+			if cleanupErr := args.Client.ForceDestroyMachines(machineId); cleanupErr != nil {
+				logger.Errorf("error cleaning up machine: %s", cleanupErr)
+			}
+			machineId = ""
+		}
+	}()
+
+	if args.Series == "" {
+		return "", errors.NotValidf("enrolling machine without series")
+	}
+	machineParams, err := gatherMachineParams(args.Host, args.Series)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Inform Juju that the machine exists. The machine remains
+	// pending until its agent connects to the controller.
+	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	disablePackageCommands := true
+	if args.UpdateBehavior != nil {
+		disablePackageCommands = !args.EnableOSRefreshUpdate && !args.EnableOSUpgrade
+	}
+	provisioningScript, err := args.Client.ProvisioningScript(params.ProvisioningScriptParams{
+		MachineId:              machineId,
+		Nonce:                  machineParams.Nonce,
+		DisablePackageCommands: disablePackageCommands,
+	})
+	if err != nil {
+		return machineId, errors.Annotate(err, "cannot obtain provisioning script")
+	}
+
+	script, err := EnrollmentScript(machineId, provisioningScript)
+	if err != nil {
+		return machineId, errors.Trace(err)
+	}
+	if _, err := fmt.Fprint(args.Stdout, script); err != nil {
+		return machineId, errors.Annotate(err, "writing enrollment script")
+	}
+	logger.Infof("Enrollment script written for machine %v", machineId)
+	return machineId, nil
+}
+
+// gatherMachineParams returns the parameters for adding the machine
+// with the given hostname and series. Unlike the other manual
+// provisioners, the machine's hardware characteristics are unknown.
+func gatherMachineParams(hostname, series string) (*params.AddMachineParams, error) {
+	// Generate a unique nonce for the machine.
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	addr, err := manual.HostAddress(hostname)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
+	}
+
+	instanceId := instance.Id(manual.ManualInstancePrefix + hostname)
+	nonce := fmt.Sprintf("%s:%s", instanceId, uuid.String())
+	return &params.AddMachineParams{
+		Series:     series,
+		InstanceId: instanceId,
+		Nonce:      nonce,
+		Addrs:      params.FromNetworkAddresses(addr),
+		Jobs:       []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+	}, nil
+}
+
+var enrollmentScriptTemplate = template.Must(template.New("").Parse(`#!/bin/bash
+# Enrolls this machine with Juju as machine {{.MachineId}}.
+#
+# Run this script once, as root, on the machine. It contains the
+# credentials of the machine agent, so keep it private, and delete
+# it once the machine has been enrolled.
+set -e
+
+if /bin/bash -c {{.ListServicesScript}} | grep -q juju; then
+  echo "machine is already provisioned" >&2
+  exit 1
+fi
+
+{{.ProvisioningScript}}
+`))
+
+// EnrollmentScript returns a script that, when run on a machine,
+// enrolls it as the machine with the given ID by running the given
+// provisioning script. The script refuses to run on a machine that
+// already has a Juju agent.
+func EnrollmentScript(machineId, provisioningScript string) (string, error) {
+	var buf bytes.Buffer
+	if err := enrollmentScriptTemplate.Execute(&buf, map[string]string{
+		"MachineId":          machineId,
+		"ListServicesScript": utils.ShQuote(service.ListServicesScript()),
+		"ProvisioningScript": provisioningScript,
+	}); err != nil {
+		return "", errors.Trace(err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enrollprovisioner_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/enrollprovisioner"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type provisionerSuite struct {
+	testing.IsolationSuite
+
+	client *fakeProvisioningClient
+	stdout bytes.Buffer
+}
+
+var _ = gc.Suite(&provisionerSuite{})
+
+func (s *provisionerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.client = &fakeProvisioningClient{script: "echo provisioning"}
+	s.stdout.Reset()
+}
+
+func (s *provisionerSuite) args() manual.ProvisionMachineArgs {
+	return manual.ProvisionMachineArgs{
+		Host:           "10.0.0.1",
+		Series:         "xenial",
+		Client:         s.client,
+		Stdout:         &s.stdout,
+		UpdateBehavior: &params.UpdateBehavior{true, false},
+	}
+}
+
+func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
+	machineId, err := enrollprovisioner.ProvisionMachine(s.args())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "42")
+
+	s.client.CheckCallNames(c, "AddMachines", "ProvisioningScript")
+	machineParams := s.client.Calls()[0].Args[0].([]params.AddMachineParams)
+	c.Assert(machineParams, gc.HasLen, 1)
+	c.Assert(machineParams[0].Series, gc.Equals, "xenial")
+	c.Assert(machineParams[0].InstanceId, gc.Equals, instance.Id("manual:10.0.0.1"))
+	c.Assert(machineParams[0].Nonce, gc.Matches, "manual:10.0.0.1:.+")
+	c.Assert(machineParams[0].Jobs, jc.DeepEquals, []multiwatcher.MachineJob{multiwatcher.JobHostUnits})
+	c.Assert(machineParams[0].Addrs, gc.HasLen, 1)
+	c.Assert(machineParams[0].Addrs[0].Value, gc.Equals, "10.0.0.1")
+
+	s.client.CheckCall(c, 1, "ProvisioningScript", params.ProvisioningScriptParams{
+		MachineId:              "42",
+		Nonce:                  machineParams[0].Nonce,
+		DisablePackageCommands: false,
+	})
+
+	script := s.stdout.String()
+	c.Assert(script, jc.HasPrefix, "#!/bin/bash\n# Enrolls this machine with Juju as machine 42.\n")
+	c.Assert(strings.HasSuffix(script, "echo provisioning\n"), jc.IsTrue)
+}
+
+func (s *provisionerSuite) TestProvisionMachineNoSeries(c *gc.C) {
+	args := s.args()
+	args.Series = ""
+	_, err := enrollprovisioner.ProvisionMachine(args)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *provisionerSuite) TestProvisionMachineScriptError(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("no tools"))
+	machineId, err := enrollprovisioner.ProvisionMachine(s.args())
+	c.Assert(err, gc.ErrorMatches, "cannot obtain provisioning script: no tools")
+	c.Assert(machineId, gc.Equals, "")
+	s.client.CheckCallNames(c, "AddMachines", "ProvisioningScript", "ForceDestroyMachines")
+	s.client.CheckCall(c, 2, "ForceDestroyMachines", []string{"42"})
+	c.Assert(s.stdout.String(), gc.Equals, "")
+}
+
+func (s *provisionerSuite) TestEnrollmentScriptChecksProvisioned(c *gc.C) {
+	script, err := enrollprovisioner.EnrollmentScript("0", "echo provisioning")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, jc.Contains, "grep -q juju")
+	c.Assert(script, jc.Contains, "machine is already provisioned")
+}
+
+type fakeProvisioningClient struct {
+	testing.Stub
+	script string
+}
+
+func (f *fakeProvisioningClient) AddMachines(args []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.MethodCall(f, "AddMachines", args)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return []params.AddMachinesResult{{Machine: "42"}}, nil
+}
+
+func (f *fakeProvisioningClient) ForceDestroyMachines(machines ...string) error {
+	f.MethodCall(f, "ForceDestroyMachines", machines)
+	return f.NextErr()
+}
+
+func (f *fakeProvisioningClient) ProvisioningScript(args params.ProvisioningScriptParams) (string, error) {
+	f.MethodCall(f, "ProvisioningScript", args)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.script, nil
+}
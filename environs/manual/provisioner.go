@@ -30,6 +30,10 @@ type ProvisionMachineArgs struct {
 	Host string
 	User string
 
+	// Series is the series of the machine. It is only used when the
+	// series cannot be detected, i.e. when enrolling a machine.
+	Series string
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
@@ -5,4 +5,5 @@ package winrmprovisioner
 
 var (
 	WinDetectHardware = detectHardware
+	BindInitScripts   = bindInitScripts
 )
@@ -121,7 +121,7 @@ func newDetectHardwareScript() (string, error) {
 // the Administrator user using the secure client
 // only if this is false then this will make a new attempt with the unsecure http client.
 func InitAdministratorUser(args *manual.ProvisionMachineArgs) error {
-	logger.Infof("Trying https client as user %s on %s", args.Host, args.User)
+	logger.Infof("Trying https client as user %s on %s", args.User, args.Host)
 	err := args.WinRM.Client.Ping()
 	if err == nil {
 		logger.Infof("Https connection is enabled on the host %s with user %s", args.Host, args.User)
@@ -139,7 +139,7 @@ func InitAdministratorUser(args *manual.ProvisionMachineArgs) error {
 		return errors.Annotatef(err, "cannot create a new http winrm client ")
 	}
 
-	logger.Infof("Trying http client as user %s on %s", args.Host, args.User)
+	logger.Infof("Trying http client as user %s on %s", args.User, args.Host)
 	if err = args.WinRM.Client.Ping(); err != nil {
 		logger.Debugf("WinRM unsecure listener is not enabled on %s", args.Host)
 		return errors.Annotatef(err, "cannot provision, because all winrm default connections failed")
@@ -174,7 +174,7 @@ func enableCertAuth(args *manual.ProvisionMachineArgs) (manual.WinrmClientAPI, e
 	var stderr bytes.Buffer
 	pass := args.WinRM.Client.Password()
 
-	scripts, err := bindInitScripts(pass, args.WinRM.Keys)
+	scripts, err := bindInitScripts(args.User, pass, args.WinRM.Keys)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -216,8 +216,9 @@ func enableCertAuth(args *manual.ProvisionMachineArgs) (manual.WinrmClientAPI, e
 // (utf-16-le, base64)format for passing to the winrm conn to be executed remotely
 // we are doing this instead of one big script because winrm supports
 // just 8192 length commands. We know we have an amount of prefixed scripts
-// that we want to bind for the init process so create an array of scripts
-func bindInitScripts(pass string, keys *winrm.X509) ([]string, error) {
+// that we want to bind for the init process so create an array of scripts.
+// The client certificate is mapped to the given user.
+func bindInitScripts(user, pass string, keys *winrm.X509) ([]string, error) {
 	var (
 		err error
 	)
@@ -240,13 +241,13 @@ func bindInitScripts(pass string, keys *winrm.X509) ([]string, error) {
 		return nil, err
 	}
 
-	scripts[2] = fmt.Sprintf(setConnWinrm, pass)
+	scripts[2] = fmt.Sprintf(setConnWinrm, user, pass)
 	scripts[2], err = shell.NewPSEncodedCommand(scripts[2])
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	return scripts, nil
 }
 
 // setFiles powershell script that will manage and create the conf folder and files
@@ -281,7 +282,7 @@ winrm set winrm/config/winrs '@{MaxMemoryPerShellMB="1024"}'
 winrm set winrm/config/client/auth '@{Digest="false"}'
 winrm set winrm/config/service/auth '@{Certificate="true"}'
 Remove-Item -Path WSMan:\localhost\ClientCertificate\ClientCertificate_* -Recurse -force | Out-null
-$username = "Administrator"
+$username = "%s"
 $password = "%s"
 $client_cert_path = [io.path]::Combine($env:APPDATA, 'Juju', 'x509', 'winrmcert.crt')
 $clientcert = New-Object System.Security.Cryptography.X509Certificates.X509Certificate2($client_cert_path)
@@ -546,13 +547,19 @@ $stream.close()
 `
 
 // runCmdProv powrshell script that decodes and executes the newly created userdata file
-// after the process of writing the sequantial script is done above
+// after the process of writing the sequantial script is done above.
+// The userdata contains the machine agent's credentials, so both files
+// are removed once it has run.
 const runCmdProv = `
 $provisionPath= [io.path]::Combine($ENV:APPDATA, 'Juju', 'provision.ps1')
+$udataPath= [io.path]::Combine($ENV:APPDATA, 'Juju', 'udata.ps1')
 $script = [IO.File]::ReadAllText($provisionPath)
 $x = [System.Text.Encoding]::ASCII.GetString([System.Convert]::FromBase64String($script))
-Set-Content C:\udata.ps1 $x
-powershell.exe -ExecutionPolicy RemoteSigned -NonInteractive -File C:\udata.ps1
+Set-Content $udataPath $x
+powershell.exe -ExecutionPolicy RemoteSigned -NonInteractive -File $udataPath
+$exitCode = $LASTEXITCODE
+Remove-Item $provisionPath, $udataPath -Force
+exit $exitCode
 `
 
 // ProvisioningScript generates a powershell script that can be
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/winrm"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/environs/manual"
@@ -72,6 +73,19 @@ func (w *winrmprovisionerSuite) TestDetectSeriesAndHardwareCharacteristics(c *gc
 	c.Assert(err, gc.IsNil)
 }
 
+func (w *winrmprovisionerSuite) TestBindInitScripts(c *gc.C) {
+	keys := winrm.NewX509()
+	scripts, err := winrmprovisioner.BindInitScripts("juju", "passw0rd", keys)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scripts, gc.HasLen, 3)
+	for _, script := range scripts {
+		c.Assert(script, gc.Not(gc.Equals), "")
+	}
+
+	_, err = winrmprovisioner.BindInitScripts("juju", "", keys)
+	c.Assert(err, gc.ErrorMatches, "The password is empty.*")
+}
+
 func (w *winrmprovisionerSuite) TestRunProvisionScript(c *gc.C) {
 	var stdin, stderr bytes.Buffer
 	fakeCli := &fakeWinRM{
@@ -3,7 +3,13 @@
 
 package tags
 
-import "gopkg.in/juju/names.v2"
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
 
 const (
 	// JujuTagPrefix is the prefix for Juju-managed tags.
@@ -42,6 +48,30 @@ const (
 	JujuMachine = JujuTagPrefix + "machine-id"
 )
 
+// Template variables which may be used in the values of user-defined
+// resource tags. The model name is expanded by the model config; the
+// UUIDs are expanded by ResourceTags.
+const (
+	TemplateModelName      = "{model-name}"
+	TemplateModelUUID      = "{model-uuid}"
+	TemplateControllerUUID = "{controller-uuid}"
+)
+
+var templateVariable = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateTemplate returns an error if the given tag value refers to
+// an unknown template variable.
+func ValidateTemplate(value string) error {
+	for _, v := range templateVariable.FindAllString(value, -1) {
+		switch v {
+		case TemplateModelName, TemplateModelUUID, TemplateControllerUUID:
+		default:
+			return errors.NotValidf("template variable %q", v)
+		}
+	}
+	return nil
+}
+
 // ResourceTagger is an interface that can provide resource tags.
 type ResourceTagger interface {
 	// ResourceTags returns a set of resource tags, and a
@@ -51,8 +81,13 @@ type ResourceTagger interface {
 }
 
 // ResourceTags returns tags to set on an infrastructure resource
-// for the specified Juju environment.
+// for the specified Juju environment. The model and controller UUID
+// template variables are expanded in the values of the taggers' tags.
 func ResourceTags(modelTag names.ModelTag, controllerTag names.ControllerTag, taggers ...ResourceTagger) map[string]string {
+	expand := strings.NewReplacer(
+		TemplateModelUUID, modelTag.Id(),
+		TemplateControllerUUID, controllerTag.Id(),
+	)
 	allTags := make(map[string]string)
 	for _, tagger := range taggers {
 		tags, ok := tagger.ResourceTags()
@@ -60,7 +95,7 @@ func ResourceTags(modelTag names.ModelTag, controllerTag names.ControllerTag, ta
 			continue
 		}
 		for k, v := range tags {
-			allTags[k] = v
+			allTags[k] = expand.Replace(v)
 		}
 	}
 	allTags[JujuModel] = modelTag.Id()
@@ -4,6 +4,7 @@
 package tags_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -61,6 +62,33 @@ func (*tagsSuite) TestResourceTagsResourceTaggers(c *gc.C) {
 	})
 }
 
+func (*tagsSuite) TestResourceTagsTemplate(c *gc.C) {
+	testResourceTags(c, testing.ControllerTag, testing.ModelTag, []tags.ResourceTagger{
+		resourceTagger(func() (map[string]string, bool) {
+			return map[string]string{
+				"model":      "m-{model-uuid}",
+				"controller": "{controller-uuid}",
+				"name":       "{model-name}",
+			}, true
+		}),
+	}, map[string]string{
+		"juju-model-uuid":      testing.ModelTag.Id(),
+		"juju-controller-uuid": testing.ControllerTag.Id(),
+		"model":                "m-" + testing.ModelTag.Id(),
+		"controller":           testing.ControllerTag.Id(),
+		"name":                 "{model-name}",
+	})
+}
+
+func (*tagsSuite) TestValidateTemplate(c *gc.C) {
+	for _, value := range []string{"", "eng", "{model-name}-{model-uuid}", "{controller-uuid}"} {
+		c.Check(tags.ValidateTemplate(value), jc.ErrorIsNil)
+	}
+	err := tags.ValidateTemplate("{model-name}-{owner}")
+	c.Assert(err, gc.ErrorMatches, `template variable "\{owner\}" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func testResourceTags(c *gc.C, controller names.ControllerTag, model names.ModelTag, taggers []tags.ResourceTagger, expectTags map[string]string) {
 	tags := tags.ResourceTags(model, controller, taggers...)
 	c.Assert(tags, jc.DeepEquals, expectTags)
@@ -40,3 +40,8 @@ const StrictMigration = "strict-migration"
 
 // CAAS enables creating models on CAAS infrastructure (k8s, etc)
 const CAAS = "caas"
+
+// ModelCache enables the API server's in-memory model cache, which
+// serves some read-heavy facade methods from AllWatcher deltas
+// instead of the database.
+const ModelCache = "model-cache"
@@ -54,7 +54,7 @@ func (s *cloudImageMetadataSuite) TestSaveAndFindAndDeleteMetadata(c *gc.C) {
 		Stream:          "stream",
 		Region:          "region",
 		Series:          "trusty",
-		Arch:            "arch",
+		Arch:            "amd64",
 		VirtType:        "virtType",
 		RootStorageType: "rootStorageType",
 		ImageId:         imageId,
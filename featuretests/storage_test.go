@@ -252,6 +252,8 @@ modelscoped-unreleasable:
   provider: modelscoped-unreleasable
 rootfs:
   provider: rootfs
+sharedfs:
+  provider: sharedfs
 static:
   provider: static
 tmpfs:
@@ -272,6 +274,7 @@ modelscoped               modelscoped
 modelscoped-block         modelscoped-block         
 modelscoped-unreleasable  modelscoped-unreleasable  
 rootfs                    rootfs                    
+sharedfs                  sharedfs                  
 static                    static                    
 tmpfs                     tmpfs                     
 
@@ -125,7 +125,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	// Process the account details obtained from login.
 	var accountDetails *jujuclient.AccountDetails
 	user, ok := st.AuthTag().(names.UserTag)
-	if !apiInfo.SkipLogin {
+	if !apiInfo.SkipLogin && apiInfo.Token == "" {
 		if ok {
 			if accountDetails, err = args.Store.AccountDetails(args.ControllerName); err != nil {
 				if !errors.IsNotFound(err) {
@@ -179,6 +179,12 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		return apiInfo, controller, nil
 	}
 	account := args.AccountDetails
+	if account.Token != "" {
+		// The token identifies the user, and takes
+		// the place of any other credentials.
+		apiInfo.Token = account.Token
+		return apiInfo, controller, nil
+	}
 	if account.User != "" {
 		userTag := names.NewUserTag(account.User)
 		if userTag.IsLocal() {
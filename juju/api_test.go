@@ -136,6 +136,37 @@ func (s *NewAPIClientSuite) TestUpdatesLastKnownAccess(c *gc.C) {
 	)
 }
 
+func (s *NewAPIClientSuite) TestWithToken(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Token, gc.Equals, "0123456789abcdef.s3cret")
+		c.Check(apiInfo.Tag, gc.IsNil)
+		c.Check(apiInfo.Password, gc.Equals, "")
+		return expectState, nil
+	}
+
+	stubStore := jujuclienttesting.WrapClientStore(store)
+	st, err := juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          stubStore,
+		ControllerName: "noconfig",
+		AccountDetails: &jujuclient.AccountDetails{Token: "0123456789abcdef.s3cret"},
+		ModelUUID:      fakeUUID,
+		DialOpts:       api.DefaultDialOpts(),
+		OpenAPI:        apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.Equals, expectState)
+	stubStore.CheckCallNames(c, "ControllerByName", "UpdateController")
+
+	// The stored account is left alone.
+	c.Assert(store.Accounts["noconfig"], jc.DeepEquals, jujuclient.AccountDetails{
+		User:     "admin",
+		Password: "hunter2",
+	})
+}
+
 func (s *NewAPIClientSuite) TestUpdatesPublicDNSName(c *gc.C) {
 	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
 		conn := mockedAPIState(noFlags)
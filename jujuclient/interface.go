@@ -80,6 +80,11 @@ type AccountDetails struct {
 
 	// LastKnownAccess is the last known access level for the account.
 	LastKnownAccess string `yaml:"last-known-access,omitempty"`
+
+	// Token is an API token with which to log in in place of the
+	// user's password. It is never written to the accounts file;
+	// it is taken from the JUJU_TOKEN environment variable.
+	Token string `yaml:"-"`
 }
 
 // BootstrapConfig holds the configuration used to bootstrap a controller.
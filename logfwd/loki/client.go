@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package loki holds the tools needed to perform log forwarding from
+// Juju to an HTTP endpoint implementing the Loki push API.
+package loki
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// DefaultTimeout is how long a Client waits for a push request to
+// complete, if no other timeout is configured.
+const DefaultTimeout = 30 * time.Second
+
+// Config holds the configuration of a Client.
+type Config struct {
+	// URL is the push endpoint, e.g.
+	// https://loki.example.com/loki/api/v1/push.
+	URL string
+
+	// TLSConfig is used when connecting to https endpoints.
+	TLSConfig *tls.Config
+
+	// Timeout is how long to wait for each push request. If it is
+	// zero, DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Validate returns an error if the config cannot be used to open a
+// Client.
+func (cfg Config) Validate() error {
+	if cfg.URL == "" {
+		return errors.NotValidf("empty URL")
+	}
+	if cfg.Timeout < 0 {
+		return errors.NotValidf("negative Timeout")
+	}
+	return nil
+}
+
+// Client sends log records to a Loki push endpoint.
+type Client struct {
+	url       string
+	transport *http.Transport
+	http      *http.Client
+}
+
+// Open returns a Client that pushes to the configured endpoint.
+func Open(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: cfg.TLSConfig,
+	}
+	return &Client{
+		url:       cfg.URL,
+		transport: transport,
+		http: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+// Send pushes the records to the endpoint in a single request.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(NewPushRequest(records))
+	if err != nil {
+		return errors.Annotate(err, "encoding push request")
+	}
+	resp, err := client.http.Post(client.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("push rejected: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// Close releases the client's idle connections.
+func (client *Client) Close() error {
+	client.transport.CloseIdleConnections()
+	return nil
+}
+
+// PushRequest is the body of a Loki push request.
+type PushRequest struct {
+	Streams []Stream `json:"streams"`
+}
+
+// Stream holds the log lines sharing a set of labels.
+type Stream struct {
+	// Labels identify the stream.
+	Labels map[string]string `json:"stream"`
+
+	// Values holds pairs of timestamp (in nanoseconds since the epoch,
+	// as a string) and log line, in order.
+	Values [][2]string `json:"values"`
+}
+
+// NewPushRequest groups the records into streams, labelled with the
+// controller, model, entity and level of each record, and returns the
+// corresponding push request. Records keep their relative order within
+// each stream.
+func NewPushRequest(records []logfwd.Record) PushRequest {
+	var req PushRequest
+	index := make(map[streamKey]int)
+	for _, rec := range records {
+		key := streamKey{
+			controller: rec.Origin.ControllerUUID,
+			model:      rec.Origin.ModelUUID,
+			entity:     rec.Origin.Entity(),
+			level:      rec.Level.String(),
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(req.Streams)
+			index[key] = i
+			req.Streams = append(req.Streams, Stream{Labels: key.labels()})
+		}
+		req.Streams[i].Values = append(req.Streams[i].Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			formatLine(rec),
+		})
+	}
+	return req
+}
+
+type streamKey struct {
+	controller string
+	model      string
+	entity     string
+	level      string
+}
+
+func (key streamKey) labels() map[string]string {
+	labels := map[string]string{
+		"source":          "juju",
+		"controller_uuid": key.controller,
+		"model_uuid":      key.model,
+		"level":           key.level,
+	}
+	if key.entity != "" {
+		labels["entity"] = key.entity
+	}
+	return labels
+}
+
+// formatLine formats the record as a log line: its module, if known,
+// followed by its message. Everything else is carried by the labels
+// and timestamp.
+func formatLine(rec logfwd.Record) string {
+	if rec.Location.Module == "" {
+		return rec.Message
+	}
+	return rec.Location.Module + " " + rec.Message
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) record(id int64, unit string, level loggo.Level, message string) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "unit-mysql-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeUnit,
+			Name:           unit,
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-unit-agent",
+				Version:                 version.MustParse("2.3.0"),
+			},
+		},
+		Timestamp: time.Unix(1500000000, int64(id)).UTC(),
+		Level:     level,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: message,
+	}
+}
+
+func (s *ClientSuite) TestNewPushRequest(c *gc.C) {
+	req := loki.NewPushRequest([]logfwd.Record{
+		s.record(1, "mysql/0", loggo.INFO, "one"),
+		s.record(2, "mysql/1", loggo.INFO, "two"),
+		s.record(3, "mysql/0", loggo.INFO, "three"),
+		s.record(4, "mysql/0", loggo.ERROR, "four"),
+	})
+	labels := func(entity, level string) map[string]string {
+		return map[string]string{
+			"source":          "juju",
+			"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+			"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			"entity":          entity,
+			"level":           level,
+		}
+	}
+	c.Assert(req, jc.DeepEquals, loki.PushRequest{
+		Streams: []loki.Stream{{
+			Labels: labels("unit-mysql-0", "INFO"),
+			Values: [][2]string{
+				{"1500000000000000001", "juju.worker.uniter one"},
+				{"1500000000000000003", "juju.worker.uniter three"},
+			},
+		}, {
+			Labels: labels("unit-mysql-1", "INFO"),
+			Values: [][2]string{
+				{"1500000000000000002", "juju.worker.uniter two"},
+			},
+		}, {
+			Labels: labels("unit-mysql-0", "ERROR"),
+			Values: [][2]string{
+				{"1500000000000000004", "juju.worker.uniter four"},
+			},
+		}},
+	})
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	var received loki.PushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.URL.Path, gc.Equals, "/loki/api/v1/push")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(json.Unmarshal(body, &received), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := loki.Open(loki.Config{URL: server.URL + "/loki/api/v1/push"})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	records := []logfwd.Record{s.record(1, "mysql/0", loggo.INFO, "one")}
+	err = client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, loki.NewPushRequest(records))
+}
+
+func (s *ClientSuite) TestSendRejected(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "entry out of order", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, err := loki.Open(loki.Config{URL: server.URL})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send([]logfwd.Record{s.record(1, "mysql/0", loggo.INFO, "one")})
+	c.Assert(err, gc.ErrorMatches, `push rejected: 400 Bad Request: entry out of order`)
+}
+
+func (s *ClientSuite) TestSendNothing(c *gc.C) {
+	client, err := loki.Open(loki.Config{URL: "http://0.1.2.3:1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := loki.Open(loki.Config{})
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
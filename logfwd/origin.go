@@ -188,6 +188,27 @@ func (o Origin) Validate() error {
 	return nil
 }
 
+// Entity returns the tag, in string form, of the entity that created
+// the record (e.g. "unit-mysql-0"). It returns "" if the entity is not
+// known.
+func (o Origin) Entity() string {
+	switch o.Type {
+	case OriginTypeUser:
+		if names.IsValidUser(o.Name) {
+			return names.NewUserTag(o.Name).String()
+		}
+	case OriginTypeMachine:
+		if names.IsValidMachine(o.Name) {
+			return names.NewMachineTag(o.Name).String()
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(o.Name) {
+			return names.NewUnitTag(o.Name).String()
+		}
+	}
+	return ""
+}
+
 // Software describes a running application.
 type Software struct {
 	// PrivateEnterpriseNumber is the IANA-registered "SMI Network
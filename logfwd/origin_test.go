@@ -78,6 +78,26 @@ func (s *OriginSuite) TestOriginForJuju(c *gc.C) {
 	})
 }
 
+func (s *OriginSuite) TestEntity(c *gc.C) {
+	for i, test := range []struct {
+		oType    logfwd.OriginType
+		name     string
+		expected string
+	}{
+		{logfwd.OriginTypeMachine, "0/lxd/1", "machine-0-lxd-1"},
+		{logfwd.OriginTypeUnit, "svc-a/0", "unit-svc-a-0"},
+		{logfwd.OriginTypeUser, "bob", "user-bob"},
+		{logfwd.OriginTypeUnit, "not a unit", ""},
+		{logfwd.OriginTypeUnknown, "", ""},
+	} {
+		c.Logf("test %d: %v %q", i, test.oType, test.name)
+		origin := validOrigin
+		origin.Type = test.oType
+		origin.Name = test.name
+		c.Check(origin.Entity(), gc.Equals, test.expected)
+	}
+}
+
 func (s *OriginSuite) TestValidateValid(c *gc.C) {
 	origin := validOrigin
 
@@ -7,17 +7,48 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"net"
+	"net/url"
+	"path"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/cert"
 )
 
+// These are the kinds of log forwarding target.
+const (
+	// TypeSyslog targets forward records as RFC 5424 messages to a
+	// syslog server over TLS.
+	TypeSyslog = "syslog"
+
+	// TypeLoki targets forward records over HTTP(S), using the Loki
+	// push API.
+	TypeLoki = "loki"
+)
+
 // RawConfig holds the raw configuration data for a connection to a
-// syslog forwarding target.
+// log forwarding target. Despite its name, it describes HTTP targets
+// as well as syslog ones.
 type RawConfig struct {
 	// Enabled is true if the log forwarding feature is enabled.
 	Enabled bool
 
+	// Type is the kind of target. If it is empty, the target is a
+	// syslog server.
+	Type string
+
+	// URL is the push endpoint of a Loki target, e.g.
+	// https://loki.example.com/loki/api/v1/push. It is not used
+	// by syslog targets.
+	URL string
+
+	// Include and Exclude hold glob patterns which are matched against
+	// the entity (e.g. "unit-mysql-0") and module (e.g.
+	// "juju.worker.uniter") of each log record. If Include is not
+	// empty, only records matching one of its patterns are forwarded.
+	// Records matching any of the Exclude patterns are not forwarded.
+	Include []string
+	Exclude []string
+
 	// Host is the host-port of the syslog host. The format is:
 	//
 	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
@@ -41,18 +72,69 @@ type RawConfig struct {
 
 // Validate ensures that the config is currently valid.
 func (cfg RawConfig) Validate() error {
-	if err := cfg.validateHost(); err != nil {
-		return errors.Trace(err)
+	switch cfg.Type {
+	case "", TypeSyslog:
+		if err := cfg.validateHost(); err != nil {
+			return errors.Trace(err)
+		}
+		if cfg.Enabled || cfg.ClientKey != "" || cfg.ClientCert != "" || cfg.CACert != "" {
+			if _, err := cfg.tlsConfig(); err != nil {
+				return errors.Annotate(err, "validating TLS config")
+			}
+		}
+	case TypeLoki:
+		if err := cfg.validateURL(); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := cfg.HTTPTLSConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	default:
+		return errors.NotValidf("Type %q", cfg.Type)
 	}
 
-	if cfg.Enabled || cfg.ClientKey != "" || cfg.ClientCert != "" || cfg.CACert != "" {
-		if _, err := cfg.tlsConfig(); err != nil {
-			return errors.Annotate(err, "validating TLS config")
+	for _, pattern := range append(cfg.Include, cfg.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.NotValidf("filter pattern %q", pattern)
 		}
 	}
 	return nil
 }
 
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" && !cfg.Enabled {
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	return nil
+}
+
+// HTTPTLSConfig returns the TLS configuration to use when connecting
+// to a Loki target. Unlike syslog targets, these need not use client
+// certificates, and may be verified against the system's CAs.
+func (cfg RawConfig) HTTPTLSConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		tlsCfg.RootCAs.AddCert(caCert)
+	}
+	return tlsCfg, nil
+}
+
 func (cfg RawConfig) validateHost() error {
 	host, _, err := net.SplitHostPort(cfg.Host)
 	if err != nil {
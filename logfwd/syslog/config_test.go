@@ -233,3 +233,85 @@ var invalidKey = `
 MIIBOgIBAAJAZabKgKInuOxj5vDWLwHHQtK3/45KB+32D15w94Nt83BmuGxo90lw
 -----END RSA PRIVATE KEY-----
 `[1:]
+
+func (s *ConfigSuite) TestRawValidateLoki(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled: true,
+		Type:    syslog.TypeLoki,
+		URL:     "https://loki.example.com/loki/api/v1/push",
+		Include: []string{"unit-mysql-*"},
+		Exclude: []string{"juju.worker.uniter.*"},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateLokiMissingURL(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled: true,
+		Type:    syslog.TypeLoki,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateLokiBadURL(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Enabled: true,
+		Type:    syslog.TypeLoki,
+		URL:     "ftp://loki.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "ftp://loki.example.com" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateLokiBadCACert(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Type:   syslog.TypeLoki,
+		URL:    "https://loki.example.com",
+		CACert: "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadType(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Type: "carrier-pigeon",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Type "carrier-pigeon" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFilterPattern(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Exclude: []string{"unit-[mysql"},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `filter pattern "unit-\[mysql" not valid`)
+}
+
+func (s *ConfigSuite) TestHTTPTLSConfigOptional(c *gc.C) {
+	cfg := syslog.RawConfig{
+		Type: syslog.TypeLoki,
+		URL:  "https://loki.example.com",
+	}
+
+	tlsCfg, err := cfg.HTTPTLSConfig()
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tlsCfg.Certificates, gc.HasLen, 0)
+	c.Check(tlsCfg.RootCAs, gc.IsNil)
+}
@@ -0,0 +1,584 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// A model archive is a gzipped tarball holding a serialized model and
+// the binaries it uses, so that the model can be imported into any
+// controller without the live migration handshake. Its entries are,
+// in order:
+//
+//	manifest.json    describes the binaries in the archive
+//	model.yaml       the serialized model description
+//	charms/<n>       charm archives
+//	tools/<n>        agent binary tarballs
+//	resources/<n>    application resources
+//	signature        HMAC-SHA256 of the preceding entries
+//
+// The signature covers the name and content of every other entry, and
+// is checked before any of them is used.
+const (
+	archiveManifestName  = "manifest.json"
+	archiveModelName     = "model.yaml"
+	archiveSignatureName = "signature"
+
+	// archiveFormatVersion is the version of the archive format
+	// written by WriteArchive.
+	archiveFormatVersion = 1
+)
+
+// archiveManifest describes the binaries in a model archive.
+type archiveManifest struct {
+	FormatVersion int               `json:"format-version"`
+	Charms        []archiveCharm    `json:"charms"`
+	Tools         []archiveTools    `json:"tools"`
+	Resources     []archiveResource `json:"resources"`
+}
+
+type archiveCharm struct {
+	URL  string `json:"url"`
+	File string `json:"file"`
+}
+
+type archiveTools struct {
+	Version string `json:"version"`
+	File    string `json:"file"`
+}
+
+type archiveResource struct {
+	// File is empty if the application revision is a placeholder.
+	File                string                     `json:"file,omitempty"`
+	ApplicationRevision archiveRevision            `json:"application-revision"`
+	CharmStoreRevision  archiveRevision            `json:"charmstore-revision"`
+	UnitRevisions       map[string]archiveRevision `json:"unit-revisions,omitempty"`
+}
+
+type archiveRevision struct {
+	ApplicationID  string    `json:"application"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Path           string    `json:"path"`
+	Description    string    `json:"description,omitempty"`
+	Origin         string    `json:"origin"`
+	Revision       int       `json:"revision"`
+	FingerprintHex string    `json:"fingerprint,omitempty"`
+	Size           int64     `json:"size"`
+	Username       string    `json:"username,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func newArchiveRevision(res resource.Resource) archiveRevision {
+	rev := archiveRevision{
+		ApplicationID: res.ApplicationID,
+		Name:          res.Name,
+		Type:          res.Type.String(),
+		Path:          res.Path,
+		Description:   res.Description,
+		Origin:        res.Origin.String(),
+		Revision:      res.Revision,
+		Size:          res.Size,
+		Username:      res.Username,
+		Timestamp:     res.Timestamp,
+	}
+	if len(res.Fingerprint.Bytes()) > 0 {
+		rev.FingerprintHex = res.Fingerprint.Hex()
+	}
+	return rev
+}
+
+func (rev archiveRevision) resource() (resource.Resource, error) {
+	type_, err := charmresource.ParseType(rev.Type)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        rev.Name,
+				Type:        type_,
+				Path:        rev.Path,
+				Description: rev.Description,
+			},
+			Origin:      origin,
+			Revision:    rev.Revision,
+			Size:        rev.Size,
+			Fingerprint: fp,
+		},
+		ApplicationID: rev.ApplicationID,
+		Username:      rev.Username,
+		Timestamp:     rev.Timestamp,
+	}, nil
+}
+
+// ArchiveConfig holds the configuration for WriteArchive.
+type ArchiveConfig struct {
+	// Model is the serialized model to archive, with the binaries
+	// it uses.
+	Model migration.SerializedModel
+
+	// CharmDownloader, ToolsDownloader and ResourceDownloader are
+	// used to read the binaries used by the model.
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+
+	// SigningKey is the key with which the archive is signed. The
+	// same key must be used to read the archive.
+	SigningKey []byte
+}
+
+// Validate returns an error if the configuration is not valid.
+func (c ArchiveConfig) Validate() error {
+	if len(c.Model.Bytes) == 0 {
+		return errors.NotValidf("empty Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	if len(c.SigningKey) == 0 {
+		return errors.NotValidf("empty SigningKey")
+	}
+	return nil
+}
+
+// WriteArchive writes a signed archive of the configured model and
+// its binaries to w. The archive can be read with ReadArchive.
+func WriteArchive(w io.Writer, config ArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	manifest := archiveManifest{FormatVersion: archiveFormatVersion}
+	for i, curl := range config.Model.Charms {
+		manifest.Charms = append(manifest.Charms, archiveCharm{
+			URL:  curl,
+			File: path.Join("charms", strconv.Itoa(i)),
+		})
+	}
+	toolsFiles := make(map[version.Binary]string)
+	for v := range config.Model.Tools {
+		file := path.Join("tools", strconv.Itoa(len(manifest.Tools)))
+		manifest.Tools = append(manifest.Tools, archiveTools{
+			Version: v.String(),
+			File:    file,
+		})
+		toolsFiles[v] = file
+	}
+	for i, res := range config.Model.Resources {
+		out := archiveResource{
+			ApplicationRevision: newArchiveRevision(res.ApplicationRevision),
+			CharmStoreRevision:  newArchiveRevision(res.CharmStoreRevision),
+		}
+		if !res.ApplicationRevision.IsPlaceholder() {
+			out.File = path.Join("resources", strconv.Itoa(i))
+		}
+		if len(res.UnitRevisions) > 0 {
+			out.UnitRevisions = make(map[string]archiveRevision)
+			for unitName, rev := range res.UnitRevisions {
+				out.UnitRevisions[unitName] = newArchiveRevision(rev)
+			}
+		}
+		manifest.Resources = append(manifest.Resources, out)
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	aw := &archiveWriter{
+		tw:  tar.NewWriter(gzw),
+		mac: hmac.New(sha256.New, config.SigningKey),
+	}
+	if err := aw.writeBytes(archiveManifestName, manifestBytes); err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.writeBytes(archiveModelName, config.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+	for _, ch := range manifest.Charms {
+		curl, err := charm.ParseURL(ch.URL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", curl)
+		}
+		err = aw.writeFrom(ch.File, reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", curl)
+		}
+	}
+	for v, uri := range config.Model.Tools {
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open agent binaries %s", v)
+		}
+		err = aw.writeFrom(toolsFiles[v], reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive agent binaries %s", v)
+		}
+	}
+	for _, res := range manifest.Resources {
+		if res.File == "" {
+			continue
+		}
+		rev := res.ApplicationRevision
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+		err = aw.writeFrom(res.File, reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+	}
+	signature := []byte(hex.EncodeToString(aw.mac.Sum(nil)))
+	if err := aw.write(archiveSignatureName, signature); err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// archiveWriter writes the entries of a model archive, adding them
+// to the archive's signature.
+type archiveWriter struct {
+	tw  *tar.Writer
+	mac hash.Hash
+}
+
+// writeBytes writes a signed entry with the given name and content.
+func (aw *archiveWriter) writeBytes(name string, data []byte) error {
+	signEntryHeader(aw.mac, name, int64(len(data)))
+	aw.mac.Write(data)
+	return errors.Trace(aw.write(name, data))
+}
+
+// writeFrom writes a signed entry with the given name and the content
+// read from r.
+func (aw *archiveWriter) writeFrom(name string, r io.Reader) error {
+	// The size of each entry must be known before it is written, so
+	// the content is staged in a temporary file.
+	content, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	signEntryHeader(aw.mac, name, size)
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(io.MultiWriter(aw.tw, aw.mac), content)
+	return errors.Trace(err)
+}
+
+// write writes an unsigned entry with the given name and content.
+func (aw *archiveWriter) write(name string, data []byte) error {
+	if err := aw.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := aw.tw.Write(data)
+	return errors.Trace(err)
+}
+
+// signEntryHeader adds the name and size of an entry to the archive's
+// signature, so that entries cannot be renamed or split.
+func signEntryHeader(mac hash.Hash, name string, size int64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(name)))
+	mac.Write(buf[:])
+	mac.Write([]byte(name))
+	binary.BigEndian.PutUint64(buf[:], uint64(size))
+	mac.Write(buf[:])
+}
+
+// Archive is a model archive that has been read and verified by
+// ReadArchive. It acts as the CharmDownloader, ToolsDownloader and
+// ResourceDownloader for the model's binaries.
+type Archive struct {
+	dir      string
+	manifest archiveManifest
+}
+
+// ReadArchive reads the model archive from r, extracting its entries
+// into dir, and verifies its signature with the given key. The entries
+// are not used if the signature does not match.
+func ReadArchive(r io.Reader, signingKey []byte, dir string) (*Archive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive")
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	mac := hmac.New(sha256.New, signingKey)
+	var signature []byte
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "cannot read model archive")
+		}
+		if signature != nil {
+			return nil, errors.Errorf("model archive has entry %q after its signature", header.Name)
+		}
+		if header.Name == archiveSignatureName {
+			if signature, err = ioutil.ReadAll(io.LimitReader(tr, 1024)); err != nil {
+				return nil, errors.Annotate(err, "cannot read model archive signature")
+			}
+			continue
+		}
+		if err := extractArchiveEntry(dir, header, tr, mac); err != nil {
+			return nil, errors.Annotatef(err, "cannot extract %q", header.Name)
+		}
+	}
+	if signature == nil {
+		return nil, errors.New("model archive is not signed")
+	}
+	expected := []byte(hex.EncodeToString(mac.Sum(nil)))
+	if !hmac.Equal(signature, expected) {
+		return nil, errors.New("model archive signature does not match")
+	}
+
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(dir, archiveManifestName))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive manifest")
+	}
+	a := &Archive{dir: dir}
+	if err := json.Unmarshal(manifestBytes, &a.manifest); err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive manifest")
+	}
+	if a.manifest.FormatVersion != archiveFormatVersion {
+		return nil, errors.NotSupportedf("model archive format version %d", a.manifest.FormatVersion)
+	}
+	return a, nil
+}
+
+// extractArchiveEntry writes the content of the archive entry with the
+// given header into dir, adding it to the archive's signature.
+func extractArchiveEntry(dir string, header *tar.Header, r io.Reader, mac hash.Hash) error {
+	if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+		return errors.NotValidf("entry type %q", header.Typeflag)
+	}
+	name := path.Clean(header.Name)
+	if name != header.Name || path.IsAbs(name) || name == ".." || len(name) > 2 && name[:3] == "../" {
+		return errors.NotValidf("entry name")
+	}
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	signEntryHeader(mac, header.Name, header.Size)
+	if _, err := io.Copy(io.MultiWriter(f, mac), r); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// ModelBytes returns the serialized model description in the archive.
+func (a *Archive) ModelBytes() ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(a.dir, archiveModelName))
+	return data, errors.Trace(err)
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (a *Archive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	for _, ch := range a.manifest.Charms {
+		if ch.URL == curl.String() {
+			return a.open(ch.File)
+		}
+	}
+	return nil, errors.NotFoundf("charm %s in model archive", curl)
+}
+
+// OpenURI is part of the ToolsDownloader interface. The URIs are
+// those returned by UploadBinariesConfig.
+func (a *Archive) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	for _, tools := range a.manifest.Tools {
+		if tools.File == uri {
+			return a.open(tools.File)
+		}
+	}
+	return nil, errors.NotFoundf("agent binaries %q in model archive", uri)
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (a *Archive) OpenResource(application, name string) (io.ReadCloser, error) {
+	for _, res := range a.manifest.Resources {
+		rev := res.ApplicationRevision
+		if res.File != "" && rev.ApplicationID == application && rev.Name == name {
+			return a.open(res.File)
+		}
+	}
+	return nil, errors.NotFoundf("resource %s/%s in model archive", application, name)
+}
+
+func (a *Archive) open(file string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(file)))
+	return f, errors.Trace(err)
+}
+
+// UploadBinariesConfig returns the configuration with which to upload
+// the archived binaries, using the archive as the downloader.
+func (a *Archive) UploadBinariesConfig(
+	charmUploader CharmUploader,
+	toolsUploader ToolsUploader,
+	resourceUploader ResourceUploader,
+) (UploadBinariesConfig, error) {
+	config := UploadBinariesConfig{
+		CharmDownloader:    a,
+		CharmUploader:      charmUploader,
+		Tools:              make(map[version.Binary]string),
+		ToolsDownloader:    a,
+		ToolsUploader:      toolsUploader,
+		ResourceDownloader: a,
+		ResourceUploader:   resourceUploader,
+	}
+	for _, ch := range a.manifest.Charms {
+		config.Charms = append(config.Charms, ch.URL)
+	}
+	for _, tools := range a.manifest.Tools {
+		v, err := version.ParseBinary(tools.Version)
+		if err != nil {
+			return UploadBinariesConfig{}, errors.Trace(err)
+		}
+		config.Tools[v] = tools.File
+	}
+	for _, res := range a.manifest.Resources {
+		out := migration.SerializedModelResource{
+			UnitRevisions: make(map[string]resource.Resource),
+		}
+		var err error
+		if out.ApplicationRevision, err = res.ApplicationRevision.resource(); err != nil {
+			return UploadBinariesConfig{}, errors.Annotate(err, "application revision")
+		}
+		if out.CharmStoreRevision, err = res.CharmStoreRevision.resource(); err != nil {
+			return UploadBinariesConfig{}, errors.Annotate(err, "charmstore revision")
+		}
+		for unitName, rev := range res.UnitRevisions {
+			if out.UnitRevisions[unitName], err = rev.resource(); err != nil {
+				return UploadBinariesConfig{}, errors.Annotate(err, "unit revision")
+			}
+		}
+		config.Resources = append(config.Resources, out)
+	}
+	return config, nil
+}
+
+// Uploaders returns the uploaders for the binaries of an imported
+// model, given the model's state.
+type Uploaders func(*state.State) (CharmUploader, ToolsUploader, ResourceUploader, error)
+
+// ImportArchive imports the model in the archive into the controller
+// whose state is given, uploads the model's binaries with the uploaders
+// returned for the new model, and activates the model. If anything
+// fails, the partially imported model is removed.
+func ImportArchive(st *state.State, archive *Archive, uploaders Uploaders) (*state.Model, *state.State, error) {
+	bytes, err := archive.ModelBytes()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbModel, dbState, err := ImportModel(st, bytes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := activateArchivedModel(dbModel, dbState, archive, uploaders); err != nil {
+		if removeErr := dbState.RemoveImportingModelDocs(); removeErr != nil {
+			logger.Errorf("cannot remove partially imported model %s: %v", dbModel.UUID(), removeErr)
+		}
+		dbState.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return dbModel, dbState, nil
+}
+
+func activateArchivedModel(model *state.Model, st *state.State, archive *Archive, uploaders Uploaders) error {
+	charms, tools, resources, err := uploaders(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	config, err := archive.UploadBinariesConfig(charms, tools, resources)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := UploadBinaries(config); err != nil {
+		return errors.Trace(err)
+	}
+	if err := model.SetStatus(status.StatusInfo{Status: status.Available}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetMigrationMode(state.MigrationModeNone))
+}
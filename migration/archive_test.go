@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/resourcetesting"
+)
+
+type ArchiveSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+var signingKey = []byte("sekrit")
+
+func (s *ArchiveSuite) archiveConfig(c *gc.C) migration.ArchiveConfig {
+	downloader := &fakeDownloader{}
+	app0Res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	app1Res := resourcetesting.NewResource(c, nil, "blob1", "app1", "blob1").Resource
+	app1UnitRes := app1Res
+	app1UnitRes.Revision = 1
+	app2Res := resourcetesting.NewPlaceholderResource(c, "blob2", "app2")
+	return migration.ArchiveConfig{
+		Model: coremigration.SerializedModel{
+			Bytes:  []byte("model: description"),
+			Charms: []string{"cs:trusty/postgresql-42", "local:trusty/magic-2"},
+			Tools: map[version.Binary]string{
+				version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+				version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/1",
+			},
+			Resources: []coremigration.SerializedModelResource{
+				{ApplicationRevision: app0Res},
+				{
+					ApplicationRevision: app1Res,
+					UnitRevisions:       map[string]resource.Resource{"app1/99": app1UnitRes},
+				},
+				{ApplicationRevision: app2Res},
+			},
+		},
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+		SigningKey:         signingKey,
+	}
+}
+
+func (s *ArchiveSuite) writeArchive(c *gc.C) []byte {
+	var buf bytes.Buffer
+	err := migration.WriteArchive(&buf, s.archiveConfig(c))
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *ArchiveSuite) TestValidateConfig(c *gc.C) {
+	check := func(modify func(*migration.ArchiveConfig), expect string) {
+		config := s.archiveConfig(c)
+		modify(&config)
+		err := config.Validate()
+		c.Check(err, gc.ErrorMatches, expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	check(func(c *migration.ArchiveConfig) { c.Model.Bytes = nil }, "empty Model not valid")
+	check(func(c *migration.ArchiveConfig) { c.CharmDownloader = nil }, "missing CharmDownloader not valid")
+	check(func(c *migration.ArchiveConfig) { c.ToolsDownloader = nil }, "missing ToolsDownloader not valid")
+	check(func(c *migration.ArchiveConfig) { c.ResourceDownloader = nil }, "missing ResourceDownloader not valid")
+	check(func(c *migration.ArchiveConfig) { c.SigningKey = nil }, "empty SigningKey not valid")
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	data := s.writeArchive(c)
+	archive, err := migration.ReadArchive(bytes.NewReader(data), signingKey, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelBytes, err := archive.ModelBytes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(modelBytes), gc.Equals, "model: description")
+
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	config, err := archive.UploadBinariesConfig(uploader, uploader, uploader)
+	c.Assert(err, jc.ErrorIsNil)
+	err = migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(uploader.charms, jc.DeepEquals, []string{
+		"cs:trusty/postgresql-42",
+		"local:trusty/magic-2",
+	})
+	// The fake downloader uses the original URI as the content.
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+		version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/1",
+	})
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"app0/blob0": "blob0",
+		"app1/blob1": "blob1",
+		"app2/blob2": "<placeholder>",
+	})
+	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
+}
+
+func (s *ArchiveSuite) TestWrongKey(c *gc.C) {
+	data := s.writeArchive(c)
+	_, err := migration.ReadArchive(bytes.NewReader(data), []byte("wrong"), c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model archive signature does not match")
+}
+
+func (s *ArchiveSuite) TestTamperedContent(c *gc.C) {
+	data := rewriteArchive(c, s.writeArchive(c), func(name string, content []byte) []byte {
+		if name == "model.yaml" {
+			return []byte("model: tampered")
+		}
+		return content
+	})
+	_, err := migration.ReadArchive(bytes.NewReader(data), signingKey, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model archive signature does not match")
+}
+
+func (s *ArchiveSuite) TestMissingSignature(c *gc.C) {
+	data := rewriteArchive(c, s.writeArchive(c), func(name string, content []byte) []byte {
+		if name == "signature" {
+			return nil
+		}
+		return content
+	})
+	_, err := migration.ReadArchive(bytes.NewReader(data), signingKey, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model archive is not signed")
+}
+
+func (s *ArchiveSuite) TestUnsafeEntryName(c *gc.C) {
+	data := rewriteArchive(c, s.writeArchive(c), func(name string, content []byte) []byte {
+		return content
+	}, "../escape")
+	_, err := migration.ReadArchive(bytes.NewReader(data), signingKey, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `cannot extract "../escape": entry name not valid`)
+}
+
+// rewriteArchive returns a copy of the given model archive, with the
+// content of each entry replaced by the result of calling modify. An
+// entry is dropped if modify returns nil. Any extra entries named are
+// prepended, with empty content.
+func rewriteArchive(c *gc.C, data []byte, modify func(string, []byte) []byte, extra ...string) []byte {
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	tr := tar.NewReader(gzr)
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	writeEntry := func(name string, content []byte) {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write(content)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, name := range extra {
+		writeEntry(name, []byte{})
+	}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		content, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		if content = modify(header.Name, content); content != nil {
+			writeEntry(header.Name, content)
+		}
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/tools"
+)
+
+// NewStateArchiveConfig returns the configuration with which to archive
+// the model whose state is given. The model's binaries are read
+// directly from the controller's storage, rather than over the API as
+// they are during a migration.
+func NewStateArchiveConfig(st *state.State, signingKey []byte) (ArchiveConfig, error) {
+	bytes, err := ExportModel(st)
+	if err != nil {
+		return ArchiveConfig{}, errors.Trace(err)
+	}
+	model := migration.SerializedModel{
+		Bytes: bytes,
+		Tools: make(map[version.Binary]string),
+	}
+	if err := addStateCharmsAndResources(st, &model); err != nil {
+		return ArchiveConfig{}, errors.Trace(err)
+	}
+	if err := addStateTools(st, &model); err != nil {
+		return ArchiveConfig{}, errors.Trace(err)
+	}
+	binaries := stateBinaries{st}
+	return ArchiveConfig{
+		Model:              model,
+		CharmDownloader:    binaries,
+		ToolsDownloader:    binaries,
+		ResourceDownloader: binaries,
+		SigningKey:         signingKey,
+	}, nil
+}
+
+// addStateCharmsAndResources adds the charms and resources used by the
+// model's applications to the serialized model.
+func addStateCharmsAndResources(st *state.State, model *migration.SerializedModel) error {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charms := set.NewStrings()
+	for _, app := range applications {
+		curl, _ := app.CharmURL()
+		charms.Add(curl.String())
+
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return errors.Annotatef(err, "cannot list resources of application %q", app.Name())
+		}
+		for i, res := range appResources.Resources {
+			out := migration.SerializedModelResource{
+				ApplicationRevision: res,
+				UnitRevisions:       make(map[string]resource.Resource),
+			}
+			// The charm store resources correspond, by index,
+			// to the application's resources.
+			if i < len(appResources.CharmStoreResources) {
+				out.CharmStoreRevision = resource.Resource{
+					Resource:      appResources.CharmStoreResources[i],
+					ApplicationID: app.Name(),
+				}
+			}
+			for _, unitResources := range appResources.UnitResources {
+				for _, unitRes := range unitResources.Resources {
+					if unitRes.Name == res.Name {
+						out.UnitRevisions[unitResources.Tag.Id()] = unitRes
+					}
+				}
+			}
+			model.Resources = append(model.Resources, out)
+		}
+	}
+	model.Charms = charms.SortedValues()
+	return nil
+}
+
+// addStateTools adds the agent binaries used by the model's machines
+// and units to the serialized model. The URI of each is its version.
+func addStateTools(st *state.State, model *migration.SerializedModel) error {
+	addTools := func(agentTools *tools.Tools, err error) error {
+		if errors.IsNotFound(err) {
+			// The agent has not yet started.
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		model.Tools[agentTools.Version] = agentTools.Version.String()
+		return nil
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if err := addTools(m.AgentTools()); err != nil {
+			return errors.Annotatef(err, "machine %s", m.Id())
+		}
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, u := range units {
+			if err := addTools(u.AgentTools()); err != nil {
+				return errors.Annotatef(err, "unit %s", u.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// stateBinaries reads a model's charms, agent binaries and resources
+// from the controller's storage.
+type stateBinaries struct {
+	st *state.State
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (b stateBinaries) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := b.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := storage.NewStorage(b.st.ModelUUID(), b.st.MongoSession())
+	r, _, err := stor.Get(ch.StoragePath())
+	return r, errors.Trace(err)
+}
+
+// OpenURI is part of the ToolsDownloader interface. The URI is the
+// version of the agent binaries.
+func (b stateBinaries) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	v, err := version.ParseBinary(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor, err := b.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, r, err := stor.Open(v.String())
+	if err != nil {
+		stor.Close()
+		return nil, errors.Trace(err)
+	}
+	return &toolsReadCloser{ReadCloser: r, stor: stor}, nil
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (b stateBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	resources, err := b.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, r, err := resources.OpenResource(application, name)
+	return r, errors.Trace(err)
+}
+
+// toolsReadCloser closes the tools storage along with the agent
+// binaries read from it.
+type toolsReadCloser struct {
+	io.ReadCloser
+	stor binarystorage.StorageCloser
+}
+
+// Close is part of the io.Closer interface.
+func (r *toolsReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	if closeErr := r.stor.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
@@ -27,6 +27,10 @@ var (
 	FsAvailSpace      = fsAvailSpace
 	PreallocFileSizes = preallocFileSizes
 	PreallocFiles     = preallocFiles
+
+	MemInfoPath           = &memInfoPath
+	TotalMemoryMB         = totalMemoryMB
+	WiredTigerCacheSizeMB = wiredTigerCacheSizeMB
 )
 
 func PatchService(patchValue func(interface{}, interface{}), data *svctesting.FakeServiceData) {
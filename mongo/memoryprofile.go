@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// memInfoPath is the file from which the machine's total memory is
+// read.
+var memInfoPath = "/proc/meminfo"
+
+// SetMemoryProfile resizes the WiredTiger cache of the mongod to which
+// the session is directly connected, to the size that mongod of the
+// given version uses when started with the given memory profile. The
+// new size lasts until mongod is restarted.
+func SetMemoryProfile(session *mgo.Session, profile MemoryProfile, version Version) error {
+	if err := profile.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if version.StorageEngine != WiredTiger {
+		logger.Debugf("not resizing cache of mongo %s", version)
+		return nil
+	}
+	totalMB, err := totalMemoryMB()
+	if err != nil {
+		return errors.Annotate(err, "cannot determine total memory")
+	}
+	cacheMB := wiredTigerCacheSizeMB(profile, version, totalMB)
+	logger.Infof("resizing mongo cache to %dM for memory profile %q", cacheMB, profile)
+	cmd := bson.D{
+		{"setParameter", 1},
+		{"wiredTigerEngineRuntimeConfig", fmt.Sprintf("cache_size=%dM", cacheMB)},
+	}
+	var result bson.M
+	if err := session.DB("admin").Run(cmd, &result); err != nil {
+		return errors.Annotate(err, "cannot resize mongo cache")
+	}
+	return nil
+}
+
+// wiredTigerCacheSizeMB returns the size of the WiredTiger cache, in
+// MiB, used by mongod of the given version with the given memory
+// profile, on a machine with the given amount of memory.
+func wiredTigerCacheSizeMB(profile MemoryProfile, version Version, totalMB uint64) uint64 {
+	if profile == MemoryProfileLow {
+		return LowCacheSize * 1024
+	}
+	// These are mongod's own defaults.
+	if version.Major < 3 || version.Major == 3 && version.Minor < 4 {
+		// The larger of 60% of memory less 1GB, and 1GB.
+		if size := totalMB * 6 / 10; size > 2048 {
+			return size - 1024
+		}
+		return 1024
+	}
+	// The larger of 50% of memory less 1GB, and 256MB.
+	if totalMB > 1024 {
+		if size := (totalMB - 1024) / 2; size > 256 {
+			return size
+		}
+	}
+	return 256
+}
+
+// totalMemoryMB returns the total memory of the machine, in MiB.
+func totalMemoryMB() (uint64, error) {
+	f, err := os.Open(memInfoPath)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "MemTotal:" || fields[2] != "kB" {
+			continue
+		}
+		kB, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, errors.Annotatef(err, "parsing %s", memInfoPath)
+		}
+		return kB / 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return 0, errors.Errorf("MemTotal not found in %s", memInfoPath)
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type memoryProfileSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&memoryProfileSuite{})
+
+func (s *memoryProfileSuite) TestWiredTigerCacheSizeMB(c *gc.C) {
+	v32 := mongo.Version{Major: 3, Minor: 2, StorageEngine: mongo.WiredTiger}
+	v34 := mongo.Version{Major: 3, Minor: 4, StorageEngine: mongo.WiredTiger}
+	for i, test := range []struct {
+		profile  mongo.MemoryProfile
+		version  mongo.Version
+		totalMB  uint64
+		expected uint64
+	}{
+		{mongo.MemoryProfileLow, v32, 16384, 1024},
+		{mongo.MemoryProfileLow, v34, 16384, 1024},
+		{mongo.MemoryProfileDefault, v32, 16384, 8806},
+		{mongo.MemoryProfileDefault, v32, 2048, 1024},
+		{mongo.MemoryProfileDefault, v34, 16384, 7680},
+		{mongo.MemoryProfileDefault, v34, 1024, 256},
+		{mongo.MemoryProfileDefault, v34, 512, 256},
+	} {
+		c.Logf("test %d: %s %s %dM", i, test.profile, test.version, test.totalMB)
+		size := mongo.WiredTigerCacheSizeMB(test.profile, test.version, test.totalMB)
+		c.Check(size, gc.Equals, test.expected)
+	}
+}
+
+func (s *memoryProfileSuite) TestTotalMemoryMB(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "meminfo")
+	err := ioutil.WriteFile(path, []byte(""+
+		"MemFree:         1024000 kB\n"+
+		"MemTotal:        8192000 kB\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(mongo.MemInfoPath, path)
+
+	total, err := mongo.TotalMemoryMB()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(total, gc.Equals, uint64(8000))
+}
+
+func (s *memoryProfileSuite) TestTotalMemoryMBNotFound(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "meminfo")
+	err := ioutil.WriteFile(path, []byte("MemFree: 1024 kB\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(mongo.MemInfoPath, path)
+
+	_, err = mongo.TotalMemoryMB()
+	c.Assert(err, gc.ErrorMatches, "MemTotal not found in .*")
+}
@@ -388,6 +388,18 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 	return addresses[index], true
 }
 
+// SelectPublicAddressPreferringIPv6 is like SelectPublicAddress, but
+// prefers IPv6 addresses over IPv4 addresses of the same scope.
+func SelectPublicAddressPreferringIPv6(addresses []Address) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferringIPv6(publicMatch))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
 // SelectPublicHostPort picks one HostPort from a slice that would be
 // appropriate to display as a publicly accessible endpoint. If there
 // are no suitable candidates, the empty string is returned.
@@ -415,6 +427,32 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 	return addresses[index], true
 }
 
+// SelectInternalAddressPreferringIPv6 is like SelectInternalAddress,
+// but prefers IPv6 addresses over IPv4 addresses of the same scope.
+func SelectInternalAddressPreferringIPv6(addresses []Address, machineLocal bool) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, preferringIPv6(internalAddressMatcher(machineLocal)))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
+// SelectFanLocalAddress picks one fan-local address from a slice,
+// preferring IPv4. Containers on a fan network use it to reach each
+// other without leaving the overlay. If there is no fan-local address,
+// an empty address and false are returned.
+func SelectFanLocalAddress(addresses []Address) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, fanLocalMatch)
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
 // SelectInternalHostPort picks one HostPort from a slice that can be
 // used as an endpoint for juju internal communication and returns it
 // in its NetAddr form. If there are no suitable addresses, the empty
@@ -461,6 +499,21 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
+// PrioritizeInternalHostPortsPreferringIPv6 is like
+// PrioritizeInternalHostPorts, but orders IPv6 addresses ahead of IPv4
+// addresses of the same scope.
+func PrioritizeInternalHostPortsPreferringIPv6(hps []HostPort, machineLocal bool) []string {
+	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
+		return hps[i].Address
+	}, preferringIPv6(internalAddressMatcher(machineLocal)))
+
+	out := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		out = append(out, hps[index].NetAddr())
+	}
+	return out
+}
+
 func publicMatch(addr Address) scopeMatch {
 	switch addr.Scope {
 	case ScopePublic:
@@ -510,6 +563,16 @@ func cloudLocalMatch(addr Address) scopeMatch {
 	return invalidScope
 }
 
+func fanLocalMatch(addr Address) scopeMatch {
+	if addr.Scope == ScopeFanLocal {
+		if addr.Type == IPv4Address {
+			return exactScopeIPv4
+		}
+		return exactScope
+	}
+	return invalidScope
+}
+
 func cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	if addr.Scope == ScopeMachineLocal {
 		if addr.Type == IPv4Address {
@@ -520,6 +583,29 @@ func cloudOrMachineLocalMatch(addr Address) scopeMatch {
 	return cloudLocalMatch(addr)
 }
 
+// preferringIPv6 returns a scopeMatchFunc that matches addresses to
+// the same scopes as match, but swaps the ranking of IPv4 and IPv6
+// addresses within each scope, so that IPv6 addresses are preferred.
+func preferringIPv6(match scopeMatchFunc) scopeMatchFunc {
+	swapped := map[scopeMatch]scopeMatch{
+		exactScopeIPv4:          exactScope,
+		exactScope:              exactScopeIPv4,
+		firstFallbackScopeIPv4:  firstFallbackScope,
+		firstFallbackScope:      firstFallbackScopeIPv4,
+		secondFallbackScopeIPv4: secondFallbackScope,
+		secondFallbackScope:     secondFallbackScopeIPv4,
+	}
+	return func(addr Address) scopeMatch {
+		result := match(addr)
+		if addr.Type == IPv4Address || addr.Type == IPv6Address {
+			if other, ok := swapped[result]; ok {
+				return other
+			}
+		}
+		return result
+	}
+}
+
 type scopeMatch int
 
 const (
@@ -630,6 +716,19 @@ func (a Address) sortOrder() int {
 	return order
 }
 
+// sortOrderPreferringIPv6 is like sortOrder, but weights IPv6
+// addresses ahead of IPv4 addresses of the same scope.
+func (a Address) sortOrderPreferringIPv6() int {
+	order := a.sortOrder()
+	switch a.Type {
+	case IPv6Address:
+		order--
+	case IPv4Address:
+		order++
+	}
+	return order
+}
+
 type addressesPreferringIPv4Slice []Address
 
 func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
@@ -537,6 +537,70 @@ func (s *AddressSuite) TestSelectInternalMachineAddress(c *gc.C) {
 	}
 }
 
+var selectFanLocalTests = []selectTest{{
+	"no addresses gives empty string result",
+	[]network.Address{},
+	-1,
+}, {
+	"no fan address gives empty string result",
+	[]network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	-1,
+}, {
+	"fan address is selected over cloud local and public addresses",
+	[]network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("252.0.16.1", network.ScopeFanLocal),
+	},
+	2,
+}, {
+	"fan address is derived from an unscoped address",
+	[]network.Address{
+		network.NewAddress("10.0.0.1"),
+		network.NewAddress("252.0.16.1"),
+	},
+	1,
+}}
+
+func (s *AddressSuite) TestSelectFanLocalAddress(c *gc.C) {
+	for i, t := range selectFanLocalTests {
+		c.Logf("test %d: %s", i, t.about)
+		expectAddr, expectOK := t.expected()
+		actualAddr, actualOK := network.SelectFanLocalAddress(t.addresses)
+		c.Check(actualOK, gc.Equals, expectOK)
+		c.Check(actualAddr, gc.Equals, expectAddr)
+	}
+}
+
+func (s *AddressSuite) TestSelectAddressPreferringIPv6(c *gc.C) {
+	addresses := []network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	}
+	addr, ok := network.SelectPublicAddressPreferringIPv6(addresses)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[3])
+	addr, ok = network.SelectInternalAddressPreferringIPv6(addresses, false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[1])
+
+	// The scope of an address still matters more than its type.
+	addr, ok = network.SelectPublicAddressPreferringIPv6(addresses[:3])
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[2])
+	addr, ok = network.SelectInternalAddressPreferringIPv6(addresses[2:], false)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addresses[3])
+
+	_, ok = network.SelectPublicAddressPreferringIPv6(nil)
+	c.Check(ok, jc.IsFalse)
+}
+
 type selectInternalHostPortsTest struct {
 	about     string
 	addresses []network.HostPort
@@ -703,6 +767,22 @@ var stringTests = []struct {
 	str: "public:foo.com@badlands(id:3)",
 }}
 
+func (s *AddressSuite) TestPrioritizeInternalHostPortsPreferringIPv6(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 123},
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("2001:db8::1", network.ScopePublic), 123},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("127.0.0.1", network.ScopeMachineLocal), 123},
+	}
+	c.Check(network.PrioritizeInternalHostPortsPreferringIPv6(hps, false), jc.DeepEquals, []string{
+		"[fc00::1]:123",
+		"10.0.0.1:123",
+		"[2001:db8::1]:123",
+		"8.8.8.8:123",
+	})
+}
+
 func (s *AddressSuite) TestString(c *gc.C) {
 	for i, test := range stringTests {
 		c.Logf("test %d: %#v", i, test.addr)
@@ -72,3 +72,50 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestWithProvenance(c *gc.C) {
+	rule := network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24")
+	described := rule.WithProvenance("unit-wordpress-0", "wordpress/0 (exposed)")
+	c.Assert(described.Origin, gc.Equals, "unit-wordpress-0")
+	c.Assert(described.Description, gc.Equals, "wordpress/0 (exposed)")
+	c.Assert(described.String(), gc.Equals, rule.String())
+	c.Assert(rule.Origin, gc.Equals, "")
+	c.Assert(rule.Description, gc.Equals, "")
+}
+
+func (*FirewallSuite) TestProviderDescription(c *gc.C) {
+	rule := network.MustNewIngressRule("tcp", 80, 100, "10.0.0.0/24")
+	c.Assert(rule.ProviderDescription(0), gc.Equals, "80-100/tcp from 10.0.0.0/24")
+
+	rule = rule.WithProvenance("unit-wordpress-0", "wordpress/0 (exposed)")
+	c.Assert(rule.ProviderDescription(0), gc.Equals, "80-100/tcp from 10.0.0.0/24: wordpress/0 (exposed)")
+	c.Assert(rule.ProviderDescription(10), gc.Equals, "80-100/tcp")
+}
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 1, 65535, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "1-65535/udp to 10.0.0.0/8,192.168.1.0/24")
+
+	rule = network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/8")
+	c.Assert(rule.String(), gc.Equals, "icmp to 10.0.0.0/8")
+}
+
+func (*FirewallSuite) TestNewEgressRuleInvalidCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 80, "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 10.0.0.0")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 10, 100, "10.0.0.0/8")
+	rule2 := network.MustNewEgressRule("tcp", 80, 90, "10.0.0.0/8")
+	rule3 := network.MustNewEgressRule("tcp", 80, 80, "192.168.1.0/24")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8")
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
@@ -131,6 +131,25 @@ func SortHostPorts(hps []HostPort) {
 	sort.Sort(hostPortsPreferringIPv4Slice(hps))
 }
 
+type hostPortsPreferringIPv6Slice []HostPort
+
+func (hp hostPortsPreferringIPv6Slice) Len() int      { return len(hp) }
+func (hp hostPortsPreferringIPv6Slice) Swap(i, j int) { hp[i], hp[j] = hp[j], hp[i] }
+func (hp hostPortsPreferringIPv6Slice) Less(i, j int) bool {
+	order1 := hp[i].sortOrderPreferringIPv6()
+	order2 := hp[j].sortOrderPreferringIPv6()
+	if order1 == order2 {
+		return hp[i].Less(hp[j])
+	}
+	return order1 < order2
+}
+
+// SortHostPortsPreferringIPv6 is like SortHostPorts, but sorts IPv6
+// addresses ahead of IPv4 addresses of the same scope.
+func SortHostPortsPreferringIPv6(hps []HostPort) {
+	sort.Sort(hostPortsPreferringIPv6Slice(hps))
+}
+
 var netLookupIP = net.LookupIP
 
 // ResolveOrDropHostnames tries to resolve each address of type
@@ -202,16 +221,30 @@ func HostPortsToStrings(hps []HostPort) []string {
 // APIHostPortsToNoProxyString converts list of lists of NetAddrs() to
 // a NoProxy-like comma separated string, ignoring local addresses
 func APIHostPortsToNoProxyString(ahp [][]HostPort) string {
+	return NoProxyString(ahp, nil)
+}
+
+// NoProxyString returns a NoProxy-like comma separated string of the
+// API server addresses and the given machine addresses, ignoring local
+// addresses. Traffic to these addresses must never go through a proxy,
+// lest agents lose contact with the controller or their own machine.
+func NoProxyString(ahp [][]HostPort, machineAddrs []Address) string {
 	noProxySet := set.NewStrings()
+	add := func(addr Address) {
+		if addr.Scope == ScopeMachineLocal ||
+			addr.Scope == ScopeLinkLocal {
+			return
+		}
+		noProxySet.Add(addr.Value)
+	}
 	for _, host := range ahp {
 		for _, hp := range host {
-			if hp.Address.Scope == ScopeMachineLocal ||
-				hp.Address.Scope == ScopeLinkLocal {
-				continue
-			}
-			noProxySet.Add(hp.Address.Value)
+			add(hp.Address)
 		}
 	}
+	for _, addr := range machineAddrs {
+		add(addr)
+	}
 	return strings.Join(noProxySet.SortedValues(), ",")
 }
 
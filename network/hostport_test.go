@@ -340,6 +340,29 @@ func (s *HostPortSuite) TestSortHostPorts(c *gc.C) {
 	)
 }
 
+func (s *HostPortSuite) TestSortHostPortsPreferringIPv6(c *gc.C) {
+	hps, err := network.ParseHostPorts(
+		"10.0.0.1:1234",
+		"[fc00::1]:1234",
+		"example.com:1234",
+		"8.8.8.8:1234",
+		"[2001:db8::1]:1234",
+		"127.0.0.1:1234",
+		"[::1]:1234",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	network.SortHostPortsPreferringIPv6(hps)
+	s.assertHostPorts(c, hps,
+		"[2001:db8::1]:1234",
+		"8.8.8.8:1234",
+		"example.com:1234",
+		"[fc00::1]:1234",
+		"10.0.0.1:1234",
+		"[::1]:1234",
+		"127.0.0.1:1234",
+	)
+}
+
 var netAddrTests = []struct {
 	addr   network.Address
 	port   int
@@ -517,3 +540,16 @@ func (s *HostPortSuite) manyHostPorts(c *gc.C, count int, addressFunc func(index
 	}
 	return results
 }
+
+func (s *HostPortSuite) TestNoProxyString(c *gc.C) {
+	apiHostPorts := [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1", "127.0.0.1", "169.254.1.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}
+	machineAddrs := network.NewAddresses("10.0.0.9", "10.0.0.1", "::1", "192.168.1.4")
+	noProxy := network.NoProxyString(apiHostPorts, machineAddrs)
+	c.Assert(noProxy, gc.Equals, "10.0.0.1,10.0.0.2,10.0.0.9,192.168.1.4")
+
+	noProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
+	c.Assert(noProxy, gc.Equals, "10.0.0.1,10.0.0.2")
+}
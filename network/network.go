@@ -290,6 +290,15 @@ type NetworkInfo struct {
 	// InterfaceName is the OS-specific interface name, eg. "eth0" or "eno1.412"
 	InterfaceName string
 
+	// InterfaceType is the type of the interface, if known.
+	InterfaceType InterfaceType
+
+	// ParentInterfaceName is the name of the parent interface, if any.
+	ParentInterfaceName string
+
+	// VLANTag is the 802.1q tag of a VLAN interface, and 0 otherwise.
+	VLANTag int
+
 	// Addresses contains a list of addresses configured on the interface.
 	Addresses []InterfaceAddress
 }
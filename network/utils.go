@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -254,3 +255,61 @@ func GetBridgePorts(sysPath, bridgeName string) []string {
 	}
 	return names
 }
+
+// GetBondSlaves extracts and returns the names of all interfaces enslaved to
+// the given bondName from the Linux kernel userspace SYSFS location
+// "<sysPath/<bondName>/bonding/slaves". SysClassNetPath should be passed as
+// sysPath. Returns an empty result if the slaves cannot be determined reliably
+// for any reason, or if the bond has no slaves.
+//
+// Example call: network.GetBondSlaves(network.SysClassNetPath, "bond0")
+func GetBondSlaves(sysPath, bondName string) []string {
+	location := filepath.Join(sysPath, bondName, "bonding", "slaves")
+	data, err := ioutil.ReadFile(location)
+	if err != nil {
+		logger.Debugf("ignoring error reading %q: %v", location, err)
+		return nil
+	}
+	slaves := strings.Fields(string(data))
+	if len(slaves) == 0 {
+		return nil
+	}
+	return slaves
+}
+
+// GetVLANParentAndTag returns the name of the device the given VLAN interface
+// is stacked on, taken from the "<sysPath>/<vlanName>/lower_<parent>" link in
+// the Linux kernel userspace SYSFS, and the VLAN tag. SYSFS does not expose
+// the tag, so it is parsed from the conventional "<parent>.<tag>" or
+// "vlan<tag>" interface names. SysClassNetPath should be passed as sysPath.
+// Returns an empty parent and 0 for anything that cannot be determined
+// reliably.
+//
+// Example call: network.GetVLANParentAndTag(network.SysClassNetPath, "eth0.42")
+func GetVLANParentAndTag(sysPath, vlanName string) (string, int) {
+	lowerGlobPath := filepath.Join(sysPath, vlanName, "lower_*")
+	// As in GetBridgePorts, Glob can only return ErrBadPattern.
+	paths, err := filepath.Glob(lowerGlobPath)
+	if err != nil {
+		logger.Debugf("ignoring error traversing path %q: %v", lowerGlobPath, err)
+	}
+	var parentName string
+	if len(paths) == 1 {
+		parentName = strings.TrimPrefix(filepath.Base(paths[0]), "lower_")
+	}
+
+	var tagPart string
+	switch {
+	case parentName != "" && strings.HasPrefix(vlanName, parentName+"."):
+		tagPart = strings.TrimPrefix(vlanName, parentName+".")
+	case strings.HasPrefix(vlanName, "vlan"):
+		tagPart = strings.TrimPrefix(vlanName, "vlan")
+	case strings.Contains(vlanName, "."):
+		tagPart = vlanName[strings.LastIndex(vlanName, ".")+1:]
+	}
+	tag, err := strconv.Atoi(tagPart)
+	if err != nil || tag < 1 || tag > 4094 {
+		tag = 0
+	}
+	return parentName, tag
+}
@@ -222,6 +222,68 @@ func (*UtilsSuite) TestGetBridgePorts(c *gc.C) {
 	c.Check(result, jc.DeepEquals, []string{"eth0", "eth1", "eth2"})
 }
 
+func (*UtilsSuite) TestGetBondSlaves(c *gc.C) {
+	fakeSysPath := filepath.Join(c.MkDir(), network.SysClassNetPath)
+	err := os.MkdirAll(fakeSysPath, 0700)
+	c.Check(err, jc.ErrorIsNil)
+
+	writeFakeSlaves := func(bondName, contents string) {
+		fakeBondingPath := filepath.Join(fakeSysPath, bondName, "bonding")
+		err := os.MkdirAll(fakeBondingPath, 0700)
+		c.Check(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(filepath.Join(fakeBondingPath, "slaves"), []byte(contents), 0644)
+		c.Check(err, jc.ErrorIsNil)
+	}
+
+	result := network.GetBondSlaves(fakeSysPath, "missing")
+	c.Check(result, gc.IsNil)
+
+	writeFakeSlaves("bond0", "\n")
+	result = network.GetBondSlaves(fakeSysPath, "bond0")
+	c.Check(result, gc.IsNil)
+
+	writeFakeSlaves("bond0", "eth0 eth1\n")
+	result = network.GetBondSlaves(fakeSysPath, "bond0")
+	c.Check(result, jc.DeepEquals, []string{"eth0", "eth1"})
+}
+
+func (*UtilsSuite) TestGetVLANParentAndTag(c *gc.C) {
+	fakeSysPath := filepath.Join(c.MkDir(), network.SysClassNetPath)
+	err := os.MkdirAll(fakeSysPath, 0700)
+	c.Check(err, jc.ErrorIsNil)
+
+	writeFakeLower := func(vlanName, parentName string) {
+		fakeVLANPath := filepath.Join(fakeSysPath, vlanName)
+		err := os.MkdirAll(fakeVLANPath, 0700)
+		c.Check(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(filepath.Join(fakeVLANPath, "lower_"+parentName), []byte(""), 0644)
+		c.Check(err, jc.ErrorIsNil)
+	}
+
+	parent, tag := network.GetVLANParentAndTag(fakeSysPath, "missing")
+	c.Check(parent, gc.Equals, "")
+	c.Check(tag, gc.Equals, 0)
+
+	writeFakeLower("bond0.42", "bond0")
+	parent, tag = network.GetVLANParentAndTag(fakeSysPath, "bond0.42")
+	c.Check(parent, gc.Equals, "bond0")
+	c.Check(tag, gc.Equals, 42)
+
+	writeFakeLower("vlan100", "eth1")
+	parent, tag = network.GetVLANParentAndTag(fakeSysPath, "vlan100")
+	c.Check(parent, gc.Equals, "eth1")
+	c.Check(tag, gc.Equals, 100)
+
+	writeFakeLower("public", "eth2")
+	parent, tag = network.GetVLANParentAndTag(fakeSysPath, "public")
+	c.Check(parent, gc.Equals, "eth2")
+	c.Check(tag, gc.Equals, 0)
+
+	parent, tag = network.GetVLANParentAndTag(fakeSysPath, "eth3.5000")
+	c.Check(parent, gc.Equals, "")
+	c.Check(tag, gc.Equals, 0)
+}
+
 type mockListener struct {
 	net.Listener
 }
@@ -5,9 +5,11 @@ package all
 
 // Register all the available providers.
 import (
+	_ "github.com/juju/juju/caas/kubernetes/provider"
 	_ "github.com/juju/juju/provider/azure"
 	_ "github.com/juju/juju/provider/cloudsigma"
 	_ "github.com/juju/juju/provider/ec2"
+	_ "github.com/juju/juju/provider/equinix"
 	_ "github.com/juju/juju/provider/gce"
 	_ "github.com/juju/juju/provider/joyent"
 	_ "github.com/juju/juju/provider/lxd"
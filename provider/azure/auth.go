@@ -17,12 +17,20 @@ import (
 	"github.com/juju/juju/provider/azure/internal/useragent"
 )
 
+// authToken is the interface common to service principal and
+// managed identity tokens.
+type authToken interface {
+	OAuthToken() string
+	EnsureFresh() error
+	Refresh() error
+}
+
 // cloudSpecAuth is an implementation of autorest.Authorizer.
 type cloudSpecAuth struct {
 	cloud  environs.CloudSpec
 	sender autorest.Sender
 	mu     sync.Mutex
-	token  *adal.ServicePrincipalToken
+	token  authToken
 }
 
 // WithAuthorization is part of the autorest.Authorizer interface.
@@ -37,6 +45,9 @@ func (c *cloudSpecAuth) WithAuthorization() autorest.PrepareDecorator {
 			if err != nil {
 				return nil, err
 			}
+			if err := token.EnsureFresh(); err != nil {
+				return nil, err
+			}
 			authorizer := autorest.NewBearerAuthorizer(token)
 			return autorest.CreatePreparer(authorizer.WithAuthorization()).Prepare(r)
 		})
@@ -51,12 +62,20 @@ func (c *cloudSpecAuth) refresh() error {
 	return token.Refresh()
 }
 
-func (c *cloudSpecAuth) getToken() (*adal.ServicePrincipalToken, error) {
+func (c *cloudSpecAuth) getToken() (authToken, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.token != nil {
 		return c.token, nil
 	}
+	if c.cloud.Credential.AuthType() == managedIdentityAuthType {
+		token, err := managedIdentityToken(c.cloud, c.sender)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.token = token
+		return c.token, nil
+	}
 	token, err := AuthToken(c.cloud, c.sender)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -65,6 +84,19 @@ func (c *cloudSpecAuth) getToken() (*adal.ServicePrincipalToken, error) {
 	return c.token, nil
 }
 
+// managedIdentityToken returns a token, suitable for authorizing
+// Resource Manager API requests, for the managed identity described
+// by the supplied CloudSpec's credential. The token is obtained from
+// the Instance Metadata Service, and renewed as it nears expiry.
+func managedIdentityToken(cloud environs.CloudSpec, sender autorest.Sender) (*azureauth.ManagedIdentityToken, error) {
+	resourceId, err := azureauth.ResourceManagerResourceId(cloud.StorageEndpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	clientId := cloud.Credential.Attributes()[credAttrManagedIdentityClientId]
+	return azureauth.NewManagedIdentityToken(resourceId, clientId, sender), nil
+}
+
 // AuthToken returns a service principal token, suitable for authorizing
 // Resource Manager API requests, based on the supplied CloudSpec.
 func AuthToken(cloud environs.CloudSpec, sender autorest.Sender) (*adal.ServicePrincipalToken, error) {
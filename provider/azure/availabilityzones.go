@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+const (
+	// jujuAvailabilityZoneTag records the availability zone that a
+	// virtual machine was created in. The compute API version we use
+	// does not report zones, so we keep track of them ourselves.
+	jujuAvailabilityZoneTag = tags.JujuTagPrefix + "availability-zone"
+)
+
+// locationAvailabilityZones records the availability zones of the
+// locations that have them. There is no API for querying them, so the
+// list was taken from
+// https://docs.microsoft.com/en-us/azure/availability-zones/az-overview.
+var locationAvailabilityZones = map[string][]string{
+	"centralus":     {"1", "2", "3"},
+	"eastus":        {"1", "2", "3"},
+	"eastus2":       {"1", "2", "3"},
+	"westus2":       {"1", "2", "3"},
+	"francecentral": {"1", "2", "3"},
+	"northeurope":   {"1", "2", "3"},
+	"westeurope":    {"1", "2", "3"},
+	"uksouth":       {"1", "2", "3"},
+	"southeastasia": {"1", "2", "3"},
+	"japaneast":     {"1", "2", "3"},
+}
+
+var _ common.ZonedEnviron = (*azureEnviron)(nil)
+
+type azureAvailabilityZone string
+
+// Name is part of the common.AvailabilityZone interface.
+func (z azureAvailabilityZone) Name() string {
+	return string(z)
+}
+
+// Available is part of the common.AvailabilityZone interface.
+func (z azureAvailabilityZone) Available() bool {
+	return true
+}
+
+// availabilityZoneNames returns the names of the availability zones
+// in the environment's location. If the location has no zones, or the
+// model cannot use them, an error satisfying errors.IsNotImplemented
+// is returned.
+func (env *azureEnviron) availabilityZoneNames() ([]string, error) {
+	zones := locationAvailabilityZones[env.location]
+	if len(zones) == 0 {
+		return nil, errors.NotImplementedf("availability zones in location %q", env.location)
+	}
+	// Zonal virtual machines require managed disks, which models
+	// created before Juju 2.3 do not use.
+	if _, err := env.getStorageAccount(); err == nil {
+		return nil, errors.NotImplementedf("availability zones with unmanaged storage")
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	return zones, nil
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *azureEnviron) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	names, err := env.availabilityZoneNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zones := make([]common.AvailabilityZone, len(names))
+	for i, name := range names {
+		zones[i] = azureAvailabilityZone(name)
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron
+// interface.
+func (env *azureEnviron) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	if _, err := env.availabilityZoneNames(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := compute.VirtualMachinesClient{env.compute}
+	result, err := client.List(env.resourceGroup)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing virtual machines")
+	}
+	vmZones := make(map[instance.Id]string)
+	if result.Value != nil {
+		for _, vm := range *result.Value {
+			vmZones[instance.Id(to.String(vm.Name))] = toTags(vm.Tags)[jujuAvailabilityZoneTag]
+		}
+	}
+
+	var found int
+	zones := make([]string, len(ids))
+	for i, id := range ids {
+		zone, ok := vmZones[id]
+		if !ok {
+			continue
+		}
+		zones[i] = zone
+		found++
+	}
+	if found == 0 {
+		return nil, environs.ErrNoInstances
+	} else if found < len(ids) {
+		return zones, environs.ErrPartialInstances
+	}
+	return zones, nil
+}
+
+// DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *azureEnviron) DeriveAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
+	zone, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if zone != "" {
+		return []string{zone}, nil
+	}
+	return nil, nil
+}
+
+// parsePlacement parses the placement directive, returning the
+// availability zone it names, if any.
+func (env *azureEnviron) parsePlacement(placement string) (string, error) {
+	if placement == "" {
+		return "", nil
+	}
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 || placement[:pos] != "zone" {
+		return "", errors.Errorf("unknown placement directive: %s", placement)
+	}
+	zone := placement[pos+1:]
+	if err := env.checkAvailabilityZone(zone); err != nil {
+		return "", errors.Trace(err)
+	}
+	return zone, nil
+}
+
+// checkAvailabilityZone returns an error if the named availability zone
+// cannot be used in this environment.
+func (env *azureEnviron) checkAvailabilityZone(zone string) error {
+	zones, err := env.availabilityZoneNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !set.NewStrings(zones...).Contains(zone) {
+		return errors.NotValidf("availability zone %q", zone)
+	}
+	return nil
+}
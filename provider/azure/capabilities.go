@@ -0,0 +1,15 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+var _ environs.CapabilitiesReporter = (*azureEnviron)(nil)
+
+// Capabilities is specified on the environs.CapabilitiesReporter interface.
+func (*azureEnviron) Capabilities() ([]environs.Capability, error) {
+	return []environs.Capability{environs.CapabilityInstanceFirewall}, nil
+}
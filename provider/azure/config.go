@@ -17,6 +17,17 @@ import (
 const (
 	configAttrStorageAccountType = "storage-account-type"
 
+	// configAttrProximityPlacementGroups, when true, places the
+	// machines hosting each application's units in a proximity
+	// placement group, for low latency between them.
+	configAttrProximityPlacementGroups = "proximity-placement-groups"
+
+	// configAttrNetwork, if set, names an existing virtual network,
+	// in the form "[resource-group/]virtual-network", that machines
+	// are connected to instead of a network created by Juju. If no
+	// resource group is given, the model's resource group is used.
+	configAttrNetwork = "network"
+
 	// The below bits are internal book-keeping things, rather than
 	// configuration. Config is just what we have to work with.
 
@@ -26,20 +37,34 @@ const (
 )
 
 var configFields = schema.Fields{
-	configAttrStorageAccountType: schema.String(),
+	configAttrStorageAccountType:       schema.String(),
+	configAttrProximityPlacementGroups: schema.Bool(),
+	configAttrNetwork:                  schema.String(),
 }
 
 var configDefaults = schema.Defaults{
-	configAttrStorageAccountType: string(storage.StandardLRS),
+	configAttrStorageAccountType:       string(storage.StandardLRS),
+	configAttrProximityPlacementGroups: false,
+	configAttrNetwork:                  "",
 }
 
 var immutableConfigAttributes = []string{
 	configAttrStorageAccountType,
+	configAttrNetwork,
 }
 
 type azureModelConfig struct {
 	*config.Config
-	storageAccountType string
+	storageAccountType       string
+	proximityPlacementGroups bool
+
+	// virtualNetworkResourceGroup and virtualNetworkName identify
+	// the existing virtual network that machines are connected to.
+	// virtualNetworkName is empty if Juju creates the network, and
+	// virtualNetworkResourceGroup is empty if the network is in the
+	// model's resource group.
+	virtualNetworkResourceGroup string
+	virtualNetworkName          string
 }
 
 var knownStorageAccountTypes = []string{
@@ -118,13 +143,48 @@ Please choose a model name of no more than %d characters.`,
 		)
 	}
 
+	virtualNetworkResourceGroup, virtualNetworkName, err := parseVirtualNetwork(
+		validated[configAttrNetwork].(string),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	azureConfig := &azureModelConfig{
 		newCfg,
 		storageAccountType,
+		validated[configAttrProximityPlacementGroups].(bool),
+		virtualNetworkResourceGroup,
+		virtualNetworkName,
 	}
 	return azureConfig, nil
 }
 
+// parseVirtualNetwork parses the value of the "network" config
+// attribute, returning the resource group and name of the virtual
+// network. Both are empty if the value is empty.
+func parseVirtualNetwork(value string) (resourceGroup, name string, _ error) {
+	if value == "" {
+		return "", "", nil
+	}
+	parts := strings.Split(value, "/")
+	switch len(parts) {
+	case 1:
+		name = parts[0]
+	case 2:
+		resourceGroup, name = parts[0], parts[1]
+		if resourceGroup == "" {
+			return "", "", errors.NotValidf("%s %q", configAttrNetwork, value)
+		}
+	default:
+		return "", "", errors.NotValidf("%s %q", configAttrNetwork, value)
+	}
+	if name == "" {
+		return "", "", errors.NotValidf("%s %q", configAttrNetwork, value)
+	}
+	return resourceGroup, name, nil
+}
+
 // isKnownStorageAccountType reports whether or not the given string identifies
 // a known storage account type.
 func isKnownStorageAccountType(t string) bool {
@@ -53,6 +53,29 @@ func (s *configSuite) TestValidateInvalidFirewallMode(c *gc.C) {
 	)
 }
 
+func (s *configSuite) TestValidateProximityPlacementGroups(c *gc.C) {
+	s.assertConfigValid(c, testing.Attrs{"proximity-placement-groups": true})
+	s.assertConfigInvalid(
+		c, testing.Attrs{"proximity-placement-groups": "yes please"},
+		`proximity-placement-groups: expected bool, got string\("yes please"\)`,
+	)
+}
+
+func (s *configSuite) TestValidateNetwork(c *gc.C) {
+	s.assertConfigValid(c, testing.Attrs{"network": "juju-network"})
+	s.assertConfigValid(c, testing.Attrs{"network": "network-group/juju-network"})
+	s.assertConfigInvalid(c, testing.Attrs{"network": "/juju-network"}, `network "/juju-network" not valid`)
+	s.assertConfigInvalid(c, testing.Attrs{"network": "network-group/"}, `network "network-group/" not valid`)
+	s.assertConfigInvalid(c, testing.Attrs{"network": "a/b/c"}, `network "a/b/c" not valid`)
+}
+
+func (s *configSuite) TestValidateNetworkCantChange(c *gc.C) {
+	cfgOld := makeTestModelConfig(c, testing.Attrs{"network": "juju-network"})
+	cfgNew := makeTestModelConfig(c, testing.Attrs{"network": "other-network"})
+	_, err := s.provider.Validate(cfgNew, cfgOld)
+	c.Assert(err, gc.ErrorMatches, `cannot change immutable "network" config \(juju-network -> other-network\)`)
+}
+
 func (s *configSuite) TestValidateModelNameLength(c *gc.C) {
 	s.assertConfigInvalid(
 		c, testing.Attrs{"name": "someextremelyoverlylongishmodelname"},
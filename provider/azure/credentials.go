@@ -23,6 +23,8 @@ const (
 	credAttrTenantId       = "tenant-id"
 	credAttrAppPassword    = "application-password"
 
+	credAttrManagedIdentityClientId = "managed-identity-client-id"
+
 	// clientCredentialsAuthType is the auth-type for the
 	// "client credentials" OAuth flow, which requires a
 	// service principal with a password.
@@ -31,6 +33,12 @@ const (
 	// deviceCodeAuthType is the auth-type for the interactive
 	// "device code" OAuth flow.
 	deviceCodeAuthType cloud.AuthType = "interactive"
+
+	// managedIdentityAuthType is the auth-type for authenticating
+	// with an Azure managed identity, using tokens obtained from the
+	// Instance Metadata Service. This is only usable from within
+	// Azure virtual machines that have been assigned an identity.
+	managedIdentityAuthType cloud.AuthType = "managed-identity"
 )
 
 type ServicePrincipalCreator interface {
@@ -47,12 +55,19 @@ type AzureCLI interface {
 	ListClouds() ([]azurecli.Cloud, error)
 }
 
+// InstanceMetadata is the interface to the Azure Instance
+// Metadata Service.
+type InstanceMetadata interface {
+	SubscriptionId() (string, error)
+}
+
 // environPoviderCredentials is an implementation of
 // environs.ProviderCredentials for the Azure Resource
 // Manager cloud provider.
 type environProviderCredentials struct {
 	servicePrincipalCreator ServicePrincipalCreator
 	azureCLI                AzureCLI
+	instanceMetadata        InstanceMetadata
 }
 
 // CredentialSchemas is part of the environs.ProviderCredentials interface.
@@ -88,13 +103,37 @@ func (c environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud
 				},
 			},
 		},
+
+		// managedIdentityAuthType uses the system-assigned managed
+		// identity of the virtual machine running the controller,
+		// or the user-assigned identity with the given client ID.
+		managedIdentityAuthType: {
+			{
+				credAttrSubscriptionId, cloud.CredentialAttr{Description: "Azure subscription ID"},
+			}, {
+				credAttrManagedIdentityClientId, cloud.CredentialAttr{
+					Description: "Client ID of a user-assigned managed identity",
+					Optional:    true,
+				},
+			},
+		},
 	}
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials
 // interface. It attempts to detect subscription IDs from accounts
-// configured in the Azure CLI.
+// configured in the Azure CLI. If there are none, and we are running
+// in an Azure virtual machine, a managed identity credential for the
+// virtual machine's subscription is returned.
 func (c environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	cred, err := c.detectAzureCLICredentials()
+	if errors.IsNotFound(err) {
+		return c.detectManagedIdentityCredentials()
+	}
+	return cred, err
+}
+
+func (c environProviderCredentials) detectAzureCLICredentials() (*cloud.CloudCredential, error) {
 	// Attempt to get accounts from az.
 	accounts, err := c.azureCLI.ListAccounts()
 	if err != nil {
@@ -147,6 +186,24 @@ func (c environProviderCredentials) DetectCredentials() (*cloud.CloudCredential,
 	}, nil
 }
 
+func (c environProviderCredentials) detectManagedIdentityCredentials() (*cloud.CloudCredential, error) {
+	subscriptionId, err := c.instanceMetadata.SubscriptionId()
+	if err != nil {
+		logger.Debugf("error getting subscription from instance metadata: %s", err)
+		return nil, errors.NotFoundf("credentials")
+	}
+	cred := cloud.NewCredential(managedIdentityAuthType, map[string]string{
+		credAttrSubscriptionId: subscriptionId,
+	})
+	cred.Label = fmt.Sprintf("managed identity for subscription %s", subscriptionId)
+	return &cloud.CloudCredential{
+		DefaultCredential: "managed-identity",
+		AuthCredentials: map[string]cloud.Credential{
+			"managed-identity": cred,
+		},
+	}, nil
+}
+
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
 func (c environProviderCredentials) FinalizeCredential(
 	ctx environs.FinalizeCredentialContext,
@@ -177,7 +234,7 @@ func (c environProviderCredentials) FinalizeCredential(
 			return nil, errors.Trace(err)
 		}
 		return c.azureCLICredential(ctx, args, params)
-	case clientCredentialsAuthType:
+	case clientCredentialsAuthType, managedIdentityAuthType:
 		return &args.Credential, nil
 	default:
 		return nil, errors.NotSupportedf("%q auth-type", authType)
@@ -27,6 +27,7 @@ type credentialsSuite struct {
 	testing.IsolationSuite
 	servicePrincipalCreator servicePrincipalCreator
 	azureCLI                azureCLI
+	instanceMetadata        instanceMetadata
 	provider                environs.EnvironProvider
 }
 
@@ -36,9 +37,11 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.servicePrincipalCreator = servicePrincipalCreator{}
 	s.azureCLI = azureCLI{}
+	s.instanceMetadata = instanceMetadata{}
 	s.provider = newProvider(c, azure.ProviderConfig{
 		ServicePrincipalCreator: &s.servicePrincipalCreator,
 		AzureCLI:                &s.azureCLI,
+		InstanceMetadata:        &s.instanceMetadata,
 	})
 }
 
@@ -46,6 +49,7 @@ func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
 	envtesting.AssertProviderAuthTypes(c, s.provider,
 		"interactive",
 		"service-principal-secret",
+		"managed-identity",
 	)
 }
 
@@ -75,6 +79,29 @@ func (s *credentialsSuite) TestDetectCredentialsNoAccounts(c *gc.C) {
 	c.Assert(calls[0].FuncName, gc.Equals, "ListAccounts")
 }
 
+func (s *credentialsSuite) TestManagedIdentityCredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "managed-identity", map[string]string{
+		"subscription-id":            "subscription",
+		"managed-identity-client-id": "client",
+	})
+}
+
+func (s *credentialsSuite) TestDetectCredentialsManagedIdentity(c *gc.C) {
+	s.instanceMetadata.subscriptionId = "vm-subscription"
+	cred, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.DefaultCredential, gc.Equals, "managed-identity")
+	c.Assert(cred.AuthCredentials, gc.HasLen, 1)
+	managed := cred.AuthCredentials["managed-identity"]
+	c.Assert(managed.AuthType(), gc.Equals, cloud.AuthType("managed-identity"))
+	c.Assert(managed.Attributes(), jc.DeepEquals, map[string]string{
+		"subscription-id": "vm-subscription",
+	})
+	c.Assert(managed.Label, gc.Equals, "managed identity for subscription vm-subscription")
+	s.azureCLI.CheckCallNames(c, "ListAccounts")
+	s.instanceMetadata.CheckCallNames(c, "SubscriptionId")
+}
+
 func (s *credentialsSuite) TestDetectCredentialsListError(c *gc.C) {
 	s.azureCLI.SetErrors(errors.New("test error"))
 	_, err := s.provider.DetectCredentials()
@@ -345,6 +372,18 @@ func (s *credentialsSuite) TestFinalizeCredentialInteractive(c *gc.C) {
 	})
 }
 
+func (s *credentialsSuite) TestFinalizeCredentialManagedIdentity(c *gc.C) {
+	in := cloud.NewCredential("managed-identity", map[string]string{"subscription-id": "subscription"})
+	ctx := cmdtesting.Context(c)
+	out, err := s.provider.FinalizeCredential(ctx, environs.FinalizeCredentialParams{
+		Credential:    in,
+		CloudEndpoint: "https://arm.invalid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, &in)
+	s.servicePrincipalCreator.CheckNoCalls(c)
+}
+
 func (s *credentialsSuite) TestFinalizeCredentialInteractiveError(c *gc.C) {
 	in := cloud.NewCredential("interactive", map[string]string{"subscription-id": "subscription"})
 	s.servicePrincipalCreator.SetErrors(errors.New("blargh"))
@@ -597,6 +636,22 @@ func (c *servicePrincipalCreator) Create(params azureauth.ServicePrincipalParams
 	return "appid", "service-principal-password", c.NextErr()
 }
 
+type instanceMetadata struct {
+	testing.Stub
+	subscriptionId string
+}
+
+func (m *instanceMetadata) SubscriptionId() (string, error) {
+	m.MethodCall(m, "SubscriptionId")
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	if m.subscriptionId == "" {
+		return "", errors.NotFoundf("subscription ID in instance metadata")
+	}
+	return m.subscriptionId, nil
+}
+
 type azureCLI struct {
 	testing.Stub
 	Accounts []azurecli.Account
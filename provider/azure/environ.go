@@ -24,6 +24,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/retry"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/os"
 	jujuseries "github.com/juju/utils/series"
 	"github.com/juju/version"
@@ -68,6 +69,12 @@ const (
 
 	computeAPIVersion = "2016-04-30-preview"
 	networkAPIVersion = "2017-03-01"
+
+	// computePlacementAPIVersion is used for compute resources that
+	// are placed in availability zones or proximity placement groups,
+	// which computeAPIVersion does not support.
+	computePlacementAPIVersion = "2018-04-01"
+
 	storageAPIVersion = "2016-12-01"
 )
 
@@ -184,13 +191,19 @@ func (env *azureEnviron) initEnviron() error {
 		"azure.storage":   &env.storage.Client,
 		"azure.network":   &env.network.Client,
 	}
+	throttle, err := newThrottle(clock.WallClock)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	for id, client := range clients {
 		useragent.UpdateClient(client)
 		client.Authorizer = env.authorizer
 		logger := loggo.GetLogger(id)
+		var sender autorest.Sender = &http.Client{}
 		if env.provider.config.Sender != nil {
-			client.Sender = env.provider.config.Sender
+			sender = env.provider.config.Sender
 		}
+		client.Sender = throttle.HTTPDoer(id, sender)
 		client.ResponseInspector = tracing.RespondDecorator(logger)
 		client.RequestInspector = tracing.PrepareDecorator(logger)
 		if env.provider.config.RequestInspector != nil {
@@ -213,7 +226,7 @@ func (env *azureEnviron) PrepareForBootstrap(ctx environs.BootstrapContext) erro
 			return errors.Trace(err)
 		}
 	}
-	return nil
+	return errors.Trace(env.validateBootstrapNetwork(ctx))
 }
 
 // Create is part of the Environ interface.
@@ -221,6 +234,9 @@ func (env *azureEnviron) Create(args environs.CreateParams) error {
 	if err := verifyCredentials(env); err != nil {
 		return errors.Trace(err)
 	}
+	if err := env.validateModelNetwork(); err != nil {
+		return errors.Trace(err)
+	}
 	return errors.Trace(env.initResourceGroup(args.ControllerUUID, false))
 }
 
@@ -289,8 +305,9 @@ func (env *azureEnviron) createCommonResourceDeployment(
 	commonResources ...armtemplates.Resource,
 ) error {
 	const apiPort = -1
+	_, existingNetwork := env.virtualNetwork()
 	commonResources = append(commonResources, networkTemplateResources(
-		env.location, tags, apiPort, rules,
+		env.location, tags, apiPort, rules, existingNetwork != "",
 	)...)
 
 	// We perform this deployment asynchronously, to avoid blocking
@@ -372,6 +389,9 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Spot,
+		constraints.InstanceProfile,
+		constraints.RootDiskSource,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -394,8 +414,8 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 
 // PrecheckInstance is defined on the environs.InstancePrechecker interface.
 func (env *azureEnviron) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	if args.Placement != "" {
-		return fmt.Errorf("unknown placement directive: %s", args.Placement)
+	if _, err := env.parsePlacement(args.Placement); err != nil {
+		return errors.Trace(err)
 	}
 	if !args.Constraints.HasInstanceType() {
 		return nil
@@ -424,6 +444,12 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, errors.New("missing controller UUID")
 	}
 
+	if args.AvailabilityZone != "" {
+		if err := env.checkAvailabilityZone(args.AvailabilityZone); err != nil {
+			return nil, common.ZoneIndependentError(err)
+		}
+	}
+
 	// Get the required configuration and config-dependent information
 	// required to create the instance. We take the lock just once, to
 	// ensure we obtain all information based on the same configuration.
@@ -434,6 +460,7 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		env.config,
 	)
 	storageAccountType := env.config.storageAccountType
+	proximityPlacementGroups := env.config.proximityPlacementGroups
 	imageStream := env.config.ImageStream()
 	instanceTypes, err := env.getInstanceTypesLocked()
 	if err != nil {
@@ -519,11 +546,16 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 	// the Juju machine name. We tag all resources related to the
 	// machine with this.
 	vmTags[jujuMachineNameTag] = vmName
+	if args.AvailabilityZone != "" {
+		vmTags[jujuAvailabilityZoneTag] = args.AvailabilityZone
+	}
 
 	if err := env.createVirtualMachine(
 		vmName, vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
 		storageAccountType,
+		args.AvailabilityZone,
+		proximityPlacementGroups,
 	); err != nil {
 		logger.Errorf("creating instance failed, destroying: %v", err)
 		if err := env.StopInstances(instance.Id(vmName)); err != nil {
@@ -543,6 +575,9 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 		RootDisk: &instanceSpec.InstanceType.RootDisk,
 		CpuCores: &instanceSpec.InstanceType.CpuCores,
 	}
+	if args.AvailabilityZone != "" {
+		hc.AvailabilityZone = &args.AvailabilityZone
+	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
 		Hardware: hc,
@@ -553,12 +588,19 @@ func (env *azureEnviron) StartInstance(args environs.StartInstanceParams) (*envi
 //
 // All resources created are tagged with the specified "vmTags", so if
 // this function fails then all resources can be deleted by tag.
+//
+// If availabilityZone is non-empty, the virtual machine is created in
+// that zone rather than in an availability set. If proximityPlacementGroups
+// is true, the virtual machine is placed in a proximity placement group
+// shared with the other machines hosting the same application.
 func (env *azureEnviron) createVirtualMachine(
 	vmName string,
 	vmTags, envTags map[string]string,
 	instanceSpec *instances.InstanceSpec,
 	instanceConfig *instancecfg.InstanceConfig,
 	storageAccountType string,
+	availabilityZone string,
+	proximityPlacementGroups bool,
 ) error {
 
 	deploymentsClient := resources.DeploymentsClient{env.resources}
@@ -575,6 +617,7 @@ func (env *azureEnviron) createVirtualMachine(
 		apiPort = apiPorts[0]
 	}
 
+	_, existingNetwork := env.virtualNetwork()
 	var nicDependsOn, vmDependsOn []string
 	var resources []armtemplates.Resource
 	createCommonResources := instanceConfig.Bootstrap != nil
@@ -582,12 +625,16 @@ func (env *azureEnviron) createVirtualMachine(
 		// We're starting the bootstrap machine, so we will create the
 		// common resources in the same deployment.
 		resources = append(resources,
-			networkTemplateResources(env.location, envTags, apiPort, nil)...,
+			networkTemplateResources(env.location, envTags, apiPort, nil, existingNetwork != "")...,
 		)
-		nicDependsOn = append(nicDependsOn, fmt.Sprintf(
-			`[resourceId('Microsoft.Network/virtualNetworks', '%s')]`,
-			internalNetworkName,
-		))
+		if existingNetwork != "" {
+			nicDependsOn = append(nicDependsOn, internalSecurityGroupId)
+		} else {
+			nicDependsOn = append(nicDependsOn, fmt.Sprintf(
+				`[resourceId('Microsoft.Network/virtualNetworks', '%s')]`,
+				internalNetworkName,
+			))
+		}
 	} else {
 		// Wait for the common resource deployment to complete.
 		if err := env.waitCommonResourcesCreated(); err != nil {
@@ -633,6 +680,42 @@ func (env *azureEnviron) createVirtualMachine(
 	if err != nil {
 		return errors.Annotate(err, "getting availability set name")
 	}
+
+	// Machines hosting units of the same application share a
+	// proximity placement group, named after the application.
+	vmAPIVersion := computeAPIVersion
+	var proximityPlacementGroupSubResource *compute.SubResource
+	if proximityPlacementGroups && instanceConfig.Controller == nil && availabilitySetName != "" {
+		proximityPlacementGroupId := fmt.Sprintf(
+			`[resourceId('Microsoft.Compute/proximityPlacementGroups','%s')]`,
+			availabilitySetName,
+		)
+		resources = append(resources, armtemplates.Resource{
+			APIVersion: computePlacementAPIVersion,
+			Type:       "Microsoft.Compute/proximityPlacementGroups",
+			Name:       availabilitySetName,
+			Location:   env.location,
+			Tags:       envTags,
+			Properties: &proximityPlacementGroupProperties{
+				ProximityPlacementGroupType: "Standard",
+			},
+		})
+		proximityPlacementGroupSubResource = &compute.SubResource{
+			ID: to.StringPtr(proximityPlacementGroupId),
+		}
+		vmAPIVersion = computePlacementAPIVersion
+		vmDependsOn = append(vmDependsOn, proximityPlacementGroupId)
+	}
+
+	// Virtual machines in an availability zone cannot also be
+	// in an availability set.
+	var vmZones []string
+	if availabilityZone != "" {
+		vmZones = []string{availabilityZone}
+		vmAPIVersion = computePlacementAPIVersion
+		availabilitySetName = ""
+	}
+
 	if availabilitySetName != "" {
 		availabilitySetId := fmt.Sprintf(
 			`[resourceId('Microsoft.Compute/availabilitySets','%s')]`,
@@ -657,8 +740,19 @@ func (env *azureEnviron) createVirtualMachine(
 				PlatformFaultDomainCount: to.Int32Ptr(maxFaultDomains(env.location)),
 			}
 		}
+		availabilitySetAPIVersion := computeAPIVersion
+		if proximityPlacementGroupSubResource != nil {
+			// The availability set must be in the same proximity
+			// placement group as its virtual machines.
+			properties, _ := availabilitySetProperties.(*compute.AvailabilitySetProperties)
+			availabilitySetProperties = &availabilitySetPlacementProperties{
+				AvailabilitySetProperties: properties,
+				ProximityPlacementGroup:   proximityPlacementGroupSubResource,
+			}
+			availabilitySetAPIVersion = computePlacementAPIVersion
+		}
 		resources = append(resources, armtemplates.Resource{
-			APIVersion: computeAPIVersion,
+			APIVersion: availabilitySetAPIVersion,
 			Type:       "Microsoft.Compute/availabilitySets",
 			Name:       availabilitySetName,
 			Location:   env.location,
@@ -698,9 +792,29 @@ func (env *azureEnviron) createVirtualMachine(
 		internalNetworkName, subnetName,
 	)
 
-	privateIP, err := machineSubnetIP(subnetPrefix, instanceConfig.MachineId)
-	if err != nil {
-		return errors.Annotatef(err, "computing private IP address")
+	var privateIPAddress *string
+	privateIPAllocationMethod := network.Static
+	var nicSecurityGroup *network.SecurityGroup
+	if existingNetwork != "" {
+		// Addresses in an existing network are not ours to
+		// assign, so Azure allocates them; and the subnet is
+		// not associated with our security group, so the NIC
+		// must be.
+		subnet, err := env.existingSubnet()
+		if err != nil {
+			return errors.Annotate(err, "finding subnet")
+		}
+		subnetId = to.String(subnet.ID)
+		privateIPAllocationMethod = network.Dynamic
+		nicSecurityGroup = &network.SecurityGroup{
+			ID: to.StringPtr(internalSecurityGroupId),
+		}
+	} else {
+		privateIP, err := machineSubnetIP(subnetPrefix, instanceConfig.MachineId)
+		if err != nil {
+			return errors.Annotatef(err, "computing private IP address")
+		}
+		privateIPAddress = to.StringPtr(privateIP.String())
 	}
 	nicName := vmName + "-primary"
 	nicId := fmt.Sprintf(`[resourceId('Microsoft.Network/networkInterfaces', '%s')]`, nicName)
@@ -709,8 +823,8 @@ func (env *azureEnviron) createVirtualMachine(
 		Name: to.StringPtr("primary"),
 		InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
 			Primary:                   to.BoolPtr(true),
-			PrivateIPAddress:          to.StringPtr(privateIP.String()),
-			PrivateIPAllocationMethod: network.Static,
+			PrivateIPAddress:          privateIPAddress,
+			PrivateIPAllocationMethod: privateIPAllocationMethod,
 			Subnet: &network.Subnet{ID: to.StringPtr(subnetId)},
 			PublicIPAddress: &network.PublicIPAddress{
 				ID: to.StringPtr(publicIPAddressId),
@@ -724,7 +838,8 @@ func (env *azureEnviron) createVirtualMachine(
 		Location:   env.location,
 		Tags:       vmTags,
 		Properties: &network.InterfacePropertiesFormat{
-			IPConfigurations: &ipConfigurations,
+			IPConfigurations:     &ipConfigurations,
+			NetworkSecurityGroup: nicSecurityGroup,
 		},
 		DependsOn: nicDependsOn,
 	})
@@ -736,26 +851,34 @@ func (env *azureEnviron) createVirtualMachine(
 		},
 	}}
 	vmDependsOn = append(vmDependsOn, nicId)
+	var vmProperties interface{} = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(
+				instanceSpec.InstanceType.Name,
+			),
+		},
+		StorageProfile: storageProfile,
+		OsProfile:      osProfile,
+		NetworkProfile: &compute.NetworkProfile{
+			&nics,
+		},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	if proximityPlacementGroupSubResource != nil {
+		vmProperties = &virtualMachinePlacementProperties{
+			VirtualMachineProperties: vmProperties.(*compute.VirtualMachineProperties),
+			ProximityPlacementGroup:  proximityPlacementGroupSubResource,
+		}
+	}
 	resources = append(resources, armtemplates.Resource{
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       vmName,
 		Location:   env.location,
 		Tags:       vmTags,
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(
-					instanceSpec.InstanceType.Name,
-				),
-			},
-			StorageProfile: storageProfile,
-			OsProfile:      osProfile,
-			NetworkProfile: &compute.NetworkProfile{
-				&nics,
-			},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: vmDependsOn,
+		Properties: vmProperties,
+		DependsOn:  vmDependsOn,
+		Zones:      vmZones,
 	})
 
 	// On Windows and CentOS, we must add the CustomScript VM
@@ -799,6 +922,26 @@ func (env *azureEnviron) createVirtualMachine(
 	return nil
 }
 
+// virtualMachinePlacementProperties extends the virtual machine
+// properties known to the SDK with a proximity placement group.
+type virtualMachinePlacementProperties struct {
+	*compute.VirtualMachineProperties
+	ProximityPlacementGroup *compute.SubResource `json:"proximityPlacementGroup,omitempty"`
+}
+
+// availabilitySetPlacementProperties extends the availability set
+// properties known to the SDK with a proximity placement group.
+type availabilitySetPlacementProperties struct {
+	*compute.AvailabilitySetProperties
+	ProximityPlacementGroup *compute.SubResource `json:"proximityPlacementGroup,omitempty"`
+}
+
+// proximityPlacementGroupProperties holds the properties of a proximity
+// placement group, which the SDK does not know about.
+type proximityPlacementGroupProperties struct {
+	ProximityPlacementGroupType string `json:"proximityPlacementGroupType,omitempty"`
+}
+
 // maxFaultDomains returns the maximum number of fault domains for the
 // given location/region. The numbers were taken from
 // https://docs.microsoft.com/en-au/azure/virtual-machines/windows/manage-availability,
@@ -47,6 +47,7 @@ import (
 	"github.com/juju/juju/provider/azure/internal/armtemplates"
 	"github.com/juju/juju/provider/azure/internal/azurestorage"
 	"github.com/juju/juju/provider/azure/internal/azuretesting"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
 )
@@ -54,9 +55,10 @@ import (
 const (
 	storageAccountName = "juju400d80004b1d0d06f00d"
 
-	computeAPIVersion = "2016-04-30-preview"
-	networkAPIVersion = "2017-03-01"
-	storageAPIVersion = "2016-12-01"
+	computeAPIVersion          = "2016-04-30-preview"
+	computePlacementAPIVersion = "2018-04-01"
+	networkAPIVersion          = "2017-03-01"
+	storageAPIVersion          = "2016-12-01"
 )
 
 var (
@@ -742,20 +744,53 @@ func (s *environSuite) TestStartInstanceServiceAvailabilitySet(c *gc.C) {
 	})
 }
 
+func (s *environSuite) TestStartInstanceProximityPlacementGroup(c *gc.C) {
+	env := s.openEnviron(c, testing.Attrs{"proximity-placement-groups": true})
+	unitsDeployed := "mysql/0"
+	s.vmTags[tags.JujuUnitsDeployed] = &unitsDeployed
+	s.sender = s.startInstanceSenders(false)
+	s.requests = nil
+	params := makeStartInstanceParams(c, s.controllerUUID, "quantal")
+	params.InstanceConfig.Tags[tags.JujuUnitsDeployed] = unitsDeployed
+
+	_, err := env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStartInstanceRequests(c, s.requests, assertStartInstanceRequestsParams{
+		availabilitySetName:         "mysql",
+		proximityPlacementGroupName: "mysql",
+		imageReference:              &quantalImageReference,
+		diskSizeGB:                  32,
+		osProfile:                   &s.linuxOsProfile,
+		instanceType:                "Standard_A1",
+	})
+}
+
+func (s *environSuite) TestStartInstanceUnknownAvailabilityZone(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = nil
+	params := makeStartInstanceParams(c, s.controllerUUID, "quantal")
+	params.AvailabilityZone = "1"
+
+	_, err := env.StartInstance(params)
+	c.Assert(err, gc.ErrorMatches, `availability zones in location "westus" not implemented`)
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+}
+
 // numExpectedStartInstanceRequests is the number of expected requests base
 // by StartInstance method calls. The number is one less for Bootstrap, which
 // does not require a query on the common deployment.
 const numExpectedStartInstanceRequests = 4
 
 type assertStartInstanceRequestsParams struct {
-	availabilitySetName string
-	imageReference      *compute.ImageReference
-	vmExtension         *compute.VirtualMachineExtensionProperties
-	diskSizeGB          int
-	osProfile           *compute.OSProfile
-	needsProviderInit   bool
-	unmanagedStorage    bool
-	instanceType        string
+	availabilitySetName         string
+	proximityPlacementGroupName string
+	imageReference              *compute.ImageReference
+	vmExtension                 *compute.VirtualMachineExtensionProperties
+	diskSizeGB                  int
+	osProfile                   *compute.OSProfile
+	needsProviderInit           bool
+	unmanagedStorage            bool
+	instanceType                string
 }
 
 func (s *environSuite) assertStartInstanceRequests(
@@ -890,6 +925,30 @@ func (s *environSuite) assertStartInstanceRequests(
 		)
 	}
 
+	vmAPIVersion := computeAPIVersion
+	var proximityPlacementGroupSubResource *compute.SubResource
+	if args.proximityPlacementGroupName != "" {
+		proximityPlacementGroupId := fmt.Sprintf(
+			`[resourceId('Microsoft.Compute/proximityPlacementGroups','%s')]`,
+			args.proximityPlacementGroupName,
+		)
+		templateResources = append(templateResources, armtemplates.Resource{
+			APIVersion: computePlacementAPIVersion,
+			Type:       "Microsoft.Compute/proximityPlacementGroups",
+			Name:       args.proximityPlacementGroupName,
+			Location:   "westus",
+			Tags:       to.StringMap(s.envTags),
+			Properties: map[string]interface{}{
+				"proximityPlacementGroupType": "Standard",
+			},
+		})
+		proximityPlacementGroupSubResource = &compute.SubResource{
+			ID: to.StringPtr(proximityPlacementGroupId),
+		}
+		vmAPIVersion = computePlacementAPIVersion
+		vmDependsOn = append(vmDependsOn, proximityPlacementGroupId)
+	}
+
 	var availabilitySetSubResource *compute.SubResource
 	if args.availabilitySetName != "" {
 		availabilitySetId := fmt.Sprintf(
@@ -903,8 +962,19 @@ func (s *environSuite) assertStartInstanceRequests(
 				PlatformFaultDomainCount: to.Int32Ptr(3),
 			}
 		}
+		availabilitySetAPIVersion := computeAPIVersion
+		if proximityPlacementGroupSubResource != nil {
+			availabilitySetProperties = struct {
+				*compute.AvailabilitySetProperties
+				ProximityPlacementGroup *compute.SubResource `json:"proximityPlacementGroup"`
+			}{
+				availabilitySetProperties.(*compute.AvailabilitySetProperties),
+				proximityPlacementGroupSubResource,
+			}
+			availabilitySetAPIVersion = computePlacementAPIVersion
+		}
 		templateResources = append(templateResources, armtemplates.Resource{
-			APIVersion: computeAPIVersion,
+			APIVersion: availabilitySetAPIVersion,
 			Type:       "Microsoft.Compute/availabilitySets",
 			Name:       args.availabilitySetName,
 			Location:   "westus",
@@ -933,6 +1003,28 @@ func (s *environSuite) assertStartInstanceRequests(
 		}
 	}
 
+	var vmProperties interface{} = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(args.instanceType),
+		},
+		StorageProfile: &compute.StorageProfile{
+			ImageReference: args.imageReference,
+			OsDisk:         osDisk,
+		},
+		OsProfile:       args.osProfile,
+		NetworkProfile:  &compute.NetworkProfile{&nics},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	if proximityPlacementGroupSubResource != nil {
+		vmProperties = struct {
+			*compute.VirtualMachineProperties
+			ProximityPlacementGroup *compute.SubResource `json:"proximityPlacementGroup"`
+		}{
+			vmProperties.(*compute.VirtualMachineProperties),
+			proximityPlacementGroupSubResource,
+		}
+	}
+
 	templateResources = append(templateResources, []armtemplates.Resource{{
 		APIVersion: networkAPIVersion,
 		Type:       "Microsoft.Network/publicIPAddresses",
@@ -953,24 +1045,13 @@ func (s *environSuite) assertStartInstanceRequests(
 		},
 		DependsOn: append(nicDependsOn, publicIPAddressId),
 	}, {
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       "machine-0",
 		Location:   "westus",
 		Tags:       to.StringMap(s.vmTags),
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(args.instanceType),
-			},
-			StorageProfile: &compute.StorageProfile{
-				ImageReference: args.imageReference,
-				OsDisk:         osDisk,
-			},
-			OsProfile:       args.osProfile,
-			NetworkProfile:  &compute.NetworkProfile{&nics},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: append(vmDependsOn, nicId),
+		Properties: vmProperties,
+		DependsOn:  append(vmDependsOn, nicId),
 	}}...)
 	if args.vmExtension != nil {
 		templateResources = append(templateResources, armtemplates.Resource{
@@ -1400,6 +1481,30 @@ func (s *environSuite) constraintsValidator(c *gc.C) constraints.Validator {
 	return validator
 }
 
+func (s *environSuite) TestAvailabilityZonesUnsupportedLocation(c *gc.C) {
+	env := s.openEnviron(c)
+	zonedEnv := env.(common.ZonedEnviron)
+	_, err := zonedEnv.AvailabilityZones()
+	c.Assert(err, gc.ErrorMatches, `availability zones in location "westus" not implemented`)
+	_, err = zonedEnv.InstanceAvailabilityZoneNames([]instance.Id{"machine-0"})
+	c.Assert(err, gc.ErrorMatches, `availability zones in location "westus" not implemented`)
+}
+
+func (s *environSuite) TestPrecheckInstancePlacement(c *gc.C) {
+	env := s.openEnviron(c)
+	err := env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:    "quantal",
+		Placement: "foo=bar",
+	})
+	c.Assert(err, gc.ErrorMatches, "unknown placement directive: foo=bar")
+
+	err = env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:    "quantal",
+		Placement: "zone=1",
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zones in location "westus" not implemented`)
+}
+
 func (s *environSuite) TestAgentMirror(c *gc.C) {
 	env := s.openEnviron(c)
 	c.Assert(env, gc.Implements, new(envtools.HasAgentMirror))
@@ -1801,3 +1906,84 @@ func (s *environSuite) TestAdoptResourcesErrorUpdating(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `failed to update controller for some resources: \[boxing-day-blues\]`)
 	c.Check(s.requests, gc.HasLen, 8)
 }
+
+func (s *environSuite) prepareForBootstrapExistingNetwork(c *gc.C, senders ...autorest.Sender) error {
+	cfg := makeTestModelConfig(c, testing.Attrs{"network": "network-group/juju-network"})
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.sender = azuretesting.Senders{
+		discoverAuthSender(),
+		tokenRefreshSender(),
+	}
+	s.sender = append(s.sender, senders...)
+	return env.PrepareForBootstrap(envtesting.BootstrapContext(c))
+}
+
+func existingNetworkWithSubnet(subnet network.Subnet) network.VirtualNetwork {
+	return network.VirtualNetwork{
+		Location: to.StringPtr("West US"),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			Subnets: &[]network.Subnet{subnet},
+		},
+	}
+}
+
+func (s *environSuite) TestPrepareForBootstrapExistingNetwork(c *gc.C) {
+	vnet := existingNetworkWithSubnet(network.Subnet{
+		ID:   to.StringPtr("/subscriptions/sub/resourceGroups/network-group/providers/Microsoft.Network/virtualNetworks/juju-network/subnets/default"),
+		Name: to.StringPtr("default"),
+	})
+	err := s.prepareForBootstrapExistingNetwork(c,
+		s.makeSender(".*/resourceGroups/network-group/providers/Microsoft.Network/virtualNetworks/juju-network", vnet),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environSuite) TestPrepareForBootstrapExistingNetworkNotFound(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithStatus(
+		"virtual network not found", http.StatusNotFound,
+	))
+	err := s.prepareForBootstrapExistingNetwork(c, sender)
+	c.Assert(err, gc.ErrorMatches, `(?s)Juju cannot use the given network for bootstrapping.*`+
+		`virtual network "juju-network" in resource group "network-group" not found`)
+}
+
+func (s *environSuite) TestPrepareForBootstrapExistingNetworkNoSubnets(c *gc.C) {
+	vnet := network.VirtualNetwork{
+		Location:                       to.StringPtr("westus"),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{},
+	}
+	err := s.prepareForBootstrapExistingNetwork(c, s.makeSender(".*/virtualNetworks/juju-network", vnet))
+	c.Assert(err, gc.ErrorMatches, `(?s)Juju cannot use the given network.*subnets in virtual network "juju-network" not found`)
+}
+
+func (s *environSuite) TestPrepareForBootstrapExistingNetworkNoEgress(c *gc.C) {
+	vnet := existingNetworkWithSubnet(network.Subnet{
+		Name: to.StringPtr("isolated"),
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			RouteTable: &network.RouteTable{
+				ID: to.StringPtr("/subscriptions/sub/resourceGroups/network-group/providers/Microsoft.Network/routeTables/no-internet"),
+			},
+		},
+	})
+	routeTable := network.RouteTable{
+		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+			Routes: &[]network.Route{{
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix: to.StringPtr("0.0.0.0/0"),
+					NextHopType:   network.RouteNextHopTypeNone,
+				},
+			}},
+		},
+	}
+	err := s.prepareForBootstrapExistingNetwork(c,
+		s.makeSender(".*/virtualNetworks/juju-network", vnet),
+		s.makeSender(".*/resourceGroups/network-group/providers/Microsoft.Network/routeTables/no-internet", routeTable),
+	)
+	c.Assert(err, gc.ErrorMatches, `(?s)Machines connected to the given network will not be able to reach the.*`+
+		`route table "no-internet" drops traffic from subnet "isolated" to 0.0.0.0/0`)
+}
@@ -63,6 +63,11 @@ type ProviderConfig struct {
 
 	// AzureCLI is the interface the to Azure CLI (az) command.
 	AzureCLI AzureCLI
+
+	// InstanceMetadata is the interface to the Azure Instance
+	// Metadata Service, used for detecting managed identity
+	// credentials.
+	InstanceMetadata InstanceMetadata
 }
 
 // Validate validates the Azure provider configuration.
@@ -85,6 +90,9 @@ func (cfg ProviderConfig) Validate() error {
 	if cfg.AzureCLI == nil {
 		return errors.NotValidf("nil AzureCLI")
 	}
+	if cfg.InstanceMetadata == nil {
+		return errors.NotValidf("nil InstanceMetadata")
+	}
 	return nil
 }
 
@@ -103,6 +111,7 @@ func NewEnvironProvider(config ProviderConfig) (*azureEnvironProvider, error) {
 		environProviderCredentials: environProviderCredentials{
 			servicePrincipalCreator: config.ServicePrincipalCreator,
 			azureCLI:                config.AzureCLI,
+			instanceMetadata:        config.InstanceMetadata,
 		},
 		config: config,
 	}, nil
@@ -160,7 +169,9 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	if spec.Credential == nil {
 		return errors.NotValidf("missing credential")
 	}
-	if authType := spec.Credential.AuthType(); authType != clientCredentialsAuthType {
+	switch authType := spec.Credential.AuthType(); authType {
+	case clientCredentialsAuthType, managedIdentityAuthType:
+	default:
 		return errors.NotSupportedf("%q auth-type", authType)
 	}
 	return nil
@@ -81,6 +81,19 @@ func (s *environProviderSuite) TestOpen(c *gc.C) {
 	c.Assert(env, gc.NotNil)
 }
 
+func (s *environProviderSuite) TestOpenManagedIdentity(c *gc.C) {
+	credential := cloud.NewCredential("managed-identity", map[string]string{
+		"subscription-id": fakeSubscriptionId,
+	})
+	s.spec.Credential = &credential
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: makeTestModelConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.NotNil)
+}
+
 func (s *environProviderSuite) TestOpenMissingCredential(c *gc.C) {
 	s.spec.Credential = nil
 	s.testOpenError(c, s.spec, `validating cloud spec: missing credential not valid`)
@@ -114,6 +127,9 @@ func newProvider(c *gc.C, config azure.ProviderConfig) environs.EnvironProvider
 	if config.AzureCLI == nil {
 		config.AzureCLI = azurecli.AzureCLI{}
 	}
+	if config.InstanceMetadata == nil {
+		config.InstanceMetadata = &instanceMetadata{}
+	}
 	config.RandomWindowsAdminPassword = func() string { return "sorandom" }
 	config.GenerateSSHKey = func(string) (string, string, error) {
 		return "private", "public", nil
@@ -36,6 +36,7 @@ func init() {
 		GenerateSSHKey:             ssh.GenerateKey,
 		ServicePrincipalCreator:    &azureauth.ServicePrincipalCreator{},
 		AzureCLI:                   azurecli.AzureCLI{},
+		InstanceMetadata:           azureauth.InstanceMetadataClient{},
 	})
 	if err != nil {
 		panic(err)
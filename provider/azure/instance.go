@@ -266,7 +266,7 @@ func (inst *azureInstance) OpenPorts(machineId string, rules []jujunetwork.Ingre
 		from := rule.SourceCIDRs[0]
 		securityRule := network.SecurityRule{
 			SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(rule.String()),
+				Description:              to.StringPtr(rule.ProviderDescription(securityRuleDescriptionMax)),
 				Protocol:                 protocol,
 				SourcePortRange:          to.StringPtr("*"),
 				DestinationPortRange:     to.StringPtr(portRange),
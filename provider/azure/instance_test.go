@@ -436,7 +436,9 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	err := fwInst.OpenPorts("0", []jujunetwork.IngressRule{
 		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
 		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
-		jujunetwork.MustNewIngressRule("tcp", 1000, 2000, "192.168.1.0/24", "10.0.0.0/24"),
+		jujunetwork.MustNewIngressRule("tcp", 1000, 2000, "192.168.1.0/24", "10.0.0.0/24").WithProvenance(
+			"unit-wordpress-0", "wordpress/0 (exposed)",
+		),
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -477,7 +479,7 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	c.Assert(s.requests[3].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-1000-2000-cidr-192-168-1-0-24"))
 	assertRequestBody(c, s.requests[3], &network.SecurityRule{
 		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Description:              to.StringPtr("1000-2000/tcp from 192.168.1.0/24"),
+			Description:              to.StringPtr("1000-2000/tcp from 192.168.1.0/24: wordpress/0 (exposed)"),
 			Protocol:                 network.SecurityRuleProtocolTCP,
 			SourcePortRange:          to.StringPtr("*"),
 			SourceAddressPrefix:      to.StringPtr("192.168.1.0/24"),
@@ -492,7 +494,7 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	c.Assert(s.requests[4].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-1000-2000-cidr-10-0-0-0-24"))
 	assertRequestBody(c, s.requests[4], &network.SecurityRule{
 		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
-			Description:              to.StringPtr("1000-2000/tcp from 10.0.0.0/24"),
+			Description:              to.StringPtr("1000-2000/tcp from 10.0.0.0/24: wordpress/0 (exposed)"),
 			Protocol:                 network.SecurityRuleProtocolTCP,
 			SourcePortRange:          to.StringPtr("*"),
 			SourceAddressPrefix:      to.StringPtr("10.0.0.0/24"),
@@ -41,4 +41,5 @@ type Resource struct {
 
 	// Non-uniform attributes.
 	StorageSku *storage.Sku `json:"sku,omitempty"`
+	Zones      []string     `json:"zones,omitempty"`
 }
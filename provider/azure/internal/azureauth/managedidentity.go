@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azureauth
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/provider/azure/internal/useragent"
+)
+
+const (
+	// instanceMetadataEndpoint is the endpoint of the Azure Instance
+	// Metadata Service (IMDS), which is reachable only from within
+	// Azure virtual machines.
+	instanceMetadataEndpoint = "http://169.254.169.254"
+
+	// instanceMetadataAPIVersion is the version of the IMDS
+	// compute metadata API that we use.
+	instanceMetadataAPIVersion = "2017-08-01"
+
+	// managedIdentityAPIVersion is the version of the IMDS
+	// managed identity token API that we use.
+	managedIdentityAPIVersion = "2018-02-01"
+
+	// managedIdentityRefreshWindow is how long before a managed
+	// identity token expires that we will obtain a new one.
+	managedIdentityRefreshWindow = 5 * time.Minute
+)
+
+// InstanceMetadataClient is a client for the Azure Instance Metadata
+// Service.
+type InstanceMetadataClient struct {
+	// Sender is the autorest.Sender used to send requests to the
+	// metadata service. If Sender is nil, the default HTTP client
+	// sender will be used.
+	Sender autorest.Sender
+}
+
+// SubscriptionId returns the ID of the subscription that the virtual
+// machine we are running on belongs to. If we are not running in an
+// Azure virtual machine, an error will be returned.
+func (c InstanceMetadataClient) SubscriptionId() (string, error) {
+	var metadata struct {
+		SubscriptionId string `json:"subscriptionId"`
+	}
+	if err := instanceMetadataRequest(
+		c.Sender, "/metadata/instance/compute",
+		map[string]interface{}{"api-version": instanceMetadataAPIVersion},
+		&metadata,
+	); err != nil {
+		return "", errors.Annotate(err, "querying instance metadata")
+	}
+	if metadata.SubscriptionId == "" {
+		return "", errors.NotFoundf("subscription ID in instance metadata")
+	}
+	return metadata.SubscriptionId, nil
+}
+
+// ManagedIdentityToken is an OAuth token for a managed identity,
+// obtained from the Azure Instance Metadata Service. The token is
+// renewed by EnsureFresh when it is close to expiry.
+type ManagedIdentityToken struct {
+	resource string
+	clientId string
+	sender   autorest.Sender
+	now      func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	expiresOn   time.Time
+}
+
+// NewManagedIdentityToken returns a new ManagedIdentityToken for
+// accessing the specified resource. If clientId is empty, the token
+// will be for the virtual machine's system-assigned identity; otherwise
+// it will be for the user-assigned identity with the given client ID.
+func NewManagedIdentityToken(resource, clientId string, sender autorest.Sender) *ManagedIdentityToken {
+	return &ManagedIdentityToken{
+		resource: resource,
+		clientId: clientId,
+		sender:   sender,
+		now:      time.Now,
+	}
+}
+
+// OAuthToken returns the current access token. Callers should call
+// EnsureFresh before using the token.
+func (t *ManagedIdentityToken) OAuthToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.accessToken
+}
+
+// EnsureFresh obtains a new access token if there is no current token,
+// or the current token is about to expire.
+func (t *ManagedIdentityToken) EnsureFresh() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.accessToken != "" && t.now().Add(managedIdentityRefreshWindow).Before(t.expiresOn) {
+		return nil
+	}
+	return t.refresh()
+}
+
+// Refresh obtains a new access token.
+func (t *ManagedIdentityToken) Refresh() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.refresh()
+}
+
+func (t *ManagedIdentityToken) refresh() error {
+	params := map[string]interface{}{
+		"api-version": managedIdentityAPIVersion,
+		"resource":    t.resource,
+	}
+	if t.clientId != "" {
+		params["client_id"] = t.clientId
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := instanceMetadataRequest(
+		t.sender, "/metadata/identity/oauth2/token", params, &token,
+	); err != nil {
+		return errors.Annotate(err, "obtaining managed identity token")
+	}
+	expiresOn, err := strconv.ParseInt(token.ExpiresOn, 10, 64)
+	if err != nil {
+		return errors.Annotatef(err, "parsing token expiry %q", token.ExpiresOn)
+	}
+	t.accessToken = token.AccessToken
+	t.expiresOn = time.Unix(expiresOn, 0)
+	return nil
+}
+
+func instanceMetadataRequest(
+	sender autorest.Sender,
+	path string,
+	params map[string]interface{},
+	result interface{},
+) error {
+	client := autorest.NewClientWithUserAgent("")
+	useragent.UpdateClient(&client)
+	if sender != nil {
+		client.Sender = sender
+	}
+	req, err := autorest.Prepare(
+		&http.Request{},
+		autorest.AsGet(),
+		autorest.WithBaseURL(instanceMetadataEndpoint),
+		autorest.WithPath(path),
+		autorest.WithQueryParameters(params),
+		autorest.WithHeader("Metadata", "true"),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(result),
+		autorest.ByClosing(),
+	); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
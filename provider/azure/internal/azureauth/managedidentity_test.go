@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azureauth_test
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/mocks"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/azure/internal/azureauth"
+)
+
+type ManagedIdentitySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManagedIdentitySuite{})
+
+func tokenResponse(token string, expiresOn time.Time) *http.Response {
+	return mocks.NewResponseWithContent(fmt.Sprintf(
+		`{"access_token":%q,"expires_on":"%d","token_type":"Bearer"}`,
+		token, expiresOn.Unix(),
+	))
+}
+
+func (*ManagedIdentitySuite) TestSubscriptionId(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithContent(
+		`{"location":"westus","subscriptionId":"22222222-2222-2222-2222-222222222222"}`,
+	))
+	client := azureauth.InstanceMetadataClient{Sender: sender}
+	subscriptionId, err := client.SubscriptionId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subscriptionId, gc.Equals, "22222222-2222-2222-2222-222222222222")
+}
+
+func (*ManagedIdentitySuite) TestSubscriptionIdError(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.SetError(fmt.Errorf("no route to host"))
+	client := azureauth.InstanceMetadataClient{Sender: sender}
+	_, err := client.SubscriptionId()
+	c.Assert(err, gc.ErrorMatches, "querying instance metadata: no route to host")
+}
+
+func (*ManagedIdentitySuite) TestEnsureFresh(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(tokenResponse("first", time.Now().Add(time.Hour)))
+	sender.AppendResponse(tokenResponse("second", time.Now().Add(time.Hour)))
+
+	token := azureauth.NewManagedIdentityToken("https://management.azure.com/", "", sender)
+	c.Assert(token.EnsureFresh(), jc.ErrorIsNil)
+	c.Assert(token.OAuthToken(), gc.Equals, "first")
+
+	// The token is fresh, so it should not be renewed.
+	c.Assert(token.EnsureFresh(), jc.ErrorIsNil)
+	c.Assert(token.OAuthToken(), gc.Equals, "first")
+	c.Assert(sender.Attempts(), gc.Equals, 1)
+
+	c.Assert(token.Refresh(), jc.ErrorIsNil)
+	c.Assert(token.OAuthToken(), gc.Equals, "second")
+}
+
+func (*ManagedIdentitySuite) TestEnsureFreshRenewsExpiringToken(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(tokenResponse("first", time.Now().Add(time.Minute)))
+	sender.AppendResponse(tokenResponse("second", time.Now().Add(time.Hour)))
+
+	token := azureauth.NewManagedIdentityToken("https://management.azure.com/", "", sender)
+	c.Assert(token.EnsureFresh(), jc.ErrorIsNil)
+	c.Assert(token.OAuthToken(), gc.Equals, "first")
+	c.Assert(token.EnsureFresh(), jc.ErrorIsNil)
+	c.Assert(token.OAuthToken(), gc.Equals, "second")
+}
+
+func (*ManagedIdentitySuite) TestUserAssignedIdentity(c *gc.C) {
+	var requests []*http.Request
+	sender := mocks.NewSender()
+	sender.AppendResponse(tokenResponse("token", time.Now().Add(time.Hour)))
+	token := azureauth.NewManagedIdentityToken(
+		"https://management.azure.com/", "client-id",
+		autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			return sender.Do(req)
+		}),
+	)
+	c.Assert(token.Refresh(), jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Header.Get("Metadata"), gc.Equals, "true")
+	c.Assert(requests[0].URL.Path, gc.Equals, "/metadata/identity/oauth2/token")
+	query := requests[0].URL.Query()
+	c.Assert(query.Get("client_id"), gc.Equals, "client-id")
+	c.Assert(query.Get("resource"), gc.Equals, "https://management.azure.com/")
+}
+
+func (*ManagedIdentitySuite) TestRefreshError(c *gc.C) {
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithStatus("400 Bad Request", http.StatusBadRequest))
+	token := azureauth.NewManagedIdentityToken("https://management.azure.com/", "", sender)
+	err := token.Refresh()
+	c.Assert(err, gc.ErrorMatches, "obtaining managed identity token: .*")
+}
@@ -82,6 +82,14 @@ func SeriesImage(
 			publisher = windowsServerPublisher
 			offering = windowsServerOffering
 			sku = "2012-R2-Datacenter"
+		case "win2016":
+			publisher = windowsServerPublisher
+			offering = windowsServerOffering
+			sku = "2016-Datacenter"
+		case "win2016nano":
+			publisher = windowsServerPublisher
+			offering = windowsServerOffering
+			sku = "2016-Nano-Server"
 		default:
 			return nil, errors.NotSupportedf("deploying %s", series)
 		}
@@ -60,6 +60,8 @@ func (s *imageutilsSuite) TestSeriesImageInvalidSKU(c *gc.C) {
 }
 
 func (s *imageutilsSuite) TestSeriesImageWindows(c *gc.C) {
+	s.assertImageId(c, "win2016", "daily", "MicrosoftWindowsServer:WindowsServer:2016-Datacenter:latest")
+	s.assertImageId(c, "win2016nano", "daily", "MicrosoftWindowsServer:WindowsServer:2016-Nano-Server:latest")
 	s.assertImageId(c, "win2012r2", "daily", "MicrosoftWindowsServer:WindowsServer:2012-R2-Datacenter:latest")
 	s.assertImageId(c, "win2012", "daily", "MicrosoftWindowsServer:WindowsServer:2012-Datacenter:latest")
 	s.assertImageId(c, "win81", "daily", "MicrosoftVisualStudio:Windows:8.1-Enterprise-N:latest")
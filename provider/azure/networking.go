@@ -59,6 +59,10 @@ const (
 	// securityRuleMax is the maximum allowable security rule
 	// priority.
 	securityRuleMax = 4096
+
+	// securityRuleDescriptionMax is the maximum length of a
+	// security rule description.
+	securityRuleDescriptionMax = 140
 )
 
 const (
@@ -105,16 +109,28 @@ var (
 	}
 )
 
+// internalSecurityGroupId is the template expression for the ID of the
+// network security group created by networkTemplateResources.
+var internalSecurityGroupId = fmt.Sprintf(
+	`[resourceId('Microsoft.Network/networkSecurityGroups', '%s')]`,
+	internalSecurityGroupName,
+)
+
 // networkTemplateResources returns resource definitions for creating network
 // resources shared by all machines in a model.
 //
 // If apiPort is -1, then there should be no controller subnet created, and
 // no network security rule allowing Juju API traffic.
+//
+// If existingNetwork is true, machines are connected to an existing virtual
+// network, so only the network security group is created. The security group
+// is then associated with each machine's NIC rather than with a subnet.
 func networkTemplateResources(
 	location string,
 	envTags map[string]string,
 	apiPort int,
 	extraRules []network.SecurityRule,
+	existingNetwork bool,
 ) []armtemplates.Resource {
 	// Create a network security group for the environment. There is only
 	// one NSG per environment (there's a limit of 100 per subscription),
@@ -124,15 +140,32 @@ func networkTemplateResources(
 		apiSecurityRule := apiSecurityRule
 		properties := *apiSecurityRule.SecurityRulePropertiesFormat
 		properties.DestinationPortRange = to.StringPtr(fmt.Sprint(apiPort))
+		if existingNetwork {
+			// Controller machines do not have a subnet of their
+			// own in an existing network; the rule applies to
+			// the NICs the security group is associated with.
+			properties.DestinationAddressPrefix = to.StringPtr("*")
+		}
 		apiSecurityRule.SecurityRulePropertiesFormat = &properties
 		securityRules = append(securityRules, apiSecurityRule)
 	}
 	securityRules = append(securityRules, extraRules...)
 
-	nsgId := fmt.Sprintf(
-		`[resourceId('Microsoft.Network/networkSecurityGroups', '%s')]`,
-		internalSecurityGroupName,
-	)
+	nsgResource := armtemplates.Resource{
+		APIVersion: networkAPIVersion,
+		Type:       "Microsoft.Network/networkSecurityGroups",
+		Name:       internalSecurityGroupName,
+		Location:   location,
+		Tags:       envTags,
+		Properties: &network.SecurityGroupPropertiesFormat{
+			SecurityRules: &securityRules,
+		},
+	}
+	if existingNetwork {
+		return []armtemplates.Resource{nsgResource}
+	}
+
+	nsgId := internalSecurityGroupId
 	subnets := []network.Subnet{{
 		Name: to.StringPtr(internalSubnetName),
 		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
@@ -155,16 +188,7 @@ func networkTemplateResources(
 	}
 
 	addressPrefixes := []string{internalSubnetPrefix, controllerSubnetPrefix}
-	resources := []armtemplates.Resource{{
-		APIVersion: networkAPIVersion,
-		Type:       "Microsoft.Network/networkSecurityGroups",
-		Name:       internalSecurityGroupName,
-		Location:   location,
-		Tags:       envTags,
-		Properties: &network.SecurityGroupPropertiesFormat{
-			SecurityRules: &securityRules,
-		},
-	}, {
+	resources := []armtemplates.Resource{nsgResource, {
 		APIVersion: networkAPIVersion,
 		Type:       "Microsoft.Network/virtualNetworks",
 		Name:       internalNetworkName,
@@ -451,6 +451,40 @@ func (v *azureVolumeSource) describeUnmanagedDiskVolumes(volumeIds []string) ([]
 	return results, nil
 }
 
+// ResizeVolume is specified on the storage.VolumeResizer interface.
+func (v *azureVolumeSource) ResizeVolume(volumeId string, size uint64) (storage.VolumeInfo, error) {
+	if v.maybeStorageClient != nil {
+		return storage.VolumeInfo{}, errors.NotSupportedf("resizing unmanaged disks")
+	}
+	diskClient := disk.DisksClient{v.env.disk}
+	diskModel, err := diskClient.Get(v.env.resourceGroup, volumeId)
+	if err != nil {
+		if isNotFoundResponse(diskModel.Response) {
+			return storage.VolumeInfo{}, errors.NotFoundf("disk %s", volumeId)
+		}
+		return storage.VolumeInfo{}, errors.Annotatef(err, "getting disk %q", volumeId)
+	}
+	if diskModel.Properties == nil {
+		diskModel.Properties = &disk.Properties{}
+	}
+	sizeInGib := mibToGib(size)
+	if uint64(to.Int32(diskModel.DiskSizeGB)) < sizeInGib {
+		// Azure only allows disks to be resized when they are
+		// unattached, or attached to a deallocated machine.
+		diskModel.DiskSizeGB = to.Int32Ptr(int32(sizeInGib))
+		resultCh, errCh := diskClient.CreateOrUpdate(v.env.resourceGroup, volumeId, diskModel, nil)
+		diskModel, err = <-resultCh, <-errCh
+		if err != nil {
+			return storage.VolumeInfo{}, errors.Annotatef(err, "resizing disk %q", volumeId)
+		}
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(to.Int32(diskModel.DiskSizeGB))),
+		Persistent: true,
+	}, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	if v.maybeStorageClient == nil {
@@ -556,6 +556,70 @@ func (s *storageSuite) TestDescribeVolumesLegacy(c *gc.C) {
 	c.Assert(results[3].Error, gc.ErrorMatches, "volume-42 not found")
 }
 
+func (s *storageSuite) TestResizeVolume(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	getSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(1),
+		},
+	})
+	getSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	putSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(2),
+		},
+	})
+	putSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	s.requests = nil
+	s.sender = azuretesting.Senders{getSender, putSender}
+
+	resizer, ok := volumeSource.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	info, err := resizer.ResizeVolume("volume-0", 1025)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "volume-0",
+		Size:       2 * 1024,
+		Persistent: true,
+	})
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	assertRequestBody(c, s.requests[1], &disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(2),
+		},
+	})
+}
+
+func (s *storageSuite) TestResizeVolumeAlreadyLargeEnough(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	getSender := azuretesting.NewSenderWithValue(&disk.Model{
+		Name: to.StringPtr("volume-0"),
+		Properties: &disk.Properties{
+			DiskSizeGB: to.Int32Ptr(2),
+		},
+	})
+	getSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	s.requests = nil
+	s.sender = azuretesting.Senders{getSender}
+
+	info, err := volumeSource.(storage.VolumeResizer).ResizeVolume("volume-0", 1025)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2*1024))
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *storageSuite) TestResizeVolumeLegacy(c *gc.C) {
+	volumeSource := s.volumeSource(c, true)
+	_, err := volumeSource.(storage.VolumeResizer).ResizeVolume("volume-0", 1025)
+	c.Assert(err, gc.ErrorMatches, "resizing unmanaged disks not supported")
+}
+
 func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	volumeSource := s.volumeSource(c, false)
 
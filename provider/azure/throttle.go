@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/provider/common"
+)
+
+// newThrottle returns a throttle for requests to the Azure Resource
+// Manager API. Each API client is throttled as a separate family.
+// Azure Resource Manager allows each subscription around 12000 reads
+// an hour, and replies to requests exceeding its limits with
+// "429 Too Many Requests" and a Retry-After header.
+func newThrottle(clock clock.Clock) (*common.Throttle, error) {
+	config := common.DefaultThrottleConfig()
+	config.Clock = clock
+	config.Default = common.ThrottleRate{Rate: 3, Burst: 100}
+	throttle, err := common.NewThrottle(config)
+	return throttle, errors.Trace(err)
+}
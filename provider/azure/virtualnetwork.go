@@ -0,0 +1,200 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+)
+
+// defaultRoutePrefix is the address prefix of a default route.
+const defaultRoutePrefix = "0.0.0.0/0"
+
+var (
+	networkNotUsableForBootstrapErrorPrefix = `
+Juju cannot use the given network for bootstrapping a controller
+instance. Please double check the given virtual network exists, is in
+the same location as the model, and contains at least one subnet.
+
+Error details`[1:]
+
+	networkNotUsableForModelErrorPrefix = `
+Juju cannot use the given network for the model being added.
+Please double check the given virtual network exists, is in the same
+location as the model, and contains at least one subnet.
+
+Error details`[1:]
+
+	networkNoEgressErrorPrefix = `
+Machines connected to the given network will not be able to reach the
+internet, because the route table of the subnet Juju would use drops
+traffic to 0.0.0.0/0. Please use a subnet whose traffic to the internet
+is routed to the internet or to a network virtual appliance.
+
+Error details`[1:]
+)
+
+// noInternetEgressError indicates that machines in an existing virtual
+// network's subnet cannot reach the internet.
+type noInternetEgressError struct {
+	errors.Err
+}
+
+// isNoInternetEgressError reports whether err was caused by a
+// noInternetEgressError.
+func isNoInternetEgressError(err error) bool {
+	_, ok := errors.Cause(err).(*noInternetEgressError)
+	return ok
+}
+
+// virtualNetwork returns the resource group and name of the existing
+// virtual network that machines are connected to. The name is empty
+// if Juju creates a network for the model.
+func (env *azureEnviron) virtualNetwork() (resourceGroup, name string) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	resourceGroup = env.config.virtualNetworkResourceGroup
+	if resourceGroup == "" {
+		resourceGroup = env.resourceGroup
+	}
+	return resourceGroup, env.config.virtualNetworkName
+}
+
+// existingSubnet returns the subnet of the existing virtual network that
+// machines are connected to. The subnet with the lowest name is used, so
+// that all machines are in the same subnet.
+func (env *azureEnviron) existingSubnet() (*network.Subnet, error) {
+	resourceGroup, name := env.virtualNetwork()
+	client := network.VirtualNetworksClient{env.network}
+	vnet, err := client.Get(resourceGroup, name, "")
+	if err != nil {
+		if isNotFoundResponse(vnet.Response) {
+			return nil, errors.NotFoundf("virtual network %q in resource group %q", name, resourceGroup)
+		}
+		return nil, errors.Annotatef(err, "querying virtual network %q", name)
+	}
+	if vnet.Location != nil && canonicalLocation(*vnet.Location) != env.location {
+		return nil, errors.NotValidf(
+			"virtual network %q in location %q, model location is %q",
+			name, *vnet.Location, env.location,
+		)
+	}
+	var subnets []network.Subnet
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		subnets = *vnet.Subnets
+	}
+	if len(subnets) == 0 {
+		return nil, errors.NotFoundf("subnets in virtual network %q", name)
+	}
+	sort.Sort(subnetsByName(subnets))
+	return &subnets[0], nil
+}
+
+// validateVirtualNetwork returns an error if the model is configured to
+// use an existing virtual network that cannot be used. An error satisfying
+// isNoInternetEgressError is returned if machines connected to the network
+// would not be able to reach the internet.
+func (env *azureEnviron) validateVirtualNetwork() error {
+	subnet, err := env.existingSubnet()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil || subnet.RouteTable.ID == nil {
+		// Without a route table, Azure routes traffic
+		// to the internet by default.
+		return nil
+	}
+	resourceGroup, name, ok := parseResourceID(to.String(subnet.RouteTable.ID))
+	if !ok {
+		return errors.Errorf("cannot parse route table ID %q", to.String(subnet.RouteTable.ID))
+	}
+	client := network.RouteTablesClient{env.network}
+	routeTable, err := client.Get(resourceGroup, name, "")
+	if err != nil {
+		return errors.Annotatef(err, "querying route table %q", name)
+	}
+	if routeTable.RouteTablePropertiesFormat == nil || routeTable.Routes == nil {
+		return nil
+	}
+	for _, route := range *routeTable.Routes {
+		if route.RoutePropertiesFormat == nil || to.String(route.AddressPrefix) != defaultRoutePrefix {
+			continue
+		}
+		if route.NextHopType == network.RouteNextHopTypeNone {
+			err := errors.Errorf(
+				"route table %q drops traffic from subnet %q to %s",
+				name, to.String(subnet.Name), defaultRoutePrefix,
+			)
+			return &noInternetEgressError{*err.(*errors.Err)}
+		}
+	}
+	return nil
+}
+
+// validateBootstrapNetwork returns an error if the existing virtual
+// network that the controller would be connected to cannot be used.
+func (env *azureEnviron) validateBootstrapNetwork(ctx environs.BootstrapContext) error {
+	resourceGroup, name := env.virtualNetwork()
+	if name == "" {
+		return nil
+	}
+	err := env.validateVirtualNetwork()
+	switch {
+	case isNoInternetEgressError(err):
+		return errors.Annotate(err, networkNoEgressErrorPrefix)
+	case err != nil:
+		return errors.Annotate(err, networkNotUsableForBootstrapErrorPrefix)
+	}
+	ctx.Infof("Using virtual network %q in resource group %q", name, resourceGroup)
+	return nil
+}
+
+// validateModelNetwork returns an error if the existing virtual network
+// that the model's machines would be connected to cannot be used.
+func (env *azureEnviron) validateModelNetwork() error {
+	_, name := env.virtualNetwork()
+	if name == "" {
+		return nil
+	}
+	err := env.validateVirtualNetwork()
+	switch {
+	case isNoInternetEgressError(err):
+		// Less important for hosted models, as the
+		// controller is already accessible.
+		logger.Warningf("machines in virtual network %q will not reach the internet: %v", name, err)
+	case err != nil:
+		return errors.Annotate(err, networkNotUsableForModelErrorPrefix)
+	}
+	logger.Infof("Using virtual network %q for model %q", name, env.envName)
+	return nil
+}
+
+// parseResourceID returns the resource group and name of the resource
+// with the given ID, which has the form
+// "/subscriptions/<id>/resourceGroups/<group>/providers/<namespace>/<type>/<name>".
+func parseResourceID(id string) (resourceGroup, name string, ok bool) {
+	parts := strings.Split(strings.Trim(id, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "resourceGroups") {
+			resourceGroup = parts[i+1]
+			break
+		}
+	}
+	name = parts[len(parts)-1]
+	return resourceGroup, name, resourceGroup != "" && name != ""
+}
+
+type subnetsByName []network.Subnet
+
+func (s subnetsByName) Len() int      { return len(s) }
+func (s subnetsByName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s subnetsByName) Less(i, j int) bool {
+	return to.String(s[i].Name) < to.String(s[j].Name)
+}
@@ -4,6 +4,9 @@
 package cloudsigma
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cloud"
@@ -21,11 +24,11 @@ const (
 func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
 	return map[cloud.AuthType]cloud.CredentialSchema{
 		cloud.UserPassAuthType: {{
-			"username", cloud.CredentialAttr{
+			credAttrUsername, cloud.CredentialAttr{
 				Description: "account username",
 			},
 		}, {
-			"password", cloud.CredentialAttr{
+			credAttrPassword, cloud.CredentialAttr{
 				Description: "account password",
 				Hidden:      true,
 			},
@@ -34,8 +37,28 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials interface.
+// Credentials are read from the CLOUDSIGMA_USERNAME and CLOUDSIGMA_PASSWORD
+// environment variables, and CLOUDSIGMA_REGION is used as the default region.
 func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
-	return nil, errors.NotFoundf("credentials")
+	username := os.Getenv("CLOUDSIGMA_USERNAME")
+	password := os.Getenv("CLOUDSIGMA_PASSWORD")
+	if username == "" || password == "" {
+		return nil, errors.NotFoundf("credentials")
+	}
+	credential := cloud.NewCredential(
+		cloud.UserPassAuthType,
+		map[string]string{
+			credAttrUsername: username,
+			credAttrPassword: password,
+		},
+	)
+	credential.Label = fmt.Sprintf("cloudsigma credential %q", username)
+	return &cloud.CloudCredential{
+		DefaultRegion: os.Getenv("CLOUDSIGMA_REGION"),
+		AuthCredentials: map[string]cloud.Credential{
+			username: credential,
+		},
+	}, nil
 }
 
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	envtesting "github.com/juju/juju/environs/testing"
 )
@@ -44,6 +45,25 @@ func (s *credentialsSuite) TestUserPassHiddenAttributes(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestDetectCredentialsNotFound(c *gc.C) {
+	// No environment variables set, so no credentials should be found.
 	_, err := s.provider.DetectCredentials()
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *credentialsSuite) TestDetectCredentialsEnvironmentVariables(c *gc.C) {
+	s.PatchEnvironment("CLOUDSIGMA_USERNAME", "bob")
+	s.PatchEnvironment("CLOUDSIGMA_PASSWORD", "dobbs")
+	s.PatchEnvironment("CLOUDSIGMA_REGION", "zrh")
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "zrh")
+	expected := cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{
+			"username": "bob",
+			"password": "dobbs",
+		},
+	)
+	expected.Label = `cloudsigma credential "bob"`
+	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
+}
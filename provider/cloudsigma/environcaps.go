@@ -12,6 +12,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator returns a Validator instance which
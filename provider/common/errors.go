@@ -3,7 +3,11 @@
 
 package common
 
-import "github.com/juju/errors"
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+)
 
 // ZoneIndependentError wraps the given error such that it
 // satisfies environs.IsAvailabilityZoneIndependent.
@@ -25,3 +29,32 @@ type zoneIndependentError struct {
 func (zoneIndependentError) AvailabilityZoneIndependent() bool {
 	return true
 }
+
+// ClassifiedError wraps the given error such that environs.ErrorClassOf
+// reports the given class. Capacity errors are treated as specific to
+// an availability zone; errors of all other classes satisfy
+// environs.IsAvailabilityZoneIndependent.
+func ClassifiedError(class environs.ErrorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, classifiedError{err, class})
+	wrapped.(*errors.Err).SetLocation(1)
+	return wrapped
+}
+
+type classifiedError struct {
+	error
+	class environs.ErrorClass
+}
+
+// ErrorClass is part of the environs.ClassifiedError interface.
+func (e classifiedError) ErrorClass() environs.ErrorClass {
+	return e.class
+}
+
+// AvailabilityZoneIndependent is part of the
+// environs.AvailabilityZoneError interface.
+func (e classifiedError) AvailabilityZoneIndependent() bool {
+	return e.class != environs.ErrorClassCapacity
+}
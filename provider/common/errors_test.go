@@ -32,3 +32,18 @@ github.com/juju/juju/provider/common/errors_test.go:.*: foo
 github.com/juju/juju/provider/common/errors_test.go:.*: bar
 github.com/juju/juju/provider/common/errors_test.go:.*: bar: foo`[1:])
 }
+
+func (*ErrorsSuite) TestWrapClassifiedError(c *gc.C) {
+	err := errors.Annotate(errors.New("foo"), "bar")
+	wrapped := common.ClassifiedError(environs.ErrorClassQuota, err)
+	c.Assert(wrapped, gc.ErrorMatches, "bar: foo")
+	c.Assert(environs.ErrorClassOf(wrapped), gc.Equals, environs.ErrorClassQuota)
+	c.Assert(environs.ErrorClassOf(errors.Annotate(wrapped, "baz")), gc.Equals, environs.ErrorClassQuota)
+	c.Assert(wrapped, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+
+	wrapped = common.ClassifiedError(environs.ErrorClassCapacity, err)
+	c.Assert(environs.ErrorClassOf(wrapped), gc.Equals, environs.ErrorClassCapacity)
+	c.Assert(wrapped, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+
+	c.Assert(environs.ErrorClassOf(err), gc.Equals, environs.ErrorClassUnknown)
+}
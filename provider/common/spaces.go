@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
+)
+
+// SubnetIdsForZone returns the sorted IDs of the subnets in the given
+// map of subnet ID to availability zones that are available in the
+// specified zone. If zone is empty, all of the subnet IDs are returned.
+// An error satisfying errors.IsNotFound is returned if there are no
+// such subnets.
+func SubnetIdsForZone(subnetsToZones map[network.Id][]string, zone string) ([]network.Id, error) {
+	var ids []string
+	for subnetId, zones := range subnetsToZones {
+		if zone == "" || set.NewStrings(zones...).Contains(zone) {
+			ids = append(ids, string(subnetId))
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.NotFoundf("subnets in AZ %q", zone)
+	}
+	sort.Strings(ids)
+	result := make([]network.Id, len(ids))
+	for i, id := range ids {
+		result[i] = network.Id(id)
+	}
+	return result, nil
+}
+
+// PrecheckSpaceSubnets checks that an instance with the given constraints
+// can be started in the specified availability zone, by checking that the
+// first positive space in the constraints has subnets in that zone. The
+// subnetsToZones map is as passed in PrecheckInstanceParams; if it is nil,
+// or the zone is empty, there is nothing to check.
+func PrecheckSpaceSubnets(cons constraints.Value, subnetsToZones map[network.Id][]string, zone string) error {
+	spaces := cons.IncludeSpaces()
+	if len(spaces) == 0 || subnetsToZones == nil {
+		return nil
+	}
+	if len(subnetsToZones) == 0 {
+		return errors.Errorf("space %q has no subnets with provider IDs and availability zones", spaces[0])
+	}
+	if zone == "" {
+		return nil
+	}
+	if _, err := SubnetIdsForZone(subnetsToZones, zone); errors.IsNotFound(err) {
+		return errors.Errorf("space %q has no subnets in availability zone %q", spaces[0], zone)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
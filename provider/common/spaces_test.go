@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type spacesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&spacesSuite{})
+
+var subnetsToZones = map[network.Id][]string{
+	"subnet-b": {"zone1"},
+	"subnet-a": {"zone1", "zone2"},
+	"subnet-c": {"zone3"},
+}
+
+func (s *spacesSuite) TestSubnetIdsForZone(c *gc.C) {
+	ids, err := common.SubnetIdsForZone(subnetsToZones, "zone1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []network.Id{"subnet-a", "subnet-b"})
+
+	ids, err = common.SubnetIdsForZone(subnetsToZones, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []network.Id{"subnet-a", "subnet-b", "subnet-c"})
+}
+
+func (s *spacesSuite) TestSubnetIdsForZoneNotFound(c *gc.C) {
+	_, err := common.SubnetIdsForZone(subnetsToZones, "zone4")
+	c.Assert(err, gc.ErrorMatches, `subnets in AZ "zone4" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *spacesSuite) TestPrecheckSpaceSubnets(c *gc.C) {
+	cons := constraints.MustParse("spaces=db,^admin")
+	c.Assert(common.PrecheckSpaceSubnets(cons, subnetsToZones, "zone2"), jc.ErrorIsNil)
+	c.Assert(common.PrecheckSpaceSubnets(cons, subnetsToZones, ""), jc.ErrorIsNil)
+	err := common.PrecheckSpaceSubnets(cons, subnetsToZones, "zone4")
+	c.Assert(err, gc.ErrorMatches, `space "db" has no subnets in availability zone "zone4"`)
+	err = common.PrecheckSpaceSubnets(cons, map[network.Id][]string{}, "")
+	c.Assert(err, gc.ErrorMatches, `space "db" has no subnets with provider IDs and availability zones`)
+}
+
+func (s *spacesSuite) TestPrecheckSpaceSubnetsNothingToCheck(c *gc.C) {
+	// No positive spaces.
+	cons := constraints.MustParse("spaces=^admin")
+	c.Assert(common.PrecheckSpaceSubnets(cons, subnetsToZones, "zone4"), jc.ErrorIsNil)
+	// Space unknown to Juju.
+	cons = constraints.MustParse("spaces=db")
+	c.Assert(common.PrecheckSpaceSubnets(cons, nil, "zone4"), jc.ErrorIsNil)
+}
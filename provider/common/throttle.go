@@ -0,0 +1,328 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs"
+)
+
+// ThrottleRate holds the rate at which calls may be made to the
+// provider API for a family of calls.
+type ThrottleRate struct {
+	// Rate is the average number of calls allowed per second.
+	Rate float64
+
+	// Burst is the maximum number of calls that may be made
+	// at once.
+	Burst int64
+}
+
+// ThrottleConfig holds the configuration for a Throttle.
+type ThrottleConfig struct {
+	// Clock is used to wait for the rate limits, and
+	// before retrying calls.
+	Clock clock.Clock
+
+	// Default is the rate of API families without an entry
+	// in Families.
+	Default ThrottleRate
+
+	// Families holds the rates of API families that differ
+	// from the default.
+	Families map[string]ThrottleRate
+
+	// MaxAttempts is the maximum number of times that a call
+	// is attempted, if it fails because it was rate limited.
+	MaxAttempts int
+
+	// RetryDelay is the time to wait before retrying a call that
+	// was rate limited, if the provider does not say how long to
+	// wait. It is doubled after each attempt, up to MaxRetryDelay.
+	RetryDelay time.Duration
+
+	// MaxRetryDelay is the maximum time to wait before retrying
+	// a call, unless the provider asks for a longer wait.
+	MaxRetryDelay time.Duration
+
+	// IsRateLimited reports whether an error returned by a call
+	// means that the provider rejected it for exceeding the API
+	// rate limits. Errors of the class environs.ErrorClassRateLimit
+	// are always considered rate limited.
+	IsRateLimited func(error) bool
+}
+
+// DefaultThrottleConfig returns a throttle configuration that stays
+// within the default API rate limits of the major public clouds.
+func DefaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		Clock:         clock.WallClock,
+		Default:       ThrottleRate{Rate: 10, Burst: 20},
+		MaxAttempts:   5,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: 30 * time.Second,
+	}
+}
+
+// Validate returns an error if the configuration is not valid.
+func (config ThrottleConfig) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if err := config.Default.validate(); err != nil {
+		return errors.Annotate(err, "default rate")
+	}
+	for family, rate := range config.Families {
+		if err := rate.validate(); err != nil {
+			return errors.Annotatef(err, "%s rate", family)
+		}
+	}
+	if config.MaxAttempts <= 0 {
+		return errors.NotValidf("MaxAttempts %d", config.MaxAttempts)
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("RetryDelay %v", config.RetryDelay)
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		return errors.NotValidf("MaxRetryDelay less than RetryDelay")
+	}
+	return nil
+}
+
+func (r ThrottleRate) validate() error {
+	if r.Rate <= 0 {
+		return errors.NotValidf("rate %v", r.Rate)
+	}
+	if r.Burst <= 0 {
+		return errors.NotValidf("burst %d", r.Burst)
+	}
+	return nil
+}
+
+// Throttle limits the rate of calls made to a provider's API, and
+// retries calls that the provider rejects for exceeding its rate
+// limits. A token bucket is kept for each family of API calls, so
+// that frequent calls of one kind, such as instance polling, do not
+// starve others of the provider's allowance.
+type Throttle struct {
+	config ThrottleConfig
+
+	mu      sync.Mutex
+	buckets map[string]*ratelimit.Bucket
+}
+
+// NewThrottle returns a new Throttle with the given configuration.
+func NewThrottle(config ThrottleConfig) (*Throttle, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating throttle config")
+	}
+	return &Throttle{
+		config:  config,
+		buckets: make(map[string]*ratelimit.Bucket),
+	}, nil
+}
+
+// Call calls f, which makes a call to the provider API in the given
+// family, once the family's rate limit allows. If f fails because
+// the provider rate limited it, it is retried after the delay the
+// provider asked for, or with exponential backoff. The error from the
+// last attempt is returned unchanged.
+func (t *Throttle) Call(family string, f func() error) error {
+	delay := t.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		t.wait(family)
+		err := f()
+		if err == nil || !t.isRateLimited(err) {
+			return err
+		}
+		if attempt >= t.config.MaxAttempts {
+			logger.Warningf("%s API call rate limited after %d attempts: %v", family, attempt, err)
+			return err
+		}
+		wait, ok := RetryAfter(err)
+		if !ok {
+			wait = delay
+			delay *= 2
+			if delay > t.config.MaxRetryDelay {
+				delay = t.config.MaxRetryDelay
+			}
+		}
+		logger.Debugf("%s API call rate limited, retrying in %v", family, wait)
+		<-t.config.Clock.After(wait)
+	}
+}
+
+// wait blocks until the family's bucket has a token available.
+func (t *Throttle) wait(family string) {
+	if d := t.bucket(family).Take(1); d > 0 {
+		<-t.config.Clock.After(d)
+	}
+}
+
+// bucket returns the token bucket for the given family,
+// creating it if necessary.
+func (t *Throttle) bucket(family string) *ratelimit.Bucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.buckets[family]
+	if !ok {
+		rate, ok := t.config.Families[family]
+		if !ok {
+			rate = t.config.Default
+		}
+		b = ratelimit.NewBucketWithRateAndClock(rate.Rate, rate.Burst, throttleClock{t.config.Clock})
+		t.buckets[family] = b
+	}
+	return b
+}
+
+func (t *Throttle) isRateLimited(err error) bool {
+	if environs.ErrorClassOf(err) == environs.ErrorClassRateLimit {
+		return true
+	}
+	return t.config.IsRateLimited != nil && t.config.IsRateLimited(err)
+}
+
+// HTTPDoer sends HTTP requests. It is satisfied by *http.Client, and
+// by the senders used by some provider SDKs.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// HTTPDoer returns an HTTPDoer that sends requests with the given
+// doer, throttled in the given family. Requests that receive a
+// "429 Too Many Requests" response are retried, honouring the
+// response's Retry-After header. If the retries are exhausted, the
+// last response is returned.
+func (t *Throttle) HTTPDoer(family string, doer HTTPDoer) HTTPDoer {
+	return throttledDoer{throttle: t, family: family, doer: doer}
+}
+
+type throttledDoer struct {
+	throttle *Throttle
+	family   string
+	doer     HTTPDoer
+}
+
+// Do is part of the HTTPDoer interface.
+func (d throttledDoer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "reading request body")
+		}
+	}
+	var resp *http.Response
+	err := d.throttle.Call(d.family, func() error {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		var err error
+		resp, err = d.doer.Do(req)
+		if err != nil {
+			resp = nil
+			return err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return nil
+		}
+		retryAfter, _ := ParseRetryAfter(resp.Header.Get("Retry-After"), d.throttle.config.Clock.Now())
+		return RateLimitError(errors.Errorf("%s %s: %s", req.Method, req.URL, resp.Status), retryAfter)
+	})
+	if resp != nil {
+		// The last response is returned even if it was rate
+		// limited, so that the caller handles it as usual.
+		return resp, nil
+	}
+	return nil, err
+}
+
+// RateLimitError wraps the given error, which the provider returned
+// because too many API calls were made, so that environs.ErrorClassOf
+// reports environs.ErrorClassRateLimit. If retryAfter is positive, it
+// is the time that the provider asked callers to wait before retrying.
+func RateLimitError(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, rateLimitError{
+		classifiedError: classifiedError{err, environs.ErrorClassRateLimit},
+		retryAfter:      retryAfter,
+	})
+	wrapped.(*errors.Err).SetLocation(1)
+	return wrapped
+}
+
+type rateLimitError struct {
+	classifiedError
+	retryAfter time.Duration
+}
+
+// RetryAfter returns the time to wait before retrying the call.
+func (e rateLimitError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// RetryAfter returns the time that the provider asked callers to wait
+// before retrying the call that failed with the given error, if the
+// error or its cause reports one.
+func RetryAfter(err error) (time.Duration, bool) {
+	retryErr, ok := errors.Cause(err).(interface {
+		RetryAfter() time.Duration
+	})
+	if !ok {
+		return 0, false
+	}
+	d := retryErr.RetryAfter()
+	return d, d > 0
+}
+
+// ParseRetryAfter parses the value of an HTTP Retry-After header,
+// which holds either a number of seconds or an HTTP date, and returns
+// the time to wait from now.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := when.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// throttleClock adapts clock.Clock to ratelimit.Clock.
+type throttleClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c throttleClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
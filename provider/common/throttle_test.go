@@ -0,0 +1,267 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+)
+
+type ThrottleSuite struct {
+	testing.IsolationSuite
+	clock  *recordingClock
+	config common.ThrottleConfig
+}
+
+var _ = gc.Suite(&ThrottleSuite{})
+
+func (s *ThrottleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = &recordingClock{Clock: testing.NewClock(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))}
+	s.config = common.ThrottleConfig{
+		Clock:         s.clock,
+		Default:       common.ThrottleRate{Rate: 100, Burst: 100},
+		MaxAttempts:   3,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: 10 * time.Second,
+	}
+}
+
+func (s *ThrottleSuite) newThrottle(c *gc.C) *common.Throttle {
+	throttle, err := common.NewThrottle(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	return throttle
+}
+
+func (s *ThrottleSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		modify func(*common.ThrottleConfig)
+		err    string
+	}{{
+		func(cfg *common.ThrottleConfig) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *common.ThrottleConfig) { cfg.Default.Rate = 0 },
+		"default rate: rate 0 not valid",
+	}, {
+		func(cfg *common.ThrottleConfig) {
+			cfg.Families = map[string]common.ThrottleRate{"describe": {Rate: 1}}
+		},
+		"describe rate: burst 0 not valid",
+	}, {
+		func(cfg *common.ThrottleConfig) { cfg.MaxAttempts = 0 },
+		"MaxAttempts 0 not valid",
+	}, {
+		func(cfg *common.ThrottleConfig) { cfg.MaxRetryDelay = time.Millisecond },
+		"MaxRetryDelay less than RetryDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.modify(&config)
+		err := config.Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	c.Assert(common.DefaultThrottleConfig().Validate(), jc.ErrorIsNil)
+}
+
+func (s *ThrottleSuite) TestCallSuccess(c *gc.C) {
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 1)
+	c.Assert(s.clock.waits, gc.HasLen, 0)
+}
+
+func (s *ThrottleSuite) TestCallOtherError(c *gc.C) {
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		return errors.NotFoundf("instance")
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *ThrottleSuite) TestCallRetriesWithBackoff(c *gc.C) {
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		if calls < 3 {
+			return common.ClassifiedError(environs.ErrorClassRateLimit, errors.New("slow down"))
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 3)
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{time.Second, 2 * time.Second})
+}
+
+func (s *ThrottleSuite) TestCallHonoursRetryAfter(c *gc.C) {
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		if calls == 1 {
+			return common.RateLimitError(errors.New("slow down"), 7*time.Second)
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 2)
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{7 * time.Second})
+}
+
+func (s *ThrottleSuite) TestCallGivesUp(c *gc.C) {
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		return common.RateLimitError(errors.New("slow down"), 0)
+	})
+	c.Assert(err, gc.ErrorMatches, "slow down")
+	c.Assert(environs.ErrorClassOf(err), gc.Equals, environs.ErrorClassRateLimit)
+	c.Assert(calls, gc.Equals, 3)
+}
+
+func (s *ThrottleSuite) TestCallIsRateLimited(c *gc.C) {
+	s.config.IsRateLimited = func(err error) bool {
+		return err.Error() == "Throttling"
+	}
+	var calls int
+	err := s.newThrottle(c).Call("describe", func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("Throttling")
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 2)
+}
+
+func (s *ThrottleSuite) TestCallRateLimitPerFamily(c *gc.C) {
+	s.config.Families = map[string]common.ThrottleRate{
+		"firewall": {Rate: 1, Burst: 2},
+	}
+	throttle := s.newThrottle(c)
+	call := func(family string) {
+		err := throttle.Call(family, func() error { return nil })
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	call("firewall")
+	call("firewall")
+	call("describe")
+	c.Assert(s.clock.waits, gc.HasLen, 0)
+	call("firewall")
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{time.Second})
+}
+
+func (s *ThrottleSuite) TestHTTPDoerRetriesTooManyRequests(c *gc.C) {
+	doer := &stubDoer{responses: []*http.Response{
+		newResponse(http.StatusTooManyRequests, "3"),
+		newResponse(http.StatusOK, ""),
+	}}
+	req, err := http.NewRequest("PUT", "https://example.com/vms/0", strings.NewReader("body"))
+	c.Assert(err, jc.ErrorIsNil)
+	resp, err := s.newThrottle(c).HTTPDoer("compute", doer).Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(doer.bodies, jc.DeepEquals, []string{"body", "body"})
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{3 * time.Second})
+}
+
+func (s *ThrottleSuite) TestHTTPDoerReturnsLastResponse(c *gc.C) {
+	doer := &stubDoer{responses: []*http.Response{
+		newResponse(http.StatusTooManyRequests, ""),
+		newResponse(http.StatusTooManyRequests, ""),
+		newResponse(http.StatusTooManyRequests, ""),
+	}}
+	req, err := http.NewRequest("GET", "https://example.com/vms", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	resp, err := s.newThrottle(c).HTTPDoer("compute", doer).Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusTooManyRequests)
+	c.Assert(doer.bodies, gc.HasLen, 3)
+	c.Assert(s.clock.waits, jc.DeepEquals, []time.Duration{time.Second, 2 * time.Second})
+}
+
+func (s *ThrottleSuite) TestParseRetryAfter(c *gc.C) {
+	now := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, test := range []struct {
+		value string
+		d     time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"Thu, 01 Jun 2017 00:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 31 May 2017 23:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	} {
+		c.Logf("test %d: %q", i, test.value)
+		d, ok := common.ParseRetryAfter(test.value, now)
+		c.Check(d, gc.Equals, test.d)
+		c.Check(ok, gc.Equals, test.ok)
+	}
+}
+
+// recordingClock is a testing clock that records the durations
+// waited for, advancing immediately.
+type recordingClock struct {
+	*testing.Clock
+	waits []time.Duration
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := c.Clock.After(d)
+	c.Advance(d)
+	return ch
+}
+
+type stubDoer struct {
+	responses []*http.Response
+	bodies    []string
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	var body string
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(data)
+	}
+	d.bodies = append(d.bodies, body)
+	resp := d.responses[0]
+	d.responses = d.responses[1:]
+	return resp, nil
+}
+
+func newResponse(status int, retryAfter string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -245,6 +246,7 @@ type environState struct {
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
 	globalRules    network.IngressRuleSlice
+	egressRules    network.EgressRuleSlice
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -1490,6 +1492,9 @@ func (e *environ) OpenPorts(rules []network.IngressRule) error {
 		if len(r.SourceCIDRs) == 0 {
 			r.SourceCIDRs = []string{"0.0.0.0/0"}
 		}
+		// Like most clouds, the dummy provider does not record
+		// rule provenance.
+		r = r.WithProvenance("", "")
 		found := false
 		for _, rule := range estate.globalRules {
 			if r.String() == rule.String() {
@@ -1541,6 +1546,99 @@ func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	return
 }
 
+// OpenEgress is specified in the environs.EgressFirewaller interface.
+func (e *environ) OpenEgress(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode == config.FwNone {
+		return fmt.Errorf("invalid firewall mode %q for opening egress on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range splitEgressRules(rules) {
+		found := false
+		for _, rule := range estate.egressRules {
+			if r.String() == rule.String() {
+				found = true
+			}
+		}
+		if !found {
+			estate.egressRules = append(estate.egressRules, r)
+		}
+	}
+	return nil
+}
+
+// CloseEgress is specified in the environs.EgressFirewaller interface.
+func (e *environ) CloseEgress(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode == config.FwNone {
+		return fmt.Errorf("invalid firewall mode %q for closing egress on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range splitEgressRules(rules) {
+		for i, rule := range estate.egressRules {
+			if r.String() == rule.String() {
+				estate.egressRules = estate.egressRules[:i+copy(estate.egressRules[i:], estate.egressRules[i+1:])]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// EgressRules is specified in the environs.EgressFirewaller interface.
+func (e *environ) EgressRules() ([]network.EgressRule, error) {
+	if mode := e.ecfg().FirewallMode(); mode == config.FwNone {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	// Combine the destinations of the rules for each port range.
+	var rules []network.EgressRule
+	index := make(map[network.PortRange]int)
+	for _, r := range estate.egressRules {
+		i, ok := index[r.PortRange]
+		if !ok {
+			i = len(rules)
+			index[r.PortRange] = i
+			rules = append(rules, network.EgressRule{PortRange: r.PortRange})
+		}
+		rules[i].DestinationCIDRs = append(rules[i].DestinationCIDRs, r.DestinationCIDRs...)
+	}
+	for _, r := range rules {
+		sort.Strings(r.DestinationCIDRs)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// splitEgressRules returns the given rules as rules with a single
+// destination each.
+func splitEgressRules(rules []network.EgressRule) []network.EgressRule {
+	var result []network.EgressRule
+	for _, r := range rules {
+		cidrs := r.DestinationCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range cidrs {
+			result = append(result, network.EgressRule{PortRange: r.PortRange, DestinationCIDRs: []string{cidr}})
+		}
+	}
+	return result
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1654,6 +1752,9 @@ func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRu
 		if len(r.SourceCIDRs) == 0 {
 			r.SourceCIDRs = []string{"0.0.0.0/0"}
 		}
+		// Like most clouds, the dummy provider does not record
+		// rule provenance.
+		r = r.WithProvenance("", "")
 		found := false
 		for i, rule := range inst.rules {
 			if r.PortRange == rule.PortRange {
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -26,6 +27,18 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	"instance-profile": {
+		Description: "The name of the AWS IAM instance profile to attach to new instances, so that workloads can use its role's credentials (optional). The instance-profile constraint overrides it for individual machines.",
+		Example:     "juju-workload-role",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	"vpc-endpoints": {
+		Description: "A comma-separated list of AWS services (s3, dynamodb) for which a gateway endpoint is created in the model's VPC, so that machines reach them without leaving the VPC (optional). The endpoints are shared by every model using the VPC, and are not removed with the model.",
+		Example:     "s3,dynamodb",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var configFields = func() schema.Fields {
@@ -37,8 +50,10 @@ var configFields = func() schema.Fields {
 }()
 
 var configDefaults = schema.Defaults{
-	"vpc-id":       "",
-	"vpc-id-force": false,
+	"vpc-id":           "",
+	"vpc-id-force":     false,
+	"instance-profile": "",
+	"vpc-endpoints":    "",
 }
 
 type environConfig struct {
@@ -54,6 +69,21 @@ func (c *environConfig) forceVPCID() bool {
 	return c.attrs["vpc-id-force"].(bool)
 }
 
+func (c *environConfig) instanceProfile() string {
+	return c.attrs["instance-profile"].(string)
+}
+
+// vpcEndpoints returns the services named in the vpc-endpoints setting.
+func (c *environConfig) vpcEndpoints() []string {
+	var services []string
+	for _, service := range strings.Split(c.attrs["vpc-endpoints"].(string), ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
 func (p environProvider) newConfig(cfg *config.Config) (*environConfig, error) {
 	valid, err := p.Validate(cfg, nil)
 	if err != nil {
@@ -100,6 +130,13 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		return nil, fmt.Errorf("cannot use vpc-id-force without specifying vpc-id as well")
 	}
 
+	for _, service := range ecfg.vpcEndpoints() {
+		if !vpcEndpointServices.Contains(service) {
+			return nil, fmt.Errorf("vpc-endpoints: unsupported service %q, expected one of %s",
+				service, strings.Join(vpcEndpointServices.SortedValues(), ", "))
+		}
+	}
+
 	if old != nil {
 		attrs := old.UnknownAttrs()
 
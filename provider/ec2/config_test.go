@@ -245,6 +245,23 @@ var configTests = []configTest{
 	}, {
 		config:       attrs{},
 		firewallMode: config.FwInstance,
+	}, {
+		config: attrs{},
+		expect: attrs{"instance-profile": ""},
+	}, {
+		config: attrs{"instance-profile": "web-role"},
+		change: attrs{"instance-profile": "db-role"},
+		expect: attrs{"instance-profile": "db-role"},
+	}, {
+		config: attrs{},
+		expect: attrs{"vpc-endpoints": ""},
+	}, {
+		config: attrs{"vpc-endpoints": "s3"},
+		change: attrs{"vpc-endpoints": "s3, dynamodb"},
+		expect: attrs{"vpc-endpoints": "s3, dynamodb"},
+	}, {
+		config: attrs{"vpc-endpoints": "s3,ec2"},
+		err:    `.*vpc-endpoints: unsupported service "ec2", expected one of dynamodb, s3`,
 	}, {
 		config:             attrs{},
 		blockStorageSource: "ebs",
@@ -263,6 +280,11 @@ var configTests = []configTest{
 			"firewall-mode": "global",
 		},
 		firewallMode: config.FwGlobal,
+	}, {
+		config: attrs{
+			"firewall-mode": "application",
+		},
+		firewallMode: config.FwApplication,
 	}, {
 		config: attrs{
 			"firewall-mode": "none",
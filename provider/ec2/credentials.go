@@ -5,9 +5,14 @@ package ec2
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -94,10 +99,62 @@ func (e environProviderCredentials) DetectCredentials() (*cloud.CloudCredential,
 	if err != nil {
 		return nil, errors.Annotate(err, "loading AWS config file")
 	}
-	result.DefaultRegion = configInfo.Section("default").Key("region").String()
+	result.DefaultRegion = defaultRegion(configInfo.Section("default").Key("region").String())
 	return &result, nil
 }
 
+// instanceMetadataURL is the base URL of the EC2 instance metadata
+// service.
+var instanceMetadataURL = "http://169.254.169.254/latest/meta-data"
+
+// metadataTimeout bounds requests made to the instance metadata
+// service, whose address is unreachable outside EC2.
+var metadataTimeout = time.Second
+
+// defaultRegion returns the given region if it is set. Otherwise,
+// when running on an EC2 instance, it returns the instance's region,
+// as reported by the instance metadata service.
+//
+// The metadata service also issues the temporary credentials of the
+// instance's IAM role, but they cannot be used as an access-key
+// credential, which has no session token, so they are not detected.
+func defaultRegion(region string) string {
+	if region != "" {
+		return region
+	}
+	region, err := instanceRegion()
+	if err != nil {
+		logger.Debugf("cannot get region from instance metadata: %v", err)
+		return ""
+	}
+	return region
+}
+
+// instanceRegion returns the region of the EC2 instance on which
+// the client is running.
+func instanceRegion() (string, error) {
+	client := http.Client{Timeout: metadataTimeout}
+	resp, err := client.Get(instanceMetadataURL + "/placement/availability-zone")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("instance metadata service returned %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// An availability zone is named after its region,
+	// followed by a letter.
+	zone := strings.TrimSpace(string(body))
+	if len(zone) < 2 {
+		return "", errors.Errorf("invalid availability zone %q", zone)
+	}
+	return zone[:len(zone)-1], nil
+}
+
 func credentialsDir() string {
 	if runtime.GOOS == "windows" {
 		return filepath.Join(os.Getenv("USERPROFILE"), ".aws")
@@ -123,6 +180,7 @@ func (environProviderCredentials) detectEnvCredentials() (*cloud.CloudCredential
 	}
 	accessKeyCredential.Label = fmt.Sprintf("aws credential %q", user)
 	return &cloud.CloudCredential{
+		DefaultRegion: defaultRegion(os.Getenv("AWS_DEFAULT_REGION")),
 		AuthCredentials: map[string]cloud.Credential{
 			user: accessKeyCredential,
 		}}, nil
@@ -4,7 +4,10 @@
 package ec2_test
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,6 +21,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/provider/ec2"
 )
 
 type credentialsSuite struct {
@@ -33,6 +37,15 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.provider, err = environs.Provider("ec2")
 	c.Assert(err, jc.ErrorIsNil)
+
+	// Tests do not run on EC2 instances.
+	s.patchInstanceMetadata(c, http.NotFoundHandler())
+}
+
+func (s *credentialsSuite) patchInstanceMetadata(c *gc.C, handler http.Handler) {
+	server := httptest.NewServer(handler)
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.PatchValue(ec2.InstanceMetadataURL, server.URL)
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
@@ -79,6 +92,37 @@ func (s *credentialsSuite) TestDetectCredentialsEnvironmentVariables(c *gc.C) {
 	)
 	expected.Label = `aws credential "fred"`
 	c.Assert(credentials.AuthCredentials["fred"], jc.DeepEquals, expected)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "")
+}
+
+func (s *credentialsSuite) TestDetectCredentialsInstanceRegion(c *gc.C) {
+	s.patchInstanceMetadata(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/placement/availability-zone" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "eu-west-1b")
+	}))
+	home := utils.Home()
+	err := utils.SetHome(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		err := utils.SetHome(home)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+	s.PatchEnvironment("USER", "fred")
+	s.PatchEnvironment("AWS_ACCESS_KEY_ID", "key-id")
+	s.PatchEnvironment("AWS_SECRET_ACCESS_KEY", "secret-access-key")
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "eu-west-1")
+
+	// A configured region takes precedence.
+	s.PatchEnvironment("AWS_DEFAULT_REGION", "us-east-1")
+	credentials, err = s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "us-east-1")
 }
 
 func (s *credentialsSuite) assertDetectCredentialsKnownLocation(c *gc.C, dir string) {
@@ -64,6 +64,9 @@ type environ struct {
 	cloud environs.CloudSpec
 	ec2   *ec2.EC2
 
+	// throttle limits the rate of calls to the EC2 API.
+	throttle *common.Throttle
+
 	// ecfgMutex protects the *Unlocked fields below.
 	ecfgMutex    sync.Mutex
 	ecfgUnlocked *environConfig
@@ -76,10 +79,16 @@ type environ struct {
 	defaultVPC        *ec2.VPC
 
 	ensureGroupMutex sync.Mutex
+
+	// vpcEndpointsMutex protects vpcEndpoints, which holds the
+	// services known to have an endpoint in the model's VPC.
+	vpcEndpointsMutex sync.Mutex
+	vpcEndpoints      set.Strings
 }
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.ApplicationFirewaller = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -161,6 +170,7 @@ var unsupportedConstraints = []string{
 	// TODO(anastasiamac 2016-03-16) LP#1557874
 	// use virt-type in StartInstances
 	constraints.VirtType,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -318,14 +328,26 @@ func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
 
 // PrecheckInstance is defined on the environs.InstancePrechecker interface.
 func (e *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	if _, _, err := e.deriveAvailabilityZoneAndSubnetID(
+	zone, placementSubnetID, err := e.deriveAvailabilityZoneAndSubnetID(
 		environs.StartInstanceParams{
 			Placement:         args.Placement,
 			VolumeAttachments: args.VolumeAttachments,
 		},
-	); err != nil {
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.PrecheckSpaceSubnets(args.Constraints, args.SubnetsToZones, zone); err != nil {
 		return errors.Trace(err)
 	}
+	if placementSubnetID != "" && len(args.SubnetsToZones) > 0 {
+		if _, ok := args.SubnetsToZones[network.Id(placementSubnetID)]; !ok {
+			return errors.Errorf(
+				"subnet %q is not in space %q",
+				placementSubnetID, args.Constraints.IncludeSpaces()[0],
+			)
+		}
+	}
 	if !args.Constraints.HasInstanceType() {
 		return nil
 	}
@@ -487,12 +509,22 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 	}
 	callback(status.Allocating, "Setting up groups", nil)
-	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	groups, err := e.setUpGroups(
+		args.ControllerUUID,
+		args.InstanceConfig.MachineId,
+		deployedApplications(args.InstanceConfig.Tags),
+		apiPort,
+	)
 	if err != nil {
 		return nil, common.ZoneIndependentError(
 			errors.Annotate(err, "cannot set up groups"),
 		)
 	}
+	if err := e.ensureVPCEndpoints(); err != nil {
+		return nil, common.ZoneIndependentError(
+			errors.Annotate(err, "cannot set up VPC endpoints"),
+		)
+	}
 
 	blockDeviceMappings := getBlockDeviceMappings(
 		args.Constraints,
@@ -527,6 +559,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 
 	runArgs := commonRunArgs
 	runArgs.AvailZone = availabilityZone
+	runArgs.IAMInstanceProfile = e.ecfg().instanceProfile()
+	if args.Constraints.HasInstanceProfile() {
+		runArgs.IAMInstanceProfile = *args.Constraints.InstanceProfile
+	}
 
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
 	var subnetIDsForZone []string
@@ -540,6 +576,14 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 				allowedSubnetIDs = append(allowedSubnetIDs, string(subnetID))
 			}
 		}
+		if len(allowedSubnetIDs) == 0 && len(args.Constraints.IncludeSpaces()) > 0 {
+			// Without any subnets from the space, we would
+			// otherwise choose from all subnets in the VPC.
+			return nil, common.ZoneIndependentError(errors.Errorf(
+				"no subnets with provider IDs and availability zones in spaces %v",
+				args.Constraints.IncludeSpaces(),
+			))
+		}
 		subnetIDsForZone, subnetErr = getVPCSubnetIDsForAvailabilityZone(e.ec2, e.ecfg().vpcID(), availabilityZone, allowedSubnetIDs)
 	} else if args.Constraints.HaveSpaces() {
 		subnetIDsForZone, subnetErr = findSubnetIDsForAvailabilityZone(availabilityZone, args.SubnetsToZones)
@@ -573,10 +617,21 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, runArgs, callback)
+	if args.Constraints.HasSpot() {
+		instResp, err = runSpotInstance(e.ec2, runArgs, args.Constraints.SpotPrice(), callback)
+		if isSpotUnfulfilledError(err) {
+			logger.Infof("%v; starting on-demand instance instead", err)
+			callback(status.Allocating, "Spot instance unavailable, starting on-demand instance", nil)
+			instResp, err = runInstances(e.ec2, runArgs, callback)
+		}
+	} else {
+		instResp, err = runInstances(e.ec2, runArgs, callback)
+	}
 	if err != nil {
 		err := errors.Annotate(err, "cannot run instances")
-		if !isZoneOrSubnetConstrainedError(err) {
+		if class := ec2ErrorClass(err); class != environs.ErrorClassUnknown {
+			err = common.ClassifiedError(class, err)
+		} else if !isZoneOrSubnetConstrainedError(err) {
 			err = common.ZoneIndependentError(err)
 		}
 		return nil, err
@@ -895,6 +950,7 @@ func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if err == environs.ErrPartialInstances {
 		for _, inst := range insts {
 			if inst != nil {
+				e.annotateSpotInstances(insts)
 				return insts, environs.ErrPartialInstances
 			}
 		}
@@ -903,6 +959,7 @@ func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if err != nil {
 		return nil, err
 	}
+	e.annotateSpotInstances(insts)
 	return insts, nil
 }
 
@@ -916,7 +973,7 @@ func (e *environ) gatherInstances(
 	insts []instance.Instance,
 	filter *ec2.Filter,
 ) error {
-	resp, err := e.ec2.Instances(nil, filter)
+	resp, err := e.instances(nil, filter)
 	if err != nil {
 		return err
 	}
@@ -1152,7 +1209,13 @@ func (e *environ) AdoptResources(controllerUUID string, fromVersion version.Numb
 
 // AllInstances is part of the environs.InstanceBroker interface.
 func (e *environ) AllInstances() ([]instance.Instance, error) {
-	return e.AllInstancesByState("pending", "running")
+	instances, err := e.AllInstancesByState("pending", "running")
+	if ec2ErrorClass(err) == environs.ErrorClassCredential {
+		// Let the credential validator tell that the
+		// credential was rejected.
+		err = common.ClassifiedError(environs.ErrorClassCredential, err)
+	}
+	return instances, err
 }
 
 // AllInstancesByState returns all instances in the environment
@@ -1233,7 +1296,7 @@ func (e *environ) allInstanceIDs(filter *ec2.Filter) ([]instance.Id, error) {
 }
 
 func (e *environ) allInstances(filter *ec2.Filter) ([]instance.Instance, error) {
-	resp, err := e.ec2.Instances(nil, filter)
+	resp, err := e.instances(nil, filter)
 	if err != nil {
 		return nil, errors.Annotate(err, "listing instances")
 	}
@@ -1324,20 +1387,32 @@ func (e *environ) allModelVolumes(includeRootDisks bool) ([]string, error) {
 	return listVolumes(e.ec2, filter, includeRootDisks)
 }
 
+// rulesToIPPerms converts ingress rules to EC2 IP permissions. EC2
+// permissions can only express IPv4 source ranges, so IPv6 ranges are
+// dropped, along with any rule that is left with no source range.
 func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(rules))
-	for i, r := range rules {
-		ipPerms[i] = ec2.IPPerm{
+	ipPerms := make([]ec2.IPPerm, 0, len(rules))
+	for _, r := range rules {
+		ipPerm := ec2.IPPerm{
 			Protocol: r.Protocol,
 			FromPort: r.FromPort,
 			ToPort:   r.ToPort,
 		}
 		if len(r.SourceCIDRs) == 0 {
-			ipPerms[i].SourceIPs = []string{defaultRouteCIDRBlock}
+			ipPerm.SourceIPs = []string{defaultRouteCIDRBlock}
 		} else {
-			ipPerms[i].SourceIPs = make([]string, len(r.SourceCIDRs))
-			copy(ipPerms[i].SourceIPs, r.SourceCIDRs)
+			for _, cidr := range r.SourceCIDRs {
+				if strings.Contains(cidr, ":") {
+					logger.Warningf("ignoring IPv6 source range %q for %v: not supported", cidr, r.PortRange)
+					continue
+				}
+				ipPerm.SourceIPs = append(ipPerm.SourceIPs, cidr)
+			}
+			if len(ipPerm.SourceIPs) == 0 {
+				continue
+			}
 		}
+		ipPerms = append(ipPerms, ipPerm)
 	}
 	return ipPerms
 }
@@ -1352,7 +1427,7 @@ func (e *environ) openPortsInGroup(name string, rules []network.IngressRule) err
 		return err
 	}
 	ipPerms := rulesToIPPerms(rules)
-	_, err = e.ec2.AuthorizeSecurityGroup(g, ipPerms)
+	err = e.authorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
 		if len(rules) == 1 {
 			return nil
@@ -1362,7 +1437,7 @@ func (e *environ) openPortsInGroup(name string, rules []network.IngressRule) err
 		// otherwise the ports that were *not* duplicates will have
 		// been ignored
 		for i := range ipPerms {
-			_, err := e.ec2.AuthorizeSecurityGroup(g, ipPerms[i:i+1])
+			err := e.authorizeSecurityGroup(g, ipPerms[i:i+1])
 			if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
 				return fmt.Errorf("cannot open port %v: %v", ipPerms[i], err)
 			}
@@ -1386,7 +1461,7 @@ func (e *environ) closePortsInGroup(name string, rules []network.IngressRule) er
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2.RevokeSecurityGroup(g, rulesToIPPerms(rules))
+	err = e.revokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
@@ -1442,6 +1517,90 @@ func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	return e.ingressRulesInGroup(e.globalGroupName())
 }
 
+// OpenApplicationPorts is part of the environs.ApplicationFirewaller interface.
+func (e *environ) OpenApplicationPorts(appName string, rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwApplication {
+		return errors.Errorf("invalid firewall mode %q for opening ports on application", e.Config().FirewallMode())
+	}
+	if err := e.openPortsInGroup(e.applicationGroupName(appName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in group for application %q: %v", appName, rules)
+	return nil
+}
+
+// CloseApplicationPorts is part of the environs.ApplicationFirewaller interface.
+func (e *environ) CloseApplicationPorts(appName string, rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwApplication {
+		return errors.Errorf("invalid firewall mode %q for closing ports on application", e.Config().FirewallMode())
+	}
+	if err := e.closePortsInGroup(e.applicationGroupName(appName), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in group for application %q: %v", appName, rules)
+	return nil
+}
+
+// ApplicationIngressRules is part of the environs.ApplicationFirewaller
+// interface. An application without a security group, because none of
+// its units has been provisioned yet, has no ingress rules.
+func (e *environ) ApplicationIngressRules(appName string) ([]network.IngressRule, error) {
+	if e.Config().FirewallMode() != config.FwApplication {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ingress rules from application", e.Config().FirewallMode())
+	}
+	rules, err := e.ingressRulesInGroup(e.applicationGroupName(appName))
+	if isNotFoundError(err) {
+		return nil, nil
+	}
+	return rules, errors.Trace(err)
+}
+
+// AddApplicationInstance is part of the environs.ApplicationFirewaller
+// interface. The instance joins the application's security group,
+// which is created if necessary. As only instances in a VPC may change
+// security groups once started, this fails for EC2-Classic instances.
+func (e *environ) AddApplicationInstance(appName string, id instance.Id) error {
+	if e.Config().FirewallMode() != config.FwApplication {
+		return errors.Errorf("invalid firewall mode %q for adding instance to application", e.Config().FirewallMode())
+	}
+	resp, err := e.instances([]string{string(id)}, nil)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get instance %q", id)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return errors.NotFoundf("instance %q", id)
+	}
+	inst := resp.Reservations[0].Instances[0]
+
+	name := e.applicationGroupName(appName)
+	groupIds := make([]string, 0, len(inst.SecurityGroups)+1)
+	for _, g := range inst.SecurityGroups {
+		if g.Name == name {
+			return nil
+		}
+		groupIds = append(groupIds, g.Id)
+	}
+	var controllerUUID string
+	for _, tag := range inst.Tags {
+		if tag.Key == tags.JujuController {
+			controllerUUID = tag.Value
+		}
+	}
+	appGroup, err := e.ensureApplicationGroup(controllerUUID, appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	groupIds = append(groupIds, appGroup.Id)
+	err = e.throttle.Call(apiFamilySecurityGroups, func() error {
+		return newInstanceGroupsAPI(e.ec2).SetInstanceGroups(string(id), groupIds)
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot add instance %q to security group %q", id, name)
+	}
+	logger.Infof("added instance %q to security group for application %q", id, appName)
+	return nil
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -1457,7 +1616,7 @@ func (e *environ) instanceSecurityGroups(instIDs []instance.Id, states ...string
 		filter.Add("instance-state-name", states...)
 	}
 
-	resp, err := e.ec2.Instances(strInstID, filter)
+	resp, err := e.instances(strInstID, filter)
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot retrieve instance information from aws to delete security groups")
 	}
@@ -1477,7 +1636,7 @@ func (e *environ) instanceSecurityGroups(instIDs []instance.Id, states ...string
 func (e *environ) controllerSecurityGroups(controllerUUID string) ([]ec2.SecurityGroup, error) {
 	filter := ec2.NewFilter()
 	e.addControllerFilter(filter, controllerUUID)
-	resp, err := e.ec2.SecurityGroups(nil, filter)
+	resp, err := e.securityGroups(nil, filter)
 	if err != nil {
 		return nil, errors.Annotate(err, "listing security groups")
 	}
@@ -1491,7 +1650,7 @@ func (e *environ) controllerSecurityGroups(controllerUUID string) ([]ec2.Securit
 func (e *environ) modelSecurityGroupIDs() ([]string, error) {
 	filter := ec2.NewFilter()
 	e.addModelFilter(filter)
-	resp, err := e.ec2.SecurityGroups(nil, filter)
+	resp, err := e.securityGroups(nil, filter)
 	if err != nil {
 		return nil, errors.Annotate(err, "listing security groups")
 	}
@@ -1667,6 +1826,10 @@ func (e *environ) machineGroupName(machineId string) string {
 	return fmt.Sprintf("%s-%s", e.jujuGroupName(), machineId)
 }
 
+func (e *environ) applicationGroupName(appName string) string {
+	return fmt.Sprintf("%s-app-%s", e.jujuGroupName(), appName)
+}
+
 func (e *environ) jujuGroupName() string {
 	return "juju-" + e.uuid()
 }
@@ -1678,7 +1841,9 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
+// In application firewall mode, the machine instead joins a group for
+// each of the given applications.
+func (e *environ) setUpGroups(controllerUUID, machineId string, appNames []string, apiPort int) ([]ec2.SecurityGroup, error) {
 
 	// Ensure there's a global group for Juju-related traffic.
 	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
@@ -1716,6 +1881,16 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 		machineGroup, err = e.ensureGroup(controllerUUID, e.machineGroupName(machineId), nil)
 	case config.FwGlobal:
 		machineGroup, err = e.ensureGroup(controllerUUID, e.globalGroupName(), nil)
+	case config.FwApplication:
+		groups := []ec2.SecurityGroup{jujuGroup}
+		for _, appName := range appNames {
+			appGroup, err := e.ensureApplicationGroup(controllerUUID, appName)
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, appGroup)
+		}
+		return groups, nil
 	}
 	if err != nil {
 		return nil, err
@@ -1723,6 +1898,38 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	return []ec2.SecurityGroup{jujuGroup, machineGroup}, nil
 }
 
+// ensureApplicationGroup returns the security group for the named
+// application, creating it if necessary. Unlike the other groups, the
+// permissions of an existing application group are left alone, as they
+// are shared by every machine hosting the application's units.
+func (e *environ) ensureApplicationGroup(controllerUUID, appName string) (ec2.SecurityGroup, error) {
+	name := e.applicationGroupName(appName)
+	g, err := e.groupByName(name)
+	if err == nil {
+		return g, nil
+	} else if !isNotFoundError(err) {
+		return zeroGroup, errors.Annotatef(err, "fetching security group %q", name)
+	}
+	return e.ensureGroup(controllerUUID, name, nil)
+}
+
+// deployedApplications returns the sorted names of the applications
+// whose units are listed in the juju-units-deployed instance tag.
+func deployedApplications(instanceTags map[string]string) []string {
+	appNames := set.NewStrings()
+	for _, unitName := range strings.Fields(instanceTags[tags.JujuUnitsDeployed]) {
+		if !names.IsValidUnit(unitName) {
+			continue
+		}
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			continue
+		}
+		appNames.Add(appName)
+	}
+	return appNames.SortedValues()
+}
+
 // zeroGroup holds the zero security group.
 var zeroGroup ec2.SecurityGroup
 
@@ -1736,13 +1943,13 @@ func (e *environ) securityGroupsByNameOrID(groupName string) (*ec2.SecurityGroup
 		filter := ec2.NewFilter()
 		filter.Add("vpc-id", chosenVPCID)
 		filter.Add("group-name", groupName)
-		return e.ec2.SecurityGroups(nil, filter)
+		return e.securityGroups(nil, filter)
 	}
 
 	// EC2-Classic or EC2-VPC with implicit default VPC need to use the
 	// GroupName.X arguments instead of the filters.
 	groups := ec2.SecurityGroupNames(groupName)
-	return e.ec2.SecurityGroups(groups, nil)
+	return e.securityGroups(groups, nil)
 }
 
 // ensureGroup returns the security group with name and perms.
@@ -1811,7 +2018,7 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 		}
 	}
 	if len(revoke) > 0 {
-		_, err := e.ec2.RevokeSecurityGroup(g, revoke.ipPerms())
+		err := e.revokeSecurityGroup(g, revoke.ipPerms())
 		if err != nil {
 			err = errors.Annotatef(err, "revoking security group %q%s", g.Id, inVPCLogSuffix)
 			return zeroGroup, err
@@ -1825,7 +2032,7 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 		}
 	}
 	if len(add) > 0 {
-		_, err := e.ec2.AuthorizeSecurityGroup(g, add.ipPerms())
+		err := e.authorizeSecurityGroup(g, add.ipPerms())
 		if err != nil {
 			err = errors.Annotatef(err, "authorizing security group %q%s", g.Id, inVPCLogSuffix)
 			return zeroGroup, err
@@ -1922,6 +2129,24 @@ func isZoneConstrainedError(err error) bool {
 	return false
 }
 
+// ec2ErrorClass returns the class of the given EC2 error, so that the
+// provisioner can decide whether and how soon to retry the request.
+func ec2ErrorClass(err error) environs.ErrorClass {
+	if err, ok := errors.Cause(err).(*ec2.Error); ok {
+		switch err.Code {
+		case "InstanceLimitExceeded", "VcpuLimitExceeded", "VolumeLimitExceeded", "AddressLimitExceeded":
+			return environs.ErrorClassQuota
+		case "RequestLimitExceeded", "Throttling":
+			return environs.ErrorClassRateLimit
+		case "InsufficientInstanceCapacity", "InsufficientHostCapacity", "InsufficientReservedInstanceCapacity":
+			return environs.ErrorClassCapacity
+		case "AuthFailure", "UnauthorizedOperation", "OptInRequired":
+			return environs.ErrorClassCredential
+		}
+	}
+	return environs.ErrorClassUnknown
+}
+
 // isSubnetConstrainedError reports whether or not the error indicates
 // RunInstances failed due to the specified VPC subnet ID being constrained for
 // the instance type being provisioned, or is otherwise unusable for the
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
@@ -155,6 +156,18 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			ToPort:    82,
 			SourceIPs: []string{"192.168.1.0/24", "0.0.0.0/0"},
 		}},
+	}, {
+		about: "IPv6 source ranges are dropped",
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 82, "0.0.0.0/0", "::/0"),
+			network.MustNewIngressRule("tcp", 100, 120, "2001:db8::/32"),
+		},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "tcp",
+			FromPort:  80,
+			ToPort:    82,
+			SourceIPs: []string{"0.0.0.0/0"},
+		}},
 	}}
 
 	for i, t := range testCases {
@@ -198,3 +211,11 @@ func (*Suite) TestSupportsContainerAddresses(c *gc.C) {
 	c.Assert(supported, jc.IsFalse)
 	c.Check(env, gc.Not(jc.Satisfies), environs.SupportsContainerAddresses)
 }
+
+func (*Suite) TestDeployedApplications(c *gc.C) {
+	c.Assert(deployedApplications(nil), gc.HasLen, 0)
+	appNames := deployedApplications(map[string]string{
+		tags.JujuUnitsDeployed: "wordpress/1 mysql/0 wordpress/0 not-a-unit",
+	})
+	c.Assert(appNames, jc.DeepEquals, []string{"mysql", "wordpress"})
+}
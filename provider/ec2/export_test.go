@@ -29,6 +29,10 @@ func MachineGroupName(e environs.Environ, machineId string) string {
 	return e.(*environ).machineGroupName(machineId)
 }
 
+func ApplicationGroupName(e environs.Environ, appName string) string {
+	return e.(*environ).applicationGroupName(appName)
+}
+
 func EnvironEC2(e environs.Environ) *ec2.EC2 {
 	return e.(*environ).ec2
 }
@@ -60,8 +64,23 @@ var (
 	GetBlockDeviceMappings   = getBlockDeviceMappings
 	IsVPCNotUsableError      = isVPCNotUsableError
 	IsVPCNotRecommendedError = isVPCNotRecommendedError
+	NewInstanceGroupsAPI     = &newInstanceGroupsAPI
 )
 
+type instanceGroupsFunc func(instId string, groupIds []string) error
+
+func (f instanceGroupsFunc) SetInstanceGroups(instId string, groupIds []string) error {
+	return f(instId, groupIds)
+}
+
+// InstanceGroupsAPI returns a replacement for newInstanceGroupsAPI
+// whose instanceGroupsAPI calls setGroups.
+func InstanceGroupsAPI(setGroups func(instId string, groupIds []string) error) func(*ec2.EC2) instanceGroupsAPI {
+	return func(*ec2.EC2) instanceGroupsAPI {
+		return instanceGroupsFunc(setGroups)
+	}
+}
+
 const VPCIDNone = vpcIDNone
 
 // TODO: Apart from overriding different hardcoded hosts, these two test helpers are identical. Let's share.
@@ -81,6 +100,7 @@ var (
 	DestroyVolumeAttempt           = &destroyVolumeAttempt
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
+	InstanceMetadataURL            = &instanceMetadataURL
 )
 
 // FabricateInstance creates a new fictitious instance
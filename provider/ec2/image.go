@@ -4,11 +4,18 @@
 package ec2
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/amz.v3/ec2"
+
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 )
 
+var _ environs.ImageChecker = (*environ)(nil)
+
 // filterImages returns only that subset of the input (in the same order) that
 // this provider finds suitable.
 func filterImages(images []*imagemetadata.ImageMetadata, ic *instances.InstanceConstraint) []*imagemetadata.ImageMetadata {
@@ -68,3 +75,29 @@ func withDefaultNonControllerConstraints(cons constraints.Value) constraints.Val
 	}
 	return cons
 }
+
+// MissingImages is specified in the environs.ImageChecker interface.
+func (e *environ) MissingImages(imageIds []string) ([]string, error) {
+	if len(imageIds) == 0 {
+		return nil, nil
+	}
+	// Filter by image ID rather than asking for the images by ID,
+	// as EC2 fails the whole request if any of them do not exist.
+	filter := ec2.NewFilter()
+	filter.Add("image-id", imageIds...)
+	resp, err := e.images(filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing images")
+	}
+	found := set.NewStrings()
+	for _, image := range resp.Images {
+		found.Add(image.Id)
+	}
+	var missing []string
+	for _, id := range imageIds {
+		if !found.Contains(id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
@@ -18,6 +18,10 @@ type ec2Instance struct {
 	e *environ
 
 	*ec2.Instance
+
+	// spotStatus holds the status code of the instance's spot request,
+	// if it is a spot instance that is being interrupted.
+	spotStatus string
 }
 
 func (inst *ec2Instance) String() string {
@@ -43,11 +47,14 @@ func (inst *ec2Instance) Status() instance.InstanceStatus {
 	default:
 		jujuStatus = status.Empty
 	}
+	message := inst.State.Name
+	if inst.spotStatus != "" {
+		message = fmt.Sprintf("%s (spot instance %s)", message, inst.spotStatus)
+	}
 	return instance.InstanceStatus{
 		Status:  jujuStatus,
-		Message: inst.State.Name,
+		Message: message,
 	}
-
 }
 
 // Addresses implements network.Addresses() returning generic address
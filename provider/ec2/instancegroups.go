@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// newInstanceGroupsAPI returns the instanceGroupsAPI used to change the
+// security groups of running instances.
+var newInstanceGroupsAPI = func(e *ec2.EC2) instanceGroupsAPI {
+	return queryClient{e}
+}
+
+// instanceGroupsAPI holds the EC2 call that changes the security groups
+// of a running instance, which the amz client does not provide.
+type instanceGroupsAPI interface {
+	// SetInstanceGroups replaces the security groups of the instance
+	// with the given ID. Only instances in a VPC may change groups.
+	SetInstanceGroups(instId string, groupIds []string) error
+}
+
+// SetInstanceGroups is part of the instanceGroupsAPI interface.
+func (c queryClient) SetInstanceGroups(instId string, groupIds []string) error {
+	params := map[string]string{
+		"Action":     "ModifyInstanceAttribute",
+		"InstanceId": instId,
+	}
+	for i, id := range groupIds {
+		params["GroupId."+strconv.Itoa(i+1)] = id
+	}
+	var resp ec2.SimpleResp
+	return errors.Trace(c.query(params, &resp))
+}
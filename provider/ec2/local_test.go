@@ -1115,6 +1115,147 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(errors.Details(err), jc.Contains, runInstancesError.Message)
 }
 
+func (t *localServerSuite) TestStartInstanceClassifiedErrors(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	for i, test := range []struct {
+		code            string
+		class           environs.ErrorClass
+		zoneIndependent bool
+	}{
+		{"InstanceLimitExceeded", environs.ErrorClassQuota, true},
+		{"RequestLimitExceeded", environs.ErrorClassRateLimit, true},
+		{"InsufficientInstanceCapacity", environs.ErrorClassCapacity, false},
+		{"UnauthorizedOperation", environs.ErrorClassCredential, true},
+		{"InternalError", environs.ErrorClassUnknown, true},
+	} {
+		c.Logf("test %d: %s", i, test.code)
+		runInstancesError := &amzec2.Error{Code: test.code, Message: "blah blah"}
+		t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+			return nil, runInstancesError
+		})
+		params := environs.StartInstanceParams{
+			ControllerUUID:   t.ControllerUUID,
+			StatusCallback:   fakeCallback,
+			AvailabilityZone: "test-available",
+		}
+		_, err := testing.StartInstanceWithParams(env, "1", params)
+		c.Check(environs.ErrorClassOf(err), gc.Equals, test.class)
+		c.Check(environs.IsAvailabilityZoneIndependent(err), gc.Equals, test.zoneIndependent)
+	}
+}
+
+func (t *localServerSuite) TestStartInstanceInstanceProfile(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var profile string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		profile = ri.IAMInstanceProfile
+		return nil, &amzec2.Error{Code: "InternalError"}
+	})
+	startInstance := func(cons constraints.Value) {
+		params := environs.StartInstanceParams{
+			ControllerUUID:   t.ControllerUUID,
+			StatusCallback:   fakeCallback,
+			AvailabilityZone: "test-available",
+			Constraints:      cons,
+		}
+		_, err := testing.StartInstanceWithParams(env, "1", params)
+		c.Assert(err, gc.ErrorMatches, ".*InternalError.*")
+	}
+
+	startInstance(constraints.Value{})
+	c.Check(profile, gc.Equals, "")
+
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"instance-profile": "model-role",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	startInstance(constraints.Value{})
+	c.Check(profile, gc.Equals, "model-role")
+
+	startInstance(constraints.MustParse("instance-profile=machine-role"))
+	c.Check(profile, gc.Equals, "machine-role")
+}
+
+func (t *localServerSuite) TestApplicationPorts(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	appFwEnv := env.(environs.ApplicationFirewaller)
+
+	// Application mode must be enabled for the methods to work.
+	err := appFwEnv.OpenApplicationPorts("wordpress", nil)
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening ports on application`)
+
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"firewall-mode": "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An application with no security group has no ingress rules.
+	rules, err := appFwEnv.ApplicationIngressRules("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	_, err = t.client.CreateSecurityGroup("", ec2.ApplicationGroupName(env, "wordpress"), "juju group")
+	c.Assert(err, jc.ErrorIsNil)
+
+	toOpen := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24"),
+	}
+	err = appFwEnv.OpenApplicationPorts("wordpress", toOpen)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = appFwEnv.ApplicationIngressRules("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, toOpen)
+
+	err = appFwEnv.CloseApplicationPorts("wordpress", toOpen[:1])
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = appFwEnv.ApplicationIngressRules("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, toOpen[1:])
+}
+
+func (t *localServerSuite) TestAddApplicationInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	appFwEnv := env.(environs.ApplicationFirewaller)
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"firewall-mode": "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The test server cannot change the groups of an instance.
+	var instIds []string
+	var groupIds [][]string
+	t.PatchValue(ec2.NewInstanceGroupsAPI, ec2.InstanceGroupsAPI(func(instId string, ids []string) error {
+		instIds = append(instIds, instId)
+		groupIds = append(groupIds, ids)
+		return nil
+	}))
+
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	err = appFwEnv.AddApplicationInstance("wordpress", inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	groupName := ec2.ApplicationGroupName(env, "wordpress")
+	resp, err := t.client.SecurityGroups(amzec2.SecurityGroupNames(groupName), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Groups, gc.HasLen, 1)
+	var expectIds []string
+	for _, g := range ec2.InstanceEC2(inst).SecurityGroups {
+		expectIds = append(expectIds, g.Id)
+	}
+	expectIds = append(expectIds, resp.Groups[0].Id)
+	c.Assert(instIds, jc.DeepEquals, []string{string(inst.Id())})
+	c.Assert(groupIds, jc.DeepEquals, [][]string{expectIds})
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets and
@@ -1484,6 +1625,32 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceSpaceSubnetsInZone(c *gc.C) {
+	env := t.Prepare(c)
+	err := env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   "zone=test-available",
+		Constraints: constraints.MustParse("spaces=db"),
+		SubnetsToZones: map[network.Id][]string{
+			"subnet-0": {"test-available"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceSpaceNoSubnetsInZone(c *gc.C) {
+	env := t.Prepare(c)
+	err := env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   "zone=test-available",
+		Constraints: constraints.MustParse("spaces=db"),
+		SubnetsToZones: map[network.Id][]string{
+			"subnet-0": {"test-impaired"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `space "db" has no subnets in availability zone "test-available"`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceVolumeAvailZoneNoPlacement(c *gc.C) {
 	t.testPrecheckInstanceVolumeAvailZone(c, "")
 }
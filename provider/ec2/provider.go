@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/amz.v3/aws"
 	"gopkg.in/amz.v3/ec2"
 
@@ -54,6 +55,10 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	e.throttle, err = newThrottle(clock.WallClock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := e.SetConfig(args.Config); err != nil {
 		return nil, errors.Trace(err)
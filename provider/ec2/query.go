@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// queryAPIVersion is the EC2 API version used for the calls that the
+// amz client does not provide. From this version on the spot price may
+// be omitted, in which case it is capped at the on-demand price.
+const queryAPIVersion = "2016-11-15"
+
+// queryClient makes EC2 query API calls with the credentials, endpoint
+// and signer of an amz EC2 client. It implements the spotAPI,
+// instanceGroupsAPI and vpcEndpointAPI interfaces.
+type queryClient struct {
+	ec2 *ec2.EC2
+}
+
+type queryErrorResp struct {
+	RequestId string      `xml:"RequestID"`
+	Errors    []ec2.Error `xml:"Errors>Error"`
+}
+
+func (c queryClient) query(params map[string]string, resp interface{}) error {
+	endpoint, err := url.Parse(c.ec2.Region.EC2Endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if endpoint.Path == "" {
+		endpoint.Path = "/"
+	}
+	query := endpoint.Query()
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	query.Set("Version", queryAPIVersion)
+	query.Set("Timestamp", time.Now().In(time.UTC).Format(time.RFC3339))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.ec2.Sign(req, c.ec2.Auth); err != nil {
+		return errors.Trace(err)
+	}
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		var errResp queryErrorResp
+		if err := xml.NewDecoder(r.Body).Decode(&errResp); err != nil || len(errResp.Errors) == 0 {
+			return &ec2.Error{StatusCode: r.StatusCode, Message: r.Status}
+		}
+		ec2Err := errResp.Errors[0]
+		ec2Err.StatusCode = r.StatusCode
+		ec2Err.RequestId = errResp.RequestId
+		return &ec2Err
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
@@ -0,0 +1,298 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// tagSpotRequest is the tag recording the spot instance request
+	// that an instance was started for.
+	tagSpotRequest = "juju-spot-request"
+)
+
+var (
+	// spotRequestAttempt governs how long we wait for a spot instance
+	// request to be fulfilled before falling back to on-demand.
+	spotRequestAttempt = utils.AttemptStrategy{
+		Total: 2 * time.Minute,
+		Delay: 5 * time.Second,
+	}
+
+	// newSpotAPI returns the spotAPI used to make spot requests.
+	newSpotAPI = func(e *ec2.EC2) spotAPI {
+		return queryClient{e}
+	}
+)
+
+// spotRequest describes an EC2 spot instance request.
+type spotRequest struct {
+	Id            string `xml:"spotInstanceRequestId"`
+	State         string `xml:"state"`
+	StatusCode    string `xml:"status>code"`
+	StatusMessage string `xml:"status>message"`
+	InstanceId    string `xml:"instanceId"`
+}
+
+// spotAPI holds the EC2 spot instance calls, which the amz client does
+// not provide.
+type spotAPI interface {
+	// RequestSpotInstance requests a single one-time spot instance
+	// matching the supplied run arguments. An empty price leaves the
+	// price capped at the on-demand price.
+	RequestSpotInstance(ri *ec2.RunInstances, price string) (spotRequest, error)
+
+	// SpotRequests returns the spot requests with the given ids.
+	SpotRequests(ids ...string) ([]spotRequest, error)
+
+	// CancelSpotRequest cancels the spot request with the given id.
+	CancelSpotRequest(id string) error
+}
+
+// errSpotUnfulfilled is returned by runSpotInstance when a spot
+// instance could not be had, and an on-demand instance should be
+// started instead.
+var errSpotUnfulfilled = errors.New("spot request not fulfilled")
+
+// spotUnfulfilledCodes are the spot request status codes indicating
+// that the request cannot currently be fulfilled.
+var spotUnfulfilledCodes = map[string]bool{
+	"capacity-not-available":      true,
+	"capacity-oversubscribed":     true,
+	"price-too-low":               true,
+	"az-group-constraint":         true,
+	"placement-group-constraint":  true,
+	"constraint-not-fulfillable":  true,
+	"launch-group-constraint":     true,
+	"system-error":                true,
+	"canceled-before-fulfillment": true,
+}
+
+// spotInterruptionCodes are the spot request status codes indicating
+// that the instance has been, or is about to be, interrupted.
+var spotInterruptionCodes = map[string]bool{
+	"marked-for-termination":                      true,
+	"marked-for-stop":                             true,
+	"instance-terminated-by-price":                true,
+	"instance-terminated-no-capacity":             true,
+	"instance-terminated-capacity-oversubscribed": true,
+	"instance-stopped-by-price":                   true,
+	"instance-stopped-no-capacity":                true,
+}
+
+var runSpotInstance = _runSpotInstance
+
+// runSpotInstance requests a spot instance matching the supplied run
+// arguments and waits for the request to be fulfilled. If it cannot be
+// fulfilled in time, the request is cancelled and errSpotUnfulfilled
+// is returned.
+func _runSpotInstance(e *ec2.EC2, ri *ec2.RunInstances, price string, c environs.StatusCallbackFunc) (*ec2.RunInstancesResp, error) {
+	api := newSpotAPI(e)
+	c(status.Allocating, "Requesting spot instance", nil)
+	req, err := api.RequestSpotInstance(ri, price)
+	if err != nil {
+		return nil, errors.Annotate(err, "requesting spot instance")
+	}
+	logger.Debugf("created spot request %q", req.Id)
+
+	for a := spotRequestAttempt.Start(); req.InstanceId == "" && a.Next(); {
+		reqs, err := api.SpotRequests(req.Id)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting spot request %q", req.Id)
+		}
+		if len(reqs) != 1 {
+			return nil, errors.Errorf("expected 1 spot request, got %d", len(reqs))
+		}
+		req = reqs[0]
+		logger.Tracef("spot request %q: %s (%s)", req.Id, req.State, req.StatusCode)
+		if req.InstanceId != "" {
+			break
+		}
+		if req.State == "failed" || spotUnfulfilledCodes[req.StatusCode] {
+			break
+		}
+		c(status.Allocating, fmt.Sprintf("Waiting for spot instance: %s", req.StatusCode), nil)
+	}
+	if req.InstanceId == "" {
+		logger.Infof("spot request %q not fulfilled: %s %s", req.Id, req.StatusCode, req.StatusMessage)
+		if err := api.CancelSpotRequest(req.Id); err != nil {
+			return nil, errors.Annotatef(err, "cancelling spot request %q", req.Id)
+		}
+		return nil, errors.Annotatef(errSpotUnfulfilled, "%s", req.StatusCode)
+	}
+
+	var resp *ec2.InstancesResp
+	for a := shortAttempt.Start(); a.Next(); {
+		resp, err = e.Instances([]string{req.InstanceId}, nil)
+		if err == nil || !isNotFoundError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting spot instance %q", req.InstanceId)
+	}
+	result := &ec2.RunInstancesResp{}
+	for _, r := range resp.Reservations {
+		result.Instances = append(result.Instances, r.Instances...)
+	}
+	if len(result.Instances) != 1 {
+		return nil, errors.Errorf("expected 1 spot instance, got %d", len(result.Instances))
+	}
+	// Remember the request, so interruptions can be reported.
+	if err := tagResources(e, map[string]string{tagSpotRequest: req.Id}, req.InstanceId); err != nil {
+		return nil, errors.Annotate(err, "tagging spot instance")
+	}
+	return result, nil
+}
+
+// isSpotUnfulfilledError reports whether the error indicates that a
+// spot instance request could not be fulfilled.
+func isSpotUnfulfilledError(err error) bool {
+	return errors.Cause(err) == errSpotUnfulfilled
+}
+
+// annotateSpotInstances records the spot request status of any spot
+// instances amongst insts whose instance is being interrupted. Failure
+// to get the requests is logged rather than returned, as it does not
+// affect the instances themselves.
+func (e *environ) annotateSpotInstances(insts []instance.Instance) {
+	byRequest := make(map[string]*ec2Instance)
+	var ids []string
+	for _, i := range insts {
+		inst, ok := i.(*ec2Instance)
+		if !ok {
+			continue
+		}
+		for _, tag := range inst.Tags {
+			if tag.Key == tagSpotRequest && tag.Value != "" {
+				byRequest[tag.Value] = inst
+				ids = append(ids, tag.Value)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	reqs, err := newSpotAPI(e.ec2).SpotRequests(ids...)
+	if err != nil {
+		logger.Warningf("cannot get spot requests: %v", err)
+		return
+	}
+	for _, req := range reqs {
+		if inst := byRequest[req.Id]; inst != nil && spotInterruptionCodes[req.StatusCode] {
+			inst.spotStatus = req.StatusCode
+		}
+	}
+}
+
+type spotRequestsResp struct {
+	Requests []spotRequest `xml:"spotInstanceRequestSet>item"`
+}
+
+// RequestSpotInstance is part of the spotAPI interface.
+func (c queryClient) RequestSpotInstance(ri *ec2.RunInstances, price string) (spotRequest, error) {
+	var resp spotRequestsResp
+	if err := c.query(spotRequestParams(ri, price), &resp); err != nil {
+		return spotRequest{}, errors.Trace(err)
+	}
+	if len(resp.Requests) != 1 {
+		return spotRequest{}, errors.Errorf("expected 1 spot request, got %d", len(resp.Requests))
+	}
+	return resp.Requests[0], nil
+}
+
+// SpotRequests is part of the spotAPI interface.
+func (c queryClient) SpotRequests(ids ...string) ([]spotRequest, error) {
+	params := map[string]string{"Action": "DescribeSpotInstanceRequests"}
+	for i, id := range ids {
+		params["SpotInstanceRequestId."+strconv.Itoa(i+1)] = id
+	}
+	var resp spotRequestsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Requests, nil
+}
+
+// CancelSpotRequest is part of the spotAPI interface.
+func (c queryClient) CancelSpotRequest(id string) error {
+	params := map[string]string{
+		"Action":                  "CancelSpotInstanceRequests",
+		"SpotInstanceRequestId.1": id,
+	}
+	var resp spotRequestsResp
+	return errors.Trace(c.query(params, &resp))
+}
+
+// spotRequestParams returns the RequestSpotInstances query parameters
+// for a single one-time instance matching the supplied run arguments.
+func spotRequestParams(ri *ec2.RunInstances, price string) map[string]string {
+	const spec = "LaunchSpecification."
+	params := map[string]string{
+		"Action":              "RequestSpotInstances",
+		"InstanceCount":       "1",
+		"Type":                "one-time",
+		spec + "ImageId":      ri.ImageId,
+		spec + "InstanceType": ri.InstanceType,
+	}
+	if price != "" {
+		params["SpotPrice"] = price
+	}
+	if len(ri.UserData) > 0 {
+		params[spec+"UserData"] = base64.StdEncoding.EncodeToString(ri.UserData)
+	}
+	if ri.AvailZone != "" {
+		params[spec+"Placement.AvailabilityZone"] = ri.AvailZone
+	}
+	if ri.SubnetId != "" {
+		params[spec+"SubnetId"] = ri.SubnetId
+	}
+	if ri.IAMInstanceProfile != "" {
+		params[spec+"IamInstanceProfile.Name"] = ri.IAMInstanceProfile
+	}
+	for i, g := range ri.SecurityGroups {
+		n := strconv.Itoa(i + 1)
+		if g.Id != "" {
+			params[spec+"SecurityGroupId."+n] = g.Id
+		} else {
+			params[spec+"SecurityGroup."+n] = g.Name
+		}
+	}
+	for i, b := range ri.BlockDeviceMappings {
+		prefix := spec + "BlockDeviceMapping." + strconv.Itoa(i+1) + "."
+		params[prefix+"DeviceName"] = b.DeviceName
+		if b.VirtualName != "" {
+			params[prefix+"VirtualName"] = b.VirtualName
+			continue
+		}
+		if b.SnapshotId != "" {
+			params[prefix+"Ebs.SnapshotId"] = b.SnapshotId
+		}
+		if b.VolumeType != "" {
+			params[prefix+"Ebs.VolumeType"] = b.VolumeType
+		}
+		if b.VolumeSize > 0 {
+			params[prefix+"Ebs.VolumeSize"] = strconv.FormatInt(b.VolumeSize, 10)
+		}
+		if b.IOPS > 0 {
+			params[prefix+"Ebs.Iops"] = strconv.FormatInt(b.IOPS, 10)
+		}
+		if b.DeleteOnTermination {
+			params[prefix+"Ebs.DeleteOnTermination"] = "true"
+		}
+	}
+	return params
+}
@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/aws"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+type spotSuite struct {
+	testing.IsolationSuite
+
+	api *fakeSpotAPI
+}
+
+var _ = gc.Suite(&spotSuite{})
+
+func (s *spotSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeSpotAPI{}
+	s.PatchValue(&newSpotAPI, func(*amzec2.EC2) spotAPI { return s.api })
+	s.PatchValue(&spotRequestAttempt, utils.AttemptStrategy{
+		Total: 50 * time.Millisecond,
+		Delay: time.Millisecond,
+	})
+}
+
+func nopCallback(status.Status, string, map[string]interface{}) error {
+	return nil
+}
+
+var _ environs.StatusCallbackFunc = nopCallback
+
+func (s *spotSuite) TestSpotRequestParams(c *gc.C) {
+	params := spotRequestParams(&amzec2.RunInstances{
+		ImageId:            "ami-123",
+		InstanceType:       "m3.medium",
+		UserData:           []byte("hello"),
+		AvailZone:          "us-east-1a",
+		SubnetId:           "subnet-1",
+		IAMInstanceProfile: "web-role",
+		SecurityGroups: []amzec2.SecurityGroup{
+			{Id: "sg-1"},
+			{Name: "juju-group"},
+		},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{{
+			DeviceName: "/dev/sda1",
+			VolumeSize: 8,
+		}, {
+			DeviceName:  "/dev/sdb",
+			VirtualName: "ephemeral0",
+		}},
+	}, "0.05")
+	c.Assert(params, jc.DeepEquals, map[string]string{
+		"Action":                           "RequestSpotInstances",
+		"InstanceCount":                    "1",
+		"Type":                             "one-time",
+		"SpotPrice":                        "0.05",
+		"LaunchSpecification.ImageId":      "ami-123",
+		"LaunchSpecification.InstanceType": "m3.medium",
+		"LaunchSpecification.UserData":     "aGVsbG8=",
+		"LaunchSpecification.Placement.AvailabilityZone":          "us-east-1a",
+		"LaunchSpecification.SubnetId":                            "subnet-1",
+		"LaunchSpecification.IamInstanceProfile.Name":             "web-role",
+		"LaunchSpecification.SecurityGroupId.1":                   "sg-1",
+		"LaunchSpecification.SecurityGroup.2":                     "juju-group",
+		"LaunchSpecification.BlockDeviceMapping.1.DeviceName":     "/dev/sda1",
+		"LaunchSpecification.BlockDeviceMapping.1.Ebs.VolumeSize": "8",
+		"LaunchSpecification.BlockDeviceMapping.2.DeviceName":     "/dev/sdb",
+		"LaunchSpecification.BlockDeviceMapping.2.VirtualName":    "ephemeral0",
+	})
+}
+
+func (s *spotSuite) TestSpotRequestParamsNoPrice(c *gc.C) {
+	params := spotRequestParams(&amzec2.RunInstances{ImageId: "ami-123"}, "")
+	_, ok := params["SpotPrice"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *spotSuite) TestRunSpotInstanceUnfulfilled(c *gc.C) {
+	s.api.requests = []spotRequest{{
+		Id:         "sir-1",
+		State:      "open",
+		StatusCode: "capacity-not-available",
+	}}
+	_, err := runSpotInstance(nil, &amzec2.RunInstances{}, "", nopCallback)
+	c.Assert(err, gc.ErrorMatches, "capacity-not-available: spot request not fulfilled")
+	c.Assert(isSpotUnfulfilledError(err), jc.IsTrue)
+	s.api.CheckCallNames(c, "RequestSpotInstance", "SpotRequests", "CancelSpotRequest")
+	s.api.CheckCall(c, 2, "CancelSpotRequest", "sir-1")
+}
+
+func (s *spotSuite) TestRunSpotInstanceTimesOut(c *gc.C) {
+	s.api.requests = []spotRequest{{
+		Id:         "sir-1",
+		State:      "open",
+		StatusCode: "pending-fulfillment",
+	}}
+	_, err := runSpotInstance(nil, &amzec2.RunInstances{}, "0.01", nopCallback)
+	c.Assert(isSpotUnfulfilledError(err), jc.IsTrue)
+	calls := s.api.Calls()
+	c.Assert(calls[len(calls)-1].FuncName, gc.Equals, "CancelSpotRequest")
+}
+
+func (s *spotSuite) TestRunSpotInstanceRequestError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := runSpotInstance(nil, &amzec2.RunInstances{}, "", nopCallback)
+	c.Assert(err, gc.ErrorMatches, "requesting spot instance: boom")
+	c.Assert(isSpotUnfulfilledError(err), jc.IsFalse)
+}
+
+func (s *spotSuite) TestAnnotateSpotInstances(c *gc.C) {
+	s.api.requests = []spotRequest{{
+		Id:         "sir-1",
+		State:      "active",
+		StatusCode: "marked-for-termination",
+	}}
+	spot := &ec2Instance{Instance: &amzec2.Instance{
+		InstanceId: "i-1",
+		State:      amzec2.InstanceState{Name: "running"},
+		Tags:       []amzec2.Tag{{Key: tagSpotRequest, Value: "sir-1"}},
+	}}
+	onDemand := &ec2Instance{Instance: &amzec2.Instance{
+		InstanceId: "i-2",
+		State:      amzec2.InstanceState{Name: "running"},
+	}}
+	e := &environ{}
+	e.annotateSpotInstances([]instance.Instance{spot, nil, onDemand})
+
+	c.Assert(spot.Status(), jc.DeepEquals, instance.InstanceStatus{
+		Status:  status.Running,
+		Message: "running (spot instance marked-for-termination)",
+	})
+	c.Assert(onDemand.Status(), jc.DeepEquals, instance.InstanceStatus{
+		Status:  status.Running,
+		Message: "running",
+	})
+	s.api.CheckCall(c, 0, "SpotRequests", []string{"sir-1"})
+}
+
+func (s *spotSuite) TestSpotClientQuery(c *gc.C) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`<DescribeSpotInstanceRequestsResponse>
+  <spotInstanceRequestSet>
+    <item>
+      <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+      <state>active</state>
+      <status><code>fulfilled</code><message>ok</message></status>
+      <instanceId>i-1</instanceId>
+    </item>
+  </spotInstanceRequestSet>
+</DescribeSpotInstanceRequestsResponse>`))
+	}))
+	defer srv.Close()
+
+	client := queryClient{newTestEC2(srv.URL)}
+	reqs, err := client.SpotRequests("sir-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reqs, jc.DeepEquals, []spotRequest{{
+		Id:            "sir-1",
+		State:         "active",
+		StatusCode:    "fulfilled",
+		StatusMessage: "ok",
+		InstanceId:    "i-1",
+	}})
+	c.Assert(query["Action"], jc.DeepEquals, []string{"DescribeSpotInstanceRequests"})
+	c.Assert(query["SpotInstanceRequestId.1"], jc.DeepEquals, []string{"sir-1"})
+	c.Assert(query["Version"], jc.DeepEquals, []string{queryAPIVersion})
+}
+
+func (s *spotSuite) TestSpotClientQueryError(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<Response><Errors><Error><Code>MaxSpotInstanceCountExceeded</Code>` +
+			`<Message>too many</Message></Error></Errors><RequestID>req-1</RequestID></Response>`))
+	}))
+	defer srv.Close()
+
+	client := queryClient{newTestEC2(srv.URL)}
+	err := client.CancelSpotRequest("sir-1")
+	c.Assert(errors.Cause(err), gc.FitsTypeOf, &amzec2.Error{})
+	ec2Err := errors.Cause(err).(*amzec2.Error)
+	c.Assert(ec2Err.Code, gc.Equals, "MaxSpotInstanceCountExceeded")
+	c.Assert(ec2Err.StatusCode, gc.Equals, http.StatusBadRequest)
+}
+
+func newTestEC2(endpoint string) *amzec2.EC2 {
+	region := aws.Region{Name: "test", EC2Endpoint: endpoint}
+	return amzec2.New(aws.Auth{AccessKey: "x", SecretKey: "y"}, region, aws.SignV4Factory(region.Name, "ec2"))
+}
+
+type fakeSpotAPI struct {
+	testing.Stub
+	requests []spotRequest
+}
+
+func (f *fakeSpotAPI) RequestSpotInstance(ri *amzec2.RunInstances, price string) (spotRequest, error) {
+	f.AddCall("RequestSpotInstance", ri, price)
+	if err := f.NextErr(); err != nil {
+		return spotRequest{}, err
+	}
+	return spotRequest{Id: "sir-1", State: "open", StatusCode: "pending-evaluation"}, nil
+}
+
+func (f *fakeSpotAPI) SpotRequests(ids ...string) ([]spotRequest, error) {
+	f.AddCall("SpotRequests", ids)
+	return f.requests, f.NextErr()
+}
+
+func (f *fakeSpotAPI) CancelSpotRequest(id string) error {
+	f.AddCall("CancelSpotRequest", id)
+	return f.NextErr()
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.ResourceSweeper = (*environ)(nil)
+
+// UnknownResources is part of the environs.ResourceSweeper interface.
+// It returns the volumes tagged for the model which are not known to
+// it, and the model's machine security groups which are not in use by
+// any of the model's instances.
+func (e *environ) UnknownResources(known environs.KnownResources) ([]environs.ModelResource, error) {
+	var unknown []environs.ModelResource
+
+	volumeIds, err := e.allModelVolumes(false)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	knownVolumes := set.NewStrings(known.Volumes...)
+	for _, id := range volumeIds {
+		if !knownVolumes.Contains(id) {
+			unknown = append(unknown, environs.ModelResource{
+				Kind: environs.ResourceKindVolume,
+				Id:   id,
+			})
+		}
+	}
+
+	groups, err := e.unusedModelSecurityGroups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, g := range groups {
+		unknown = append(unknown, environs.ModelResource{
+			Kind: environs.ResourceKindSecurityGroup,
+			Id:   g.Id,
+		})
+	}
+	return unknown, nil
+}
+
+// unusedModelSecurityGroups returns the security groups tagged for
+// the model, other than the model's juju and global groups, which are
+// not used by any of the model's instances that have not been
+// terminated.
+func (e *environ) unusedModelSecurityGroups() ([]ec2.SecurityGroup, error) {
+	filter := ec2.NewFilter()
+	e.addModelFilter(filter)
+	resp, err := e.ec2.SecurityGroups(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing security groups")
+	}
+	if len(resp.Groups) == 0 {
+		return nil, nil
+	}
+
+	insts, err := e.AllInstancesByState("pending", "running", "stopping", "stopped")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	inUse := set.NewStrings()
+	if len(insts) > 0 {
+		ids := make([]instance.Id, len(insts))
+		for i, inst := range insts {
+			ids[i] = inst.Id()
+		}
+		used, err := e.instanceSecurityGroups(ids)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, g := range used {
+			inUse.Add(g.Id)
+		}
+	}
+
+	reserved := set.NewStrings(e.jujuGroupName(), e.globalGroupName())
+	var unused []ec2.SecurityGroup
+	for _, info := range resp.Groups {
+		if reserved.Contains(info.Name) || inUse.Contains(info.Id) {
+			continue
+		}
+		unused = append(unused, info.SecurityGroup)
+	}
+	return unused, nil
+}
+
+// RemoveResources is part of the environs.ResourceSweeper interface.
+// Each resource is removed in turn; the last failure, if any, is
+// returned once all of them have been tried.
+func (e *environ) RemoveResources(resources []environs.ModelResource) error {
+	var lastErr error
+	for _, r := range resources {
+		var err error
+		switch r.Kind {
+		case environs.ResourceKindVolume:
+			err = destroyVolume(e.ec2, r.Id)
+		case environs.ResourceKindSecurityGroup:
+			err = deleteSecurityGroupInsistently(e.ec2, ec2.SecurityGroup{Id: r.Id}, clock.WallClock)
+		default:
+			err = errors.NotSupportedf("removing %s", r.Kind)
+		}
+		if err != nil {
+			logger.Errorf("cannot remove %s %q: %v", r.Kind, r.Id, err)
+			lastErr = errors.Annotatef(err, "removing %s %q", r.Kind, r.Id)
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+)
+
+// The EC2 API families throttled separately. EC2 rate limits describe
+// calls and calls that modify resources with separate token buckets;
+// instance polling and the firewaller are their most frequent callers.
+const (
+	apiFamilyDescribe       = "describe"
+	apiFamilySecurityGroups = "security-groups"
+)
+
+// newThrottle returns a throttle for calls to the EC2 API, which
+// retries calls failing with RequestLimitExceeded or Throttling.
+func newThrottle(clock clock.Clock) (*common.Throttle, error) {
+	config := common.DefaultThrottleConfig()
+	config.Clock = clock
+	config.Families = map[string]common.ThrottleRate{
+		apiFamilyDescribe:       {Rate: 20, Burst: 100},
+		apiFamilySecurityGroups: {Rate: 5, Burst: 50},
+	}
+	config.IsRateLimited = func(err error) bool {
+		return ec2ErrorClass(err) == environs.ErrorClassRateLimit
+	}
+	throttle, err := common.NewThrottle(config)
+	return throttle, errors.Trace(err)
+}
+
+// instances calls ec2.Instances, throttled as a describe call.
+func (e *environ) instances(ids []string, filter *ec2.Filter) (resp *ec2.InstancesResp, err error) {
+	err = e.throttle.Call(apiFamilyDescribe, func() error {
+		resp, err = e.ec2.Instances(ids, filter)
+		return err
+	})
+	return resp, err
+}
+
+// securityGroups calls ec2.SecurityGroups, throttled as a
+// describe call.
+func (e *environ) securityGroups(groups []ec2.SecurityGroup, filter *ec2.Filter) (resp *ec2.SecurityGroupsResp, err error) {
+	err = e.throttle.Call(apiFamilyDescribe, func() error {
+		resp, err = e.ec2.SecurityGroups(groups, filter)
+		return err
+	})
+	return resp, err
+}
+
+// images calls ec2.Images, throttled as a describe call.
+func (e *environ) images(filter *ec2.Filter) (resp *ec2.ImagesResp, err error) {
+	err = e.throttle.Call(apiFamilyDescribe, func() error {
+		resp, err = e.ec2.Images(nil, filter)
+		return err
+	})
+	return resp, err
+}
+
+// authorizeSecurityGroup calls ec2.AuthorizeSecurityGroup, throttled
+// as a security group change.
+func (e *environ) authorizeSecurityGroup(group ec2.SecurityGroup, perms []ec2.IPPerm) error {
+	return e.throttle.Call(apiFamilySecurityGroups, func() error {
+		_, err := e.ec2.AuthorizeSecurityGroup(group, perms)
+		return err
+	})
+}
+
+// revokeSecurityGroup calls ec2.RevokeSecurityGroup, throttled
+// as a security group change.
+func (e *environ) revokeSecurityGroup(group ec2.SecurityGroup, perms []ec2.IPPerm) error {
+	return e.throttle.Call(apiFamilySecurityGroups, func() error {
+		_, err := e.ec2.RevokeSecurityGroup(group, perms)
+		return err
+	})
+}
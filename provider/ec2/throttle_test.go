@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type throttleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&throttleSuite{})
+
+func (s *throttleSuite) TestInstancesRetriesRequestLimitExceeded(c *gc.C) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`<Response><Errors><Error><Code>RequestLimitExceeded</Code>` +
+				`<Message>Request limit exceeded.</Message></Error></Errors><RequestID>req-1</RequestID></Response>`))
+			return
+		}
+		w.Write([]byte(`<DescribeInstancesResponse><reservationSet/></DescribeInstancesResponse>`))
+	}))
+	defer srv.Close()
+
+	clock := testing.NewClock(time.Time{})
+	throttle, err := newThrottle(autoAdvancingClock{clock})
+	c.Assert(err, jc.ErrorIsNil)
+	e := &environ{ec2: newTestEC2(srv.URL), throttle: throttle}
+
+	resp, err := e.instances(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Reservations, gc.HasLen, 0)
+	c.Assert(requests, gc.Equals, 2)
+	c.Assert(clock.Now(), gc.Equals, time.Time{}.Add(time.Second))
+}
+
+func (s *throttleSuite) TestInstancesOtherError(c *gc.C) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<Response><Errors><Error><Code>InvalidInstanceID.NotFound</Code>` +
+			`<Message>not found</Message></Error></Errors><RequestID>req-1</RequestID></Response>`))
+	}))
+	defer srv.Close()
+
+	throttle, err := newThrottle(testing.NewClock(time.Time{}))
+	c.Assert(err, jc.ErrorIsNil)
+	e := &environ{ec2: newTestEC2(srv.URL), throttle: throttle}
+
+	_, err = e.instances([]string{"i-1"}, nil)
+	c.Assert(ec2ErrCode(err), gc.Equals, "InvalidInstanceID.NotFound")
+	c.Assert(requests, gc.Equals, 1)
+}
+
+// autoAdvancingClock is a testing clock that advances
+// immediately when waited on.
+type autoAdvancingClock struct {
+	*testing.Clock
+}
+
+func (c autoAdvancingClock) After(d time.Duration) <-chan time.Time {
+	ch := c.Clock.After(d)
+	c.Advance(d)
+	return ch
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// vpcEndpointServices holds the AWS services for which a gateway VPC
+// endpoint may be requested with the vpc-endpoints setting.
+var vpcEndpointServices = set.NewStrings("s3", "dynamodb")
+
+// newVPCEndpointAPI returns the vpcEndpointAPI used to manage VPC
+// endpoints.
+var newVPCEndpointAPI = func(e *ec2.EC2) vpcEndpointAPI {
+	return queryClient{e}
+}
+
+// vpcEndpoint describes an EC2 VPC endpoint.
+type vpcEndpoint struct {
+	Id            string   `xml:"vpcEndpointId"`
+	VPCId         string   `xml:"vpcId"`
+	ServiceName   string   `xml:"serviceName"`
+	State         string   `xml:"state"`
+	RouteTableIds []string `xml:"routeTableIdSet>item"`
+}
+
+// vpcEndpointAPI holds the EC2 VPC endpoint calls, which the amz client
+// does not provide.
+type vpcEndpointAPI interface {
+	// VPCEndpoints returns the endpoints of the VPC with the given ID.
+	VPCEndpoints(vpcId string) ([]vpcEndpoint, error)
+
+	// CreateVPCEndpoint creates a gateway endpoint for the named
+	// service in the VPC with the given ID, routed from the given
+	// route tables.
+	CreateVPCEndpoint(vpcId, serviceName string, routeTableIds []string) (vpcEndpoint, error)
+}
+
+type vpcEndpointsResp struct {
+	Endpoints []vpcEndpoint `xml:"vpcEndpointSet>item"`
+}
+
+type createVPCEndpointResp struct {
+	Endpoint vpcEndpoint `xml:"vpcEndpoint"`
+}
+
+// VPCEndpoints is part of the vpcEndpointAPI interface.
+func (c queryClient) VPCEndpoints(vpcId string) ([]vpcEndpoint, error) {
+	params := map[string]string{
+		"Action":           "DescribeVpcEndpoints",
+		"Filter.1.Name":    "vpc-id",
+		"Filter.1.Value.1": vpcId,
+	}
+	var resp vpcEndpointsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Endpoints, nil
+}
+
+// CreateVPCEndpoint is part of the vpcEndpointAPI interface.
+func (c queryClient) CreateVPCEndpoint(vpcId, serviceName string, routeTableIds []string) (vpcEndpoint, error) {
+	params := map[string]string{
+		"Action":      "CreateVpcEndpoint",
+		"VpcId":       vpcId,
+		"ServiceName": serviceName,
+	}
+	for i, id := range routeTableIds {
+		params["RouteTableId."+strconv.Itoa(i+1)] = id
+	}
+	var resp createVPCEndpointResp
+	if err := c.query(params, &resp); err != nil {
+		return vpcEndpoint{}, errors.Trace(err)
+	}
+	return resp.Endpoint, nil
+}
+
+// vpcEndpointServiceName returns the name of the given AWS service in
+// the given region, as used for VPC endpoints.
+func vpcEndpointServiceName(region, service string) string {
+	return "com.amazonaws." + region + "." + service
+}
+
+// ensureVPCEndpoints ensures that the VPC used by the model has a
+// gateway endpoint for each service in the vpc-endpoints setting, so
+// that machines reach those services without leaving the VPC. The
+// endpoints belong to the VPC rather than the model: they are shared
+// by all models using the VPC, and are left in place when the model is
+// destroyed.
+func (e *environ) ensureVPCEndpoints() error {
+	services := e.ecfg().vpcEndpoints()
+	if len(services) == 0 {
+		return nil
+	}
+	e.vpcEndpointsMutex.Lock()
+	defer e.vpcEndpointsMutex.Unlock()
+	if e.vpcEndpoints == nil {
+		e.vpcEndpoints = set.NewStrings()
+	}
+	var missing []string
+	for _, service := range services {
+		if !e.vpcEndpoints.Contains(service) {
+			missing = append(missing, service)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	vpcId := e.ecfg().vpcID()
+	if !isVPCIDSet(vpcId) {
+		hasDefaultVPC, err := e.hasDefaultVPC()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !hasDefaultVPC {
+			return errors.NotSupportedf("VPC endpoints without a VPC")
+		}
+		vpcId = e.defaultVPC.Id
+	}
+
+	api := newVPCEndpointAPI(e.ec2)
+	endpoints, err := api.VPCEndpoints(vpcId)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get endpoints of VPC %q", vpcId)
+	}
+	existing := set.NewStrings()
+	for _, endpoint := range endpoints {
+		if endpoint.State != "deleting" && endpoint.State != "deleted" {
+			existing.Add(endpoint.ServiceName)
+		}
+	}
+
+	var routeTableIds []string
+	for _, service := range missing {
+		serviceName := vpcEndpointServiceName(e.cloud.Region, service)
+		if !existing.Contains(serviceName) {
+			if routeTableIds == nil {
+				tables, err := getVPCRouteTables(e.ec2, &ec2.VPC{Id: vpcId})
+				if err != nil {
+					return errors.Trace(err)
+				}
+				for _, table := range tables {
+					routeTableIds = append(routeTableIds, table.Id)
+				}
+			}
+			endpoint, err := api.CreateVPCEndpoint(vpcId, serviceName, routeTableIds)
+			if err != nil {
+				return errors.Annotatef(err, "cannot create endpoint for %q in VPC %q", serviceName, vpcId)
+			}
+			logger.Infof("created VPC endpoint %q for %q in VPC %q", endpoint.Id, serviceName, vpcId)
+		}
+		e.vpcEndpoints.Add(service)
+	}
+	return nil
+}
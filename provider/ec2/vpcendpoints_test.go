@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type vpcEndpointsSuite struct {
+	testing.IsolationSuite
+
+	api *fakeVPCEndpointAPI
+}
+
+var _ = gc.Suite(&vpcEndpointsSuite{})
+
+func (s *vpcEndpointsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeVPCEndpointAPI{}
+	s.PatchValue(&newVPCEndpointAPI, func(*amzec2.EC2) vpcEndpointAPI { return s.api })
+}
+
+// newVPCEndpointsEnviron returns an environ using the VPC with the given
+// ID, whose route tables are described by the given server.
+func (s *vpcEndpointsSuite) newVPCEndpointsEnviron(srv *httptest.Server, vpcId, services string) *environ {
+	return &environ{
+		cloud: environs.CloudSpec{Region: "us-east-1"},
+		ec2:   newTestEC2(srv.URL),
+		ecfgUnlocked: &environConfig{attrs: map[string]interface{}{
+			"vpc-id":        vpcId,
+			"vpc-endpoints": services,
+		}},
+	}
+}
+
+func (s *vpcEndpointsSuite) TestEnsureVPCEndpoints(c *gc.C) {
+	var actions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actions = append(actions, r.URL.Query().Get("Action"))
+		w.Write([]byte(`<DescribeRouteTablesResponse>
+  <routeTableSet>
+    <item><routeTableId>rtb-1</routeTableId><vpcId>vpc-1</vpcId></item>
+    <item><routeTableId>rtb-2</routeTableId><vpcId>vpc-1</vpcId></item>
+  </routeTableSet>
+</DescribeRouteTablesResponse>`))
+	}))
+	defer srv.Close()
+	s.api.endpoints = []vpcEndpoint{{
+		Id:          "vpce-1",
+		VPCId:       "vpc-1",
+		ServiceName: "com.amazonaws.us-east-1.s3",
+		State:       "available",
+	}}
+
+	e := s.newVPCEndpointsEnviron(srv, "vpc-1", "s3, dynamodb")
+	err := e.ensureVPCEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, jc.DeepEquals, []string{"DescribeRouteTables"})
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"VPCEndpoints", []interface{}{"vpc-1"}},
+		{"CreateVPCEndpoint", []interface{}{"vpc-1", "com.amazonaws.us-east-1.dynamodb", []string{"rtb-1", "rtb-2"}}},
+	})
+
+	// The endpoints are only checked once.
+	s.api.ResetCalls()
+	err = e.ensureVPCEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *vpcEndpointsSuite) TestEnsureVPCEndpointsNoneRequested(c *gc.C) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	e := s.newVPCEndpointsEnviron(srv, "vpc-1", "")
+	err := e.ensureVPCEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *vpcEndpointsSuite) TestEnsureVPCEndpointsError(c *gc.C) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	s.api.endpoints = []vpcEndpoint{{
+		Id:          "vpce-1",
+		VPCId:       "vpc-1",
+		ServiceName: "com.amazonaws.us-east-1.s3",
+		State:       "available",
+	}}
+	s.api.SetErrors(&amzec2.Error{Code: "UnauthorizedOperation"})
+
+	e := s.newVPCEndpointsEnviron(srv, "vpc-1", "s3")
+	err := e.ensureVPCEndpoints()
+	c.Assert(err, gc.ErrorMatches, `cannot get endpoints of VPC "vpc-1": .*UnauthorizedOperation.*`)
+
+	// A failure is retried.
+	s.api.ResetCalls()
+	err = e.ensureVPCEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "VPCEndpoints")
+}
+
+func (s *vpcEndpointsSuite) TestQueryClientVPCEndpoints(c *gc.C) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`<DescribeVpcEndpointsResponse>
+  <vpcEndpointSet>
+    <item>
+      <vpcEndpointId>vpce-1</vpcEndpointId>
+      <vpcId>vpc-1</vpcId>
+      <serviceName>com.amazonaws.us-east-1.s3</serviceName>
+      <state>available</state>
+      <routeTableIdSet><item>rtb-1</item></routeTableIdSet>
+    </item>
+  </vpcEndpointSet>
+</DescribeVpcEndpointsResponse>`))
+	}))
+	defer srv.Close()
+
+	client := queryClient{newTestEC2(srv.URL)}
+	endpoints, err := client.VPCEndpoints("vpc-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, []vpcEndpoint{{
+		Id:            "vpce-1",
+		VPCId:         "vpc-1",
+		ServiceName:   "com.amazonaws.us-east-1.s3",
+		State:         "available",
+		RouteTableIds: []string{"rtb-1"},
+	}})
+	c.Assert(query["Action"], jc.DeepEquals, []string{"DescribeVpcEndpoints"})
+	c.Assert(query["Filter.1.Name"], jc.DeepEquals, []string{"vpc-id"})
+	c.Assert(query["Filter.1.Value.1"], jc.DeepEquals, []string{"vpc-1"})
+}
+
+func (s *vpcEndpointsSuite) TestQueryClientCreateVPCEndpoint(c *gc.C) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`<CreateVpcEndpointResponse>
+  <vpcEndpoint>
+    <vpcEndpointId>vpce-2</vpcEndpointId>
+    <vpcId>vpc-1</vpcId>
+    <serviceName>com.amazonaws.us-east-1.dynamodb</serviceName>
+    <state>pending</state>
+  </vpcEndpoint>
+</CreateVpcEndpointResponse>`))
+	}))
+	defer srv.Close()
+
+	client := queryClient{newTestEC2(srv.URL)}
+	endpoint, err := client.CreateVPCEndpoint("vpc-1", "com.amazonaws.us-east-1.dynamodb", []string{"rtb-1", "rtb-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoint, jc.DeepEquals, vpcEndpoint{
+		Id:          "vpce-2",
+		VPCId:       "vpc-1",
+		ServiceName: "com.amazonaws.us-east-1.dynamodb",
+		State:       "pending",
+	})
+	c.Assert(query["Action"], jc.DeepEquals, []string{"CreateVpcEndpoint"})
+	c.Assert(query["VpcId"], jc.DeepEquals, []string{"vpc-1"})
+	c.Assert(query["ServiceName"], jc.DeepEquals, []string{"com.amazonaws.us-east-1.dynamodb"})
+	c.Assert(query["RouteTableId.1"], jc.DeepEquals, []string{"rtb-1"})
+	c.Assert(query["RouteTableId.2"], jc.DeepEquals, []string{"rtb-2"})
+}
+
+func (s *vpcEndpointsSuite) TestQueryClientSetInstanceGroups(c *gc.C) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`<ModifyInstanceAttributeResponse><return>true</return></ModifyInstanceAttributeResponse>`))
+	}))
+	defer srv.Close()
+
+	client := queryClient{newTestEC2(srv.URL)}
+	err := client.SetInstanceGroups("i-1", []string{"sg-1", "sg-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(query["Action"], jc.DeepEquals, []string{"ModifyInstanceAttribute"})
+	c.Assert(query["InstanceId"], jc.DeepEquals, []string{"i-1"})
+	c.Assert(query["GroupId.1"], jc.DeepEquals, []string{"sg-1"})
+	c.Assert(query["GroupId.2"], jc.DeepEquals, []string{"sg-2"})
+}
+
+type fakeVPCEndpointAPI struct {
+	testing.Stub
+	endpoints []vpcEndpoint
+}
+
+func (f *fakeVPCEndpointAPI) VPCEndpoints(vpcId string) ([]vpcEndpoint, error) {
+	f.AddCall("VPCEndpoints", vpcId)
+	return f.endpoints, f.NextErr()
+}
+
+func (f *fakeVPCEndpointAPI) CreateVPCEndpoint(vpcId, serviceName string, routeTableIds []string) (vpcEndpoint, error) {
+	f.AddCall("CreateVPCEndpoint", vpcId, serviceName, routeTableIds)
+	return vpcEndpoint{Id: "vpce-new", VPCId: vpcId, ServiceName: serviceName}, f.NextErr()
+}
@@ -0,0 +1,385 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+)
+
+// defaultEndpoint is the Equinix Metal API endpoint used when the
+// cloud does not specify one.
+const defaultEndpoint = "https://api.equinix.com/metal/v1"
+
+// devicesPerPage is the number of devices requested per page when
+// listing devices.
+const devicesPerPage = 100
+
+// metalAPI is the subset of the Equinix Metal API used by the provider.
+type metalAPI interface {
+	// Devices returns all of the devices in the project.
+	Devices() ([]metalDevice, error)
+
+	// CreateDevice creates a device, returning it in its
+	// provisioning state.
+	CreateDevice(req createDeviceRequest) (*metalDevice, error)
+
+	// DeleteDevice deletes the device with the given ID.
+	DeleteDevice(id string) error
+
+	// UpdateDeviceTags replaces the tags of the device with the
+	// given ID.
+	UpdateDeviceTags(id string, tags []string) error
+
+	// Facilities returns the facilities available to the project.
+	Facilities() ([]metalFacility, error)
+
+	// Plans returns the device plans available to the project.
+	Plans() ([]metalPlan, error)
+
+	// IPReservations returns the public IPv4 reservations in the
+	// project, along with their assignments.
+	IPReservations() ([]metalIPReservation, error)
+
+	// ReserveIP reserves a single public IPv4 address in the given
+	// metro.
+	ReserveIP(metro string, tags []string) (*metalIPReservation, error)
+
+	// AssignIP assigns the given address to the device with the
+	// given ID.
+	AssignIP(deviceID, address string) error
+
+	// DeleteIP deletes an IP reservation or an IP assignment. Deleting
+	// an assignment unassigns the address from its device, whereas
+	// deleting a reservation releases the addresses back to the pool.
+	DeleteIP(id string) error
+}
+
+type metalDevice struct {
+	ID          string           `json:"id"`
+	Hostname    string           `json:"hostname"`
+	State       string           `json:"state"`
+	Tags        []string         `json:"tags"`
+	Facility    metalFacility    `json:"facility"`
+	Plan        metalPlan        `json:"plan"`
+	IPAddresses []metalIPAddress `json:"ip_addresses"`
+}
+
+type metalIPAddress struct {
+	ID            string `json:"id"`
+	Address       string `json:"address"`
+	AddressFamily int    `json:"address_family"`
+	Public        bool   `json:"public"`
+	Management    bool   `json:"management"`
+}
+
+type metalMetro struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type metalFacility struct {
+	ID       string      `json:"id"`
+	Code     string      `json:"code"`
+	Name     string      `json:"name"`
+	Features []string    `json:"features"`
+	Metro    *metalMetro `json:"metro"`
+}
+
+type metalPlan struct {
+	ID          string           `json:"id"`
+	Slug        string           `json:"slug"`
+	Name        string           `json:"name"`
+	Line        string           `json:"line"`
+	Specs       metalPlanSpecs   `json:"specs"`
+	Pricing     metalPlanPricing `json:"pricing"`
+	AvailableIn []metalHref      `json:"available_in"`
+}
+
+type metalPlanSpecs struct {
+	CPUs   []metalPlanCPU   `json:"cpus"`
+	Memory metalPlanMemory  `json:"memory"`
+	Drives []metalPlanDrive `json:"drives"`
+}
+
+type metalPlanCPU struct {
+	Count int    `json:"count"`
+	Type  string `json:"type"`
+}
+
+type metalPlanMemory struct {
+	Total string `json:"total"`
+}
+
+type metalPlanDrive struct {
+	Count int    `json:"count"`
+	Size  string `json:"size"`
+	Type  string `json:"type"`
+}
+
+type metalPlanPricing struct {
+	Hour float64 `json:"hour"`
+}
+
+type metalHref struct {
+	Href string `json:"href"`
+}
+
+type metalIPReservation struct {
+	ID          string              `json:"id"`
+	Address     string              `json:"address"`
+	Tags        []string            `json:"tags"`
+	Metro       *metalMetro         `json:"metro"`
+	Assignments []metalIPAssignment `json:"assignments"`
+}
+
+type metalIPAssignment struct {
+	ID         string    `json:"id"`
+	Address    string    `json:"address"`
+	AssignedTo metalHref `json:"assigned_to"`
+}
+
+// deviceID returns the ID of the device that the address is assigned
+// to, taken from the device's API path.
+func (a metalIPAssignment) deviceID() string {
+	return path.Base(a.AssignedTo.Href)
+}
+
+type createDeviceRequest struct {
+	Hostname        string   `json:"hostname"`
+	Plan            string   `json:"plan"`
+	Facility        []string `json:"facility,omitempty"`
+	Metro           string   `json:"metro,omitempty"`
+	OperatingSystem string   `json:"operating_system"`
+	BillingCycle    string   `json:"billing_cycle"`
+	UserData        string   `json:"userdata"`
+	Tags            []string `json:"tags"`
+}
+
+type metalPageMeta struct {
+	CurrentPage int `json:"current_page"`
+	LastPage    int `json:"last_page"`
+}
+
+// metalClient is an implementation of metalAPI that sends requests
+// to the Equinix Metal REST API. There is no Go client library for
+// Equinix Metal among our dependencies.
+type metalClient struct {
+	http      *http.Client
+	endpoint  string
+	projectID string
+	token     string
+}
+
+// newMetalClient returns a metalAPI for the given cloud spec. It is a
+// variable so that it may be replaced in tests.
+var newMetalClient = func(spec environs.CloudSpec) (metalAPI, error) {
+	attrs := spec.Credential.Attributes()
+	endpoint := spec.Endpoint
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	return &metalClient{
+		http:      http.DefaultClient,
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		projectID: attrs[credAttrProjectID],
+		token:     attrs[credAttrAPIToken],
+	}, nil
+}
+
+func (c *metalClient) projectPath(path string) string {
+	return "/projects/" + c.projectID + path
+}
+
+// Devices is part of the metalAPI interface.
+func (c *metalClient) Devices() ([]metalDevice, error) {
+	var devices []metalDevice
+	for page := 1; ; page++ {
+		var resp struct {
+			Devices []metalDevice `json:"devices"`
+			Meta    metalPageMeta `json:"meta"`
+		}
+		query := url.Values{
+			"page":     {fmt.Sprint(page)},
+			"per_page": {fmt.Sprint(devicesPerPage)},
+			"include":  {"facility,plan"},
+		}
+		if err := c.do("GET", c.projectPath("/devices"), query, nil, &resp); err != nil {
+			return nil, errors.Annotate(err, "listing devices")
+		}
+		devices = append(devices, resp.Devices...)
+		if resp.Meta.CurrentPage >= resp.Meta.LastPage {
+			return devices, nil
+		}
+	}
+}
+
+// CreateDevice is part of the metalAPI interface.
+func (c *metalClient) CreateDevice(req createDeviceRequest) (*metalDevice, error) {
+	var device metalDevice
+	if err := c.do("POST", c.projectPath("/devices"), nil, req, &device); err != nil {
+		return nil, errors.Annotate(err, "creating device")
+	}
+	return &device, nil
+}
+
+// DeleteDevice is part of the metalAPI interface.
+func (c *metalClient) DeleteDevice(id string) error {
+	return errors.Annotatef(c.do("DELETE", "/devices/"+id, nil, nil, nil), "deleting device %q", id)
+}
+
+// UpdateDeviceTags is part of the metalAPI interface.
+func (c *metalClient) UpdateDeviceTags(id string, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+	req := map[string]interface{}{"tags": tags}
+	return errors.Annotatef(c.do("PUT", "/devices/"+id, nil, req, nil), "updating tags of device %q", id)
+}
+
+// Facilities is part of the metalAPI interface.
+func (c *metalClient) Facilities() ([]metalFacility, error) {
+	var resp struct {
+		Facilities []metalFacility `json:"facilities"`
+	}
+	query := url.Values{"include": {"metro"}}
+	if err := c.do("GET", c.projectPath("/facilities"), query, nil, &resp); err != nil {
+		return nil, errors.Annotate(err, "listing facilities")
+	}
+	return resp.Facilities, nil
+}
+
+// Plans is part of the metalAPI interface.
+func (c *metalClient) Plans() ([]metalPlan, error) {
+	var resp struct {
+		Plans []metalPlan `json:"plans"`
+	}
+	query := url.Values{"type": {"standard"}}
+	if err := c.do("GET", c.projectPath("/plans"), query, nil, &resp); err != nil {
+		return nil, errors.Annotate(err, "listing plans")
+	}
+	return resp.Plans, nil
+}
+
+// IPReservations is part of the metalAPI interface.
+func (c *metalClient) IPReservations() ([]metalIPReservation, error) {
+	var resp struct {
+		IPAddresses []metalIPReservation `json:"ip_addresses"`
+	}
+	query := url.Values{
+		"types":   {"public_ipv4"},
+		"include": {"assignments,metro"},
+	}
+	if err := c.do("GET", c.projectPath("/ips"), query, nil, &resp); err != nil {
+		return nil, errors.Annotate(err, "listing IP reservations")
+	}
+	return resp.IPAddresses, nil
+}
+
+// ReserveIP is part of the metalAPI interface.
+func (c *metalClient) ReserveIP(metro string, tags []string) (*metalIPReservation, error) {
+	req := map[string]interface{}{
+		"type":     "public_ipv4",
+		"quantity": 1,
+		"metro":    metro,
+		"tags":     tags,
+	}
+	var reservation metalIPReservation
+	if err := c.do("POST", c.projectPath("/ips"), nil, req, &reservation); err != nil {
+		return nil, errors.Annotatef(err, "reserving IP address in metro %q", metro)
+	}
+	return &reservation, nil
+}
+
+// AssignIP is part of the metalAPI interface.
+func (c *metalClient) AssignIP(deviceID, address string) error {
+	req := map[string]interface{}{"address": address + "/32"}
+	return errors.Annotatef(
+		c.do("POST", "/devices/"+deviceID+"/ips", nil, req, nil),
+		"assigning IP address %s to device %q", address, deviceID,
+	)
+}
+
+// DeleteIP is part of the metalAPI interface.
+func (c *metalClient) DeleteIP(id string) error {
+	return errors.Annotatef(c.do("DELETE", "/ips/"+id, nil, nil, nil), "deleting IP %q", id)
+}
+
+// do sends a request to the API, decoding the response into result if
+// it is non-nil. Errors are classified according to the response
+// status, so that the provisioner can decide whether to retry in
+// another availability zone.
+func (c *metalClient) do(method, path string, query url.Values, body, result interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	reqURL := c.endpoint + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, reqURL, &reqBody)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("X-Auth-Token", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(resp)
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Annotate(err, "decoding response")
+	}
+	return nil
+}
+
+// responseError returns an error for the given unsuccessful response.
+func responseError(resp *http.Response) error {
+	data, _ := ioutil.ReadAll(resp.Body)
+	var body struct {
+		Errors []string `json:"errors"`
+	}
+	message := resp.Status
+	if err := json.Unmarshal(data, &body); err == nil && len(body.Errors) > 0 {
+		message = strings.Join(body.Errors, "; ")
+	}
+	err := errors.New(message)
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return errors.NewNotFound(err, "")
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return common.ClassifiedError(environs.ErrorClassCredential, err)
+	case http.StatusTooManyRequests:
+		return common.ClassifiedError(environs.ErrorClassRateLimit, err)
+	case http.StatusServiceUnavailable:
+		return common.ClassifiedError(environs.ErrorClassCapacity, err)
+	}
+	return err
+}
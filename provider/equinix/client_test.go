@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+
+	server    *httptest.Server
+	requests  []*http.Request
+	bodies    []string
+	responses []func(w http.ResponseWriter)
+	client    metalAPI
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.responses = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(body))
+		respond := s.responses[0]
+		s.responses = s.responses[1:]
+		respond(w)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	spec := fakeCloudSpec()
+	spec.Endpoint = s.server.URL + "/"
+	client, err := newMetalClient(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client = client
+}
+
+func (s *clientSuite) respond(status int, response interface{}) {
+	s.responses = append(s.responses, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+func (s *clientSuite) TestDevicesPaginated(c *gc.C) {
+	s.respond(http.StatusOK, map[string]interface{}{
+		"devices": []map[string]interface{}{{"id": "a"}},
+		"meta":    map[string]interface{}{"current_page": 1, "last_page": 2},
+	})
+	s.respond(http.StatusOK, map[string]interface{}{
+		"devices": []map[string]interface{}{{"id": "b"}},
+		"meta":    map[string]interface{}{"current_page": 2, "last_page": 2},
+	})
+	devices, err := s.client.Devices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.HasLen, 2)
+	c.Assert(devices[0].ID, gc.Equals, "a")
+	c.Assert(devices[1].ID, gc.Equals, "b")
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/projects/project-id/devices")
+	c.Assert(s.requests[0].Header.Get("X-Auth-Token"), gc.Equals, "secret")
+	c.Assert(s.requests[1].URL.Query().Get("page"), gc.Equals, "2")
+}
+
+func (s *clientSuite) TestReserveIP(c *gc.C) {
+	s.respond(http.StatusCreated, map[string]interface{}{
+		"id":      "ip-0",
+		"address": "147.75.0.1",
+	})
+	reservation, err := s.client.ReserveIP("da", []string{"juju-device=a"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reservation.Address, gc.Equals, "147.75.0.1")
+
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/projects/project-id/ips")
+	var body map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(s.bodies[0]), &body), jc.ErrorIsNil)
+	c.Assert(body, jc.DeepEquals, map[string]interface{}{
+		"type":     "public_ipv4",
+		"quantity": float64(1),
+		"metro":    "da",
+		"tags":     []interface{}{"juju-device=a"},
+	})
+}
+
+func (s *clientSuite) TestDeleteIPNotFound(c *gc.C) {
+	s.respond(http.StatusNotFound, map[string]interface{}{
+		"errors": []string{"Not found"},
+	})
+	err := s.client.DeleteIP("ip-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.requests[0].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/ips/ip-0")
+}
+
+func (s *clientSuite) TestErrorClasses(c *gc.C) {
+	for status, class := range map[int]environs.ErrorClass{
+		http.StatusUnauthorized:       environs.ErrorClassCredential,
+		http.StatusTooManyRequests:    environs.ErrorClassRateLimit,
+		http.StatusServiceUnavailable: environs.ErrorClassCapacity,
+		http.StatusBadRequest:         environs.ErrorClassUnknown,
+	} {
+		s.respond(status, map[string]interface{}{
+			"errors": []string{"oops"},
+		})
+		_, err := s.client.CreateDevice(createDeviceRequest{})
+		c.Check(err, gc.ErrorMatches, "creating device: oops")
+		c.Check(environs.ErrorClassOf(err), gc.Equals, class, gc.Commentf("status %d", status))
+	}
+}
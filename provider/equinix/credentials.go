@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type environProviderCredentials struct{}
+
+const (
+	credAttrProjectID = "project-id"
+	credAttrAPIToken  = "api-token"
+)
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.AccessKeyAuthType: {{
+			credAttrProjectID, cloud.CredentialAttr{
+				Description: "ID of the project in which to create devices",
+			},
+		}, {
+			credAttrAPIToken, cloud.CredentialAttr{
+				Description: "API token with read-write access to the project",
+				Hidden:      true,
+			},
+		}},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+// Credentials are read from the METAL_AUTH_TOKEN and METAL_PROJECT_ID
+// environment variables, as used by the Equinix Metal CLI, and
+// METAL_METRO is used as the default region.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	token := os.Getenv("METAL_AUTH_TOKEN")
+	projectID := os.Getenv("METAL_PROJECT_ID")
+	if token == "" || projectID == "" {
+		return nil, errors.NotFoundf("credentials")
+	}
+	credential := cloud.NewCredential(
+		cloud.AccessKeyAuthType,
+		map[string]string{
+			credAttrProjectID: projectID,
+			credAttrAPIToken:  token,
+		},
+	)
+	credential.Label = fmt.Sprintf("equinix credential for project %q", projectID)
+	return &cloud.CloudCredential{
+		DefaultRegion: os.Getenv("METAL_METRO"),
+		AuthCredentials: map[string]cloud.Credential{
+			projectID: credential,
+		},
+	}, nil
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
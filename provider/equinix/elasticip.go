@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/tags"
+)
+
+// tagElasticIPDevice is the key of the IP reservation tag recording
+// the ID of the device that Juju reserved the address for.
+const tagElasticIPDevice = tags.JujuTagPrefix + "device"
+
+// ensureElasticIP ensures that an elastic IP address is reserved for,
+// and assigned to, the device. The reservation is made in the metro of
+// the device, and is tagged with the device's model and controller.
+func (env *environ) ensureElasticIP(device metalDevice) error {
+	reservations, err := env.client.IPReservations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, reservation := range reservations {
+		if !hasTag(reservation.Tags, tagElasticIPDevice, device.ID) {
+			continue
+		}
+		for _, assignment := range reservation.Assignments {
+			if assignment.deviceID() == device.ID {
+				return nil
+			}
+		}
+		return errors.Trace(env.client.AssignIP(device.ID, reservation.Address))
+	}
+
+	metro := env.cloud.Region
+	if device.Facility.Metro != nil {
+		metro = device.Facility.Metro.Code
+	}
+	reservationTags := []string{
+		tagString(tagElasticIPDevice, device.ID),
+		tagString(tags.JujuModel, env.Config().UUID()),
+	}
+	if controllerUUID, ok := tagValue(device.Tags, tags.JujuController); ok {
+		reservationTags = append(reservationTags, tagString(tags.JujuController, controllerUUID))
+	}
+	reservation, err := env.client.ReserveIP(metro, reservationTags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("reserved elastic IP address %s for device %q", reservation.Address, device.ID)
+	return errors.Trace(env.client.AssignIP(device.ID, reservation.Address))
+}
+
+// releaseIPs unassigns and releases the elastic IP addresses whose
+// reservations have the given tag.
+func (env *environ) releaseIPs(key, value string) error {
+	reservations, err := env.client.IPReservations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, reservation := range reservations {
+		if !hasTag(reservation.Tags, key, value) {
+			continue
+		}
+		for _, assignment := range reservation.Assignments {
+			if err := env.client.DeleteIP(assignment.ID); err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+		}
+		if err := env.client.DeleteIP(reservation.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		logger.Infof("released elastic IP address %s", reservation.Address)
+	}
+	return nil
+}
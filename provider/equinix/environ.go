@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name      string
+	cloud     environs.CloudSpec
+	client    metalAPI
+	namespace instance.Namespace
+
+	lock sync.Mutex
+	ecfg *environConfig
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+// Name returns the Environ's name.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider returns the EnvironProvider that created this Environ.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig updates the Environ's configuration.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	ecfg, err := validateConfig(cfg, env.ecfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config returns the configuration data with which the Environ was created.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.Config
+}
+
+// PrepareForBootstrap is part of the Environ interface.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create is part of the Environ interface.
+func (env *environ) Create(environs.CreateParams) error {
+	return nil
+}
+
+// Bootstrap is part of the Environ interface.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, env, params)
+}
+
+// ControllerInstances is part of the Environ interface.
+func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
+	devices, err := env.client.Devices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, device := range devices {
+		if hasTag(device.Tags, tags.JujuController, controllerUUID) &&
+			hasTag(device.Tags, tags.JujuIsController, "true") {
+			ids = append(ids, instance.Id(device.ID))
+		}
+	}
+	if len(ids) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return ids, nil
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	devices, err := env.modelDevices()
+	if err != nil {
+		return errors.Annotate(err, "adopting devices")
+	}
+	var failed []string
+	for _, device := range devices {
+		deviceTags := setTag(device.Tags, tags.JujuController, controllerUUID)
+		if err := env.client.UpdateDeviceTags(device.ID, deviceTags); err != nil {
+			logger.Errorf("error adopting device %q: %v", device.ID, err)
+			failed = append(failed, device.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to adopt devices %v", failed)
+	}
+	return nil
+}
+
+// Destroy is part of the Environ interface.
+func (env *environ) Destroy() error {
+	if err := common.Destroy(env); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.releaseIPs(tags.JujuModel, env.Config().UUID()))
+}
+
+// DestroyController is part of the Environ interface. All devices and
+// IP addresses tagged with the controller's UUID, including those of
+// hosted models, are removed.
+func (env *environ) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+	devices, err := env.client.Devices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, device := range devices {
+		if hasTag(device.Tags, tags.JujuController, controllerUUID) {
+			ids = append(ids, instance.Id(device.ID))
+		}
+	}
+	if err := env.StopInstances(ids...); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.releaseIPs(tags.JujuController, controllerUUID))
+}
+
+// modelDevices returns the devices tagged with the model's UUID.
+func (env *environ) modelDevices() ([]metalDevice, error) {
+	devices, err := env.client.Devices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUID := env.Config().UUID()
+	var result []metalDevice
+	for _, device := range devices {
+		if hasTag(device.Tags, tags.JujuModel, modelUUID) {
+			result = append(result, device)
+		}
+	}
+	return result, nil
+}
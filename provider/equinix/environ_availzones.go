@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ common.ZonedEnviron = (*environ)(nil)
+
+// facilityZone is an Equinix Metal facility. The facilities in the
+// region's metro are treated as availability zones.
+type facilityZone struct {
+	facility metalFacility
+}
+
+// Name is part of the common.AvailabilityZone interface.
+func (z facilityZone) Name() string {
+	return z.facility.Code
+}
+
+// Available is part of the common.AvailabilityZone interface.
+func (z facilityZone) Available() bool {
+	return true
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	facilities, err := env.client.Facilities()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var zones []common.AvailabilityZone
+	for _, facility := range facilities {
+		if facility.Metro != nil && facility.Metro.Code == env.cloud.Region {
+			zones = append(zones, facilityZone{facility})
+		}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron
+// interface.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ids)
+	if err != nil && err != environs.ErrPartialInstances {
+		return nil, err
+	}
+	zones := make([]string, len(ids))
+	for i, inst := range instances {
+		if inst != nil {
+			zones[i] = inst.(*environInstance).device.Facility.Code
+		}
+	}
+	return zones, err
+}
+
+// DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) DeriveAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
+	zone, err := env.placementZone(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if zone == "" {
+		return nil, nil
+	}
+	return []string{zone}, nil
+}
+
+// placementZone returns the availability zone named in the placement
+// directive, checking that it exists. An empty string is returned if
+// there is no placement directive.
+func (env *environ) placementZone(placement string) (string, error) {
+	if placement == "" {
+		return "", nil
+	}
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 || placement[:pos] != "zone" {
+		return "", errors.Errorf("unknown placement directive: %v", placement)
+	}
+	zone := placement[pos+1:]
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, z := range zones {
+		if z.Name() == zone {
+			return zone, nil
+		}
+	}
+	return "", errors.NotValidf("availability zone %q", zone)
+}
@@ -0,0 +1,178 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools"
+)
+
+// billingCycle is the billing cycle of the devices created by Juju.
+const billingCycle = "hourly"
+
+// MaintainInstance is part of the InstanceBroker interface.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance is part of the InstanceBroker interface. The device is
+// created in the facility named by the availability zone, if one is
+// given, or else in any facility in the region's metro.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if args.InstanceConfig == nil {
+		return nil, common.ZoneIndependentError(errors.New("instance configuration is nil"))
+	}
+	req, itype, err := env.createDeviceRequest(args)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if args.AvailabilityZone != "" {
+		req.Facility = []string{args.AvailabilityZone}
+	} else {
+		req.Metro = env.cloud.Region
+	}
+
+	device, err := env.client.CreateDevice(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("started device %q (%s) in %q", device.ID, device.Hostname, device.Facility.Code)
+
+	inst := &environInstance{env: env, device: *device}
+	arch := itype.Arches[0]
+	hwc := &instance.HardwareCharacteristics{
+		Arch:     &arch,
+		CpuCores: &itype.CpuCores,
+		Mem:      &itype.Mem,
+	}
+	if itype.RootDisk > 0 {
+		hwc.RootDisk = &itype.RootDisk
+	}
+	zone := args.AvailabilityZone
+	if zone == "" {
+		zone = device.Facility.Code
+	}
+	if zone != "" {
+		hwc.AvailabilityZone = &zone
+	}
+	return &environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hwc,
+	}, nil
+}
+
+// createDeviceRequest returns the request for creating a device for
+// the instance, along with the instance type chosen for it.
+func (env *environ) createDeviceRequest(args environs.StartInstanceParams) (createDeviceRequest, instances.InstanceType, error) {
+	var req createDeviceRequest
+	os, err := operatingSystem(args.InstanceConfig.Series)
+	if err != nil {
+		return req, instances.InstanceType{}, errors.Trace(err)
+	}
+	itype, err := env.findInstanceType(args.Constraints, args.Tools.Arches())
+	if err != nil {
+		return req, instances.InstanceType{}, errors.Trace(err)
+	}
+	arch := itype.Arches[0]
+	agentTools, err := args.Tools.Match(tools.Filter{Arch: arch})
+	if err != nil {
+		return req, instances.InstanceType{}, errors.Errorf("chosen architecture %v not present in %v", arch, args.Tools.Arches())
+	}
+	if err := args.InstanceConfig.SetTools(agentTools); err != nil {
+		return req, instances.InstanceType{}, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return req, instances.InstanceType{}, errors.Trace(err)
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, MetalRenderer{})
+	if err != nil {
+		return req, instances.InstanceType{}, errors.Annotate(err, "cannot make user data")
+	}
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return req, instances.InstanceType{}, errors.Trace(err)
+	}
+	return createDeviceRequest{
+		Hostname:        hostname,
+		Plan:            itype.Name,
+		OperatingSystem: os,
+		BillingCycle:    billingCycle,
+		UserData:        string(userData),
+		Tags:            tagStrings(args.InstanceConfig.Tags),
+	}, itype, nil
+}
+
+// AllInstances is part of the InstanceBroker interface.
+func (env *environ) AllInstances() ([]instance.Instance, error) {
+	devices, err := env.modelDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]instance.Instance, len(devices))
+	for i, device := range devices {
+		result[i] = &environInstance{env: env, device: device}
+	}
+	return result, nil
+}
+
+// Instances is part of the Environ interface.
+func (env *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	devices, err := env.modelDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byID := make(map[instance.Id]metalDevice)
+	for _, device := range devices {
+		byID[instance.Id(device.ID)] = device
+	}
+
+	var found int
+	result := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		if device, ok := byID[id]; ok {
+			result[i] = &environInstance{env: env, device: device}
+			found++
+		}
+	}
+	if found == 0 {
+		return nil, environs.ErrNoInstances
+	} else if found < len(ids) {
+		return result, environs.ErrPartialInstances
+	}
+	return result, nil
+}
+
+// StopInstances is part of the InstanceBroker interface. The elastic
+// IP addresses reserved for the devices are released.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var failed []instance.Id
+	for _, id := range ids {
+		if err := env.client.DeleteDevice(string(id)); err != nil && !errors.IsNotFound(err) {
+			logger.Errorf("error stopping device %q: %v", id, err)
+			failed = append(failed, id)
+			continue
+		}
+		if err := env.releaseIPs(tagElasticIPDevice, string(id)); err != nil {
+			logger.Errorf("error releasing IP address of device %q: %v", id, err)
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to stop devices %v", failed)
+	}
+	return nil
+}
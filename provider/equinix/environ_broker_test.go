@@ -0,0 +1,282 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"errors"
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type brokerSuite struct {
+	environSuite
+}
+
+var _ = gc.Suite(&brokerSuite{})
+
+func (s *brokerSuite) startInstanceParams(c *gc.C) environs.StartInstanceParams {
+	machineTag := names.NewMachineTag("0")
+	apiInfo := &api.Info{
+		Addrs:    []string{"localhost:17777"},
+		CACert:   testing.CACert,
+		Password: "admin",
+		Tag:      machineTag,
+		ModelTag: testing.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		testing.ControllerTag, machineTag.Id(), "yanonce",
+		imagemetadata.ReleasedStream, "xenial", apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	icfg.Tags = map[string]string{
+		tags.JujuModel:      testing.ModelTag.Id(),
+		tags.JujuController: testing.ControllerTag.Id(),
+	}
+
+	var toolsList tools.List
+	for _, a := range []string{arch.AMD64, arch.ARM64} {
+		toolsVersion := version.Binary{
+			Number: version.MustParse("2.2.0"),
+			Series: "xenial",
+			Arch:   a,
+		}
+		toolsList = append(toolsList, &tools.Tools{
+			Version: toolsVersion,
+			URL:     fmt.Sprintf("http://example.com/tools/juju-%s.tgz", toolsVersion),
+			SHA256:  "1234567890abcdef",
+			Size:    1024,
+		})
+	}
+	return environs.StartInstanceParams{
+		ControllerUUID: testing.ControllerTag.Id(),
+		Tools:          toolsList,
+		InstanceConfig: icfg,
+	}
+}
+
+func (s *brokerSuite) TestStartInstance(c *gc.C) {
+	result, err := s.env.StartInstance(s.startInstanceParams(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("device-0"))
+
+	s.client.CheckCallNames(c, "Plans", "CreateDevice")
+	req := s.client.Calls()[1].Args[0].(createDeviceRequest)
+	hostname, err := s.env.namespace.Hostname("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.Hostname, gc.Equals, hostname)
+	c.Assert(req.OperatingSystem, gc.Equals, "ubuntu_16_04")
+	c.Assert(req.BillingCycle, gc.Equals, "hourly")
+	c.Assert(req.Metro, gc.Equals, "da")
+	c.Assert(req.Facility, gc.HasLen, 0)
+	c.Assert(req.UserData, gc.Matches, "(?s)#cloud-config\n.*")
+	c.Assert(req.Tags, jc.DeepEquals, []string{
+		"juju-controller-uuid=" + testing.ControllerTag.Id(),
+		"juju-model-uuid=" + testing.ModelTag.Id(),
+	})
+
+	// The cheapest plan with at least 1GB of memory is chosen.
+	c.Assert(req.Plan, gc.Equals, "c3.large.arm")
+	c.Assert(*result.Hardware.Arch, gc.Equals, arch.ARM64)
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(128*1024))
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "da11")
+}
+
+func (s *brokerSuite) TestStartInstanceAvailabilityZone(c *gc.C) {
+	args := s.startInstanceParams(c)
+	args.AvailabilityZone = "da6"
+	args.Constraints = constraints.MustParse("arch=amd64")
+	result, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := s.client.Calls()[1].Args[0].(createDeviceRequest)
+	c.Assert(req.Facility, jc.DeepEquals, []string{"da6"})
+	c.Assert(req.Metro, gc.Equals, "")
+	c.Assert(req.Plan, gc.Equals, "c3.small.x86")
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "da6")
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(480*1024))
+}
+
+func (s *brokerSuite) TestStartInstanceUnsupportedSeries(c *gc.C) {
+	args := s.startInstanceParams(c)
+	args.InstanceConfig.Series = "precise"
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, gc.ErrorMatches, `series "precise" not supported`)
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *brokerSuite) TestStartInstanceNoMatchingPlan(c *gc.C) {
+	args := s.startInstanceParams(c)
+	args.Constraints = constraints.MustParse("mem=512G")
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, gc.ErrorMatches, `no instance types in da matching constraints "mem=524288M"`)
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+	s.client.CheckCallNames(c, "Plans")
+}
+
+func (s *brokerSuite) TestStartInstanceCapacityError(c *gc.C) {
+	s.client.SetErrors(nil, common.ClassifiedError(
+		environs.ErrorClassCapacity, errors.New("no available hardware"),
+	))
+	args := s.startInstanceParams(c)
+	args.AvailabilityZone = "da6"
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, gc.ErrorMatches, "no available hardware")
+	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+}
+
+func (s *brokerSuite) TestInstances(c *gc.C) {
+	s.client.devices = []metalDevice{
+		{ID: "a", Tags: []string{"juju-model-uuid=" + testing.ModelTag.Id()}},
+		{ID: "b", Tags: []string{"juju-model-uuid=other"}},
+	}
+	insts, err := s.env.Instances([]instance.Id{"a", "b"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id("a"))
+	c.Assert(insts[1], gc.IsNil)
+
+	_, err = s.env.Instances([]instance.Id{"b"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+
+	all, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *brokerSuite) TestStopInstances(c *gc.C) {
+	s.client.reservations = []metalIPReservation{{
+		ID:          "ip-a",
+		Tags:        []string{"juju-device=a"},
+		Assignments: []metalIPAssignment{{ID: "assignment-a"}},
+	}, {
+		ID:   "ip-b",
+		Tags: []string{"juju-device=b"},
+	}}
+	err := s.env.StopInstances("a")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "DeleteDevice", "IPReservations", "DeleteIP", "DeleteIP")
+	s.client.CheckCall(c, 0, "DeleteDevice", "a")
+	s.client.CheckCall(c, 2, "DeleteIP", "assignment-a")
+	s.client.CheckCall(c, 3, "DeleteIP", "ip-a")
+}
+
+func (s *brokerSuite) TestControllerInstances(c *gc.C) {
+	controllerUUID := testing.ControllerTag.Id()
+	s.client.devices = []metalDevice{{
+		ID:   "a",
+		Tags: []string{"juju-controller-uuid=" + controllerUUID, "juju-is-controller=true"},
+	}, {
+		ID:   "b",
+		Tags: []string{"juju-controller-uuid=" + controllerUUID},
+	}}
+	ids, err := s.env.ControllerInstances(controllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"a"})
+
+	_, err = s.env.ControllerInstances("other")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *brokerSuite) TestAdoptResources(c *gc.C) {
+	s.client.devices = []metalDevice{{
+		ID:   "a",
+		Tags: []string{"juju-controller-uuid=old", "juju-model-uuid=" + testing.ModelTag.Id()},
+	}}
+	err := s.env.AdoptResources("new", version.MustParse("2.2.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCall(c, 1, "UpdateDeviceTags", "a", []string{
+		"juju-model-uuid=" + testing.ModelTag.Id(),
+		"juju-controller-uuid=new",
+	})
+}
+
+func (s *brokerSuite) TestAvailabilityZones(c *gc.C) {
+	zones, err := s.env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "da11")
+	c.Assert(zones[1].Name(), gc.Equals, "da6")
+}
+
+func (s *brokerSuite) TestDeriveAvailabilityZones(c *gc.C) {
+	zones, err := s.env.DeriveAvailabilityZones(environs.StartInstanceParams{Placement: "zone=da6"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"da6"})
+
+	_, err = s.env.DeriveAvailabilityZones(environs.StartInstanceParams{Placement: "zone=ny5"})
+	c.Assert(err, gc.ErrorMatches, `availability zone "ny5" not valid`)
+}
+
+func (s *brokerSuite) TestPrecheckInstance(c *gc.C) {
+	err := s.env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("instance-type=m3.large.x86"),
+		Placement:   "zone=da11",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("instance-type=t1.small"),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid instance type "t1.small"`)
+}
+
+func (s *brokerSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("cpu-power=10 virt-type=kvm mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "virt-type"})
+
+	_, err = validator.Validate(constraints.MustParse("instance-type=t1.small"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: instance-type=t1.small\n.*`)
+}
+
+func (s *brokerSuite) TestInstanceTypes(c *gc.C) {
+	result, err := s.env.InstanceTypes(constraints.MustParse("cores=2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.CostDivisor, gc.Equals, uint64(1000))
+	c.Assert(result.InstanceTypes, gc.HasLen, 1)
+	c.Assert(result.InstanceTypes[0], jc.DeepEquals, instances.InstanceType{
+		Id:       "m3.large.x86",
+		Name:     "m3.large.x86",
+		Arches:   []string{arch.AMD64},
+		CpuCores: 2,
+		Mem:      256 * 1024,
+		Cost:     2000,
+	})
+}
+
+func (s *brokerSuite) TestParseSize(c *gc.C) {
+	for size, expect := range map[string]uint64{
+		"32GB":   32 * 1024,
+		"1.5TB":  1536 * 1024,
+		"512MB":  512,
+		"lots":   0,
+		"xGB":    0,
+		" 8GB ":  8 * 1024,
+		"":       0,
+		"240 GB": 240 * 1024,
+	} {
+		c.Check(parseSize(size), gc.Equals, expect, gc.Commentf("%q", size))
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+)
+
+var unsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
+	constraints.Container,
+}
+
+// ConstraintsValidator is part of the environs.Environ interface.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterConflicts(
+		[]string{constraints.InstanceType},
+		[]string{constraints.Mem, constraints.Cores, constraints.Arch},
+	)
+	instanceTypes, err := env.instanceTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(instanceTypes))
+	for i, itype := range instanceTypes {
+		names[i] = itype.Name
+	}
+	validator.RegisterVocabulary(constraints.InstanceType, names)
+	return validator, nil
+}
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
+	if _, err := operatingSystem(args.Series); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := env.placementZone(args.Placement); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Constraints.HasInstanceType() {
+		instanceTypes, err := env.instanceTypes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, itype := range instanceTypes {
+			if itype.Name == *args.Constraints.InstanceType {
+				return nil
+			}
+		}
+		return errors.Errorf("invalid instance type %q", *args.Constraints.InstanceType)
+	}
+	return nil
+}
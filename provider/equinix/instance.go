@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+)
+
+// tagIngress is the key of the device tags recording the ingress
+// rules opened on the device.
+const tagIngress = tags.JujuTagPrefix + "ingress"
+
+type environInstance struct {
+	env    *environ
+	device metalDevice
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+var _ instance.InstanceFirewaller = (*environInstance)(nil)
+
+// Id is part of the instance.Instance interface.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.device.ID)
+}
+
+// Status is part of the instance.Instance interface.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	var jujuStatus status.Status
+	switch inst.device.State {
+	case "queued", "provisioning":
+		jujuStatus = status.Allocating
+	case "active":
+		jujuStatus = status.Running
+	case "failed":
+		jujuStatus = status.ProvisioningError
+	case "deprovisioning", "inactive":
+		jujuStatus = status.Empty
+	default:
+		jujuStatus = status.Pending
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: inst.device.State,
+	}
+}
+
+// Addresses is part of the instance.Instance interface.
+func (inst *environInstance) Addresses() ([]network.Address, error) {
+	addrs := make([]network.Address, 0, len(inst.device.IPAddresses))
+	for _, ip := range inst.device.IPAddresses {
+		scope := network.ScopeCloudLocal
+		if ip.Public {
+			scope = network.ScopePublic
+		}
+		addrs = append(addrs, network.NewScopedAddress(ip.Address, scope))
+	}
+	return addrs, nil
+}
+
+// OpenPorts is part of the instance.InstanceFirewaller interface.
+//
+// Equinix Metal has no network firewall, so the rules cannot be
+// enforced by the cloud. They are recorded in the device's tags, and
+// the device is given an elastic IP address, so that exposed
+// applications have a public address that is stable across the
+// lifetime of the machine.
+func (inst *environInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+	if err := inst.env.ensureElasticIP(inst.device); err != nil {
+		return errors.Annotatef(err, "opening ports on machine %q", machineId)
+	}
+	deviceTags := inst.device.Tags
+	for _, rule := range rules {
+		tag := ingressTag(rule)
+		if !containsString(deviceTags, tag) {
+			deviceTags = append(deviceTags, tag)
+		}
+	}
+	if err := inst.env.client.UpdateDeviceTags(inst.device.ID, deviceTags); err != nil {
+		return errors.Annotatef(err, "opening ports on machine %q", machineId)
+	}
+	inst.device.Tags = deviceTags
+	return nil
+}
+
+// ClosePorts is part of the instance.InstanceFirewaller interface.
+// Once no ports remain open, the device's elastic IP address is
+// released.
+func (inst *environInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+	closing := make(map[string]bool)
+	for _, rule := range rules {
+		closing[ingressTag(rule)] = true
+	}
+	deviceTags := make([]string, 0, len(inst.device.Tags))
+	for _, tag := range inst.device.Tags {
+		if !closing[tag] {
+			deviceTags = append(deviceTags, tag)
+		}
+	}
+	if err := inst.env.client.UpdateDeviceTags(inst.device.ID, deviceTags); err != nil {
+		return errors.Annotatef(err, "closing ports on machine %q", machineId)
+	}
+	inst.device.Tags = deviceTags
+
+	if _, ok := tagValue(deviceTags, tagIngress); ok {
+		return nil
+	}
+	if err := inst.env.releaseIPs(tagElasticIPDevice, inst.device.ID); err != nil {
+		return errors.Annotatef(err, "closing ports on machine %q", machineId)
+	}
+	return nil
+}
+
+// IngressRules is part of the instance.InstanceFirewaller interface.
+func (inst *environInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	prefix := tagIngress + "="
+	var rules []network.IngressRule
+	for _, tag := range inst.device.Tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		rule, err := parseIngressRule(tag[len(prefix):])
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing tag %q of machine %q", tag, machineId)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// ingressTag returns the device tag recording the ingress rule, in the
+// form "juju-ingress=<port-range>@<cidr>[,<cidr>...]".
+func ingressTag(rule network.IngressRule) string {
+	cidrs := rule.SourceCIDRs
+	if len(cidrs) == 0 {
+		cidrs = []string{"0.0.0.0/0"}
+	}
+	return tagString(tagIngress, rule.PortRange.String()+"@"+strings.Join(cidrs, ","))
+}
+
+// parseIngressRule parses the value of an ingress tag.
+func parseIngressRule(value string) (network.IngressRule, error) {
+	parts := strings.SplitN(value, "@", 2)
+	if len(parts) != 2 {
+		return network.IngressRule{}, errors.NotValidf("ingress rule %q", value)
+	}
+	portRange, err := network.ParsePortRange(parts[0])
+	if err != nil {
+		return network.IngressRule{}, errors.Trace(err)
+	}
+	return network.NewIngressRule(
+		portRange.Protocol, portRange.FromPort, portRange.ToPort,
+		strings.Split(parts[1], ",")...,
+	)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
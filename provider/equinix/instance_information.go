@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+)
+
+var _ environs.InstanceTypesFetcher = (*environ)(nil)
+
+// costDivisor is the number that instance type costs must be divided
+// by to obtain the hourly price in US dollars.
+const costDivisor = 1000
+
+// InstanceTypes implements InstanceTypesFetcher.
+func (env *environ) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	all, err := env.instanceTypes()
+	if err != nil {
+		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+	}
+	matching, err := instances.MatchingInstanceTypes(all, env.cloud.Region, c)
+	if err != nil {
+		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+	}
+	return instances.InstanceTypesWithCostMetadata{
+		InstanceTypes: matching,
+		CostUnit:      "USD/h",
+		CostCurrency:  "USD",
+		CostDivisor:   costDivisor,
+	}, nil
+}
+
+// instanceTypes returns an instance type for each of the plans
+// available to the project.
+func (env *environ) instanceTypes() ([]instances.InstanceType, error) {
+	plans, err := env.client.Plans()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]instances.InstanceType, 0, len(plans))
+	for _, plan := range plans {
+		result = append(result, planInstanceType(plan))
+	}
+	return result, nil
+}
+
+// findInstanceType returns the cheapest instance type matching the
+// constraints, with an architecture for which there are agent binaries.
+func (env *environ) findInstanceType(cons constraints.Value, arches []string) (instances.InstanceType, error) {
+	all, err := env.instanceTypes()
+	if err != nil {
+		return instances.InstanceType{}, errors.Trace(err)
+	}
+	matching, err := instances.MatchingInstanceTypes(all, env.cloud.Region, cons)
+	if err != nil {
+		return instances.InstanceType{}, errors.Trace(err)
+	}
+	for _, itype := range matching {
+		for _, a := range itype.Arches {
+			for _, toolsArch := range arches {
+				if a == toolsArch {
+					itype.Arches = []string{a}
+					return itype, nil
+				}
+			}
+		}
+	}
+	return instances.InstanceType{}, errors.NotFoundf(
+		"plan matching constraints %q with architectures %v", cons, arches,
+	)
+}
+
+// planInstanceType returns the instance type describing the plan.
+func planInstanceType(plan metalPlan) instances.InstanceType {
+	planArch := arch.AMD64
+	if strings.Contains(plan.Slug, ".arm") {
+		planArch = arch.ARM64
+	}
+	var cores uint64
+	for _, cpu := range plan.Specs.CPUs {
+		cores += uint64(cpu.Count)
+	}
+	var rootDisk uint64
+	if len(plan.Specs.Drives) > 0 {
+		rootDisk = parseSize(plan.Specs.Drives[0].Size)
+	}
+	return instances.InstanceType{
+		Id:       plan.Slug,
+		Name:     plan.Slug,
+		Arches:   []string{planArch},
+		CpuCores: cores,
+		Mem:      parseSize(plan.Specs.Memory.Total),
+		RootDisk: rootDisk,
+		Cost:     uint64(plan.Pricing.Hour * costDivisor),
+	}
+}
+
+// parseSize parses a size as reported in plan specs, such as "32GB",
+// returning it in MiB. Zero is returned if the size cannot be parsed.
+func parseSize(size string) uint64 {
+	multipliers := []struct {
+		suffix string
+		mb     float64
+	}{
+		{"TB", 1024 * 1024},
+		{"GB", 1024},
+		{"MB", 1},
+	}
+	size = strings.TrimSpace(size)
+	for _, m := range multipliers {
+		if !strings.HasSuffix(size, m.suffix) {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(size, m.suffix)), 64)
+		if err != nil {
+			return 0
+		}
+		return uint64(n * m.mb)
+	}
+	return 0
+}
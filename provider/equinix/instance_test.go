@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type instanceSuite struct {
+	environSuite
+
+	device metalDevice
+	inst   *environInstance
+}
+
+var _ = gc.Suite(&instanceSuite{})
+
+func (s *instanceSuite) SetUpTest(c *gc.C) {
+	s.environSuite.SetUpTest(c)
+	s.device = metalDevice{
+		ID:    "device-0",
+		State: "active",
+		Tags: []string{
+			"juju-controller-uuid=" + testing.ControllerTag.Id(),
+			"juju-model-uuid=" + testing.ModelTag.Id(),
+		},
+		Facility: metalFacility{Code: "da11", Metro: &metalMetro{Code: "da"}},
+		IPAddresses: []metalIPAddress{
+			{Address: "147.75.0.10", AddressFamily: 4, Public: true},
+			{Address: "10.0.0.2", AddressFamily: 4},
+		},
+	}
+	s.inst = &environInstance{env: s.env, device: s.device}
+}
+
+func (s *instanceSuite) TestStatus(c *gc.C) {
+	for state, expect := range map[string]status.Status{
+		"queued":       status.Allocating,
+		"provisioning": status.Allocating,
+		"active":       status.Running,
+		"failed":       status.ProvisioningError,
+		"inactive":     status.Empty,
+		"unknown":      status.Pending,
+	} {
+		s.inst.device.State = state
+		c.Check(s.inst.Status(), jc.DeepEquals, instance.InstanceStatus{
+			Status:  expect,
+			Message: state,
+		})
+	}
+}
+
+func (s *instanceSuite) TestAddresses(c *gc.C) {
+	addrs, err := s.inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("147.75.0.10", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.2", network.ScopeCloudLocal),
+	})
+}
+
+func (s *instanceSuite) TestOpenPortsReservesElasticIP(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("udp", 1000, 2000, "10.0.0.0/8"),
+	}
+	err := s.inst.OpenPorts("0", rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "IPReservations", "ReserveIP", "AssignIP", "UpdateDeviceTags")
+	s.client.CheckCall(c, 1, "ReserveIP", "da", []string{
+		"juju-device=device-0",
+		"juju-model-uuid=" + testing.ModelTag.Id(),
+		"juju-controller-uuid=" + testing.ControllerTag.Id(),
+	})
+	s.client.CheckCall(c, 2, "AssignIP", "device-0", "147.75.0.1")
+	s.client.CheckCall(c, 3, "UpdateDeviceTags", "device-0", []string{
+		"juju-controller-uuid=" + testing.ControllerTag.Id(),
+		"juju-model-uuid=" + testing.ModelTag.Id(),
+		"juju-ingress=80/tcp@0.0.0.0/0",
+		"juju-ingress=1000-2000/udp@10.0.0.0/8",
+	})
+
+	ingress, err := s.inst.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("udp", 1000, 2000, "10.0.0.0/8"),
+	})
+}
+
+func (s *instanceSuite) TestOpenPortsExistingElasticIP(c *gc.C) {
+	s.client.reservations = []metalIPReservation{{
+		ID:      "ip-0",
+		Address: "147.75.0.1",
+		Tags:    []string{"juju-device=device-0"},
+		Assignments: []metalIPAssignment{{
+			ID:         "assignment-0",
+			AssignedTo: metalHref{Href: "/metal/v1/devices/device-0"},
+		}},
+	}}
+	err := s.inst.OpenPorts("0", []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "IPReservations", "UpdateDeviceTags")
+}
+
+func (s *instanceSuite) TestClosePortsReleasesElasticIP(c *gc.C) {
+	s.inst.device.Tags = append(s.inst.device.Tags,
+		"juju-ingress=80/tcp@0.0.0.0/0",
+		"juju-ingress=443/tcp@0.0.0.0/0",
+	)
+	s.client.reservations = []metalIPReservation{{
+		ID:          "ip-0",
+		Tags:        []string{"juju-device=device-0"},
+		Assignments: []metalIPAssignment{{ID: "assignment-0"}},
+	}}
+
+	err := s.inst.ClosePorts("0", []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "UpdateDeviceTags")
+
+	s.client.ResetCalls()
+	err = s.inst.ClosePorts("0", []network.IngressRule{network.MustNewIngressRule("tcp", 443, 443)})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "UpdateDeviceTags", "IPReservations", "DeleteIP", "DeleteIP")
+	s.client.CheckCall(c, 0, "UpdateDeviceTags", "device-0", []string{
+		"juju-controller-uuid=" + testing.ControllerTag.Id(),
+		"juju-model-uuid=" + testing.ModelTag.Id(),
+	})
+	s.client.CheckCall(c, 2, "DeleteIP", "assignment-0")
+	s.client.CheckCall(c, 3, "DeleteIP", "ip-0")
+}
+
+func (s *instanceSuite) TestIngressRulesInvalidTag(c *gc.C) {
+	s.inst.device.Tags = []string{"juju-ingress=80/tcp"}
+	_, err := s.inst.IngressRules("0")
+	c.Assert(err, gc.ErrorMatches, `parsing tag "juju-ingress=80/tcp" of machine "0": ingress rule "80/tcp" not valid`)
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	stdtesting "testing"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+func newConfig(c *gc.C, attrs testing.Attrs) *config.Config {
+	attrs = testing.FakeConfig().Merge(testing.Attrs{"type": "equinix"}).Merge(attrs)
+	cfg, err := config.New(config.NoDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		credAttrProjectID: "project-id",
+		credAttrAPIToken:  "secret",
+	})
+	return environs.CloudSpec{
+		Type:       "equinix",
+		Name:       "equinix",
+		Region:     "da",
+		Credential: &cred,
+	}
+}
+
+// environSuite is the base suite for tests that use an environ with
+// a fake Equinix Metal API.
+type environSuite struct {
+	testing.BaseSuite
+
+	client *fakeMetalAPI
+	env    *environ
+}
+
+func (s *environSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.client = &fakeMetalAPI{
+		facilities: []metalFacility{
+			{ID: "f1", Code: "da11", Metro: &metalMetro{Code: "da"}},
+			{ID: "f2", Code: "da6", Metro: &metalMetro{Code: "da"}},
+			{ID: "f3", Code: "ny5", Metro: &metalMetro{Code: "ny"}},
+		},
+		plans: []metalPlan{{
+			Slug: "c3.small.x86",
+			Specs: metalPlanSpecs{
+				CPUs:   []metalPlanCPU{{Count: 1}},
+				Memory: metalPlanMemory{Total: "32GB"},
+				Drives: []metalPlanDrive{{Count: 2, Size: "480GB"}},
+			},
+			Pricing: metalPlanPricing{Hour: 0.5},
+		}, {
+			Slug: "c3.large.arm",
+			Specs: metalPlanSpecs{
+				CPUs:   []metalPlanCPU{{Count: 1}},
+				Memory: metalPlanMemory{Total: "128GB"},
+			},
+			Pricing: metalPlanPricing{Hour: 0.4},
+		}, {
+			Slug: "m3.large.x86",
+			Specs: metalPlanSpecs{
+				CPUs:   []metalPlanCPU{{Count: 2}},
+				Memory: metalPlanMemory{Total: "256GB"},
+			},
+			Pricing: metalPlanPricing{Hour: 2},
+		}},
+	}
+	s.PatchValue(&newMetalClient, func(environs.CloudSpec) (metalAPI, error) {
+		return s.client, nil
+	})
+	env, err := providerInstance.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env.(*environ)
+}
+
+// fakeMetalAPI is an in-memory implementation of metalAPI.
+type fakeMetalAPI struct {
+	gitjujutesting.Stub
+
+	devices      []metalDevice
+	facilities   []metalFacility
+	plans        []metalPlan
+	reservations []metalIPReservation
+}
+
+func (f *fakeMetalAPI) Devices() ([]metalDevice, error) {
+	f.MethodCall(f, "Devices")
+	return f.devices, f.NextErr()
+}
+
+func (f *fakeMetalAPI) CreateDevice(req createDeviceRequest) (*metalDevice, error) {
+	f.MethodCall(f, "CreateDevice", req)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	facility := metalFacility{Code: "da11", Metro: &metalMetro{Code: "da"}}
+	if len(req.Facility) > 0 {
+		facility.Code = req.Facility[0]
+	}
+	device := metalDevice{
+		ID:       "device-0",
+		Hostname: req.Hostname,
+		State:    "provisioning",
+		Tags:     req.Tags,
+		Facility: facility,
+	}
+	f.devices = append(f.devices, device)
+	return &device, nil
+}
+
+func (f *fakeMetalAPI) DeleteDevice(id string) error {
+	f.MethodCall(f, "DeleteDevice", id)
+	return f.NextErr()
+}
+
+func (f *fakeMetalAPI) UpdateDeviceTags(id string, tags []string) error {
+	f.MethodCall(f, "UpdateDeviceTags", id, tags)
+	return f.NextErr()
+}
+
+func (f *fakeMetalAPI) Facilities() ([]metalFacility, error) {
+	f.MethodCall(f, "Facilities")
+	return f.facilities, f.NextErr()
+}
+
+func (f *fakeMetalAPI) Plans() ([]metalPlan, error) {
+	f.MethodCall(f, "Plans")
+	return f.plans, f.NextErr()
+}
+
+func (f *fakeMetalAPI) IPReservations() ([]metalIPReservation, error) {
+	f.MethodCall(f, "IPReservations")
+	return f.reservations, f.NextErr()
+}
+
+func (f *fakeMetalAPI) ReserveIP(metro string, tags []string) (*metalIPReservation, error) {
+	f.MethodCall(f, "ReserveIP", metro, tags)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	reservation := metalIPReservation{
+		ID:      "ip-0",
+		Address: "147.75.0.1",
+		Tags:    tags,
+	}
+	f.reservations = append(f.reservations, reservation)
+	return &reservation, nil
+}
+
+func (f *fakeMetalAPI) AssignIP(deviceID, address string) error {
+	f.MethodCall(f, "AssignIP", deviceID, address)
+	return f.NextErr()
+}
+
+func (f *fakeMetalAPI) DeleteIP(id string) error {
+	f.MethodCall(f, "DeleteIP", id)
+	return f.NextErr()
+}
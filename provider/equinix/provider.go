@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package equinix implements a Juju provider for Equinix Metal, a
+// bare-metal cloud. Devices are provisioned with the operating system
+// images provided by Equinix Metal, and are configured with cloud-init
+// user data.
+package equinix
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+)
+
+var logger = loggo.GetLogger("juju.provider.equinix")
+
+const (
+	providerType = "equinix"
+)
+
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance = environProvider{}
+
+// check the provider implements environs.EnvironProvider interface
+var _ environs.EnvironProvider = (*environProvider)(nil)
+
+func init() {
+	// This will only happen in binaries that actually import this provider
+	// somewhere. To enable a provider, import it in the "providers/all"
+	// package; please do *not* import individual providers anywhere else,
+	// except in direct tests for that provider.
+	environs.RegisterProvider(providerType, providerInstance)
+}
+
+// Version is part of the EnvironProvider interface.
+func (environProvider) Version() int {
+	return 0
+}
+
+// Open is part of the EnvironProvider interface.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	logger.Infof("opening model %q", args.Config.Name())
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+
+	client, err := newMetalClient(args.Cloud)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespace, err := instance.NewNamespace(args.Config.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env := &environ{
+		name:      args.Config.Name(),
+		cloud:     args.Cloud,
+		client:    client,
+		namespace: namespace,
+	}
+	if err := env.SetConfig(args.Config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return env, nil
+}
+
+// CloudSchema returns the schema used to validate input for add-cloud.
+// Equinix Metal clouds need nothing more than a region, and an optional
+// endpoint.
+func (environProvider) CloudSchema() *jsonschema.Schema {
+	return nil
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (environProvider) Ping(endpoint string) error {
+	return errors.NotImplementedf("Ping")
+}
+
+// PrepareConfig is part of the EnvironProvider interface.
+func (environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate is part of the config.Validator interface.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	newEcfg, err := validateConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Errorf("invalid config: %v", err)
+	}
+	if old != nil {
+		oldEcfg, err := validateConfig(old, nil)
+		if err != nil {
+			return nil, errors.Errorf("invalid base config: %v", err)
+		}
+		if newEcfg, err = validateConfig(cfg, oldEcfg); err != nil {
+			return nil, errors.Errorf("invalid config change: %v", err)
+		}
+	}
+	return newEcfg.Config, nil
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Region == "" {
+		return errors.NotValidf("missing region")
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if authType := spec.Credential.AuthType(); authType != cloud.AccessKeyAuthType {
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	attrs := spec.Credential.Attributes()
+	for _, attr := range []string{credAttrProjectID, credAttrAPIToken} {
+		if attrs[attr] == "" {
+			return errors.NotValidf("credential with empty %q", attr)
+		}
+	}
+	return nil
+}
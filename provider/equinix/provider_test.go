@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	testing.IsolationSuite
+
+	provider environs.EnvironProvider
+	spec     environs.CloudSpec
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	provider, err := environs.Provider("equinix")
+	c.Assert(err, jc.ErrorIsNil)
+	s.provider = provider
+	s.spec = fakeCloudSpec()
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpenMissingRegion(c *gc.C) {
+	s.spec.Region = ""
+	s.testOpenError(c, s.spec, `validating cloud spec: missing region not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	s.spec.Credential = nil
+	s.testOpenError(c, s.spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	s.spec.Credential = &credential
+	s.testOpenError(c, s.spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) TestOpenEmptyToken(c *gc.C) {
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		credAttrProjectID: "project-id",
+	})
+	s.spec.Credential = &credential
+	s.testOpenError(c, s.spec, `validating cloud spec: credential with empty "api-token" not valid`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: newConfig(c, nil),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestValidateGlobalFirewallMode(c *gc.C) {
+	cfg := newConfig(c, coretesting.Attrs{"firewall-mode": "global"})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, "invalid config: global firewall mode is not supported")
+}
+
+func (s *providerSuite) TestDetectCredentials(c *gc.C) {
+	s.PatchEnvironment("METAL_AUTH_TOKEN", "secret")
+	s.PatchEnvironment("METAL_PROJECT_ID", "project-id")
+	s.PatchEnvironment("METAL_METRO", "da")
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "da")
+
+	expected := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"project-id": "project-id",
+		"api-token":  "secret",
+	})
+	expected.Label = `equinix credential for project "project-id"`
+	c.Assert(credentials.AuthCredentials["project-id"], jc.DeepEquals, expected)
+}
+
+func (s *providerSuite) TestDetectCredentialsNotFound(c *gc.C) {
+	s.PatchEnvironment("METAL_AUTH_TOKEN", "")
+	s.PatchEnvironment("METAL_PROJECT_ID", "project-id")
+	_, err := s.provider.DetectCredentials()
+	c.Assert(err, gc.ErrorMatches, "credentials not found")
+}
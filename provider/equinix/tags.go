@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"sort"
+	"strings"
+)
+
+// Equinix Metal tags are plain strings, so Juju's key/value tags are
+// recorded as "key=value".
+
+func tagString(key, value string) string {
+	return key + "=" + value
+}
+
+// tagStrings returns the given key/value tags as sorted tag strings.
+func tagStrings(tags map[string]string) []string {
+	result := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if k == "" {
+			continue
+		}
+		result = append(result, tagString(k, v))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// tagValue returns the value of the tag with the given key, and
+// whether it was found.
+func tagValue(tags []string, key string) (string, bool) {
+	prefix := key + "="
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, prefix) {
+			return tag[len(prefix):], true
+		}
+	}
+	return "", false
+}
+
+// hasTag reports whether the tags contain the given key and value.
+func hasTag(tags []string, key, value string) bool {
+	v, ok := tagValue(tags, key)
+	return ok && v == value
+}
+
+// setTag returns a copy of the tags with the tag with the given key
+// set to the value.
+func setTag(tags []string, key, value string) []string {
+	result := removeTags(tags, key)
+	return append(result, tagString(key, value))
+}
+
+// removeTags returns a copy of the tags without the tags with the
+// given key.
+func removeTags(tags []string, key string) []string {
+	prefix := key + "="
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
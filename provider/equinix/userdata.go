@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package equinix
+
+import (
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+// MetalRenderer renders cloud-init user data for Equinix Metal devices.
+// The user data is passed to cloud-init as is, so it is not encoded.
+type MetalRenderer struct{}
+
+// Render is part of the renderers.ProviderRenderer interface.
+func (MetalRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS: %s", os.String())
+	}
+}
+
+// operatingSystems maps series to the slugs of the Equinix Metal
+// operating systems that devices are provisioned with.
+var operatingSystems = map[string]string{
+	"trusty":  "ubuntu_14_04",
+	"xenial":  "ubuntu_16_04",
+	"bionic":  "ubuntu_18_04",
+	"centos7": "centos_7",
+}
+
+// operatingSystem returns the slug of the operating system for the
+// given series.
+func operatingSystem(series string) (string, error) {
+	os, ok := operatingSystems[series]
+	if !ok {
+		return "", errors.NotSupportedf("series %q", series)
+	}
+	return os, nil
+}
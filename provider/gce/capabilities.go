@@ -0,0 +1,15 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+var _ environs.CapabilitiesReporter = (*environ)(nil)
+
+// Capabilities is specified on the environs.CapabilitiesReporter interface.
+func (*environ) Capabilities() ([]environs.Capability, error) {
+	return []environs.Capability{environs.CapabilityInstanceFirewall}, nil
+}
@@ -21,7 +21,26 @@ import (
 // that we can use to validate this provider's potentially out-of-date
 // data.
 
-var configSchema = environschema.Fields{}
+const (
+	cfgVPCID      = "vpc-id"
+	cfgForceVPCID = "vpc-id-force"
+)
+
+var configSchema = environschema.Fields{
+	cfgVPCID: {
+		Description: "Use a specific VPC network (optional). When not specified, Juju uses the project's default network.",
+		Example:     "juju-network",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+	cfgForceVPCID: {
+		Description: "Force Juju to use the VPC network specified with vpc-id, when it fails the minimum validation criteria. Not accepted without vpc-id",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+}
 
 // configFields is the spec for each GCE config value's type.
 var configFields = func() schema.Fields {
@@ -32,9 +51,15 @@ var configFields = func() schema.Fields {
 	return fs
 }()
 
-var configImmutableFields = []string{}
+var configImmutableFields = []string{
+	cfgVPCID,
+	cfgForceVPCID,
+}
 
-var configDefaults = schema.Defaults{}
+var configDefaults = schema.Defaults{
+	cfgVPCID:      "",
+	cfgForceVPCID: false,
+}
 
 type environConfig struct {
 	config *config.Config
@@ -77,5 +102,20 @@ func newConfig(cfg, old *config.Config) (*environConfig, error) {
 		config: cfg,
 		attrs:  attrs,
 	}
+	if ecfg.vpcID() == "" && ecfg.forceVPCID() {
+		return nil, errors.NotValidf("%s without %s", cfgForceVPCID, cfgVPCID)
+	}
 	return ecfg, nil
 }
+
+// vpcID returns the name of the VPC network that machines are
+// started in, or "" if the default network is used.
+func (c *environConfig) vpcID() string {
+	return c.attrs[cfgVPCID].(string)
+}
+
+// forceVPCID reports whether the VPC network should be used even
+// if it does not meet the minimum requirements.
+func (c *environConfig) forceVPCID() bool {
+	return c.attrs[cfgForceVPCID].(bool)
+}
@@ -92,6 +92,14 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "vpc-id is accepted",
+	insert: testing.Attrs{"vpc-id": "juju-network", "vpc-id-force": true},
+	expect: testing.Attrs{"vpc-id": "juju-network", "vpc-id-force": true},
+}, {
+	info:   "vpc-id-force requires vpc-id",
+	insert: testing.Attrs{"vpc-id-force": true},
+	err:    "vpc-id-force without vpc-id not valid",
 }}
 
 func (s *ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -154,6 +162,10 @@ var changeConfigTests = []configTestSpec{{
 	info:   "can insert unknown field",
 	insert: testing.Attrs{"unknown": "ignoti"},
 	expect: testing.Attrs{"unknown": "ignoti"},
+}, {
+	info:   "cannot change vpc-id",
+	insert: testing.Attrs{"vpc-id": "juju-network"},
+	err:    "vpc-id: cannot change from  to juju-network",
 }}
 
 // TODO(wwitzel3) refactor this to the provider_test file.
@@ -6,9 +6,14 @@ package gce
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -110,12 +115,69 @@ func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, e
 	}
 	cred.Label = fmt.Sprintf("google credential %q", credName)
 	return &cloud.CloudCredential{
-		DefaultRegion: os.Getenv("CLOUDSDK_COMPUTE_REGION"),
+		DefaultRegion: defaultRegion(os.Getenv("CLOUDSDK_COMPUTE_REGION")),
 		AuthCredentials: map[string]cloud.Credential{
 			user: cred,
 		}}, nil
 }
 
+// metadataURL is the base URL of the GCE metadata server.
+var metadataURL = "http://metadata.google.internal/computeMetadata/v1"
+
+// metadataTimeout bounds requests made to the metadata server,
+// which cannot be resolved outside GCE.
+var metadataTimeout = time.Second
+
+// defaultRegion returns the given region if it is set. Otherwise,
+// when running on a GCE instance, it returns the instance's region,
+// as reported by the metadata server.
+//
+// The metadata server only issues access tokens for the instance's
+// service account, not the key that a credential requires, so
+// credentials are not detected from it.
+func defaultRegion(region string) string {
+	if region != "" {
+		return region
+	}
+	region, err := instanceRegion()
+	if err != nil {
+		logger.Debugf("cannot get region from instance metadata: %v", err)
+		return ""
+	}
+	return region
+}
+
+// instanceRegion returns the region of the GCE instance on which
+// the client is running.
+func instanceRegion() (string, error) {
+	req, err := http.NewRequest("GET", metadataURL+"/instance/zone", nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	client := http.Client{Timeout: metadataTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("metadata server returned %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// The zone is reported as "projects/<number>/zones/<zone>",
+	// and is named after its region, followed by "-<letter>".
+	zone := path.Base(strings.TrimSpace(string(body)))
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 {
+		return "", errors.Errorf("invalid zone %q", zone)
+	}
+	return zone[:i], nil
+}
+
 func wellKnownCredentialsFile() string {
 	const f = "application_default_credentials.json"
 	if runtime.GOOS == "windows" {
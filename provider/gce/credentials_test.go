@@ -4,7 +4,10 @@
 package gce_test
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -17,6 +20,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	envtesting "github.com/juju/juju/environs/testing"
+	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
 
@@ -33,6 +37,15 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.provider, err = environs.Provider("gce")
 	c.Assert(err, jc.ErrorIsNil)
+
+	// Tests do not run on GCE instances.
+	s.patchMetadata(c, http.NotFoundHandler())
+}
+
+func (s *credentialsSuite) patchMetadata(c *gc.C, handler http.Handler) {
+	server := httptest.NewServer(handler)
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.PatchValue(gce.MetadataURL, server.URL)
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
@@ -97,6 +110,22 @@ func (s *credentialsSuite) TestDetectCredentialsFromEnvVar(c *gc.C) {
 	c.Assert(credentials.AuthCredentials["fred"], jc.DeepEquals, expected)
 }
 
+func (s *credentialsSuite) TestDetectCredentialsInstanceRegion(c *gc.C) {
+	s.patchMetadata(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/instance/zone" || r.Header.Get("Metadata-Flavor") != "Google" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "projects/123456789/zones/europe-west1-c")
+	}))
+	jsonpath := createCredsFile(c, "")
+	s.PatchEnvironment("USER", "fred")
+	s.PatchEnvironment("GOOGLE_APPLICATION_CREDENTIALS", jsonpath)
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "europe-west1")
+}
+
 func (s *credentialsSuite) assertDetectCredentialsKnownLocation(c *gc.C, jsonpath string) {
 	s.PatchEnvironment("USER", "fred")
 	s.PatchEnvironment("CLOUDSDK_COMPUTE_REGION", "region")
@@ -6,8 +6,10 @@ package gce
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"google.golang.org/api/compute/v1"
 
 	jujucloud "github.com/juju/juju/cloud"
@@ -27,6 +29,10 @@ type gceConnection interface {
 	// and returns it.
 	Instance(id, zone string) (google.Instance, error)
 	Instances(prefix string, statuses ...string) ([]google.Instance, error)
+	// ChangedInstances returns the ids of the instances with the given
+	// prefix on which operations completed after the given time, and
+	// the completion time of the most recent operation.
+	ChangedInstances(prefix string, since time.Time) ([]string, time.Time, error)
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 	UpdateMetadata(key, value string, ids ...string) error
@@ -42,6 +48,8 @@ type gceConnection interface {
 	// Networks returns the available networks that exist across
 	// regions.
 	Networks() ([]*compute.Network, error)
+	// Routes returns the routes defined for all networks.
+	Routes() ([]*compute.Route, error)
 
 	// Storage related methods.
 
@@ -76,6 +84,10 @@ type environ struct {
 	cloud environs.CloudSpec
 	gce   gceConnection
 
+	// projectID is the GCE project that the model's
+	// resources are created in.
+	projectID string
+
 	lock sync.Mutex // lock protects access to ecfg
 	ecfg *environConfig
 
@@ -90,7 +102,11 @@ var _ environs.NetworkingEnviron = (*environ)(nil)
 // for testing purposes.
 var (
 	newConnection = func(conn google.ConnectionConfig, creds *google.Credentials) (gceConnection, error) {
-		return google.Connect(conn, creds)
+		gce, err := google.Connect(conn, creds)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newThrottledConnection(gce, clock.WallClock)
 	}
 	destroyEnv = common.Destroy
 	bootstrap  = common.Bootstrap
@@ -121,6 +137,7 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 	connectionConfig := google.ConnectionConfig{
 		Region:    cloud.Region,
 		ProjectID: credential.ProjectID,
+		Network:   ecfg.vpcID(),
 	}
 
 	// Connect and authenticate.
@@ -139,6 +156,7 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 		cloud:     cloud,
 		ecfg:      ecfg,
 		gce:       conn,
+		projectID: credential.ProjectID,
 		namespace: namespace,
 	}, nil
 }
@@ -188,6 +206,12 @@ func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
 			return errors.Trace(err)
 		}
 	}
+	env.lock.Lock()
+	vpcID, forceVPCID := env.ecfg.vpcID(), env.ecfg.forceVPCID()
+	env.lock.Unlock()
+	if err := validateBootstrapVPC(env.gce, env.cloud.Region, vpcID, forceVPCID, ctx); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -196,6 +220,9 @@ func (env *environ) Create(environs.CreateParams) error {
 	if err := env.gce.VerifyCredentials(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateModelVPC(env.gce, env.cloud.Region, env.name, env.vpcID()); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
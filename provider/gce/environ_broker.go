@@ -126,7 +126,11 @@ func (env *environ) findInstanceSpec(
 	imageMetadata []*imagemetadata.ImageMetadata,
 ) (*instances.InstanceSpec, error) {
 	images := instances.ImageMetadataToImages(imageMetadata)
-	spec, err := instances.FindInstanceSpec(images, ic, allInstanceTypes)
+	instanceTypes := env.instanceTypes()
+	if itype, ok := customInstanceType(ic.Constraints); ok {
+		instanceTypes = append(instanceTypes[:len(instanceTypes):len(instanceTypes)], itype)
+	}
+	spec, err := instances.FindInstanceSpec(images, ic, instanceTypes)
 	return spec, errors.Trace(err)
 }
 
@@ -164,8 +168,11 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		return nil, common.ZoneIndependentError(err)
 	}
 
-	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
-	// TODO(ericsnow) Make the network name configurable?
+	networkSpec, err := env.vpcNetworkSpec()
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
 	// TODO(ericsnow) Support multiple networks?
 	// TODO(ericsnow) Use a different net interface name? Configurable?
 	inst, err := env.gce.AddInstance(google.InstanceSpec{
@@ -176,7 +183,8 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
-		// Network is omitted (left empty).
+		Preemptible:       args.Constraints.HasSpot(),
+		Network:           networkSpec,
 	})
 	if err != nil {
 		// We currently treat all AddInstance failures
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
@@ -158,6 +159,26 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, jc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) TestFindInstanceSpecCustom(c *gc.C) {
+	s.ic.Constraints = constraints.MustParse("cores=3 mem=10G")
+	spec, err := gce.FindInstanceSpec(s.Env, s.ic, s.imageMetadata)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "custom-4-10240")
+}
+
+func (s *environBrokerSuite) TestNewRawInstancePreemptible(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("spot=true")
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "AddInstance")
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Preemptible, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestNewRawInstanceZoneSpecificError(c *gc.C) {
 	s.FakeConn.Err = errors.New("blargh")
 
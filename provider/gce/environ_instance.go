@@ -168,9 +168,9 @@ func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 
 // checkInstanceType is used to ensure the the provided constraints
 // specify a recognized instance type.
-func checkInstanceType(cons constraints.Value) bool {
+func (env *environ) checkInstanceType(cons constraints.Value) bool {
 	// Constraint has an instance-type constraint so let's see if it is valid.
-	for _, itype := range allInstanceTypes {
+	for _, itype := range env.instanceTypes() {
 		if itype.Name == *cons.InstanceType {
 			return true
 		}
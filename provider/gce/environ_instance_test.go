@@ -4,7 +4,10 @@
 package gce_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -15,6 +18,8 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
 )
 
 type environInstSuite struct {
@@ -174,7 +179,7 @@ func (s *environInstSuite) TestCheckInstanceType(c *gc.C) {
 	cons := constraints.Value{
 		InstanceType: &typ,
 	}
-	matched := gce.CheckInstanceType(cons)
+	matched := gce.CheckInstanceType(s.Env, cons)
 
 	c.Check(matched, jc.IsTrue)
 }
@@ -184,12 +189,13 @@ func (s *environInstSuite) TestCheckInstanceTypeUnknown(c *gc.C) {
 	cons := constraints.Value{
 		InstanceType: &typ,
 	}
-	matched := gce.CheckInstanceType(cons)
+	matched := gce.CheckInstanceType(s.Env, cons)
 
 	c.Check(matched, jc.IsFalse)
 }
 
 func (s *environInstSuite) TestListMachineTypes(c *gc.C) {
+	gce.RefreshInstanceTypesCache()
 	_, err := s.Env.InstanceTypes(constraints.Value{})
 	c.Assert(err, gc.ErrorMatches, "no instance types in  matching constraints \"\"")
 
@@ -203,6 +209,37 @@ func (s *environInstSuite) TestListMachineTypes(c *gc.C) {
 
 }
 
+func (s *environInstSuite) TestListMachineTypesCached(c *gc.C) {
+	gce.RefreshInstanceTypesCache()
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+	for i := 0; i < 2; i++ {
+		types, err := s.Env.InstanceTypes(constraints.Value{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(types.InstanceTypes, gc.HasLen, 2)
+	}
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "AvailabilityZones")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListMachineTypes")
+}
+
+func (s *environInstSuite) TestCheckInstanceTypeListed(c *gc.C) {
+	gce.RefreshInstanceTypesCache()
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+	listed, builtin := "type-1", "n1-standard-1"
+	c.Check(gce.CheckInstanceType(s.Env, constraints.Value{InstanceType: &listed}), jc.IsTrue)
+	c.Check(gce.CheckInstanceType(s.Env, constraints.Value{InstanceType: &builtin}), jc.IsFalse)
+}
+
+func (s *environInstSuite) TestCheckInstanceTypeBuiltinFallback(c *gc.C) {
+	gce.RefreshInstanceTypesCache()
+	builtin := "n1-standard-1"
+	c.Check(gce.CheckInstanceType(s.Env, constraints.Value{InstanceType: &builtin}), jc.IsTrue)
+}
+
 func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	john := s.NewInstance(c, "john")
 	misty := s.NewInstance(c, "misty")
@@ -217,3 +254,22 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	c.Check(call.Key, gc.Equals, tags.JujuController)
 	c.Check(call.Value, gc.Equals, "other-uuid")
 }
+
+func (s *environInstSuite) TestWatchInstanceChanges(c *gc.C) {
+	s.FakeConn.ChangedIDs = [][]string{{"juju-old"}, nil, {"spam", "ham", "spam"}}
+	clock := testing.NewClock(time.Time{})
+	w, err := gce.NewInstanceChangesWatcher(s.Env, clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The operations completed before the watcher started
+	// are not reported.
+	c.Assert(clock.WaitAdvance(gce.InstanceChangesPollInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(gce.InstanceChangesPollInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case ids := <-w.Changes():
+		c.Assert(ids, jc.DeepEquals, []string{"spam", "ham"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for instance changes")
+	}
+}
@@ -22,17 +22,24 @@ func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error
 	}
 
 	if args.Constraints.HasInstanceType() {
-		if !checkInstanceType(args.Constraints) {
+		if !env.checkInstanceType(args.Constraints) {
 			return errors.Errorf("invalid GCE instance type %q", *args.Constraints.InstanceType)
 		}
 	}
 
+	// GCE preemptible instances have a fixed price.
+	if price := args.Constraints.SpotPrice(); price != "" {
+		return errors.NotSupportedf("spot price %q on GCE (use spot=true)", price)
+	}
+
 	return nil
 }
 
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -64,8 +71,9 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	// vocab
 
-	instTypeNames := make([]string, len(allInstanceTypes))
-	for i, itype := range allInstanceTypes {
+	instanceTypes := env.instanceTypes()
+	instTypeNames := make([]string, len(instanceTypes))
+	for i, itype := range instanceTypes {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
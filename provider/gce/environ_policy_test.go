@@ -58,6 +58,17 @@ func (s *environPolSuite) TestPrecheckInstanceInvalidInstanceType(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.*invalid GCE instance type.*`)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceSpot(c *gc.C) {
+	cons := constraints.MustParse("spot=true")
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Constraints: cons})
+	c.Check(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("spot=0.05")
+	err = s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Constraints: cons})
+	c.Check(err, gc.ErrorMatches, `spot price "0.05" on GCE \(use spot=true\) not supported`)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceDiskSize(c *gc.C) {
 	cons := constraints.MustParse("instance-type=n1-standard-1 root-disk=1G")
 	placement := ""
@@ -0,0 +1,289 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+const (
+	defaultRouteDestRange = "0.0.0.0/0"
+	internetGatewaySuffix = "/global/gateways/default-internet-gateway"
+	networkPathInfix      = "/global/networks/"
+)
+
+var (
+	vpcNotUsableForBootstrapErrorPrefix = `
+Juju cannot use the given vpc-id for bootstrapping a controller
+instance. Please double check the given VPC network name is correct,
+and that the network has at least one subnet in the model's region.
+
+Error details`[1:]
+
+	vpcNotUsableForModelErrorPrefix = `
+Juju cannot use the given vpc-id for the model being added.
+Please double check the given VPC network name is correct, and that
+the network has at least one subnet in the model's region.
+
+Error details`[1:]
+
+	vpcNotRecommendedErrorPrefix = `
+The given vpc-id does not meet one or more of the following minimum
+Juju requirements:
+
+1. The VPC network should exist in the project, and have at least one
+   subnet in the model's region (auto mode networks always do).
+2. The VPC network should have a default route (0.0.0.0/0) to the
+   default internet gateway, so that machines can reach the internet.
+
+If you still want to use the VPC network, try running 'juju bootstrap'
+again with:
+
+  --config vpc-id=%s --config vpc-id-force=true
+
+to force Juju to bypass the requirements check (NOT recommended unless
+you understand the implications: most importantly, machines may be
+unable to download packages and tools, likely causing bootstrap to fail).
+
+Error details`[1:]
+
+	cannotValidateVPCErrorPrefix = `
+Juju could not verify whether the given vpc-id meets the minimum Juju
+connectivity requirements. Please double check the VPC network name is
+correct, your credentials are sufficient to list networks and routes,
+or simply retry bootstrapping again.
+
+Error details`[1:]
+
+	vpcNotRecommendedButForcedWarning = `
+WARNING! The specified vpc-id does not satisfy the minimum Juju requirements,
+but will be used anyway because vpc-id-force=true is also specified.
+
+`[1:]
+)
+
+// vpcNotUsableError indicates a user-specified VPC network cannot be
+// used, either because it is missing or because it has no subnets in
+// the model's region.
+type vpcNotUsableError struct {
+	errors.Err
+}
+
+// vpcNotUsablef returns an error which satisfies isVPCNotUsableError().
+func vpcNotUsablef(format string, args ...interface{}) error {
+	outerErr := errors.Errorf(format, args...)
+	innerErr, _ := outerErr.(*errors.Err) // cannot fail.
+	return &vpcNotUsableError{*innerErr}
+}
+
+// isVPCNotUsableError reports whether err was created with vpcNotUsablef().
+func isVPCNotUsableError(err error) bool {
+	_, ok := errors.Cause(err).(*vpcNotUsableError)
+	return ok
+}
+
+// vpcNotRecommendedError indicates a user-specified VPC network is
+// unlikely to be suitable for hosting a Juju controller, because its
+// machines cannot reach the internet.
+type vpcNotRecommendedError struct {
+	errors.Err
+}
+
+// vpcNotRecommendedf returns an error which satisfies isVPCNotRecommendedError().
+func vpcNotRecommendedf(format string, args ...interface{}) error {
+	outerErr := errors.Errorf(format, args...)
+	innerErr, _ := outerErr.(*errors.Err) // cannot fail.
+	return &vpcNotRecommendedError{*innerErr}
+}
+
+// isVPCNotRecommendedError reports whether err was created with vpcNotRecommendedf().
+func isVPCNotRecommendedError(err error) bool {
+	_, ok := errors.Cause(err).(*vpcNotRecommendedError)
+	return ok
+}
+
+// vpcConnection defines the subset of the GCE connection needed to
+// validate a VPC network.
+type vpcConnection interface {
+	Networks() ([]*compute.Network, error)
+	Subnetworks(region string) ([]*compute.Subnetwork, error)
+	Routes() ([]*compute.Route, error)
+}
+
+// validateVPC returns an error if the named VPC network cannot be used
+// for machines in the given region. An error satisfying
+// isVPCNotUsableError is returned if the network does not exist or has
+// no subnets in the region; one satisfying isVPCNotRecommendedError is
+// returned if the network has no route to the internet.
+func validateVPC(conn vpcConnection, region, vpcID string) error {
+	network, err := findVPCNetwork(conn, vpcID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subnets, err := findVPCSubnets(conn, region, vpcID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isCustomModeNetwork(network) && len(subnets) == 0 {
+		return vpcNotUsablef("VPC network %q has no subnets in region %q", vpcID, region)
+	}
+
+	routes, err := conn.Routes()
+	if err != nil {
+		return errors.Annotate(err, "listing routes")
+	}
+	for _, route := range routes {
+		if !isNetworkURL(route.Network, vpcID) || route.DestRange != defaultRouteDestRange {
+			continue
+		}
+		if strings.HasSuffix(route.NextHopGateway, internetGatewaySuffix) {
+			return nil
+		}
+	}
+	return vpcNotRecommendedf("VPC network %q has no default route to the internet gateway", vpcID)
+}
+
+// findVPCNetwork returns the named network, or an error satisfying
+// isVPCNotUsableError if it does not exist.
+func findVPCNetwork(conn vpcConnection, vpcID string) (*compute.Network, error) {
+	networks, err := conn.Networks()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing networks")
+	}
+	for _, network := range networks {
+		if network.Name == vpcID {
+			return network, nil
+		}
+	}
+	return nil, vpcNotUsablef("VPC network %q not found", vpcID)
+}
+
+// findVPCSubnets returns the subnets of the named network in the
+// given region, sorted by name.
+func findVPCSubnets(conn vpcConnection, region, vpcID string) ([]*compute.Subnetwork, error) {
+	allSubnets, err := conn.Subnetworks(region)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing subnets")
+	}
+	var subnets []*compute.Subnetwork
+	for _, subnet := range allSubnets {
+		if isNetworkURL(subnet.Network, vpcID) {
+			subnets = append(subnets, subnet)
+		}
+	}
+	sort.Sort(subnetsByName(subnets))
+	return subnets, nil
+}
+
+// isCustomModeNetwork reports whether subnets must be created in the
+// network explicitly. Instances in such networks must be started with
+// a subnet specified.
+func isCustomModeNetwork(network *compute.Network) bool {
+	return !network.AutoCreateSubnetworks && network.IPv4Range == ""
+}
+
+// isNetworkURL reports whether the given URL identifies the named
+// network.
+func isNetworkURL(url, name string) bool {
+	return strings.HasSuffix(url, networkPathInfix+name)
+}
+
+type subnetsByName []*compute.Subnetwork
+
+func (s subnetsByName) Len() int           { return len(s) }
+func (s subnetsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s subnetsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+func validateBootstrapVPC(conn vpcConnection, region, vpcID string, forceVPCID bool, ctx environs.BootstrapContext) error {
+	if vpcID == "" {
+		return nil
+	}
+
+	err := validateVPC(conn, region, vpcID)
+	switch {
+	case isVPCNotUsableError(err):
+		// VPC network missing or has no subnets in the region.
+		return errors.Annotate(err, vpcNotUsableForBootstrapErrorPrefix)
+	case isVPCNotRecommendedError(err):
+		// VPC network does not meet minimum validation criteria.
+		if !forceVPCID {
+			return errors.Annotatef(err, vpcNotRecommendedErrorPrefix, vpcID)
+		}
+		ctx.Infof(vpcNotRecommendedButForcedWarning)
+	case err != nil:
+		// Anything else unexpected while validating the VPC network.
+		return errors.Annotate(err, cannotValidateVPCErrorPrefix)
+	}
+
+	ctx.Infof("Using VPC network %q in region %q", vpcID, region)
+	return nil
+}
+
+func validateModelVPC(conn vpcConnection, region, modelName, vpcID string) error {
+	if vpcID == "" {
+		return nil
+	}
+
+	err := validateVPC(conn, region, vpcID)
+	switch {
+	case isVPCNotUsableError(err):
+		// VPC network missing or has no subnets in the region.
+		return errors.Annotate(err, vpcNotUsableForModelErrorPrefix)
+	case isVPCNotRecommendedError(err):
+		// VPC network does not meet minimum validation criteria, but
+		// that's less important for hosted models, as the controller
+		// is already accessible.
+		logger.Infof(
+			"Juju will use, but does not recommend using VPC network %q: %v",
+			vpcID, err.Error(),
+		)
+	case err != nil:
+		// Anything else unexpected while validating the VPC network.
+		return errors.Annotate(err, cannotValidateVPCErrorPrefix)
+	}
+	logger.Infof("Using VPC network %q for model %q", vpcID, modelName)
+	return nil
+}
+
+// vpcNetworkSpec returns the network spec for new instances. If the
+// model uses a VPC network in custom subnet mode, the first of the
+// network's subnets in the model's region is used.
+func (env *environ) vpcNetworkSpec() (google.NetworkSpec, error) {
+	vpcID := env.vpcID()
+	if vpcID == "" {
+		return google.NetworkSpec{}, nil
+	}
+	spec := google.NetworkSpec{Name: vpcID}
+	network, err := findVPCNetwork(env.gce, vpcID)
+	if err != nil {
+		return spec, errors.Trace(err)
+	}
+	if !isCustomModeNetwork(network) {
+		return spec, nil
+	}
+	subnets, err := findVPCSubnets(env.gce, env.cloud.Region, vpcID)
+	if err != nil {
+		return spec, errors.Trace(err)
+	}
+	if len(subnets) == 0 {
+		return spec, vpcNotUsablef("VPC network %q has no subnets in region %q", vpcID, env.cloud.Region)
+	}
+	spec.Subnetwork = subnets[0].SelfLink
+	return spec, nil
+}
+
+// vpcID returns the name of the VPC network that the model's machines
+// are started in, or "" if the default network is used.
+func (env *environ) vpcID() string {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.vpcID()
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	envtesting "github.com/juju/juju/environs/testing"
+)
+
+const (
+	testNetworkURL  = "https://www.googleapis.com/compute/v1/projects/spam/global/networks/juju-network"
+	testGatewayURL  = "https://www.googleapis.com/compute/v1/projects/spam/global/gateways/default-internet-gateway"
+	testSubnetURL   = "https://www.googleapis.com/compute/v1/projects/spam/regions/us-east1/subnetworks/"
+	otherNetworkURL = "https://www.googleapis.com/compute/v1/projects/spam/global/networks/default"
+)
+
+type vpcSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&vpcSuite{})
+
+func (s *vpcSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.FakeConn.Networks_ = []*compute.Network{{
+		Name:     "juju-network",
+		SelfLink: testNetworkURL,
+	}}
+	s.FakeConn.Subnets = []*compute.Subnetwork{{
+		Name:     "b-subnet",
+		Network:  testNetworkURL,
+		SelfLink: testSubnetURL + "b-subnet",
+	}, {
+		Name:     "a-subnet",
+		Network:  testNetworkURL,
+		SelfLink: testSubnetURL + "a-subnet",
+	}, {
+		Name:     "default",
+		Network:  otherNetworkURL,
+		SelfLink: testSubnetURL + "default",
+	}}
+	s.FakeConn.Routes_ = []*compute.Route{{
+		Name:           "default-route",
+		Network:        testNetworkURL,
+		DestRange:      "0.0.0.0/0",
+		NextHopGateway: testGatewayURL,
+	}}
+}
+
+func (s *vpcSuite) TestValidateVPC(c *gc.C) {
+	err := validateVPC(s.FakeConn, "us-east1", "juju-network")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vpcSuite) TestValidateVPCNotFound(c *gc.C) {
+	err := validateVPC(s.FakeConn, "us-east1", "missing")
+	c.Assert(err, gc.ErrorMatches, `VPC network "missing" not found`)
+	c.Assert(err, jc.Satisfies, isVPCNotUsableError)
+}
+
+func (s *vpcSuite) TestValidateVPCNoSubnetsInRegion(c *gc.C) {
+	s.FakeConn.Subnets = s.FakeConn.Subnets[2:]
+	err := validateVPC(s.FakeConn, "us-east1", "juju-network")
+	c.Assert(err, gc.ErrorMatches, `VPC network "juju-network" has no subnets in region "us-east1"`)
+	c.Assert(err, jc.Satisfies, isVPCNotUsableError)
+}
+
+func (s *vpcSuite) TestValidateVPCAutoModeWithoutSubnets(c *gc.C) {
+	s.FakeConn.Networks_[0].AutoCreateSubnetworks = true
+	s.FakeConn.Subnets = nil
+	err := validateVPC(s.FakeConn, "us-east1", "juju-network")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vpcSuite) TestValidateVPCNoInternetRoute(c *gc.C) {
+	s.FakeConn.Routes_[0].NextHopGateway = ""
+	s.FakeConn.Routes_[0].NextHopIp = "10.0.0.2"
+	err := validateVPC(s.FakeConn, "us-east1", "juju-network")
+	c.Assert(err, gc.ErrorMatches, `VPC network "juju-network" has no default route to the internet gateway`)
+	c.Assert(err, jc.Satisfies, isVPCNotRecommendedError)
+}
+
+func (s *vpcSuite) TestValidateBootstrapVPCNotRecommended(c *gc.C) {
+	s.FakeConn.Routes_ = nil
+	ctx := envtesting.BootstrapContext(c)
+	err := validateBootstrapVPC(s.FakeConn, "us-east1", "juju-network", false, ctx)
+	c.Assert(err, gc.ErrorMatches, `(?s)The given vpc-id does not meet .*vpc-id=juju-network --config vpc-id-force=true.*`)
+
+	err = validateBootstrapVPC(s.FakeConn, "us-east1", "juju-network", true, ctx)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vpcSuite) TestValidateModelVPCNotRecommended(c *gc.C) {
+	s.FakeConn.Routes_ = nil
+	err := validateModelVPC(s.FakeConn, "us-east1", "model", "juju-network")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *vpcSuite) TestValidateModelVPCNotUsable(c *gc.C) {
+	err := validateModelVPC(s.FakeConn, "us-east1", "model", "missing")
+	c.Assert(err, gc.ErrorMatches, `(?s)Juju cannot use the given vpc-id for the model being added.*not found`)
+}
+
+func (s *vpcSuite) TestCreateValidatesVPC(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"vpc-id": "missing"})
+	err := s.Env.Create(environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `(?s)Juju cannot use the given vpc-id.*`)
+}
+
+func (s *vpcSuite) TestVPCNetworkSpecCustomMode(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"vpc-id": "juju-network"})
+	spec, err := s.Env.vpcNetworkSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Name, gc.Equals, "juju-network")
+	c.Assert(spec.Subnetwork, gc.Equals, testSubnetURL+"a-subnet")
+}
+
+func (s *vpcSuite) TestVPCNetworkSpecDefaultNetwork(c *gc.C) {
+	spec, err := s.Env.vpcNetworkSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Name, gc.Equals, "")
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 0)
+}
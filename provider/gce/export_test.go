@@ -4,17 +4,21 @@
 package gce
 
 import (
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/gce/google"
+	"github.com/juju/juju/watcher"
 )
 
 var (
 	Provider                 environs.EnvironProvider = providerInstance
 	NewInstance                                       = newInstance
-	CheckInstanceType                                 = checkInstanceType
+	CustomInstanceType                                = customInstanceType
 	GetMetadata                                       = getMetadata
 	GetDisks                                          = getDisks
 	UbuntuImageBasePath                               = ubuntuImageBasePath
@@ -22,6 +26,10 @@ var (
 	WindowsImageBasePath                              = windowsImageBasePath
 )
 
+var InstanceChangesPollInterval = instanceChangesPollInterval
+
+var MetadataURL = &metadataURL
+
 func ExposeInstBase(inst instance.Instance) *google.Instance {
 	return inst.(*environInstance).base
 }
@@ -34,6 +42,10 @@ func ExposeEnvConfig(env *environ) *environConfig {
 	return env.ecfg
 }
 
+func NewInstanceChangesWatcher(env *environ, clock clock.Clock) (watcher.StringsWatcher, error) {
+	return newInstanceChangesWatcher(env.gce, env.namespace.Prefix(), clock)
+}
+
 func ExposeEnvConnection(env *environ) gceConnection {
 	return env.gce
 }
@@ -58,6 +70,16 @@ func FindInstanceSpec(
 	return env.findInstanceSpec(ic, imageMetadata)
 }
 
+func CheckInstanceType(env *environ, cons constraints.Value) bool {
+	return env.checkInstanceType(cons)
+}
+
+// RefreshInstanceTypesCache empties the machine types cache, which
+// BaseSuite seeds with the built-in machine types.
+func RefreshInstanceTypesCache() {
+	instanceTypesCache.Refresh()
+}
+
 func BuildInstanceSpec(env *environ, args environs.StartInstanceParams) (*instances.InstanceSpec, error) {
 	return env.buildInstanceSpec(args)
 }
@@ -189,6 +189,10 @@ type ConnectionConfig struct {
 	// ProjectID is the project ID to use in all GCE API requests for
 	// the connection.
 	ProjectID string
+
+	// Network is the name of the network in which firewall rules are
+	// created. If it is empty, the project's default network is used.
+	Network string
 }
 
 // Validate checks the connection's fields for invalid values.
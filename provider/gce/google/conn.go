@@ -26,6 +26,11 @@ type rawConnectionWrapper interface {
 	// the specified statuses (if any).
 	ListInstances(projectID, prefix string, status ...string) ([]*compute.Instance, error)
 
+	// ListInstanceOperations sends a request to the GCE API for a list
+	// of the operations, in all zones of the project, which target an
+	// instance whose name starts with the provided prefix.
+	ListInstanceOperations(projectID, prefix string) ([]*compute.Operation, error)
+
 	// AddInstance sends a request to GCE to add a new instance to the
 	// given project, with the provided instance data. The call blocks
 	// until the instance is created or the request fails.
@@ -104,6 +109,9 @@ type rawConnectionWrapper interface {
 
 	// ListNetworks returns a list of Networks available in the given project.
 	ListNetworks(projectID string) ([]*compute.Network, error)
+
+	// ListRoutes returns a list of Routes defined in the given project.
+	ListRoutes(projectID string) ([]*compute.Route, error)
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -120,6 +128,7 @@ type Connection struct {
 	raw       rawConnectionWrapper
 	region    string
 	projectID string
+	network   string
 }
 
 // Connect authenticates using the provided credentials and opens a
@@ -137,6 +146,7 @@ func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error)
 		raw:       &rawConn{raw},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
+		network:   connCfg.Network,
 	}
 	return conn, nil
 }
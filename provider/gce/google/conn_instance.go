@@ -5,6 +5,7 @@ package google
 
 import (
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
@@ -84,6 +85,47 @@ func (gce *Connection) Instances(prefix string, statuses ...string) ([]Instance,
 	return insts, nil
 }
 
+// ChangedInstances sends a request to the GCE API for the operations
+// on instances (in the Connection's project) for which the name starts
+// with the provided prefix, and returns the IDs of the instances whose
+// operations completed after the given time. The completion time of
+// the most recent operation is also returned, for use in the next call;
+// it is the given time if there are no newer operations.
+func (gce *Connection) ChangedInstances(prefix string, since time.Time) ([]string, time.Time, error) {
+	ops, err := gce.raw.ListInstanceOperations(gce.projectID, prefix)
+	if err != nil {
+		return nil, since, errors.Trace(err)
+	}
+
+	latest := since
+	var ids []string
+	seen := make(map[string]bool)
+	for _, op := range ops {
+		if op.Status != StatusDone {
+			// The change will be reported once the operation
+			// completes.
+			continue
+		}
+		endTime, err := time.Parse(time.RFC3339, op.EndTime)
+		if err != nil {
+			logger.Debugf("ignoring operation %q with invalid end time %q", op.Name, op.EndTime)
+			continue
+		}
+		if !endTime.After(since) {
+			continue
+		}
+		if endTime.After(latest) {
+			latest = endTime
+		}
+		id := path.Base(op.TargetLink)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, latest, nil
+}
+
 // removeInstance sends a request to the GCE API to remove the instance
 // with the provided ID (in the specified zone). The call blocks until
 // the instance is removed (or the request fails).
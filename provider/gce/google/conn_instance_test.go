@@ -4,6 +4,8 @@
 package google_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
@@ -194,6 +196,47 @@ func (s *connSuite) TestConnectionInstancesFailure(c *gc.C) {
 	c.Check(errors.Cause(err), gc.Equals, failure)
 }
 
+func (s *connSuite) TestConnectionChangedInstances(c *gc.C) {
+	s.FakeConn.Operations = []*compute.Operation{{
+		Name:       "op-1",
+		Status:     google.StatusDone,
+		EndTime:    "2017-10-01T10:00:00Z",
+		TargetLink: "https://www.googleapis.com/compute/v1/projects/p/zones/a-zone/instances/spam",
+	}, {
+		Name:       "op-2",
+		Status:     google.StatusDone,
+		EndTime:    "2017-10-01T10:05:00Z",
+		TargetLink: "https://www.googleapis.com/compute/v1/projects/p/zones/a-zone/instances/eggs",
+	}, {
+		Name:       "op-3",
+		Status:     "RUNNING",
+		TargetLink: "https://www.googleapis.com/compute/v1/projects/p/zones/a-zone/instances/ham",
+	}, {
+		Name:       "op-4",
+		Status:     google.StatusDone,
+		EndTime:    "2017-10-01T10:10:00Z",
+		TargetLink: "https://www.googleapis.com/compute/v1/projects/p/zones/a-zone/instances/eggs",
+	}}
+
+	since := time.Date(2017, 10, 1, 10, 0, 0, 0, time.UTC)
+	ids, latest, err := s.Conn.ChangedInstances("sp", since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []string{"eggs"})
+	c.Check(latest, gc.Equals, time.Date(2017, 10, 1, 10, 10, 0, 0, time.UTC))
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstanceOperations")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "sp")
+}
+
+func (s *connSuite) TestConnectionChangedInstancesFailure(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+	_, _, err := s.Conn.ChangedInstances("sp", time.Time{})
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
+
 func (s *connSuite) TestConnectionRemoveInstance(c *gc.C) {
 	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
 
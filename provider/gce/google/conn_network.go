@@ -101,7 +101,7 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 				return errors.Trace(err)
 			}
 			allNames.Add(name)
-			spec := firewallSpec(name, target, inputFirewall.SourceCIDRs, inputFirewall.AllowedPorts)
+			spec := gce.firewallSpec(name, target, inputFirewall.SourceCIDRs, inputFirewall.AllowedPorts)
 			if err := gce.raw.AddFirewall(gce.projectID, spec); err != nil {
 				return errors.Annotatef(err, "opening port(s) %+v", rules)
 			}
@@ -120,7 +120,7 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 		combinedCIDRs := cidrs.Union(set.NewStrings(inputFirewall.SourceCIDRs...)).SortedValues()
 
 		// Copy new firewall details into required firewall spec.
-		spec := firewallSpec(existingFirewall.Name, target, combinedCIDRs, allowedPorts)
+		spec := gce.firewallSpec(existingFirewall.Name, target, combinedCIDRs, allowedPorts)
 		if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", rules)
 		}
@@ -186,7 +186,7 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 			}
 
 			// Update the existing firewall with the remaining CIDRs.
-			spec := firewallSpec(existingFirewall.Name, target, remainingCidrs, existingFirewall.AllowedPorts)
+			spec := gce.firewallSpec(existingFirewall.Name, target, remainingCidrs, existingFirewall.AllowedPorts)
 			if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
 				return errors.Annotatef(err, "closing port(s) %+v", rules)
 			}
@@ -208,7 +208,7 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 		remainingPorts := existingFirewall.AllowedPorts.remove(inputFirewall.AllowedPorts)
 
 		// Copy new firewall details into required firewall spec.
-		spec := firewallSpec(existingFirewall.Name, target, existingFirewall.SourceCIDRs, remainingPorts)
+		spec := gce.firewallSpec(existingFirewall.Name, target, existingFirewall.SourceCIDRs, remainingPorts)
 		if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
 			return errors.Annotatef(err, "closing port(s) %+v", rules)
 		}
@@ -216,6 +216,17 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 	return nil
 }
 
+// firewallSpec returns a compute.Firewall for the provided name, in
+// the connection's network.
+func (gce Connection) firewallSpec(name, target string, sourceCIDRs []string, ports protocolPorts) *compute.Firewall {
+	spec := firewallSpec(name, target, sourceCIDRs, ports)
+	if gce.network != "" {
+		networkSpec := NetworkSpec{Name: gce.network}
+		spec.Network = networkSpec.Path()
+	}
+	return spec
+}
+
 // Subnetworks returns the subnets available in this region.
 func (gce Connection) Subnetworks(region string) ([]*compute.Subnetwork, error) {
 	results, err := gce.raw.ListSubnetworks(gce.projectID, region)
@@ -233,3 +244,12 @@ func (gce Connection) Networks() ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+// Routes returns the routes defined for all networks.
+func (gce Connection) Routes() ([]*compute.Route, error) {
+	results, err := gce.raw.ListRoutes(gce.projectID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
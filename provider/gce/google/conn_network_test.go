@@ -170,6 +170,19 @@ func (s *connSuite) TestConnectionOpenPortsAdd(c *gc.C) {
 	})
 }
 
+func (s *connSuite) TestConnectionOpenPortsNetwork(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+	google.SetConnNetwork(s.Conn, "juju-network")
+
+	rule := network.MustNewIngressRule("tcp", 80, 81, "10.0.0.0/24")
+	err := s.Conn.OpenPortsWithNamer("spam", google.HashSuffixNamer, rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall.Network, gc.Equals, "global/networks/juju-network")
+}
+
 func (s *connSuite) TestConnectionOpenPortsUpdateSameCIDR(c *gc.C) {
 	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam-ad7554",
@@ -478,3 +491,18 @@ func (s *connSuite) TestRandomSuffixNamer(c *gc.C) {
 	}
 	c.Assert(i, gc.Equals, 2)
 }
+
+func (s *connSuite) TestRoutes(c *gc.C) {
+	s.FakeConn.Routes = []*compute.Route{{
+		Name:      "default-route",
+		DestRange: "0.0.0.0/0",
+	}}
+	results, err := s.Conn.Routes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert((*results[0]).Name, gc.Equals, "default-route")
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListRoutes")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+}
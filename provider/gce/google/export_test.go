@@ -24,6 +24,10 @@ func SetRawConn(conn *Connection, raw rawConnectionWrapper) {
 	conn.raw = raw
 }
 
+func SetConnNetwork(conn *Connection, network string) {
+	conn.network = network
+}
+
 func ExposeRawService(conn *Connection) *compute.Service {
 	return conn.raw.(*rawConn).Service
 }
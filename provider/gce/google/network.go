@@ -26,6 +26,10 @@ const (
 type NetworkSpec struct {
 	// Name is the unqualified name of the network.
 	Name string
+	// Subnetwork is the URL of the subnetwork that interfaces are
+	// connected to. It must be set for networks in custom subnet
+	// mode, and is otherwise optional.
+	Subnetwork string
 	// TODO(ericsnow) support a CIDR for internal IP addr range?
 }
 
@@ -53,6 +57,7 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 	}
 	return &compute.NetworkInterface{
 		Network:       ns.Path(),
+		Subnetwork:    ns.Subnetwork,
 		AccessConfigs: access,
 	}
 }
@@ -29,6 +29,19 @@ func (s *networkSuite) TestNetworkSpecPath(c *gc.C) {
 	c.Check(path, gc.Equals, "global/networks/spam")
 }
 
+func (s *networkSuite) TestNetworkSpecNewInterfaceSubnetwork(c *gc.C) {
+	spec := google.NetworkSpec{
+		Name:       "spam",
+		Subnetwork: "regions/us-east1/subnetworks/eggs",
+	}
+	netIF := google.NewNetInterface(spec, "")
+
+	c.Check(netIF, gc.DeepEquals, &compute.NetworkInterface{
+		Network:    "global/networks/spam",
+		Subnetwork: "regions/us-east1/subnetworks/eggs",
+	})
+}
+
 func (s *networkSuite) TestNetworkSpecNewInterface(c *gc.C) {
 	spec := google.NetworkSpec{
 		Name: "spam",
@@ -81,6 +81,28 @@ func (rc *rawConn) ListInstances(projectID, prefix string, statuses ...string) (
 	return results, nil
 }
 
+func (rc *rawConn) ListInstanceOperations(projectID, prefix string) ([]*compute.Operation, error) {
+	call := rc.GlobalOperations.AggregatedList(projectID)
+	call = call.Filter("targetLink eq .*/instances/" + prefix + ".*")
+
+	var results []*compute.Operation
+	for {
+		rawResult, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		for _, opList := range rawResult.Items {
+			results = append(results, opList.Operations...)
+		}
+		if rawResult.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(rawResult.NextPageToken)
+	}
+	return results, nil
+}
+
 func checkInstStatus(inst *compute.Instance, statuses []string) bool {
 	if len(statuses) == 0 {
 		return true
@@ -421,3 +443,17 @@ func (rc *rawConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+func (rc *rawConn) ListRoutes(projectID string) ([]*compute.Route, error) {
+	ctx := context.Background()
+	call := rc.Routes.List(projectID)
+	var results []*compute.Route
+	err := call.Pages(ctx, func(page *compute.RouteList) error {
+		results = append(results, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
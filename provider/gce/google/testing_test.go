@@ -178,6 +178,7 @@ type fakeConn struct {
 	Project       *compute.Project
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
+	Operations    []*compute.Operation
 	Firewalls     []*compute.Firewall
 	Zones         []*compute.Zone
 	Err           error
@@ -187,6 +188,7 @@ type fakeConn struct {
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
+	Routes        []*compute.Route
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -235,6 +237,21 @@ func (rc *fakeConn) ListInstances(projectID, prefix string, statuses ...string)
 	return rc.Instances, err
 }
 
+func (rc *fakeConn) ListInstanceOperations(projectID, prefix string) ([]*compute.Operation, error) {
+	call := fakeCall{
+		FuncName:  "ListInstanceOperations",
+		ProjectID: projectID,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Operations, err
+}
+
 func (rc *fakeConn) AddInstance(projectID, zoneName string, spec *compute.Instance) error {
 	call := fakeCall{
 		FuncName:  "AddInstance",
@@ -542,3 +559,20 @@ func (rc *fakeConn) ListSubnetworks(projectID, region string) ([]*compute.Subnet
 	}
 	return rc.Subnetworks, nil
 }
+
+func (rc *fakeConn) ListRoutes(projectID string) ([]*compute.Route, error) {
+	call := fakeCall{
+		FuncName:  "ListRoutes",
+		ProjectID: projectID,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.Routes, nil
+}
@@ -4,10 +4,10 @@
 package gce
 
 import (
+	"sort"
 	"strconv"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils/arch"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
@@ -15,50 +15,94 @@ import (
 )
 
 var _ environs.InstanceTypesFetcher = (*environ)(nil)
-var virtType = "kvm"
+
+// instanceTypesCache holds the machine types listed from GCE. It is a
+// variable so that tests can replace it.
+var instanceTypesCache = instances.DefaultTypesCache
 
 // InstanceTypes implements InstanceTypesFetcher
 func (env *environ) InstanceTypes(c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
-	reg, err := env.Region()
-	if err != nil {
+	result, err := instanceTypesCache.Get(env.instanceTypesCacheKey(), env.fetchInstanceTypes)
+	if err != nil && !errors.IsNotFound(err) {
 		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
 	}
-	zones, err := env.gce.AvailabilityZones(reg.Region)
+	result, err = instances.MatchingInstanceTypes(result, "", c)
 	if err != nil {
 		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
 	}
-	resultUnique := map[string]instances.InstanceType{}
+	return instances.InstanceTypesWithCostMetadata{InstanceTypes: result}, nil
+}
 
+// instanceTypes returns the machine types available in the model's
+// region. If they cannot be listed, the built-in machine types are
+// returned instead.
+func (env *environ) instanceTypes() []instances.InstanceType {
+	instanceTypes, err := instanceTypesCache.Get(env.instanceTypesCacheKey(), env.fetchInstanceTypes)
+	if err != nil {
+		logger.Warningf("cannot list machine types, using built-in machine types: %v", err)
+		return allInstanceTypes
+	}
+	return instanceTypes
+}
+
+// instanceTypesCacheKey returns the key of the model's machine types
+// in instanceTypesCache. Machine types differ between regions, and
+// may differ between projects.
+func (env *environ) instanceTypesCacheKey() string {
+	return "gce/" + env.projectID + "/" + env.cloud.Region
+}
+
+// fetchInstanceTypes lists the machine types available in any of the
+// available zones in the model's region. The CPU power of machine
+// types in the built-in list is filled in, as GCE does not report it.
+func (env *environ) fetchInstanceTypes() ([]instances.InstanceType, error) {
+	zones, err := env.gce.AvailabilityZones(env.cloud.Region)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	knownTypes := make(map[string]instances.InstanceType)
+	for _, itype := range allInstanceTypes {
+		knownTypes[itype.Name] = itype
+	}
+	resultUnique := map[string]instances.InstanceType{}
 	for _, z := range zones {
 		if !z.Available() {
 			continue
 		}
 		machines, err := env.gce.ListMachineTypes(z.Name())
 		if err != nil {
-			return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		for _, m := range machines {
 			i := instances.InstanceType{
-				Id:       strconv.FormatUint(m.Id, 10),
-				Name:     m.Name,
-				CpuCores: uint64(m.GuestCpus),
-				Mem:      uint64(m.MemoryMb),
-				Arches:   []string{arch.AMD64},
-				VirtType: &virtType,
+				Id:         strconv.FormatUint(m.Id, 10),
+				Name:       m.Name,
+				CpuCores:   uint64(m.GuestCpus),
+				Mem:        uint64(m.MemoryMb),
+				Arches:     arches,
+				VirtType:   &vtype,
+				Deprecated: m.Deprecated,
+			}
+			if known, ok := knownTypes[m.Name]; ok {
+				i.CpuPower = known.CpuPower
 			}
 			resultUnique[m.Name] = i
 		}
 	}
+	if len(resultUnique) == 0 {
+		return nil, errors.NotFoundf("machine types in region %q", env.cloud.Region)
+	}
 
-	result := make([]instances.InstanceType, len(resultUnique))
-	i := 0
+	result := make([]instances.InstanceType, 0, len(resultUnique))
 	for _, it := range resultUnique {
-		result[i] = it
-		i++
-	}
-	result, err = instances.MatchingInstanceTypes(result, "", c)
-	if err != nil {
-		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
+		result = append(result, it)
 	}
-	return instances.InstanceTypesWithCostMetadata{InstanceTypes: result}, nil
+	sort.Sort(instanceTypesByName(result))
+	return result, nil
 }
+
+type instanceTypesByName []instances.InstanceType
+
+func (s instanceTypesByName) Len() int           { return len(s) }
+func (s instanceTypesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s instanceTypesByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
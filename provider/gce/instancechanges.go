@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// instanceChangesPollInterval is how often the GCE API is asked for
+// the operations completed on the model's instances. Listing the
+// operations is a single request however many instances there are.
+var instanceChangesPollInterval = 10 * time.Second
+
+var _ environs.InstanceChangeWatcher = (*environ)(nil)
+
+// WatchInstanceChanges implements environs.InstanceChangeWatcher. GCE
+// records an operation for each change made to an instance, such as
+// starting, stopping or changing its network interfaces, so the
+// instances reported are those with recently completed operations.
+func (env *environ) WatchInstanceChanges() (watcher.StringsWatcher, error) {
+	return newInstanceChangesWatcher(env.gce, env.namespace.Prefix(), clock.WallClock)
+}
+
+// instanceChangesWatcher reports the instances on which GCE operations
+// have completed since the watcher started.
+type instanceChangesWatcher struct {
+	catacomb catacomb.Catacomb
+	conn     gceConnection
+	prefix   string
+	clock    clock.Clock
+	out      chan []string
+}
+
+func newInstanceChangesWatcher(conn gceConnection, prefix string, clock clock.Clock) (*instanceChangesWatcher, error) {
+	w := &instanceChangesWatcher{
+		conn:   conn,
+		prefix: prefix,
+		clock:  clock,
+		out:    make(chan []string),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Changes is part of the watcher.StringsWatcher interface.
+func (w *instanceChangesWatcher) Changes() watcher.StringsChannel {
+	return w.out
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *instanceChangesWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *instanceChangesWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *instanceChangesWatcher) loop() error {
+	var (
+		since    time.Time
+		baseline bool
+		changes  []string
+		out      chan<- []string
+	)
+	pending := make(map[string]bool)
+	poll := func() {
+		ids, latest, err := w.conn.ChangedInstances(w.prefix, since)
+		if err != nil {
+			// Polling instances is the fallback, so failing to
+			// list operations is not fatal.
+			logger.Warningf("cannot list instance operations: %v", err)
+			return
+		}
+		since = latest
+		if !baseline {
+			// The operations completed before the watcher
+			// started are not changes.
+			baseline = true
+			return
+		}
+		for _, id := range ids {
+			if !pending[id] {
+				pending[id] = true
+				changes = append(changes, id)
+			}
+		}
+		if len(changes) > 0 {
+			out = w.out
+		}
+	}
+	poll()
+	timer := w.clock.After(instanceChangesPollInterval)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer:
+			poll()
+			timer = w.clock.After(instanceChangesPollInterval)
+		case out <- changes:
+			changes = nil
+			pending = make(map[string]bool)
+			out = nil
+		}
+	}
+}
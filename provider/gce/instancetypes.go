@@ -4,8 +4,11 @@
 package gce
 
 import (
+	"fmt"
+
 	"github.com/juju/utils/arch"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/instances"
 )
 
@@ -17,9 +20,8 @@ var (
 // Instance types are not associated with disks in GCE, so we do not
 // set RootDisk.
 
-// TODO(axw) 2016-10-03 #1629821
-// Query the machine types dynamically, to avoid hard-coding this
-// information (if possible), or else to augment it.
+// allInstanceTypes holds the machine types used if they cannot be
+// listed from GCE, and the CPU power of the listed machine types.
 var allInstanceTypes = []instances.InstanceType{
 	{ // Standard machine types
 		Name:     "n1-standard-1",
@@ -155,3 +157,58 @@ var allInstanceTypes = []instances.InstanceType{
 		VirtType: &vtype,
 	},
 }
+
+// Limits on the shape of GCE custom machine types. Memory is in MiB.
+const (
+	customMaxCores        = 96
+	customMemIncrement    = 256
+	customMinMemPerCore   = 922  // 0.9GiB
+	customMaxMemPerCore   = 6656 // 6.5GiB
+	customCpuPowerPerCore = 275
+)
+
+// customInstanceType returns a GCE custom machine type shaped to the
+// cores and mem constraints, if either is specified. The machine type
+// is named custom-<cores>-<mem>, as GCE expects. False is returned if
+// the constraints do not call for a custom machine type or cannot be
+// satisfied by one.
+func customInstanceType(cons constraints.Value) (instances.InstanceType, bool) {
+	if cons.HasInstanceType() || (cons.CpuCores == nil && cons.Mem == nil) {
+		return instances.InstanceType{}, false
+	}
+	cores := uint64(1)
+	if cons.CpuCores != nil && *cons.CpuCores > cores {
+		cores = *cons.CpuCores
+	}
+	var mem uint64
+	if cons.Mem != nil {
+		mem = roundUp(*cons.Mem, customMemIncrement)
+	}
+	// Add cores until the memory fits, and then make the count
+	// even, as custom machine types require beyond a single core.
+	if minCores := roundUp(mem, customMaxMemPerCore) / customMaxMemPerCore; minCores > cores {
+		cores = minCores
+	}
+	if cores > 1 && cores%2 != 0 {
+		cores++
+	}
+	if cores > customMaxCores {
+		return instances.InstanceType{}, false
+	}
+	if minMem := roundUp(cores*customMinMemPerCore, customMemIncrement); mem < minMem {
+		mem = minMem
+	}
+	return instances.InstanceType{
+		Name:     fmt.Sprintf("custom-%d-%d", cores, mem),
+		Arches:   arches,
+		CpuCores: cores,
+		CpuPower: instances.CpuPower(cores * customCpuPowerPerCore),
+		Mem:      mem,
+		VirtType: &vtype,
+	}, true
+}
+
+// roundUp returns n rounded up to the next multiple of m.
+func roundUp(n, m uint64) uint64 {
+	return (n + m - 1) / m * m
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/gce"
+)
+
+type instanceTypesSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&instanceTypesSuite{})
+
+func (s *instanceTypesSuite) TestCustomInstanceType(c *gc.C) {
+	for i, test := range []struct {
+		cons     string
+		name     string
+		cores    uint64
+		mem      uint64
+		notFound bool
+	}{
+		{cons: "", notFound: true},
+		{cons: "instance-type=n1-standard-1 cores=2", notFound: true},
+		{cons: "cores=1", name: "custom-1-1024", cores: 1, mem: 1024},
+		{cons: "cores=3", name: "custom-4-3840", cores: 4, mem: 3840},
+		{cons: "mem=4000M", name: "custom-1-4096", cores: 1, mem: 4096},
+		{cons: "mem=20G", name: "custom-4-20480", cores: 4, mem: 20480},
+		{cons: "cores=8 mem=2G", name: "custom-8-7424", cores: 8, mem: 7424},
+		{cons: "cores=128", notFound: true},
+	} {
+		c.Logf("test %d: %q", i, test.cons)
+		itype, ok := gce.CustomInstanceType(constraints.MustParse(test.cons))
+		if test.notFound {
+			c.Check(ok, jc.IsFalse)
+			continue
+		}
+		c.Assert(ok, jc.IsTrue)
+		c.Check(itype.Name, gc.Equals, test.name)
+		c.Check(itype.CpuCores, gc.Equals, test.cores)
+		c.Check(itype.Mem, gc.Equals, test.mem)
+	}
+}
@@ -6,10 +6,12 @@ package gce
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
@@ -329,6 +331,15 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.PatchValue(&newRawInstance, s.FakeEnviron.NewRawInstance)
 	s.PatchValue(&findInstanceSpec, s.FakeEnviron.FindInstanceSpec)
 	s.PatchValue(&getInstances, s.FakeEnviron.GetInstances)
+
+	// Seed the machine types cache with the built-in machine types,
+	// so that tests only list them from the fake connection if they
+	// refresh the cache.
+	s.PatchValue(&instanceTypesCache, instances.NewTypesCache(clock.WallClock, instances.DefaultTypesCacheTTL))
+	_, err := instanceTypesCache.Get(s.Env.instanceTypesCacheKey(), func() ([]instances.InstanceType, error) {
+		return allInstanceTypes, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *BaseSuite) CheckNoAPI(c *gc.C) {
@@ -496,6 +507,11 @@ type fakeConn struct {
 	Zones     []google.AvailabilityZone
 	Subnets   []*compute.Subnetwork
 	Networks_ []*compute.Network
+	Routes_   []*compute.Route
+
+	// ChangedIDs holds the ids returned by successive calls
+	// to ChangedInstances.
+	ChangedIDs [][]string
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
@@ -538,6 +554,18 @@ func (fc *fakeConn) Instances(prefix string, statuses ...string) ([]google.Insta
 	return fc.Insts, fc.err()
 }
 
+func (fc *fakeConn) ChangedInstances(prefix string, since time.Time) ([]string, time.Time, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ChangedInstances",
+		Prefix:   prefix,
+	})
+	var ids []string
+	if len(fc.ChangedIDs) > 0 {
+		ids, fc.ChangedIDs = fc.ChangedIDs[0], fc.ChangedIDs[1:]
+	}
+	return ids, since.Add(time.Second), fc.err()
+}
+
 func (fc *fakeConn) AddInstance(spec google.InstanceSpec) (*google.Instance, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "AddInstance",
@@ -614,6 +642,13 @@ func (fc *fakeConn) Networks() ([]*compute.Network, error) {
 	return fc.Networks_, fc.err()
 }
 
+func (fc *fakeConn) Routes() ([]*compute.Route, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "Routes",
+	})
+	return fc.Routes_, fc.err()
+}
+
 func (fc *fakeConn) CreateDisks(zone string, disks []google.DiskSpec) ([]*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "CreateDisks",
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"google.golang.org/api/googleapi"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+// The GCE API families throttled separately. Instance polling and
+// the firewaller make the most frequent calls.
+const (
+	apiFamilyInstances = "instances"
+	apiFamilyFirewalls = "firewalls"
+)
+
+// throttledConnection is a gceConnection that throttles the calls
+// made by instance polling and the firewaller.
+type throttledConnection struct {
+	gceConnection
+	throttle *common.Throttle
+}
+
+// newThrottledConnection returns a gceConnection that throttles
+// calls made with the given connection.
+func newThrottledConnection(conn gceConnection, clock clock.Clock) (gceConnection, error) {
+	config := common.DefaultThrottleConfig()
+	config.Clock = clock
+	config.Families = map[string]common.ThrottleRate{
+		apiFamilyInstances: {Rate: 20, Burst: 50},
+		apiFamilyFirewalls: {Rate: 5, Burst: 20},
+	}
+	config.IsRateLimited = isRateLimitedError
+	throttle, err := common.NewThrottle(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &throttledConnection{conn, throttle}, nil
+}
+
+// isRateLimitedError reports whether the error is from a GCE API call
+// that was rejected for exceeding the project's rate limits.
+func isRateLimitedError(err error) bool {
+	apiErr, ok := errors.Cause(err).(*googleapi.Error)
+	if !ok {
+		return false
+	}
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	if apiErr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded":
+			return true
+		}
+	}
+	return false
+}
+
+// Instances is part of the gceConnection interface.
+func (c *throttledConnection) Instances(prefix string, statuses ...string) (result []google.Instance, err error) {
+	err = c.throttle.Call(apiFamilyInstances, func() error {
+		result, err = c.gceConnection.Instances(prefix, statuses...)
+		return err
+	})
+	return result, err
+}
+
+// ChangedInstances is part of the gceConnection interface.
+func (c *throttledConnection) ChangedInstances(prefix string, since time.Time) (ids []string, latest time.Time, err error) {
+	err = c.throttle.Call(apiFamilyInstances, func() error {
+		ids, latest, err = c.gceConnection.ChangedInstances(prefix, since)
+		return err
+	})
+	return ids, latest, err
+}
+
+// IngressRules is part of the gceConnection interface.
+func (c *throttledConnection) IngressRules(fwname string) (rules []network.IngressRule, err error) {
+	err = c.throttle.Call(apiFamilyFirewalls, func() error {
+		rules, err = c.gceConnection.IngressRules(fwname)
+		return err
+	})
+	return rules, err
+}
+
+// OpenPorts is part of the gceConnection interface.
+func (c *throttledConnection) OpenPorts(fwname string, rules ...network.IngressRule) error {
+	return c.throttle.Call(apiFamilyFirewalls, func() error {
+		return c.gceConnection.OpenPorts(fwname, rules...)
+	})
+}
+
+// ClosePorts is part of the gceConnection interface.
+func (c *throttledConnection) ClosePorts(fwname string, rules ...network.IngressRule) error {
+	return c.throttle.Call(apiFamilyFirewalls, func() error {
+		return c.gceConnection.ClosePorts(fwname, rules...)
+	})
+}
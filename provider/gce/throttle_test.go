@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/googleapi"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+type throttleSuite struct {
+	testing.IsolationSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&throttleSuite{})
+
+func (s *throttleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+}
+
+func (s *throttleSuite) newConnection(c *gc.C, fake *fakeConn) gceConnection {
+	conn, err := newThrottledConnection(fake, autoAdvancingClock{s.clock})
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+func (s *throttleSuite) TestInstancesRetriesTooManyRequests(c *gc.C) {
+	fake := &fakeConn{
+		Insts: []google.Instance{{}},
+		Err:   &googleapi.Error{Code: http.StatusTooManyRequests},
+	}
+	insts, err := s.newConnection(c, fake).Instances("juju-", "RUNNING")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 1)
+	c.Assert(fake.Calls, gc.HasLen, 2)
+	c.Assert(s.clock.Now(), gc.Equals, time.Time{}.Add(time.Second))
+}
+
+func (s *throttleSuite) TestOpenPortsRetriesRateLimitExceeded(c *gc.C) {
+	fake := &fakeConn{
+		Err: &googleapi.Error{
+			Code:   http.StatusForbidden,
+			Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
+		},
+	}
+	rule := network.MustNewIngressRule("tcp", 80, 80)
+	err := s.newConnection(c, fake).OpenPorts("juju-fw", rule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.Calls, gc.HasLen, 2)
+}
+
+func (s *throttleSuite) TestForbiddenNotRetried(c *gc.C) {
+	fake := &fakeConn{
+		Err: &googleapi.Error{
+			Code:   http.StatusForbidden,
+			Errors: []googleapi.ErrorItem{{Reason: "forbidden"}},
+		},
+	}
+	err := s.newConnection(c, fake).ClosePorts("juju-fw")
+	c.Assert(err, gc.Equals, fake.Err)
+	c.Assert(fake.Calls, gc.HasLen, 1)
+}
+
+// autoAdvancingClock is a testing clock that advances
+// immediately when waited on.
+type autoAdvancingClock struct {
+	*testing.Clock
+}
+
+func (c autoAdvancingClock) After(d time.Duration) <-chan time.Time {
+	ch := c.Clock.After(d)
+	c.Advance(d)
+	return ch
+}
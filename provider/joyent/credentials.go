@@ -4,7 +4,13 @@
 package joyent
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
@@ -47,8 +53,41 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials interface.
+// Credentials are read from the SDC_ACCOUNT, SDC_KEY_ID and SDC_KEY_FILE
+// environment variables used by the SmartDataCenter command line tools.
+// If SDC_KEY_FILE is not set, $HOME/.ssh/id_rsa is used.
 func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
-	return nil, errors.NotFoundf("credentials")
+	user := os.Getenv("SDC_ACCOUNT")
+	keyID := os.Getenv("SDC_KEY_ID")
+	if user == "" || keyID == "" {
+		return nil, errors.NotFoundf("credentials")
+	}
+	keyFile := os.Getenv("SDC_KEY_FILE")
+	if keyFile == "" {
+		keyFile = filepath.Join(utils.Home(), ".ssh", "id_rsa")
+	}
+	privateKey, err := ioutil.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("private key file %q", keyFile)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "reading private key")
+	}
+	credential := cloud.NewCredential(
+		cloud.UserPassAuthType,
+		map[string]string{
+			credAttrSDCUser:    user,
+			credAttrSDCKeyID:   keyID,
+			credAttrPrivateKey: string(privateKey),
+			credAttrAlgorithm:  algorithmDefault,
+		},
+	)
+	credential.Label = fmt.Sprintf("joyent credential %q", user)
+	return &cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			user: credential,
+		},
+	}, nil
 }
 
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
@@ -4,11 +4,15 @@
 package joyent_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	envtesting "github.com/juju/juju/environs/testing"
 )
@@ -50,3 +54,34 @@ func (s *credentialsSuite) TestDetectCredentialsNotFound(c *gc.C) {
 	_, err := s.provider.DetectCredentials()
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *credentialsSuite) TestDetectCredentialsEnvironmentVariables(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "id_rsa")
+	err := ioutil.WriteFile(keyFile, []byte("private-key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvironment("SDC_ACCOUNT", "bob")
+	s.PatchEnvironment("SDC_KEY_ID", "key-id")
+	s.PatchEnvironment("SDC_KEY_FILE", keyFile)
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{
+			"sdc-user":    "bob",
+			"sdc-key-id":  "key-id",
+			"private-key": "private-key",
+			"algorithm":   "rsa-sha256",
+		},
+	)
+	expected.Label = `joyent credential "bob"`
+	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
+}
+
+func (s *credentialsSuite) TestDetectCredentialsMissingKeyFile(c *gc.C) {
+	s.PatchEnvironment("SDC_ACCOUNT", "bob")
+	s.PatchEnvironment("SDC_KEY_ID", "key-id")
+	s.PatchEnvironment("SDC_KEY_FILE", filepath.Join(c.MkDir(), "id_rsa"))
+
+	_, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -48,6 +48,9 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
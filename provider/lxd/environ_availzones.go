@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+var _ common.ZonedEnviron = (*environ)(nil)
+
+// lxdAvailabilityZone is a member of an LXD cluster. Each cluster
+// member is treated as an availability zone.
+type lxdAvailabilityZone struct {
+	member lxdclient.ClusterMember
+}
+
+// Name is part of the common.AvailabilityZone interface.
+func (z lxdAvailabilityZone) Name() string {
+	return z.member.Name
+}
+
+// Available is part of the common.AvailabilityZone interface.
+func (z lxdAvailabilityZone) Available() bool {
+	return z.member.Status == lxdclient.ClusterMemberOnline
+}
+
+// clusterMembers returns the members of the LXD cluster. If the LXD
+// server is not clustered, an error satisfying errors.IsNotImplemented
+// is returned.
+func (env *environ) clusterMembers() ([]lxdclient.ClusterMember, error) {
+	members, err := env.raw.ClusterMembers()
+	if err != nil && !errors.IsNotSupported(err) {
+		return nil, errors.Trace(err)
+	}
+	if len(members) == 0 {
+		return nil, errors.NotImplementedf("availability zones on a non-clustered LXD server")
+	}
+	return members, nil
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	members, err := env.clusterMembers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zones := make([]common.AvailabilityZone, len(members))
+	for i, member := range members {
+		zones[i] = lxdAvailabilityZone{member}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron
+// interface.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	if _, err := env.clusterMembers(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	locations, err := env.raw.InstanceLocations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var found int
+	zones := make([]string, len(ids))
+	for i, id := range ids {
+		location, ok := locations[string(id)]
+		if !ok {
+			continue
+		}
+		zones[i] = location
+		found++
+	}
+	if found == 0 {
+		return nil, environs.ErrNoInstances
+	} else if found < len(ids) {
+		return zones, environs.ErrPartialInstances
+	}
+	return zones, nil
+}
+
+// DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) DeriveAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if placement.nodeName == "" {
+		return nil, nil
+	}
+	if err := env.checkClusterMember(args.Placement, placement.nodeName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []string{placement.nodeName}, nil
+}
+
+// checkClusterMember returns an error if the named cluster member,
+// specified in the given placement directive, does not exist.
+func (env *environ) checkClusterMember(placement, name string) error {
+	members, err := env.clusterMembers()
+	if errors.IsNotImplemented(err) {
+		return errors.Errorf("unknown placement directive: %v", placement)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, member := range members {
+		if member.Name == name {
+			return nil
+		}
+	}
+	return errors.NotFoundf("cluster member %q", name)
+}
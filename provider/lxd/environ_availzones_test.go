@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/series"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type environAvailzonesSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) setCluster() {
+	s.Client.ClusterMembersList = []lxdclient.ClusterMember{{
+		Name:   "node1",
+		Status: lxdclient.ClusterMemberOnline,
+	}, {
+		Name:   "node2",
+		Status: "Offline",
+	}}
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	s.setCluster()
+	c.Assert(s.Env, gc.Implements, new(common.ZonedEnviron))
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+
+	zones, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "node1")
+	c.Assert(zones[0].Available(), jc.IsTrue)
+	c.Assert(zones[1].Name(), gc.Equals, "node2")
+	c.Assert(zones[1].Available(), jc.IsFalse)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesNotClustered(c *gc.C) {
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+	_, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesClusteringNotSupported(c *gc.C) {
+	s.Stub.SetErrors(errors.NotSupportedf("clustering API on this remote"))
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+	_, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	s.setCluster()
+	s.Client.Locations = map[string]string{
+		"juju-0": "node1",
+		"juju-1": "node2",
+	}
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+
+	zones, err := zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"juju-1", "juju-0", "juju-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(zones, jc.DeepEquals, []string{"node2", "node1", ""})
+
+	_, err = zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"juju-2"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZones(c *gc.C) {
+	s.setCluster()
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+
+	for _, placement := range []string{"node2", "zone=node2"} {
+		zones, err := zonedEnviron.DeriveAvailabilityZones(environs.StartInstanceParams{
+			Placement: placement,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(zones, jc.DeepEquals, []string{"node2"})
+	}
+
+	zones, err := zonedEnviron.DeriveAvailabilityZones(environs.StartInstanceParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 0)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZonesUnknownMember(c *gc.C) {
+	s.setCluster()
+	zonedEnviron := s.Env.(common.ZonedEnviron)
+
+	_, err := zonedEnviron.DeriveAvailabilityZones(environs.StartInstanceParams{
+		Placement: "node3",
+	})
+	c.Assert(err, gc.ErrorMatches, `cluster member "node3" not found`)
+}
+
+func (s *environAvailzonesSuite) TestPrecheckInstanceClusterMember(c *gc.C) {
+	s.setCluster()
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:    series.LatestLts(),
+		Placement: "zone=node1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:    series.LatestLts(),
+		Placement: "node=node1",
+	})
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: node=node1`)
+}
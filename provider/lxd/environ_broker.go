@@ -41,7 +41,19 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 
 	// TODO(ericsnow) Handle constraints?
 
-	raw, err := env.newRawInstance(args, arch)
+	// If the LXD server is clustered, the availability zone is the
+	// cluster member on which to create the container. A cluster
+	// member named in the placement directive takes precedence.
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target := args.AvailabilityZone
+	if placement.nodeName != "" {
+		target = placement.nodeName
+	}
+
+	raw, err := env.newRawInstance(args, arch, target)
 	if err != nil {
 		if args.StatusCallback != nil {
 			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
@@ -53,6 +65,12 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 
 	// Build the result.
 	hwc := env.getHardwareCharacteristics(args, inst)
+	if target == "" {
+		target = env.instanceLocation(raw.Name)
+	}
+	if target != "" {
+		hwc.AvailabilityZone = &target
+	}
 	result := environs.StartInstanceResult{
 		Instance: inst,
 		Hardware: hwc,
@@ -123,6 +141,7 @@ func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 func (env *environ) newRawInstance(
 	args environs.StartInstanceParams,
 	arch string,
+	target string,
 ) (*lxdclient.Instance, error) {
 	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
 	if err != nil {
@@ -165,7 +184,12 @@ func (env *environ) newRawInstance(
 		statusCallback(status.Allocating, copyProgress)
 	}
 	series := args.InstanceConfig.Series
-	image, err := env.raw.EnsureImageExists(series, arch, imageSources, imageCallback)
+	instType := lxdclient.ConstraintsInstanceType(args.Constraints)
+	ensureImageExists := env.raw.EnsureImageExists
+	if instType == lxdclient.InstanceTypeVirtualMachine {
+		ensureImageExists = env.raw.EnsureVirtualMachineImageExists
+	}
+	image, err := ensureImageExists(series, arch, imageSources, imageCallback)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -181,14 +205,19 @@ func (env *environ) newRawInstance(
 		return nil, errors.Trace(err)
 	}
 
+	charmProfiles, err := env.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot write charm lxd profiles")
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
 	// TODO(ericsnow) Use a different net interface name? Configurable?
 	instSpec := lxdclient.InstanceSpec{
 		Name:  hostname,
+		Type:  instType,
 		Image: image,
-		//Type:              spec.InstanceType.Name,
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
@@ -201,7 +230,9 @@ func (env *environ) newRawInstance(
 			env.profileName(),
 		},
 		// Network is omitted (left empty).
+		Target: target,
 	}
+	instSpec.Profiles = append(instSpec.Profiles, charmProfiles...)
 
 	logger.Infof("starting instance %q (image %q)...", instSpec.Name, instSpec.Image)
 
@@ -210,7 +241,11 @@ func (env *environ) newRawInstance(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	statusCallback(status.Running, "container started")
+	if instType == lxdclient.InstanceTypeVirtualMachine {
+		statusCallback(status.Running, "virtual machine started")
+	} else {
+		statusCallback(status.Running, "container started")
+	}
 	return inst, nil
 }
 
@@ -253,6 +288,20 @@ func getMetadata(cloudcfg cloudinit.CloudConfig, args environs.StartInstancePara
 	return metadata, nil
 }
 
+// instanceLocation returns the name of the cluster member on which
+// the named container is located, or the empty string if the LXD
+// server is not clustered.
+func (env *environ) instanceLocation(name string) string {
+	locations, err := env.raw.InstanceLocations()
+	if err != nil {
+		if !errors.IsNotSupported(err) {
+			logger.Warningf("cannot determine location of %q: %v", name, err)
+		}
+		return ""
+	}
+	return locations[name]
+}
+
 // getHardwareCharacteristics compiles hardware-related details about
 // the given instance and relative to the provided spec and returns it.
 func (env *environ) getHardwareCharacteristics(args environs.StartInstanceParams, inst *environInstance) *instance.HardwareCharacteristics {
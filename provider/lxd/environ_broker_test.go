@@ -11,7 +11,9 @@ import (
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -36,10 +38,81 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	c.Check(result.Hardware, gc.DeepEquals, s.HWC)
 	c.Assert(s.StartInstArgs.InstanceConfig.AgentVersion().Arch, gc.Equals, arch.ARM64)
 
-	s.Stub.CheckCallNames(c, "EnsureImageExists", "AddInstance")
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "AddInstance", "InstanceLocations")
 	s.Stub.CheckCall(c, 0, "EnsureImageExists", "trusty", "arm64")
 }
 
+func (s *environBrokerSuite) TestStartInstanceClusterMember(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	args := s.StartInstArgs
+	args.AvailabilityZone = "node1"
+	result, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Hardware.AvailabilityZone, gc.NotNil)
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "node1")
+
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "AddInstance")
+	spec := s.Stub.Calls()[1].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node1")
+}
+
+func (s *environBrokerSuite) TestStartInstancePlacementOverridesZone(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	args := s.StartInstArgs
+	args.AvailabilityZone = "node1"
+	args.Placement = "node2"
+	result, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "node2")
+
+	spec := s.Stub.Calls()[1].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node2")
+}
+
+func (s *environBrokerSuite) TestStartInstanceReportsLocation(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.Client.Locations = map[string]string{s.RawInstance.Name: "node3"}
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	result, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "node3")
+}
+
+func (s *environBrokerSuite) TestStartInstanceVirtualMachine(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	args := s.StartInstArgs
+	args.Constraints = constraints.MustParse("virt-type=virtual-machine")
+	_, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureVirtualMachineImageExists", "AddInstance", "InstanceLocations")
+	s.Stub.CheckCall(c, 0, "EnsureVirtualMachineImageExists", "trusty", "arm64")
+	spec := s.Stub.Calls()[1].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Type, gc.Equals, lxdclient.InstanceTypeVirtualMachine)
+	c.Assert(spec.Image, gc.Equals, "juju/trusty/arm64/vm")
+}
+
+func (s *environBrokerSuite) TestStartInstanceContainerVirtType(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	args := s.StartInstArgs
+	args.Constraints = constraints.MustParse("virt-type=container")
+	_, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "AddInstance", "InstanceLocations")
+	spec := s.Stub.Calls()[1].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Type, gc.Equals, lxdclient.InstanceTypeContainer)
+}
+
 func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	s.Client.Inst = s.RawInstance
 
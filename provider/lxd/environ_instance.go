@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -115,14 +117,27 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
-type instPlacement struct{}
+type instPlacement struct {
+	// nodeName is the name of the cluster member on which to
+	// create the instance, if specified.
+	nodeName string
+}
 
+// parsePlacement parses the placement directive, which may be either
+// the name of a cluster member, or "zone=<name>".
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 	if placement == "" {
 		return &instPlacement{}, nil
 	}
 
-	return nil, errors.Errorf("unknown placement directive: %v", placement)
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return &instPlacement{nodeName: placement}, nil
+	}
+	if placement[:pos] != "zone" || placement[pos+1:] == "" {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+	return &instPlacement{nodeName: placement[pos+1:]}, nil
 }
 
 // AdoptResources updates the controller tags on all instances to have the
@@ -11,14 +11,21 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	if _, err := env.parsePlacement(args.Placement); err != nil {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if placement.nodeName != "" {
+		if err := env.checkClusterMember(args.Placement, placement.nodeName); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if args.Constraints.HasInstanceType() {
 		return errors.Errorf("LXD does not support instance types (got %q)", *args.Constraints.InstanceType)
@@ -33,7 +40,9 @@ var unsupportedConstraints = []string{
 	//TODO(ericsnow) Add constraints.Mem as unsupported?
 	constraints.InstanceType,
 	constraints.Tags,
-	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -55,6 +64,10 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 	// the local machine.  If/when we support a remote lxd environment, we'll
 	// need to change this to match the arch of the remote machine.
 	validator.RegisterVocabulary(constraints.Arch, []string{arch.HostArch()})
+	validator.RegisterVocabulary(constraints.VirtType, []string{
+		string(lxdclient.InstanceTypeContainer),
+		string(lxdclient.InstanceTypeVirtualMachine),
+	})
 
 	// TODO(ericsnow) Get this working...
 	//validator.RegisterVocabulary(constraints.Container, supportedContainerTypes)
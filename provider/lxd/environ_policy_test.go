@@ -97,7 +97,6 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"instance-type=some-type",
 		"cores=2",
 		"cpu-power=250",
-		"virt-type=kvm",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -107,7 +106,6 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"instance-type",
 		"cores",
 		"cpu-power",
-		"virt-type",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
@@ -136,6 +134,28 @@ func (s *environPolSuite) TestConstraintsValidatorVocabArchUnknown(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are: \\[amd64\\]")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabVirtTypeKnown(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, virtType := range []string{"container", "virtual-machine"} {
+		cons := constraints.MustParse("virt-type=" + virtType)
+		unsupported, err := validator.Validate(cons)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(unsupported, gc.HasLen, 0)
+	}
+}
+
+func (s *environPolSuite) TestConstraintsValidatorVocabVirtTypeUnknown(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("virt-type=kvm")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: virt-type=kvm\nvalid values are: \\[container virtual-machine\\]")
+}
+
 func (s *environPolSuite) TestConstraintsValidatorVocabContainerUnknown(c *gc.C) {
 	c.Skip("this will fail until we add a container vocabulary")
 	validator, err := s.Env.ConstraintsValidator()
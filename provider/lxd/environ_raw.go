@@ -21,6 +21,7 @@ type rawProvider struct {
 	lxdProfiles
 	lxdImages
 	lxdStorage
+	lxdCluster
 
 	remote lxdclient.Remote
 }
@@ -45,16 +46,26 @@ type lxdInstances interface {
 	Addresses(string) ([]network.Address, error)
 	AttachDisk(string, string, lxdclient.DiskDevice) error
 	RemoveDevice(string, string) error
+	InstanceProfiles(string) ([]string, error)
+	SetInstanceProfiles(string, []string) error
 }
 
 type lxdProfiles interface {
 	DefaultProfileBridgeName() string
 	CreateProfile(string, map[string]string) error
 	HasProfile(string) (bool, error)
+	CreateProfileWithDevices(string, map[string]string, map[string]map[string]string) error
+	ProfileDelete(string) error
 }
 
 type lxdImages interface {
 	EnsureImageExists(series, arch string, sources []lxdclient.Remote, copyProgressHandler func(string)) (string, error)
+	EnsureVirtualMachineImageExists(series, arch string, sources []lxdclient.Remote, copyProgressHandler func(string)) (string, error)
+}
+
+type lxdCluster interface {
+	ClusterMembers() ([]lxdclient.ClusterMember, error)
+	InstanceLocations() (map[string]string, error)
 }
 
 type lxdStorage interface {
@@ -103,6 +114,7 @@ func newRawProviderFromConfig(config lxdclient.Config) (*rawProvider, error) {
 		lxdProfiles:  client,
 		lxdImages:    client,
 		lxdStorage:   client,
+		lxdCluster:   client,
 		remote:       config.Remote,
 	}, nil
 }
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.LXDProfiler = (*environ)(nil)
+
+// MaybeWriteLXDProfile is part of the environs.LXDProfiler interface.
+func (env *environ) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	exists, err := env.raw.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return nil
+	}
+	logger.Debugf("creating charm profile %q", name)
+	return errors.Trace(env.raw.CreateProfileWithDevices(name, profile.Config, profile.Devices))
+}
+
+// ReplaceLXDProfiles is part of the environs.LXDProfiler interface.
+func (env *environ) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	name := string(id)
+	current, err := env.raw.InstanceProfiles(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	profiles := lxdprofile.ReplaceNames(current, remove, add)
+	logger.Infof("instance %q profiles changing from %v to %v", name, current, profiles)
+	if err := env.raw.SetInstanceProfiles(name, profiles); err != nil {
+		return errors.Trace(err)
+	}
+	for _, profile := range remove {
+		// LXD refuses to delete a profile that is still in use by
+		// another instance, which is exactly what we want.
+		if err := env.raw.ProfileDelete(profile); err != nil {
+			logger.Debugf("not deleting profile %q: %v", profile, err)
+		}
+	}
+	return nil
+}
+
+// writeCharmProfiles ensures the given charm profiles exist, returning
+// their names in a stable order.
+func (env *environ) writeCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := env.MaybeWriteLXDProfile(name, profiles[name]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return names, nil
+}
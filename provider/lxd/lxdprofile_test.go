@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type lxdProfileSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&lxdProfileSuite{})
+
+var testCharmProfile = &lxdprofile.Profile{
+	Config: map[string]string{"security.nesting": "true"},
+	Devices: map[string]map[string]string{
+		"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+	},
+}
+
+func (s *lxdProfileSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	args := s.StartInstArgs
+	args.CharmLXDProfiles = map[string]*lxdprofile.Profile{
+		"juju-model-app-1": testCharmProfile,
+	}
+	_, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "HasProfile", "CreateProfileWithDevices", "AddInstance", "InstanceLocations")
+	s.Stub.CheckCall(c, 2, "CreateProfileWithDevices", "juju-model-app-1", testCharmProfile.Config, testCharmProfile.Devices)
+	spec := s.Stub.Calls()[3].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Profiles, jc.DeepEquals, []string{"default", "juju-testenv", "juju-model-app-1"})
+}
+
+func (s *lxdProfileSuite) TestReplaceLXDProfiles(c *gc.C) {
+	s.Client.Profiles = []string{"default", "juju-testenv", "juju-model-app-1"}
+	s.Stub.SetErrors(nil, nil, errors.New("profile in use"))
+
+	err := s.Env.ReplaceLXDProfiles("juju-0", []string{"juju-model-app-1"}, []string{"juju-model-app-2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "InstanceProfiles", "SetInstanceProfiles", "ProfileDelete")
+	s.Stub.CheckCall(c, 1, "SetInstanceProfiles", "juju-0", []string{"default", "juju-testenv", "juju-model-app-2"})
+}
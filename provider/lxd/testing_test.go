@@ -348,6 +348,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		lxdStorage:   s.Client,
+		lxdCluster:   s.Client,
 		remote: lxdclient.Remote{
 			Cert: &lxdclient.Cert{
 				Name:    "juju",
@@ -472,7 +473,10 @@ type StubClient struct {
 	Inst               *lxdclient.Instance
 	Server             *api.Server
 	StorageIsSupported bool
+	Profiles           []string
 	Volumes            map[string][]api.StorageVolume
+	ClusterMembersList []lxdclient.ClusterMember
+	Locations          map[string]string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -511,6 +515,15 @@ func (conn *StubClient) EnsureImageExists(series, arch string, _ []lxdclient.Rem
 	return path.Join("juju", series, arch), nil
 }
 
+func (conn *StubClient) EnsureVirtualMachineImageExists(series, arch string, _ []lxdclient.Remote, _ func(string)) (string, error) {
+	conn.AddCall("EnsureVirtualMachineImageExists", series, arch)
+	if err := conn.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	return path.Join("juju", series, arch, "vm"), nil
+}
+
 func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	conn.AddCall("Addresses", name)
 	if err := conn.NextErr(); err != nil {
@@ -584,6 +597,26 @@ func (conn *StubClient) HasProfile(name string) (bool, error) {
 	return false, conn.NextErr()
 }
 
+func (conn *StubClient) CreateProfileWithDevices(name string, config map[string]string, devices map[string]map[string]string) error {
+	conn.AddCall("CreateProfileWithDevices", name, config, devices)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) ProfileDelete(name string) error {
+	conn.AddCall("ProfileDelete", name)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) InstanceProfiles(name string) ([]string, error) {
+	conn.AddCall("InstanceProfiles", name)
+	return conn.Profiles, conn.NextErr()
+}
+
+func (conn *StubClient) SetInstanceProfiles(name string, profiles []string) error {
+	conn.AddCall("SetInstanceProfiles", name, profiles)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) AttachDisk(container, device string, disk lxdclient.DiskDevice) error {
 	conn.AddCall("AttachDisk", container, device, disk)
 	return conn.NextErr()
@@ -594,6 +627,16 @@ func (conn *StubClient) RemoveDevice(container, device string) error {
 	return conn.NextErr()
 }
 
+func (conn *StubClient) ClusterMembers() ([]lxdclient.ClusterMember, error) {
+	conn.AddCall("ClusterMembers")
+	return conn.ClusterMembersList, conn.NextErr()
+}
+
+func (conn *StubClient) InstanceLocations() (map[string]string, error) {
+	conn.AddCall("InstanceLocations")
+	return conn.Locations, conn.NextErr()
+}
+
 func (conn *StubClient) StorageSupported() bool {
 	conn.AddCall("StorageSupported")
 	return conn.StorageIsSupported
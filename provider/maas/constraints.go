@@ -20,6 +20,9 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
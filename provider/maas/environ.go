@@ -93,6 +93,10 @@ type maasEnviron struct {
 	// maasController provides access to the MAAS 2.0 API.
 	maasController gomaasapi.Controller
 
+	// podComposer provides access to MAAS 2.0 pods, which
+	// the controller does not.
+	podComposer podComposer
+
 	// namespace is used to create the machine and device hostnames.
 	namespace instance.Namespace
 
@@ -301,7 +305,12 @@ func (env *maasEnviron) SetConfig(cfg *config.Config) error {
 	case err != nil:
 		return errors.Trace(err)
 	default:
+		pods, err := newPodComposer(maasServer, maasOAuth)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		env.maasController = controller
+		env.podComposer = pods
 	}
 	env.apiVersion = apiVersion
 	return nil
@@ -1320,12 +1329,42 @@ func (environ *maasEnviron) selectNode2(args selectNodeArgs) (maasInstance, *sel
 		args.Interfaces,
 		args.Volumes,
 	)
-	if err != nil {
+	if err == nil {
+		return inst, nil
+	}
+	if !gomaasapi.IsNoMatchError(err) || args.NodeName != "" || args.SystemId != "" {
 		return nil, &selectNodeError{
 			error:   errors.Trace(err),
 			noMatch: gomaasapi.IsNoMatchError(err),
 		}
 	}
+
+	// No ready machine matches; try composing one from a pod,
+	// and then acquire that.
+	systemId, composeErr := environ.composeMachine(args)
+	if composeErr != nil {
+		if !errors.IsNotFound(composeErr) {
+			logger.Warningf("cannot compose machine: %v", composeErr)
+		}
+		return nil, &selectNodeError{
+			error:   errors.Trace(err),
+			noMatch: true,
+		}
+	}
+	inst, err = environ.acquireNode2(
+		"",
+		args.AvailabilityZone,
+		systemId,
+		args.Constraints,
+		args.Interfaces,
+		args.Volumes,
+	)
+	if err != nil {
+		return nil, &selectNodeError{
+			error:   errors.Annotatef(err, "acquiring composed machine %q", systemId),
+			noMatch: gomaasapi.IsNoMatchError(err),
+		}
+	}
 	return inst, nil
 }
 
@@ -6,6 +6,7 @@ package maas
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/juju/errors"
 	"github.com/juju/gomaasapi"
@@ -356,6 +357,95 @@ func (suite *maas2EnvironSuite) TestStartInstanceParams(c *gc.C) {
 	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("Bruce Sterling"))
 }
 
+func (suite *maas2EnvironSuite) TestStartInstanceComposesFromPod(c *gc.C) {
+	var env *maasEnviron
+	var controller *fakeController
+	controller = &fakeController{
+		allocateMachineArgsCheck: func(args gomaasapi.AllocateMachineArgs) {
+			if args.SystemId == "" {
+				return
+			}
+			c.Assert(args, gc.DeepEquals, gomaasapi.AllocateMachineArgs{
+				AgentName:   env.Config().UUID(),
+				Zone:        "foo",
+				SystemId:    "composed",
+				MinCPUCount: 2,
+				MinMemory:   8192,
+			})
+			controller.allocateMachineError = nil
+		},
+		allocateMachine:      newFakeMachine("composed", arch.HostArch(), ""),
+		allocateMachineError: gomaasapi.NewNoMatchError("no machines"),
+		allocateMachineMatches: gomaasapi.ConstraintMatches{
+			Storage: map[string][]gomaasapi.BlockDevice{},
+		},
+		zones: []gomaasapi.Zone{&fakeZone{name: "foo"}},
+	}
+	suite.injectController(controller)
+	composer := &fakePodComposer{Stub: &testing.Stub{}, systemId: "composed"}
+	// Pod 1 is in the wrong zone, and pod 3 has too
+	// little memory available; only pod 2 is suitable.
+	composer.pods = make([]maasPod, 3)
+	for i, zone := range []string{"bar", "foo", "foo"} {
+		composer.pods[i].ID = i + 1
+		composer.pods[i].Zone.Name = zone
+		composer.pods[i].Available.Cores = 4
+		composer.pods[i].Available.Memory = 16384
+	}
+	composer.pods[2].Available.Memory = 4096
+	suite.injectPodComposer(composer)
+	suite.setupFakeTools(c)
+	env = suite.makeEnviron(c, nil)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:   suite.controllerUUID,
+		AvailabilityZone: "foo",
+		Constraints:      constraints.MustParse("cores=2 mem=8G"),
+	}
+	result, err := jujutesting.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("composed"))
+	composer.CheckCallNames(c, "Pods", "Compose")
+	composer.CheckCall(c, 1, "Compose", 2, url.Values{
+		"cores":  {"2"},
+		"memory": {"8192"},
+	})
+}
+
+func (suite *maas2EnvironSuite) TestStartInstanceNoSuitablePod(c *gc.C) {
+	controller := newFakeController()
+	controller.allocateMachineError = gomaasapi.NewNoMatchError("no machines")
+	suite.injectController(controller)
+	composer := &fakePodComposer{Stub: &testing.Stub{}}
+	composer.pods = make([]maasPod, 1)
+	composer.pods[0].Available.Cores = 1
+	suite.injectPodComposer(composer)
+	suite.setupFakeTools(c)
+	env := suite.makeEnviron(c, nil)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:   suite.controllerUUID,
+		AvailabilityZone: "mossack",
+		Constraints:      constraints.MustParse("cores=2"),
+	}
+	_, err := jujutesting.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, "failed to acquire node: .*no machines")
+	composer.CheckCallNames(c, "Pods")
+}
+
+func (suite *maas2EnvironSuite) TestComposeParams(c *gc.C) {
+	params := composeParams(constraints.MustParse("arch=amd64 cores=4 mem=2G"), []volumeInfo{
+		{name: "root", sizeInGB: 20},
+		{name: "data", sizeInGB: 50, tags: []string{"ssd"}},
+	})
+	c.Assert(params, jc.DeepEquals, url.Values{
+		"architecture": {"amd64/generic"},
+		"cores":        {"4"},
+		"memory":       {"2048"},
+		"storage":      {"root:20,data:50(ssd)"},
+	})
+}
+
 func (suite *maas2EnvironSuite) TestAcquireNodePassedAgentName(c *gc.C) {
 	var env *maasEnviron
 	suite.injectController(&fakeController{
@@ -4,6 +4,8 @@
 package maas
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
 	"github.com/juju/gomaasapi"
 	"github.com/juju/testing"
@@ -27,6 +29,13 @@ func (suite *maas2Suite) injectController(controller gomaasapi.Controller) {
 		return controller, nil
 	}
 	suite.PatchValue(&GetMAAS2Controller, mockGetController)
+	suite.injectPodComposer(&fakePodComposer{Stub: &testing.Stub{}})
+}
+
+func (suite *maas2Suite) injectPodComposer(composer podComposer) {
+	suite.PatchValue(&newPodComposer, func(maasServer, apiKey string) (podComposer, error) {
+		return composer, nil
+	})
 }
 
 func (suite *maas2Suite) makeEnviron(c *gc.C, controller gomaasapi.Controller) *maasEnviron {
@@ -646,3 +655,23 @@ func (d *fakeDevice) Delete() error {
 	}
 	return d.NextErr()
 }
+
+type fakePodComposer struct {
+	*testing.Stub
+
+	pods     []maasPod
+	systemId string
+}
+
+func (c *fakePodComposer) Pods() ([]maasPod, error) {
+	c.MethodCall(c, "Pods")
+	return c.pods, c.NextErr()
+}
+
+func (c *fakePodComposer) Compose(podID int, params url.Values) (string, error) {
+	c.MethodCall(c, "Compose", podID, params)
+	if err := c.NextErr(); err != nil {
+		return "", err
+	}
+	return c.systemId, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gomaasapi"
+
+	"github.com/juju/juju/constraints"
+)
+
+// maasPod describes a MAAS pod (VM host), from which machines
+// may be composed on demand.
+type maasPod struct {
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Architectures []string `json:"architectures"`
+	Zone          struct {
+		Name string `json:"name"`
+	} `json:"zone"`
+	Available struct {
+		Cores  int `json:"cores"`
+		Memory int `json:"memory"`
+	} `json:"available"`
+}
+
+// podComposer lists MAAS pods and composes machines from them.
+// The gomaasapi.Controller interface does not cover pods, so
+// the MAAS 2.0 API is used directly.
+type podComposer interface {
+	// Pods returns all of the pods known to MAAS.
+	Pods() ([]maasPod, error)
+
+	// Compose composes a new machine in the pod with the given
+	// ID, returning the system ID of the composed machine.
+	Compose(podID int, params url.Values) (string, error)
+}
+
+var newPodComposer = func(maasServer, apiKey string) (podComposer, error) {
+	versionURL := maasServer
+	if _, _, includesVersion := gomaasapi.SplitVersionedURL(maasServer); !includesVersion {
+		versionURL = gomaasapi.AddAPIVersionToURL(maasServer, apiVersion2)
+	}
+	client, err := gomaasapi.NewAuthenticatedClient(versionURL, apiKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return maasPods{gomaasapi.NewMAAS(*client)}, nil
+}
+
+type maasPods struct {
+	client *gomaasapi.MAASObject
+}
+
+// Pods is part of the podComposer interface.
+func (p maasPods) Pods() ([]maasPod, error) {
+	result, err := p.client.GetSubObject("pods").CallGet("", nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list pods")
+	}
+	podsJSON, err := getJSONBytes(result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var pods []maasPod
+	if err := json.Unmarshal(podsJSON, &pods); err != nil {
+		return nil, errors.Annotate(err, "parsing pods")
+	}
+	return pods, nil
+}
+
+// Compose is part of the podComposer interface.
+func (p maasPods) Compose(podID int, params url.Values) (string, error) {
+	podAPI := p.client.GetSubObject("pods").GetSubObject(fmt.Sprint(podID))
+	result, err := podAPI.CallPost("compose", params)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machine, err := result.GetMap()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	systemId, err := machine["system_id"].GetString()
+	if err != nil {
+		return "", errors.Annotate(err, "getting system ID of composed machine")
+	}
+	return systemId, nil
+}
+
+// composeParams returns the parameters for composing a machine that
+// satisfies the given constraints and has the given volumes.
+func composeParams(cons constraints.Value, volumes []volumeInfo) url.Values {
+	params := make(url.Values)
+	if cons.Arch != nil {
+		params.Add("architecture", podArchitecture(*cons.Arch))
+	}
+	if cons.CpuCores != nil {
+		params.Add("cores", fmt.Sprint(*cons.CpuCores))
+	}
+	if cons.Mem != nil {
+		params.Add("memory", fmt.Sprint(*cons.Mem))
+	}
+	addStorage(params, volumes)
+	return params
+}
+
+// podArchitecture returns the MAAS architecture name, including
+// subarchitecture, for the given Juju architecture.
+func podArchitecture(arch string) string {
+	if strings.Contains(arch, "/") {
+		return arch
+	}
+	return arch + "/generic"
+}
+
+// podSuitable reports whether a machine satisfying the given
+// constraints could be composed in the pod, in the given zone.
+func podSuitable(pod maasPod, zone string, cons constraints.Value) bool {
+	if zone != "" && pod.Zone.Name != zone {
+		return false
+	}
+	if cons.Arch != nil {
+		arch := podArchitecture(*cons.Arch)
+		var found bool
+		for _, podArch := range pod.Architectures {
+			if podArch == arch {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if cons.CpuCores != nil && uint64(pod.Available.Cores) < *cons.CpuCores {
+		return false
+	}
+	if cons.Mem != nil && uint64(pod.Available.Memory) < *cons.Mem {
+		return false
+	}
+	return true
+}
+
+// composeMachine composes a machine from the first pod able to
+// satisfy the given arguments, returning its system ID. If no pod
+// could compose a suitable machine, an error satisfying
+// errors.IsNotFound is returned.
+func (environ *maasEnviron) composeMachine(args selectNodeArgs) (string, error) {
+	if args.Constraints.Tags != nil {
+		// Composed machines do not have tags, so there is no
+		// point in composing one if tags are required.
+		if positives, _ := parseDelimitedValues(*args.Constraints.Tags); len(positives) > 0 {
+			return "", errors.NotFoundf("pod for machine with tags")
+		}
+	}
+	pods, err := environ.podComposer.Pods()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	params := composeParams(args.Constraints, args.Volumes)
+	for _, pod := range pods {
+		if !podSuitable(pod, args.AvailabilityZone, args.Constraints) {
+			continue
+		}
+		systemId, err := environ.podComposer.Compose(pod.ID, params)
+		if err != nil {
+			logger.Debugf("cannot compose machine in pod %q: %v", pod.Name, err)
+			continue
+		}
+		logger.Infof("composed machine %q in pod %q", systemId, pod.Name)
+		return systemId, nil
+	}
+	return "", errors.NotFoundf("pod able to compose a matching machine")
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/goose.v2/cinder"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/nova"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/common"
+)
+
+const (
+	// rootDiskSourceLocal is the root-disk-source constraint value
+	// for instances which boot from the disk of their flavor.
+	rootDiskSourceLocal = "local"
+
+	// rootDiskSourceVolume is the root-disk-source constraint value
+	// for instances which boot from a Cinder volume of the default
+	// volume type. Any other value except "local" names the Cinder
+	// volume type of the volume to boot from.
+	rootDiskSourceVolume = "volume"
+)
+
+// bootVolumeAttempt is the strategy used when waiting for a boot volume
+// to become available. Copying the image to the volume may take a lot
+// longer than creating an empty volume.
+var bootVolumeAttempt = utils.AttemptStrategy{
+	Total: 10 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+// bootVolumeType reports whether instances started with the given
+// constraints boot from a Cinder volume and, if so, the volume type
+// to use. An empty volume type selects the cloud's default.
+func bootVolumeType(cons constraints.Value) (string, bool) {
+	if !cons.HasRootDiskSource() || *cons.RootDiskSource == rootDiskSourceLocal {
+		return "", false
+	}
+	if *cons.RootDiskSource == rootDiskSourceVolume {
+		return "", true
+	}
+	return *cons.RootDiskSource, true
+}
+
+// bootVolumeSize returns the size in GiB of the volume to boot an
+// instance from: the root-disk constraint if given, else the size of
+// the flavor's disk, and never less than the minimum for the series.
+func bootVolumeSize(cons constraints.Value, flavorRootDisk uint64, series string) uint64 {
+	size := common.MiBToGiB(flavorRootDisk)
+	if cons.RootDisk != nil {
+		size = common.MiBToGiB(*cons.RootDisk)
+	}
+	if minSize := common.MinRootDiskSizeGiB(series); size < minSize {
+		size = minSize
+	}
+	return size
+}
+
+// blockDeviceMapping describes a block device to attach to a server
+// when it is created, as in the Nova block_device_mapping_v2 request
+// attribute.
+type blockDeviceMapping struct {
+	BootIndex           int    `json:"boot_index"`
+	UUID                string `json:"uuid"`
+	SourceType          string `json:"source_type"`
+	DestinationType     string `json:"destination_type"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+}
+
+// runServerWithBlockDevices creates a server with the given block
+// devices. The goose nova client has no support for block device
+// mappings, so the request is sent directly.
+var runServerWithBlockDevices = func(c client.Client, opts nova.RunServerOpts, devices []blockDeviceMapping) (*nova.Entity, error) {
+	var req struct {
+		Server struct {
+			nova.RunServerOpts
+			BlockDevices []blockDeviceMapping `json:"block_device_mapping_v2"`
+		} `json:"server"`
+	}
+	req.Server.RunServerOpts = opts
+	req.Server.BlockDevices = devices
+	var resp struct {
+		Server nova.Entity `json:"server"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       &req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	if err := c.SendRequest("POST", "compute", "v2", "servers", &requestData); err != nil {
+		return nil, errors.Annotate(err, "failed to run a server with block devices")
+	}
+	return &resp.Server, nil
+}
+
+// createBootVolume creates a Cinder volume of the given type and size
+// in GiB holding the image with the given ID, and waits for it to
+// become available so that an instance can boot from it.
+func createBootVolume(
+	storageAdapter OpenstackStorage,
+	name, imageId, volumeType, zone string,
+	size uint64,
+	tags map[string]string,
+) (*cinder.Volume, error) {
+	var metadata interface{}
+	if len(tags) > 0 {
+		metadata = tags
+	}
+	volume, err := storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
+		Size:             int(size),
+		Name:             name,
+		ImageRef:         imageId,
+		VolumeType:       volumeType,
+		AvailabilityZone: zone,
+		Metadata:         metadata,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeId := volume.ID
+	for a := bootVolumeAttempt.Start(); a.Next(); {
+		volume, err = storageAdapter.GetVolume(volumeId)
+		if err != nil {
+			err = errors.Annotate(err, "getting volume")
+			break
+		}
+		if volume.Status == "available" {
+			return volume, nil
+		}
+		if volume.Status == "error" {
+			err = errors.Errorf("volume %q has status %q", volumeId, volume.Status)
+			break
+		}
+		err = errors.Errorf("timed out waiting for volume %q to become available", volumeId)
+	}
+	if err := storageAdapter.DeleteVolume(volumeId); err != nil {
+		logger.Warningf("destroying volume %s: %s", volumeId, err)
+	}
+	return nil, errors.Trace(err)
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+)
+
+type bootVolumeSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&bootVolumeSuite{})
+
+func (*bootVolumeSuite) TestBootVolumeType(c *gc.C) {
+	for i, t := range []struct {
+		cons           string
+		volumeType     string
+		bootFromVolume bool
+	}{
+		{"", "", false},
+		{"root-disk-source=", "", false},
+		{"root-disk-source=local", "", false},
+		{"root-disk-source=volume", "", true},
+		{"root-disk-source=ssd", "ssd", true},
+	} {
+		c.Logf("test %d: %q", i, t.cons)
+		volumeType, bootFromVolume := bootVolumeType(constraints.MustParse(t.cons))
+		c.Check(volumeType, gc.Equals, t.volumeType)
+		c.Check(bootFromVolume, gc.Equals, t.bootFromVolume)
+	}
+}
+
+func (*bootVolumeSuite) TestBootVolumeSize(c *gc.C) {
+	for i, t := range []struct {
+		cons           string
+		flavorRootDisk uint64
+		series         string
+		size           uint64
+	}{
+		{"", 0, "xenial", 8},
+		{"", 20 * 1024, "xenial", 20},
+		{"root-disk=30G", 20 * 1024, "xenial", 30},
+		{"root-disk=1500M", 0, "xenial", 8},
+		{"root-disk=10241M", 0, "xenial", 11},
+		{"", 0, "win2012r2", 40},
+	} {
+		c.Logf("test %d: %q", i, t.cons)
+		size := bootVolumeSize(constraints.MustParse(t.cons), t.flavorRootDisk, t.series)
+		c.Check(size, gc.Equals, t.size)
+	}
+}
@@ -43,7 +43,10 @@ func (OpenstackCredentials) CredentialSchemas() map[cloud.AuthType]cloud.Credent
 					Hidden:      true,
 				},
 			}, {
-				CredAttrTenantName, cloud.CredentialAttr{Description: "The OpenStack tenant name."},
+				CredAttrTenantName, cloud.CredentialAttr{
+					Description: "The OpenStack tenant name. If omitted, a domain-scoped token for domain-name will be requested.",
+					Optional:    true,
+				},
 			}, {
 				CredAttrDomainName, cloud.CredentialAttr{
 					Description: "The OpenStack domain name.",
@@ -116,7 +119,10 @@ func (c OpenstackCredentials) DetectCredentials() (*cloud.CloudCredential, error
 
 func (c OpenstackCredentials) detectCredential() (*cloud.Credential, string, string, error) {
 	creds := identity.CredentialsFromEnv()
-	if creds.TenantName == "" {
+	if creds.TenantName == "" && (creds.Domain == "" || os.Getenv("OS_USERNAME") == "") {
+		// A domain-scoped token may be requested instead of a
+		// project-scoped one, but only with Keystone v3 username
+		// and password credentials.
 		return nil, "", "", errors.NewNotFound(nil, "OS_TENANT_NAME environment variable not set")
 	}
 	if creds.User == "" {
@@ -160,7 +166,11 @@ func (c OpenstackCredentials) detectCredential() (*cloud.Credential, string, str
 	if region == "" {
 		region = "<unspecified>"
 	}
-	credential.Label = fmt.Sprintf("openstack region %q project %q user %q", region, creds.TenantName, user)
+	if creds.TenantName == "" {
+		credential.Label = fmt.Sprintf("openstack region %q domain %q user %q", region, creds.Domain, user)
+	} else {
+		credential.Label = fmt.Sprintf("openstack region %q project %q user %q", region, creds.TenantName, user)
+	}
 	return &credential, user, creds.Region, nil
 }
 
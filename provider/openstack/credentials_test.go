@@ -139,6 +139,29 @@ func (s *credentialsSuite) TestDetectCredentialsUserPassDefaultDomain(c *gc.C) {
 	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
 }
 
+func (s *credentialsSuite) TestDetectCredentialsUserPassDomainScoped(c *gc.C) {
+	s.PatchEnvironment("USER", "fred")
+	s.PatchEnvironment("OS_USERNAME", "bob")
+	s.PatchEnvironment("OS_PASSWORD", "dobbs")
+	s.PatchEnvironment("OS_REGION_NAME", "west")
+	s.PatchEnvironment("OS_DOMAIN_NAME", "engineering")
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{
+			"username":            "bob",
+			"password":            "dobbs",
+			"tenant-name":         "",
+			"domain-name":         "engineering",
+			"project-domain-name": "",
+			"user-domain-name":    "",
+		},
+	)
+	expected.Label = `openstack region "west" domain "engineering" user "bob"`
+	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
+}
+
 func (s *credentialsSuite) TestDetectCredentialsNovarc(c *gc.C) {
 	if runtime.GOOS != "linux" {
 		c.Skip("not running linux")
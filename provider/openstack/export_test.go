@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"gopkg.in/goose.v2/client"
 	"gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/neutron"
@@ -74,8 +75,24 @@ var (
 	NovaListAvailabilityZones   = &novaListAvailabilityZones
 	AvailabilityZoneAllocations = &availabilityZoneAllocations
 	NewOpenstackStorage         = &newOpenstackStorage
+	RunServerWithBlockDevices   = &runServerWithBlockDevices
 )
 
+// RunServerWithBlockDevicesFunc returns a function which may replace
+// runServerWithBlockDevices, calling f with the server options and the
+// IDs of the volumes in the block device mappings.
+func RunServerWithBlockDevicesFunc(
+	f func(opts nova.RunServerOpts, volumeIds []string) (*nova.Entity, error),
+) func(client.Client, nova.RunServerOpts, []blockDeviceMapping) (*nova.Entity, error) {
+	return func(_ client.Client, opts nova.RunServerOpts, devices []blockDeviceMapping) (*nova.Entity, error) {
+		volumeIds := make([]string, len(devices))
+		for i, device := range devices {
+			volumeIds[i] = device.UUID
+		}
+		return f(opts, volumeIds)
+	}
+}
+
 func NewCinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
 	return NewCinderVolumeSourceForModel(s, testing.ModelTag.Id())
 }
@@ -530,6 +547,8 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
 
+var SecGroupMatchesEgressRule = secGroupMatchesEgressRule
+
 var MakeServiceURL = &makeServiceURL
 
 var GetVolumeEndpointURL = getVolumeEndpointURL
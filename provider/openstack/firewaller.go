@@ -6,6 +6,7 @@ package openstack
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -125,21 +126,80 @@ func (f *switchingFirewaller) OpenPorts(rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.OpenPorts(rules)
+	return f.env.throttle.Call(apiFamilySecurityGroups, func() error {
+		return f.fw.OpenPorts(rules)
+	})
 }
 
 func (f *switchingFirewaller) ClosePorts(rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.ClosePorts(rules)
+	return f.env.throttle.Call(apiFamilySecurityGroups, func() error {
+		return f.fw.ClosePorts(rules)
+	})
 }
 
 func (f *switchingFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.IngressRules()
+	var rules []network.IngressRule
+	err := f.env.throttle.Call(apiFamilySecurityGroups, func() (err error) {
+		rules, err = f.fw.IngressRules()
+		return err
+	})
+	return rules, err
+}
+
+// egressFirewaller is implemented by firewallers which can restrict
+// the destinations to which the model's machines send traffic.
+type egressFirewaller interface {
+	// OpenEgress allows outgoing traffic matching the given rules
+	// from all of the model's machines.
+	OpenEgress(rules []network.EgressRule) error
+
+	// CloseEgress stops allowing outgoing traffic matching the given
+	// rules.
+	CloseEgress(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole model.
+	EgressRules() ([]network.EgressRule, error)
+}
+
+func (f *switchingFirewaller) egressFirewaller() (egressFirewaller, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw, ok := f.fw.(egressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules without neutron")
+	}
+	return fw, nil
+}
+
+func (f *switchingFirewaller) OpenEgress(rules []network.EgressRule) error {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.OpenEgress(rules)
+}
+
+func (f *switchingFirewaller) CloseEgress(rules []network.EgressRule) error {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.CloseEgress(rules)
+}
+
+func (f *switchingFirewaller) EgressRules() ([]network.EgressRule, error) {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fw.EgressRules()
 }
 
 func (f *switchingFirewaller) DeleteAllModelGroups() error {
@@ -188,21 +248,30 @@ func (f *switchingFirewaller) OpenInstancePorts(inst instance.Instance, machineI
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.OpenInstancePorts(inst, machineId, rules)
+	return f.env.throttle.Call(apiFamilySecurityGroups, func() error {
+		return f.fw.OpenInstancePorts(inst, machineId, rules)
+	})
 }
 
 func (f *switchingFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
-	return f.fw.CloseInstancePorts(inst, machineId, rules)
+	return f.env.throttle.Call(apiFamilySecurityGroups, func() error {
+		return f.fw.CloseInstancePorts(inst, machineId, rules)
+	})
 }
 
 func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if err := f.initFirewaller(); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return f.fw.InstanceIngressRules(inst, machineId)
+	var rules []network.IngressRule
+	err := f.env.throttle.Call(apiFamilySecurityGroups, func() (err error) {
+		rules, err = f.fw.InstanceIngressRules(inst, machineId)
+		return err
+	})
+	return rules, err
 }
 
 type firewallerBase struct {
@@ -418,6 +487,12 @@ func (c *firewallerBase) jujuGroupRegexp() string {
 	return fmt.Sprintf("juju-.*-%v", cfg.UUID())
 }
 
+// modelGroupRegexp matches only the model's juju group, which holds
+// the model's egress rules.
+func (c *firewallerBase) modelGroupRegexp() string {
+	return fmt.Sprintf("^%s$", c.jujuGroupRegexp())
+}
+
 func (c *firewallerBase) globalGroupRegexp() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupRegexp())
 }
@@ -457,6 +532,13 @@ func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPor
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Neutron allows all outgoing traffic from new groups, which
+	// would override the model's egress rules.
+	if hasEgressRules(jujuGroup) {
+		if err := c.removeDefaultEgress(fmt.Sprintf("^%s$", regexp.QuoteMeta(machineGroup.Name))); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	groups := []string{jujuGroup.Name, machineGroup.Name}
 	if c.environ.ecfg().useDefaultSecurityGroup() {
 		groups = append(groups, "default")
@@ -861,6 +943,9 @@ func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule n
 		secGroupRule.PortRangeMin == nil || *secGroupRule.PortRangeMin == 0 {
 		return false
 	}
+	if secGroupRule.Direction == "egress" {
+		return false
+	}
 	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
 		*secGroupRule.PortRangeMin == rule.FromPort &&
 		*secGroupRule.PortRangeMax == rule.ToPort
@@ -869,17 +954,31 @@ func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule n
 	}
 	// The ports match, so if the security group RemoteIPPrefix matches *any* of the
 	// rule's source ranges, then that's a match.
+	remotePrefix := secGroupRuleRemotePrefix(secGroupRule)
 	if len(rule.SourceCIDRs) == 0 {
-		return secGroupRule.RemoteIPPrefix == "" || secGroupRule.RemoteIPPrefix == "0.0.0.0/0"
+		return remotePrefix == "0.0.0.0/0"
 	}
 	for _, r := range rule.SourceCIDRs {
-		if r == secGroupRule.RemoteIPPrefix {
+		if r == remotePrefix {
 			return true
 		}
 	}
 	return false
 }
 
+// secGroupRuleRemotePrefix returns the CIDR a security group rule
+// applies to. Neutron leaves RemoteIPPrefix empty for rules that
+// apply to every address of the rule's ethertype.
+func secGroupRuleRemotePrefix(secGroupRule neutron.SecurityGroupRuleV2) string {
+	if secGroupRule.RemoteIPPrefix != "" {
+		return secGroupRule.RemoteIPPrefix
+	}
+	if secGroupRule.EthernetType == "IPv6" {
+		return "::/0"
+	}
+	return "0.0.0.0/0"
+}
+
 func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network.IngressRule) error {
 	if len(rules) == 0 {
 		return nil
@@ -927,10 +1026,7 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 			portRange.ToPort = *p.PortRangeMax
 		}
 		// Record the RemoteIPPrefix for the port range.
-		remotePrefix := p.RemoteIPPrefix
-		if remotePrefix == "" {
-			remotePrefix = "0.0.0.0/0"
-		}
+		remotePrefix := secGroupRuleRemotePrefix(p)
 		sourceCIDRs, ok := portSourceCIDRs[portRange]
 		if !ok {
 			sourceCIDRs = &[]string{}
@@ -954,6 +1050,222 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+// OpenEgress implements egressFirewaller. The model's egress rules are
+// held in the model's juju group. Neutron allows all outgoing traffic
+// from new security groups, so once egress is restricted those default
+// rules are removed from all of the model's groups. Egress cannot be
+// restricted for machines which are also in the "default" group.
+func (c *neutronFirewaller) OpenEgress(rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, ruleInfo := range egressRulesToRuleInfo(group.Id, rules) {
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(ruleInfo); err != nil {
+			return errors.Annotate(err, "cannot create egress rule")
+		}
+	}
+	if err := c.removeDefaultEgress(c.jujuGroupRegexp()); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress in model group: %v", rules)
+	return nil
+}
+
+// CloseEgress implements egressFirewaller. Once no egress rules remain,
+// all outgoing traffic is allowed from the model's juju group again.
+func (c *neutronFirewaller) CloseEgress(rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range rules {
+		for _, p := range group.Rules {
+			if !secGroupMatchesEgressRule(p, rule) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	remaining, err := c.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(remaining) == 0 {
+		for _, ruleInfo := range defaultEgressRuleInfo(group.Id) {
+			if _, err := neutronClient.CreateSecurityGroupRuleV2(ruleInfo); err != nil {
+				return errors.Annotate(err, "cannot restore default egress rule")
+			}
+		}
+	}
+	logger.Infof("closed egress in model group: %v", rules)
+	return nil
+}
+
+// EgressRules implements egressFirewaller.
+func (c *neutronFirewaller) EgressRules() ([]network.EgressRule, error) {
+	group, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	var portRanges []network.PortRange
+	destinationCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		if p.Direction != "egress" || isDefaultEgressRule(p) || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{Protocol: *p.IPProtocol, FromPort: -1, ToPort: -1}
+		if portRange.Protocol != "icmp" {
+			if p.PortRangeMin != nil {
+				portRange.FromPort = *p.PortRangeMin
+			}
+			if p.PortRangeMax != nil {
+				portRange.ToPort = *p.PortRangeMax
+			}
+		}
+		if _, ok := destinationCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		destinationCIDRs[portRange] = append(destinationCIDRs[portRange], p.RemoteIPPrefix)
+	}
+	var rules []network.EgressRule
+	for _, portRange := range portRanges {
+		cidrs := destinationCIDRs[portRange]
+		sort.Strings(cidrs)
+		rule, err := network.NewEgressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// removeDefaultEgress removes the rules allowing all outgoing traffic
+// from the security groups matching the given regexp.
+func (c *neutronFirewaller) removeDefaultEgress(nameRegExp string) error {
+	re, err := regexp.Compile(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	groups, err := neutronClient.ListSecurityGroupsV2()
+	if err != nil {
+		return errors.Annotate(err, "cannot list security groups")
+	}
+	for _, group := range groups {
+		if !re.MatchString(group.Name) {
+			continue
+		}
+		for _, p := range group.Rules {
+			if !isDefaultEgressRule(p) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Annotatef(err, "cannot remove default egress rule from security group %q", group.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// isDefaultEgressRule reports whether the rule is one of those allowing
+// all outgoing traffic, which Neutron adds to new security groups.
+func isDefaultEgressRule(rule neutron.SecurityGroupRuleV2) bool {
+	return rule.Direction == "egress" && rule.IPProtocol == nil && rule.RemoteIPPrefix == ""
+}
+
+// hasEgressRules reports whether the group holds any egress rules other
+// than the defaults.
+func hasEgressRules(group neutron.SecurityGroupV2) bool {
+	for _, p := range group.Rules {
+		if p.Direction == "egress" && !isDefaultEgressRule(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultEgressRuleInfo returns the rules allowing all outgoing traffic,
+// as added by Neutron to new security groups.
+func defaultEgressRuleInfo(groupId string) []neutron.RuleInfoV2 {
+	return []neutron.RuleInfoV2{{
+		Direction:     "egress",
+		ParentGroupId: groupId,
+	}, {
+		Direction:     "egress",
+		ParentGroupId: groupId,
+		EthernetType:  "IPv6",
+	}}
+}
+
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			IPProtocol:    r.Protocol,
+		}
+		// Neutron uses the port range of ICMP rules for the ICMP type
+		// and code, so leave them unset to allow all ICMP traffic.
+		if r.Protocol != "icmp" {
+			ruleInfo.PortRangeMin = r.FromPort
+			ruleInfo.PortRangeMax = r.ToPort
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = cidr
+			ruleInfo.EthernetType = ""
+			if strings.Contains(cidr, ":") {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// secGroupMatchesEgressRule checks if supplied neutron security group rule
+// matches the egress rule.
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.EgressRule) bool {
+	if secGroupRule.Direction != "egress" || secGroupRule.IPProtocol == nil ||
+		*secGroupRule.IPProtocol != rule.Protocol {
+		return false
+	}
+	if rule.Protocol != "icmp" {
+		if secGroupRule.PortRangeMin == nil || *secGroupRule.PortRangeMin != rule.FromPort ||
+			secGroupRule.PortRangeMax == nil || *secGroupRule.PortRangeMax != rule.ToPort {
+			return false
+		}
+	}
+	destinationCIDRs := rule.DestinationCIDRs
+	if len(destinationCIDRs) == 0 {
+		destinationCIDRs = []string{"0.0.0.0/0"}
+	}
+	for _, cidr := range destinationCIDRs {
+		if cidr == secGroupRule.RemoteIPPrefix {
+			return true
+		}
+	}
+	return false
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
@@ -523,6 +523,22 @@ func (s *localServerSuite) TestStartInstanceNetworkUnknownLabel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no networks exist with label .*")
 }
 
+func (s *localServerSuite) TestCreateNetworkUnknownLabel(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network": "no-network-with-this-label",
+	})
+	err := env.Create(environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot use network "no-network-with-this-label": no networks exist with label .*`)
+}
+
+func (s *localServerSuite) TestCreateNetworkLabel(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"network": "net",
+	})
+	err := env.Create(environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *localServerSuite) TestStartInstanceExternalNetworkUnknownLabel(c *gc.C) {
 	cfg, err := s.env.Config().Apply(coretesting.Attrs{
 		// A label that has no related network in the neutron test service
@@ -590,6 +606,85 @@ func (s *localServerSuite) TestStartInstanceNetworkNoExternalNetInAZ(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot allocate a public IP as needed: could not find an external network in availability zone.*")
 }
 
+func (s *localServerSuite) TestStartInstancePublicIPErrorTerminatesServer(c *gc.C) {
+	cfg, err := s.env.Config().Apply(coretesting.Attrs{
+		"network":         "net", // az = nova
+		"use-floating-ip": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, _, err = testing.StartInstance(s.env, s.ControllerUUID, "100")
+	c.Assert(err, gc.ErrorMatches, "cannot allocate a public IP as needed: .*")
+
+	// The server that was started must not be left behind.
+	insts, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 0)
+}
+
+func (s *localServerSuite) TestStartInstanceBootFromVolume(c *gc.C) {
+	adapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: "root-volume", Status: "creating"}, nil
+		},
+	}
+	overrideCinderProvider(c, &s.CleanupSuite, adapter)
+	novaClient := openstack.GetNovaClient(s.env)
+	var volumeIds []string
+	s.PatchValue(openstack.RunServerWithBlockDevices, openstack.RunServerWithBlockDevicesFunc(
+		func(opts nova.RunServerOpts, ids []string) (*nova.Entity, error) {
+			c.Check(opts.ImageId, gc.Equals, "")
+			volumeIds = ids
+			// The test service cannot boot from volumes.
+			opts.ImageId = "1"
+			return novaClient.RunServer(opts)
+		},
+	))
+
+	cons := constraints.MustParse("root-disk=20G root-disk-source=fast")
+	_, hc := testing.AssertStartInstanceWithConstraints(c, s.env, s.ControllerUUID, "100", cons)
+	c.Check(*hc.RootDisk, gc.Equals, uint64(20*1024))
+	c.Check(volumeIds, jc.DeepEquals, []string{"root-volume"})
+	adapter.CheckCallNames(c, "CreateVolume", "GetVolume")
+	args := adapter.Calls()[0].Args[0].(cinder.CreateVolumeVolumeParams)
+	c.Check(args.Size, gc.Equals, 20)
+	c.Check(args.VolumeType, gc.Equals, "fast")
+	c.Check(args.ImageRef, gc.Not(gc.Equals), "")
+}
+
+func (s *localServerSuite) TestStartInstanceBootFromVolumeErrorDeletesVolume(c *gc.C) {
+	adapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: "root-volume", Status: "creating"}, nil
+		},
+	}
+	overrideCinderProvider(c, &s.CleanupSuite, adapter)
+	s.PatchValue(openstack.RunServerWithBlockDevices, openstack.RunServerWithBlockDevicesFunc(
+		func(nova.RunServerOpts, []string) (*nova.Entity, error) {
+			return nil, errors.New("boom")
+		},
+	))
+
+	cons := constraints.MustParse("root-disk-source=volume")
+	_, _, _, err := testing.StartInstanceWithConstraints(s.env, s.ControllerUUID, "100", cons)
+	c.Assert(err, gc.ErrorMatches, "cannot run instance: boom")
+	adapter.CheckCallNames(c, "CreateVolume", "GetVolume", "DeleteVolume")
+	adapter.CheckCall(c, 2, "DeleteVolume", "root-volume")
+	args := adapter.Calls()[0].Args[0].(cinder.CreateVolumeVolumeParams)
+	c.Check(args.VolumeType, gc.Equals, "")
+}
+
+func (s *localServerSuite) TestStartInstanceRootDiskSourceLocal(c *gc.C) {
+	adapter := &mockAdapter{}
+	overrideCinderProvider(c, &s.CleanupSuite, adapter)
+
+	cons := constraints.MustParse("root-disk-source=local")
+	testing.AssertStartInstanceWithConstraints(c, s.env, s.ControllerUUID, "100", cons)
+	adapter.CheckNoCalls(c)
+}
+
 func (s *localServerSuite) TestStartInstancePortSecurityEnabled(c *gc.C) {
 	cfg, err := s.env.Config().Apply(coretesting.Attrs{
 		"network": "net",
@@ -1158,6 +1253,7 @@ func (s *localServerSuite) TestSubnetsFindAll(c *gc.C) {
 		expectedSubnetMap[network.Id(os.Id)] = network.SubnetInfo{
 			CIDR:              os.Cidr,
 			ProviderId:        network.Id(os.Id),
+			ProviderNetworkId: network.Id(os.NetworkId),
 			VLANTag:           0,
 			AvailabilityZones: net.AvailabilityZones,
 			SpaceProviderId:   "",
@@ -1332,6 +1428,18 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `availability zone "test-unknown" not valid`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceSpaceNoSubnetsInZone(c *gc.C) {
+	err := t.env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   "zone=test-available",
+		Constraints: constraints.MustParse("spaces=db"),
+		SubnetsToZones: map[network.Id][]string{
+			"subnet-0": {"az1"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `space "db" has no subnets in availability zone "test-available"`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceAvailZonesUnsupported(c *gc.C) {
 	t.srv.Nova.SetAvailabilityZones() // no availability zone support
 	placement := "zone=test-unknown"
@@ -1996,6 +2104,42 @@ func (t *localServerSuite) TestStartInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
 }
 
+func (t *localServerSuite) TestStartInstanceSpaceConstraint(c *gc.C) {
+	subnets, err := openstack.GetNeutronClient(t.env).ListSubnetsV2()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.Not(gc.HasLen), 0)
+	_, err = t.testStartInstanceSpaceConstraint(c, map[network.Id][]string{
+		network.Id(subnets[0].Id): {"test-available"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestStartInstanceSpaceConstraintNoSubnetsInZone(c *gc.C) {
+	_, err := t.testStartInstanceSpaceConstraint(c, map[network.Id][]string{
+		"subnet-0": {"az1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `subnets in AZ "test-available" not found`)
+	// Another zone may have subnets in the space.
+	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+}
+
+func (t *localServerSuite) testStartInstanceSpaceConstraint(c *gc.C, subnetsToZones map[network.Id][]string) (instance.Instance, error) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:   t.ControllerUUID,
+		AvailabilityZone: "test-available",
+		Constraints:      constraints.MustParse("spaces=db"),
+		SubnetsToZones:   subnetsToZones,
+	}
+	result, err := testing.StartInstanceWithParams(t.env, "1", params)
+	if err != nil {
+		return nil, err
+	}
+	return result.Instance, nil
+}
+
 func (t *localServerSuite) testStartInstanceAvailZone(c *gc.C, zone string) (instance.Instance, error) {
 	err := bootstrapEnv(c, t.env)
 	c.Assert(err, jc.ErrorIsNil)
@@ -314,6 +314,7 @@ func makeSubnetInfo(neutron *neutron.Client, subnet neutron.SubnetV2) (network.S
 	info := network.SubnetInfo{
 		CIDR:              subnet.Cidr,
 		ProviderId:        network.Id(subnet.Id),
+		ProviderNetworkId: network.Id(subnet.NetworkId),
 		VLANTag:           0,
 		AvailabilityZones: net.AvailabilityZones,
 		SpaceProviderId:   "",
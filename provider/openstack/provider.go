@@ -18,6 +18,7 @@ import (
 	"github.com/juju/retry"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/goose.v2/cinder"
 	"gopkg.in/goose.v2/client"
@@ -160,6 +161,10 @@ func (p EnvironProvider) Open(args environs.OpenParams) (environs.Environ, error
 		configurator: p.Configurator,
 		flavorFilter: p.FlavorFilter,
 	}
+	e.throttle, err = newThrottle(e.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	e.firewaller = p.FirewallerFactory.GetFirewaller(e)
 
 	var networking Networking = &switchingNetworking{env: e}
@@ -294,6 +299,10 @@ type Environ struct {
 	// Clock is defined so it can be replaced for testing
 	clock clock.Clock
 
+	// throttle limits the rate of calls made to the OpenStack APIs
+	// by instance polling and the firewaller.
+	throttle *common.Throttle
+
 	publicIPMutex sync.Mutex
 }
 
@@ -323,7 +332,7 @@ var _ instance.Instance = (*openstackInstance)(nil)
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
 	defer inst.mu.Unlock()
-	server, err := inst.e.nova().GetServer(inst.serverDetail.Id)
+	server, err := inst.e.getServer(inst.serverDetail.Id)
 	if err != nil {
 		return err
 	}
@@ -394,7 +403,7 @@ func (inst *openstackInstance) hardwareCharacteristics() *instance.HardwareChara
 func (inst *openstackInstance) getAddresses() (map[string][]nova.IPAddress, error) {
 	addrs := inst.getServerDetail().Addresses
 	if len(addrs) == 0 {
-		server, err := inst.e.nova().GetServer(string(inst.Id()))
+		server, err := inst.e.getServer(string(inst.Id()))
 		if err != nil {
 			return nil, err
 		}
@@ -496,6 +505,8 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.Spot,
+	constraints.InstanceProfile,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -605,7 +616,11 @@ func (e *Environ) parsePlacement(placement string) (*openstackPlacement, error)
 
 // PrecheckInstance is defined on the environs.InstancePrechecker interface.
 func (e *Environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	if _, err := e.deriveAvailabilityZone(args.Placement, args.VolumeAttachments); err != nil {
+	zone, err := e.deriveAvailabilityZone(args.Placement, args.VolumeAttachments)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.PrecheckSpaceSubnets(args.Constraints, args.SubnetsToZones, zone); err != nil {
 		return errors.Trace(err)
 	}
 	if !args.Constraints.HasInstanceType() {
@@ -644,6 +659,20 @@ func (e *Environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
 `,
 		)
 	}
+	return errors.Trace(e.validateNetwork())
+}
+
+// validateNetwork returns an error if the model is configured to use
+// an existing network that cannot be found, so that bootstrap and
+// add-model fail early rather than when the first instance starts.
+func (e *Environ) validateNetwork() error {
+	networkName := e.ecfg().network()
+	if networkName == "" {
+		return nil
+	}
+	if _, err := e.networking.ResolveNetwork(networkName, false); err != nil {
+		return errors.Annotatef(err, "cannot use network %q", networkName)
+	}
 	return nil
 }
 
@@ -653,6 +682,9 @@ func (e *Environ) Create(environs.CreateParams) error {
 	if err := authenticateClient(e.client()); err != nil {
 		return err
 	}
+	if err := e.validateNetwork(); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(axw) 2016-08-04 #1609643
 	// Create global security group(s) here.
 	return nil
@@ -718,6 +750,9 @@ func newCredentials(spec environs.CloudSpec) (identity.Credentials, identity.Aut
 		cred.UserDomain = credAttrs[CredAttrUserDomainName]
 		cred.Domain = credAttrs[CredAttrDomainName]
 		authMode = identity.AuthUserPass
+		// Domains are a Keystone v3 concept. If no tenant is
+		// specified, a token scoped to the domain is requested
+		// instead of one scoped to a project.
 		if cred.Domain != "" || cred.UserDomain != "" || cred.ProjectDomain != "" {
 			authMode = identity.AuthUserPassV3
 		}
@@ -954,11 +989,18 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
+	cons := args.Constraints
+	volumeType, bootFromVolume := bootVolumeType(cons)
+	if bootFromVolume {
+		// The root disk is a volume of the requested size, so
+		// the flavor's own disk does not matter.
+		cons.RootDisk = nil
+	}
 	spec, err := findInstanceSpec(e, &instances.InstanceConstraint{
 		Region:      e.cloud.Region,
 		Series:      series,
 		Arches:      arches,
-		Constraints: args.Constraints,
+		Constraints: cons,
 	}, args.ImageMetadata)
 	if err != nil {
 		return nil, common.ZoneIndependentError(err)
@@ -992,7 +1034,18 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		return nil, common.ZoneIndependentError(errors.Annotate(err, "getting initial networks"))
 	}
 	usingNetwork := e.ecfg().network()
-	if usingNetwork != "" {
+	if len(args.Constraints.IncludeSpaces()) > 0 {
+		// The networks of the space's subnets are used in
+		// place of the configured network.
+		spaceNetworks, err := e.spaceNetworks(args.AvailabilityZone, args.SubnetsToZones)
+		if errors.IsNotFound(err) {
+			// The space may have subnets in another zone.
+			return nil, errors.Trace(err)
+		} else if err != nil {
+			return nil, common.ZoneIndependentError(err)
+		}
+		networks = append(networks, spaceNetworks...)
+	} else if usingNetwork != "" {
 		networkId, err := e.networking.ResolveNetwork(usingNetwork, false)
 		if err != nil {
 			return nil, common.ZoneIndependentError(err)
@@ -1085,9 +1138,14 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		attempts utils.AttemptStrategy,
 		client *nova.Client,
 		instanceOpts nova.RunServerOpts,
+		blockDevices []blockDeviceMapping,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			if len(blockDevices) > 0 {
+				server, err = runServerWithBlockDevices(e.client(), instanceOpts, blockDevices)
+			} else {
+				server, err = client.RunServer(instanceOpts)
+			}
 			if err != nil {
 				break
 			}
@@ -1126,8 +1184,46 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 	e.configurator.ModifyRunServerOptions(&opts)
 
-	server, err := tryStartNovaInstance(shortAttempt, e.nova(), opts)
+	instType := spec.InstanceType
+	var blockDevices []blockDeviceMapping
+	var deleteBootVolume func()
+	if bootFromVolume {
+		storageAdapter, err := newOpenstackStorage(e)
+		if err != nil {
+			return nil, common.ZoneIndependentError(errors.Annotate(err, "cannot boot from volume"))
+		}
+		size := bootVolumeSize(args.Constraints, instType.RootDisk, series)
+		volume, err := createBootVolume(
+			storageAdapter, machineName, spec.Image.Id, volumeType,
+			args.AvailabilityZone, size, args.InstanceConfig.Tags,
+		)
+		if err != nil {
+			return nil, common.ZoneIndependentError(errors.Annotate(err, "cannot create root disk volume"))
+		}
+		logger.Debugf("created root disk volume %q of %d GiB", volume.ID, size)
+		deleteBootVolume = func() {
+			if err := storageAdapter.DeleteVolume(volume.ID); err != nil {
+				// ignore the failure at this stage, just log it
+				logger.Debugf("failed to delete root disk volume %q: %v", volume.ID, err)
+			}
+		}
+		// The volume is deleted along with the server.
+		blockDevices = []blockDeviceMapping{{
+			BootIndex:           0,
+			UUID:                volume.ID,
+			SourceType:          "volume",
+			DestinationType:     "volume",
+			DeleteOnTermination: true,
+		}}
+		opts.ImageId = ""
+		instType.RootDisk = size * 1024
+	}
+
+	server, err := tryStartNovaInstance(shortAttempt, e.nova(), opts, blockDevices)
 	if err != nil {
+		if deleteBootVolume != nil {
+			deleteBootVolume()
+		}
 		// 'No valid host available' is typically a resource error,
 		// let the provisioner know it is a good idea to try another
 		// AZ if available.
@@ -1139,8 +1235,18 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		return nil, err
 	}
 
+	// If anything fails from here on, the server must be
+	// terminated so that it is not leaked.
+	terminateServer := func() {
+		if err := e.terminateInstances([]instance.Id{instance.Id(server.Id)}); err != nil {
+			// ignore the failure at this stage, just log it
+			logger.Debugf("failed to terminate instance %q: %v", server.Id, err)
+		}
+	}
+
 	detail, err := e.nova().GetServer(server.Id)
 	if err != nil {
+		terminateServer()
 		return nil, common.ZoneIndependentError(errors.Annotate(err, "cannot get started instance"))
 	}
 
@@ -1148,7 +1254,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		e:            e,
 		serverDetail: detail,
 		arch:         &spec.Image.Arch,
-		instType:     &spec.InstanceType,
+		instType:     &instType,
 	}
 	logger.Infof("started instance %q", inst.Id())
 	withPublicIP := e.ecfg().useFloatingIP()
@@ -1161,16 +1267,14 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		var publicIP *string
 		logger.Debugf("allocating public IP address for openstack node")
 		if fip, err := e.networking.AllocatePublicIP(inst.Id()); err != nil {
+			terminateServer()
 			return nil, common.ZoneIndependentError(errors.Annotate(err, "cannot allocate a public IP as needed"))
 		} else {
 			publicIP = fip
 			logger.Infof("allocated public IP %s", *publicIP)
 		}
 		if err := e.assignPublicIP(publicIP, string(inst.Id())); err != nil {
-			if err := e.terminateInstances([]instance.Id{inst.Id()}); err != nil {
-				// ignore the failure at this stage, just log it
-				logger.Debugf("failed to terminate instance %q: %v", inst.Id(), err)
-			}
+			terminateServer()
 			return nil, common.ZoneIndependentError(errors.Annotatef(err,
 				"cannot assign public address %s to instance %q",
 				publicIP, inst.Id(),
@@ -1206,6 +1310,44 @@ func (e *Environ) deriveAvailabilityZone(
 	return instPlacement.zoneName, nil
 }
 
+// spaceNetworks returns the networks that an instance in the specified
+// availability zone should be connected to in order to satisfy a spaces
+// constraint. Each of the networks contains at least one of the space's
+// subnets in the zone. An error satisfying errors.IsNotFound is returned
+// if the space has no subnets in the zone.
+//
+// Nova chooses the subnet from which to allocate an instance's address,
+// so a network containing subnets from more than one space should not be
+// used with spaces constraints.
+func (e *Environ) spaceNetworks(zone string, subnetsToZones map[network.Id][]string) ([]nova.ServerNetworks, error) {
+	if len(subnetsToZones) == 0 {
+		return nil, errors.New("no subnets with provider IDs and availability zones in space")
+	}
+	subnetIds, err := common.SubnetIdsForZone(subnetsToZones, zone)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := e.networking.Subnets(instance.UnknownId, subnetIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting space subnets")
+	}
+	var networks []nova.ServerNetworks
+	seen := set.NewStrings()
+	for _, subnet := range subnets {
+		networkId := string(subnet.ProviderNetworkId)
+		if networkId == "" || seen.Contains(networkId) {
+			continue
+		}
+		seen.Add(networkId)
+		logger.Debugf("using network id %q for subnet %q", networkId, subnet.ProviderId)
+		networks = append(networks, nova.ServerNetworks{NetworkId: networkId})
+	}
+	if len(networks) == 0 {
+		return nil, errors.Errorf("no networks found for subnets %v", subnetIds)
+	}
+	return networks, nil
+}
+
 func validateAvailabilityZoneConsistency(instanceZone, volumeAttachmentsZone string) error {
 	if volumeAttachmentsZone != "" && instanceZone != volumeAttachmentsZone {
 		return errors.Errorf(
@@ -1294,7 +1436,7 @@ func (e *Environ) listServers(ids []instance.Id) ([]nova.ServerDetail, error) {
 	if len(ids) == 1 {
 		// Common case, single instance, may return NotFound
 		var maybeServer *nova.ServerDetail
-		maybeServer, err := e.nova().GetServer(string(ids[0]))
+		maybeServer, err := e.getServer(string(ids[0]))
 		if err != nil {
 			return nil, err
 		}
@@ -1330,7 +1472,7 @@ func (e *Environ) listServers(ids []instance.Id) ([]nova.ServerDetail, error) {
 // updateFloatingIPAddresses updates the instances with any floating IP address
 // that have been assigned to those instances.
 func (e *Environ) updateFloatingIPAddresses(instances map[string]instance.Instance) error {
-	servers, err := e.nova().ListServersDetail(jujuMachineFilter())
+	servers, err := e.listServersDetail(jujuMachineFilter())
 	if err != nil {
 		return err
 	}
@@ -1493,7 +1635,7 @@ type tagValue struct {
 // allControllerManagedInstances returns all instances managed by this
 // environment's controller, matching the optionally specified filter.
 func (e *Environ) allInstances(tagFilter tagValue, updateFloatingIPAddresses bool) ([]instance.Instance, error) {
-	servers, err := e.nova().ListServersDetail(jujuMachineFilter())
+	servers, err := e.listServersDetail(jujuMachineFilter())
 	if err != nil {
 		return nil, err
 	}
@@ -1613,6 +1755,10 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 		}
 		for _, sr := range sourceCIDRs {
 			ruleInfo.RemoteIPPrefix = sr
+			ruleInfo.EthernetType = ""
+			if strings.Contains(sr, ":") {
+				ruleInfo.EthernetType = "IPv6"
+			}
 			result = append(result, ruleInfo)
 		}
 	}
@@ -1631,6 +1777,33 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+// OpenEgress implements environs.EgressFirewaller.
+func (e *Environ) OpenEgress(rules []network.EgressRule) error {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.OpenEgress(rules)
+}
+
+// CloseEgress implements environs.EgressFirewaller.
+func (e *Environ) CloseEgress(rules []network.EgressRule) error {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.CloseEgress(rules)
+}
+
+// EgressRules implements environs.EgressFirewaller.
+func (e *Environ) EgressRules() ([]network.EgressRule, error) {
+	fw, ok := e.firewaller.(egressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	return fw.EgressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -1704,8 +1877,14 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	if spec.Credential == nil {
 		return errors.NotValidf("missing credential")
 	}
+	credAttrs := spec.Credential.Attributes()
 	switch authType := spec.Credential.AuthType(); authType {
 	case cloud.UserPassAuthType:
+		// Without a tenant, a domain-scoped token is requested,
+		// which requires a domain to scope it to.
+		if credAttrs[CredAttrTenantName] == "" && credAttrs[CredAttrDomainName] == "" {
+			return errors.NotValidf("credential without %s or %s", CredAttrTenantName, CredAttrDomainName)
+		}
 	case cloud.AccessKeyAuthType:
 	default:
 		return errors.NotSupportedf("%q auth-type", authType)
@@ -1733,7 +1912,15 @@ func (e *Environ) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo
 
 // SupportsSpaces is specified on environs.Networking.
 func (e *Environ) SupportsSpaces() (bool, error) {
-	return false, nil
+	// Spaces are mapped to Neutron subnets; there is
+	// no support for them with legacy Nova networking.
+	client := e.client()
+	if !client.IsAuthenticated() {
+		if err := authenticateClient(client); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	return e.supportsNeutron(), nil
 }
 
 // SupportsSpaceDiscovery is specified on environs.Networking.
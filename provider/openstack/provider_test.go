@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/neutron"
 	"gopkg.in/goose.v2/nova"
 	"gopkg.in/yaml.v2"
@@ -261,6 +262,26 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			RemoteIPPrefix: "0.0.0.0/0",
 			ParentGroupId:  groupId,
 		}},
+	}, {
+		about: "IPv6 source range",
+		rules: []network.IngressRule{network.MustNewIngressRule(
+			"tcp", 80, 80, "0.0.0.0/0", "::/0")},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   80,
+			PortRangeMax:   80,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+		}, {
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMin:   80,
+			PortRangeMax:   80,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+			ParentGroupId:  groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
@@ -368,6 +389,26 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 			RemoteIPPrefix: "192.168.100.0/24",
 		},
 		expected: false,
+	}, {
+		about: "empty RemoteIPPrefix matching IPv6 rule",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85, "::/0"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_85,
+			EthernetType: "IPv6",
+		},
+		expected: true,
+	}, {
+		about: "empty RemoteIPPrefix not matching IPv4 rule",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_85,
+			EthernetType: "IPv6",
+		},
+		expected: false,
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -375,6 +416,84 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	}
 }
 
+func (*localTests) TestSecGroupMatchesEgressRule(c *gc.C) {
+	proto_tcp := "tcp"
+	proto_icmp := "icmp"
+	port_80 := 80
+	port_85 := 85
+
+	testCases := []struct {
+		about        string
+		rule         network.EgressRule
+		secGroupRule neutron.SecurityGroupRuleV2
+		expected     bool
+	}{{
+		about: "matching destination",
+		rule:  network.MustNewEgressRule(proto_tcp, 80, 85, "10.0.0.0/8", "192.168.1.0/24"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "192.168.1.0/24",
+		},
+		expected: true,
+	}, {
+		about: "ingress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "ingress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		expected: false,
+	}, {
+		about: "default egress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 80, 85),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction: "egress",
+		},
+		expected: false,
+	}, {
+		about: "mismatched port range",
+		rule:  network.MustNewEgressRule(proto_tcp, 80, 80),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		expected: false,
+	}, {
+		about: "icmp ignores ports",
+		rule:  network.MustNewEgressRule(proto_icmp, -1, -1, "10.0.0.0/8"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_icmp,
+			RemoteIPPrefix: "10.0.0.0/8",
+		},
+		expected: true,
+	}, {
+		about: "non-matching destination",
+		rule:  network.MustNewEgressRule(proto_tcp, 80, 85, "10.0.0.0/8"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_80,
+			PortRangeMax:   &port_85,
+			RemoteIPPrefix: "192.168.1.0/24",
+		},
+		expected: false,
+	}}
+	for i, t := range testCases {
+		c.Logf("test %d: %s", i, t.about)
+		c.Check(SecGroupMatchesEgressRule(t.secGroupRule, t.rule), gc.Equals, t.expected)
+	}
+}
+
 func (s *localTests) TestDetectRegionsNoRegionName(c *gc.C) {
 	_, err := s.detectRegions(c)
 	c.Assert(err, gc.ErrorMatches, "OS_REGION_NAME environment variable not set")
@@ -584,3 +703,37 @@ func (s *providerUnitTests) TestIdentityClientVersion_ParsesGoodURL(c *gc.C) {
 	_, err = identityClientVersion("https://keystone.internal/")
 	c.Check(err, jc.ErrorIsNil)
 }
+
+func (s *providerUnitTests) TestValidateCloudSpecDomainScoped(c *gc.C) {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		CredAttrUserName:   "bob",
+		CredAttrPassword:   "dobbs",
+		CredAttrDomainName: "engineering",
+	})
+	spec := environs.CloudSpec{
+		Type:       "openstack",
+		Name:       "openstack",
+		Endpoint:   "https://keystone.internal/v3",
+		Credential: &cred,
+	}
+	c.Assert(validateCloudSpec(spec), jc.ErrorIsNil)
+
+	credentials, authMode := newCredentials(spec)
+	c.Assert(authMode, gc.Equals, identity.AuthUserPassV3)
+	c.Assert(credentials.TenantName, gc.Equals, "")
+	c.Assert(credentials.Domain, gc.Equals, "engineering")
+}
+
+func (s *providerUnitTests) TestValidateCloudSpecUnscoped(c *gc.C) {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		CredAttrUserName: "bob",
+		CredAttrPassword: "dobbs",
+	})
+	err := validateCloudSpec(environs.CloudSpec{
+		Type:       "openstack",
+		Name:       "openstack",
+		Endpoint:   "https://keystone.internal/v3",
+		Credential: &cred,
+	})
+	c.Assert(err, gc.ErrorMatches, "credential without tenant-name or domain-name not valid")
+}
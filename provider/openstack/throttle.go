@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/goose.v2/nova"
+
+	"github.com/juju/juju/provider/common"
+)
+
+// The OpenStack API families throttled separately. Goose already
+// retries requests that are rejected with a Retry-After header, so
+// the throttle only keeps instance polling and the firewaller within
+// the cloud's rate limits.
+const (
+	apiFamilyServers        = "servers"
+	apiFamilySecurityGroups = "security-groups"
+)
+
+// newThrottle returns a throttle for calls to the OpenStack APIs.
+func newThrottle(clock clock.Clock) (*common.Throttle, error) {
+	config := common.DefaultThrottleConfig()
+	config.Clock = clock
+	config.Families = map[string]common.ThrottleRate{
+		apiFamilyServers:        {Rate: 10, Burst: 50},
+		apiFamilySecurityGroups: {Rate: 5, Burst: 20},
+	}
+	throttle, err := common.NewThrottle(config)
+	return throttle, errors.Trace(err)
+}
+
+// listServersDetail calls nova.ListServersDetail, throttled as a
+// servers call.
+func (e *Environ) listServersDetail(filter *nova.Filter) (servers []nova.ServerDetail, err error) {
+	err = e.throttle.Call(apiFamilyServers, func() error {
+		servers, err = e.nova().ListServersDetail(filter)
+		return err
+	})
+	return servers, err
+}
+
+// getServer calls nova.GetServer, throttled as a servers call.
+func (e *Environ) getServer(serverId string) (server *nova.ServerDetail, err error) {
+	err = e.throttle.Call(apiFamilyServers, func() error {
+		server, err = e.nova().GetServer(serverId)
+		return err
+	})
+	return server, err
+}
@@ -650,6 +650,9 @@ func (o *OracleEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.Spot,
+		constraints.InstanceProfile,
+		constraints.RootDiskSource,
 	}
 
 	// we choose to use the default validator implementation
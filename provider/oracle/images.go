@@ -17,7 +17,7 @@ import (
 
 var windowsServerMap = map[string]string{
 	"Microsoft_Windows_Server_2012_R2": "win2012r2",
-	"Microsoft_Windows_Server_2008_R2": "win2018r2",
+	"Microsoft_Windows_Server_2008_R2": "win2008r2",
 }
 
 // instanceTypes returns all oracle cloud shapes and wraps them into instance.InstanceType
@@ -8,13 +8,17 @@ import (
 	"github.com/juju/schema"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
 )
 
 // The vmware-specific config keys.
 const (
-	cfgPrimaryNetwork  = "primary-network"
-	cfgExternalNetwork = "external-network"
-	cfgDatastore       = "datastore"
+	cfgPrimaryNetwork       = "primary-network"
+	cfgExternalNetwork      = "external-network"
+	cfgDatastore            = "datastore"
+	cfgResourcePool         = "resource-pool"
+	cfgVMFolder             = "vm-folder"
+	cfgDiskProvisioningType = "disk-provisioning-type"
 )
 
 // configFields is the spec for each vmware config value's type.
@@ -23,16 +27,29 @@ var (
 		cfgExternalNetwork: schema.String(),
 		cfgDatastore:       schema.String(),
 		cfgPrimaryNetwork:  schema.String(),
+		cfgResourcePool:    schema.String(),
+		cfgVMFolder:        schema.String(),
+		cfgDiskProvisioningType: schema.OneOf(
+			schema.Const(string(vsphereclient.DiskTypeThin)),
+			schema.Const(string(vsphereclient.DiskTypeThick)),
+			schema.Const(string(vsphereclient.DiskTypeThickEagerZero)),
+		),
 	}
 
 	configDefaults = schema.Defaults{
-		cfgExternalNetwork: "",
-		cfgDatastore:       schema.Omit,
-		cfgPrimaryNetwork:  schema.Omit,
+		cfgExternalNetwork:      "",
+		cfgDatastore:            schema.Omit,
+		cfgPrimaryNetwork:       schema.Omit,
+		cfgResourcePool:         schema.Omit,
+		cfgVMFolder:             "",
+		cfgDiskProvisioningType: string(vsphereclient.DiskTypeThin),
 	}
 
-	configRequiredFields  = []string{}
-	configImmutableFields = []string{}
+	configRequiredFields = []string{}
+
+	// vm-folder is immutable, as the model's VMs are located
+	// by their folder.
+	configImmutableFields = []string{cfgVMFolder}
 )
 
 type environConfig struct {
@@ -92,6 +109,24 @@ func (c *environConfig) primaryNetwork() string {
 	return network
 }
 
+func (c *environConfig) resourcePool() string {
+	pool, _ := c.attrs[cfgResourcePool].(string)
+	return pool
+}
+
+func (c *environConfig) vmFolder() string {
+	folder, _ := c.attrs[cfgVMFolder].(string)
+	return folder
+}
+
+func (c *environConfig) diskProvisioningType() vsphereclient.DiskProvisioningType {
+	diskType, _ := c.attrs[cfgDiskProvisioningType].(string)
+	if diskType == "" {
+		return vsphereclient.DiskTypeThin
+	}
+	return vsphereclient.DiskProvisioningType(diskType)
+}
+
 // validate checks vmware-specific config values.
 func (c environConfig) validate() error {
 	// All fields must be populated, even with just the default.
@@ -122,6 +122,21 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": "12345"},
 	expect: testing.Attrs{"unknown-field": "12345"},
+}, {
+	info:   "resource pool and VM folder may be specified",
+	insert: testing.Attrs{"resource-pool": "parent/child", "vm-folder": "juju"},
+	expect: testing.Attrs{"resource-pool": "parent/child", "vm-folder": "juju"},
+}, {
+	info:   "disk provisioning type defaults to thin",
+	expect: testing.Attrs{"disk-provisioning-type": "thin"},
+}, {
+	info:   "disk provisioning type may be thickEagerZero",
+	insert: testing.Attrs{"disk-provisioning-type": "thickEagerZero"},
+	expect: testing.Attrs{"disk-provisioning-type": "thickEagerZero"},
+}, {
+	info:   "invalid disk provisioning type",
+	insert: testing.Attrs{"disk-provisioning-type": "sparse"},
+	err:    `disk-provisioning-type: unexpected value "sparse"`,
 }}
 
 func (*ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -198,6 +213,18 @@ var changeConfigTests = []configTestSpec{{
 	info:   "can insert unknown field",
 	insert: testing.Attrs{"unknown": "ignoti"},
 	expect: testing.Attrs{"unknown": "ignoti"},
+}, {
+	info:   "can change resource pool",
+	insert: testing.Attrs{"resource-pool": "pool"},
+	expect: testing.Attrs{"resource-pool": "pool"},
+}, {
+	info:   "can change disk provisioning type",
+	insert: testing.Attrs{"disk-provisioning-type": "thick"},
+	expect: testing.Attrs{"disk-provisioning-type": "thick"},
+}, {
+	info:   "cannot change VM folder",
+	insert: testing.Attrs{"vm-folder": "juju"},
+	err:    "vm-folder: cannot change from  to juju",
 }}
 
 func (s *ConfigSuite) TestValidateChange(c *gc.C) {
@@ -4,6 +4,9 @@
 package vsphere
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cloud"
@@ -34,8 +37,27 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials interface.
+// Credentials are read from the GOVC_USERNAME and GOVC_PASSWORD environment
+// variables, as used by the govc vSphere command line client.
 func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
-	return nil, errors.NotFoundf("credentials")
+	user := os.Getenv("GOVC_USERNAME")
+	password := os.Getenv("GOVC_PASSWORD")
+	if user == "" || password == "" {
+		return nil, errors.NotFoundf("credentials")
+	}
+	credential := cloud.NewCredential(
+		cloud.UserPassAuthType,
+		map[string]string{
+			credAttrUser:     user,
+			credAttrPassword: password,
+		},
+	)
+	credential.Label = fmt.Sprintf("vsphere credential %q", user)
+	return &cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			user: credential,
+		},
+	}, nil
 }
 
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
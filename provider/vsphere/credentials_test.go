@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
 	envtesting "github.com/juju/juju/environs/testing"
 )
@@ -47,3 +48,19 @@ func (s *credentialsSuite) TestDetectCredentialsNotFound(c *gc.C) {
 	_, err := s.provider.DetectCredentials()
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *credentialsSuite) TestDetectCredentialsEnvironmentVariables(c *gc.C) {
+	s.PatchEnvironment("GOVC_USERNAME", "bob")
+	s.PatchEnvironment("GOVC_PASSWORD", "dobbs")
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{
+			"user":     "bob",
+			"password": "dobbs",
+		},
+	)
+	expected.Label = `vsphere credential "bob"`
+	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
+}
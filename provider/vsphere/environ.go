@@ -147,10 +147,7 @@ func (env *sessionEnviron) Bootstrap(
 }
 
 func (env *sessionEnviron) ensureVMFolder(controllerUUID string) error {
-	_, err := env.client.EnsureVMFolder(env.ctx, path.Join(
-		controllerFolderName(controllerUUID),
-		env.modelFolderName(),
-	))
+	_, err := env.client.EnsureVMFolder(env.ctx, env.modelFolderPath(controllerUUID))
 	return errors.Trace(err)
 }
 
@@ -168,11 +165,8 @@ func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Nu
 // AdoptResources is part of the Environ interface.
 func (env *sessionEnviron) AdoptResources(controllerUUID string, fromVersion version.Number) error {
 	return env.client.MoveVMFolderInto(env.ctx,
-		controllerFolderName(controllerUUID),
-		path.Join(
-			controllerFolderName("*"),
-			env.modelFolderName(),
-		),
+		env.controllerFolderPath(controllerUUID),
+		env.modelFolderPath("*"),
 	)
 }
 
@@ -188,10 +182,7 @@ func (env *sessionEnviron) Destroy() error {
 	if err := DestroyEnv(env); err != nil {
 		return errors.Trace(err)
 	}
-	return env.client.DestroyVMFolder(env.ctx, path.Join(
-		controllerFolderName("*"),
-		env.modelFolderName(),
-	))
+	return env.client.DestroyVMFolder(env.ctx, env.modelFolderPath("*"))
 }
 
 // DestroyController implements the Environ interface.
@@ -206,15 +197,15 @@ func (env *sessionEnviron) DestroyController(controllerUUID string) error {
 	if err := env.Destroy(); err != nil {
 		return errors.Trace(err)
 	}
-	controllerFolderName := controllerFolderName(controllerUUID)
+	controllerFolderPath := env.controllerFolderPath(controllerUUID)
 	if err := env.client.RemoveVirtualMachines(env.ctx, path.Join(
-		controllerFolderName,
+		controllerFolderPath,
 		modelFolderName("*", "*"),
 		"*",
 	)); err != nil {
 		return errors.Annotate(err, "removing VMs")
 	}
-	if err := env.client.DestroyVMFolder(env.ctx, controllerFolderName); err != nil {
+	if err := env.client.DestroyVMFolder(env.ctx, controllerFolderPath); err != nil {
 		return errors.Annotate(err, "destroying VM folder")
 	}
 
@@ -105,13 +105,15 @@ func (env *environ) DeriveAvailabilityZones(args environs.StartInstanceParams) (
 // DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
 func (env *sessionEnviron) DeriveAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
 	if args.Placement != "" {
-		// args.Placement will always be a zone name or empty.
-		placement, err := env.parsePlacement(args.Placement)
+		placement, err := parsePlacement(args.Placement)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if placement.Name() != "" {
-			return []string{placement.Name()}, nil
+		if placement.zone != "" {
+			if _, err := env.availZone(placement.zone); err != nil {
+				return nil, errors.Trace(err)
+			}
+			return []string{placement.zone}, nil
 		}
 	}
 	return nil, nil
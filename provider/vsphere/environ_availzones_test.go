@@ -92,3 +92,22 @@ func (s *environAvailzonesSuite) TestDeriveAvailabilityZonesInvalidPlacement(c *
 	c.Assert(err, gc.ErrorMatches, `unknown placement directive: invalid-placement`)
 	c.Assert(zones, gc.HasLen, 0)
 }
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZonesMultipleDirectives(c *gc.C) {
+	s.client.computeResources = []*mo.ComputeResource{
+		newComputeResource("test-available"),
+	}
+	zonedEnviron := s.env.(common.ZonedEnviron)
+
+	zones, err := zonedEnviron.DeriveAvailabilityZones(environs.StartInstanceParams{
+		Placement: "datastore=datastore0,zone=test-available,resource-pool=pool0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.DeepEquals, []string{"test-available"})
+
+	zones, err = zonedEnviron.DeriveAvailabilityZones(environs.StartInstanceParams{
+		Placement: "datastore=datastore0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 0)
+}
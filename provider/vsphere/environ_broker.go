@@ -183,11 +183,8 @@ func (env *sessionEnviron) newRawInstance(
 	}
 
 	createVMArgs := vsphereclient.CreateVirtualMachineParams{
-		Name: vmName,
-		Folder: path.Join(
-			controllerFolderName(args.ControllerUUID),
-			env.modelFolderName(),
-		),
+		Name:                   vmName,
+		Folder:                 env.modelFolderPath(args.ControllerUUID),
 		Series:                 series,
 		ReadOVA:                readOVA,
 		OVASHA256:              img.Sha256,
@@ -198,11 +195,28 @@ func (env *sessionEnviron) newRawInstance(
 		PrimaryNetwork:         env.ecfg.primaryNetwork(),
 		ExternalNetwork:        externalNetwork,
 		Datastore:              env.ecfg.datastore(),
+		ResourcePool:           env.ecfg.resourcePool(),
+		DiskProvisioningType:   env.ecfg.diskProvisioningType(),
 		UpdateProgress:         updateProgress,
 		UpdateProgressInterval: updateProgressInterval,
 		Clock: clock.WallClock,
 	}
 
+	// Placement directives override the datastore and resource
+	// pool specified in model config.
+	placement, err := parsePlacement(args.Placement)
+	if err != nil {
+		return nil, nil, common.ZoneIndependentError(err)
+	}
+	if placement != nil {
+		if placement.datastore != "" {
+			createVMArgs.Datastore = placement.datastore
+		}
+		if placement.resourcePool != "" {
+			createVMArgs.ResourcePool = placement.resourcePool
+		}
+	}
+
 	// Attempt to create a VM in each of the AZs in turn.
 	logger.Debugf("attempting to create VM in availability zone %s", args.AvailabilityZone)
 	availZone, err := env.availZone(args.AvailabilityZone)
@@ -237,10 +251,7 @@ func (env *environ) AllInstances() (instances []instance.Instance, err error) {
 
 // AllInstances implements environs.InstanceBroker.
 func (env *sessionEnviron) AllInstances() ([]instance.Instance, error) {
-	modelFolderPath := path.Join(
-		controllerFolderName("*"),
-		env.modelFolderName(),
-	)
+	modelFolderPath := env.modelFolderPath("*")
 	vms, err := env.client.VirtualMachines(env.ctx, modelFolderPath+"/*")
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -264,10 +275,7 @@ func (env *environ) StopInstances(ids ...instance.Id) error {
 
 // StopInstances implements environs.InstanceBroker.
 func (env *sessionEnviron) StopInstances(ids ...instance.Id) error {
-	modelFolderPath := path.Join(
-		controllerFolderName("*"),
-		env.modelFolderName(),
-	)
+	modelFolderPath := env.modelFolderPath("*")
 	results := make([]error, len(ids))
 	var wg sync.WaitGroup
 	for i, id := range ids {
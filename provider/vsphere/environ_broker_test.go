@@ -129,6 +129,7 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 		OVASHA256:              ovatest.FakeOVASHA256(),
 		Metadata:               startInstArgs.InstanceConfig.Tags,
 		ComputeResource:        s.client.computeResources[0],
+		DiskProvisioningType:   vsphereclient.DiskTypeThin,
 		UpdateProgressInterval: 5 * time.Second,
 	})
 
@@ -324,6 +325,74 @@ func (s *environBrokerSuite) TestStartInstanceDatastore(c *gc.C) {
 	c.Assert(createVMArgs.Datastore, gc.Equals, "datastore0")
 }
 
+func (s *environBrokerSuite) TestStartInstanceResourcePoolAndDiskType(c *gc.C) {
+	cfg := s.env.Config()
+	cfg, err := cfg.Apply(map[string]interface{}{
+		"resource-pool":          "parent/child",
+		"disk-provisioning-type": "thick",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	call := s.client.Calls()[1]
+	createVMArgs := call.Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.ResourcePool, gc.Equals, "parent/child")
+	c.Assert(createVMArgs.DiskProvisioningType, gc.Equals, vsphereclient.DiskTypeThick)
+}
+
+func (s *environBrokerSuite) TestStartInstancePlacement(c *gc.C) {
+	cfg := s.env.Config()
+	cfg, err := cfg.Apply(map[string]interface{}{
+		"datastore":     "datastore0",
+		"resource-pool": "pool0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = "datastore=datastore1,resource-pool=pool1"
+	_, err = s.env.StartInstance(startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	call := s.client.Calls()[1]
+	createVMArgs := call.Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.Datastore, gc.Equals, "datastore1")
+	c.Assert(createVMArgs.ResourcePool, gc.Equals, "pool1")
+}
+
+func (s *environBrokerSuite) TestStartInstanceInvalidPlacement(c *gc.C) {
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = "datastore=datastore1,host=foo"
+	_, err := s.env.StartInstance(startInstArgs)
+	c.Assert(err, gc.ErrorMatches, "unknown placement directive: datastore=datastore1,host=foo")
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+}
+
+func (s *environBrokerSuite) TestStartInstanceVMFolder(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"vm-folder":          "juju/models",
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	call := s.client.Calls()[1]
+	createVMArgs := call.Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.Folder, gc.Equals,
+		`juju/models/Juju Controller (deadbeef-1bad-500d-9000-4b1d0d06f00d)/Model "testenv" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)`,
+	)
+}
+
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	err := s.env.StopInstances("vm-0", "vm-1")
 	c.Assert(err, jc.ErrorIsNil)
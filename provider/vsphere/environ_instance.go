@@ -4,6 +4,7 @@
 package vsphere
 
 import (
+	"path"
 	"strings"
 
 	"github.com/juju/errors"
@@ -98,31 +99,65 @@ func (env *sessionEnviron) ControllerInstances(controllerUUID string) ([]instanc
 	return results, nil
 }
 
-// parsePlacement extracts the availability zone from the placement
-// string and returns it. If no zone is found there then an error is
-// returned.
-func (env *sessionEnviron) parsePlacement(placement string) (*vmwareAvailZone, error) {
+// vmwarePlacement holds the values of the directives in a placement
+// string.
+type vmwarePlacement struct {
+	// zone is the name of the availability zone named by the
+	// "zone" directive, if any.
+	zone string
+
+	// datastore is the name of the datastore named by the
+	// "datastore" directive, if any.
+	datastore string
+
+	// resourcePool is the path of the resource pool named by the
+	// "resource-pool" directive, if any.
+	resourcePool string
+}
+
+// parsePlacement parses the placement string, which is a comma-separated
+// list of directives of the form key=value. The supported directives are
+// "zone", "datastore" and "resource-pool". An error is returned if any
+// directive is unknown.
+func parsePlacement(placement string) (*vmwarePlacement, error) {
 	if placement == "" {
 		return nil, nil
 	}
 
-	pos := strings.IndexRune(placement, '=')
-	if pos == -1 {
-		return nil, errors.Errorf("unknown placement directive: %v", placement)
-	}
-
-	switch key, value := placement[:pos], placement[pos+1:]; key {
-	case "zone":
-		zone, err := env.availZone(value)
-		if err != nil {
-			return nil, errors.Trace(err)
+	var result vmwarePlacement
+	for _, directive := range strings.Split(placement, ",") {
+		pos := strings.IndexRune(directive, '=')
+		if pos == -1 {
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
+		}
+		switch key, value := directive[:pos], directive[pos+1:]; key {
+		case "zone":
+			result.zone = value
+		case "datastore":
+			result.datastore = value
+		case "resource-pool":
+			result.resourcePool = value
+		default:
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
 		}
-		return zone.(*vmwareAvailZone), nil
 	}
-	return nil, errors.Errorf("unknown placement directive: %v", placement)
+	return &result, nil
 }
 
 func (env *sessionEnviron) modelFolderName() string {
 	cfg := env.Config()
 	return modelFolderName(cfg.UUID(), cfg.Name())
 }
+
+// controllerFolderPath returns the path of the given controller's VM
+// folder, relative to the root VM folder. If the user has specified a
+// vm-folder, the controller's folder is located within it.
+func (env *sessionEnviron) controllerFolderPath(controllerUUID string) string {
+	return path.Join(env.ecfg.vmFolder(), controllerFolderName(controllerUUID))
+}
+
+// modelFolderPath returns the path of the model's VM folder within the
+// given controller's folder, relative to the root VM folder.
+func (env *sessionEnviron) modelFolderPath(controllerUUID string) string {
+	return path.Join(env.controllerFolderPath(controllerUUID), env.modelFolderName())
+}
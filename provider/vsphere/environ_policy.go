@@ -22,13 +22,22 @@ func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error
 
 // PrecheckInstance is part of the environs.Environ interface.
 func (env *sessionEnviron) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	_, err := env.parsePlacement(args.Placement)
+	placement, err := parsePlacement(args.Placement)
+	if err != nil {
+		return err
+	}
+	if placement != nil && placement.zone != "" {
+		_, err = env.availZone(placement.zone)
+	}
 	return err
 }
 
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Spot,
+	constraints.InstanceProfile,
+	constraints.RootDiskSource,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
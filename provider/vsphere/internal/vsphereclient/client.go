@@ -403,11 +403,23 @@ func (c *Client) cloneVM(
 	srcVM *object.VirtualMachine,
 	dstName string,
 	vmFolder *object.Folder,
+	datastore *object.Datastore,
+	diskType DiskProvisioningType,
 	taskWaiter *taskWaiter,
 ) (*object.VirtualMachine, error) {
+	var location types.VirtualMachineRelocateSpec
+	if diskType == DiskTypeThick || diskType == DiskTypeThickEagerZero {
+		// The source VM's disk is thin provisioned, so convert
+		// the disks to thick while cloning.
+		disks, err := c.relocateDisks(ctx, srcVM, datastore, diskType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		location.Disk = disks
+	}
 	task, err := srcVM.Clone(ctx, vmFolder, dstName, types.VirtualMachineCloneSpec{
 		Config:   &types.VirtualMachineConfigSpec{},
-		Location: types.VirtualMachineRelocateSpec{},
+		Location: location,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -419,6 +431,40 @@ func (c *Client) cloneVM(
 	return object.NewVirtualMachine(c.client.Client, info.Result.(types.ManagedObjectReference)), nil
 }
 
+// relocateDisks returns disk locators for each of the VM's disks,
+// converting them to the specified provisioning type.
+func (c *Client) relocateDisks(
+	ctx context.Context,
+	vm *object.VirtualMachine,
+	datastore *object.Datastore,
+	diskType DiskProvisioningType,
+) ([]types.VirtualMachineRelocateSpecDiskLocator, error) {
+	var mo mo.VirtualMachine
+	if err := c.client.RetrieveOne(ctx, vm.Reference(), []string{"config.hardware.device"}, &mo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mo.Config == nil {
+		return nil, nil
+	}
+	var disks []types.VirtualMachineRelocateSpecDiskLocator
+	for _, dev := range mo.Config.Hardware.Device {
+		disk, ok := dev.(*types.VirtualDisk)
+		if !ok {
+			continue
+		}
+		disks = append(disks, types.VirtualMachineRelocateSpecDiskLocator{
+			DiskId:    disk.Key,
+			Datastore: datastore.Reference(),
+			DiskBackingInfo: &types.VirtualDiskFlatVer2BackingInfo{
+				DiskMode:        string(types.VirtualDiskModePersistent),
+				ThinProvisioned: types.NewBool(false),
+				EagerlyScrub:    types.NewBool(diskType == DiskTypeThickEagerZero),
+			},
+		})
+	}
+	return disks, nil
+}
+
 func (c *Client) extendDisk(
 	ctx context.Context,
 	datacenter *object.Datacenter,
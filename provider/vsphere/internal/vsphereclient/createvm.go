@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -24,6 +25,24 @@ import (
 
 //go:generate go run ../../../../generate/filetoconst/filetoconst.go UbuntuOVF ubuntu.ovf ovf_ubuntu.go 2017 vsphereclient
 
+// DiskProvisioningType specifies how a virtual machine's disks are
+// provisioned in the datastore.
+type DiskProvisioningType string
+
+const (
+	// DiskTypeThin provisions disks on demand, as the guest writes
+	// to them.
+	DiskTypeThin DiskProvisioningType = "thin"
+
+	// DiskTypeThick allocates all of the disk's space up front,
+	// zeroing blocks lazily on first write.
+	DiskTypeThick DiskProvisioningType = "thick"
+
+	// DiskTypeThickEagerZero allocates all of the disk's space up
+	// front, and zeroes it when the disk is created.
+	DiskTypeThickEagerZero DiskProvisioningType = "thickEagerZero"
+)
+
 // CreateVirtualMachineParams contains the parameters required for creating
 // a new virtual machine.
 type CreateVirtualMachineParams struct {
@@ -63,6 +82,17 @@ type CreateVirtualMachineParams struct {
 	// If this is empty, any accessible datastore will be used.
 	Datastore string
 
+	// ResourcePool is the path of the resource pool in which to create
+	// the VM, relative to the compute resource's root resource pool,
+	// e.g. "parent/child". If this is empty, the root resource pool
+	// will be used.
+	ResourcePool string
+
+	// DiskProvisioningType is the provisioning type to use for the
+	// VM's root disk. If this is empty, the disk will be thin
+	// provisioned.
+	DiskProvisioningType DiskProvisioningType
+
 	// Metadata are metadata key/value pairs to apply to the VM as
 	// "extra config".
 	Metadata map[string]string
@@ -147,7 +177,10 @@ func (c *Client) CreateVirtualMachine(
 
 	// Ensure the VMDK is present in the datastore, uploading it if it
 	// doesn't already exist.
-	resourcePool := object.NewResourcePool(c.client.Client, *args.ComputeResource.ResourcePool)
+	resourcePool, err := c.resourcePool(ctx, *args.ComputeResource.ResourcePool, args.ResourcePool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	taskWaiter := &taskWaiter{args.Clock, args.UpdateProgress, args.UpdateProgressInterval}
 	vmdkDatastorePath, releaseVMDK, err := c.ensureVMDK(ctx, args, datastore, datacenter, taskWaiter)
 	if err != nil {
@@ -159,7 +192,7 @@ func (c *Client) CreateVirtualMachine(
 	// import the VMDK, which exists in the datastore as a not-a-disk
 	// file type.
 	args.UpdateProgress("creating import spec")
-	importSpec, err := c.createImportSpec(ctx, args, datastore, vmdkDatastorePath)
+	importSpec, err := c.createImportSpec(ctx, args, datastore, resourcePool, vmdkDatastorePath)
 	if err != nil {
 		return nil, errors.Annotate(err, "creating import spec")
 	}
@@ -191,7 +224,11 @@ func (c *Client) CreateVirtualMachine(
 	// VMDK from the temporary VM to avoid deleting it when destroying
 	// the VM.
 	c.logger.Debugf("cloning VM")
-	vm, err := c.cloneVM(ctx, tempVM, args.Name, vmFolder, taskWaiter)
+	vm, err := c.cloneVM(
+		ctx, tempVM, args.Name, vmFolder,
+		datastore, args.DiskProvisioningType,
+		taskWaiter,
+	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -278,6 +315,7 @@ func (c *Client) createImportSpec(
 	ctx context.Context,
 	args CreateVirtualMachineParams,
 	datastore *object.Datastore,
+	resourcePool *object.ResourcePool,
 	vmdkDatastorePath string,
 ) (*types.VirtualMachineImportSpec, error) {
 	cisp := types.OvfCreateImportSpecParams{
@@ -314,8 +352,6 @@ func (c *Client) createImportSpec(
 	}
 
 	ovfManager := ovf.NewManager(c.client.Client)
-	resourcePool := object.NewReference(c.client.Client, *args.ComputeResource.ResourcePool)
-
 	spec, err := ovfManager.CreateImportSpec(ctx, UbuntuOVF, resourcePool, datastore, cisp)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -398,6 +434,44 @@ func (c *Client) addRootDisk(
 	return nil
 }
 
+// resourcePool returns the resource pool with the given path, relative
+// to the specified root resource pool. If the path is empty, the root
+// resource pool is returned.
+func (c *Client) resourcePool(
+	ctx context.Context,
+	root types.ManagedObjectReference,
+	poolPath string,
+) (*object.ResourcePool, error) {
+	ref := root
+	for _, name := range strings.Split(poolPath, "/") {
+		if name == "" {
+			continue
+		}
+		var pool mo.ResourcePool
+		if err := c.client.RetrieveOne(ctx, ref, []string{"resourcePool"}, &pool); err != nil {
+			return nil, errors.Annotate(err, "retrieving resource pool details")
+		}
+		var children []mo.ResourcePool
+		if len(pool.ResourcePool) > 0 {
+			if err := c.client.Retrieve(ctx, pool.ResourcePool, []string{"name"}, &children); err != nil {
+				return nil, errors.Annotate(err, "retrieving resource pool details")
+			}
+		}
+		var found bool
+		for _, child := range children {
+			if child.Name == name {
+				ref = child.Reference()
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NotFoundf("resource pool %q", poolPath)
+		}
+	}
+	return object.NewResourcePool(c.client.Client, ref), nil
+}
+
 func (c *Client) selectDatastore(
 	ctx context.Context,
 	args CreateVirtualMachineParams,
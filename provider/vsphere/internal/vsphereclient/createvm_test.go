@@ -384,6 +384,68 @@ func (s *clientSuite) TestCreateVirtualMachineRootDiskSize(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestCreateVirtualMachineResourcePool(c *gc.C) {
+	s.roundTripper.contents["FakeResourcePool1"] = []types.ObjectContent{{
+		Obj: types.ManagedObjectReference{
+			Type:  "ResourcePool",
+			Value: "FakeResourcePool1",
+		},
+		PropSet: []types.DynamicProperty{
+			{Name: "resourcePool", Val: []types.ManagedObjectReference{{
+				Type:  "ResourcePool",
+				Value: "FakeResourcePool3",
+			}}},
+		},
+	}}
+	s.roundTripper.contents["FakeResourcePool3"] = []types.ObjectContent{{
+		Obj: types.ManagedObjectReference{
+			Type:  "ResourcePool",
+			Value: "FakeResourcePool3",
+		},
+		PropSet: []types.DynamicProperty{
+			{Name: "name", Val: "child"},
+		},
+	}}
+
+	args := baseCreateVirtualMachineParams(c)
+	args.ResourcePool = "child"
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualMachine(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.roundTripper.CheckCall(c, 9, "RetrieveProperties", "FakeResourcePool1")
+	s.roundTripper.CheckCall(c, 10, "RetrieveProperties", "FakeResourcePool3")
+}
+
+func (s *clientSuite) TestCreateVirtualMachineResourcePoolNotFound(c *gc.C) {
+	args := baseCreateVirtualMachineParams(c)
+	args.ResourcePool = "missing"
+
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualMachine(context.Background(), args)
+	c.Assert(err, gc.ErrorMatches, `resource pool "missing" not found`)
+}
+
+func (s *clientSuite) TestCreateVirtualMachineThickDisk(c *gc.C) {
+	args := baseCreateVirtualMachineParams(c)
+	args.DiskProvisioningType = DiskTypeThickEagerZero
+
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualMachine(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The temporary VM's disks must be inspected before cloning,
+	// so that they can be converted.
+	calls := s.roundTripper.Calls()
+	for i, call := range calls {
+		if call.FuncName == "CloneVM_Task" {
+			c.Assert(calls[i-1], jc.DeepEquals, retrievePropertiesStubCall("FakeVm0"))
+			return
+		}
+	}
+	c.Fatalf("failed to find call %q", "CloneVM_Task")
+}
+
 func baseCreateVirtualMachineParams(c *gc.C) CreateVirtualMachineParams {
 	readOVA := func() (string, io.ReadCloser, error) {
 		r := bytes.NewReader(ovatest.FakeOVAContents())
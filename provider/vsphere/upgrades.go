@@ -4,8 +4,6 @@
 package vsphere
 
 import (
-	"path"
-
 	"github.com/juju/errors"
 	"github.com/vmware/govmomi/vim25/types"
 
@@ -95,10 +93,7 @@ func (modelFoldersUpgradeStep) Description() string {
 func (step modelFoldersUpgradeStep) Run() error {
 	return step.env.withSession(func(env *sessionEnviron) error {
 		// We must create the folder even if there are no VMs in the model.
-		modelFolderPath := path.Join(
-			controllerFolderName(step.controllerUUID),
-			env.modelFolderName(),
-		)
+		modelFolderPath := env.modelFolderPath(step.controllerUUID)
 		if _, err := env.client.EnsureVMFolder(env.ctx, modelFolderPath); err != nil {
 			return errors.Annotate(err, "creating model folder")
 		}
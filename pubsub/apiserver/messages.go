@@ -21,3 +21,27 @@ type Details struct {
 	Servers   map[string]APIServer `yaml:"servers"`
 	LocalOnly bool                 `yaml:"local-only"`
 }
+
+// DrainTopic is the topic name for the published message that requests
+// an API server to start or stop draining its connections.
+const DrainTopic = "apiserver.drain"
+
+// DrainStatusTopic is the topic name for the published message that
+// reports an API server's drain status. It is published by an API
+// server when it starts or stops draining, and as its connections
+// close while it drains.
+const DrainStatusTopic = "apiserver.drain-status"
+
+// DrainRequest asks the API server of the identified machine to start
+// or stop draining its connections.
+type DrainRequest struct {
+	ID    string `yaml:"id"`
+	Drain bool   `yaml:"drain"`
+}
+
+// DrainStatus reports the drain status of a single API server machine.
+type DrainStatus struct {
+	ID          string `yaml:"id"`
+	Draining    bool   `yaml:"draining"`
+	Connections int64  `yaml:"connections"`
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/trace"
 )
 
 var ErrShutdown = errors.New("connection is shut down")
@@ -41,6 +43,37 @@ func (e *RequestError) ErrorCode() string {
 	return e.Code
 }
 
+// tracedError associates a RequestError with the correlation ID
+// that the server assigned to the failed request. Its cause is the
+// RequestError, so callers inspecting errors.Cause are unaffected.
+type tracedError struct {
+	*RequestError
+	traceId string
+}
+
+// Cause is part of the errors.causer interface.
+func (e *tracedError) Cause() error {
+	return e.RequestError
+}
+
+// TraceId returns the correlation ID of the failed request that
+// caused err, or the empty string if there is none.
+func TraceId(err error) string {
+	for err != nil {
+		if err, ok := err.(*tracedError); ok {
+			return err.traceId
+		}
+		wrapper, ok := err.(interface {
+			Underlying() error
+		})
+		if !ok {
+			break
+		}
+		err = wrapper.Underlying()
+	}
+	return ""
+}
+
 func (conn *Conn) send(call *Call) {
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
@@ -108,10 +141,18 @@ func (conn *Conn) handleResponse(hdr *Header) error {
 		// We've got an error response. Give this to the request;
 		// any subsequent requests will get the ReadResponseBody
 		// error if there is one.
-		call.Error = &RequestError{
+		reqErr := &RequestError{
 			Message: hdr.Error,
 			Code:    hdr.ErrorCode,
 		}
+		call.Error = reqErr
+		if hdr.TraceId != "" {
+			call.Error = &tracedError{reqErr, hdr.TraceId}
+			// Record the failure against the trace ID, so that the
+			// caller's side of the request is found with the server's.
+			logger.Debugf("%s.%s failed: %v (%s)",
+				call.Request.Type, call.Request.Action, reqErr, trace.Marker(hdr.TraceId))
+		}
 		err = conn.readBody(nil, false)
 		call.done()
 	default:
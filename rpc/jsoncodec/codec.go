@@ -71,6 +71,7 @@ type inMsgV1 struct {
 	Params    json.RawMessage `json:"params"`
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
+	TraceId   string          `json:"trace-id"`
 	Response  json.RawMessage `json:"response"`
 }
 
@@ -96,6 +97,7 @@ type outMsgV1 struct {
 	Params    interface{} `json:"params,omitempty"`
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
+	TraceId   string      `json:"trace-id,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
 }
 
@@ -139,6 +141,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	}
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
+	hdr.TraceId = c.msg.TraceId
 	hdr.Version = version
 	return nil
 }
@@ -272,6 +275,7 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		TraceId:   hdr.TraceId,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
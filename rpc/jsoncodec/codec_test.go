@@ -95,6 +95,15 @@ func (*suite) TestRead(c *gc.C) {
 			Version:   1,
 		},
 		expectBody: new(map[string]interface{}),
+	}, {
+		msg: `{"request-id": 2, "error": "an error", "trace-id": "0123456789abcdef"}`,
+		expectHdr: rpc.Header{
+			RequestId: 2,
+			Error:     "an error",
+			TraceId:   "0123456789abcdef",
+			Version:   1,
+		},
+		expectBody: new(map[string]interface{}),
 	}, {
 		msg: `{"request-id": 3, "response": {"X": "result"}}`,
 		expectHdr: rpc.Header{
@@ -216,6 +225,14 @@ func (*suite) TestWrite(c *gc.C) {
 			Version:   1,
 		},
 		expect: `{"request-id": 2, "error": "an error", "error-code": "a code"}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 2,
+			Error:     "an error",
+			TraceId:   "0123456789abcdef",
+			Version:   1,
+		},
+		expect: `{"request-id": 2, "error": "an error", "trace-id": "0123456789abcdef"}`,
 	}, {
 		hdr: &rpc.Header{
 			RequestId: 3,
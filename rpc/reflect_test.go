@@ -4,11 +4,13 @@
 package rpc_test
 
 import (
+	"context"
 	"reflect"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/testing"
 )
@@ -130,7 +132,7 @@ func (*reflectSuite) TestFindMethod(c *gc.C) {
 	c.Assert(m.ParamsType(), gc.Equals, reflect.TypeOf(stringVal{}))
 	c.Assert(m.ResultType(), gc.Equals, reflect.TypeOf(stringVal{}))
 
-	ret, err := m.Call("a99", reflect.ValueOf(stringVal{"foo"}))
+	ret, err := m.Call(context.Background(), "a99", reflect.ValueOf(stringVal{"foo"}))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ret.Interface(), gc.Equals, stringVal{"Call1r1e ret"})
 }
@@ -152,3 +154,39 @@ func (*reflectSuite) TestFindMethodAcceptsAnyVersion(c *gc.C) {
 	c.Assert(m.ParamsType(), gc.Equals, reflect.TypeOf(stringVal{}))
 	c.Assert(m.ResultType(), gc.Equals, reflect.TypeOf(stringVal{}))
 }
+
+type ContextMethods struct{}
+
+func (ContextMethods) Call0r1(ctx context.Context) stringVal {
+	return stringVal{trace.ID(ctx)}
+}
+
+func (ContextMethods) Call1r1e(ctx context.Context, arg stringVal) (stringVal, error) {
+	return stringVal{arg.Val + " " + trace.ID(ctx)}, nil
+}
+
+func (ContextMethods) Discard1(arg stringVal, ctx context.Context) {}
+
+func (*reflectSuite) TestObjTypeOfContextMethods(c *gc.C) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(ContextMethods{}))
+	c.Check(objType.DiscardedMethods(), gc.DeepEquals, []string{"Discard1"})
+	c.Check(objType.MethodNames(), gc.DeepEquals, []string{"Call0r1", "Call1r1e"})
+
+	ctx := trace.WithID(context.Background(), "abcd")
+	rcvr := reflect.ValueOf(ContextMethods{})
+
+	m, err := objType.Method("Call0r1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Params, gc.IsNil)
+	c.Check(m.Result, gc.Equals, reflect.TypeOf(stringVal{}))
+	ret, err := m.Call(ctx, rcvr, reflect.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ret.Interface(), gc.Equals, stringVal{"abcd"})
+
+	m, err = objType.Method("Call1r1e")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Params, gc.Equals, reflect.TypeOf(stringVal{}))
+	ret, err = m.Call(ctx, rcvr, reflect.ValueOf(stringVal{"traced"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ret.Interface(), gc.Equals, stringVal{"traced abcd"})
+}
@@ -4,6 +4,7 @@
 package rpc_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -297,7 +298,7 @@ func (c customMethodCaller) ResultType() reflect.Type {
 	return c.objMethod.Result
 }
 
-func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (c customMethodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	sm, err := c.root.SimpleMethods(objId)
 	if err != nil {
 		return reflect.Value{}, err
@@ -307,7 +308,7 @@ func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value
 		logger.Errorf("got the wrong type back, expected %s got %T", c.expectedType, obj)
 	}
 	logger.Debugf("calling: %T %v %#v", obj, obj, c.objMethod)
-	return c.objMethod.Call(obj, arg)
+	return c.objMethod.Call(ctx, obj, arg)
 }
 
 func (cc *CustomRoot) Kill() {
@@ -468,6 +469,7 @@ func (root *Root) testCall(c *gc.C, args testCallParams) {
 		c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 			Message: args.errorMessage(),
 		})
+		c.Assert(rpc.TraceId(err), gc.Matches, "[0-9a-f]{16}")
 		c.Assert(response, gc.Equals, stringVal{})
 	case args.nret > 0:
 		c.Check(response, gc.Equals, stringVal{args.request().Action + " ret"})
@@ -504,10 +506,13 @@ func (root *Root) assertServerNotified(c *gc.C, p testCallParams, requestId uint
 	// Test that there was a notification for the request.
 	c.Assert(p.serverNotifier.serverRequests, gc.HasLen, 1)
 	serverReq := p.serverNotifier.serverRequests[0]
+	traceId := serverReq.hdr.TraceId
+	c.Assert(traceId, gc.Matches, "[0-9a-f]{16}")
 	c.Assert(serverReq.hdr, gc.DeepEquals, rpc.Header{
 		RequestId: requestId,
 		Request:   p.request(),
 		Version:   1,
+		TraceId:   traceId,
 	})
 	if p.narg > 0 {
 		c.Assert(serverReq.body, gc.Equals, stringVal{"arg"})
@@ -529,6 +534,7 @@ func (root *Root) assertServerNotified(c *gc.C, p testCallParams, requestId uint
 			RequestId: requestId,
 			Error:     p.errorMessage(),
 			Version:   1,
+			TraceId:   traceId,
 		})
 	} else {
 		c.Assert(serverReply.hdr, gc.Equals, rpc.Header{
@@ -907,11 +913,14 @@ func testBadCall(
 	if requestKnown {
 		expectBody = struct{}{}
 	}
+	traceId := serverNotifier.serverRequests[0].hdr.TraceId
+	c.Assert(traceId, gc.Matches, "[0-9a-f]{16}")
 	c.Assert(serverNotifier.serverRequests[0], gc.DeepEquals, requestEvent{
 		hdr: rpc.Header{
 			RequestId: client.ClientRequestID(),
 			Request:   req,
 			Version:   1,
+			TraceId:   traceId,
 		},
 		body: expectBody,
 	})
@@ -925,6 +934,7 @@ func testBadCall(
 			Error:     expectedErr,
 			ErrorCode: expectedErrCode,
 			Version:   1,
+			TraceId:   traceId,
 		},
 		req:  req,
 		body: struct{}{},
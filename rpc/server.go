@@ -4,6 +4,9 @@
 package rpc
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"io"
 	"reflect"
 	"runtime/debug"
@@ -13,6 +16,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/trace"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
 
@@ -66,6 +70,12 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceId holds the correlation ID of the request. If the client
+	// doesn't supply one, the server assigns it. It is recorded in the
+	// server's request logs, and returned to the client in error
+	// responses, so that a failed request can be found in the logs.
+	TraceId string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -389,6 +399,9 @@ func (conn *Conn) readBody(resp interface{}, isRequest bool) error {
 }
 
 func (conn *Conn) handleRequest(hdr *Header) error {
+	if hdr.TraceId == "" {
+		hdr.TraceId = newTraceId()
+	}
 	observer := conn.observerFactory.RPCObserver()
 	req, err := conn.bindRequest(hdr)
 	if err != nil {
@@ -450,6 +463,7 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, observer Observe
 	hdr := &Header{
 		RequestId: reqHdr.RequestId,
 		Version:   reqHdr.Version,
+		TraceId:   reqHdr.TraceId,
 	}
 	if err, ok := err.(ErrorCoder); ok {
 		hdr.ErrorCode = err.ErrorCode()
@@ -507,13 +521,18 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, o
 	defer func() {
 		if panicResult := recover(); panicResult != nil {
 			logger.Criticalf(
-				"panic running request %+v with arg %+v: %v\n%v", req, arg, panicResult, string(debug.Stack()))
+				"panic running request %+v (trace %s) with arg %+v: %v\n%v",
+				req, req.hdr.TraceId, arg, panicResult, string(debug.Stack()))
 			conn.writeErrorResponse(&req.hdr, errors.Errorf("%v", panicResult), observer)
 		}
 	}()
 	defer conn.srvPending.Done()
 
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	// The trace ID is passed to facade methods that take a context,
+	// so that they can mark the log messages written on behalf of
+	// the request.
+	ctx := trace.WithID(context.Background(), req.hdr.TraceId)
+	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
@@ -565,3 +584,15 @@ type nopObserver struct{}
 func (nopObserver) ServerRequest(hdr *Header, body interface{}) {}
 
 func (nopObserver) ServerReply(req Request, hdr *Header, body interface{}) {}
+
+// newTraceId returns a new random correlation ID for a request.
+func newTraceId() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// This should never happen; fall back to no ID
+		// rather than failing the request.
+		logger.Errorf("cannot generate trace id: %v", err)
+		return ""
+	}
+	return hex.EncodeToString(buf[:])
+}
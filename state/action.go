@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/juju/errors"
@@ -210,10 +212,33 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// MaxActionResultsSize is the largest size, in bytes, of the
+// structured results that are stored for an action. Larger results
+// are discarded, and the action's message records that they were.
+// Actions that need to return more data can store it as artifacts.
+const MaxActionResultsSize = 512 * 1024
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
-	return a.removeAndLog(results.Status, results.Results, results.Message)
+	output, message := limitActionResults(results.Results, results.Message)
+	return a.removeAndLog(results.Status, output, message)
+}
+
+// limitActionResults returns the given results and message, unless
+// the results are larger than MaxActionResultsSize, in which case
+// they are discarded and the message says so.
+func limitActionResults(results map[string]interface{}, message string) (map[string]interface{}, string) {
+	data, err := bson.Marshal(bson.M{"results": results})
+	if err != nil || len(data) <= MaxActionResultsSize {
+		// Any error will be reported when the results are stored.
+		return results, message
+	}
+	note := fmt.Sprintf("results discarded: %d bytes exceeds the limit of %d bytes", len(data), MaxActionResultsSize)
+	if message == "" {
+		return nil, note
+	}
+	return nil, fmt.Sprintf("%s (%s)", message, note)
 }
 
 // removeAndLog takes the action off of the pending queue, and creates
@@ -369,6 +394,74 @@ func (m *Model) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
+// ActionQuery holds the criteria used by FindActions. Actions must
+// match all of the criteria that are set.
+type ActionQuery struct {
+	// Application, if set, matches the actions of the
+	// application's units.
+	Application string
+
+	// Statuses, if not empty, matches actions with any of the
+	// given statuses.
+	Statuses []ActionStatus
+
+	// EnqueuedAfter, if not zero, matches actions that were
+	// enqueued at or after the given time.
+	EnqueuedAfter time.Time
+
+	// EnqueuedBefore, if not zero, matches actions that were
+	// enqueued before the given time.
+	EnqueuedBefore time.Time
+
+	// Limit, if positive, is the maximum number of actions
+	// returned.
+	Limit int
+}
+
+// FindActions returns the actions matching the given query, most
+// recently enqueued first.
+func (m *Model) FindActions(query ActionQuery) ([]Action, error) {
+	sel := bson.D{}
+	if query.Application != "" {
+		if !names.IsValidApplication(query.Application) {
+			return nil, errors.NotValidf("application name %q", query.Application)
+		}
+		sel = append(sel, bson.DocElem{"receiver", bson.D{
+			{"$regex", "^" + regexp.QuoteMeta(query.Application) + "/"},
+		}})
+	}
+	if len(query.Statuses) > 0 {
+		sel = append(sel, bson.DocElem{"status", bson.D{{"$in", query.Statuses}}})
+	}
+	enqueued := bson.D{}
+	if !query.EnqueuedAfter.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$gte", query.EnqueuedAfter})
+	}
+	if !query.EnqueuedBefore.IsZero() {
+		enqueued = append(enqueued, bson.DocElem{"$lt", query.EnqueuedBefore})
+	}
+	if len(enqueued) > 0 {
+		sel = append(sel, bson.DocElem{"enqueued", enqueued})
+	}
+
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	q := actions.Find(sel).Sort("-enqueued")
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	var docs []actionDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot find actions")
+	}
+	results := make([]Action, len(docs))
+	for i, doc := range docs {
+		results[i] = newAction(m.st, doc)
+	}
+	return results, nil
+}
+
 // EnqueueAction
 func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
 	if len(actionName) == 0 {
@@ -483,8 +576,12 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 // PruneActions removes action entries until
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// deletion. The artifacts of the removed actions are removed
+// too.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
 	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(pruneActionArtifacts(st))
 }
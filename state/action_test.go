@@ -540,6 +540,72 @@ func (s *ActionSuite) TestFindActionsByName(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestFindActions(c *gc.C) {
+	a0, err := s.model.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err := s.model.EnqueueAction(s.unit2.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.EnqueueAction(s.actionlessUnit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ids := func(actions []state.Action) []string {
+		var ids []string
+		for _, action := range actions {
+			ids = append(ids, action.Id())
+		}
+		return ids
+	}
+
+	results, err := s.model.FindActions(state.ActionQuery{Application: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids(results), jc.SameContents, []string{a0.Id(), a1.Id()})
+
+	results, err = s.model.FindActions(state.ActionQuery{
+		Application: "dummy",
+		Statuses:    []state.ActionStatus{state.ActionPending},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids(results), jc.DeepEquals, []string{a0.Id()})
+
+	results, err = s.model.FindActions(state.ActionQuery{
+		EnqueuedAfter: a0.Enqueued().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+
+	results, err = s.model.FindActions(state.ActionQuery{
+		EnqueuedBefore: a0.Enqueued().Add(time.Hour),
+		Limit:          2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+}
+
+func (s *ActionSuite) TestFindActionsInvalidApplication(c *gc.C) {
+	_, err := s.model.FindActions(state.ActionQuery{Application: "dummy/0"})
+	c.Assert(err, gc.ErrorMatches, `application name "dummy/0" not valid`)
+}
+
+func (s *ActionSuite) TestFinishDiscardsLargeResults(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{
+		"output": strings.Repeat("x", state.MaxActionResultsSize),
+	}
+	result, err := a.Finish(state.ActionResults{
+		Status:  state.ActionCompleted,
+		Results: output,
+		Message: "done",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	res, message := result.Results()
+	c.Assert(res, gc.HasLen, 0)
+	c.Assert(message, gc.Matches, `done \(results discarded: \d+ bytes exceeds the limit of 524288 bytes\)`)
+}
+
 func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	// LP-1391914 :: idPrefixWatcher fails watcher contract to send
 	// initial Change event
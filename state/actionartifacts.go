@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+const (
+	// MaxActionArtifactSize is the largest artifact, in bytes, that
+	// may be stored for an action.
+	MaxActionArtifactSize = 4 * 1024 * 1024
+
+	// MaxActionArtifacts is the most artifacts that may be stored for
+	// an action.
+	MaxActionArtifacts = 10
+)
+
+var validActionArtifactName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ActionArtifact describes a file returned by an action. The content
+// of the file is held in the model's blob store.
+type ActionArtifact struct {
+	// Name identifies the artifact amongst those of its action.
+	Name string
+
+	// Size is the size of the artifact in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA-256 hash of the artifact.
+	SHA256 string
+
+	// Created is the time the artifact was stored.
+	Created time.Time
+}
+
+type actionArtifactDoc struct {
+	DocId       string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	ActionId    string    `bson:"action-id"`
+	Name        string    `bson:"name"`
+	Size        int64     `bson:"size"`
+	SHA256      string    `bson:"sha256"`
+	StoragePath string    `bson:"storage-path"`
+	Created     time.Time `bson:"created"`
+}
+
+func (doc actionArtifactDoc) artifact() ActionArtifact {
+	return ActionArtifact{
+		Name:    doc.Name,
+		Size:    doc.Size,
+		SHA256:  doc.SHA256,
+		Created: doc.Created,
+	}
+}
+
+func actionArtifactDocID(actionId, name string) string {
+	return actionId + "#" + name
+}
+
+// AddActionArtifact stores a file returned by the running action with
+// the given tag.
+func (m *Model) AddActionArtifact(tag names.ActionTag, name string, content []byte) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add artifact %q to action %s", name, tag.Id())
+	if !validActionArtifactName.MatchString(name) {
+		return errors.NotValidf("artifact name %q", name)
+	}
+	if len(content) > MaxActionArtifactSize {
+		return errors.Errorf("artifact is larger than %d bytes", MaxActionArtifactSize)
+	}
+	action, err := m.ActionByTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if action.Status() != ActionRunning {
+		return errors.Errorf("action is %s", action.Status())
+	}
+	artifacts, err := m.ActionArtifacts(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(artifacts) >= MaxActionArtifacts {
+		return errors.Errorf("action already has %d artifacts", len(artifacts))
+	}
+
+	uuid, err := NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st := m.st
+	storagePath := fmt.Sprintf("actions/%s/%s-%s", tag.Id(), name, uuid)
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(storagePath, bytes.NewReader(content), int64(len(content))); err != nil {
+		return errors.Annotate(err, "storing artifact")
+	}
+	hash := sha256.Sum256(content)
+	doc := actionArtifactDoc{
+		DocId:       st.docID(actionArtifactDocID(tag.Id(), name)),
+		ModelUUID:   st.ModelUUID(),
+		ActionId:    tag.Id(),
+		Name:        name,
+		Size:        int64(len(content)),
+		SHA256:      hex.EncodeToString(hash[:]),
+		StoragePath: storagePath,
+		Created:     st.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      actionsC,
+		Id:     st.docID(tag.Id()),
+		Assert: bson.D{{"status", ActionRunning}},
+	}, {
+		C:      actionArtifactsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if removeErr := stor.Remove(storagePath); removeErr != nil {
+			logger.Warningf("cannot remove artifact %q: %v", storagePath, removeErr)
+		}
+		if err == txn.ErrAborted {
+			if _, err := m.actionArtifactDoc(tag, name); err == nil {
+				return errors.AlreadyExistsf("artifact %q", name)
+			}
+			return errors.New("action is no longer running")
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ActionArtifacts returns the artifacts stored for the action with
+// the given tag, ordered by name.
+func (m *Model) ActionArtifacts(tag names.ActionTag) ([]ActionArtifact, error) {
+	coll, closer := m.st.db().GetCollection(actionArtifactsC)
+	defer closer()
+
+	var docs []actionArtifactDoc
+	if err := coll.Find(bson.D{{"action-id", tag.Id()}}).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get artifacts for action %s", tag.Id())
+	}
+	artifacts := make([]ActionArtifact, len(docs))
+	for i, doc := range docs {
+		artifacts[i] = doc.artifact()
+	}
+	return artifacts, nil
+}
+
+// OpenActionArtifact returns the named artifact of the action with
+// the given tag, and a reader for its content. The reader must be
+// closed by the caller.
+func (m *Model) OpenActionArtifact(tag names.ActionTag, name string) (ActionArtifact, io.ReadCloser, error) {
+	doc, err := m.actionArtifactDoc(tag, name)
+	if err != nil {
+		return ActionArtifact{}, nil, errors.Trace(err)
+	}
+	stor := storage.NewStorage(m.st.ModelUUID(), m.st.MongoSession())
+	r, _, err := stor.Get(doc.StoragePath)
+	if err != nil {
+		return ActionArtifact{}, nil, errors.Annotatef(err, "cannot read artifact %q", name)
+	}
+	return doc.artifact(), r, nil
+}
+
+func (m *Model) actionArtifactDoc(tag names.ActionTag, name string) (actionArtifactDoc, error) {
+	coll, closer := m.st.db().GetCollection(actionArtifactsC)
+	defer closer()
+
+	var doc actionArtifactDoc
+	err := coll.FindId(actionArtifactDocID(tag.Id(), name)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return actionArtifactDoc{}, errors.NotFoundf("artifact %q of action %s", name, tag.Id())
+	} else if err != nil {
+		return actionArtifactDoc{}, errors.Trace(err)
+	}
+	return doc, nil
+}
+
+// pruneActionArtifacts removes the artifacts of actions that no
+// longer exist.
+func pruneActionArtifacts(st *State) error {
+	artifacts, closer := st.db().GetCollection(actionArtifactsC)
+	defer closer()
+	actions, closer2 := st.db().GetCollection(actionsC)
+	defer closer2()
+
+	var docs []actionArtifactDoc
+	if err := artifacts.Find(nil).Select(bson.D{
+		{"_id", 1}, {"action-id", 1}, {"storage-path", 1},
+	}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get action artifacts")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	actionIds := set.NewStrings()
+	for _, doc := range docs {
+		actionIds.Add(st.docID(doc.ActionId))
+	}
+	var existing []struct {
+		Id string `bson:"_id"`
+	}
+	if err := actions.Find(bson.D{{"_id", bson.D{{"$in", actionIds.Values()}}}}).Select(bson.D{{"_id", 1}}).All(&existing); err != nil {
+		return errors.Annotate(err, "cannot get actions")
+	}
+	existingIds := set.NewStrings()
+	for _, action := range existing {
+		existingIds.Add(st.localID(action.Id))
+	}
+
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	var ops []txn.Op
+	for _, doc := range docs {
+		if existingIds.Contains(doc.ActionId) {
+			continue
+		}
+		if err := stor.Remove(doc.StoragePath); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "cannot remove artifact %q", doc.StoragePath)
+		}
+		ops = append(ops, txn.Op{
+			C:      actionArtifactsC,
+			Id:     doc.DocId,
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(st.db().RunTransaction(ops))
+}
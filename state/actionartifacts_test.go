@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionArtifactsSuite struct {
+	ConnSuite
+	model  *state.Model
+	action state.Action
+}
+
+var _ = gc.Suite(&ActionArtifactsSuite{})
+
+func (s *ActionArtifactsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	app := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.model.EnqueueAction(unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifact(c *gc.C) {
+	tag := s.action.ActionTag()
+	err := s.model.AddActionArtifact(tag, "dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.AddActionArtifact(tag, "backup.tgz", []byte("tarball"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	artifacts, err := s.model.ActionArtifacts(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts, gc.HasLen, 2)
+	c.Assert(artifacts[0].Name, gc.Equals, "backup.tgz")
+	c.Assert(artifacts[1].Name, gc.Equals, "dump.sql")
+	c.Assert(artifacts[1].Size, gc.Equals, int64(len("create table")))
+	hash := sha256.Sum256([]byte("create table"))
+	c.Assert(artifacts[1].SHA256, gc.Equals, hex.EncodeToString(hash[:]))
+
+	artifact, r, err := s.model.OpenActionArtifact(tag, "dump.sql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Assert(artifact, jc.DeepEquals, artifacts[1])
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "create table")
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifactExists(c *gc.C) {
+	tag := s.action.ActionTag()
+	err := s.model.AddActionArtifact(tag, "dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.AddActionArtifact(tag, "dump.sql", []byte("drop table"))
+	c.Assert(err, gc.ErrorMatches, `cannot add artifact "dump.sql" to action .*: artifact "dump.sql" already exists`)
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifactInvalidName(c *gc.C) {
+	err := s.model.AddActionArtifact(s.action.ActionTag(), "../passwd", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add artifact "../passwd" to action .*: artifact name "../passwd" not valid`)
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifactTooLarge(c *gc.C) {
+	content := make([]byte, state.MaxActionArtifactSize+1)
+	err := s.model.AddActionArtifact(s.action.ActionTag(), "big", content)
+	c.Assert(err, gc.ErrorMatches, `cannot add artifact "big" to action .*: artifact is larger than 4194304 bytes`)
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifactTooMany(c *gc.C) {
+	tag := s.action.ActionTag()
+	for i := 0; i < state.MaxActionArtifacts; i++ {
+		err := s.model.AddActionArtifact(tag, string('a'+rune(i)), nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.model.AddActionArtifact(tag, "z", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add artifact "z" to action .*: action already has 10 artifacts`)
+}
+
+func (s *ActionArtifactsSuite) TestAddActionArtifactNotRunning(c *gc.C) {
+	_, err := s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.AddActionArtifact(s.action.ActionTag(), "dump.sql", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add artifact "dump.sql" to action .*: action is completed`)
+}
+
+func (s *ActionArtifactsSuite) TestOpenActionArtifactNotFound(c *gc.C) {
+	_, _, err := s.model.OpenActionArtifact(s.action.ActionTag(), "dump.sql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionArtifactsSuite) TestPruneRemovesArtifacts(c *gc.C) {
+	tag := s.action.ActionTag()
+	err := s.model.AddActionArtifact(tag, "dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Artifacts are kept for as long as their action is.
+	err = state.PruneActions(s.State, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	artifacts, err := s.model.ActionArtifacts(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts, gc.HasLen, 1)
+
+	state.RemoveAction(c, s.State, tag.Id())
+	err = state.PruneActions(s.State, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	artifacts, err = s.model.ActionArtifacts(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(artifacts, gc.HasLen, 0)
+}
@@ -0,0 +1,291 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MinActionScheduleInterval is the shortest interval at which a
+// scheduled action may recur.
+const MinActionScheduleInterval = time.Minute
+
+// ActionSchedule represents an action that is enqueued on a unit
+// at regular intervals.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the name of the unit on which the action runs.
+	Receiver string `bson:"receiver"`
+
+	// Name identifies the action.
+	Name string `bson:"name"`
+
+	// Parameters holds the action's parameters.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Interval is the time between successive runs.
+	Interval time.Duration `bson:"interval"`
+
+	// NextRun is the time at which the action is next due to be
+	// enqueued.
+	NextRun time.Time `bson:"next-run"`
+
+	// LastActionId is the id of the most recently enqueued action.
+	LastActionId string `bson:"last-action-id,omitempty"`
+}
+
+// Id returns the id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Receiver returns the tag of the unit on which the action runs.
+func (s *ActionSchedule) Receiver() names.UnitTag {
+	return names.NewUnitTag(s.doc.Receiver)
+}
+
+// Name returns the name of the scheduled action.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters of the scheduled action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Interval returns the time between successive runs of the action.
+func (s *ActionSchedule) Interval() time.Duration {
+	return s.doc.Interval
+}
+
+// NextRun returns the time at which the action is next due.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastActionId returns the id of the action most recently enqueued
+// by the schedule, or the empty string if none has been.
+func (s *ActionSchedule) LastActionId() string {
+	return s.doc.LastActionId
+}
+
+// ActionScheduleArgs holds the arguments for AddActionSchedule.
+type ActionScheduleArgs struct {
+	// Receiver is the unit on which the action will run.
+	Receiver names.UnitTag
+
+	// Name is the name of the action.
+	Name string
+
+	// Parameters holds the action's parameters.
+	Parameters map[string]interface{}
+
+	// Interval is the time between successive runs of the action.
+	Interval time.Duration
+
+	// Start is the time of the first run. If it is zero, the
+	// action first runs after one interval.
+	Start time.Time
+}
+
+// AddActionSchedule adds a schedule which will enqueue the given
+// action on a unit at regular intervals.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if args.Interval < MinActionScheduleInterval {
+		return nil, errors.NotValidf("interval %v (minimum %v)", args.Interval, MinActionScheduleInterval)
+	}
+	unit, err := m.st.Unit(args.Receiver.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, err := unit.actionSpec(args.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	id, err := NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	start := args.Start
+	if start.IsZero() {
+		start = m.st.nowToTheSecond().Add(args.Interval)
+	}
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(id.String()),
+		ModelUUID:  m.st.ModelUUID(),
+		Receiver:   unit.Name(),
+		Name:       args.Name,
+		Parameters: args.Parameters,
+		Interval:   args.Interval,
+		NextRun:    start.UTC(),
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     unit.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("unit %q is not alive", unit.Name())
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	doc.NextRun = doc.NextRun.UTC()
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedules returns all the action schedules in the model,
+// ordered by the time they are next due.
+func (m *Model) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("next-run").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		doc.NextRun = doc.NextRun.UTC()
+		result[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
+
+// DispatchDueActionSchedules enqueues the actions for all schedules
+// that are due, and returns the time at which the next schedule is
+// due, or the zero time if there are no schedules. Runs missed while
+// no controller was dispatching are skipped, rather than enqueued
+// all at once. Schedules for units that no longer exist are removed.
+func (m *Model) DispatchDueActionSchedules() (time.Time, error) {
+	schedules, err := m.ActionSchedules()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	var next time.Time
+	for _, schedule := range schedules {
+		nextRun := schedule.doc.NextRun
+		if !nextRun.After(now) {
+			nextRun, err = m.dispatchActionSchedule(schedule, now)
+			if err != nil {
+				return time.Time{}, errors.Trace(err)
+			}
+		}
+		if !nextRun.IsZero() && (next.IsZero() || nextRun.Before(next)) {
+			next = nextRun
+		}
+	}
+	return next, nil
+}
+
+// dispatchActionSchedule enqueues the schedule's action, and returns
+// the time at which it is next due, or the zero time if the schedule
+// no longer exists.
+func (m *Model) dispatchActionSchedule(schedule *ActionSchedule, now time.Time) (time.Time, error) {
+	doc := schedule.doc
+	missed := now.Sub(doc.NextRun) / doc.Interval
+	nextRun := doc.NextRun.Add((missed + 1) * doc.Interval)
+
+	// Advance the schedule before enqueuing the action, so that
+	// concurrent dispatchers cannot both enqueue it.
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"next-run", doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{{"next-run", nextRun}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The schedule was removed, or dispatched elsewhere.
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Annotatef(err, "cannot advance action schedule %q", schedule.Id())
+	}
+
+	unit, err := m.st.Unit(doc.Receiver)
+	if errors.IsNotFound(err) {
+		logger.Infof("removing action schedule %q for missing unit %q", schedule.Id(), doc.Receiver)
+		if err := m.RemoveActionSchedule(schedule.Id()); err != nil && !errors.IsNotFound(err) {
+			return time.Time{}, errors.Trace(err)
+		}
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	// Copy the parameters, as AddAction inserts defaults into them.
+	payload := make(map[string]interface{}, len(doc.Parameters))
+	for k, v := range doc.Parameters {
+		payload[k] = v
+	}
+	action, err := unit.AddAction(doc.Name, payload)
+	if err != nil {
+		// The charm may have been upgraded, or the unit may be
+		// dying; keep the schedule so it can run again later.
+		logger.Warningf("cannot enqueue scheduled action %q on unit %q: %v", doc.Name, doc.Receiver, err)
+		return nextRun, nil
+	}
+	ops = []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"last-action-id", action.Id()}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return time.Time{}, errors.Annotatef(err, "cannot update action schedule %q", schedule.Id())
+	}
+	return nextRun, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	unit  *state.Unit
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	err = s.unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C) *state.ActionSchedule {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver:   s.unit.UnitTag(),
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "out.tar.bz2"},
+		Interval:   time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c)
+	c.Assert(schedule.Receiver(), gc.Equals, s.unit.UnitTag())
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Interval(), gc.Equals, time.Hour)
+	c.Assert(schedule.NextRun(), gc.Equals, s.Clock.Now().Round(time.Second).UTC().Add(time.Hour))
+
+	schedules, err := s.model.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Id(), gc.Equals, schedule.Id())
+	c.Assert(schedules[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.tar.bz2"})
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, t := range []struct {
+		args        state.ActionScheduleArgs
+		expectedErr string
+	}{{
+		args: state.ActionScheduleArgs{
+			Receiver: s.unit.UnitTag(),
+			Name:     "snapshot",
+			Interval: time.Second,
+		},
+		expectedErr: "interval 1s \\(minimum 1m0s\\) not valid",
+	}, {
+		args: state.ActionScheduleArgs{
+			Receiver: s.unit.UnitTag(),
+			Name:     "nonexistent",
+			Interval: time.Hour,
+		},
+		expectedErr: `action "nonexistent" not defined on unit "dummy/0"`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Receiver:   s.unit.UnitTag(),
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": 5.0},
+			Interval:   time.Hour,
+		},
+		expectedErr: `validation failed: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(t.args)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c)
+	err := s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestDispatchNotDue(c *gc.C) {
+	schedule := s.addSchedule(c)
+	next, err := s.model.DispatchDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, schedule.NextRun())
+	s.assertActionCount(c, 0)
+}
+
+func (s *ActionScheduleSuite) TestDispatchDue(c *gc.C) {
+	schedule := s.addSchedule(c)
+	s.Clock.Advance(time.Hour)
+	next, err := s.model.DispatchDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, schedule.NextRun().Add(time.Hour))
+	s.assertActionCount(c, 1)
+
+	schedule, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun(), gc.Equals, next)
+	action, err := s.model.Action(schedule.LastActionId())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Name(), gc.Equals, "snapshot")
+
+	// Dispatching again does not enqueue another action.
+	_, err = s.model.DispatchDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionCount(c, 1)
+}
+
+func (s *ActionScheduleSuite) TestDispatchSkipsMissedRuns(c *gc.C) {
+	schedule := s.addSchedule(c)
+	s.Clock.Advance(5*time.Hour + 30*time.Minute)
+	next, err := s.model.DispatchDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next, gc.Equals, schedule.NextRun().Add(5*time.Hour))
+	s.assertActionCount(c, 1)
+}
+
+func (s *ActionScheduleSuite) TestDispatchRemovesScheduleForMissingUnit(c *gc.C) {
+	schedule := s.addSchedule(c)
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	next, err := s.model.DispatchDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.IsZero(), jc.IsTrue)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) assertActionCount(c *gc.C, count int) {
+	actions, err := s.unit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, count)
+}
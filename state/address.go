@@ -145,6 +145,20 @@ func (st *State) APIHostPorts() ([][]network.HostPort, error) {
 	return networkHostsPorts(doc.APIHostPorts), nil
 }
 
+// PreferIPv6 reports whether the model prefers IPv6 addresses over
+// IPv4 ones, as determined by its prefer-ipv6 config setting.
+func (st *State) PreferIPv6() (bool, error) {
+	m, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.PreferIPv6(), nil
+}
+
 // address represents the location of a machine, including metadata
 // about what kind of location the address describes.
 //
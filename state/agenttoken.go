@@ -0,0 +1,314 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ErrAgentTokenExpired is returned by AgentCredentialsValid when the
+// credentials match a token that has expired but has not been revoked.
+var ErrAgentTokenExpired = errors.New("agent token expired")
+
+// AgentTokenHolder is an entity whose agent may authenticate with
+// rotating agent tokens.
+type AgentTokenHolder interface {
+	Entity
+	Authenticator
+}
+
+// agentTokenDoc records the validity of an agent's tokens. Each token
+// is set as the agent's password when it is issued, so the password
+// hash held by the entity is always the hash of the current token;
+// this document adds an expiry, and keeps the previous token valid
+// until its own expiry in case the agent fails to record the new one.
+//
+// An agent with no token document authenticates with its password
+// alone, as it did before tokens were introduced.
+type agentTokenDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Entity is the tag of the agent's entity.
+	Entity string `bson:"entity"`
+
+	// TokenHash is the hash of the current token.
+	TokenHash string `bson:"token-hash"`
+
+	// Issued is the time at which the current token was issued.
+	Issued time.Time `bson:"issued"`
+
+	// Expires is the time at which the current token expires.
+	Expires time.Time `bson:"expires"`
+
+	// PreviousTokenHash is the hash of the previously issued token,
+	// or of the agent's password when only one token has been issued.
+	PreviousTokenHash string `bson:"previous-token-hash,omitempty"`
+
+	// PreviousExpires is the time at which the previous token expires.
+	PreviousExpires time.Time `bson:"previous-expires"`
+
+	// Revoked records that the agent may no longer authenticate.
+	Revoked bool `bson:"revoked"`
+}
+
+// IssueAgentToken issues a new token for the entity's agent, valid
+// for the given lifetime, and sets it as the agent's password. The
+// previously issued token remains valid until it expires; when the
+// first token is issued, the agent's password remains valid for the
+// token's lifetime.
+func (st *State) IssueAgentToken(entity AgentTokenHolder, lifetime time.Duration) (string, time.Time, error) {
+	if lifetime <= 0 {
+		return "", time.Time{}, errors.NotValidf("agent token lifetime %v", lifetime)
+	}
+	token, err := utils.RandomPassword()
+	if err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	tokenHash := utils.AgentPasswordHash(token)
+	tag := entity.Tag()
+	now := st.nowToTheSecond()
+	expires := now.Add(lifetime)
+	previousHash := agentPasswordHash(entity)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := entity.Refresh(); errors.IsNotFound(err) {
+				return nil, ErrDead
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// The password hash is updated in the same transaction
+		// as the token document, so that the two never disagree.
+		ops, err := setAgentPasswordHashOps(entity, tokenHash)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc, err := st.agentToken(tag)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      agentTokensC,
+				Id:     st.docID(tag.String()),
+				Assert: txn.DocMissing,
+				Insert: &agentTokenDoc{
+					Entity:            tag.String(),
+					TokenHash:         tokenHash,
+					Issued:            now,
+					Expires:           expires,
+					PreviousTokenHash: previousHash,
+					PreviousExpires:   expires,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Revoked {
+			return nil, errors.Unauthorizedf("agent tokens for %s have been revoked", names.ReadableString(tag))
+		}
+		return append(ops, txn.Op{
+			C:  agentTokensC,
+			Id: doc.DocID,
+			Assert: bson.D{
+				{"token-hash", doc.TokenHash},
+				{"revoked", false},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"token-hash", tokenHash},
+				{"issued", now},
+				{"expires", expires},
+				{"previous-token-hash", doc.TokenHash},
+				{"previous-expires", doc.Expires},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return "", time.Time{}, errors.Annotatef(err, "cannot issue agent token for %s", names.ReadableString(tag))
+	}
+	setAgentPasswordHash(entity, tokenHash)
+	return token, expires, nil
+}
+
+// AgentTokenValidity returns the times at which the current token of
+// the agent of the entity with the given tag was issued and expires.
+// It returns a NotFound error if no token has been issued.
+func (st *State) AgentTokenValidity(tag names.Tag) (time.Time, time.Time, error) {
+	doc, err := st.agentToken(tag)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Trace(err)
+	}
+	if doc.Revoked {
+		return time.Time{}, time.Time{}, errors.Unauthorizedf("agent tokens for %s have been revoked", names.ReadableString(tag))
+	}
+	return doc.Issued, doc.Expires, nil
+}
+
+// AgentCredentialsValid reports whether the credentials are valid for
+// the entity's agent. Once an agent has been issued a token, it may
+// only authenticate with an unexpired token. If the credentials match
+// a token that has expired, as happens when the agent has been offline
+// for longer than the token's lifetime, ErrAgentTokenExpired is
+// returned; the agent may then only be issued a new token. Revoking
+// the agent's tokens is what prevents it from authenticating at all.
+func (st *State) AgentCredentialsValid(entity AgentTokenHolder, credentials string) (bool, error) {
+	doc, err := st.agentToken(entity.Tag())
+	if errors.IsNotFound(err) {
+		return entity.PasswordValid(credentials), nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if doc.Revoked {
+		return false, nil
+	}
+	var expires time.Time
+	if entity.PasswordValid(credentials) {
+		expires = doc.Expires
+	} else if doc.PreviousTokenHash != "" && utils.AgentPasswordHash(credentials) == doc.PreviousTokenHash {
+		expires = doc.PreviousExpires
+	} else {
+		return false, nil
+	}
+	if !st.clock().Now().Before(expires) {
+		return false, ErrAgentTokenExpired
+	}
+	return true, nil
+}
+
+// RevokeAgentTokens prevents the agent of the entity with the given tag
+// from authenticating, and from being issued new tokens. Revoking the
+// tokens of a machine also revokes those of the units it hosts.
+func (st *State) RevokeAgentTokens(tag names.Tag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		tags := []names.Tag{tag}
+		if machineTag, ok := tag.(names.MachineTag); ok {
+			machine, err := st.Machine(machineTag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := machine.Units()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range units {
+				tags = append(tags, unit.UnitTag())
+			}
+		}
+		var ops []txn.Op
+		for _, tag := range tags {
+			op, err := st.revokeAgentTokensOp(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, op)
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot revoke agent tokens for %s", names.ReadableString(tag))
+	}
+	return nil
+}
+
+// revokeAgentTokensOp returns an operation that revokes the agent
+// tokens of the entity with the given tag.
+func (st *State) revokeAgentTokensOp(tag names.Tag) (txn.Op, error) {
+	_, err := st.agentToken(tag)
+	if errors.IsNotFound(err) {
+		return txn.Op{
+			C:      agentTokensC,
+			Id:     st.docID(tag.String()),
+			Assert: txn.DocMissing,
+			Insert: &agentTokenDoc{
+				Entity:  tag.String(),
+				Revoked: true,
+			},
+		}, nil
+	} else if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	return txn.Op{
+		C:      agentTokensC,
+		Id:     st.docID(tag.String()),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"token-hash", ""},
+			{"previous-token-hash", ""},
+			{"revoked", true},
+		}}},
+	}, nil
+}
+
+// agentPasswordHash returns the hash of the password the entity's
+// agent currently authenticates with.
+func agentPasswordHash(entity AgentTokenHolder) string {
+	switch entity := entity.(type) {
+	case *Machine:
+		return entity.doc.PasswordHash
+	case *Unit:
+		return entity.doc.PasswordHash
+	}
+	return ""
+}
+
+// setAgentPasswordHashOps returns the operations that set the password
+// hash of the entity's agent.
+func setAgentPasswordHashOps(entity AgentTokenHolder, passwordHash string) ([]txn.Op, error) {
+	switch entity := entity.(type) {
+	case *Machine:
+		return entity.setPasswordHashOps(passwordHash)
+	case *Unit:
+		if entity.doc.Life == Dead {
+			return nil, ErrDead
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     entity.doc.DocID,
+			Assert: notDeadDoc,
+			Update: bson.D{{"$set", bson.D{{"passwordhash", passwordHash}}}},
+		}}, nil
+	}
+	return nil, errors.NotSupportedf("agent tokens for %s", names.ReadableString(entity.Tag()))
+}
+
+// setAgentPasswordHash records the password hash of the entity's agent
+// once it has been written to the database.
+func setAgentPasswordHash(entity AgentTokenHolder, passwordHash string) {
+	switch entity := entity.(type) {
+	case *Machine:
+		entity.doc.PasswordHash = passwordHash
+	case *Unit:
+		entity.doc.PasswordHash = passwordHash
+	}
+}
+
+func (st *State) agentToken(tag names.Tag) (*agentTokenDoc, error) {
+	tokens, closer := st.db().GetCollection(agentTokensC)
+	defer closer()
+
+	var doc agentTokenDoc
+	err := tokens.FindId(tag.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("agent token for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// removeAgentTokenOp returns an operation that removes the agent
+// token document for the entity with the given tag, if it exists.
+func removeAgentTokenOp(st *State, tag names.Tag) txn.Op {
+	return txn.Op{
+		C:      agentTokensC,
+		Id:     st.docID(tag.String()),
+		Remove: true,
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AgentTokenSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&AgentTokenSuite{})
+
+func (s *AgentTokenSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetPassword("password-that-is-long-enough")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentTokenSuite) assertValid(c *gc.C, credentials string, expect bool) {
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	valid, err := s.State.AgentCredentialsValid(machine, credentials)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid, gc.Equals, expect)
+}
+
+func (s *AgentTokenSuite) assertExpired(c *gc.C, credentials string) {
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	valid, err := s.State.AgentCredentialsValid(machine, credentials)
+	c.Assert(err, gc.Equals, state.ErrAgentTokenExpired)
+	c.Assert(valid, jc.IsFalse)
+}
+
+func (s *AgentTokenSuite) TestPasswordValidWithoutToken(c *gc.C) {
+	s.assertValid(c, "password-that-is-long-enough", true)
+	s.assertValid(c, "wrong-password-that-is-long", false)
+}
+
+func (s *AgentTokenSuite) TestIssueAgentToken(c *gc.C) {
+	token, expires, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token, gc.Not(gc.Equals), "")
+	c.Assert(expires, gc.Equals, s.Clock.Now().Round(time.Second).UTC().Add(time.Hour))
+
+	s.assertValid(c, token, true)
+	s.assertValid(c, "password-that-is-long-enough", true)
+
+	// The password is replaced by the token, and remains valid only
+	// until the token expires.
+	s.Clock.Advance(2 * time.Hour)
+	s.assertExpired(c, "password-that-is-long-enough")
+}
+
+func (s *AgentTokenSuite) TestIssueAgentTokenSetsPasswordHash(c *gc.C) {
+	token, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.PasswordValid(token), jc.IsTrue)
+
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.PasswordValid(token), jc.IsTrue)
+}
+
+func (s *AgentTokenSuite) TestIssueAgentTokenDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrDead)
+}
+
+func (s *AgentTokenSuite) TestAgentTokenValidity(c *gc.C) {
+	_, _, err := s.State.AgentTokenValidity(s.machine.MachineTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	now := s.Clock.Now().Round(time.Second).UTC()
+	_, _, err = s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	issued, expires, err := s.State.AgentTokenValidity(s.machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issued, gc.Equals, now)
+	c.Assert(expires, gc.Equals, now.Add(time.Hour))
+}
+
+func (s *AgentTokenSuite) TestPasswordInvalidAfterSecondToken(c *gc.C) {
+	_, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertValid(c, "password-that-is-long-enough", false)
+}
+
+func (s *AgentTokenSuite) TestIssueAgentTokenInvalidLifetime(c *gc.C) {
+	_, _, err := s.State.IssueAgentToken(s.machine, 0)
+	c.Assert(err, gc.ErrorMatches, "agent token lifetime 0s not valid")
+}
+
+func (s *AgentTokenSuite) TestPreviousTokenRemainsValid(c *gc.C) {
+	first, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(30 * time.Minute)
+	second, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertValid(c, first, true)
+	s.assertValid(c, second, true)
+
+	s.Clock.Advance(45 * time.Minute)
+	s.assertExpired(c, first)
+	s.assertValid(c, second, true)
+}
+
+func (s *AgentTokenSuite) TestTokenExpires(c *gc.C) {
+	token, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(2 * time.Hour)
+	s.assertExpired(c, token)
+	s.assertValid(c, "wrong-password-that-is-long", false)
+}
+
+func (s *AgentTokenSuite) TestRevokeAgentTokens(c *gc.C) {
+	token, _, err := s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevokeAgentTokens(s.machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertValid(c, token, false)
+
+	_, _, err = s.State.IssueAgentToken(s.machine, time.Hour)
+	c.Assert(err, gc.ErrorMatches, `cannot issue agent token for machine 0: agent tokens for machine 0 have been revoked`)
+}
+
+func (s *AgentTokenSuite) TestRevokeAgentTokensWithoutToken(c *gc.C) {
+	err := s.State.RevokeAgentTokens(s.machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertValid(c, "password-that-is-long-enough", false)
+}
+
+func (s *AgentTokenSuite) TestRevokeAgentTokensCascadesToUnits(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+	err := unit.SetPassword("unit-password-that-is-long")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevokeAgentTokens(s.machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err = s.State.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	valid, err := s.State.AgentCredentialsValid(unit, "unit-password-that-is-long")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid, jc.IsFalse)
+	_, _, err = s.State.IssueAgentToken(unit, time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
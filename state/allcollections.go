@@ -104,6 +104,36 @@ func allCollections() collectionSchema {
 		// the simplestreams data source pointing to Juju GUI archives.
 		guimetadataC: {global: true},
 
+		// This collection holds the endpoints to which the controller
+		// sends events about the entities in its models.
+		webhooksC: {global: true},
+
+		// This collection holds the scoped tokens with which local
+		// users may log in in place of their passwords.
+		apiTokensC: {global: true},
+
+		// This collection holds the details of the signed model
+		// archives held in the controller's blob store. Archives
+		// outlive their models, so the collection is global.
+		modelArchivesC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
+
+		// This collection holds a rolling window of the internal
+		// metrics sampled by each controller machine agent.
+		controllerMetricsC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"machine", "time"},
+			}, {
+				Key: []string{"time"},
+			}},
+		},
+
 		// This collection holds Juju GUI current version and other settings.
 		guisettingsC: {global: true},
 
@@ -208,7 +238,15 @@ func allCollections() collectionSchema {
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
-			rawAccess: true,
+			rawAccess:     true,
+			modelDatabase: true,
+		},
+
+		// This collection holds the most recent dependency engine
+		// report sent by each of the model's agents.
+		engineReportsC: {
+			rawAccess:     true,
+			modelDatabase: true,
 		},
 
 		// -----------------
@@ -268,6 +306,19 @@ func allCollections() collectionSchema {
 		},
 		minUnitsC: {},
 
+		// This collection holds the charm upgrade, if any, found by
+		// the charm revision updater for each application.
+		charmUpgradesC: {},
+
+		// This collection holds the reboots requested by units, which
+		// are granted one application unit at a time by the reboot
+		// coordinator.
+		rebootRequestsC: {},
+
+		// This collection holds the model's staged agent upgrade, if
+		// any, which controls which machines may upgrade.
+		stagedUpgradesC: {},
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -302,6 +353,10 @@ func allCollections() collectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the WireGuard key pairs generated by
+		// the controller for machines joining a WireGuard mesh.
+		wireGuardKeysC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -364,10 +419,30 @@ func allCollections() collectionSchema {
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "enqueued"},
 			}},
 		},
 		actionNotificationsC: {},
 
+		// This collection holds the metadata of the files returned
+		// by actions; their content is held in the blob store.
+		actionArtifactsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "action-id"},
+			}},
+		},
+
+		// This collection holds schedules for recurring actions,
+		// which are dispatched by the actionscheduler worker.
+		actionSchedulesC: {},
+
+		// -----
+
+		// This collection holds the expiry and revocation state of
+		// rotating agent tokens for machine and unit agents.
+		agentTokensC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -401,6 +476,14 @@ func allCollections() collectionSchema {
 		// unit relation settings, model config, etc etc etc.
 		settingsC: {},
 
+		// This collection holds a bounded history of the changes made
+		// to application config and model config settings.
+		settingsHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "globalkey", "version"},
+			}},
+		},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		statusesC: {
@@ -409,7 +492,8 @@ func allCollections() collectionSchema {
 			}},
 		},
 		statusesHistoryC: {
-			rawAccess: true,
+			rawAccess:     true,
+			modelDatabase: true,
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "globalkey", "updated"},
 			}, {
@@ -421,6 +505,16 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds periodic snapshots of the full status
+		// of each model, so that past status may be reviewed.
+		statusSnapshotsC: {
+			rawAccess:     true,
+			modelDatabase: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "-taken"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -478,8 +572,12 @@ func allCollections() collectionSchema {
 // inspection.
 const (
 	actionNotificationsC     = "actionnotifications"
+	actionArtifactsC         = "actionartifacts"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
+	agentTokensC             = "agenttokens"
+	apiTokensC               = "apitokens"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
@@ -495,6 +593,7 @@ const (
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
+	controllerMetricsC       = "controllermetrics"
 	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
@@ -516,6 +615,7 @@ const (
 	migrationsStatusC        = "migrations.status"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
+	modelArchivesC           = "modelarchives"
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
 	openedPortsC             = "openedPorts"
@@ -523,18 +623,24 @@ const (
 	permissionsC             = "permissions"
 	providerIDsC             = "providerIDs"
 	rebootC                  = "reboot"
+	rebootRequestsC          = "rebootrequests"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
+	charmUpgradesC           = "charmupgrades"
 	endpointBindingsC        = "endpointbindings"
+	engineReportsC           = "enginereports"
 	settingsC                = "settings"
+	settingsHistoryC         = "settingshistory"
 	refcountsC               = "refcounts"
 	sshHostKeysC             = "sshhostkeys"
 	spacesC                  = "spaces"
+	stagedUpgradesC          = "stagedupgrades"
 	statusesC                = "statuses"
 	statusesHistoryC         = "statuseshistory"
+	statusSnapshotsC         = "statussnapshots"
 	storageAttachmentsC      = "storageattachments"
 	storageConstraintsC      = "storageconstraints"
 	storageInstancesC        = "storageinstances"
@@ -552,6 +658,7 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	webhooksC                = "webhooks"
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
@@ -562,4 +669,5 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+	wireGuardKeysC       = "wireguardKeys"
 )
@@ -4,7 +4,10 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -19,11 +22,15 @@ import (
 // due to the fact that it is not accessed directly, but through
 // Annotations/Annotation below.
 // Note also the correspondence with AnnotationInfo in apiserver/params.
+//
+// Each key is held in at most one of Annotations and Structured: setting
+// a string value for a key removes any structured value, and vice versa.
 type annotatorDoc struct {
-	ModelUUID   string            `bson:"model-uuid"`
-	GlobalKey   string            `bson:"globalkey"`
-	Tag         string            `bson:"tag"`
-	Annotations map[string]string `bson:"annotations"`
+	ModelUUID   string                 `bson:"model-uuid"`
+	GlobalKey   string                 `bson:"globalkey"`
+	Tag         string                 `bson:"tag"`
+	Annotations map[string]string      `bson:"annotations"`
+	Structured  map[string]interface{} `bson:"structured,omitempty"`
 }
 
 // SetAnnotations adds key/value pairs to annotations in MongoDB.
@@ -33,20 +40,62 @@ func (m *Model) SetAnnotations(entity GlobalEntity, annotations map[string]strin
 		return nil
 	}
 	// Collect in separate maps pairs to be inserted/updated or removed.
-	toRemove := make(bson.M)
 	toInsert := make(map[string]string)
-	toUpdate := make(bson.M)
+	toSet := make(bson.M)
+	toUnset := make(bson.M)
 	for key, value := range annotations {
-		if strings.Contains(key, ".") {
-			return fmt.Errorf("invalid key %q", key)
+		if err := validateAnnotationKey(key); err != nil {
+			return errors.Trace(err)
 		}
+		toUnset["structured."+key] = true
 		if value == "" {
-			toRemove[key] = true
+			toUnset["annotations."+key] = true
 		} else {
 			toInsert[key] = value
-			toUpdate[key] = value
+			toSet["annotations."+key] = value
 		}
 	}
+	return m.setAnnotations(entity, &annotatorDoc{Annotations: toInsert}, toSet, toUnset)
+}
+
+// SetStructuredAnnotations adds key/value pairs to annotations in
+// MongoDB, where the values may be any value that can be represented
+// in JSON. Structured values are stored as documents, so that entities
+// can be found by their fields with AnnotatedEntities. A nil value
+// removes the annotation.
+func (m *Model) SetStructuredAnnotations(entity GlobalEntity, values map[string]interface{}) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update annotations on %s", entity.Tag())
+	if len(values) == 0 {
+		return nil
+	}
+	toInsert := make(map[string]interface{})
+	toSet := make(bson.M)
+	toUnset := make(bson.M)
+	for key, value := range values {
+		if err := validateAnnotationKey(key); err != nil {
+			return errors.Trace(err)
+		}
+		toUnset["annotations."+key] = true
+		if value == nil {
+			toUnset["structured."+key] = true
+			continue
+		}
+		if err := validateStructuredValue(value); err != nil {
+			return errors.Annotatef(err, "invalid value for key %q", key)
+		}
+		toInsert[key] = value
+		toSet["structured."+key] = value
+	}
+	return m.setAnnotations(entity, &annotatorDoc{
+		Annotations: make(map[string]string),
+		Structured:  toInsert,
+	}, toSet, toUnset)
+}
+
+// setAnnotations inserts the given annotations document for the entity
+// if it has none, or else updates its document with the given $set and
+// $unset operands.
+func (m *Model) setAnnotations(entity GlobalEntity, insert *annotatorDoc, toSet, toUnset bson.M) error {
 	// Set up and call the necessary transactions - if the document does not
 	// already exist, one of the clients will create it and the others will
 	// fail, then all the rest of the clients should succeed on their second
@@ -63,27 +112,195 @@ func (m *Model) SetAnnotations(entity GlobalEntity, annotations map[string]strin
 			if attempt != 0 {
 				return nil, fmt.Errorf("%s no longer exists", entity.Tag())
 			}
-			return insertAnnotationsOps(m.st, entity, toInsert)
+			return insertAnnotationsOps(m.st, entity, insert)
 		}
-		return updateAnnotations(m.st, entity, toUpdate, toRemove), nil
+		return updateAnnotations(m.st, entity, toSet, toUnset), nil
 	}
 	return m.st.db().Run(buildTxn)
 }
 
-// Annotations returns all the annotations corresponding to an entity.
-func (m *Model) Annotations(entity GlobalEntity) (map[string]string, error) {
+// validateAnnotationKey returns an error if the given key cannot be
+// stored as an annotation key.
+func validateAnnotationKey(key string) error {
+	if strings.Contains(key, ".") || strings.HasPrefix(key, "$") {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	return nil
+}
+
+// validateStructuredValue returns an error if the given value cannot
+// be represented in JSON, or has keys that cannot be stored in MongoDB.
+func validateStructuredValue(value interface{}) error {
+	switch value := value.(type) {
+	case nil, string, bool, int, int32, int64, float32, float64:
+		return nil
+	case map[string]interface{}:
+		for key, v := range value {
+			if err := validateAnnotationKey(key); err != nil {
+				return errors.Trace(err)
+			}
+			if err := validateStructuredValue(v); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	case []interface{}:
+		for _, v := range value {
+			if err := validateStructuredValue(v); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	return errors.NotValidf("value of type %T", value)
+}
+
+// normaliseStructuredValue returns the given value read from MongoDB
+// with any embedded documents converted to plain maps.
+func normaliseStructuredValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case bson.M:
+		return normaliseStructuredValue(map[string]interface{}(value))
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			result[key] = normaliseStructuredValue(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = normaliseStructuredValue(v)
+		}
+		return result
+	}
+	return value
+}
+
+// flatAnnotations returns the annotations in the document as strings,
+// with structured values encoded as JSON.
+func (doc *annotatorDoc) flatAnnotations() (map[string]string, error) {
+	result := make(map[string]string, len(doc.Annotations)+len(doc.Structured))
+	for key, value := range doc.Annotations {
+		result[key] = value
+	}
+	for key, value := range doc.Structured {
+		data, err := json.Marshal(normaliseStructuredValue(value))
+		if err != nil {
+			return nil, errors.Annotatef(err, "encoding annotation %q", key)
+		}
+		result[key] = string(data)
+	}
+	return result, nil
+}
+
+func (m *Model) annotatorDoc(entity GlobalEntity) (*annotatorDoc, error) {
 	doc := new(annotatorDoc)
 	annotations, closer := m.st.db().GetCollection(annotationsC)
 	defer closer()
 	err := annotations.FindId(entity.globalKey()).One(doc)
 	if err == mgo.ErrNotFound {
-		// Returning an empty map if there are no annotations.
-		return make(map[string]string), nil
+		return doc, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc, nil
+}
+
+// Annotations returns all the annotations corresponding to an entity.
+// Structured annotations are returned encoded as JSON.
+func (m *Model) Annotations(entity GlobalEntity) (map[string]string, error) {
+	doc, err := m.annotatorDoc(entity)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
+	// Returning an empty map if there are no annotations.
+	return doc.flatAnnotations()
+}
+
+// StructuredAnnotations returns all the annotations corresponding to an
+// entity, with string annotations returned as strings.
+func (m *Model) StructuredAnnotations(entity GlobalEntity) (map[string]interface{}, error) {
+	doc, err := m.annotatorDoc(entity)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return doc.Annotations, nil
+	result := make(map[string]interface{}, len(doc.Annotations)+len(doc.Structured))
+	for key, value := range doc.Annotations {
+		result[key] = value
+	}
+	for key, value := range doc.Structured {
+		result[key] = normaliseStructuredValue(value)
+	}
+	return result, nil
+}
+
+// AnnotationFilter matches entities by one of their annotations.
+type AnnotationFilter struct {
+	// Key is the annotation key. The fields of structured annotations
+	// may be matched with a dotted path, such as "owner.team".
+	Key string
+
+	// Value is the value to match. If it is nil, any entity with the
+	// annotation matches, whatever its value.
+	Value interface{}
+}
+
+// AnnotatedEntities returns the tags of the entities in the model whose
+// annotations match all of the given filters, in tag order. If kind is
+// not empty, only the entities with that kind of tag are returned.
+func (m *Model) AnnotatedEntities(kind string, filters ...AnnotationFilter) ([]names.Tag, error) {
+	var query bson.D
+	if kind != "" {
+		query = append(query, bson.DocElem{
+			"tag", bson.D{{"$regex", "^" + regexp.QuoteMeta(kind) + "-"}},
+		})
+	}
+	var and []bson.D
+	for _, filter := range filters {
+		key := filter.Key
+		if i := strings.Index(key, "."); i >= 0 {
+			key = key[:i]
+		}
+		if err := validateAnnotationKey(key); err != nil || key == "" {
+			return nil, errors.NotValidf("annotation filter key %q", filter.Key)
+		}
+		var match interface{} = filter.Value
+		if filter.Value == nil {
+			match = bson.D{{"$exists", true}}
+		} else if err := validateStructuredValue(filter.Value); err != nil {
+			return nil, errors.Annotatef(err, "annotation filter %q", filter.Key)
+		}
+		or := []bson.D{{{"structured." + filter.Key, match}}}
+		if key == filter.Key {
+			// Only whole values can match string annotations.
+			or = append(or, bson.D{{"annotations." + filter.Key, match}})
+		}
+		and = append(and, bson.D{{"$or", or}})
+	}
+	if len(and) > 0 {
+		query = append(query, bson.DocElem{"$and", and})
+	}
+
+	annotations, closer := m.st.db().GetCollection(annotationsC)
+	defer closer()
+	var docs []annotatorDoc
+	if err := annotations.Find(query).Select(bson.D{{"tag", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]names.Tag, 0, len(docs))
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].String() < tags[j].String()
+	})
+	return tags, nil
 }
 
 // Annotation returns the annotation value corresponding to the given key.
@@ -97,17 +314,15 @@ func (m *Model) Annotation(entity GlobalEntity, key string) (string, error) {
 }
 
 // insertAnnotationsOps returns the operations required to insert annotations in MongoDB.
-func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]string) ([]txn.Op, error) {
+func insertAnnotationsOps(st *State, entity GlobalEntity, doc *annotatorDoc) ([]txn.Op, error) {
 	tag := entity.Tag()
+	doc.GlobalKey = entity.globalKey()
+	doc.Tag = tag.String()
 	ops := []txn.Op{{
 		C:      annotationsC,
 		Id:     st.docID(entity.globalKey()),
 		Assert: txn.DocMissing,
-		Insert: &annotatorDoc{
-			GlobalKey:   entity.globalKey(),
-			Tag:         tag.String(),
-			Annotations: toInsert,
-		},
+		Insert: doc,
 	}}
 
 	switch tag := tag.(type) {
@@ -133,13 +348,22 @@ func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]st
 	}), nil
 }
 
-// updateAnnotations returns the operations required to update or remove annotations in MongoDB.
-func updateAnnotations(mb modelBackend, entity GlobalEntity, toUpdate, toRemove bson.M) []txn.Op {
+// updateAnnotations returns the operations required to update or remove
+// annotations in MongoDB. The keys of toSet and toUnset are the full paths
+// of the fields to update.
+func updateAnnotations(mb modelBackend, entity GlobalEntity, toSet, toUnset bson.M) []txn.Op {
+	var update bson.D
+	if len(toSet) > 0 {
+		update = append(update, bson.DocElem{"$set", toSet})
+	}
+	if len(toUnset) > 0 {
+		update = append(update, bson.DocElem{"$unset", toUnset})
+	}
 	return []txn.Op{{
 		C:      annotationsC,
 		Id:     mb.docID(entity.globalKey()),
 		Assert: txn.DocExists,
-		Update: setUnsetUpdateAnnotations(toUpdate, toRemove),
+		Update: update,
 	}}
 }
 
@@ -152,21 +376,3 @@ func annotationRemoveOp(mb modelBackend, id string) txn.Op {
 		Remove: true,
 	}
 }
-
-// setUnsetUpdateAnnotations returns a bson.D for use
-// in an annotationsC txn.Op's Update field, containing $set and
-// $unset operators if the corresponding operands
-// are non-empty.
-func setUnsetUpdateAnnotations(set, unset bson.M) bson.D {
-	var update bson.D
-	replace := inSubdocReplacer("annotations")
-	if len(set) > 0 {
-		set = bson.M(copyMap(map[string]interface{}(set), replace))
-		update = append(update, bson.DocElem{"$set", set})
-	}
-	if len(unset) > 0 {
-		unset = bson.M(copyMap(map[string]interface{}(unset), replace))
-		update = append(update, bson.DocElem{"$unset", unset})
-	}
-	return update
-}
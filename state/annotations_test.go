@@ -134,6 +134,102 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.Model, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestSetStructuredAnnotations(c *gc.C) {
+	s.assertSetAnnotation(c, "owner", "bob")
+	err := s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{
+		"cost": map[string]interface{}{"centre": "ops", "budget": 100},
+		"tags": []interface{}{"a", "b"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.Model.StructuredAnnotations(s.testEntity)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]interface{}{
+		"owner": "bob",
+		"cost":  map[string]interface{}{"centre": "ops", "budget": 100},
+		"tags":  []interface{}{"a", "b"},
+	})
+
+	annts, err := s.Model.Annotations(s.testEntity)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annts, jc.DeepEquals, map[string]string{
+		"owner": "bob",
+		"cost":  `{"budget":100,"centre":"ops"}`,
+		"tags":  `["a","b"]`,
+	})
+}
+
+func (s *AnnotationsSuite) TestSetStructuredAnnotationsReplacesString(c *gc.C) {
+	s.assertSetAnnotation(c, "owner", "bob")
+	err := s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{
+		"owner": map[string]interface{}{"name": "bob"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	annts, err := s.Model.Annotations(s.testEntity)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annts, jc.DeepEquals, map[string]string{"owner": `{"name":"bob"}`})
+
+	s.assertSetAnnotation(c, "owner", "alice")
+	assertAnnotation(c, s.Model, s.testEntity, "owner", "alice")
+
+	err = s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{"owner": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	annts, err = s.Model.Annotations(s.testEntity)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annts, gc.HasLen, 0)
+}
+
+func (s *AnnotationsSuite) TestSetStructuredAnnotationsInvalid(c *gc.C) {
+	err := s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{
+		"cost": map[string]interface{}{"$centre": "ops"},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*invalid value for key "cost": invalid key "\$centre"`)
+
+	err = s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{
+		"cost": struct{}{},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*invalid value for key "cost": value of type struct {} not valid`)
+}
+
+func (s *AnnotationsSuite) TestAnnotatedEntities(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetStructuredAnnotations(s.testEntity, map[string]interface{}{
+		"cost": map[string]interface{}{"centre": "ops"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(s.testEntity, map[string]string{"owner": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetStructuredAnnotations(other, map[string]interface{}{
+		"cost": map[string]interface{}{"centre": "dev"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(s.Model, map[string]string{"owner": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	check := func(kind string, filters []state.AnnotationFilter, expect ...names.Tag) {
+		tags, err := s.Model.AnnotatedEntities(kind, filters...)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(tags, jc.DeepEquals, expect)
+	}
+	check("", []state.AnnotationFilter{{Key: "owner", Value: "bob"}},
+		s.testEntity.Tag(), s.Model.ModelTag())
+	check("machine", []state.AnnotationFilter{{Key: "owner", Value: "bob"}},
+		s.testEntity.Tag())
+	check("machine", []state.AnnotationFilter{{Key: "cost"}},
+		s.testEntity.Tag(), other.Tag())
+	check("", []state.AnnotationFilter{{Key: "cost.centre", Value: "dev"}},
+		other.Tag())
+	check("", []state.AnnotationFilter{
+		{Key: "cost.centre", Value: "ops"},
+		{Key: "owner", Value: "bob"},
+	}, s.testEntity.Tag())
+	check("", []state.AnnotationFilter{{Key: "owner", Value: "alice"}})
+
+	_, err = s.Model.AnnotatedEntities("", state.AnnotationFilter{Key: ".centre"})
+	c.Assert(err, gc.ErrorMatches, `annotation filter key ".centre" not valid`)
+}
+
 type AnnotationsEnvSuite struct {
 	ConnSuite
 }
@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/apitoken"
+)
+
+// APIToken describes a revocable token with which a local user may
+// log in to the controller in place of their password. A token may be
+// limited to a single model, and to a set of capabilities.
+type APIToken struct {
+	// ID uniquely identifies the token in the controller.
+	ID string
+
+	// User is the user on whose behalf the token logs in.
+	User names.UserTag
+
+	// ModelUUID holds the UUID of the only model the token may log
+	// in to. If it is empty, the token may log in to any model the
+	// user has access to, or to the controller.
+	ModelUUID string
+
+	// Capabilities holds the capabilities granted to the token,
+	// as defined in the core/apitoken package.
+	Capabilities []string
+
+	// Created is the time at which the token was created.
+	Created time.Time
+
+	// Expires is the time at which the token expires.
+	Expires time.Time
+}
+
+// AddAPITokenArgs holds the arguments for adding an API token.
+type AddAPITokenArgs struct {
+	// User is the local user on whose behalf the token logs in.
+	User names.UserTag
+
+	// ModelUUID, if set, limits the token to the model with that UUID.
+	ModelUUID string
+
+	// Capabilities holds the capabilities granted to the token.
+	Capabilities []string
+
+	// Lifetime is how long the token remains valid.
+	Lifetime time.Duration
+}
+
+// apiTokenDoc is the mongo document representation of an API token.
+// Only a hash of the token's secret is stored.
+type apiTokenDoc struct {
+	DocID        string    `bson:"_id"`
+	User         string    `bson:"user"`
+	ModelUUID    string    `bson:"model-uuid,omitempty"`
+	Capabilities []string  `bson:"capabilities"`
+	SecretHash   string    `bson:"secret-hash"`
+	Created      time.Time `bson:"created"`
+	Expires      time.Time `bson:"expires"`
+}
+
+func (doc *apiTokenDoc) token() APIToken {
+	return APIToken{
+		ID:           doc.DocID,
+		User:         names.NewUserTag(doc.User),
+		ModelUUID:    doc.ModelUUID,
+		Capabilities: doc.Capabilities,
+		Created:      doc.Created.UTC(),
+		Expires:      doc.Expires.UTC(),
+	}
+}
+
+// AddAPIToken adds a new API token, returning it along with its
+// secret. The secret is not recorded, and cannot be retrieved later.
+func (st *State) AddAPIToken(args AddAPITokenArgs) (APIToken, string, error) {
+	if !args.User.IsLocal() {
+		return APIToken{}, "", errors.NotValidf("API token for external user %q", args.User.Id())
+	}
+	if err := apitoken.ValidateCapabilities(args.Capabilities); err != nil {
+		return APIToken{}, "", errors.Trace(err)
+	}
+	if args.Lifetime <= 0 {
+		return APIToken{}, "", errors.NotValidf("API token lifetime %v", args.Lifetime)
+	}
+	idBytes, err := utils.RandomBytes(8)
+	if err != nil {
+		return APIToken{}, "", errors.Trace(err)
+	}
+	secret, err := utils.RandomPassword()
+	if err != nil {
+		return APIToken{}, "", errors.Trace(err)
+	}
+	now := st.nowToTheSecond()
+	doc := apiTokenDoc{
+		DocID:        fmt.Sprintf("%x", idBytes),
+		User:         args.User.Id(),
+		ModelUUID:    args.ModelUUID,
+		Capabilities: args.Capabilities,
+		SecretHash:   utils.AgentPasswordHash(secret),
+		Created:      now,
+		Expires:      now.Add(args.Lifetime),
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     strings.ToLower(args.User.Name()),
+		Assert: bson.D{{"deleted", bson.D{{"$ne", true}}}},
+	}}
+	if args.ModelUUID != "" {
+		ops = append(ops, txn.Op{
+			C:      modelsC,
+			Id:     args.ModelUUID,
+			Assert: isAliveDoc,
+		})
+	}
+	ops = append(ops, txn.Op{
+		C:      apiTokensC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	})
+	err = st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		if _, err := st.User(args.User); err != nil {
+			return APIToken{}, "", errors.Trace(err)
+		}
+		if args.ModelUUID != "" {
+			return APIToken{}, "", errors.NotFoundf("model %q", args.ModelUUID)
+		}
+		return APIToken{}, "", errors.Errorf("API token ID collision")
+	} else if err != nil {
+		return APIToken{}, "", errors.Annotatef(err, "cannot add API token for %q", args.User.Id())
+	}
+	return doc.token(), secret, nil
+}
+
+// APIToken returns the API token with the given ID.
+func (st *State) APIToken(id string) (APIToken, error) {
+	doc, err := st.apiToken(id)
+	if err != nil {
+		return APIToken{}, errors.Trace(err)
+	}
+	return doc.token(), nil
+}
+
+// APITokens returns the API tokens of the given user, or of all users
+// if the tag is nil, sorted by ID.
+func (st *State) APITokens(user *names.UserTag) ([]APIToken, error) {
+	coll, closer := st.db().GetCollection(apiTokensC)
+	defer closer()
+
+	var query bson.D
+	if user != nil {
+		query = bson.D{{"user", user.Id()}}
+	}
+	var docs []apiTokenDoc
+	if err := coll.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read API tokens")
+	}
+	tokens := make([]APIToken, len(docs))
+	for i, doc := range docs {
+		tokens[i] = doc.token()
+	}
+	return tokens, nil
+}
+
+// RemoveAPIToken removes the API token with the given ID, so that it
+// may no longer be used to log in.
+func (st *State) RemoveAPIToken(id string) error {
+	ops := []txn.Op{{
+		C:      apiTokensC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("API token %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove API token %q", id)
+	}
+	return nil
+}
+
+// AuthenticateAPIToken returns the API token with the given ID if the
+// secret matches and the token has not expired. Otherwise it returns
+// an error satisfying errors.IsUnauthorized.
+func (st *State) AuthenticateAPIToken(id, secret string) (APIToken, error) {
+	doc, err := st.apiToken(id)
+	if errors.IsNotFound(err) {
+		return APIToken{}, errors.Unauthorizedf("invalid API token")
+	} else if err != nil {
+		return APIToken{}, errors.Trace(err)
+	}
+	hash := utils.AgentPasswordHash(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(doc.SecretHash)) != 1 {
+		return APIToken{}, errors.Unauthorizedf("invalid API token")
+	}
+	if !st.clock().Now().Before(doc.Expires) {
+		return APIToken{}, errors.Unauthorizedf("API token %q expired", id)
+	}
+	return doc.token(), nil
+}
+
+func (st *State) apiToken(id string) (*apiTokenDoc, error) {
+	coll, closer := st.db().GetCollection(apiTokensC)
+	defer closer()
+
+	var doc apiTokenDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("API token %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
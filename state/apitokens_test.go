@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type APITokensSuite struct {
+	ConnSuite
+	user names.UserTag
+}
+
+var _ = gc.Suite(&APITokensSuite{})
+
+func (s *APITokensSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.user = s.Factory.MakeUser(c, &factory.UserParams{Name: "ci"}).UserTag()
+}
+
+func (s *APITokensSuite) addToken(c *gc.C, args state.AddAPITokenArgs) (state.APIToken, string) {
+	token, secret, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return token, secret
+}
+
+func (s *APITokensSuite) TestAddAPIToken(c *gc.C) {
+	token, secret := s.addToken(c, state.AddAPITokenArgs{
+		User:         s.user,
+		ModelUUID:    s.State.ModelUUID(),
+		Capabilities: []string{"read", "run-actions"},
+		Lifetime:     time.Hour,
+	})
+	c.Assert(secret, gc.Not(gc.Equals), "")
+	c.Assert(token.ID, gc.HasLen, 16)
+	now := s.Clock.Now().Round(time.Second).UTC()
+	c.Assert(token, jc.DeepEquals, state.APIToken{
+		ID:           token.ID,
+		User:         s.user,
+		ModelUUID:    s.State.ModelUUID(),
+		Capabilities: []string{"read", "run-actions"},
+		Created:      now,
+		Expires:      now.Add(time.Hour),
+	})
+
+	stored, err := s.State.APIToken(token.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, token)
+}
+
+func (s *APITokensSuite) TestAddAPITokenInvalid(c *gc.C) {
+	_, _, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:         s.user,
+		Capabilities: []string{"everything"},
+		Lifetime:     time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `capability "everything" .* not valid`)
+
+	_, _, err = s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:         s.user,
+		Capabilities: []string{"read"},
+	})
+	c.Assert(err, gc.ErrorMatches, "API token lifetime 0s not valid")
+
+	_, _, err = s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:         names.NewUserTag("bob@external"),
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `API token for external user "bob@external" not valid`)
+}
+
+func (s *APITokensSuite) TestAddAPITokenUnknownUser(c *gc.C) {
+	_, _, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:         names.NewUserTag("nobody"),
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APITokensSuite) TestAddAPITokenUnknownModel(c *gc.C) {
+	_, _, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:         s.user,
+		ModelUUID:    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `model "deadbeef-0bad-400d-8000-4b1d0d06f00d" not found`)
+}
+
+func (s *APITokensSuite) TestAPITokens(c *gc.C) {
+	other := s.Factory.MakeUser(c, &factory.UserParams{Name: "other"}).UserTag()
+	mine, _ := s.addToken(c, state.AddAPITokenArgs{
+		User:         s.user,
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+	theirs, _ := s.addToken(c, state.AddAPITokenArgs{
+		User:         other,
+		Capabilities: []string{"write"},
+		Lifetime:     time.Hour,
+	})
+
+	tokens, err := s.State.APITokens(&s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, jc.DeepEquals, []state.APIToken{mine})
+
+	tokens, err = s.State.APITokens(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 2)
+	ids := []string{tokens[0].ID, tokens[1].ID}
+	c.Assert(ids, jc.SameContents, []string{mine.ID, theirs.ID})
+}
+
+func (s *APITokensSuite) TestAuthenticateAPIToken(c *gc.C) {
+	token, secret := s.addToken(c, state.AddAPITokenArgs{
+		User:         s.user,
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+
+	authenticated, err := s.State.AuthenticateAPIToken(token.ID, secret)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(authenticated, jc.DeepEquals, token)
+
+	_, err = s.State.AuthenticateAPIToken(token.ID, "wrong")
+	c.Assert(err, gc.ErrorMatches, "invalid API token")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	_, err = s.State.AuthenticateAPIToken("0123456789abcdef", secret)
+	c.Assert(err, gc.ErrorMatches, "invalid API token")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	s.Clock.Advance(2 * time.Hour)
+	_, err = s.State.AuthenticateAPIToken(token.ID, secret)
+	c.Assert(err, gc.ErrorMatches, `API token ".*" expired`)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *APITokensSuite) TestRemoveAPIToken(c *gc.C) {
+	token, secret := s.addToken(c, state.AddAPITokenArgs{
+		User:         s.user,
+		Capabilities: []string{"read"},
+		Lifetime:     time.Hour,
+	})
+	err := s.State.RemoveAPIToken(token.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.APIToken(token.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.AuthenticateAPIToken(token.ID, secret)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	err = s.State.RemoveAPIToken(token.ID)
+	c.Assert(err, gc.ErrorMatches, `API token ".*" not found`)
+}
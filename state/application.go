@@ -50,6 +50,18 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// HookRetry holds the application's overrides of the model's
+	// hook retry behaviour, if any.
+	HookRetry *hookRetryPolicyDoc `bson:"hook-retry,omitempty"`
+
+	// CharmPinned records that the application's charm was set to a
+	// specific revision, and should not be offered upgrades.
+	CharmPinned bool `bson:"charm-pinned,omitempty"`
+
+	// ProxyOverrides holds the application's overrides of the model's
+	// proxy settings, as seen by its units' hooks, if any.
+	ProxyOverrides *proxyOverridesDoc `bson:"proxy-overrides,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -324,6 +336,12 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	disownOps, err := a.disownSharedStorageOps()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, disownOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -347,7 +365,37 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeLeadershipSettingsOp(name),
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
+		removeCharmUpgradeOp(a.st, name),
 	)
+	historyOps, err := removeAllSettingsHistoryOps(a.st.db(), globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
+	return ops, nil
+}
+
+// disownSharedStorageOps returns txn.Ops to disown the application's
+// shared storage instances, so that they are left in the model as
+// detached storage when the application is removed.
+func (a *Application) disownSharedStorageOps() ([]txn.Op, error) {
+	storageInstances, closer := a.st.db().GetCollection(storageInstancesC)
+	defer closer()
+
+	owner := a.ApplicationTag().String()
+	var docs []storageInstanceDoc
+	if err := storageInstances.Find(bson.D{{"owner", owner}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "getting shared storage")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      storageInstancesC,
+			Id:     doc.Id,
+			Assert: bson.D{{"owner", owner}},
+			Update: bson.D{{"$unset", bson.D{{"owner", nil}}}},
+		}
+	}
 	return ops, nil
 }
 
@@ -421,6 +469,13 @@ func (a *Application) Channel() csparams.Channel {
 	return csparams.Channel(a.doc.Channel)
 }
 
+// CharmPinned returns whether the application's charm was set to a
+// specific revision. The charm revision updater does not look for
+// upgrades to pinned charms.
+func (a *Application) CharmPinned() bool {
+	return a.doc.CharmPinned
+}
+
 // Endpoints returns the application's currently available relation endpoints.
 func (a *Application) Endpoints() (eps []Endpoint, err error) {
 	ch, _, err := a.Charm()
@@ -921,6 +976,10 @@ type SetCharmConfig struct {
 	// ForceUnits forces the upgrade on units in an error state.
 	ForceUnits bool
 
+	// Pinned records that the charm was chosen at a specific
+	// revision, so that the application is not offered upgrades.
+	Pinned bool
+
 	// ForceSeries forces the use of the charm even if it is not one of
 	// the charm's supported series.
 	ForceSeries bool
@@ -1059,6 +1118,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
 		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$set", bson.D{{"charm-pinned", cfg.Pinned}}}},
+		})
 
 		return ops, nil
 	}
@@ -1068,6 +1132,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
+	a.doc.CharmPinned = cfg.Pinned
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
 	return nil
 }
@@ -1192,12 +1257,25 @@ func (a *Application) Refresh() error {
 
 // newUnitName returns the next unit name.
 func (a *Application) newUnitName() (string, error) {
-	unitSeq, err := sequence(a.st, a.Tag().String())
+	names, err := a.newUnitNames(1)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	name := a.doc.Name + "/" + strconv.Itoa(unitSeq)
-	return name, nil
+	return names[0], nil
+}
+
+// newUnitNames reserves the next n unit names with a single update
+// of the application's unit sequence.
+func (a *Application) newUnitNames(n int) ([]string, error) {
+	first, err := sequenceN(a.st, a.Tag().String(), n)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = a.doc.Name + "/" + strconv.Itoa(first+i)
+	}
+	return names, nil
 }
 
 // addUnitOps returns a unique name for a new unit, and a list of txn operations
@@ -1240,48 +1318,61 @@ func (a *Application) addUnitOps(
 	}
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
-	ops = append(ops, a.incUnitCountOp(asserts))
+	ops = append(ops, a.incUnitCountOp(asserts, 1))
 	return names, ops, err
 }
 
+// applicationAddUnitOpsArgs holds the arguments to addUnitOpsWithCons.
+// The unitName, charm and model fields are optional; callers creating
+// many units at once set them to avoid a database round trip per unit,
+// and to reuse the same unit names when a transaction is retried.
+//
+// If sharedStorage is non-nil, it identifies the application's shared
+// storage instances, which are being created in the same transaction
+// as the unit; otherwise the application's existing shared storage is
+// attached to the unit.
 type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
 	attachStorage []names.StorageTag
+	sharedStorage []names.StorageTag
+	unitName      string
+	charm         *Charm
+	model         *IAASModel
 }
 
-// addApplicationUnitOps is just like addUnitOps but explicitly takes a
-// constraints value (this is used at application creation time).
-func (a *Application) addApplicationUnitOps(args applicationAddUnitOpsArgs) (string, []txn.Op, error) {
-	names, ops, err := a.addUnitOpsWithCons(args)
-	if err == nil {
-		ops = append(ops, a.incUnitCountOp(nil))
-	}
-	return names, ops, err
-}
-
-// addUnitOpsWithCons is a helper method for returning addUnitOps.
+// addUnitOpsWithCons is a helper method for returning addUnitOps. It does
+// not include the operation to increment the application's unit count.
 func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string, []txn.Op, error) {
 	if a.doc.Subordinate && args.principalName == "" {
 		return "", nil, errors.New("application is a subordinate")
 	} else if !a.doc.Subordinate && args.principalName != "" {
 		return "", nil, errors.New("application is not a subordinate")
 	}
-	name, err := a.newUnitName()
-	if err != nil {
-		return "", nil, err
+	name := args.unitName
+	if name == "" {
+		var err error
+		if name, err = a.newUnitName(); err != nil {
+			return "", nil, err
+		}
 	}
 	unitTag := names.NewUnitTag(name)
 
-	charm, _, err := a.Charm()
-	if err != nil {
-		return "", nil, err
+	charm := args.charm
+	if charm == nil {
+		var err error
+		if charm, _, err = a.Charm(); err != nil {
+			return "", nil, err
+		}
 	}
 
-	im, err := a.st.IAASModel()
-	if err != nil {
-		return "", nil, errors.Trace(err)
+	im := args.model
+	if im == nil {
+		var err error
+		if im, err = a.st.IAASModel(); err != nil {
+			return "", nil, errors.Trace(err)
+		}
 	}
 
 	// Reduce the count of new storage created for each existing storage
@@ -1354,6 +1445,14 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		numStorageAttachments++
 		storageTags[si.StorageName()] = append(storageTags[si.StorageName()], storageTag)
 	}
+	sharedStorageOps, numSharedStorage, err := a.attachSharedStorageOps(
+		im, unitTag, charm, args.sharedStorage, machineAssignable,
+	)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	storageOps = append(storageOps, sharedStorageOps...)
+	numStorageAttachments += numSharedStorage
 	for name, tags := range storageTags {
 		count := len(tags)
 		charmStorage := charm.Meta().Storage[name]
@@ -1469,12 +1568,13 @@ func decApplicationOffersRefOp(mb modelBackend, appName string) (txn.Op, error)
 	return decRefOp, nil
 }
 
-// incUnitCountOp returns the operation to increment the application's unit count.
-func (a *Application) incUnitCountOp(asserts bson.D) txn.Op {
+// incUnitCountOp returns the operation to increment the application's unit
+// count by n.
+func (a *Application) incUnitCountOp(asserts bson.D, n int) txn.Op {
 	op := txn.Op{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
-		Update: bson.D{{"$inc", bson.D{{"unitcount", 1}}}},
+		Update: bson.D{{"$inc", bson.D{{"unitcount", n}}}},
 	}
 	if len(asserts) > 0 {
 		op.Assert = asserts
@@ -1482,6 +1582,50 @@ func (a *Application) incUnitCountOp(asserts bson.D) txn.Op {
 	return op
 }
 
+// attachSharedStorageOps returns txn.Ops to attach the application's
+// shared storage instances to a new unit, and the number of storage
+// attachments that the ops create. If newSharedStorage is non-nil, it
+// identifies shared storage instances that are being created in the
+// same transaction, whose attachment counts must be incremented
+// without asserting on them.
+func (a *Application) attachSharedStorageOps(
+	im *IAASModel,
+	unitTag names.UnitTag,
+	ch *Charm,
+	newSharedStorage []names.StorageTag,
+	machineAssignable machineAssignable,
+) ([]txn.Op, int, error) {
+	if newSharedStorage != nil {
+		ops := make([]txn.Op, 0, len(newSharedStorage)*2)
+		for _, storageTag := range newSharedStorage {
+			ops = append(ops, createStorageAttachmentOp(storageTag, unitTag), txn.Op{
+				C:      storageInstancesC,
+				Id:     storageTag.Id(),
+				Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+			})
+		}
+		return ops, len(newSharedStorage), nil
+	}
+	sharedStorage, err := im.storageInstances(bson.D{{"owner", a.ApplicationTag().String()}})
+	if err != nil {
+		return nil, -1, errors.Annotate(err, "getting shared storage")
+	}
+	var ops []txn.Op
+	var n int
+	for _, si := range sharedStorage {
+		if si.Life() != Alive {
+			continue
+		}
+		siOps, err := im.attachStorageOps(si, unitTag, a.doc.Series, ch, machineAssignable)
+		if err != nil {
+			return nil, -1, errors.Annotatef(err, "attaching %s", names.ReadableString(si.StorageTag()))
+		}
+		ops = append(ops, siOps...)
+		n++
+	}
+	return ops, n, nil
+}
+
 // AddUnitParams contains parameters for the Application.AddUnit method.
 type AddUnitParams struct {
 	// AttachStorage identifies storage instances to attach to the unit.
@@ -1509,6 +1653,102 @@ func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	return a.st.Unit(name)
 }
 
+// maxUnitsPerTxn is the maximum number of units that AddUnits creates
+// in a single transaction, which keeps the transaction document well
+// within mongo's size limit.
+const maxUnitsPerTxn = 50
+
+// AddUnits adds n new principal units to the application. The unit
+// names are reserved up front, and the units are created in as few
+// transactions as possible. If a transaction fails, the units created
+// by earlier transactions remain, and are returned with the error.
+func (a *Application) AddUnits(n int, args AddUnitParams) (units []*Unit, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add %d units to application %q", n, a)
+	if n < 1 {
+		return nil, errors.NotValidf("adding %d units", n)
+	}
+	if len(args.AttachStorage) > 0 && n != 1 {
+		return nil, errors.NotValidf("attaching storage to %d units", n)
+	}
+	if a.doc.Subordinate {
+		return nil, errors.New("application is a subordinate")
+	}
+	scons, err := a.Constraints()
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("application %q", a.Name())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := a.st.resolveConstraints(scons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageCons, err := a.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	im, err := a.st.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames, err := a.newUnitNames(n)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for len(unitNames) > 0 {
+		batch := unitNames
+		if len(batch) > maxUnitsPerTxn {
+			batch = batch[:maxUnitsPerTxn]
+		}
+		unitNames = unitNames[len(batch):]
+
+		// The unit names in the batch are reused if the transaction
+		// is retried; they were reserved above, so no other unit can
+		// have taken them in the meantime.
+		buildTxn := func(attempt int) ([]txn.Op, error) {
+			if attempt > 0 {
+				if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+					return nil, errors.Trace(err)
+				} else if !alive {
+					return nil, errors.New("application is not alive")
+				}
+			}
+			var ops []txn.Op
+			for _, name := range batch {
+				_, unitOps, err := a.addUnitOpsWithCons(applicationAddUnitOpsArgs{
+					cons:          cons,
+					storageCons:   storageCons,
+					attachStorage: args.AttachStorage,
+					unitName:      name,
+					charm:         ch,
+					model:         im,
+				})
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, unitOps...)
+			}
+			return append(ops, a.incUnitCountOp(isAliveDoc, len(batch))), nil
+		}
+		if err := a.st.db().Run(buildTxn); err != nil {
+			return units, errors.Trace(err)
+		}
+		for _, name := range batch {
+			unit, err := a.st.Unit(name)
+			if err != nil {
+				return units, errors.Trace(err)
+			}
+			units = append(units, unit)
+		}
+	}
+	return units, nil
+}
+
 // removeUnitOps returns the operations necessary to remove the supplied unit,
 // assuming the supplied asserts apply to the unit document.
 func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
@@ -1550,6 +1790,8 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeAgentTokenOp(a.st, u.Tag()),
+		removeRebootRequestOp(a.st, u.doc.Name),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
@@ -1665,6 +1907,14 @@ func (a *Application) ConfigSettings() (charm.Settings, error) {
 // UpdateConfigSettings changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
+	return a.UpdateConfigSettingsBy("", changes)
+}
+
+// UpdateConfigSettingsBy changes a application's charm config settings on
+// behalf of the named user, recording the change in the application's
+// config history. Values set to nil will be deleted; unknown and invalid
+// values will return an error.
+func (a *Application) UpdateConfigSettingsBy(author string, changes charm.Settings) error {
 	charm, _, err := a.Charm()
 	if err != nil {
 		return err
@@ -1677,19 +1927,37 @@ func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
 	// about every use case. This needs to be resolved some time; but at
 	// least the settings docs are keyed by charm url as well as application
 	// name, so the actual impact of a race is non-threatening.
-	node, err := readSettings(a.st.db(), settingsC, a.settingsKey())
-	if err != nil {
-		return err
-	}
-	for name, value := range changes {
-		if value == nil {
-			node.Delete(name)
-		} else {
-			node.Set(name, value)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		node, err := readSettings(a.st.db(), settingsC, a.settingsKey())
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range changes {
+			if value == nil {
+				node.Delete(name)
+			} else {
+				node.Set(name, value)
+			}
+		}
+		_, ops, err := node.versionedUpdateOps(a.globalKey(), author, a.st.clock().Now())
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot write settings")
 	}
-	_, err = node.Write()
-	return err
+	return nil
+}
+
+// ConfigHistory returns the recorded changes to the application's charm
+// config settings, oldest first. Only the most recent changes are kept.
+func (a *Application) ConfigHistory() ([]SettingsChange, error) {
+	return readSettingsHistory(a.st.db(), a.globalKey())
 }
 
 // LeaderSettings returns a application's leader settings. If nothing has been set
@@ -87,6 +87,28 @@ func (s *ApplicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsTrue)
 }
 
+func (s *ApplicationSuite) TestSetCharmPinned(c *gc.C) {
+	c.Assert(s.mysql.CharmPinned(), jc.IsFalse)
+
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:  sch,
+		Pinned: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CharmPinned(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CharmPinned(), jc.IsTrue)
+
+	// Setting the same charm again without pinning unpins it.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CharmPinned(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestSetCharmCharmSettings(c *gc.C) {
 	newCh := s.AddConfigCharm(c, "mysql", stringConfig, 2)
 	err := s.mysql.SetCharm(state.SetCharmConfig{
@@ -1837,6 +1859,53 @@ func (s *ApplicationSuite) TestAddUnitWhenNotAlive(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": application "mysql" not found`)
 }
 
+func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.mysql.AddUnits(3, state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 3)
+	for i, unit := range units {
+		c.Check(unit.Name(), gc.Equals, fmt.Sprintf("mysql/%d", i+1))
+		c.Check(unit.IsPrincipal(), jc.IsTrue)
+		assertLife(c, unit, state.Alive)
+	}
+	all, err := s.mysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 4)
+
+	// Unit names are not reused.
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Name(), gc.Equals, "mysql/4")
+}
+
+func (s *ApplicationSuite) TestAddUnitsInvalid(c *gc.C) {
+	_, err := s.mysql.AddUnits(0, state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add 0 units to application "mysql": adding 0 units not valid`)
+
+	subCharm := s.AddTestingCharm(c, "logging")
+	logging := s.AddTestingApplication(c, "logging", subCharm)
+	_, err = logging.AddUnits(2, state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add 2 units to application "logging": application is a subordinate`)
+}
+
+func (s *ApplicationSuite) TestAddUnitsWhenNotAlive(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.mysql.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.mysql.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.mysql.AddUnits(2, state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add 2 units to application "mysql": application is not alive`)
+	all, err := s.mysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
 func (s *ApplicationSuite) TestReadUnit(c *gc.C) {
 	_, err := s.mysql.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// bulkEntityIds groups the doc ids of the given machines, units and
+// applications by collection. Tags of other kinds are ignored.
+func (st *State) bulkEntityIds(tags []names.Tag) map[string][]string {
+	ids := make(map[string][]string)
+	for _, tag := range tags {
+		var coll string
+		switch tag.(type) {
+		case names.MachineTag:
+			coll = machinesC
+		case names.UnitTag:
+			coll = unitsC
+		case names.ApplicationTag:
+			coll = applicationsC
+		default:
+			continue
+		}
+		ids[coll] = append(ids[coll], st.docID(tag.Id()))
+	}
+	return ids
+}
+
+// entityTag returns the tag of the entity with the given local id
+// in the given collection.
+func entityTag(coll, id string) names.Tag {
+	switch coll {
+	case machinesC:
+		return names.NewMachineTag(id)
+	case unitsC:
+		return names.NewUnitTag(id)
+	default:
+		return names.NewApplicationTag(id)
+	}
+}
+
+// EntitiesLife returns the life of each of the given machines, units
+// and applications, reading each collection at most once. Entities
+// that do not exist, and tags of other kinds, are omitted from the
+// result; callers wanting an error for those should look them up
+// individually.
+func (st *State) EntitiesLife(tags []names.Tag) (map[names.Tag]Life, error) {
+	result := make(map[names.Tag]Life)
+	for collName, ids := range st.bulkEntityIds(tags) {
+		coll, closer := st.db().GetCollection(collName)
+		iter := coll.Find(bson.D{{"_id", bson.D{{"$in", ids}}}}).Select(lifeFields).Iter()
+		var doc lifeDoc
+		for iter.Next(&doc) {
+			result[entityTag(collName, st.localID(doc.Id))] = doc.Life
+		}
+		err := iter.Close()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read life from %s", collName)
+		}
+	}
+	return result, nil
+}
+
+// FindEntities returns the given machines and units, reading each
+// collection at most once. Entities that do not exist, and tags of
+// other kinds, are omitted from the result; callers wanting an error
+// for those should use FindEntity.
+func (st *State) FindEntities(tags []names.Tag) (map[names.Tag]Entity, error) {
+	result := make(map[names.Tag]Entity)
+	ids := st.bulkEntityIds(tags)
+	if machineIds := ids[machinesC]; len(machineIds) > 0 {
+		machines, closer := st.db().GetCollection(machinesC)
+		defer closer()
+		var docs []machineDoc
+		err := machines.Find(bson.D{{"_id", bson.D{{"$in", machineIds}}}}).All(&docs)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get machines")
+		}
+		for i := range docs {
+			machine := newMachine(st, &docs[i])
+			result[machine.Tag()] = machine
+		}
+	}
+	if unitIds := ids[unitsC]; len(unitIds) > 0 {
+		units, closer := st.db().GetCollection(unitsC)
+		defer closer()
+		var docs []unitDoc
+		err := units.Find(bson.D{{"_id", bson.D{{"$in", unitIds}}}}).All(&docs)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get units")
+		}
+		for i := range docs {
+			unit := newUnit(st, &docs[i])
+			result[unit.Tag()] = unit
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type BulkEntitiesSuite struct {
+	ConnSuite
+	machine     *state.Machine
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&BulkEntitiesSuite{})
+
+func (s *BulkEntitiesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.application = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BulkEntitiesSuite) TestEntitiesLife(c *gc.C) {
+	lives, err := s.State.EntitiesLife([]names.Tag{
+		s.machine.Tag(),
+		s.application.Tag(),
+		s.unit.Tag(),
+		names.NewMachineTag("42"),
+		names.NewUnitTag("wordpress/42"),
+		names.NewUserTag("bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lives, jc.DeepEquals, map[names.Tag]state.Life{
+		s.machine.Tag():     state.Dying,
+		s.application.Tag(): state.Alive,
+		s.unit.Tag():        state.Dead,
+	})
+}
+
+func (s *BulkEntitiesSuite) TestEntitiesLifeNone(c *gc.C) {
+	lives, err := s.State.EntitiesLife(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lives, gc.HasLen, 0)
+}
+
+func (s *BulkEntitiesSuite) TestFindEntities(c *gc.C) {
+	entities, err := s.State.FindEntities([]names.Tag{
+		s.machine.Tag(),
+		s.application.Tag(),
+		s.unit.Tag(),
+		names.NewMachineTag("42"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, gc.HasLen, 2)
+
+	machine, ok := entities[s.machine.Tag()].(*state.Machine)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(machine.Id(), gc.Equals, s.machine.Id())
+	c.Assert(machine.Life(), gc.Equals, state.Dying)
+
+	unit, ok := entities[s.unit.Tag()].(*state.Unit)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(unit.Name(), gc.Equals, s.unit.Name())
+	c.Assert(unit.Life(), gc.Equals, state.Dead)
+}
@@ -16,7 +16,9 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+	goyaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +83,11 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the charm's lxd-profile.yaml, if it has one.
+	// It is kept as YAML because LXD config keys contain dots, which
+	// mongo does not allow in field names.
+	LXDProfile string `bson:"lxd-profile,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -90,6 +97,7 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+	LXDProfile  *lxdprofile.Profile
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -99,6 +107,10 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		return nil, errors.New("*charm.URL was nil")
 	}
 
+	lxdProfile, err := lxdProfileText(info.LXDProfile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	doc := charmDoc{
 		DocID:        info.ID.String(),
 		URL:          info.ID,
@@ -108,6 +120,7 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Actions:      info.Charm.Actions(),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+		LXDProfile:   lxdProfile,
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -205,6 +218,10 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	}
 	op.Assert = append(lifeAssert, assert...)
 
+	lxdProfile, err := lxdProfileText(info.LXDProfile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	data := bson.D{
 		{"meta", info.Charm.Meta()},
 		{"config", safeConfig(info.Charm)},
@@ -214,6 +231,7 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
 		{"placeholder", false},
+		{"lxd-profile", lxdProfile},
 	}
 	if err := checkCharmDataIsStorable(data); err != nil {
 		return nil, errors.Trace(err)
@@ -454,6 +472,31 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile shipped with the charm, or nil if
+// it has none.
+func (c *Charm) LXDProfile() (*lxdprofile.Profile, error) {
+	if c.doc.LXDProfile == "" {
+		return nil, nil
+	}
+	profile, err := lxdprofile.Parse([]byte(c.doc.LXDProfile))
+	if err != nil {
+		return nil, errors.Annotatef(err, "charm %q", c.doc.URL)
+	}
+	return profile, nil
+}
+
+// lxdProfileText returns the profile as it is stored in a charm document.
+func lxdProfileText(profile *lxdprofile.Profile) (string, error) {
+	if profile == nil {
+		return "", nil
+	}
+	data, err := goyaml.Marshal(profile)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot marshal lxd profile")
+	}
+	return string(data), nil
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+// CharmProfiles returns the names of the charm LXD profiles that have
+// been applied to the machine's instance.
+func (m *Machine) CharmProfiles() []string {
+	return m.doc.CharmProfiles
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles that have
+// been applied to the machine's instance, replacing any recorded before.
+func (m *Machine) SetCharmProfiles(names []string) error {
+	if len(names) == 0 {
+		names = nil
+	} else {
+		names = append([]string(nil), names...)
+		sort.Strings(names)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, ErrDead
+		}
+		if sameStrings(m.doc.CharmProfiles, names) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$unset", bson.D{{"charm-profiles", nil}}}}
+		if len(names) > 0 {
+			update = bson.D{{"$set", bson.D{{"charm-profiles", names}}}}
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set charm profiles of machine %s", m)
+	}
+	m.doc.CharmProfiles = names
+	return nil
+}
+
+// CharmLXDProfiles returns the LXD profiles, keyed by profile name, that
+// the charms of the applications with units on the machine ship. When an
+// application's charm is upgraded, the profile of the new charm is
+// returned in place of the old one.
+func (m *Machine) CharmLXDProfiles() (map[string]*lxdprofile.Profile, error) {
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	profiles := make(map[string]*lxdprofile.Profile)
+	seen := make(map[string]bool)
+	for _, unit := range units {
+		appName := unit.ApplicationName()
+		if seen[appName] {
+			continue
+		}
+		seen[appName] = true
+		app, err := unit.Application()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile, err := ch.LXDProfile()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if profile.Empty() {
+			continue
+		}
+		name := lxdprofile.Name(model.Name(), appName, ch.Revision())
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// WatchCharmProfiles returns a NotifyWatcher that triggers when the
+// applications or units of the model change, since either may change
+// the charm LXD profiles that machines need.
+func (st *State) WatchCharmProfiles() NotifyWatcher {
+	return newNotifyCollsWatcher(st, isLocalID(st), applicationsC, unitsC)
+}
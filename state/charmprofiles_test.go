@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testcharms"
+)
+
+type CharmProfilesSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&CharmProfilesSuite{})
+
+var testProfile = &lxdprofile.Profile{
+	Description: "nesting",
+	Config:      map[string]string{"security.nesting": "true"},
+}
+
+func (s *CharmProfilesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *CharmProfilesSuite) addCharm(c *gc.C, revision int, profile *lxdprofile.Profile) *state.Charm {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("dummy"),
+		ID:          charm.MustParseURL("local:quantal/dummy").WithRevision(revision),
+		StoragePath: "dummy-1",
+		SHA256:      "dummy-1-sha256",
+		LXDProfile:  profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *CharmProfilesSuite) TestCharmLXDProfile(c *gc.C) {
+	ch := s.addCharm(c, 1, testProfile)
+	ch, err := s.State.Charm(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err := ch.LXDProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, testProfile)
+}
+
+func (s *CharmProfilesSuite) TestCharmWithoutLXDProfile(c *gc.C) {
+	ch := s.addCharm(c, 1, nil)
+	profile, err := ch.LXDProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (s *CharmProfilesSuite) TestSetCharmProfiles(c *gc.C) {
+	c.Assert(s.machine.CharmProfiles(), gc.HasLen, 0)
+
+	err := s.machine.SetCharmProfiles([]string{"juju-b-2", "juju-a-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CharmProfiles(), jc.DeepEquals, []string{"juju-a-1", "juju-b-2"})
+
+	err = s.machine.SetCharmProfiles(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CharmProfiles(), gc.HasLen, 0)
+}
+
+func (s *CharmProfilesSuite) TestSetCharmProfilesDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetCharmProfiles([]string{"juju-a-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm profiles of machine 0: not found or dead`)
+}
+
+func (s *CharmProfilesSuite) TestCharmLXDProfiles(c *gc.C) {
+	app := s.AddTestingApplication(c, "lxd-profile", s.addCharm(c, 1, testProfile))
+	s.AddTestingApplication(c, "plain", s.addCharm(c, 2, nil))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	profiles, err := s.machine.CharmLXDProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, map[string]*lxdprofile.Profile{
+		"juju-testenv-lxd-profile-1": testProfile,
+	})
+
+	upgraded := &lxdprofile.Profile{
+		Config: map[string]string{"security.privileged": "true"},
+	}
+	err = app.SetCharm(state.SetCharmConfig{Charm: s.addCharm(c, 3, upgraded)})
+	c.Assert(err, jc.ErrorIsNil)
+	profiles, err = s.machine.CharmLXDProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, map[string]*lxdprofile.Profile{
+		"juju-testenv-lxd-profile-3": upgraded,
+	})
+}
+
+func (s *CharmProfilesSuite) TestWatchCharmProfiles(c *gc.C) {
+	w := s.State.WatchCharmProfiles()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	app := s.AddTestingApplication(c, "lxd-profile", s.addCharm(c, 1, testProfile))
+	wc.AssertOneChange()
+
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
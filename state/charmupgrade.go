@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CharmUpgrade describes an upgrade available to an application from
+// the charm store, as found by the charm revision updater.
+type CharmUpgrade struct {
+	// CharmURL is the URL of the latest charm revision in the
+	// application's channel.
+	CharmURL *charm.URL
+
+	// Channel is the charm store channel that was queried.
+	Channel csparams.Channel
+
+	// Resources holds the names of the application's resources for
+	// which the charm store has newer revisions.
+	Resources []string
+
+	// Updated is the time at which the upgrade was recorded.
+	Updated time.Time
+}
+
+type charmUpgradeDoc struct {
+	DocID       string     `bson:"_id"`
+	ModelUUID   string     `bson:"model-uuid"`
+	Application string     `bson:"application"`
+	CharmURL    *charm.URL `bson:"charmurl"`
+	Channel     string     `bson:"cs-channel"`
+	Resources   []string   `bson:"resources,omitempty"`
+	Updated     time.Time  `bson:"updated"`
+}
+
+// CharmUpgrade returns the charm upgrade most recently recorded for
+// the application. If no upgrade is available, an error satisfying
+// errors.IsNotFound is returned.
+func (a *Application) CharmUpgrade() (CharmUpgrade, error) {
+	upgrades, closer := a.st.db().GetCollection(charmUpgradesC)
+	defer closer()
+
+	var doc charmUpgradeDoc
+	err := upgrades.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return CharmUpgrade{}, errors.NotFoundf("charm upgrade for application %q", a.doc.Name)
+	} else if err != nil {
+		return CharmUpgrade{}, errors.Annotatef(err, "cannot get charm upgrade for application %q", a.doc.Name)
+	}
+	return CharmUpgrade{
+		CharmURL:  doc.CharmURL,
+		Channel:   csparams.Channel(doc.Channel),
+		Resources: doc.Resources,
+		Updated:   doc.Updated.UTC(),
+	}, nil
+}
+
+// SetCharmUpgrade records the charm upgrade available to the
+// application, replacing any previous record. A nil upgrade records
+// that no upgrade is available.
+func (a *Application) SetCharmUpgrade(upgrade *CharmUpgrade) error {
+	if upgrade != nil && upgrade.CharmURL == nil {
+		return errors.NotValidf("charm upgrade without charm URL")
+	}
+	upgrades, closer := a.st.db().GetCollection(charmUpgradesC)
+	defer closer()
+
+	docID := a.st.docID(a.doc.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		} else if !alive {
+			return nil, errNotAlive
+		}
+		count, err := upgrades.FindId(a.doc.Name).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		switch {
+		case upgrade == nil && count == 0:
+			return nil, jujutxn.ErrNoOperations
+		case upgrade == nil:
+			ops = append(ops, removeCharmUpgradeOp(a.st, a.doc.Name))
+		case count == 0:
+			ops = append(ops, txn.Op{
+				C:      charmUpgradesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &charmUpgradeDoc{
+					DocID:       docID,
+					ModelUUID:   a.st.ModelUUID(),
+					Application: a.doc.Name,
+					CharmURL:    upgrade.CharmURL,
+					Channel:     string(upgrade.Channel),
+					Resources:   upgrade.Resources,
+					Updated:     a.st.nowToTheSecond(),
+				},
+			})
+		default:
+			ops = append(ops, txn.Op{
+				C:      charmUpgradesC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"charmurl", upgrade.CharmURL},
+					{"cs-channel", string(upgrade.Channel)},
+					{"resources", upgrade.Resources},
+					{"updated", a.st.nowToTheSecond()},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot set charm upgrade: application " + err.Error())
+		}
+		return errors.Annotate(err, "cannot set charm upgrade")
+	}
+	return nil
+}
+
+// removeCharmUpgradeOp returns an operation that removes the charm
+// upgrade recorded for the named application, if any.
+func removeCharmUpgradeOp(mb modelBackend, appName string) txn.Op {
+	return txn.Op{
+		C:      charmUpgradesC,
+		Id:     mb.docID(appName),
+		Remove: true,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v2/csclient/params"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmUpgradeSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+func (s *CharmUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *CharmUpgradeSuite) TestCharmUpgradeNotFound(c *gc.C) {
+	_, err := s.app.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `charm upgrade for application "wordpress" not found`)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmUpgrade(c *gc.C) {
+	err := s.app.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL: charm.MustParseURL("cs:quantal/wordpress-5"),
+		Channel:  csparams.StableChannel,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL:  charm.MustParseURL("cs:quantal/wordpress-6"),
+		Channel:   csparams.EdgeChannel,
+		Resources: []string{"data"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.app.CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.CharmURL, jc.DeepEquals, charm.MustParseURL("cs:quantal/wordpress-6"))
+	c.Assert(upgrade.Channel, gc.Equals, csparams.EdgeChannel)
+	c.Assert(upgrade.Resources, jc.DeepEquals, []string{"data"})
+	c.Assert(upgrade.Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *CharmUpgradeSuite) TestClearCharmUpgrade(c *gc.C) {
+	err := s.app.SetCharmUpgrade(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL: charm.MustParseURL("cs:quantal/wordpress-5"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetCharmUpgrade(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.app.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmUpgradeInvalid(c *gc.C) {
+	err := s.app.SetCharmUpgrade(&state.CharmUpgrade{})
+	c.Assert(err, gc.ErrorMatches, "charm upgrade without charm URL not valid")
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmUpgradeApplicationNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL: charm.MustParseURL("cs:quantal/wordpress-5"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot set charm upgrade: application not found or not alive")
+}
+
+func (s *CharmUpgradeSuite) TestCharmUpgradeRemovedWithApplication(c *gc.C) {
+	err := s.app.SetCharmUpgrade(&state.CharmUpgrade{
+		CharmURL: charm.MustParseURL("cs:quantal/wordpress-5"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := s.app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A new application with the same name has no upgrade.
+	s.app = s.AddTestingApplication(c, "wordpress", ch)
+	_, err = s.app.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
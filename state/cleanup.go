@@ -5,6 +5,8 @@ package state
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
@@ -43,6 +45,12 @@ type cleanupDoc struct {
 	Kind   cleanupKind   `bson:"kind"`
 	Prefix string        `bson:"prefix"`
 	Args   []*cleanupArg `bson:"args,omitempty"`
+
+	// Attempts, LastAttempt and LastError record the failed attempts
+	// to run the cleanup. LastAttempt is in unix nanoseconds.
+	Attempts    int    `bson:"attempts,omitempty"`
+	LastAttempt int64  `bson:"last-attempt,omitempty"`
+	LastError   string `bson:"last-error,omitempty"`
 }
 
 type cleanupArg struct {
@@ -94,72 +102,159 @@ func (st *State) NeedsCleanup() (bool, error) {
 	return count > 0, nil
 }
 
-// Cleanup removes all documents that were previously marked for removal, if
-// any such exist. It should be called periodically by at least one element
-// of the system.
+// CleanupInfo describes a cleanup that has not yet completed.
+type CleanupInfo struct {
+	// Id identifies the cleanup.
+	Id string
+
+	// Kind is the kind of cleanup, and Prefix identifies what is
+	// being cleaned up.
+	Kind   string
+	Prefix string
+
+	// Created is when the cleanup was scheduled.
+	Created time.Time
+
+	// Attempts is the number of times the cleanup has failed, and
+	// LastAttempt and LastError record the most recent failure.
+	Attempts    int
+	LastAttempt time.Time
+	LastError   string
+}
+
+// Cleanups returns the cleanups in the model that have not yet
+// completed, in the order they will be run.
+func (st *State) Cleanups() ([]CleanupInfo, error) {
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+	var docs []cleanupDoc
+	if err := cleanups.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading cleanups")
+	}
+	result := make([]CleanupInfo, len(docs))
+	for i, doc := range docs {
+		info := CleanupInfo{
+			Id:        st.localID(doc.DocID),
+			Kind:      string(doc.Kind),
+			Prefix:    doc.Prefix,
+			Attempts:  doc.Attempts,
+			LastError: doc.LastError,
+		}
+		// Cleanup ids are formatted object ids, which hold the
+		// time they were created.
+		hex := strings.TrimSuffix(strings.TrimPrefix(info.Id, `ObjectIdHex("`), `")`)
+		if bson.IsObjectIdHex(hex) {
+			info.Id = hex
+			info.Created = bson.ObjectIdHex(hex).Time().UTC()
+		}
+		if doc.LastAttempt != 0 {
+			info.LastAttempt = time.Unix(0, doc.LastAttempt).UTC()
+		}
+		result[i] = info
+	}
+	return result, nil
+}
+
+// Cleanup runs the cleanups that were previously scheduled, if any
+// such exist, in the order they were scheduled. A cleanup that fails
+// is kept, with a record of the failure, and is run again by the next
+// call. It should be called periodically by at least one element of
+// the system.
 func (st *State) Cleanup() (err error) {
 	var doc cleanupDoc
 	cleanups, closer := st.db().GetCollection(cleanupsC)
 	defer closer()
-	iter := cleanups.Find(nil).Iter()
+	iter := cleanups.Find(nil).Sort("_id").Iter()
 	defer closeIter(iter, &err, "reading cleanup document")
 	for iter.Next(&doc) {
-		var err error
-		logger.Debugf("running %q cleanup: %q", doc.Kind, doc.Prefix)
-		args := make([]bson.Raw, len(doc.Args))
-		for i, arg := range doc.Args {
-			args[i] = arg.Value.(bson.Raw)
-		}
-		switch doc.Kind {
-		case cleanupRelationSettings:
-			err = st.cleanupRelationSettings(doc.Prefix)
-		case cleanupCharm:
-			err = st.cleanupCharm(doc.Prefix)
-		case cleanupUnitsForDyingApplication:
-			err = st.cleanupUnitsForDyingApplication(doc.Prefix, args)
-		case cleanupDyingUnit:
-			err = st.cleanupDyingUnit(doc.Prefix, args)
-		case cleanupRemovedUnit:
-			err = st.cleanupRemovedUnit(doc.Prefix)
-		case cleanupApplicationsForDyingModel:
-			err = st.cleanupApplicationsForDyingModel()
-		case cleanupDyingMachine:
-			err = st.cleanupDyingMachine(doc.Prefix)
-		case cleanupForceDestroyedMachine:
-			err = st.cleanupForceDestroyedMachine(doc.Prefix)
-		case cleanupAttachmentsForDyingStorage:
-			err = st.cleanupAttachmentsForDyingStorage(doc.Prefix)
-		case cleanupAttachmentsForDyingVolume:
-			err = st.cleanupAttachmentsForDyingVolume(doc.Prefix)
-		case cleanupAttachmentsForDyingFilesystem:
-			err = st.cleanupAttachmentsForDyingFilesystem(doc.Prefix)
-		case cleanupModelsForDyingController:
-			err = st.cleanupModelsForDyingController(args)
-		case cleanupMachinesForDyingModel:
-			err = st.cleanupMachinesForDyingModel()
-		case cleanupResourceBlob:
-			err = st.cleanupResourceBlob(doc.Prefix)
-		case cleanupStorageForDyingModel:
-			err = st.cleanupStorageForDyingModel(args)
-		default:
-			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
-		}
-		if err != nil {
+		if err := st.runCleanup(doc); err != nil {
 			logger.Errorf("cleanup failed for %v(%q): %v", doc.Kind, doc.Prefix, err)
-			continue
-		}
-		ops := []txn.Op{{
-			C:      cleanupsC,
-			Id:     doc.DocID,
-			Remove: true,
-		}}
-		if err := st.db().RunTransaction(ops); err != nil {
-			return errors.Annotate(err, "cannot remove empty cleanup document")
+			if err := st.recordCleanupFailure(doc, err); err != nil {
+				return errors.Trace(err)
+			}
+		} else {
+			ops := []txn.Op{{
+				C:      cleanupsC,
+				Id:     doc.DocID,
+				Remove: true,
+			}}
+			if err := st.db().RunTransaction(ops); err != nil {
+				return errors.Annotate(err, "cannot remove empty cleanup document")
+			}
 		}
+		// Fields omitted from the next document are not reset by
+		// unmarshalling.
+		doc = cleanupDoc{}
 	}
 	return nil
 }
 
+// recordCleanupFailure records a failed attempt to run the supplied
+// cleanup.
+func (st *State) recordCleanupFailure(doc cleanupDoc, cleanupErr error) error {
+	ops := []txn.Op{{
+		C:      cleanupsC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{
+			{"$inc", bson.D{{"attempts", 1}}},
+			{"$set", bson.D{
+				{"last-attempt", st.clock().Now().UnixNano()},
+				{"last-error", cleanupErr.Error()},
+			}},
+		},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// The cleanup has been removed by another runner.
+		return nil
+	}
+	return errors.Annotate(err, "cannot record cleanup failure")
+}
+
+// runCleanup runs the supplied cleanup.
+func (st *State) runCleanup(doc cleanupDoc) error {
+	logger.Debugf("running %q cleanup: %q", doc.Kind, doc.Prefix)
+	args := make([]bson.Raw, len(doc.Args))
+	for i, arg := range doc.Args {
+		args[i] = arg.Value.(bson.Raw)
+	}
+	switch doc.Kind {
+	case cleanupRelationSettings:
+		return st.cleanupRelationSettings(doc.Prefix)
+	case cleanupCharm:
+		return st.cleanupCharm(doc.Prefix)
+	case cleanupUnitsForDyingApplication:
+		return st.cleanupUnitsForDyingApplication(doc.Prefix, args)
+	case cleanupDyingUnit:
+		return st.cleanupDyingUnit(doc.Prefix, args)
+	case cleanupRemovedUnit:
+		return st.cleanupRemovedUnit(doc.Prefix)
+	case cleanupApplicationsForDyingModel:
+		return st.cleanupApplicationsForDyingModel()
+	case cleanupDyingMachine:
+		return st.cleanupDyingMachine(doc.Prefix)
+	case cleanupForceDestroyedMachine:
+		return st.cleanupForceDestroyedMachine(doc.Prefix)
+	case cleanupAttachmentsForDyingStorage:
+		return st.cleanupAttachmentsForDyingStorage(doc.Prefix)
+	case cleanupAttachmentsForDyingVolume:
+		return st.cleanupAttachmentsForDyingVolume(doc.Prefix)
+	case cleanupAttachmentsForDyingFilesystem:
+		return st.cleanupAttachmentsForDyingFilesystem(doc.Prefix)
+	case cleanupModelsForDyingController:
+		return st.cleanupModelsForDyingController(args)
+	case cleanupMachinesForDyingModel:
+		return st.cleanupMachinesForDyingModel()
+	case cleanupResourceBlob:
+		return st.cleanupResourceBlob(doc.Prefix)
+	case cleanupStorageForDyingModel:
+		return st.cleanupStorageForDyingModel(args)
+	default:
+		return errors.Errorf("unknown cleanup kind %q", doc.Kind)
+	}
+}
+
 func (st *State) cleanupResourceBlob(storagePath string) error {
 	// Ignore attempts to clean up a placeholder resource.
 	if storagePath == "" {
@@ -525,7 +620,19 @@ func (st *State) cleanupUnitStorageInstances(unitTag names.UnitTag) error {
 	}
 	for _, storageAttachment := range storageAttachments {
 		storageTag := storageAttachment.StorageInstance()
-		err := im.DestroyStorageInstance(storageTag, true)
+		storageInstance, err := im.StorageInstance(storageTag)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if owner, ok := storageInstance.Owner(); ok && owner.Kind() == names.ApplicationTagKind {
+			// Shared storage is used by the application's other
+			// units, so it is only detached from this one.
+			err = im.DetachStorage(storageTag, unitTag)
+		} else {
+			err = im.DestroyStorageInstance(storageTag, true)
+		}
 		if errors.IsNotFound(err) {
 			continue
 		} else if err != nil {
@@ -783,6 +783,49 @@ func (s *CleanupSuite) TestNothingToCleanup(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 }
 
+func (s *CleanupSuite) TestCleanupsRecordFailures(c *gc.C) {
+	state.ScheduleCleanup(c, s.State, "bogus", "first")
+	state.ScheduleCleanup(c, s.State, "bogus", "second")
+	cleanups, err := s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 2)
+	c.Check(cleanups[0].Prefix, gc.Equals, "first")
+	c.Check(cleanups[0].Kind, gc.Equals, "bogus")
+	c.Check(cleanups[0].Id, gc.Not(gc.Equals), cleanups[1].Id)
+	c.Check(cleanups[0].Created.IsZero(), jc.IsFalse)
+	c.Check(cleanups[0].Attempts, gc.Equals, 0)
+	c.Check(cleanups[0].LastAttempt.IsZero(), jc.IsTrue)
+	c.Check(cleanups[0].LastError, gc.Equals, "")
+	c.Check(cleanups[1].Prefix, gc.Equals, "second")
+
+	// Failed cleanups are kept, and run again.
+	s.assertCleanupRuns(c)
+	s.assertCleanupRuns(c)
+	cleanups, err = s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 2)
+	for i, prefix := range []string{"first", "second"} {
+		c.Check(cleanups[i].Prefix, gc.Equals, prefix)
+		c.Check(cleanups[i].Attempts, gc.Equals, 2)
+		c.Check(cleanups[i].LastAttempt.IsZero(), jc.IsFalse)
+		c.Check(cleanups[i].LastError, gc.Equals, `unknown cleanup kind "bogus"`)
+	}
+}
+
+func (s *CleanupSuite) TestCleanupsOmitsCompleted(c *gc.C) {
+	state.ScheduleCleanup(c, s.State, "bogus", "stuck")
+	state.ScheduleCleanup(c, s.State, "resourceBlob", "")
+	cleanups, err := s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 2)
+
+	s.assertCleanupRuns(c)
+	cleanups, err = s.State.Cleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 1)
+	c.Check(cleanups[0].Kind, gc.Equals, "bogus")
+}
+
 func (s *CleanupSuite) assertCleanupRuns(c *gc.C) {
 	err := s.State.Cleanup()
 	c.Assert(err, jc.ErrorIsNil)
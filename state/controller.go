@@ -87,3 +87,59 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig changes the controller config. The values in
+// updateAttrs are applied to the current config, and the attributes in
+// removeAttrs revert to their defaults. Only the attributes listed in
+// controller.AllowedUpdateConfigAttributes may be changed after the
+// controller has been bootstrapped.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	if err := checkUpdateControllerConfig(updateAttrs, removeAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Annotate(err, "controller config")
+	}
+	for _, key := range removeAttrs {
+		settings.Delete(key)
+	}
+	settings.Update(updateAttrs)
+
+	// Coerce and validate the new config, and write the coerced
+	// values so that, for example, removed attributes take their
+	// default values.
+	current := jujucontroller.Config(settings.Map())
+	caCert, _ := current.CACert()
+	coerced, err := jujucontroller.NewConfig(current.ControllerUUID(), caCert, settings.Map())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, key := range removeAttrs {
+		if value, ok := coerced[key]; ok {
+			settings.Set(key, value)
+		}
+	}
+	for key := range updateAttrs {
+		settings.Set(key, coerced[key])
+	}
+	_, err = settings.Write()
+	return errors.Annotate(err, "updating controller config")
+}
+
+func checkUpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	for key := range updateAttrs {
+		if !jujucontroller.AllowedUpdateConfigAttributes.Contains(key) {
+			return errors.Errorf("cannot change %q after bootstrap", key)
+		}
+	}
+	for _, key := range removeAttrs {
+		if !jujucontroller.AllowedUpdateConfigAttributes.Contains(key) {
+			return errors.Errorf("cannot change %q after bootstrap", key)
+		}
+		if _, ok := updateAttrs[key]; ok {
+			return errors.Errorf("cannot both update and remove %q", key)
+		}
+	}
+	return nil
+}
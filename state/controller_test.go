@@ -4,12 +4,15 @@
 package state_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type ControllerSuite struct {
@@ -25,12 +28,24 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
-		controller.MongoMemoryProfile:  true,
+		controller.IdentityURL:                 true,
+		controller.IdentityPublicKey:           true,
+		controller.AutocertURLKey:              true,
+		controller.AutocertDNSNameKey:          true,
+		controller.AllowModelAccessKey:         true,
+		controller.MongoMemoryProfile:          true,
+		controller.MetricsEndpointAllowedCIDRs: true,
+		controller.HealthEndpointAllowedCIDRs:  true,
+		controller.AuthorizationWebhookURL:     true,
+		controller.StoragePlugins:              true,
+		controller.PasswordMinLength:           true,
+		controller.PasswordMinCharacterClasses: true,
+		controller.PasswordMaxAge:              true,
+		controller.LoginLockoutThreshold:       true,
+		controller.LoginLockoutDuration:        true,
+		controller.APIRequestRateLimit:         true,
+		controller.APIRequestRateBurst:         true,
+		controller.AgentTokenLifetime:          true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -55,6 +70,82 @@ func (s *ControllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg["controller-uuid"], gc.Equals, s.State.ControllerUUID())
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled:     true,
+		controller.APIRequestRateLimit: 20.0,
+		controller.MaxLogsAge:          "24h",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	limit, ok := cfg.APIRequestRateLimit()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(limit, gc.Equals, 20)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 24*time.Hour)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRemove(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxLogsAge:          "24h",
+		controller.APIRequestRateLimit: 20,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(nil, []string{
+		controller.MaxLogsAge,
+		controller.APIRequestRateLimit,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxLogsAge(), gc.Equals, 72*time.Hour)
+	_, ok := cfg.APIRequestRateLimit()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigNotAllowed(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.StatePort})
+	c.Assert(err, gc.ErrorMatches, `cannot change "state-port" after bootstrap`)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+	}, []string{controller.AuditingEnabled})
+	c.Assert(err, gc.ErrorMatches, `cannot both update and remove "auditing-enabled"`)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigInvalid(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MongoMemoryProfile: "huge",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `mongo-memory-profile: expected one of low or default got string\("huge"\)`)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MongoMemoryProfile(), gc.Equals, controller.DefaultMongoMemoryProfile)
+}
+
+func (s *ControllerSuite) TestWatchControllerConfigUpdate(c *gc.C) {
+	w := s.State.WatchControllerConfig()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *ControllerSuite) TestPing(c *gc.C) {
 	c.Assert(s.Controller.Ping(), gc.IsNil)
 	gitjujutesting.MgoServer.Restart()
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ControllerMetricsSample holds the internal metrics sampled by a
+// controller machine agent at a point in time.
+type ControllerMetricsSample struct {
+	// Machine is the id of the controller machine that took the
+	// sample.
+	Machine string
+
+	// Time is when the sample was taken.
+	Time time.Time
+
+	// Metrics holds the sampled values, keyed by metric name.
+	Metrics map[string]float64
+}
+
+type controllerMetricsDoc struct {
+	DocID   bson.ObjectId      `bson:"_id"`
+	Machine string             `bson:"machine"`
+	Time    time.Time          `bson:"time"`
+	Metrics map[string]float64 `bson:"metrics"`
+}
+
+// AddControllerMetrics records a sample of controller metrics, and
+// discards samples from the same machine that are older than retain,
+// so that only a rolling window of samples is kept.
+func (st *State) AddControllerMetrics(sample ControllerMetricsSample, retain time.Duration) error {
+	if sample.Machine == "" {
+		return errors.NotValidf("empty machine")
+	}
+	if sample.Time.IsZero() {
+		return errors.NotValidf("zero time")
+	}
+	for name := range sample.Metrics {
+		if name == "" || strings.ContainsAny(name, ".$") {
+			return errors.NotValidf("metric name %q", name)
+		}
+	}
+	metrics, closer := st.db().GetRawCollection(controllerMetricsC)
+	defer closer()
+
+	// Samples are taken regularly, and losing one is harmless; don't
+	// require write majority, nor sync to disk.
+	session := metrics.Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	doc := controllerMetricsDoc{
+		DocID:   bson.NewObjectId(),
+		Machine: sample.Machine,
+		Time:    sample.Time.UTC(),
+		Metrics: sample.Metrics,
+	}
+	if err := metrics.Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot add controller metrics for machine %s", sample.Machine)
+	}
+	_, err := metrics.RemoveAll(bson.D{
+		{"machine", sample.Machine},
+		{"time", bson.D{{"$lt", doc.Time.Add(-retain)}}},
+	})
+	return errors.Annotatef(err, "cannot prune controller metrics for machine %s", sample.Machine)
+}
+
+// ControllerMetrics returns the controller metrics samples taken at
+// or after the given time, ordered by time.
+func (st *State) ControllerMetrics(since time.Time) ([]ControllerMetricsSample, error) {
+	metrics, closer := st.db().GetRawCollection(controllerMetricsC)
+	defer closer()
+
+	var docs []controllerMetricsDoc
+	query := metrics.Find(bson.D{{"time", bson.D{{"$gte", since.UTC()}}}})
+	if err := query.Sort("time", "machine").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get controller metrics")
+	}
+	samples := make([]ControllerMetricsSample, len(docs))
+	for i, doc := range docs {
+		samples[i] = ControllerMetricsSample{
+			Machine: doc.Machine,
+			Time:    doc.Time.UTC(),
+			Metrics: doc.Metrics,
+		}
+	}
+	return samples, nil
+}
+
+// MongoStats holds statistics about the controller's database.
+type MongoStats struct {
+	// DataSize, StorageSize and IndexSize are the sizes, in bytes,
+	// of the juju database's data, the storage allocated for it, and
+	// its indexes.
+	DataSize    float64 `bson:"dataSize"`
+	StorageSize float64 `bson:"storageSize"`
+	IndexSize   float64 `bson:"indexSize"`
+
+	// Objects is the number of documents in the juju database.
+	Objects float64 `bson:"objects"`
+}
+
+// MongoStats returns statistics about the juju database.
+func (st *State) MongoStats() (MongoStats, error) {
+	var stats MongoStats
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	if err := session.DB(jujuDB).Run(bson.D{{"dbStats", 1}}, &stats); err != nil {
+		return MongoStats{}, errors.Annotate(err, "cannot get database stats")
+	}
+	return stats, nil
+}
+
+// PendingTransactionCount returns the number of transactions that
+// have not yet been applied or aborted.
+func (st *State) PendingTransactionCount() (int, error) {
+	txns, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+
+	// These are mgo/txn's preparing, prepared, aborting and
+	// applying states.
+	count, err := txns.Find(bson.D{{"s", bson.D{{"$in", []int{1, 2, 3, 4}}}}}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending transactions")
+	}
+	return count, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ControllerMetricsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerMetricsSuite{})
+
+var controllerMetricsEpoch = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *ControllerMetricsSuite) sample(machine string, offset time.Duration, value float64) state.ControllerMetricsSample {
+	return state.ControllerMetricsSample{
+		Machine: machine,
+		Time:    controllerMetricsEpoch.Add(offset),
+		Metrics: map[string]float64{"txn-queue-depth": value},
+	}
+}
+
+func (s *ControllerMetricsSuite) TestAddControllerMetrics(c *gc.C) {
+	first := s.sample("0", 0, 1)
+	second := s.sample("1", time.Minute, 2)
+	third := s.sample("0", 2*time.Minute, 3)
+	for _, sample := range []state.ControllerMetricsSample{third, first, second} {
+		err := s.State.AddControllerMetrics(sample, time.Hour)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	samples, err := s.State.ControllerMetrics(controllerMetricsEpoch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(samples, jc.DeepEquals, []state.ControllerMetricsSample{first, second, third})
+
+	samples, err = s.State.ControllerMetrics(controllerMetricsEpoch.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(samples, jc.DeepEquals, []state.ControllerMetricsSample{second, third})
+}
+
+func (s *ControllerMetricsSuite) TestAddControllerMetricsPrunes(c *gc.C) {
+	old := s.sample("0", 0, 1)
+	other := s.sample("1", 0, 2)
+	recent := s.sample("0", 30*time.Minute, 3)
+	latest := s.sample("0", 61*time.Minute, 4)
+	for _, sample := range []state.ControllerMetricsSample{old, other, recent, latest} {
+		err := s.State.AddControllerMetrics(sample, time.Hour)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only machine 0's samples more than an hour older than its
+	// latest are discarded.
+	samples, err := s.State.ControllerMetrics(time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(samples, jc.DeepEquals, []state.ControllerMetricsSample{other, recent, latest})
+}
+
+func (s *ControllerMetricsSuite) TestAddControllerMetricsInvalid(c *gc.C) {
+	sample := s.sample("0", 0, 1)
+	sample.Metrics["mongo.data-size"] = 1
+	err := s.State.AddControllerMetrics(sample, time.Hour)
+	c.Assert(err, gc.ErrorMatches, `metric name "mongo.data-size" not valid`)
+
+	err = s.State.AddControllerMetrics(state.ControllerMetricsSample{Time: controllerMetricsEpoch}, time.Hour)
+	c.Assert(err, gc.ErrorMatches, `empty machine not valid`)
+}
+
+func (s *ControllerMetricsSuite) TestMongoStats(c *gc.C) {
+	stats, err := s.State.MongoStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats.Objects > 0, jc.IsTrue)
+	c.Assert(stats.DataSize > 0, jc.IsTrue)
+}
+
+func (s *ControllerMetricsSuite) TestPendingTransactionCount(c *gc.C) {
+	count, err := s.State.PendingTransactionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
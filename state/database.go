@@ -165,6 +165,12 @@ type collectionInfo struct {
 	// very careful analysis; and then, please, just don't do it anyway. If you
 	// need raw mgo, use a rawAccess collection.
 	rawAccess bool
+
+	// modelDatabase collections are placed in the model's own database,
+	// if one was selected when the model was created. Transactions
+	// cannot span databases, so only rawAccess collections that are not
+	// global may be placed in a model's database.
+	modelDatabase bool
 }
 
 // collectionSchema defines the set of collections used in juju.
@@ -236,6 +242,11 @@ type database struct {
 	// runTransactionObserver is passed on to txn.TransactionRunner, to be
 	// invoked after calls to Run and RunTransaction.
 	runTransactionObserver RunTransactionObserverFunc
+
+	// modelDatabases records the databases selected for models'
+	// modelDatabase collections. It is shared by all copies of the
+	// database.
+	modelDatabases *modelDatabases
 }
 
 // RunTransactionObserverFunc is the type of a function to be called
@@ -250,6 +261,8 @@ func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 		modelUUID:  modelUUID,
 		runner:     db.runner,
 		ownSession: true,
+
+		modelDatabases: db.modelDatabases,
 	}, session.Close
 }
 
@@ -273,12 +286,20 @@ func (db *database) GetCollection(name string) (collection mongo.Collection, clo
 		}
 	}
 
+	// Use the model's own database if it has one.
+	raw := db.raw
+	if info.modelDatabase && db.modelDatabases != nil {
+		if dbName := db.modelDatabases.name(db.raw, db.modelUUID); dbName != "" {
+			raw = db.raw.Session.DB(dbName)
+		}
+	}
+
 	// Copy session if necessary.
 	if db.ownSession {
-		collection = mongo.WrapCollection(db.raw.C(name))
+		collection = mongo.WrapCollection(raw.C(name))
 		closer = dontCloseAnything
 	} else {
-		collection, closer = mongo.CollectionFromName(db.raw, name)
+		collection, closer = mongo.CollectionFromName(raw, name)
 	}
 
 	// Apply model filtering.
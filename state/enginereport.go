@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+)
+
+// MaxEngineReportSize is the largest dependency engine report, in
+// bytes, that may be stored.
+const MaxEngineReportSize = 1024 * 1024
+
+// EngineReport holds the most recent report of an agent's dependency
+// engine. The report is opaque to state; it is formatted by the agent
+// that sends it.
+type EngineReport struct {
+	// Report holds the formatted report.
+	Report string
+
+	// Updated is the time at which the report was recorded.
+	Updated time.Time
+}
+
+type engineReportDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Agent     string    `bson:"agent"`
+	Report    string    `bson:"report"`
+	Updated   time.Time `bson:"updated"`
+}
+
+// SetEngineReport records the given dependency engine report for the
+// agent with the given tag, replacing any previous report.
+func (st *State) SetEngineReport(agent names.Tag, report string) error {
+	if len(report) > MaxEngineReportSize {
+		return errors.Errorf("engine report is larger than %d bytes", MaxEngineReportSize)
+	}
+	reports, closer := st.db().GetCollection(engineReportsC)
+	defer closer()
+
+	// Reports are sent regularly, and losing one is harmless; don't
+	// require write majority, nor sync to disk.
+	reportsW := reports.Writeable()
+	session := reportsW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	doc := engineReportDoc{
+		DocID:     st.docID(agent.String()),
+		ModelUUID: st.ModelUUID(),
+		Agent:     agent.String(),
+		Report:    report,
+		Updated:   st.nowToTheSecond(),
+	}
+	if _, err := reportsW.UpsertId(doc.DocID, doc); err != nil {
+		return errors.Annotatef(err, "cannot set engine report for %s", names.ReadableString(agent))
+	}
+	return nil
+}
+
+// EngineReport returns the most recent dependency engine report of the
+// agent with the given tag. If the agent has not sent a report, an
+// error satisfying errors.IsNotFound is returned.
+func (st *State) EngineReport(agent names.Tag) (EngineReport, error) {
+	reports, closer := st.db().GetCollection(engineReportsC)
+	defer closer()
+
+	var doc engineReportDoc
+	err := reports.FindId(agent.String()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return EngineReport{}, errors.NotFoundf("engine report for %s", names.ReadableString(agent))
+	} else if err != nil {
+		return EngineReport{}, errors.Annotatef(err, "cannot get engine report for %s", names.ReadableString(agent))
+	}
+	return EngineReport{
+		Report:  doc.Report,
+		Updated: doc.Updated.UTC(),
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type EngineReportSuite struct {
+	statetesting.StateWithWallClockSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&EngineReportSuite{})
+
+func (s *EngineReportSuite) SetUpTest(c *gc.C) {
+	s.StateWithWallClockSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *EngineReportSuite) TestEngineReportNotFound(c *gc.C) {
+	_, err := s.State.EngineReport(names.NewMachineTag("0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `engine report for machine 0 not found`)
+}
+
+func (s *EngineReportSuite) TestSetEngineReport(c *gc.C) {
+	tag := names.NewUnitTag("mysql/0")
+	err := s.State.SetEngineReport(tag, "first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Minute)
+	err = s.State.SetEngineReport(tag, "second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := s.State.EngineReport(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, state.EngineReport{
+		Report:  "second",
+		Updated: time.Date(2017, 6, 1, 12, 1, 0, 0, time.UTC),
+	})
+}
+
+func (s *EngineReportSuite) TestEngineReportPerModel(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	err := s.State.SetEngineReport(tag, "controller model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	_, err = otherState.EngineReport(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *EngineReportSuite) TestSetEngineReportTooLarge(c *gc.C) {
+	report := strings.Repeat("x", state.MaxEngineReportSize+1)
+	err := s.State.SetEngineReport(names.NewMachineTag("0"), report)
+	c.Assert(err, gc.ErrorMatches, `engine report is larger than 1048576 bytes`)
+}
@@ -46,6 +46,7 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+	UnitsC            = unitsC
 )
 
 var (
@@ -370,6 +371,10 @@ func Sequence(st *State, name string) (int, error) {
 	return sequence(st, name)
 }
 
+func SequenceN(st *State, name string, n int) (int, error) {
+	return sequenceN(st, name, n)
+}
+
 func SequenceWithMin(st *State, name string, minVal int) (int, error) {
 	return sequenceWithMin(st, name, minVal)
 }
@@ -469,6 +474,14 @@ func MakeLogDoc(
 	}
 }
 
+// SetLogDocStructuredFields sets the labels and correlation ID of a
+// log document made by MakeLogDoc.
+func SetLogDocStructuredFields(doc *logDoc, labels []string, correlationID string) *logDoc {
+	doc.Labels = labels
+	doc.CorrelationID = correlationID
+	return doc
+}
+
 func SpaceDoc(s *Space) spaceDoc {
 	return s.doc
 }
@@ -617,6 +630,15 @@ func PrimeUnitStatusHistory(
 // entry with a 1MB string. This should allow us to infer the
 // approximate size of the entry and limit the number of entries that
 // must be generated for size related tests.
+// RemoveAction removes the action with the given id, leaving any
+// artifacts it has in place.
+func RemoveAction(c *gc.C, st *State, id string) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+	err := actions.Writeable().RemoveId(st.docID(id))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func PrimeActions(c *gc.C, age time.Time, unit *Unit, count int) {
 	actionCollection, closer := unit.st.db().GetCollection(actionsC)
 	defer closer()
@@ -689,6 +711,12 @@ func AssertNoCleanupsWithKind(c *gc.C, st *State, kind cleanupKind) {
 	}
 }
 
+// ScheduleCleanup schedules a cleanup of the given kind and prefix.
+func ScheduleCleanup(c *gc.C, st *State, kind, prefix string) {
+	err := st.db().RunTransaction([]txn.Op{newCleanupOp(cleanupKind(kind), prefix)})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 // AssertNoCleanups checks that there are no cleanups scheduled.
 func AssertNoCleanups(c *gc.C, st *State) {
 	var docs []cleanupDoc
@@ -31,18 +31,29 @@ type FirewallRule struct {
 
 	// WhitelistCIDRS is the whitelist CIDRs for the rule.
 	WhitelistCIDRs []string
+
+	// Description optionally explains the purpose of the rule.
+	Description string
+
+	// Origin is the tag of the entity, usually a user, which
+	// last set the rule.
+	Origin string
 }
 
 type firewallRulesDoc struct {
 	Id               string   `bson:"_id"`
 	WellKnownService string   `bson:"known-service"`
 	WhitelistCIDRS   []string `bson:"whitelist-cidrs"`
+	Description      string   `bson:"description,omitempty"`
+	Origin           string   `bson:"origin,omitempty"`
 }
 
 func (r *firewallRulesDoc) toRule() *FirewallRule {
 	return &FirewallRule{
 		WellKnownService: WellKnownServiceType(r.WellKnownService),
 		WhitelistCIDRs:   r.WhitelistCIDRS,
+		Description:      r.Description,
+		Origin:           r.Origin,
 	}
 }
 
@@ -99,6 +110,8 @@ func (fw *firewallRulesState) Save(rule FirewallRule) error {
 		Id:               serviceStr,
 		WellKnownService: serviceStr,
 		WhitelistCIDRS:   rule.WhitelistCIDRs,
+		Description:      rule.Description,
+		Origin:           rule.Origin,
 	}
 	buildTxn := func(int) ([]txn.Op, error) {
 		model, err := fw.st.Model()
@@ -120,7 +133,11 @@ func (fw *firewallRulesState) Save(rule FirewallRule) error {
 				Id:     serviceStr,
 				Assert: txn.DocExists,
 				Update: bson.D{
-					{"$set", bson.D{{"whitelist-cidrs", rule.WhitelistCIDRs}}},
+					{"$set", bson.D{
+						{"whitelist-cidrs", rule.WhitelistCIDRs},
+						{"description", rule.Description},
+						{"origin", rule.Origin},
+					}},
 				},
 			}, model.assertActiveOp()}
 		} else {
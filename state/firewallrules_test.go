@@ -77,6 +77,33 @@ func (s *FirewallRulesSuite) TestSaveIdempotent(c *gc.C) {
 	s.assertSavedRules(c, state.SSHRule, []string{"192.168.1.0/16"})
 }
 
+func (s *FirewallRulesSuite) TestSaveProvenance(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuApplicationOfferRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/16"},
+		Description:      "office network",
+		Origin:           "user-admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := rules.Rule(state.JujuApplicationOfferRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Description, gc.Equals, "office network")
+	c.Assert(result.Origin, gc.Equals, "user-admin")
+
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuApplicationOfferRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+		Origin:           "user-bob",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = rules.Rule(state.JujuApplicationOfferRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.WhitelistCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(result.Description, gc.Equals, "")
+	c.Assert(result.Origin, gc.Equals, "user-bob")
+}
+
 func (s *FirewallRulesSuite) TestRule(c *gc.C) {
 	rules := state.NewFirewallRules(s.State)
 	err := rules.Save(state.FirewallRule{
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HookRetryPolicy holds an application's overrides of the automatic
+// retrying of failed hooks, which is otherwise governed by the model's
+// automatically-retry-hooks setting. The zero value applies no
+// overrides.
+type HookRetryPolicy struct {
+	// Disabled, if true, stops failed hooks of the application's
+	// units being retried automatically, whatever the model says.
+	Disabled bool
+
+	// MaxAttempts, if non-zero, limits the number of times a failed
+	// hook is retried automatically before it is left for the
+	// operator to resolve.
+	MaxAttempts int
+
+	// MaxRetryTime, if non-zero, is the longest delay between
+	// automatic retries of a failed hook.
+	MaxRetryTime time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p HookRetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return errors.NotValidf("negative max attempts")
+	}
+	if p.MaxRetryTime < 0 {
+		return errors.NotValidf("negative max retry time")
+	}
+	return nil
+}
+
+type hookRetryPolicyDoc struct {
+	Disabled     bool  `bson:"disabled,omitempty"`
+	MaxAttempts  int   `bson:"max-attempts,omitempty"`
+	MaxRetryTime int64 `bson:"max-retry-time,omitempty"`
+}
+
+// HookRetryPolicy returns the application's hook retry policy.
+func (a *Application) HookRetryPolicy() HookRetryPolicy {
+	doc := a.doc.HookRetry
+	if doc == nil {
+		return HookRetryPolicy{}
+	}
+	return HookRetryPolicy{
+		Disabled:     doc.Disabled,
+		MaxAttempts:  doc.MaxAttempts,
+		MaxRetryTime: time.Duration(doc.MaxRetryTime),
+	}
+}
+
+// SetHookRetryPolicy sets the application's hook retry policy. The
+// zero policy removes any overrides.
+func (a *Application) SetHookRetryPolicy(policy HookRetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var update bson.D
+	var doc *hookRetryPolicyDoc
+	if policy == (HookRetryPolicy{}) {
+		update = bson.D{{"$unset", bson.D{{"hook-retry", nil}}}}
+	} else {
+		doc = &hookRetryPolicyDoc{
+			Disabled:     policy.Disabled,
+			MaxAttempts:  policy.MaxAttempts,
+			MaxRetryTime: int64(policy.MaxRetryTime),
+		}
+		update = bson.D{{"$set", bson.D{{"hook-retry", doc}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, errNotAlive
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot set hook retry policy: application " + err.Error())
+		}
+		return errors.Annotate(err, "cannot set hook retry policy")
+	}
+	a.doc.HookRetry = doc
+	return nil
+}
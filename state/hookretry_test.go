@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookRetrySuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *HookRetrySuite) TestDefaultPolicy(c *gc.C) {
+	c.Assert(s.app.HookRetryPolicy(), jc.DeepEquals, state.HookRetryPolicy{})
+}
+
+func (s *HookRetrySuite) TestSetHookRetryPolicy(c *gc.C) {
+	policy := state.HookRetryPolicy{
+		MaxAttempts:  3,
+		MaxRetryTime: time.Minute,
+	}
+	err := s.app.SetHookRetryPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.HookRetryPolicy(), jc.DeepEquals, policy)
+
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.HookRetryPolicy(), jc.DeepEquals, policy)
+
+	err = app.SetHookRetryPolicy(state.HookRetryPolicy{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.HookRetryPolicy(), jc.DeepEquals, state.HookRetryPolicy{})
+}
+
+func (s *HookRetrySuite) TestSetHookRetryPolicyInvalid(c *gc.C) {
+	err := s.app.SetHookRetryPolicy(state.HookRetryPolicy{MaxAttempts: -1})
+	c.Assert(err, gc.ErrorMatches, "negative max attempts not valid")
+}
+
+func (s *HookRetrySuite) TestSetHookRetryPolicyApplicationNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetHookRetryPolicy(state.HookRetryPolicy{Disabled: true})
+	c.Assert(err, gc.ErrorMatches, "cannot set hook retry policy: application not found or not alive")
+}
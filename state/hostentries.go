@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HostEntry maps an address to the host names that should resolve to it.
+type HostEntry struct {
+	Address string
+	Names   []string
+}
+
+// HostEntries returns the host names that should resolve on the given
+// machine. Containers are named by their instance id, which is also
+// their hostname; units are named by their unit name with the slash
+// replaced by a dash, e.g. "mysql-0". Only the machine's own containers
+// and the units deployed to it and its containers are included, unless
+// the model's model-wide-hosts setting is true, in which case every
+// container and unit in the model is. Entries are ordered by address.
+func (st *State) HostEntries(machineId string) ([]HostEntry, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelWide := cfg.ModelWideHosts()
+	included := func(id string) bool {
+		return modelWide || id == machineId || strings.HasPrefix(id, machineId+"/")
+	}
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses := make(map[string]string)
+	names := make(map[string]set.Strings)
+	addName := func(machineId, name string) {
+		addr, ok := addresses[machineId]
+		if !ok {
+			return
+		}
+		if names[addr] == nil {
+			names[addr] = set.NewStrings()
+		}
+		names[addr].Add(name)
+	}
+	for _, m := range machines {
+		if !included(m.Id()) {
+			continue
+		}
+		addr, err := m.PrivateAddress()
+		if err != nil {
+			// Machines without an address cannot be resolved yet.
+			continue
+		}
+		addresses[m.Id()] = addr.Value
+		if m.ContainerType() == "" {
+			continue
+		}
+		if instId, err := m.InstanceId(); err == nil {
+			addName(m.Id(), string(instId))
+		} else if !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	var docs []unitDoc
+	err = units.Find(bson.D{{"machineid", bson.D{{"$ne", ""}}}}).Select(bson.D{
+		{"name", 1}, {"machineid", 1},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read units")
+	}
+	for _, doc := range docs {
+		addName(doc.MachineId, strings.Replace(doc.Name, "/", "-", -1))
+	}
+
+	entries := make([]HostEntry, 0, len(names))
+	for addr, addrNames := range names {
+		entries = append(entries, HostEntry{
+			Address: addr,
+			Names:   addrNames.SortedValues(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Address < entries[j].Address
+	})
+	return entries, nil
+}
+
+// WatchHostEntries returns a NotifyWatcher that triggers when the
+// machines or units of the model change, since either may change the
+// host entries that machines need.
+func (st *State) WatchHostEntries() NotifyWatcher {
+	return newNotifyCollsWatcher(st, isLocalID(st), machinesC, unitsC)
+}
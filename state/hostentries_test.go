@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type HostEntriesSuite struct {
+	ConnSuite
+	host      *state.Machine
+	container *state.Machine
+	other     *state.Machine
+}
+
+var _ = gc.Suite(&HostEntriesSuite{})
+
+func (s *HostEntriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.host, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.container, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.other, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.container.SetProvisioned("juju-abcdef-0-lxd-0", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for addr, m := range map[string]*state.Machine{
+		"10.0.0.1": s.host,
+		"10.0.0.2": s.container,
+		"10.0.0.3": s.other,
+	} {
+		err := m.SetProviderAddresses(network.NewAddress(addr))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for _, m := range []*state.Machine{s.container, s.host, s.other} {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *HostEntriesSuite) TestHostEntries(c *gc.C) {
+	entries, err := s.State.HostEntries(s.host.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.HostEntry{{
+		Address: "10.0.0.1",
+		Names:   []string{"wordpress-1"},
+	}, {
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-0-lxd-0", "wordpress-0"},
+	}})
+}
+
+func (s *HostEntriesSuite) TestHostEntriesModelWide(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"model-wide-hosts": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.HostEntries(s.other.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.HostEntry{{
+		Address: "10.0.0.1",
+		Names:   []string{"wordpress-1"},
+	}, {
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-0-lxd-0", "wordpress-0"},
+	}, {
+		Address: "10.0.0.3",
+		Names:   []string{"wordpress-2"},
+	}})
+}
+
+func (s *HostEntriesSuite) TestHostEntriesSkipsMachinesWithoutAddresses(c *gc.C) {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.other.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetProvisioned("juju-abcdef-1-lxd-0", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.HostEntries(s.other.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.HostEntry{{
+		Address: "10.0.0.3",
+		Names:   []string{"wordpress-2"},
+	}})
+}
+
+func (s *HostEntriesSuite) TestWatchHostEntries(c *gc.C) {
+	w := s.State.WatchHostEntries()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.other.SetProviderAddresses(network.NewAddress("10.0.0.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -350,6 +350,7 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 			args.CloudName, args.CloudRegion, args.CloudCredential,
 			args.MigrationMode,
 			args.EnvironVersion,
+			args.Database,
 		),
 		createUniqueOwnerModelNameOp(args.Owner, args.Config.Name()),
 	)
@@ -0,0 +1,340 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// IntegrityProblemKind identifies a kind of dangling reference found
+// by CheckIntegrity.
+type IntegrityProblemKind string
+
+const (
+	// UnitMissingMachine is a unit assigned to a machine that does not
+	// exist. It cannot be repaired automatically.
+	UnitMissingMachine IntegrityProblemKind = "unit-missing-machine"
+
+	// OrphanedRelationScope is a relation scope document for a
+	// relation or unit that does not exist.
+	OrphanedRelationScope IntegrityProblemKind = "orphaned-relation-scope"
+
+	// LeakedSettings is a settings document for a relation or
+	// application that does not exist.
+	LeakedSettings IntegrityProblemKind = "leaked-settings"
+)
+
+// IntegrityProblem describes a document in the model that refers to an
+// entity that does not exist.
+type IntegrityProblem struct {
+	// Kind identifies the kind of problem.
+	Kind IntegrityProblemKind
+
+	// Collection and Id identify the document with the problem.
+	Collection string
+	Id         string
+
+	// Message describes the problem.
+	Message string
+
+	// ops holds the operations that repair the problem. It is empty
+	// if the problem cannot be repaired automatically.
+	ops []txn.Op
+}
+
+// Repairable reports whether the problem can be repaired by
+// RepairIntegrity.
+func (p IntegrityProblem) Repairable() bool {
+	return len(p.ops) > 0
+}
+
+// integrityChecker holds the documents read from a model by
+// CheckIntegrity.
+type integrityChecker struct {
+	st           *State
+	machines     map[string]bool
+	units        map[string]bool
+	applications map[string]bool
+	// relations maps the ids of the model's relations to their keys.
+	relations map[int]string
+	problems  []IntegrityProblem
+}
+
+// CheckIntegrity returns the dangling references found in the model:
+// units assigned to machines that do not exist, relation scopes of
+// relations or units that do not exist, and settings of relations or
+// applications that do not exist. The problems are sorted by
+// collection and id.
+//
+// The documents are read without a transaction, so a problem may be
+// reported for an entity that was being added or removed at the time.
+// RepairIntegrity asserts that each problem still exists before
+// repairing it.
+func (st *State) CheckIntegrity() ([]IntegrityProblem, error) {
+	checker := &integrityChecker{
+		st:           st,
+		machines:     make(map[string]bool),
+		units:        make(map[string]bool),
+		applications: make(map[string]bool),
+		relations:    make(map[int]string),
+	}
+	if err := checker.load(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checker.checkUnits(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checker.checkRelationScopes(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checker.checkSettings(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems := checker.problems
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Collection != problems[j].Collection {
+			return problems[i].Collection < problems[j].Collection
+		}
+		return problems[i].Id < problems[j].Id
+	})
+	return problems, nil
+}
+
+// RepairIntegrity repairs the repairable problems returned by
+// CheckIntegrity, and returns the number repaired. A problem that no
+// longer exists is skipped.
+func (st *State) RepairIntegrity(problems []IntegrityProblem) (int, error) {
+	repaired := 0
+	for _, problem := range problems {
+		if !problem.Repairable() {
+			continue
+		}
+		err := st.db().RunTransaction(problem.ops)
+		if err == txn.ErrAborted {
+			logger.Debugf("not repairing %s %q: no longer a problem", problem.Kind, problem.Id)
+			continue
+		} else if err != nil {
+			return repaired, errors.Annotatef(err, "repairing %s %q", problem.Kind, problem.Id)
+		}
+		logger.Infof("repaired %s %q in model %s", problem.Kind, problem.Id, st.ModelUUID())
+		repaired++
+	}
+	return repaired, nil
+}
+
+func (c *integrityChecker) load() error {
+	machines, closer := c.st.db().GetCollection(machinesC)
+	defer closer()
+	var machineDocs []struct {
+		Id string `bson:"machineid"`
+	}
+	if err := machines.Find(nil).Select(bson.D{{"machineid", 1}}).All(&machineDocs); err != nil {
+		return errors.Annotate(err, "reading machines")
+	}
+	for _, doc := range machineDocs {
+		c.machines[doc.Id] = true
+	}
+
+	units, closer := c.st.db().GetCollection(unitsC)
+	defer closer()
+	var unitDocs []struct {
+		Name string `bson:"name"`
+	}
+	if err := units.Find(nil).Select(bson.D{{"name", 1}}).All(&unitDocs); err != nil {
+		return errors.Annotate(err, "reading units")
+	}
+	for _, doc := range unitDocs {
+		c.units[doc.Name] = true
+	}
+
+	applications, closer := c.st.db().GetCollection(applicationsC)
+	defer closer()
+	var applicationDocs []struct {
+		Name string `bson:"name"`
+	}
+	if err := applications.Find(nil).Select(bson.D{{"name", 1}}).All(&applicationDocs); err != nil {
+		return errors.Annotate(err, "reading applications")
+	}
+	for _, doc := range applicationDocs {
+		c.applications[doc.Name] = true
+	}
+
+	relations, closer := c.st.db().GetCollection(relationsC)
+	defer closer()
+	var relationDocs []struct {
+		Key string `bson:"key"`
+		Id  int    `bson:"id"`
+	}
+	if err := relations.Find(nil).Select(bson.D{{"key", 1}, {"id", 1}}).All(&relationDocs); err != nil {
+		return errors.Annotate(err, "reading relations")
+	}
+	for _, doc := range relationDocs {
+		c.relations[doc.Id] = doc.Key
+	}
+	return nil
+}
+
+func (c *integrityChecker) addProblem(kind IntegrityProblemKind, collection, id, message string, ops ...txn.Op) {
+	c.problems = append(c.problems, IntegrityProblem{
+		Kind:       kind,
+		Collection: collection,
+		Id:         id,
+		Message:    message,
+		ops:        ops,
+	})
+}
+
+// checkUnits reports units assigned to machines that do not exist.
+func (c *integrityChecker) checkUnits() error {
+	units, closer := c.st.db().GetCollection(unitsC)
+	defer closer()
+	var docs []struct {
+		Name      string `bson:"name"`
+		MachineId string `bson:"machineid"`
+	}
+	err := units.Find(bson.D{{"machineid", bson.D{{"$ne", ""}}}}).
+		Select(bson.D{{"name", 1}, {"machineid", 1}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "reading units")
+	}
+	for _, doc := range docs {
+		if doc.MachineId == "" || c.machines[doc.MachineId] {
+			continue
+		}
+		c.addProblem(UnitMissingMachine, unitsC, doc.Name, fmt.Sprintf(
+			"unit %q is assigned to machine %q, which does not exist",
+			doc.Name, doc.MachineId,
+		))
+	}
+	return nil
+}
+
+// checkRelationScopes reports relation scopes of relations or units
+// that do not exist. They are repaired by removing the scope and the
+// unit's relation settings, and decrementing the relation's unit count
+// if the relation exists.
+func (c *integrityChecker) checkRelationScopes() error {
+	scopes, closer := c.st.db().GetCollection(relationScopesC)
+	defer closer()
+	var docs []relationScopeDoc
+	if err := scopes.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "reading relation scopes")
+	}
+	for _, doc := range docs {
+		relationId, ok := relationIdFromKey(doc.Key)
+		if !ok {
+			c.addProblem(OrphanedRelationScope, relationScopesC, doc.Key, fmt.Sprintf(
+				"relation scope %q has an invalid key", doc.Key,
+			))
+			continue
+		}
+		unitName := doc.unitName()
+		relationKey, relationExists := c.relations[relationId]
+		if relationExists && c.units[unitName] {
+			continue
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     c.st.docID(unitName),
+			Assert: txn.DocMissing,
+		}, {
+			C:      relationScopesC,
+			Id:     c.st.docID(doc.Key),
+			Assert: txn.DocExists,
+			Remove: true,
+		}, {
+			C:      settingsC,
+			Id:     c.st.docID(doc.Key),
+			Remove: true,
+		}}
+		var message string
+		if relationExists {
+			message = fmt.Sprintf("unit %q is in scope of relation %d, but does not exist", unitName, relationId)
+			ops = append(ops, txn.Op{
+				C:      relationsC,
+				Id:     c.st.docID(relationKey),
+				Assert: bson.D{{"unitcount", bson.D{{"$gt", 0}}}},
+				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
+			})
+		} else {
+			message = fmt.Sprintf("unit %q is in scope of relation %d, which does not exist", unitName, relationId)
+			if c.units[unitName] {
+				// Only the relation is missing; relation ids are
+				// never reused, so there is nothing to assert.
+				ops = ops[1:]
+			}
+		}
+		c.addProblem(OrphanedRelationScope, relationScopesC, doc.Key, message, ops...)
+	}
+	return nil
+}
+
+// checkSettings reports relation settings of relations that do not
+// exist, and application settings of applications that do not exist.
+// They are repaired by removing the settings.
+func (c *integrityChecker) checkSettings() error {
+	settings, closer := c.st.db().GetCollection(settingsC)
+	defer closer()
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := settings.Find(nil).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Annotate(err, "reading settings")
+	}
+	for _, doc := range docs {
+		key := c.st.localID(doc.DocID)
+		remove := txn.Op{
+			C:      settingsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}
+		switch {
+		case strings.HasPrefix(key, "r#"):
+			relationId, ok := relationIdFromKey(key)
+			if !ok || c.relations[relationId] != "" {
+				continue
+			}
+			// Relation ids are never reused, so the relation
+			// cannot be added again.
+			c.addProblem(LeakedSettings, settingsC, key, fmt.Sprintf(
+				"settings %q belong to relation %d, which does not exist", key, relationId,
+			), remove)
+		case strings.HasPrefix(key, "a#"):
+			appName := strings.SplitN(key[len("a#"):], "#", 2)[0]
+			if c.applications[appName] {
+				continue
+			}
+			c.addProblem(LeakedSettings, settingsC, key, fmt.Sprintf(
+				"settings %q belong to application %q, which does not exist", key, appName,
+			), txn.Op{
+				C:      applicationsC,
+				Id:     c.st.docID(appName),
+				Assert: txn.DocMissing,
+			}, remove)
+		}
+	}
+	return nil
+}
+
+// relationIdFromKey returns the relation id from a relation scope or
+// relation settings key, which starts with "r#<id>#".
+func relationIdFromKey(key string) (int, bool) {
+	parts := strings.SplitN(key, "#", 3)
+	if len(parts) < 3 || parts[0] != "r" {
+		return 0, false
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
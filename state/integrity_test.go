@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strconv"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type IntegritySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntegritySuite{})
+
+func (s *IntegritySuite) TestCheckIntegrityHealthy(c *gc.C) {
+	s.addRelatedUnits(c)
+	problems, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestCheckAndRepairIntegrity(c *gc.C) {
+	relId := s.addRelatedUnits(c)
+
+	// Remove the machine of wordpress/0, and the mysql/0 unit while
+	// it is in scope of the relation.
+	machines, closer := state.GetRawCollection(s.State, state.MachinesC)
+	defer closer()
+	err := machines.RemoveId(state.DocID(s.State, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	units, closer := state.GetRawCollection(s.State, state.UnitsC)
+	defer closer()
+	err = units.RemoveId(state.DocID(s.State, "mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Leak the settings of a removed relation and application.
+	settings, closer := state.GetRawCollection(s.State, state.SettingsC)
+	defer closer()
+	for _, key := range []string{"r#999#peer#foo/0", "a#gone#cs:quantal/gone-1"} {
+		err = settings.Insert(bson.M{
+			"_id":        state.DocID(s.State, key),
+			"model-uuid": s.State.ModelUUID(),
+			"settings":   bson.M{},
+			"version":    0,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	problems, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 4)
+	scopeKey := "r#" + relId + "#provider#mysql/0"
+	expect := []struct {
+		kind       state.IntegrityProblemKind
+		id         string
+		repairable bool
+	}{
+		{state.OrphanedRelationScope, scopeKey, true},
+		{state.LeakedSettings, "a#gone#cs:quantal/gone-1", true},
+		{state.LeakedSettings, "r#999#peer#foo/0", true},
+		{state.UnitMissingMachine, "wordpress/0", false},
+	}
+	for i, problem := range problems {
+		c.Check(problem.Kind, gc.Equals, expect[i].kind)
+		c.Check(problem.Id, gc.Equals, expect[i].id)
+		c.Check(problem.Repairable(), gc.Equals, expect[i].repairable)
+	}
+	c.Check(problems[3].Message, gc.Equals,
+		`unit "wordpress/0" is assigned to machine "0", which does not exist`)
+
+	repaired, err := s.State.RepairIntegrity(problems)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.Equals, 3)
+
+	problems, err = s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0].Kind, gc.Equals, state.UnitMissingMachine)
+
+	// Repairing again does nothing.
+	repaired, err = s.State.RepairIntegrity(problems)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.Equals, 0)
+}
+
+func (s *IntegritySuite) TestRepairIntegritySkipsFixedProblems(c *gc.C) {
+	settings, closer := state.GetRawCollection(s.State, state.SettingsC)
+	defer closer()
+	err := settings.Insert(bson.M{
+		"_id":        state.DocID(s.State, "a#mysql#cs:quantal/mysql-1"),
+		"model-uuid": s.State.ModelUUID(),
+		"settings":   bson.M{},
+		"version":    0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	problems, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+
+	// Once the application exists, the settings are no longer leaked.
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	repaired, err := s.State.RepairIntegrity(problems)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.Equals, 0)
+}
+
+// addRelatedUnits adds a wordpress unit on machine 0 and a mysql unit
+// in scope of a relation between them, and returns the relation id.
+func (s *IntegritySuite) addRelatedUnits(c *gc.C) string {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Id(), gc.Equals, "0")
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return strconv.Itoa(rel.Id())
+}
@@ -55,6 +55,10 @@ type linkLayerDeviceDoc struct {
 	// is inside a container, in which case ParentName can be a global key of a
 	// BridgeDevice on the host machine of the container.
 	ParentName string `bson:"parent-name"`
+
+	// VLANTag is the 802.1q VLAN tag of a VLAN_8021QDevice, and 0 for all
+	// other device types.
+	VLANTag int `bson:"vlan-tag,omitempty"`
 }
 
 // LinkLayerDeviceType defines the type of a link-layer network device.
@@ -181,6 +185,20 @@ func (dev *LinkLayerDevice) ParentName() string {
 	return dev.doc.ParentName
 }
 
+// ParentDeviceName returns the name of this device's parent device, if set.
+// Unlike ParentName(), it never returns a global key, even when the parent is
+// a BridgeDevice on the host machine of a container.
+func (dev *LinkLayerDevice) ParentDeviceName() string {
+	parentDeviceName, _ := dev.parentDeviceNameAndMachineID()
+	return parentDeviceName
+}
+
+// VLANTag returns the 802.1q VLAN tag of a VLAN_8021QDevice, or 0 for other
+// device types or when the tag is not known.
+func (dev *LinkLayerDevice) VLANTag() int {
+	return dev.doc.VLANTag
+}
+
 func (dev *LinkLayerDevice) parentDeviceNameAndMachineID() (string, string) {
 	if dev.doc.ParentName == "" {
 		// No parent set, so no ID and name to return.
@@ -381,6 +399,9 @@ func updateLinkLayerDeviceDocOp(existingDoc, newDoc *linkLayerDeviceDoc) (txn.Op
 	if existingDoc.ParentName != newDoc.ParentName {
 		changes["parent-name"] = newDoc.ParentName
 	}
+	if existingDoc.VLANTag != newDoc.VLANTag {
+		changes["vlan-tag"] = newDoc.VLANTag
+	}
 
 	var updates bson.D
 	if len(changes) > 0 {
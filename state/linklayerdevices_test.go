@@ -139,6 +139,62 @@ func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesInvalidMACAddress(c
 	s.assertSetLinkLayerDevicesReturnsNotValidError(c, args, `MACAddress "bad mac" not valid`)
 }
 
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesVLANTagOnNonVLANDevice(c *gc.C) {
+	args := state.LinkLayerDeviceArgs{
+		Name:    "eth0",
+		Type:    state.EthernetDevice,
+		VLANTag: 42,
+	}
+	s.assertSetLinkLayerDevicesReturnsNotValidError(c, args, `VLANTag 42 for "ethernet" device not valid`)
+}
+
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesVLANTagOutOfRange(c *gc.C) {
+	args := state.LinkLayerDeviceArgs{
+		Name:    "eth0.5000",
+		Type:    state.VLAN_8021QDevice,
+		VLANTag: 5000,
+	}
+	s.assertSetLinkLayerDevicesReturnsNotValidError(c, args, `VLANTag 5000 \(must be between 1 and 4094\) not valid`)
+}
+
+func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesBondAndVLANTopology(c *gc.C) {
+	s.setMultipleDevicesSucceedsAndCheckAllAdded(c, []state.LinkLayerDeviceArgs{{
+		Name: "bond0",
+		Type: state.BondDevice,
+	}})
+	s.setMultipleDevicesSucceedsAndCheckAllAdded(c, []state.LinkLayerDeviceArgs{{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		ParentName: "bond0",
+	}, {
+		Name:       "eth1",
+		Type:       state.EthernetDevice,
+		ParentName: "bond0",
+	}, {
+		Name:       "bond0.42",
+		Type:       state.VLAN_8021QDevice,
+		ParentName: "bond0",
+		VLANTag:    42,
+	}})
+
+	// Updating the tag is possible.
+	args := state.LinkLayerDeviceArgs{
+		Name:       "bond0.42",
+		Type:       state.VLAN_8021QDevice,
+		ParentName: "bond0",
+		VLANTag:    43,
+	}
+	vlan := s.assertSetLinkLayerDevicesSucceedsAndResultMatchesArgs(c, args)
+	c.Check(vlan.ParentDeviceName(), gc.Equals, "bond0")
+
+	bond, err := s.machine.LinkLayerDevice("bond0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = bond.Remove()
+	c.Assert(err, gc.ErrorMatches,
+		`cannot remove bond device "bond0" on machine "0": parent device "bond0" has 3 children`,
+	)
+}
+
 func (s *linkLayerDevicesStateSuite) TestSetLinkLayerDevicesWhenMachineNotAliveOrGone(c *gc.C) {
 	err := s.machine.EnsureDead()
 	c.Assert(err, jc.ErrorIsNil)
@@ -210,6 +266,7 @@ func (s *linkLayerDevicesStateSuite) checkSetDeviceMatchesArgs(c *gc.C, setDevic
 	c.Check(setDevice.IsAutoStart(), gc.Equals, args.IsAutoStart)
 	c.Check(setDevice.IsUp(), gc.Equals, args.IsUp)
 	c.Check(setDevice.ParentName(), gc.Equals, args.ParentName)
+	c.Check(setDevice.VLANTag(), gc.Equals, args.VLANTag)
 }
 
 func (s *linkLayerDevicesStateSuite) checkSetDeviceMatchesMachineIDAndModelUUID(c *gc.C, setDevice *state.LinkLayerDevice, machineID, modelUUID string) {
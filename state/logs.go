@@ -60,12 +60,14 @@ type ModelSessioner interface {
 }
 
 // logIndexes defines the indexes we need on the log collection.
-var logIndexes = [][]string{
+var logIndexes = []mgo.Index{
 	// This index needs to include _id because
 	// logTailer.processCollection uses _id to ensure log records with
 	// the same time have a consistent ordering.
-	{"t", "_id"},
-	{"n"},
+	{Key: []string{"t", "_id"}},
+	{Key: []string{"n"}},
+	// Only records marked with a trace ID have a correlation ID.
+	{Key: []string{"i"}, Sparse: true},
 }
 
 func logCollectionName(modelUUID string) string {
@@ -75,9 +77,9 @@ func logCollectionName(modelUUID string) string {
 // InitDbLogs sets up the indexes for the logs collection. It should
 // be called as state is opened. It is idempotent.
 func InitDbLogs(session *mgo.Session, modelUUID string) error {
-	logsColl := session.DB(logsDB).C(logCollectionName(modelUUID))
-	for _, key := range logIndexes {
-		err := logsColl.EnsureIndex(mgo.Index{Key: key})
+	logsColl := modelLogsDB(session, modelUUID).C(logCollectionName(modelUUID))
+	for _, index := range logIndexes {
+		err := logsColl.EnsureIndex(index)
 		if err != nil {
 			return errors.Annotate(err, "cannot create index for logs collection")
 		}
@@ -201,6 +203,11 @@ type logDoc struct {
 	Location string        `bson:"l"` // "filename:lineno"
 	Level    int           `bson:"v"`
 	Message  string        `bson:"x"`
+
+	// Labels and CorrelationID are only stored when set, so that
+	// unlabelled records are no larger than before.
+	Labels        []string `bson:"c,omitempty"`
+	CorrelationID string   `bson:"i,omitempty"`
 }
 
 type DbLogger struct {
@@ -248,6 +255,9 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+
+			Labels:        r.Labels,
+			CorrelationID: r.CorrelationID,
 		})
 	}
 	_, err := bulk.Run()
@@ -307,13 +317,23 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+
+	// structured fields
+	Labels        []string
+	CorrelationID string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
+//
+// If EndTime is set, only records logged before it are returned, and
+// the LogTailer stops once the recorded logs have been returned, as
+// if NoTail were set. IncludeTrace matches records with any of the
+// given correlation IDs.
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -321,6 +341,9 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
+	IncludeLabel  []string
+	ExcludeLabel  []string
+	IncludeTrace  []string
 	Oplog         *mgo.Collection // For testing only
 }
 
@@ -341,9 +364,12 @@ const oplogOverlap = time.Minute
 // output of large broken models with logging at DEBUG.
 var maxRecentLogIds = int(oplogOverlap.Minutes() * 150000)
 
-// maxInitialLines limits the number of documents we will load into memory
-// so that we can iterate them in the correct order.
-var maxInitialLines = 10000
+// MaxInitialLogLines limits the number of documents we will load
+// into memory so that we can iterate them in the correct order.
+const MaxInitialLogLines = 10000
+
+// maxInitialLines is MaxInitialLogLines, as a variable for testing.
+var maxInitialLines = MaxInitialLogLines
 
 // LogTailerState describes the methods on State required for logging to
 // the database.
@@ -357,11 +383,14 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	if err := params.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
 		session:         session,
-		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
+		logsColl:        modelLogsDB(session, st.ModelUUID()).C(logCollectionName(st.ModelUUID())),
 		params:          params,
 		logCh:           make(chan *LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
@@ -377,6 +406,18 @@ func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error)
 	return t, nil
 }
 
+// validate returns an error if the params cannot be satisfied.
+func (params LogTailerParams) validate() error {
+	if params.InitialLines > maxInitialLines {
+		return errors.NotValidf("%d initial lines (maximum is %d)", params.InitialLines, maxInitialLines)
+	}
+	if !params.EndTime.IsZero() && params.EndTime.Before(params.StartTime) {
+		return errors.NotValidf("end time %s before start time %s",
+			params.EndTime.Format(time.RFC3339), params.StartTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
 type logTailer struct {
 	tomb            tomb.Tomb
 	modelUUID       string
@@ -420,7 +461,7 @@ func (t *logTailer) loop() error {
 		return err
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -439,7 +480,7 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 	// (a) makes the most sense for me :)
 	if t.params.InitialLines > t.maxInitialLines {
 		return errors.Errorf("too many lines requested (%d) maximum is %d",
-			t.params.InitialLines, maxInitialLines)
+			t.params.InitialLines, t.maxInitialLines)
 	}
 	query.Sort("-t", "-_id")
 	query.Limit(t.params.InitialLines)
@@ -546,7 +587,7 @@ func (t *logTailer) tailOplog() error {
 	newParams := t.params
 	newParams.StartID = t.lastID // (t.lastID + 1) once Id is a sequential int.
 	oplogSel := append(t.paramsToSelector(newParams, "o."),
-		bson.DocElem{"ns", logsDB + "." + logCollectionName(t.modelUUID)},
+		bson.DocElem{"ns", t.logsColl.FullName},
 	)
 
 	oplog := t.params.Oplog
@@ -617,8 +658,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -639,6 +687,19 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	labels := bson.M{}
+	if len(params.IncludeLabel) > 0 {
+		labels["$in"] = params.IncludeLabel
+	}
+	if len(params.ExcludeLabel) > 0 {
+		labels["$nin"] = params.ExcludeLabel
+	}
+	if len(labels) > 0 {
+		sel = append(sel, bson.DocElem{"c", labels})
+	}
+	if len(params.IncludeTrace) > 0 {
+		sel = append(sel, bson.DocElem{"i", bson.M{"$in": params.IncludeTrace}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -745,6 +806,9 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+
+		Labels:        doc.Labels,
+		CorrelationID: doc.CorrelationID,
 	}
 	return rec, nil
 }
@@ -764,6 +828,20 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int) erro
 	if err != nil {
 		return errors.Annotate(err, "failed to get log counts")
 	}
+	// The logs of models with their own database are held there.
+	modelDBs, err := modelDatabaseNames(session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range modelDBs {
+		colls, err := getLogCollections(session.DB(name))
+		if err != nil {
+			return errors.Annotate(err, "failed to get log counts")
+		}
+		for modelUUID, coll := range colls {
+			logColls[modelUUID] = coll
+		}
+	}
 
 	pruneCounts := make(map[string]int)
 
@@ -848,7 +926,8 @@ func initLogsSessionDB(st MongoSessioner) (*mgo.Session, *mgo.Database) {
 // returning the session and a logs mgo.Collection connected to that
 // session.
 func initLogsSession(st ModelSessioner) (*mgo.Session, *mgo.Collection) {
-	session, db := initLogsSessionDB(st)
+	session, _ := initLogsSessionDB(st)
+	db := modelLogsDB(session, st.ModelUUID())
 	return session, db.C(logCollectionName(st.ModelUUID()))
 }
 
@@ -927,14 +1006,13 @@ func getRowCountForCollection(coll *mgo.Collection) (int, error) {
 }
 
 func removeModelLogs(session *mgo.Session, modelUUID string) error {
-	logsDB := session.DB(logsDB)
-	logsColl := logsDB.C(logCollectionName(modelUUID))
+	logsColl := modelLogsDB(session, modelUUID).C(logCollectionName(modelUUID))
 	if err := logsColl.DropCollection(); err != nil {
 		return errors.Trace(err)
 	}
 
 	// Also remove the tracked high-water times.
-	trackersColl := logsDB.C(forwardedC)
+	trackersColl := session.DB(logsDB).C(forwardedC)
 	_, err := trackersColl.RemoveAll(bson.M{"model-uuid": modelUUID})
 	return errors.Trace(err)
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -158,6 +159,9 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 		Location: "bar.go:42",
 		Level:    loggo.ERROR,
 		Message:  "oh noes",
+
+		Labels:        []string{"charm"},
+		CorrelationID: "abcd",
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -172,6 +176,10 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
 	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
 	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	_, ok := docs[0]["c"]
+	c.Assert(ok, jc.IsFalse)
+	_, ok = docs[0]["i"]
+	c.Assert(ok, jc.IsFalse)
 
 	c.Assert(docs[1]["t"], gc.Equals, t1.UnixNano())
 	c.Assert(docs[1]["n"], gc.Equals, "machine-47")
@@ -179,6 +187,8 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["c"], jc.DeepEquals, []interface{}{"charm"})
+	c.Assert(docs[1]["i"], gc.Equals, "abcd")
 }
 
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
@@ -386,6 +396,53 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	startT := coretesting.NonZeroTime()
+	endT := startT.Add(5 * time.Second)
+	s.writeLogsT(c, s.otherUUID, startT.Add(-5*time.Second), startT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, startT, endT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, s.otherUUID, endT, endT.Add(5*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: startT,
+		EndTime:   endT,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The tailer stops once the time range has been read, without
+	// tailing the oplog.
+	select {
+	case _, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
+func (s *LogTailerSuite) TestInvalidParams(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		InitialLines: state.MaxInitialLogLines + 1,
+	})
+	c.Assert(err, gc.ErrorMatches, "10001 initial lines \\(maximum is 10000\\) not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	startT := coretesting.NonZeroTime()
+	_, err = state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: startT,
+		EndTime:   startT.Add(-time.Second),
+	})
+	c.Assert(err, gc.ErrorMatches, "end time .* before start time .* not valid")
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -734,6 +791,63 @@ func (s *LogTailerSuite) TestIncludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeTrace(c *gc.C) {
+	other := logTemplate{Message: "other", CorrelationID: "00ff"}
+	traced := logTemplate{Message: "traced", CorrelationID: "abcd"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, traced)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		IncludeTrace: []string{"abcd"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, traced)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeTraceIgnoresMessage(c *gc.C) {
+	// Only the correlation ID is matched; a trace ID that merely
+	// appears in the message text is not.
+	unmarked := logTemplate{Message: `-> [1] user-admin {"request-id":2,"trace-id":"abcd"}`}
+	correlated := logTemplate{Message: "correlated", CorrelationID: "abcd"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, unmarked)
+		s.writeLogs(c, s.otherUUID, 1, correlated)
+		s.writeLogs(c, s.otherUUID, 1, unmarked)
+	}
+	params := state.LogTailerParams{
+		IncludeTrace: []string{"abcd"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, correlated)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeLabel(c *gc.C) {
+	unlabelled := logTemplate{Message: "unlabelled"}
+	charm := logTemplate{Message: "charm", Labels: []string{"charm"}}
+	api := logTemplate{Message: "api", Labels: []string{"api"}}
+	both := logTemplate{Message: "both", Labels: []string{"api", "charm"}}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, unlabelled)
+		s.writeLogs(c, s.otherUUID, 1, charm)
+		s.writeLogs(c, s.otherUUID, 1, api)
+		s.writeLogs(c, s.otherUUID, 1, both)
+	}
+	params := state.LogTailerParams{
+		IncludeLabel: []string{"charm"},
+		ExcludeLabel: []string{"api"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, charm)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestExcludeModule(c *gc.C) {
 	mod0 := logTemplate{Module: "foo.bar"}
 	mod1 := logTemplate{Module: "juju.thing"}
@@ -810,6 +924,9 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+
+	Labels        []string
+	CorrelationID string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -891,7 +1008,7 @@ func (s *LogTailerSuite) normaliseLogTemplate(lt *logTemplate) {
 
 func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface{} {
 	s.normaliseLogTemplate(&lt)
-	return state.MakeLogDoc(
+	doc := state.MakeLogDoc(
 		lt.Entity,
 		t,
 		lt.Module,
@@ -899,6 +1016,7 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Level,
 		lt.Message,
 	)
+	return state.SetLogDocStructuredFields(doc, lt.Labels, lt.CorrelationID)
 }
 
 func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedCount int, lt logTemplate) {
@@ -918,6 +1036,8 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
+			c.Assert(log.CorrelationID, gc.Equals, lt.CorrelationID)
 			count++
 			if count == expectedCount {
 				return
@@ -140,6 +140,14 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// HealthProblems holds the problems most recently reported by
+	// the machine agent's health checks, if any.
+	HealthProblems []string `bson:"health-problems,omitempty"`
+
+	// CharmProfiles holds the names of the charm LXD profiles that
+	// the provisioner has applied to the machine's instance.
+	CharmProfiles []string `bson:"charm-profiles,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -893,6 +901,8 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeWireGuardKeyOp(m.globalKey()),
+		removeAgentTokenOp(m.st, m.Tag()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -1431,16 +1441,21 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
+		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic) &&
+			(!preferIPv6 || addr.AddressType == string(network.IPv6Address))
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		selectAddress := network.SelectPublicAddress
+		if preferIPv6 {
+			selectAddress = network.SelectPublicAddressPreferringIPv6
+		}
+		addr, _ := selectAddress(networkAddresses(addresses))
 		return addr
 	}
 
@@ -1454,15 +1469,37 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, preferFan, preferIPv6 bool) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
+	if preferFan {
+		// Containers on a fan network reach each other over the fan,
+		// so its address wins over any other that is available.
+		origin := OriginProvider
+		netAddr, ok := network.SelectFanLocalAddress(networkAddresses(providerAddresses))
+		if !ok {
+			origin = OriginMachine
+			netAddr, ok = network.SelectFanLocalAddress(networkAddresses(machineAddresses))
+		}
+		if ok {
+			if netAddr.Value == privateAddress.Value {
+				return []txn.Op{}, nil
+			}
+			newAddr := fromNetworkAddress(netAddr, origin)
+			return m.setPreferredAddressOps(newAddr, false), &newAddr
+		}
+	}
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal)
+		return network.ExactScopeMatch(addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal) &&
+			(!preferIPv6 || addr.AddressType == string(network.IPv6Address))
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		selectAddress := network.SelectInternalAddress
+		if preferIPv6 {
+			selectAddress = network.SelectInternalAddressPreferringIPv6
+		}
+		addr, _ := selectAddress(networkAddresses(addresses), false)
 		return addr
 	}
 
@@ -1475,6 +1512,24 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	return ops, &newAddr
 }
 
+// addressPreferences reports how the machine's preferred addresses
+// are chosen. preferFan is true if the machine is a container in a
+// model using fan container networking, in which case its preferred
+// private address is its fan address. preferIPv6 is true if the model
+// prefers IPv6 addresses over IPv4 ones.
+func (m *Machine) addressPreferences() (preferFan, preferIPv6 bool, _ error) {
+	model, err := m.st.Model()
+	if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, false, errors.Trace(err)
+	}
+	preferFan = m.ContainerType() != "" && cfg.ContainerNetworkingMethod() == "fan"
+	return preferFan, cfg.PreferIPv6(), nil
+}
+
 // SetProviderAddresses records any addresses related to the machine, sourced
 // by asking the provider.
 func (m *Machine) SetProviderAddresses(addresses ...network.Address) error {
@@ -1592,8 +1647,12 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	preferFan, preferIPv6, err := m.addressPreferences()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, preferFan, preferIPv6)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
@@ -167,6 +167,10 @@ type LinkLayerDeviceArgs struct {
 	// key of a BridgeDevice on the host machine of the container. Traffic
 	// originating from a device egresses from its parent device.
 	ParentName string
+
+	// VLANTag is the 802.1q VLAN tag of a VLAN_8021QDevice, between 1 and
+	// 4094, or 0 when unknown. It must be 0 for all other device types.
+	VLANTag int
 }
 
 // SetLinkLayerDevices sets link-layer devices on the machine, adding or
@@ -316,6 +320,15 @@ func (m *Machine) validateSetLinkLayerDeviceArgs(args *LinkLayerDeviceArgs) erro
 		return errors.NotValidf("Type %q", args.Type)
 	}
 
+	if args.VLANTag != 0 {
+		if args.Type != VLAN_8021QDevice {
+			return errors.NotValidf("VLANTag %d for %q device", args.VLANTag, args.Type)
+		}
+		if args.VLANTag < 1 || args.VLANTag > 4094 {
+			return errors.NotValidf("VLANTag %d (must be between 1 and 4094)", args.VLANTag)
+		}
+	}
+
 	if args.MACAddress != "" {
 		if _, err := net.ParseMAC(args.MACAddress); err != nil {
 			return errors.NotValidf("MACAddress %q", args.MACAddress)
@@ -429,6 +442,7 @@ func (m *Machine) newLinkLayerDeviceDocFromArgs(args *LinkLayerDeviceArgs) *link
 		IsAutoStart: args.IsAutoStart,
 		IsUp:        args.IsUp,
 		ParentName:  args.ParentName,
+		VLANTag:     args.VLANTag,
 	}
 }
 
@@ -1141,18 +1155,19 @@ func addAddressToResult(networkInfos []network.NetworkInfo, address *Address) ([
 		}
 	}
 
-	MAC := ""
+	networkInfo := network.NetworkInfo{
+		InterfaceName: address.DeviceName(),
+		Addresses:     []network.InterfaceAddress{ifaceAddress},
+	}
 	device, err := address.Device()
 	if err == nil {
-		MAC = device.MACAddress()
+		networkInfo.MACAddress = device.MACAddress()
+		networkInfo.InterfaceType = network.InterfaceType(device.Type())
+		networkInfo.ParentInterfaceName = device.ParentDeviceName()
+		networkInfo.VLANTag = device.VLANTag()
 	} else if !errors.IsNotFound(err) {
 		return nil, err
 	}
-	networkInfo := network.NetworkInfo{
-		InterfaceName: address.DeviceName(),
-		MACAddress:    MAC,
-		Addresses:     []network.InterfaceAddress{ifaceAddress},
-	}
 	return append(networkInfos, networkInfo), nil
 }
 
@@ -1684,6 +1684,70 @@ func (s *MachineSuite) TestSetMachineAddresses(c *gc.C) {
 	c.Assert(machine.MachineAddresses(), jc.DeepEquals, expectedAddresses)
 }
 
+func (s *MachineSuite) TestContainerOnFanPrefersFanAddress(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"fan-config":                  "10.0.0.0/16=252.0.0.0/8",
+		"container-networking-method": "fan",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = container.SetProviderAddresses(network.NewAddress("10.0.0.5"))
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := container.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.5")
+
+	// A fan address wins even over a provider address.
+	err = container.SetMachineAddresses(network.NewAddresses("10.0.0.5", "252.0.5.1")...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = container.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "252.0.5.1")
+
+	// Host machines keep preferring cloud-local addresses.
+	err = s.machine.SetMachineAddresses(network.NewAddresses("10.0.0.4", "252.0.4.1")...)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = s.machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.4")
+}
+
+func (s *MachineSuite) TestPreferIPv6Addresses(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"prefer-ipv6": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.4", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := s.machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+
+	// IPv6 addresses win once the machine has them.
+	err = s.machine.SetProviderAddresses(
+		network.NewScopedAddress("10.0.0.4", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::4", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::4", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = s.machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "2001:db8::4")
+	addr, err = s.machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "fc00::4")
+}
+
 func (s *MachineSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// healthCheckStatusKey is the status data key which marks a machine
+// status as having been set because of health problems.
+const healthCheckStatusKey = "health-check"
+
+// HealthProblems returns the problems most recently reported by the
+// machine agent's health checks, such as a full disk or a skewed
+// clock. An empty result means the machine is healthy.
+func (m *Machine) HealthProblems() []string {
+	return m.doc.HealthProblems
+}
+
+// SetHealthProblems records the problems found by the machine agent's
+// health checks, replacing any previously reported. While there are
+// problems, the machine's status is error, with the problems as its
+// message; once they are resolved the machine is started again. A
+// machine whose status was set for some other reason is left alone.
+func (m *Machine) SetHealthProblems(problems []string) error {
+	if len(problems) == 0 {
+		problems = nil
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, ErrDead
+		}
+		if sameStrings(m.doc.HealthProblems, problems) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$unset", bson.D{{"health-problems", nil}}}}
+		if len(problems) > 0 {
+			update = bson.D{{"$set", bson.D{{"health-problems", problems}}}}
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set health problems of machine %s", m)
+	}
+	m.doc.HealthProblems = problems
+	return errors.Trace(m.updateHealthStatus(problems))
+}
+
+// updateHealthStatus sets the machine's status to reflect the given
+// health problems, unless its status was set for some other reason.
+func (m *Machine) updateHealthStatus(problems []string) error {
+	current, err := m.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	setByHealthCheck, _ := current.Data[healthCheckStatusKey].(bool)
+	switch {
+	case len(problems) > 0 && (current.Status == status.Started || setByHealthCheck):
+		message := "unhealthy: " + strings.Join(problems, "; ")
+		if setByHealthCheck && current.Message == message {
+			return nil
+		}
+		return m.SetStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: message,
+			Data:    map[string]interface{}{healthCheckStatusKey: true},
+		})
+	case len(problems) == 0 && setByHealthCheck:
+		return m.SetStatus(status.StatusInfo{Status: status.Started})
+	}
+	return nil
+}
+
+// checkMachineHealthy returns an error if the machine has reported
+// health problems and the model does not allow units to be assigned
+// to unhealthy machines. It also returns any assertion required to
+// ensure that the machine's health does not change concurrently.
+func checkMachineHealthy(st *State, m *Machine) (bson.D, error) {
+	block, err := st.blockUnhealthyMachines()
+	if err != nil || !block {
+		return nil, errors.Trace(err)
+	}
+	if len(m.doc.HealthProblems) > 0 {
+		return nil, errors.Errorf("machine %s is unhealthy: %s", m, strings.Join(m.doc.HealthProblems, "; "))
+	}
+	return healthyMachineTerm(), nil
+}
+
+// healthyMachineTerm returns a query term matching machines which have
+// no health problems.
+func healthyMachineTerm() bson.D {
+	return bson.D{{"health-problems", bson.D{{"$exists", false}}}}
+}
+
+// blockUnhealthyMachines reports whether the model's config prevents
+// units being assigned to unhealthy machines.
+func (st *State) blockUnhealthyMachines() (bool, error) {
+	model, err := st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cfg.BlockUnhealthyMachines(), nil
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
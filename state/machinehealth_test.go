@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type MachineHealthSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineHealthSuite{})
+
+func (s *MachineHealthSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	err := s.machine.SetStatus(status.StatusInfo{Status: status.Started})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineHealthSuite) addUnit(c *gc.C) *state.Unit {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *MachineHealthSuite) assertStatus(c *gc.C, expected status.Status, message string) {
+	info, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, expected)
+	c.Assert(info.Message, gc.Equals, message)
+}
+
+func (s *MachineHealthSuite) TestSetHealthProblems(c *gc.C) {
+	c.Assert(s.machine.HealthProblems(), gc.HasLen, 0)
+
+	problems := []string{"root disk 95% full", "clock skewed by 2m0s from controller"}
+	err := s.machine.SetHealthProblems(problems)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Error, "unhealthy: root disk 95% full; clock skewed by 2m0s from controller")
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.HealthProblems(), jc.DeepEquals, problems)
+}
+
+func (s *MachineHealthSuite) TestClearHealthProblems(c *gc.C) {
+	err := s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetHealthProblems(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Started, "")
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.HealthProblems(), gc.HasLen, 0)
+}
+
+func (s *MachineHealthSuite) TestUpdateHealthProblems(c *gc.C) {
+	err := s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetHealthProblems([]string{"root disk 99% full"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Error, "unhealthy: root disk 99% full")
+}
+
+func (s *MachineHealthSuite) TestHealthProblemsLeaveOtherStatusAlone(c *gc.C) {
+	err := s.machine.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "provisioning failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Error, "provisioning failed")
+
+	err = s.machine.SetHealthProblems(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Error, "provisioning failed")
+}
+
+func (s *MachineHealthSuite) TestSetHealthProblemsDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, gc.ErrorMatches, `cannot set health problems of machine 0: not found or dead`)
+}
+
+func (s *MachineHealthSuite) TestAssignToUnhealthyMachineAllowedByDefault(c *gc.C) {
+	err := s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit := s.addUnit(c)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineHealthSuite) TestAssignToUnhealthyMachineBlocked(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"block-unhealthy-machines": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetHealthProblems([]string{"root disk 95% full"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit := s.addUnit(c)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/0" to machine 0: machine 0 is unhealthy: root disk 95% full`)
+
+	_, err = unit.AssignToCleanMachine()
+	c.Assert(err, gc.ErrorMatches, `.*all eligible machines in use`)
+
+	err = s.machine.SetHealthProblems(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, s.machine.Id())
+}
@@ -1290,12 +1290,22 @@ func (e *exporter) readAllConstraints() error {
 // getAnnotations doesn't really care if there are any there or not
 // for the key, but if they were there, they are removed so we can
 // check at the end of the export for anything we have forgotten.
+// The model description only holds string annotations, so structured
+// annotations are exported encoded as JSON.
 func (e *exporter) getAnnotations(key string) map[string]string {
 	result, found := e.annotations[key]
-	if found {
-		delete(e.annotations, key)
+	if !found {
+		return nil
+	}
+	delete(e.annotations, key)
+	annotations, err := result.flatAnnotations()
+	if err != nil {
+		// Structured values are validated when they are set, so
+		// this should never happen.
+		e.logger.Errorf("cannot export annotations for %s: %v", result.Tag, err)
+		return result.Annotations
 	}
-	return result.Annotations
+	return annotations
 }
 
 func (e *exporter) readAllSettings() error {
@@ -104,6 +104,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// Controller metrics describe the source controller.
+		controllerMetricsC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -157,6 +159,19 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Action artifacts are not yet migrated; their content
+		// is held in the source model's blob store.
+		actionArtifactsC,
+
+		// Action schedules are not yet migrated; they need to be
+		// recreated in the target model.
+		actionSchedulesC,
+
+		// Agent tokens are not migrated; agents authenticate to the
+		// target controller with their current token as a password,
+		// and are issued new tokens there.
+		agentTokensC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -171,6 +186,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// migrate that information.
 		rebootC,
 
+		// Pending coordinated reboot requests are not migrated either;
+		// units must request them again in the target model.
+		rebootRequestsC,
+
+		// A staged agent upgrade must be completed, or ended, before
+		// a model is migrated.
+		stagedUpgradesC,
+
 		// Charms are added into the migrated model during the binary transfer
 		// phase after the initial model migration.
 		charmsC,
@@ -183,6 +206,35 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// independent global clock.
 		globalClockC,
 
+		// The settings history is not migrated; the target model
+		// starts recording changes afresh.
+		settingsHistoryC,
+
+		// Status snapshots are not migrated; the target model
+		// starts taking snapshots afresh.
+		statusSnapshotsC,
+
+		// Webhooks are controller global, and not migrated.
+		webhooksC,
+
+		// API tokens are controller global, and not migrated.
+		apiTokensC,
+
+		// Model archives are controller global, and not migrated.
+		modelArchivesC,
+
+		// Engine reports describe the agents running against the
+		// source controller; agents send new ones after migration.
+		engineReportsC,
+
+		// WireGuard meshes only span models on the same controller;
+		// machines are given new keys by the target controller.
+		wireGuardKeysC,
+
+		// Available charm upgrades are recomputed by the charm
+		// revision updater running in the target controller.
+		charmUpgradesC,
+
 		// Leases are not migrated either. When an application is migrated,
 		// we include the name of the leader unit. On import, a new lease
 		// is created for the leader unit.
@@ -241,6 +293,12 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"SLA",
 		"MeterStatus",
 		"EnvironVersion",
+		// Database is local to the controller; the target
+		// controller places the imported model in its own database.
+		"Database",
+		// Quotas are set by the administrators of the source
+		// controller, and are not carried over to the target.
+		"Quotas",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -303,6 +361,12 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Health problems are reported again by the machine agent
+		// once it connects to the new controller.
+		"HealthProblems",
+		// Charm profiles are applied again by the provisioner of
+		// the new controller.
+		"CharmProfiles",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -368,6 +432,15 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// The hook retry policy is not yet migrated; the target
+		// application falls back to the model's retry behaviour.
+		"HookRetry",
+		// Charm pinning is not yet migrated; the target controller
+		// may offer upgrades for an application pinned in the source.
+		"CharmPinned",
+		// Proxy overrides are not yet migrated; the target
+		// application's hooks see the model's proxy settings.
+		"ProxyOverrides",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -397,6 +470,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// A move to another machine that has not completed is
+		// abandoned by migration.
+		"MovingTo",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -495,6 +571,8 @@ func (s *MigrationSuite) TestAnnatatorDocFields(c *gc.C) {
 		"GlobalKey",
 		"Tag",
 		"Annotations",
+		// Structured annotations are exported encoded as JSON.
+		"Structured",
 	)
 	s.AssertExportedFields(c, annotatorDoc{}, fields)
 }
@@ -654,6 +732,8 @@ func (s *MigrationSuite) TestLinkLayerDeviceDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
 		"DocID",
+		// VLANTag is rediscovered by the machiner after migration.
+		"VLANTag",
 	)
 	migrated := set.NewStrings(
 		"MachineID",
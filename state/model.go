@@ -113,6 +113,14 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// Quotas holds the limits on the resources the model may use.
+	Quotas modelQuotasDoc `bson:"quotas,omitempty"`
+
+	// Database is the name of the database holding the model's
+	// modelDatabase collections. It is empty if they are held in the
+	// controller's database.
+	Database string `bson:"database,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -279,6 +287,13 @@ type ModelArgs struct {
 
 	// EnvironVersion is the initial version of the Environ for the model.
 	EnvironVersion int
+
+	// Database is the name of the mongo database in which to place the
+	// model's logs, status history, status snapshots, engine reports and
+	// user connection times, to isolate a heavyweight model from the
+	// controller's database. If it is empty, the controller's databases
+	// are used.
+	Database string
 }
 
 // Validate validates the ModelArgs.
@@ -312,6 +327,9 @@ func (m ModelArgs) Validate() error {
 	default:
 		return errors.NotValidf("initial migration mode %q", m.MigrationMode)
 	}
+	if m.Database != "" && !IsValidModelDatabase(m.Database) {
+		return errors.NotValidf("database name %q", m.Database)
+	}
 	return nil
 }
 
@@ -400,6 +418,12 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
 
+	if args.Database != "" {
+		if err := createModelDatabase(session, args.Database); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+
 	modelOps, modelStatusDoc, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "failed to create new model")
@@ -789,6 +813,13 @@ func (m *Model) EnvironVersion() int {
 	return m.doc.EnvironVersion
 }
 
+// Database returns the name of the mongo database holding the model's
+// logs and non-transactional collections, or "" if they are held in
+// the controller's databases.
+func (m *Model) Database() string {
+	return m.doc.Database
+}
+
 // SetEnvironVersion sets the model's current environ version. The value
 // must be monotonically increasing.
 func (m *Model) SetEnvironVersion(v int) error {
@@ -1490,6 +1521,7 @@ func createModelOp(
 	cloudCredential names.CloudCredentialTag,
 	migrationMode MigrationMode,
 	environVersion int,
+	database string,
 ) txn.Op {
 	doc := &modelDoc{
 		Type:            modelType,
@@ -1503,6 +1535,7 @@ func createModelOp(
 		Cloud:           cloudName,
 		CloudRegion:     cloudRegion,
 		CloudCredential: cloudCredential.Id(),
+		Database:        database,
 	}
 	return txn.Op{
 		C:      modelsC,
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// ModelArchive describes a signed archive of a model, held in the
+// controller's blob store. Archives are kept after their model is
+// destroyed, so that the model can later be imported again.
+type ModelArchive struct {
+	// ID uniquely identifies the archive in the controller.
+	ID string
+
+	// ModelUUID and ModelName identify the archived model.
+	ModelUUID string
+	ModelName string
+
+	// Size is the size of the archive in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA-256 hash of the archive.
+	SHA256 string
+
+	// Created is the time the archive was stored.
+	Created time.Time
+}
+
+type modelArchiveDoc struct {
+	ID          string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	ModelName   string    `bson:"model-name"`
+	Size        int64     `bson:"size"`
+	SHA256      string    `bson:"sha256"`
+	StoragePath string    `bson:"storage-path"`
+	Created     time.Time `bson:"created"`
+}
+
+func (doc modelArchiveDoc) archive() ModelArchive {
+	return ModelArchive{
+		ID:        doc.ID,
+		ModelUUID: doc.ModelUUID,
+		ModelName: doc.ModelName,
+		Size:      doc.Size,
+		SHA256:    doc.SHA256,
+		Created:   doc.Created,
+	}
+}
+
+// modelArchiveStorage returns the blob store in which model archives
+// are held. It is namespaced to the controller rather than the model,
+// so that archives outlive their models.
+func (st *State) modelArchiveStorage() storage.Storage {
+	return storage.NewStorage(st.ControllerUUID(), st.MongoSession())
+}
+
+// AddModelArchive stores the model archive of the given size read from
+// r, and records that it holds the model with the given UUID and name.
+func (st *State) AddModelArchive(modelUUID, modelName string, r io.Reader, size int64) (_ ModelArchive, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add archive of model %q", modelName)
+	id, err := utils.NewUUID()
+	if err != nil {
+		return ModelArchive{}, errors.Trace(err)
+	}
+	storagePath := "modelarchives/" + id.String()
+	stor := st.modelArchiveStorage()
+	hash := sha256.New()
+	if err := stor.Put(storagePath, io.TeeReader(r, hash), size); err != nil {
+		return ModelArchive{}, errors.Annotate(err, "storing archive")
+	}
+	doc := modelArchiveDoc{
+		ID:          id.String(),
+		ModelUUID:   modelUUID,
+		ModelName:   modelName,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		StoragePath: storagePath,
+		Created:     st.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      modelArchivesC,
+		Id:     doc.ID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if removeErr := stor.Remove(storagePath); removeErr != nil {
+			logger.Warningf("cannot remove model archive %q: %v", storagePath, removeErr)
+		}
+		return ModelArchive{}, errors.Trace(err)
+	}
+	return doc.archive(), nil
+}
+
+// ModelArchives returns the archives of the model with the given UUID,
+// or of all models if the UUID is empty, oldest first.
+func (st *State) ModelArchives(modelUUID string) ([]ModelArchive, error) {
+	coll, closer := st.db().GetCollection(modelArchivesC)
+	defer closer()
+
+	query := bson.D{}
+	if modelUUID != "" {
+		query = bson.D{{"model-uuid", modelUUID}}
+	}
+	var docs []modelArchiveDoc
+	if err := coll.Find(query).Sort("created", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read model archives")
+	}
+	archives := make([]ModelArchive, len(docs))
+	for i, doc := range docs {
+		archives[i] = doc.archive()
+	}
+	return archives, nil
+}
+
+// OpenModelArchive returns the description and content of the model
+// archive with the given ID. The caller must close the reader.
+func (st *State) OpenModelArchive(id string) (ModelArchive, io.ReadCloser, error) {
+	coll, closer := st.db().GetCollection(modelArchivesC)
+	defer closer()
+
+	var doc modelArchiveDoc
+	if err := coll.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return ModelArchive{}, nil, errors.NotFoundf("model archive %q", id)
+	} else if err != nil {
+		return ModelArchive{}, nil, errors.Annotatef(err, "cannot read model archive %q", id)
+	}
+	r, _, err := st.modelArchiveStorage().Get(doc.StoragePath)
+	if err != nil {
+		return ModelArchive{}, nil, errors.Annotatef(err, "cannot open model archive %q", id)
+	}
+	return doc.archive(), r, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ModelArchivesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelArchivesSuite{})
+
+func (s *ModelArchivesSuite) TestAddModelArchive(c *gc.C) {
+	content := "archived model"
+	archive, err := s.State.AddModelArchive(s.State.ModelUUID(), "testenv", strings.NewReader(content), int64(len(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.ID, gc.Not(gc.Equals), "")
+	c.Check(archive.ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(archive.ModelName, gc.Equals, "testenv")
+	c.Check(archive.Size, gc.Equals, int64(len(content)))
+	hash := sha256.Sum256([]byte(content))
+	c.Check(archive.SHA256, gc.Equals, hex.EncodeToString(hash[:]))
+	c.Check(archive.Created.IsZero(), jc.IsFalse)
+
+	opened, r, err := s.State.OpenModelArchive(archive.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Check(opened, jc.DeepEquals, archive)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, content)
+}
+
+func (s *ModelArchivesSuite) TestModelArchives(c *gc.C) {
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+
+	first, err := s.State.AddModelArchive(s.State.ModelUUID(), "testenv", strings.NewReader("one"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.State.AddModelArchive(other.ModelUUID(), "other", strings.NewReader("two"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archives, err := s.State.ModelArchives(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archives, gc.HasLen, 1)
+	c.Check(archives[0].ID, gc.Equals, first.ID)
+
+	archives, err = other.ModelArchives("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 2)
+	ids := []string{archives[0].ID, archives[1].ID}
+	c.Check(ids, jc.SameContents, []string{first.ID, second.ID})
+}
+
+func (s *ModelArchivesSuite) TestOpenModelArchiveNotFound(c *gc.C) {
+	_, _, err := s.State.OpenModelArchive("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `model archive "missing" not found`)
+}
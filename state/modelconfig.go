@@ -343,8 +343,24 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 	validAttrs = config.CoerceForStorage(validAttrs)
 
 	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	_, ops, err := modelSettings.versionedUpdateOps(modelGlobalKey, "", st.clock().Now())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := modelSettings.write(ops); errors.IsNotFound(err) {
+		// The settings were read above, so if the version
+		// assertion failed they were changed concurrently.
+		return errors.New("cannot write model config: concurrent settings change detected")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ConfigHistory returns the recorded changes to the model's config,
+// oldest first. Only the most recent changes are kept.
+func (m *Model) ConfigHistory() ([]SettingsChange, error) {
+	return readSettingsHistory(m.st.db(), modelGlobalKey)
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
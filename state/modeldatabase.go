@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// validModelDatabase matches the names of the databases that may hold
+// a model's modelDatabase collections. The "juju-" prefix keeps them
+// apart from the databases the controller itself uses.
+var validModelDatabase = regexp.MustCompile(`^juju-[a-z0-9][a-z0-9-]{0,58}$`)
+
+// IsValidModelDatabase reports whether the given name may be used as
+// the database for a model's modelDatabase collections.
+func IsValidModelDatabase(name string) bool {
+	return validModelDatabase.MatchString(name)
+}
+
+// modelDatabases caches the names of the databases selected for the
+// modelDatabase collections of models. A model's database is chosen
+// when the model is created and never changes, so cached names never
+// need to be invalidated.
+type modelDatabases struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func newModelDatabases() *modelDatabases {
+	return &modelDatabases{names: make(map[string]string)}
+}
+
+// name returns the name of the database selected for the model with
+// the given UUID, or "" if the model uses the controller's database.
+// Models that do not exist yet use the controller's database.
+func (m *modelDatabases) name(db *mgo.Database, modelUUID string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if name, ok := m.names[modelUUID]; ok {
+		return name
+	}
+	name, err := readModelDatabase(db, modelUUID)
+	if errors.IsNotFound(err) {
+		return ""
+	} else if err != nil {
+		logger.Errorf("%v", err)
+		return ""
+	}
+	m.names[modelUUID] = name
+	return name
+}
+
+// readModelDatabase returns the name of the database selected for the
+// model with the given UUID, read from the models collection in db.
+func readModelDatabase(db *mgo.Database, modelUUID string) (string, error) {
+	var doc struct {
+		Database string `bson:"database"`
+	}
+	err := db.C(modelsC).FindId(modelUUID).Select(bson.D{{"database", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("model %q", modelUUID)
+	} else if err != nil {
+		return "", errors.Annotatef(err, "cannot read database of model %q", modelUUID)
+	}
+	return doc.Database, nil
+}
+
+// modelLogsDB returns the database holding the log collection of the
+// model with the given UUID: the model's own database if it has one,
+// or the logs database.
+func modelLogsDB(session *mgo.Session, modelUUID string) *mgo.Database {
+	name, err := readModelDatabase(session.DB(jujuDB), modelUUID)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Errorf("%v", err)
+	}
+	if name == "" {
+		return session.DB(logsDB)
+	}
+	return session.DB(name)
+}
+
+// modelDatabaseNames returns the names of the databases selected for
+// models when they were created.
+func modelDatabaseNames(session *mgo.Session) ([]string, error) {
+	var names []string
+	models := session.DB(jujuDB).C(modelsC)
+	err := models.Find(bson.D{{"database", bson.D{{"$exists", true}}}}).Distinct("database", &names)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model databases")
+	}
+	return names, nil
+}
+
+// modelDatabaseSchema returns the part of the schema that is placed in
+// a model's own database, if it has one.
+func modelDatabaseSchema(schema collectionSchema) collectionSchema {
+	result := make(collectionSchema)
+	for name, info := range schema {
+		if info.modelDatabase {
+			result[name] = info
+		}
+	}
+	return result
+}
+
+// createModelDatabase creates and indexes the modelDatabase collections
+// in the named database.
+func createModelDatabase(session *mgo.Session, name string) error {
+	schema := modelDatabaseSchema(allCollections())
+	if err := schema.Create(session.DB(name), nil); err != nil {
+		return errors.Annotatef(err, "creating model database %q", name)
+	}
+	return nil
+}
+
+// removeModelDatabase drops the named database, which held the
+// modelDatabase collections of a removed model, unless another model
+// still uses it.
+func (st *State) removeModelDatabase(name string) error {
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	count, err := models.Find(bson.D{{"database", name}}).Count()
+	if err != nil {
+		return errors.Annotatef(err, "counting models using database %q", name)
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := st.session.DB(name).DropDatabase(); err != nil {
+		return errors.Annotatef(err, "dropping model database %q", name)
+	}
+	return nil
+}
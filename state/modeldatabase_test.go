@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+)
+
+type ModelDatabaseSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelDatabaseSuite{})
+
+func (s *ModelDatabaseSuite) newModel(c *gc.C, database string) (*state.Model, *state.State) {
+	cfg, _ := createTestModelConfig(c, s.State.ControllerUUID())
+	model, st, err := s.State.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   names.NewUserTag("test@remote"),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+		Database:                database,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return model, st
+}
+
+func (s *ModelDatabaseSuite) count(c *gc.C, database, collection, modelUUID string) int {
+	coll := s.State.MongoSession().DB(database).C(collection)
+	count, err := coll.Find(map[string]string{"model-uuid": modelUUID}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return count
+}
+
+func (s *ModelDatabaseSuite) logCount(c *gc.C, database, modelUUID string) int {
+	count, err := s.State.MongoSession().DB(database).C("logs." + modelUUID).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return count
+}
+
+func (s *ModelDatabaseSuite) writeLog(c *gc.C, st *state.State, t time.Time) {
+	logger := state.NewDbLogger(st)
+	defer logger.Close()
+	err := logger.Log([]state.LogRecord{{
+		Time:     t,
+		Entity:   names.NewMachineTag("0"),
+		Module:   "some.where",
+		Location: "foo.go:99",
+		Level:    loggo.INFO,
+		Message:  "all is well",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelDatabaseSuite) TestInvalidDatabase(c *gc.C) {
+	cfg, _ := createTestModelConfig(c, s.State.ControllerUUID())
+	_, _, err := s.State.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   names.NewUserTag("test@remote"),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+		Database:                "admin",
+	})
+	c.Assert(err, gc.ErrorMatches, `database name "admin" not valid`)
+}
+
+func (s *ModelDatabaseSuite) TestDefaultDatabase(c *gc.C) {
+	model, st := s.newModel(c, "")
+	defer st.Close()
+	c.Assert(model.Database(), gc.Equals, "")
+
+	err := model.SetStatus(status.StatusInfo{Status: status.Busy})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.count(c, "juju", "statuseshistory", model.UUID()), gc.Not(gc.Equals), 0)
+}
+
+func (s *ModelDatabaseSuite) TestStatusHistoryInModelDatabase(c *gc.C) {
+	model, st := s.newModel(c, "juju-heavy")
+	defer st.Close()
+	c.Assert(model.Database(), gc.Equals, "juju-heavy")
+
+	err := model.SetStatus(status.StatusInfo{Status: status.Busy, Message: "working"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.count(c, "juju-heavy", "statuseshistory", model.UUID()), gc.Not(gc.Equals), 0)
+	c.Assert(s.count(c, "juju", "statuseshistory", model.UUID()), gc.Equals, 0)
+
+	history, err := model.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "working")
+}
+
+func (s *ModelDatabaseSuite) TestRemoveModelDropsDatabase(c *gc.C) {
+	model, st := s.newModel(c, "juju-heavy")
+	defer st.Close()
+	err := model.SetStatus(status.StatusInfo{Status: status.Busy})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = model.Destroy(state.DestroyModelParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.RemoveAllModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	databases, err := s.State.MongoSession().DatabaseNames()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range databases {
+		c.Check(name, gc.Not(gc.Equals), "juju-heavy")
+	}
+}
+
+func (s *ModelDatabaseSuite) TestStatusSnapshotsInModelDatabase(c *gc.C) {
+	model, st := s.newModel(c, "juju-heavy")
+	defer st.Close()
+
+	err := model.AddStatusSnapshot([]byte(`{"model":{}}`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.count(c, "juju-heavy", "statussnapshots", model.UUID()), gc.Equals, 1)
+	c.Assert(s.count(c, "juju", "statussnapshots", model.UUID()), gc.Equals, 0)
+}
+
+func (s *ModelDatabaseSuite) TestLogsInModelDatabase(c *gc.C) {
+	model, st := s.newModel(c, "juju-heavy")
+	defer st.Close()
+
+	s.writeLog(c, st, time.Now())
+	c.Assert(s.logCount(c, "juju-heavy", model.UUID()), gc.Equals, 1)
+	c.Assert(s.logCount(c, "logs", model.UUID()), gc.Equals, 0)
+}
+
+func (s *ModelDatabaseSuite) TestPruneLogsInModelDatabase(c *gc.C) {
+	model, st := s.newModel(c, "juju-heavy")
+	defer st.Close()
+
+	now := time.Now()
+	s.writeLog(c, st, now.Add(-time.Hour))
+	err := state.PruneLogs(s.State, now.Add(-time.Minute), 1000)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.logCount(c, "juju-heavy", model.UUID()), gc.Equals, 0)
+}
@@ -0,0 +1,220 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelQuotas holds the limits on the resources a model may use.
+// A zero limit means that the resource is not limited.
+type ModelQuotas struct {
+	// MaxMachines is the maximum number of machines, including
+	// containers, in the model.
+	MaxMachines int
+
+	// MaxUnits is the maximum number of principal units in the model.
+	MaxUnits int
+
+	// MaxStorageGB is the maximum total size, in GiB, of the volumes
+	// and filesystems in the model.
+	MaxStorageGB uint64
+}
+
+// Validate returns an error if the quotas are not valid.
+func (q ModelQuotas) Validate() error {
+	if q.MaxMachines < 0 {
+		return errors.NotValidf("negative max-machines %d", q.MaxMachines)
+	}
+	if q.MaxUnits < 0 {
+		return errors.NotValidf("negative max-units %d", q.MaxUnits)
+	}
+	return nil
+}
+
+// modelQuotasDoc records the quotas of a model in its model doc.
+type modelQuotasDoc struct {
+	MaxMachines  int    `bson:"max-machines,omitempty"`
+	MaxUnits     int    `bson:"max-units,omitempty"`
+	MaxStorageGB uint64 `bson:"max-storage-gb,omitempty"`
+}
+
+// QuotaUsage describes the resources subject to quotas that are
+// used by, or requested for, a model.
+type QuotaUsage struct {
+	Machines   int
+	Units      int
+	StorageMiB uint64
+}
+
+// Quotas returns the resource quotas of the model.
+func (m *Model) Quotas() ModelQuotas {
+	return ModelQuotas{
+		MaxMachines:  m.doc.Quotas.MaxMachines,
+		MaxUnits:     m.doc.Quotas.MaxUnits,
+		MaxStorageGB: m.doc.Quotas.MaxStorageGB,
+	}
+}
+
+// SetQuotas replaces the resource quotas of the model. Lowering a
+// quota below the model's current usage does not remove anything; it
+// only prevents the model from growing further.
+func (m *Model) SetQuotas(quotas ModelQuotas) error {
+	if err := quotas.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:  modelsC,
+		Id: m.doc.UUID,
+		Update: bson.D{{"$set", bson.D{{"quotas", modelQuotasDoc{
+			MaxMachines:  quotas.MaxMachines,
+			MaxUnits:     quotas.MaxUnits,
+			MaxStorageGB: quotas.MaxStorageGB,
+		}}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot set model quotas")
+	}
+	return m.Refresh()
+}
+
+// QuotaUsage returns the resources currently used by the model.
+func (st *State) QuotaUsage() (QuotaUsage, error) {
+	var usage QuotaUsage
+	machines, closer := st.db().GetCollection(machinesC)
+	defer closer()
+	n, err := machines.Count()
+	if err != nil {
+		return QuotaUsage{}, errors.Annotate(err, "cannot count machines")
+	}
+	usage.Machines = n
+
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	n, err = units.Find(bson.D{{"principal", ""}}).Count()
+	if err != nil {
+		return QuotaUsage{}, errors.Annotate(err, "cannot count units")
+	}
+	usage.Units = n
+
+	volumes, closer := st.db().GetCollection(volumesC)
+	defer closer()
+	var volumeDocs []volumeDoc
+	if err := volumes.Find(nil).All(&volumeDocs); err != nil {
+		return QuotaUsage{}, errors.Annotate(err, "cannot get volumes")
+	}
+	for _, doc := range volumeDocs {
+		switch {
+		case doc.Info != nil:
+			usage.StorageMiB += doc.Info.Size
+		case doc.Params != nil:
+			usage.StorageMiB += doc.Params.Size
+		}
+	}
+
+	filesystems, closer := st.db().GetCollection(filesystemsC)
+	defer closer()
+	var filesystemDocs []filesystemDoc
+	if err := filesystems.Find(nil).All(&filesystemDocs); err != nil {
+		return QuotaUsage{}, errors.Annotate(err, "cannot get filesystems")
+	}
+	for _, doc := range filesystemDocs {
+		if doc.VolumeId != "" {
+			// The backing volume is already counted.
+			continue
+		}
+		switch {
+		case doc.Info != nil:
+			usage.StorageMiB += doc.Info.Size
+		case doc.Params != nil:
+			usage.StorageMiB += doc.Params.Size
+		}
+	}
+	return usage, nil
+}
+
+// CheckModelQuotas returns an error satisfying IsQuotaExceededError if
+// adding the requested resources to the model would exceed any of its
+// quotas. The check is not made in the same transaction as the
+// resources are added, so concurrent requests may overshoot a quota.
+func (st *State) CheckModelQuotas(request QuotaUsage) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	quotas := model.Quotas()
+	if quotas == (ModelQuotas{}) {
+		return nil
+	}
+	usage, err := st.QuotaUsage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return checkQuotas(quotas, usage, request)
+}
+
+func checkQuotas(quotas ModelQuotas, usage, request QuotaUsage) error {
+	if quotas.MaxMachines > 0 && request.Machines > 0 &&
+		usage.Machines+request.Machines > quotas.MaxMachines {
+		return &quotaExceededError{
+			resource:  "machines",
+			limit:     uint64(quotas.MaxMachines),
+			used:      uint64(usage.Machines),
+			requested: uint64(request.Machines),
+		}
+	}
+	if quotas.MaxUnits > 0 && request.Units > 0 &&
+		usage.Units+request.Units > quotas.MaxUnits {
+		return &quotaExceededError{
+			resource:  "units",
+			limit:     uint64(quotas.MaxUnits),
+			used:      uint64(usage.Units),
+			requested: uint64(request.Units),
+		}
+	}
+	limitMiB := quotas.MaxStorageGB * 1024
+	if limitMiB > 0 && request.StorageMiB > 0 &&
+		usage.StorageMiB+request.StorageMiB > limitMiB {
+		return &quotaExceededError{
+			resource:  "GiB of storage",
+			limit:     quotas.MaxStorageGB,
+			used:      mibToGiB(usage.StorageMiB),
+			requested: mibToGiB(request.StorageMiB),
+		}
+	}
+	return nil
+}
+
+// mibToGiB converts a size in MiB to GiB, rounding up.
+func mibToGiB(mib uint64) uint64 {
+	return (mib + 1023) / 1024
+}
+
+// quotaExceededError is returned when a request would take a model
+// over one of its quotas.
+type quotaExceededError struct {
+	resource  string
+	limit     uint64
+	used      uint64
+	requested uint64
+}
+
+// Error is part of the error interface.
+func (e *quotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"model quota exceeded: adding %d %s would exceed the limit of %d (%d in use)",
+		e.requested, e.resource, e.limit, e.used,
+	)
+}
+
+// IsQuotaExceededError reports whether or not the given error was
+// caused by a request that would exceed a model quota.
+func IsQuotaExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*quotaExceededError)
+	return ok
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelQuotasSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelQuotasSuite{})
+
+func (s *ModelQuotasSuite) setQuotas(c *gc.C, quotas state.ModelQuotas) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetQuotas(quotas)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestSetQuotas(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, state.ModelQuotas{})
+
+	quotas := state.ModelQuotas{MaxMachines: 5, MaxUnits: 10, MaxStorageGB: 100}
+	err = model.SetQuotas(quotas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quotas)
+
+	model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Quotas(), gc.Equals, quotas)
+}
+
+func (s *ModelQuotasSuite) TestSetQuotasInvalid(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetQuotas(state.ModelQuotas{MaxMachines: -1})
+	c.Assert(err, gc.ErrorMatches, "negative max-machines -1 not valid")
+	err = model.SetQuotas(state.ModelQuotas{MaxUnits: -2})
+	c.Assert(err, gc.ErrorMatches, "negative max-units -2 not valid")
+}
+
+func (s *ModelQuotasSuite) TestQuotaUsage(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Size: 1024},
+		}, {
+			Volume: state.VolumeParams{Size: 2048},
+		}},
+	})
+	app := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	usage, err := s.State.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{
+		Machines:   3,
+		Units:      2,
+		StorageMiB: 3072,
+	})
+}
+
+func (s *ModelQuotasSuite) TestCheckModelQuotasUnlimited(c *gc.C) {
+	err := s.State.CheckModelQuotas(state.QuotaUsage{
+		Machines:   1000,
+		Units:      1000,
+		StorageMiB: 1 << 30,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestCheckModelQuotasMachines(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	s.setQuotas(c, state.ModelQuotas{MaxMachines: 3})
+
+	err := s.State.CheckModelQuotas(state.QuotaUsage{Machines: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckModelQuotas(state.QuotaUsage{Machines: 3})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(err, gc.ErrorMatches,
+		`model quota exceeded: adding 3 machines would exceed the limit of 3 \(1 in use\)`)
+
+	// Units are not limited.
+	err = s.State.CheckModelQuotas(state.QuotaUsage{Units: 100})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestCheckModelQuotasUnits(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	s.setQuotas(c, state.ModelQuotas{MaxUnits: 2})
+
+	err := s.State.CheckModelQuotas(state.QuotaUsage{Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckModelQuotas(state.QuotaUsage{Units: 2})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(err, gc.ErrorMatches,
+		`model quota exceeded: adding 2 units would exceed the limit of 2 \(1 in use\)`)
+}
+
+func (s *ModelQuotasSuite) TestCheckModelQuotasStorage(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Size: 8 * 1024},
+		}},
+	})
+	s.setQuotas(c, state.ModelQuotas{MaxStorageGB: 10})
+
+	err := s.State.CheckModelQuotas(state.QuotaUsage{StorageMiB: 2 * 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckModelQuotas(state.QuotaUsage{StorageMiB: 2*1024 + 1})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(err, gc.ErrorMatches,
+		`model quota exceeded: adding 3 GiB of storage would exceed the limit of 10 \(8 in use\)`)
+}
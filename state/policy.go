@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/storage"
 )
@@ -68,14 +69,49 @@ func (st *State) precheckInstance(
 	if prechecker == nil {
 		return errors.New("policy returned nil prechecker without an error")
 	}
+	subnetsToZones, err := st.spaceSubnetsToZones(cons)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	return prechecker.PrecheckInstance(environs.PrecheckInstanceParams{
 		Series:            series,
 		Constraints:       cons,
 		Placement:         placement,
 		VolumeAttachments: volumeAttachments,
+		SubnetsToZones:    subnetsToZones,
 	})
 }
 
+// spaceSubnetsToZones returns a map of provider subnet ID to availability
+// zones for the subnets of the first positive space in the constraints,
+// as the provisioner will pass to StartInstance. Unknown spaces, and
+// subnets without a provider ID or zone, are skipped here; they are
+// reported when the machine is provisioned.
+func (st *State) spaceSubnetsToZones(cons constraints.Value) (map[network.Id][]string, error) {
+	spaces := cons.IncludeSpaces()
+	if len(spaces) == 0 {
+		return nil, nil
+	}
+	space, err := st.Space(spaces[0])
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnetsToZones := make(map[network.Id][]string)
+	for _, subnet := range subnets {
+		if subnet.Life() != Alive || subnet.ProviderId() == "" || subnet.AvailabilityZone() == "" {
+			continue
+		}
+		subnetsToZones[subnet.ProviderId()] = []string{subnet.AvailabilityZone()}
+	}
+	return subnetsToZones, nil
+}
+
 func (st *State) constraintsValidator() (constraints.Validator, error) {
 	// Default behaviour is to simply use a standard validator with
 	// no model specific behaviour built in.
@@ -247,3 +247,22 @@ func (p *StatePool) IntrospectionReport() string {
 		"Marked for removal: %d models\n"+
 		"\n%s", len(p.pool), removeCount, buff)
 }
+
+// LeaseReport returns the reports of the leadership and singular lease
+// managers of the system state and the States in the pool, keyed by
+// model UUID and then by lease type.
+func (p *StatePool) LeaseReport() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := map[string]interface{}{
+		p.systemState.ModelUUID(): p.systemState.workers.leaseReport(),
+	}
+	for uuid, item := range p.pool {
+		if item.remove {
+			continue
+		}
+		report[uuid] = item.state.workers.leaseReport()
+	}
+	return report
+}
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/worker/lease"
 	"github.com/juju/juju/worker/workertest"
 )
 
@@ -220,3 +222,21 @@ func (s *statePoolSuite) TestGetRemovedNotAllowed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model %v has been removed", s.ModelUUID1))
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *statePoolSuite) TestLeaseReport(c *gc.C) {
+	st1, release, err := s.Pool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	err = st1.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := s.Pool.LeaseReport()
+	c.Assert(report, gc.HasLen, 2)
+	c.Assert(report[s.ModelUUID], gc.NotNil)
+	leadership := report[s.ModelUUID1].(map[string]interface{})["leadership"].(map[string]interface{})
+	c.Check(leadership[lease.KeyEntityUUID], gc.Equals, s.ModelUUID1)
+	c.Check(leadership[lease.KeyClaimsGranted], gc.Equals, 1)
+	leases := leadership[lease.KeyLeases].(map[string]interface{})
+	c.Assert(leases["mysql"], gc.NotNil)
+	c.Check(leases["mysql"].(map[string]interface{})[lease.KeyHolder], gc.Equals, "mysql/0")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	statetxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -494,6 +495,12 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 		// No assigned machine, so there won't be any ports.
 		return nil, nil
 	}
+	return removeMachinePortsForUnitsOps(st, machineId, set.NewStrings(unit.Name()))
+}
+
+// removeMachinePortsForUnitsOps returns the ops needed to close all
+// the ports opened on the given machine by any of the named units.
+func removeMachinePortsForUnitsOps(st *State, machineId string, unitNames set.Strings) ([]txn.Op, error) {
 	machine, err := st.Machine(machineId)
 	if errors.IsNotFound(err) {
 		// Machine is removed, so there won't be a ports doc for it.
@@ -510,7 +517,7 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
 		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
+			if !unitNames.Contains(unitName) {
 				unitRange := PortRange{
 					UnitName: unitName,
 					FromPort: portRange.FromPort,
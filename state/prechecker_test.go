@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
@@ -76,6 +77,34 @@ func (s *PrecheckerSuite) TestPrecheckInstanceWithPlacement(c *gc.C) {
 	c.Assert(s.prechecker.precheckInstanceArgs.Constraints, gc.DeepEquals, template.Constraints)
 }
 
+func (s *PrecheckerSuite) TestPrecheckInstanceSpaceSubnetsToZones(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       "subnet-0",
+		AvailabilityZone: "zone1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.1.0/24",
+		AvailabilityZone: "zone2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.0.0.0/24", "10.0.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.addOneMachine(c, constraints.MustParse("spaces=db"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.prechecker.precheckInstanceArgs.SubnetsToZones, jc.DeepEquals, map[network.Id][]string{
+		// The subnet without a provider ID cannot be used
+		// by the provider, and is omitted.
+		"subnet-0": {"zone1"},
+	})
+
+	_, err = s.addOneMachine(c, constraints.MustParse("spaces=unknown"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.prechecker.precheckInstanceArgs.SubnetsToZones, gc.IsNil)
+}
+
 func (s *PrecheckerSuite) TestPrecheckErrors(c *gc.C) {
 	// Ensure that AddOneMachine fails when PrecheckInstance returns an error.
 	s.prechecker.precheckInstanceError = fmt.Errorf("no instance for you")
@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/proxy"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ProxyOverrides holds an application's overrides of the model's proxy
+// settings. They apply only to the environment of the application's
+// hooks; the proxy settings of the machines hosting its units are not
+// changed. Empty fields do not override the model's settings.
+type ProxyOverrides struct {
+	HTTP    string
+	HTTPS   string
+	FTP     string
+	NoProxy string
+}
+
+// Apply returns the given proxy settings with the overrides applied.
+func (o ProxyOverrides) Apply(settings proxy.Settings) proxy.Settings {
+	if o.HTTP != "" {
+		settings.Http = o.HTTP
+	}
+	if o.HTTPS != "" {
+		settings.Https = o.HTTPS
+	}
+	if o.FTP != "" {
+		settings.Ftp = o.FTP
+	}
+	if o.NoProxy != "" {
+		settings.NoProxy = o.NoProxy
+	}
+	return settings
+}
+
+type proxyOverridesDoc struct {
+	HTTP    string `bson:"http,omitempty"`
+	HTTPS   string `bson:"https,omitempty"`
+	FTP     string `bson:"ftp,omitempty"`
+	NoProxy string `bson:"no-proxy,omitempty"`
+}
+
+// ProxyOverrides returns the application's proxy overrides.
+func (a *Application) ProxyOverrides() ProxyOverrides {
+	doc := a.doc.ProxyOverrides
+	if doc == nil {
+		return ProxyOverrides{}
+	}
+	return ProxyOverrides{
+		HTTP:    doc.HTTP,
+		HTTPS:   doc.HTTPS,
+		FTP:     doc.FTP,
+		NoProxy: doc.NoProxy,
+	}
+}
+
+// SetProxyOverrides sets the application's proxy overrides. The zero
+// value removes any overrides.
+func (a *Application) SetProxyOverrides(overrides ProxyOverrides) error {
+	var update bson.D
+	var doc *proxyOverridesDoc
+	if overrides == (ProxyOverrides{}) {
+		update = bson.D{{"$unset", bson.D{{"proxy-overrides", nil}}}}
+	} else {
+		doc = &proxyOverridesDoc{
+			HTTP:    overrides.HTTP,
+			HTTPS:   overrides.HTTPS,
+			FTP:     overrides.FTP,
+			NoProxy: overrides.NoProxy,
+		}
+		update = bson.D{{"$set", bson.D{{"proxy-overrides", doc}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, errNotAlive
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot set proxy overrides: application " + err.Error())
+		}
+		return errors.Annotate(err, "cannot set proxy overrides")
+	}
+	a.doc.ProxyOverrides = doc
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/proxy"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ProxyOverridesSuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&ProxyOverridesSuite{})
+
+func (s *ProxyOverridesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *ProxyOverridesSuite) TestDefaultOverrides(c *gc.C) {
+	c.Assert(s.app.ProxyOverrides(), jc.DeepEquals, state.ProxyOverrides{})
+}
+
+func (s *ProxyOverridesSuite) TestSetProxyOverrides(c *gc.C) {
+	overrides := state.ProxyOverrides{
+		HTTP:    "http://proxy.example.com:3128",
+		NoProxy: "10.0.0.0/8",
+	}
+	err := s.app.SetProxyOverrides(overrides)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.ProxyOverrides(), jc.DeepEquals, overrides)
+
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ProxyOverrides(), jc.DeepEquals, overrides)
+
+	err = app.SetProxyOverrides(state.ProxyOverrides{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.ProxyOverrides(), jc.DeepEquals, state.ProxyOverrides{})
+}
+
+func (s *ProxyOverridesSuite) TestSetProxyOverridesApplicationNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetProxyOverrides(state.ProxyOverrides{HTTP: "http://proxy"})
+	c.Assert(err, gc.ErrorMatches, "cannot set proxy overrides: application not found or not alive")
+}
+
+func (s *ProxyOverridesSuite) TestApply(c *gc.C) {
+	settings := proxy.Settings{
+		Http:        "http://model-proxy",
+		Https:       "https://model-proxy",
+		NoProxy:     "localhost",
+		AutoNoProxy: "10.0.0.1",
+	}
+	overrides := state.ProxyOverrides{
+		HTTPS: "https://app-proxy",
+		FTP:   "ftp://app-proxy",
+	}
+	c.Assert(overrides.Apply(settings), jc.DeepEquals, proxy.Settings{
+		Http:        "http://model-proxy",
+		Https:       "https://app-proxy",
+		Ftp:         "ftp://app-proxy",
+		NoProxy:     "localhost",
+		AutoNoProxy: "10.0.0.1",
+	})
+}
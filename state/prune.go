@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -17,7 +18,24 @@ import (
 // that the collection is smaller than <maxLogsMB> after the
 // deletion.
 func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int, collectionName string, ageField string, timeUnit TimeUnit) error {
+	_, err := pruneModelCollection(mb, maxHistoryTime, maxHistoryMB, collectionName, ageField, timeUnit, false)
+	return errors.Trace(err)
+}
 
+// pruneModelCollection is like pruneCollection, but reports what was
+// removed. If modelBudget is true, <maxLogsMB> is the size budget for
+// the model's own entries rather than for the whole collection, so
+// that the entries of one model cannot cause the entries of another
+// to be pruned.
+func pruneModelCollection(
+	mb modelBackend,
+	maxHistoryTime time.Duration,
+	maxHistoryMB int,
+	collectionName string,
+	ageField string,
+	timeUnit TimeUnit,
+	modelBudget bool,
+) (PruneResult, error) {
 	// NOTE(axw) we require a raw collection to obtain the size of the
 	// collection. Take care to include model-uuid in queries where
 	// appropriate.
@@ -25,20 +43,44 @@ func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB
 	defer closer()
 
 	p := collectionPruner{
-		st:       mb,
-		coll:     entries,
-		maxAge:   maxHistoryTime,
-		maxSize:  maxHistoryMB,
-		ageField: ageField,
-		timeUnit: timeUnit,
+		st:          mb,
+		coll:        entries,
+		maxAge:      maxHistoryTime,
+		maxSize:     maxHistoryMB,
+		ageField:    ageField,
+		timeUnit:    timeUnit,
+		modelBudget: modelBudget,
 	}
 	if err := p.validate(); err != nil {
-		return errors.Trace(err)
+		return PruneResult{}, errors.Trace(err)
 	}
 	if err := p.pruneByAge(); err != nil {
-		return errors.Trace(err)
+		return PruneResult{}, errors.Trace(err)
 	}
-	return errors.Trace(p.pruneBySize())
+	if modelBudget {
+		if err := p.pruneByModelSize(); err != nil {
+			return PruneResult{}, errors.Trace(err)
+		}
+	} else if err := p.pruneBySize(); err != nil {
+		return PruneResult{}, errors.Trace(err)
+	}
+	return p.result, nil
+}
+
+// PruneResult describes the entries removed when pruning a history
+// collection.
+type PruneResult struct {
+	// DeletedByAge is the number of entries removed because they
+	// were older than the maximum age.
+	DeletedByAge int
+
+	// DeletedBySize is the number of entries removed to keep the
+	// history within its size budget.
+	DeletedBySize int
+
+	// ReclaimedBytes is an estimate of the space freed by removing
+	// the entries, based on the collection's average entry size.
+	ReclaimedBytes int64
 }
 
 const historyPruneBatchSize = 1000
@@ -62,6 +104,28 @@ type collectionPruner struct {
 
 	ageField string
 	timeUnit TimeUnit
+
+	// modelBudget is true if maxSize applies to the model's entries
+	// rather than to the whole collection.
+	modelBudget bool
+
+	// result records the entries removed.
+	result PruneResult
+}
+
+// recordDeleted adds the given number of removed entries to the
+// pruner's result.
+func (p *collectionPruner) recordDeleted(deleted *int, n int) error {
+	if n == 0 {
+		return nil
+	}
+	avgSize, err := getAverageEntryBytes(p.coll)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving %s entry size", p.coll.Name)
+	}
+	*deleted += n
+	p.result.ReclaimedBytes += int64(n) * avgSize
+	return nil
 }
 
 func (p *collectionPruner) validate() error {
@@ -111,6 +175,47 @@ func (p *collectionPruner) pruneByAge() error {
 	if deleted > 0 {
 		logger.Infof("%s age pruning (%s): %d rows deleted", p.coll.Name, modelName, deleted)
 	}
+	return errors.Trace(p.recordDeleted(&p.result.DeletedByAge, deleted))
+}
+
+// pruneByModelSize removes the model's oldest entries until the
+// estimated size of those remaining is within maxSize.
+func (p *collectionPruner) pruneByModelSize() error {
+	if p.maxSize == 0 {
+		return nil
+	}
+	avgSize, err := getAverageEntryBytes(p.coll)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving %s entry size", p.coll.Name)
+	}
+	if avgSize <= 0 {
+		// The collection is empty.
+		return nil
+	}
+	query := p.coll.Find(bson.D{{"model-uuid", p.st.modelUUID()}})
+	count, err := query.Count()
+	if err != nil {
+		return errors.Annotatef(err, "counting %s records", p.coll.Name)
+	}
+	maxCount := int(int64(p.maxSize) * int64(humanize.MiByte) / avgSize)
+	if count <= maxCount {
+		return nil
+	}
+	toDelete := count - maxCount
+
+	modelName, err := p.st.modelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	iter := query.Sort(p.ageField).Limit(toDelete).Select(bson.M{"_id": 1}).Iter()
+	template := fmt.Sprintf("%s size pruning (%s): deleted %%d of %d", p.coll.Name, modelName, toDelete)
+	deleted, err := p.deleteInBatches(iter, template, noEarlyFinish)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("%s size pruning (%s) finished: %d rows deleted", p.coll.Name, modelName, deleted)
+	p.result.DeletedBySize += deleted
+	p.result.ReclaimedBytes += int64(deleted) * avgSize
 	return nil
 }
 
@@ -173,7 +278,7 @@ func (p *collectionPruner) pruneBySize() error {
 
 	logger.Infof("%s size pruning finished: %d rows deleted", p.coll.Name, deleted)
 
-	return nil
+	return errors.Trace(p.recordDeleted(&p.result.DeletedBySize, deleted))
 }
 
 func (p *collectionPruner) deleteInBatches(iter *mgo.Iter, logTemplate string, shouldStop doneCheck) (int, error) {
@@ -227,3 +332,16 @@ func (p *collectionPruner) deleteInBatches(iter *mgo.Iter, logTemplate string, s
 func noEarlyFinish() (bool, error) {
 	return false, nil
 }
+
+// getAverageEntryBytes returns the average size of the documents in
+// the given collection, in bytes, or 0 if the collection is empty.
+func getAverageEntryBytes(coll *mgo.Collection) (int64, error) {
+	var result struct {
+		AvgObjSize float64 `bson:"avgObjSize"`
+	}
+	err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int64(result.AvgObjSize), nil
+}
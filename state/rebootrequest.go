@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RebootRequest describes a reboot of a unit's machine that was
+// requested by the unit, and which is granted by the reboot
+// coordinator when no other unit of the same application is
+// rebooting.
+type RebootRequest struct {
+	// Unit is the name of the unit that requested the reboot.
+	Unit string
+
+	// Application is the name of the unit's application.
+	Application string
+
+	// Machine is the id of the machine to be rebooted.
+	Machine string
+
+	// Requested is the time at which the reboot was requested.
+	Requested time.Time
+
+	// Granted reports whether the reboot has been granted, and the
+	// machine's reboot flag set.
+	Granted bool
+}
+
+type rebootRequestDoc struct {
+	DocID       string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	Unit        string    `bson:"unit"`
+	Application string    `bson:"application"`
+	Machine     string    `bson:"machineid"`
+	Requested   time.Time `bson:"requested"`
+	Granted     bool      `bson:"granted"`
+}
+
+func (doc rebootRequestDoc) request() RebootRequest {
+	return RebootRequest{
+		Unit:        doc.Unit,
+		Application: doc.Application,
+		Machine:     doc.Machine,
+		Requested:   doc.Requested.UTC(),
+		Granted:     doc.Granted,
+	}
+}
+
+// RequestCoordinatedReboot records that the unit needs its machine to
+// be rebooted. Unlike setting the machine's reboot flag directly, the
+// reboot happens only once it is granted by the reboot coordinator,
+// which reboots the machines of one unit of an application at a time.
+// Requesting a reboot that is already pending does nothing.
+func (u *Unit) RequestCoordinatedReboot() error {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotate(err, "cannot request reboot")
+	}
+	docID := u.st.docID(u.doc.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		requests, closer := u.st.db().GetCollection(rebootRequestsC)
+		defer closer()
+		if count, err := requests.FindId(u.doc.Name).Count(); err != nil {
+			return nil, errors.Trace(err)
+		} else if count > 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      rebootRequestsC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &rebootRequestDoc{
+				DocID:       docID,
+				ModelUUID:   u.st.ModelUUID(),
+				Unit:        u.doc.Name,
+				Application: u.doc.Application,
+				Machine:     machineId,
+				Requested:   u.st.nowToTheSecond(),
+			},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		if err == errNotAlive {
+			return errors.New("cannot request reboot: unit " + err.Error())
+		}
+		return errors.Annotate(err, "cannot request reboot")
+	}
+	return nil
+}
+
+// RebootRequests returns the model's pending reboot requests, oldest
+// first.
+func (st *State) RebootRequests() ([]RebootRequest, error) {
+	requests, closer := st.db().GetCollection(rebootRequestsC)
+	defer closer()
+
+	var docs []rebootRequestDoc
+	if err := requests.Find(nil).Sort("requested", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get reboot requests")
+	}
+	result := make([]RebootRequest, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.request()
+	}
+	return result, nil
+}
+
+// GrantRebootRequest grants the reboot requested by the named unit,
+// setting the reboot flag of the unit's machine.
+func (st *State) GrantRebootRequest(unitName string) error {
+	requests, closer := st.db().GetCollection(rebootRequestsC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		var doc rebootRequestDoc
+		err := requests.FindId(unitName).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("reboot request for unit %q", unitName)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Granted {
+			return nil, jujutxn.ErrNoOperations
+		}
+		machineDocID := st.docID(doc.Machine)
+		if notDead, err := isNotDead(st, machinesC, machineDocID); err != nil {
+			return nil, errors.Trace(err)
+		} else if !notDead {
+			return nil, errors.Errorf("machine %s is dead", doc.Machine)
+		}
+		return []txn.Op{{
+			C:      rebootRequestsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"granted", false}},
+			Update: bson.D{{"$set", bson.D{{"granted", true}}}},
+		}, {
+			C:      machinesC,
+			Id:     machineDocID,
+			Assert: notDeadDoc,
+		}, {
+			C:      rebootC,
+			Id:     machineDocID,
+			Insert: &rebootDoc{Id: doc.Machine},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot grant reboot for unit %q", unitName)
+	}
+	return nil
+}
+
+// RemoveRebootRequest removes the reboot request of the named unit, if
+// any. It is called once a granted reboot has completed.
+func (st *State) RemoveRebootRequest(unitName string) error {
+	ops := []txn.Op{removeRebootRequestOp(st, unitName)}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove reboot request for unit %q", unitName)
+	}
+	return nil
+}
+
+func removeRebootRequestOp(mb modelBackend, unitName string) txn.Op {
+	return txn.Op{
+		C:      rebootRequestsC,
+		Id:     mb.docID(unitName),
+		Remove: true,
+	}
+}
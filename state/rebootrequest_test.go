@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type RebootRequestSuite struct {
+	ConnSuite
+	clock *testing.Clock
+	unit  *state.Unit
+}
+
+var _ = gc.Suite(&RebootRequestSuite{})
+
+func (s *RebootRequestSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *RebootRequestSuite) machine(c *gc.C, unit *state.Unit) *state.Machine {
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *RebootRequestSuite) TestRequestCoordinatedReboot(c *gc.C) {
+	err := s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	err = s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, jc.DeepEquals, []state.RebootRequest{{
+		Unit:        s.unit.Name(),
+		Application: s.unit.ApplicationName(),
+		Machine:     s.machine(c, s.unit).Id(),
+		Requested:   time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+	}})
+
+	// The machine is not rebooted until the request is granted.
+	flag, err := s.machine(c, s.unit).GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(flag, jc.IsFalse)
+}
+
+func (s *RebootRequestSuite) TestRebootRequestsOrdered(c *gc.C) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	err = other.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	err = s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 2)
+	c.Assert(requests[0].Unit, gc.Equals, other.Name())
+	c.Assert(requests[1].Unit, gc.Equals, s.unit.Name())
+}
+
+func (s *RebootRequestSuite) TestRequestCoordinatedRebootUnitNotAlive(c *gc.C) {
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RequestCoordinatedReboot()
+	c.Assert(err, gc.ErrorMatches, "cannot request reboot: unit not found or not alive")
+}
+
+func (s *RebootRequestSuite) TestGrantRebootRequest(c *gc.C) {
+	err := s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.GrantRebootRequest(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting again does nothing.
+	err = s.State.GrantRebootRequest(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Granted, jc.IsTrue)
+
+	flag, err := s.machine(c, s.unit).GetRebootFlag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(flag, jc.IsTrue)
+}
+
+func (s *RebootRequestSuite) TestGrantRebootRequestNotFound(c *gc.C) {
+	err := s.State.GrantRebootRequest(s.unit.Name())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot grant reboot for unit ".*": reboot request for unit ".*" not found`)
+}
+
+func (s *RebootRequestSuite) TestRemoveRebootRequest(c *gc.C) {
+	err := s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveRebootRequest(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	// Removing a missing request does nothing.
+	err = s.State.RemoveRebootRequest(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 0)
+}
+
+func (s *RebootRequestSuite) TestRebootRequestRemovedWithUnit(c *gc.C) {
+	err := s.unit.RequestCoordinatedReboot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 0)
+}
@@ -21,6 +21,15 @@ type sequenceDoc struct {
 // sequence safely increments a database backed sequence, returning
 // the next value.
 func sequence(mb modelBackend, name string) (int, error) {
+	return sequenceN(mb, name, 1)
+}
+
+// sequenceN safely reserves n consecutive values of a database backed
+// sequence in a single update, returning the first of them.
+func sequenceN(mb modelBackend, name string, n int) (int, error) {
+	if n < 1 {
+		return -1, errors.NotValidf("reserving %d %q sequence numbers", n, name)
+	}
 	sequences, closer := mb.db().GetCollection(sequenceC)
 	defer closer()
 	query := sequences.FindId(name)
@@ -30,7 +39,7 @@ func sequence(mb modelBackend, name string) (int, error) {
 				"name":       name,
 				"model-uuid": mb.modelUUID(),
 			},
-			"$inc": bson.M{"counter": 1},
+			"$inc": bson.M{"counter": n},
 		},
 		Upsert: true,
 	}
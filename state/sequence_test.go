@@ -41,6 +41,24 @@ func (s *sequenceSuite) TestMultipleSequences(c *gc.C) {
 	s.checkDoc(c, s.State.ModelUUID(), "bar", 3)
 }
 
+func (s *sequenceSuite) TestSequenceN(c *gc.C) {
+	value, err := state.SequenceN(s.State, "foo", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 0)
+	s.checkDoc(c, s.State.ModelUUID(), "foo", 5)
+
+	s.incAndCheck(c, s.State, "foo", 5)
+
+	value, err = state.SequenceN(s.State, "foo", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 6)
+	s.checkDoc(c, s.State.ModelUUID(), "foo", 9)
+
+	_, err = state.SequenceN(s.State, "foo", 0)
+	c.Assert(err, gc.ErrorMatches, `reserving 0 "foo" sequence numbers not valid`)
+	s.checkDoc(c, s.State.ModelUUID(), "foo", 9)
+}
+
 func (s *sequenceSuite) TestSequenceWithMultipleEnvs(c *gc.C) {
 	state1 := s.State
 	state2 := s.Factory.MakeModel(c, nil)
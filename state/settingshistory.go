@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// settingsHistoryLimit is the number of changes kept in the settings
+// history of each entity. Older changes are discarded as new ones are
+// recorded.
+const settingsHistoryLimit = 20
+
+// settingsHistoryDoc records a change made to the settings of the
+// entity with the given global key.
+type settingsHistoryDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	GlobalKey string `bson:"globalkey"`
+
+	// Version is the version of the settings document
+	// produced by the change.
+	Version int64 `bson:"version"`
+
+	// Author is the name of the user who made the change,
+	// or empty if it was not made on behalf of a user.
+	Author string `bson:"author,omitempty"`
+
+	// Changed is the time of the change, in unix nanoseconds.
+	Changed int64 `bson:"changed"`
+
+	Changes []settingsChangeDoc `bson:"changes"`
+}
+
+// settingsChangeDoc records the change of a single settings item.
+type settingsChangeDoc struct {
+	Type     int         `bson:"type"`
+	Key      string      `bson:"key"`
+	OldValue interface{} `bson:"old,omitempty"`
+	NewValue interface{} `bson:"new,omitempty"`
+}
+
+// SettingsChange describes a change made to the settings of an entity.
+type SettingsChange struct {
+	// Version is the version of the settings produced by the change.
+	Version int64
+
+	// Author is the name of the user who made the change, or
+	// empty if it was not made on behalf of a user.
+	Author string
+
+	// Changed is the time at which the change was made.
+	Changed time.Time
+
+	// Changes holds the items changed, sorted by key.
+	Changes []ItemChange
+}
+
+func settingsHistoryId(globalKey string, version int64) string {
+	return fmt.Sprintf("%s#%d", globalKey, version)
+}
+
+// versionedUpdateOps returns the item changes and txn ops necessary
+// to write the changes made to s back onto its node, and to record them
+// in the settings history of the entity with the given global key. The
+// ops assert that the node has not changed since it was read, so that
+// the recorded changes are exactly those applied.
+func (s *Settings) versionedUpdateOps(globalKey, author string, now time.Time) ([]ItemChange, []txn.Op, error) {
+	changes, ops := s.settingsUpdateOps()
+	if len(ops) == 0 {
+		return changes, nil, nil
+	}
+	ops[0].Assert = bson.D{{"version", s.version}}
+	historyOps, err := settingsHistoryOps(s.db, globalKey, s.version+1, author, changes, now)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return changes, append(ops, historyOps...), nil
+}
+
+// settingsHistoryOps returns the txn ops necessary to record the given
+// changes in the settings history of the entity with the given global
+// key, and to discard the oldest changes beyond settingsHistoryLimit.
+func settingsHistoryOps(db Database, globalKey string, version int64, author string, changes []ItemChange, now time.Time) ([]txn.Op, error) {
+	doc := &settingsHistoryDoc{
+		GlobalKey: globalKey,
+		Version:   version,
+		Author:    author,
+		Changed:   now.UnixNano(),
+	}
+	for _, change := range changes {
+		doc.Changes = append(doc.Changes, settingsChangeDoc{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	ops := []txn.Op{{
+		C:      settingsHistoryC,
+		Id:     settingsHistoryId(globalKey, version),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+
+	ids, err := settingsHistoryIds(db, globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if excess := len(ids) + 1 - settingsHistoryLimit; excess > 0 {
+		ops = append(ops, removeSettingsHistoryOps(ids[:excess])...)
+	}
+	return ops, nil
+}
+
+// settingsHistoryIds returns the ids of the settings history documents
+// of the entity with the given global key, oldest first.
+func settingsHistoryIds(db Database, globalKey string) ([]string, error) {
+	coll, closer := db.GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"globalkey", globalKey}}).
+		Sort("version").Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading settings history of %q", globalKey)
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.DocID
+	}
+	return ids, nil
+}
+
+func removeSettingsHistoryOps(ids []string) []txn.Op {
+	ops := make([]txn.Op, len(ids))
+	for i, id := range ids {
+		ops[i] = txn.Op{
+			C:      settingsHistoryC,
+			Id:     id,
+			Remove: true,
+		}
+	}
+	return ops
+}
+
+// removeAllSettingsHistoryOps returns the txn ops necessary to remove
+// the settings history of the entity with the given global key.
+func removeAllSettingsHistoryOps(db Database, globalKey string) ([]txn.Op, error) {
+	ids, err := settingsHistoryIds(db, globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return removeSettingsHistoryOps(ids), nil
+}
+
+// readSettingsHistory returns the recorded changes to the settings of
+// the entity with the given global key, oldest first.
+func readSettingsHistory(db Database, globalKey string) ([]SettingsChange, error) {
+	coll, closer := db.GetCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []settingsHistoryDoc
+	if err := coll.Find(bson.D{{"globalkey", globalKey}}).Sort("version").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading settings history of %q", globalKey)
+	}
+	result := make([]SettingsChange, len(docs))
+	for i, doc := range docs {
+		change := SettingsChange{
+			Version: doc.Version,
+			Author:  doc.Author,
+			Changed: time.Unix(0, doc.Changed).UTC(),
+		}
+		for _, item := range doc.Changes {
+			change.Changes = append(change.Changes, ItemChange{
+				Type:     item.Type,
+				Key:      item.Key,
+				OldValue: item.OldValue,
+				NewValue: item.NewValue,
+			})
+		}
+		result[i] = change
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/state"
+)
+
+type SettingsHistorySuite struct {
+	ConnSuite
+	app *state.Application
+}
+
+var _ = gc.Suite(&SettingsHistorySuite{})
+
+func (s *SettingsHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+}
+
+func (s *SettingsHistorySuite) TestApplicationConfigHistory(c *gc.C) {
+	err := s.app.UpdateConfigSettingsBy("bob", charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateConfigSettingsBy("alice", charm.Settings{
+		"outlook": nil,
+		"title":   "sir",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.SettingsChange{{
+		Version: 1,
+		Author:  "bob",
+		Changed: s.Clock.Now().UTC(),
+		Changes: []state.ItemChange{
+			{Type: state.ItemAdded, Key: "outlook", NewValue: "positive"},
+		},
+	}, {
+		Version: 2,
+		Author:  "alice",
+		Changed: s.Clock.Now().UTC(),
+		Changes: []state.ItemChange{
+			{Type: state.ItemDeleted, Key: "outlook", OldValue: "positive"},
+			{Type: state.ItemAdded, Key: "title", NewValue: "sir"},
+		},
+	}})
+}
+
+func (s *SettingsHistorySuite) TestApplicationConfigHistoryUnchanged(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Author, gc.Equals, "")
+}
+
+func (s *SettingsHistorySuite) TestApplicationConfigHistoryBounded(c *gc.C) {
+	for i := 0; i < 25; i++ {
+		err := s.app.UpdateConfigSettings(charm.Settings{"title": fmt.Sprint(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 20)
+	c.Assert(history[0].Version, gc.Equals, int64(6))
+	c.Assert(history[19].Version, gc.Equals, int64(25))
+	c.Assert(history[19].Changes, jc.DeepEquals, []state.ItemChange{{
+		Type:     state.ItemModified,
+		Key:      "title",
+		OldValue: "23",
+		NewValue: "24",
+	}})
+}
+
+func (s *SettingsHistorySuite) TestApplicationRemovalRemovesConfigHistory(c *gc.C) {
+	err := s.app.UpdateConfigSettings(charm.Settings{"outlook": "positive"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	history, err := app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *SettingsHistorySuite) TestModelConfigHistory(c *gc.C) {
+	err := s.IAASModel.UpdateModelConfig(map[string]interface{}{"foo": "bar"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.IAASModel.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.Not(gc.HasLen), 0)
+	last := history[len(history)-1]
+	c.Assert(last.Changes, jc.DeepEquals, []state.ItemChange{{
+		Type:     state.ItemAdded,
+		Key:      "foo",
+		NewValue: "bar",
+	}})
+}
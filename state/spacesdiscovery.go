@@ -32,27 +32,81 @@ func (st *State) getModelSubnets() (set.Strings, error) {
 // ReloadSpaces loads spaces and subnets from provider specified by environ into state.
 // Currently it's an append-only operation, no spaces/subnets are deleted.
 func (st *State) ReloadSpaces(environ environs.Environ) error {
+	_, err := st.reloadSpaces(environ)
+	return errors.Trace(err)
+}
+
+// ReconcileSubnets brings the model's subnets into line with those
+// known to the provider specified by environ. Subnets that are new to
+// the model are added, as with ReloadSpaces; subnets that were
+// discovered from the provider, but which the provider no longer
+// reports, are marked Dead. Subnets without a provider ID are left
+// alone.
+func (st *State) ReconcileSubnets(environ environs.Environ) error {
+	providerSubnets, err := st.reloadSpaces(environ)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerIds := make(set.Strings)
+	for _, subnet := range providerSubnets {
+		providerIds.Add(string(subnet.ProviderId))
+	}
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, subnet := range subnets {
+		id := string(subnet.ProviderId())
+		if id == "" || subnet.Life() != Alive {
+			continue
+		}
+		if subnet.FanLocalUnderlay() != "" {
+			// FAN overlay segments live and die with their underlay
+			// subnet, whose provider ID prefixes their own.
+			if i := strings.Index(id, "-INFAN-"); i > 0 {
+				id = id[:i]
+			}
+		}
+		if providerIds.Contains(id) {
+			continue
+		}
+		logger.Infof("subnet %q (%s) no longer exists in the provider, marking it dead", subnet.CIDR(), subnet.ProviderId())
+		if err := subnet.EnsureDead(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// reloadSpaces loads spaces and subnets from the provider specified by
+// environ into state, and returns all of the subnets the provider
+// reported.
+func (st *State) reloadSpaces(environ environs.Environ) ([]network.SubnetInfo, error) {
 	netEnviron, ok := environs.SupportsNetworking(environ)
 	if !ok {
-		return errors.NotSupportedf("spaces discovery in a non-networking environ")
+		return nil, errors.NotSupportedf("spaces discovery in a non-networking environ")
 	}
 	canDiscoverSpaces, err := netEnviron.SupportsSpaceDiscovery()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if canDiscoverSpaces {
 		spaces, err := netEnviron.Spaces()
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
+		}
+		var subnets []network.SubnetInfo
+		for _, space := range spaces {
+			subnets = append(subnets, space.Subnets...)
 		}
-		return errors.Trace(st.SaveSpacesFromProvider(spaces))
+		return subnets, errors.Trace(st.SaveSpacesFromProvider(spaces))
 	} else {
 		logger.Debugf("environ does not support space discovery, falling back to subnet discovery")
 		subnets, err := netEnviron.Subnets(instance.UnknownId, nil)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
-		return errors.Trace(st.SaveSubnetsFromProvider(subnets, ""))
+		return subnets, errors.Trace(st.SaveSubnetsFromProvider(subnets, ""))
 	}
 }
 
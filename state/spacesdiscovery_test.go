@@ -401,3 +401,93 @@ func (s *SpacesDiscoverySuite) TestReloadSpacesWithFAN(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	checkSpacesEqual(c, spaces, spaceOneAfterFAN)
 }
+
+func (s *SpacesDiscoverySuite) TestReconcileSubnetsNetworklessEnviron(c *gc.C) {
+	err := s.State.ReconcileSubnets(networkLessEnviron{})
+	c.Check(err, gc.ErrorMatches, "spaces discovery in a non-networking environ not supported")
+}
+
+func (s *SpacesDiscoverySuite) TestReconcileSubnetsMarksRemovedSubnetsDead(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: false,
+		subnets:        twoSubnets,
+	}
+	s.usedEnviron = &s.environ
+	err := s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Subnets added by hand, without a provider ID, are not touched.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.environ.subnets = []network.SubnetInfo{twoSubnets[1], anotherTwoSubnets[0]}
+	err = s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	life := make(map[string]state.Life)
+	for _, subnet := range subnets {
+		life[subnet.CIDR()] = subnet.Life()
+	}
+	c.Assert(life, jc.DeepEquals, map[string]state.Life{
+		"10.0.0.1/24":    state.Dead,
+		"10.100.30.1/24": state.Alive,
+		"10.101.0.1/24":  state.Alive,
+		"192.168.1.0/24": state.Alive,
+	})
+}
+
+func (s *SpacesDiscoverySuite) TestReconcileSubnetsWithFAN(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: false,
+		subnets:        twoSubnets,
+	}
+	s.usedEnviron = &s.environ
+
+	s.IAASModel.UpdateModelConfig(map[string]interface{}{"fan-config": "10.100.0.0/16=253.0.0.0/8"}, nil)
+	err := s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The FAN overlay segment lives as long as its underlay subnet.
+	s.environ.subnets = twoSubnets[1:]
+	err = s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	overlay, err := s.State.Subnet("253.30.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overlay.Life(), gc.Equals, state.Alive)
+
+	s.environ.subnets = twoSubnets[:1]
+	err = s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	err = overlay.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overlay.Life(), gc.Equals, state.Dead)
+}
+
+func (s *SpacesDiscoverySuite) TestReconcileSubnetsWithSpaces(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: true,
+		spaces:         twoSpaces,
+	}
+	s.usedEnviron = &s.environ
+	err := s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.environ.spaces = spaceTwo
+	err = s.State.ReconcileSubnets(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, info := range fourSubnets {
+		subnet, err := s.State.Subnet(info.CIDR)
+		c.Assert(err, jc.ErrorIsNil)
+		expect := state.Alive
+		if info.ProviderId == "1" || info.ProviderId == "2" {
+			expect = state.Dead
+		}
+		c.Check(subnet.Life(), gc.Equals, expect, gc.Commentf("subnet %q", info.CIDR))
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// stagedUpgradeKey is the key of the model's staged upgrade document.
+const stagedUpgradeKey = "staged-upgrade"
+
+// StagedUpgrade describes an agent upgrade that is rolled out to a
+// model's machines in stages: first to the controllers, then to a set
+// of canary machines, then to the rest of the machines in batches.
+// The model's agent version is the new version throughout, but only
+// the controllers and the admitted machines are told to upgrade; the
+// rest are held at the previous version until they are admitted.
+type StagedUpgrade struct {
+	// Version is the version being upgraded to.
+	Version version.Number
+
+	// PreviousVersion is the model's agent version before the
+	// upgrade started.
+	PreviousVersion version.Number
+
+	// Canaries holds the ids of the machines to be upgraded first.
+	Canaries []string
+
+	// BatchSize is the number of machines to be upgraded in each
+	// batch after the canaries. Zero means all the remaining
+	// machines are upgraded together.
+	BatchSize int
+
+	// Admitted holds the ids of the machines that have been allowed
+	// to upgrade.
+	Admitted []string
+
+	// Started is the time at which the upgrade started.
+	Started time.Time
+}
+
+// IsAdmitted reports whether the machine with the given id has been
+// allowed to upgrade.
+func (u StagedUpgrade) IsAdmitted(machineId string) bool {
+	for _, id := range u.Admitted {
+		if id == machineId {
+			return true
+		}
+	}
+	return false
+}
+
+type stagedUpgradeParams struct {
+	canaries  []string
+	batchSize int
+}
+
+type stagedUpgradeDoc struct {
+	DocID           string    `bson:"_id"`
+	ModelUUID       string    `bson:"model-uuid"`
+	Version         string    `bson:"version"`
+	PreviousVersion string    `bson:"previous-version"`
+	Canaries        []string  `bson:"canaries"`
+	BatchSize       int       `bson:"batch-size"`
+	Admitted        []string  `bson:"admitted"`
+	Started         time.Time `bson:"started"`
+}
+
+// SetModelAgentVersionStaged changes the agent version for the model
+// to the given version, as SetModelAgentVersion does, but rolls the
+// upgrade out in stages. After the controllers, only the canary
+// machines are allowed to upgrade; the remaining machines are allowed
+// to upgrade in batches of the given size by the upgrade stager once
+// the machines already upgraded are healthy.
+func (st *State) SetModelAgentVersionStaged(newVersion version.Number, ignoreAgentVersions bool, canaries []string, batchSize int) error {
+	if batchSize < 0 {
+		return errors.NotValidf("negative batch size")
+	}
+	if len(canaries) == 0 && batchSize == 0 {
+		return errors.NotValidf("staged upgrade without canaries or batch size")
+	}
+	stage := &stagedUpgradeParams{
+		canaries:  set.NewStrings(canaries...).SortedValues(),
+		batchSize: batchSize,
+	}
+	return st.setModelAgentVersion(newVersion, ignoreAgentVersions, stage)
+}
+
+// stagedUpgradeOps returns the operations needed to start a staged
+// upgrade from currentVersion to newVersion if stage is not nil, or to
+// end any staged upgrade in progress otherwise.
+func (st *State) stagedUpgradeOps(currentVersion string, newVersion version.Number, stage *stagedUpgradeParams) ([]txn.Op, error) {
+	stagedUpgrades, closer := st.db().GetCollection(stagedUpgradesC)
+	defer closer()
+	count, err := stagedUpgrades.FindId(stagedUpgradeKey).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if stage == nil {
+		if count == 0 {
+			return nil, nil
+		}
+		return []txn.Op{{
+			C:      stagedUpgradesC,
+			Id:     st.docID(stagedUpgradeKey),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+
+	if count > 0 {
+		return nil, errors.New("a staged upgrade is already in progress")
+	}
+	if newVersion.String() == currentVersion {
+		return nil, errors.Errorf("model is already at version %s", currentVersion)
+	}
+	previousVersion, err := version.Parse(currentVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, id := range stage.canaries {
+		machine, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid canary")
+		}
+		if machine.Life() == Dead {
+			return nil, errors.Errorf("invalid canary: machine %s is dead", id)
+		}
+		ops = append(ops, txn.Op{
+			C:      machinesC,
+			Id:     machine.doc.DocID,
+			Assert: notDeadDoc,
+		})
+	}
+	return append(ops, txn.Op{
+		C:      stagedUpgradesC,
+		Id:     st.docID(stagedUpgradeKey),
+		Assert: txn.DocMissing,
+		Insert: &stagedUpgradeDoc{
+			DocID:           st.docID(stagedUpgradeKey),
+			ModelUUID:       st.ModelUUID(),
+			Version:         newVersion.String(),
+			PreviousVersion: previousVersion.String(),
+			Canaries:        stage.canaries,
+			BatchSize:       stage.batchSize,
+			Admitted:        []string{},
+			Started:         st.nowToTheSecond(),
+		},
+	}), nil
+}
+
+// StagedUpgrade returns the model's staged upgrade. If no staged
+// upgrade is in progress, an error satisfying errors.IsNotFound is
+// returned.
+func (st *State) StagedUpgrade() (StagedUpgrade, error) {
+	stagedUpgrades, closer := st.db().GetCollection(stagedUpgradesC)
+	defer closer()
+
+	var doc stagedUpgradeDoc
+	err := stagedUpgrades.FindId(stagedUpgradeKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return StagedUpgrade{}, errors.NotFoundf("staged upgrade")
+	} else if err != nil {
+		return StagedUpgrade{}, errors.Annotate(err, "cannot get staged upgrade")
+	}
+	newVersion, err := version.Parse(doc.Version)
+	if err != nil {
+		return StagedUpgrade{}, errors.Trace(err)
+	}
+	previousVersion, err := version.Parse(doc.PreviousVersion)
+	if err != nil {
+		return StagedUpgrade{}, errors.Trace(err)
+	}
+	return StagedUpgrade{
+		Version:         newVersion,
+		PreviousVersion: previousVersion,
+		Canaries:        doc.Canaries,
+		BatchSize:       doc.BatchSize,
+		Admitted:        doc.Admitted,
+		Started:         doc.Started.UTC(),
+	}, nil
+}
+
+// AdmitStagedUpgradeMachines allows the machines with the given ids to
+// upgrade to the version of the model's staged upgrade.
+func (st *State) AdmitStagedUpgradeMachines(machineIds []string) error {
+	ops := []txn.Op{{
+		C:      stagedUpgradesC,
+		Id:     st.docID(stagedUpgradeKey),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"admitted", bson.D{{"$each", machineIds}}}}}},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("staged upgrade")
+	} else if err != nil {
+		return errors.Annotate(err, "cannot admit machines to staged upgrade")
+	}
+	return nil
+}
+
+// CompleteStagedUpgrade ends the model's staged upgrade, if any, once
+// all of the model's machines have been admitted. Agents are then all
+// told to run the model's agent version.
+func (st *State) CompleteStagedUpgrade() error {
+	ops := []txn.Op{{
+		C:      stagedUpgradesC,
+		Id:     st.docID(stagedUpgradeKey),
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot complete staged upgrade")
+	}
+	return nil
+}
+
+// WatchStagedUpgrade returns a watcher that notifies of changes to the
+// model's staged upgrade.
+func (st *State) WatchStagedUpgrade() NotifyWatcher {
+	return newEntityWatcher(st, stagedUpgradesC, st.docID(stagedUpgradeKey))
+}
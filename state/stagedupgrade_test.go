@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type StagedUpgradeSuite struct {
+	ConnSuite
+	clock    *testing.Clock
+	current  version.Number
+	target   version.Number
+	machines []*state.Machine
+}
+
+var _ = gc.Suite(&StagedUpgradeSuite{})
+
+func (s *StagedUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := m.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	current, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	s.current = current
+	s.target = current
+	s.target.Patch++
+
+	s.machines = nil
+	for i := 0; i < 3; i++ {
+		machine := s.Factory.MakeMachine(c, nil)
+		err := machine.SetAgentVersion(version.Binary{
+			Number: current,
+			Series: "quantal",
+			Arch:   "amd64",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		s.machines = append(s.machines, machine)
+	}
+}
+
+func (s *StagedUpgradeSuite) TestStagedUpgradeNotFound(c *gc.C) {
+	_, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "staged upgrade not found")
+}
+
+func (s *StagedUpgradeSuite) TestSetModelAgentVersionStaged(c *gc.C) {
+	canaries := []string{s.machines[1].Id(), s.machines[0].Id()}
+	err := s.State.SetModelAgentVersionStaged(s.target, false, canaries, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, s.target.String())
+
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade, jc.DeepEquals, state.StagedUpgrade{
+		Version:         s.target,
+		PreviousVersion: s.current,
+		Canaries:        []string{s.machines[0].Id(), s.machines[1].Id()},
+		BatchSize:       2,
+		Admitted:        []string{},
+		Started:         time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *StagedUpgradeSuite) TestSetModelAgentVersionStagedInvalid(c *gc.C) {
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 0)
+	c.Assert(err, gc.ErrorMatches, "staged upgrade without canaries or batch size not valid")
+	err = s.State.SetModelAgentVersionStaged(s.target, false, nil, -1)
+	c.Assert(err, gc.ErrorMatches, "negative batch size not valid")
+	err = s.State.SetModelAgentVersionStaged(s.target, false, []string{"42"}, 0)
+	c.Assert(err, gc.ErrorMatches, "invalid canary: machine 42 not found")
+	err = s.State.SetModelAgentVersionStaged(s.current, false, nil, 1)
+	c.Assert(err, gc.ErrorMatches, "model is already at version .*")
+	assertAgentVersion(c, s.State, s.current.String())
+}
+
+func (s *StagedUpgradeSuite) TestSetModelAgentVersionStagedAlreadyInProgress(c *gc.C) {
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	next := s.target
+	next.Patch++
+	err = s.State.SetModelAgentVersionStaged(next, true, nil, 1)
+	c.Assert(err, gc.ErrorMatches, "a staged upgrade is already in progress")
+}
+
+func (s *StagedUpgradeSuite) TestSetModelAgentVersionEndsStagedUpgrade(c *gc.C) {
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting the same version again admits all the agents.
+	err = s.State.SetModelAgentVersion(s.target, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StagedUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	assertAgentVersion(c, s.State, s.target.String())
+}
+
+func (s *StagedUpgradeSuite) TestAdmitStagedUpgradeMachines(c *gc.C) {
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AdmitStagedUpgradeMachines([]string{s.machines[0].Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AdmitStagedUpgradeMachines([]string{s.machines[0].Id(), s.machines[2].Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Admitted, jc.DeepEquals, []string{s.machines[0].Id(), s.machines[2].Id()})
+	c.Assert(upgrade.IsAdmitted(s.machines[0].Id()), jc.IsTrue)
+	c.Assert(upgrade.IsAdmitted(s.machines[1].Id()), jc.IsFalse)
+}
+
+func (s *StagedUpgradeSuite) TestAdmitStagedUpgradeMachinesNotFound(c *gc.C) {
+	err := s.State.AdmitStagedUpgradeMachines([]string{"0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StagedUpgradeSuite) TestCompleteStagedUpgrade(c *gc.C) {
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CompleteStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StagedUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Completing again does nothing.
+	err = s.State.CompleteStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StagedUpgradeSuite) TestWatchStagedUpgrade(c *gc.C) {
+	w := s.State.WatchStagedUpgrade()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetModelAgentVersionStaged(s.target, false, nil, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.AdmitStagedUpgradeMachines([]string{s.machines[0].Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.CompleteStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
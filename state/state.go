@@ -293,7 +293,17 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	if !st.IsController() {
 		ops = append(ops, decHostedModelCountOp())
 	}
-	return st.db().RunTransaction(ops)
+	if err := st.db().RunTransaction(ops); err != nil {
+		return err
+	}
+	if model.Database() != "" {
+		// The model has gone, so its own database is not needed.
+		// Failing to drop it leaves only an empty database behind.
+		if err := st.removeModelDatabase(model.Database()); err != nil {
+			logger.Warningf("%v", err)
+		}
+	}
+	return nil
 }
 
 // removeAllInCollectionRaw removes all the documents from the given
@@ -632,6 +642,32 @@ func IsVersionInconsistentError(e interface{}) bool {
 }
 
 func (st *State) checkCanUpgrade(currentVersion, newVersion string) error {
+	agentTags, err := st.agentsNotAtVersion(currentVersion, newVersion)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(agentTags) > 0 {
+		err := newVersionInconsistentError(version.MustParse(currentVersion), agentTags)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AgentsNotAtVersion returns the tags of the machine and unit agents
+// in the model that are running neither the current version nor the
+// new version, including those that have not yet reported a version.
+// Such agents prevent the model from being upgraded to the new
+// version unless agent versions are ignored.
+func (st *State) AgentsNotAtVersion(currentVersion, newVersion version.Number) ([]string, error) {
+	agentTags, err := st.agentsNotAtVersion(currentVersion.String(), newVersion.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(agentTags)
+	return agentTags, nil
+}
+
+func (st *State) agentsNotAtVersion(currentVersion, newVersion string) ([]string, error) {
 	matchCurrent := "^" + regexp.QuoteMeta(currentVersion) + "-"
 	matchNew := "^" + regexp.QuoteMeta(newVersion) + "-"
 	// Get all machines and units with a different or empty version.
@@ -653,7 +689,7 @@ func (st *State) checkCanUpgrade(currentVersion, newVersion string) error {
 		for iter.Next(&doc) {
 			localID, err := st.strictLocalID(doc.DocID)
 			if err != nil {
-				return errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 			switch name {
 			case machinesC:
@@ -663,14 +699,10 @@ func (st *State) checkCanUpgrade(currentVersion, newVersion string) error {
 			}
 		}
 		if err := iter.Close(); err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 	}
-	if len(agentTags) > 0 {
-		err := newVersionInconsistentError(version.MustParse(currentVersion), agentTags)
-		return errors.Trace(err)
-	}
-	return nil
+	return agentTags, nil
 }
 
 var errUpgradeInProgress = errors.New(params.CodeUpgradeInProgress)
@@ -685,7 +717,14 @@ func IsUpgradeInProgressError(err error) bool {
 // given version, only if the model is in a stable state (all agents are
 // running the current version). If this is a hosted model, newVersion
 // cannot be higher than the controller version.
+//
+// Any staged upgrade in progress is ended, so that all agents are
+// upgraded to the new version.
 func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVersions bool) (err error) {
+	return st.setModelAgentVersion(newVersion, ignoreAgentVersions, nil)
+}
+
+func (st *State) setModelAgentVersion(newVersion version.Number, ignoreAgentVersions bool, stage *stagedUpgradeParams) (err error) {
 	if newVersion.Compare(jujuversion.Current) > 0 && !st.IsController() {
 		return errors.Errorf("a hosted model cannot have a higher version than the server model: %s > %s",
 			newVersion.String(),
@@ -706,7 +745,16 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 		if !ok {
 			return nil, errors.Errorf("invalid agent version format: expected string, got %v", agentVersion)
 		}
+		stagedOps, err := st.stagedUpgradeOps(currentVersion, newVersion, stage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		if newVersion.String() == currentVersion {
+			if len(stagedOps) > 0 {
+				// Ending a staged upgrade to the current version
+				// admits all the remaining agents.
+				return stagedOps, nil
+			}
 			// Nothing to do.
 			return nil, jujutxn.ErrNoOperations
 		}
@@ -717,7 +765,7 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 			}
 		}
 
-		ops := []txn.Op{
+		ops := append(stagedOps, []txn.Op{
 			// Can't set agent-version if there's an active upgradeInfo doc.
 			{
 				C:      upgradeInfoC,
@@ -731,7 +779,7 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 					{"$set", bson.D{{"settings.agent-version", newVersion.String()}}},
 				},
 			},
-		}
+		}...)
 		return ops, nil
 	}
 	if err = st.db().Run(buildTxn); err == jujutxn.ErrExcessiveContention {
@@ -1242,6 +1290,16 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		NeverSet: true,
 	}
 
+	// Reserve the names of the new units up front, so that they are
+	// allocated with a single update and reused if the transaction
+	// below is retried.
+	var unitNames []string
+	if args.NumUnits > 0 {
+		if unitNames, err = app.newUnitNames(args.NumUnits); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// When creating the settings, we ignore nils.  In other circumstances, nil
 	// means to delete the value (reset to default), so creating with nil should
 	// mean to use the default, i.e. don't set the value.
@@ -1285,6 +1343,25 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 		ops = append(ops, addOps...)
 
+		// Create the application's shared storage instances,
+		// which are attached to each of the application's units.
+		sharedStorageOps, sharedStorageTags, _, err := createStorageOps(
+			im,
+			app.ApplicationTag(),
+			args.Charm.Meta(),
+			args.Storage,
+			args.Series,
+			nil,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, sharedStorageOps...)
+		sharedStorage := []names.StorageTag{}
+		for _, tags := range sharedStorageTags {
+			sharedStorage = append(sharedStorage, tags...)
+		}
+
 		// Collect peer relation addition operations.
 		//
 		// TODO(dimitern): Ensure each st.Endpoint has a space name associated in a
@@ -1309,11 +1386,15 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 
 		// Collect unit-adding operations.
-		for x := 0; x < args.NumUnits; x++ {
-			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
+		for x, unitName := range unitNames {
+			_, unitOps, err := app.addUnitOpsWithCons(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
 				storageCons:   args.Storage,
 				attachStorage: args.AttachStorage,
+				sharedStorage: sharedStorage,
+				unitName:      unitName,
+				charm:         args.Charm,
+				model:         im,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -1325,6 +1406,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
 		}
+		if len(unitNames) > 0 {
+			ops = append(ops, app.incUnitCountOp(nil, len(unitNames)))
+		}
 		return ops, nil
 	}
 	// At the last moment before inserting the application, prime status history.
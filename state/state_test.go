@@ -3577,6 +3577,30 @@ func (s *StateSuite) TestIsUpgradeInProgressError(c *gc.C) {
 	c.Assert(state.IsUpgradeInProgressError(errors.Trace(state.UpgradeInProgressError)), jc.IsTrue)
 }
 
+func (s *StateSuite) TestAgentsNotAtVersion(c *gc.C) {
+	current := version.MustParse("2.2.0")
+	next := version.MustParse("2.3.0")
+	machine0, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine0.SetAgentVersion(version.MustParseBinary("2.2.0-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	machine1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine1.SetAgentVersion(version.MustParseBinary("2.1.0-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, nil)
+	unit0, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit0.SetAgentVersion(version.MustParseBinary("2.3.0-quantal-amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	agents, err := s.State.AgentsNotAtVersion(current, next)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agents, jc.DeepEquals, []string{"machine-1", "unit-" + app.Name() + "-1"})
+}
+
 func (s *StateSuite) TestSetModelAgentVersionErrors(c *gc.C) {
 	// Get the agent-version set in the model.
 	envConfig, err := s.IAASModel.ModelConfig()
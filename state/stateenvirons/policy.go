@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -82,13 +83,23 @@ func (p environStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewStorageProviderRegistry(env), nil
+	controllerConfig, err := p.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProviderRegistry(env, controllerConfig), nil
 }
 
 // NewStorageProviderRegistry returns a storage.ProviderRegistry that chains
-// the provided Environ with the common storage providers.
-func NewStorageProviderRegistry(env environs.Environ) storage.ProviderRegistry {
-	return storage.ChainedProviderRegistry{env, provider.CommonStorageProviders()}
+// the provided Environ with the common storage providers, and then with
+// the storage provider plugins configured for the controller. Plugins
+// cannot replace the Environ's or the common storage providers.
+func NewStorageProviderRegistry(env environs.Environ, controllerConfig controller.Config) storage.ProviderRegistry {
+	return storage.ChainedProviderRegistry{
+		env,
+		provider.CommonStorageProviders(),
+		provider.PluginStorageProviders(controllerConfig.StoragePlugins()),
+	}
 }
 
 func environProvider(st *state.State) (environs.EnvironProvider, error) {
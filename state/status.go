@@ -443,7 +443,15 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
-func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
-	return errors.Trace(err)
+// PruneStatusHistory removes the model's status history entries until
+// only the ones newer than maxHistoryTime remain, and the model's
+// history is smaller than maxHistoryMB. The size budget applies to
+// each model separately, so that a model with a lot of history cannot
+// cause another model's history to be pruned.
+func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) (PruneResult, error) {
+	result, err := pruneModelCollection(
+		st, maxHistoryTime, maxHistoryMB,
+		statusesHistoryC, "updated", NanoSeconds, true,
+	)
+	return result, errors.Trace(err)
 }
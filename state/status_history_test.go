@@ -39,8 +39,11 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(s.State, 0, 1)
+	result, err := state.PruneStatusHistory(s.State, 0, 1)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.DeletedByAge, gc.Equals, 0)
+	c.Assert(result.DeletedBySize, jc.GreaterThan, 10000)
+	c.Assert(result.ReclaimedBytes, jc.GreaterThan, int64(0))
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
 	c.Assert(err, jc.ErrorIsNil)
@@ -52,32 +55,39 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Assert(historyLen, jc.LessThan, 10000)
 }
 
-func (s *StatusHistorySuite) TestPruneStatusBySizeOnlyForController(c *gc.C) {
+func (s *StatusHistorySuite) TestPruneStatusHistoryBySizePerModel(c *gc.C) {
 	clock := testing.NewClock(coretesting.NonZeroTime())
 	err := s.State.SetClockForTesting(clock)
 	c.Assert(err, jc.ErrorIsNil)
+	controllerUnit := s.Factory.MakeUnit(c, nil)
+	state.PrimeUnitStatusHistory(c, clock, controllerUnit, status.Active, 100, 1000, nil)
+
 	st := s.Factory.MakeModel(c, &factory.ModelParams{})
 	defer st.Close()
-
 	localFactory := factory.NewFactory(st)
 	application := localFactory.MakeApplication(c, nil)
 	unit := localFactory.MakeUnit(c, &factory.UnitParams{Application: application})
 	state.PrimeUnitStatusHistory(c, clock, unit, status.Active, 20000, 1000, nil)
 
-	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	// The controller model's history is within its budget,
+	// however large the hosted model's history is.
+	result, err := state.PruneStatusHistory(s.State, 0, 1)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Logf("%d\n", len(history))
-	c.Assert(history, gc.HasLen, 20001)
+	c.Assert(result, jc.DeepEquals, state.PruneResult{})
 
-	err = state.PruneStatusHistory(st, 0, 1)
+	// The hosted model's history is pruned to its own budget,
+	// without touching the controller model's history.
+	result, err = state.PruneStatusHistory(st, 0, 1)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.DeletedBySize, jc.GreaterThan, 10000)
 
-	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
 	c.Assert(err, jc.ErrorIsNil)
-	historyLen := len(history)
+	c.Assert(len(history), jc.LessThan, 10000)
 
-	// Pruning by size should only be done for the controller state.
-	c.Assert(historyLen, gc.Equals, 20001)
+	history, err = controllerUnit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 101)
 }
 
 func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
@@ -124,8 +134,10 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 9-i, 24*time.Hour)
 	}
 
-	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	result, err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.DeletedByAge, gc.Equals, 320)
+	c.Assert(result.DeletedBySize, gc.Equals, 0)
 
 	history, err = units[0].StatusHistory(status.StatusHistoryFilter{Size: 50})
 	c.Assert(err, jc.ErrorIsNil)
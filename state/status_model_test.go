@@ -66,6 +66,21 @@ func (s *ModelStatusSuite) TestSetUnknownStatus(c *gc.C) {
 	s.checkInitialStatus(c)
 }
 
+func (s *ModelStatusSuite) TestSetSuspendedStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	err := s.model.SetStatus(status.StatusInfo{
+		Status:  status.Suspended,
+		Message: "suspended: cloud credential is not valid",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.model.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Suspended)
+	c.Check(statusInfo.Message, gc.Equals, "suspended: cloud credential is not valid")
+}
+
 func (s *ModelStatusSuite) TestSetOverwritesData(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MaxStatusSnapshotSize is the largest status snapshot, in bytes,
+// that may be stored.
+const MaxStatusSnapshotSize = 8 * 1024 * 1024
+
+// StatusSnapshot holds the status of a model as it was at a point
+// in time. The snapshot data is opaque to state; it is recorded and
+// interpreted by the API server.
+type StatusSnapshot struct {
+	// Taken is the time at which the snapshot was taken.
+	Taken time.Time
+
+	// Data holds the encoded status of the model.
+	Data []byte
+}
+
+type statusSnapshotDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+
+	// Taken is the time the snapshot was taken, in unix nanoseconds.
+	Taken int64 `bson:"taken"`
+
+	Data []byte `bson:"data"`
+}
+
+// AddStatusSnapshot records a snapshot of the model's status, taken
+// now.
+func (m *Model) AddStatusSnapshot(data []byte) error {
+	if len(data) > MaxStatusSnapshotSize {
+		return errors.Errorf("status snapshot is larger than %d bytes", MaxStatusSnapshotSize)
+	}
+	snapshots, closer := m.st.db().GetCollection(statusSnapshotsC)
+	defer closer()
+
+	doc := &statusSnapshotDoc{
+		Taken: m.st.clock().Now().UnixNano(),
+		Data:  data,
+	}
+	if err := snapshots.Writeable().Insert(doc); err != nil {
+		return errors.Annotate(err, "cannot add status snapshot")
+	}
+	return nil
+}
+
+// StatusSnapshotAt returns the most recent snapshot of the model's
+// status taken no later than the given time. If there is no such
+// snapshot, an error satisfying errors.IsNotFound is returned.
+func (m *Model) StatusSnapshotAt(t time.Time) (StatusSnapshot, error) {
+	snapshots, closer := m.st.db().GetCollection(statusSnapshotsC)
+	defer closer()
+
+	var doc statusSnapshotDoc
+	err := snapshots.Find(bson.D{{"taken", bson.D{{"$lte", t.UnixNano()}}}}).Sort("-taken").One(&doc)
+	if err == mgo.ErrNotFound {
+		return StatusSnapshot{}, errors.NotFoundf("status snapshot at %s", t.UTC().Format(time.RFC3339))
+	} else if err != nil {
+		return StatusSnapshot{}, errors.Annotate(err, "cannot get status snapshot")
+	}
+	return StatusSnapshot{
+		Taken: time.Unix(0, doc.Taken).UTC(),
+		Data:  doc.Data,
+	}, nil
+}
+
+// PruneStatusSnapshots removes the model's status snapshots until
+// only the ones newer than now - maxAge remain and the model's
+// snapshots are smaller than maxSizeMB.
+func PruneStatusSnapshots(st *State, maxAge time.Duration, maxSizeMB int) (PruneResult, error) {
+	result, err := pruneModelCollection(
+		st, maxAge, maxSizeMB,
+		statusSnapshotsC, "taken", NanoSeconds, true,
+	)
+	return result, errors.Trace(err)
+}
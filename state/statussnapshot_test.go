@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StatusSnapshotSuite struct {
+	statetesting.StateWithWallClockSuite
+	clock *testing.Clock
+	model *state.Model
+}
+
+var _ = gc.Suite(&StatusSnapshotSuite{})
+
+func (s *StatusSnapshotSuite) SetUpTest(c *gc.C) {
+	s.StateWithWallClockSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StatusSnapshotSuite) addSnapshots(c *gc.C, model *state.Model, data ...string) []time.Time {
+	taken := make([]time.Time, len(data))
+	for i, d := range data {
+		taken[i] = s.clock.Now().UTC()
+		err := model.AddStatusSnapshot([]byte(d))
+		c.Assert(err, jc.ErrorIsNil)
+		s.clock.Advance(time.Hour)
+	}
+	return taken
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotAt(c *gc.C) {
+	taken := s.addSnapshots(c, s.model, "first", "second", "third")
+
+	for i, t := range []struct {
+		at       time.Time
+		expected int
+	}{
+		{at: taken[0], expected: 0},
+		{at: taken[1].Add(-time.Second), expected: 0},
+		{at: taken[1], expected: 1},
+		{at: taken[2].Add(time.Minute), expected: 2},
+		{at: s.clock.Now().Add(24 * time.Hour), expected: 2},
+	} {
+		c.Logf("test %d: %v", i, t.at)
+		snapshot, err := s.model.StatusSnapshotAt(t.at)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(snapshot.Taken, gc.Equals, taken[t.expected])
+		c.Check(string(snapshot.Data), gc.Equals, []string{"first", "second", "third"}[t.expected])
+	}
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotAtNotFound(c *gc.C) {
+	taken := s.addSnapshots(c, s.model, "first")
+	_, err := s.model.StatusSnapshotAt(taken[0].Add(-time.Second))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "status snapshot at .* not found")
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotAtOtherModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{})
+	defer st.Close()
+	err := st.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.addSnapshots(c, other, "other")
+
+	_, err = s.model.StatusSnapshotAt(s.clock.Now())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StatusSnapshotSuite) TestAddStatusSnapshotTooLarge(c *gc.C) {
+	err := s.model.AddStatusSnapshot(make([]byte, state.MaxStatusSnapshotSize+1))
+	c.Assert(err, gc.ErrorMatches, "status snapshot is larger than 8388608 bytes")
+}
+
+func (s *StatusSnapshotSuite) TestPruneStatusSnapshotsByAge(c *gc.C) {
+	taken := s.addSnapshots(c, s.model, "first", "second", "third")
+
+	// The clock is now an hour after the last snapshot.
+	result, err := state.PruneStatusSnapshots(s.State, 150*time.Minute, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.DeletedByAge, gc.Equals, 1)
+
+	_, err = s.model.StatusSnapshotAt(taken[0])
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	snapshot, err := s.model.StatusSnapshotAt(taken[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(snapshot.Data), gc.Equals, "second")
+}
@@ -1001,14 +1001,34 @@ func (im *IAASModel) attachStorageOps(
 	}
 
 	// Check that the unit's charm declares storage with the storage
-	// instance's storage name.
+	// instance's storage name, of the same kind, and no larger than
+	// the storage instance. Detached storage may be attached to a
+	// unit of a different application, so long as the application's
+	// charm declares compatible storage.
 	charmMeta := ch.Meta()
-	if _, ok := charmMeta.Storage[si.StorageName()]; !ok {
+	charmStorage, ok := charmMeta.Storage[si.StorageName()]
+	if !ok {
 		return nil, errors.Errorf(
 			"charm %s has no storage called %s",
 			charmMeta.Name, si.StorageName(),
 		)
 	}
+	if string(charmStorage.Type) != si.Kind().String() {
+		return nil, errors.Errorf(
+			"charm %s storage %s is %s, %s is %s",
+			charmMeta.Name, si.StorageName(), charmStorage.Type,
+			names.ReadableString(si.StorageTag()), si.Kind(),
+		)
+	}
+	if size := si.doc.Constraints.Size; charmStorage.MinimumSize > 0 && size < charmStorage.MinimumSize {
+		return nil, errors.Errorf(
+			"charm %s storage %s: minimum storage size is %s, %s is %s",
+			charmMeta.Name, si.StorageName(),
+			humanize.Bytes(charmStorage.MinimumSize*humanize.MByte),
+			names.ReadableString(si.StorageTag()),
+			humanize.Bytes(size*humanize.MByte),
+		)
+	}
 
 	// Create a storage attachment doc, ensuring that the storage instance
 	// owner does not change, and that both the storage instance and unit
@@ -1594,11 +1614,9 @@ func validateStorageConstraintsAgainstCharm(
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
 		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
+			if err := validateSharedStoragePool(im, cons.Pool, charmStorage); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
 		}
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
@@ -1748,6 +1766,26 @@ func poolStorageProvider(im *IAASModel, poolName string) (storage.ProviderType,
 	return providerType, provider, nil
 }
 
+// validateSharedStoragePool returns an error if storage from the
+// specified pool cannot be shared by the units of an application.
+func validateSharedStoragePool(im *IAASModel, poolName string, charmStorage charm.Storage) error {
+	if charmStorage.Type != charm.StorageFilesystem {
+		return errors.NotSupportedf("shared %s storage", charmStorage.Type)
+	}
+	if poolName == "" {
+		return errors.New("pool name is required")
+	}
+	_, provider, err := poolStorageProvider(im, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sharer, ok := provider.(storage.SharedStorageProvider)
+	if !ok || !sharer.SupportsSharing(storage.StorageKindFilesystem) {
+		return errors.Errorf("storage pool %q does not support shared storage", poolName)
+	}
+	return nil
+}
+
 // ErrNoDefaultStoragePool is returned when a storage pool is required but none
 // is specified nor available as a default.
 var ErrNoDefaultStoragePool = fmt.Errorf("no storage pool specifed and no default available")
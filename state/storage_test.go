@@ -839,6 +839,226 @@ func (s *StorageStateSuite) TestAttachStorageAssignedMachineExistingVolumeAttach
 	)
 }
 
+func (s *StorageStateSuite) TestAttachStorageOtherApplication(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Detached storage may be attached to a unit of another
+	// application that uses the same charm.
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	app2 := s.AddTestingApplication(c, "secondwind", ch)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+}
+
+func (s *StorageStateSuite) TestAttachStorageKindMismatch(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "storage-filesystem", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		CountMin: 0,
+		CountMax: 2,
+	})
+	app2 := s.AddTestingApplication(c, "secondwind", ch)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit secondwind/0: charm storage-filesystem storage data is filesystem, storage data/0 is block`,
+	)
+}
+
+func (s *StorageStateSuite) TestAttachStorageMinimumSize(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "storage-block-big", charm.Storage{
+		Name:        "data",
+		Type:        charm.StorageBlock,
+		CountMin:    0,
+		CountMax:    2,
+		MinimumSize: 2048,
+	})
+	app2 := s.AddTestingApplication(c, "secondwind", ch)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit secondwind/0: charm storage-block-big storage data: minimum storage size is 2.0GB, storage data/0 is 1.0GB`,
+	)
+}
+
+func (s *StorageStateSuite) TestAttachStorageOtherApplicationAssignedMachine(c *gc.C) {
+	// Create volume-backed filesystem storage, and assign the unit
+	// to a machine so that the volume and filesystem are attached
+	// to the machine.
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "filesystem", "modelscoped-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	oldMachineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	oldMachineTag := names.NewMachineTag(oldMachineId)
+	volume := s.storageInstanceVolume(c, storageTag)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+
+	// Detach the storage, and remove the machine attachments as
+	// the storage provisioner would.
+	err = s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.RemoveFilesystemAttachment(oldMachineTag, filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.RemoveVolumeAttachment(oldMachineTag, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Add a unit of another application using the same charm,
+	// assigned to another machine.
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	app2 := s.AddTestingApplication(c, "secondwind", ch)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), oldMachineId)
+	machineTag := names.NewMachineTag(machineId)
+
+	w := s.IAASModel.WatchStorageAttachments(u2.UnitTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Attaching the storage to the unit attaches the existing volume
+	// and filesystem to the unit's machine, for the storage provisioner
+	// to act on, and notifies the unit's storage attachment watcher,
+	// which triggers the unit's storage-attached hook.
+	err = s.IAASModel.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(storageTag.Id())
+	wc.AssertNoChange()
+
+	s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+}
+
+func (s *StorageStateSuite) addSharedStorageApplication(c *gc.C, pool string, numUnits int) (*state.Application, error) {
+	ch := s.createStorageCharm(c, "storage-shared", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+		Location: "/srv/data",
+	})
+	return s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "storage-shared",
+		Series:   "quantal",
+		Charm:    ch,
+		NumUnits: numUnits,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons(pool, 1024, 1),
+		},
+	})
+}
+
+func (s *StorageStateSuite) addSharedFSPool(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
+	_, err := pm.Create("nfs", provider.SharedFSProviderType, map[string]interface{}{
+		"fstype": "nfs4",
+		"source": "nas.example.com:/export/data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorage(c *gc.C) {
+	s.addSharedFSPool(c)
+	app, err := s.addSharedStorageApplication(c, "nfs", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// One storage instance is created, owned by
+	// the application rather than by any unit.
+	all, err := s.IAASModel.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	storageTag := names.NewStorageTag("data/0")
+	c.Assert(all[0].StorageTag(), gc.Equals, storageTag)
+	c.Assert(all[0].Kind(), gc.Equals, state.StorageKindFilesystem)
+	owner, ok := all[0].Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.ApplicationTag())
+
+	// Units added later are attached to the same storage.
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.IAASModel.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	units := make([]names.UnitTag, len(attachments))
+	for i, a := range attachments {
+		units[i] = a.Unit()
+	}
+	c.Assert(units, jc.SameContents, []names.UnitTag{
+		names.NewUnitTag("storage-shared/0"),
+		names.NewUnitTag("storage-shared/1"),
+		names.NewUnitTag("storage-shared/2"),
+	})
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStoragePoolNotShareable(c *gc.C) {
+	_, err := s.addSharedStorageApplication(c, "modelscoped", 1)
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-shared": charm "storage-shared" store "data": storage pool "modelscoped" does not support shared storage`)
+}
+
+func (s *StorageStateSuite) TestDestroyUnitDetachesSharedStorage(c *gc.C) {
+	s.addSharedFSPool(c)
+	app, err := s.addSharedStorageApplication(c, "nfs", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageTag := names.NewStorageTag("data/0")
+	u, err := s.State.Unit("storage-shared/0")
+	c.Assert(err, jc.ErrorIsNil)
+	op := u.DestroyOperation()
+	op.DestroyStorage = true
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCleanupRuns(c, s.State)
+
+	// The storage is detached from the destroyed unit, which is
+	// not assigned to a machine, and remains attached to the
+	// other unit.
+	_, err = s.IAASModel.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	si, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	owner, _ := si.Owner()
+	c.Assert(owner, gc.Equals, app.ApplicationTag())
+	_, err = s.IAASModel.StorageAttachment(storageTag, names.NewUnitTag("storage-shared/1"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *StorageStateSuite) TestAddApplicationAttachStorage(c *gc.C) {
 	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
 
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/loggo"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/trace"
+)
+
+var traceLogger = loggo.GetLogger("juju.state.trace")
+
+// WithTraceID returns a State whose transactions are logged, at
+// debug level to the juju.state.trace module, marked with the given
+// trace ID, so that the changes made on behalf of an API request can
+// be found with debug-log --include-trace. The returned State shares
+// st's resources and must not be closed. If id is empty, st itself
+// is returned.
+func (st *State) WithTraceID(id string) *State {
+	if id == "" {
+		return st
+	}
+	traced := *st
+	traced.database = tracedDatabase{
+		Database: st.database,
+		traceID:  id,
+	}
+	return &traced
+}
+
+// tracedDatabase is a Database that logs the transactions it runs
+// marked with a trace ID.
+type tracedDatabase struct {
+	Database
+	traceID string
+}
+
+// RunTransaction is part of the Database interface.
+func (db tracedDatabase) RunTransaction(ops []txn.Op) error {
+	err := db.Database.RunTransaction(ops)
+	db.logTransaction(ops, err)
+	return err
+}
+
+// RunTransactionFor is part of the Database interface.
+func (db tracedDatabase) RunTransactionFor(modelUUID string, ops []txn.Op) error {
+	err := db.Database.RunTransactionFor(modelUUID, ops)
+	db.logTransaction(ops, err)
+	return err
+}
+
+// RunRawTransaction is part of the Database interface.
+func (db tracedDatabase) RunRawTransaction(ops []txn.Op) error {
+	err := db.Database.RunRawTransaction(ops)
+	db.logTransaction(ops, err)
+	return err
+}
+
+// Run is part of the Database interface.
+func (db tracedDatabase) Run(transactions jujutxn.TransactionSource) error {
+	var ops []txn.Op
+	err := db.Database.Run(func(attempt int) ([]txn.Op, error) {
+		var err error
+		ops, err = transactions(attempt)
+		return ops, err
+	})
+	if len(ops) > 0 {
+		db.logTransaction(ops, err)
+	}
+	return err
+}
+
+func (db tracedDatabase) logTransaction(ops []txn.Op, err error) {
+	if !traceLogger.IsDebugEnabled() {
+		return
+	}
+	if err != nil {
+		traceLogger.Debugf("transaction on %s failed: %v (%s)", describeOps(ops), err, trace.Marker(db.traceID))
+		return
+	}
+	traceLogger.Debugf("transaction on %s applied (%s)", describeOps(ops), trace.Marker(db.traceID))
+}
+
+// describeOps returns a short description of the documents that
+// the given transaction operations change.
+func describeOps(ops []txn.Op) string {
+	docs := make([]string, len(ops))
+	for i, op := range ops {
+		action := "assert"
+		switch {
+		case op.Insert != nil:
+			action = "insert"
+		case op.Update != nil:
+			action = "update"
+		case op.Remove:
+			action = "remove"
+		}
+		docs[i] = fmt.Sprintf("%s %s/%v", action, op.C, op.Id)
+	}
+	return strings.Join(docs, ", ")
+}
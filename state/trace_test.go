@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type TraceSuite struct {
+	ConnSuite
+	writer loggo.TestWriter
+}
+
+var _ = gc.Suite(&TraceSuite{})
+
+func (s *TraceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.writer.Clear()
+	c.Assert(loggo.RegisterWriter("trace-tester", &s.writer), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { loggo.RemoveWriter("trace-tester") })
+	logger := loggo.GetLogger("juju.state.trace")
+	oldLevel := logger.LogLevel()
+	logger.SetLogLevel(loggo.DEBUG)
+	s.AddCleanup(func(*gc.C) { logger.SetLogLevel(oldLevel) })
+}
+
+func (s *TraceSuite) TestTransactionsMarked(c *gc.C) {
+	traced := s.State.WithTraceID("abcd")
+	c.Assert(traced, gc.Not(gc.Equals), s.State)
+	_, err := traced.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.writer.Log(), jc.LogMatches, jc.SimpleMessages{{
+		loggo.DEBUG,
+		`transaction on .*insert machines/.*0.* applied \("trace-id":"abcd"\)`,
+	}})
+}
+
+func (s *TraceSuite) TestUntracedTransactionsNotLogged(c *gc.C) {
+	c.Assert(s.State.WithTraceID(""), gc.Equals, s.State)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.writer.Log(), gc.Not(jc.LogMatches), jc.SimpleMessages{{
+		loggo.DEBUG,
+		`transaction on .*`,
+	}})
+}
@@ -87,6 +87,7 @@ type unitDoc struct {
 	Subordinates           []string
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
+	MovingTo               string `bson:"movingto,omitempty"`
 	Resolved               ResolvedMode
 	Tools                  *tools.Tools `bson:",omitempty"`
 	Life                   Life
@@ -1364,6 +1365,10 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	healthAssert, err := checkMachineHealthy(u.st, m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := u.st.machineStorageOps(
 		&m.doc, storageParams,
 	)
@@ -1392,7 +1397,7 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 			{{"machineid", m.Id()}},
 		},
 	}}...)
-	massert := isAliveDoc
+	massert := append(isAliveDoc, healthAssert...)
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
@@ -2105,6 +2110,13 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 		{"clean", true},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
 	}
+	block, err := u.st.blockUnhealthyMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if block {
+		terms = append(terms, healthyMachineTerm()...)
+	}
 	// Add the container filter term if necessary.
 	var containerType instance.ContainerType
 	if cons.Container != nil {
@@ -2358,21 +2370,12 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
-
-	// If the action is predefined inside juju, get spec from map
-	spec, ok := actions.PredefinedActionsSpec[name]
-	if !ok {
-		specs, err := u.ActionSpecs()
-		if err != nil {
-			return nil, err
-		}
-		spec, ok = specs[name]
-		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
-		}
+	spec, err := u.actionSpec(name)
+	if err != nil {
+		return nil, err
 	}
 	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
+	err = spec.ValidateParams(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -2389,6 +2392,24 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
 }
 
+// actionSpec returns the spec for the named action, which may be
+// predefined by juju or defined by the unit's charm.
+func (u *Unit) actionSpec(name string) (charm.ActionSpec, error) {
+	// If the action is predefined inside juju, get spec from map
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		specs, err := u.ActionSpecs()
+		if err != nil {
+			return charm.ActionSpec{}, err
+		}
+		spec, ok = specs[name]
+		if !ok {
+			return charm.ActionSpec{}, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+		}
+	}
+	return spec, nil
+}
+
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
 func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 	none := ActionSpecsByName{}
@@ -0,0 +1,171 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MovingTo returns the id of the machine to which the unit is being
+// moved, or "" if it is not being moved.
+func (u *Unit) MovingTo() string {
+	return u.doc.MovingTo
+}
+
+// MoveToMachine requests that the principal unit, along with its
+// subordinates, be moved from its current machine to the given one.
+//
+// The unit remains assigned to its current machine until the move is
+// completed by CompleteMove. This is normally called by the unit's
+// agent once it has run the charm's stop hook, after which the unit
+// agent on the old machine is removed, and a new one is deployed to
+// the new machine. A move that has been requested but not completed
+// may be redirected to another machine by calling MoveToMachine
+// again.
+//
+// Units with storage attachments cannot currently be moved.
+func (u *Unit) MoveToMachine(m *Machine) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot move unit %q to machine %s", u, m)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, m := u, m // don't change outer vars
+		if attempt > 0 {
+			var err error
+			u, err = u.st.Unit(u.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			m, err = u.st.Machine(m.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return u.moveToMachineOps(m)
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.MovingTo = m.Id()
+	return nil
+}
+
+func (u *Unit) moveToMachineOps(m *Machine) ([]txn.Op, error) {
+	if !u.IsPrincipal() {
+		return nil, errors.New("unit is a subordinate")
+	}
+	if u.doc.Life != Alive {
+		return nil, unitNotAliveErr
+	}
+	if u.doc.MachineId == "" {
+		return nil, unitNotAssignedError(u)
+	}
+	if u.doc.MovingTo == m.Id() {
+		return nil, jujutxn.ErrNoOperations
+	}
+	if u.doc.MachineId == m.Id() {
+		return nil, errors.Errorf("unit is already assigned to machine %s", m)
+	}
+	if u.doc.StorageAttachmentCount > 0 {
+		return nil, errors.NotSupportedf("moving units with storage")
+	}
+	if err := validateUnitMachineAssignment(m, u.doc.Series, false, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:  unitsC,
+		Id: u.doc.DocID,
+		Assert: append(isAliveDoc, bson.D{
+			{"machineid", u.doc.MachineId},
+			{"movingto", bson.D{{"$in", []interface{}{nil, u.doc.MovingTo}}}},
+			{"storageattachmentcount", 0},
+		}...),
+		Update: bson.D{{"$set", bson.D{{"movingto", m.Id()}}}},
+	}, {
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: isAliveDoc,
+	}}, nil
+}
+
+// CompleteMove completes a move of the unit requested with
+// MoveToMachine, assigning the unit to the machine to which it is
+// being moved. The ports opened by the unit and its subordinates on
+// the old machine are closed. CompleteMove does nothing if the unit
+// is not being moved.
+func (u *Unit) CompleteMove() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete move of unit %q", u)
+	var machineId string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.MovingTo == "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if u.doc.Life != Alive {
+			return nil, unitNotAliveErr
+		}
+		m, err := u.st.Machine(u.doc.MovingTo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Life() != Alive {
+			return nil, machineNotAliveErr
+		}
+		machineId = m.Id()
+		return u.completeMoveOps(m)
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if machineId != "" {
+		u.doc.MachineId = machineId
+		u.doc.MovingTo = ""
+	}
+	return nil
+}
+
+func (u *Unit) completeMoveOps(m *Machine) ([]txn.Op, error) {
+	unitNames := set.NewStrings(u.doc.Name)
+	for _, name := range u.doc.Subordinates {
+		unitNames.Add(name)
+	}
+	portsOps, err := removeMachinePortsForUnitsOps(u.st, u.doc.MachineId, unitNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:  unitsC,
+		Id: u.doc.DocID,
+		Assert: append(isAliveDoc, bson.D{
+			{"machineid", u.doc.MachineId},
+			{"movingto", m.Id()},
+			{"subordinates", u.doc.Subordinates},
+			{"storageattachmentcount", 0},
+		}...),
+		Update: bson.D{
+			{"$set", bson.D{{"machineid", m.Id()}}},
+			{"$unset", bson.D{{"movingto", nil}}},
+		},
+	}, {
+		C:      machinesC,
+		Id:     u.st.docID(u.doc.MachineId),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"principals", u.doc.Name}}}},
+	}, {
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{
+			{"$addToSet", bson.D{{"principals", u.doc.Name}}},
+			{"$set", bson.D{{"clean", false}}},
+		},
+	}}
+	return append(ops, portsOps...), nil
+}
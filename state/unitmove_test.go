@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UnitMoveSuite struct {
+	ConnSuite
+	unit     *state.Unit
+	machine0 *state.Machine
+	machine1 *state.Machine
+}
+
+var _ = gc.Suite(&UnitMoveSuite{})
+
+func (s *UnitMoveSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine0, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.machine1, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(s.machine0)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitMoveSuite) addSubordinate(c *gc.C) *state.Unit {
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	sub, err := s.State.Unit("logging/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	return sub
+}
+
+func (s *UnitMoveSuite) TestMoveToMachine(c *gc.C) {
+	err := s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MovingTo(), gc.Equals, s.machine1.Id())
+
+	// The unit stays where it is until the move is completed.
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MovingTo(), gc.Equals, s.machine1.Id())
+	id, err := s.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, s.machine0.Id())
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineRetarget(c *gc.C) {
+	machine2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.MoveToMachine(machine2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MovingTo(), gc.Equals, machine2.Id())
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineSameMachine(c *gc.C) {
+	err := s.unit.MoveToMachine(s.machine0)
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "wordpress/0" to machine 0: unit is already assigned to machine 0`)
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineNotAssigned(c *gc.C) {
+	err := s.unit.UnassignFromMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "wordpress/0" to machine 1: unit "wordpress/0" is not assigned to a machine`)
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineSubordinate(c *gc.C) {
+	sub := s.addSubordinate(c)
+	err := sub.MoveToMachine(s.machine1)
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "logging/0" to machine 1: unit is a subordinate`)
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineDyingMachine(c *gc.C) {
+	err := s.machine1.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "wordpress/0" to machine 1: machine is not alive`)
+}
+
+func (s *UnitMoveSuite) TestMoveToMachineDyingUnit(c *gc.C) {
+	preventUnitDestroyRemove(c, s.unit)
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, gc.ErrorMatches, `cannot move unit "wordpress/0" to machine 1: unit is not alive`)
+}
+
+func (s *UnitMoveSuite) TestCompleteMove(c *gc.C) {
+	sub := s.addSubordinate(c)
+	err := s.unit.OpenPorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sub.OpenPorts("tcp", 514, 514)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.CompleteMove()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MovingTo(), gc.Equals, "")
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MovingTo(), gc.Equals, "")
+	id, err := s.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, s.machine1.Id())
+	id, err = sub.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, s.machine1.Id())
+
+	err = s.machine0.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine0.Principals(), gc.HasLen, 0)
+	err = s.machine1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine1.Principals(), jc.DeepEquals, []string{"wordpress/0"})
+
+	ports, err := s.machine0.AllPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, p := range ports {
+		c.Check(p.PortsForUnit(s.unit.Name()), gc.HasLen, 0)
+		c.Check(p.PortsForUnit(sub.Name()), gc.HasLen, 0)
+	}
+}
+
+func (s *UnitMoveSuite) TestCompleteMoveNotMoving(c *gc.C) {
+	err := s.unit.CompleteMove()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, s.machine0.Id())
+}
+
+func (s *UnitMoveSuite) TestCompleteMoveMachineDying(c *gc.C) {
+	err := s.unit.MoveToMachine(s.machine1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine1.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.CompleteMove()
+	c.Assert(err, gc.ErrorMatches, `cannot complete move of unit "wordpress/0": machine is not alive`)
+}
@@ -94,6 +94,7 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		}
 		user.doc.PasswordHash = utils.UserPasswordHash(password, salt)
 		user.doc.PasswordSalt = salt
+		user.doc.PasswordChanged = dateCreated
 	}
 
 	ops := []txn.Op{{
@@ -282,6 +283,16 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+
+	// PasswordChanged records when the password was last set. It is
+	// not set for users whose password predates it being recorded.
+	PasswordChanged time.Time `bson:"passwordchanged,omitempty"`
+
+	// FailedLogins holds the number of consecutive failed password
+	// logins, and LockedAt the time at which too many of them
+	// locked the user out.
+	FailedLogins int       `bson:"failedlogins,omitempty"`
+	LockedAt     time.Time `bson:"lockedat,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -419,9 +430,11 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 		// explicit check before login.
 		return errors.Annotate(err, "cannot set password hash")
 	}
+	changed := u.st.nowToTheSecond()
 	update := bson.D{{"$set", bson.D{
 		{"passwordhash", pwHash},
 		{"passwordsalt", pwSalt},
+		{"passwordchanged", changed},
 	}}}
 	if u.doc.SecretKey != nil {
 		update = append(update,
@@ -439,10 +452,84 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 	}
 	u.doc.PasswordHash = pwHash
 	u.doc.PasswordSalt = pwSalt
+	u.doc.PasswordChanged = changed
 	u.doc.SecretKey = nil
 	return nil
 }
 
+// PasswordChanged returns when the user's password was last set. For
+// users whose password predates this being recorded, the time the user
+// was created is returned.
+func (u *User) PasswordChanged() time.Time {
+	if u.doc.PasswordChanged.IsZero() {
+		return u.doc.DateCreated
+	}
+	return u.doc.PasswordChanged
+}
+
+// FailedLogins returns the number of consecutive failed password
+// logins recorded for the user.
+func (u *User) FailedLogins() int {
+	return u.doc.FailedLogins
+}
+
+// LockedAt returns when the user was locked out after too many failed
+// logins, or the zero time if the user has not been locked out.
+// Whether the user remains locked out depends on the controller's
+// password policy.
+func (u *User) LockedAt() time.Time {
+	return u.doc.LockedAt
+}
+
+// RecordFailedLogin records a failed password login for the user. If
+// the number of consecutive failed logins reaches the given threshold,
+// the user is locked out. A threshold of zero never locks out the user.
+func (u *User) RecordFailedLogin(threshold int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := u.ensureNotDeleted(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		update := bson.D{{"$inc", bson.D{{"failedlogins", 1}}}}
+		if threshold > 0 && u.doc.FailedLogins+1 >= threshold && u.doc.LockedAt.IsZero() {
+			update = append(update, bson.DocElem{
+				"$set", bson.D{{"lockedat", u.st.nowToTheSecond()}},
+			})
+		}
+		return []txn.Op{{
+			C:      usersC,
+			Id:     u.Name(),
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record failed login for user %q", u.Name())
+	}
+	return errors.Trace(u.Refresh())
+}
+
+// Unlock clears the user's failed logins, lifting any lockout.
+func (u *User) Unlock() error {
+	if err := u.ensureNotDeleted(); err != nil {
+		return errors.Annotate(err, "cannot unlock")
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     u.Name(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{
+			{"failedlogins", ""},
+			{"lockedat", ""},
+		}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot unlock user %q", u.Name())
+	}
+	u.doc.FailedLogins = 0
+	u.doc.LockedAt = time.Time{}
+	return nil
+}
+
 // PasswordValid returns whether the given password is valid for the User. The
 // caller should call user.Refresh before calling this.
 func (u *User) PasswordValid(password string) bool {
@@ -279,6 +279,65 @@ func (s *UserSuite) TestDisable(c *gc.C) {
 	c.Assert(s.activeUsers(c), jc.DeepEquals, []string{"test-admin", user.Name()})
 }
 
+func (s *UserSuite) TestPasswordChanged(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "a-password"})
+	c.Assert(user.PasswordChanged(), gc.Equals, user.DateCreated())
+
+	err := user.SetPassword("another-password")
+	c.Assert(err, jc.ErrorIsNil)
+	changed := user.PasswordChanged()
+	c.Assert(changed.Before(user.DateCreated()), jc.IsFalse)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.PasswordChanged().Equal(changed), jc.IsTrue)
+}
+
+func (s *UserSuite) TestRecordFailedLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "a-password"})
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+
+	for i := 0; i < 2; i++ {
+		err := user.RecordFailedLogin(3)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(user.FailedLogins(), gc.Equals, 2)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+
+	err := user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 3)
+	lockedAt := user.LockedAt()
+	c.Assert(lockedAt.IsZero(), jc.IsFalse)
+
+	// Further failures do not move the lockout time.
+	err = user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 4)
+	c.Assert(user.LockedAt().Equal(lockedAt), jc.IsTrue)
+
+	err = user.Unlock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+}
+
+func (s *UserSuite) TestRecordFailedLoginNoThreshold(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "a-password"})
+	for i := 0; i < 5; i++ {
+		err := user.RecordFailedLogin(0)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(user.FailedLogins(), gc.Equals, 5)
+	c.Assert(user.LockedAt().IsZero(), jc.IsTrue)
+}
+
 func (s *UserSuite) activeUsers(c *gc.C) []string {
 	users, err := s.State.AllUsers(false)
 	c.Assert(err, jc.ErrorIsNil)
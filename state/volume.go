@@ -851,6 +851,73 @@ func (im *IAASModel) addVolumeOps(params VolumeParams, machineId string) ([]txn.
 	return im.newVolumeOps(doc, statusDoc), names.NewVolumeTag(name), nil
 }
 
+// AddExistingVolume imports an existing, already-provisioned
+// volume into the model. The volume will start out with the status
+// "detached". The volume will be associated with the given storage
+// name, with the allocated storage tag being returned.
+func (im *IAASModel) AddExistingVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume")
+	if err := validateAddExistingVolume(im, info, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(im.mb, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	volumeOps, _, err := im.addVolumeOps(
+		VolumeParams{
+			Pool:       info.Pool,
+			Size:       info.Size,
+			volumeInfo: &info,
+			storage:    storageTag,
+		},
+		"", // no machine ID
+	)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindBlock,
+			StorageName: storageName,
+			Constraints: storageInstanceConstraints{
+				Pool: info.Pool,
+				Size: info.Size,
+			},
+		},
+	}}
+	ops = append(ops, volumeOps...)
+	if err := im.mb.db().RunTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+func validateAddExistingVolume(im *IAASModel, info VolumeInfo, storageName string) error {
+	if !storage.IsValidPoolName(info.Pool) {
+		return errors.NotValidf("pool name %q", info.Pool)
+	}
+	if !storageNameRE.MatchString(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.VolumeId == "" {
+		return errors.NotValidf("empty volume ID")
+	}
+	_, provider, err := poolStorageProvider(im, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return errors.NotSupportedf("volumes in pool %q", info.Pool)
+	}
+	return nil
+}
+
 func (im *IAASModel) newVolumeOps(doc volumeDoc, status statusDoc) []txn.Op {
 	return []txn.Op{
 		createStatusOp(im.mb, volumeGlobalKey(doc.Name), status),
@@ -2773,15 +2773,21 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 // filter function.
 type notifyCollWatcher struct {
 	commonWatcher
-	collName string
-	filter   func(interface{}) bool
-	sink     chan struct{}
+	collNames []string
+	filter    func(interface{}) bool
+	sink      chan struct{}
 }
 
 func newNotifyCollWatcher(backend modelBackend, collName string, filter func(interface{}) bool) NotifyWatcher {
+	return newNotifyCollsWatcher(backend, filter, collName)
+}
+
+// newNotifyCollsWatcher returns a NotifyWatcher that triggers on changes
+// to documents in any of the named collections that pass the filter.
+func newNotifyCollsWatcher(backend modelBackend, filter func(interface{}) bool, collNames ...string) NotifyWatcher {
 	w := &notifyCollWatcher{
 		commonWatcher: newCommonWatcher(backend),
-		collName:      collName,
+		collNames:     collNames,
 		filter:        filter,
 		sink:          make(chan struct{}),
 	}
@@ -2801,8 +2807,10 @@ func (w *notifyCollWatcher) Changes() <-chan struct{} {
 func (w *notifyCollWatcher) loop() error {
 	in := make(chan watcher.Change)
 
-	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
-	defer w.watcher.UnwatchCollection(w.collName, in)
+	for _, collName := range w.collNames {
+		w.watcher.WatchCollectionWithFilter(collName, in, w.filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
 
 	out := w.sink // out set so that initial event is sent.
 	for {
@@ -10,5 +10,9 @@ import (
 )
 
 func NewTestWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator) *Watcher {
-	return newWatcher(changelog, iteratorFunc)
+	return newWatcher(changelog, iteratorFunc, false)
+}
+
+func NewTestTailingWatcher(changelog *mgo.Collection) *Watcher {
+	return newWatcher(changelog, nil, true)
 }
@@ -4,6 +4,11 @@
 // The watcher package provides an interface for observing changes
 // to arbitrary MongoDB documents that are maintained via the
 // mgo/txn transaction package.
+//
+// A single Watcher reads the transaction changelog on behalf of all
+// the watches registered with it, and multiplexes the changes it sees
+// to them; its watches are indexed by collection, so that each change
+// is matched only against the watches of the collection it is in.
 package watcher
 
 import (
@@ -30,8 +35,13 @@ type Watcher struct {
 	iteratorFunc func() mongo.Iterator
 	log          *mgo.Collection
 
-	// watches holds the observers managed by Watch/Unwatch.
-	watches map[watchKey][]watchInfo
+	// tailing is true if the watcher tails the changelog rather than
+	// polling it.
+	tailing bool
+
+	// watches holds the observers managed by Watch/Unwatch, keyed by
+	// collection name.
+	watches map[string]*collectionWatches
 
 	// current holds the current txn-revno values for all the observed
 	// documents known to exist. Documents not observed or deleted are
@@ -96,6 +106,16 @@ func (k watchKey) match(k1 watchKey) bool {
 	return k.id == k1.id
 }
 
+// collectionWatches holds the observers of a single collection.
+type collectionWatches struct {
+	// all holds the observers of every document in the collection.
+	all []watchInfo
+
+	// docs holds the observers of individual documents, keyed by
+	// document id.
+	docs map[interface{}][]watchInfo
+}
+
 type watchInfo struct {
 	ch     chan<- Change
 	revno  int64
@@ -112,17 +132,35 @@ type event struct {
 // It must not be changed when any watchers are active.
 var Period time.Duration = 5 * time.Second
 
+// tailTimeout is the time for which a tailing watcher waits for new
+// changelog entries before checking whether it has been stopped, and
+// the delay before it resumes tailing an empty changelog.
+const tailTimeout = time.Second
+
 // New returns a new Watcher observing the changelog collection,
-// which must be a capped collection maintained by mgo/txn.
+// which must be a capped collection maintained by mgo/txn. The
+// watcher polls the changelog for new entries every Period, or
+// when StartSync is called.
 func New(changelog *mgo.Collection) *Watcher {
-	return newWatcher(changelog, nil)
+	return newWatcher(changelog, nil, false)
+}
+
+// NewTailing returns a new Watcher observing the changelog collection,
+// which must be a capped collection maintained by mgo/txn. Rather than
+// polling the changelog, the watcher follows it with a tailable cursor,
+// so that changes are delivered as soon as they are logged and the
+// database is not queried while nothing changes. StartSync has no
+// effect on a tailing watcher.
+func NewTailing(changelog *mgo.Collection) *Watcher {
+	return newWatcher(changelog, nil, true)
 }
 
-func newWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator) *Watcher {
+func newWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator, tailing bool) *Watcher {
 	w := &Watcher{
 		log:          changelog,
 		iteratorFunc: iteratorFunc,
-		watches:      make(map[watchKey][]watchInfo),
+		tailing:      tailing,
+		watches:      make(map[string]*collectionWatches),
 		current:      make(map[watchKey]int64),
 		request:      make(chan interface{}),
 	}
@@ -130,7 +168,12 @@ func newWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterator) *
 		w.iteratorFunc = w.iter
 	}
 	go func() {
-		err := w.loop(Period)
+		var err error
+		if w.tailing {
+			err = w.tailLoop()
+		} else {
+			err = w.loop(Period)
+		}
 		cause := errors.Cause(err)
 		// tomb expects ErrDying or ErrStillAlive as
 		// exact values, so we need to log and unwrap
@@ -282,6 +325,104 @@ func (w *Watcher) loop(period time.Duration) error {
 	}
 }
 
+// tailLoop implements the main loop of a tailing watcher.
+func (w *Watcher) tailLoop() error {
+	if err := w.initLastId(); err != nil {
+		return errors.Trace(err)
+	}
+	entries := make(chan bson.D)
+	tailErr := make(chan error, 1)
+	go func() {
+		tailErr <- w.tail(w.lastId, entries)
+	}()
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return errors.Trace(tomb.ErrDying)
+		case err := <-tailErr:
+			// As in loop, restart the agent if the txn log
+			// collection overflows from underneath us.
+			if errors.Cause(err) == cappedPositionLostError {
+				return jworker.ErrRestartAgent
+			}
+			return errors.Trace(err)
+		case entry := <-entries:
+			w.lastId = entry[0].Value
+			w.process(entry, make(map[watchKey]bool))
+			w.flush()
+		case req := <-w.request:
+			w.handle(req)
+			w.flush()
+		}
+	}
+}
+
+// tail follows the changelog with a tailable cursor, sending the
+// entries logged after the one with the given id to the entries
+// channel, oldest first, until the watcher is stopped.
+func (w *Watcher) tail(lastId interface{}, entries chan<- bson.D) error {
+	session := w.log.Database.Session.Copy()
+	defer session.Close()
+	changelog := w.log.With(session)
+
+	for {
+		// Tailable cursors cannot be started from a given position,
+		// so skip the entries up to and including lastId. If lastId
+		// is not found, it has been overwritten, and changes have
+		// been missed.
+		found := lastId == nil
+		iter := changelog.Find(nil).Sort("$natural").Tail(tailTimeout)
+		for {
+			var entry bson.D
+			if iter.Next(&entry) {
+				if len(entry) == 0 || entry[0].Name != "_id" {
+					logger.Warningf("changelog document has no leading _id: %#v", entry)
+					continue
+				}
+				if !found {
+					found = entry[0].Value == lastId
+					continue
+				}
+				lastId = entry[0].Value
+				select {
+				case entries <- entry:
+				case <-w.tomb.Dying():
+					iter.Close()
+					return tomb.ErrDying
+				}
+				continue
+			}
+			if iter.Err() != nil || !iter.Timeout() {
+				// The cursor has failed, or died because the
+				// changelog was empty.
+				break
+			}
+			if !found {
+				iter.Close()
+				logger.Warningf("watcher lost its position in the txn log collection")
+				return cappedPositionLostError
+			}
+			select {
+			case <-w.tomb.Dying():
+				iter.Close()
+				return tomb.ErrDying
+			default:
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return errors.Annotate(iterError(err), "watcher iteration error")
+		}
+		if !found {
+			return cappedPositionLostError
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-time.After(tailTimeout):
+		}
+	}
+}
+
 // flush sends all pending events to their respective channels.
 func (w *Watcher) flush() {
 	// refreshEvents are stored newest first.
@@ -327,7 +468,8 @@ func (w *Watcher) handle(req interface{}) {
 	case reqSync:
 		w.needSync = true
 	case reqWatch:
-		for _, info := range w.watches[r.key] {
+		watches := w.watchInfos(r.key)
+		for _, info := range watches {
 			if info.ch == r.info.ch {
 				panic(fmt.Errorf("tried to re-add channel %v for %s", info.ch, r.key))
 			}
@@ -336,14 +478,14 @@ func (w *Watcher) handle(req interface{}) {
 			r.info.revno = revno
 			w.requestEvents = append(w.requestEvents, event{r.info.ch, r.key, revno})
 		}
-		w.watches[r.key] = append(w.watches[r.key], r.info)
+		w.setWatchInfos(r.key, append(watches, r.info))
 	case reqUnwatch:
-		watches := w.watches[r.key]
+		watches := w.watchInfos(r.key)
 		removed := false
 		for i, info := range watches {
 			if info.ch == r.ch {
 				watches[i] = watches[len(watches)-1]
-				w.watches[r.key] = watches[:len(watches)-1]
+				w.setWatchInfos(r.key, watches[:len(watches)-1])
 				removed = true
 				break
 			}
@@ -368,6 +510,39 @@ func (w *Watcher) handle(req interface{}) {
 	}
 }
 
+// watchInfos returns the observers of the given key.
+func (w *Watcher) watchInfos(key watchKey) []watchInfo {
+	cw := w.watches[key.c]
+	if cw == nil {
+		return nil
+	}
+	if key.id == nil {
+		return cw.all
+	}
+	return cw.docs[key.id]
+}
+
+// setWatchInfos sets the observers of the given key, discarding the
+// index entries that are left with no observers.
+func (w *Watcher) setWatchInfos(key watchKey, infos []watchInfo) {
+	cw := w.watches[key.c]
+	if cw == nil {
+		cw = &collectionWatches{docs: make(map[interface{}][]watchInfo)}
+		w.watches[key.c] = cw
+	}
+	switch {
+	case key.id == nil:
+		cw.all = infos
+	case len(infos) == 0:
+		delete(cw.docs, key.id)
+	default:
+		cw.docs[key.id] = infos
+	}
+	if len(cw.all) == 0 && len(cw.docs) == 0 {
+		delete(w.watches, key.c)
+	}
+}
+
 // initLastId reads the most recent changelog document and initializes
 // lastId with it. This causes all history that precedes the creation
 // of the watcher to be ignored.
@@ -414,69 +589,87 @@ func (w *Watcher) sync() error {
 		if id.Value == lastId {
 			break
 		}
-		logger.Tracef("got changelog document: %#v", entry)
-		for _, c := range entry[1:] {
-			// See txn's Runner.ChangeLog for the structure of log entries.
-			var d, r []interface{}
-			dr, _ := c.Value.(bson.D)
-			for _, item := range dr {
-				switch item.Name {
-				case "d":
-					d, _ = item.Value.([]interface{})
-				case "r":
-					r, _ = item.Value.([]interface{})
-				}
+		w.process(entry, seen)
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(iterError(err), "watcher iteration error")
+	}
+	return nil
+}
+
+// iterError returns cappedPositionLostError if the given changelog
+// iteration error indicates that the txn log collection has overflowed,
+// and the error itself otherwise.
+func iterError(err error) error {
+	if qerr, ok := err.(*mgo.QueryError); ok {
+		// CappedPositionLost is code 136.
+		// Just in case that changes for some reason, we'll also check the error message.
+		if qerr.Code == 136 || strings.Contains(qerr.Message, "CappedPositionLost") {
+			logger.Warningf("watcher iterator failed due to txn log collection overflow")
+			return cappedPositionLostError
+		}
+	}
+	return err
+}
+
+// process updates the watcher knowledge from a single changelog entry,
+// and queues events to observing channels. Documents in seen have been
+// processed from newer entries, and are skipped.
+func (w *Watcher) process(entry bson.D, seen map[watchKey]bool) {
+	logger.Tracef("got changelog document: %#v", entry)
+	for _, c := range entry[1:] {
+		// See txn's Runner.ChangeLog for the structure of log entries.
+		var d, r []interface{}
+		dr, _ := c.Value.(bson.D)
+		for _, item := range dr {
+			switch item.Name {
+			case "d":
+				d, _ = item.Value.([]interface{})
+			case "r":
+				r, _ = item.Value.([]interface{})
 			}
-			if len(d) == 0 || len(d) != len(r) {
-				logger.Warningf("changelog has invalid collection document: %#v", c)
+		}
+		if len(d) == 0 || len(d) != len(r) {
+			logger.Warningf("changelog has invalid collection document: %#v", c)
+			continue
+		}
+		for i := len(d) - 1; i >= 0; i-- {
+			key := watchKey{c.Name, d[i]}
+			if seen[key] {
 				continue
 			}
-			for i := len(d) - 1; i >= 0; i-- {
-				key := watchKey{c.Name, d[i]}
-				if seen[key] {
-					continue
-				}
-				seen[key] = true
-				revno, ok := r[i].(int64)
-				if !ok {
-					logger.Warningf("changelog has revno with type %T: %#v", r[i], r[i])
-					continue
-				}
-				if revno < 0 {
-					revno = -1
-				}
-				if w.current[key] == revno {
+			seen[key] = true
+			revno, ok := r[i].(int64)
+			if !ok {
+				logger.Warningf("changelog has revno with type %T: %#v", r[i], r[i])
+				continue
+			}
+			if revno < 0 {
+				revno = -1
+			}
+			if w.current[key] == revno {
+				continue
+			}
+			w.current[key] = revno
+			cw := w.watches[c.Name]
+			if cw == nil {
+				continue
+			}
+			// Queue notifications for per-collection watches.
+			for _, info := range cw.all {
+				if info.filter != nil && !info.filter(d[i]) {
 					continue
 				}
-				w.current[key] = revno
-				// Queue notifications for per-collection watches.
-				for _, info := range w.watches[watchKey{c.Name, nil}] {
-					if info.filter != nil && !info.filter(d[i]) {
-						continue
-					}
+				w.syncEvents = append(w.syncEvents, event{info.ch, key, revno})
+			}
+			// Queue notifications for per-document watches.
+			infos := cw.docs[d[i]]
+			for i, info := range infos {
+				if revno > info.revno || revno < 0 && info.revno >= 0 {
+					infos[i].revno = revno
 					w.syncEvents = append(w.syncEvents, event{info.ch, key, revno})
 				}
-				// Queue notifications for per-document watches.
-				infos := w.watches[key]
-				for i, info := range infos {
-					if revno > info.revno || revno < 0 && info.revno >= 0 {
-						infos[i].revno = revno
-						w.syncEvents = append(w.syncEvents, event{info.ch, key, revno})
-					}
-				}
 			}
 		}
 	}
-	if err := iter.Close(); err != nil {
-		if qerr, ok := err.(*mgo.QueryError); ok {
-			// CappedPositionLost is code 136.
-			// Just in case that changes for some reason, we'll also check the error message.
-			if qerr.Code == 136 || strings.Contains(qerr.Message, "CappedPositionLost") {
-				logger.Warningf("watcher iterator failed due to txn log collection overflow")
-				err = cappedPositionLostError
-			}
-		}
-		return errors.Annotate(err, "watcher iteration error")
-	}
-	return nil
 }
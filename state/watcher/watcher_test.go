@@ -706,6 +706,52 @@ func (s *SlowPeriodSuite) TestStartSyncStartsImmediately(c *gc.C) {
 	}
 }
 
+// TailingSuite runs the FastPeriodSuite tests against
+// a watcher that tails the changelog.
+type TailingSuite struct {
+	FastPeriodSuite
+}
+
+var _ = gc.Suite(&TailingSuite{})
+
+func (s *TailingSuite) SetUpTest(c *gc.C) {
+	s.FastPeriodSuite.SetUpTest(c)
+	c.Assert(s.w.Stop(), gc.IsNil)
+	s.w = watcher.NewTestTailingWatcher(s.log)
+}
+
+func (s *TailingSuite) TestChangesWithoutSync(c *gc.C) {
+	s.w.Watch("test", "a", -1, s.ch)
+	chAll := make(chan watcher.Change)
+	s.w.WatchCollection("test", chAll)
+
+	revno1 := s.insert(c, "test", "a")
+	assertChange(c, s.ch, watcher.Change{"test", "a", revno1})
+	assertChange(c, chAll, watcher.Change{"test", "a", revno1})
+
+	revno2 := s.update(c, "test", "a")
+	assertChange(c, s.ch, watcher.Change{"test", "a", revno2})
+	assertChange(c, chAll, watcher.Change{"test", "a", revno2})
+
+	revno3 := s.insert(c, "test", "b")
+	assertChange(c, chAll, watcher.Change{"test", "b", revno3})
+	assertNoChange(c, s.ch)
+	assertNoChange(c, chAll)
+}
+
+func (s *TailingSuite) TestIgnoreAncientHistory(c *gc.C) {
+	s.insert(c, "test", "a")
+
+	w := watcher.NewTestTailingWatcher(s.log)
+	defer w.Stop()
+
+	w.Watch("test", "a", -1, s.ch)
+	assertNoChange(c, s.ch)
+
+	revno := s.update(c, "test", "a")
+	assertChange(c, s.ch, watcher.Change{"test", "a", revno})
+}
+
 type badIter struct {
 	*mgo.Iter
 
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// WebhookMachineProvisioned is the webhook event sent when a
+	// machine is provisioned with an instance.
+	WebhookMachineProvisioned = "machine-provisioned"
+
+	// WebhookUnitRemoved is the webhook event sent when a unit is
+	// removed.
+	WebhookUnitRemoved = "unit-removed"
+
+	// WebhookApplicationStatusChanged is the webhook event sent when
+	// the status of an application changes.
+	WebhookApplicationStatusChanged = "application-status-changed"
+)
+
+// WebhookEvents holds the events that webhooks may be sent.
+var WebhookEvents = set.NewStrings(
+	WebhookMachineProvisioned,
+	WebhookUnitRemoved,
+	WebhookApplicationStatusChanged,
+)
+
+var validWebhookName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Webhook describes an HTTPS endpoint to which the controller POSTs
+// events about the entities in its models.
+type Webhook struct {
+	// Name uniquely identifies the webhook in the controller.
+	Name string
+
+	// URL is the HTTPS URL to which events are POSTed.
+	URL string
+
+	// Secret is the key used to sign the events sent to the
+	// webhook, so that the receiver can verify their origin.
+	Secret string
+
+	// Events holds the events sent to the webhook. If it is
+	// empty, all events are sent.
+	Events []string
+}
+
+// Validate returns an error if the webhook is not valid.
+func (w Webhook) Validate() error {
+	if !validWebhookName.MatchString(w.Name) {
+		return errors.NotValidf("webhook name %q", w.Name)
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return errors.Annotatef(err, "invalid webhook URL %q", w.URL)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.NotValidf("webhook URL %q (expected an https URL)", w.URL)
+	}
+	if w.Secret == "" {
+		return errors.NotValidf("empty webhook secret")
+	}
+	for _, event := range w.Events {
+		if !WebhookEvents.Contains(event) {
+			return errors.NotValidf("webhook event %q", event)
+		}
+	}
+	return nil
+}
+
+// Wants returns whether the given event should be sent to the webhook.
+func (w Webhook) Wants(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookDoc is the mongo document representation of a webhook.
+type webhookDoc struct {
+	Name   string   `bson:"_id"`
+	URL    string   `bson:"url"`
+	Secret string   `bson:"secret"`
+	Events []string `bson:"events,omitempty"`
+}
+
+// AddWebhook adds a webhook to the controller.
+func (st *State) AddWebhook(webhook Webhook) error {
+	if err := webhook.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      webhooksC,
+		Id:     webhook.Name,
+		Assert: txn.DocMissing,
+		Insert: &webhookDoc{
+			Name:   webhook.Name,
+			URL:    webhook.URL,
+			Secret: webhook.Secret,
+			Events: webhook.Events,
+		},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.AlreadyExistsf("webhook %q", webhook.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add webhook %q", webhook.Name)
+	}
+	return nil
+}
+
+// RemoveWebhook removes the named webhook from the controller.
+func (st *State) RemoveWebhook(name string) error {
+	ops := []txn.Op{{
+		C:      webhooksC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("webhook %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove webhook %q", name)
+	}
+	return nil
+}
+
+// Webhooks returns the controller's webhooks, sorted by name.
+func (st *State) Webhooks() ([]Webhook, error) {
+	coll, closer := st.db().GetCollection(webhooksC)
+	defer closer()
+
+	var docs []webhookDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read webhooks")
+	}
+	webhooks := make([]Webhook, len(docs))
+	for i, doc := range docs {
+		webhooks[i] = Webhook{
+			Name:   doc.Name,
+			URL:    doc.URL,
+			Secret: doc.Secret,
+			Events: doc.Events,
+		}
+	}
+	return webhooks, nil
+}
+
+// WatchWebhooks returns a NotifyWatcher that notifies of changes to
+// the controller's webhooks.
+func (st *State) WatchWebhooks() NotifyWatcher {
+	filter := func(interface{}) bool {
+		return true
+	}
+	return newNotifyCollWatcher(st, webhooksC, filter)
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type WebhooksSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&WebhooksSuite{})
+
+func (s *WebhooksSuite) TestAddWebhook(c *gc.C) {
+	err := s.State.AddWebhook(state.Webhook{
+		Name:   "ops",
+		URL:    "https://ops.example.com/juju",
+		Secret: "sekrit",
+		Events: []string{state.WebhookUnitRemoved},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddWebhook(state.Webhook{
+		Name:   "audit",
+		URL:    "https://audit.example.com/",
+		Secret: "hush",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	webhooks, err := s.State.Webhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, jc.DeepEquals, []state.Webhook{{
+		Name:   "audit",
+		URL:    "https://audit.example.com/",
+		Secret: "hush",
+	}, {
+		Name:   "ops",
+		URL:    "https://ops.example.com/juju",
+		Secret: "sekrit",
+		Events: []string{state.WebhookUnitRemoved},
+	}})
+}
+
+func (s *WebhooksSuite) TestWebhooksGlobal(c *gc.C) {
+	err := s.State.AddWebhook(state.Webhook{
+		Name:   "ops",
+		URL:    "https://ops.example.com/",
+		Secret: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	webhooks, err := st.Webhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, gc.HasLen, 1)
+}
+
+func (s *WebhooksSuite) TestAddWebhookExists(c *gc.C) {
+	webhook := state.Webhook{
+		Name:   "ops",
+		URL:    "https://ops.example.com/",
+		Secret: "sekrit",
+	}
+	err := s.State.AddWebhook(webhook)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddWebhook(webhook)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `webhook "ops" already exists`)
+}
+
+func (s *WebhooksSuite) TestAddWebhookInvalid(c *gc.C) {
+	for i, test := range []struct {
+		webhook state.Webhook
+		err     string
+	}{{
+		webhook: state.Webhook{Name: "Ops", URL: "https://example.com/", Secret: "s"},
+		err:     `webhook name "Ops" not valid`,
+	}, {
+		webhook: state.Webhook{Name: "ops", URL: "http://example.com/", Secret: "s"},
+		err:     `webhook URL "http://example.com/" \(expected an https URL\) not valid`,
+	}, {
+		webhook: state.Webhook{Name: "ops", URL: "https://example.com/"},
+		err:     `empty webhook secret not valid`,
+	}, {
+		webhook: state.Webhook{Name: "ops", URL: "https://example.com/", Secret: "s", Events: []string{"unit-added"}},
+		err:     `webhook event "unit-added" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.AddWebhook(test.webhook)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WebhooksSuite) TestRemoveWebhook(c *gc.C) {
+	err := s.State.AddWebhook(state.Webhook{
+		Name:   "ops",
+		URL:    "https://ops.example.com/",
+		Secret: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveWebhook("ops")
+	c.Assert(err, jc.ErrorIsNil)
+
+	webhooks, err := s.State.Webhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, gc.HasLen, 0)
+
+	err = s.State.RemoveWebhook("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *WebhooksSuite) TestWatchWebhooks(c *gc.C) {
+	w := s.State.WatchWebhooks()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.AddWebhook(state.Webhook{
+		Name:   "ops",
+		URL:    "https://ops.example.com/",
+		Secret: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveWebhook("ops")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *WebhooksSuite) TestWants(c *gc.C) {
+	all := state.Webhook{}
+	c.Assert(all.Wants(state.WebhookUnitRemoved), jc.IsTrue)
+	some := state.Webhook{Events: []string{state.WebhookMachineProvisioned}}
+	c.Assert(some.Wants(state.WebhookMachineProvisioned), jc.IsTrue)
+	c.Assert(some.Wants(state.WebhookUnitRemoved), jc.IsFalse)
+}
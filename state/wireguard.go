@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"golang.org/x/crypto/curve25519"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// WireGuardKey holds a machine's WireGuard key pair, base64 encoded as
+// WireGuard expects. The keys are generated and held by the controller,
+// which hands the private key to the machine's agent and the public key
+// to the machine's peers.
+type WireGuardKey struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// wireGuardKeyDoc represents the MongoDB document that stores the
+// WireGuard key pair of a machine, keyed by the machine's global key.
+type wireGuardKeyDoc struct {
+	PrivateKey string `bson:"private-key"`
+	PublicKey  string `bson:"public-key"`
+}
+
+// newWireGuardKey generates a new Curve25519 key pair.
+func newWireGuardKey() (WireGuardKey, error) {
+	var private, public [32]byte
+	if _, err := rand.Read(private[:]); err != nil {
+		return WireGuardKey{}, errors.Annotate(err, "cannot generate WireGuard private key")
+	}
+	// Clamp the private key as described in the Curve25519 paper.
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+	curve25519.ScalarBaseMult(&public, &private)
+	return WireGuardKey{
+		PrivateKey: base64.StdEncoding.EncodeToString(private[:]),
+		PublicKey:  base64.StdEncoding.EncodeToString(public[:]),
+	}, nil
+}
+
+// WireGuardKey returns the machine's WireGuard key pair, or an error
+// satisfying errors.IsNotFound if none has been generated.
+func (m *Machine) WireGuardKey() (WireGuardKey, error) {
+	coll, closer := m.st.db().GetCollection(wireGuardKeysC)
+	defer closer()
+
+	var doc wireGuardKeyDoc
+	err := coll.FindId(m.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return WireGuardKey{}, errors.NotFoundf("WireGuard key for machine %v", m.Id())
+	} else if err != nil {
+		return WireGuardKey{}, errors.Annotatef(err, "cannot get WireGuard key for machine %v", m.Id())
+	}
+	return WireGuardKey{
+		PrivateKey: doc.PrivateKey,
+		PublicKey:  doc.PublicKey,
+	}, nil
+}
+
+// EnsureWireGuardKey returns the machine's WireGuard key pair,
+// generating and storing a new one if the machine has none. Keys
+// may only be generated for alive machines.
+func (m *Machine) EnsureWireGuardKey() (WireGuardKey, error) {
+	key, err := m.WireGuardKey()
+	if !errors.IsNotFound(err) {
+		return key, errors.Trace(err)
+	}
+	key, err = newWireGuardKey()
+	if err != nil {
+		return WireGuardKey{}, errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := m.WireGuardKey(); err == nil {
+				// Another request generated the key first.
+				return nil, jujutxn.ErrNoOperations
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if m.Life() != Alive {
+				return nil, errors.Errorf("machine is not alive")
+			}
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      wireGuardKeysC,
+			Id:     m.globalKey(),
+			Assert: txn.DocMissing,
+			Insert: wireGuardKeyDoc{
+				PrivateKey: key.PrivateKey,
+				PublicKey:  key.PublicKey,
+			},
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return WireGuardKey{}, errors.Annotatef(err, "cannot set WireGuard key for machine %v", m.Id())
+	}
+	return m.WireGuardKey()
+}
+
+// removeWireGuardKeyOp returns the operation needed to remove the
+// WireGuard key document associated with the given globalKey.
+func removeWireGuardKeyOp(globalKey string) txn.Op {
+	return txn.Op{
+		C:      wireGuardKeysC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
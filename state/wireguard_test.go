@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"encoding/base64"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type WireGuardKeySuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&WireGuardKeySuite{})
+
+func (s *WireGuardKeySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *WireGuardKeySuite) TestWireGuardKeyNotFound(c *gc.C) {
+	_, err := s.machine.WireGuardKey()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *WireGuardKeySuite) TestEnsureWireGuardKey(c *gc.C) {
+	key, err := s.machine.EnsureWireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, encoded := range []string{key.PrivateKey, key.PublicKey} {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(raw, gc.HasLen, 32)
+	}
+	c.Assert(key.PrivateKey, gc.Not(gc.Equals), key.PublicKey)
+
+	// The key is stable once generated.
+	again, err := s.machine.EnsureWireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, jc.DeepEquals, key)
+	stored, err := s.machine.WireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, key)
+}
+
+func (s *WireGuardKeySuite) TestEnsureWireGuardKeyDistinctMachines(c *gc.C) {
+	key1, err := s.machine.EnsureWireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	key2, err := s.Factory.MakeMachine(c, nil).EnsureWireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key1.PublicKey, gc.Not(gc.Equals), key2.PublicKey)
+}
+
+func (s *WireGuardKeySuite) TestEnsureWireGuardKeyDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.EnsureWireGuardKey()
+	c.Assert(err, gc.ErrorMatches, `cannot set WireGuard key for machine .*: machine is not alive`)
+}
+
+func (s *WireGuardKeySuite) TestRemoveMachineRemovesKey(c *gc.C) {
+	_, err := s.machine.EnsureWireGuardKey()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.WireGuardKey()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -49,7 +49,7 @@ func newWorkers(st *State) (*workers, error) {
 		}),
 	}
 	ws.StartWorker(txnLogWorker, func() (worker.Worker, error) {
-		return watcher.New(st.getTxnLogCollection()), nil
+		return watcher.NewTailing(st.getTxnLogCollection()), nil
 	})
 	ws.StartWorker(presenceWorker, func() (worker.Worker, error) {
 		return presence.NewWatcher(st.getPresenceCollection(), st.modelTag), nil
@@ -142,6 +142,15 @@ func (ws *workers) singularManager() *lease.Manager {
 	return w.(*lease.Manager)
 }
 
+// leaseReport returns the reports of the leadership and singular lease
+// managers, keyed by lease type.
+func (ws *workers) leaseReport() map[string]interface{} {
+	return map[string]interface{}{
+		leadershipWorker: ws.leadershipManager().Report(),
+		singularWorker:   ws.singularManager().Report(),
+	}
+}
+
 func (ws *workers) allManager(params WatchParams) *storeManager {
 	w, err := ws.Worker(allManagerWorker, nil)
 	if err == nil {
@@ -201,6 +201,9 @@ const (
 
 	// Suspended is used to signify that a relation is temporarily broken pending
 	// action to resume it.
+	//
+	// It is also used for models whose cloud credential is not valid; such
+	// models become available again once the credential is updated.
 	Suspended Status = "suspended"
 )
 
@@ -305,6 +308,7 @@ func ValidModelStatus(status Status) bool {
 		Available,
 		Busy,
 		Destroying,
+		Suspended,
 		Error:
 		return true
 	default:
@@ -213,6 +213,29 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes that
+// have already been provisioned.
+type VolumeResizer interface {
+	// ResizeVolume grows the volume with the specified volume
+	// provider ID to at least the given size in MiB, returning
+	// the updated volume information to store in the model.
+	//
+	// Implementations of ResizeVolume must not shrink volumes.
+	// Growing any filesystem on the volume is the responsibility
+	// of the machine that the volume is attached to.
+	ResizeVolume(volumeId string, size uint64) (VolumeInfo, error)
+}
+
+// SharedStorageProvider is an optional interface that may be implemented
+// by storage providers whose storage can be attached to many machines at
+// once, and so can be used for charm storage that is shared by all units
+// of an application.
+type SharedStorageProvider interface {
+	// SupportsSharing reports whether or not storage of the
+	// specified kind can be shared.
+	SupportsSharing(kind StorageKind) bool
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -48,6 +48,46 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 		return nil, MissingTypeError
 	}
 
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := pm.settings.CreateSettings(globalKey(name), poolSettings(cfg)); err != nil {
+		return nil, errors.Annotatef(err, "creating pool %q", name)
+	}
+	return cfg, nil
+}
+
+// Replace is defined on PoolManager interface.
+func (pm *poolManager) Replace(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
+	if name == "" {
+		return nil, MissingNameError
+	}
+	existing, err := pm.Get(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if providerType == "" {
+		providerType = existing.Provider()
+	}
+
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolSettings(cfg)); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("pool %q", name)
+		}
+		return nil, errors.Annotatef(err, "replacing pool %q", name)
+	}
+	return cfg, nil
+}
+
+// validatedConfig returns the pool configuration with the given
+// name, provider type and attributes, after validating it against
+// the storage provider.
+func (pm *poolManager) validatedConfig(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	cfg, err := storage.NewConfig(name, providerType, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -59,14 +99,16 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	if err := provider.ValidateConfig(p, cfg); err != nil {
 		return nil, errors.Annotate(err, "validating storage provider config")
 	}
+	return cfg, nil
+}
 
+// poolSettings returns the settings to persist for the given
+// pool configuration.
+func poolSettings(cfg *storage.Config) map[string]interface{} {
 	poolAttrs := cfg.Attrs()
-	poolAttrs[Name] = name
-	poolAttrs[Type] = string(providerType)
-	if err := pm.settings.CreateSettings(globalKey(name), poolAttrs); err != nil {
-		return nil, errors.Annotatef(err, "creating pool %q", name)
-	}
-	return cfg, nil
+	poolAttrs[Name] = cfg.Name()
+	poolAttrs[Type] = string(cfg.Provider())
+	return poolAttrs
 }
 
 // Delete is defined on PoolManager interface.
@@ -130,6 +130,50 @@ func (s *poolSuite) TestCreateInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
 }
 
+func (s *poolSuite) TestReplace(c *gc.C) {
+	s.createSettings(c)
+	replaced, err := s.poolManager.Replace("testpool", "", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replaced, gc.DeepEquals, p)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"baz": "qux"})
+	c.Assert(p.Name(), gc.Equals, "testpool")
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("loop"))
+}
+
+func (s *poolSuite) TestReplaceProvider(c *gc.C) {
+	s.registry.Providers["other"] = &dummystorage.StorageProvider{}
+	s.createSettings(c)
+	_, err := s.poolManager.Replace("testpool", "other", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("other"))
+}
+
+func (s *poolSuite) TestReplaceNotFound(c *gc.C) {
+	_, err := s.poolManager.Replace("testpool", "", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+}
+
+func (s *poolSuite) TestReplaceInvalidConfig(c *gc.C) {
+	s.registry.Providers["invalid"] = &dummystorage.StorageProvider{
+		ValidateConfigFunc: func(*storage.Config) error {
+			return errors.New("no good")
+		},
+	}
+	s.createSettings(c)
+	_, err := s.poolManager.Replace("testpool", "invalid", nil)
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+
+	// The pool is unchanged.
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
 func (s *poolSuite) TestDelete(c *gc.C) {
 	s.createSettings(c)
 	err := s.poolManager.Delete("testpool")
@@ -128,3 +128,10 @@ func TmpfsFilesystemSource(storageDir string, run func(string, ...string) (strin
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
+
+func PluginProvider(
+	executable string,
+	run func(executable, verb string, request []byte) ([]byte, error),
+) storage.Provider {
+	return &pluginProvider{executable, run}
+}
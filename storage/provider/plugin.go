@@ -0,0 +1,362 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+// Verbs understood by storage provider plugins. A plugin is an
+// executable which is run with the verb as its only argument, reads a
+// JSON request from stdin, and writes a JSON response to stdout. The
+// response contains one result for each volume or attachment in the
+// request, in the same order; a result with a non-empty "error"
+// indicates that the operation failed for that item only. A non-zero
+// exit status fails the whole request, with stderr as the error.
+const (
+	// PluginCreate creates volumes.
+	//
+	// Request:  {"volumes": [{"tag": "0", "size": 1024, "attributes": {...}, "resource-tags": {...}}]}
+	// Response: {"results": [{"volume-id": "vol-0", "hardware-id": "", "wwn": "", "size": 1024, "persistent": true, "error": ""}]}
+	PluginCreate = "create"
+
+	// PluginAttach attaches volumes to machines.
+	//
+	// Request:  {"attachments": [{"volume": "0", "volume-id": "vol-0", "machine": "1", "instance-id": "i-1", "read-only": false}]}
+	// Response: {"results": [{"device-name": "", "device-link": "", "bus-address": "", "read-only": false, "error": ""}]}
+	PluginAttach = "attach"
+
+	// PluginDetach detaches volumes from machines.
+	//
+	// Request:  as for attach.
+	// Response: {"results": [{"error": ""}]}
+	PluginDetach = "detach"
+
+	// PluginDestroy destroys volumes.
+	//
+	// Request:  {"volume-ids": ["vol-0"]}
+	// Response: {"results": [{"error": ""}]}
+	PluginDestroy = "destroy"
+)
+
+// runPluginFunc runs a plugin executable with the given verb and
+// request, returning its response.
+type runPluginFunc func(executable, verb string, request []byte) ([]byte, error)
+
+// PluginStorageProviders returns a storage.ProviderRegistry that contains
+// a storage provider for each of the given plugin executables, keyed by
+// storage provider type.
+func PluginStorageProviders(plugins map[string]string) storage.ProviderRegistry {
+	providers := make(map[storage.ProviderType]storage.Provider)
+	for providerType, executable := range plugins {
+		providers[storage.ProviderType(providerType)] = &pluginProvider{executable, runPlugin}
+	}
+	return storage.StaticProviderRegistry{providers}
+}
+
+// pluginProvider is a storage provider which delegates the management
+// of volumes to an external executable. Plugin volumes are model
+// scoped, and are managed by the controller.
+type pluginProvider struct {
+	executable string
+	run        runPluginFunc
+}
+
+var _ storage.Provider = (*pluginProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *pluginProvider) ValidateConfig(cfg *storage.Config) error {
+	// Pool attributes are passed through to the plugin
+	// when creating volumes, which may reject them.
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *pluginProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &pluginVolumeSource{p.executable, p.run}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *pluginProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*pluginProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*pluginProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*pluginProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*pluginProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*pluginProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+type pluginVolumeSource struct {
+	executable string
+	run        runPluginFunc
+}
+
+var _ storage.VolumeSource = (*pluginVolumeSource)(nil)
+
+type pluginCreateRequest struct {
+	Volumes []pluginVolumeParams `json:"volumes"`
+}
+
+type pluginVolumeParams struct {
+	Tag          string                 `json:"tag"`
+	Size         uint64                 `json:"size"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	ResourceTags map[string]string      `json:"resource-tags,omitempty"`
+}
+
+type pluginCreateResponse struct {
+	Results []pluginVolumeResult `json:"results"`
+}
+
+type pluginVolumeResult struct {
+	VolumeId   string `json:"volume-id"`
+	HardwareId string `json:"hardware-id"`
+	WWN        string `json:"wwn"`
+	Size       uint64 `json:"size"`
+	Persistent bool   `json:"persistent"`
+	Error      string `json:"error"`
+}
+
+type pluginAttachRequest struct {
+	Attachments []pluginAttachmentParams `json:"attachments"`
+}
+
+type pluginAttachmentParams struct {
+	Volume     string `json:"volume"`
+	VolumeId   string `json:"volume-id"`
+	Machine    string `json:"machine"`
+	InstanceId string `json:"instance-id"`
+	ReadOnly   bool   `json:"read-only"`
+}
+
+type pluginAttachResponse struct {
+	Results []pluginAttachmentResult `json:"results"`
+}
+
+type pluginAttachmentResult struct {
+	DeviceName string `json:"device-name"`
+	DeviceLink string `json:"device-link"`
+	BusAddress string `json:"bus-address"`
+	ReadOnly   bool   `json:"read-only"`
+	Error      string `json:"error"`
+}
+
+type pluginDestroyRequest struct {
+	VolumeIds []string `json:"volume-ids"`
+}
+
+type pluginErrorsResponse struct {
+	Results []pluginErrorResult `json:"results"`
+}
+
+type pluginErrorResult struct {
+	Error string `json:"error"`
+}
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	request := pluginCreateRequest{Volumes: make([]pluginVolumeParams, len(args))}
+	for i, arg := range args {
+		request.Volumes[i] = pluginVolumeParams{
+			Tag:          arg.Tag.Id(),
+			Size:         arg.Size,
+			Attributes:   arg.Attributes,
+			ResourceTags: arg.ResourceTags,
+		}
+	}
+	var response pluginCreateResponse
+	if err := s.call(PluginCreate, request, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkPluginResults(PluginCreate, len(response.Results), len(args)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, result := range response.Results {
+		if result.Error != "" {
+			results[i].Error = errors.New(result.Error)
+			continue
+		}
+		results[i].Volume = &storage.Volume{
+			args[i].Tag,
+			storage.VolumeInfo{
+				VolumeId:   result.VolumeId,
+				HardwareId: result.HardwareId,
+				WWN:        result.WWN,
+				Size:       result.Size,
+				Persistent: result.Persistent,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) ListVolumes() ([]string, error) {
+	return nil, errors.NotSupportedf("listing volumes with storage plugin")
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+	return nil, errors.NotSupportedf("describing volumes with storage plugin")
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+	var response pluginErrorsResponse
+	request := pluginDestroyRequest{VolumeIds: volIds}
+	if err := s.call(PluginDestroy, request, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkPluginResults(PluginDestroy, len(response.Results), len(volIds)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.errors(), nil
+}
+
+// ReleaseVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) ReleaseVolumes(volIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes with storage plugin")
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	var response pluginAttachResponse
+	if err := s.call(PluginAttach, newPluginAttachRequest(args), &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkPluginResults(PluginAttach, len(response.Results), len(args)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, result := range response.Results {
+		if result.Error != "" {
+			results[i].Error = errors.New(result.Error)
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			args[i].Volume,
+			args[i].Machine,
+			storage.VolumeAttachmentInfo{
+				DeviceName: result.DeviceName,
+				DeviceLink: result.DeviceLink,
+				BusAddress: result.BusAddress,
+				ReadOnly:   result.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *pluginVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	var response pluginErrorsResponse
+	if err := s.call(PluginDetach, newPluginAttachRequest(args), &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkPluginResults(PluginDetach, len(response.Results), len(args)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.errors(), nil
+}
+
+func newPluginAttachRequest(args []storage.VolumeAttachmentParams) pluginAttachRequest {
+	request := pluginAttachRequest{Attachments: make([]pluginAttachmentParams, len(args))}
+	for i, arg := range args {
+		request.Attachments[i] = pluginAttachmentParams{
+			Volume:     arg.Volume.Id(),
+			VolumeId:   arg.VolumeId,
+			Machine:    arg.Machine.Id(),
+			InstanceId: string(arg.InstanceId),
+			ReadOnly:   arg.ReadOnly,
+		}
+	}
+	return request
+}
+
+func (r pluginErrorsResponse) errors() []error {
+	results := make([]error, len(r.Results))
+	for i, result := range r.Results {
+		if result.Error != "" {
+			results[i] = errors.New(result.Error)
+		}
+	}
+	return results
+}
+
+// call runs the plugin with the given verb and request, and decodes its
+// response into the given value.
+func (s *pluginVolumeSource) call(verb string, request, response interface{}) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err := s.run(s.executable, verb, data)
+	if err != nil {
+		return errors.Annotatef(err, "running storage plugin %s %s", s.executable, verb)
+	}
+	if err := json.Unmarshal(output, response); err != nil {
+		return errors.Annotatef(err, "decoding storage plugin %s response", verb)
+	}
+	return nil
+}
+
+// checkPluginResults returns an error if a plugin did not return
+// exactly one result for each item in a request.
+func checkPluginResults(verb string, n, expect int) error {
+	if n != expect {
+		return errors.Errorf("storage plugin %s returned %d results, expected %d", verb, n, expect)
+	}
+	return nil
+}
+
+// runPlugin runs a plugin executable with the given verb, writing the
+// request to its stdin and returning its stdout.
+func runPlugin(executable, verb string, request []byte) ([]byte, error) {
+	logger.Debugf("running storage plugin: %s %s", executable, verb)
+	var stdout, stderr bytes.Buffer
+	c := exec.Command(executable, verb)
+	c.Stdin = bytes.NewReader(request)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			err = errors.Annotate(err, output)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&pluginSuite{})
+
+type pluginSuite struct {
+	testing.BaseSuite
+
+	verbs    []string
+	requests []map[string]interface{}
+	response string
+	err      error
+}
+
+func (s *pluginSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.verbs = nil
+	s.requests = nil
+	s.response = ""
+	s.err = nil
+}
+
+func (s *pluginSuite) run(c *gc.C) func(string, string, []byte) ([]byte, error) {
+	return func(executable, verb string, request []byte) ([]byte, error) {
+		c.Assert(executable, gc.Equals, "/usr/lib/san/plugin")
+		var decoded map[string]interface{}
+		err := json.Unmarshal(request, &decoded)
+		c.Assert(err, jc.ErrorIsNil)
+		s.verbs = append(s.verbs, verb)
+		s.requests = append(s.requests, decoded)
+		if s.err != nil {
+			return nil, s.err
+		}
+		return []byte(s.response), nil
+	}
+}
+
+func (s *pluginSuite) volumeSource(c *gc.C) storage.VolumeSource {
+	p := provider.PluginProvider("/usr/lib/san/plugin", s.run(c))
+	cfg, err := storage.NewConfig("san", "san", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *pluginSuite) TestProvider(c *gc.C) {
+	p := provider.PluginProvider("/usr/lib/san/plugin", s.run(c))
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	_, err := p.FilesystemSource(nil)
+	c.Assert(err, gc.ErrorMatches, "filesystems not supported")
+}
+
+func (s *pluginSuite) TestPluginStorageProviders(c *gc.C) {
+	registry := provider.PluginStorageProviders(map[string]string{
+		"san": "/usr/lib/san/plugin",
+	})
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"san"})
+}
+
+func (s *pluginSuite) TestCreateVolumes(c *gc.C) {
+	s.response = `{"results": [
+		{"volume-id": "vol-0", "wwn": "abc", "size": 2048, "persistent": true},
+		{"error": "out of space"}
+	]}`
+	results, err := s.volumeSource(c).CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       1024,
+		Attributes: map[string]interface{}{"tier": "gold"},
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.verbs, jc.DeepEquals, []string{"create"})
+	c.Assert(s.requests[0], jc.DeepEquals, map[string]interface{}{
+		"volumes": []interface{}{
+			map[string]interface{}{
+				"tag":        "0",
+				"size":       1024.0,
+				"attributes": map[string]interface{}{"tier": "gold"},
+			},
+			map[string]interface{}{
+				"tag":  "1",
+				"size": 1024.0,
+			},
+		},
+	})
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId:   "vol-0",
+			WWN:        "abc",
+			Size:       2048,
+			Persistent: true,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "out of space")
+}
+
+func (s *pluginSuite) TestCreateVolumesResultCountMismatch(c *gc.C) {
+	s.response = `{"results": []}`
+	_, err := s.volumeSource(c).CreateVolumes([]storage.VolumeParams{{
+		Tag: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, gc.ErrorMatches, "storage plugin create returned 0 results, expected 1")
+}
+
+func (s *pluginSuite) TestCreateVolumesInvalidResponse(c *gc.C) {
+	s.response = `nope`
+	_, err := s.volumeSource(c).CreateVolumes([]storage.VolumeParams{{
+		Tag: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, gc.ErrorMatches, "decoding storage plugin create response: .*")
+}
+
+func (s *pluginSuite) TestCreateVolumesRunError(c *gc.C) {
+	s.err = errors.New("exit status 1")
+	_, err := s.volumeSource(c).CreateVolumes([]storage.VolumeParams{{
+		Tag: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, gc.ErrorMatches, "running storage plugin /usr/lib/san/plugin create: exit status 1")
+}
+
+func (s *pluginSuite) TestAttachVolumes(c *gc.C) {
+	s.response = `{"results": [{"device-link": "/dev/disk/by-id/san-vol-0"}]}`
+	results, err := s.volumeSource(c).AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: instance.Id("i-1"),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.verbs, jc.DeepEquals, []string{"attach"})
+	c.Assert(s.requests[0], jc.DeepEquals, map[string]interface{}{
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"volume":      "0",
+				"volume-id":   "vol-0",
+				"machine":     "1",
+				"instance-id": "i-1",
+				"read-only":   false,
+			},
+		},
+	})
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("1"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/disk/by-id/san-vol-0",
+			},
+		},
+	}})
+}
+
+func (s *pluginSuite) TestDetachVolumes(c *gc.C) {
+	s.response = `{"results": [{}, {"error": "busy"}]}`
+	results, err := s.volumeSource(c).DetachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{Machine: names.NewMachineTag("1")},
+		Volume:           names.NewVolumeTag("0"),
+		VolumeId:         "vol-0",
+	}, {
+		AttachmentParams: storage.AttachmentParams{Machine: names.NewMachineTag("1")},
+		Volume:           names.NewVolumeTag("1"),
+		VolumeId:         "vol-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.verbs, jc.DeepEquals, []string{"detach"})
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "busy")
+}
+
+func (s *pluginSuite) TestDestroyVolumes(c *gc.C) {
+	s.response = `{"results": [{}]}`
+	results, err := s.volumeSource(c).DestroyVolumes([]string{"vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.verbs, jc.DeepEquals, []string{"destroy"})
+	c.Assert(s.requests[0], jc.DeepEquals, map[string]interface{}{
+		"volume-ids": []interface{}{"vol-0"},
+	})
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
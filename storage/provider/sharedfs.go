@@ -0,0 +1,185 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	SharedFSProviderType = storage.ProviderType("sharedfs")
+
+	// SharedFSType is the pool attribute specifying the type of the
+	// network filesystem: "nfs", "nfs4" or "ceph".
+	SharedFSType = "fstype"
+
+	// SharedFSSource is the pool attribute specifying the network
+	// filesystem to mount, e.g. "server:/export" for NFS, or
+	// "mon1,mon2:/path" for CephFS.
+	SharedFSSource = "source"
+
+	// SharedFSOptions is the pool attribute specifying a comma-separated
+	// list of mount options to use when mounting the filesystem.
+	SharedFSOptions = "options"
+)
+
+var sharedFSTypes = map[string]bool{
+	"nfs":  true,
+	"nfs4": true,
+	"ceph": true,
+}
+
+// sharedFSProvider is a storage provider for network filesystems that
+// exist outside of Juju, such as NFS exports and CephFS. Each pool
+// describes a single share, which may be attached to any number of
+// machines at once; sharedfs pools can therefore be used for charm
+// storage that is shared by all units of an application.
+//
+// Juju does not create or destroy the share; it only records how the
+// share is to be mounted.
+type sharedFSProvider struct{}
+
+var (
+	_ storage.Provider              = (*sharedFSProvider)(nil)
+	_ storage.SharedStorageProvider = (*sharedFSProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*sharedFSProvider) ValidateConfig(cfg *storage.Config) error {
+	fsType, _ := cfg.ValueString(SharedFSType)
+	if !sharedFSTypes[fsType] {
+		return errors.NotValidf("%s %q", SharedFSType, fsType)
+	}
+	if source, _ := cfg.ValueString(SharedFSSource); source == "" {
+		return errors.Errorf("%s not specified", SharedFSSource)
+	}
+	if options, ok := cfg.Attrs()[SharedFSOptions]; ok {
+		if _, ok := options.(string); !ok {
+			return errors.Errorf("%s must be a string, got %T", SharedFSOptions, options)
+		}
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*sharedFSProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *sharedFSProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// fstype and source are validated by ValidateConfig.
+	fsType, _ := sourceConfig.ValueString(SharedFSType)
+	source, _ := sourceConfig.ValueString(SharedFSSource)
+	return &sharedFSFilesystemSource{
+		descriptor: fmt.Sprintf("%s:%s", fsType, source),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*sharedFSProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// SupportsSharing is defined on the SharedStorageProvider interface.
+func (*sharedFSProvider) SupportsSharing(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*sharedFSProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*sharedFSProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*sharedFSProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*sharedFSProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// sharedFSFilesystemSource is a storage.FilesystemSource for a single
+// network filesystem. The filesystem ID of every filesystem created
+// by the source is the share's mount descriptor, "<fstype>:<source>".
+type sharedFSFilesystemSource struct {
+	descriptor string
+}
+
+var _ storage.FilesystemSource = (*sharedFSFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		// The share already exists; we cannot know its size, so
+		// record the size that was asked for.
+		results[i].Filesystem = &storage.Filesystem{
+			arg.Tag,
+			names.VolumeTag{},
+			storage.FilesystemInfo{
+				FilesystemId: s.descriptor,
+				Size:         arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// The share is managed outside of Juju, so there is
+	// nothing to destroy.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) ReleaseFilesystems(filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		if arg.Path == "" {
+			results[i].Error = errNoMountPoint
+			continue
+		}
+		results[i].FilesystemAttachment = &storage.FilesystemAttachment{
+			arg.Filesystem,
+			arg.Machine,
+			storage.FilesystemAttachmentInfo{
+				Path:     arg.Path,
+				ReadOnly: arg.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *sharedFSFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	return make([]error, len(args)), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&sharedFSSuite{})
+
+type sharedFSSuite struct {
+	testing.BaseSuite
+}
+
+func (s *sharedFSSuite) sharedFSProvider(c *gc.C) storage.Provider {
+	p, err := provider.CommonStorageProviders().StorageProvider(provider.SharedFSProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *sharedFSSuite) filesystemSource(c *gc.C) storage.FilesystemSource {
+	cfg, err := storage.NewConfig("nfs", provider.SharedFSProviderType, map[string]interface{}{
+		"fstype": "nfs4",
+		"source": "nas.example.com:/export/data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.sharedFSProvider(c).FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *sharedFSSuite) TestProvider(c *gc.C) {
+	p := s.sharedFSProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	_, err := p.VolumeSource(nil)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+
+	shared, ok := p.(storage.SharedStorageProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(shared.SupportsSharing(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(shared.SupportsSharing(storage.StorageKindBlock), jc.IsFalse)
+}
+
+func (s *sharedFSSuite) TestValidateConfig(c *gc.C) {
+	p := s.sharedFSProvider(c)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"fstype": "nfs", "source": "nas:/export"},
+	}, {
+		attrs: map[string]interface{}{"fstype": "ceph", "source": "mon1,mon2:/", "options": "name=admin"},
+	}, {
+		attrs: map[string]interface{}{"source": "nas:/export"},
+		err:   `fstype "" not valid`,
+	}, {
+		attrs: map[string]interface{}{"fstype": "ext4", "source": "/dev/sdb"},
+		err:   `fstype "ext4" not valid`,
+	}, {
+		attrs: map[string]interface{}{"fstype": "nfs"},
+		err:   "source not specified",
+	}, {
+		attrs: map[string]interface{}{"fstype": "nfs", "source": "nas:/export", "options": 123},
+		err:   "options must be a string, got int",
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("nfs", provider.SharedFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *sharedFSSuite) TestCreateFilesystems(c *gc.C) {
+	results, err := s.filesystemSource(c).CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}, {
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs4:nas.example.com:/export/data",
+				Size:         1024,
+			},
+		},
+	}, {
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("1"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs4:nas.example.com:/export/data",
+				Size:         2048,
+			},
+		},
+	}})
+}
+
+func (s *sharedFSSuite) TestAttachFilesystems(c *gc.C) {
+	results, err := s.filesystemSource(c).AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+		Filesystem: names.NewFilesystemTag("0"),
+		Path:       "/srv/data",
+	}, {
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("1"),
+		},
+		Filesystem: names.NewFilesystemTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.AttachFilesystemsResult{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "filesystem mount point not specified")
+}
+
+func (s *sharedFSSuite) TestDestroyFilesystems(c *gc.C) {
+	results, err := s.filesystemSource(c).DestroyFilesystems([]string{"nfs4:nas.example.com:/export/data"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
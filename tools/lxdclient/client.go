@@ -106,6 +106,7 @@ type Client struct {
 	*imageClient
 	*networkClient
 	*storageClient
+	*clusterClient
 	baseURL                  string
 	defaultProfileBridgeName string
 }
@@ -134,6 +135,9 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 
 	networkAPISupported := false
 	storageAPISupported := false
+	clusterAPISupported := false
+	instancesAPISupported := false
+	virtualMachinesSupported := false
 	var defaultProfile *api.Profile
 	if cfg.Remote.Protocol != SimplestreamsProtocol {
 		status, err := raw.ServerStatus()
@@ -149,6 +153,18 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 			storageAPISupported = true
 		}
 
+		if lxdshared.StringInSlice("clustering", status.APIExtensions) {
+			clusterAPISupported = true
+		}
+
+		if lxdshared.StringInSlice("instances", status.APIExtensions) {
+			instancesAPISupported = true
+		}
+
+		if lxdshared.StringInSlice("virtual-machines", status.APIExtensions) {
+			virtualMachinesSupported = true
+		}
+
 		defaultProfile, err = raw.ProfileConfig("default")
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -174,14 +190,35 @@ func Connect(cfg Config, verifyBridgeConfig bool) (*Client, error) {
 		}
 	}
 
+	rest := &restClient{raw, &raw.Http, raw.BaseURL, instancesAPISupported}
+	cluster := &clusterClient{rest, clusterAPISupported}
+	instances := &instanceClient{
+		raw:             raw,
+		remote:          remoteID,
+		rest:            rest,
+		cluster:         cluster,
+		virtualMachines: virtualMachinesSupported,
+	}
+	images := &imageClient{
+		raw:             raw,
+		connectToSource: connectToRaw,
+	}
+	if instancesAPISupported {
+		// Virtual machines are only visible through the instances API.
+		instances.raw = instancesRawClient{raw, rest}
+	}
+	if virtualMachinesSupported {
+		images.pullVirtualMachineImage = rest.pullVirtualMachineImage
+	}
 	conn := &Client{
 		configClient:             &configClient{raw},
 		certClient:               &certClient{raw},
 		profileClient:            &profileClient{raw},
-		instanceClient:           &instanceClient{raw, remoteID},
-		imageClient:              &imageClient{raw, connectToRaw},
+		instanceClient:           instances,
+		imageClient:              images,
 		networkClient:            &networkClient{raw, networkAPISupported},
 		storageClient:            &storageClient{raw, storageAPISupported},
+		clusterClient:            cluster,
 		baseURL:                  raw.BaseURL,
 		defaultProfileBridgeName: bridgeName,
 	}
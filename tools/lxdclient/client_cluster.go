@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+)
+
+// ClusterMemberOnline is the status of a cluster member that is
+// available for running containers.
+const ClusterMemberOnline = "Online"
+
+// ClusterMember describes a member of an LXD cluster.
+type ClusterMember struct {
+	// Name is the name of the cluster member.
+	Name string `json:"server_name"`
+
+	// URL is the URL of the cluster member's API.
+	URL string `json:"url"`
+
+	// Database reports whether the member is a database node.
+	Database bool `json:"database"`
+
+	// Status is the status of the cluster member, e.g. "Online".
+	Status string `json:"status"`
+
+	// Message is a human-readable description of the status.
+	Message string `json:"message"`
+}
+
+// clusterClient provides access to the LXD clustering API.
+type clusterClient struct {
+	rest      *restClient
+	supported bool
+}
+
+// ClusterSupported reports whether or not the LXD remote supports
+// clustering.
+func (c *clusterClient) ClusterSupported() bool {
+	return c.supported
+}
+
+// ClusterMembers returns the members of the LXD cluster. If the remote
+// is not clustered, no members are returned.
+func (c *clusterClient) ClusterMembers() ([]ClusterMember, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("clustering API on this remote")
+	}
+	var members []ClusterMember
+	if _, err := c.rest.query("GET", "/1.0/cluster/members", url.Values{"recursion": {"1"}}, nil, &members); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Annotate(err, "listing cluster members")
+	}
+	return members, nil
+}
+
+// InstanceLocations returns the names of the cluster members on which
+// the containers are located, keyed by container name. Containers on
+// a remote that is not clustered are omitted.
+func (c *clusterClient) InstanceLocations() (map[string]string, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("clustering API on this remote")
+	}
+	var containers []struct {
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	}
+	if _, err := c.rest.query("GET", c.rest.instancesPath(), url.Values{"recursion": {"1"}}, nil, &containers); err != nil {
+		return nil, errors.Annotate(err, "listing containers")
+	}
+	locations := make(map[string]string)
+	for _, container := range containers {
+		// Non-clustered servers report "none" as the location.
+		if container.Location == "" || container.Location == "none" {
+			continue
+		}
+		locations[container.Name] = container.Location
+	}
+	return locations, nil
+}
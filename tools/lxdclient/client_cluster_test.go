@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type ClusterClientSuite struct {
+	restSuite
+}
+
+var _ = gc.Suite(&ClusterClientSuite{})
+
+func (s *ClusterClientSuite) TestClusterNotSupported(c *gc.C) {
+	client := lxdclient.NewClusterClient(s.raw, s.server.URL, false)
+	c.Assert(client.ClusterSupported(), jc.IsFalse)
+
+	_, err := client.ClusterMembers()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.InstanceLocations()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClusterClientSuite) TestClusterMembers(c *gc.C) {
+	s.respond(http.StatusOK, map[string]interface{}{
+		"type": "sync",
+		"metadata": []map[string]interface{}{{
+			"server_name": "node1",
+			"url":         "https://10.0.0.1:8443",
+			"database":    true,
+			"status":      "Online",
+		}, {
+			"server_name": "node2",
+			"url":         "https://10.0.0.2:8443",
+			"status":      "Offline",
+			"message":     "no heartbeat",
+		}},
+	})
+	client := lxdclient.NewClusterClient(s.raw, s.server.URL, true)
+	members, err := client.ClusterMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, jc.DeepEquals, []lxdclient.ClusterMember{{
+		Name:     "node1",
+		URL:      "https://10.0.0.1:8443",
+		Database: true,
+		Status:   lxdclient.ClusterMemberOnline,
+	}, {
+		Name:    "node2",
+		URL:     "https://10.0.0.2:8443",
+		Status:  "Offline",
+		Message: "no heartbeat",
+	}})
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/cluster/members")
+	c.Assert(s.requests[0].URL.Query().Get("recursion"), gc.Equals, "1")
+}
+
+func (s *ClusterClientSuite) TestClusterMembersNotClustered(c *gc.C) {
+	s.respond(http.StatusNotFound, map[string]interface{}{
+		"type":       "error",
+		"error":      "not found",
+		"error_code": 404,
+	})
+	client := lxdclient.NewClusterClient(s.raw, s.server.URL, true)
+	members, err := client.ClusterMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, gc.HasLen, 0)
+}
+
+func (s *ClusterClientSuite) TestClusterMembersError(c *gc.C) {
+	s.respond(http.StatusInternalServerError, map[string]interface{}{
+		"type":       "error",
+		"error":      "database is locked",
+		"error_code": 500,
+	})
+	client := lxdclient.NewClusterClient(s.raw, s.server.URL, true)
+	_, err := client.ClusterMembers()
+	c.Assert(err, gc.ErrorMatches, "listing cluster members: database is locked")
+}
+
+func (s *ClusterClientSuite) TestInstanceLocations(c *gc.C) {
+	s.respond(http.StatusOK, map[string]interface{}{
+		"type": "sync",
+		"metadata": []map[string]interface{}{
+			{"name": "juju-0", "location": "node1"},
+			{"name": "juju-1", "location": "node2"},
+			{"name": "juju-2", "location": "none"},
+		},
+	})
+	client := lxdclient.NewClusterClient(s.raw, s.server.URL, true)
+	locations, err := client.InstanceLocations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locations, jc.DeepEquals, map[string]string{
+		"juju-0": "node1",
+		"juju-1": "node2",
+	})
+}
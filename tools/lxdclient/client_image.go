@@ -36,6 +36,11 @@ type remoteClient interface {
 type imageClient struct {
 	raw             rawImageClient
 	connectToSource func(Remote) (remoteClient, error)
+
+	// pullVirtualMachineImage has the LXD server pull a virtual
+	// machine image from a source, and assign it a local alias. It
+	// is nil if the server does not support virtual machines.
+	pullVirtualMachineImage func(source Remote, alias, localAlias string) error
 }
 
 type rawWrapper struct {
@@ -183,6 +188,47 @@ func (i *imageClient) ensureImage(
 	return errors.Annotatef(err, "unable to get LXD image for %s", imageName)
 }
 
+// EnsureVirtualMachineImageExists is like EnsureImageExists, but makes
+// sure we have a local virtual machine image. The LXD client library
+// cannot copy virtual machine images, so the LXD server is asked to
+// pull the image from the sources itself.
+func (i *imageClient) EnsureVirtualMachineImageExists(
+	series, arch string,
+	sources []Remote,
+	copyProgressHandler func(string),
+) (string, error) {
+	imageName := virtualMachineLocalAlias(series, arch)
+	if target := i.raw.GetAlias(imageName); target != "" {
+		return imageName, nil
+	}
+	if i.pullVirtualMachineImage == nil {
+		return "", errors.NotSupportedf("virtual machines on this remote")
+	}
+
+	aliases, err := seriesRemoteAliases(series, arch)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var lastErr error
+	for _, remote := range sources {
+		for _, alias := range aliases {
+			if copyProgressHandler != nil {
+				copyProgressHandler(fmt.Sprintf("pulling image for %s from %s", imageName, remote.Host))
+			}
+			err := i.pullVirtualMachineImage(remote, alias, imageName)
+			if err == nil {
+				return imageName, nil
+			}
+			logger.Infof("failed to pull image for %s from %q: %v", imageName, remote.Host, err)
+			lastErr = err
+		}
+	}
+	if lastErr == nil {
+		return "", errors.NotFoundf("image for %s", imageName)
+	}
+	return "", errors.Annotatef(lastErr, "unable to get LXD image for %s", imageName)
+}
+
 // seriesLocalAlias returns the alias to assign to images for the
 // specified series. The alias is juju-specific, to support the
 // user supplying a customised image (e.g. CentOS with cloud-init).
@@ -190,6 +236,12 @@ func seriesLocalAlias(series, arch string) string {
 	return fmt.Sprintf("juju/%s/%s", series, arch)
 }
 
+// virtualMachineLocalAlias returns the alias to assign to virtual
+// machine images for the specified series.
+func virtualMachineLocalAlias(series, arch string) string {
+	return seriesLocalAlias(series, arch) + "/vm"
+}
+
 // seriesRemoteAliases returns the aliases to look for in remotes.
 func seriesRemoteAliases(series, arch string) ([]string, error) {
 	seriesOS, err := jujuseries.GetOSFromSeries(series)
@@ -321,3 +321,70 @@ func (s *imageSuite) TestEnsureImageExistsCallbackIncludesSourceURL(c *gc.C) {
 		c.Fatalf("no messages received")
 	}
 }
+
+func (s *imageSuite) TestEnsureVirtualMachineImageExistsAlreadyPresent(c *gc.C) {
+	raw := &stubClient{
+		stub:    s.Stub,
+		Aliases: map[string]string{"juju/xenial/amd64/vm": "dead-beef"},
+	}
+	client := &imageClient{raw: raw}
+	image, err := client.EnsureVirtualMachineImageExists("xenial", "amd64", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(image, gc.Equals, "juju/xenial/amd64/vm")
+	s.Stub.CheckCallNames(c, "GetAlias")
+}
+
+func (s *imageSuite) TestEnsureVirtualMachineImageExistsNotSupported(c *gc.C) {
+	client := &imageClient{raw: &stubClient{stub: s.Stub}}
+	_, err := client.EnsureVirtualMachineImageExists("xenial", "amd64", nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *imageSuite) TestEnsureVirtualMachineImageExistsPullsImage(c *gc.C) {
+	var messages []string
+	client := &imageClient{
+		raw: &stubClient{stub: s.Stub},
+		pullVirtualMachineImage: func(source Remote, alias, localAlias string) error {
+			s.Stub.AddCall("pullVirtualMachineImage", source.Host, alias, localAlias)
+			if source.Host == "https://missing" {
+				return errors.New("image not found")
+			}
+			return nil
+		},
+	}
+	remotes := []Remote{
+		s.remoteWithNothing.AsRemote(),
+		s.remoteWithTrusty.AsRemote(),
+	}
+	image, err := client.EnsureVirtualMachineImageExists("xenial", "amd64", remotes, func(msg string) {
+		messages = append(messages, msg)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(image, gc.Equals, "juju/xenial/amd64/vm")
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "GetAlias",
+		Args:     []interface{}{"juju/xenial/amd64/vm"},
+	}, {
+		FuncName: "pullVirtualMachineImage",
+		Args:     []interface{}{"https://missing", "xenial/amd64", "juju/xenial/amd64/vm"},
+	}, {
+		FuncName: "pullVirtualMachineImage",
+		Args:     []interface{}{"https://match", "xenial/amd64", "juju/xenial/amd64/vm"},
+	}})
+	c.Assert(messages, jc.DeepEquals, []string{
+		"pulling image for juju/xenial/amd64/vm from https://missing",
+		"pulling image for juju/xenial/amd64/vm from https://match",
+	})
+}
+
+func (s *imageSuite) TestEnsureVirtualMachineImageExistsPullFails(c *gc.C) {
+	client := &imageClient{
+		raw: &stubClient{stub: s.Stub},
+		pullVirtualMachineImage: func(source Remote, alias, localAlias string) error {
+			return errors.New("image not found")
+		},
+	}
+	remotes := []Remote{s.remoteWithNothing.AsRemote()}
+	_, err := client.EnsureVirtualMachineImageExists("xenial", "amd64", remotes, nil)
+	c.Assert(err, gc.ErrorMatches, "unable to get LXD image for juju/xenial/amd64/vm: image not found")
+}
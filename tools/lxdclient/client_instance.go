@@ -42,11 +42,19 @@ type rawInstanceClient interface {
 	ContainerDeviceAdd(container, devname, devtype string, props []string) (*api.Response, error)
 	ContainerDeviceDelete(container, devname string) (*api.Response, error)
 	PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error
+	ApplyProfile(container, profile string) (*api.Response, error)
 }
 
+// configDriveDevice is the name of the device through which virtual
+// machines are given their cloud-init configuration.
+const configDriveDevice = "config"
+
 type instanceClient struct {
-	raw    rawInstanceClient
-	remote string
+	raw             rawInstanceClient
+	remote          string
+	rest            *restClient
+	cluster         *clusterClient
+	virtualMachines bool
 }
 
 func (client *instanceClient) addInstance(spec InstanceSpec) error {
@@ -73,6 +81,31 @@ func (client *instanceClient) addInstance(spec InstanceSpec) error {
 		lxdDevices[name] = lxdDevice
 	}
 
+	if spec.Type == InstanceTypeVirtualMachine {
+		if !client.virtualMachines {
+			return errors.NotSupportedf("virtual machines on this remote")
+		}
+		// Virtual machines are given their cloud-init configuration,
+		// including the agent's user-data, through a config drive.
+		if _, ok := lxdDevices[configDriveDevice]; !ok {
+			lxdDevices[configDriveDevice] = Device{
+				"type":   "disk",
+				"source": "cloud-init:config",
+			}
+		}
+	}
+	if spec.Target != "" && (client.cluster == nil || !client.cluster.supported) {
+		return errors.NotSupportedf("clustering API on this remote")
+	}
+	if spec.Target != "" || spec.Type == InstanceTypeVirtualMachine {
+		// The LXD client library supports neither targeting cluster
+		// members nor virtual machines, so the REST API is used.
+		if imageRemote != client.remote {
+			return errors.NotSupportedf("creating instance with image from remote %q", imageRemote)
+		}
+		return errors.Trace(client.rest.createInstance(spec, lxdDevices))
+	}
+
 	config := spec.config()
 	resp, err := client.raw.Init(spec.Name, imageRemote, imageAlias, profiles, config, lxdDevices, spec.Ephemeral)
 	if err != nil {
@@ -144,6 +177,29 @@ func (client *instanceClient) Instance(name string) (*Instance, error) {
 	return inst, nil
 }
 
+// InstanceProfiles returns the names of the profiles applied to the
+// named instance, in the order they are applied.
+func (client *instanceClient) InstanceProfiles(name string) ([]string, error) {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.Profiles, nil
+}
+
+// SetInstanceProfiles replaces the profiles applied to the named
+// instance. The change takes effect on the running instance.
+func (client *instanceClient) SetInstanceProfiles(name string, profiles []string) error {
+	resp, err := client.raw.ApplyProfile(name, strings.Join(profiles, ","))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (client *instanceClient) Status(name string) (string, error) {
 	info, err := client.raw.ContainerInfo(name)
 	if err != nil {
@@ -8,6 +8,7 @@ package lxdclient_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	lxdapi "github.com/lxc/lxd/shared/api"
@@ -256,3 +257,31 @@ func (s *devicesSuite) TestRemoveDeviceAsyncError(c *gc.C) {
 	err := client.RemoveDevice("instance", "device")
 	c.Assert(err, gc.ErrorMatches, "async error")
 }
+
+func (s *devicesSuite) TestSetInstanceProfiles(c *gc.C) {
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.SetInstanceProfiles("instance", []string{"default", "juju-model", "juju-model-app-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ApplyProfile", []interface{}{"instance", "default,juju-model,juju-model-app-1"}},
+		{"WaitForSuccess", []interface{}{""}},
+	})
+}
+
+func (s *devicesSuite) TestSetInstanceProfilesAsyncError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("async error"))
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.SetInstanceProfiles("instance", []string{"default"})
+	c.Assert(err, gc.ErrorMatches, "async error")
+}
+
+func (s *devicesSuite) TestAddInstanceVirtualMachineNotSupported(c *gc.C) {
+	client := lxdclient.NewInstanceClient(s.Client)
+	_, err := client.AddInstance(lxdclient.InstanceSpec{
+		Name: "instance",
+		Type: lxdclient.InstanceTypeVirtualMachine,
+	})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	s.Stub.CheckNoCalls(c)
+}
@@ -6,6 +6,9 @@
 package lxdclient
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/lxc/lxd/shared/api"
 )
@@ -59,6 +62,33 @@ func (p profileClient) CreateProfile(name string, config map[string]string) erro
 	return nil
 }
 
+// CreateProfileWithDevices attempts to create a new lxc profile with the
+// given config and devices. Each device must have a "type".
+func (p profileClient) CreateProfileWithDevices(name string, config map[string]string, devices map[string]map[string]string) error {
+	if err := p.CreateProfile(name, config); err != nil {
+		return errors.Trace(err)
+	}
+	deviceNames := make([]string, 0, len(devices))
+	for devname := range devices {
+		deviceNames = append(deviceNames, devname)
+	}
+	sort.Strings(deviceNames)
+	for _, devname := range deviceNames {
+		device := devices[devname]
+		var props []string
+		for k, v := range device {
+			if k != "type" {
+				props = append(props, fmt.Sprintf("%s=%s", k, v))
+			}
+		}
+		sort.Strings(props)
+		if _, err := p.raw.ProfileDeviceAdd(name, devname, device["type"], props); err != nil {
+			return errors.Annotatef(err, "adding device %q to profile %q", devname, name)
+		}
+	}
+	return nil
+}
+
 // HasProfile returns true/false if the profile exists.
 func (p profileClient) HasProfile(name string) (bool, error) {
 	profiles, err := p.raw.ListProfiles()
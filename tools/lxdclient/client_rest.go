@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+)
+
+type rawRESTClient interface {
+	WaitForSuccess(waitURL string) error
+}
+
+// restClient sends requests to the LXD REST API directly. The LXD
+// client library that we use predates clustering and virtual machines,
+// so the API for those features is accessed with this.
+type restClient struct {
+	raw     rawRESTClient
+	http    *http.Client
+	baseURL string
+
+	// instances reports whether the remote supports the instances
+	// API, which covers both containers and virtual machines.
+	instances bool
+}
+
+// lxdResponse is the standard LXD REST API response.
+type lxdResponse struct {
+	Type      string          `json:"type"`
+	Operation string          `json:"operation"`
+	ErrorCode int             `json:"error_code"`
+	Error     string          `json:"error"`
+	Metadata  json.RawMessage `json:"metadata"`
+}
+
+// instancesPath returns the path of the API endpoint for instances.
+// Remotes that support virtual machines only report containers at
+// the older containers endpoint.
+func (c *restClient) instancesPath() string {
+	if c.instances {
+		return "/1.0/instances"
+	}
+	return "/1.0/containers"
+}
+
+// createInstance creates an instance from a local image. If the spec
+// has a target, the instance is created on the cluster member with
+// that name.
+func (c *restClient) createInstance(spec InstanceSpec, devices map[string]map[string]string) error {
+	profiles := spec.Profiles
+	if profiles == nil {
+		profiles = []string{}
+	}
+	req := map[string]interface{}{
+		"name":      spec.Name,
+		"profiles":  profiles,
+		"config":    spec.config(),
+		"devices":   devices,
+		"ephemeral": spec.Ephemeral,
+		"source": map[string]string{
+			"type":  "image",
+			"alias": spec.Image,
+		},
+	}
+	path := "/1.0/containers"
+	if spec.Type == InstanceTypeVirtualMachine {
+		path = "/1.0/instances"
+		req["type"] = string(spec.Type)
+	}
+	query := make(url.Values)
+	if spec.Target != "" {
+		query.Set("target", spec.Target)
+	}
+	operation, err := c.query("POST", path, query, req, nil)
+	if err != nil {
+		if spec.Target != "" {
+			return errors.Annotatef(err, "creating instance on cluster member %q", spec.Target)
+		}
+		return errors.Annotate(err, "creating instance")
+	}
+	return errors.Trace(c.raw.WaitForSuccess(operation))
+}
+
+// pullVirtualMachineImage has the remote pull the virtual machine
+// image with the given alias from the source, assigning it the given
+// local alias.
+func (c *restClient) pullVirtualMachineImage(source Remote, alias, localAlias string) error {
+	req := map[string]interface{}{
+		"auto_update": true,
+		"aliases":     []map[string]string{{"name": localAlias}},
+		"source": map[string]string{
+			"type":        "image",
+			"mode":        "pull",
+			"server":      source.Host,
+			"protocol":    string(source.Protocol),
+			"certificate": source.ServerPEMCert,
+			"alias":       alias,
+			"image_type":  string(InstanceTypeVirtualMachine),
+		},
+	}
+	operation, err := c.query("POST", "/1.0/images", nil, req, nil)
+	if err != nil {
+		return errors.Annotatef(err, "pulling image %q from %s", alias, source.Host)
+	}
+	return errors.Trace(c.raw.WaitForSuccess(operation))
+}
+
+// query sends a request to the LXD REST API, decoding the response
+// metadata into result if it is non-nil. The operation URL is returned
+// for asynchronous requests.
+func (c *restClient) query(method, path string, query url.Values, body, result interface{}) (string, error) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, reqURL, &reqBody)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errors.NotFoundf("%s", path)
+	}
+
+	var response lxdResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", errors.Annotate(err, "decoding response")
+	}
+	if response.Type == "error" {
+		return "", errors.New(response.Error)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", errors.Errorf("unexpected status %s", resp.Status)
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Metadata, result); err != nil {
+			return "", errors.Annotate(err, "decoding response metadata")
+		}
+	}
+	return response.Operation, nil
+}
+
+// instancesRawClient overrides the methods of the raw LXD client that
+// query or act on existing containers, so that they use the instances
+// API instead. This is required for virtual machines to be visible.
+type instancesRawClient struct {
+	rawInstanceClient
+	rest *restClient
+}
+
+// ListContainers is part of the rawInstanceClient interface.
+func (c instancesRawClient) ListContainers() ([]api.Container, error) {
+	var instances []api.Container
+	if _, err := c.rest.query("GET", "/1.0/instances", url.Values{"recursion": {"1"}}, nil, &instances); err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	return instances, nil
+}
+
+// ContainerInfo is part of the rawInstanceClient interface.
+func (c instancesRawClient) ContainerInfo(name string) (*api.Container, error) {
+	var instance api.Container
+	if _, err := c.rest.query("GET", "/1.0/instances/"+name, nil, nil, &instance); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &instance, nil
+}
+
+// ContainerState is part of the rawInstanceClient interface.
+func (c instancesRawClient) ContainerState(name string) (*api.ContainerState, error) {
+	var state api.ContainerState
+	if _, err := c.rest.query("GET", "/1.0/instances/"+name+"/state", nil, nil, &state); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &state, nil
+}
+
+// Action is part of the rawInstanceClient interface.
+func (c instancesRawClient) Action(name string, action shared.ContainerAction, timeout int, force bool, stateful bool) (*api.Response, error) {
+	req := map[string]interface{}{
+		"action":   string(action),
+		"timeout":  timeout,
+		"force":    force,
+		"stateful": stateful,
+	}
+	operation, err := c.rest.query("PUT", "/1.0/instances/"+name+"/state", nil, req, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &api.Response{Operation: operation}, nil
+}
+
+// Delete is part of the rawInstanceClient interface.
+func (c instancesRawClient) Delete(name string) (*api.Response, error) {
+	operation, err := c.rest.query("DELETE", "/1.0/instances/"+name, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &api.Response{Operation: operation}, nil
+}
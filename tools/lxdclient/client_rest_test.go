@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	lxdshared "github.com/lxc/lxd/shared"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+// restSuite runs an HTTP server standing in for the LXD REST API.
+type restSuite struct {
+	testing.IsolationSuite
+
+	raw      *mockRawRESTClient
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   []string
+	handler  func(w http.ResponseWriter, r *http.Request)
+}
+
+func (s *restSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.raw = &mockRawRESTClient{}
+	s.requests = nil
+	s.bodies = nil
+	s.handler = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(body))
+		s.handler(w, r)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *restSuite) respond(status int, response interface{}) {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (s *restSuite) respondAsync(operation string) {
+	s.respond(http.StatusAccepted, map[string]interface{}{
+		"type":      "async",
+		"operation": operation,
+	})
+}
+
+func (s *restSuite) requestBody(c *gc.C, i int) map[string]interface{} {
+	var body map[string]interface{}
+	err := json.Unmarshal([]byte(s.bodies[i]), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	return body
+}
+
+type RESTClientSuite struct {
+	restSuite
+}
+
+var _ = gc.Suite(&RESTClientSuite{})
+
+func (s *RESTClientSuite) TestCreateInstanceOnClusterMember(c *gc.C) {
+	s.respondAsync("/1.0/operations/abc")
+	client := lxdclient.NewRESTClient(s.raw, s.server.URL, false)
+	err := client.CreateInstance(lxdclient.InstanceSpec{
+		Name:     "juju-0",
+		Image:    "ubuntu-xenial",
+		Profiles: []string{"default"},
+		Target:   "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/containers")
+	c.Assert(s.requests[0].URL.Query().Get("target"), gc.Equals, "node2")
+
+	body := s.requestBody(c, 0)
+	c.Assert(body["name"], gc.Equals, "juju-0")
+	c.Assert(body["type"], gc.IsNil)
+	c.Assert(body["source"], jc.DeepEquals, map[string]interface{}{
+		"type":  "image",
+		"alias": "ubuntu-xenial",
+	})
+	s.raw.CheckCall(c, 0, "WaitForSuccess", "/1.0/operations/abc")
+}
+
+func (s *RESTClientSuite) TestCreateVirtualMachine(c *gc.C) {
+	s.respondAsync("/1.0/operations/abc")
+	client := lxdclient.NewRESTClient(s.raw, s.server.URL, true)
+	err := client.CreateInstance(lxdclient.InstanceSpec{
+		Name:  "juju-0",
+		Type:  lxdclient.InstanceTypeVirtualMachine,
+		Image: "juju/xenial/amd64/vm",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/instances")
+	c.Assert(s.requests[0].URL.Query().Get("target"), gc.Equals, "")
+	body := s.requestBody(c, 0)
+	c.Assert(body["type"], gc.Equals, "virtual-machine")
+	c.Assert(body["profiles"], jc.DeepEquals, []interface{}{})
+	s.raw.CheckCallNames(c, "WaitForSuccess")
+}
+
+func (s *RESTClientSuite) TestCreateInstanceError(c *gc.C) {
+	s.respond(http.StatusBadRequest, map[string]interface{}{
+		"type":       "error",
+		"error":      "image not found",
+		"error_code": 400,
+	})
+	client := lxdclient.NewRESTClient(s.raw, s.server.URL, true)
+	err := client.CreateInstance(lxdclient.InstanceSpec{Name: "juju-0"})
+	c.Assert(err, gc.ErrorMatches, "creating instance: image not found")
+	s.raw.CheckNoCalls(c)
+}
+
+func (s *RESTClientSuite) TestPullVirtualMachineImage(c *gc.C) {
+	s.respondAsync("/1.0/operations/abc")
+	client := lxdclient.NewRESTClient(s.raw, s.server.URL, true)
+	err := client.PullVirtualMachineImage(
+		lxdclient.CloudImagesRemote, "xenial/amd64", "juju/xenial/amd64/vm",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/images")
+	body := s.requestBody(c, 0)
+	c.Assert(body["aliases"], jc.DeepEquals, []interface{}{
+		map[string]interface{}{"name": "juju/xenial/amd64/vm"},
+	})
+	c.Assert(body["source"], jc.DeepEquals, map[string]interface{}{
+		"type":        "image",
+		"mode":        "pull",
+		"server":      lxdclient.CloudImagesRemote.Host,
+		"protocol":    "simplestreams",
+		"certificate": "",
+		"alias":       "xenial/amd64",
+		"image_type":  "virtual-machine",
+	})
+	s.raw.CheckCall(c, 0, "WaitForSuccess", "/1.0/operations/abc")
+}
+
+func (s *RESTClientSuite) TestInstancesRawClientListContainers(c *gc.C) {
+	s.respond(http.StatusOK, map[string]interface{}{
+		"type": "sync",
+		"metadata": []map[string]interface{}{
+			{"name": "juju-0", "status": "Running"},
+			{"name": "juju-1", "status": "Stopped"},
+		},
+	})
+	raw := lxdclient.NewInstancesRawClient(nil, lxdclient.NewRESTClient(s.raw, s.server.URL, true))
+	containers, err := raw.ListContainers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containers, gc.HasLen, 2)
+	c.Assert(containers[0].Name, gc.Equals, "juju-0")
+	c.Assert(containers[1].Status, gc.Equals, "Stopped")
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/instances")
+	c.Assert(s.requests[0].URL.Query().Get("recursion"), gc.Equals, "1")
+}
+
+func (s *RESTClientSuite) TestInstancesRawClientContainerInfoNotFound(c *gc.C) {
+	s.respond(http.StatusNotFound, map[string]interface{}{
+		"type":       "error",
+		"error":      "not found",
+		"error_code": 404,
+	})
+	raw := lxdclient.NewInstancesRawClient(nil, lxdclient.NewRESTClient(s.raw, s.server.URL, true))
+	_, err := raw.ContainerInfo("juju-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/instances/juju-0")
+}
+
+func (s *RESTClientSuite) TestInstancesRawClientAction(c *gc.C) {
+	s.respondAsync("/1.0/operations/abc")
+	raw := lxdclient.NewInstancesRawClient(nil, lxdclient.NewRESTClient(s.raw, s.server.URL, true))
+	resp, err := raw.Action("juju-0", lxdshared.Stop, 30, true, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Operation, gc.Equals, "/1.0/operations/abc")
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/instances/juju-0/state")
+	c.Assert(s.requestBody(c, 0), jc.DeepEquals, map[string]interface{}{
+		"action":   "stop",
+		"timeout":  float64(30),
+		"force":    true,
+		"stateful": false,
+	})
+}
+
+func (s *RESTClientSuite) TestInstancesRawClientDelete(c *gc.C) {
+	s.respondAsync("/1.0/operations/abc")
+	raw := lxdclient.NewInstancesRawClient(nil, lxdclient.NewRESTClient(s.raw, s.server.URL, true))
+	resp, err := raw.Delete("juju-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Operation, gc.Equals, "/1.0/operations/abc")
+	c.Assert(s.requests[0].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/1.0/instances/juju-0")
+}
+
+type mockRawRESTClient struct {
+	testing.Stub
+}
+
+func (m *mockRawRESTClient) WaitForSuccess(waitURL string) error {
+	m.MethodCall(m, "WaitForSuccess", waitURL)
+	return m.NextErr()
+}
@@ -6,6 +6,8 @@
 package lxdclient
 
 import (
+	"net/http"
+
 	"github.com/juju/testing"
 )
 
@@ -14,6 +16,7 @@ var NewInstanceSummary = newInstanceSummary
 type (
 	RawInstanceClient rawInstanceClient
 	RawStorageClient  rawStorageClient
+	RawRESTClient     rawRESTClient
 )
 
 func NewInstanceClient(raw RawInstanceClient) *instanceClient {
@@ -30,6 +33,34 @@ func NewStorageClient(raw RawStorageClient, supported bool) *storageClient {
 	}
 }
 
+func NewRESTClient(raw RawRESTClient, baseURL string, instances bool) *restClient {
+	return &restClient{
+		raw:       raw,
+		http:      http.DefaultClient,
+		baseURL:   baseURL,
+		instances: instances,
+	}
+}
+
+func NewClusterClient(raw RawRESTClient, baseURL string, supported bool) *clusterClient {
+	return &clusterClient{
+		rest:      NewRESTClient(raw, baseURL, false),
+		supported: supported,
+	}
+}
+
+func NewInstancesRawClient(raw RawInstanceClient, rest *restClient) RawInstanceClient {
+	return instancesRawClient{raw, rest}
+}
+
+func (c *restClient) CreateInstance(spec InstanceSpec) error {
+	return c.createInstance(spec, nil)
+}
+
+func (c *restClient) PullVirtualMachineImage(source Remote, alias, localAlias string) error {
+	return c.pullVirtualMachineImage(source, alias, localAlias)
+}
+
 func PatchGenerateCertificate(s *testing.CleanupSuite, cert, key string) {
 	s.PatchValue(&generateCertificate, func() ([]byte, []byte, error) {
 		return []byte(cert), []byte(key), nil
@@ -14,6 +14,8 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/lxc/lxd/shared"
 	"github.com/lxc/lxd/shared/api"
+
+	"github.com/juju/juju/constraints"
 )
 
 // Constants related to user metadata.
@@ -79,12 +81,38 @@ var AliveStatuses = []string{
 	StatusStopped,
 }
 
+// InstanceType is the type of an LXD instance.
+type InstanceType string
+
+const (
+	// InstanceTypeContainer is the type of an LXD container.
+	InstanceTypeContainer InstanceType = "container"
+
+	// InstanceTypeVirtualMachine is the type of an LXD virtual
+	// machine.
+	InstanceTypeVirtualMachine InstanceType = "virtual-machine"
+)
+
+// ConstraintsInstanceType returns the type of instance to create for
+// the given constraints. Containers are created unless a virtual
+// machine is asked for with the virt-type constraint.
+func ConstraintsInstanceType(cons constraints.Value) InstanceType {
+	if cons.HasVirtType() && *cons.VirtType == string(InstanceTypeVirtualMachine) {
+		return InstanceTypeVirtualMachine
+	}
+	return InstanceTypeContainer
+}
+
 // InstanceSpec holds all the information needed to create a new LXD
 // container.
 type InstanceSpec struct {
 	// Name is the "name" of the instance.
 	Name string
 
+	// Type is the type of the instance. If this is empty, a
+	// container is created.
+	Type InstanceType
+
 	// Image is the name of the image to use.
 	Image string
 
@@ -106,6 +134,10 @@ type InstanceSpec struct {
 	// Devices to be added at container initialisation time.
 	Devices
 
+	// Target is the name of the cluster member on which to create
+	// the container. If this is empty, LXD will choose a member.
+	Target string
+
 	// TODO(ericsnow) Other possible fields:
 	// Disks
 	// Networks
@@ -166,6 +198,10 @@ type InstanceSummary struct {
 	// Name is the "name" of the instance.
 	Name string
 
+	// Type is the type of the instance. If this is empty, a
+	// container is created.
+	Type InstanceType
+
 	// Status holds the status of the instance at a certain point in time.
 	Status string
 
@@ -175,6 +175,14 @@ func (s *stubClient) ContainerInfo(name string) (*api.Container, error) {
 	return &api.Container{}, nil
 }
 
+func (s *stubClient) ApplyProfile(container, profile string) (*api.Response, error) {
+	s.stub.AddCall("ApplyProfile", container, profile)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &api.Response{}, nil
+}
+
 func (s *stubClient) PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error {
 	s.stub.AddCall("PushFile", container, path, gid, uid, mode, buf)
 	if err := s.stub.NextErr(); err != nil {
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// DispatchDue enqueues the actions for all schedules that are
+	// due, and returns the time at which the next is due, or the
+	// zero time if there are no schedules.
+	DispatchDue() (time.Time, error)
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// PollInterval is the longest time the worker waits between
+	// dispatches, so that schedules added while it is waiting are
+	// picked up.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a worker that enqueues scheduled actions when they
+// are due.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker dispatches scheduled actions.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		next, err := w.config.Facade.DispatchDue()
+		if err != nil {
+			return errors.Annotate(err, "cannot dispatch scheduled actions")
+		}
+		delay := w.config.PollInterval
+		if !next.IsZero() {
+			if untilNext := next.Sub(w.config.Clock.Now()); untilNext < delay {
+				delay = untilNext
+			}
+		}
+		if delay < 0 {
+			delay = 0
+		}
+		logger.Tracef("next dispatch in %v", delay)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+		}
+	}
+}
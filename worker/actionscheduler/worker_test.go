@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+}
+
+func (s *WorkerSuite) config() actionscheduler.Config {
+	return actionscheduler.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.PollInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestPollsWithoutSchedules(c *gc.C) {
+	w, err := actionscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertDispatched(c)
+	s.assertNotDispatched(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDispatched(c)
+}
+
+func (s *WorkerSuite) TestWaitsForNextRun(c *gc.C) {
+	s.facade.next = []time.Time{s.clock.Now().Add(10 * time.Second)}
+	w, err := actionscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertDispatched(c)
+	err = s.clock.WaitAdvance(9*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotDispatched(c)
+	s.clock.Advance(time.Second)
+	s.assertDispatched(c)
+}
+
+func (s *WorkerSuite) TestDispatchError(c *gc.C) {
+	s.facade.err = errors.New("blammo")
+	w, err := actionscheduler.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot dispatch scheduled actions: blammo")
+}
+
+func (s *WorkerSuite) assertDispatched(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for dispatch")
+	}
+}
+
+func (s *WorkerSuite) assertNotDispatched(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected dispatch")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade returns each of the next times in turn, and then reports
+// that there are no schedules.
+type mockFacade struct {
+	next  []time.Time
+	err   error
+	calls chan struct{}
+}
+
+func (m *mockFacade) DispatchDue() (time.Time, error) {
+	m.calls <- struct{}{}
+	var next time.Time
+	if len(m.next) > 0 {
+		next, m.next = m.next[0], m.next[1:]
+	}
+	return next, m.err
+}
+
+var _ worker.Worker = (*actionscheduler.Worker)(nil)
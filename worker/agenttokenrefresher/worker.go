@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agenttokenrefresher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.agenttokenrefresher")
+
+// minRefreshDelay is the shortest time the worker waits between
+// refreshes, however soon the token expires.
+const minRefreshDelay = time.Minute
+
+// refreshWindow is the fraction of a token's lifetime, before it
+// expires, during which the worker refreshes it.
+const refreshWindow = 4
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// RefreshToken issues a new token for the agent, and returns
+	// it along with the time at which it expires.
+	RefreshToken() (string, time.Time, error)
+
+	// TokenValidity returns the times at which the agent's current
+	// token was issued and expires, or zero times if the agent has
+	// not been issued a token.
+	TokenValidity() (time.Time, time.Time, error)
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Agent  agent.Agent
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a worker that keeps the agent's token fresh, refreshing
+// it during the last quarter of its lifetime. An agent that has not
+// yet been issued a token is issued one straight away.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker refreshes an agent's token.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	issued, expires, err := w.config.Facade.TokenValidity()
+	if err != nil {
+		return errors.Annotate(err, "cannot get agent token validity")
+	}
+	// If the token has already expired, the agent was only allowed
+	// to connect so that it could obtain a new one; once it has,
+	// the agent must restart to reconnect with full access.
+	expired := !expires.IsZero() && !w.config.Clock.Now().Before(expires)
+	delay := w.refreshDelay(issued, expires)
+	for {
+		if delay > 0 {
+			logger.Debugf("next agent token refresh in %v", delay)
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			case <-w.config.Clock.After(delay):
+			}
+		}
+		expires, err := w.refresh()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if expired {
+			logger.Infof("replaced expired agent token, restarting agent")
+			return jworker.ErrRestartAgent
+		}
+		delay = w.refreshDelay(w.config.Clock.Now(), expires)
+		if delay < minRefreshDelay {
+			delay = minRefreshDelay
+		}
+	}
+}
+
+// refreshDelay returns how long to wait before refreshing a token
+// issued and expiring at the given times.
+func (w *Worker) refreshDelay(issued, expires time.Time) time.Duration {
+	if expires.IsZero() {
+		return 0
+	}
+	refreshAt := expires.Add(-expires.Sub(issued) / refreshWindow)
+	delay := refreshAt.Sub(w.config.Clock.Now())
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// refresh obtains a new token and records it as the agent's password,
+// keeping the current password as a fallback. The controller keeps
+// the current password valid until it expires, so the agent is not
+// locked out if it fails to record the new token.
+func (w *Worker) refresh() (time.Time, error) {
+	token, expires, err := w.config.Facade.RefreshToken()
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot refresh agent token")
+	}
+	err = w.config.Agent.ChangeConfig(func(c agent.ConfigSetter) error {
+		info, ok := c.APIInfo()
+		if !ok {
+			return errors.New("API info not available")
+		}
+		c.SetOldPassword(info.Password)
+		c.SetPassword(token)
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "cannot record agent token")
+	}
+	logger.Infof("agent token refreshed, expires %v", expires)
+	return expires, nil
+}
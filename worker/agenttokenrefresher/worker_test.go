@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agenttokenrefresher_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/agenttokenrefresher"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+	agent  *mockAgent
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		clock: s.clock,
+		calls: make(chan string, 10),
+	}
+	s.agent = &mockAgent{password: "original"}
+}
+
+func (s *WorkerSuite) config() agenttokenrefresher.Config {
+	return agenttokenrefresher.Config{
+		Facade: s.facade,
+		Agent:  s.agent,
+		Clock:  s.clock,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Agent = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Agent not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestRefreshesNearExpiry(c *gc.C) {
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertRefreshed(c, "token-1")
+	err = s.clock.WaitAdvance(17*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotRefreshed(c)
+	s.clock.Advance(time.Hour)
+	s.assertRefreshed(c, "token-2")
+
+	c.Check(s.agent.previousPassword(), gc.Equals, "token-1")
+}
+
+func (s *WorkerSuite) TestWaitsForCurrentTokenToNearExpiry(c *gc.C) {
+	s.facade.issued = s.clock.Now().Add(-4 * time.Hour)
+	s.facade.expires = s.clock.Now().Add(20 * time.Hour)
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(13*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotRefreshed(c)
+	s.clock.Advance(time.Hour)
+	s.assertRefreshed(c, "token-1")
+}
+
+func (s *WorkerSuite) TestExpiredTokenRestartsAgent(c *gc.C) {
+	s.facade.issued = s.clock.Now().Add(-25 * time.Hour)
+	s.facade.expires = s.clock.Now().Add(-time.Hour)
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.assertRefreshed(c, "token-1")
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.Equals, jworker.ErrRestartAgent)
+	c.Check(s.agent.previousPassword(), gc.Equals, "original")
+}
+
+func (s *WorkerSuite) TestTokenValidityError(c *gc.C) {
+	s.facade.validityErr = errors.New("boom")
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot get agent token validity: boom")
+	c.Check(s.agent.currentPassword(), gc.Equals, "original")
+}
+
+func (s *WorkerSuite) TestRecordsOldPassword(c *gc.C) {
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertRefreshed(c, "token-1")
+	c.Check(s.agent.previousPassword(), gc.Equals, "original")
+}
+
+func (s *WorkerSuite) TestRefreshError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := agenttokenrefresher.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot refresh agent token: boom")
+	c.Check(s.agent.currentPassword(), gc.Equals, "original")
+}
+
+func (s *WorkerSuite) assertRefreshed(c *gc.C, token string) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("token not refreshed")
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.agent.currentPassword() == token {
+			return
+		}
+	}
+	c.Fatalf("token %q not recorded", token)
+}
+
+func (s *WorkerSuite) assertNotRefreshed(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected token refresh")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	clock       *testing.Clock
+	calls       chan string
+	count       int
+	err         error
+	issued      time.Time
+	expires     time.Time
+	validityErr error
+}
+
+func (f *mockFacade) TokenValidity() (time.Time, time.Time, error) {
+	return f.issued, f.expires, f.validityErr
+}
+
+func (f *mockFacade) RefreshToken() (string, time.Time, error) {
+	if f.err != nil {
+		return "", time.Time{}, f.err
+	}
+	f.count++
+	token := fmt.Sprintf("token-%d", f.count)
+	f.calls <- token
+	return token, f.clock.Now().Add(24 * time.Hour), nil
+}
+
+type mockAgent struct {
+	agent.Agent
+	mu          sync.Mutex
+	password    string
+	oldPassword string
+}
+
+func (a *mockAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return mutate(&mockSetter{agent: a})
+}
+
+func (a *mockAgent) currentPassword() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.password
+}
+
+func (a *mockAgent) previousPassword() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.oldPassword
+}
+
+type mockSetter struct {
+	agent.ConfigSetter
+	agent *mockAgent
+}
+
+func (s *mockSetter) APIInfo() (*api.Info, bool) {
+	return &api.Info{Password: s.agent.password}, true
+}
+
+func (s *mockSetter) SetOldPassword(password string) {
+	s.agent.oldPassword = password
+}
+
+func (s *mockSetter) SetPassword(password string) {
+	s.agent.password = password
+}
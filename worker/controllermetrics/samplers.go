@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllermetrics
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/state"
+)
+
+// These are the names of the metrics that are sampled.
+const (
+	MongoDataBytes    = "mongo-data-bytes"
+	MongoStorageBytes = "mongo-storage-bytes"
+	MongoIndexBytes   = "mongo-index-bytes"
+	MongoObjects      = "mongo-objects"
+	MongoPingSeconds  = "mongo-ping-seconds"
+	TxnQueueDepth     = "txn-queue-depth"
+	PubSubLagSeconds  = "pubsub-lag-seconds"
+	LeaseReadSeconds  = "lease-read-seconds"
+)
+
+// probeTopic is the pubsub topic used to measure delivery lag.
+const probeTopic = "controllermetrics.probe"
+
+// pubSubTimeout is how long to wait for a pubsub probe to be
+// delivered before giving up.
+const pubSubTimeout = 30 * time.Second
+
+// MongoBackend exposes the database statistics sampled by
+// MongoSampler.
+type MongoBackend interface {
+	MongoStats() (state.MongoStats, error)
+	Ping() error
+}
+
+// MongoSampler returns a Sampler that reports the size of the juju
+// database, and the time taken to ping it.
+func MongoSampler(backend MongoBackend, clock clock.Clock) Sampler {
+	return SamplerFunc(func() (map[string]float64, error) {
+		start := clock.Now()
+		if err := backend.Ping(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ping := clock.Now().Sub(start)
+		stats, err := backend.MongoStats()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return map[string]float64{
+			MongoDataBytes:    stats.DataSize,
+			MongoStorageBytes: stats.StorageSize,
+			MongoIndexBytes:   stats.IndexSize,
+			MongoObjects:      stats.Objects,
+			MongoPingSeconds:  ping.Seconds(),
+		}, nil
+	})
+}
+
+// TxnBackend exposes the transaction queue sampled by TxnSampler.
+type TxnBackend interface {
+	PendingTransactionCount() (int, error)
+}
+
+// TxnSampler returns a Sampler that reports the number of
+// transactions waiting to be applied.
+func TxnSampler(backend TxnBackend) Sampler {
+	return SamplerFunc(func() (map[string]float64, error) {
+		count, err := backend.PendingTransactionCount()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return map[string]float64{TxnQueueDepth: float64(count)}, nil
+	})
+}
+
+// LeaseBackend exposes the leases read by LeaseSampler.
+type LeaseBackend interface {
+	ApplicationLeaders() (map[string]string, error)
+}
+
+// LeaseSampler returns a Sampler that reports the time taken to read
+// the model's leadership leases.
+func LeaseSampler(backend LeaseBackend, clock clock.Clock) Sampler {
+	return SamplerFunc(func() (map[string]float64, error) {
+		start := clock.Now()
+		if _, err := backend.ApplicationLeaders(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return map[string]float64{
+			LeaseReadSeconds: clock.Now().Sub(start).Seconds(),
+		}, nil
+	})
+}
+
+// PubSubSampler returns a Sampler that reports the time taken for a
+// message published on the hub to be delivered to its subscribers.
+func PubSubSampler(hub *pubsub.StructuredHub, clock clock.Clock) Sampler {
+	return SamplerFunc(func() (map[string]float64, error) {
+		unsubscribe, err := hub.Subscribe(probeTopic, func(string, map[string]interface{}, error) {})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer unsubscribe()
+
+		start := clock.Now()
+		done, err := hub.Publish(probeTopic, map[string]interface{}{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		select {
+		case <-done:
+		case <-clock.After(pubSubTimeout):
+			return nil, errors.Errorf("probe not delivered after %v", pubSubTimeout)
+		}
+		return map[string]float64{
+			PubSubLagSeconds: clock.Now().Sub(start).Seconds(),
+		}, nil
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllermetrics_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/controllermetrics"
+)
+
+type SamplersSuite struct {
+	jujutesting.IsolationSuite
+	clock *jujutesting.Clock
+}
+
+var _ = gc.Suite(&SamplersSuite{})
+
+func (s *SamplersSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *SamplersSuite) TestMongoSampler(c *gc.C) {
+	backend := &stubBackend{
+		Stub: &jujutesting.Stub{},
+		stats: state.MongoStats{
+			DataSize:    100,
+			StorageSize: 200,
+			IndexSize:   50,
+			Objects:     10,
+		},
+	}
+	values, err := controllermetrics.MongoSampler(backend, s.clock).Sample()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]float64{
+		controllermetrics.MongoDataBytes:    100,
+		controllermetrics.MongoStorageBytes: 200,
+		controllermetrics.MongoIndexBytes:   50,
+		controllermetrics.MongoObjects:      10,
+		controllermetrics.MongoPingSeconds:  0,
+	})
+	backend.CheckCallNames(c, "Ping", "MongoStats")
+}
+
+func (s *SamplersSuite) TestMongoSamplerError(c *gc.C) {
+	backend := &stubBackend{Stub: &jujutesting.Stub{}}
+	backend.SetErrors(errors.New("no reachable servers"))
+	_, err := controllermetrics.MongoSampler(backend, s.clock).Sample()
+	c.Assert(err, gc.ErrorMatches, "no reachable servers")
+}
+
+func (s *SamplersSuite) TestTxnSampler(c *gc.C) {
+	backend := &stubBackend{Stub: &jujutesting.Stub{}, pending: 7}
+	values, err := controllermetrics.TxnSampler(backend).Sample()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]float64{
+		controllermetrics.TxnQueueDepth: 7,
+	})
+}
+
+func (s *SamplersSuite) TestLeaseSampler(c *gc.C) {
+	backend := &stubBackend{Stub: &jujutesting.Stub{}}
+	values, err := controllermetrics.LeaseSampler(backend, s.clock).Sample()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, map[string]float64{
+		controllermetrics.LeaseReadSeconds: 0,
+	})
+	backend.CheckCallNames(c, "ApplicationLeaders")
+}
+
+func (s *SamplersSuite) TestPubSubSampler(c *gc.C) {
+	hub := pubsub.NewStructuredHub(nil)
+	values, err := controllermetrics.PubSubSampler(hub, s.clock).Sample()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, gc.HasLen, 1)
+	c.Assert(values[controllermetrics.PubSubLagSeconds], gc.Equals, float64(0))
+}
+
+type stubBackend struct {
+	*jujutesting.Stub
+	stats   state.MongoStats
+	pending int
+}
+
+func (b *stubBackend) Ping() error {
+	b.AddCall("Ping")
+	return b.NextErr()
+}
+
+func (b *stubBackend) MongoStats() (state.MongoStats, error) {
+	b.AddCall("MongoStats")
+	return b.stats, b.NextErr()
+}
+
+func (b *stubBackend) PendingTransactionCount() (int, error) {
+	b.AddCall("PendingTransactionCount")
+	return b.pending, b.NextErr()
+}
+
+func (b *stubBackend) ApplicationLeaders() (map[string]string, error) {
+	b.AddCall("ApplicationLeaders")
+	return map[string]string{"mysql": "mysql/0"}, b.NextErr()
+}
@@ -0,0 +1,19 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllermetrics
+
+import (
+	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
+)
+
+// NewWorker returns a new controllermetrics worker, for use in a
+// Manifold.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllermetrics provides a worker which runs in each
+// controller machine agent, regularly sampling internal metrics such
+// as database size, transaction queue depth and pubsub lag, and
+// recording a rolling window of samples in state. The samples can be
+// retrieved with the controller-metrics command, without depending on
+// an external monitoring system.
+package controllermetrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.controllermetrics")
+
+const (
+	// DefaultInterval is how often metrics are sampled.
+	DefaultInterval = time.Minute
+
+	// DefaultWindow is how long samples are kept for.
+	DefaultWindow = 24 * time.Hour
+)
+
+// Store records samples of controller metrics.
+type Store interface {
+	AddControllerMetrics(sample state.ControllerMetricsSample, retain time.Duration) error
+}
+
+// Sampler samples one or more metrics.
+type Sampler interface {
+	Sample() (map[string]float64, error)
+}
+
+// SamplerFunc is a function that implements Sampler.
+type SamplerFunc func() (map[string]float64, error)
+
+// Sample is part of the Sampler interface.
+func (f SamplerFunc) Sample() (map[string]float64, error) {
+	return f()
+}
+
+// Config defines the parameters of the controllermetrics worker.
+type Config struct {
+	Store    Store
+	Machine  string
+	Samplers map[string]Sampler
+	Clock    clock.Clock
+	Interval time.Duration
+	Window   time.Duration
+}
+
+// Validate returns an error if Config cannot drive a controllermetrics
+// worker.
+func (config Config) Validate() error {
+	if config.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	if config.Machine == "" {
+		return errors.NotValidf("empty Machine")
+	}
+	if len(config.Samplers) == 0 {
+		return errors.NotValidf("empty Samplers")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.Window < config.Interval {
+		return errors.NotValidf("Window shorter than Interval")
+	}
+	return nil
+}
+
+// New returns a Worker that samples metrics when it starts, and
+// regularly thereafter, recording each sample in the Store.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &controllerMetrics{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type controllerMetrics struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *controllerMetrics) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *controllerMetrics) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *controllerMetrics) loop() error {
+	for {
+		if err := w.sample(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// sample runs every sampler and records the results. A sampler that
+// fails is logged and skipped, so that one unavailable metric does not
+// prevent the others from being recorded.
+func (w *controllerMetrics) sample() error {
+	sample := state.ControllerMetricsSample{
+		Machine: w.config.Machine,
+		Time:    w.config.Clock.Now(),
+		Metrics: make(map[string]float64),
+	}
+	names := make([]string, 0, len(w.config.Samplers))
+	for name := range w.config.Samplers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values, err := w.config.Samplers[name].Sample()
+		if err != nil {
+			logger.Warningf("cannot sample %s metrics: %v", name, err)
+			continue
+		}
+		for metric, value := range values {
+			sample.Metrics[metric] = value
+		}
+	}
+	if err := w.config.Store.AddControllerMetrics(sample, w.config.Window); err != nil {
+		return errors.Annotate(err, "recording controller metrics")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllermetrics_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllermetrics"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	clock  *jujutesting.Clock
+	store  *stubStore
+	config controllermetrics.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.store = &stubStore{
+		Stub:    &jujutesting.Stub{},
+		samples: make(chan state.ControllerMetricsSample, 10),
+	}
+	s.config = controllermetrics.Config{
+		Store:   s.store,
+		Machine: "0",
+		Samplers: map[string]controllermetrics.Sampler{
+			"txn": controllermetrics.SamplerFunc(func() (map[string]float64, error) {
+				return map[string]float64{"txn-queue-depth": 3}, nil
+			}),
+			"broken": controllermetrics.SamplerFunc(func() (map[string]float64, error) {
+				return nil, errors.New("boom")
+			}),
+		},
+		Clock:    s.clock,
+		Interval: time.Minute,
+		Window:   time.Hour,
+	}
+}
+
+func (s *WorkerSuite) waitSample(c *gc.C) state.ControllerMetricsSample {
+	select {
+	case sample := <-s.store.samples:
+		return sample
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sample")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Window = time.Second
+	_, err := controllermetrics.New(s.config)
+	c.Assert(err, gc.ErrorMatches, "Window shorter than Interval not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	s.config.Samplers = nil
+	_, err = controllermetrics.New(s.config)
+	c.Assert(err, gc.ErrorMatches, "empty Samplers not valid")
+}
+
+func (s *WorkerSuite) TestSamplesPeriodically(c *gc.C) {
+	w, err := controllermetrics.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	sample := s.waitSample(c)
+	c.Assert(sample, jc.DeepEquals, state.ControllerMetricsSample{
+		Machine: "0",
+		Time:    s.clock.Now(),
+		Metrics: map[string]float64{"txn-queue-depth": 3},
+	})
+	s.store.CheckCall(c, 0, "AddControllerMetrics", sample, time.Hour)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	sample = s.waitSample(c)
+	c.Assert(sample.Time, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestStoreError(c *gc.C) {
+	s.store.SetErrors(errors.New("no mongo"))
+	w, err := controllermetrics.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "recording controller metrics: no mongo")
+}
+
+type stubStore struct {
+	*jujutesting.Stub
+	samples chan state.ControllerMetricsSample
+}
+
+func (s *stubStore) AddControllerMetrics(sample state.ControllerMetricsSample, retain time.Duration) error {
+	s.AddCall("AddControllerMetrics", sample, retain)
+	if err := s.NextErr(); err != nil {
+		return err
+	}
+	s.samples <- sample
+	return nil
+}
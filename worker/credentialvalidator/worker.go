@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.credentialvalidator")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+	environs.EnvironConfigGetter
+
+	// WatchModelCredential returns a watcher that reports changes
+	// to the model's cloud credential. If the model has no
+	// credential, it returns an error satisfying errors.IsNotFound.
+	WatchModelCredential() (watcher.NotifyWatcher, error)
+
+	// SetCredentialValidity records whether the cloud accepted the
+	// model's credential, suspending the model if it did not.
+	SetCredentialValidity(valid bool, reason string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade         Facade
+	Clock          clock.Clock
+	NewEnvironFunc environs.NewEnvironFunc
+
+	// CheckInterval is the longest time the worker waits between
+	// checks of the model's credential.
+	CheckInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewEnvironFunc == nil {
+		return errors.NotValidf("nil NewEnvironFunc")
+	}
+	if config.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	return nil
+}
+
+// New returns a worker that checks the model's cloud credential
+// against the cloud, suspending the model while the cloud rejects
+// it. The credential is checked at regular intervals, and whenever
+// it is updated.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker checks the validity of the model's cloud credential.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	// Models on clouds that need no credential are still checked,
+	// but there is no credential to watch.
+	var credentialChanges watcher.NotifyChannel
+	credentialWatcher, err := w.config.Facade.WatchModelCredential()
+	if err == nil {
+		if err := w.catacomb.Add(credentialWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		credentialChanges = credentialWatcher.Changes()
+	} else if !errors.IsNotFound(err) {
+		return errors.Annotate(err, "cannot watch model credential")
+	}
+
+	for {
+		if err := w.check(); err != nil {
+			return errors.Annotate(err, "cannot check model credential")
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-credentialChanges:
+			if !ok {
+				return errors.New("model credential watcher closed")
+			}
+			logger.Debugf("model credential changed")
+		case <-w.config.Clock.After(w.config.CheckInterval):
+		}
+	}
+}
+
+// check asks the cloud for the model's instances, using a freshly
+// opened environ so that the current credential is used. If the
+// cloud fails the request for a reason other than the credential,
+// the credential's validity is left as it was.
+func (w *Worker) check() error {
+	environ, err := environs.GetEnviron(w.config.Facade, w.config.NewEnvironFunc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = environ.AllInstances()
+	switch {
+	case err == nil, errors.Cause(err) == environs.ErrNoInstances:
+		return errors.Trace(w.config.Facade.SetCredentialValidity(true, ""))
+	case environs.ErrorClassOf(err) == environs.ErrorClassCredential:
+		logger.Warningf("model credential is not valid: %v", err)
+		return errors.Trace(w.config.Facade.SetCredentialValidity(false, errors.Cause(err).Error()))
+	default:
+		logger.Warningf("cannot determine validity of model credential: %v", err)
+		return nil
+	}
+}
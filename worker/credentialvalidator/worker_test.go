@@ -0,0 +1,220 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	facade  *mockFacade
+	environ *mockEnviron
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		watcher: newNotifyWatcher(),
+		calls:   make(chan validity, 10),
+	}
+	s.environ = &mockEnviron{}
+}
+
+func (s *WorkerSuite) config() credentialvalidator.Config {
+	return credentialvalidator.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		NewEnvironFunc: func(environs.OpenParams) (environs.Environ, error) {
+			return s.environ, nil
+		},
+		CheckInterval: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.NewEnvironFunc = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil NewEnvironFunc not valid")
+
+	config = s.config()
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+}
+
+func (s *WorkerSuite) TestValidCredential(c *gc.C) {
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c, validity{valid: true})
+	s.assertNotChecked(c)
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertChecked(c, validity{valid: true})
+}
+
+func (s *WorkerSuite) TestInvalidCredential(c *gc.C) {
+	s.environ.err = common.ClassifiedError(environs.ErrorClassCredential, errors.New("token expired"))
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c, validity{reason: "token expired"})
+}
+
+func (s *WorkerSuite) TestUnclassifiedErrorIgnored(c *gc.C) {
+	s.environ.err = errors.New("cloud on fire")
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotChecked(c)
+}
+
+func (s *WorkerSuite) TestChecksWhenCredentialChanges(c *gc.C) {
+	s.environ.err = common.ClassifiedError(environs.ErrorClassCredential, errors.New("token expired"))
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.assertChecked(c, validity{reason: "token expired"})
+
+	s.environ.setErr(nil)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertChecked(c, validity{valid: true})
+}
+
+func (s *WorkerSuite) TestNoModelCredential(c *gc.C) {
+	s.facade.watchErr = errors.NotFoundf("model credential")
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c, validity{valid: true})
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.watchErr = errors.New("blammo")
+	w, err := credentialvalidator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot watch model credential: blammo")
+}
+
+func (s *WorkerSuite) assertChecked(c *gc.C, expected validity) {
+	select {
+	case v := <-s.facade.calls:
+		c.Check(v, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for check")
+	}
+}
+
+func (s *WorkerSuite) assertNotChecked(c *gc.C) {
+	select {
+	case v := <-s.facade.calls:
+		c.Fatalf("unexpected check: %+v", v)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type validity struct {
+	valid  bool
+	reason string
+}
+
+// mockFacade implements credentialvalidator.Facade, sending each
+// recorded validity on its calls channel.
+type mockFacade struct {
+	watcher  *notifyWatcher
+	watchErr error
+	calls    chan validity
+}
+
+func (m *mockFacade) ModelConfig() (*config.Config, error) {
+	return nil, nil
+}
+
+func (m *mockFacade) CloudSpec() (environs.CloudSpec, error) {
+	return environs.CloudSpec{}, nil
+}
+
+func (m *mockFacade) WatchModelCredential() (watcher.NotifyWatcher, error) {
+	if m.watchErr != nil {
+		return nil, m.watchErr
+	}
+	return m.watcher, nil
+}
+
+func (m *mockFacade) SetCredentialValidity(valid bool, reason string) error {
+	m.calls <- validity{valid, reason}
+	return nil
+}
+
+// mockEnviron fails AllInstances with its error, if any.
+type mockEnviron struct {
+	environs.Environ
+	mu  sync.Mutex
+	err error
+}
+
+func (e *mockEnviron) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return nil, e.err
+}
+
+func newNotifyWatcher() *notifyWatcher {
+	return &notifyWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan struct{}, 1),
+	}
+}
+
+type notifyWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *notifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
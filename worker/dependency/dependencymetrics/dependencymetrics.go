@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependencymetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/dependency"
+)
+
+var (
+	jujuDependencyWorkerStartsTotalDesc = prometheus.NewDesc(
+		"juju_dependency_engine_worker_starts_total",
+		"Total number of times each manifold's worker has been started.",
+		[]string{"manifold"},
+		prometheus.Labels{},
+	)
+	jujuDependencyWorkersDesc = prometheus.NewDesc(
+		"juju_dependency_engine_workers",
+		"Number of manifold workers in each state.",
+		[]string{"state"},
+		prometheus.Labels{},
+	)
+)
+
+// EngineMetrics is a prometheus.Collector that collects worker start
+// counts and states from a dependency engine's report.
+type EngineMetrics struct {
+	dependency.Reporter
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (EngineMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jujuDependencyWorkerStartsTotalDesc
+	ch <- jujuDependencyWorkersDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m EngineMetrics) Collect(ch chan<- prometheus.Metric) {
+	manifolds, _ := m.Report()[dependency.KeyManifolds].(map[string]interface{})
+	states := make(map[string]int)
+	for name, value := range manifolds {
+		report, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if state, ok := report[dependency.KeyState].(string); ok {
+			states[state]++
+		}
+		starts, _ := report[dependency.KeyStartCount].(int)
+		ch <- prometheus.MustNewConstMetric(
+			jujuDependencyWorkerStartsTotalDesc,
+			prometheus.CounterValue,
+			float64(starts),
+			name,
+		)
+	}
+	for state, count := range states {
+		ch <- prometheus.MustNewConstMetric(
+			jujuDependencyWorkersDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			state,
+		)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependencymetrics_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dependency/dependencymetrics"
+)
+
+type engineMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&engineMetricsSuite{})
+
+type fakeReporter map[string]interface{}
+
+func (r fakeReporter) Report() map[string]interface{} {
+	return r
+}
+
+func (s *engineMetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		dependencymetrics.EngineMetrics{Reporter: fakeReporter{}}.Describe(ch)
+	}()
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	c.Assert(descs, gc.HasLen, 2)
+	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_dependency_engine_worker_starts_total".*`)
+	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_dependency_engine_workers".*`)
+}
+
+func (s *engineMetricsSuite) TestCollect(c *gc.C) {
+	reporter := fakeReporter{
+		dependency.KeyState: "started",
+		dependency.KeyManifolds: map[string]interface{}{
+			"task": map[string]interface{}{
+				dependency.KeyState:      "started",
+				dependency.KeyStartCount: 3,
+			},
+		},
+	}
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		dependencymetrics.EngineMetrics{Reporter: reporter}.Collect(ch)
+	}()
+
+	var metrics []dto.Metric
+	for metric := range ch {
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		metrics = append(metrics, m)
+	}
+
+	float64ptr := func(v float64) *float64 {
+		return &v
+	}
+	stringptr := func(v string) *string {
+		return &v
+	}
+	c.Assert(metrics, jc.DeepEquals, []dto.Metric{{
+		Label: []*dto.LabelPair{{
+			Name:  stringptr("manifold"),
+			Value: stringptr("task"),
+		}},
+		Counter: &dto.Counter{Value: float64ptr(3)},
+	}, {
+		Label: []*dto.LabelPair{{
+			Name:  stringptr("state"),
+			Value: stringptr("started"),
+		}},
+		Gauge: &dto.Gauge{Value: float64ptr(1)},
+	}})
+}
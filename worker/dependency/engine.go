@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/tomb.v1"
@@ -42,6 +43,10 @@ type EngineConfig struct {
 	// a worker that was deliberately stopped because its dependencies
 	// changed. It must not be negative.
 	BounceDelay time.Duration
+
+	// Clock is used to record when workers start, so that reports can
+	// include their uptime. It must not be nil.
+	Clock clock.Clock
 }
 
 // Validate returns an error if any field is invalid.
@@ -58,6 +63,9 @@ func (config *EngineConfig) Validate() error {
 	if config.BounceDelay < 0 {
 		return errors.New("BounceDelay is negative")
 	}
+	if config.Clock == nil {
+		return errors.New("Clock not specified")
+	}
 	return nil
 }
 
@@ -234,10 +242,19 @@ func (engine *Engine) manifoldsReport() map[string]interface{} {
 			KeyState:       info.state(),
 			KeyInputs:      engine.manifolds[name].Inputs,
 			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyStartCount:  info.startCount,
 		}
 		if info.err != nil {
 			report[KeyError] = info.err.Error()
 		}
+		if info.lastErr != nil {
+			report[KeyLastError] = info.lastErr.Error()
+		}
+		if info.worker != nil {
+			report[KeyStarted] = info.started
+			uptime := engine.config.Clock.Now().Sub(info.started)
+			report[KeyUptime] = (uptime / time.Second * time.Second).String()
+		}
 		if reporter, ok := info.worker.(Reporter); ok {
 			if reporter != engine {
 				report[KeyReport] = reporter.Report()
@@ -487,6 +504,9 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 		engine.current[name] = workerInfo{
 			worker:      worker,
 			resourceLog: resourceLog,
+			startCount:  info.startCount + 1,
+			started:     engine.config.Clock.Now(),
+			lastErr:     info.lastErr,
 		}
 
 		// Any manifold that declares this one as an input needs to be restarted.
@@ -516,9 +536,16 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	}
 
 	// Reset engine info; and bail out if we can be sure there's no need to bounce.
+	// Deliberate restarts aren't worth remembering as the last error.
+	lastErr := info.lastErr
+	if err != nil && errors.Cause(err) != ErrBounce {
+		lastErr = err
+	}
 	engine.current[name] = workerInfo{
 		err:         err,
+		lastErr:     lastErr,
 		resourceLog: resourceLog,
+		startCount:  info.startCount,
 	}
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
@@ -631,6 +658,14 @@ type workerInfo struct {
 	worker      worker.Worker
 	err         error
 	resourceLog []resourceAccess
+	startCount  int
+
+	// started holds the time at which the current worker started.
+	started time.Time
+
+	// lastErr holds the most recent error returned by any of the
+	// manifold's workers, and survives their restarts.
+	lastErr error
 }
 
 // stopped returns true unless the worker is either assigned or starting.
@@ -687,6 +687,10 @@ func (s *EngineSuite) TestConfigValidate(c *gc.C) {
 		func(config *dependency.EngineConfig) {
 			config.BounceDelay = -time.Second
 		}, "BounceDelay is negative",
+	}, {
+		func(config *dependency.EngineConfig) {
+			config.Clock = nil
+		}, "Clock not specified",
 	}}
 
 	for i, test := range tests {
@@ -696,6 +700,7 @@ func (s *EngineSuite) TestConfigValidate(c *gc.C) {
 			WorstError:  firstError,
 			ErrorDelay:  time.Second,
 			BounceDelay: time.Second,
+			Clock:       testing.NewClock(time.Time{}),
 		}
 		test.breakConfig(&config)
 
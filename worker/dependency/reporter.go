@@ -57,6 +57,24 @@ const (
 	// error encountered.
 	KeyResourceLog = "resource-log"
 
+	// KeyStartCount holds the number of times the manifold's worker has
+	// been successfully started by the engine.
+	KeyStartCount = "start-count"
+
+	// KeyStarted holds the time at which the manifold's current worker was
+	// started; it is only present while the worker is running.
+	KeyStarted = "started"
+
+	// KeyUptime holds how long the manifold's current worker has been
+	// running, to the nearest second; it is only present while the worker
+	// is running.
+	KeyUptime = "uptime"
+
+	// KeyLastError holds the most recent error returned by any of the
+	// manifold's workers (or their start funcs). Unlike KeyError, it is
+	// kept when the worker is restarted.
+	KeyLastError = "last-error"
+
 	// KeyName holds the name of some resource.
 	KeyName = "name"
 
@@ -6,6 +6,7 @@ package dependency_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -94,6 +95,9 @@ func (s *ReportSuite) TestReportStopping(c *gc.C) {
 					"state":        "stopping",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
+					"started":      time.Time{},
+					"uptime":       "0s",
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -123,6 +127,9 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 					"state":        "started",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
+					"started":      time.Time{},
+					"uptime":       "0s",
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -134,6 +141,9 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 						"name": "task",
 						"type": "<nil>",
 					}},
+					"start-count": 1,
+					"started":     time.Time{},
+					"uptime":      "0s",
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -157,16 +167,71 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 			"state": "stopped",
 			"manifolds": map[string]interface{}{
 				"task": map[string]interface{}{
-					"state":  "stopped",
-					"error":  `"missing" not running: dependency not available`,
-					"inputs": []string{"missing"},
+					"state":      "stopped",
+					"error":      `"missing" not running: dependency not available`,
+					"last-error": `"missing" not running: dependency not available`,
+					"inputs":     []string{"missing"},
 					"resource-log": []map[string]interface{}{{
 						"name":  "missing",
 						"type":  "<nil>",
 						"error": `"missing" not running: dependency not available`,
 					}},
+					"start-count": 0,
 				},
 			},
 		})
 	})
 }
+
+func (s *ReportSuite) TestReportStartCount(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		mh1.InjectError(c, errors.New("boom"))
+		mh1.AssertOneStart(c)
+
+		report := engine.Report()
+		manifolds := report["manifolds"].(map[string]interface{})
+		task := manifolds["task"].(map[string]interface{})
+		c.Check(task["start-count"], gc.Equals, 2)
+	})
+}
+
+func (s *ReportSuite) TestReportUptime(c *gc.C) {
+	s.fix.clock = testing.NewClock(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		s.fix.clock.Advance(90*time.Second + 300*time.Millisecond)
+		report := engine.Report()
+		manifolds := report["manifolds"].(map[string]interface{})
+		task := manifolds["task"].(map[string]interface{})
+		c.Check(task["started"], gc.Equals, time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+		c.Check(task["uptime"], gc.Equals, "1m30s")
+	})
+}
+
+func (s *ReportSuite) TestReportLastError(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		mh1.InjectError(c, errors.New("boom"))
+		mh1.AssertOneStart(c)
+
+		report := engine.Report()
+		manifolds := report["manifolds"].(map[string]interface{})
+		task := manifolds["task"].(map[string]interface{})
+		c.Check(task["state"], gc.Equals, "started")
+		c.Check(task["error"], gc.IsNil)
+		c.Check(task["last-error"], gc.Equals, "boom")
+	})
+}
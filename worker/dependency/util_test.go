@@ -6,6 +6,7 @@ package dependency_test
 import (
 	"time"
 
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	worker "gopkg.in/juju/worker.v1"
@@ -20,6 +21,7 @@ type engineFixture struct {
 	isFatal    dependency.IsFatalFunc
 	worstError dependency.WorstErrorFunc
 	filter     dependency.FilterFunc
+	clock      *testing.Clock
 	dirty      bool
 }
 
@@ -37,6 +39,13 @@ func (fix *engineFixture) worstErrorFunc() dependency.WorstErrorFunc {
 	return firstError
 }
 
+func (fix *engineFixture) clockOrDefault() *testing.Clock {
+	if fix.clock == nil {
+		fix.clock = testing.NewClock(time.Time{})
+	}
+	return fix.clock
+}
+
 func (fix *engineFixture) run(c *gc.C, test func(*dependency.Engine)) {
 	config := dependency.EngineConfig{
 		IsFatal:     fix.isFatalFunc(),
@@ -44,6 +53,7 @@ func (fix *engineFixture) run(c *gc.C, test func(*dependency.Engine)) {
 		Filter:      fix.filter, // can be nil anyway
 		ErrorDelay:  coretesting.ShortWait / 2,
 		BounceDelay: coretesting.ShortWait / 10,
+		Clock:       fix.clockOrDefault(),
 	}
 
 	engine, err := dependency.NewEngine(config)
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.deployer")
@@ -54,7 +55,9 @@ type Context interface {
 }
 
 // NewDeployer returns a Worker that deploys and recalls unit agents
-// via ctx, taking a machine id to operate on.
+// via ctx, taking a machine id to operate on. If ctx is itself a
+// worker, as when it runs the unit agents in-process, it is stopped
+// along with the deployer.
 func NewDeployer(st *apideployer.State, ctx Context) (worker.Worker, error) {
 	d := &Deployer{
 		st:       st,
@@ -64,12 +67,51 @@ func NewDeployer(st *apideployer.State, ctx Context) (worker.Worker, error) {
 	w, err := watcher.NewStringsWorker(watcher.StringsConfig{
 		Handler: d,
 	})
+	ctxWorker, isWorker := ctx.(worker.Worker)
+	if err != nil {
+		if isWorker {
+			worker.Stop(ctxWorker)
+		}
+		return nil, errors.Trace(err)
+	}
+	if !isWorker {
+		return w, nil
+	}
+	return newDeployerWorker(w, ctxWorker)
+}
+
+// deployerWorker runs a deployer along with the context it deploys
+// units with.
+type deployerWorker struct {
+	catacomb catacomb.Catacomb
+}
+
+func newDeployerWorker(deployer, ctx worker.Worker) (worker.Worker, error) {
+	w := &deployerWorker{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			<-w.catacomb.Dying()
+			return w.catacomb.ErrDying()
+		},
+		Init: []worker.Worker{deployer, ctx},
+	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return w, nil
 }
 
+// Kill is part of the worker.Worker interface.
+func (w *deployerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *deployerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
 func (d *Deployer) SetUp() (watcher.StringsWatcher, error) {
 	tag := d.ctx.AgentConfig().Tag()
 	machineTag, ok := tag.(names.MachineTag)
@@ -32,3 +32,9 @@ func NewTestSimpleContext(agentConfig agent.Config, logDir string, data *svctest
 		},
 	}
 }
+
+func NewTestNestedContext(agentConfig agent.Config, newUnitAgent NewUnitAgentFunc) *NestedContext {
+	return NewNestedContext(agentConfig, &fakeAPI{}, newUnitAgent)
+}
+
+var HasUnitAgents = hasUnitAgents
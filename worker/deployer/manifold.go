@@ -4,6 +4,10 @@
 package deployer
 
 import (
+	"io/ioutil"
+	"os"
+	"strconv"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
@@ -70,11 +74,60 @@ func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller)
 		return nil, dependency.ErrUninstall
 	}
 
+	if err := recordUnitAgentsInProcess(a, agentFacade); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	deployerFacade := apideployer.NewState(apiCaller)
-	context := config.NewDeployContext(deployerFacade, cfg)
+	context := config.NewDeployContext(deployerFacade, a.CurrentConfig())
 	w, err := NewDeployer(deployerFacade, context)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start unit agent deployer worker")
 	}
 	return w, nil
 }
+
+// recordUnitAgentsInProcess records in the agent's config whether the
+// agents of the machine's units run within the machine agent's
+// process, if that has not already been decided. They do if the
+// model's unit-agents-in-process config is set, and no unit has yet
+// been deployed to the machine as a service.
+func recordUnitAgentsInProcess(a agent.Agent, facade *apiagent.State) error {
+	cfg := a.CurrentConfig()
+	if cfg.Value(agent.UnitAgentsInProcess) != "" {
+		return nil
+	}
+	modelConfig, err := facade.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read model config")
+	}
+	inProcess := modelConfig.UnitAgentsInProcess()
+	if inProcess {
+		deployed, err := hasUnitAgents(cfg.DataDir())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		inProcess = !deployed
+	}
+	return a.ChangeConfig(func(setter agent.ConfigSetter) error {
+		setter.SetValue(agent.UnitAgentsInProcess, strconv.FormatBool(inProcess))
+		return nil
+	})
+}
+
+// hasUnitAgents reports whether any unit agent has been deployed
+// under the given data directory.
+func hasUnitAgents(dataDir string) (bool, error) {
+	infos, err := ioutil.ReadDir(agent.BaseDir(dataDir))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, info := range infos {
+		if _, err := names.ParseUnitTag(info.Name()); err == nil && info.IsDir() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/tools"
+	jworker "github.com/juju/juju/worker"
+)
+
+// NewUnitAgentFunc starts the workers of the named unit's agent, whose
+// config has been written to the agent's directory.
+type NewUnitAgentFunc func(unitName string) (worker.Worker, error)
+
+// NestedContext is a Context that runs the agents of the units it
+// deploys within the current process, rather than installing an init
+// service for each. This saves the memory of a process per unit on
+// machines hosting many units, and means that the unit agents are
+// always running the same version as the machine agent.
+//
+// A NestedContext is also a worker, responsible for the unit agents'
+// workers; it must be stopped when it is no longer used.
+type NestedContext struct {
+	api          APICalls
+	agentConfig  agent.Config
+	newUnitAgent NewUnitAgentFunc
+	runner       *worker.Runner
+}
+
+var _ Context = (*NestedContext)(nil)
+
+// NewNestedContext returns a new NestedContext, acting on behalf of
+// the specified deployer, that runs unit agents created by the given
+// function.
+func NewNestedContext(agentConfig agent.Config, api APICalls, newUnitAgent NewUnitAgentFunc) *NestedContext {
+	return &NestedContext{
+		api:          api,
+		agentConfig:  agentConfig,
+		newUnitAgent: newUnitAgent,
+		runner: worker.NewRunner(worker.RunnerParams{
+			// A unit agent's failure must not take down the
+			// machine agent, nor the other units' agents.
+			IsFatal:      func(error) bool { return false },
+			RestartDelay: jworker.RestartDelay,
+		}),
+	}
+}
+
+// AgentConfig is part of the Context interface.
+func (ctx *NestedContext) AgentConfig() agent.Config {
+	return ctx.agentConfig
+}
+
+// DeployUnit is part of the Context interface.
+func (ctx *NestedContext) DeployUnit(unitName, initialPassword string) (err error) {
+	tag := names.NewUnitTag(unitName)
+	dataDir := ctx.agentConfig.DataDir()
+	agentDir := agent.Dir(dataDir, tag)
+	if _, err := os.Stat(agentDir); err == nil {
+		return errors.Errorf("unit %q is already deployed", unitName)
+	} else if !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	defer removeOnErr(&err, tools.ToolsDir(dataDir, tag.String()))
+	defer removeOnErr(&err, agentDir)
+	if err := writeUnitAgentConfig(ctx.api, ctx.agentConfig, unitName, initialPassword); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ctx.start(unitName))
+}
+
+// RecallUnit is part of the Context interface.
+func (ctx *NestedContext) RecallUnit(unitName string) error {
+	tag := names.NewUnitTag(unitName)
+	dataDir := ctx.agentConfig.DataDir()
+	agentDir := agent.Dir(dataDir, tag)
+	if _, err := os.Stat(agentDir); os.IsNotExist(err) {
+		return errors.Errorf("unit %q is not deployed", unitName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ctx.runner.StopWorker(unitName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.RemoveAll(agentDir); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Remove(tools.ToolsDir(dataDir, tag.String())))
+}
+
+// DeployedUnits is part of the Context interface. The agents of the
+// units found are started if they are not already running, so that
+// units deployed before the machine agent restarted keep running.
+func (ctx *NestedContext) DeployedUnits() ([]string, error) {
+	infos, err := ioutil.ReadDir(agent.BaseDir(ctx.agentConfig.DataDir()))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var deployed []string
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		tag, err := names.ParseUnitTag(info.Name())
+		if err != nil {
+			continue
+		}
+		if err := ctx.start(tag.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		deployed = append(deployed, tag.Id())
+	}
+	sort.Strings(deployed)
+	return deployed, nil
+}
+
+func (ctx *NestedContext) start(unitName string) error {
+	return ctx.runner.StartWorker(unitName, func() (worker.Worker, error) {
+		return ctx.newUnitAgent(unitName)
+	})
+}
+
+// Kill is part of the worker.Worker interface.
+func (ctx *NestedContext) Kill() {
+	ctx.runner.Kill()
+}
+
+// Wait is part of the worker.Worker interface.
+func (ctx *NestedContext) Wait() error {
+	return ctx.runner.Wait()
+}
@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer_test
+
+import (
+	"os"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/deployer"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type NestedContextSuite struct {
+	SimpleToolsFixture
+	started  chan string
+	agents   chan worker.Worker
+	contexts []*deployer.NestedContext
+}
+
+var _ = gc.Suite(&NestedContextSuite{})
+
+func (s *NestedContextSuite) SetUpTest(c *gc.C) {
+	s.SimpleToolsFixture.SetUp(c, c.MkDir())
+	s.started = make(chan string, 10)
+	s.agents = make(chan worker.Worker, 10)
+	s.contexts = nil
+}
+
+func (s *NestedContextSuite) TearDownTest(c *gc.C) {
+	for _, ctx := range s.contexts {
+		workertest.CleanKill(c, ctx)
+	}
+	s.SimpleToolsFixture.TearDown(c)
+}
+
+func (s *NestedContextSuite) newUnitAgent(unitName string) (worker.Worker, error) {
+	w := workertest.NewErrorWorker(nil)
+	s.started <- unitName
+	s.agents <- w
+	return w, nil
+}
+
+func (s *NestedContextSuite) newContext(c *gc.C) *deployer.NestedContext {
+	config := agentConfig(names.NewMachineTag("99"), s.dataDir, s.logDir)
+	ctx := deployer.NewTestNestedContext(config, s.newUnitAgent)
+	s.contexts = append(s.contexts, ctx)
+	return ctx
+}
+
+func (s *NestedContextSuite) assertStarted(c *gc.C, unitName string) worker.Worker {
+	select {
+	case name := <-s.started:
+		c.Assert(name, gc.Equals, unitName)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("unit agent %q not started", unitName)
+	}
+	return <-s.agents
+}
+
+func (s *NestedContextSuite) assertNotStarted(c *gc.C) {
+	select {
+	case name := <-s.started:
+		c.Fatalf("unexpected unit agent %q started", name)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *NestedContextSuite) TestHasUnitAgents(c *gc.C) {
+	deployed, err := deployer.HasUnitAgents(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployed, jc.IsFalse)
+
+	err = os.MkdirAll(agent.Dir(s.dataDir, names.NewMachineTag("99")), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	deployed, err = deployer.HasUnitAgents(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployed, jc.IsFalse)
+
+	err = os.MkdirAll(agent.Dir(s.dataDir, names.NewUnitTag("mysql/0")), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	deployed, err = deployer.HasUnitAgents(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployed, jc.IsTrue)
+}
+
+func (s *NestedContextSuite) TestDeployRecall(c *gc.C) {
+	ctx := s.newContext(c)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	unitAgent := s.assertStarted(c, "foo/123")
+
+	tag := names.NewUnitTag("foo/123")
+	conf, err := agent.ReadConfig(agent.ConfigPath(s.dataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Tag(), gc.Equals, tag)
+	c.Assert(conf.DataDir(), gc.Equals, s.dataDir)
+	// No init service is installed for the unit.
+	s.assertUpstartCount(c, 0)
+
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+	// The running agent is not started again.
+	s.assertNotStarted(c)
+
+	err = ctx.RecallUnit("foo/123")
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckKilled(c, unitAgent)
+	units, err = ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+	s.checkUnitRemoved(c, "foo/123")
+}
+
+func (s *NestedContextSuite) TestDeployedUnitsStartsAgents(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStarted(c, "foo/123")
+	workertest.CleanKill(c, ctx)
+
+	// A new context, as after the machine agent restarts, starts the
+	// agents of the units already deployed.
+	ctx = s.newContext(c)
+	units, err := ctx.DeployedUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.DeepEquals, []string{"foo/123"})
+	s.assertStarted(c, "foo/123")
+}
+
+func (s *NestedContextSuite) TestDeployUnitAlreadyDeployed(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStarted(c, "foo/123")
+
+	err = ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is already deployed`)
+	s.assertNotStarted(c)
+}
+
+func (s *NestedContextSuite) TestRecallUnitNotDeployed(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.RecallUnit("foo/123")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/123" is not deployed`)
+}
+
+func (s *NestedContextSuite) TestKillStopsUnitAgents(c *gc.C) {
+	ctx := s.newContext(c)
+	err := ctx.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	unitAgent := s.assertStarted(c, "foo/123")
+
+	workertest.CleanKill(c, ctx)
+	workertest.CheckKilled(c, unitAgent)
+
+	// Stopping the agents leaves the units deployed.
+	_, err = os.Stat(agent.Dir(s.dataDir, names.NewUnitTag("foo/123")))
+	c.Assert(err, jc.ErrorIsNil)
+}
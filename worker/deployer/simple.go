@@ -107,60 +107,13 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 		return fmt.Errorf("unit %q is already deployed", unitName)
 	}
 
-	// Link the current tools for use by the new agent.
 	tag := names.NewUnitTag(unitName)
 	dataDir := ctx.agentConfig.DataDir()
-	logDir := ctx.agentConfig.LogDir()
-	hostSeries, err := series.HostSeries()
-	if err != nil {
-		return errors.Trace(err)
-	}
-	current := version.Binary{
-		Number: jujuversion.Current,
-		Arch:   arch.HostArch(),
-		Series: hostSeries,
-	}
-	toolsDir := tools.ToolsDir(dataDir, tag.String())
-	defer removeOnErr(&err, toolsDir)
-	_, err = tools.ChangeAgentTools(dataDir, tag.String(), current)
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	result, err := ctx.api.ConnectionInfo()
-	if err != nil {
+	defer removeOnErr(&err, tools.ToolsDir(dataDir, tag.String()))
+	defer removeOnErr(&err, agent.Dir(dataDir, tag))
+	if err := writeUnitAgentConfig(ctx.api, ctx.agentConfig, unitName, initialPassword); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Debugf("API addresses: %q", result.APIAddresses)
-	containerType := ctx.agentConfig.Value(agent.ContainerType)
-	namespace := ctx.agentConfig.Value(agent.Namespace)
-	conf, err := agent.NewAgentConfig(
-		agent.AgentConfigParams{
-			Paths: agent.Paths{
-				DataDir:         dataDir,
-				LogDir:          logDir,
-				MetricsSpoolDir: agent.DefaultPaths.MetricsSpoolDir,
-			},
-			UpgradedToVersion: jujuversion.Current,
-			Tag:               tag,
-			Password:          initialPassword,
-			Nonce:             "unused",
-			Controller:        ctx.agentConfig.Controller(),
-			Model:             ctx.agentConfig.Model(),
-			APIAddresses:      result.APIAddresses,
-			CACert:            ctx.agentConfig.CACert(),
-			Values: map[string]string{
-				agent.ContainerType: containerType,
-				agent.Namespace:     namespace,
-			},
-		})
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if err := conf.Write(); err != nil {
-		return err
-	}
-	defer removeOnErr(&err, conf.Dir())
 
 	// Install an init service that runs the unit agent.
 	if err := service.InstallAndStart(svc); err != nil {
@@ -290,6 +243,59 @@ func (ctx *SimpleContext) service(unitName string, renderer shell.Renderer) (dep
 	return ctx.discoverService(svcName, conf)
 }
 
+// writeUnitAgentConfig links the current tools for use by the agent
+// of the named unit, and writes the agent's config, based on that of
+// the machine agent running the deployer.
+func writeUnitAgentConfig(api APICalls, agentConfig agent.Config, unitName, initialPassword string) error {
+	tag := names.NewUnitTag(unitName)
+	dataDir := agentConfig.DataDir()
+	logDir := agentConfig.LogDir()
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current := version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: hostSeries,
+	}
+	if _, err := tools.ChangeAgentTools(dataDir, tag.String(), current); err != nil {
+		return errors.Trace(err)
+	}
+
+	result, err := api.ConnectionInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("API addresses: %q", result.APIAddresses)
+	containerType := agentConfig.Value(agent.ContainerType)
+	namespace := agentConfig.Value(agent.Namespace)
+	conf, err := agent.NewAgentConfig(
+		agent.AgentConfigParams{
+			Paths: agent.Paths{
+				DataDir:         dataDir,
+				LogDir:          logDir,
+				MetricsSpoolDir: agent.DefaultPaths.MetricsSpoolDir,
+			},
+			UpgradedToVersion: jujuversion.Current,
+			Tag:               tag,
+			Password:          initialPassword,
+			Nonce:             "unused",
+			Controller:        agentConfig.Controller(),
+			Model:             agentConfig.Model(),
+			APIAddresses:      result.APIAddresses,
+			CACert:            agentConfig.CACert(),
+			Values: map[string]string{
+				agent.ContainerType: containerType,
+				agent.Namespace:     namespace,
+			},
+		})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(conf.Write())
+}
+
 func removeOnErr(err *error, path string) {
 	if *err != nil {
 		if err := os.RemoveAll(path); err != nil {
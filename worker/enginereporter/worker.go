@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.enginereporter")
+
+// DefaultInterval is how often an agent sends its dependency engine
+// report to the controller.
+const DefaultInterval = 5 * time.Minute
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	SetEngineReport(agent names.Tag, report string) error
+}
+
+// Config defines the parameters of the enginereporter worker.
+type Config struct {
+	Facade   Facade
+	Reporter dependency.Reporter
+	Agent    names.Tag
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if Config cannot drive an enginereporter.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Reporter == nil {
+		return errors.NotValidf("nil Reporter")
+	}
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a Worker that sends the agent's dependency engine
+// report to the controller when it starts, and regularly thereafter,
+// so that it can be retrieved remotely.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &enginereporter{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type enginereporter struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *enginereporter) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *enginereporter) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *enginereporter) loop() error {
+	for {
+		if err := w.send(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// send formats the engine report as the introspection socket does,
+// and sends it to the controller.
+func (w *enginereporter) send() error {
+	report, err := yaml.Marshal(w.config.Reporter.Report())
+	if err != nil {
+		return errors.Annotate(err, "formatting engine report")
+	}
+	if err := w.config.Facade.SetEngineReport(w.config.Agent, string(report)); err != nil {
+		return errors.Annotate(err, "sending engine report")
+	}
+	logger.Tracef("sent engine report for %s", names.ReadableString(w.config.Agent))
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginereporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/enginereporter"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	clock  *jujutesting.Clock
+	facade *stubFacade
+	config enginereporter.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Time{})
+	s.facade = &stubFacade{
+		Stub: &jujutesting.Stub{},
+		sent: make(chan string, 10),
+	}
+	s.config = enginereporter.Config{
+		Facade:   s.facade,
+		Reporter: stubReporter{"state": "started"},
+		Agent:    names.NewUnitTag("mysql/0"),
+		Clock:    s.clock,
+		Interval: time.Minute,
+	}
+}
+
+func (s *Suite) waitSent(c *gc.C) string {
+	select {
+	case report := <-s.facade.sent:
+		return report
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for engine report")
+	}
+	panic("unreachable")
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Reporter = nil
+	_, err := enginereporter.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Reporter not valid")
+}
+
+func (s *Suite) TestSendsReport(c *gc.C) {
+	w, err := enginereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Check(s.waitSent(c), gc.Equals, "state: started\n")
+	s.facade.CheckCall(c, 0, "SetEngineReport", names.NewUnitTag("mysql/0"), "state: started\n")
+}
+
+func (s *Suite) TestSendsReportEveryInterval(c *gc.C) {
+	w, err := enginereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitSent(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSent(c)
+	s.facade.CheckCallNames(c, "SetEngineReport", "SetEngineReport")
+}
+
+func (s *Suite) TestSendError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := enginereporter.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "sending engine report: boom")
+}
+
+type stubReporter map[string]interface{}
+
+func (r stubReporter) Report() map[string]interface{} {
+	return r
+}
+
+type stubFacade struct {
+	*jujutesting.Stub
+	sent chan string
+}
+
+func (f *stubFacade) SetEngineReport(agent names.Tag, report string) error {
+	f.AddCall("SetEngineReport", agent, report)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.sent <- report
+	return nil
+}
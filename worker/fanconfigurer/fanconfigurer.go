@@ -6,12 +6,14 @@ package fanconfigurer
 import (
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/utils/scriptrunner"
@@ -26,7 +28,11 @@ type FanConfigurer struct {
 	config   FanConfigurerConfig
 	clock    clock.Clock
 	mu       sync.Mutex
-	enabled  bool
+
+	// fans holds the fans enabled by the configurer, as
+	// "underlay=overlay" pairs, so that they can be disabled
+	// when they are removed from the configuration.
+	fans set.Strings
 }
 
 type FanConfigurerFacade interface {
@@ -38,6 +44,21 @@ type FanConfigurerConfig struct {
 	Facade FanConfigurerFacade
 }
 
+// runCommand runs a fan command; it is a variable so it can be
+// replaced in tests.
+var runCommand = scriptrunner.RunCommand
+
+// runFanCommand runs the given fan command, logging its output.
+func (fc *FanConfigurer) runFanCommand(format string, args ...interface{}) error {
+	line := fmt.Sprintf(format, args...)
+	result, err := runCommand(line, os.Environ(), fc.clock, 5000*time.Millisecond)
+	if err != nil {
+		return errors.Annotatef(err, "running %q", line)
+	}
+	logger.Debugf("Launched %s - result %v %v %d", line, string(result.Stdout), string(result.Stderr), result.Code)
+	return nil
+}
+
 // processNewConfig acts on a new fan config.
 func (fc *FanConfigurer) processNewConfig() error {
 	logger.Debugf("Processing new fan config")
@@ -48,33 +69,43 @@ func (fc *FanConfigurer) processNewConfig() error {
 	if err != nil {
 		return err
 	}
+	wanted := set.NewStrings()
+	for _, fan := range fanConfig {
+		wanted.Add(fmt.Sprintf("%s=%s", fan.Underlay, fan.Overlay))
+	}
+
+	// Fans that are no longer configured are torn down first, so
+	// that a changed overlay for an underlay can be brought up.
+	for _, fan := range fc.fans.Difference(wanted).SortedValues() {
+		cidrs := strings.SplitN(fan, "=", 2)
+		logger.Debugf("Removing config for %s %s", cidrs[0], cidrs[1])
+		if err := fc.runFanCommand("fanatic disable-fan -u %s -o %s", cidrs[0], cidrs[1]); err != nil {
+			return err
+		}
+		fc.fans.Remove(fan)
+	}
 	if len(fanConfig) == 0 {
 		logger.Debugf("Fan not enabled")
-		// TODO(wpk) 2017-08-05 We have to clean this up!
 		return nil
 	}
 
 	for i, fan := range fanConfig {
 		logger.Debugf("Adding config for %d: %s %s", i, fan.Underlay, fan.Overlay)
-		line := fmt.Sprintf("fanatic enable-fan -u %s -o %s", fan.Underlay, fan.Overlay)
-		result, err := scriptrunner.RunCommand(line, os.Environ(), fc.clock, 5000*time.Millisecond)
-		logger.Debugf("Launched %s - result %v %v %d", line, string(result.Stdout), string(result.Stderr), result.Code)
-		if err != nil {
+		if err := fc.runFanCommand("fanatic enable-fan -u %s -o %s", fan.Underlay, fan.Overlay); err != nil {
 			return err
 		}
+		fc.fans.Add(fmt.Sprintf("%s=%s", fan.Underlay, fan.Overlay))
 	}
 	// TODO(wpk) 2017-09-28 Although officially not needed we do fanctl up -a just to be sure -
 	// fanatic sometimes fails to bring up interface because of some weird interactions with iptables.
-	result, err := scriptrunner.RunCommand("fanctl up -a", os.Environ(), fc.clock, 5000*time.Millisecond)
-	logger.Debugf("Launched fanctl up -a - result %v %v %d", string(result.Stdout), string(result.Stderr), result.Code)
-
-	return err
+	return fc.runFanCommand("fanctl up -a")
 }
 
 func NewFanConfigurer(config FanConfigurerConfig, clock clock.Clock) (*FanConfigurer, error) {
 	fc := &FanConfigurer{
 		config: config,
 		clock:  clock,
+		fans:   set.NewStrings(),
 	}
 	// We need to launch it once here to make sure that it's configured right away,
 	// so that machiner will have a proper fan device address to report back
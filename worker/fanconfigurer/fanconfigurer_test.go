@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/utils/scriptrunner"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/fanconfigurer"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type FanConfigurerSuite struct {
+	testing.IsolationSuite
+
+	facade   *mockFacade
+	commands chan string
+}
+
+var _ = gc.Suite(&FanConfigurerSuite{})
+
+func (s *FanConfigurerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.commands = make(chan string, 10)
+	s.facade = &mockFacade{changes: make(chan struct{})}
+	s.PatchValue(fanconfigurer.RunCommand, func(command string, _ []string, _ clock.Clock, _ time.Duration) (*scriptrunner.ScriptResult, error) {
+		s.commands <- command
+		return &scriptrunner.ScriptResult{}, nil
+	})
+}
+
+func (s *FanConfigurerSuite) startConfigurer(c *gc.C, config string) {
+	s.facade.setConfig(c, config)
+	fc, err := fanconfigurer.NewFanConfigurer(fanconfigurer.FanConfigurerConfig{
+		Facade: s.facade,
+	}, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, fc) })
+}
+
+// changeConfig sets a new fan configuration and notifies the
+// configurer of it.
+func (s *FanConfigurerSuite) changeConfig(c *gc.C, config string) {
+	s.facade.setConfig(c, config)
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("configurer did not accept change")
+	}
+}
+
+func (s *FanConfigurerSuite) assertCommands(c *gc.C, expected ...string) {
+	var commands []string
+	for range expected {
+		select {
+		case command := <-s.commands:
+			commands = append(commands, command)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for commands; got %q", commands)
+		}
+	}
+	c.Assert(commands, jc.DeepEquals, expected)
+	select {
+	case command := <-s.commands:
+		c.Fatalf("unexpected command %q", command)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *FanConfigurerSuite) TestEnablesFans(c *gc.C) {
+	s.startConfigurer(c, "10.0.0.0/16=252.0.0.0/8 172.16.0.0/16=253.0.0.0/8")
+	s.assertCommands(c,
+		"fanatic enable-fan -u 10.0.0.0/16 -o 252.0.0.0/8",
+		"fanatic enable-fan -u 172.16.0.0/16 -o 253.0.0.0/8",
+		"fanctl up -a",
+	)
+}
+
+func (s *FanConfigurerSuite) TestDisablesRemovedFans(c *gc.C) {
+	s.startConfigurer(c, "10.0.0.0/16=252.0.0.0/8 172.16.0.0/16=253.0.0.0/8")
+	s.assertCommands(c,
+		"fanatic enable-fan -u 10.0.0.0/16 -o 252.0.0.0/8",
+		"fanatic enable-fan -u 172.16.0.0/16 -o 253.0.0.0/8",
+		"fanctl up -a",
+	)
+
+	s.changeConfig(c, "10.0.0.0/16=250.0.0.0/8")
+	s.assertCommands(c,
+		"fanatic disable-fan -u 10.0.0.0/16 -o 252.0.0.0/8",
+		"fanatic disable-fan -u 172.16.0.0/16 -o 253.0.0.0/8",
+		"fanatic enable-fan -u 10.0.0.0/16 -o 250.0.0.0/8",
+		"fanctl up -a",
+	)
+}
+
+func (s *FanConfigurerSuite) TestDisablesAllFans(c *gc.C) {
+	s.startConfigurer(c, "10.0.0.0/16=252.0.0.0/8")
+	s.assertCommands(c,
+		"fanatic enable-fan -u 10.0.0.0/16 -o 252.0.0.0/8",
+		"fanctl up -a",
+	)
+
+	s.changeConfig(c, "")
+	s.assertCommands(c,
+		"fanatic disable-fan -u 10.0.0.0/16 -o 252.0.0.0/8",
+	)
+}
+
+type mockFacade struct {
+	mu      sync.Mutex
+	config  network.FanConfig
+	changes chan struct{}
+}
+
+func (f *mockFacade) setConfig(c *gc.C, line string) {
+	config, err := network.ParseFanConfig(line)
+	c.Assert(err, jc.ErrorIsNil)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+func (f *mockFacade) FanConfig() (network.FanConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, nil
+}
+
+func (f *mockFacade) WatchForFanConfigChanges() (watcher.NotifyWatcher, error) {
+	return &mockWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.changes,
+	}, nil
+}
+
+type mockWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (w *mockWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
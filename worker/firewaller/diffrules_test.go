@@ -129,3 +129,38 @@ func (s *DiffRulesSuite) TestDiffRangesClosesPortsIfRulesAreDisjoint(c *gc.C) {
 	c.Assert(toOpen, gc.DeepEquals, wanted)
 	c.Assert(toClose, gc.DeepEquals, current)
 }
+
+func (s *DiffRulesSuite) TestDiffEgressRules(c *gc.C) {
+	current := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 1, 65535, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 1, 65535, "10.0.0.0/8"),
+	}
+	wanted := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 1, 65535, "10.0.0.0/8", "192.168.1.0/24"),
+	}
+	toOpen, toClose := diffEgressRules(current, wanted)
+	c.Assert(toOpen, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 1, 65535, "192.168.1.0/24"),
+	})
+	c.Assert(toClose, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 1, 65535, "10.0.0.0/8"),
+	})
+}
+
+func (s *DiffRulesSuite) TestProvenanceKept(c *gc.C) {
+	current := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0").WithProvenance("unit-wordpress-0", "wordpress/0 (exposed)"),
+	}
+	wanted := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24").WithProvenance("unit-mysql-0", `mysql/0 (relation "wordpress:db mysql:server")`),
+		network.MustNewIngressRule("tcp", 443, 443, "192.168.1.0/24").WithProvenance("unit-mysql-1", `mysql/1 (relation "wordpress:db mysql:server")`),
+	}
+	toOpen, toClose := diffRanges(current, wanted)
+	c.Assert(toOpen, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/24", "192.168.1.0/24").WithProvenance(
+			"unit-mysql-0,unit-mysql-1",
+			`mysql/0 (relation "wordpress:db mysql:server"); mysql/1 (relation "wordpress:db mysql:server")`,
+		),
+	})
+	c.Assert(toClose, jc.DeepEquals, current)
+}
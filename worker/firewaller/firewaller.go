@@ -4,7 +4,9 @@
 package firewaller
 
 import (
+	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -43,6 +45,8 @@ type FirewallerAPI interface {
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(serviceNames ...string) ([]params.FirewallRule, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
 }
 
 // CrossModelFirewallerFacade exposes firewaller functionality on the
@@ -82,6 +86,14 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EnvironApplicationFirewaller is used to open and close ports
+	// when Mode is FwApplication.
+	EnvironApplicationFirewaller environs.ApplicationFirewaller
+
+	// EnvironEgressFirewaller, if set, is used to restrict outgoing
+	// traffic from the model's machines.
+	EnvironEgressFirewaller environs.EgressFirewaller
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -101,6 +113,9 @@ func (cfg Config) Validate() error {
 	if cfg.Mode == config.FwGlobal && cfg.EnvironFirewaller == nil {
 		return errors.NotValidf("nil EnvironFirewaller")
 	}
+	if cfg.Mode == config.FwApplication && cfg.EnvironApplicationFirewaller == nil {
+		return errors.NotValidf("nil EnvironApplicationFirewaller")
+	}
 	if cfg.EnvironInstances == nil {
 		return errors.NotValidf("nil EnvironInstances")
 	}
@@ -122,6 +137,11 @@ type Firewaller struct {
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
 
+	environEgressFirewaller environs.EgressFirewaller
+	modelConfigWatcher      watcher.NotifyWatcher
+	egressCIDRs             []string
+	exposeIPv6              bool
+
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
@@ -132,6 +152,10 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
+	environApplicationFirewaller environs.ApplicationFirewaller
+	applicationMode              bool
+	applicationIngressRuleRef    map[string]map[string]int // map of application names to rule reference counts
+
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc newCrossModelFacadeFunc
 	remoteRelationsWatcher     watcher.StringsWatcher
@@ -151,20 +175,22 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		clk = clock.WallClock
 	}
 	fw := &Firewaller{
-		firewallerApi:              cfg.FirewallerAPI,
-		remoteRelationsApi:         cfg.RemoteRelationsApi,
-		environFirewaller:          cfg.EnvironFirewaller,
-		environInstances:           cfg.EnvironInstances,
-		newRemoteFirewallerAPIFunc: cfg.NewCrossModelFacadeFunc,
-		modelUUID:                  cfg.ModelUUID,
-		machineds:                  make(map[names.MachineTag]*machineData),
-		unitsChange:                make(chan *unitsChange),
-		unitds:                     make(map[names.UnitTag]*unitData),
-		applicationids:             make(map[names.ApplicationTag]*applicationData),
-		exposedChange:              make(chan *exposedChange),
-		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
-		localRelationsChange:       make(chan *remoteRelationNetworkChange),
-		pollClock:                  clk,
+		firewallerApi:                cfg.FirewallerAPI,
+		remoteRelationsApi:           cfg.RemoteRelationsApi,
+		environFirewaller:            cfg.EnvironFirewaller,
+		environInstances:             cfg.EnvironInstances,
+		environEgressFirewaller:      cfg.EnvironEgressFirewaller,
+		environApplicationFirewaller: cfg.EnvironApplicationFirewaller,
+		newRemoteFirewallerAPIFunc:   cfg.NewCrossModelFacadeFunc,
+		modelUUID:                    cfg.ModelUUID,
+		machineds:                    make(map[names.MachineTag]*machineData),
+		unitsChange:                  make(chan *unitsChange),
+		unitds:                       make(map[names.UnitTag]*unitData),
+		applicationids:               make(map[names.ApplicationTag]*applicationData),
+		exposedChange:                make(chan *exposedChange),
+		relationIngress:              make(map[names.RelationTag]*remoteRelationData),
+		localRelationsChange:         make(chan *remoteRelationNetworkChange),
+		pollClock:                    clk,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
 
@@ -182,6 +208,9 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalIngressRuleRef = make(map[string]int)
+	case config.FwApplication:
+		fw.applicationMode = true
+		fw.applicationIngressRuleRef = make(map[string]map[string]int)
 	default:
 		return nil, errors.Errorf("invalid firewall-mode %q", cfg.Mode)
 	}
@@ -223,6 +252,22 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.modelConfigWatcher, err = fw.firewallerApi.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := fw.catacomb.Add(fw.modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	modelConfig, err := fw.firewallerApi.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fw.exposeIPv6 = modelConfig.PreferIPv6()
+	if fw.environEgressFirewaller != nil {
+		fw.egressCIDRs = modelConfig.FirewallEgressCIDRs()
+	}
+
 	logger.Debugf("started watching opened port ranges for the model")
 	return nil
 }
@@ -249,9 +294,12 @@ func (fw *Firewaller) loop() error {
 			if !reconciled {
 				reconciled = true
 				var err error
-				if fw.globalMode {
+				switch {
+				case fw.globalMode:
 					err = fw.reconcileGlobal()
-				} else {
+				case fw.applicationMode:
+					err = fw.reconcileApplications()
+				default:
 					err = fw.reconcileInstances()
 				}
 				if err != nil {
@@ -280,6 +328,13 @@ func (fw *Firewaller) loop() error {
 					return err
 				}
 			}
+		case _, ok := <-fw.modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := fw.modelConfigChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case change := <-fw.localRelationsChange:
 			// We have a notification that the remote (consuming) model
 			// has changed egress networks so need to update the local
@@ -323,6 +378,103 @@ func (fw *Firewaller) relationIngressChanged(change *remoteRelationNetworkChange
 	if err := fw.flushUnits(unitds); err != nil {
 		return errors.Annotate(err, "cannot change firewall ports")
 	}
+	return errors.Trace(fw.flushEgress())
+}
+
+// modelConfigChanged applies any change to the model's egress CIDRs,
+// and reopens exposed ports if the model's IPv6 preference changed.
+func (fw *Firewaller) modelConfigChanged() error {
+	modelConfig, err := fw.firewallerApi.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exposeIPv6 := modelConfig.PreferIPv6(); exposeIPv6 != fw.exposeIPv6 {
+		fw.exposeIPv6 = exposeIPv6
+		unitds := []*unitData{}
+		for _, unitd := range fw.unitds {
+			if unitd.applicationd.exposed {
+				unitds = append(unitds, unitd)
+			}
+		}
+		if err := fw.flushUnits(unitds); err != nil {
+			return errors.Annotate(err, "cannot change firewall ports")
+		}
+	}
+	if fw.environEgressFirewaller == nil {
+		return nil
+	}
+	fw.egressCIDRs = modelConfig.FirewallEgressCIDRs()
+	return errors.Trace(fw.flushEgress())
+}
+
+// allTraffic holds the port ranges which together cover all traffic,
+// for egress rules which restrict only the destination.
+var allTraffic = []network.PortRange{
+	{Protocol: "icmp", FromPort: -1, ToPort: -1},
+	{Protocol: "tcp", FromPort: 1, ToPort: 65535},
+	{Protocol: "udp", FromPort: 1, ToPort: 65535},
+}
+
+// gatherEgressRules returns the egress rules wanted for the model:
+// traffic is allowed to the model's egress CIDRs and to the networks
+// of any cross model relations requiring ingress. If the model has no
+// egress CIDRs, outgoing traffic is not restricted and no rules are
+// returned.
+func (fw *Firewaller) gatherEgressRules() ([]network.EgressRule, error) {
+	if len(fw.egressCIDRs) == 0 {
+		return nil, nil
+	}
+	cidrs := set.NewStrings(fw.egressCIDRs...)
+	for _, data := range fw.relationIngress {
+		if !data.ingressRequired {
+			continue
+		}
+		for _, cidr := range data.networks.Values() {
+			cidrs.Add(cidr)
+		}
+	}
+	want := make([]network.EgressRule, len(allTraffic))
+	for i, portRange := range allTraffic {
+		rule, err := network.NewEgressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		want[i] = rule
+	}
+	return want, nil
+}
+
+// flushEgress opens and closes egress rules in the environment so that
+// they match the rules wanted for the model.
+func (fw *Firewaller) flushEgress() error {
+	if fw.environEgressFirewaller == nil {
+		return nil
+	}
+	want, err := fw.gatherEgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := fw.environEgressFirewaller.EgressRules()
+	if errors.IsNotSupported(err) {
+		// Some clouds of the provider cannot restrict egress.
+		logger.Warningf("cannot enforce egress rules: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot get egress rules")
+	}
+	toOpen, toClose := diffEgressRules(current, want)
+	if len(toOpen) > 0 {
+		if err := fw.environEgressFirewaller.OpenEgress(toOpen); err != nil {
+			return errors.Annotate(err, "cannot open egress rules")
+		}
+		logger.Infof("opened egress rules %v in environment", toOpen)
+	}
+	if len(toClose) > 0 {
+		if err := fw.environEgressFirewaller.CloseEgress(toClose); err != nil {
+			return errors.Annotate(err, "cannot close egress rules")
+		}
+		logger.Infof("closed egress rules %v in environment", toClose)
+	}
 	return nil
 }
 
@@ -335,6 +487,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		unitds:       make(map[names.UnitTag]*unitData),
 		ingressRules: make([]network.IngressRule, 0),
 		definedPorts: make(map[names.UnitTag]portRanges),
+		applications: set.NewStrings(),
 	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
@@ -501,6 +654,52 @@ func (fw *Firewaller) reconcileGlobal() error {
 	return nil
 }
 
+// reconcileApplications compares the initially started watcher for
+// machines, units and applications with the opened and closed ports of
+// each application and opens and closes the appropriate ports for each
+// application.
+func (fw *Firewaller) reconcileApplications() error {
+	var machines []*machineData
+	for _, machined := range fw.machineds {
+		machines = append(machines, machined)
+	}
+	want, err := fw.gatherApplicationIngressRules(machines...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, machined := range machines {
+		machineRules, err := fw.gatherApplicationIngressRules(machined)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := fw.addApplicationMachine(machined, machineRules); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for appName, rules := range want {
+		initialRules, err := fw.environApplicationFirewaller.ApplicationIngressRules(appName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		// Check which ports to open or to close.
+		toOpen, toClose := diffRanges(initialRules, rules)
+		if len(toOpen) > 0 {
+			logger.Infof("opening port ranges %v for application %q", toOpen, appName)
+			if err := fw.environApplicationFirewaller.OpenApplicationPorts(appName, toOpen); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if len(toClose) > 0 {
+			logger.Infof("closing port ranges %v for application %q", toClose, appName)
+			if err := fw.environApplicationFirewaller.CloseApplicationPorts(appName, toClose); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
 // reconcileInstances compares the initially started watcher for machines,
 // units and appications with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
@@ -701,6 +900,9 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
+	if fw.applicationMode {
+		return fw.flushApplicationMachine(machined)
+	}
 	want, err := fw.gatherIngressRules(machined)
 	if err != nil {
 		return errors.Trace(err)
@@ -713,6 +915,73 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	return fw.flushInstancePorts(machined, toOpen, toClose)
 }
 
+// flushApplicationMachine opens and closes ports for the applications
+// with units on the passed machine.
+func (fw *Firewaller) flushApplicationMachine(machined *machineData) error {
+	want, err := fw.gatherApplicationIngressRules(machined)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appNames := set.NewStrings()
+	for appName := range want {
+		appNames.Add(appName)
+	}
+	for appName := range machined.applicationIngressRules {
+		appNames.Add(appName)
+	}
+	if err := fw.addApplicationMachine(machined, want); err != nil {
+		return errors.Trace(err)
+	}
+	for _, appName := range appNames.SortedValues() {
+		toOpen, toClose := diffRanges(machined.applicationIngressRules[appName], want[appName])
+		if err := fw.flushApplicationPorts(appName, toOpen, toClose); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	machined.applicationIngressRules = want
+	return nil
+}
+
+// addApplicationMachine ensures that the ports opened for each of the
+// applications with ingress rules on the passed machine apply to its
+// instance. Instances join the groups of the units known when they are
+// started; this covers units placed on the machine afterwards.
+func (fw *Firewaller) addApplicationMachine(machined *machineData, rules map[string][]network.IngressRule) error {
+	var appNames []string
+	for appName := range rules {
+		if !machined.applications.Contains(appName) {
+			appNames = append(appNames, appName)
+		}
+	}
+	if len(appNames) == 0 {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// Not provisioned yet, so the instance will be started
+		// with the groups of the units already on the machine.
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sort.Strings(appNames)
+	for _, appName := range appNames {
+		if err := fw.environApplicationFirewaller.AddApplicationInstance(appName, instanceId); err != nil {
+			return errors.Annotatef(err, "cannot add %q to application %q", machined.tag, appName)
+		}
+		machined.applications.Add(appName)
+	}
+	return nil
+}
+
 // gatherIngressRules returns the ingress rules to open and close
 // for the specified machines.
 func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.IngressRule, error) {
@@ -724,41 +993,94 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				logger.Debugf("no ingress rules for unknown %v on %v", unitTag, machined.tag)
 				continue
 			}
+			rules, err := fw.unitIngressRules(unitd, portRanges)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			want = append(want, rules...)
+		}
+	}
+	return want, nil
+}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
-				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
+// gatherApplicationIngressRules returns the ingress rules to open and
+// close for the specified machines, keyed by application name.
+func (fw *Firewaller) gatherApplicationIngressRules(machines ...*machineData) (map[string][]network.IngressRule, error) {
+	want := make(map[string][]network.IngressRule)
+	for _, machined := range machines {
+		for unitTag, portRanges := range machined.definedPorts {
+			unitd, known := machined.unitds[unitTag]
+			if !known {
+				logger.Debugf("no ingress rules for unknown %v on %v", unitTag, machined.tag)
+				continue
 			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
-				}
+			rules, err := fw.unitIngressRules(unitd, portRanges)
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
+			if len(rules) == 0 {
+				continue
+			}
+			appName := unitd.applicationd.application.Tag().Id()
+			want[appName] = append(want[appName], rules...)
 		}
 	}
 	return want, nil
 }
 
+// unitIngressRules returns the ingress rules needed for the given port
+// ranges opened by a unit.
+func (fw *Firewaller) unitIngressRules(unitd *unitData, portRanges portRanges) ([]network.IngressRule, error) {
+	cidrs := set.NewStrings()
+	// The unit opened the ports, so it is the origin of the rules;
+	// the description records why the ports are reachable.
+	var reasons []string
+	// If the unit is exposed, allow access from everywhere. Models that
+	// prefer IPv6 are reachable over IPv6 too.
+	if unitd.applicationd.exposed {
+		cidrs.Add("0.0.0.0/0")
+		if fw.exposeIPv6 {
+			cidrs.Add("::/0")
+		}
+		reasons = []string{"exposed"}
+	} else {
+		// Not exposed, so add any ingress rules required by remote relations.
+		var err error
+		reasons, err = fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("CIDRS for %v: %v", unitd.tag, cidrs.Values())
+	}
+	if cidrs.Size() == 0 {
+		return nil, nil
+	}
+	origin := unitd.tag.String()
+	description := fmt.Sprintf("%s (%s)", unitd.tag.Id(), strings.Join(reasons, ", "))
+	var rules []network.IngressRule
+	for portRange := range portRanges {
+		sourceCidrs := cidrs.SortedValues()
+		rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule.WithProvenance(origin, description))
+	}
+	return rules, nil
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
-func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) error {
+// updateForRemoteRelationIngress adds to cidrs the networks requiring
+// ingress to the application over remote relations, and returns the
+// reasons the networks were allowed, for use in rule descriptions.
+func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) ([]string, error) {
 	logger.Debugf("finding egress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
 	// unit's application is a part.
 	newCidrs := make(set.Strings)
+	relationKeys := set.NewStrings()
 	for _, data := range fw.relationIngress {
 		if data.localApplicationTag != appTag {
 			continue
@@ -769,6 +1091,13 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		for _, cidr := range data.networks.Values() {
 			newCidrs.Add(cidr)
 		}
+		if data.networks.Size() > 0 {
+			relationKeys.Add(data.tag.Id())
+		}
+	}
+	var reasons []string
+	for _, key := range relationKeys.SortedValues() {
+		reasons = append(reasons, fmt.Sprintf("relation %q", key))
 	}
 	// If we have too many CIDRs to create a rule for, consolidate.
 	// If a firewall rule with a whitelist of CIDRs has been set up,
@@ -777,7 +1106,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		// First, try and merge the cidrs.
 		merged, err := cidrman.MergeCIDRs(newCidrs.Values())
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		newCidrs = set.NewStrings(merged...)
 	}
@@ -787,7 +1116,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		newCidrs = make(set.Strings)
 		rules, err := fw.firewallerApi.FirewallRules("juju-application-offer")
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		if len(rules) > 0 {
 			rule := rules[0]
@@ -795,6 +1124,7 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 				for _, cidr := range rule.WhitelistCIDRS {
 					newCidrs.Add(cidr)
 				}
+				reasons = append(reasons, firewallRuleReason(rule))
 			}
 		}
 		// No relevant firewall rule exists, so go public.
@@ -805,7 +1135,20 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	for _, cidr := range newCidrs.Values() {
 		cidrs.Add(cidr)
 	}
-	return nil
+	return reasons, nil
+}
+
+// firewallRuleReason describes the use of a firewall rule's whitelist,
+// including who set the rule and why when that is known.
+func firewallRuleReason(rule params.FirewallRule) string {
+	reason := fmt.Sprintf("firewall rule %q", rule.KnownService)
+	if rule.Origin != "" {
+		reason += " set by " + rule.Origin
+	}
+	if rule.Description != "" {
+		reason += ": " + rule.Description
+	}
+	return reason
 }
 
 // flushGlobalPorts opens and closes global ports in the environment.
@@ -849,6 +1192,54 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	return nil
 }
 
+// flushApplicationPorts opens and closes the ports of an application in
+// the environment. Like flushGlobalPorts, it keeps a reference count for
+// each application's ports so that only 0-to-1 and 1-to-0 events modify
+// the environment.
+func (fw *Firewaller) flushApplicationPorts(appName string, rawOpen, rawClose []network.IngressRule) error {
+	ruleRef := fw.applicationIngressRuleRef[appName]
+	if ruleRef == nil {
+		ruleRef = make(map[string]int)
+		fw.applicationIngressRuleRef[appName] = ruleRef
+	}
+	// Filter which ports are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		ruleName := rule.String()
+		if ruleRef[ruleName] == 0 {
+			toOpen = append(toOpen, rule)
+		}
+		ruleRef[ruleName]++
+	}
+	for _, rule := range rawClose {
+		ruleName := rule.String()
+		ruleRef[ruleName]--
+		if ruleRef[ruleName] == 0 {
+			toClose = append(toClose, rule)
+			delete(ruleRef, ruleName)
+		}
+	}
+	if len(ruleRef) == 0 {
+		delete(fw.applicationIngressRuleRef, appName)
+	}
+	// Open and close the ports.
+	if len(toOpen) > 0 {
+		if err := fw.environApplicationFirewaller.OpenApplicationPorts(appName, toOpen); err != nil {
+			return err
+		}
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened port ranges %v for application %q", toOpen, appName)
+	}
+	if len(toClose) > 0 {
+		if err := fw.environApplicationFirewaller.CloseApplicationPorts(appName, toClose); err != nil {
+			return err
+		}
+		network.SortIngressRules(toClose)
+		logger.Infof("closed port ranges %v for application %q", toClose, appName)
+	}
+	return nil
+}
+
 // flushInstancePorts opens and closes ports global on the machine.
 func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
@@ -1000,6 +1391,12 @@ type machineData struct {
 	tag          names.MachineTag
 	unitds       map[names.UnitTag]*unitData
 	ingressRules []network.IngressRule
+	// ingress rules of each application with units on this machine,
+	// used in application firewall mode
+	applicationIngressRules map[string][]network.IngressRule
+	// applications whose ports are known to apply to the machine's
+	// instance, used in application firewall mode
+	applications set.Strings
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
 }
@@ -1137,63 +1534,138 @@ func parsePortsKey(change string) (machineTag names.MachineTag, subnetTag names.
 	return machineTag, subnetTag, nil
 }
 
-func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClose []network.IngressRule) {
-	portCidrs := func(rules []network.IngressRule) map[network.PortRange]set.Strings {
-		result := make(map[network.PortRange]set.Strings)
-		for _, rule := range rules {
-			cidrs, ok := result[rule.PortRange]
-			if !ok {
-				cidrs = set.NewStrings()
-				result[rule.PortRange] = cidrs
-			}
-			ruleCidrs := rule.SourceCIDRs
-			if len(ruleCidrs) == 0 {
-				ruleCidrs = []string{"0.0.0.0/0"}
-			}
-			for _, cidr := range ruleCidrs {
-				cidrs.Add(cidr)
-			}
-		}
-		return result
+// portCIDRs maps port ranges to the CIDRs for which they apply.
+type portCIDRs map[network.PortRange]set.Strings
+
+func (p portCIDRs) add(portRange network.PortRange, cidrs []string) {
+	existing, ok := p[portRange]
+	if !ok {
+		existing = set.NewStrings()
+		p[portRange] = existing
 	}
+	if len(cidrs) == 0 {
+		cidrs = []string{"0.0.0.0/0"}
+	}
+	for _, cidr := range cidrs {
+		existing.Add(cidr)
+	}
+}
 
-	currentPortCidrs := portCidrs(currentRules)
-	wantedPortCidrs := portCidrs(wantedRules)
-	for portRange, wantedCidrs := range wantedPortCidrs {
-		existingCidrs, ok := currentPortCidrs[portRange]
+// diff returns the port ranges and CIDRs which are wanted but not in
+// p, and those in p which are not wanted.
+func (p portCIDRs) diff(wanted portCIDRs) (toOpen, toClose portCIDRs) {
+	toOpen = make(portCIDRs)
+	toClose = make(portCIDRs)
+	for portRange, wantedCidrs := range wanted {
+		existingCidrs, ok := p[portRange]
 
 		// If the wanted port range doesn't exist at all, the entire rule is to be opened.
 		if !ok {
-			rule := network.IngressRule{PortRange: portRange, SourceCIDRs: wantedCidrs.SortedValues()}
-			toOpen = append(toOpen, rule)
+			toOpen[portRange] = wantedCidrs
 			continue
 		}
 
 		// Figure out the difference between CIDRs to get the rules to open/close.
-		toOpenCidrs := wantedCidrs.Difference(existingCidrs)
-		if toOpenCidrs.Size() > 0 {
-			rule := network.IngressRule{PortRange: portRange, SourceCIDRs: toOpenCidrs.SortedValues()}
-			toOpen = append(toOpen, rule)
+		if toOpenCidrs := wantedCidrs.Difference(existingCidrs); toOpenCidrs.Size() > 0 {
+			toOpen[portRange] = toOpenCidrs
 		}
-		toCloseCidrs := existingCidrs.Difference(wantedCidrs)
-		if toCloseCidrs.Size() > 0 {
-			rule := network.IngressRule{PortRange: portRange, SourceCIDRs: toCloseCidrs.SortedValues()}
-			toClose = append(toClose, rule)
+		if toCloseCidrs := existingCidrs.Difference(wantedCidrs); toCloseCidrs.Size() > 0 {
+			toClose[portRange] = toCloseCidrs
 		}
 	}
 
-	for portRange, currentCidrs := range currentPortCidrs {
+	for portRange, currentCidrs := range p {
 		// If a current port range doesn't exist at all in the wanted set, the entire rule is to be closed.
-		if _, ok := wantedPortCidrs[portRange]; !ok {
-			rule := network.IngressRule{PortRange: portRange, SourceCIDRs: currentCidrs.SortedValues()}
-			toClose = append(toClose, rule)
+		if _, ok := wanted[portRange]; !ok {
+			toClose[portRange] = currentCidrs
 		}
 	}
-	network.SortIngressRules(toOpen)
-	network.SortIngressRules(toClose)
 	return toOpen, toClose
 }
 
+// portProvenance maps port ranges to the origins and descriptions of
+// the ingress rules which apply to them, so that they survive diffing.
+type portProvenance map[network.PortRange]ruleProvenance
+
+type ruleProvenance struct {
+	origins      set.Strings
+	descriptions set.Strings
+}
+
+func (p portProvenance) add(rule network.IngressRule) {
+	prov, ok := p[rule.PortRange]
+	if !ok {
+		prov = ruleProvenance{origins: set.NewStrings(), descriptions: set.NewStrings()}
+		p[rule.PortRange] = prov
+	}
+	if rule.Origin != "" {
+		prov.origins.Add(rule.Origin)
+	}
+	if rule.Description != "" {
+		prov.descriptions.Add(rule.Description)
+	}
+}
+
+// apply returns the rule with the combined origins and descriptions
+// recorded for its port range.
+func (p portProvenance) apply(rule network.IngressRule) network.IngressRule {
+	prov, ok := p[rule.PortRange]
+	if !ok {
+		return rule
+	}
+	return rule.WithProvenance(
+		strings.Join(prov.origins.SortedValues(), ","),
+		strings.Join(prov.descriptions.SortedValues(), "; "),
+	)
+}
+
+func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClose []network.IngressRule) {
+	ingressPortCIDRs := func(rules []network.IngressRule) (portCIDRs, portProvenance) {
+		result := make(portCIDRs)
+		provenance := make(portProvenance)
+		for _, rule := range rules {
+			result.add(rule.PortRange, rule.SourceCIDRs)
+			provenance.add(rule)
+		}
+		return result, provenance
+	}
+	ingressRules := func(p portCIDRs, provenance portProvenance) []network.IngressRule {
+		var rules []network.IngressRule
+		for portRange, cidrs := range p {
+			rule := network.IngressRule{PortRange: portRange, SourceCIDRs: cidrs.SortedValues()}
+			rules = append(rules, provenance.apply(rule))
+		}
+		network.SortIngressRules(rules)
+		return rules
+	}
+
+	current, currentProvenance := ingressPortCIDRs(currentRules)
+	wanted, wantedProvenance := ingressPortCIDRs(wantedRules)
+	openCIDRs, closeCIDRs := current.diff(wanted)
+	return ingressRules(openCIDRs, wantedProvenance), ingressRules(closeCIDRs, currentProvenance)
+}
+
+func diffEgressRules(currentRules, wantedRules []network.EgressRule) (toOpen, toClose []network.EgressRule) {
+	egressPortCIDRs := func(rules []network.EgressRule) portCIDRs {
+		result := make(portCIDRs)
+		for _, rule := range rules {
+			result.add(rule.PortRange, rule.DestinationCIDRs)
+		}
+		return result
+	}
+	egressRules := func(p portCIDRs) []network.EgressRule {
+		var rules []network.EgressRule
+		for portRange, cidrs := range p {
+			rules = append(rules, network.EgressRule{PortRange: portRange, DestinationCIDRs: cidrs.SortedValues()})
+		}
+		network.SortEgressRules(rules)
+		return rules
+	}
+
+	openCIDRs, closeCIDRs := egressPortCIDRs(currentRules).diff(egressPortCIDRs(wantedRules))
+	return egressRules(openCIDRs), egressRules(closeCIDRs)
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
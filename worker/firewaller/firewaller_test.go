@@ -6,6 +6,7 @@ package firewaller_test
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -512,6 +513,41 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationPreferIPv6(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+
+	// Preferring IPv6 opens exposed ports to IPv6 clients too.
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"prefer-ipv6": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0"),
+	})
+
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"prefer-ipv6": false,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1207,10 +1243,16 @@ func (s *GlobalModeSuite) TearDownTest(c *gc.C) {
 }
 
 func (s *GlobalModeSuite) newFirewaller(c *gc.C) worker.Worker {
+	fw, err := firewaller.NewFirewaller(s.firewallerConfig(c))
+	c.Assert(err, jc.ErrorIsNil)
+	return fw
+}
+
+func (s *GlobalModeSuite) firewallerConfig(c *gc.C) firewaller.Config {
 	fwEnv, ok := s.Environ.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
 
-	cfg := firewaller.Config{
+	return firewaller.Config{
 		ModelUUID:          s.State.ModelUUID(),
 		Mode:               config.FwGlobal,
 		EnvironFirewaller:  fwEnv,
@@ -1221,9 +1263,6 @@ func (s *GlobalModeSuite) newFirewaller(c *gc.C) worker.Worker {
 			return s.crossmodelFirewaller, nil
 		},
 	}
-	fw, err := firewaller.NewFirewaller(cfg)
-	c.Assert(err, jc.ErrorIsNil)
-	return fw
 }
 
 func (s *GlobalModeSuite) TestStartStop(c *gc.C) {
@@ -1231,6 +1270,62 @@ func (s *GlobalModeSuite) TestStartStop(c *gc.C) {
 	statetesting.AssertKillAndWait(c, fw)
 }
 
+func (s *GlobalModeSuite) assertEgressRules(c *gc.C, expected []network.EgressRule) {
+	egressEnv, ok := s.Environ.(environs.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := egressEnv.EgressRules()
+		c.Assert(err, jc.ErrorIsNil)
+		if reflect.DeepEqual(got, expected) {
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *GlobalModeSuite) TestEgressRules(c *gc.C) {
+	cfg := s.firewallerConfig(c)
+	cfg.EnvironEgressFirewaller = s.Environ.(environs.EgressFirewaller)
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// Outgoing traffic is not restricted until egress CIDRs are set.
+	s.assertEgressRules(c, nil)
+
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"firewall-egress-cidrs": "10.0.0.0/8",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, []network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 1, 65535, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 1, 65535, "10.0.0.0/8"),
+	})
+
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"firewall-egress-cidrs": "10.0.0.0/8,192.168.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, []network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("tcp", 1, 65535, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("udp", 1, 65535, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{
+		"firewall-egress-cidrs": "",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, nil)
+}
+
 func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	// Start firewaller and open ports.
 	fw := s.newFirewaller(c)
@@ -1446,6 +1541,286 @@ func (s *GlobalModeSuite) TestRestartPortCount(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+type ApplicationModeSuite struct {
+	firewallerBaseSuite
+	appFirewaller *fakeApplicationFirewaller
+}
+
+var _ = gc.Suite(&ApplicationModeSuite{})
+
+func (s *ApplicationModeSuite) SetUpTest(c *gc.C) {
+	s.firewallerBaseSuite.setUpTest(c, config.FwApplication)
+	s.appFirewaller = &fakeApplicationFirewaller{
+		rules:     make(map[string][]network.IngressRule),
+		instances: make(map[string][]instance.Id),
+	}
+}
+
+func (s *ApplicationModeSuite) TearDownTest(c *gc.C) {
+	s.firewallerBaseSuite.JujuConnSuite.TearDownTest(c)
+}
+
+func (s *ApplicationModeSuite) firewallerConfig() firewaller.Config {
+	return firewaller.Config{
+		ModelUUID:                    s.State.ModelUUID(),
+		Mode:                         config.FwApplication,
+		EnvironApplicationFirewaller: s.appFirewaller,
+		EnvironInstances:             s.Environ,
+		FirewallerAPI:                s.firewaller,
+		RemoteRelationsApi:           s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+	}
+}
+
+func (s *ApplicationModeSuite) newFirewaller(c *gc.C) worker.Worker {
+	fw, err := firewaller.NewFirewaller(s.firewallerConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	return fw
+}
+
+func (s *ApplicationModeSuite) assertApplicationPorts(c *gc.C, appName string, expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := s.appFirewaller.ApplicationIngressRules(appName)
+		c.Assert(err, jc.ErrorIsNil)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *ApplicationModeSuite) TestValidateConfig(c *gc.C) {
+	cfg := s.firewallerConfig()
+	cfg.EnvironApplicationFirewaller = nil
+	err := cfg.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "nil EnvironApplicationFirewaller not valid")
+}
+
+func (s *ApplicationModeSuite) TestApplicationMode(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app1.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u1, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+	u2, m2 := s.addUnit(c, app1)
+	s.startInstance(c, m2)
+	err = u1.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2 := s.AddTestingApplication(c, "moinmoin", s.charm)
+	err = app2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u3, m3 := s.addUnit(c, app2)
+	s.startInstance(c, m3)
+	err = u3.OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertApplicationPorts(c, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+	s.assertApplicationPorts(c, "moinmoin", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+	c.Assert(s.appFirewaller.openedRule("443/tcp"), jc.DeepEquals, network.MustNewIngressRule(
+		"tcp", 443, 443, "0.0.0.0/0",
+	).WithProvenance("unit-moinmoin-0", "moinmoin/0 (exposed)"))
+
+	// Closing a port still opened by another unit of the same
+	// application won't touch the application's group.
+	err = u1.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+
+	// Closing the last reference does.
+	err = u2.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+
+	// Unexposing an application closes all of its ports.
+	err = app2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "moinmoin", nil)
+}
+
+func (s *ApplicationModeSuite) TestUnitPlacedAfterProvisioning(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app1.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m := s.addUnit(c, app1)
+	inst := s.startInstance(c, m)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+
+	// A unit placed on the running machine brings the machine's
+	// instance under its application's ports.
+	app2 := s.AddTestingApplication(c, "moinmoin", s.charm)
+	err = app2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "moinmoin", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+	c.Assert(s.appFirewaller.applicationInstances("moinmoin"), jc.DeepEquals, []instance.Id{inst.Id()})
+
+	// The instance is only added once.
+	err = u2.OpenPort("tcp", 8443)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, "moinmoin", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8443, 8443, "0.0.0.0/0"),
+	})
+	c.Assert(s.appFirewaller.applicationInstances("moinmoin"), jc.DeepEquals, []instance.Id{inst.Id()})
+}
+
+func (s *ApplicationModeSuite) TestRestart(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Simulate a port left open by a previous firewaller.
+	err = s.appFirewaller.OpenApplicationPorts("wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+	s.assertApplicationPorts(c, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
+// fakeApplicationFirewaller is an environs.ApplicationFirewaller which
+// records the open ports of each application in memory. Like most
+// clouds it does not keep rule provenance, but the rules passed to
+// OpenApplicationPorts are recorded as given in opened. The instances
+// added to each application are recorded in instances.
+type fakeApplicationFirewaller struct {
+	mu        sync.Mutex
+	rules     map[string][]network.IngressRule
+	opened    []network.IngressRule
+	instances map[string][]instance.Id
+}
+
+func (f *fakeApplicationFirewaller) OpenApplicationPorts(appName string, rules []network.IngressRule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.opened = append(f.opened, rules...)
+	existing := f.rules[appName]
+	for _, rule := range rules {
+		rule = rule.WithProvenance("", "")
+		found := false
+		for _, e := range existing {
+			if reflect.DeepEqual(e, rule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, rule)
+		}
+	}
+	f.rules[appName] = existing
+	return nil
+}
+
+func (f *fakeApplicationFirewaller) CloseApplicationPorts(appName string, rules []network.IngressRule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var remaining []network.IngressRule
+	for _, e := range f.rules[appName] {
+		closed := false
+		for _, rule := range rules {
+			if reflect.DeepEqual(e, rule.WithProvenance("", "")) {
+				closed = true
+				break
+			}
+		}
+		if !closed {
+			remaining = append(remaining, e)
+		}
+	}
+	f.rules[appName] = remaining
+	return nil
+}
+
+// openedRule returns the last opened rule with the given string form.
+func (f *fakeApplicationFirewaller) openedRule(rule string) network.IngressRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.opened) - 1; i >= 0; i-- {
+		if f.opened[i].String() == rule {
+			return f.opened[i]
+		}
+	}
+	return network.IngressRule{}
+}
+
+func (f *fakeApplicationFirewaller) ApplicationIngressRules(appName string) ([]network.IngressRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.rules[appName]) == 0 {
+		return nil, nil
+	}
+	rules := make([]network.IngressRule, len(f.rules[appName]))
+	copy(rules, f.rules[appName])
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+func (f *fakeApplicationFirewaller) AddApplicationInstance(appName string, id instance.Id) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.instances[appName] = append(f.instances[appName], id)
+	return nil
+}
+
+// applicationInstances returns the instances added to the named
+// application.
+func (f *fakeApplicationFirewaller) applicationInstances(appName string) []instance.Id {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]instance.Id(nil), f.instances[appName]...)
+}
+
 type NoneModeSuite struct {
 	firewallerBaseSuite
 }
@@ -103,6 +103,14 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		}
 	}
 
+	// Application mode needs a provider that can group ports by
+	// application.
+	appFwEnv, appFwEnvOK := environ.(environs.ApplicationFirewaller)
+	if mode == config.FwApplication && !appFwEnvOK {
+		logger.Infof("Firewall application mode set on provider with no support. stopping firewaller")
+		return nil, dependency.ErrUninstall
+	}
+
 	firewallerAPI, err := cfg.NewFirewallerFacade(apiConn)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -112,14 +120,19 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
+	// Egress is only restricted where the provider supports it.
+	egressEnv, _ := environ.(environs.EgressFirewaller)
+
 	w, err := cfg.NewFirewallerWorker(Config{
-		ModelUUID:          agent.CurrentConfig().Model().Id(),
-		RemoteRelationsApi: remoteRelationsAPI,
-		FirewallerAPI:      firewallerAPI,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   environ,
-		Mode:               mode,
-		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
+		ModelUUID:                    agent.CurrentConfig().Model().Id(),
+		RemoteRelationsApi:           remoteRelationsAPI,
+		FirewallerAPI:                firewallerAPI,
+		EnvironFirewaller:            fwEnv,
+		EnvironInstances:             environ,
+		EnvironEgressFirewaller:      egressEnv,
+		EnvironApplicationFirewaller: appFwEnv,
+		Mode:                         mode,
+		NewCrossModelFacadeFunc:      crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
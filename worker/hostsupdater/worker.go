@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostsupdater provides a worker which keeps a block of entries
+// in the machine's hosts file up to date, so that the containers and
+// units the machine knows about can be resolved by name.
+package hostsupdater
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostsupdater")
+
+const (
+	// DefaultHostsPath is the path of the hosts file maintained by the
+	// worker.
+	DefaultHostsPath = "/etc/hosts"
+
+	beginMarker = "# Begin Juju managed hosts"
+	endMarker   = "# End Juju managed hosts"
+)
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	WatchHostEntries() (watcher.NotifyWatcher, error)
+	HostEntries(machine names.MachineTag) ([]params.HostEntry, error)
+}
+
+// Config defines the parameters of the hostsupdater worker.
+type Config struct {
+	Facade  Facade
+	Machine names.MachineTag
+
+	// Path is the path of the hosts file to maintain.
+	Path string
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Machine.Id() == "" {
+		return errors.NotValidf("empty Machine")
+	}
+	if config.Path == "" {
+		return errors.NotValidf("empty Path")
+	}
+	return nil
+}
+
+// New returns a worker that rewrites the Juju managed block of the
+// configured hosts file whenever the machine's host entries change.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &hostsUpdater{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type hostsUpdater struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (u *hostsUpdater) SetUp() (watcher.NotifyWatcher, error) {
+	return u.config.Facade.WatchHostEntries()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (u *hostsUpdater) Handle(_ <-chan struct{}) error {
+	entries, err := u.config.Facade.HostEntries(u.config.Machine)
+	if err != nil {
+		return errors.Annotate(err, "cannot get host entries")
+	}
+	return errors.Trace(u.writeHosts(entries))
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (u *hostsUpdater) TearDown() error {
+	return nil
+}
+
+// writeHosts replaces the Juju managed block of the hosts file with
+// the given entries, leaving the rest of the file untouched. The file
+// is only rewritten if its content changes.
+func (u *hostsUpdater) writeHosts(entries []params.HostEntry) error {
+	path := u.config.Path
+	mode := os.FileMode(0644)
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode()
+	}
+	content := updateHosts(string(data), entries)
+	if content == string(data) {
+		return nil
+	}
+	logger.Debugf("writing %d host entries to %s", len(entries), path)
+	if err := utils.AtomicWriteFile(path, []byte(content), mode); err != nil {
+		return errors.Annotatef(err, "cannot write %s", path)
+	}
+	return nil
+}
+
+// updateHosts returns the supplied hosts file content with its Juju
+// managed block replaced by one holding the given entries. A missing
+// block is appended; an empty set of entries removes the block.
+func updateHosts(content string, entries []params.HostEntry) string {
+	var lines, block []string
+	inBlock := false
+	for _, line := range strings.SplitAfter(content, "\n") {
+		switch strings.TrimSpace(line) {
+		case beginMarker:
+			inBlock = true
+			continue
+		case endMarker:
+			inBlock = false
+			continue
+		}
+		if !inBlock && line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for _, entry := range entries {
+		if entry.Address == "" || len(entry.Names) == 0 {
+			continue
+		}
+		block = append(block, entry.Address+"\t"+strings.Join(entry.Names, " ")+"\n")
+	}
+	if len(block) == 0 {
+		return strings.Join(lines, "")
+	}
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines[n-1] += "\n"
+	}
+	lines = append(lines, beginMarker+"\n")
+	lines = append(lines, block...)
+	lines = append(lines, endMarker+"\n")
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostsupdater_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/hostsupdater"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const initialHosts = "127.0.0.1\tlocalhost\n::1\tip6-localhost ip6-loopback\n"
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	path   string
+	facade *mockFacade
+	config hostsupdater.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.path = filepath.Join(c.MkDir(), "hosts")
+	err := ioutil.WriteFile(s.path, []byte(initialHosts), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = &mockFacade{changes: make(chan struct{})}
+	s.config = hostsupdater.Config{
+		Facade:  s.facade,
+		Machine: names.NewMachineTag("0"),
+		Path:    s.path,
+	}
+}
+
+func (s *Suite) startWorker(c *gc.C) {
+	w, err := hostsupdater.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *Suite) notify(c *gc.C) {
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *Suite) waitHosts(c *gc.C, expected string) {
+	var content string
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		data, err := ioutil.ReadFile(s.path)
+		c.Assert(err, jc.ErrorIsNil)
+		content = string(data)
+		if content == expected {
+			return
+		}
+	}
+	c.Fatalf("hosts file never became %q; last saw %q", expected, content)
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Path = ""
+	_, err := hostsupdater.New(s.config)
+	c.Assert(err, gc.ErrorMatches, "empty Path not valid")
+}
+
+func (s *Suite) TestWritesManagedBlock(c *gc.C) {
+	s.facade.setEntries([]params.HostEntry{{
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-0-lxd-0", "wordpress-0"},
+	}, {
+		Address: "10.0.0.3",
+		Names:   []string{"juju-abcdef-0-lxd-1"},
+	}})
+	s.startWorker(c)
+	s.notify(c)
+	s.waitHosts(c, initialHosts+
+		"# Begin Juju managed hosts\n"+
+		"10.0.0.2\tjuju-abcdef-0-lxd-0 wordpress-0\n"+
+		"10.0.0.3\tjuju-abcdef-0-lxd-1\n"+
+		"# End Juju managed hosts\n",
+	)
+}
+
+func (s *Suite) TestReplacesManagedBlock(c *gc.C) {
+	s.facade.setEntries([]params.HostEntry{{
+		Address: "10.0.0.2",
+		Names:   []string{"juju-abcdef-0-lxd-0"},
+	}})
+	s.startWorker(c)
+	s.notify(c)
+	s.waitHosts(c, initialHosts+
+		"# Begin Juju managed hosts\n"+
+		"10.0.0.2\tjuju-abcdef-0-lxd-0\n"+
+		"# End Juju managed hosts\n",
+	)
+
+	s.facade.setEntries([]params.HostEntry{{
+		Address: "10.0.0.4",
+		Names:   []string{"juju-abcdef-0-lxd-0"},
+	}})
+	s.notify(c)
+	s.waitHosts(c, initialHosts+
+		"# Begin Juju managed hosts\n"+
+		"10.0.0.4\tjuju-abcdef-0-lxd-0\n"+
+		"# End Juju managed hosts\n",
+	)
+}
+
+func (s *Suite) TestRemovesManagedBlock(c *gc.C) {
+	err := ioutil.WriteFile(s.path, []byte(initialHosts+
+		"# Begin Juju managed hosts\n"+
+		"10.0.0.2\tjuju-abcdef-0-lxd-0\n"+
+		"# End Juju managed hosts\n"+
+		"192.168.1.1\trouter\n",
+	), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.startWorker(c)
+	s.notify(c)
+	s.waitHosts(c, initialHosts+"192.168.1.1\trouter\n")
+}
+
+type mockFacade struct {
+	mu      sync.Mutex
+	entries []params.HostEntry
+	changes chan struct{}
+}
+
+func (f *mockFacade) setEntries(entries []params.HostEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = entries
+}
+
+func (f *mockFacade) HostEntries(machine names.MachineTag) ([]params.HostEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.entries, nil
+}
+
+func (f *mockFacade) WatchHostEntries() (watcher.NotifyWatcher, error) {
+	return &mockWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: f.changes,
+	}, nil
+}
+
+type mockWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+func (w *mockWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
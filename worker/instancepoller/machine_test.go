@@ -46,7 +46,7 @@ func (s *machineSuite) TestSetsInstanceInfoInitially(c *gc.C) {
 	died := make(chan machine)
 
 	clock := newTestClock()
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 
@@ -71,7 +71,7 @@ func (s *machineSuite) TestSetsInstanceInfoDeadMachineInitially(c *gc.C) {
 	died := make(chan machine)
 
 	clock := newTestClock()
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 
@@ -134,7 +134,7 @@ func (s *machineSuite) TestNoPollWhenNotProvisioned(c *gc.C) {
 
 	clock := gitjujutesting.NewClock(time.Time{})
 	changed := make(chan struct{})
-	go runMachine(context, m, changed, died, clock)
+	go runMachine(context, m, changed, nil, died, clock)
 
 	expectPoll := func() {
 		c.Assert(clock.WaitAdvance(ShortPoll, 0, 1), jc.ErrorIsNil)
@@ -193,6 +193,81 @@ func (s *machineSuite) TestLongPollIntervalWhenHasAllInstanceInfo(c *gc.C) {
 	clock.CheckCall(c, 0, "After", LongPoll)
 }
 
+func (s *machineSuite) TestStablePollBackoffLimit(c *gc.C) {
+	pollDurations := []time.Duration{
+		15 * time.Minute, // LongPoll
+		30 * time.Minute,
+		60 * time.Minute, // limit is 1 hour (StablePoll)
+		60 * time.Minute,
+	}
+
+	clock := newTestClock()
+	testRunMachine(c, testAddrs, "i1234", "running", status.Started, clock, func() {
+		for _, d := range pollDurations {
+			c.Assert(clock.WaitAdvance(d, 0, 1), jc.ErrorIsNil)
+		}
+	})
+	for i, d := range pollDurations {
+		clock.CheckCall(c, i, "After", d)
+	}
+}
+
+func (s *machineSuite) TestInstanceChangedPollsImmediately(c *gc.C) {
+	polled := make(chan struct{}, 10)
+	instStatus := "running"
+	var mu sync.Mutex
+	getInstanceInfo := func(id instance.Id) (instanceInfo, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		polled <- struct{}{}
+		return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Unknown, Message: instStatus}}, nil
+	}
+	context := &testMachineContext{
+		getInstanceInfo: getInstanceInfo,
+		dyingc:          make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		addresses:  testAddrs,
+		life:       params.Alive,
+		status:     status.Started,
+	}
+	died := make(chan machine)
+	instanceChanged := make(chan struct{}, 1)
+	clock := newTestClock()
+	go runMachine(context, m, nil, instanceChanged, died, clock)
+
+	expectPoll := func() {
+		select {
+		case <-polled:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("expected instance poll")
+		}
+	}
+	expectPoll()
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
+	expectPoll()
+
+	// The instance changing resets the poll interval.
+	mu.Lock()
+	instStatus = "stopping"
+	mu.Unlock()
+	instanceChanged <- struct{}{}
+	expectPoll()
+	// The timer set after the previous poll is still pending.
+	c.Assert(clock.WaitAdvance(LongPoll, 0, 2), jc.ErrorIsNil)
+	expectPoll()
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.instStatusInfo, gc.Equals, "stopping")
+	clock.CheckCall(c, 0, "After", LongPoll)
+	clock.CheckCall(c, 1, "After", 2*LongPoll)
+	clock.CheckCall(c, 2, "After", LongPoll)
+}
+
 func testRunMachine(
 	c *gc.C,
 	addrs []network.Address,
@@ -222,7 +297,7 @@ func testRunMachine(
 	}
 	died := make(chan machine)
 
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	test()
 
 	killMachineLoop(c, m, context.dyingc, died)
@@ -248,7 +323,7 @@ func (*machineSuite) TestChangedRefreshes(c *gc.C) {
 	died := make(chan machine)
 	changed := make(chan struct{})
 	clock := newTestClock()
-	go runMachine(context, m, changed, died, clock)
+	go runMachine(context, m, changed, nil, died, clock)
 
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	select {
@@ -327,7 +402,7 @@ func testTerminatingErrors(c *gc.C, mutate func(m *testMachine, err error)) {
 	mutate(m, expectErr)
 	died := make(chan machine)
 	changed := make(chan struct{}, 1)
-	go runMachine(context, m, changed, died, newTestClock())
+	go runMachine(context, m, changed, nil, died, newTestClock())
 	changed <- struct{}{}
 	select {
 	case <-died:
@@ -26,11 +26,16 @@ var logger = loggo.GetLogger("juju.worker.instancepoller")
 // with an exponent of ShortPollBackoff until a maximum(ish) of LongPoll.
 //
 // When a machine has an address and is started LongPoll will be used to
-// check that the instance address or status has not changed.
+// check that the instance address or status has not changed. While the
+// instance stays unchanged, the interval is backed off with an exponent
+// of StablePollBackoff until a maximum of StablePoll. Any change to the
+// instance resets the interval to LongPoll.
 var (
-	ShortPoll        = 1 * time.Second
-	ShortPollBackoff = 2.0
-	LongPoll         = 15 * time.Minute
+	ShortPoll         = 1 * time.Second
+	ShortPollBackoff  = 2.0
+	LongPoll          = 15 * time.Minute
+	StablePollBackoff = 2.0
+	StablePoll        = 1 * time.Hour
 )
 
 type machine interface {
@@ -73,20 +78,45 @@ type updaterContext interface {
 	getMachine(tag names.MachineTag) (machine, error)
 }
 
+// machineRunner tracks the goroutine polling a machine's instance.
+type machineRunner struct {
+	m machine
+
+	// instanceId holds the id of the machine's instance, once it
+	// is known to the updater.
+	instanceId instance.Id
+
+	// changed is notified when the machine's life may have changed.
+	changed chan struct{}
+
+	// instanceChanged is notified when the cloud reports that the
+	// machine's instance may have changed. It is buffered so that
+	// notifications are coalesced.
+	instanceChanged chan struct{}
+}
+
 type updater struct {
 	context     updaterContext
-	machines    map[names.MachineTag]chan struct{}
+	machines    map[names.MachineTag]*machineRunner
+	instances   map[instance.Id]*machineRunner
 	machineDead chan machine
 }
 
 // watchMachinesLoop watches for changes provided by the given
 // machinesWatcher and starts machine goroutines to deal with them,
 // using the provided newMachineContext function to create the
-// appropriate context for each new machine tag.
-func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWatcher) (err error) {
+// appropriate context for each new machine tag. If instancesWatcher
+// is not nil, the machines whose instances it reports are polled
+// immediately.
+func watchMachinesLoop(
+	context updaterContext,
+	machinesWatcher watcher.StringsWatcher,
+	instancesWatcher watcher.StringsWatcher,
+) (err error) {
 	p := &updater{
 		context:     context,
-		machines:    make(map[names.MachineTag]chan struct{}),
+		machines:    make(map[names.MachineTag]*machineRunner),
+		instances:   make(map[instance.Id]*machineRunner),
 		machineDead: make(chan machine),
 	}
 	defer func() {
@@ -94,9 +124,13 @@ func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWa
 		// strongly suspect these machine goroutines could be managed rather
 		// less opaquely if we made them all workers.
 		for len(p.machines) > 0 {
-			delete(p.machines, (<-p.machineDead).Tag())
+			p.removeMachine(<-p.machineDead)
 		}
 	}()
+	var instanceChanges watcher.StringsChannel
+	if instancesWatcher != nil {
+		instanceChanges = instancesWatcher.Changes()
+	}
 	for {
 		select {
 		case <-p.context.dying():
@@ -112,15 +146,75 @@ func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWa
 			if err := p.startMachines(tags); err != nil {
 				return err
 			}
+		case ids, ok := <-instanceChanges:
+			if !ok {
+				return errors.New("instances watcher closed")
+			}
+			instIds := make([]instance.Id, len(ids))
+			for i := range ids {
+				instIds[i] = instance.Id(ids[i])
+			}
+			p.instancesChanged(instIds)
 		case m := <-p.machineDead:
-			delete(p.machines, m.Tag())
+			p.removeMachine(m)
 		}
 	}
 }
 
+func (p *updater) removeMachine(m machine) {
+	if r := p.machines[m.Tag()]; r != nil && r.instanceId != "" {
+		delete(p.instances, r.instanceId)
+	}
+	delete(p.machines, m.Tag())
+}
+
+// instancesChanged tells the goroutines of the machines with the given
+// instances to poll them now.
+func (p *updater) instancesChanged(ids []instance.Id) {
+	for _, id := range ids {
+		if p.instances[id] == nil {
+			// At least one instance is new to us, so find the
+			// instance ids of the machines provisioned since the
+			// last change.
+			p.mapInstances()
+			break
+		}
+	}
+	for _, id := range ids {
+		r := p.instances[id]
+		if r == nil {
+			continue
+		}
+		select {
+		case r.instanceChanged <- struct{}{}:
+		default:
+			// A poll is already pending.
+		}
+	}
+}
+
+// mapInstances records the instance ids of the machines whose instance
+// ids are not yet known.
+func (p *updater) mapInstances() {
+	for _, r := range p.machines {
+		if r.instanceId != "" {
+			continue
+		}
+		instId, err := r.m.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			logger.Warningf("cannot get instance id of machine %v: %v", r.m.Id(), err)
+			continue
+		}
+		r.instanceId = instId
+		p.instances[instId] = r
+	}
+}
+
 func (p *updater) startMachines(tags []names.MachineTag) error {
 	for _, tag := range tags {
-		if c := p.machines[tag]; c == nil {
+		if r := p.machines[tag]; r == nil {
 			// We don't know about the machine - start
 			// a goroutine to deal with it.
 			m, err := p.context.getMachine(tag)
@@ -146,15 +240,19 @@ func (p *updater) startMachines(tags []names.MachineTag) error {
 				}
 				continue
 			}
-			c = make(chan struct{})
-			p.machines[tag] = c
+			r = &machineRunner{
+				m:               m,
+				changed:         make(chan struct{}),
+				instanceChanged: make(chan struct{}, 1),
+			}
+			p.machines[tag] = r
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			go runMachine(p.context.newMachineContext(), m, c, p.machineDead, clock.WallClock)
+			go runMachine(p.context.newMachineContext(), m, r.changed, r.instanceChanged, p.machineDead, clock.WallClock)
 		} else {
 			select {
 			case <-p.context.dying():
 				return p.context.errDying()
-			case c <- struct{}{}:
+			case r.changed <- struct{}{}:
 			}
 		}
 	}
@@ -162,8 +260,16 @@ func (p *updater) startMachines(tags []names.MachineTag) error {
 }
 
 // runMachine processes the address and status publishing for a given machine.
-// We assume that the machine is alive when this is first called.
-func runMachine(context machineContext, m machine, changed <-chan struct{}, died chan<- machine, clock clock.Clock) {
+// We assume that the machine is alive when this is first called. The
+// machine's instance is polled immediately whenever instanceChanged is
+// notified.
+func runMachine(
+	context machineContext,
+	m machine,
+	changed, instanceChanged <-chan struct{},
+	died chan<- machine,
+	clock clock.Clock,
+) {
 	defer func() {
 		// We can't just send on the died channel because the
 		// central loop might be trying to write to us on the
@@ -176,16 +282,22 @@ func runMachine(context machineContext, m machine, changed <-chan struct{}, died
 			}
 		}
 	}()
-	if err := machineLoop(context, m, changed, clock); err != nil {
+	if err := machineLoop(context, m, changed, instanceChanged, clock); err != nil {
 		context.kill(err)
 	}
 }
 
-func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{}, clock clock.Clock) error {
+func machineLoop(
+	context machineContext,
+	m machine,
+	lifeChanged, instanceChanged <-chan struct{},
+	clock clock.Clock,
+) error {
 	// Use a short poll interval when initially waiting for
 	// a machine's address and machine agent to start, and a long one when it already
 	// has an address and the machine agent is started.
 	pollInterval := ShortPoll
+	var lastInfo *instanceInfo
 	pollInstance := func() error {
 		instInfo, err := pollInstanceInfo(context, m)
 		if err != nil {
@@ -206,8 +318,16 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 		// without it the instance status will say "pending" for +10 minutes after the agent comes up to "started"
 		if instInfo.status.Status != status.Allocating && instInfo.status.Status != status.Pending {
 			if len(instInfo.addresses) > 0 && machineStatus == status.Started {
-				// We've got at least one address and a status and instance is started, so poll infrequently.
-				pollInterval = LongPoll
+				// We've got at least one address and a status and instance is started, so poll infrequently,
+				// and increasingly rarely for as long as nothing changes.
+				if pollInterval >= LongPoll && lastInfo != nil && instanceInfoEqual(*lastInfo, instInfo) {
+					pollInterval = time.Duration(float64(pollInterval) * StablePollBackoff)
+					if pollInterval > StablePoll {
+						pollInterval = StablePoll
+					}
+				} else {
+					pollInterval = LongPoll
+				}
 			} else if pollInterval < LongPoll {
 				// We have no addresses or not started - poll increasingly rarely
 				// until we do.
@@ -217,6 +337,7 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 				}
 			}
 		}
+		lastInfo = &instInfo
 		return nil
 	}
 
@@ -235,6 +356,8 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 			return context.errDying()
 		case <-clock.After(pollInterval):
 			shouldPollInstance = true
+		case <-instanceChanged:
+			shouldPollInstance = true
 		case <-lifeChanged:
 			if err := m.Refresh(); err != nil {
 				return err
@@ -303,6 +426,12 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 	return instInfo, nil
 }
 
+// instanceInfoEqual reports whether two polls found the instance in
+// the same state.
+func instanceInfoEqual(i0, i1 instanceInfo) bool {
+	return i0.status == i1.status && addressesEqual(i0.addresses, i1.addresses)
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(a0, a1 []network.Address) bool {
 	if len(a0) != len(a1) {
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -60,7 +61,7 @@ func (*updaterSuite) TestWatchMachinesWaitsForMachinePollers(c *gc.C) {
 	}
 	done := make(chan error)
 	go func() {
-		done <- watchMachinesLoop(context, watcher)
+		done <- watchMachinesLoop(context, watcher, nil)
 	}()
 	// Send two changes; the first one should start the machineLoop;
 	// the second should call Refresh.
@@ -133,7 +134,7 @@ func (s *updaterSuite) TestManualMachinesStatusChangedAndIgnored(c *gc.C) {
 	}
 	done := make(chan error)
 	go func() {
-		done <- watchMachinesLoop(context, watcher)
+		done <- watchMachinesLoop(context, watcher, nil)
 	}()
 	// Send a change to start the machineLoop;
 	watcher.changes <- []string{"99"}
@@ -155,6 +156,71 @@ func (s *updaterSuite) TestManualMachinesStatusChangedAndIgnored(c *gc.C) {
 	c.Assert(statusCalled, gc.Equals, 1)
 }
 
+func (*updaterSuite) TestInstanceChangesPollMachine(c *gc.C) {
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		life:       params.Alive,
+		refresh:    func() error { return nil },
+	}
+	polled := make(chan instance.Id, 10)
+	dyingc := make(chan struct{})
+	context := &testUpdaterContext{
+		dyingc: dyingc,
+		newMachineContextFunc: func() machineContext {
+			return &testMachineContext{
+				getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+					polled <- id
+					return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Running}}, nil
+				},
+				dyingc: dyingc,
+			}
+		},
+		getMachineFunc: func(tag names.MachineTag) (machine, error) {
+			return m, nil
+		},
+	}
+	machinesWatcher := &testMachinesWatcher{
+		changes: make(chan []string),
+	}
+	instancesWatcher := &testMachinesWatcher{
+		changes: make(chan []string),
+	}
+	done := make(chan error)
+	go func() {
+		done <- watchMachinesLoop(context, machinesWatcher, instancesWatcher)
+	}()
+	assertPolled := func() {
+		select {
+		case id := <-polled:
+			c.Assert(id, gc.Equals, instance.Id("i1234"))
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for instance poll")
+		}
+	}
+	machinesWatcher.changes <- []string{"99"}
+	assertPolled()
+
+	// Changes to unknown instances are ignored.
+	instancesWatcher.changes <- []string{"i5678"}
+	select {
+	case <-polled:
+		c.Fatalf("unexpected instance poll")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	instancesWatcher.changes <- []string{"i5678", "i1234"}
+	assertPolled()
+
+	close(dyingc)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for watchMachinesLoop to terminate")
+	}
+}
+
 type testUpdaterContext struct {
 	updaterContext
 	newMachineContextFunc func() machineContext
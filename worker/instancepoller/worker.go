@@ -12,7 +12,9 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -89,14 +91,38 @@ func (u *updaterWorker) loop() (err error) {
 	if err := u.catacomb.Add(u.aggregator); err != nil {
 		return errors.Trace(err)
 	}
-	watcher, err := u.config.Facade.WatchModelMachines()
+	machinesWatcher, err := u.config.Facade.WatchModelMachines()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := u.catacomb.Add(watcher); err != nil {
+	if err := u.catacomb.Add(machinesWatcher); err != nil {
 		return errors.Trace(err)
 	}
-	return watchMachinesLoop(u, watcher)
+	instancesWatcher, err := u.watchInstanceChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return watchMachinesLoop(u, machinesWatcher, instancesWatcher)
+}
+
+// watchInstanceChanges returns a watcher of the instances the cloud
+// reports as changed, or nil if the environ cannot report them.
+func (u *updaterWorker) watchInstanceChanges() (watcher.StringsWatcher, error) {
+	changeWatcher, ok := u.config.Environ.(environs.InstanceChangeWatcher)
+	if !ok {
+		return nil, nil
+	}
+	w, err := changeWatcher.WatchInstanceChanges()
+	if errors.IsNotSupported(err) {
+		logger.Infof("instance change notifications not available: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot watch instance changes")
+	}
+	if err := u.catacomb.Add(w); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
 }
 
 // newMachineContext is part of the updaterContext interface.
@@ -95,6 +95,10 @@ juju-pubsub-report () {
   jujuMachineOrUnit pubsub $@
 }
 
+juju-leases-report () {
+  jujuMachineOrUnit leases $@
+}
+
 juju-metrics () {
   jujuMachineOrUnit metrics $@
 }
@@ -114,4 +118,5 @@ export -f juju-metrics
 export -f juju-statepool-report
 export -f juju-statetracker-report
 export -f juju-pubsub-report
+export -f juju-leases-report
 `
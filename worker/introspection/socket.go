@@ -35,12 +35,21 @@ type IntrospectionReporter interface {
 	IntrospectionReport() string
 }
 
+// LeaseReporter provides insight into the lease managers of the agent's
+// models.
+type LeaseReporter interface {
+	// LeaseReport returns a map describing the lease managers, keyed by
+	// model UUID. It is expected to be goroutine-safe.
+	LeaseReport() map[string]interface{}
+}
+
 // Config describes the arguments required to create the introspection worker.
 type Config struct {
 	SocketName         string
 	DepEngine          DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	Leases             LeaseReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -62,6 +71,7 @@ type socketListener struct {
 	depEngine          DepEngineReporter
 	statePool          IntrospectionReporter
 	pubsub             IntrospectionReporter
+	leases             LeaseReporter
 	prometheusGatherer prometheus.Gatherer
 	done               chan struct{}
 }
@@ -93,6 +103,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		depEngine:          config.DepEngine,
 		statePool:          config.StatePool,
 		pubsub:             config.PubSub,
+		leases:             config.Leases,
 		prometheusGatherer: config.PrometheusGatherer,
 		done:               make(chan struct{}),
 	}
@@ -108,6 +119,7 @@ func (w *socketListener) serve() {
 			DependencyEngine:   w.depEngine,
 			StatePool:          w.statePool,
 			PubSub:             w.pubsub,
+			Leases:             w.leases,
 			PrometheusGatherer: w.prometheusGatherer,
 		}, mux.Handle)
 
@@ -144,6 +156,7 @@ type ReportSources struct {
 	DependencyEngine   DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	Leases             LeaseReporter
 	PrometheusGatherer prometheus.Gatherer
 }
 
@@ -168,6 +181,7 @@ func RegisterHTTPHandlers(
 		name:     "PubSub Report",
 		reporter: sources.PubSub,
 	})
+	handle("/leases", leasesHandler{sources.Leases})
 	handle("/metrics", promhttp.HandlerFor(sources.PrometheusGatherer, promhttp.HandlerOpts{}))
 }
 
@@ -195,6 +209,30 @@ func (h depengineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(bytes)
 }
 
+type leasesHandler struct {
+	reporter LeaseReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h leasesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.reporter == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "missing lease reporter")
+		return
+	}
+	bytes, err := yaml.Marshal(h.reporter.LeaseReport())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Lease Report\n\n")
+	w.Write(bytes)
+}
+
 type introspectionReporterHandler struct {
 	name     string
 	reporter IntrospectionReporter
@@ -56,6 +56,7 @@ type introspectionSuite struct {
 	name     string
 	worker   worker.Worker
 	reporter introspection.DepEngineReporter
+	leases   introspection.LeaseReporter
 	gatherer prometheus.Gatherer
 }
 
@@ -67,6 +68,7 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	}
 	s.IsolationSuite.SetUpTest(c)
 	s.reporter = nil
+	s.leases = nil
 	s.worker = nil
 	s.gatherer = newPrometheusGatherer()
 	s.startWorker(c)
@@ -77,6 +79,7 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 	w, err := introspection.NewWorker(introspection.Config{
 		SocketName:         s.name,
 		DepEngine:          s.reporter,
+		Leases:             s.leases,
 		PrometheusGatherer: s.gatherer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -130,6 +133,12 @@ func (s *introspectionSuite) TestMissingPubSubReporter(c *gc.C) {
 	matches(c, buf, "PubSub Report: missing reporter")
 }
 
+func (s *introspectionSuite) TestMissingLeaseReporter(c *gc.C) {
+	buf := s.call(c, "/leases")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "missing lease reporter")
+}
+
 func (s *introspectionSuite) TestStateTrackerReporter(c *gc.C) {
 	buf := s.call(c, "/debug/pprof/juju/state/tracker?debug=1")
 	matches(c, buf, "200 OK")
@@ -152,6 +161,25 @@ func (s *introspectionSuite) TestEngineReporter(c *gc.C) {
 	matches(c, buf, "working: true")
 }
 
+func (s *introspectionSuite) TestLeaseReporter(c *gc.C) {
+	// We need to make sure the existing worker is shut down
+	// so we can connect to the socket.
+	workertest.CheckKill(c, s.worker)
+	s.leases = leaseReporter{
+		"model-uuid": map[string]interface{}{
+			"leadership": map[string]interface{}{
+				"expired": 3,
+			},
+		},
+	}
+	s.startWorker(c)
+	buf := s.call(c, "/leases")
+
+	matches(c, buf, "200 OK")
+	matches(c, buf, "Lease Report")
+	matches(c, buf, "    expired: 3")
+}
+
 func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	buf := s.call(c, "/metrics")
 	c.Assert(buf, gc.NotNil)
@@ -184,6 +212,12 @@ func (r *reporter) Report() map[string]interface{} {
 	return r.values
 }
 
+type leaseReporter map[string]interface{}
+
+func (r leaseReporter) LeaseReport() map[string]interface{} {
+	return r
+}
+
 func newPrometheusGatherer() prometheus.Gatherer {
 	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "tau", Help: "Tau."})
 	counter.Add(6.283185)
@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leasemetrics
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/lease"
+)
+
+var (
+	jujuLeasesDesc = prometheus.NewDesc(
+		"juju_leases",
+		"Number of leases held in each model, by lease type.",
+		[]string{"model", "type"},
+		prometheus.Labels{},
+	)
+	jujuLeasesOverdueDesc = prometheus.NewDesc(
+		"juju_leases_overdue",
+		"Number of leases past their expiry time that have not been expired.",
+		[]string{"model", "type"},
+		prometheus.Labels{},
+	)
+	jujuLeaseClaimsTotalDesc = prometheus.NewDesc(
+		"juju_lease_claims_total",
+		"Total number of lease claims handled, by result.",
+		[]string{"model", "type", "result"},
+		prometheus.Labels{},
+	)
+	jujuLeaseExpiriesTotalDesc = prometheus.NewDesc(
+		"juju_lease_expiries_total",
+		"Total number of leases expired.",
+		[]string{"model", "type"},
+		prometheus.Labels{},
+	)
+)
+
+// Reporter provides the reports of lease managers, keyed by model UUID
+// and then by lease type. *state.StatePool implements Reporter.
+type Reporter interface {
+	LeaseReport() map[string]interface{}
+}
+
+// LeaseMetrics is a prometheus.Collector that collects lease counts,
+// claims and expiries from the reports of lease managers.
+type LeaseMetrics struct {
+	Reporter
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (LeaseMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jujuLeasesDesc
+	ch <- jujuLeasesOverdueDesc
+	ch <- jujuLeaseClaimsTotalDesc
+	ch <- jujuLeaseExpiriesTotalDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m LeaseMetrics) Collect(ch chan<- prometheus.Metric) {
+	models := m.LeaseReport()
+	for _, model := range sortedKeys(models) {
+		types, _ := models[model].(map[string]interface{})
+		for _, leaseType := range sortedKeys(types) {
+			report, ok := types[leaseType].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			leases, _ := report[lease.KeyLeases].(map[string]interface{})
+			overdue, _ := report[lease.KeyOverdue].(int)
+			granted, _ := report[lease.KeyClaimsGranted].(int)
+			rejected, _ := report[lease.KeyClaimsRejected].(int)
+			expired, _ := report[lease.KeyExpired].(int)
+			ch <- prometheus.MustNewConstMetric(
+				jujuLeasesDesc,
+				prometheus.GaugeValue,
+				float64(len(leases)),
+				model, leaseType,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				jujuLeasesOverdueDesc,
+				prometheus.GaugeValue,
+				float64(overdue),
+				model, leaseType,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				jujuLeaseClaimsTotalDesc,
+				prometheus.CounterValue,
+				float64(granted),
+				model, leaseType, "granted",
+			)
+			ch <- prometheus.MustNewConstMetric(
+				jujuLeaseClaimsTotalDesc,
+				prometheus.CounterValue,
+				float64(rejected),
+				model, leaseType, "rejected",
+			)
+			ch <- prometheus.MustNewConstMetric(
+				jujuLeaseExpiriesTotalDesc,
+				prometheus.CounterValue,
+				float64(expired),
+				model, leaseType,
+			)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
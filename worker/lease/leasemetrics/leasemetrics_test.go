@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leasemetrics_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/lease"
+	"github.com/juju/juju/worker/lease/leasemetrics"
+)
+
+type leaseMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&leaseMetricsSuite{})
+
+type fakeReporter map[string]interface{}
+
+func (r fakeReporter) LeaseReport() map[string]interface{} {
+	return r
+}
+
+func (s *leaseMetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		leasemetrics.LeaseMetrics{fakeReporter{}}.Describe(ch)
+	}()
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	c.Assert(descs, gc.HasLen, 4)
+	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_leases".*`)
+	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_leases_overdue".*`)
+	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_lease_claims_total".*`)
+	c.Assert(descs[3].String(), gc.Matches, `.*fqName: "juju_lease_expiries_total".*`)
+}
+
+func (s *leaseMetricsSuite) TestCollect(c *gc.C) {
+	reporter := fakeReporter{
+		"uuid": map[string]interface{}{
+			"leadership": map[string]interface{}{
+				lease.KeyLeases: map[string]interface{}{
+					"mysql": map[string]interface{}{
+						lease.KeyHolder: "mysql/0",
+					},
+				},
+				lease.KeyOverdue:        1,
+				lease.KeyClaimsGranted:  3,
+				lease.KeyClaimsRejected: 2,
+				lease.KeyExpired:        4,
+			},
+		},
+	}
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		leasemetrics.LeaseMetrics{reporter}.Collect(ch)
+	}()
+
+	var metrics []dto.Metric
+	for metric := range ch {
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		metrics = append(metrics, m)
+	}
+
+	float64ptr := func(v float64) *float64 {
+		return &v
+	}
+	stringptr := func(v string) *string {
+		return &v
+	}
+	labels := func(result string) []*dto.LabelPair {
+		pairs := []*dto.LabelPair{{
+			Name:  stringptr("model"),
+			Value: stringptr("uuid"),
+		}}
+		if result != "" {
+			pairs = append(pairs, &dto.LabelPair{
+				Name:  stringptr("result"),
+				Value: stringptr(result),
+			})
+		}
+		return append(pairs, &dto.LabelPair{
+			Name:  stringptr("type"),
+			Value: stringptr("leadership"),
+		})
+	}
+	c.Assert(metrics, jc.DeepEquals, []dto.Metric{{
+		Label: labels(""),
+		Gauge: &dto.Gauge{Value: float64ptr(1)},
+	}, {
+		Label: labels(""),
+		Gauge: &dto.Gauge{Value: float64ptr(1)},
+	}, {
+		Label:   labels("granted"),
+		Counter: &dto.Counter{Value: float64ptr(3)},
+	}, {
+		Label:   labels("rejected"),
+		Counter: &dto.Counter{Value: float64ptr(2)},
+	}, {
+		Label:   labels(""),
+		Counter: &dto.Counter{Value: float64ptr(4)},
+	}})
+}
@@ -6,6 +6,7 @@ package lease
 import (
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -34,6 +35,7 @@ func NewDeadManager(err error) *Manager {
 	m := Manager{
 		config: ManagerConfig{
 			Secretary: dummySecretary{},
+			Clock:     clock.WallClock,
 		},
 	}
 	catacomb.Invoke(catacomb.Plan{
@@ -92,6 +94,19 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// mu protects the fields below, which are updated by the loop and
+	// read by Report.
+	mu sync.Mutex
+
+	// leases holds the leases most recently seen by the loop.
+	leases map[string]lease.Info
+
+	// claimsGranted, claimsRejected and expired count the claims
+	// handled, and the leases expired, by the manager.
+	claimsGranted  int
+	claimsRejected int
+	expired        int
 }
 
 // Kill is part of the worker.Worker interface.
@@ -107,12 +122,14 @@ func (manager *Manager) Wait() error {
 // loop runs until the manager is stopped.
 func (manager *Manager) loop() error {
 	blocks := make(blocks)
+	manager.recordLeases(manager.config.Client.Leases())
 	for {
 		if err := manager.choose(blocks); err != nil {
 			return errors.Trace(err)
 		}
 
 		leases := manager.config.Client.Leases()
+		manager.recordLeases(leases)
 		for leaseName := range blocks {
 			if _, found := leases[leaseName]; !found {
 				logger.Tracef("[%s] unblocking: %s", manager.logContext, leaseName)
@@ -189,6 +206,7 @@ func (manager *Manager) handleClaim(claim claim) error {
 				remaining := info.Expiry.Sub(manager.config.Clock.Now())
 				logger.Tracef("[%s] %s asked for lease %s, held by %s for another %s, rejecting",
 					manager.logContext, claim.holderName, claim.leaseName, info.Holder, remaining)
+				manager.recordClaim(false)
 				claim.respond(false)
 				return nil
 			}
@@ -197,6 +215,7 @@ func (manager *Manager) handleClaim(claim claim) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	manager.recordClaim(true)
 	claim.respond(true)
 	return nil
 }
@@ -298,7 +317,9 @@ func (manager *Manager) tick() error {
 			continue
 		}
 		switch err := client.ExpireLease(name); err {
-		case nil, lease.ErrInvalid:
+		case nil:
+			manager.recordExpiry()
+		case lease.ErrInvalid:
 		default:
 			return errors.Trace(err)
 		}
@@ -311,3 +332,90 @@ func (manager *Manager) tick() error {
 	}
 	return nil
 }
+
+// recordLeases stores the leases seen by the loop, for reporting.
+func (manager *Manager) recordLeases(leases map[string]lease.Info) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	manager.leases = leases
+}
+
+// recordClaim counts a claim handled by the loop, for reporting.
+func (manager *Manager) recordClaim(granted bool) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	if granted {
+		manager.claimsGranted++
+	} else {
+		manager.claimsRejected++
+	}
+}
+
+// recordExpiry counts a lease expired by the loop, for reporting.
+func (manager *Manager) recordExpiry() {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	manager.expired++
+}
+
+const (
+	// KeyEntityUUID is the report key for the UUID of the entity
+	// the manager is running for.
+	KeyEntityUUID = "entity-uuid"
+
+	// KeyLeases is the report key for the leases known to the
+	// manager. The value is a map of lease name to lease report.
+	KeyLeases = "leases"
+
+	// KeyHolder, KeyExpiry and KeyExpiresIn are the lease report keys
+	// for the holder of a lease, when it expires, and how long until
+	// it expires. A negative KeyExpiresIn means the lease is overdue
+	// for expiry.
+	KeyHolder    = "holder"
+	KeyExpiry    = "expiry"
+	KeyExpiresIn = "expires-in"
+
+	// KeyOverdue is the report key for the number of leases whose
+	// expiry time has passed but which have not yet been expired.
+	// Leases that remain overdue suggest that the manager is stalled,
+	// or that clocks across the controllers are skewed.
+	KeyOverdue = "overdue"
+
+	// KeyClaimsGranted and KeyClaimsRejected are the report keys for
+	// the number of claims granted and rejected by the manager.
+	KeyClaimsGranted  = "claims-granted"
+	KeyClaimsRejected = "claims-rejected"
+
+	// KeyExpired is the report key for the number of leases expired
+	// by the manager.
+	KeyExpired = "expired"
+)
+
+// Report returns a map describing the leases known to the manager, and
+// the claims and expiries it has handled. It is goroutine-safe.
+func (manager *Manager) Report() map[string]interface{} {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	now := manager.config.Clock.Now()
+	leases := make(map[string]interface{})
+	overdue := 0
+	for name, info := range manager.leases {
+		remaining := info.Expiry.Sub(now)
+		if remaining < 0 {
+			overdue++
+		}
+		leases[name] = map[string]interface{}{
+			KeyHolder:    info.Holder,
+			KeyExpiry:    info.Expiry.UTC().Format(time.RFC3339Nano),
+			KeyExpiresIn: remaining.String(),
+		}
+	}
+	return map[string]interface{}{
+		KeyEntityUUID:     manager.config.EntityUUID,
+		KeyLeases:         leases,
+		KeyOverdue:        overdue,
+		KeyClaimsGranted:  manager.claimsGranted,
+		KeyClaimsRejected: manager.claimsRejected,
+		KeyExpired:        manager.expired,
+	}
+}
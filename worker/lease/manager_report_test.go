@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ReportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReportSuite{})
+
+func (s *ReportSuite) TestReportLeases(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testing.Clock) {
+		clock.Advance(almostSeconds(60))
+		c.Check(manager.Report(), jc.DeepEquals, map[string]interface{}{
+			lease.KeyEntityUUID: "",
+			lease.KeyLeases: map[string]interface{}{
+				"redis": map[string]interface{}{
+					lease.KeyHolder:    "redis/0",
+					lease.KeyExpiry:    offset(time.Minute).UTC().Format(time.RFC3339Nano),
+					lease.KeyExpiresIn: "1ns",
+				},
+			},
+			lease.KeyOverdue:        0,
+			lease.KeyClaimsGranted:  0,
+			lease.KeyClaimsRejected: 0,
+			lease.KeyExpired:        0,
+		})
+	})
+}
+
+func (s *ReportSuite) TestReportClaims(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/1",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args:   []interface{}{"mongodb", corelease.Request{"mongodb/0", time.Minute}},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testing.Clock) {
+		err := manager.Claim("mongodb", "mongodb/0", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+		err = manager.Claim("redis", "redis/0", time.Minute)
+		c.Assert(err, gc.Equals, corelease.ErrClaimDenied)
+
+		report := manager.Report()
+		c.Check(report[lease.KeyClaimsGranted], gc.Equals, 1)
+		c.Check(report[lease.KeyClaimsRejected], gc.Equals, 1)
+	})
+}
+
+func (s *ReportSuite) TestReportExpiries(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(-time.Second)},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{"redis"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "redis")
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testing.Clock) {
+		// Wait for the manager to sleep again after expiring.
+		waitAlarms(c, clock, 1)
+		report := manager.Report()
+		c.Check(report[lease.KeyExpired], gc.Equals, 1)
+		c.Check(report[lease.KeyOverdue], gc.Equals, 0)
+		c.Check(report[lease.KeyLeases], gc.DeepEquals, map[string]interface{}{})
+	})
+}
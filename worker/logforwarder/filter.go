@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"path"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// recordFilter selects the log records to forward, by matching glob
+// patterns against the entity and module of each record.
+type recordFilter struct {
+	include []string
+	exclude []string
+}
+
+// filter returns the records which should be forwarded.
+func (f recordFilter) filter(records []logfwd.Record) []logfwd.Record {
+	if len(f.include) == 0 && len(f.exclude) == 0 {
+		return records
+	}
+	var result []logfwd.Record
+	for _, rec := range records {
+		if f.allows(rec) {
+			result = append(result, rec)
+		}
+	}
+	return result
+}
+
+// allows reports whether the record should be forwarded.
+func (f recordFilter) allows(rec logfwd.Record) bool {
+	entity, module := rec.Origin.Entity(), rec.Location.Module
+	if len(f.include) > 0 && !matchAny(f.include, entity, module) {
+		return false
+	}
+	return !matchAny(f.exclude, entity, module)
+}
+
+// matchAny reports whether any of the non-empty values matches any of
+// the patterns.
+func matchAny(patterns []string, values ...string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
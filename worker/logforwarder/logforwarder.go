@@ -6,19 +6,34 @@ package logforwarder
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/catacomb"
 )
 
 var logger = loggo.GetLogger("juju.worker.logforwarder")
 
+const (
+	// initialRetryDelay is how long the forwarder waits before
+	// retrying a failed send. The delay doubles with each consecutive
+	// failure, up to maxRetryDelay.
+	initialRetryDelay = time.Second
+	maxRetryDelay     = time.Minute
+
+	// maxSendAttempts is how many times the forwarder tries to send
+	// a batch of records before giving up, and restarting.
+	maxSendAttempts = 10
+)
+
 // LogStream streams log entries from a log source (e.g. the Juju controller).
 type LogStream interface {
 	// Next returns the next batch of log records from the stream.
@@ -51,6 +66,10 @@ type LogForwarder struct {
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+
+	// cfg holds the most recent valid config, from which the sink
+	// is reopened after a failed send.
+	cfg *syslog.RawConfig
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -74,6 +93,10 @@ type OpenLogForwarderArgs struct {
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
+
+	// Clock is used to schedule the retry of failed sends. If it is
+	// nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // processNewConfig acts on a new syslog forward config change.
@@ -83,6 +106,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 
 	closeExisting := func() error {
 		lf.enabled = false
+		lf.cfg = nil
 		// If we are already sending, close the current sender.
 		if currentSender != nil {
 			return currentSender.Close()
@@ -113,6 +137,17 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	sink, err := lf.openSink(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lf.cfg = cfg
+	lf.enabledCh <- true
+	return sink, nil
+}
+
+// openSink opens a tracking sink for the given config.
+func (lf *LogForwarder) openSink(cfg *syslog.RawConfig) (SendCloser, error) {
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
 		Name:     lf.args.Name,
 		Config:   cfg,
@@ -122,10 +157,41 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	lf.enabledCh <- true
 	return sink, nil
 }
 
+// reopenSender closes the current sender, whose connection may be
+// broken, and opens a new one from the most recent config. It returns
+// a nil sender if log forwarding has since been disabled.
+func (lf *LogForwarder) reopenSender(current SendCloser) (SendCloser, error) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+
+	if current != nil {
+		if err := current.Close(); err != nil {
+			logger.Debugf("closing log sink: %v", err)
+		}
+	}
+	if lf.cfg == nil {
+		return nil, nil
+	}
+	sink, err := lf.openSink(lf.cfg)
+	return sink, errors.Trace(err)
+}
+
+// retryDelay returns how long to wait before the next attempt to send,
+// after the given number of consecutive failures.
+func retryDelay(failures int) time.Duration {
+	delay := initialRetryDelay
+	for i := 1; i < failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
 // waitForEnabled returns true if streaming is enabled.
 // Otherwise if blocks and waits for enabled to be true.
 func (lf *LogForwarder) waitForEnabled() (bool, error) {
@@ -154,6 +220,9 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 // NewLogForwarder returns a worker that forwards logs received from
 // the stream to the sender.
 func NewLogForwarder(args OpenLogForwarderArgs) (*LogForwarder, error) {
+	if args.Clock == nil {
+		args.Clock = clock.WallClock
+	}
 	lf := &LogForwarder{
 		args:      args,
 		enabledCh: make(chan bool, 1),
@@ -217,7 +286,15 @@ func (lf *LogForwarder) loop() error {
 		}
 	}()
 
-	var sender SendCloser
+	var (
+		sender SendCloser
+
+		// pending holds records whose send failed, and which will
+		// be sent again when retry fires.
+		pending  []logfwd.Record
+		failures int
+		retry    <-chan time.Time
+	)
 	defer func() {
 		if sender != nil {
 			sender.Close()
@@ -225,6 +302,15 @@ func (lf *LogForwarder) loop() error {
 	}()
 
 	for {
+		// While a failed send is pending, no more records are taken
+		// from the stream, so the stream is held back until the sink
+		// recovers rather than records being dropped or buffered
+		// without limit.
+		incoming := records
+		if pending != nil {
+			incoming = nil
+		}
+		var sendErr error
 		select {
 		case <-lf.catacomb.Dying():
 			return lf.catacomb.ErrDying()
@@ -235,14 +321,36 @@ func (lf *LogForwarder) loop() error {
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
 			}
-		case rec := <-records:
+			continue
+		case rec := <-incoming:
 			if sender == nil {
 				continue
 			}
-			if err := sender.Send(rec); err != nil {
-				return errors.Trace(err)
+			pending = rec
+			sendErr = sender.Send(pending)
+		case <-retry:
+			retry = nil
+			sender, sendErr = lf.reopenSender(sender)
+			if sendErr == nil {
+				if sender == nil {
+					// Log forwarding has been disabled.
+					pending, failures = nil, 0
+					continue
+				}
+				sendErr = sender.Send(pending)
 			}
 		}
+		if sendErr == nil {
+			pending, failures = nil, 0
+			continue
+		}
+		failures++
+		if failures >= maxSendAttempts {
+			return errors.Annotatef(sendErr, "sending log records failed %d times", failures)
+		}
+		delay := retryDelay(failures)
+		logger.Warningf("cannot send log records, retrying in %v: %v", delay, sendErr)
+		retry = lf.args.Clock.After(delay)
 	}
 }
 
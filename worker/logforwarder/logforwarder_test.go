@@ -28,6 +28,7 @@ type LogForwarderSuite struct {
 
 	stream *stubStream
 	sender *stubSender
+	clock  *testing.Clock
 	rec    logfwd.Record
 }
 
@@ -38,6 +39,7 @@ func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
 
 	s.stream = newStubStream()
 	s.sender = newStubSender()
+	s.clock = testing.NewClock(time.Now())
 	s.rec = logfwd.Record{
 		Origin: logfwd.Origin{
 			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
@@ -83,6 +85,7 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 ) logforwarder.OpenLogForwarderArgs {
 	return logforwarder.OpenLogForwarderArgs{
 		Caller:           &mockCaller{},
+		Clock:            s.clock,
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
 		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
@@ -183,32 +186,106 @@ func (s *LogForwarderSuite) TestStreamError(c *gc.C) {
 	})
 }
 
-func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
+func (s *LogForwarderSuite) TestSenderErrorRetries(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.sender.stub.SetErrors(nil, failure)
 
 	rec0 := s.rec
 	rec1 := s.rec
 	rec1.ID = 11
-	s.stream.addRecords(c, rec0, rec1)
+	rec2 := s.rec
+	rec2.ID = 12
+	s.stream.addRecords(c, rec0, rec1, rec2)
 
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, s.stream, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
 	defer workertest.DirtyKill(c, lf)
 
-	err = workertest.CheckKilled(c, lf)
-	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.sender.waitForSend(c)
+	s.sender.waitForSend(c)
 
+	// The failed send is retried, with a new sink, after a delay;
+	// no more records are sent until it succeeds.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.sender.waitForClose(c)
+	s.sender.waitForSend(c)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
 	s.sender.stub.CheckCalls(c, []testing.StubCall{
 		{"Send", []interface{}{[]logfwd.Record{rec0}}},
 		{"Send", []interface{}{[]logfwd.Record{rec1}}},
 		{"Close", nil},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Send", []interface{}{[]logfwd.Record{rec2}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSenderErrorGivesUp(c *gc.C) {
+	failure := errors.New("<failure>")
+	// Every send fails; each retry closes the previous sink first.
+	errs := []error{failure}
+	for i := 1; i < 10; i++ {
+		errs = append(errs, nil, failure)
+	}
+	s.sender.stub.SetErrors(errs...)
+	s.stream.addRecords(c, s.rec)
+
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	for i := 1; i < 10; i++ {
+		err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		s.sender.waitForClose(c)
+		s.sender.waitForSend(c)
+	}
+
+	err = workertest.CheckKilled(c, lf)
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(err, gc.ErrorMatches, "sending log records failed 10 times: <failure>")
+}
+
+func (s *LogForwarderSuite) TestFilters(c *gc.C) {
+	unitRec := s.rec
+	unitRec.ID = 11
+	unitRec.Origin.Type = logfwd.OriginTypeUnit
+	unitRec.Origin.Name = "mysql/0"
+	uniterRec := unitRec
+	uniterRec.ID = 12
+	uniterRec.Location.Module = "juju.worker.uniter"
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+		include: []string{"unit-mysql-*"},
+		exclude: []string{"juju.worker.uniter"},
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	// Only the unit's record not from the uniter is forwarded.
+	s.stream.addRecords(c, s.rec, uniterRec, unitRec)
+	s.sender.waitForSend(c)
+
+	workertest.CleanKill(c, lf)
+	unitRec.Message = "send to 10.0.0.1"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{unitRec}}},
+		{"Close", nil},
 	})
 }
 
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
+	include []string
+	exclude []string
 	changes chan struct{}
 }
 
@@ -255,6 +332,8 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 		CACert:     coretesting.CACert,
 		ClientCert: coretesting.ServerCert,
 		ClientKey:  coretesting.ServerKey,
+		Include:    c.include,
+		Exclude:    c.exclude,
 	}, true, nil
 }
 
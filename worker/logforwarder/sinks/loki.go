@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenLoki returns a sink which forwards log messages to a Loki push
+// endpoint.
+func OpenLoki(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	tlsConfig, err := cfg.HTTPTLSConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := loki.Open(loki.Config{
+		URL:       cfg.URL,
+		TLSConfig: tlsConfig,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
+
+// Open returns a sink of the kind described by the config.
+func Open(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+	switch cfg.Type {
+	case "", syslog.TypeSyslog:
+		return OpenSyslog(cfg)
+	case syslog.TypeLoki:
+		return OpenLoki(cfg)
+	}
+	return nil, errors.NotValidf("log forwarding type %q", cfg.Type)
+}
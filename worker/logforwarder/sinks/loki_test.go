@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type OpenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&OpenSuite{})
+
+func (s *OpenSuite) TestOpenLoki(c *gc.C) {
+	sink, err := sinks.Open(&syslog.RawConfig{
+		Enabled: true,
+		Type:    syslog.TypeLoki,
+		URL:     "https://loki.example.com/loki/api/v1/push",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.SendCloser, gc.FitsTypeOf, &loki.Client{})
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *OpenSuite) TestOpenLokiNotEnabled(c *gc.C) {
+	_, err := sinks.Open(&syslog.RawConfig{
+		Type: syslog.TypeLoki,
+		URL:  "https://loki.example.com/loki/api/v1/push",
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *OpenSuite) TestOpenUnknownType(c *gc.C) {
+	_, err := sinks.Open(&syslog.RawConfig{
+		Enabled: true,
+		Type:    "carrier-pigeon",
+	})
+	c.Assert(err, gc.ErrorMatches, `log forwarding type "carrier-pigeon" not valid`)
+}
@@ -30,7 +30,8 @@ type TrackingSinkArgs struct {
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
-// The sender also tracks records that were successfully sent.
+// The sender also tracks records that were successfully sent, and
+// filters out records as directed by the config.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	sink, err := args.OpenSink(args.Config)
 	if err != nil {
@@ -41,6 +42,10 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 		&trackingSender{
 			SendCloser: sink,
 			tracker:    newLastSentTracker(args.Name, args.Caller),
+			filter: recordFilter{
+				include: args.Config.Include,
+				exclude: args.Config.Exclude,
+			},
 		},
 	}, nil
 }
@@ -48,12 +53,16 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 type trackingSender struct {
 	SendCloser
 	tracker *lastSentTracker
+	filter  recordFilter
 }
 
-// Send implements Sender.
+// Send implements Sender. Records excluded by the config's filters are
+// not sent, but are still tracked, so that they are not streamed again.
 func (s *trackingSender) Send(records []logfwd.Record) error {
-	if err := s.SendCloser.Send(records); err != nil {
-		return errors.Trace(err)
+	if forward := s.filter.filter(records); len(forward) > 0 {
+		if err := s.SendCloser.Send(forward); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if err := s.tracker.setLastSent(records); err != nil {
 		return errors.Trace(err)
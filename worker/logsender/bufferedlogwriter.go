@@ -12,6 +12,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/deque"
+
+	"github.com/juju/juju/core/trace"
 )
 
 // LogRecord represents a log message in an agent which is to be
@@ -23,6 +25,11 @@ type LogRecord struct {
 	Level    loggo.Level
 	Message  string
 
+	// Labels are derived from the module, and CorrelationID from
+	// the trace ID with which the message is marked, if any.
+	Labels        []string
+	CorrelationID string
+
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
 }
@@ -153,6 +160,9 @@ func (w *BufferedLogWriter) Write(entry loggo.Entry) {
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
 		Message:  entry.Message,
+
+		Labels:        labelsForModule(entry.Module),
+		CorrelationID: trace.IDFromMessage(entry.Message),
 	}
 }
 
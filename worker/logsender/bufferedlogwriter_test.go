@@ -77,6 +77,35 @@ func (s *bufferedLogWriterSuite) TestBuffering(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestStructuredFields(c *gc.C) {
+	now := time.Now()
+	s.writer.Write(
+		loggo.Entry{
+			Level:     loggo.DEBUG,
+			Module:    "juju.apiserver.observer",
+			Filename:  "request.go",
+			Line:      12,
+			Timestamp: now,
+			Message:   `-> [1] user-admin {"request-id":1,"trace-id":"abcd"}`,
+		})
+	s.writer.Write(
+		loggo.Entry{
+			Level:     loggo.INFO,
+			Module:    "unit.mysql/0.juju-log",
+			Filename:  "juju-log.go",
+			Line:      60,
+			Timestamp: now,
+			Message:   "hello",
+		})
+
+	rec := s.receiveOne(c)
+	c.Check(rec.Labels, jc.DeepEquals, []string{"api"})
+	c.Check(rec.CorrelationID, gc.Equals, "abcd")
+	rec = s.receiveOne(c)
+	c.Check(rec.Labels, jc.DeepEquals, []string{"charm"})
+	c.Check(rec.CorrelationID, gc.Equals, "")
+}
+
 func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	write := func(msgNum int) {
 		s.writer.Write(
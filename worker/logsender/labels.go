@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsender
+
+import (
+	"strings"
+)
+
+// moduleLabels holds the labels attached to log messages from each
+// logging module. A module's messages also carry the labels of its
+// parent modules.
+var moduleLabels = map[string][]string{
+	// Hook output and juju-log messages are logged to modules
+	// named "unit.<unit>.<hook>".
+	"unit":               {"charm"},
+	"juju.worker.uniter": {"charm"},
+	"juju.apiserver":     {"api"},
+	"juju.rpc":           {"api"},
+}
+
+// labelsForModule returns the labels for the named logging module,
+// or nil if it has none.
+func labelsForModule(module string) []string {
+	var labels []string
+	for {
+		for _, label := range moduleLabels[module] {
+			if !containsString(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		i := strings.LastIndex(module, ".")
+		if i < 0 {
+			return labels
+		}
+		module = module[:i]
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,10 @@ import (
 
 const loggerName = "juju.worker.logsender"
 
+// maxBatchSize is the maximum number of log records sent to the
+// controller at once.
+const maxBatchSize = 500
+
 // New starts a logsender worker which reads log message structs from
 // a channel and sends them to the JES via the logsink API.
 func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
@@ -27,10 +31,10 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 		// the logsender worker from shutting down, and causes the entire
 		// agent to get wedged. To mitigate this, we get the LogWriter in a
 		// different goroutine allowing the worker to interrupt this.
-		sender := make(chan logsender.LogWriter)
+		sender := make(chan logsender.BatchLogWriter)
 		errChan := make(chan error)
 		go func() {
-			logWriter, err := logSenderAPI.LogWriter()
+			logWriter, err := newLogWriter(logSenderAPI)
 			if err != nil {
 				select {
 				case errChan <- err:
@@ -45,7 +49,7 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 			}
 			return
 		}()
-		var logWriter logsender.LogWriter
+		var logWriter logsender.BatchLogWriter
 		var err error
 		select {
 		case logWriter = <-sender:
@@ -58,42 +62,21 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 		for {
 			select {
 			case rec := <-logs:
-				err := logWriter.WriteLog(&params.LogRecord{
-					Time:     rec.Time,
-					Module:   rec.Module,
-					Location: rec.Location,
-					Level:    rec.Level.String(),
-					Message:  rec.Message,
-				})
-				if err != nil {
-					return errors.Trace(err)
-				}
-				if rec.DroppedAfter > 0 {
-					// If messages were dropped after this one, report
-					// the count (the source of the log messages -
-					// BufferedLogWriter - handles the actual dropping
-					// and counting).
-					//
-					// Any logs indicated as dropped here are will
-					// never end up in the logs DB in the JES
-					// (although will still be in the local agent log
-					// file). Message dropping by the
-					// BufferedLogWriter is last resort protection
-					// against memory exhaustion and should only
-					// happen if API connectivity is lost for extended
-					// periods. The maximum in-memory log buffer is
-					// quite large (see the InstallBufferedLogWriter
-					// call in jujuDMain).
-					err := logWriter.WriteLog(&params.LogRecord{
-						Time:    rec.Time,
-						Module:  loggerName,
-						Level:   loggo.WARNING.String(),
-						Message: fmt.Sprintf("%d log messages dropped due to lack of API connectivity", rec.DroppedAfter),
-					})
-					if err != nil {
-						return errors.Trace(err)
+				// Send any other records that are already waiting
+				// along with this one.
+				batch := appendLogRecord(nil, rec)
+			gather:
+				for len(batch) < maxBatchSize {
+					select {
+					case rec := <-logs:
+						batch = appendLogRecord(batch, rec)
+					default:
+						break gather
 					}
 				}
+				if err := logWriter.WriteLogs(batch); err != nil {
+					return errors.Trace(err)
+				}
 
 			case <-stop:
 				return nil
@@ -102,3 +85,75 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 	}
 	return jworker.NewSimpleWorker(loop)
 }
+
+// newLogWriter returns a writer that sends batches of log records to
+// the controller, falling back to sending them one at a time if the
+// controller does not support batches.
+func newLogWriter(logSenderAPI *logsender.API) (logsender.BatchLogWriter, error) {
+	batchWriter, err := logSenderAPI.BatchLogWriter()
+	if err == nil {
+		return batchWriter, nil
+	} else if !errors.IsNotSupported(err) {
+		return nil, errors.Trace(err)
+	}
+	logWriter, err := logSenderAPI.LogWriter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return singleLogWriter{logWriter}, nil
+}
+
+// singleLogWriter adapts a logsender.LogWriter to the
+// logsender.BatchLogWriter interface.
+type singleLogWriter struct {
+	logsender.LogWriter
+}
+
+// WriteLogs is part of the logsender.BatchLogWriter interface.
+func (w singleLogWriter) WriteLogs(records []*params.LogRecord) error {
+	for _, record := range records {
+		if err := w.WriteLog(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// appendLogRecord appends the API form of the log record to the
+// batch, followed by a warning if messages were dropped after it.
+func appendLogRecord(batch []*params.LogRecord, rec *LogRecord) []*params.LogRecord {
+	batch = append(batch, &params.LogRecord{
+		Time:     rec.Time,
+		Module:   rec.Module,
+		Location: rec.Location,
+		Level:    rec.Level.String(),
+		Message:  rec.Message,
+
+		Labels:        rec.Labels,
+		CorrelationID: rec.CorrelationID,
+	})
+	if rec.DroppedAfter > 0 {
+		// If messages were dropped after this one, report
+		// the count (the source of the log messages -
+		// BufferedLogWriter - handles the actual dropping
+		// and counting).
+		//
+		// Any logs indicated as dropped here are will
+		// never end up in the logs DB in the JES
+		// (although will still be in the local agent log
+		// file). Message dropping by the
+		// BufferedLogWriter is last resort protection
+		// against memory exhaustion and should only
+		// happen if API connectivity is lost for extended
+		// periods. The maximum in-memory log buffer is
+		// quite large (see the InstallBufferedLogWriter
+		// call in jujuDMain).
+		batch = append(batch, &params.LogRecord{
+			Time:    rec.Time,
+			Module:  loggerName,
+			Level:   loggo.WARNING.String(),
+			Message: fmt.Sprintf("%d log messages dropped due to lack of API connectivity", rec.DroppedAfter),
+		})
+	}
+	return batch
+}
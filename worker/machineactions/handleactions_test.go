@@ -5,6 +5,11 @@
 package machineactions_test
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
 	"strings"
 
 	"github.com/juju/errors"
@@ -12,6 +17,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/exec"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/machineactions"
@@ -78,3 +84,73 @@ func (s *HandleSuite) TestErrorRun(c *gc.C) {
 	c.Assert(results["Stdout"], gc.Equals, "")
 	c.Assert(results["Stderr"], gc.Equals, "")
 }
+
+func (s *HandleSuite) serveIntrospection(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("abstract domain sockets are only supported on linux")
+	}
+	socketName := fmt.Sprintf("machineactions-test-%d", os.Getpid())
+	s.PatchValue(&machineactions.IntrospectionSocketName, func(tag names.Tag) string {
+		return socketName + "-" + tag.String()
+	})
+	listener, err := net.Listen("unix", "@"+socketName+"-machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "engine report")
+	})
+	go http.Serve(listener, mux)
+}
+
+func (s *HandleSuite) TestIntrospect(c *gc.C) {
+	s.serveIntrospection(c)
+	params := map[string]interface{}{
+		"agent": "machine-0",
+		"path":  "/depengine",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{
+		"Code":   "200",
+		"Stdout": "engine report",
+	})
+}
+
+func (s *HandleSuite) TestIntrospectNotFound(c *gc.C) {
+	s.serveIntrospection(c)
+	params := map[string]interface{}{
+		"agent": "machine-0",
+		"path":  "missing",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results["Code"], gc.Equals, "404")
+	c.Assert(results["Stderr"], gc.Equals, "404 page not found\n")
+}
+
+func (s *HandleSuite) TestIntrospectNoSocket(c *gc.C) {
+	s.serveIntrospection(c)
+	params := map[string]interface{}{
+		"agent": "unit-mysql-0",
+		"path":  "depengine",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, `querying unit-mysql-0 introspection socket: .*`)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *HandleSuite) TestIntrospectInvalidAgent(c *gc.C) {
+	params := map[string]interface{}{
+		"agent": "application-mysql",
+		"path":  "depengine",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, `agent "application-mysql" not valid`)
+	c.Assert(results, gc.IsNil)
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineactions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// IntrospectionSocketName returns the name of the abstract domain
+// socket that the introspection worker of the agent with the given
+// tag serves requests over. It must match the name used by jujud.
+var IntrospectionSocketName = func(tag names.Tag) string {
+	return "jujud-" + tag.String()
+}
+
+// introspectTimeout bounds how long a single introspection query may
+// take. CPU profiles block for 30 seconds by default, so this needs
+// to be comfortably longer than that.
+var introspectTimeout = 2 * time.Minute
+
+func handleJujuIntrospectAction(params map[string]interface{}) (results map[string]interface{}, err error) {
+	// The spec checks that the parameters are available so we don't need to check again here
+	agent, _ := params["agent"].(string)
+	path, _ := params["path"].(string)
+
+	tag, err := names.ParseTag(agent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag.Kind() {
+	case names.MachineTagKind, names.UnitTagKind:
+	default:
+		return nil, errors.NotValidf("agent %q", agent)
+	}
+	targetURL, err := url.Parse("http://unix.socket/" + strings.TrimLeft(path, "/"))
+	if err != nil || targetURL.Host != "unix.socket" {
+		return nil, errors.NotValidf("introspection path %q", path)
+	}
+
+	socketName := "@" + IntrospectionSocketName(tag)
+	logger.Tracef("querying %s introspection socket: %s", socketName, targetURL.Path)
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", socketName)
+			},
+		},
+		Timeout: introspectTimeout,
+	}
+	resp, err := client.Get(targetURL.String())
+	if err != nil {
+		return nil, errors.Annotatef(err, "querying %s introspection socket", tag)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading introspection response")
+	}
+
+	actionResults := map[string]interface{}{}
+	actionResults["Code"] = fmt.Sprintf("%d", resp.StatusCode)
+	if resp.StatusCode == http.StatusOK {
+		storeOutput(actionResults, "Stdout", body)
+	} else {
+		storeOutput(actionResults, "Stderr", body)
+	}
+	return actionResults, nil
+}
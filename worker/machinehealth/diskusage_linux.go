@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// GetDiskUsage returns the usage of the filesystem containing path.
+func GetDiskUsage(path string) (DiskUsage, error) {
+	var statfs syscall.Statfs_t
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return DiskUsage{}, errors.Annotatef(err, "cannot stat filesystem of %q", path)
+	}
+	return DiskUsage{
+		Blocks:     statfs.Blocks,
+		FreeBlocks: statfs.Bavail,
+		Inodes:     statfs.Files,
+		FreeInodes: statfs.Ffree,
+	}, nil
+}
@@ -0,0 +1,16 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package machinehealth
+
+import (
+	"github.com/juju/errors"
+)
+
+// GetDiskUsage returns the usage of the filesystem containing path.
+// It is only supported on linux.
+func GetDiskUsage(path string) (DiskUsage, error) {
+	return DiskUsage{}, errors.NotSupportedf("checking disk usage")
+}
@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinehealth provides a worker which regularly checks the
+// health of the machine it runs on -- root disk usage, inode usage and
+// clock skew relative to the controller -- and reports any problems
+// found so that they are reflected in the machine's status.
+package machinehealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.machinehealth")
+
+const (
+	// DefaultInterval is how often the machine's health is checked.
+	DefaultInterval = 5 * time.Minute
+
+	// DefaultDiskThreshold is the percentage of the root disk which
+	// may be used before the machine is considered unhealthy.
+	DefaultDiskThreshold = 90
+
+	// DefaultInodeThreshold is the percentage of the root disk's
+	// inodes which may be used before the machine is considered
+	// unhealthy.
+	DefaultInodeThreshold = 90
+
+	// DefaultMaxClockSkew is how far the machine's clock may drift
+	// from the controller's before the machine is considered
+	// unhealthy.
+	DefaultMaxClockSkew = time.Minute
+)
+
+// DiskUsage describes the space and inodes used on a filesystem.
+type DiskUsage struct {
+	// Blocks and FreeBlocks hold the total number of blocks in the
+	// filesystem, and the number available to unprivileged users.
+	Blocks     uint64
+	FreeBlocks uint64
+
+	// Inodes and FreeInodes hold the total and free number of inodes
+	// in the filesystem.
+	Inodes     uint64
+	FreeInodes uint64
+}
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	ControllerTime() (time.Time, error)
+	SetHealthProblems(machine names.MachineTag, problems []string) error
+}
+
+// Config defines the parameters of the machinehealth worker.
+type Config struct {
+	Facade   Facade
+	Machine  names.MachineTag
+	Clock    clock.Clock
+	Interval time.Duration
+
+	// Path is a path on the filesystem whose usage is checked.
+	Path string
+
+	// DiskThreshold and InodeThreshold are the percentages of the
+	// filesystem's space and inodes which may be used before the
+	// machine is reported unhealthy.
+	DiskThreshold  int
+	InodeThreshold int
+
+	// MaxClockSkew is the largest difference between the machine's
+	// clock and the controller's that is considered healthy.
+	MaxClockSkew time.Duration
+
+	// DiskUsage returns the usage of the filesystem containing the
+	// supplied path. If it returns a NotSupported error, disk checks
+	// are skipped.
+	DiskUsage func(path string) (DiskUsage, error)
+}
+
+// Validate returns an error if Config cannot drive a machinehealth
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Machine.Id() == "" {
+		return errors.NotValidf("empty Machine")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.Path == "" {
+		return errors.NotValidf("empty Path")
+	}
+	if config.DiskThreshold <= 0 || config.DiskThreshold > 100 {
+		return errors.NotValidf("DiskThreshold %d", config.DiskThreshold)
+	}
+	if config.InodeThreshold <= 0 || config.InodeThreshold > 100 {
+		return errors.NotValidf("InodeThreshold %d", config.InodeThreshold)
+	}
+	if config.MaxClockSkew <= 0 {
+		return errors.NotValidf("non-positive MaxClockSkew")
+	}
+	if config.DiskUsage == nil {
+		return errors.NotValidf("nil DiskUsage")
+	}
+	return nil
+}
+
+// New returns a Worker that checks the machine's health when it
+// starts, and regularly thereafter, reporting any change in the
+// problems it finds to the controller.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &machineHealth{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type machineHealth struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *machineHealth) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *machineHealth) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *machineHealth) loop() error {
+	var reported []string
+	first := true
+	for {
+		problems, err := w.check()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if first || !sameProblems(problems, reported) {
+			if err := w.config.Facade.SetHealthProblems(w.config.Machine, problems); err != nil {
+				return errors.Annotate(err, "reporting health problems")
+			}
+			if len(problems) > 0 {
+				logger.Warningf("machine is unhealthy: %v", problems)
+			} else if !first {
+				logger.Infof("machine is healthy again")
+			}
+			reported = problems
+			first = false
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// check returns the health problems currently affecting the machine.
+func (w *machineHealth) check() ([]string, error) {
+	var problems []string
+	usage, err := w.config.DiskUsage(w.config.Path)
+	switch {
+	case errors.IsNotSupported(err):
+		logger.Debugf("not checking disk usage: %v", err)
+	case err != nil:
+		return nil, errors.Annotate(err, "checking disk usage")
+	default:
+		if percent := percentUsed(usage.Blocks, usage.FreeBlocks); percent >= w.config.DiskThreshold {
+			problems = append(problems, fmt.Sprintf("root disk %d%% full", percent))
+		}
+		if percent := percentUsed(usage.Inodes, usage.FreeInodes); percent >= w.config.InodeThreshold {
+			problems = append(problems, fmt.Sprintf("root disk inodes %d%% used", percent))
+		}
+	}
+
+	skew, err := w.clockSkew()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking clock skew")
+	}
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > w.config.MaxClockSkew {
+		skew -= skew % time.Second
+		problems = append(problems, fmt.Sprintf("clock skewed by %v from controller", skew))
+	}
+	return problems, nil
+}
+
+// clockSkew returns the difference between the local clock and the
+// controller's, compensating for the time taken to ask the controller.
+func (w *machineHealth) clockSkew() (time.Duration, error) {
+	before := w.config.Clock.Now()
+	controllerTime, err := w.config.Facade.ControllerTime()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	after := w.config.Clock.Now()
+	local := before.Add(after.Sub(before) / 2)
+	return local.Sub(controllerTime), nil
+}
+
+// percentUsed returns the percentage of total that is not free.
+func percentUsed(total, free uint64) int {
+	if total == 0 || free > total {
+		return 0
+	}
+	return int((total - free) * 100 / total)
+}
+
+func sameProblems(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinehealth"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	clock  *jujutesting.Clock
+	facade *stubFacade
+	usage  machinehealth.DiskUsage
+	config machinehealth.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = jujutesting.NewClock(now)
+	s.facade = &stubFacade{
+		Stub:           &jujutesting.Stub{},
+		controllerTime: now,
+		sent:           make(chan []string, 10),
+	}
+	s.usage = machinehealth.DiskUsage{
+		Blocks:     1000,
+		FreeBlocks: 500,
+		Inodes:     1000,
+		FreeInodes: 500,
+	}
+	s.config = machinehealth.Config{
+		Facade:         s.facade,
+		Machine:        names.NewMachineTag("0"),
+		Clock:          s.clock,
+		Interval:       time.Minute,
+		Path:           "/",
+		DiskThreshold:  90,
+		InodeThreshold: 90,
+		MaxClockSkew:   time.Minute,
+		DiskUsage: func(path string) (machinehealth.DiskUsage, error) {
+			s.facade.AddCall("DiskUsage", path)
+			return s.usage, s.facade.NextErr()
+		},
+	}
+}
+
+func (s *Suite) waitSent(c *gc.C) []string {
+	select {
+	case problems := <-s.facade.sent:
+		return problems
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health problems")
+	}
+	panic("unreachable")
+}
+
+func (s *Suite) startWorker(c *gc.C) {
+	w, err := machinehealth.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.DiskThreshold = 101
+	_, err := machinehealth.New(s.config)
+	c.Check(err, gc.ErrorMatches, "DiskThreshold 101 not valid")
+
+	s.config.DiskThreshold = 90
+	s.config.DiskUsage = nil
+	_, err = machinehealth.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil DiskUsage not valid")
+}
+
+func (s *Suite) TestReportsHealthy(c *gc.C) {
+	s.startWorker(c)
+	c.Check(s.waitSent(c), gc.HasLen, 0)
+	s.facade.CheckCall(c, 0, "DiskUsage", "/")
+	s.facade.CheckCall(c, 2, "SetHealthProblems", names.NewMachineTag("0"), []string(nil))
+}
+
+func (s *Suite) TestReportsProblems(c *gc.C) {
+	s.usage.FreeBlocks = 50
+	s.usage.FreeInodes = 20
+	s.facade.controllerTime = s.clock.Now().Add(-2 * time.Minute)
+	s.startWorker(c)
+	c.Check(s.waitSent(c), jc.DeepEquals, []string{
+		"root disk 95% full",
+		"root disk inodes 98% used",
+		"clock skewed by 2m0s from controller",
+	})
+}
+
+func (s *Suite) TestReportsOnlyChanges(c *gc.C) {
+	s.startWorker(c)
+	s.waitSent(c)
+
+	// Nothing has changed, so nothing is reported.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case problems := <-s.facade.sent:
+		c.Fatalf("unexpected report: %v", problems)
+	default:
+	}
+}
+
+func (s *Suite) TestDiskUsageNotSupported(c *gc.C) {
+	s.facade.SetErrors(errors.NotSupportedf("checking disk usage"))
+	s.startWorker(c)
+	c.Check(s.waitSent(c), gc.HasLen, 0)
+}
+
+func (s *Suite) TestDiskUsageError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := machinehealth.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "checking disk usage: boom")
+}
+
+func (s *Suite) TestSetHealthProblemsError(c *gc.C) {
+	s.facade.SetErrors(nil, nil, errors.New("boom"))
+	w, err := machinehealth.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "reporting health problems: boom")
+}
+
+type stubFacade struct {
+	*jujutesting.Stub
+	controllerTime time.Time
+	sent           chan []string
+}
+
+func (f *stubFacade) ControllerTime() (time.Time, error) {
+	f.AddCall("ControllerTime")
+	return f.controllerTime, f.NextErr()
+}
+
+func (f *stubFacade) SetHealthProblems(machine names.MachineTag, problems []string) error {
+	f.AddCall("SetHealthProblems", machine, problems)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.sent <- problems
+	return nil
+}
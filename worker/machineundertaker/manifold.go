@@ -4,7 +4,10 @@
 package machineundertaker
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/base"
@@ -49,3 +52,50 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		},
 	}
 }
+
+// SweeperManifoldConfig defines the resource sweeper's configuration
+// and dependencies.
+type SweeperManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	ClockName     string
+	Interval      time.Duration
+
+	NewSweeper func(SweeperConfig) (worker.Worker, error)
+}
+
+// SweeperManifold returns a dependency.Manifold that runs a sweeper
+// of provider resources unknown to the model.
+func SweeperManifold(config SweeperManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.APICallerName, config.EnvironName, config.ClockName},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var environ environs.Environ
+			if err := context.Get(config.EnvironName, &environ); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var clock clock.Clock
+			if err := context.Get(config.ClockName, &clock); err != nil {
+				return nil, errors.Trace(err)
+			}
+			api, err := machineundertaker.NewAPI(apiCaller, watcher.NewNotifyWatcher)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			w, err := config.NewSweeper(SweeperConfig{
+				Facade:   api,
+				Environ:  environ,
+				Clock:    clock,
+				Interval: config.Interval,
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return w, nil
+		},
+	}
+}
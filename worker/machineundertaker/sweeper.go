@@ -0,0 +1,215 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineundertaker
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// DefaultSweepInterval is how often the provider resources tagged for
+// the model are reconciled with the model's state.
+const DefaultSweepInterval = time.Hour
+
+// SweeperFacade defines the interface we require from the machine
+// undertaker facade to find the provider resources known to the model.
+type SweeperFacade interface {
+	KnownProviderResources() ([]instance.Id, []string, error)
+}
+
+// SweeperConfig holds the dependencies of a sweeper worker.
+type SweeperConfig struct {
+	Facade   SweeperFacade
+	Environ  environs.Environ
+	Clock    clock.Clock
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// sweeper.
+func (config SweeperConfig) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Sweeper periodically looks for provider resources which are tagged
+// for the model but unknown to it, such as instances and volumes left
+// behind by crashed provisioning attempts. Depending on the model's
+// resource-sweep setting, they are reported or removed. A resource is
+// only removed once it has been found in two consecutive sweeps, so
+// that resources which are being provisioned are left alone.
+type Sweeper struct {
+	catacomb catacomb.Catacomb
+	config   SweeperConfig
+
+	// suspects holds the unknown resources found by the last sweep.
+	suspects map[environs.ModelResource]bool
+}
+
+// NewSweeper returns a worker which sweeps the provider resources
+// tagged for the model.
+func NewSweeper(config SweeperConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &Sweeper{
+		config:   config,
+		suspects: make(map[environs.ModelResource]bool),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *Sweeper) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *Sweeper) Wait() error {
+	return s.catacomb.Wait()
+}
+
+func (s *Sweeper) loop() error {
+	for {
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case <-s.config.Clock.After(s.config.Interval):
+			if err := s.sweep(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// sweep finds the unknown provider resources, and reports or removes
+// them. Failures to talk to the provider are logged rather than
+// returned, and the resources are looked at again in the next sweep.
+func (s *Sweeper) sweep() error {
+	mode := s.config.Environ.Config().ResourceSweep()
+	if mode == config.ResourceSweepOff {
+		s.suspects = make(map[environs.ModelResource]bool)
+		return nil
+	}
+	instanceIds, volumeIds, err := s.config.Facade.KnownProviderResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := environs.KnownResources{
+		Instances: instanceIds,
+		Volumes:   volumeIds,
+	}
+	unknown, err := s.unknownResources(known)
+	if err != nil {
+		logger.Errorf("cannot list provider resources: %v", err)
+		return nil
+	}
+
+	if len(unknown) == 0 {
+		logger.Debugf("no provider resources unknown to the model")
+	}
+	var confirmed []environs.ModelResource
+	suspects := make(map[environs.ModelResource]bool)
+	for _, r := range unknown {
+		suspects[r] = true
+		switch {
+		case mode != config.ResourceSweepRemove:
+			logger.Warningf("found %s %q tagged for the model but unknown to it (dry run: not removing)", r.Kind, r.Id)
+		case s.suspects[r]:
+			confirmed = append(confirmed, r)
+		default:
+			logger.Infof("found %s %q tagged for the model but unknown to it; removing it if still unknown in the next sweep", r.Kind, r.Id)
+		}
+	}
+	s.suspects = suspects
+	s.removeResources(confirmed)
+	return nil
+}
+
+// unknownResources returns the provider resources tagged for the model
+// which are not accounted for by the known resources.
+func (s *Sweeper) unknownResources(known environs.KnownResources) ([]environs.ModelResource, error) {
+	instances, err := s.config.Environ.AllInstances()
+	if err != nil && errors.Cause(err) != environs.ErrNoInstances {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	knownInstances := make(map[instance.Id]bool)
+	for _, id := range known.Instances {
+		knownInstances[id] = true
+	}
+	var unknown []environs.ModelResource
+	for _, inst := range instances {
+		if !knownInstances[inst.Id()] {
+			unknown = append(unknown, environs.ModelResource{
+				Kind: environs.ResourceKindInstance,
+				Id:   string(inst.Id()),
+			})
+		}
+	}
+	if sweeper, ok := s.config.Environ.(environs.ResourceSweeper); ok {
+		others, err := sweeper.UnknownResources(known)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unknown = append(unknown, others...)
+	}
+	return unknown, nil
+}
+
+// removeResources removes the given resources, instances first so
+// that the resources they were using can be removed.
+func (s *Sweeper) removeResources(resources []environs.ModelResource) {
+	var instanceIds []instance.Id
+	var others []environs.ModelResource
+	for _, r := range resources {
+		if r.Kind == environs.ResourceKindInstance {
+			instanceIds = append(instanceIds, instance.Id(r.Id))
+		} else {
+			others = append(others, r)
+		}
+	}
+	if len(instanceIds) > 0 {
+		logger.Infof("stopping instances unknown to the model: %v", instanceIds)
+		if err := s.config.Environ.StopInstances(instanceIds...); err != nil {
+			logger.Errorf("cannot stop instances %v: %v", instanceIds, err)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+	sweeper, ok := s.config.Environ.(environs.ResourceSweeper)
+	if !ok {
+		return
+	}
+	logger.Infof("removing provider resources unknown to the model: %v", others)
+	if err := sweeper.RemoveResources(others); err != nil {
+		logger.Errorf("cannot remove provider resources: %v", err)
+	}
+}
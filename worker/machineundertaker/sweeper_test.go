@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineundertaker_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machineundertaker"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type sweeperSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sweeperSuite{})
+
+const sweepInterval = time.Minute
+
+func (s *sweeperSuite) startSweeper(c *gc.C, mode string) (*sweeperEnviron, *testing.Clock, func()) {
+	env := &sweeperEnviron{
+		Stub: &testing.Stub{},
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			config.ResourceSweepKey: mode,
+		}),
+		instances: []instance.Instance{
+			&fakeInstance{id: "known"},
+			&fakeInstance{id: "leaked"},
+		},
+		swept:   make(chan struct{}, 10),
+		stopped: make(chan struct{}, 10),
+	}
+	clock := testing.NewClock(time.Time{})
+	w, err := machineundertaker.NewSweeper(machineundertaker.SweeperConfig{
+		Facade:   &fakeSweeperFacade{instanceIds: []instance.Id{"known"}},
+		Environ:  env,
+		Clock:    clock,
+		Interval: sweepInterval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return env, clock, func() { workertest.CleanKill(c, w) }
+}
+
+func (s *sweeperSuite) sweep(c *gc.C, env *sweeperEnviron, clock *testing.Clock) {
+	err := clock.WaitAdvance(sweepInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-env.swept:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sweep")
+	}
+}
+
+func (s *sweeperSuite) TestValidate(c *gc.C) {
+	_, err := machineundertaker.NewSweeper(machineundertaker.SweeperConfig{
+		Facade:  &fakeSweeperFacade{},
+		Environ: &sweeperEnviron{},
+		Clock:   testing.NewClock(time.Time{}),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *sweeperSuite) TestReportDoesNotRemove(c *gc.C) {
+	env, clock, kill := s.startSweeper(c, config.ResourceSweepReport)
+	s.sweep(c, env, clock)
+	s.sweep(c, env, clock)
+	// Wait for the sweeper to finish the second sweep.
+	err := clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	kill()
+	env.CheckCallNames(c, "AllInstances", "AllInstances")
+}
+
+func (s *sweeperSuite) TestRemoveOnSecondSweep(c *gc.C) {
+	env, clock, kill := s.startSweeper(c, config.ResourceSweepRemove)
+	defer kill()
+	s.sweep(c, env, clock)
+	select {
+	case <-env.stopped:
+		c.Fatalf("instance stopped after first sweep")
+	default:
+	}
+	s.sweep(c, env, clock)
+	select {
+	case <-env.stopped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for instance to be stopped")
+	}
+	env.CheckCall(c, 2, "StopInstances", []instance.Id{"leaked"})
+}
+
+type fakeSweeperFacade struct {
+	instanceIds []instance.Id
+	volumeIds   []string
+}
+
+func (f *fakeSweeperFacade) KnownProviderResources() ([]instance.Id, []string, error) {
+	return f.instanceIds, f.volumeIds, nil
+}
+
+type sweeperEnviron struct {
+	environs.Environ
+	*testing.Stub
+
+	config    *config.Config
+	instances []instance.Instance
+	swept     chan struct{}
+	stopped   chan struct{}
+}
+
+func (e *sweeperEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *sweeperEnviron) AllInstances() ([]instance.Instance, error) {
+	e.AddCall("AllInstances")
+	e.swept <- struct{}{}
+	return e.instances, e.NextErr()
+}
+
+func (e *sweeperEnviron) StopInstances(ids ...instance.Id) error {
+	e.AddCall("StopInstances", ids)
+	e.stopped <- struct{}{}
+	return e.NextErr()
+}
+
+type fakeInstance struct {
+	instance.Instance
+	id instance.Id
+}
+
+func (i *fakeInstance) Id() instance.Id {
+	return i.id
+}
@@ -587,6 +587,9 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				Location: msg.Location,
 				Level:    msg.Severity,
 				Message:  msg.Message,
+
+				Labels:        msg.Labels,
+				CorrelationID: msg.CorrelationID,
 			})
 			if err != nil {
 				return errors.Trace(err)
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongoprofile
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a mongoprofile
+// worker in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName string
+	StateName string
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// mongoprofile worker.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a mongoprofile
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var a agent.Agent
+	if err := context.Get(config.AgentName, &a); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Backend: st,
+		SetMemoryProfile: func(profile mongo.MemoryProfile) error {
+			return setMemoryProfile(a, profile)
+		},
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		worker.Wait()
+		stTracker.Done()
+	}()
+	return worker, nil
+}
+
+// setMemoryProfile resizes the cache of the controller's local mongod
+// to suit the given profile, and records the profile in the agent's
+// configuration so that mongod is started with it in future.
+func setMemoryProfile(a agent.Agent, profile mongo.MemoryProfile) error {
+	agentConfig := a.CurrentConfig()
+	info, ok := agentConfig.MongoInfo()
+	if !ok {
+		return errors.New("no mongo info in agent configuration")
+	}
+	// Each controller runs its own mongod, so dial it directly
+	// rather than the replica set's primary.
+	opts := mongo.DefaultDialOpts()
+	opts.Direct = true
+	session, err := mongo.DialWithInfo(info.Info, opts)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to mongo")
+	}
+	defer session.Close()
+	if err := session.DB("admin").Login(info.Tag.String(), info.Password); err != nil {
+		return errors.Annotate(err, "cannot log in to mongo")
+	}
+	if err := mongo.SetMemoryProfile(session, profile, agentConfig.MongoVersion()); err != nil {
+		return errors.Trace(err)
+	}
+	err = a.ChangeConfig(func(config agent.ConfigSetter) error {
+		config.SetMongoMemoryProfile(profile)
+		return nil
+	})
+	return errors.Annotate(err, "cannot record mongo memory profile")
+}
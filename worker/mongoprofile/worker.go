@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package mongoprofile provides a worker that applies changes to the
+// controller's mongo-memory-profile to the local mongod.
+package mongoprofile
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.mongoprofile")
+
+// Backend provides the controller configuration used by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
+}
+
+// Config holds the configuration and dependencies of a mongoprofile
+// worker.
+type Config struct {
+	Backend Backend
+
+	// SetMemoryProfile applies the given memory profile to the
+	// local mongod.
+	SetMemoryProfile func(mongo.MemoryProfile) error
+}
+
+// Validate returns an error if the config cannot be used to start a
+// mongoprofile worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.SetMemoryProfile == nil {
+		return errors.NotValidf("nil SetMemoryProfile")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which applies the controller's mongo
+// memory profile to the local mongod when the worker starts, and
+// whenever the profile changes.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &profileWorker{config: config}
+	return jworker.NewSimpleWorker(w.loop), nil
+}
+
+type profileWorker struct {
+	config Config
+}
+
+func (w *profileWorker) loop(stopCh <-chan struct{}) error {
+	controllerConfigWatcher := w.config.Backend.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	// The profile mongod was started with is not known, so the
+	// first profile read is always applied.
+	var (
+		current                 mongo.MemoryProfile
+		controllerConfigChanges = controllerConfigWatcher.Changes()
+	)
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+
+		case _, ok := <-controllerConfigChanges:
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			profile, err := mongo.NewMemoryProfile(controllerConfig.MongoMemoryProfile())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if profile == current {
+				continue
+			}
+			logger.Infof("applying mongo memory profile %q", profile)
+			if err := w.config.SetMemoryProfile(profile); err != nil {
+				return errors.Annotatef(err, "cannot apply mongo memory profile %q", profile)
+			}
+			current = profile
+		}
+	}
+}
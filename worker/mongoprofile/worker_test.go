@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongoprofile_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/mongoprofile"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend  *mockBackend
+	profiles chan mongo.MemoryProfile
+	setErr   error
+	config   mongoprofile.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		config:  controller.Config{controller.MongoMemoryProfile: "low"},
+		watcher: newMockNotifyWatcher(),
+	}
+	s.profiles = make(chan mongo.MemoryProfile, 10)
+	s.setErr = nil
+	s.config = mongoprofile.Config{
+		Backend: s.backend,
+		SetMemoryProfile: func(profile mongo.MemoryProfile) error {
+			s.profiles <- profile
+			return s.setErr
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	c.Assert(s.config.Validate(), jc.ErrorIsNil)
+	s.config.SetMemoryProfile = nil
+	_, err := mongoprofile.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil SetMemoryProfile not valid")
+}
+
+func (s *WorkerSuite) assertProfile(c *gc.C, expected mongo.MemoryProfile) {
+	select {
+	case profile := <-s.profiles:
+		c.Assert(profile, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for memory profile %q", expected)
+	}
+}
+
+func (s *WorkerSuite) assertNoProfile(c *gc.C) {
+	select {
+	case profile := <-s.profiles:
+		c.Fatalf("unexpected memory profile %q", profile)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.backend.watcher.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) TestAppliesProfileChanges(c *gc.C) {
+	w, err := mongoprofile.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertProfile(c, mongo.MemoryProfileLow)
+
+	// Changes to other keys are ignored.
+	s.backend.setConfig(controller.Config{
+		controller.MongoMemoryProfile: "low",
+		controller.AuditingEnabled:    true,
+	})
+	s.sendChange(c)
+	s.assertNoProfile(c)
+
+	s.backend.setConfig(controller.Config{controller.MongoMemoryProfile: "default"})
+	s.sendChange(c)
+	s.assertProfile(c, mongo.MemoryProfileDefault)
+}
+
+func (s *WorkerSuite) TestSetMemoryProfileError(c *gc.C) {
+	s.setErr = errors.New("boom")
+	w, err := mongoprofile.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	s.assertProfile(c, mongo.MemoryProfileLow)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `cannot apply mongo memory profile "low": boom`)
+}
+
+type mockBackend struct {
+	mu      sync.Mutex
+	config  controller.Config
+	watcher *mockNotifyWatcher
+}
+
+func (b *mockBackend) setConfig(config controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = config
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.config, nil
+}
+
+func (b *mockBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *mockNotifyWatcher) Err() error {
+	return w.tomb.Err()
+}
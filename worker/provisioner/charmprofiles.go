@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+// charmProfileNames returns the sorted names of the given profiles.
+func charmProfileNames(profiles map[string]*lxdprofile.Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recordCharmProfiles records the charm LXD profiles a newly started
+// machine was given, so later changes can be reconciled against them.
+func (task *provisionerTask) recordCharmProfiles(machine *apiprovisioner.Machine, profiles map[string]*lxdprofile.Profile) {
+	if _, ok := task.broker.(environs.LXDProfiler); !ok || len(profiles) == 0 {
+		return
+	}
+	err := machine.SetCharmProfiles(charmProfileNames(profiles))
+	if err != nil && !errors.IsNotSupported(err) {
+		logger.Errorf("cannot record charm profiles of machine %s: %v", machine, err)
+	}
+}
+
+// processCharmProfiles brings the charm LXD profiles of every
+// provisioned machine in line with the charms deployed to it. Failures
+// are logged rather than returned; the next change retries them.
+func (task *provisionerTask) processCharmProfiles() {
+	profiler, ok := task.broker.(environs.LXDProfiler)
+	if !ok {
+		return
+	}
+	for _, machine := range task.machines {
+		if machine.Life() != params.Alive {
+			continue
+		}
+		instId, err := machine.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			logger.Errorf("cannot get instance id of machine %s: %v", machine, err)
+			continue
+		}
+		if err := task.updateCharmProfiles(profiler, machine, instId); err != nil {
+			logger.Errorf("cannot update charm profiles of machine %s: %v", machine, err)
+		}
+	}
+}
+
+func (task *provisionerTask) updateCharmProfiles(
+	profiler environs.LXDProfiler,
+	machine *apiprovisioner.Machine,
+	instId instance.Id,
+) error {
+	current, profiles, err := machine.CharmProfiles()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	have := set.NewStrings(current...)
+	want := set.NewStrings(charmProfileNames(profiles)...)
+	add := want.Difference(have).SortedValues()
+	remove := have.Difference(want).SortedValues()
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	for _, name := range add {
+		if err := profiler.MaybeWriteLXDProfile(name, profiles[name]); err != nil {
+			return errors.Annotatef(err, "writing profile %q", name)
+		}
+	}
+	if err := profiler.ReplaceLXDProfiles(instId, remove, add); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("machine %s charm profiles: added %v, removed %v", machine, add, remove)
+	return errors.Trace(machine.SetCharmProfiles(want.SortedValues()))
+}
@@ -5,7 +5,9 @@ package provisioner
 
 import (
 	"sort"
+	"time"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
@@ -39,6 +41,14 @@ var (
 
 var ClassifyMachine = classifyMachine
 
+func RetryLimit(s RetryStrategy, class environs.ErrorClass) int {
+	return s.retryLimit(class)
+}
+
+func RetryBackoff(s RetryStrategy, retry int) time.Duration {
+	return s.backoff(retry)
+}
+
 // GetCopyAvailabilityZoneMachines returns a copy of p.(*provisionerTask).availabilityZoneMachines
 func GetCopyAvailabilityZoneMachines(p ProvisionerTask) []AvailabilityZoneMachine {
 	task := p.(*provisionerTask)
@@ -4,6 +4,8 @@
 package provisioner
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -119,6 +122,14 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
+	if len(args.CharmLXDProfiles) > 0 {
+		profileNames, err := broker.writeCharmProfiles(args.CharmLXDProfiles)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot write charm lxd profiles")
+		}
+		args.InstanceConfig.CharmLXDProfiles = profileNames
+	}
+
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -168,3 +179,45 @@ func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) err
 	)
 	return err
 }
+
+// MaybeWriteLXDProfile is part of the environs.LXDProfiler interface.
+func (broker *lxdBroker) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	profiler, err := broker.profiler()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return profiler.MaybeWriteLXDProfile(name, profile)
+}
+
+// ReplaceLXDProfiles is part of the environs.LXDProfiler interface.
+func (broker *lxdBroker) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	profiler, err := broker.profiler()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return profiler.ReplaceLXDProfiles(id, remove, add)
+}
+
+func (broker *lxdBroker) profiler() (environs.LXDProfiler, error) {
+	profiler, ok := broker.manager.(environs.LXDProfiler)
+	if !ok {
+		return nil, errors.NotSupportedf("charm lxd profiles")
+	}
+	return profiler, nil
+}
+
+// writeCharmProfiles ensures the given charm profiles exist, returning
+// their names in a stable order.
+func (broker *lxdBroker) writeCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := broker.MaybeWriteLXDProfile(name, profiles[name]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return names, nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -153,6 +154,40 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need agent binaries for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	profile := &lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+	}
+	_, err := broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-model-b-1": profile,
+			"juju-model-a-2": profile,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "MaybeWriteLXDProfile", "MaybeWriteLXDProfile", "CreateContainer")
+	s.manager.CheckCall(c, 0, "MaybeWriteLXDProfile", "juju-model-a-2", profile)
+	s.manager.CheckCall(c, 1, "MaybeWriteLXDProfile", "juju-model-b-1", profile)
+	instanceConfig := s.manager.Calls()[2].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.CharmLXDProfiles, jc.DeepEquals, []string{"juju-model-a-2", "juju-model-b-1"})
+}
+
+func (s *lxdBrokerSuite) TestReplaceLXDProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	profiler := broker.(environs.LXDProfiler)
+	err := profiler.ReplaceLXDProfiles("juju-0", []string{"juju-model-a-1"}, []string{"juju-model-a-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCall(c, 0, "ReplaceLXDProfiles", instance.Id("juju-0"), []string{"juju-model-a-1"}, []string{"juju-model-a-2"})
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -188,3 +223,13 @@ func (m *fakeContainerManager) IsInitialized() bool {
 	m.PopNoErr()
 	return true
 }
+
+func (m *fakeContainerManager) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	m.MethodCall(m, "MaybeWriteLXDProfile", name, profile)
+	return m.NextErr()
+}
+
+func (m *fakeContainerManager) ReplaceLXDProfiles(id instance.Id, remove, add []string) error {
+	m.MethodCall(m, "ReplaceLXDProfiles", id, remove, add)
+	return m.NextErr()
+}
@@ -29,8 +29,20 @@ var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
 var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 10
+	retryStrategyDelay    = 10 * time.Second
+	retryStrategyMaxDelay = 5 * time.Minute
+	retryStrategyCount    = 10
+
+	// retryStrategyClassCounts holds the number of times a StartInstance
+	// failure of each class is retried, where it differs from the
+	// strategy's retry count.
+	retryStrategyClassCounts = map[environs.ErrorClass]int{
+		// An invalid credential has to be updated by the user, so
+		// retrying only delays reporting the error.
+		environs.ErrorClassCredential: 0,
+		// Quotas are rarely raised while we wait.
+		environs.ErrorClassQuota: 3,
+	}
 )
 
 // Provisioner represents a running provisioner worker.
@@ -73,17 +85,45 @@ type provisioner struct {
 //
 // TODO(katco): 2016-08-09: lp:1611427
 type RetryStrategy struct {
-	retryDelay time.Duration
-	retryCount int
+	retryDelay       time.Duration
+	maxRetryDelay    time.Duration
+	retryCount       int
+	classRetryCounts map[environs.ErrorClass]int
 }
 
-// NewRetryStrategy returns a new retry strategy with the specified delay and
-// count for use with retryable provisioning errors.
+// NewRetryStrategy returns a new retry strategy with the specified initial
+// delay and count for use with retryable provisioning errors. The delay
+// doubles after each retry, and fewer retries are made for errors that
+// are unlikely to be resolved by waiting.
 func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	return RetryStrategy{
-		retryDelay: delay,
-		retryCount: count,
+		retryDelay:       delay,
+		maxRetryDelay:    retryStrategyMaxDelay,
+		retryCount:       count,
+		classRetryCounts: retryStrategyClassCounts,
+	}
+}
+
+// retryLimit returns the number of times a failure of the given class
+// should be retried. The limit for a class never exceeds the strategy's
+// retry count.
+func (s RetryStrategy) retryLimit(class environs.ErrorClass) int {
+	if count, ok := s.classRetryCounts[class]; ok && count < s.retryCount {
+		return count
+	}
+	return s.retryCount
+}
+
+// backoff returns the delay before the given retry, counting from zero.
+func (s RetryStrategy) backoff(retry int) time.Duration {
+	delay := s.retryDelay
+	for i := 0; i < retry && delay < s.maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if s.maxRetryDelay > 0 && delay > s.maxRetryDelay {
+		delay = s.maxRetryDelay
 	}
+	return delay
 }
 
 // configObserver is implemented so that tests can see
@@ -140,6 +180,10 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	if err != nil && !errors.IsNotImplemented(err) {
 		return nil, err
 	}
+	profileWatcher, err := p.getCharmProfileWatcher()
+	if err != nil {
+		return nil, err
+	}
 	tag := p.agentConfig.Tag()
 	machineTag, ok := tag.(names.MachineTag)
 	if !ok {
@@ -165,10 +209,12 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		profileWatcher,
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		NewRetryStrategy(retryStrategyDelay, retryStrategyCount),
+		modelCfg.NumProvisionWorkers(),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -176,6 +222,21 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	return task, nil
 }
 
+// getCharmProfileWatcher returns a watcher for changes to the charm LXD
+// profiles machines need, or nil if the broker cannot apply them or the
+// controller does not support them.
+func (p *provisioner) getCharmProfileWatcher() (watcher.NotifyWatcher, error) {
+	if _, ok := p.broker.(environs.LXDProfiler); !ok {
+		return nil, nil
+	}
+	w, err := p.st.WatchCharmProfiles()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("controller does not support charm LXD profiles")
+		return nil, nil
+	}
+	return w, errors.Trace(err)
+}
+
 // NewEnvironProvisioner returns a new Provisioner for an environment.
 // When new machines are added to the state, it allocates instances
 // from the environment and allocates them to the new machines.
@@ -245,6 +306,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetNumProvisionWorkers(modelConfig.NumProvisionWorkers())
 		}
 	}
 }
@@ -340,6 +402,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetNumProvisionWorkers(modelConfig.NumProvisionWorkers())
 		}
 	}
 }
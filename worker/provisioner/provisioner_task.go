@@ -48,11 +48,16 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetNumProvisionWorkers sets the number of machines the
+	// provisioner task starts concurrently.
+	SetNumProvisionWorkers(numWorkers int)
 }
 
 type MachineGetter interface {
 	Machines(...names.MachineTag) ([]apiprovisioner.MachineResult, error)
 	MachinesWithTransientErrors() ([]apiprovisioner.MachineStatusResult, error)
+	ProvisioningInfo(...names.MachineTag) ([]apiprovisioner.ProvisioningInfoResult, error)
 }
 
 type DistributionGroupFinder interface {
@@ -77,10 +82,12 @@ func NewProvisionerTask(
 	toolsFinder ToolsFinder,
 	machineWatcher watcher.StringsWatcher,
 	retryWatcher watcher.NotifyWatcher,
+	profileWatcher watcher.NotifyWatcher,
 	broker environs.InstanceBroker,
 	auth authentication.AuthenticationProvider,
 	imageStream string,
 	retryStartInstanceStrategy RetryStrategy,
+	numProvisionWorkers int,
 ) (ProvisionerTask, error) {
 	machineChanges := machineWatcher.Changes()
 	workers := []worker.Worker{machineWatcher}
@@ -89,6 +96,11 @@ func NewProvisionerTask(
 		retryChanges = retryWatcher.Changes()
 		workers = append(workers, retryWatcher)
 	}
+	var profileChanges watcher.NotifyChannel
+	if profileWatcher != nil {
+		profileChanges = profileWatcher.Changes()
+		workers = append(workers, profileWatcher)
+	}
 	task := &provisionerTask{
 		controllerUUID:             controllerUUID,
 		machineTag:                 machineTag,
@@ -97,6 +109,7 @@ func NewProvisionerTask(
 		toolsFinder:                toolsFinder,
 		machineChanges:             machineChanges,
 		retryChanges:               retryChanges,
+		profileChanges:             profileChanges,
 		broker:                     broker,
 		auth:                       auth,
 		harvestMode:                harvestMode,
@@ -105,6 +118,7 @@ func NewProvisionerTask(
 		availabilityZoneMachines:   make([]*AvailabilityZoneMachine, 0),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		numProvisionWorkers:        numProvisionWorkers,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -131,6 +145,7 @@ type provisionerTask struct {
 	toolsFinder                ToolsFinder
 	machineChanges             watcher.StringsChannel
 	retryChanges               watcher.NotifyChannel
+	profileChanges             watcher.NotifyChannel
 	broker                     environs.InstanceBroker
 	catacomb                   catacomb.Catacomb
 	auth                       authentication.AuthenticationProvider
@@ -144,6 +159,11 @@ type provisionerTask struct {
 	machines                 map[string]*apiprovisioner.Machine
 	azMachinesMutex          sync.RWMutex
 	availabilityZoneMachines []*AvailabilityZoneMachine
+
+	// numProvisionWorkers is the number of machines started
+	// concurrently. It is guarded by numProvisionWorkersMutex.
+	numProvisionWorkersMutex sync.Mutex
+	numProvisionWorkers      int
 }
 
 // Kill implements worker.Worker.Kill.
@@ -164,6 +184,10 @@ func (task *provisionerTask) loop() error {
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
 
+	// Likewise, charm profile changes are only reconciled once the
+	// machines map has been populated.
+	var profileChanges watcher.NotifyChannel
+
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
@@ -182,6 +206,7 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes. Enable modification of
 			// harvesting mode.
 			harvestModeChan = task.harvestModeChan
+			profileChanges = task.profileChanges
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -198,6 +223,8 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case <-profileChanges:
+			task.processCharmProfiles()
 		}
 	}
 }
@@ -210,6 +237,26 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetNumProvisionWorkers implements ProvisionerTask.SetNumProvisionWorkers().
+// The new number takes effect for the next machines to be started.
+func (task *provisionerTask) SetNumProvisionWorkers(numWorkers int) {
+	task.numProvisionWorkersMutex.Lock()
+	defer task.numProvisionWorkersMutex.Unlock()
+	if numWorkers != task.numProvisionWorkers {
+		logger.Infof("provisioning up to %d machines concurrently", numWorkers)
+	}
+	task.numProvisionWorkers = numWorkers
+}
+
+func (task *provisionerTask) provisionWorkers() int {
+	task.numProvisionWorkersMutex.Lock()
+	defer task.numProvisionWorkersMutex.Unlock()
+	if task.numProvisionWorkers < 1 {
+		return 1
+	}
+	return task.numProvisionWorkers
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -693,6 +740,7 @@ func (task *provisionerTask) constructStartInstanceParams(
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
 		StatusCallback:    machine.SetInstanceStatus,
+		CharmLXDProfiles:  apiprovisioner.CharmLXDProfiles(provisioningInfo.CharmLXDProfiles),
 	}
 
 	return startInstanceParams, nil
@@ -869,16 +917,17 @@ func (b byPopulationThenNames) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
 
-// startMachines starts a goroutine for each specified machine to
-// start it.  Errors from individual start machine attempts will be logged.
+// startMachines starts the specified machines, using a pool of
+// goroutines sized by the model's num-provision-workers setting.
+// Errors from individual start machine attempts will be logged.
 func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) error {
 	if len(machines) == 0 {
 		return nil
 	}
 
-	// Get the distributionGroups for each machine now to avoid
-	// successive calls to DistributionGroupByMachineId which will
-	// return the same data.
+	// Get the distributionGroups and provisioning info for all the
+	// machines now, rather than making the same calls once for each
+	// machine.
 	machineTags := make([]names.MachineTag, len(machines))
 	for i, machine := range machines {
 		machineTags[i] = machine.MachineTag()
@@ -887,9 +936,20 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 	if err != nil {
 		return err
 	}
+	provisioningInfo, err := task.machineGetter.ProvisioningInfo(machineTags...)
+	if err != nil {
+		return errors.Annotate(err, "fetching provisioning info")
+	}
+	// All the machines share the model's agent version.
+	agentVersion, err := machines[0].ModelAgentVersion()
+	if err != nil {
+		return err
+	}
+	toolsFinder := newBatchToolsFinder(task.toolsFinder)
 
 	var wg sync.WaitGroup
 	errMachines := make([]error, len(machines))
+	workers := make(chan struct{}, task.provisionWorkers())
 	for i, m := range machines {
 		if machineDistributionGroups[i].Err != nil {
 			task.setErrorStatus(
@@ -898,14 +958,30 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 			)
 			continue
 		}
+		if provisioningInfo[i].Err != nil {
+			task.setErrorStatus(
+				"fetching provisioning info for machine %q: %v",
+				m, provisioningInfo[i].Err,
+			)
+			continue
+		}
+		select {
+		case workers <- struct{}{}:
+		case <-task.catacomb.Dying():
+			wg.Wait()
+			return task.catacomb.ErrDying()
+		}
 		wg.Add(1)
-		go func(machine *apiprovisioner.Machine, dg []string, index int) {
-			defer wg.Done()
-			if err := task.startMachine(machine, dg); err != nil {
+		go func(machine *apiprovisioner.Machine, pInfo *params.ProvisioningInfo, dg []string, index int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			if err := task.startMachine(machine, pInfo, agentVersion, toolsFinder, dg); err != nil {
 				task.removeMachineFromAZMap(machine)
 				errMachines[index] = err
 			}
-		}(m, machineDistributionGroups[i].MachineIds, i)
+		}(m, provisioningInfo[i].Info, machineDistributionGroups[i].MachineIds, i)
 	}
 
 	wg.Wait()
@@ -936,17 +1012,26 @@ func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisio
 	return nil
 }
 
+// classifiedStartError returns an error describing a StartInstance
+// failure of the given class, for reporting in the machine's status.
+func classifiedStartError(class environs.ErrorClass, err error) error {
+	if class == environs.ErrorClassUnknown {
+		return err
+	}
+	return errors.Errorf("%s error: %v", class, err)
+}
+
 // setupToStartMachine gathers the nessecessary information, based on the specified
-// machine, to create ProvisioningInfo and StartInstanceParms to be used by startMachine.
-func (task *provisionerTask) setupToStartMachine(machine *apiprovisioner.Machine, version *version.Number) (
+// machine and its ProvisioningInfo, to create StartInstanceParms to be used by startMachine.
+func (task *provisionerTask) setupToStartMachine(
+	machine *apiprovisioner.Machine,
+	pInfo *params.ProvisioningInfo,
+	version *version.Number,
+	toolsFinder ToolsFinder,
+) (
 	environs.StartInstanceParams,
 	error,
 ) {
-	pInfo, err := machine.ProvisioningInfo()
-	if err != nil {
-		return environs.StartInstanceParams{}, errors.Annotatef(err, "fetching provisioning info for machine %q", machine)
-	}
-
 	instanceCfg, err := task.constructInstanceConfig(machine, task.auth, pInfo)
 	if err != nil {
 		return environs.StartInstanceParams{}, errors.Annotatef(err, "creating instance config for machine %q", machine)
@@ -959,7 +1044,7 @@ func (task *provisionerTask) setupToStartMachine(machine *apiprovisioner.Machine
 		arch = *pInfo.Constraints.Arch
 	}
 
-	possibleTools, err := task.toolsFinder.FindTools(
+	possibleTools, err := toolsFinder.FindTools(
 		*version,
 		pInfo.Series,
 		arch,
@@ -1010,13 +1095,12 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 
 func (task *provisionerTask) startMachine(
 	machine *apiprovisioner.Machine,
+	pInfo *params.ProvisioningInfo,
+	agentVersion *version.Number,
+	toolsFinder ToolsFinder,
 	distributionGroupMachineIds []string,
 ) error {
-	v, err := machine.ModelAgentVersion()
-	if err != nil {
-		return err
-	}
-	startInstanceParams, err := task.setupToStartMachine(machine, v)
+	startInstanceParams, err := task.setupToStartMachine(machine, pInfo, agentVersion, toolsFinder)
 	if err != nil {
 		return task.setErrorStatus("%v", machine, err)
 	}
@@ -1033,17 +1117,18 @@ func (task *provisionerTask) startMachine(
 		logger.Errorf("%v", err)
 	}
 
-	// TODO ProvisionerParallelization 2017-10-03
-	// Improve the retry loop, newer methodology
-	// Is rate limiting handled correctly?
 	var result *environs.StartInstanceResult
 
-	// Attempt creating the instance "retryCount" times. If the provider
-	// supports availability zones and we're automatically distributing
-	// across the zones, then we try each zone for every attempt, or until
-	// one of the StartInstance calls returns an error satisfying
-	// environs.IsAvailabilityZoneIndependent.
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; {
+	// Attempt creating the instance until the retry limit for the class
+	// of error is reached, backing off exponentially between attempts.
+	// If the provider supports availability zones and we're automatically
+	// distributing across the zones, then we try each zone for every
+	// attempt, or until one of the StartInstance calls returns an error
+	// satisfying environs.IsAvailabilityZoneIndependent.
+	strategy := task.retryStartInstanceStrategy
+	retries := make(map[environs.ErrorClass]int)
+	totalRetries := 0
+	for {
 		startInstanceParams.AvailabilityZone, err = task.machineAvailabilityZoneDistribution(machine.Id(), distributionGroupMachineIds)
 		if err != nil {
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
@@ -1056,15 +1141,19 @@ func (task *provisionerTask) startMachine(
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		class := environs.ErrorClassOf(err)
+		attemptsLeft := strategy.retryLimit(class) - retries[class]
+		if attemptsLeft <= 0 {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved.
 			task.removeMachineFromAZMap(machine)
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, classifiedStartError(class, err))
 		}
 
 		retrying := true
 		retryMsg := ""
+		delay := strategy.backoff(totalRetries)
 		if startInstanceParams.AvailabilityZone != "" && !environs.IsAvailabilityZoneIndependent(err) {
 			// We've specified a zone, and the error may be specific to
 			// that zone. Retry in another zone if there are any untried.
@@ -1076,13 +1165,14 @@ func (task *provisionerTask) startMachine(
 				return err2
 			}
 			if azRemaining {
+				delay = strategy.retryDelay
 				retryMsg = fmt.Sprintf(
 					"failed to start machine %s in zone %q, retrying in %v with new availability zone: %s",
-					machine, startInstanceParams.AvailabilityZone,
-					task.retryStartInstanceStrategy.retryDelay, err,
+					machine, startInstanceParams.AvailabilityZone, delay, classifiedStartError(class, err),
 				)
 				logger.Debugf("%s", retryMsg)
-				// There's still more zones to try, so don't decrement "attemptsLeft" yet.
+				// There's still more zones to try, so don't count this
+				// as a retry yet.
 				retrying = false
 			} else {
 				// All availability zones have been attempted for this iteration,
@@ -1094,10 +1184,17 @@ func (task *provisionerTask) startMachine(
 		if retrying {
 			retryMsg = fmt.Sprintf(
 				"failed to start machine %s (%s), retrying in %v (%d more attempts)",
-				machine, err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft,
+				machine, classifiedStartError(class, err), delay, attemptsLeft,
 			)
-			logger.Warningf("%s", retryMsg)
-			attemptsLeft--
+			// Only warn about the first failure of each class; the
+			// status records the progress of later attempts.
+			if retries[class] == 0 {
+				logger.Warningf("%s", retryMsg)
+			} else {
+				logger.Debugf("%s", retryMsg)
+			}
+			retries[class]++
+			totalRetries++
 		}
 
 		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err3 != nil {
@@ -1107,7 +1204,7 @@ func (task *provisionerTask) startMachine(
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(delay):
 		}
 	}
 
@@ -1132,6 +1229,7 @@ func (task *provisionerTask) startMachine(
 		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
+	task.recordCharmProfiles(machine, startInstanceParams.CharmLXDProfiles)
 
 	logger.Infof(
 		"started machine %s as instance %s with hardware %q, network config %+v, volumes %v, volume attachments %v, subnets to zones %v",
@@ -1205,6 +1303,39 @@ func (task *provisionerTask) removeMachineFromAZMap(machine *apiprovisioner.Mach
 	}
 }
 
+// batchToolsFinder wraps a ToolsFinder, remembering the tools found
+// so that machines started together with the same series and
+// architecture share a single request.
+type batchToolsFinder struct {
+	ToolsFinder
+
+	mu      sync.Mutex
+	results map[string]coretools.List
+}
+
+func newBatchToolsFinder(finder ToolsFinder) *batchToolsFinder {
+	return &batchToolsFinder{
+		ToolsFinder: finder,
+		results:     make(map[string]coretools.List),
+	}
+}
+
+// FindTools implements ToolsFinder.
+func (f *batchToolsFinder) FindTools(version version.Number, series string, arch string) (coretools.List, error) {
+	key := fmt.Sprintf("%s-%s-%s", version, series, arch)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if list, ok := f.results[key]; ok {
+		return list, nil
+	}
+	list, err := f.ToolsFinder.FindTools(version, series, arch)
+	if err != nil {
+		return nil, err
+	}
+	f.results[key] = list
+	return list, nil
+}
+
 type provisioningInfo struct {
 	Constraints    constraints.Value
 	Series         string
@@ -676,6 +676,81 @@ func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreatio
 	c.Check(instanceStatus.Message, gc.Equals, destroyError.Error())
 }
 
+func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithCredentialError(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
+	s.PatchValue(provisioner.RetryStrategyCount, 2)
+
+	errorInjectionChannel := make(chan error, 1)
+	p := s.newEnvironProvisioner(c)
+	defer workertest.CleanKill(c, p)
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// Credential errors are not retried, so the machine fails even
+	// though the next attempt would succeed.
+	errorInjectionChannel <- providercommon.ClassifiedError(
+		environs.ErrorClassCredential, errors.New("not authorized"),
+	)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	_, instanceStatus := s.waitUntilMachineNotPending(c, m)
+	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(instanceStatus.Message, gc.Equals, "credential error: not authorized")
+}
+
+func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithQuotaErrors(c *gc.C) {
+	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
+	s.PatchValue(provisioner.RetryStrategyCount, 10)
+
+	errorInjectionChannel := make(chan error, 4)
+	p := s.newEnvironProvisioner(c)
+	defer workertest.CleanKill(c, p)
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// Quota errors are retried fewer times than unclassified errors.
+	quotaError := providercommon.ClassifiedError(
+		environs.ErrorClassQuota, errors.New("instance limit exceeded"),
+	)
+	for i := 0; i < 4; i++ {
+		errorInjectionChannel <- quotaError
+	}
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	_, instanceStatus := s.waitUntilMachineNotPending(c, m)
+	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Check(instanceStatus.Message, gc.Equals, "quota error: instance limit exceeded")
+	c.Check(errorInjectionChannel, gc.HasLen, 0)
+}
+
+func (s *ProvisionerSuite) TestRetryStrategy(c *gc.C) {
+	strategy := provisioner.NewRetryStrategy(10*time.Second, 10)
+	c.Check(provisioner.RetryLimit(strategy, environs.ErrorClassUnknown), gc.Equals, 10)
+	c.Check(provisioner.RetryLimit(strategy, environs.ErrorClassRateLimit), gc.Equals, 10)
+	c.Check(provisioner.RetryLimit(strategy, environs.ErrorClassQuota), gc.Equals, 3)
+	c.Check(provisioner.RetryLimit(strategy, environs.ErrorClassCredential), gc.Equals, 0)
+
+	var delays []time.Duration
+	for i := 0; i < 7; i++ {
+		delays = append(delays, provisioner.RetryBackoff(strategy, i))
+	}
+	c.Check(delays, jc.DeepEquals, []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second,
+		160 * time.Second, 5 * time.Minute, 5 * time.Minute,
+	})
+
+	// Class limits never exceed the strategy's retry count.
+	strategy = provisioner.NewRetryStrategy(0, 1)
+	c.Check(provisioner.RetryLimit(strategy, environs.ErrorClassQuota), gc.Equals, 1)
+	c.Check(provisioner.RetryBackoff(strategy, 3), gc.Equals, time.Duration(0))
+}
+
 func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetryableCreationError(c *gc.C) {
 	// Set the retry delay to 0, and retry count to 2 to keep tests short
 	s.PatchValue(provisioner.RetryStrategyDelay, 0*time.Second)
@@ -1194,6 +1269,10 @@ func (*mockMachineGetter) MachinesWithTransientErrors() ([]apiprovisioner.Machin
 	return nil, fmt.Errorf("error")
 }
 
+func (*mockMachineGetter) ProvisioningInfo(tags ...names.MachineTag) ([]apiprovisioner.ProvisioningInfoResult, error) {
+	return nil, fmt.Errorf("error")
+}
+
 type mockDistributionGroupFinder struct {
 	groups map[names.MachineTag][]string
 }
@@ -1281,6 +1360,20 @@ func (s *ProvisionerSuite) newProvisionerTaskWithRetryStrategy(
 	toolsFinder provisioner.ToolsFinder,
 	retryStrategy provisioner.RetryStrategy,
 ) provisioner.ProvisionerTask {
+	return s.newProvisionerTaskWithWorkers(c, harvestingMethod, broker, machineGetter,
+		distributionGroupFinder, toolsFinder, retryStrategy, config.DefaultNumProvisionWorkers)
+}
+
+func (s *ProvisionerSuite) newProvisionerTaskWithWorkers(
+	c *gc.C,
+	harvestingMethod config.HarvestMode,
+	broker environs.InstanceBroker,
+	machineGetter provisioner.MachineGetter,
+	distributionGroupFinder provisioner.DistributionGroupFinder,
+	toolsFinder provisioner.ToolsFinder,
+	retryStrategy provisioner.RetryStrategy,
+	numProvisionWorkers int,
+) provisioner.ProvisionerTask {
 
 	machineWatcher, err := s.provisioner.WatchModelMachines()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1298,10 +1391,12 @@ func (s *ProvisionerSuite) newProvisionerTaskWithRetryStrategy(
 		toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		nil,
 		broker,
 		auth,
 		imagemetadata.ReleasedStream,
 		retryStrategy,
+		numProvisionWorkers,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -1881,6 +1976,57 @@ func (s *ProvisionerSuite) TestProvisioningMachinesNoZonedEnviron(c *gc.C) {
 	c.Assert(expected, gc.HasLen, 0)
 }
 
+func (s *ProvisionerSuite) TestProvisioningMachinesLimitedWorkers(c *gc.C) {
+	// Add the machines before starting the task, so that they are
+	// all started together.
+	machines, err := s.addMachines(5)
+	c.Assert(err, jc.ErrorIsNil)
+
+	broker := &mockConcurrencyBroker{Environ: s.Environ}
+	task := s.newProvisionerTaskWithWorkers(c,
+		config.HarvestDestroyed,
+		broker,
+		s.provisioner,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+		provisioner.NewRetryStrategy(0*time.Second, 0),
+		2,
+	)
+	defer workertest.CleanKill(c, task)
+
+	s.checkStartInstances(c, machines)
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	c.Assert(broker.maxActive, gc.Equals, 2)
+}
+
+// mockConcurrencyBroker records the largest number of concurrent
+// StartInstance calls.
+type mockConcurrencyBroker struct {
+	environs.Environ
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (b *mockConcurrencyBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	b.mu.Lock()
+	b.active++
+	if b.active > b.maxActive {
+		b.maxActive = b.active
+	}
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.active--
+		b.mu.Unlock()
+	}()
+	// Give the other workers the chance to start instances.
+	time.Sleep(coretesting.ShortWait)
+	return b.Environ.StartInstance(args)
+}
+
 type mockNoZonedEnvironBroker struct {
 	environs.Environ
 }
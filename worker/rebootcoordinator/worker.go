@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/rebootcoordinator"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.rebootcoordinator")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// RebootApplications returns the pending reboot requests of the
+	// model's units, grouped by application.
+	RebootApplications() ([]rebootcoordinator.RebootApplication, error)
+
+	// GrantReboot grants the reboot requested by the given unit.
+	GrantReboot(names.UnitTag) error
+
+	// CompleteReboot records that the granted reboot of the given
+	// unit has completed.
+	CompleteReboot(names.UnitTag) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// PollInterval is the time the worker waits between checks of
+	// the model's reboot requests.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a worker that grants the reboots requested by units,
+// one unit of each application at a time.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker coordinates the reboots requested by units.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		apps, err := w.config.Facade.RebootApplications()
+		if err != nil {
+			return errors.Annotate(err, "cannot get reboot requests")
+		}
+		for _, app := range apps {
+			if err := w.coordinate(app); err != nil {
+				return errors.Annotatef(err, "cannot coordinate reboots of %s", names.ReadableString(app.Application))
+			}
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+	}
+}
+
+// coordinate completes the application's granted reboot once the unit
+// is back up and ready; and, when no reboot is in progress and all the
+// application's units are ready, grants the oldest pending request.
+func (w *Worker) coordinate(app rebootcoordinator.RebootApplication) error {
+	var pending []rebootcoordinator.RebootRequest
+	for _, req := range app.Requests {
+		if !req.Granted {
+			pending = append(pending, req)
+			continue
+		}
+		if req.Rebooting || !req.Ready {
+			logger.Debugf("waiting for %s to reboot", names.ReadableString(req.Unit))
+			return nil
+		}
+		logger.Infof("%s has rebooted", names.ReadableString(req.Unit))
+		if err := w.config.Facade.CompleteReboot(req.Unit); err != nil {
+			return errors.Trace(err)
+		}
+		// Let the next check see the application's units as they
+		// are now, before granting another reboot.
+		return nil
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if !app.UnitsReady {
+		logger.Debugf("waiting for units of %s to be ready", names.ReadableString(app.Application))
+		return nil
+	}
+	unit := pending[0].Unit
+	logger.Infof("granting reboot of %s", names.ReadableString(unit))
+	return errors.Trace(w.config.Facade.GrantReboot(unit))
+}
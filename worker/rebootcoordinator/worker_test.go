@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rebootcoordinator_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/rebootcoordinator"
+	coretesting "github.com/juju/juju/testing"
+	worker_rebootcoordinator "github.com/juju/juju/worker/rebootcoordinator"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+}
+
+func (s *WorkerSuite) config() worker_rebootcoordinator.Config {
+	return worker_rebootcoordinator.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.PollInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestGrantsOldestRequest(c *gc.C) {
+	s.facade.apps = []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("mysql/1")},
+			{Unit: names.NewUnitTag("mysql/0")},
+		},
+	}, {
+		Application: names.NewApplicationTag("wordpress"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("wordpress/0")},
+		},
+	}}
+	s.runOnce(c)
+	s.facade.check(c, []string{"mysql/1", "wordpress/0"}, nil)
+}
+
+func (s *WorkerSuite) TestWaitsForUnitsReady(c *gc.C) {
+	s.facade.apps = []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("mysql/0")},
+		},
+	}}
+	s.runOnce(c)
+	s.facade.check(c, nil, nil)
+}
+
+func (s *WorkerSuite) TestWaitsForGrantedReboot(c *gc.C) {
+	s.facade.apps = []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("mysql/0"), Granted: true, Rebooting: true},
+			{Unit: names.NewUnitTag("mysql/1")},
+		},
+	}, {
+		Application: names.NewApplicationTag("wordpress"),
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("wordpress/0"), Granted: true},
+		},
+	}}
+	s.runOnce(c)
+	s.facade.check(c, nil, nil)
+}
+
+func (s *WorkerSuite) TestCompletesReboot(c *gc.C) {
+	s.facade.apps = []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("mysql/0"), Granted: true, Ready: true},
+			{Unit: names.NewUnitTag("mysql/1")},
+		},
+	}}
+	s.runOnce(c)
+	s.facade.check(c, nil, []string{"mysql/0"})
+}
+
+func (s *WorkerSuite) TestPolls(c *gc.C) {
+	w, err := worker_rebootcoordinator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c)
+	s.assertNotChecked(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertChecked(c)
+}
+
+func (s *WorkerSuite) TestRebootApplicationsError(c *gc.C) {
+	s.facade.err = errors.New("blammo")
+	w, err := worker_rebootcoordinator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot get reboot requests: blammo")
+}
+
+func (s *WorkerSuite) TestGrantRebootError(c *gc.C) {
+	s.facade.apps = []rebootcoordinator.RebootApplication{{
+		Application: names.NewApplicationTag("mysql"),
+		UnitsReady:  true,
+		Requests: []rebootcoordinator.RebootRequest{
+			{Unit: names.NewUnitTag("mysql/0")},
+		},
+	}}
+	s.facade.grantErr = errors.New("blammo")
+	w, err := worker_rebootcoordinator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot coordinate reboots of application mysql: blammo")
+}
+
+// runOnce starts a worker and stops it once it has waited for its next
+// poll, so that it has acted on the facade's requests exactly once.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	w, err := worker_rebootcoordinator.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) assertChecked(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for check")
+	}
+}
+
+func (s *WorkerSuite) assertNotChecked(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected check")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade reports the same reboot requests on every call, and
+// records the reboots granted and completed.
+type mockFacade struct {
+	apps     []rebootcoordinator.RebootApplication
+	err      error
+	grantErr error
+	calls    chan struct{}
+
+	mu        sync.Mutex
+	granted   []string
+	completed []string
+}
+
+func (m *mockFacade) RebootApplications() ([]rebootcoordinator.RebootApplication, error) {
+	m.calls <- struct{}{}
+	return m.apps, m.err
+}
+
+func (m *mockFacade) GrantReboot(unit names.UnitTag) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.granted = append(m.granted, unit.Id())
+	return m.grantErr
+}
+
+func (m *mockFacade) CompleteReboot(unit names.UnitTag) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed = append(m.completed, unit.Id())
+	return nil
+}
+
+func (m *mockFacade) check(c *gc.C, granted, completed []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c.Check(m.granted, jc.DeepEquals, granted)
+	c.Check(m.completed, jc.DeepEquals, completed)
+}
+
+var _ worker.Worker = (*worker_rebootcoordinator.Worker)(nil)
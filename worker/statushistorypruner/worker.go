@@ -6,7 +6,9 @@ package statushistorypruner
 import (
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/base"
@@ -16,6 +18,8 @@ import (
 	"github.com/juju/juju/worker/pruner"
 )
 
+var logger = loggo.GetLogger("juju.worker.statushistorypruner")
+
 // Worker prunes status history records at regular intervals.
 type Worker struct {
 	pruner.PrunerWorker
@@ -23,7 +27,30 @@ type Worker struct {
 
 // NewFacade returns a new status history facade.
 func NewFacade(caller base.APICaller) pruner.Facade {
-	return statushistory.NewFacade(caller)
+	return reportingFacade{statushistory.NewFacade(caller)}
+}
+
+// reportingFacade wraps the status history facade to report the
+// entries removed and the space reclaimed by each prune.
+type reportingFacade struct {
+	*statushistory.Facade
+}
+
+// Prune is part of the pruner.Facade interface.
+func (f reportingFacade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
+	result, err := f.PruneWithResult(maxHistoryTime, maxHistoryMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.DeletedByAge > 0 || result.DeletedBySize > 0 {
+		logger.Infof(
+			"pruned %d status history entries older than %v and %d to stay within %dM, reclaiming about %s",
+			result.DeletedByAge, maxHistoryTime,
+			result.DeletedBySize, maxHistoryMB,
+			humanize.IBytes(uint64(result.ReclaimedBytes)),
+		)
+	}
+	return nil
 }
 
 func (w *Worker) loop() error {
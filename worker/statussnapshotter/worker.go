@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshotter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/statussnapshotter"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/pruner"
+)
+
+// Worker takes snapshots of the model's status at regular intervals,
+// pruning the snapshots that are older than the model's status
+// history.
+type Worker struct {
+	pruner.PrunerWorker
+}
+
+// NewFacade returns a facade whose Prune method takes a status
+// snapshot and prunes the old ones.
+func NewFacade(caller base.APICaller) pruner.Facade {
+	return statussnapshotter.NewFacade(caller)
+}
+
+func (w *Worker) loop() error {
+	// Snapshots are kept for as long as the status history, so that
+	// they cover the same span of time.
+	return w.Work(func(config *config.Config) (time.Duration, uint) {
+		return config.MaxStatusHistoryAge(), config.MaxStatusHistorySizeMB()
+	})
+}
+
+// New creates a new status snapshotter worker.
+func New(conf pruner.Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		pruner.New(conf),
+	}
+
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: w.Catacomb(),
+		Work: w.loop,
+	})
+
+	return w, errors.Trace(err)
+}
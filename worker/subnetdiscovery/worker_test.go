@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnetdiscovery_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/subnetdiscovery"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+}
+
+func (s *WorkerSuite) config() subnetdiscovery.Config {
+	return subnetdiscovery.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.PollInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestPolls(c *gc.C) {
+	w, err := subnetdiscovery.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertReconciled(c)
+	s.assertNotReconciled(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertReconciled(c)
+}
+
+func (s *WorkerSuite) TestReconcileSubnetsError(c *gc.C) {
+	s.facade.err = errors.New("blammo")
+	w, err := subnetdiscovery.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot reconcile subnets: blammo")
+}
+
+func (s *WorkerSuite) TestNotSupportedUninstalls(c *gc.C) {
+	s.facade.err = &params.Error{Code: params.CodeNotSupported, Message: "not supported"}
+	w, err := subnetdiscovery.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *WorkerSuite) assertReconciled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for reconciliation")
+	}
+}
+
+func (s *WorkerSuite) assertNotReconciled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected reconciliation")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	err   error
+	calls chan struct{}
+}
+
+func (m *mockFacade) ReconcileSubnets() error {
+	m.calls <- struct{}{}
+	return m.err
+}
+
+var _ worker.Worker = (*subnetdiscovery.Worker)(nil)
@@ -23,6 +23,10 @@ func (*dummyRelations) NextHook(_ resolver.LocalState, _ remotestate.Snapshot) (
 	return hook.Info{}, resolver.ErrNoOperation
 }
 
+func (*dummyRelations) NextHooks(_ resolver.LocalState, _ remotestate.Snapshot) ([]hook.Info, error) {
+	return nil, resolver.ErrNoOperation
+}
+
 type dummyStorageAccessor struct {
 	storage.StorageAccessor
 }
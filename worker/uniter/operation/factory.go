@@ -84,6 +84,33 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 	}, nil
 }
 
+// NewRunHooks is part of the Factory interface.
+func (f *factory) NewRunHooks(hookInfos []hook.Info) (Operation, error) {
+	if len(hookInfos) < 2 {
+		return nil, errors.New("at least two hooks required")
+	}
+	relationIds := make(map[int]bool)
+	op := &runHooks{callbacks: f.config.Callbacks}
+	for _, hookInfo := range hookInfos {
+		if err := hookInfo.Validate(); err != nil {
+			return nil, err
+		}
+		if !hookInfo.Kind.IsRelation() {
+			return nil, errors.Errorf("cannot run %s hook concurrently", hookInfo.Kind)
+		}
+		if relationIds[hookInfo.RelationId] {
+			return nil, errors.Errorf("cannot run two hooks of relation %d concurrently", hookInfo.RelationId)
+		}
+		relationIds[hookInfo.RelationId] = true
+		op.hooks = append(op.hooks, &runHook{
+			info:          hookInfo,
+			callbacks:     f.config.Callbacks,
+			runnerFactory: f.config.RunnerFactory,
+		})
+	}
+	return op, nil
+}
+
 // NewSkipHook is part of the Factory interface.
 func (f *factory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
 	hookOp, err := f.NewRunHook(hookInfo)
@@ -31,6 +31,12 @@ type runHook struct {
 
 // String is part of the Operation interface.
 func (rh *runHook) String() string {
+	return fmt.Sprintf("run %s hook", rh.describe())
+}
+
+// describe returns the hook kind, qualified by the relation and remote
+// unit or the storage it concerns.
+func (rh *runHook) describe() string {
 	suffix := ""
 	switch {
 	case rh.info.Kind.IsRelation():
@@ -42,32 +48,43 @@ func (rh *runHook) String() string {
 	case rh.info.Kind.IsStorage():
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
-	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
+	return fmt.Sprintf("%s%s", rh.info.Kind, suffix)
 }
 
 // Prepare ensures the hook can be executed.
 // Prepare is part of the Operation interface.
 func (rh *runHook) Prepare(state State) (*State, error) {
-	name, err := rh.callbacks.PrepareHook(rh.info)
+	err := rh.prepare(func() (runner.Runner, error) {
+		return rh.runnerFactory.NewHookRunner(rh.info)
+	})
 	if err != nil {
 		return nil, err
 	}
-	rnr, err := rh.runnerFactory.NewHookRunner(rh.info)
+	return stateChange{
+		Kind: RunHook,
+		Step: Pending,
+		Hook: &rh.info,
+	}.apply(state), nil
+}
+
+// prepare validates the hook, and creates and prepares the runner that
+// will execute it.
+func (rh *runHook) prepare(newRunner func() (runner.Runner, error)) error {
+	name, err := rh.callbacks.PrepareHook(rh.info)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	rnr, err := newRunner()
+	if err != nil {
+		return err
 	}
 	err = rnr.Context().Prepare()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return errors.Trace(err)
 	}
 	rh.name = name
 	rh.runner = rnr
-
-	return stateChange{
-		Kind: RunHook,
-		Step: Pending,
-		Hook: &rh.info,
-	}.apply(state), nil
+	return nil
 }
 
 // RunningHookMessage returns the info message to print when running a hook.
@@ -89,23 +106,8 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	// to count so reset it here before running the hook.
 	rh.runner.Context().ResetExecutionSetUnitStatus()
 
-	ranHook := true
-	step := Done
-
-	err := rh.runner.RunHook(rh.name)
-	cause := errors.Cause(err)
-	switch {
-	case context.IsMissingHookError(cause):
-		ranHook = false
-		err = nil
-	case cause == context.ErrRequeueAndReboot:
-		step = Queued
-		fallthrough
-	case cause == context.ErrReboot:
-		err = ErrNeedsReboot
-	case err == nil:
-	default:
-		logger.Errorf("hook %q failed: %v", rh.name, err)
+	ranHook, step, err := rh.run()
+	if err == ErrHookFailed {
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
@@ -130,6 +132,33 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// run runs the prepared hook. It reports whether the hook was run,
+// which it is not if the charm does not implement it, and the step to
+// record once it has. A failed hook is reported as ErrHookFailed, and
+// a reboot request as ErrNeedsReboot.
+func (rh *runHook) run() (ranHook bool, step Step, err error) {
+	ranHook = true
+	step = Done
+
+	err = rh.runner.RunHook(rh.name)
+	cause := errors.Cause(err)
+	switch {
+	case context.IsMissingHookError(cause):
+		ranHook = false
+		err = nil
+	case cause == context.ErrRequeueAndReboot:
+		step = Queued
+		fallthrough
+	case cause == context.ErrReboot:
+		err = ErrNeedsReboot
+	case err == nil:
+	default:
+		logger.Errorf("hook %q failed: %v", rh.name, err)
+		err = ErrHookFailed
+	}
+	return ranHook, step, err
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+// runHooks runs hooks of relations that the charm declares independent
+// concurrently, each in its own context.
+//
+// Each hook is committed as soon as the batch has finished running, so
+// the operation state records no hook while the batch is in progress:
+// hooks interrupted by a restart are still pending in the relation
+// state, and will be run again. If any hook fails, the first failure
+// is recorded as the pending hook, and is resolved or retried like any
+// other failed hook.
+type runHooks struct {
+	hooks     []*runHook
+	callbacks Callbacks
+
+	RequiresMachineLock
+}
+
+// hookResult holds the outcome of one of a batch of concurrent hooks.
+type hookResult struct {
+	ranHook bool
+	step    Step
+	err     error
+}
+
+// String is part of the Operation interface.
+func (rh *runHooks) String() string {
+	descriptions := make([]string, len(rh.hooks))
+	for i, h := range rh.hooks {
+		descriptions[i] = h.describe()
+	}
+	return fmt.Sprintf("run %s hooks concurrently", strings.Join(descriptions, ", "))
+}
+
+// Prepare ensures the hooks can be executed, giving each its own hook
+// tool socket.
+// Prepare is part of the Operation interface.
+func (rh *runHooks) Prepare(state State) (*State, error) {
+	for i, h := range rh.hooks {
+		h, slot := h, i
+		err := h.prepare(func() (runner.Runner, error) {
+			return h.runnerFactory.NewParallelHookRunner(h.info, slot)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// Execute runs the hooks concurrently, and commits those that succeed.
+// Execute is part of the Operation interface.
+func (rh *runHooks) Execute(state State) (*State, error) {
+	hookNames := make([]string, len(rh.hooks))
+	for i, h := range rh.hooks {
+		hookNames[i] = h.name
+	}
+	message := fmt.Sprintf("running %s hooks", strings.Join(hookNames, ", "))
+	if err := rh.callbacks.SetExecutingStatus(message); err != nil {
+		return nil, err
+	}
+
+	results := make([]hookResult, len(rh.hooks))
+	var wg sync.WaitGroup
+	for i, h := range rh.hooks {
+		h.runner.Context().ResetExecutionSetUnitStatus()
+		wg.Add(1)
+		go func(i int, h *runHook) {
+			defer wg.Done()
+			result := &results[i]
+			result.ranHook, result.step, result.err = h.run()
+		}(i, h)
+	}
+	wg.Wait()
+
+	// Notifications and commits are made one hook at a time, in the
+	// order the hooks were given.
+	var failed *hook.Info
+	var needsReboot, hasRunStatusSet bool
+	for i, h := range rh.hooks {
+		result := results[i]
+		switch result.err {
+		case ErrHookFailed:
+			h.callbacks.NotifyHookFailed(h.name, h.runner.Context())
+			if failed == nil {
+				failed = &h.info
+			}
+			continue
+		case ErrNeedsReboot:
+			needsReboot = true
+		}
+		if result.ranHook {
+			logger.Infof("ran %q hook", h.name)
+			h.callbacks.NotifyHookCompleted(h.name, h.runner.Context())
+		} else {
+			logger.Infof("skipped %q hook (missing)", h.name)
+		}
+		statusSet, err := h.afterHook(state)
+		if err != nil {
+			return nil, err
+		}
+		hasRunStatusSet = hasRunStatusSet || statusSet
+		if result.step == Queued {
+			// The hook asked to be run again after a reboot, so
+			// it stays pending.
+			continue
+		}
+		if err := h.callbacks.CommitHook(h.info); err != nil {
+			return nil, err
+		}
+	}
+
+	if failed != nil {
+		return stateChange{
+			Kind:            RunHook,
+			Step:            Pending,
+			Hook:            failed,
+			HasRunStatusSet: hasRunStatusSet,
+		}.apply(state), ErrHookFailed
+	}
+	var err error
+	if needsReboot {
+		err = ErrNeedsReboot
+	}
+	return stateChange{
+		Kind:            Continue,
+		Step:            Pending,
+		HasRunStatusSet: hasRunStatusSet,
+	}.apply(state), err
+}
+
+// Commit does nothing, because the hooks were committed as they
+// completed.
+// Commit is part of the Operation interface.
+func (rh *runHooks) Commit(state State) (*State, error) {
+	return nil, nil
+}
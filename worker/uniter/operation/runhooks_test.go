@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type RunHooksSuite struct {
+	testing.IsolationSuite
+
+	callbacks     *runHooksCallbacks
+	runnerFactory *parallelRunnerFactory
+	factory       operation.Factory
+	hooks         []hook.Info
+}
+
+var _ = gc.Suite(&RunHooksSuite{})
+
+func (s *RunHooksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.callbacks = &runHooksCallbacks{}
+	s.runnerFactory = &parallelRunnerFactory{errs: make(map[int]error)}
+	s.factory = operation.NewFactory(operation.FactoryParams{
+		Callbacks:     s.callbacks,
+		RunnerFactory: s.runnerFactory,
+	})
+	s.hooks = []hook.Info{{
+		Kind:       hooks.RelationJoined,
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+	}, {
+		Kind:       hooks.RelationChanged,
+		RelationId: 2,
+		RemoteUnit: "memcached/1",
+	}}
+}
+
+func (s *RunHooksSuite) run(c *gc.C) (*operation.State, error) {
+	op, err := s.factory.NewRunHooks(s.hooks)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+
+	newState, err := op.Prepare(operation.State{Kind: operation.Continue, Step: operation.Pending})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.IsNil)
+	return op.Execute(operation.State{Kind: operation.Continue, Step: operation.Pending})
+}
+
+func (s *RunHooksSuite) TestNewRunHooksValidation(c *gc.C) {
+	_, err := s.factory.NewRunHooks(s.hooks[:1])
+	c.Assert(err, gc.ErrorMatches, "at least two hooks required")
+
+	_, err = s.factory.NewRunHooks([]hook.Info{s.hooks[0], {Kind: hooks.ConfigChanged}})
+	c.Assert(err, gc.ErrorMatches, "cannot run config-changed hook concurrently")
+
+	s.hooks[1].RelationId = 1
+	_, err = s.factory.NewRunHooks(s.hooks)
+	c.Assert(err, gc.ErrorMatches, "cannot run two hooks of relation 1 concurrently")
+}
+
+func (s *RunHooksSuite) TestString(c *gc.C) {
+	op, err := s.factory.NewRunHooks(s.hooks)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals,
+		"run relation-joined (1; mysql/0), relation-changed (2; memcached/1) hooks concurrently")
+}
+
+func (s *RunHooksSuite) TestRunHooks(c *gc.C) {
+	newState, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(s.runnerFactory.slots, jc.SameContents, []int{0, 1})
+	c.Assert(s.callbacks.executingMessage, gc.Equals,
+		"running rel1-relation-joined, rel2-relation-changed hooks")
+	c.Assert(s.callbacks.completed, jc.DeepEquals, []string{
+		"rel1-relation-joined", "rel2-relation-changed",
+	})
+	c.Assert(s.callbacks.committed, jc.DeepEquals, s.hooks)
+}
+
+func (s *RunHooksSuite) TestRunHooksFailure(c *gc.C) {
+	s.runnerFactory.errs[2] = fmt.Errorf("exit status 1")
+	newState, err := s.run(c)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &s.hooks[1],
+	})
+	c.Assert(s.callbacks.failed, jc.DeepEquals, []string{"rel2-relation-changed"})
+	c.Assert(s.callbacks.committed, jc.DeepEquals, s.hooks[:1])
+}
+
+func (s *RunHooksSuite) TestRunHooksRequeueAndReboot(c *gc.C) {
+	s.runnerFactory.errs[1] = context.ErrRequeueAndReboot
+	newState, err := s.run(c)
+	c.Assert(err, gc.Equals, operation.ErrNeedsReboot)
+	c.Assert(newState.Kind, gc.Equals, operation.Continue)
+	c.Assert(s.callbacks.committed, jc.DeepEquals, s.hooks[1:])
+}
+
+func (s *RunHooksSuite) TestCommitDoesNothing(c *gc.C) {
+	op, err := s.factory.NewRunHooks(s.hooks)
+	c.Assert(err, jc.ErrorIsNil)
+	newState, err := op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.IsNil)
+}
+
+type runHooksCallbacks struct {
+	operation.Callbacks
+	executingMessage string
+	completed        []string
+	failed           []string
+	committed        []hook.Info
+}
+
+func (cb *runHooksCallbacks) PrepareHook(info hook.Info) (string, error) {
+	return fmt.Sprintf("rel%d-%s", info.RelationId, info.Kind), nil
+}
+
+func (cb *runHooksCallbacks) SetExecutingStatus(message string) error {
+	cb.executingMessage = message
+	return nil
+}
+
+func (cb *runHooksCallbacks) NotifyHookCompleted(name string, _ runner.Context) {
+	cb.completed = append(cb.completed, name)
+}
+
+func (cb *runHooksCallbacks) NotifyHookFailed(name string, _ runner.Context) {
+	cb.failed = append(cb.failed, name)
+}
+
+func (cb *runHooksCallbacks) CommitHook(info hook.Info) error {
+	cb.committed = append(cb.committed, info)
+	return nil
+}
+
+// parallelRunnerFactory creates a separate runner for each hook, whose
+// RunHook returns the error configured for the hook's relation.
+type parallelRunnerFactory struct {
+	runner.Factory
+	mu    sync.Mutex
+	errs  map[int]error
+	slots []int
+}
+
+func (f *parallelRunnerFactory) NewParallelHookRunner(info hook.Info, slot int) (runner.Runner, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.slots = append(f.slots, slot)
+	return &MockRunner{
+		MockRunHook: &MockRunHook{err: f.errs[info.RelationId]},
+		context:     &MockContext{},
+	}, nil
+}
@@ -254,6 +254,10 @@ func (f *MockRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, er
 	return f.MockNewHookRunner.Call(hookInfo)
 }
 
+func (f *MockRunnerFactory) NewParallelHookRunner(hookInfo hook.Info, slot int) (runner.Runner, error) {
+	return f.MockNewHookRunner.Call(hookInfo)
+}
+
 func (f *MockRunnerFactory) NewCommandRunner(commandInfo context.CommandInfo) (runner.Runner, error) {
 	return f.MockNewCommandRunner.Call(commandInfo)
 }
@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// independentMeta holds the part of a charm's metadata that declares
+// which of its endpoints are independent of each other. Hooks of
+// relations on independent endpoints may run concurrently, each in its
+// own context. For example:
+//
+//	independent-relations: [db, cache, website]
+//
+// Charms that do not declare any have all their hooks run one at a
+// time.
+type independentMeta struct {
+	IndependentRelations []string `yaml:"independent-relations"`
+}
+
+// ReadIndependentEndpoints returns the names of the endpoints that the
+// charm in charmDir declares independent.
+func ReadIndependentEndpoints(charmDir string) (set.Strings, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return set.NewStrings(), nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var meta independentMeta
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Annotate(err, "reading independent relations")
+	}
+	return set.NewStrings(meta.IndependentRelations...), nil
+}
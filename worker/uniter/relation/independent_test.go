@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/relation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+type independentSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&independentSuite{})
+
+func (s *independentSuite) writeMetadata(c *gc.C, metadata string) string {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return dir
+}
+
+func (s *independentSuite) TestReadIndependentEndpoints(c *gc.C) {
+	dir := s.writeMetadata(c, `
+name: hub
+summary: hub
+description: hub
+independent-relations: [db, cache]
+`)
+	endpoints, err := relation.ReadIndependentEndpoints(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints.SortedValues(), jc.DeepEquals, []string{"cache", "db"})
+}
+
+func (s *independentSuite) TestReadIndependentEndpointsNoneDeclared(c *gc.C) {
+	dir := s.writeMetadata(c, minimalMetadata)
+	endpoints, err := relation.ReadIndependentEndpoints(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints.IsEmpty(), jc.IsTrue)
+
+	endpoints, err = relation.ReadIndependentEndpoints(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints.IsEmpty(), jc.IsTrue)
+}
+
+func (s *independentSuite) TestReadIndependentEndpointsInvalid(c *gc.C) {
+	dir := s.writeMetadata(c, "independent-relations: {db: true}\n")
+	_, err := relation.ReadIndependentEndpoints(dir)
+	c.Assert(err, gc.ErrorMatches, "reading independent relations: .*")
+}
+
+func (s *independentSuite) TestResolverRunsSingleHook(c *gc.C) {
+	hookInfo := hook.Info{Kind: hooks.RelationJoined, RelationId: 1, RemoteUnit: "mysql/0"}
+	r := relation.NewRelationsResolver(&fakeRelations{hooks: []hook.Info{hookInfo}})
+	op, err := r.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, &mockOperation{hookInfo})
+}
+
+func (s *independentSuite) TestResolverRunsHooksConcurrently(c *gc.C) {
+	hookInfos := []hook.Info{
+		{Kind: hooks.RelationJoined, RelationId: 1, RemoteUnit: "mysql/0"},
+		{Kind: hooks.RelationChanged, RelationId: 2, RemoteUnit: "memcached/0"},
+	}
+	r := relation.NewRelationsResolver(&fakeRelations{hooks: hookInfos})
+	op, err := r.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op, jc.DeepEquals, &mockConcurrentOperation{hookInfos: hookInfos})
+}
+
+type fakeRelations struct {
+	relation.Relations
+	hooks []hook.Info
+}
+
+func (r *fakeRelations) NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error) {
+	return r.hooks, nil
+}
+
+type mockConcurrentOperation struct {
+	operation.Operation
+	hookInfos []hook.Info
+}
@@ -18,6 +18,10 @@ func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, er
 	return &mockOperation{hookInfo}, nil
 }
 
+func (m *mockOperations) NewRunHooks(hookInfos []hook.Info) (operation.Operation, error) {
+	return &mockConcurrentOperation{hookInfos: hookInfos}, nil
+}
+
 type mockOperation struct {
 	hookInfo hook.Info
 }
@@ -4,6 +4,8 @@
 package relation
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -47,6 +49,13 @@ type Relations interface {
 	// NextHook returns details on the next hook to execute, based on the local
 	// and remote states.
 	NextHook(resolver.LocalState, remotestate.Snapshot) (hook.Info, error)
+
+	// NextHooks returns details on the next hooks to execute, based on
+	// the local and remote states. More than one hook is returned only
+	// when hooks of several relations are pending, and the charm
+	// declares those relations' endpoints independent, so that the
+	// hooks may run concurrently.
+	NextHooks(resolver.LocalState, remotestate.Snapshot) ([]hook.Info, error)
 }
 
 // NewRelationsResolver returns a new Resolver that handles differences in
@@ -65,11 +74,14 @@ func (s *relationsResolver) NextOp(
 	remoteState remotestate.Snapshot,
 	opFactory operation.Factory,
 ) (operation.Operation, error) {
-	hook, err := s.relations.NextHook(localState, remoteState)
+	hooks, err := s.relations.NextHooks(localState, remoteState)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return opFactory.NewRunHook(hook)
+	if len(hooks) == 1 {
+		return opFactory.NewRunHook(hooks[0])
+	}
+	return opFactory.NewRunHooks(hooks)
 }
 
 // relations implements Relations.
@@ -196,6 +208,49 @@ func (r *relations) NextHook(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
 ) (hook.Info, error) {
+	hooks, err := r.pendingHooks(localState, remoteState)
+	if err != nil {
+		return hook.Info{}, err
+	}
+	return hooks[0], nil
+}
+
+// NextHooks implements Relations.
+func (r *relations) NextHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) ([]hook.Info, error) {
+	hooks, err := r.pendingHooks(localState, remoteState)
+	if err != nil {
+		return nil, err
+	}
+	if len(hooks) == 1 {
+		return hooks, nil
+	}
+	independent, err := ReadIndependentEndpoints(r.charmDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var concurrent []hook.Info
+	for _, hookInfo := range hooks {
+		relationer := r.relationers[hookInfo.RelationId]
+		if independent.Contains(relationer.ru.Endpoint().Name) {
+			concurrent = append(concurrent, hookInfo)
+		}
+	}
+	if len(concurrent) < 2 {
+		return hooks[:1], nil
+	}
+	return concurrent, nil
+}
+
+// pendingHooks returns the next hook to execute for each relation that
+// has one, ordered by relation id, or ErrNoOperation if there are
+// none.
+func (r *relations) pendingHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) ([]hook.Info, error) {
 
 	if remoteState.Life == params.Dying {
 		// The unit is Dying, so make sure all subordinates are dying.
@@ -216,22 +271,29 @@ func (r *relations) NextHook(
 		}
 		if destroyAllSubordinates {
 			if err := r.unit.DestroyAllSubordinates(); err != nil {
-				return hook.Info{}, errors.Trace(err)
+				return nil, errors.Trace(err)
 			}
 		}
 	}
 
 	// Add/remove local relation state; enter and leave scope as necessary.
 	if err := r.update(remoteState.Relations); err != nil {
-		return hook.Info{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if localState.Kind != operation.Continue {
-		return hook.Info{}, resolver.ErrNoOperation
+		return nil, resolver.ErrNoOperation
 	}
 
 	// See if any of the relations have operations to perform.
-	for relationId, relationSnapshot := range remoteState.Relations {
+	var relationIds []int
+	for relationId := range remoteState.Relations {
+		relationIds = append(relationIds, relationId)
+	}
+	sort.Ints(relationIds)
+	var hooks []hook.Info
+	for _, relationId := range relationIds {
+		relationSnapshot := remoteState.Relations[relationId]
 		relationer, ok := r.relationers[relationId]
 		if !ok || relationer.IsImplicit() {
 			continue
@@ -248,10 +310,15 @@ func (r *relations) NextHook(
 		hook, err := nextRelationHook(relationer.dir, relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
+		} else if err != nil {
+			return nil, err
 		}
-		return hook, err
+		hooks = append(hooks, hook)
 	}
-	return hook.Info{}, resolver.ErrNoOperation
+	if len(hooks) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	return hooks, nil
 }
 
 // nextRelationHook returns the next hook op that should be executed in the
@@ -191,6 +191,7 @@ type mockUnit struct {
 	life                  params.Life
 	resolved              params.ResolvedMode
 	series                string
+	movingTo              string
 	application           mockApplication
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
@@ -220,6 +221,10 @@ func (u *mockUnit) Series() string {
 	return u.series
 }
 
+func (u *mockUnit) MovingTo() string {
+	return u.movingTo
+}
+
 func (u *mockUnit) Tag() names.UnitTag {
 	return u.tag
 }
@@ -71,6 +71,10 @@ type Snapshot struct {
 
 	// Series is the current series running on the unit
 	Series string
+
+	// MovingTo is the id of the machine to which the unit
+	// is being moved, or "" if it is not being moved.
+	MovingTo string
 }
 
 type RelationSnapshot struct {
@@ -86,4 +90,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Shared   bool
 }
@@ -36,6 +36,7 @@ type Unit interface {
 	Resolved() params.ResolvedMode
 	Application() (Application, error)
 	Series() string
+	MovingTo() string
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
 	WatchAddresses() (watcher.NotifyWatcher, error)
@@ -77,11 +77,14 @@ func getStorageSnapshot(
 	if err != nil {
 		return StorageSnapshot{}, errors.Annotate(err, "refreshing storage details")
 	}
+	// Shared storage is owned by the application rather than the unit.
+	_, err = names.ParseApplicationTag(attachment.OwnerTag)
 	snapshot := StorageSnapshot{
 		Life:     attachment.Life,
 		Kind:     attachment.Kind,
 		Attached: true,
 		Location: attachment.Location,
+		Shared:   err == nil,
 	}
 	return snapshot, nil
 }
@@ -5,9 +5,11 @@ package remotestate
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
@@ -35,6 +37,9 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       UpdateStatusTimerFunc
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
+	coalesceWindow            time.Duration
+	resync                    chan struct{}
 
 	catacomb catacomb.Catacomb
 
@@ -52,11 +57,36 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// Clock is used to time the coalescing window. It must be set
+	// if CoalesceWindow is positive.
+	Clock clock.Clock
+
+	// CoalesceWindow is how long to wait after a config, address,
+	// leader settings or relation change before publishing it, so
+	// that a burst of such changes is published as one. Zero
+	// publishes every change immediately.
+	CoalesceWindow time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// a RemoteStateWatcher.
+func (config WatcherConfig) Validate() error {
+	if config.CoalesceWindow < 0 {
+		return errors.NotValidf("negative CoalesceWindow")
+	}
+	if config.CoalesceWindow > 0 && config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
 // supplied unit.
 func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	w := &RemoteStateWatcher{
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
@@ -67,6 +97,9 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     config.Clock,
+		coalesceWindow:            config.CoalesceWindow,
+		resync:                    make(chan struct{}, 1),
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -103,6 +136,19 @@ func (w *RemoteStateWatcher) RemoteStateChanged() <-chan struct{} {
 	return w.out
 }
 
+// Resync asks the watcher to re-read the unit, application and relation
+// state and publish a fresh snapshot. It is much cheaper than restarting
+// the watcher: no watchers are recreated, and the config and leader
+// settings versions are left alone so no hooks are triggered by the
+// resync itself. Resync does not block; requests made while one is
+// pending are merged.
+func (w *RemoteStateWatcher) Resync() {
+	select {
+	case w.resync <- struct{}{}:
+	default:
+	}
+}
+
 func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -306,11 +352,28 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		return errors.Trace(err)
 	}
 
+	// coalesceTimer is non-nil while coalesced changes are waiting
+	// to be published.
+	var coalesceTimer <-chan time.Time
+
 	for {
+		// coalesce is set by changes that may arrive in bursts, and
+		// so can wait for the coalescing window before being published.
+		var coalesce bool
+
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
 
+		case <-coalesceTimer:
+			logger.Debugf("publishing coalesced changes")
+
+		case <-w.resync:
+			logger.Debugf("resyncing remote state")
+			if err := w.resyncState(); err != nil {
+				return errors.Trace(err)
+			}
+
 		case _, ok := <-unitw.Changes():
 			logger.Debugf("got unit change")
 			if !ok {
@@ -339,6 +402,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.configChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			coalesce = true
 			observedEvent(&seenConfigChange)
 
 		case _, ok := <-addressesw.Changes():
@@ -349,6 +413,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.addressesChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			coalesce = true
 			observedEvent(&seenAddressesChange)
 
 		case _, ok := <-leaderSettingsw.Changes():
@@ -359,6 +424,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.leaderSettingsChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			coalesce = true
 			observedEvent(&seenLeaderSettingsChange)
 
 		case actions, ok := <-actionsw.Changes():
@@ -379,6 +445,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.relationsChanged(keys); err != nil {
 				return errors.Trace(err)
 			}
+			coalesce = true
 			observedEvent(&seenRelationsChange)
 
 		case keys, ok := <-storagew.Changes():
@@ -418,6 +485,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.relationUnitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
+			coalesce = true
 
 		case <-w.updateStatusChannel(updateStatusInterval).After():
 			logger.Debugf("update status timer triggered")
@@ -445,10 +513,35 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		}
 
 		// Something changed.
+		if coalesce && w.coalesceWindow > 0 && eventsObserved == requiredEvents {
+			if coalesceTimer == nil {
+				coalesceTimer = w.clock.After(w.coalesceWindow)
+			}
+			continue
+		}
+		// Firing publishes everything, including any changes waiting
+		// to be coalesced.
+		coalesceTimer = nil
 		fire()
 	}
 }
 
+// resyncState re-reads the state of the unit, its application and its
+// known relations.
+func (w *RemoteStateWatcher) resyncState() error {
+	if err := w.unitChanged(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.applicationChanged(); err != nil {
+		return errors.Trace(err)
+	}
+	keys := make([]string, 0, len(w.relations))
+	for tag := range w.relations {
+		keys = append(keys, tag.Id())
+	}
+	return errors.Trace(w.relationsChanged(keys))
+}
+
 // updateStatusChanged is called when the update status timer expires.
 func (w *RemoteStateWatcher) updateStatusChanged() error {
 	w.mu.Lock()
@@ -483,6 +576,7 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
 	w.current.Series = w.unit.Series()
+	w.current.MovingTo = w.unit.MovingTo()
 	return nil
 }
 
@@ -35,6 +35,13 @@ var _ = gc.Suite(&WatcherSuite{})
 
 func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.setUpWatcher(c, 0)
+}
+
+// setUpWatcher starts the watcher under test, with fresh mocks and the
+// given coalescing window.
+func (s *WatcherSuite) setUpWatcher(c *gc.C, coalesceWindow time.Duration) {
 	s.st = &mockState{
 		unit: mockUnit{
 			tag:  names.NewUnitTag("mysql/0"),
@@ -66,7 +73,6 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		minionTicket: mockTicket{make(chan struct{}, 1), true},
 	}
 
-	s.clock = testing.NewClock(time.Now())
 	statusTicker := func(wait time.Duration) remotestate.Waiter {
 		return dummyWaiter{s.clock.After(statusTickDuration)}
 	}
@@ -76,6 +82,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
+		CoalesceWindow:      coalesceWindow,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -179,6 +187,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.movingTo = "1"
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().MovingTo, gc.Equals, "1")
+
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+1)
@@ -568,6 +581,50 @@ func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
 	)
 }
 
+func (s *WatcherSuite) TestResync(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	relationTag := names.NewRelationTag("mysql:db wordpress:db")
+	s.st.relations[relationTag] = &mockRelation{
+		id: 123, life: params.Alive,
+	}
+	s.st.relationUnitsWatchers[relationTag] = newMockRelationUnitsWatcher()
+	s.st.unit.relationsWatcher.changes <- []string{relationTag.Id()}
+	s.st.relationUnitsWatchers[relationTag].changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{"wordpress/0": {1}},
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	initial := s.watcher.Snapshot()
+
+	// Change state behind the watchers' backs.
+	s.st.unit.life = params.Dying
+	s.st.unit.application.forceUpgrade = true
+	s.st.relations[relationTag].life = params.Dying
+
+	s.watcher.Resync()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.Life, gc.Equals, params.Dying)
+	c.Assert(snapshot.ForceCharmUpgrade, jc.IsTrue)
+	c.Assert(snapshot.Relations[123].Life, gc.Equals, params.Dying)
+	c.Assert(snapshot.Relations[123].Members, jc.DeepEquals, map[string]int64{"wordpress/0": 1})
+	c.Assert(snapshot.ConfigVersion, gc.Equals, initial.ConfigVersion)
+	c.Assert(snapshot.LeaderSettingsVersion, gc.Equals, initial.LeaderSettingsVersion)
+	c.Assert(s.st.relationUnitsWatchers[relationTag].Stopped(), jc.IsFalse)
+}
+
+func (s *WatcherSuite) TestCoalesceWindowRequiresClock(c *gc.C) {
+	_, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:          s.st,
+		UnitTag:        s.st.unit.tag,
+		CoalesceWindow: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
 func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	initial := s.watcher.Snapshot()
@@ -611,3 +668,55 @@ func (s *WatcherSuite) waitAlarmsStable(c *gc.C) {
 		}
 	}
 }
+
+func (s *WatcherSuite) restartWatcher(c *gc.C, coalesceWindow time.Duration) {
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.setUpWatcher(c, coalesceWindow)
+}
+
+func (s *WatcherSuite) TestCoalescesBurst(c *gc.C) {
+	s.restartWatcher(c, time.Second)
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	initial := s.watcher.Snapshot()
+
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	s.st.unit.addressesWatcher.changes <- struct{}{}
+	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.ConfigVersion, gc.Equals, initial.ConfigVersion+3)
+	c.Assert(snapshot.LeaderSettingsVersion, gc.Equals, initial.LeaderSettingsVersion+1)
+}
+
+func (s *WatcherSuite) TestCoalescedChangesPublishedWithOthers(c *gc.C) {
+	s.restartWatcher(c, time.Second)
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	initial := s.watcher.Snapshot()
+
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+
+	// A unit change is published straight away, along with the
+	// config change that was waiting.
+	s.st.unit.life = params.Dying
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.Life, gc.Equals, params.Dying)
+	c.Assert(snapshot.ConfigVersion, gc.Equals, initial.ConfigVersion+1)
+
+	// Nothing is left to publish when the window closes.
+	s.waitAlarmsStable(c)
+	s.clock.Advance(time.Second)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+}
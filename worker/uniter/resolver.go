@@ -19,6 +19,7 @@ type ResolverConfig struct {
 	ClearResolved       func() error
 	ReportHookError     func(hook.Info) error
 	ShouldRetryHooks    bool
+	MaxHookRetries      int
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
 	Leadership          resolver.Resolver
@@ -31,6 +32,10 @@ type ResolverConfig struct {
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// retryHookAttempts counts the automatic retries of the
+	// currently failing hook.
+	retryHookAttempts int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -41,6 +46,13 @@ func NewUniterResolver(cfg ResolverConfig) resolver.Resolver {
 	}
 }
 
+// retryHooksExhausted returns whether the failing hook has been
+// retried automatically as many times as allowed.
+func (s *uniterResolver) retryHooksExhausted() bool {
+	max := s.config.MaxHookRetries
+	return max > 0 && s.retryHookAttempts >= max
+}
+
 func (s *uniterResolver) NextOp(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
@@ -65,12 +77,16 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrRestart
 	}
 
-	if s.retryHookTimerStarted && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
-		// The hook-retry timer is running, but there is no pending
-		// hook operation. We're not in an error state, so stop the
-		// timer now to reset the backoff state.
-		s.config.StopRetryHookTimer()
-		s.retryHookTimerStarted = false
+	if localState.Kind != operation.RunHook || localState.Step != operation.Pending {
+		// There is no pending hook operation, so we're not in an
+		// error state; the next failing hook may be retried afresh.
+		s.retryHookAttempts = 0
+		if s.retryHookTimerStarted {
+			// The hook-retry timer is running; stop it now to
+			// reset the backoff state.
+			s.config.StopRetryHookTimer()
+			s.retryHookTimerStarted = false
+		}
 	}
 
 	op, err := s.config.Leadership.NextOp(localState, remoteState, opFactory)
@@ -172,9 +188,13 @@ func (s *uniterResolver) nextOpHookError(
 			// timer. If the hook succeeds, we'll enter nextOp
 			// and stop the timer.
 			s.retryHookTimerStarted = false
+			s.retryHookAttempts++
+			if s.retryHooksExhausted() {
+				logger.Infof("retrying %q hook for the last time", localState.Hook.Kind)
+			}
 			return opFactory.NewRunHook(*localState.Hook)
 		}
-		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks && !s.retryHooksExhausted() {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
@@ -185,6 +205,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -192,6 +213,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -248,6 +270,16 @@ func (s *uniterResolver) nextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	if remoteState.MovingTo != "" {
+		// The unit is being moved to another machine; stop it
+		// here, and tell the uniter to terminate so the move
+		// can be completed.
+		if localState.Started {
+			return opFactory.NewRunHook(hook.Info{Kind: hooks.Stop})
+		}
+		return nil, resolver.ErrTerminate
+	}
+
 	// Now that storage hooks have run at least once, before anything else,
 	// we need to run the install hook.
 	// TODO(cmars): remove !localState.Started. It's here as a temporary
@@ -40,6 +40,16 @@ func (s *resolverOpFactory) NewRunHook(info hook.Info) (operation.Operation, err
 	return s.wrapHookOp(op, info), nil
 }
 
+func (s *resolverOpFactory) NewRunHooks(infos []hook.Info) (operation.Operation, error) {
+	op, err := s.Factory.NewRunHooks(infos)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Concurrent hooks are all relation hooks, which share the same
+	// local state changes.
+	return s.wrapHookOp(op, infos[0]), nil
+}
+
 func (s *resolverOpFactory) NewSkipHook(info hook.Info) (operation.Operation, error) {
 	op, err := s.Factory.NewSkipHook(info)
 	if err != nil {
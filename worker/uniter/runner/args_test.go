@@ -99,3 +99,35 @@ func (s *WindowsHookSuite) TestSearchHookWindowsError(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, filepath.FromSlash("hooks/something-happened does not exist"))
 	c.Assert(obtained, gc.Equals, "")
 }
+
+func (s *WindowsHookSuite) TestSearchHookWindowsRunsPowerShell(c *gc.C) {
+	restorer := envtesting.PatchValue(&os.HostOS, func() os.OSType { return os.Windows })
+	defer restorer()
+
+	// A .ps1 hook is preferred over other suffixes, and is run
+	// through powershell.exe.
+	charmDir := c.MkDir()
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: "install.ps1",
+		perm: 0755,
+	}, charmDir)
+	for _, name := range []string{"install.cmd", "install.exe"} {
+		makeCharm(c, hookSpec{
+			name: filepath.Join("hooks", name),
+			perm: 0755,
+		}, charmDir)
+	}
+
+	hook, err := runner.SearchHook(charmDir, filepath.Join("hooks", "install"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hook, gc.Equals, filepath.Join(charmDir, "hooks", "install.ps1"))
+	c.Assert(runner.HookCommand(hook), gc.DeepEquals, []string{
+		"powershell.exe",
+		"-NonInteractive",
+		"-ExecutionPolicy",
+		"RemoteSigned",
+		"-File",
+		hook,
+	})
+}
@@ -15,6 +15,14 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+	Artifacts      []ActionArtifact
+}
+
+// ActionArtifact holds a file recorded with action-artifact, to be
+// stored when the Action completes.
+type ActionArtifact struct {
+	Name    string
+	Content []byte
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -516,6 +516,24 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// AddActionArtifact records a file to be stored with the results of
+// the Action when it completes.
+func (ctx *HookContext) AddActionArtifact(name string, content []byte) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	for _, artifact := range ctx.actionData.Artifacts {
+		if artifact.Name == name {
+			return errors.AlreadyExistsf("artifact %q", name)
+		}
+	}
+	ctx.actionData.Artifacts = append(ctx.actionData.Artifacts, ActionArtifact{
+		Name:    name,
+		Content: content,
+	})
+	return nil
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -625,6 +643,16 @@ func (ctx *HookContext) handleReboot(err *error) {
 		*err = ErrReboot
 	case jujuc.RebootNow:
 		*err = ErrRequeueAndReboot
+	case jujuc.RebootCoordinated:
+		// The controller sets the machine's reboot flag when it is
+		// this unit's turn; the unit carries on until then.
+		if *err != nil {
+			return
+		}
+		if reqErr := ctx.unit.RequestCoordinatedReboot(); reqErr != nil {
+			*err = reqErr
+		}
+		return
 	}
 	err2 := ctx.unit.SetUnitStatus(status.Rebooting, "", nil)
 	if err2 != nil {
@@ -757,6 +785,17 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// Artifacts are stored while the action is still running; if any
+	// can't be stored, the action fails.
+	for _, artifact := range ctx.actionData.Artifacts {
+		if callErr := ctx.state.AddActionArtifact(tag, artifact.Name, artifact.Content); callErr != nil {
+			logger.Errorf("cannot store artifact %q of action %s: %v", artifact.Name, tag.Id(), callErr)
+			status = params.ActionFailed
+			message = fmt.Sprintf("cannot store artifact %q: %v", artifact.Name, callErr)
+			break
+		}
+	}
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -240,6 +240,8 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.AddActionArtifact("dump.sql", nil)
+	c.Check(err, gc.ErrorMatches, "not running an action")
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -302,6 +304,21 @@ func (s *InterfaceSuite) TestSetActionFailed(c *gc.C) {
 	c.Check(actionData.Failed, jc.IsTrue)
 }
 
+// TestAddActionArtifact ensures AddActionArtifact works properly.
+func (s *InterfaceSuite) TestAddActionArtifact(c *gc.C) {
+	hctx := context.GetStubActionContext(nil)
+	err := hctx.AddActionArtifact("dump.sql", []byte("create table"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = hctx.AddActionArtifact("dump.sql", []byte("drop table"))
+	c.Assert(err, gc.ErrorMatches, `artifact "dump.sql" already exists`)
+	actionData, err := hctx.ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actionData.Artifacts, jc.DeepEquals, []context.ActionArtifact{{
+		Name:    "dump.sql",
+		Content: []byte("create table"),
+	}})
+}
+
 // TestSetActionMessage ensures SetActionMessage works properly.
 func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	hctx := context.GetStubActionContext(nil)
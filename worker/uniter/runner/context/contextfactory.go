@@ -302,13 +302,19 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.slaLevel = sla
 
-	// TODO(fwereade) 23-10-2014 bug 1384572
-	// Nothing here should ever be getting the environ config directly.
-	modelConfig, err := f.state.ModelConfig()
-	if err != nil {
-		return err
+	ctx.proxySettings, err = f.unit.ProxySettings()
+	if errors.IsNotSupported(err) {
+		// Older controllers don't support application overrides.
+		// TODO(fwereade) 23-10-2014 bug 1384572
+		// Nothing here should ever be getting the environ config directly.
+		modelConfig, err := f.state.ModelConfig()
+		if err != nil {
+			return err
+		}
+		ctx.proxySettings = modelConfig.ProxySettings()
+	} else if err != nil {
+		return errors.Annotate(err, "could not retrieve proxy settings")
 	}
-	ctx.proxySettings = modelConfig.ProxySettings()
 
 	// Calling these last, because there's a potential race: they're not guaranteed
 	// to be set in time to be needed for a hook. If they're not, we just leave them
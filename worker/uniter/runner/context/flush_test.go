@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -92,6 +93,35 @@ func (s *FlushContextSuite) TestRunHookRelationFlushingSuccess(c *gc.C) {
 	})
 }
 
+func (s *FlushContextSuite) TestRunHookRequestsCoordinatedReboot(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.RequestReboot(jujuc.RebootCoordinated)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A coordinated reboot does not stop the uniter.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 1)
+	c.Assert(requests[0].Unit, gc.Equals, "u/0")
+	c.Assert(requests[0].Granted, jc.IsFalse)
+}
+
+func (s *FlushContextSuite) TestRunHookFailureSkipsCoordinatedReboot(c *gc.C) {
+	ctx := s.context(c)
+	err := ctx.RequestReboot(jujuc.RebootCoordinated)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	requests, err := s.State.RebootRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.HasLen, 0)
+}
+
 func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	// Initially, no port ranges are open on the unit or its machine.
 	unitRanges, err := s.unit.OpenedPorts()
@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+// ExecParams describes a process to be started by an ExecBackend.
+type ExecParams struct {
+	// Args holds the command to run, and its arguments.
+	Args []string
+
+	// Env holds the environment of the process, in "key=value" form.
+	Env []string
+
+	// Dir is the working directory of the process.
+	Dir string
+
+	// Stdin, Stdout and Stderr are connected to the process's
+	// standard input, output and error, if not nil.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Process is a process started by an ExecBackend.
+type Process interface {
+	context.HookProcess
+
+	// Wait waits for the process to exit, returning an error if it
+	// could not be waited for or exited unsuccessfully.
+	Wait() error
+}
+
+// ExecBackend starts the processes which run charm hooks, actions
+// and commands. The local backend starts them directly on the
+// machine; other backends may start them in a separate process
+// namespace, such as a container or pod.
+type ExecBackend interface {
+	// Start starts the process described by params.
+	Start(params ExecParams) (Process, error)
+
+	// BridgeSocket makes the jujuc server listening on the given
+	// socket reachable from the processes started by the backend.
+	// It returns the socket path those processes should use, and a
+	// Closer that tears down the bridge.
+	BridgeSocket(socketPath string) (string, io.Closer, error)
+}
+
+// LocalExecBackend returns an ExecBackend which starts processes
+// directly on the machine.
+func LocalExecBackend() ExecBackend {
+	return localExecBackend{}
+}
+
+type localExecBackend struct{}
+
+// Start is part of the ExecBackend interface.
+func (localExecBackend) Start(params ExecParams) (Process, error) {
+	if len(params.Args) == 0 {
+		return nil, errors.NotValidf("empty Args")
+	}
+	return startProcess(params.Args, params)
+}
+
+// BridgeSocket is part of the ExecBackend interface. Processes on
+// the machine can reach the socket directly.
+func (localExecBackend) BridgeSocket(socketPath string) (string, io.Closer, error) {
+	return socketPath, nopCloser{}, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// startProcess starts the given command with the environment, working
+// directory and standard streams described by params.
+func startProcess(args []string, params ExecParams) (Process, error) {
+	ps := exec.Command(args[0], args[1:]...)
+	ps.Env = params.Env
+	ps.Dir = params.Dir
+	ps.Stdin = params.Stdin
+	ps.Stdout = params.Stdout
+	ps.Stderr = params.Stderr
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &cmdProcess{ps}, nil
+}
+
+// cmdProcess implements Process for an *exec.Cmd.
+type cmdProcess struct {
+	cmd *exec.Cmd
+}
+
+// Pid is part of the Process interface.
+func (p *cmdProcess) Pid() int {
+	return p.cmd.Process.Pid
+}
+
+// Kill is part of the Process interface.
+func (p *cmdProcess) Kill() error {
+	return p.cmd.Process.Kill()
+}
+
+// Wait is part of the Process interface.
+func (p *cmdProcess) Wait() error {
+	return p.cmd.Wait()
+}
@@ -4,6 +4,8 @@
 package runner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -27,24 +29,36 @@ type Factory interface {
 	// supplied hook definition (which must be valid).
 	NewHookRunner(hookInfo hook.Info) (Runner, error)
 
+	// NewParallelHookRunner returns an execution context suitable for
+	// running the supplied hook definition concurrently with other
+	// hooks. Each concurrent runner must be given a distinct slot, which
+	// selects the socket on which its hook tools are served.
+	NewParallelHookRunner(hookInfo hook.Info, slot int) (Runner, error)
+
 	// NewActionRunner returns an execution context suitable for running the
 	// action identified by the supplied id.
 	NewActionRunner(actionId string) (Runner, error)
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands, whose processes are started with
+// the supplied backend.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	backend ExecBackend,
 ) (
 	Factory, error,
 ) {
+	if backend == nil {
+		return nil, errors.NotValidf("nil backend")
+	}
 	f := &factory{
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		backend:        backend,
 	}
 
 	return f, nil
@@ -57,7 +71,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths   context.Paths
+	backend ExecBackend
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -66,7 +81,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := NewBackendRunner(ctx, f.paths, f.backend)
 	return runner, nil
 }
 
@@ -80,10 +95,41 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := NewBackendRunner(ctx, f.paths, f.backend)
 	return runner, nil
 }
 
+// NewParallelHookRunner exists to satisfy the Factory interface.
+func (f *factory) NewParallelHookRunner(hookInfo hook.Info, slot int) (Runner, error) {
+	if err := hookInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ctx, err := f.contextFactory.HookContext(hookInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := slotPaths{
+		Paths:  f.paths,
+		socket: fmt.Sprintf("%s-%d", f.paths.GetJujucSocket(), slot),
+	}
+	runner := NewBackendRunner(ctx, paths, f.backend)
+	return runner, nil
+}
+
+// slotPaths overrides the jujuc socket of the wrapped Paths, so that
+// hooks running concurrently each serve their hook tools on their own
+// socket.
+type slotPaths struct {
+	context.Paths
+	socket string
+}
+
+// GetJujucSocket is part of the context.Paths interface.
+func (p slotPaths) GetJujucSocket() string {
+	return p.socket
+}
+
 // NewActionRunner exists to satisfy the Factory interface.
 func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	ch, err := getCharm(f.paths.GetCharmDir())
@@ -123,7 +169,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	runner := NewBackendRunner(ctx, f.paths, f.backend)
 	return runner, nil
 }
 
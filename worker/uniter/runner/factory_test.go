@@ -113,6 +113,14 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertPaths(c, rnr)
 }
 
+func (s *FactorySuite) TestNewParallelHookRunner(c *gc.C) {
+	rnr, err := s.factory.NewParallelHookRunner(hook.Info{Kind: hooks.ConfigChanged}, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runner.RunnerPaths(rnr)
+	c.Assert(paths.GetJujucSocket(), gc.Equals, s.paths.GetJujucSocket()+"-2")
+	c.Assert(paths.GetCharmDir(), gc.Equals, s.paths.GetCharmDir())
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -174,6 +182,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		runner.LocalExecBackend(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
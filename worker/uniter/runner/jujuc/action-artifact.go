@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// maxActionArtifactSize is the largest file that may be stored as an
+// action artifact. It matches the limit enforced by the controller.
+const maxActionArtifactSize = 4 * 1024 * 1024
+
+// ActionArtifactCommand implements the action-artifact command.
+type ActionArtifactCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	path string
+	name string
+}
+
+// NewActionArtifactCommand returns a new ActionArtifactCommand with the
+// given context.
+func NewActionArtifactCommand(ctx Context) (cmd.Command, error) {
+	return &ActionArtifactCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionArtifactCommand) Info() *cmd.Info {
+	doc := `
+action-artifact stores a file with the results of the running action. The
+file is read when action-artifact is run, and is uploaded to the controller
+when the action completes. Artifacts are named after the file unless a name
+is given with --name; names must be unique within an action. An action may
+store up to 10 artifacts of up to 4MiB each. The artifacts are listed by
+"juju show-action-output", which can also save them.
+`
+	return &cmd.Info{
+		Name:    "action-artifact",
+		Args:    "<path>",
+		Purpose: "store a file with the action's results",
+		Doc:     doc,
+	}
+}
+
+// SetFlags adds the --name flag.
+func (c *ActionArtifactCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.name, "name", "", "name of the artifact (default is the file name)")
+}
+
+// Init sets the path and checks for malformed invocations.
+func (c *ActionArtifactCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no path specified")
+	}
+	c.path = args[0]
+	if c.name == "" {
+		c.name = filepath.Base(c.path)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run reads the file and records it as an artifact of the Action.
+func (c *ActionArtifactCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !info.Mode().IsRegular() {
+		return errors.Errorf("%q is not a regular file", c.path)
+	}
+	if info.Size() > maxActionArtifactSize {
+		return errors.Errorf("%q is larger than %d bytes", c.path, maxActionArtifactSize)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.ctx.AddActionArtifact(c.name, content)
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionArtifactSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&ActionArtifactSuite{})
+
+type actionArtifactContext struct {
+	jujuc.Context
+	artifacts map[string]string
+}
+
+func (ctx *actionArtifactContext) AddActionArtifact(name string, content []byte) error {
+	if ctx.artifacts == nil {
+		return fmt.Errorf("not running an action")
+	}
+	ctx.artifacts[name] = string(content)
+	return nil
+}
+
+func (s *ActionArtifactSuite) run(c *gc.C, hctx jujuc.Context, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString("action-artifact"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *ActionArtifactSuite) TestActionArtifact(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "dump.sql"), []byte("create table"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, args := range [][]string{
+		{filepath.Join(dir, "dump.sql")},
+		{"--name", "backup.sql", filepath.Join(dir, "dump.sql")},
+	} {
+		c.Logf("test %d: %v", i, args)
+		hctx := &actionArtifactContext{artifacts: make(map[string]string)}
+		code, ctx := s.run(c, hctx, args...)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		name := filepath.Base(args[len(args)-1])
+		if len(args) > 1 {
+			name = args[1]
+		}
+		c.Check(hctx.artifacts, jc.DeepEquals, map[string]string{name: "create table"})
+	}
+}
+
+func (s *ActionArtifactSuite) TestActionArtifactErrors(c *gc.C) {
+	dir := c.MkDir()
+	big := filepath.Join(dir, "big")
+	err := ioutil.WriteFile(big, make([]byte, 4*1024*1024+1), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, t := range []struct {
+		args   []string
+		code   int
+		errMsg string
+	}{{
+		args:   nil,
+		code:   2,
+		errMsg: "ERROR no path specified\n",
+	}, {
+		args:   []string{big, "extra"},
+		code:   2,
+		errMsg: "ERROR unrecognized args: [\"extra\"]\n",
+	}, {
+		args:   []string{filepath.Join(dir, "missing")},
+		code:   1,
+		errMsg: fmt.Sprintf("ERROR stat %s: no such file or directory\n", filepath.Join(dir, "missing")),
+	}, {
+		args:   []string{dir},
+		code:   1,
+		errMsg: fmt.Sprintf("ERROR %q is not a regular file\n", dir),
+	}, {
+		args:   []string{big},
+		code:   1,
+		errMsg: fmt.Sprintf("ERROR %q is larger than 4194304 bytes\n", big),
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := &actionArtifactContext{artifacts: make(map[string]string)}
+		code, ctx := s.run(c, hctx, t.args...)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.artifacts, gc.HasLen, 0)
+	}
+}
+
+func (s *ActionArtifactSuite) TestNonActionContext(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "dump.sql")
+	err := ioutil.WriteFile(path, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	code, ctx := s.run(c, &actionArtifactContext{}, path)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR not running an action\n")
+}
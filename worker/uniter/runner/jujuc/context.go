@@ -31,6 +31,10 @@ const (
 	// RebootNow means reboot immediately, killing and requeueing the
 	// calling hook
 	RebootNow
+	// RebootCoordinated means wait for the current hook to finish,
+	// then ask the controller to reboot once no other unit of the
+	// application is rebooting.
+	RebootCoordinated
 )
 
 // Context is the interface that all hook helper commands
@@ -98,6 +102,10 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// AddActionArtifact records a file to be stored with the
+	// Action's results upon completion of the Action.
+	AddActionArtifact(name string, content []byte) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -287,6 +295,10 @@ type ContextStorageAttachment interface {
 	// Location returns the location of the storage: the mount point for
 	// filesystem-kind stores, and the device path for block-kind stores.
 	Location() string
+
+	// Shared reports whether the storage is shared by all units of
+	// the application, rather than belonging to the unit alone.
+	Shared() bool
 }
 
 // ContextVersion expresses the parts of a hook context related to
@@ -48,7 +48,8 @@ func (c *NetworkGetCommand) Info() *cmd.Info {
 	doc := `
 network-get returns the network config for a given binding name. By default
 it returns the list of interfaces and associated addresses in the space for
-the binding, as well as the ingress address for the binding. If defined, any
+the binding, as well as the ingress address for the binding. Each interface
+includes its type, parent interface and VLAN tag, when known. If defined, any
 egress subnets are also returned.
 If one of the following flags are specified, just that value is returned.
 If more than one flag is specified, a map of values is returned.
@@ -59,7 +59,10 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 	presetBindings["known-extra"] = params.NetworkInfoResult{
 		Info: []params.NetworkInfo{
 			{MACAddress: "00:11:22:33:44:22",
-				InterfaceName: "eth2",
+				InterfaceName:       "bond0.100",
+				InterfaceType:       "802.1q",
+				ParentInterfaceName: "bond0",
+				VLANTag:             100,
 				Addresses: []params.InterfaceAddress{
 					{
 						Address: "10.20.1.42",
@@ -163,7 +166,10 @@ ingress-address: 10.20.1.42`[1:],
 		out: `
 bind-addresses:
 - macaddress: "00:11:22:33:44:22"
-  interfacename: eth2
+  interfacename: bond0.100
+  interfacetype: 802.1q
+  parentinterfacename: bond0
+  vlantag: 100
   addresses:
   - address: 10.20.1.42
     cidr: 10.20.1.42/24
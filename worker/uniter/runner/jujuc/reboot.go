@@ -13,8 +13,9 @@ import (
 // JujuRebootCommand implements the juju-reboot command.
 type JujuRebootCommand struct {
 	cmd.CommandBase
-	ctx Context
-	Now bool
+	ctx         Context
+	Now         bool
+	Coordinated bool
 }
 
 func NewJujuRebootCommand(ctx Context) (cmd.Command, error) {
@@ -37,6 +38,13 @@ func (c *JujuRebootCommand) Info() *cmd.Info {
 	be sure to terminate on unexpected errors, so as to guarantee expected behaviour
 	in all situations.
 
+	If the --coordinated flag is passed, the reboot is requested from the
+	controller once the current hook completes successfully. The controller
+	reboots the machines of the application's units one at a time, and waits
+	for each rebooted unit, and every other unit of the application, to be
+	active again before rebooting the next. --coordinated and --now cannot be
+	used together.
+
 	juju-reboot is not supported when running actions.
 	`
 	return &cmd.Info{
@@ -49,9 +57,13 @@ func (c *JujuRebootCommand) Info() *cmd.Info {
 
 func (c *JujuRebootCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Now, "now", false, "reboot immediately, killing the invoking process")
+	f.BoolVar(&c.Coordinated, "coordinated", false, "reboot when the controller allows, one unit of the application at a time")
 }
 
 func (c *JujuRebootCommand) Init(args []string) error {
+	if c.Now && c.Coordinated {
+		return errors.New("--now and --coordinated cannot be used together")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -61,8 +73,11 @@ func (c *JujuRebootCommand) Run(ctx *cmd.Context) error {
 	}
 
 	rebootPriority := RebootAfterHook
-	if c.Now {
+	switch {
+	case c.Now:
 		rebootPriority = RebootNow
+	case c.Coordinated:
+		rebootPriority = RebootCoordinated
 	}
 
 	return c.ctx.RequestReboot(rebootPriority)
@@ -269,6 +269,47 @@ var newCommandTests = []struct {
 	{"random", "unknown command: random(.exe)?"},
 }
 
+func (s *NewCommandSuite) TestCommandNames(c *gc.C) {
+	// Hooks on Windows run the tools through their .exe names.
+	if runtime.GOOS == "windows" {
+		c.Assert(jujuc.CmdSuffix, gc.Equals, ".exe")
+	} else {
+		c.Assert(jujuc.CmdSuffix, gc.Equals, "")
+	}
+	expected := []string{
+		"action-artifact",
+		"action-fail",
+		"action-get",
+		"action-set",
+		"add-metric",
+		"application-version-set",
+		"close-port",
+		"config-get",
+		"is-leader",
+		"juju-log",
+		"juju-reboot",
+		"leader-get",
+		"leader-set",
+		"network-get",
+		"open-port",
+		"opened-ports",
+		"relation-get",
+		"relation-ids",
+		"relation-list",
+		"relation-set",
+		"status-get",
+		"status-set",
+		"storage-add",
+		"storage-get",
+		"storage-list",
+		"unit-get",
+	}
+	for i, name := range expected {
+		expected[i] = cmdString(name)
+	}
+	c.Assert(jujuc.CommandNames(), jc.DeepEquals, expected)
+}
+
 func (s *NewCommandSuite) TestNewCommand(c *gc.C) {
 	ctx, _ := s.newHookContext(0, "")
 	for _, t := range newCommandTests {
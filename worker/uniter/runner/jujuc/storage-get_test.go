@@ -32,6 +32,7 @@ var storageGetTests = []struct {
 	{[]string{"--format", "json"}, formatJson, storageAttributes},
 	{[]string{}, formatYaml, storageAttributes},
 	{[]string{"location"}, -1, "/dev/sda\n"},
+	{[]string{"shared"}, -1, "False\n"},
 }
 
 func (s *storageGetSuite) TestOutputFormatKey(c *gc.C) {
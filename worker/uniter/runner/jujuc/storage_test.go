@@ -13,6 +13,7 @@ var (
 	storageAttributes = map[string]interface{}{
 		"location": "/dev/sda",
 		"kind":     "block",
+		"shared":   false,
 	}
 
 	storageName = "data/0"
@@ -69,3 +69,16 @@ func (c *ContextActionHook) SetActionFailed() error {
 	}
 	return nil
 }
+
+// AddActionArtifact implements jujuc.ActionHookContext.
+func (c *ContextActionHook) AddActionArtifact(name string, content []byte) error {
+	c.stub.AddCall("AddActionArtifact", name, content)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ type StorageAttachment struct {
 	Tag      names.StorageTag
 	Kind     storage.StorageKind
 	Location string
+	Shared   bool
 }
 
 // ContextStorageAttachment is a test double for jujuc.ContextStorageAttachment.
@@ -45,3 +46,11 @@ func (c *ContextStorageAttachment) Location() string {
 
 	return c.info.Location
 }
+
+// Shared implements jujuc.StorageAttachement.
+func (c *ContextStorageAttachment) Shared() bool {
+	c.stub.AddCall("Shared")
+	c.stub.NextErr()
+
+	return c.info.Shared
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/juju/sockets"
+)
+
+// RemoteExecConfig holds the configuration of an ExecBackend which
+// starts processes in a separate process namespace, such as a
+// container or pod. The charm directory must be visible in the
+// namespace at the same path as on the machine.
+type RemoteExecConfig struct {
+	// Command is the command, and its leading arguments, which runs
+	// the command following it in the namespace; for example
+	// "nsenter --target 1234 --mount --pid --" or
+	// "kubectl exec -i mypod --".
+	Command []string
+
+	// SocketDir is a directory, shared with the namespace, in which
+	// the sockets bridging the jujuc server are created.
+	SocketDir string
+
+	// RemoteSocketDir is the path at which SocketDir is visible in
+	// the namespace.
+	RemoteSocketDir string
+}
+
+// Validate returns an error if the config cannot be used to create
+// a remote ExecBackend.
+func (config RemoteExecConfig) Validate() error {
+	if len(config.Command) == 0 {
+		return errors.NotValidf("empty Command")
+	}
+	if config.SocketDir == "" {
+		return errors.NotValidf("empty SocketDir")
+	}
+	if config.RemoteSocketDir == "" {
+		return errors.NotValidf("empty RemoteSocketDir")
+	}
+	return nil
+}
+
+// NewRemoteExecBackend returns an ExecBackend which starts processes
+// in the namespace described by config.
+func NewRemoteExecBackend(config RemoteExecConfig) (ExecBackend, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &remoteExecBackend{config}, nil
+}
+
+type remoteExecBackend struct {
+	config RemoteExecConfig
+}
+
+// Start is part of the ExecBackend interface. The environment and
+// working directory are set up inside the namespace, since the
+// command which enters it need not pass them on. Killing the
+// process kills that command.
+func (b *remoteExecBackend) Start(params ExecParams) (Process, error) {
+	if len(params.Args) == 0 {
+		return nil, errors.NotValidf("empty Args")
+	}
+	args := append([]string{}, b.config.Command...)
+	args = append(args, "env")
+	args = append(args, params.Env...)
+	if params.Dir != "" {
+		args = append(args, "/bin/sh", "-c", `cd "$0" && exec "$@"`, params.Dir)
+	}
+	args = append(args, params.Args...)
+	return startProcess(args, ExecParams{
+		Stdin:  params.Stdin,
+		Stdout: params.Stdout,
+		Stderr: params.Stderr,
+	})
+}
+
+// BridgeSocket is part of the ExecBackend interface. Connections to
+// a socket in the shared directory are forwarded to the given socket,
+// which may not be reachable from the namespace; for example, when
+// it is an abstract socket in the machine's network namespace.
+func (b *remoteExecBackend) BridgeSocket(socketPath string) (string, io.Closer, error) {
+	name := filepath.Base(socketPath)
+	listener, err := sockets.Listen(filepath.Join(b.config.SocketDir, name))
+	if err != nil {
+		return "", nil, errors.Annotate(err, "listening on bridge socket")
+	}
+	bridge := &socketBridge{
+		listener: listener,
+		target:   socketPath,
+	}
+	bridge.wg.Add(1)
+	go bridge.run()
+	return filepath.Join(b.config.RemoteSocketDir, name), bridge, nil
+}
+
+// socketBridge forwards the connections accepted by a listener to
+// a target socket.
+type socketBridge struct {
+	listener net.Listener
+	target   string
+	wg       sync.WaitGroup
+}
+
+func (b *socketBridge) run() {
+	defer b.wg.Done()
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			// The listener has been closed.
+			return
+		}
+		go b.forward(conn)
+	}
+}
+
+func (b *socketBridge) forward(conn net.Conn) {
+	defer conn.Close()
+	target, err := net.Dial("unix", b.target)
+	if err != nil {
+		logger.Errorf("cannot connect to %q: %v", b.target, err)
+		return
+	}
+	defer target.Close()
+	done := make(chan struct{}, 2)
+	copyConn := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go copyConn(target, conn)
+	go copyConn(conn, target)
+	// Either side closing ends the connection.
+	<-done
+}
+
+// Close stops the bridge accepting connections.
+func (b *socketBridge) Close() error {
+	err := b.listener.Close()
+	b.wg.Wait()
+	return errors.Trace(err)
+}
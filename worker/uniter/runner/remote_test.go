@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner_test
+
+import (
+	"bytes"
+	"net"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner"
+	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
+)
+
+type RemoteExecSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&RemoteExecSuite{})
+
+// newBackend returns a remote backend whose "namespace" is the
+// machine itself, entered by running env.
+func (s *RemoteExecSuite) newBackend(c *gc.C) (runner.ExecBackend, string) {
+	socketDir := c.MkDir()
+	backend, err := runner.NewRemoteExecBackend(runner.RemoteExecConfig{
+		Command:         []string{"env"},
+		SocketDir:       socketDir,
+		RemoteSocketDir: socketDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return backend, socketDir
+}
+
+func (s *RemoteExecSuite) TestValidate(c *gc.C) {
+	_, err := runner.NewRemoteExecBackend(runner.RemoteExecConfig{
+		Command:   []string{"nsenter", "--target", "1", "--"},
+		SocketDir: "/var/run/juju",
+	})
+	c.Assert(err, gc.ErrorMatches, "empty RemoteSocketDir not valid")
+}
+
+func (s *RemoteExecSuite) TestStart(c *gc.C) {
+	backend, _ := s.newBackend(c)
+	dir := c.MkDir()
+	var stdout bytes.Buffer
+	process, err := backend.Start(runner.ExecParams{
+		Args:   []string{"/bin/sh", "-c", "echo $FOO; pwd"},
+		Env:    []string{"FOO=bar"},
+		Dir:    dir,
+		Stdout: &stdout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(process.Wait(), jc.ErrorIsNil)
+	c.Assert(stdout.String(), gc.Equals, "bar\n"+dir+"\n")
+}
+
+func (s *RemoteExecSuite) TestBridgeSocket(c *gc.C) {
+	target := filepath.Join(c.MkDir(), "agent.socket")
+	listener, err := net.Listen("unix", target)
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4)
+		if _, err := conn.Read(buf); err == nil {
+			conn.Write(buf)
+		}
+	}()
+
+	backend, socketDir := s.newBackend(c)
+	socketPath, bridge, err := backend.BridgeSocket(target)
+	c.Assert(err, jc.ErrorIsNil)
+	defer bridge.Close()
+	c.Assert(socketPath, gc.Equals, filepath.Join(socketDir, "agent.socket"))
+
+	conn, err := net.Dial("unix", socketPath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping"))
+	c.Assert(err, jc.ErrorIsNil)
+	buf := make([]byte, 4)
+	_, err = conn.Read(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf), gc.Equals, "ping")
+}
+
+func (s *RemoteExecSuite) TestRunCommands(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runnertesting.NewRealPaths(c)
+	backend, socketDir := s.newBackend(c)
+	rnr := runner.NewBackendRunner(ctx, paths, backend)
+
+	commands := `
+echo $JUJU_AGENT_SOCKET
+echo this is standard err >&2
+exit 42
+`
+	result, err := rnr.RunCommands(commands)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.Code, gc.Equals, 42)
+	expectSocket := filepath.Join(socketDir, filepath.Base(paths.GetJujucSocket()))
+	c.Assert(strings.TrimRight(string(result.Stdout), "\n"), gc.Equals, expectSocket)
+	c.Assert(strings.TrimRight(string(result.Stderr), "\n"), gc.Equals, "this is standard err")
+	c.Assert(ctx.GetProcess(), gc.NotNil)
+}
@@ -4,11 +4,15 @@
 package runner
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
@@ -57,15 +61,24 @@ type Context interface {
 	Flush(badge string, failure error) error
 }
 
-// NewRunner returns a Runner backed by the supplied context and paths.
+// NewRunner returns a Runner backed by the supplied context and paths,
+// which runs hooks, actions and commands directly on the machine.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return NewBackendRunner(context, paths, LocalExecBackend())
+}
+
+// NewBackendRunner returns a Runner backed by the supplied context and
+// paths, which starts the processes running hooks, actions and commands
+// with the supplied backend.
+func NewBackendRunner(context Context, paths context.Paths, backend ExecBackend) Runner {
+	return &runner{context, paths, backend}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	backend ExecBackend
 }
 
 func (runner *runner) Context() Context {
@@ -87,10 +100,14 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	}
 	defer srv.Close()
 
-	env, err := runner.context.HookVars(runner.paths)
+	env, bridge, err := runner.hookVars()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	defer bridge.Close()
+	if _, ok := runner.backend.(localExecBackend); !ok {
+		return runner.runBackendCommands(commands, env, timeout, clock)
+	}
 	command := utilexec.RunParams{
 		Commands:    commands,
 		WorkingDir:  runner.paths.GetCharmDir(),
@@ -117,6 +134,60 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	return command.WaitWithCancel(cancel)
 }
 
+// runBackendCommands runs the supplied script with the runner's
+// backend, in the same way as utils/exec runs it on the machine.
+func (runner *runner) runBackendCommands(commands string, env []string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+	var stdout, stderr bytes.Buffer
+	process, err := runner.backend.Start(ExecParams{
+		Args:   []string{"/bin/bash", "-s"},
+		Env:    env,
+		Dir:    runner.paths.GetCharmDir(),
+		Stdin:  strings.NewReader(commands),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner.context.SetProcess(process)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- process.Wait()
+	}()
+	var timedOut <-chan time.Time
+	if timeout != 0 {
+		timedOut = clock.After(timeout)
+	}
+	select {
+	case err = <-done:
+	case <-timedOut:
+		if err := process.Kill(); err != nil {
+			logger.Errorf("cannot kill process %d: %v", process.Pid(), err)
+		}
+		<-done
+		return nil, utilexec.ErrCancelled
+	}
+
+	code := 0
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, errors.Trace(err)
+		}
+		status, ok := exitErr.ProcessState.Sys().(syscall.WaitStatus)
+		if !ok {
+			return nil, errors.Trace(err)
+		}
+		code = status.ExitStatus()
+	}
+	return &utilexec.ExecResponse{
+		Code:   code,
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}, nil
+}
+
 // runJujuRunAction is the function that executes when a juju-run action is ran.
 func (runner *runner) runJujuRunAction() (err error) {
 	params, err := runner.context.ActionParams()
@@ -210,10 +281,11 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	}
 	defer srv.Close()
 
-	env, err := runner.context.HookVars(runner.paths)
+	env, bridge, err := runner.hookVars()
 	if err != nil {
 		return errors.Trace(err)
 	}
+	defer bridge.Close()
 	if jujuos.HostOS() == jujuos.Windows {
 		// TODO(fwereade): somehow consolidate with utils/exec?
 		// We don't do this on the other code path, which uses exec.RunCommands,
@@ -237,34 +309,54 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err != nil {
 		return err
 	}
-	hookCmd := hookCommand(hook)
-	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
-	ps.Env = env
-	ps.Dir = charmDir
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
 	}
-	ps.Stdout = outWriter
-	ps.Stderr = outWriter
 	hookLogger := &hookLogger{
 		r:      outReader,
 		done:   make(chan struct{}),
 		logger: runner.getLogger(hookName),
 	}
 	go hookLogger.run()
-	err = ps.Start()
+	process, err := runner.backend.Start(ExecParams{
+		Args:   hookCommand(hook),
+		Env:    env,
+		Dir:    charmDir,
+		Stdout: outWriter,
+		Stderr: outWriter,
+	})
 	outWriter.Close()
 	if err == nil {
-		// Record the *os.Process of the hook
-		runner.context.SetProcess(hookProcess{ps.Process})
+		// Record the process running the hook
+		runner.context.SetProcess(process)
 		// Block until execution finishes
-		err = ps.Wait()
+		err = process.Wait()
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// hookVars returns the environment of the processes run by the runner,
+// pointing them at the jujuc socket as seen by the runner's backend,
+// and a Closer which tears down any bridge to that socket.
+func (runner *runner) hookVars() ([]string, io.Closer, error) {
+	env, err := runner.context.HookVars(runner.paths)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	socketPath, bridge, err := runner.backend.BridgeSocket(runner.paths.GetJujucSocket())
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "bridging jujuc socket")
+	}
+	for i, v := range env {
+		if strings.HasPrefix(v, "JUJU_AGENT_SOCKET=") {
+			env[i] = "JUJU_AGENT_SOCKET=" + socketPath
+		}
+	}
+	return env, bridge, nil
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -103,6 +103,7 @@ type ContextStorage struct {
 	CTag      names.StorageTag
 	CKind     storage.StorageKind
 	CLocation string
+	CShared   bool
 }
 
 func (c *ContextStorage) Tag() names.StorageTag {
@@ -117,6 +118,10 @@ func (c *ContextStorage) Location() string {
 	return c.CLocation
 }
 
+func (c *ContextStorage) Shared() bool {
+	return c.CShared
+}
+
 type FakeTracker struct {
 	leadership.Tracker
 }
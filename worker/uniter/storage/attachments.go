@@ -131,12 +131,15 @@ func (a *Attachments) init() error {
 				storageTag.Id(),
 			)
 		}
+		// Shared storage is owned by the application rather than the unit.
+		_, err = names.ParseApplicationTag(attachment.OwnerTag)
 		a.storageAttachments[storageTag] = storageAttachment{
 			stateFile,
 			&contextStorage{
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				shared:   err == nil,
 			},
 		}
 	}
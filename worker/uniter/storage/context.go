@@ -14,6 +14,7 @@ type contextStorage struct {
 	tag      names.StorageTag
 	kind     storage.StorageKind
 	location string
+	shared   bool
 }
 
 func (ctx *contextStorage) Tag() names.StorageTag {
@@ -27,3 +28,7 @@ func (ctx *contextStorage) Kind() storage.StorageKind {
 func (ctx *contextStorage) Location() string {
 	return ctx.location
 }
+
+func (ctx *contextStorage) Shared() bool {
+	return ctx.shared
+}
@@ -175,6 +175,7 @@ func (s *storageResolver) nextHookOp(
 			tag:      tag,
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
+			shared:   snap.Shared,
 		},
 	}
 
@@ -43,6 +43,10 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter")
 
+// remoteStateCoalesceWindow is how long the remote state watcher gathers
+// bursts of config and relation changes before publishing them.
+const remoteStateCoalesceWindow = 250 * time.Millisecond
+
 // A UniterExecutionObserver gets the appropriate methods called when a hook
 // is executed and either succeeds or fails.  Missing hooks don't get reported
 // in this way.
@@ -101,6 +105,10 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// execBackend starts the processes running hooks, actions and
+	// commands.
+	execBackend runner.ExecBackend
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -117,6 +125,9 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	// ExecBackend starts the processes running the unit's hooks,
+	// actions and commands. If nil, they run directly on the machine.
+	ExecBackend runner.ExecBackend
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -134,6 +145,11 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		translateResolverErr = func(err error) error { return err }
 	}
 
+	execBackend := uniterParams.ExecBackend
+	if execBackend == nil {
+		execBackend = runner.LocalExecBackend()
+	}
+
 	u := &Uniter{
 		st:                   uniterParams.UniterFacade,
 		paths:                NewPaths(uniterParams.DataDir, uniterParams.UnitTag),
@@ -147,6 +163,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		execBackend:          execBackend,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -245,6 +262,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
+				CoalesceWindow:      remoteStateCoalesceWindow,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -275,8 +294,17 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		return nil
 	}
 
+	var (
+		localState resolver.LocalState
+		resync     bool
+	)
 	for {
-		if err = restartWatcher(); err != nil {
+		if resync {
+			// The existing watchers are still good; just make sure
+			// the snapshot is up to date rather than rebuilding them.
+			watcher.Resync()
+			err = nil
+		} else if err = restartWatcher(); err != nil {
 			err = errors.Annotate(err, "(re)starting watcher")
 			break
 		}
@@ -285,6 +313,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
+			MaxHookRetries:      u.hookRetryStrategy.MaxHookRetries,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
 			Actions:             actions.NewResolver(),
@@ -305,9 +334,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		case <-watcher.RemoteStateChanged():
 		}
 
-		localState := resolver.LocalState{
-			CharmURL:             charmURL,
-			CharmModifiedVersion: charmModifiedVersion,
+		if !resync {
+			localState = resolver.LocalState{
+				CharmURL:             charmURL,
+				CharmModifiedVersion: charmModifiedVersion,
+			}
 		}
 		for err == nil {
 			err = resolver.Loop(resolver.LoopConfig{
@@ -336,6 +367,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			case resolver.ErrTerminate:
 				err = u.terminate()
 			case resolver.ErrRestart:
+				// Watchers only need to be restarted after a charm
+				// upgrade, which changes the events we watch. Any
+				// other restart keeps them and the local state, so
+				// that hooks already run are not run again.
+				resync = !localState.Restart
 				// make sure we update the two values used above in
 				// creating LocalState.
 				charmURL = localState.CharmURL
@@ -381,6 +417,19 @@ func (u *Uniter) terminate() error {
 			if err := u.unit.Refresh(); err != nil {
 				return errors.Trace(err)
 			}
+			if u.unit.Life() == params.Alive {
+				// The unit has stopped so that it can be moved to
+				// another machine; it must not be made Dead. Once
+				// the move is complete, the unit is recalled from
+				// this machine.
+				if u.unit.MovingTo() == "" {
+					continue
+				}
+				if err := u.unit.CompleteMove(); err != nil {
+					return errors.Trace(err)
+				}
+				return jworker.ErrTerminateAgent
+			}
 			if hasSubs, err := u.unit.HasSubordinates(); err != nil {
 				return errors.Trace(err)
 			} else if hasSubs {
@@ -477,7 +526,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.execBackend,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/upgradestager"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.upgradestager")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// Status returns the progress of the model's staged upgrade.
+	Status() (upgradestager.Status, error)
+
+	// AdmitMachines allows the identified machines to upgrade.
+	AdmitMachines(ids []string) error
+
+	// Complete ends the model's staged upgrade.
+	Complete() error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// PollInterval is the time the worker waits between checks of
+	// the staged upgrade's progress.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// New returns a worker that rolls a staged agent upgrade out to the
+// model's machines: first to the canaries, then to the remaining
+// machines in batches, admitting each stage only once every machine
+// already admitted has upgraded and is healthy.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker rolls out staged agent upgrades.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		status, err := w.config.Facade.Status()
+		if err != nil {
+			return errors.Annotate(err, "cannot get staged upgrade status")
+		}
+		if status.InProgress {
+			if err := w.advance(status); err != nil {
+				return errors.Annotatef(err, "cannot advance staged upgrade to %s", status.Version)
+			}
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+		}
+	}
+}
+
+// advance admits the next stage of the upgrade, or completes it, once
+// all the machines already admitted have upgraded and are healthy.
+func (w *Worker) advance(status upgradestager.Status) error {
+	canaries := set.NewStrings(status.Canaries...)
+	var pendingCanaries, pending []string
+	for _, machine := range status.Machines {
+		if machine.Admitted {
+			if !machine.Upgraded || !machine.Healthy {
+				logger.Debugf("waiting for machine %s to upgrade to %s", machine.Id, status.Version)
+				return nil
+			}
+			continue
+		}
+		if canaries.Contains(machine.Id) {
+			pendingCanaries = append(pendingCanaries, machine.Id)
+		} else {
+			pending = append(pending, machine.Id)
+		}
+	}
+	if len(pendingCanaries) > 0 {
+		logger.Infof("upgrading canary machines %v to %s", pendingCanaries, status.Version)
+		return errors.Trace(w.config.Facade.AdmitMachines(pendingCanaries))
+	}
+	if len(pending) == 0 {
+		logger.Infof("staged upgrade to %s complete", status.Version)
+		return errors.Trace(w.config.Facade.Complete())
+	}
+	if status.BatchSize > 0 && len(pending) > status.BatchSize {
+		pending = pending[:status.BatchSize]
+	}
+	logger.Infof("upgrading machines %v to %s", pending, status.Version)
+	return errors.Trace(w.config.Facade.AdmitMachines(pending))
+}
@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradestager_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/upgradestager"
+	coretesting "github.com/juju/juju/testing"
+	worker_upgradestager "github.com/juju/juju/worker/upgradestager"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+}
+
+func (s *WorkerSuite) config() worker_upgradestager.Config {
+	return worker_upgradestager.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) status(batchSize int, canaries []string, machines ...upgradestager.Machine) {
+	s.facade.status = upgradestager.Status{
+		InProgress: true,
+		Version:    version.MustParse("2.3.1"),
+		Canaries:   canaries,
+		BatchSize:  batchSize,
+		Machines:   machines,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.PollInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestNotInProgress(c *gc.C) {
+	s.runOnce(c)
+	s.facade.check(c, nil, false)
+}
+
+func (s *WorkerSuite) TestAdmitsCanariesFirst(c *gc.C) {
+	s.status(1, []string{"2", "3"},
+		upgradestager.Machine{Id: "1"},
+		upgradestager.Machine{Id: "2"},
+		upgradestager.Machine{Id: "3"},
+	)
+	s.runOnce(c)
+	s.facade.check(c, [][]string{{"2", "3"}}, false)
+}
+
+func (s *WorkerSuite) TestAdmitsBatch(c *gc.C) {
+	s.status(2, []string{"1"},
+		upgradestager.Machine{Id: "1", Admitted: true, Upgraded: true, Healthy: true},
+		upgradestager.Machine{Id: "2"},
+		upgradestager.Machine{Id: "3"},
+		upgradestager.Machine{Id: "4"},
+	)
+	s.runOnce(c)
+	s.facade.check(c, [][]string{{"2", "3"}}, false)
+}
+
+func (s *WorkerSuite) TestAdmitsAllWithoutBatchSize(c *gc.C) {
+	s.status(0, []string{"1"},
+		upgradestager.Machine{Id: "1", Admitted: true, Upgraded: true, Healthy: true},
+		upgradestager.Machine{Id: "2"},
+		upgradestager.Machine{Id: "3"},
+	)
+	s.runOnce(c)
+	s.facade.check(c, [][]string{{"2", "3"}}, false)
+}
+
+func (s *WorkerSuite) TestWaitsForUpgrade(c *gc.C) {
+	s.status(1, nil,
+		upgradestager.Machine{Id: "1", Admitted: true, Healthy: true},
+		upgradestager.Machine{Id: "2"},
+	)
+	s.runOnce(c)
+	s.facade.check(c, nil, false)
+}
+
+func (s *WorkerSuite) TestWaitsForHealth(c *gc.C) {
+	s.status(1, nil,
+		upgradestager.Machine{Id: "1", Admitted: true, Upgraded: true},
+		upgradestager.Machine{Id: "2"},
+	)
+	s.runOnce(c)
+	s.facade.check(c, nil, false)
+}
+
+func (s *WorkerSuite) TestCompletes(c *gc.C) {
+	s.status(1, nil,
+		upgradestager.Machine{Id: "1", Admitted: true, Upgraded: true, Healthy: true},
+	)
+	s.runOnce(c)
+	s.facade.check(c, nil, true)
+}
+
+func (s *WorkerSuite) TestPolls(c *gc.C) {
+	w, err := worker_upgradestager.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c)
+	s.assertNotChecked(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertChecked(c)
+}
+
+func (s *WorkerSuite) TestStatusError(c *gc.C) {
+	s.facade.err = errors.New("blammo")
+	w, err := worker_upgradestager.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot get staged upgrade status: blammo")
+}
+
+func (s *WorkerSuite) TestAdmitMachinesError(c *gc.C) {
+	s.status(1, nil, upgradestager.Machine{Id: "1"})
+	s.facade.admitErr = errors.New("blammo")
+	w, err := worker_upgradestager.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot advance staged upgrade to 2.3.1: blammo")
+}
+
+// runOnce starts a worker and stops it once it has waited for its next
+// poll, so that it has acted on the facade's status exactly once.
+func (s *WorkerSuite) runOnce(c *gc.C) {
+	w, err := worker_upgradestager.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.assertChecked(c)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) assertChecked(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for check")
+	}
+}
+
+func (s *WorkerSuite) assertNotChecked(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected check")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade reports the same status on every call, and records the
+// machines admitted and whether the upgrade was completed.
+type mockFacade struct {
+	status   upgradestager.Status
+	err      error
+	admitErr error
+	calls    chan struct{}
+
+	mu        sync.Mutex
+	admitted  [][]string
+	completed bool
+}
+
+func (m *mockFacade) Status() (upgradestager.Status, error) {
+	m.calls <- struct{}{}
+	return m.status, m.err
+}
+
+func (m *mockFacade) AdmitMachines(ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.admitted = append(m.admitted, ids)
+	return m.admitErr
+}
+
+func (m *mockFacade) Complete() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed = true
+	return nil
+}
+
+func (m *mockFacade) check(c *gc.C, admitted [][]string, completed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c.Check(m.admitted, jc.DeepEquals, admitted)
+	c.Check(m.completed, gc.Equals, completed)
+}
+
+var _ worker.Worker = (*worker_upgradestager.Worker)(nil)
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type entityKey struct {
+	modelUUID string
+	id        string
+}
+
+// tracker remembers enough about the entities reported by an all
+// models watcher to turn its deltas into webhook events.
+type tracker struct {
+	// started records whether the tracker has seen the initial
+	// deltas from the watcher, which describe the existing
+	// entities and do not generate events.
+	started bool
+
+	modelNames  map[string]string
+	instanceIds map[entityKey]string
+	appStatuses map[entityKey]status.Status
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		modelNames:  make(map[string]string),
+		instanceIds: make(map[entityKey]string),
+		appStatuses: make(map[entityKey]status.Status),
+	}
+}
+
+// process updates the tracker with the given deltas and returns the
+// webhook events they represent. The Id and Time of the returned
+// events are left for the caller to fill in.
+func (t *tracker) process(deltas []multiwatcher.Delta) []params.WebhookEvent {
+	// Model names are recorded first so that events for entities
+	// in a new model can be labelled with the model's name.
+	for _, delta := range deltas {
+		if info, ok := delta.Entity.(*multiwatcher.ModelInfo); ok && !delta.Removed {
+			t.modelNames[info.ModelUUID] = info.Name
+		}
+	}
+
+	var events []params.WebhookEvent
+	for _, delta := range deltas {
+		if event, ok := t.processDelta(delta); ok && t.started {
+			event.ModelName = t.modelNames[event.ModelUUID]
+			events = append(events, event)
+		}
+	}
+
+	for _, delta := range deltas {
+		if info, ok := delta.Entity.(*multiwatcher.ModelInfo); ok && delta.Removed {
+			delete(t.modelNames, info.ModelUUID)
+		}
+	}
+	t.started = true
+	return events
+}
+
+func (t *tracker) processDelta(delta multiwatcher.Delta) (params.WebhookEvent, bool) {
+	switch info := delta.Entity.(type) {
+	case *multiwatcher.MachineInfo:
+		key := entityKey{info.ModelUUID, info.Id}
+		if delta.Removed {
+			delete(t.instanceIds, key)
+			break
+		}
+		previous := t.instanceIds[key]
+		t.instanceIds[key] = info.InstanceId
+		if previous == "" && info.InstanceId != "" {
+			return params.WebhookEvent{
+				Type:       state.WebhookMachineProvisioned,
+				ModelUUID:  info.ModelUUID,
+				Machine:    info.Id,
+				InstanceId: info.InstanceId,
+			}, true
+		}
+
+	case *multiwatcher.UnitInfo:
+		if delta.Removed {
+			return params.WebhookEvent{
+				Type:        state.WebhookUnitRemoved,
+				ModelUUID:   info.ModelUUID,
+				Unit:        info.Name,
+				Application: info.Application,
+				Machine:     info.MachineId,
+			}, true
+		}
+
+	case *multiwatcher.ApplicationInfo:
+		key := entityKey{info.ModelUUID, info.Name}
+		if delta.Removed {
+			delete(t.appStatuses, key)
+			break
+		}
+		previous, known := t.appStatuses[key]
+		current := info.Status.Current
+		t.appStatuses[key] = current
+		if known && previous != current {
+			return params.WebhookEvent{
+				Type:           state.WebhookApplicationStatusChanged,
+				ModelUUID:      info.ModelUUID,
+				Application:    info.Name,
+				Status:         string(current),
+				PreviousStatus: string(previous),
+				Message:        info.Status.Message,
+			}, true
+		}
+	}
+	return params.WebhookEvent{}, false
+}
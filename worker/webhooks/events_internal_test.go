@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+type trackerSuite struct {
+	testing.IsolationSuite
+	tracker *tracker
+}
+
+var _ = gc.Suite(&trackerSuite{})
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *trackerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.tracker = newTracker()
+	events := s.tracker.process([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ModelInfo{ModelUUID: modelUUID, Name: "prod"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "0", InstanceId: "i-0"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "1"},
+	}, {
+		Entity: &multiwatcher.ApplicationInfo{
+			ModelUUID: modelUUID,
+			Name:      "mysql",
+			Status:    multiwatcher.StatusInfo{Current: status.Waiting},
+		},
+	}})
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *trackerSuite) TestMachineProvisioned(c *gc.C) {
+	events := s.tracker.process([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "0", InstanceId: "i-0"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "1", InstanceId: "i-1"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "2"},
+	}})
+	c.Assert(events, jc.DeepEquals, []params.WebhookEvent{{
+		Type:       state.WebhookMachineProvisioned,
+		ModelUUID:  modelUUID,
+		ModelName:  "prod",
+		Machine:    "1",
+		InstanceId: "i-1",
+	}})
+}
+
+func (s *trackerSuite) TestNewMachineProvisioned(c *gc.C) {
+	events := s.tracker.process([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "3", InstanceId: "i-3"},
+	}})
+	c.Assert(events, gc.HasLen, 1)
+	c.Assert(events[0].Machine, gc.Equals, "3")
+}
+
+func (s *trackerSuite) TestUnitRemoved(c *gc.C) {
+	events := s.tracker.process([]multiwatcher.Delta{{
+		Removed: true,
+		Entity: &multiwatcher.UnitInfo{
+			ModelUUID:   modelUUID,
+			Name:        "mysql/0",
+			Application: "mysql",
+			MachineId:   "0",
+		},
+	}})
+	c.Assert(events, jc.DeepEquals, []params.WebhookEvent{{
+		Type:        state.WebhookUnitRemoved,
+		ModelUUID:   modelUUID,
+		ModelName:   "prod",
+		Unit:        "mysql/0",
+		Application: "mysql",
+		Machine:     "0",
+	}})
+}
+
+func (s *trackerSuite) TestApplicationStatusChanged(c *gc.C) {
+	app := &multiwatcher.ApplicationInfo{
+		ModelUUID: modelUUID,
+		Name:      "mysql",
+		Status: multiwatcher.StatusInfo{
+			Current: status.Active,
+			Message: "ready",
+		},
+	}
+	events := s.tracker.process([]multiwatcher.Delta{{Entity: app}})
+	c.Assert(events, jc.DeepEquals, []params.WebhookEvent{{
+		Type:           state.WebhookApplicationStatusChanged,
+		ModelUUID:      modelUUID,
+		ModelName:      "prod",
+		Application:    "mysql",
+		Status:         "active",
+		PreviousStatus: "waiting",
+		Message:        "ready",
+	}})
+
+	// Other changes to the application are not reported.
+	app.Exposed = true
+	events = s.tracker.process([]multiwatcher.Delta{{Entity: app}})
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *trackerSuite) TestRemovedEntitiesForgotten(c *gc.C) {
+	events := s.tracker.process([]multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{ModelUUID: modelUUID, Id: "0", InstanceId: "i-0"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.ApplicationInfo{ModelUUID: modelUUID, Name: "mysql"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.ModelInfo{ModelUUID: modelUUID, Name: "prod"},
+	}})
+	c.Assert(events, gc.HasLen, 0)
+	c.Assert(s.tracker.instanceIds, gc.HasLen, 1)
+	c.Assert(s.tracker.appStatuses, gc.HasLen, 0)
+	c.Assert(s.tracker.modelNames, gc.HasLen, 0)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a webhooks
+// worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	RetryDelay  time.Duration
+	MaxAttempts int
+	NewWorker   func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxAttempts <= 0 {
+		return errors.NotValidf("non-positive MaxAttempts")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a webhooks
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool := state.NewStatePool(st)
+
+	worker, err := config.NewWorker(Config{
+		Backend:     backendShim{st, pool},
+		Clock:       clock,
+		HTTPClient:  &http.Client{Timeout: requestTimeout},
+		RetryDelay:  config.RetryDelay,
+		MaxAttempts: config.MaxAttempts,
+	})
+	if err != nil {
+		pool.Close()
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		worker.Wait()
+		pool.Close()
+		stTracker.Done()
+	}()
+	return worker, nil
+}
+
+// requestTimeout is the time allowed for a webhook to respond to
+// a request before the attempt is considered to have failed.
+const requestTimeout = 30 * time.Second
+
+// backendShim implements Backend using a *state.State.
+type backendShim struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+func (b backendShim) Webhooks() ([]state.Webhook, error) {
+	return b.st.Webhooks()
+}
+
+func (b backendShim) WatchWebhooks() state.NotifyWatcher {
+	return b.st.WatchWebhooks()
+}
+
+func (b backendShim) WatchAllModels() AllWatcher {
+	return b.st.WatchAllModels(b.pool)
+}
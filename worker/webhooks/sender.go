@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the body
+	// of a webhook request, made with the webhook's secret, in the
+	// form "sha256=<hex signature>".
+	SignatureHeader = "X-Juju-Signature"
+
+	// EventHeader holds the type of the event in a webhook request.
+	EventHeader = "X-Juju-Event"
+
+	// DeliveryHeader holds the id of the event in a webhook request.
+	DeliveryHeader = "X-Juju-Delivery"
+)
+
+// queueSize is the number of events that may wait to be sent to a
+// webhook before further events are dropped.
+const queueSize = 100
+
+// Sign returns the signature of the given body made with the given
+// secret, as sent in the SignatureHeader of webhook requests.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sender delivers events to a single webhook, one at a time and in
+// the order they were queued.
+type sender struct {
+	worker.Worker
+	config  Config
+	webhook state.Webhook
+	queue   chan params.WebhookEvent
+}
+
+func newSender(config Config, webhook state.Webhook) *sender {
+	s := &sender{
+		config:  config,
+		webhook: webhook,
+		queue:   make(chan params.WebhookEvent, queueSize),
+	}
+	s.Worker = jworker.NewSimpleWorker(s.loop)
+	return s
+}
+
+// enqueue queues the event for delivery, dropping it if the queue
+// is full.
+func (s *sender) enqueue(event params.WebhookEvent) {
+	select {
+	case s.queue <- event:
+	default:
+		logger.Warningf("webhook %q is not keeping up; dropping %s event %s", s.webhook.Name, event.Type, event.Id)
+	}
+}
+
+func (s *sender) loop(stop <-chan struct{}) error {
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event := <-s.queue:
+			if err := s.deliver(event, stop); err != nil {
+				logger.Errorf("cannot send %s event %s to webhook %q: %v", event.Type, event.Id, s.webhook.Name, err)
+			}
+		}
+	}
+}
+
+// deliver POSTs the event to the webhook, retrying with increasing
+// delays until it succeeds, the attempts run out, or stop is closed.
+func (s *sender) deliver(event params.WebhookEvent, stop <-chan struct{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	signature := Sign(s.webhook.Secret, body)
+
+	var lastErr error
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return s.post(event, body, signature)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("attempt %d to send %s event %s to webhook %q failed: %v", attempt, event.Type, event.Id, s.webhook.Name, err)
+			lastErr = err
+		},
+		Attempts:    s.config.MaxAttempts,
+		Delay:       s.config.RetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       s.config.Clock,
+		Stop:        stop,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		return errors.Annotatef(lastErr, "giving up after %d attempts", s.config.MaxAttempts)
+	}
+	select {
+	case <-stop:
+		return nil
+	default:
+	}
+	return errors.Trace(err)
+}
+
+func (s *sender) post(event params.WebhookEvent, body []byte, signature string) error {
+	req, err := http.NewRequest("POST", s.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", params.ContentTypeJSON)
+	req.Header.Set(SignatureHeader, signature)
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.Id)
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
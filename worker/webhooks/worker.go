@@ -0,0 +1,221 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package webhooks provides a worker that POSTs events about the
+// machines, units and applications in a controller's models to the
+// webhooks configured on the controller.
+package webhooks
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.webhooks")
+
+// AllWatcher reports changes to the entities in all models.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// Backend provides the controller state used by the worker.
+type Backend interface {
+	Webhooks() ([]state.Webhook, error)
+	WatchWebhooks() state.NotifyWatcher
+	WatchAllModels() AllWatcher
+}
+
+// HTTPClient sends HTTP requests. It is satisfied by *http.Client.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config holds the configuration and dependencies of a webhooks
+// worker.
+type Config struct {
+	Backend    Backend
+	Clock      clock.Clock
+	HTTPClient HTTPClient
+
+	// RetryDelay is the delay before an event is sent again after
+	// the first failed attempt. The delay doubles after each
+	// further failure.
+	RetryDelay time.Duration
+
+	// MaxAttempts is the number of times the worker will try to
+	// send an event to a webhook before giving up on it.
+	MaxAttempts int
+}
+
+// Validate returns an error if the config cannot be used to start a
+// webhooks worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxAttempts <= 0 {
+		return errors.NotValidf("non-positive MaxAttempts")
+	}
+	return nil
+}
+
+// Worker sends events to the controller's webhooks. It must not be
+// run in more than one agent concurrently.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	senders  map[string]*sender
+}
+
+// NewWorker returns a new webhooks worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:  config,
+		senders: make(map[string]*sender),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	defer w.stopSenders()
+
+	webhooksWatcher := w.config.Backend.WatchWebhooks()
+	if err := w.catacomb.Add(webhooksWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	allWatcher := w.config.Backend.WatchAllModels()
+	defer allWatcher.Stop()
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := allWatcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-w.catacomb.Dying():
+				return
+			}
+		}
+	}()
+
+	tracker := newTracker()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-webhooksWatcher.Changes():
+			if !ok {
+				return errors.New("webhooks watcher closed")
+			}
+			if err := w.updateSenders(); err != nil {
+				return errors.Trace(err)
+			}
+		case deltas := <-deltasCh:
+			for _, event := range tracker.process(deltas) {
+				if err := w.dispatch(event); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case err := <-errCh:
+			return errors.Annotate(err, "watching models")
+		}
+	}
+}
+
+// dispatch queues the event for delivery to each webhook that wants
+// it.
+func (w *Worker) dispatch(event params.WebhookEvent) error {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	event.Id = uuid.String()
+	event.Time = w.config.Clock.Now().UTC()
+	for _, s := range w.senders {
+		if s.webhook.Wants(event.Type) {
+			s.enqueue(event)
+		}
+	}
+	return nil
+}
+
+// updateSenders starts senders for new or changed webhooks, and
+// stops the senders of changed or removed webhooks.
+func (w *Worker) updateSenders() error {
+	webhooks, err := w.config.Backend.Webhooks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current := make(map[string]bool)
+	for _, webhook := range webhooks {
+		current[webhook.Name] = true
+		if s, ok := w.senders[webhook.Name]; ok {
+			if reflect.DeepEqual(s.webhook, webhook) {
+				continue
+			}
+			worker.Stop(s)
+		}
+		logger.Debugf("sending events to webhook %q at %s", webhook.Name, webhook.URL)
+		w.senders[webhook.Name] = newSender(w.config, webhook)
+	}
+	for name, s := range w.senders {
+		if !current[name] {
+			worker.Stop(s)
+			delete(w.senders, name)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) stopSenders() {
+	for _, s := range w.senders {
+		worker.Stop(s)
+	}
+}
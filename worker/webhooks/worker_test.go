@@ -0,0 +1,317 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhooks_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/webhooks"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend *mockBackend
+	client  *mockHTTPClient
+	clock   *testing.Clock
+	config  webhooks.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		webhooks: []state.Webhook{{
+			Name:   "ops",
+			URL:    "https://ops.example.com/",
+			Secret: "sekrit",
+		}, {
+			Name:   "audit",
+			URL:    "https://audit.example.com/",
+			Secret: "hush",
+			Events: []string{state.WebhookMachineProvisioned},
+		}},
+		webhooksWatcher: newMockNotifyWatcher(),
+		allWatcher:      newMockAllWatcher(),
+	}
+	s.client = &mockHTTPClient{requests: make(chan *http.Request, 10)}
+	s.clock = testing.NewClock(time.Time{})
+	s.config = webhooks.Config{
+		Backend:     s.backend,
+		Clock:       s.clock,
+		HTTPClient:  s.client,
+		RetryDelay:  time.Second,
+		MaxAttempts: 3,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	c.Assert(s.config.Validate(), jc.ErrorIsNil)
+	s.config.MaxAttempts = 0
+	_, err := webhooks.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "non-positive MaxAttempts not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := webhooks.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+
+	s.backend.webhooksWatcher.changes <- struct{}{}
+	s.backend.allWatcher.deltas <- []multiwatcher.Delta{{
+		Entity: &multiwatcher.ModelInfo{ModelUUID: "uuid", Name: "prod"},
+	}, {
+		Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql"},
+	}}
+	return w
+}
+
+func (s *WorkerSuite) TestUnitRemoved(c *gc.C) {
+	s.startWorker(c)
+	s.backend.allWatcher.deltas <- []multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql"},
+	}}
+
+	req := s.nextRequest(c)
+	c.Assert(req.URL.String(), gc.Equals, "https://ops.example.com/")
+	c.Assert(req.Header.Get("Content-Type"), gc.Equals, params.ContentTypeJSON)
+	c.Assert(req.Header.Get(webhooks.EventHeader), gc.Equals, state.WebhookUnitRemoved)
+
+	body, err := ioutil.ReadAll(req.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.Header.Get(webhooks.SignatureHeader), gc.Equals, webhooks.Sign("sekrit", body))
+
+	var event params.WebhookEvent
+	err = json.Unmarshal(body, &event)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event.Id, gc.Not(gc.Equals), "")
+	c.Assert(req.Header.Get(webhooks.DeliveryHeader), gc.Equals, event.Id)
+	event.Id = ""
+	c.Assert(event, jc.DeepEquals, params.WebhookEvent{
+		Type:        state.WebhookUnitRemoved,
+		Time:        s.clock.Now().UTC(),
+		ModelUUID:   "uuid",
+		ModelName:   "prod",
+		Unit:        "mysql/0",
+		Application: "mysql",
+	})
+
+	// The audit webhook does not want unit events.
+	s.assertNoRequest(c)
+}
+
+func (s *WorkerSuite) TestRetries(c *gc.C) {
+	s.client.setStatuses(http.StatusServiceUnavailable, http.StatusOK)
+	s.startWorker(c)
+	s.backend.allWatcher.deltas <- []multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql"},
+	}}
+
+	first := s.nextRequest(c)
+	s.assertNoRequest(c)
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	second := s.nextRequest(c)
+	c.Assert(second.Header.Get(webhooks.DeliveryHeader), gc.Equals, first.Header.Get(webhooks.DeliveryHeader))
+}
+
+func (s *WorkerSuite) TestGivesUp(c *gc.C) {
+	s.config.MaxAttempts = 1
+	s.backend.webhooks = s.backend.webhooks[:1]
+	s.client.setStatuses(http.StatusInternalServerError)
+	s.startWorker(c)
+	s.backend.allWatcher.deltas <- []multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "mysql/0", Application: "mysql"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0", InstanceId: "i-0"},
+	}}
+
+	// The failed unit event is not retried, and does not hold up
+	// the machine event.
+	req := s.nextRequest(c)
+	c.Assert(req.Header.Get(webhooks.EventHeader), gc.Equals, state.WebhookUnitRemoved)
+	req = s.nextRequest(c)
+	c.Assert(req.Header.Get(webhooks.EventHeader), gc.Equals, state.WebhookMachineProvisioned)
+	s.assertNoRequest(c)
+}
+
+func (s *WorkerSuite) TestWebhookRemoved(c *gc.C) {
+	s.startWorker(c)
+	s.backend.setWebhooks(nil)
+	s.backend.webhooksWatcher.changes <- struct{}{}
+	s.backend.allWatcher.deltas <- []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0", InstanceId: "i-0"},
+	}}
+	s.assertNoRequest(c)
+}
+
+func (s *WorkerSuite) TestWatcherError(c *gc.C) {
+	w, err := webhooks.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.backend.allWatcher.err <- errors.New("boom")
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "watching models: boom")
+}
+
+func (s *WorkerSuite) nextRequest(c *gc.C) *http.Request {
+	select {
+	case req := <-s.client.requests:
+		return req
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for webhook request")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoRequest(c *gc.C) {
+	select {
+	case req := <-s.client.requests:
+		c.Fatalf("unexpected %s request to %s", req.Header.Get(webhooks.EventHeader), req.URL)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockBackend struct {
+	mu              sync.Mutex
+	webhooks        []state.Webhook
+	webhooksWatcher *mockNotifyWatcher
+	allWatcher      *mockAllWatcher
+}
+
+func (b *mockBackend) setWebhooks(webhooks []state.Webhook) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.webhooks = webhooks
+}
+
+func (b *mockBackend) Webhooks() ([]state.Webhook, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.webhooks, nil
+}
+
+func (b *mockBackend) WatchWebhooks() state.NotifyWatcher {
+	return b.webhooksWatcher
+}
+
+func (b *mockBackend) WatchAllModels() webhooks.AllWatcher {
+	return b.allWatcher
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *mockNotifyWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+type mockAllWatcher struct {
+	deltas   chan []multiwatcher.Delta
+	err      chan error
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newMockAllWatcher() *mockAllWatcher {
+	return &mockAllWatcher{
+		deltas:  make(chan []multiwatcher.Delta),
+		err:     make(chan error),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (w *mockAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case err := <-w.err:
+		return nil, err
+	case <-w.stopped:
+		return nil, state.ErrStopped
+	}
+}
+
+func (w *mockAllWatcher) Stop() error {
+	w.stopOnce.Do(func() { close(w.stopped) })
+	return nil
+}
+
+type mockHTTPClient struct {
+	mu       sync.Mutex
+	statuses []int
+	requests chan *http.Request
+}
+
+// setStatuses sets the status codes of the responses to the
+// following requests. Once they are used up, requests succeed.
+func (c *mockHTTPClient) setStatuses(statuses ...int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = statuses
+}
+
+func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	code := http.StatusOK
+	if len(c.statuses) > 0 {
+		code, c.statuses = c.statuses[0], c.statuses[1:]
+	}
+	c.mu.Unlock()
+	c.requests <- req
+	return &http.Response{
+		Status:     http.StatusText(code),
+		StatusCode: code,
+		Body:       ioutil.NopCloser(&bytes.Buffer{}),
+	}, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireguardmesh
+
+import (
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ApplyConfig uses wg-quick to bring the named WireGuard interface up
+// with the configuration in DefaultConfigDir, or to take it down. An
+// interface that is already up is restarted, so that wg-quick updates
+// the routes to its peers as well as the peers themselves.
+func ApplyConfig(iface string, up bool) error {
+	if _, err := exec.LookPath("wg-quick"); err != nil {
+		return errors.NotFoundf("wg-quick (install the wireguard tools)")
+	}
+	_, err := net.InterfaceByName(iface)
+	exists := err == nil
+	if exists {
+		if err := runCommand("wg-quick", "down", iface); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !up {
+		return nil
+	}
+	return errors.Trace(runCommand("wg-quick", "up", iface))
+}
+
+func runCommand(name string, args ...string) error {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return errors.Annotatef(err, "running %s %s: %s", name, strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package wireguardmesh provides a worker which keeps a machine's
+// WireGuard mesh interface configured with the key and peers provided
+// by the controller, so that the machine can reach the machines of
+// cross-model related models without their ports being exposed.
+package wireguardmesh
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.wireguardmesh")
+
+const (
+	// DefaultInterval is how often the mesh configuration is refreshed.
+	DefaultInterval = time.Minute
+
+	// DefaultConfigDir is the directory in which WireGuard interface
+	// configuration files are kept.
+	DefaultConfigDir = "/etc/wireguard"
+
+	// DefaultInterface is the name of the mesh's WireGuard interface.
+	DefaultInterface = "juju-mesh"
+
+	// persistentKeepalive is the interval, in seconds, at which
+	// keepalive packets are sent to peers, so that machines behind
+	// NAT remain reachable.
+	persistentKeepalive = 25
+)
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	MeshConfig(machine names.MachineTag) (params.WireGuardMeshConfig, error)
+}
+
+// Config defines the parameters of the wireguardmesh worker.
+type Config struct {
+	Facade   Facade
+	Machine  names.MachineTag
+	Clock    clock.Clock
+	Interval time.Duration
+
+	// ConfigDir is the directory in which the interface's
+	// configuration file is written.
+	ConfigDir string
+
+	// Interface is the name of the WireGuard interface.
+	Interface string
+
+	// Apply brings the named interface up with its current
+	// configuration if up is true, and takes it down otherwise. If it
+	// returns a NotFound error, the WireGuard tools are assumed to be
+	// missing and the configuration is applied when next refreshed.
+	Apply func(iface string, up bool) error
+}
+
+// Validate returns an error if Config cannot drive a wireguardmesh
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Machine.Id() == "" {
+		return errors.NotValidf("empty Machine")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.ConfigDir == "" {
+		return errors.NotValidf("empty ConfigDir")
+	}
+	if config.Interface == "" {
+		return errors.NotValidf("empty Interface")
+	}
+	if config.Apply == nil {
+		return errors.NotValidf("nil Apply")
+	}
+	return nil
+}
+
+// New returns a Worker that configures the machine's WireGuard mesh
+// interface when it starts, and regularly thereafter.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &meshWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type meshWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// applied holds the configuration last applied to the interface.
+	applied string
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *meshWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *meshWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *meshWorker) loop() error {
+	for {
+		if err := w.update(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+// update fetches the machine's mesh configuration and applies it to
+// the interface if it has changed.
+func (w *meshWorker) update() error {
+	config, err := w.config.Facade.MeshConfig(w.config.Machine)
+	if err != nil {
+		return errors.Annotate(err, "getting mesh configuration")
+	}
+	path := filepath.Join(w.config.ConfigDir, w.config.Interface+".conf")
+	if !config.Enabled {
+		return errors.Trace(w.leave(path))
+	}
+
+	content := renderConfig(config)
+	if content == w.applied {
+		return nil
+	}
+	existing, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if string(existing) != content {
+		if err := os.MkdirAll(w.config.ConfigDir, 0700); err != nil {
+			return errors.Trace(err)
+		}
+		// The file holds the machine's private key.
+		if err := utils.AtomicWriteFile(path, []byte(content), 0600); err != nil {
+			return errors.Annotate(err, "writing mesh configuration")
+		}
+	}
+	err = w.config.Apply(w.config.Interface, true)
+	if errors.IsNotFound(err) {
+		logger.Warningf("cannot configure WireGuard mesh: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "configuring mesh interface")
+	}
+	w.applied = content
+	logger.Infof("WireGuard mesh configured with %d peer(s)", len(config.Peers))
+	return nil
+}
+
+// leave takes down the mesh interface and removes its configuration,
+// if the machine was part of a mesh.
+func (w *meshWorker) leave(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err := w.config.Apply(w.config.Interface, false)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "stopping mesh interface")
+	}
+	if err := os.Remove(path); err != nil {
+		return errors.Trace(err)
+	}
+	w.applied = ""
+	logger.Infof("left WireGuard mesh")
+	return nil
+}
+
+// renderConfig returns the contents of a wg-quick configuration file
+// for the supplied mesh configuration.
+func renderConfig(config params.WireGuardMeshConfig) string {
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by the Juju machine agent; do not edit.\n")
+	buf.WriteString("[Interface]\n")
+	fmt.Fprintf(&buf, "PrivateKey = %s\n", config.PrivateKey)
+	fmt.Fprintf(&buf, "ListenPort = %d\n", config.ListenPort)
+	for _, peer := range config.Peers {
+		buf.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&buf, "PublicKey = %s\n", peer.PublicKey)
+		if peer.Endpoint != "" {
+			fmt.Fprintf(&buf, "Endpoint = %s\n", peer.Endpoint)
+		}
+		if len(peer.AllowedIPs) > 0 {
+			fmt.Fprintf(&buf, "AllowedIPs = %s\n", strings.Join(peer.AllowedIPs, ", "))
+		}
+		fmt.Fprintf(&buf, "PersistentKeepalive = %d\n", persistentKeepalive)
+	}
+	return buf.String()
+}
@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wireguardmesh_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/wireguardmesh"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	clock   *jujutesting.Clock
+	facade  *stubFacade
+	dir     string
+	applied chan bool
+	config  wireguardmesh.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+const expectedConfig = `# Generated by the Juju machine agent; do not edit.
+[Interface]
+PrivateKey = private-key
+ListenPort = 51820
+
+[Peer]
+PublicKey = peer-key
+Endpoint = 203.0.113.1:51820
+AllowedIPs = 10.0.0.1/32, fd00::1/128
+PersistentKeepalive = 25
+`
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &stubFacade{
+		Stub: &jujutesting.Stub{},
+		config: params.WireGuardMeshConfig{
+			Enabled:    true,
+			PrivateKey: "private-key",
+			ListenPort: 51820,
+			Peers: []params.WireGuardPeer{{
+				PublicKey:  "peer-key",
+				Endpoint:   "203.0.113.1:51820",
+				AllowedIPs: []string{"10.0.0.1/32", "fd00::1/128"},
+			}},
+		},
+	}
+	s.dir = filepath.Join(c.MkDir(), "wireguard")
+	s.applied = make(chan bool, 10)
+	s.config = wireguardmesh.Config{
+		Facade:    s.facade,
+		Machine:   names.NewMachineTag("0"),
+		Clock:     s.clock,
+		Interval:  time.Minute,
+		ConfigDir: s.dir,
+		Interface: "juju-mesh",
+		Apply: func(iface string, up bool) error {
+			s.facade.AddCall("Apply", iface, up)
+			if err := s.facade.NextErr(); err != nil {
+				return err
+			}
+			s.applied <- up
+			return nil
+		},
+	}
+}
+
+func (s *Suite) startWorker(c *gc.C) {
+	w, err := wireguardmesh.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *Suite) waitApplied(c *gc.C) bool {
+	select {
+	case up := <-s.applied:
+		return up
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for mesh configuration")
+	}
+	panic("unreachable")
+}
+
+func (s *Suite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) configPath() string {
+	return filepath.Join(s.dir, "juju-mesh.conf")
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.Interface = ""
+	_, err := wireguardmesh.New(s.config)
+	c.Check(err, gc.ErrorMatches, "empty Interface not valid")
+
+	s.config.Interface = "juju-mesh"
+	s.config.Apply = nil
+	_, err = wireguardmesh.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Apply not valid")
+}
+
+func (s *Suite) TestWritesAndAppliesConfig(c *gc.C) {
+	s.startWorker(c)
+	c.Check(s.waitApplied(c), jc.IsTrue)
+
+	content, err := ioutil.ReadFile(s.configPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, expectedConfig)
+	info, err := os.Stat(s.configPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+	s.facade.CheckCall(c, 0, "MeshConfig", names.NewMachineTag("0"))
+	s.facade.CheckCall(c, 1, "Apply", "juju-mesh", true)
+}
+
+func (s *Suite) TestAppliesOnlyChanges(c *gc.C) {
+	s.startWorker(c)
+	s.waitApplied(c)
+
+	// Nothing has changed, so nothing is applied.
+	s.advance(c)
+	s.advance(c)
+	select {
+	case up := <-s.applied:
+		c.Fatalf("unexpected apply: %v", up)
+	default:
+	}
+
+	s.facade.setPeers(nil)
+	s.advance(c)
+	c.Check(s.waitApplied(c), jc.IsTrue)
+	content, err := ioutil.ReadFile(s.configPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Not(jc.Contains), "[Peer]")
+}
+
+func (s *Suite) TestLeavesMesh(c *gc.C) {
+	s.startWorker(c)
+	s.waitApplied(c)
+
+	s.facade.setEnabled(false)
+	s.advance(c)
+	c.Check(s.waitApplied(c), jc.IsFalse)
+	_, err := os.Stat(s.configPath())
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *Suite) TestNotInMesh(c *gc.C) {
+	s.facade.setEnabled(false)
+	s.startWorker(c)
+	s.advance(c)
+	select {
+	case up := <-s.applied:
+		c.Fatalf("unexpected apply: %v", up)
+	default:
+	}
+	_, err := os.Stat(s.configPath())
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *Suite) TestToolsMissing(c *gc.C) {
+	s.facade.SetErrors(nil, errors.NotFoundf("wg-quick"))
+	s.startWorker(c)
+
+	// The configuration is applied again on the next refresh.
+	s.advance(c)
+	c.Check(s.waitApplied(c), jc.IsTrue)
+	s.facade.CheckCallNames(c, "MeshConfig", "Apply", "MeshConfig", "Apply")
+}
+
+func (s *Suite) TestMeshConfigError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := wireguardmesh.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "getting mesh configuration: boom")
+}
+
+func (s *Suite) TestApplyError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w, err := wireguardmesh.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "configuring mesh interface: boom")
+}
+
+type stubFacade struct {
+	*jujutesting.Stub
+
+	mu     sync.Mutex
+	config params.WireGuardMeshConfig
+}
+
+func (f *stubFacade) setEnabled(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config.Enabled = enabled
+}
+
+func (f *stubFacade) setPeers(peers []params.WireGuardPeer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config.Peers = peers
+}
+
+func (f *stubFacade) MeshConfig(machine names.MachineTag) (params.WireGuardMeshConfig, error) {
+	f.AddCall("MeshConfig", machine)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, f.NextErr()
+}